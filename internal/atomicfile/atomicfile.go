@@ -0,0 +1,121 @@
+// Package atomicfile replaces spec artifacts (spec.yaml, plan.yaml,
+// tasks.yaml, ...) so that a crash or full disk mid-write never leaves a
+// truncated file behind.
+//
+// WriteFile writes the new content to a temp file in the same directory,
+// fsyncs it, and renames it over the original, then fsyncs the directory so
+// the rename itself survives a crash. The previous content is kept in
+// <path>.bak, one version deep, to recover from a bad edit.
+package atomicfile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// BackupSuffix is appended to a file's path to name its backup.
+const BackupSuffix = ".bak"
+
+// WriteFile atomically replaces path with data, keeping the previous version
+// in path+BackupSuffix. An existing file keeps its permissions; a new one
+// gets perm.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+		if err := backup(path); err != nil {
+			return err
+		}
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() {
+		// Clean up temp file on error
+		if tmpPath != "" {
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return fmt.Errorf("setting permissions: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("syncing temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing temp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("renaming temp file to %s: %w", path, err)
+	}
+	tmpPath = "" // Prevent cleanup since rename succeeded
+	syncDir(dir)
+	return nil
+}
+
+// backup replaces path's backup with its current content. The backup is a
+// hard link where possible, since the rename in WriteFile leaves the old
+// file's data untouched, and a synced copy otherwise.
+func backup(path string) error {
+	backupPath := path + BackupSuffix
+	if err := os.Remove(backupPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing old backup: %w", err)
+	}
+	if err := os.Link(path, backupPath); err == nil {
+		return nil
+	}
+	if err := copyFile(path, backupPath); err != nil {
+		os.Remove(backupPath)
+		return err
+	}
+	return nil
+}
+
+// copyFile copies src to dst and syncs it.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("reading %s for backup: %w", src, err)
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("creating backup: %w", err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return fmt.Errorf("writing backup: %w", err)
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return fmt.Errorf("syncing backup: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("closing backup: %w", err)
+	}
+	return nil
+}
+
+// syncDir flushes a directory entry change to disk. Best effort: directories
+// can't be opened for syncing on every platform (e.g. Windows).
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	d.Close()
+}
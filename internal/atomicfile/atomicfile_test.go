@@ -0,0 +1,99 @@
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFile(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		existing   *string
+		wantBackup *string
+	}{
+		"new file has no backup": {},
+		"existing file is backed up": {
+			existing:   ptr("tasks: [v1]\n"),
+			wantBackup: ptr("tasks: [v1]\n"),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "tasks.yaml")
+			if tt.existing != nil {
+				require.NoError(t, os.WriteFile(path, []byte(*tt.existing), 0o644))
+			}
+
+			require.NoError(t, WriteFile(path, []byte("tasks: [v2]\n"), 0o644))
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, "tasks: [v2]\n", string(data))
+			if tt.wantBackup == nil {
+				assert.NoFileExists(t, path+BackupSuffix)
+			} else {
+				backup, err := os.ReadFile(path + BackupSuffix)
+				require.NoError(t, err)
+				assert.Equal(t, *tt.wantBackup, string(backup))
+			}
+			entries, err := os.ReadDir(filepath.Dir(path))
+			require.NoError(t, err)
+			for _, e := range entries {
+				assert.NotContains(t, e.Name(), ".tmp", "temp file left behind")
+			}
+		})
+	}
+}
+
+func TestWriteFile_BackupRollsToPreviousVersion(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+
+	for _, content := range []string{"v1\n", "v2\n", "v3\n"} {
+		require.NoError(t, WriteFile(path, []byte(content), 0o644))
+	}
+
+	backup, err := os.ReadFile(path + BackupSuffix)
+	require.NoError(t, err)
+	assert.Equal(t, "v2\n", string(backup))
+}
+
+func TestWriteFile_KeepsPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permission bits are not preserved on Windows")
+	}
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "plan.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("v1\n"), 0o600))
+
+	require.NoError(t, WriteFile(path, []byte("v2\n"), 0o644))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestWriteFile_FailureKeepsOriginal(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	// A directory at the target path can't be replaced by the rename
+	path := filepath.Join(dir, "tasks.yaml")
+	require.NoError(t, os.Mkdir(path, 0o755))
+
+	assert.Error(t, WriteFile(path, []byte("new\n"), 0o644))
+	assert.DirExists(t, path)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.NotContains(t, e.Name(), ".tmp", "temp file left behind")
+	}
+}
+
+func ptr(s string) *string { return &s }
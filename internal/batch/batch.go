@@ -0,0 +1,268 @@
+// Package batch runs the workflow for several specs in one invocation.
+// Related: internal/cli/batch.go
+// Tags: batch, workflow, parallel, worktree, summary
+//
+// Each spec runs in a child autospec process (`autospec run --spec <name>`)
+// so its terminal output, working directory and retry state stay separate.
+// Specs run one after another in the current tree, or concurrently with each
+// spec in its own git worktree.
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/worktree"
+	"golang.org/x/sync/errgroup"
+)
+
+// Job is one spec to run.
+type Job struct {
+	Spec   string   // Spec directory name, e.g. "001-user-auth"
+	Dir    string   // Spec directory path
+	Stages []string // Stages to run; empty runs the ones the spec still needs
+}
+
+// stages returns the stages to run for the job.
+func (j Job) stages() []string {
+	if len(j.Stages) > 0 {
+		return j.Stages
+	}
+	return Stages(j.Dir)
+}
+
+// Result is the outcome of running one spec.
+type Result struct {
+	Spec     string
+	Stages   []string // Stages the child run was asked to execute
+	Success  bool
+	Err      error
+	Retries  int // Validation retries across all stages
+	Duration time.Duration
+	LogPath  string // Child output for parallel runs
+	Worktree string // Worktree the spec ran in, if kept
+}
+
+// Workspace isolates specs in parallel runs. Implemented by
+// worktree.DefaultManager.
+type Workspace interface {
+	Isolate(specName, specDir string) (*worktree.Worktree, bool, error)
+	Release(wt *worktree.Worktree, specDir string) (bool, error)
+}
+
+// ExecFunc runs autospec with args in dir, writing its output to out.
+type ExecFunc func(ctx context.Context, dir string, args []string, out io.Writer) error
+
+// Runner runs a batch of specs.
+type Runner struct {
+	Out       io.Writer // Progress lines; also child output in sequential runs
+	Parallel  int       // Maximum concurrent specs; 1 or less runs sequentially
+	LogDir    string    // Directory for <spec>.log files in parallel runs
+	Workspace Workspace // Required for parallel runs
+	RunFlags  []string  // Extra flags for each `autospec run`, e.g. --agent
+	StateDir  string    // Retry state shared with child runs, used to count their retries
+	Exec      ExecFunc
+
+	workspaceMu sync.Mutex // Serializes Isolate/Release, which share the worktree state file
+}
+
+// Stages returns the workflow stages a spec still needs, based on which
+// artifacts exist: plan and tasks are skipped once their YAML is present, and
+// implement always runs (resuming if tasks.yaml already exists).
+func Stages(specDir string) []string {
+	switch {
+	case !fileExists(filepath.Join(specDir, "plan.yaml")):
+		return []string{"plan", "tasks", "implement"}
+	case !fileExists(filepath.Join(specDir, "tasks.yaml")):
+		return []string{"tasks", "implement"}
+	default:
+		return []string{"implement"}
+	}
+}
+
+// runArgs builds the `autospec run` arguments for a spec.
+func (r *Runner) runArgs(job Job, stages []string) []string {
+	args := append([]string{"run", "--spec", job.Spec, "--yes"}, r.RunFlags...)
+	for _, stage := range stages {
+		args = append(args, "--"+stage)
+	}
+	if len(stages) == 1 && stages[0] == "implement" {
+		args = append(args, "--resume")
+	}
+	return args
+}
+
+// Run executes every job and returns one result per job, in job order.
+// A failing spec does not stop the others.
+func (r *Runner) Run(ctx context.Context, jobs []Job) []Result {
+	results := make([]Result, len(jobs))
+	if r.Parallel <= 1 {
+		for i, job := range jobs {
+			fmt.Fprintf(r.Out, "\n=== [%d/%d] %s ===\n", i+1, len(jobs), job.Spec)
+			results[i] = r.runSequential(ctx, job)
+			r.report(results[i])
+		}
+		return results
+	}
+
+	var mu sync.Mutex
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(r.Parallel)
+	for i, job := range jobs {
+		g.Go(func() error {
+			result := r.runIsolated(gctx, job)
+			mu.Lock()
+			defer mu.Unlock()
+			results[i] = result
+			r.report(result)
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return results
+}
+
+// RunJob executes one job: in the current tree when Parallel is 1 or less,
+// otherwise in the spec's worktree. Callers that schedule jobs themselves,
+// such as the queue worker, use it instead of Run.
+func (r *Runner) RunJob(ctx context.Context, job Job) Result {
+	if r.Parallel <= 1 {
+		return r.runSequential(ctx, job)
+	}
+	return r.runIsolated(ctx, job)
+}
+
+// runSequential runs a spec in the current tree, streaming its output.
+func (r *Runner) runSequential(ctx context.Context, job Job) Result {
+	stages := job.stages()
+	retriesBefore := r.retries(job, stages)
+	start := time.Now()
+	err := r.Exec(ctx, "", r.runArgs(job, stages), r.Out)
+	return newResult(job, stages, err, r.retries(job, stages)-retriesBefore, time.Since(start))
+}
+
+// retries returns the retries recorded so far for the spec's stages.
+func (r *Runner) retries(job Job, stages []string) int {
+	if r.StateDir == "" {
+		return 0
+	}
+	return retry.TotalRetries(r.StateDir, job.Spec, stages)
+}
+
+// isolate creates or reuses the spec's worktree. Worktrees for different
+// specs are set up one at a time since the manager's state file is shared.
+func (r *Runner) isolate(job Job) (*worktree.Worktree, error) {
+	r.workspaceMu.Lock()
+	defer r.workspaceMu.Unlock()
+	wt, _, err := r.Workspace.Isolate(job.Spec, job.Dir)
+	return wt, err
+}
+
+// release removes the spec's worktree if it is clean; see isolate for locking.
+func (r *Runner) release(wt *worktree.Worktree, job Job) bool {
+	r.workspaceMu.Lock()
+	defer r.workspaceMu.Unlock()
+	removed, err := r.Workspace.Release(wt, job.Dir)
+	return err == nil && removed
+}
+
+// runIsolated runs a spec in its own worktree, writing output to a log file.
+// The worktree is removed after a clean success and kept otherwise.
+func (r *Runner) runIsolated(ctx context.Context, job Job) Result {
+	stages := job.stages()
+	start := time.Now()
+	fail := func(err error) Result {
+		return newResult(job, stages, err, 0, time.Since(start))
+	}
+
+	wt, err := r.isolate(job)
+	if err != nil {
+		return fail(fmt.Errorf("creating worktree: %w", err))
+	}
+	logPath := filepath.Join(r.LogDir, job.Spec+".log")
+	logFile, err := createLog(logPath)
+	if err != nil {
+		return fail(err)
+	}
+	fmt.Fprintf(r.Out, "→ %s: running %v in %s (log: %s)\n", job.Spec, stages, wt.Path, logPath)
+
+	retriesBefore := r.retries(job, stages)
+	runErr := r.Exec(ctx, wt.Path, r.runArgs(job, stages), logFile)
+	logFile.Close()
+
+	result := newResult(job, stages, runErr, r.retries(job, stages)-retriesBefore, time.Since(start))
+	result.LogPath = logPath
+	result.Worktree = wt.Path
+	if runErr == nil && r.release(wt, job) {
+		result.Worktree = ""
+	}
+	return result
+}
+
+// createLog creates a log file, creating its directory if needed.
+func createLog(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("creating log directory: %w", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating log file: %w", err)
+	}
+	return f, nil
+}
+
+// newResult builds a Result from a finished child run.
+func newResult(job Job, stages []string, err error, retries int, elapsed time.Duration) Result {
+	return Result{
+		Spec:     job.Spec,
+		Stages:   stages,
+		Success:  err == nil,
+		Err:      err,
+		Retries:  retries,
+		Duration: elapsed.Round(time.Second),
+	}
+}
+
+// report prints a one-line outcome for a finished spec.
+func (r *Runner) report(res Result) {
+	if res.Success {
+		fmt.Fprintf(r.Out, "✓ %s completed in %s\n", res.Spec, res.Duration)
+		return
+	}
+	fmt.Fprintf(r.Out, "✗ %s failed after %s: %v\n", res.Spec, res.Duration, res.Err)
+}
+
+// fileExists reports whether path exists.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// PendingJobs returns a job for every spec under specsDir whose tasks are not
+// all completed, including specs without a plan or tasks yet.
+func PendingJobs(specsDir string) ([]Job, error) {
+	entries, err := os.ReadDir(specsDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading specs directory: %w", err)
+	}
+	var jobs []Job
+	for _, entry := range entries {
+		dir := filepath.Join(specsDir, entry.Name())
+		if !entry.IsDir() || !fileExists(filepath.Join(dir, "spec.yaml")) {
+			continue
+		}
+		stats, err := validation.GetTaskStats(filepath.Join(dir, "tasks.yaml"))
+		if err == nil && stats.IsComplete() {
+			continue
+		}
+		jobs = append(jobs, Job{Spec: entry.Name(), Dir: dir})
+	}
+	return jobs, nil
+}
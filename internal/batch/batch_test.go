@@ -0,0 +1,274 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/worktree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSpec creates a spec directory with the given artifacts.
+func writeSpec(t *testing.T, specsDir, name string, files map[string]string) string {
+	t.Helper()
+	dir := filepath.Join(specsDir, name)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	for file, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0o644))
+	}
+	return dir
+}
+
+const (
+	pendingTasks   = "phases:\n  - number: 1\n    tasks:\n      - id: T001\n        status: Pending\n"
+	completedTasks = "phases:\n  - number: 1\n    tasks:\n      - id: T001\n        status: Completed\n"
+)
+
+func TestStages(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		files map[string]string
+		want  []string
+	}{
+		"spec only":       {files: map[string]string{"spec.yaml": "x"}, want: []string{"plan", "tasks", "implement"}},
+		"spec and plan":   {files: map[string]string{"spec.yaml": "x", "plan.yaml": "x"}, want: []string{"tasks", "implement"}},
+		"tasks generated": {files: map[string]string{"spec.yaml": "x", "plan.yaml": "x", "tasks.yaml": pendingTasks}, want: []string{"implement"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir := writeSpec(t, t.TempDir(), "001-auth", tt.files)
+			assert.Equal(t, tt.want, Stages(dir))
+		})
+	}
+}
+
+func TestPendingJobs(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	writeSpec(t, specsDir, "001-done", map[string]string{"spec.yaml": "x", "plan.yaml": "x", "tasks.yaml": completedTasks})
+	writeSpec(t, specsDir, "002-pending", map[string]string{"spec.yaml": "x", "plan.yaml": "x", "tasks.yaml": pendingTasks})
+	writeSpec(t, specsDir, "003-new", map[string]string{"spec.yaml": "x"})
+	writeSpec(t, specsDir, "notes", map[string]string{"README.md": "x"})
+
+	jobs, err := PendingJobs(specsDir)
+	require.NoError(t, err)
+
+	var names []string
+	for _, job := range jobs {
+		names = append(names, job.Spec)
+	}
+	assert.Equal(t, []string{"002-pending", "003-new"}, names)
+}
+
+// fakeExec records child runs and fails specs listed in failures. Like a real
+// child, it records retries of the implement stage in stateDir and resets
+// the stage's count when it succeeds.
+type fakeExec struct {
+	mu       sync.Mutex
+	calls    map[string][]string // spec -> args
+	dirs     map[string]string   // spec -> working directory
+	failures map[string]bool
+	retries  map[string]int // spec -> implement retries to record
+	stateDir string
+	output   string
+}
+
+func (f *fakeExec) run(_ context.Context, dir string, args []string, out io.Writer) error {
+	spec := args[2]
+	f.mu.Lock()
+	if f.calls == nil {
+		f.calls, f.dirs = map[string][]string{}, map[string]string{}
+	}
+	f.calls[spec], f.dirs[spec] = args, dir
+	defer f.mu.Unlock()
+
+	for range f.retries[spec] {
+		if _, err := retry.IncrementRetryCount(f.stateDir, spec, "implement", 10); err != nil {
+			return err
+		}
+	}
+	fmt.Fprint(out, f.output)
+	if f.failures[spec] {
+		return errors.New("exit status 1")
+	}
+	return retry.ResetRetryCount(f.stateDir, spec, "implement")
+}
+
+// fakeWorkspace hands out worktrees under root and removes clean ones. It
+// records whether Isolate or Release calls ever overlapped.
+type fakeWorkspace struct {
+	root       string
+	mu         sync.Mutex
+	released   []string
+	inFlight   atomic.Int32
+	overlapped atomic.Bool
+}
+
+// enter marks a workspace call as running until the returned func is called.
+func (w *fakeWorkspace) enter() func() {
+	if w.inFlight.Add(1) > 1 {
+		w.overlapped.Store(true)
+	}
+	time.Sleep(10 * time.Millisecond)
+	return func() { w.inFlight.Add(-1) }
+}
+
+func (w *fakeWorkspace) Isolate(specName, _ string) (*worktree.Worktree, bool, error) {
+	defer w.enter()()
+	path := filepath.Join(w.root, specName+"-implement")
+	return &worktree.Worktree{Name: specName + "-implement", Path: path}, false, os.MkdirAll(path, 0o755)
+}
+
+func (w *fakeWorkspace) Release(wt *worktree.Worktree, _ string) (bool, error) {
+	defer w.enter()()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.released = append(w.released, wt.Name)
+	return true, nil
+}
+
+func TestRunner_Sequential(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	jobs := []Job{
+		{Spec: "001-auth", Dir: writeSpec(t, specsDir, "001-auth", map[string]string{"spec.yaml": "x"})},
+		{Spec: "004-search", Dir: writeSpec(t, specsDir, "004-search", map[string]string{"spec.yaml": "x", "plan.yaml": "x", "tasks.yaml": pendingTasks})},
+	}
+	stateDir := t.TempDir()
+	// An earlier run of 001-auth left retries behind; only this run's count
+	_, err := retry.IncrementRetryCount(stateDir, "001-auth", "implement", 10)
+	require.NoError(t, err)
+	exec := &fakeExec{
+		failures: map[string]bool{"004-search": true},
+		retries:  map[string]int{"001-auth": 2, "004-search": 1},
+		stateDir: stateDir,
+		output:   "working\n",
+	}
+	var out bytes.Buffer
+	runner := &Runner{Out: &out, RunFlags: []string{"--agent", "codex"}, StateDir: stateDir, Exec: exec.run}
+
+	results := runner.Run(context.Background(), jobs)
+
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Success)
+	assert.Equal(t, 2, results[0].Retries, "retries are counted even though success resets them")
+	assert.False(t, results[1].Success)
+	assert.Equal(t, 1, results[1].Retries)
+	assert.Equal(t, []string{"run", "--spec", "001-auth", "--yes", "--agent", "codex", "--plan", "--tasks", "--implement"}, exec.calls["001-auth"])
+	assert.Equal(t, []string{"run", "--spec", "004-search", "--yes", "--agent", "codex", "--implement", "--resume"}, exec.calls["004-search"])
+	assert.Empty(t, exec.dirs["001-auth"], "sequential runs use the current directory")
+	assert.Contains(t, out.String(), "=== [2/2] 004-search ===")
+	assert.Contains(t, out.String(), "working", "child output is streamed")
+}
+
+func TestRunner_RunJobWithStages(t *testing.T) {
+	t.Parallel()
+
+	dir := writeSpec(t, t.TempDir(), "001-auth", map[string]string{"spec.yaml": "x"})
+	tests := map[string]struct {
+		stages []string
+		want   []string
+	}{
+		"given stages":         {stages: []string{"plan", "analyze"}, want: []string{"run", "--spec", "001-auth", "--yes", "--plan", "--analyze"}},
+		"single non-implement": {stages: []string{"clarify"}, want: []string{"run", "--spec", "001-auth", "--yes", "--clarify"}},
+		"remaining stages":     {want: []string{"run", "--spec", "001-auth", "--yes", "--plan", "--tasks", "--implement"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			exec := &fakeExec{stateDir: t.TempDir()}
+			runner := &Runner{Out: io.Discard, Exec: exec.run}
+
+			result := runner.RunJob(context.Background(), Job{Spec: "001-auth", Dir: dir, Stages: tt.stages})
+			assert.True(t, result.Success)
+			assert.Equal(t, tt.want, exec.calls["001-auth"])
+		})
+	}
+}
+
+func TestRunner_ParallelUsesWorktrees(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	var jobs []Job
+	for _, name := range []string{"001-auth", "004-search", "007-export"} {
+		jobs = append(jobs, Job{Spec: name, Dir: writeSpec(t, specsDir, name, map[string]string{"spec.yaml": "x"})})
+	}
+	exec := &fakeExec{failures: map[string]bool{"007-export": true}, stateDir: t.TempDir(), output: "agent output\n"}
+	workspace := &fakeWorkspace{root: t.TempDir()}
+	logDir := t.TempDir()
+	var out syncBuffer
+	runner := &Runner{Out: &out, Parallel: 3, LogDir: logDir, Workspace: workspace, Exec: exec.run}
+
+	results := runner.Run(context.Background(), jobs)
+
+	require.Len(t, results, 3)
+	assert.False(t, workspace.overlapped.Load(), "worktree setup and removal are serialized")
+	for i, job := range jobs {
+		assert.Equal(t, job.Spec, results[i].Spec, "results keep job order")
+		assert.Equal(t, filepath.Join(workspace.root, job.Spec+"-implement"), exec.dirs[job.Spec])
+		log, err := os.ReadFile(filepath.Join(logDir, job.Spec+".log"))
+		require.NoError(t, err)
+		assert.Equal(t, "agent output\n", string(log))
+	}
+	assert.ElementsMatch(t, []string{"001-auth-implement", "004-search-implement"}, workspace.released, "only successful worktrees are released")
+	assert.Empty(t, results[0].Worktree)
+	assert.Equal(t, exec.dirs["007-export"], results[2].Worktree, "failed worktree is kept")
+	assert.NotContains(t, out.String(), "agent output", "parallel output goes to log files")
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWriteSummary(t *testing.T) {
+	t.Parallel()
+
+	results := []Result{
+		{Spec: "001-auth", Stages: []string{"implement"}, Success: true, Retries: 2},
+		{Spec: "004-search", Stages: []string{"tasks", "implement"}, Err: errors.New("exit status 1"), Retries: 1,
+			LogPath: "/state/batch/004-search.log", Worktree: "/repo/.worktrees/004-search-implement"},
+	}
+	var out bytes.Buffer
+
+	WriteSummary(&out, results)
+
+	lines := strings.Split(out.String(), "\n")
+	assert.Contains(t, lines, "SPEC        STATUS   RETRIES   DURATION  STAGES")
+	assert.Contains(t, lines, "001-auth    ok             2         0s  implement")
+	assert.Contains(t, lines, "004-search  failed         1         0s  tasks,implement")
+	assert.Contains(t, out.String(), "1 succeeded, 1 failed, 3 retries")
+	assert.Contains(t, out.String(), "004-search: exit status 1\n    log: /state/batch/004-search.log\n    worktree: /repo/.worktrees/004-search-implement")
+}
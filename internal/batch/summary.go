@@ -0,0 +1,63 @@
+package batch
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Failed returns the number of results that did not succeed.
+func Failed(results []Result) int {
+	failed := 0
+	for _, res := range results {
+		if !res.Success {
+			failed++
+		}
+	}
+	return failed
+}
+
+// WriteSummary prints a table of per-spec outcomes followed by totals.
+func WriteSummary(out io.Writer, results []Result) {
+	width := len("SPEC")
+	for _, res := range results {
+		width = max(width, len(res.Spec))
+	}
+
+	fmt.Fprintf(out, "\nBatch summary\n\n")
+	fmt.Fprintf(out, "%-*s  %-7s  %7s  %9s  %s\n", width, "SPEC", "STATUS", "RETRIES", "DURATION", "STAGES")
+	retries := 0
+	for _, res := range results {
+		status := "ok"
+		if !res.Success {
+			status = "failed"
+		}
+		retries += res.Retries
+		fmt.Fprintf(out, "%-*s  %-7s  %7d  %9s  %s\n", width, res.Spec, status, res.Retries, res.Duration, strings.Join(res.Stages, ","))
+	}
+
+	failed := Failed(results)
+	fmt.Fprintf(out, "\n%d succeeded, %d failed, %d retries\n", len(results)-failed, failed, retries)
+	for _, res := range results {
+		writeDetails(out, res)
+	}
+}
+
+// writeDetails prints the error, log and kept worktree of a failed spec, and
+// the worktree of a successful one that was kept for uncommitted changes.
+func writeDetails(out io.Writer, res Result) {
+	switch {
+	case !res.Success:
+		fmt.Fprintf(out, "  %s: %v\n", res.Spec, res.Err)
+		if res.LogPath != "" {
+			fmt.Fprintf(out, "    log: %s\n", res.LogPath)
+		}
+	case res.Worktree != "":
+		fmt.Fprintf(out, "  %s: worktree kept for review\n", res.Spec)
+	default:
+		return
+	}
+	if res.Worktree != "" {
+		fmt.Fprintf(out, "    worktree: %s\n", res.Worktree)
+	}
+}
@@ -0,0 +1,192 @@
+// Package budget stops workflow runs that exceed a token or cost ceiling.
+// Related: internal/workflow/budget.go, internal/cliagent/usage.go
+// Tags: budget, cost, tokens, usage, guardrails
+//
+// A Tracker adds up the usage agents report (Claude's result events) for the
+// current run and, persisted in the state directory, for each spec across
+// runs. The executor checks it before every agent session, so a run that
+// reaches a limit stops between sessions and can be resumed once the limit is
+// raised. Agents that report no usage are not limited.
+package budget
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/filelock"
+)
+
+// SpendFileName is the name of the file that stores each spec's spend.
+const SpendFileName = "spec_spend.json"
+
+// Limit is a ceiling on tokens and estimated cost. Zero fields are unlimited.
+type Limit struct {
+	// CostUSD is the most the agent's cost estimates may add up to.
+	CostUSD float64 `koanf:"cost_usd" yaml:"cost_usd" json:"cost_usd"`
+
+	// Tokens is the most input, output and cache tokens may add up to.
+	Tokens int `koanf:"tokens" yaml:"tokens" json:"tokens"`
+}
+
+// IsZero reports whether the limit is unlimited.
+func (l Limit) IsZero() bool {
+	return l.CostUSD <= 0 && l.Tokens <= 0
+}
+
+// reached reports whether spent is at or over the limit.
+func (l Limit) reached(spent cliagent.Usage) bool {
+	return (l.CostUSD > 0 && spent.CostUSD >= l.CostUSD) || (l.Tokens > 0 && spent.TotalTokens() >= l.Tokens)
+}
+
+// Config sets the budget of a run and of a spec.
+//
+// Example:
+//
+//	budget:
+//	  run:
+//	    cost_usd: 5
+//	  spec:
+//	    cost_usd: 20
+//	    tokens: 5000000
+type Config struct {
+	// Run limits one autospec command, across every spec it works on.
+	Run Limit `koanf:"run" yaml:"run" json:"run"`
+
+	// Spec limits everything spent on one spec, across runs.
+	Spec Limit `koanf:"spec" yaml:"spec" json:"spec"`
+}
+
+// Validate checks that no limit is negative.
+func (c Config) Validate() error {
+	if c.Run.CostUSD < 0 || c.Run.Tokens < 0 {
+		return fmt.Errorf("run limits must not be negative")
+	}
+	if c.Spec.CostUSD < 0 || c.Spec.Tokens < 0 {
+		return fmt.Errorf("spec limits must not be negative")
+	}
+	return nil
+}
+
+// ExceededError is returned when a run or spec has used up its budget.
+type ExceededError struct {
+	// Scope is "run" or "spec".
+	Scope string
+	// Spec is the spec whose budget ran out (empty for the run budget).
+	Spec  string
+	Spent cliagent.Usage
+	Limit Limit
+}
+
+func (e *ExceededError) Error() string {
+	scope := "run"
+	if e.Scope == "spec" {
+		scope = fmt.Sprintf("spec %s", e.Spec)
+	}
+	var spent []string
+	if e.Limit.CostUSD > 0 {
+		spent = append(spent, fmt.Sprintf("$%.2f of $%.2f", e.Spent.CostUSD, e.Limit.CostUSD))
+	}
+	if e.Limit.Tokens > 0 {
+		spent = append(spent, fmt.Sprintf("%d of %d tokens", e.Spent.TotalTokens(), e.Limit.Tokens))
+	}
+	return fmt.Sprintf("budget exceeded for %s (%s); raise budget.%s and re-run with --resume to continue",
+		scope, strings.Join(spent, ", "), e.Scope)
+}
+
+// Tracker adds up usage and enforces a Config.
+type Tracker struct {
+	Config Config
+	// StateDir stores each spec's spend (empty = spec spend is not tracked).
+	StateDir string
+
+	run cliagent.Usage
+}
+
+// NewTracker returns a Tracker for cfg, or nil when cfg has no limits.
+func NewTracker(cfg Config, stateDir string) *Tracker {
+	if cfg.Run.IsZero() && cfg.Spec.IsZero() {
+		return nil
+	}
+	return &Tracker{Config: cfg, StateDir: stateDir}
+}
+
+// Check returns an *ExceededError if the run, or specName when set, has
+// reached its limit. A nil Tracker never fails.
+func (t *Tracker) Check(specName string) error {
+	if t == nil {
+		return nil
+	}
+	if t.Config.Run.reached(t.run) {
+		return &ExceededError{Scope: "run", Spent: t.run, Limit: t.Config.Run}
+	}
+	if specName == "" || t.Config.Spec.IsZero() || t.StateDir == "" {
+		return nil
+	}
+	if spent := SpecSpend(t.StateDir, specName); t.Config.Spec.reached(spent) {
+		return &ExceededError{Scope: "spec", Spec: specName, Spent: spent, Limit: t.Config.Spec}
+	}
+	return nil
+}
+
+// Charge adds usage to the run and to specName's persisted spend.
+func (t *Tracker) Charge(specName string, usage cliagent.Usage) error {
+	if t == nil {
+		return nil
+	}
+	t.run.Add(usage)
+	if specName == "" || t.StateDir == "" {
+		return nil
+	}
+	return addSpecSpend(t.StateDir, specName, usage)
+}
+
+// Run returns the usage charged in this run.
+func (t *Tracker) Run() cliagent.Usage {
+	if t == nil {
+		return cliagent.Usage{}
+	}
+	return t.run
+}
+
+// SpecSpend returns the usage recorded for specName across runs.
+func SpecSpend(stateDir, specName string) cliagent.Usage {
+	return loadSpend(filepath.Join(stateDir, SpendFileName))[specName]
+}
+
+// addSpecSpend adds usage to specName's recorded spend.
+func addSpecSpend(stateDir, specName string, usage cliagent.Usage) error {
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		return fmt.Errorf("creating state directory: %w", err)
+	}
+	path := filepath.Join(stateDir, SpendFileName)
+	return filelock.With(path, func() error {
+		spend := loadSpend(path)
+		total := spend[specName]
+		total.Add(usage)
+		spend[specName] = total
+
+		data, err := json.MarshalIndent(spend, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling spec spend: %w", err)
+		}
+		return atomicfile.WriteFile(path, data, 0644)
+	})
+}
+
+// loadSpend reads the spend store, treating a missing or corrupted file as empty.
+func loadSpend(path string) map[string]cliagent.Usage {
+	spend := make(map[string]cliagent.Usage)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return spend
+	}
+	if err := json.Unmarshal(data, &spend); err != nil {
+		return make(map[string]cliagent.Usage)
+	}
+	return spend
+}
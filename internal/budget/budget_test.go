@@ -0,0 +1,69 @@
+package budget
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTracker(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, NewTracker(Config{}, t.TempDir()))
+	assert.NotNil(t, NewTracker(Config{Run: Limit{CostUSD: 1}}, t.TempDir()))
+	assert.NotNil(t, NewTracker(Config{Spec: Limit{Tokens: 1000}}, t.TempDir()))
+}
+
+func TestTracker_RunLimit(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewTracker(Config{Run: Limit{CostUSD: 1}}, t.TempDir())
+	require.NoError(t, tracker.Check("001-auth"))
+
+	require.NoError(t, tracker.Charge("001-auth", cliagent.Usage{CostUSD: 0.6}))
+	require.NoError(t, tracker.Check("001-auth"))
+
+	require.NoError(t, tracker.Charge("002-cache", cliagent.Usage{CostUSD: 0.5}))
+	err := tracker.Check("003-other")
+	var exceeded *ExceededError
+	require.True(t, errors.As(err, &exceeded))
+	assert.Equal(t, "run", exceeded.Scope)
+	assert.Contains(t, err.Error(), "$1.10 of $1.00")
+	assert.Contains(t, err.Error(), "--resume")
+	assert.InDelta(t, 1.1, tracker.Run().CostUSD, 1e-9)
+}
+
+func TestTracker_SpecLimitPersistsAcrossRuns(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	cfg := Config{Spec: Limit{Tokens: 1000}}
+
+	first := NewTracker(cfg, stateDir)
+	require.NoError(t, first.Charge("001-auth", cliagent.Usage{InputTokens: 600}))
+	require.NoError(t, first.Check("001-auth"))
+
+	second := NewTracker(cfg, stateDir)
+	require.NoError(t, second.Charge("001-auth", cliagent.Usage{OutputTokens: 400}))
+	err := second.Check("001-auth")
+	var exceeded *ExceededError
+	require.True(t, errors.As(err, &exceeded))
+	assert.Equal(t, "spec", exceeded.Scope)
+	assert.Contains(t, err.Error(), "spec 001-auth (1000 of 1000 tokens)")
+
+	assert.NoError(t, second.Check("002-cache"), "other specs have their own budget")
+	assert.NoError(t, second.Check(""), "specify runs before the spec is known")
+	assert.Equal(t, 1000, SpecSpend(stateDir, "001-auth").TotalTokens())
+}
+
+func TestTracker_Nil(t *testing.T) {
+	t.Parallel()
+
+	var tracker *Tracker
+	assert.NoError(t, tracker.Check("001-auth"))
+	assert.NoError(t, tracker.Charge("001-auth", cliagent.Usage{CostUSD: 100}))
+	assert.Zero(t, tracker.Run())
+}
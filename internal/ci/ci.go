@@ -0,0 +1,153 @@
+// Package ci runs the remaining workflow stages of one spec unattended and
+// reports the outcome to GitHub Actions.
+// Related: internal/cli/ci.go
+// Tags: ci, github-actions, workflow, summary
+//
+// Each stage runs in its own child autospec process (`autospec run --spec
+// <name> --<stage>`) inside a collapsible log group, so a failure is
+// attributed to the stage that caused it. Failures become ::error
+// annotations, blocked tasks ::warning annotations, and a markdown job
+// summary lists the stage results and task progress.
+package ci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/batch"
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+// ExecFunc runs autospec with args, writing its output to out.
+type ExecFunc func(ctx context.Context, args []string, out io.Writer) error
+
+// StageResult is the outcome of one stage.
+type StageResult struct {
+	Stage    string
+	Status   string // "passed", "failed" or "skipped" (an earlier stage failed)
+	ExitCode int
+	Err      error
+	Retries  int
+	Duration time.Duration
+}
+
+// Stage statuses.
+const (
+	StagePassed  = "passed"
+	StageFailed  = "failed"
+	StageSkipped = "skipped"
+)
+
+// Report is the outcome of a CI run.
+type Report struct {
+	Spec     string
+	Stages   []StageResult
+	Tasks    *validation.TaskStats // nil until tasks.yaml exists
+	Blocked  []validation.TaskItem
+	ExitCode int // Exit code of the failed stage; 0 when all passed
+	Duration time.Duration
+}
+
+// Runner runs a spec's remaining stages.
+type Runner struct {
+	Out      io.Writer // Workflow commands and child output
+	RunFlags []string  // Extra flags for each `autospec run`, e.g. --agent
+	StateDir string    // Retry state shared with child runs, used to count their retries
+	Exec     ExecFunc
+}
+
+// Run executes the stages the spec still needs (see batch.Stages), stopping
+// at the first failure, and returns the report. Stages after a failure are
+// reported as skipped.
+func (r *Runner) Run(ctx context.Context, specName, specDir string) *Report {
+	report := &Report{Spec: specName}
+	start := time.Now()
+
+	for _, stage := range batch.Stages(specDir) {
+		if report.ExitCode != 0 {
+			report.Stages = append(report.Stages, StageResult{Stage: stage, Status: StageSkipped})
+			continue
+		}
+		result := r.runStage(ctx, specName, stage)
+		report.Stages = append(report.Stages, result)
+		if result.Status == StageFailed {
+			report.ExitCode = result.ExitCode
+			Error(r.Out, fmt.Sprintf("autospec %s failed", stage), fmt.Sprintf("%s: %v", specName, result.Err))
+		}
+	}
+
+	tasksPath := validation.GetTasksFilePath(specDir)
+	if stats, err := validation.GetTaskStats(tasksPath); err == nil {
+		report.Tasks = stats
+	}
+	if tasks, err := validation.GetAllTasks(tasksPath); err == nil {
+		for _, task := range tasks {
+			if strings.EqualFold(task.Status, "Blocked") {
+				report.Blocked = append(report.Blocked, task)
+				Warning(r.Out, fmt.Sprintf("Task %s blocked", task.ID), blockedMessage(task))
+			}
+		}
+	}
+	report.Duration = time.Since(start).Round(time.Second)
+	return report
+}
+
+// runStage runs one stage in a log group.
+func (r *Runner) runStage(ctx context.Context, specName, stage string) StageResult {
+	args := append([]string{"run", "--spec", specName, "--yes", "--" + stage}, r.RunFlags...)
+	if stage == "implement" {
+		// Picks up where a previous CI run (or a human) left off
+		args = append(args, "--resume")
+	}
+	retriesBefore := r.retries(specName, stage)
+
+	Group(r.Out, "autospec "+stage+" ("+specName+")")
+	start := time.Now()
+	err := r.Exec(ctx, args, r.Out)
+	EndGroup(r.Out)
+
+	result := StageResult{
+		Stage:    stage,
+		Status:   StagePassed,
+		Err:      err,
+		Retries:  r.retries(specName, stage) - retriesBefore,
+		Duration: time.Since(start).Round(time.Second),
+	}
+	if err != nil {
+		result.Status = StageFailed
+		result.ExitCode = exitCode(err)
+	}
+	return result
+}
+
+// retries returns the retries recorded so far for the stage.
+func (r *Runner) retries(specName, stage string) int {
+	if r.StateDir == "" {
+		return 0
+	}
+	return retry.TotalRetries(r.StateDir, specName, []string{stage})
+}
+
+// exitCode returns a failed child run's exit code, so autospec's own codes
+// (e.g. 2 for an exhausted retry limit) reach the CI job. Runs that ended
+// without one, e.g. killed by a signal, report 1.
+func exitCode(err error) int {
+	var coded interface{ ExitCode() int }
+	if errors.As(err, &coded) && coded.ExitCode() > 0 {
+		return coded.ExitCode()
+	}
+	return 1
+}
+
+// blockedMessage describes a blocked task for an annotation.
+func blockedMessage(task validation.TaskItem) string {
+	if task.BlockedReason == "" {
+		return task.Title
+	}
+	return task.Title + ": " + task.BlockedReason
+}
@@ -0,0 +1,168 @@
+package ci
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tasksYAML = `phases:
+  - number: 1
+    title: "Setup"
+    tasks:
+      - id: T001
+        title: "Add login"
+        status: Completed
+      - id: T002
+        title: "Wire OAuth"
+        status: Blocked
+        blocked_reason: "Needs client secret"
+`
+
+// codedError is a child run failure with an exit code, like *exec.ExitError.
+type codedError int
+
+func (e codedError) Error() string { return fmt.Sprintf("exit status %d", int(e)) }
+func (e codedError) ExitCode() int { return int(e) }
+
+// writeSpec creates a spec directory with the given artifacts.
+func writeSpec(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "001-auth")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	for file, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestRunner_Run(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		files        map[string]string
+		failures     map[string]error // stage -> error returned by its run
+		wantStatuses []string
+		wantExitCode int
+		wantOutput   []string
+	}{
+		"all stages pass": {
+			files:        map[string]string{"spec.yaml": "x"},
+			wantStatuses: []string{"plan:passed", "tasks:passed", "implement:passed"},
+			wantOutput: []string{
+				"::group::autospec plan (001-auth)\nran run --spec 001-auth --yes --plan --agent gemini\n::endgroup::\n",
+				"ran run --spec 001-auth --yes --implement --agent gemini --resume\n",
+			},
+		},
+		"failure skips later stages": {
+			files:        map[string]string{"spec.yaml": "x"},
+			failures:     map[string]error{"tasks": codedError(2)},
+			wantStatuses: []string{"plan:passed", "tasks:failed", "implement:skipped"},
+			wantExitCode: 2,
+			wantOutput:   []string{"::error title=autospec tasks failed::001-auth: exit status 2\n"},
+		},
+		"failure without exit code": {
+			files:        map[string]string{"spec.yaml": "x", "plan.yaml": "x", "tasks.yaml": tasksYAML},
+			failures:     map[string]error{"implement": errors.New("signal: killed")},
+			wantStatuses: []string{"implement:failed"},
+			wantExitCode: 1,
+		},
+		"blocked tasks are annotated": {
+			files:        map[string]string{"spec.yaml": "x", "plan.yaml": "x", "tasks.yaml": tasksYAML},
+			wantStatuses: []string{"implement:passed"},
+			wantOutput:   []string{"::warning title=Task T002 blocked::Wire OAuth: Needs client secret\n"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir := writeSpec(t, tt.files)
+			var out bytes.Buffer
+			runner := &Runner{
+				Out:      &out,
+				RunFlags: []string{"--agent", "gemini"},
+				Exec: func(_ context.Context, args []string, w io.Writer) error {
+					fmt.Fprintf(w, "ran %s\n", strings.Join(args, " "))
+					return tt.failures[strings.TrimPrefix(args[4], "--")]
+				},
+			}
+
+			report := runner.Run(context.Background(), "001-auth", dir)
+
+			var statuses []string
+			for _, stage := range report.Stages {
+				statuses = append(statuses, stage.Stage+":"+stage.Status)
+			}
+			assert.Equal(t, tt.wantStatuses, statuses)
+			assert.Equal(t, tt.wantExitCode, report.ExitCode)
+			for _, want := range tt.wantOutput {
+				assert.Contains(t, out.String(), want)
+			}
+		})
+	}
+}
+
+func TestAnnotations_Escaping(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	Error(&out, "plan: failed, 50%", "line one\nline two")
+	Group(&out, "100% done")
+
+	assert.Equal(t, "::error title=plan%3A failed%2C 50%25::line one%0Aline two\n::group::100%25 done\n", out.String())
+}
+
+func TestWriteSummary(t *testing.T) {
+	t.Parallel()
+
+	dir := writeSpec(t, map[string]string{"tasks.yaml": tasksYAML})
+	stats, err := validation.GetTaskStats(filepath.Join(dir, "tasks.yaml"))
+	require.NoError(t, err)
+	report := &Report{
+		Spec: "001-auth",
+		Stages: []StageResult{
+			{Stage: "tasks", Status: StagePassed, Retries: 1},
+			{Stage: "implement", Status: StageFailed, ExitCode: 2, Err: codedError(2)},
+		},
+		Tasks:    stats,
+		Blocked:  []validation.TaskItem{{ID: "T002", Title: "Wire OAuth", BlockedReason: "Needs client secret"}},
+		ExitCode: 2,
+	}
+
+	var out bytes.Buffer
+	WriteSummary(&out, report)
+	summary := out.String()
+
+	assert.Contains(t, summary, "## autospec: 001-auth")
+	assert.Contains(t, summary, "❌ failed (exit code 2)")
+	assert.Contains(t, summary, "| tasks | ✅ passed | 1 | 0s |")
+	assert.Contains(t, summary, "| implement | ❌ failed | 0 | 0s |")
+	assert.Contains(t, summary, "**Tasks:** 1/2 completed (50%), 0 in progress, 0 pending, 1 blocked")
+	assert.Contains(t, summary, "| 1 | Setup | 1/2 |")
+	assert.Contains(t, summary, "- **T002** Wire OAuth: Needs client secret")
+	assert.Contains(t, summary, "`autospec implement` failed: exit status 2")
+}
+
+func TestAppendSummary(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "summary.md")
+	require.NoError(t, os.WriteFile(path, []byte("# Earlier step\n"), 0o644))
+
+	require.NoError(t, AppendSummary(path, &Report{Spec: "001-auth"}))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "# Earlier step\n## autospec: 001-auth"))
+}
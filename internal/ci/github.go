@@ -0,0 +1,122 @@
+package ci
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Group starts a collapsible log group in the GitHub Actions log.
+func Group(w io.Writer, title string) {
+	fmt.Fprintf(w, "::group::%s\n", escapeData(title))
+}
+
+// EndGroup ends the current log group.
+func EndGroup(w io.Writer) {
+	fmt.Fprintln(w, "::endgroup::")
+}
+
+// Error emits an error annotation, shown on the workflow run and PR.
+func Error(w io.Writer, title, message string) {
+	annotate(w, "error", title, message)
+}
+
+// Warning emits a warning annotation.
+func Warning(w io.Writer, title, message string) {
+	annotate(w, "warning", title, message)
+}
+
+func annotate(w io.Writer, level, title, message string) {
+	fmt.Fprintf(w, "::%s title=%s::%s\n", level, escapeProperty(title), escapeData(message))
+}
+
+// escapeData escapes a workflow command's message, which ends at a newline.
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a workflow command property, which also ends at a
+// comma or colon.
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// stageIcons marks stage statuses in the job summary.
+var stageIcons = map[string]string{
+	StagePassed:  "✅ passed",
+	StageFailed:  "❌ failed",
+	StageSkipped: "⏭️ skipped",
+}
+
+// WriteSummary writes the report as GitHub-flavored markdown.
+func WriteSummary(w io.Writer, report *Report) {
+	result := "✅ succeeded"
+	if report.ExitCode != 0 {
+		result = fmt.Sprintf("❌ failed (exit code %d)", report.ExitCode)
+	}
+	fmt.Fprintf(w, "## autospec: %s\n\n", report.Spec)
+	fmt.Fprintf(w, "**Result:** %s in %s\n\n", result, report.Duration)
+
+	if len(report.Stages) > 0 {
+		fmt.Fprintln(w, "| Stage | Result | Retries | Duration |")
+		fmt.Fprintln(w, "|-------|--------|---------|----------|")
+		for _, stage := range report.Stages {
+			duration := "-"
+			if stage.Status != StageSkipped {
+				duration = stage.Duration.String()
+			}
+			fmt.Fprintf(w, "| %s | %s | %d | %s |\n", stage.Stage, stageIcons[stage.Status], stage.Retries, duration)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if report.Tasks != nil {
+		tasks := report.Tasks
+		fmt.Fprintf(w, "**Tasks:** %d/%d completed (%.0f%%), %d in progress, %d pending, %d blocked\n\n",
+			tasks.CompletedTasks, tasks.TotalTasks, tasks.CompletionPercentage(),
+			tasks.InProgressTasks, tasks.PendingTasks, tasks.BlockedTasks)
+		if len(tasks.PhaseStats) > 0 {
+			fmt.Fprintln(w, "| Phase | Title | Completed |")
+			fmt.Fprintln(w, "|-------|-------|-----------|")
+			for _, phase := range tasks.PhaseStats {
+				fmt.Fprintf(w, "| %d | %s | %d/%d |\n", phase.Number, escapeCell(phase.Title), phase.CompletedTasks, phase.TotalTasks)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+
+	if len(report.Blocked) > 0 {
+		fmt.Fprintln(w, "### Blocked tasks")
+		fmt.Fprintln(w)
+		for _, task := range report.Blocked {
+			fmt.Fprintf(w, "- **%s** %s\n", task.ID, blockedMessage(task))
+		}
+		fmt.Fprintln(w)
+	}
+
+	for _, stage := range report.Stages {
+		if stage.Status == StageFailed {
+			fmt.Fprintf(w, "> [!CAUTION]\n> `autospec %s` failed: %v\n", stage.Stage, stage.Err)
+		}
+	}
+}
+
+// AppendSummary appends the report to the job summary file at path (the
+// GITHUB_STEP_SUMMARY file in GitHub Actions).
+func AppendSummary(path string, report *Report) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening job summary: %w", err)
+	}
+	WriteSummary(f, report)
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing job summary: %w", err)
+	}
+	return nil
+}
+
+// escapeCell keeps text from breaking a markdown table row.
+func escapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
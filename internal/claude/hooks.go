@@ -0,0 +1,62 @@
+package claude
+
+// Hook event names understood by Claude Code.
+const (
+	// HookSessionStart runs when a Claude Code session starts or resumes.
+	HookSessionStart = "SessionStart"
+	// HookPostToolUse runs after a tool call completes.
+	HookPostToolUse = "PostToolUse"
+)
+
+// getHooks returns the hooks object, creating it if necessary.
+func (s *Settings) getHooks() map[string]interface{} {
+	hooks, ok := s.data["hooks"].(map[string]interface{})
+	if !ok {
+		hooks = make(map[string]interface{})
+		s.data["hooks"] = hooks
+	}
+	return hooks
+}
+
+// HasHook checks if a command hook is registered for the given event,
+// regardless of which matcher group it belongs to.
+func (s *Settings) HasHook(event, command string) bool {
+	groups, _ := s.getHooks()[event].([]interface{})
+	for _, g := range groups {
+		group, ok := g.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		entries, _ := group["hooks"].([]interface{})
+		for _, e := range entries {
+			entry, ok := e.(map[string]interface{})
+			if ok && entry["command"] == command {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AddHook registers a command hook for the given event and tool matcher.
+// An empty matcher applies to all tools (or to events without tools).
+// Returns false if the command is already registered for the event.
+func (s *Settings) AddHook(event, matcher, command string) bool {
+	if s.HasHook(event, command) {
+		return false
+	}
+
+	group := map[string]interface{}{
+		"hooks": []interface{}{
+			map[string]interface{}{"type": "command", "command": command},
+		},
+	}
+	if matcher != "" {
+		group["matcher"] = matcher
+	}
+
+	hooks := s.getHooks()
+	groups, _ := hooks[event].([]interface{})
+	hooks[event] = append(groups, group)
+	return true
+}
@@ -0,0 +1,129 @@
+// Package claude_test tests Claude settings hook registration.
+// Related: internal/claude/hooks.go
+// Tags: claude, settings, hooks, json
+
+package claude
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasHook(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		settings string
+		event    string
+		command  string
+		want     bool
+	}{
+		"no hooks": {
+			settings: `{}`,
+			event:    HookPostToolUse,
+			command:  "autospec claude-hook post-edit",
+			want:     false,
+		},
+		"hook registered under matcher": {
+			settings: `{"hooks": {"PostToolUse": [{"matcher": "Write", "hooks": [{"type": "command", "command": "autospec claude-hook post-edit"}]}]}}`,
+			event:    HookPostToolUse,
+			command:  "autospec claude-hook post-edit",
+			want:     true,
+		},
+		"hook registered for other event": {
+			settings: `{"hooks": {"SessionStart": [{"hooks": [{"type": "command", "command": "autospec status"}]}]}}`,
+			event:    HookPostToolUse,
+			command:  "autospec status",
+			want:     false,
+		},
+		"malformed hooks ignored": {
+			settings: `{"hooks": {"PostToolUse": ["not-a-group", {"hooks": "nope"}]}}`,
+			event:    HookPostToolUse,
+			command:  "autospec status",
+			want:     false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			createSettingsFile(t, dir, tt.settings)
+			s, err := Load(dir)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, s.HasHook(tt.event, tt.command))
+		})
+	}
+}
+
+func TestAddHook(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		settings  string
+		matcher   string
+		wantAdded bool
+		wantCount int
+	}{
+		"adds to empty settings": {
+			settings:  `{}`,
+			matcher:   "Write|Edit",
+			wantAdded: true,
+			wantCount: 1,
+		},
+		"appends next to existing user hook": {
+			settings:  `{"hooks": {"PostToolUse": [{"matcher": "Bash", "hooks": [{"type": "command", "command": "lint"}]}]}}`,
+			matcher:   "Write|Edit",
+			wantAdded: true,
+			wantCount: 2,
+		},
+		"skips duplicate": {
+			settings:  `{"hooks": {"PostToolUse": [{"hooks": [{"type": "command", "command": "autospec claude-hook post-edit"}]}]}}`,
+			matcher:   "Write|Edit",
+			wantAdded: false,
+			wantCount: 1,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			createSettingsFile(t, dir, tt.settings)
+			s, err := Load(dir)
+			require.NoError(t, err)
+
+			added := s.AddHook(HookPostToolUse, tt.matcher, "autospec claude-hook post-edit")
+
+			assert.Equal(t, tt.wantAdded, added)
+			assert.True(t, s.HasHook(HookPostToolUse, "autospec claude-hook post-edit"))
+			groups := s.getHooks()[HookPostToolUse].([]interface{})
+			assert.Len(t, groups, tt.wantCount)
+		})
+	}
+}
+
+func TestHooksRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	createSettingsFile(t, dir, `{"permissions": {"allow": ["Bash(autospec:*)"]}}`)
+
+	s, err := Load(dir)
+	require.NoError(t, err)
+	s.AddHook(HookSessionStart, "", "autospec status")
+	s.AddHook(HookPostToolUse, "Write|Edit|MultiEdit", "autospec claude-hook post-edit")
+	require.NoError(t, s.Save())
+
+	s2, err := Load(dir)
+	require.NoError(t, err)
+	assert.True(t, s2.HasPermission(RequiredPermission))
+	assert.True(t, s2.HasHook(HookSessionStart, "autospec status"))
+	assert.True(t, s2.HasHook(HookPostToolUse, "autospec claude-hook post-edit"))
+
+	group := s2.getHooks()[HookSessionStart].([]interface{})[0].(map[string]interface{})
+	assert.NotContains(t, group, "matcher", "empty matcher should be omitted")
+}
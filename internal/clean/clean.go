@@ -70,7 +70,16 @@ func FindAutospecFiles(keepSpecs bool) ([]CleanTarget, error) {
 		}
 	}
 
-	// Check for .claude/commands/autospec*.md files using filepath.Glob
+	commands, err := FindCommandFiles()
+	if err != nil {
+		return nil, err
+	}
+	return append(targets, commands...), nil
+}
+
+// FindCommandFiles detects the .claude/commands/autospec*.md slash commands.
+func FindCommandFiles() ([]CleanTarget, error) {
+	var targets []CleanTarget
 	matches, err := filepath.Glob(".claude/commands/autospec*.md")
 	if err != nil {
 		return nil, err
@@ -82,10 +91,52 @@ func FindAutospecFiles(keepSpecs bool) ([]CleanTarget, error) {
 			Description: "Autospec slash command",
 		})
 	}
-
 	return targets, nil
 }
 
+// stateDirs are the directories under .autospec that autospec generates
+// while running, as opposed to configuration, scripts and memory.
+var stateDirs = []CleanTarget{
+	{Path: filepath.Join(".autospec", "state"), Type: TypeDirectory, Description: "Project state (worktree tracking)"},
+	{Path: filepath.Join(".autospec", "context"), Type: TypeDirectory, Description: "Phase context files"},
+	{Path: filepath.Join(".autospec", "tmp"), Type: TypeDirectory, Description: "Prompt files for oversized prompts"},
+}
+
+// FindStateFiles detects the generated state directories under .autospec,
+// leaving configuration, scripts, prompts and memory in place.
+func FindStateFiles() []CleanTarget {
+	var targets []CleanTarget
+	for _, target := range stateDirs {
+		if info, err := os.Stat(target.Path); err == nil && info.IsDir() {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// FindGlobalFiles detects the global ~/.autospec directory (state, history
+// and legacy config) under homeDir.
+func FindGlobalFiles(homeDir string) []CleanTarget {
+	path := filepath.Join(homeDir, ".autospec")
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return nil
+	}
+	return []CleanTarget{{
+		Path:        path,
+		Type:        TypeDirectory,
+		Description: "Global autospec state and history",
+	}}
+}
+
+// SpecTarget returns the target for one spec directory.
+func SpecTarget(specDir string) CleanTarget {
+	return CleanTarget{
+		Path:        specDir,
+		Type:        TypeDirectory,
+		Description: "Feature specification " + filepath.Base(specDir),
+	}
+}
+
 // RemoveFiles removes the specified targets and returns the results.
 // It continues after individual failures and reports all results.
 func RemoveFiles(targets []CleanTarget) []CleanResult {
@@ -359,3 +359,47 @@ func TestFindAutospecFiles_MixedScenario(t *testing.T) {
 		assert.NotContains(t, target.Path, "src")
 	}
 }
+
+func TestFindStateFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	origWd, _ := os.Getwd()
+	defer os.Chdir(origWd)
+	require.NoError(t, os.Chdir(tmpDir))
+
+	require.NoError(t, os.MkdirAll(".autospec/context", 0755))
+	require.NoError(t, os.MkdirAll(".autospec/tmp", 0755))
+	require.NoError(t, os.MkdirAll(".autospec/memory", 0755))
+
+	targets := FindStateFiles()
+	require.Len(t, targets, 2)
+	assert.Equal(t, filepath.Join(".autospec", "context"), targets[0].Path)
+	assert.Equal(t, filepath.Join(".autospec", "tmp"), targets[1].Path)
+}
+
+func TestFindCommandFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	origWd, _ := os.Getwd()
+	defer os.Chdir(origWd)
+	require.NoError(t, os.Chdir(tmpDir))
+
+	require.NoError(t, os.MkdirAll(".claude/commands", 0755))
+	require.NoError(t, os.WriteFile(".claude/commands/autospec.plan.md", []byte("plan"), 0644))
+	require.NoError(t, os.WriteFile(".claude/commands/other.md", []byte("other"), 0644))
+
+	targets, err := FindCommandFiles()
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	assert.Equal(t, filepath.Join(".claude", "commands", "autospec.plan.md"), targets[0].Path)
+	assert.Equal(t, TypeFile, targets[0].Type)
+}
+
+func TestFindGlobalFiles(t *testing.T) {
+	home := t.TempDir()
+	assert.Empty(t, FindGlobalFiles(home))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".autospec", "state"), 0755))
+	targets := FindGlobalFiles(home)
+	require.Len(t, targets, 1)
+	assert.Equal(t, filepath.Join(home, ".autospec"), targets[0].Path)
+	assert.Equal(t, TypeDirectory, targets[0].Type)
+}
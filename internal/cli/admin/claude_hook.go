@@ -0,0 +1,165 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+// artifactStages maps spec artifact filenames to the workflow stage that produces them.
+// Retry state is keyed by stage name, so a valid hand-edited artifact resets that stage.
+var artifactStages = map[string]string{
+	"spec.yaml":  "specify",
+	"plan.yaml":  "plan",
+	"tasks.yaml": "tasks",
+}
+
+var claudeHookCmd = &cobra.Command{
+	Use:   "claude-hook <event>",
+	Short: "Handle a Claude Code hook event (used by 'autospec init --claude-plugin')",
+	Long: `Handle a Claude Code hook event read as JSON from stdin.
+
+Events:
+  post-edit  After Claude writes a file: validates spec.yaml, plan.yaml and
+             tasks.yaml in the specs directory. Invalid artifacts are reported
+             back to Claude; valid ones reset the stage's retry state.
+
+This command is installed into .claude/settings.local.json by
+'autospec init --claude-plugin' and is not meant to be run by hand.`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"post-edit"},
+	Hidden:    true,
+	RunE:      runClaudeHook,
+}
+
+func init() {
+	claudeHookCmd.GroupID = shared.GroupInternal
+}
+
+// hookInput is the subset of the Claude Code hook payload used by autospec.
+type hookInput struct {
+	Cwd       string `json:"cwd"`
+	ToolInput struct {
+		FilePath string `json:"file_path"`
+	} `json:"tool_input"`
+}
+
+// hookOutput is the JSON decision returned to Claude Code.
+// A "block" decision after a tool call feeds Reason back to Claude.
+type hookOutput struct {
+	Decision string `json:"decision,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+func runClaudeHook(cmd *cobra.Command, args []string) error {
+	if args[0] != "post-edit" {
+		return fmt.Errorf("unknown hook event %q (valid: post-edit)", args[0])
+	}
+
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
+	var input hookInput
+	if err := json.NewDecoder(cmd.InOrStdin()).Decode(&input); err != nil && err != io.EOF {
+		return fmt.Errorf("decoding hook input: %w", err)
+	}
+
+	out, err := handlePostEdit(input, cfg.SpecsDir, cfg.StateDir)
+	if err != nil {
+		return err
+	}
+	return writeHookOutput(cmd.OutOrStdout(), out)
+}
+
+// handlePostEdit validates an edited spec artifact and syncs its retry state.
+// Returns nil output when the file is not a spec artifact or is valid.
+func handlePostEdit(input hookInput, specsDir, stateDir string) (*hookOutput, error) {
+	specName, filename, ok := specArtifactPath(input.Cwd, specsDir, input.ToolInput.FilePath)
+	if !ok {
+		return nil, nil
+	}
+
+	artifactType, err := validation.InferArtifactTypeFromFilename(filename)
+	if err != nil {
+		return nil, nil
+	}
+	validator, err := validation.NewArtifactValidator(artifactType)
+	if err != nil {
+		return nil, fmt.Errorf("creating %s validator: %w", artifactType, err)
+	}
+
+	path := resolveHookPath(input.Cwd, input.ToolInput.FilePath)
+	result := validator.Validate(path)
+	if !result.Valid {
+		return &hookOutput{Decision: "block", Reason: formatHookErrors(path, result)}, nil
+	}
+
+	if err := retry.ResetRetryCount(stateDir, specName, artifactStages[filename]); err != nil {
+		return nil, fmt.Errorf("resetting retry state for %s: %w", specName, err)
+	}
+	return nil, nil
+}
+
+// specArtifactPath reports whether filePath is <specsDir>/<spec>/<artifact>.yaml
+// for one of the stage artifacts, returning the spec name and filename.
+func specArtifactPath(cwd, specsDir, filePath string) (specName, filename string, ok bool) {
+	if filePath == "" {
+		return "", "", false
+	}
+	rel, err := filepath.Rel(resolveHookPath(cwd, specsDir), resolveHookPath(cwd, filePath))
+	if err != nil {
+		return "", "", false
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	if len(parts) != 2 || parts[0] == ".." {
+		return "", "", false
+	}
+	if _, known := artifactStages[parts[1]]; !known {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// resolveHookPath makes path absolute relative to the hook's working directory.
+func resolveHookPath(cwd, path string) string {
+	if filepath.IsAbs(path) || cwd == "" {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(cwd, path)
+}
+
+// formatHookErrors renders validation errors for Claude to fix.
+func formatHookErrors(path string, result *validation.ValidationResult) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "autospec: %s failed validation (%d errors). Fix them and re-run 'autospec artifact %s':\n",
+		path, len(result.Errors), path)
+	for _, e := range result.Errors {
+		sb.WriteString(e.FormatFull())
+	}
+	return sb.String()
+}
+
+// writeHookOutput writes the hook decision as JSON, or nothing when there is none.
+func writeHookOutput(w io.Writer, out *hookOutput) error {
+	if out == nil {
+		return nil
+	}
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		return fmt.Errorf("writing hook output: %w", err)
+	}
+	return nil
+}
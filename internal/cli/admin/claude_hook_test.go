@@ -0,0 +1,151 @@
+// Package admin_test tests the Claude Code hook handler.
+// Related: internal/cli/admin/claude_hook.go
+// Tags: admin, claude, hooks, validation, retry
+
+package admin
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecArtifactPath(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		cwd          string
+		specsDir     string
+		filePath     string
+		wantSpec     string
+		wantFilename string
+		wantOK       bool
+	}{
+		"absolute tasks path": {
+			cwd:          "/repo",
+			specsDir:     "./specs",
+			filePath:     "/repo/specs/001-auth/tasks.yaml",
+			wantSpec:     "001-auth",
+			wantFilename: "tasks.yaml",
+			wantOK:       true,
+		},
+		"relative spec path": {
+			cwd:          "/repo",
+			specsDir:     "specs",
+			filePath:     "specs/002-ui/spec.yaml",
+			wantSpec:     "002-ui",
+			wantFilename: "spec.yaml",
+			wantOK:       true,
+		},
+		"non-artifact file in spec dir": {
+			cwd:      "/repo",
+			specsDir: "specs",
+			filePath: "/repo/specs/001-auth/analysis.yaml",
+		},
+		"file outside specs dir": {
+			cwd:      "/repo",
+			specsDir: "specs",
+			filePath: "/repo/internal/plan.yaml",
+		},
+		"nested too deep": {
+			cwd:      "/repo",
+			specsDir: "specs",
+			filePath: "/repo/specs/001-auth/checklists/plan.yaml",
+		},
+		"empty file path": {
+			cwd:      "/repo",
+			specsDir: "specs",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			spec, filename, ok := specArtifactPath(tt.cwd, tt.specsDir, tt.filePath)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantSpec, spec)
+			assert.Equal(t, tt.wantFilename, filename)
+		})
+	}
+}
+
+func TestHandlePostEdit(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		fixture       string
+		artifact      string
+		wantBlock     bool
+		wantRetryZero bool
+	}{
+		"valid spec resets specify retry state": {
+			fixture:       "valid.yaml",
+			artifact:      "spec.yaml",
+			wantRetryZero: true,
+		},
+		"invalid spec is reported back": {
+			fixture:   "missing_feature.yaml",
+			artifact:  "spec.yaml",
+			wantBlock: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cwd := t.TempDir()
+			stateDir := t.TempDir()
+			specDir := filepath.Join(cwd, "specs", "001-auth")
+			require.NoError(t, os.MkdirAll(specDir, 0755))
+			content, err := os.ReadFile(filepath.Join("..", "..", "validation", "testdata", "spec", tt.fixture))
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(specDir, tt.artifact), content, 0644))
+			_, err = retry.IncrementRetryCount(stateDir, "001-auth", "specify", 3)
+			require.NoError(t, err)
+
+			input := hookInput{Cwd: cwd}
+			input.ToolInput.FilePath = filepath.Join(specDir, tt.artifact)
+			out, err := handlePostEdit(input, "specs", stateDir)
+			require.NoError(t, err)
+
+			if tt.wantBlock {
+				require.NotNil(t, out)
+				assert.Equal(t, "block", out.Decision)
+				assert.Contains(t, out.Reason, "failed validation")
+			} else {
+				assert.Nil(t, out)
+			}
+
+			state, err := retry.LoadRetryState(stateDir, "001-auth", "specify", 3)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRetryZero, state.Count == 0)
+		})
+	}
+}
+
+func TestHandlePostEdit_IgnoresOtherFiles(t *testing.T) {
+	t.Parallel()
+
+	input := hookInput{Cwd: t.TempDir()}
+	input.ToolInput.FilePath = "main.go"
+
+	out, err := handlePostEdit(input, "specs", t.TempDir())
+	require.NoError(t, err)
+	assert.Nil(t, out)
+}
+
+func TestWriteHookOutput(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, writeHookOutput(&buf, nil))
+	assert.Empty(t, buf.String())
+
+	require.NoError(t, writeHookOutput(&buf, &hookOutput{Decision: "block", Reason: "bad"}))
+	assert.JSONEq(t, `{"decision":"block","reason":"bad"}`, buf.String())
+}
@@ -1,5 +1,5 @@
 // Package admin provides administrative CLI commands for autospec.
-// Includes: commands, completion_install, uninstall
+// Includes: commands, completion_install, uninstall, serve-mcp, claude-hook
 package admin
 
 import (
@@ -18,4 +18,6 @@ func Register(rootCmd *cobra.Command) {
 	rootCmd.AddCommand(commandsCmd)
 	rootCmd.AddCommand(completionCmd)
 	rootCmd.AddCommand(uninstallCmd)
+	rootCmd.AddCommand(serveMCPCmd)
+	rootCmd.AddCommand(claudeHookCmd)
 }
@@ -35,6 +35,7 @@ func TestRegister(t *testing.T) {
 	// Should have commands, completion, and uninstall
 	assert.True(t, commandNames["commands"], "Should have 'commands' command")
 	assert.True(t, commandNames["uninstall"], "Should have 'uninstall' command")
+	assert.True(t, commandNames["serve-mcp"], "Should have 'serve-mcp' command")
 }
 
 func TestCommandsCmdStructure(t *testing.T) {
@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ariel-frischer/autospec/internal/build"
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/mcp"
+	"github.com/spf13/cobra"
+)
+
+var serveMCPCmd = &cobra.Command{
+	Use:   "serve-mcp",
+	Short: "Run autospec as an MCP server over stdio",
+	Long: `Run autospec as a Model Context Protocol (MCP) server over stdin/stdout.
+
+MCP clients such as Claude Code can then drive autospec natively through tools:
+  Query:     list_specs, status, read_artifact, list_tasks
+  Tasks:     update_task, block_task, unblock_task
+  Workflow:  specify, plan, tasks, implement
+
+Workflow stages and task updates run as autospec subprocesses in the server's
+working directory, so they use the same config and spec detection as the CLI.
+The server exits when the client closes stdin.`,
+	Example: `  # Register with Claude Code (project scope)
+  claude mcp add autospec -- autospec serve-mcp
+
+  # Use a specific config file
+  claude mcp add autospec -- autospec serve-mcp --config .autospec/config.yml`,
+	Args: cobra.NoArgs,
+	RunE: runServeMCP,
+}
+
+func init() {
+	serveMCPCmd.GroupID = shared.GroupInternal
+}
+
+func runServeMCP(cmd *cobra.Command, _ []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating autospec executable: %w", err)
+	}
+
+	runner := &mcp.ExecRunner{Executable: executable}
+	if cmd.Flags().Changed("config") {
+		runner.GlobalArgs = []string{"--config", configPath}
+	}
+
+	server := mcp.NewServer("autospec", build.Version)
+	mcp.RegisterTools(server, mcp.ToolOptions{SpecsDir: cfg.SpecsDir, Runner: runner})
+
+	// stdout carries the protocol; diagnostics must go to stderr
+	if err := server.Serve(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout()); err != nil {
+		return fmt.Errorf("running mcp server: %w", err)
+	}
+	return nil
+}
@@ -53,6 +53,10 @@ func TestCommandAliases(t *testing.T) {
 			commandName:   "analyze",
 			expectedAlias: []string{"az"},
 		},
+		"all command has alias full": {
+			commandName:   "all",
+			expectedAlias: []string{"full"},
+		},
 		"version command has alias v": {
 			commandName:   "version",
 			expectedAlias: []string{"v"},
@@ -2,7 +2,9 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/ariel-frischer/autospec/internal/config"
@@ -15,8 +17,9 @@ import (
 )
 
 var allCmd = &cobra.Command{
-	Use:   "all <feature-description>",
-	Short: "Run complete specify -> plan -> tasks -> implement workflow",
+	Use:     "all <feature-description>",
+	Aliases: []string{"full"},
+	Short:   "Run complete specify -> plan -> tasks -> implement workflow",
 	Long: `Run the complete SpecKit workflow including implementation with automatic validation and retry.
 
 This command will:
@@ -31,13 +34,25 @@ This command will:
 9. Validate all tasks are completed
 
 Each stage is validated and will retry up to max_retries times if validation fails.
-This is equivalent to running 'autospec run -a <feature-description>'.`,
+The pipeline config key changes which stages run and can insert custom command
+steps, e.g. pipeline: [specify, clarify, plan, tasks, analyze, implement].
+This is equivalent to running 'autospec run -a <feature-description>'.
+
+Progress is checkpointed after each stage. If a run dies, re-run it with --resume
+and the same feature description to skip the stages that already completed and
+continue implementation where it left off.
+
+Use --plan to preview the run without executing it: the ordered stages, the
+agent each uses, retries remaining and which artifacts already exist.`,
 	Example: `  # Run complete workflow for a new feature
   autospec all "Add user authentication feature"
 
-  # Resume interrupted implementation
+  # Resume an interrupted run (skips completed stages)
   autospec all "Add user auth" --resume
 
+  # Preview the stages, agents and retries without running anything
+  autospec full "Add user auth" --plan
+
   # Skip preflight checks for faster execution
   autospec all "Add API endpoints" --skip-preflight`,
 	Args: cobra.ExactArgs(1),
@@ -51,6 +66,7 @@ This is equivalent to running 'autospec run -a <feature-description>'.`,
 		maxRetries, _ := cmd.Flags().GetInt("max-retries")
 		resume, _ := cmd.Flags().GetBool("resume")
 		debug, _ := cmd.Flags().GetBool("debug")
+		showPlan, _ := cmd.Flags().GetBool("plan")
 
 		// Load configuration
 		cfg, err := config.Load(configPath)
@@ -60,9 +76,22 @@ This is equivalent to running 'autospec run -a <feature-description>'.`,
 			return cliErr
 		}
 
+		if showPlan {
+			if cmd.Flags().Changed("max-retries") {
+				cfg.MaxRetries = maxRetries
+			}
+			plan, err := workflow.PlanFullWorkflow(cfg, featureDescription, resume)
+			if err != nil {
+				return fmt.Errorf("building execution plan: %w", err)
+			}
+			printFullWorkflowPlan(cmd.OutOrStdout(), plan)
+			return nil
+		}
+
 		// Create notification handler and history logger
 		notifHandler := notify.NewHandler(cfg.Notifications)
-		historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
+		historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+		historyLogger.Shared = cfg.SharedHistory()
 
 		// Show security notice (once per user)
 		shared.ShowSecurityNotice(cmd.OutOrStdout(), cfg)
@@ -87,7 +116,7 @@ This is equivalent to running 'autospec run -a <feature-description>'.`,
 			}
 
 			// Check if constitution exists (required for all workflow stages)
-			constitutionCheck := workflow.CheckConstitutionExists()
+			constitutionCheck := workflow.CheckConstitutionAt(cfg.Constitution)
 			if !constitutionCheck.Exists {
 				fmt.Fprint(os.Stderr, constitutionCheck.ErrorMessage)
 				return fmt.Errorf("constitution required")
@@ -98,10 +127,15 @@ This is equivalent to running 'autospec run -a <feature-description>'.`,
 			orchestrator.Debug = debug
 			orchestrator.Executor.Debug = debug
 			orchestrator.Executor.NotificationHandler = notifHandler
+			orchestrator.Executor.UsageRecorder = historyLogger
 
 			// Apply output style from CLI flag (overrides config)
 			shared.ApplyOutputStyle(cmd, orchestrator)
 
+			// Stream agent output into the live view when --tui is set
+			defer shared.StartLiveOutput(cmd, orchestrator)()
+			defer shared.StartReport(cmd, orchestrator)()
+
 			if debug {
 				fmt.Println("[DEBUG] Debug mode enabled")
 				fmt.Printf("[DEBUG] Config: %+v\n", cfg)
@@ -122,8 +156,69 @@ func init() {
 	rootCmd.AddCommand(allCmd)
 
 	allCmd.Flags().IntP("max-retries", "r", 0, "Override max retry attempts (overrides config when set)")
-	allCmd.Flags().Bool("resume", false, "Resume implementation from where it left off")
+	allCmd.Flags().Bool("resume", false, "Resume from the last checkpointed stage and continue implementation where it left off")
+	allCmd.Flags().Bool("plan", false, "Print the execution plan (stages, agents, retries, artifacts) without running it")
 
 	// Auto-commit flags
 	shared.AddAutoCommitFlags(allCmd)
 }
+
+// printFullWorkflowPlan renders the preview shown by `autospec full --plan`.
+func printFullWorkflowPlan(w io.Writer, plan *workflow.FullWorkflowPlan) {
+	fmt.Fprintln(w, "Execution Plan")
+	fmt.Fprintln(w, "==============")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Feature: %s\n", plan.FeatureDescription)
+	if plan.SpecName != "" {
+		fmt.Fprintf(w, "Spec: %s (resuming)\n", plan.SpecName)
+	} else {
+		fmt.Fprintln(w, "Spec: new (created by specify)")
+	}
+	if plan.ConstitutionPath != "" {
+		fmt.Fprintf(w, "Constitution: %s\n", plan.ConstitutionPath)
+	} else {
+		fmt.Fprintln(w, "Constitution: missing (required, run 'autospec constitution' first)")
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "%-3s %-10s %-24s %-8s %s\n", "#", "STAGE", "AGENT", "RETRIES", "ARTIFACT")
+	for i, stage := range plan.Stages {
+		agent, retries := stage.Agent, fmt.Sprintf("%d/%d", stage.RetriesRemaining, stage.MaxRetries)
+		if stage.Command != "" {
+			agent, retries = "(command)", "-"
+		}
+		fmt.Fprintf(w, "%-3d %-10s %-24s %-8s %s\n", i+1, stage.Name, agent, retries, plannedArtifact(stage, plan.ImplementMethod))
+	}
+	fmt.Fprintln(w)
+
+	if len(plan.NotRun) > 0 {
+		notRun := make([]string, len(plan.NotRun))
+		for i, stage := range plan.NotRun {
+			notRun[i] = string(stage)
+		}
+		fmt.Fprintf(w, "Not in pipeline: %s (add them with the pipeline config key)\n", strings.Join(notRun, ", "))
+	}
+	fmt.Fprintln(w, "No changes made. Remove --plan to execute.")
+}
+
+// plannedArtifact describes a stage's artifact state for the plan table.
+func plannedArtifact(stage workflow.PlannedStage, implementMethod string) string {
+	switch {
+	case stage.Command != "":
+		return stage.Command
+	case stage.Stage == "":
+		return "(custom phase)"
+	case stage.Stage == workflow.StageImplement:
+		return fmt.Sprintf("(code changes, %s)", implementMethod)
+	case stage.Skipped && stage.Artifact == "":
+		return "(skipped)"
+	case stage.Artifact == "":
+		return "-"
+	case stage.Skipped:
+		return stage.Artifact + " (exists, skipped)"
+	case stage.ArtifactExists:
+		return stage.Artifact + " (exists, regenerated)"
+	default:
+		return stage.Artifact
+	}
+}
@@ -4,10 +4,12 @@
 package cli
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/ariel-frischer/autospec/internal/workflow"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -32,7 +34,8 @@ func TestAllCmdFlags(t *testing.T) {
 		usage     string
 	}{
 		"max-retries": {shorthand: "r", usage: "Override max retry attempts"},
-		"resume":      {shorthand: "", usage: "Resume implementation"},
+		"resume":      {shorthand: "", usage: "Resume from the last checkpointed stage"},
+		"plan":        {shorthand: "", usage: "Print the execution plan"},
 	}
 
 	for flagName, flag := range flags {
@@ -181,3 +184,32 @@ func TestResumeFlag(t *testing.T) {
 	require.NotNil(t, f)
 	assert.Equal(t, "false", f.DefValue)
 }
+
+func TestPrintFullWorkflowPlan(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	printFullWorkflowPlan(&buf, &workflow.FullWorkflowPlan{
+		FeatureDescription: "Add user auth",
+		SpecName:           "001-user-auth",
+		ImplementMethod:    "tasks",
+		Stages: []workflow.PlannedStage{
+			{Name: "specify", Stage: workflow.StageSpecify, Agent: "claude", RetriesRemaining: 3, MaxRetries: 3, Artifact: "spec.yaml", ArtifactExists: true, Skipped: true},
+			{Name: "plan", Stage: workflow.StagePlan, Agent: "claude", RetriesRemaining: 1, MaxRetries: 3, Artifact: "plan.yaml", ArtifactExists: true},
+			{Name: "lint-plan", Command: "make lint-plan"},
+			{Name: "tasks", Stage: workflow.StageTasks, Agent: "claude", RetriesRemaining: 3, MaxRetries: 3, Artifact: "tasks.yaml"},
+			{Name: "implement", Stage: workflow.StageImplement, Agent: "opencode (builder)", RetriesRemaining: 3, MaxRetries: 3},
+		},
+		NotRun: []workflow.Stage{workflow.StageClarify, workflow.StageAnalyze},
+	})
+
+	out := buf.String()
+	assert.Contains(t, out, "Spec: 001-user-auth (resuming)")
+	assert.Contains(t, out, "Constitution: missing")
+	assert.Regexp(t, `1\s+specify\s+claude\s+3/3\s+spec.yaml \(exists, skipped\)`, out)
+	assert.Regexp(t, `2\s+plan\s+claude\s+1/3\s+plan.yaml \(exists, regenerated\)`, out)
+	assert.Regexp(t, `3\s+lint-plan\s+\(command\)\s+-\s+make lint-plan\n`, out)
+	assert.Regexp(t, `4\s+tasks\s+claude\s+3/3\s+tasks.yaml\n`, out)
+	assert.Regexp(t, `5\s+implement\s+opencode \(builder\)\s+3/3\s+\(code changes, tasks\)`, out)
+	assert.Contains(t, out, "Not in pipeline: clarify, analyze")
+}
@@ -26,7 +26,11 @@ The analyze command will:
 - Auto-detect the current spec from git branch or most recent spec
 - Perform non-destructive cross-artifact consistency analysis
 - Check quality across spec.yaml, plan.yaml, and tasks.yaml
-- Report findings and recommendations
+- Check plan.yaml and tasks.yaml against the project constitution
+- Write findings and recommendations to analysis.yaml
+
+The command fails if analysis.yaml is missing, invalid, or reports a
+CRITICAL constitution violation.
 
 Prerequisites:
 - spec.yaml must exist (run 'autospec specify' first)
@@ -39,7 +43,10 @@ Prerequisites:
   autospec analyze "Focus on security implications"
 
   # Verify API contracts
-  autospec analyze "Verify API contracts"`,
+  autospec analyze "Verify API contracts"
+
+  # Write findings as SARIF for GitHub code scanning
+  autospec analyze --report sarif=autospec.sarif`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true // Don't show help for execution errors
 		// Get optional prompt from args
@@ -66,7 +73,7 @@ Prerequisites:
 		}
 
 		// Check if constitution exists (required for analyze)
-		constitutionCheck := workflow.CheckConstitutionExists()
+		constitutionCheck := workflow.CheckConstitutionAt(cfg.Constitution)
 		if !constitutionCheck.Exists {
 			fmt.Fprint(os.Stderr, constitutionCheck.ErrorMessage)
 			cmd.SilenceUsage = true
@@ -91,7 +98,8 @@ Prerequisites:
 
 		// Create notification handler and history logger
 		notifHandler := notify.NewHandler(cfg.Notifications)
-		historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
+		historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+		historyLogger.Shared = cfg.SharedHistory()
 		specName := fmt.Sprintf("%s-%s", metadata.Number, metadata.Name)
 
 		// Wrap command execution with lifecycle for timing, notification, and history
@@ -99,10 +107,20 @@ Prerequisites:
 			// Create workflow orchestrator
 			orch := workflow.NewWorkflowOrchestrator(cfg)
 			orch.Executor.NotificationHandler = notifHandler
+			orch.Executor.UsageRecorder = historyLogger
 
 			// Apply output style from CLI flag (overrides config)
 			shared.ApplyOutputStyle(cmd, orch)
 
+			// Let the user review or replace the prompt (--edit-prompt, --prompt-file)
+			if err := shared.ApplyPromptFlags(cmd, orch); err != nil {
+				return err
+			}
+
+			// Stream agent output into the live view when --tui is set
+			defer shared.StartLiveOutput(cmd, orch)()
+			defer shared.StartReport(cmd, orch)()
+
 			// Execute analyze stage
 			if err := orch.ExecuteAnalyze(specName, prompt); err != nil {
 				return fmt.Errorf("analyze stage failed: %w", err)
@@ -117,4 +135,7 @@ func init() {
 	analyzeCmd.GroupID = GroupOptionalStages
 	rootCmd.AddCommand(analyzeCmd)
 	// Note: No --max-retries flag - analyze doesn't produce artifacts that need validation/retry
+
+	// Prompt review flags
+	shared.AddPromptFlags(analyzeCmd)
 }
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/ariel-frischer/autospec/internal/config"
 	"github.com/ariel-frischer/autospec/internal/spec"
 	"github.com/ariel-frischer/autospec/internal/validation"
@@ -17,6 +18,7 @@ import (
 var (
 	artifactSchemaFlag bool
 	artifactFixFlag    bool
+	artifactJSONOutput bool // Set from the global --output flag
 )
 
 var artifactCmd = &cobra.Command{
@@ -71,12 +73,16 @@ Exit Codes:
   autospec artifact constitution --schema
 
   # Auto-fix common issues
-  autospec artifact specs/001-feature/plan.yaml --fix`,
+  autospec artifact specs/001-feature/plan.yaml --fix
+
+  # Machine-readable result (errors with line numbers, summary counts)
+  autospec artifact specs/001-feature/tasks.yaml --output json`,
 	Args:          cobra.RangeArgs(1, 2),
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		configPath, _ := cmd.Flags().GetString("config")
+		artifactJSONOutput = shared.IsJSONOutput(cmd)
 		return runArtifactCommand(args, configPath, cmd.OutOrStdout(), cmd.ErrOrStderr())
 	},
 }
@@ -211,7 +217,9 @@ func runArtifactCommand(args []string, configPath string, out, errOut io.Writer)
 	}
 
 	// Print spec identification for auto-detected paths
-	printSpecIdentification(parsed, out)
+	if !artifactJSONOutput {
+		printSpecIdentification(parsed, out)
+	}
 
 	// Handle --fix flag
 	if artifactFixFlag {
@@ -228,6 +236,10 @@ func runArtifactCommand(args []string, configPath string, out, errOut io.Writer)
 	// Run validation
 	result := validator.Validate(parsed.filePath)
 
+	if artifactJSONOutput {
+		return writeValidationResultJSON(result, parsed.filePath, parsed.artType, out)
+	}
+
 	// Format and display results
 	return formatValidationResult(result, parsed.filePath, parsed.artType, out, errOut)
 }
@@ -352,6 +364,28 @@ func formatValidationResult(result *validation.ValidationResult, filePath string
 	return NewExitError(ExitValidationFailed)
 }
 
+// artifactReport is the --output json form of an artifact validation result.
+type artifactReport struct {
+	File string                  `json:"file"`
+	Type validation.ArtifactType `json:"type"`
+	*validation.ValidationResult
+}
+
+// writeValidationResultJSON writes the validation result as JSON to out.
+// Returns an exit error when the artifact is invalid, matching text mode.
+func writeValidationResultJSON(result *validation.ValidationResult, filePath string, artType validation.ArtifactType, out io.Writer) error {
+	if result.Errors == nil {
+		result.Errors = []*validation.ValidationError{}
+	}
+	if err := shared.WriteJSON(out, artifactReport{File: filePath, Type: artType, ValidationResult: result}); err != nil {
+		return err
+	}
+	if !result.Valid {
+		return NewExitError(ExitValidationFailed)
+	}
+	return nil
+}
+
 // runAutoFix runs the auto-fix operation on an artifact file.
 func runAutoFix(filePath string, artType validation.ArtifactType, out, errOut io.Writer) error {
 	green := color.New(color.FgGreen).SprintFunc()
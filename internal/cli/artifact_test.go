@@ -5,6 +5,7 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -85,6 +86,51 @@ func TestArtifactCommand_InvalidSpec(t *testing.T) {
 	}
 }
 
+func TestArtifactCommand_JSONOutput(t *testing.T) {
+	artifactJSONOutput = true
+	t.Cleanup(func() { artifactJSONOutput = false })
+
+	tests := map[string]struct {
+		file      string
+		wantValid bool
+		wantCode  int
+	}{
+		"valid spec":   {file: "valid.yaml", wantValid: true, wantCode: ExitSuccess},
+		"invalid spec": {file: "missing_feature.yaml", wantCode: ExitValidationFailed},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			testFile := filepath.Join("..", "validation", "testdata", "spec", tt.file)
+			err := runArtifactCommand([]string{"spec", testFile}, "", &stdout, &stderr)
+
+			if code := ExitCode(err); code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d", code, tt.wantCode)
+			}
+
+			var report struct {
+				File   string            `json:"file"`
+				Type   string            `json:"type"`
+				Valid  bool              `json:"valid"`
+				Errors []json.RawMessage `json:"errors"`
+			}
+			if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+				t.Fatalf("stdout is not valid JSON: %v\n%s", err, stdout.String())
+			}
+			if report.Type != "spec" || report.File != testFile {
+				t.Errorf("report identifies %s %q, want spec %q", report.Type, report.File, testFile)
+			}
+			if report.Valid != tt.wantValid {
+				t.Errorf("valid = %v, want %v", report.Valid, tt.wantValid)
+			}
+			if tt.wantValid != (len(report.Errors) == 0) {
+				t.Errorf("errors = %d, want errors only when invalid", len(report.Errors))
+			}
+		})
+	}
+}
+
 func TestArtifactCommand_ValidPlan(t *testing.T) {
 	var stdout, stderr bytes.Buffer
 	testFile := filepath.Join("..", "validation", "testdata", "plan", "valid.yaml")
@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/batch"
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var batchCmd = &cobra.Command{
+	Use:   "batch",
+	Short: "Run workflows for several specs at once",
+	Long: `Commands for running the workflow across multiple specs.
+
+Available subcommands:
+  run   Run the remaining stages of several specs, sequentially or in parallel`,
+	Example: `  # Run three specs one after another
+  autospec batch run specs/001-auth specs/004-search specs/007-export
+
+  # Run every spec with unfinished tasks, three at a time in worktrees
+  autospec batch run --all-pending --parallel 3`,
+}
+
+var batchRunCmd = &cobra.Command{
+	Use:               "run [spec...]",
+	ValidArgsFunction: shared.CompleteSpecNames,
+	Short:             "Run the remaining stages of several specs",
+	Long: `Run the workflow for several specs and print a summary table.
+
+Each spec runs in its own autospec process with the stages it still needs:
+  no plan.yaml   → plan, tasks, implement
+  no tasks.yaml  → tasks, implement
+  otherwise      → implement (resumed)
+
+Specs are given by directory (specs/001-auth), full name (001-auth), number
+(001) or name (auth). --all-pending selects every spec whose tasks are not
+all completed.
+
+By default specs run one after another in the current tree with their output
+streamed. With --parallel N, up to N specs run at once, each in its own
+"<spec>-implement" git worktree, with output written to a log file in the
+state directory. Worktrees are removed after a clean success and kept for
+review otherwise.
+
+A failing spec does not stop the batch. The command exits non-zero if any
+spec failed.`,
+	Example: `  # Run three specs sequentially
+  autospec batch run specs/001-auth specs/004-search specs/007-export
+
+  # Run all pending specs, up to three at a time in isolated worktrees
+  autospec batch run --all-pending --parallel 3`,
+	RunE: runBatch,
+}
+
+func init() {
+	batchCmd.GroupID = GroupWorkflows
+	batchCmd.AddCommand(batchRunCmd)
+	rootCmd.AddCommand(batchCmd)
+
+	batchRunCmd.Flags().Bool("all-pending", false, "Run every spec whose tasks are not all completed")
+	batchRunCmd.Flags().Int("parallel", 1, "Maximum specs to run at once, each in its own worktree (1 = sequential)")
+	shared.AddAgentFlag(batchRunCmd)
+	shared.AddModelFlag(batchRunCmd)
+}
+
+// runBatch resolves the specs to run, runs them and prints the summary.
+func runBatch(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	configPath, _ := cmd.Flags().GetString("config")
+	allPending, _ := cmd.Flags().GetBool("all-pending")
+	parallel, _ := cmd.Flags().GetInt("parallel")
+
+	if allPending == (len(args) > 0) {
+		cliErr := clierrors.NewArgumentError("specify spec directories or --all-pending, but not both")
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
+	jobs, err := batchJobs(cfg.SpecsDir, args, allPending)
+	if err != nil {
+		return err
+	}
+	if len(jobs) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No pending specs found.")
+		return nil
+	}
+
+	runner, err := newBatchRunner(cmd, cfg, parallel)
+	if err != nil {
+		return err
+	}
+	results := runner.Run(cmd.Context(), jobs)
+	batch.WriteSummary(cmd.OutOrStdout(), results)
+	if batch.Failed(results) > 0 {
+		return shared.NewExitError(shared.ExitValidationFailed)
+	}
+	return nil
+}
+
+// batchJobs resolves spec arguments, or finds all pending specs.
+func batchJobs(specsDir string, args []string, allPending bool) ([]batch.Job, error) {
+	if allPending {
+		return batch.PendingJobs(specsDir)
+	}
+	jobs := make([]batch.Job, 0, len(args))
+	for _, arg := range args {
+		dir, err := resolveBatchSpec(specsDir, arg)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, batch.Job{Spec: filepath.Base(dir), Dir: dir})
+	}
+	return jobs, nil
+}
+
+// resolveBatchSpec accepts a spec directory path or any identifier
+// understood by spec.GetSpecDirectory.
+func resolveBatchSpec(specsDir, arg string) (string, error) {
+	if info, err := os.Stat(arg); err == nil && info.IsDir() {
+		return filepath.Clean(arg), nil
+	}
+	dir, err := spec.GetSpecDirectory(specsDir, filepath.Base(arg))
+	if err != nil {
+		return "", fmt.Errorf("resolving spec %q: %w", arg, err)
+	}
+	return dir, nil
+}
+
+// newBatchRunner builds a runner that re-executes the autospec binary,
+// forwarding --config, --agent and --model to each child run.
+func newBatchRunner(cmd *cobra.Command, cfg *config.Configuration, parallel int) (*batch.Runner, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("locating autospec executable: %w", err)
+	}
+	globalArgs, err := batchGlobalArgs(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	runner := &batch.Runner{
+		Out:      cmd.OutOrStdout(),
+		Parallel: parallel,
+		LogDir:   filepath.Join(cfg.StateDir, "batch"),
+		RunFlags: batchRunFlags(cmd),
+		StateDir: cfg.StateDir,
+		Exec: func(ctx context.Context, dir string, args []string, out io.Writer) error {
+			child := exec.CommandContext(ctx, executable, append(append([]string{}, globalArgs...), args...)...)
+			child.Dir = dir
+			// A child that can't reach the agent fails its item rather than
+			// deferring a second one or waiting on a prompt
+			child.Env = append(os.Environ(), "AUTOSPEC_ON_UNREACHABLE=fail")
+			child.Stdout = out
+			child.Stderr = out
+			return child.Run()
+		},
+	}
+	if parallel > 1 {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("getting working directory: %w", err)
+		}
+		repoRoot, err := worktree.GetRepoRoot(wd)
+		if err != nil {
+			return nil, fmt.Errorf("--parallel needs a git repository: %w", err)
+		}
+		runner.Workspace = newIsolationWorkspace(cfg, repoRoot, cmd.OutOrStdout())
+	}
+	return runner, nil
+}
+
+// newIsolationWorkspace builds the worktree manager used by parallel batches.
+func newIsolationWorkspace(cfg *config.Configuration, repoRoot string, out io.Writer) batch.Workspace {
+	wtConfig := cfg.Worktree
+	if wtConfig == nil {
+		wtConfig = worktree.DefaultConfig()
+	}
+	return worktree.NewManager(wtConfig, cfg.StateDir, repoRoot, worktree.WithStdout(out))
+}
+
+// batchGlobalArgs returns the global flags forwarded to child runs. The config
+// path is made absolute since parallel children run inside worktrees.
+func batchGlobalArgs(cmd *cobra.Command) ([]string, error) {
+	if !cmd.Flags().Changed("config") {
+		return nil, nil
+	}
+	configPath, _ := cmd.Flags().GetString("config")
+	abs, err := filepath.Abs(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolving config path: %w", err)
+	}
+	return []string{"--config", abs}, nil
+}
+
+// batchRunFlags returns the `autospec run` flags forwarded to child runs.
+func batchRunFlags(cmd *cobra.Command) []string {
+	var flags []string
+	if agent, _ := cmd.Flags().GetString("agent"); agent != "" {
+		flags = append(flags, "--agent", agent)
+	}
+	if model, _ := cmd.Flags().GetString(shared.ModelFlagName); model != "" {
+		flags = append(flags, "--"+shared.ModelFlagName, model)
+	}
+	return flags
+}
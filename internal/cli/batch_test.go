@@ -0,0 +1,84 @@
+// Package cli tests the batch command which runs several specs in one go.
+// Related: internal/cli/batch.go, internal/batch/batch.go
+// Tags: cli, batch, workflow, parallel
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchCmdRegistration(t *testing.T) {
+	var run *cobra.Command
+	for _, cmd := range batchCmd.Commands() {
+		if cmd.Name() == "run" {
+			run = cmd
+		}
+	}
+	require.NotNil(t, run, "batch run should be registered")
+
+	for _, flag := range []string{"all-pending", "parallel"} {
+		assert.NotNil(t, run.Flags().Lookup(flag), "batch run should have --%s", flag)
+	}
+}
+
+func TestRunBatch_RequiresSpecsOrAllPending(t *testing.T) {
+	tests := map[string]struct {
+		args       []string
+		allPending bool
+	}{
+		"neither": {},
+		"both":    {args: []string{"001"}, allPending: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("config", "", "")
+			cmd.Flags().Bool("all-pending", tt.allPending, "")
+			cmd.Flags().Int("parallel", 1, "")
+
+			err := runBatch(cmd, tt.args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "--all-pending")
+		})
+	}
+}
+
+func TestBatchJobs(t *testing.T) {
+	specsDir := t.TempDir()
+	for _, name := range []string{"001-auth", "004-search"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(specsDir, name), 0o755))
+	}
+
+	tests := map[string]struct {
+		arg      string
+		wantSpec string
+		wantErr  bool
+	}{
+		"directory path": {arg: filepath.Join(specsDir, "001-auth"), wantSpec: "001-auth"},
+		"spec number":    {arg: "004", wantSpec: "004-search"},
+		"spec name":      {arg: "auth", wantSpec: "001-auth"},
+		"specs/ prefix":  {arg: "specs/004-search", wantSpec: "004-search"},
+		"unknown spec":   {arg: "999", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			jobs, err := batchJobs(specsDir, []string{tt.arg}, false)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, jobs, 1)
+			assert.Equal(t, tt.wantSpec, jobs[0].Spec)
+			assert.Equal(t, filepath.Join(specsDir, tt.wantSpec), jobs[0].Dir)
+		})
+	}
+}
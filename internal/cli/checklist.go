@@ -26,6 +26,9 @@ The checklist command will:
 - Auto-detect the current spec from git branch or most recent spec
 - Generate a custom checklist based on user requirements
 - Create checklist files in the checklists/ directory
+- Validate each checklist against the checklist schema
+
+Use 'autospec checklist status' to see completion per checklist.
 
 Prerequisites:
 - spec.yaml must exist (run 'autospec specify' first)`,
@@ -36,7 +39,10 @@ Prerequisites:
   autospec checklist "Focus on security requirements"
 
   # Include accessibility checks
-  autospec checklist "Include accessibility checks"`,
+  autospec checklist "Include accessibility checks"
+
+  # Show completion of existing checklists
+  autospec checklist status`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true // Don't show help for execution errors
 		// Get optional prompt from args
@@ -69,7 +75,7 @@ Prerequisites:
 		}
 
 		// Check if constitution exists (required for checklist)
-		constitutionCheck := workflow.CheckConstitutionExists()
+		constitutionCheck := workflow.CheckConstitutionAt(cfg.Constitution)
 		if !constitutionCheck.Exists {
 			fmt.Fprint(os.Stderr, constitutionCheck.ErrorMessage)
 			cmd.SilenceUsage = true
@@ -94,7 +100,8 @@ Prerequisites:
 
 		// Create notification handler and history logger
 		notifHandler := notify.NewHandler(cfg.Notifications)
-		historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
+		historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+		historyLogger.Shared = cfg.SharedHistory()
 		specName := fmt.Sprintf("%s-%s", metadata.Number, metadata.Name)
 
 		// Wrap command execution with lifecycle for timing, notification, and history
@@ -102,10 +109,20 @@ Prerequisites:
 			// Create workflow orchestrator
 			orch := workflow.NewWorkflowOrchestrator(cfg)
 			orch.Executor.NotificationHandler = notifHandler
+			orch.Executor.UsageRecorder = historyLogger
 
 			// Apply output style from CLI flag (overrides config)
 			shared.ApplyOutputStyle(cmd, orch)
 
+			// Let the user review or replace the prompt (--edit-prompt, --prompt-file)
+			if err := shared.ApplyPromptFlags(cmd, orch); err != nil {
+				return err
+			}
+
+			// Stream agent output into the live view when --tui is set
+			defer shared.StartLiveOutput(cmd, orch)()
+			defer shared.StartReport(cmd, orch)()
+
 			// Execute checklist stage
 			if err := orch.ExecuteChecklist(specName, prompt); err != nil {
 				return fmt.Errorf("checklist stage failed: %w", err)
@@ -122,4 +139,7 @@ func init() {
 
 	// Command-specific flags
 	checklistCmd.Flags().IntP("max-retries", "r", 0, "Override max retry attempts (overrides config when set)")
+
+	// Prompt review flags
+	shared.AddPromptFlags(checklistCmd)
 }
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+var checklistStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show completion of each checklist for the current feature",
+	Long: `Show how many items of each checklist in the current feature's checklists/
+directory have passed, failed, or are still pending, with the share of passed
+items as the completion percentage.`,
+	Example: `  # Show checklist completion for the current spec
+  autospec checklist status`,
+	Args: cobra.NoArgs,
+	RunE: runChecklistStatus,
+}
+
+func init() {
+	checklistCmd.AddCommand(checklistStatusCmd)
+}
+
+func runChecklistStatus(cmd *cobra.Command, _ []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
+	metadata, err := spec.DetectCurrentSpec(cfg.SpecsDir)
+	if err != nil {
+		return fmt.Errorf("failed to detect spec: %w", err)
+	}
+	PrintSpecInfo(metadata)
+
+	statuses, err := validation.GetChecklistStatuses(metadata.Directory)
+	if err != nil {
+		return fmt.Errorf("loading checklists: %w", err)
+	}
+	if len(statuses) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No checklists found. Run 'autospec checklist' to generate one.")
+		return nil
+	}
+
+	printChecklistStatuses(cmd.OutOrStdout(), statuses)
+	return nil
+}
+
+// printChecklistStatuses prints one line per checklist followed by a total.
+func printChecklistStatuses(w io.Writer, statuses []validation.ChecklistStatus) {
+	var total validation.ChecklistStatus
+	for _, s := range statuses {
+		fmt.Fprintf(w, "  %-20s %3d%%  %d/%d passed, %d failed, %d pending\n",
+			filepath.Base(s.Path), s.Percent(), s.Passed, s.Total, s.Failed, s.Pending)
+		total.Total += s.Total
+		total.Passed += s.Passed
+		total.Failed += s.Failed
+		total.Pending += s.Pending
+	}
+	if len(statuses) > 1 {
+		fmt.Fprintf(w, "  %-20s %3d%%  %d/%d passed, %d failed, %d pending\n",
+			"total", total.Percent(), total.Passed, total.Total, total.Failed, total.Pending)
+	}
+}
@@ -0,0 +1,47 @@
+// Package cli_test tests the checklist status subcommand output.
+// Related: internal/cli/checklist_status.go
+// Tags: cli, checklist, status, completion
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintChecklistStatuses(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		statuses []validation.ChecklistStatus
+		want     string
+	}{
+		"single checklist has no total": {
+			statuses: []validation.ChecklistStatus{
+				{Path: "specs/001-x/checklists/security.yaml", Total: 4, Passed: 3, Pending: 1},
+			},
+			want: "  security.yaml         75%  3/4 passed, 0 failed, 1 pending\n",
+		},
+		"several checklists add a total": {
+			statuses: []validation.ChecklistStatus{
+				{Path: "checklists/api.yaml", Total: 2, Passed: 2},
+				{Path: "checklists/ux.yaml", Total: 2, Failed: 1, Pending: 1},
+			},
+			want: "  api.yaml             100%  2/2 passed, 0 failed, 0 pending\n" +
+				"  ux.yaml                0%  0/2 passed, 1 failed, 1 pending\n" +
+				"  total                 50%  2/4 passed, 1 failed, 1 pending\n",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			printChecklistStatuses(&buf, tt.statuses)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
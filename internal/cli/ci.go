@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/ci"
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/spf13/cobra"
+)
+
+var ciCmd = &cobra.Command{
+	Use:   "ci [spec]",
+	Short: "Run a spec's remaining stages non-interactively for GitHub Actions",
+	Long: `Run the stages a spec still needs, unattended, and report the outcome in
+GitHub Actions' formats:
+  no plan.yaml   → plan, tasks, implement
+  no tasks.yaml  → tasks, implement
+  otherwise      → implement (resumed)
+
+The spec is given by directory, full name, number or name, or detected from
+the current branch. Each stage runs in its own autospec process with
+confirmations skipped, inside a collapsible ::group:: of the job log. A failed
+stage is reported with an ::error annotation and the remaining stages are
+skipped; blocked tasks are reported with ::warning annotations.
+
+When GITHUB_STEP_SUMMARY is set, a markdown job summary is appended to it
+with each stage's result, retries and duration, task progress by phase and
+the blocked tasks.
+
+Exit codes:
+  0  every stage passed
+  1  a stage failed validation, or was interrupted
+  2  a stage exhausted its retry limit
+  3  invalid arguments (e.g. unknown spec)
+  4  missing dependencies
+  5  a stage timed out`,
+	Example: `  # Implement the spec of the PR's branch
+  autospec ci
+
+  # Run a specific spec
+  autospec ci 003-search
+
+  # In a workflow triggered by a PR label
+  - run: autospec ci "${{ github.head_ref }}"`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteSpecNames),
+	SilenceUsage:      true,
+	RunE:              runCI,
+}
+
+func init() {
+	ciCmd.GroupID = GroupWorkflows
+	shared.AddAgentFlag(ciCmd)
+	shared.AddModelFlag(ciCmd)
+	rootCmd.AddCommand(ciCmd)
+}
+
+// runCI resolves the spec, runs its remaining stages and writes the summary.
+func runCI(cmd *cobra.Command, args []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
+	specDir, err := resolveCISpec(cfg.SpecsDir, args)
+	if err != nil {
+		ci.Error(cmd.OutOrStdout(), "autospec ci", err.Error())
+		return shared.NewExitError(shared.ExitInvalidArguments)
+	}
+
+	runner, err := newCIRunner(cmd, cfg.StateDir)
+	if err != nil {
+		return err
+	}
+	report := runner.Run(cmd.Context(), filepath.Base(specDir), specDir)
+
+	if path := os.Getenv("GITHUB_STEP_SUMMARY"); path != "" {
+		if err := ci.AppendSummary(path, report); err != nil {
+			ci.Warning(cmd.OutOrStdout(), "Job summary not written", err.Error())
+		}
+	}
+	if report.ExitCode != 0 {
+		return shared.NewExitError(report.ExitCode)
+	}
+	return nil
+}
+
+// resolveCISpec returns the directory of the spec named in args, or of the
+// spec detected from the current branch.
+func resolveCISpec(specsDir string, args []string) (string, error) {
+	if len(args) > 0 {
+		return resolveBatchSpec(specsDir, args[0])
+	}
+	metadata, err := spec.DetectCurrentSpec(specsDir)
+	if err != nil {
+		return "", fmt.Errorf("detecting spec from branch (pass the spec as an argument): %w", err)
+	}
+	return metadata.Directory, nil
+}
+
+// newCIRunner builds a runner that re-executes the autospec binary, forwarding
+// --config, --agent and --model to each stage.
+func newCIRunner(cmd *cobra.Command, stateDir string) (*ci.Runner, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("locating autospec executable: %w", err)
+	}
+	globalArgs, err := batchGlobalArgs(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return &ci.Runner{
+		Out:      cmd.OutOrStdout(),
+		RunFlags: batchRunFlags(cmd),
+		StateDir: stateDir,
+		Exec: func(ctx context.Context, args []string, out io.Writer) error {
+			child := exec.CommandContext(ctx, executable, append(append([]string{}, globalArgs...), args...)...)
+			// Agent output must not be colored or wait on prompts in a CI log
+			child.Env = append(os.Environ(), "AUTOSPEC_YES=1", "NO_COLOR=1")
+			child.Stdout = out
+			child.Stderr = out
+			return child.Run()
+		},
+	}, nil
+}
@@ -0,0 +1,55 @@
+// Package cli tests the ci command which runs a spec for GitHub Actions.
+// Related: internal/cli/ci.go, internal/ci/ci.go
+// Tags: cli, ci, github-actions
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCICmdRegistration(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "ci" {
+			found = true
+		}
+	}
+	assert.True(t, found, "ci command should be registered")
+}
+
+func TestResolveCISpec(t *testing.T) {
+	specsDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(specsDir, "003-search"), 0o755))
+
+	dir, err := resolveCISpec(specsDir, []string{"003"})
+	require.NoError(t, err)
+	assert.Equal(t, "003-search", filepath.Base(dir))
+
+	_, err = resolveCISpec(specsDir, []string{"999"})
+	assert.Error(t, err)
+}
+
+func TestRunCI_UnknownSpec(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte("specs_dir: "+filepath.Join(dir, "specs")+"\n"), 0o644))
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("config", configPath, "")
+	cmd.Flags().String("agent", "", "")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	err := runCI(cmd, []string{"999-missing"})
+	require.Error(t, err)
+	assert.Equal(t, shared.ExitInvalidArguments, shared.ExitCode(err))
+	assert.Contains(t, out.String(), "::error title=autospec ci::")
+}
@@ -64,7 +64,7 @@ Prerequisites:
 		}
 
 		// Check if constitution exists (required for clarify)
-		constitutionCheck := workflow.CheckConstitutionExists()
+		constitutionCheck := workflow.CheckConstitutionAt(cfg.Constitution)
 		if !constitutionCheck.Exists {
 			fmt.Fprint(os.Stderr, constitutionCheck.ErrorMessage)
 			cmd.SilenceUsage = true
@@ -89,7 +89,8 @@ Prerequisites:
 
 		// Create notification handler and history logger
 		notifHandler := notify.NewHandler(cfg.Notifications)
-		historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
+		historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+		historyLogger.Shared = cfg.SharedHistory()
 		specName := fmt.Sprintf("%s-%s", metadata.Number, metadata.Name)
 
 		// Wrap command execution with lifecycle for timing, notification, and history
@@ -97,10 +98,20 @@ Prerequisites:
 			// Create workflow orchestrator
 			orch := workflow.NewWorkflowOrchestrator(cfg)
 			orch.Executor.NotificationHandler = notifHandler
+			orch.Executor.UsageRecorder = historyLogger
 
 			// Apply output style from CLI flag (overrides config)
 			shared.ApplyOutputStyle(cmd, orch)
 
+			// Let the user review or replace the prompt (--edit-prompt, --prompt-file)
+			if err := shared.ApplyPromptFlags(cmd, orch); err != nil {
+				return err
+			}
+
+			// Stream agent output into the live view when --tui is set
+			defer shared.StartLiveOutput(cmd, orch)()
+			defer shared.StartReport(cmd, orch)()
+
 			// Execute clarify stage
 			if err := orch.ExecuteClarify(specName, prompt); err != nil {
 				return fmt.Errorf("clarify stage failed: %w", err)
@@ -115,4 +126,7 @@ func init() {
 	clarifyCmd.GroupID = GroupOptionalStages
 	rootCmd.AddCommand(clarifyCmd)
 	// Note: No --max-retries flag - clarify doesn't produce artifacts that need validation/retry
+
+	// Prompt review flags
+	shared.AddPromptFlags(clarifyCmd)
 }
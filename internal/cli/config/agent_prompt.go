@@ -32,11 +32,13 @@ type AgentOption struct {
 
 // agentDisplayNames maps agent names to their human-readable display names.
 var agentDisplayNames = map[string]string{
+	"aider":    "Aider",
 	"claude":   "Claude Code",
 	"cline":    "Cline",
 	"codex":    "Codex CLI",
 	"gemini":   "Gemini CLI",
 	"goose":    "Goose",
+	"ollama":   "Ollama",
 	"opencode": "OpenCode",
 }
 
@@ -14,8 +14,8 @@ func TestGetSupportedAgents(t *testing.T) {
 
 	agents := GetSupportedAgents()
 
-	// Verify we get all 6 registered agents
-	require.Len(t, agents, 6, "expected 6 registered agents")
+	// Verify we get all 8 registered agents
+	require.Len(t, agents, 8, "expected 8 registered agents")
 
 	// Build a map for easier lookup
 	agentMap := make(map[string]AgentOption)
@@ -24,7 +24,7 @@ func TestGetSupportedAgents(t *testing.T) {
 	}
 
 	// Verify all expected agents are present
-	expectedAgents := []string{"claude", "cline", "codex", "gemini", "goose", "opencode"}
+	expectedAgents := []string{"aider", "claude", "cline", "codex", "gemini", "goose", "ollama", "opencode"}
 	for _, name := range expectedAgents {
 		_, ok := agentMap[name]
 		assert.True(t, ok, "expected agent %q to be present", name)
@@ -143,8 +143,8 @@ func TestGetSupportedAgentsWithDefaults(t *testing.T) {
 			wantSelected:  []string{"claude"},
 		},
 		"all agents selected": {
-			defaultAgents: []string{"claude", "cline", "codex", "gemini", "goose", "opencode"},
-			wantSelected:  []string{"claude", "cline", "codex", "gemini", "goose", "opencode"},
+			defaultAgents: []string{"aider", "claude", "cline", "codex", "gemini", "goose", "opencode"},
+			wantSelected:  []string{"aider", "claude", "cline", "codex", "gemini", "goose", "opencode"},
 		},
 	}
 
@@ -351,15 +351,15 @@ func TestPromptAgentSelection(t *testing.T) {
 			wantSelected: []string{"claude"},
 		},
 		"toggle and confirm": {
-			input:        "2\n\n", // Toggle cline (index 2), then confirm
+			input:        "3\n\n", // Toggle cline (index 3), then confirm
 			wantSelected: []string{"claude", "cline"},
 		},
 		"toggle off claude and confirm": {
-			input:        "1\n\n", // Toggle claude off (index 1)
+			input:        "2\n\n", // Toggle claude off (index 2)
 			wantSelected: nil,
 		},
 		"select multiple then confirm": {
-			input:        "3 4\n\n", // Toggle codex and gemini
+			input:        "4 5\n\n", // Toggle codex and gemini
 			wantSelected: []string{"claude", "codex", "gemini"},
 		},
 	}
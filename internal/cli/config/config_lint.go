@@ -0,0 +1,149 @@
+package config
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	cfgpkg "github.com/ariel-frischer/autospec/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configLintCmd = &cobra.Command{
+	Use:   "lint [path]",
+	Short: "Check config files for unknown keys, type mismatches and invalid values",
+	Long: `Check configuration files against the config schema and suggest fixes.
+
+Each file is checked on its own, before merging, for:
+  - YAML/JSON syntax errors
+  - Unknown keys (with "did you mean" suggestions for typos)
+  - Type mismatches (e.g. a quoted number for max_retries)
+  - Invalid enum values (implement_method, output_style, notifications.type, ...)
+  - Unknown agent names in agent_preset and default_agents
+  - custom_agent without a command or without {{PROMPT}} in args
+  - Unknown stage names in post_validate, sub_agent.stages and retry_policy.stages
+  - Hook names other than pre_<stage>/post_<stage>
+
+Profiles are checked like a full config. Without a path, the user and project
+config files are linted (legacy config.json files are used when no YAML exists).
+Exits with a non-zero status when any issue is found.`,
+	Example: `  # Lint user and project config
+  autospec config lint
+
+  # Lint only the project config
+  autospec config lint --project
+
+  # Lint a specific file
+  autospec config lint ./ci/autospec.yml`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigLint,
+}
+
+// configLintResult holds the lint issues for one config file.
+type configLintResult struct {
+	Path   string             `json:"path"`
+	Issues []cfgpkg.LintIssue `json:"issues"`
+}
+
+func init() {
+	configCmd.AddCommand(configLintCmd)
+
+	configLintCmd.Flags().Bool("user", false, "Lint the user-level config only")
+	configLintCmd.Flags().Bool("project", false, "Lint the project-level config only")
+}
+
+func runConfigLint(cmd *cobra.Command, args []string) error {
+	paths, err := lintTargets(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if len(paths) == 0 {
+		fmt.Fprintln(out, "No config files found (run 'autospec init' to create one)")
+		return nil
+	}
+
+	results := make([]configLintResult, 0, len(paths))
+	total := 0
+	for _, path := range paths {
+		issues, err := cfgpkg.LintConfigFile(path)
+		if err != nil {
+			return err
+		}
+		if issues == nil {
+			issues = []cfgpkg.LintIssue{}
+		}
+		results = append(results, configLintResult{Path: path, Issues: issues})
+		total += len(issues)
+	}
+
+	if shared.IsJSONOutput(cmd) {
+		if err := shared.WriteJSON(out, results); err != nil {
+			return err
+		}
+	} else {
+		printLintResults(out, results)
+	}
+
+	if total > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("config lint found %d issue(s)", total)
+	}
+	return nil
+}
+
+// lintTargets returns the config files to lint: the path argument if given,
+// otherwise the existing user and/or project config files.
+func lintTargets(cmd *cobra.Command, args []string) ([]string, error) {
+	if len(args) == 1 {
+		return args, nil
+	}
+
+	useUser, _ := cmd.Flags().GetBool("user")
+	useProject, _ := cmd.Flags().GetBool("project")
+	if useUser && useProject {
+		return nil, fmt.Errorf("--user and --project are mutually exclusive")
+	}
+
+	var paths []string
+	if !useProject {
+		userPath, err := cfgpkg.UserConfigPath()
+		if err != nil {
+			return nil, fmt.Errorf("getting user config path: %w", err)
+		}
+		legacyUserPath, _ := cfgpkg.LegacyUserConfigPath()
+		paths = appendFirstExisting(paths, userPath, legacyUserPath)
+	}
+	if !useUser {
+		paths = appendFirstExisting(paths, cfgpkg.ProjectConfigPath(), cfgpkg.LegacyProjectConfigPath())
+	}
+	return paths, nil
+}
+
+// appendFirstExisting appends the first of candidates that exists, mirroring
+// how config loading prefers YAML over legacy JSON.
+func appendFirstExisting(paths []string, candidates ...string) []string {
+	for _, c := range candidates {
+		if c != "" && fileExistsCheck(c) {
+			return append(paths, c)
+		}
+	}
+	return paths
+}
+
+func printLintResults(out io.Writer, results []configLintResult) {
+	for _, r := range results {
+		fmt.Fprintf(out, "%s:\n", r.Path)
+		if len(r.Issues) == 0 {
+			fmt.Fprintln(out, "  ✓ no issues")
+			continue
+		}
+		for _, issue := range r.Issues {
+			fmt.Fprintf(out, "  ✗ %s\n", issue)
+			if issue.Suggestion != "" {
+				fmt.Fprintf(out, "    → %s\n", issue.Suggestion)
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Package config tests CLI configuration commands for autospec.
+// Related: internal/cli/config/config_lint.go
+// Tags: config, cli, lint
+
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunConfigLint(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		content      string
+		wantErr      bool
+		wantContains []string
+	}{
+		"clean config": {
+			content:      "max_retries: 3\nspecs_dir: ./specs\n",
+			wantContains: []string{"✓ no issues"},
+		},
+		"issues with suggestions": {
+			content: "max_retires: 3\nimplement_method: phase\n",
+			wantErr: true,
+			wantContains: []string{
+				"✗ line 1: max_retires: unknown key",
+				`→ did you mean "max_retries"?`,
+				`✗ line 2: implement_method: invalid value "phase"`,
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "config.yml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o644))
+
+			cmd := &cobra.Command{Use: "lint", RunE: runConfigLint}
+			cmd.Flags().Bool("user", false, "")
+			cmd.Flags().Bool("project", false, "")
+			cmd.SetArgs([]string{path})
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+
+			err := cmd.Execute()
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "config lint found 2 issue(s)")
+			} else {
+				assert.NoError(t, err)
+			}
+			for _, want := range tt.wantContains {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestConfigLintCmd_Registered(t *testing.T) {
+	t.Parallel()
+
+	found := false
+	for _, sub := range configCmd.Commands() {
+		if sub.Name() == "lint" {
+			found = true
+		}
+	}
+	assert.True(t, found, "config should have a lint subcommand")
+}
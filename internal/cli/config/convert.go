@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	cfgpkg "github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Convert artifacts between markdown and YAML",
+	Long: `Commands for converting spec artifacts between markdown and YAML in
+either direction, keeping the content in sync with the other format.`,
+	Example: `  # Convert tasks.md to tasks.yaml for the current spec
+  autospec convert tasks --to yaml
+
+  # List available conversions
+  autospec convert --help`,
+}
+
+var convertTasksCmd = &cobra.Command{
+	Use:   "tasks [spec-name]",
+	Short: "Convert tasks.md to tasks.yaml or back",
+	Long: `Convert a spec's task list between the markdown checkbox format
+(tasks.md) and the structured schema (tasks.yaml).
+
+--to yaml reads tasks.md: "## Phase N: Title" headings become phases and
+checkbox items become tasks, keeping IDs, [P] and [USn] markers and
+completion state ("- [x]" is Completed). Items without an ID are numbered
+after the highest existing one. Indented "Key: value" bullets below a task
+set its Status, Blocked reason, Depends on, Type, File, Acceptance and
+Notes; other indented bullets become acceptance criteria. The result is
+validated like any tasks.yaml.
+
+--to md writes tasks.yaml back in that format, so the two directions round
+trip. The target file is not overwritten unless --force is given.`,
+	Example: `  # Adopt a spec-kit style tasks.md
+  autospec convert tasks --to yaml
+
+  # Render tasks.yaml as a checkbox list for review
+  autospec convert tasks 003-auth --to md
+
+  # Regenerate tasks.yaml after editing tasks.md
+  autospec convert tasks --to yaml --force`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteSpecNames),
+	SilenceUsage:      true,
+	RunE:              runConvertTasks,
+}
+
+func init() {
+	convertCmd.GroupID = shared.GroupInternal
+	convertCmd.AddCommand(convertTasksCmd)
+	convertTasksCmd.Flags().String("to", "", "Target format: yaml or md (required)")
+	convertTasksCmd.Flags().BoolP("force", "f", false, "Overwrite the target file if it exists")
+	_ = convertTasksCmd.MarkFlagRequired("to")
+}
+
+func runConvertTasks(cmd *cobra.Command, args []string) error {
+	to, _ := cmd.Flags().GetString("to")
+	if to != "yaml" && to != "md" {
+		return fmt.Errorf("invalid --to %q: must be yaml or md", to)
+	}
+	force, _ := cmd.Flags().GetBool("force")
+
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := cfgpkg.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
+	var metadata *spec.Metadata
+	if len(args) > 0 {
+		metadata, err = spec.GetSpecMetadata(cfg.SpecsDir, args[0])
+	} else {
+		metadata, err = spec.DetectCurrentSpec(cfg.SpecsDir)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find spec: %w", err)
+	}
+
+	return convertTasks(cmd, metadata.Directory, filepath.Base(metadata.Directory), to, force)
+}
+
+// convertTasks converts specDir's tasks.md to tasks.yaml (to == "yaml") or
+// the reverse, refusing to replace an existing target unless force is set.
+func convertTasks(cmd *cobra.Command, specDir, branch, to string, force bool) error {
+	srcPath := filepath.Join(specDir, "tasks.md")
+	dstPath := filepath.Join(specDir, "tasks.yaml")
+	if to == "md" {
+		srcPath, dstPath = dstPath, srcPath
+	}
+
+	src, err := os.ReadFile(srcPath)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", srcPath, err)
+	}
+	if _, err := os.Stat(dstPath); err == nil && !force {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", dstPath)
+	}
+
+	var out []byte
+	if to == "yaml" {
+		out, err = validation.TasksMarkdownToYAML(src, branch)
+	} else {
+		out, err = validation.TasksYAMLToMarkdown(src)
+	}
+	if err != nil {
+		return fmt.Errorf("converting %s: %w", srcPath, err)
+	}
+	if err := atomicfile.WriteFile(dstPath, out, 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", dstPath, err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Converted %s → %s\n", srcPath, dstPath)
+
+	if to == "yaml" {
+		result := (&validation.TasksValidator{}).Validate(dstPath)
+		for _, e := range result.Errors {
+			fmt.Fprintf(cmd.ErrOrStderr(), "  ⚠ %s\n", e)
+		}
+		if !result.Valid {
+			return fmt.Errorf("converted %s has validation errors; fix %s and convert again with --force", dstPath, srcPath)
+		}
+	}
+	return nil
+}
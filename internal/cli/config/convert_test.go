@@ -0,0 +1,88 @@
+// Package config tests CLI configuration commands for autospec.
+// Related: internal/cli/config/convert.go
+// Tags: config, cli, convert, tasks
+
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertCmd_Structure(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "convert", convertCmd.Use)
+	assert.Equal(t, "internal", convertCmd.GroupID)
+	require.NotNil(t, convertTasksCmd.Flags().Lookup("to"))
+	require.NotNil(t, convertTasksCmd.Flags().Lookup("force"))
+	assert.Contains(t, convertCmd.Commands(), convertTasksCmd)
+}
+
+func TestConvertTasks(t *testing.T) {
+	t.Parallel()
+
+	const tasksMD = "## Phase 1: Setup\n\n- [x] T001 Create module\n- [ ] T002 [P] Add parser\n"
+
+	tests := map[string]struct {
+		to         string
+		force      bool
+		existing   string
+		wantErr    string
+		wantTarget string
+	}{
+		"md to yaml": {
+			to:         "yaml",
+			wantTarget: "tasks.yaml",
+		},
+		"existing target kept without force": {
+			to:       "yaml",
+			existing: "tasks.yaml",
+			wantErr:  "already exists",
+		},
+		"existing target replaced with force": {
+			to:         "yaml",
+			force:      true,
+			existing:   "tasks.yaml",
+			wantTarget: "tasks.yaml",
+		},
+		"missing source": {
+			to:      "md",
+			wantErr: "reading",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			specDir := filepath.Join(t.TempDir(), "003-auth")
+			require.NoError(t, os.MkdirAll(specDir, 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(specDir, "tasks.md"), []byte(tasksMD), 0o644))
+			if tt.existing != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(specDir, tt.existing), []byte("old"), 0o644))
+			}
+
+			cmd := &cobra.Command{}
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			err := convertTasks(cmd, specDir, "003-auth", tt.to, tt.force)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), "✓ Converted")
+			data, err := os.ReadFile(filepath.Join(specDir, tt.wantTarget))
+			require.NoError(t, err)
+			assert.Contains(t, string(data), "branch: 003-auth")
+			assert.Contains(t, string(data), "id: T002")
+		})
+	}
+}
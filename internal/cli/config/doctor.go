@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	cfgpkg "github.com/ariel-frischer/autospec/internal/config"
 	"github.com/ariel-frischer/autospec/internal/health"
 	"github.com/spf13/cobra"
 )
@@ -20,19 +21,55 @@ This command checks for:
   - Git
   - Claude settings (Bash(autospec:*) permission in .claude/settings.local.json)
 
-Each check will display a checkmark if passed or an X with an error message if failed.`,
+Projects can add their own checks under doctor_checks in the config, each a
+shell command with the exit code, output pattern or minimum version it must
+produce. Checks with severity: warn are reported with a warning sign and don't
+fail doctor.
+
+Each check will display a checkmark if passed or an X with an error message if failed.
+
+With --fix, doctor first repairs what it can:
+  - Creates a missing .autospec/config.yml with defaults
+  - Reinstalls missing or outdated command templates in .claude/commands
+  - Makes scripts in .autospec/scripts executable
+  - Offers to run 'claude login' when the Claude OAuth token has expired`,
 	Example: `  # Check all dependencies
   autospec doctor
 
   # Run before starting a new project
-  autospec doctor && autospec init`,
+  autospec doctor && autospec init
+
+  # Machine-readable report for CI
+  autospec doctor --output json
+
+  # Repair missing config, templates and script permissions
+  autospec doctor --fix`,
 	Run: func(cmd *cobra.Command, args []string) {
+		fix, _ := cmd.Flags().GetBool("fix")
+		jsonOutput := shared.IsJSONOutput(cmd)
+
+		var fixes []health.FixResult
+		if fix {
+			fixes = health.RunFixes(doctorFixOptions(cmd, jsonOutput))
+			if !jsonOutput {
+				fmt.Print(health.FormatFixResults(fixes) + "\n")
+			}
+		}
+
 		// Run all health checks
 		report := health.RunHealthChecks()
+		report.AddChecks(health.RunCustomChecks(doctorChecks(cmd))...)
+		report.Fixes = fixes
 
 		// Format and display the report
-		output := health.FormatReport(report)
-		fmt.Print(output)
+		if jsonOutput {
+			if err := shared.WriteJSON(cmd.OutOrStdout(), report); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		} else {
+			fmt.Print(health.FormatReport(report))
+		}
 
 		// Exit with non-zero status if any checks failed
 		if !report.Passed {
@@ -43,4 +80,29 @@ Each check will display a checkmark if passed or an X with an error message if f
 
 func init() {
 	doctorCmd.GroupID = shared.GroupConfiguration
+	doctorCmd.Flags().Bool("fix", false, "Repair missing config, command templates, script permissions and expired Claude login")
+}
+
+// doctorChecks returns the project's doctor_checks. A config that fails to
+// load is reported on stderr rather than failing the built-in checks.
+func doctorChecks(cmd *cobra.Command) []cfgpkg.DoctorCheck {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := cfgpkg.Load(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: skipping doctor_checks: %v\n", err)
+		return nil
+	}
+	return cfg.DoctorChecks
+}
+
+// doctorFixOptions builds the fix options for the doctor command.
+// The Claude login prompt is only offered on an interactive terminal.
+func doctorFixOptions(cmd *cobra.Command, jsonOutput bool) health.FixOptions {
+	opts := health.FixOptions{}
+	if !jsonOutput && isTerminal() {
+		opts.ConfirmLogin = func() bool {
+			return promptYesNo(cmd, "Claude OAuth token has expired. Run 'claude login' now?")
+		}
+	}
+	return opts
 }
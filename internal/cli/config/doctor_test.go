@@ -85,3 +85,11 @@ func TestDoctorCmd_IsExecutable(t *testing.T) {
 	assert.True(t, doctorCmd.Run != nil || doctorCmd.RunE != nil,
 		"Doctor command should have a Run or RunE function")
 }
+
+func TestDoctorCmd_FixFlag(t *testing.T) {
+
+	flag := doctorCmd.Flags().Lookup("fix")
+	if assert.NotNil(t, flag, "doctor should have a --fix flag") {
+		assert.Equal(t, "false", flag.DefValue)
+	}
+}
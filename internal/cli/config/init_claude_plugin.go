@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/claude"
+	"github.com/ariel-frischer/autospec/internal/commands"
+	"github.com/ariel-frischer/autospec/internal/config"
+)
+
+// claudePluginHook is a Claude Code hook installed by --claude-plugin.
+type claudePluginHook struct {
+	event   string
+	matcher string
+	command string
+}
+
+// claudePluginHooks keep autospec state in sync while Claude works interactively:
+// session start loads progress into context, and file edits to spec artifacts
+// are validated and reset the stage's retry state.
+var claudePluginHooks = []claudePluginHook{
+	{event: claude.HookSessionStart, command: "autospec status"},
+	{event: claude.HookPostToolUse, matcher: "Write|Edit|MultiEdit", command: "autospec claude-hook post-edit"},
+}
+
+// installClaudePlugin installs the plugin slash commands, the CLAUDE.md section,
+// and the Claude Code hooks into the current project.
+func installClaudePlugin(out io.Writer, configPath string) error {
+	specsDir := "specs"
+	if cfg, err := config.Load(configPath); err == nil && cfg.SpecsDir != "" {
+		specsDir = filepath.Clean(cfg.SpecsDir)
+	}
+
+	cmdDir := commands.GetDefaultCommandsDir()
+	results, err := commands.InstallPluginTemplates(cmdDir)
+	if err != nil {
+		return fmt.Errorf("installing plugin commands: %w", err)
+	}
+	installed, updated := countResults(results)
+	fmt.Fprintf(out, "%s %s: %d installed, %d updated → %s/\n",
+		cGreen("✓"), cBold("Plugin commands"), installed, updated, cDim(cmdDir))
+
+	action, err := commands.UpsertClaudeMD("CLAUDE.md", specsDir)
+	if err != nil {
+		return fmt.Errorf("updating CLAUDE.md: %w", err)
+	}
+	fmt.Fprintf(out, "%s %s: autospec section %s\n", cGreen("✓"), cBold("CLAUDE.md"), claudeMDActionText(action))
+
+	if err := installClaudePluginHooks(out, "."); err != nil {
+		return fmt.Errorf("installing claude hooks: %w", err)
+	}
+	return nil
+}
+
+// installClaudePluginHooks merges the plugin hooks into .claude/settings.local.json,
+// preserving any hooks the user already has.
+func installClaudePluginHooks(out io.Writer, projectDir string) error {
+	settings, err := claude.Load(projectDir)
+	if err != nil {
+		return fmt.Errorf("loading claude settings: %w", err)
+	}
+
+	added := 0
+	for _, h := range claudePluginHooks {
+		if settings.AddHook(h.event, h.matcher, h.command) {
+			added++
+		}
+	}
+	if added == 0 {
+		fmt.Fprintf(out, "%s %s: up to date\n", cGreen("✓"), cBold("Claude hooks"))
+		return nil
+	}
+
+	if err := settings.Save(); err != nil {
+		return fmt.Errorf("saving claude settings: %w", err)
+	}
+	fmt.Fprintf(out, "%s %s: %d added → %s\n", cGreen("✓"), cBold("Claude hooks"), added, cDim(settings.FilePath()))
+	return nil
+}
+
+// claudeMDActionText describes an UpsertClaudeMD action for display.
+func claudeMDActionText(action string) string {
+	switch action {
+	case "installed":
+		return "added"
+	case "updated":
+		return "updated"
+	default:
+		return "up to date"
+	}
+}
@@ -0,0 +1,74 @@
+// Package config tests the Claude plugin installation performed by init.
+// Related: internal/cli/config/init_claude_plugin.go
+// Tags: config, cli, init, claude, plugin, hooks
+
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/claude"
+	"github.com/ariel-frischer/autospec/internal/commands"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallClaudePlugin(t *testing.T) {
+	// Cannot run in parallel due to working directory change
+	tmpDir := t.TempDir()
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() {
+		_ = os.Chdir(origDir)
+	}()
+
+	require.NoError(t, os.WriteFile("CLAUDE.md", []byte("# My Project\n"), 0644))
+	configPath := filepath.Join(tmpDir, "config.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte("specs_dir: ./features\n"), 0644))
+
+	var buf bytes.Buffer
+	require.NoError(t, installClaudePlugin(&buf, configPath))
+
+	assert.FileExists(t, filepath.Join(".claude", "commands", "autospec.next.md"))
+	content, err := os.ReadFile("CLAUDE.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# My Project\n")
+	assert.Contains(t, string(content), commands.ClaudeMDBeginMarker)
+	assert.Contains(t, string(content), "`features/<NNN-feature>/`")
+
+	settings, err := claude.Load(".")
+	require.NoError(t, err)
+	for _, h := range claudePluginHooks {
+		assert.True(t, settings.HasHook(h.event, h.command), "missing hook %s", h.command)
+	}
+	assert.Contains(t, buf.String(), "Claude hooks")
+
+	// Re-running is idempotent
+	buf.Reset()
+	require.NoError(t, installClaudePlugin(&buf, configPath))
+	assert.Contains(t, buf.String(), "CLAUDE.md")
+	assert.Contains(t, buf.String(), "up to date")
+}
+
+func TestInstallClaudePluginHooks_PreservesUserHooks(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	settingsDir := filepath.Join(dir, claude.SettingsDir)
+	require.NoError(t, os.MkdirAll(settingsDir, 0755))
+	existing := `{"hooks": {"PostToolUse": [{"matcher": "Bash", "hooks": [{"type": "command", "command": "my-linter"}]}]}}`
+	require.NoError(t, os.WriteFile(filepath.Join(settingsDir, claude.SettingsFileName), []byte(existing), 0644))
+
+	var buf bytes.Buffer
+	require.NoError(t, installClaudePluginHooks(&buf, dir))
+
+	settings, err := claude.Load(dir)
+	require.NoError(t, err)
+	assert.True(t, settings.HasHook(claude.HookPostToolUse, "my-linter"))
+	assert.True(t, settings.HasHook(claude.HookPostToolUse, "autospec claude-hook post-edit"))
+	assert.True(t, settings.HasHook(claude.HookSessionStart, "autospec status"))
+}
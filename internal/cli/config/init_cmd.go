@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/ariel-frischer/autospec/internal/build"
@@ -42,8 +43,28 @@ This command:
   1. Installs command templates to .claude/commands/ (automatic)
   2. Creates user-level configuration at ~/.config/autospec/config.yml
 
+With --target, the command templates are installed for other agent tools
+instead, in each tool's layout (repeat the flag or separate with commas):
+  claude    .claude/commands/*.md (default)
+  codex     $CODEX_HOME/prompts/*.md (default ~/.codex/prompts; Codex only reads user prompts)
+  cursor    .cursor/commands/*.md
+  opencode  .opencode/command/*.md
+  gemini    .gemini/commands/*.toml
+
+With --claude-plugin, it also sets up Claude Code as the main interface:
+  - Extra slash commands (/autospec.status, /autospec.next, /autospec.sync)
+  - An autospec section in CLAUDE.md describing how to update task state
+  - Hooks in .claude/settings.local.json that load progress at session start
+    and validate spec artifacts after every edit
+
 If config already exists, it is left unchanged (use --force to overwrite).
 
+When the project has a go.mod, package.json or pyproject.toml, init detects
+the stack and seeds spec templates in .autospec/templates/ and guidance for
+the constitution session. A new project config (--project) also gets the
+stack's test command and task gate (build/lint) commands. Use --no-scaffold
+to skip this.
+
 By default, creates user-level config which applies to all your projects.
 Use --project to create project-specific config that overrides user settings.
 
@@ -59,7 +80,13 @@ Configuration precedence (highest to lowest):
   autospec init --project
 
   # Overwrite existing config with defaults
-  autospec init --force`,
+  autospec init --force
+
+  # Also install Claude Code plugin commands, CLAUDE.md section, and hooks
+  autospec init --claude-plugin
+
+  # Install the commands for Claude Code and Cursor
+  autospec init --target claude,cursor`,
 	RunE: runInit,
 }
 
@@ -67,6 +94,10 @@ func init() {
 	initCmd.GroupID = shared.GroupGettingStarted
 	initCmd.Flags().BoolP("project", "p", false, "Create project-level config (.autospec/config.yml)")
 	initCmd.Flags().BoolP("force", "f", false, "Overwrite existing config with defaults")
+	initCmd.Flags().Bool("claude-plugin", false, "Install Claude Code plugin commands, CLAUDE.md section, and state-sync hooks")
+	initCmd.Flags().StringSlice("target", []string{commands.DefaultTarget}, "Agent tools to install command templates for: "+strings.Join(commands.TargetNames(), ", "))
+	_ = initCmd.RegisterFlagCompletionFunc("target", cobra.FixedCompletions(commands.TargetNames(), cobra.ShellCompDirectiveNoFileComp))
+	initCmd.Flags().Bool("no-scaffold", false, "Skip project-type detection (spec templates, validation commands, constitution guidance)")
 	// Multi-agent selection only available in dev builds
 	if build.MultiAgentEnabled() {
 		initCmd.Flags().Bool("no-agents", false, "[DEV] Skip agent configuration prompt")
@@ -79,6 +110,11 @@ func init() {
 func runInit(cmd *cobra.Command, args []string) error {
 	project, _ := cmd.Flags().GetBool("project")
 	force, _ := cmd.Flags().GetBool("force")
+	claudePlugin, _ := cmd.Flags().GetBool("claude-plugin")
+	targets, err := commandTargets(cmd)
+	if err != nil {
+		return err
+	}
 	// Only check --no-agents flag if multi-agent is enabled (dev builds)
 	var noAgents bool
 	if build.MultiAgentEnabled() {
@@ -92,16 +128,16 @@ func runInit(cmd *cobra.Command, args []string) error {
 	// ═══════════════════════════════════════════════════════════════════════
 	// Phase 1: Fast setup (immediate file operations)
 	// ═══════════════════════════════════════════════════════════════════════
-	if err := installCommandTemplates(out); err != nil {
-		return fmt.Errorf("installing command templates: %w", err)
+	for _, target := range targets {
+		if err := installCommandTemplates(out, target); err != nil {
+			return fmt.Errorf("installing %s command templates: %w", target.Name, err)
+		}
 	}
 
 	newConfigCreated, err := initializeConfig(out, project, force)
 	if err != nil {
 		return fmt.Errorf("initializing config: %w", err)
 	}
-	_ = newConfigCreated // Used for tracking first-time setup
-
 	// Handle agent selection and configuration
 	if err := handleAgentConfiguration(cmd, out, project, noAgents); err != nil {
 		return fmt.Errorf("configuring agents: %w", err)
@@ -111,6 +147,15 @@ func runInit(cmd *cobra.Command, args []string) error {
 	configPath, _ := getConfigPath(project)
 	handleClaudeAuthDetection(cmd, out, configPath)
 
+	// Seed templates and validation commands for the detected project type
+	handleProjectScaffold(out, detectStack(cmd), configPath, project && newConfigCreated)
+
+	if claudePlugin {
+		if err := installClaudePlugin(out, configPath); err != nil {
+			return fmt.Errorf("installing claude plugin: %w", err)
+		}
+	}
+
 	// Check current state of constitution and worktree script
 	constitutionExists := handleConstitution(out)
 	worktreeScriptPath := filepath.Join(".autospec", "scripts", "setup-worktree.sh")
@@ -612,20 +657,46 @@ func isTerminal() bool {
 	return term.IsTerminal(int(os.Stdin.Fd()))
 }
 
-// installCommandTemplates installs command templates and prints status
-func installCommandTemplates(out io.Writer) error {
-	cmdDir := commands.GetDefaultCommandsDir()
-	cmdResults, err := commands.InstallTemplates(cmdDir)
+// commandTargets returns the --target agent tools, rejecting unknown names.
+func commandTargets(cmd *cobra.Command) ([]commands.Target, error) {
+	names, _ := cmd.Flags().GetStringSlice("target")
+	if len(names) == 0 {
+		names = []string{commands.DefaultTarget}
+	}
+	var targets []commands.Target
+	for _, name := range names {
+		target, err := commands.GetTarget(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		if !slices.ContainsFunc(targets, func(t commands.Target) bool { return t.Name == target.Name }) {
+			targets = append(targets, target)
+		}
+	}
+	return targets, nil
+}
+
+// installCommandTemplates installs command templates for target and prints status
+func installCommandTemplates(out io.Writer, target commands.Target) error {
+	cmdDir, err := target.Dir()
+	if err != nil {
+		return err
+	}
+	cmdResults, err := target.Install()
 	if err != nil {
 		return fmt.Errorf("failed to install commands: %w", err)
 	}
 
+	label := "Commands"
+	if target.Name != commands.DefaultTarget {
+		label = fmt.Sprintf("Commands (%s)", target.Name)
+	}
 	cmdInstalled, cmdUpdated := countResults(cmdResults)
 	if cmdInstalled+cmdUpdated > 0 {
 		fmt.Fprintf(out, "%s %s: %d installed, %d updated → %s/\n",
-			cGreen("✓"), cBold("Commands"), cmdInstalled, cmdUpdated, cDim(cmdDir))
+			cGreen("✓"), cBold(label), cmdInstalled, cmdUpdated, cDim(cmdDir))
 	} else {
-		fmt.Fprintf(out, "%s %s: up to date\n", cGreen("✓"), cBold("Commands"))
+		fmt.Fprintf(out, "%s %s: up to date\n", cGreen("✓"), cBold(label))
 	}
 	return nil
 }
@@ -770,7 +841,8 @@ func runConstitutionFromInitImpl(cmd *cobra.Command, configPath string) bool {
 
 	// Create notification handler and history logger
 	notifHandler := notify.NewHandler(cfg.Notifications)
-	historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
+	historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+	historyLogger.Shared = cfg.SharedHistory()
 
 	fmt.Fprintf(out, "\n")
 
@@ -778,8 +850,13 @@ func runConstitutionFromInitImpl(cmd *cobra.Command, configPath string) bool {
 	err = lifecycle.RunWithHistory(notifHandler, historyLogger, "constitution", "", func() error {
 		orch := workflow.NewWorkflowOrchestrator(cfg)
 		orch.Executor.NotificationHandler = notifHandler
+		orch.Executor.UsageRecorder = historyLogger
 		shared.ApplyOutputStyle(cmd, orch)
-		return orch.ExecuteConstitution("")
+		guidance := ""
+		if stack := detectStack(cmd); stack != nil {
+			guidance = stack.ConstitutionGuidance()
+		}
+		return orch.ExecuteConstitution(guidance)
 	})
 
 	if err != nil {
@@ -814,13 +891,15 @@ func runWorktreeGenScriptFromInitImpl(cmd *cobra.Command, configPath string) boo
 	}
 
 	notifHandler := notify.NewHandler(cfg.Notifications)
-	historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
+	historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+	historyLogger.Shared = cfg.SharedHistory()
 
 	fmt.Fprintf(out, "\n")
 
 	err = lifecycle.RunWithHistory(notifHandler, historyLogger, "worktree-gen-script", "", func() error {
 		orch := workflow.NewWorkflowOrchestrator(cfg)
 		orch.Executor.NotificationHandler = notifHandler
+		orch.Executor.UsageRecorder = historyLogger
 		shared.ApplyOutputStyle(cmd, orch)
 
 		fmt.Fprintf(out, "Generating worktree setup script...\n\n")
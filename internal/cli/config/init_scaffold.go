@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/scaffold"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/spf13/cobra"
+)
+
+// detectStack returns the project's stack, or nil when none is detected or
+// --no-scaffold is set.
+func detectStack(cmd *cobra.Command) *scaffold.Stack {
+	if noScaffold, _ := cmd.Flags().GetBool("no-scaffold"); noScaffold {
+		return nil
+	}
+	return scaffold.Detect(".")
+}
+
+// handleProjectScaffold seeds spec templates and, in a newly created project
+// config, validation commands for the detected stack.
+func handleProjectScaffold(out io.Writer, stack *scaffold.Stack, configPath string, seedConfig bool) {
+	if stack == nil {
+		return
+	}
+	fmt.Fprintf(out, "%s %s: %s %s\n", cGreen("✓"), cBold("Project type"), stack.Label(), cDim("(from "+stack.Marker+")"))
+
+	written, err := stack.WriteTemplates(spec.TemplatesDir())
+	switch {
+	case err != nil:
+		fmt.Fprintf(out, "%s %s: %v\n", cYellow("⚠"), cBold("Spec templates"), err)
+	case len(written) > 0:
+		fmt.Fprintf(out, "%s %s: %s → %s/\n", cGreen("✓"), cBold("Spec templates"), strings.Join(written, ", "), cDim(spec.TemplatesDir()))
+	}
+
+	if stack.TestCommand == "" && len(stack.TaskGate) == 0 {
+		return
+	}
+	commands := validationSummary(stack)
+	if !seedConfig {
+		fmt.Fprintf(out, "  %s suggested validation: %s %s\n", cDim("→"), commands, cDim("(set in .autospec/config.yml)"))
+		return
+	}
+	if err := seedValidationConfig(configPath, stack); err != nil {
+		fmt.Fprintf(out, "%s %s: %v\n", cYellow("⚠"), cBold("Validation"), err)
+		return
+	}
+	fmt.Fprintf(out, "%s %s: %s\n", cGreen("✓"), cBold("Validation"), commands)
+}
+
+// validationSummary lists the stack's test and task gate commands.
+func validationSummary(stack *scaffold.Stack) string {
+	var parts []string
+	if stack.TestCommand != "" {
+		parts = append(parts, "tests: "+stack.TestCommand)
+	}
+	if len(stack.TaskGate) > 0 {
+		parts = append(parts, "task gate: "+strings.Join(stack.TaskGate, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// seedValidationConfig writes the stack's validation commands into the
+// config file at configPath.
+func seedValidationConfig(configPath string, stack *scaffold.Stack) error {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+	updated := seedValidationInConfig(string(content), stack)
+	if err := os.WriteFile(configPath, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	return nil
+}
+
+// seedValidationInConfig sets validation.tests.command and uncomments
+// validation.task_gate in the default config template content with the
+// stack's commands. Content without the template's lines is left unchanged.
+func seedValidationInConfig(content string, stack *scaffold.Stack) string {
+	lines := strings.Split(content, "\n")
+	inValidation := false
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case line == "validation:":
+			inValidation = true
+		case inValidation && line != "" && !strings.HasPrefix(line, " "):
+			inValidation = false
+		case inValidation && stack.TestCommand != "" && strings.HasPrefix(line, `    command: ""`):
+			lines[i] = fmt.Sprintf("    command: %s # Detected from %s", strconv.Quote(stack.TestCommand), stack.Marker)
+		case inValidation && len(stack.TaskGate) > 0 && line == "  # task_gate:":
+			end := i + 1
+			for end < len(lines) && strings.HasPrefix(lines[end], "  #   - ") {
+				end++
+			}
+			gate := []string{"  task_gate:"}
+			for _, command := range stack.TaskGate {
+				gate = append(gate, "    - "+strconv.Quote(command))
+			}
+			lines = append(lines[:i], append(gate, lines[end:]...)...)
+			i += len(gate) - 1
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,73 @@
+// Package config tests project-type scaffolding in autospec init.
+// Related: internal/cli/config/init_scaffold.go, internal/scaffold/scaffold.go
+// Tags: config, cli, init, scaffold, validation
+
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/scaffold"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedValidationInConfig(t *testing.T) {
+	t.Parallel()
+
+	stack := &scaffold.Stack{
+		Language:    "node",
+		Marker:      "package.json",
+		TestCommand: "npm run test",
+		TaskGate:    []string{"npm run lint", "npm run typecheck"},
+	}
+	content := seedValidationInConfig(config.GetDefaultConfigTemplate(), stack)
+	assert.Contains(t, content, `    command: "npm run test" # Detected from package.json`)
+	assert.Contains(t, content, "  task_gate:\n    - \"npm run lint\"\n    - \"npm run typecheck\"\n")
+	assert.NotContains(t, content, "#   - go vet ./...")
+
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+	cfg, err := config.Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "npm run test", cfg.Validation.Tests.Command)
+	assert.Equal(t, []string{"npm run lint", "npm run typecheck"}, cfg.Validation.TaskGate)
+}
+
+func TestSeedValidationInConfig_NoCommands(t *testing.T) {
+	t.Parallel()
+
+	template := config.GetDefaultConfigTemplate()
+	assert.Equal(t, template, seedValidationInConfig(template, &scaffold.Stack{Language: "python"}))
+}
+
+func TestHandleProjectScaffold(t *testing.T) {
+	// Cannot run in parallel - changes working directory
+	tmpDir := t.TempDir()
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	configPath := filepath.Join(".autospec", "config.yml")
+	require.NoError(t, writeDefaultConfig(configPath))
+	require.NoError(t, os.WriteFile("go.mod", []byte("module example.com/svc\n"), 0644))
+
+	var out bytes.Buffer
+	handleProjectScaffold(&out, scaffold.Detect("."), configPath, true)
+
+	output := out.String()
+	assert.Contains(t, output, "Project type")
+	assert.Contains(t, output, "Go")
+	assert.Contains(t, output, "cli-command, http-handler")
+	assert.Contains(t, output, "tests: go test ./...; task gate: go build ./..., go vet ./...")
+	assert.FileExists(t, filepath.Join(".autospec", "templates", "http-handler.yaml"))
+
+	cfg, err := config.Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "go test ./...", cfg.Validation.Tests.Command)
+}
@@ -134,6 +134,53 @@ func TestCountResults(t *testing.T) {
 	}
 }
 
+func TestCommandTargets(t *testing.T) {
+
+	tests := map[string]struct {
+		args    []string
+		want    []string
+		wantErr string
+	}{
+		"default is claude": {
+			want: []string{"claude"},
+		},
+		"comma separated": {
+			args: []string{"--target", "claude,cursor"},
+			want: []string{"claude", "cursor"},
+		},
+		"repeated and deduplicated": {
+			args: []string{"--target", "gemini", "--target", "codex", "--target", "gemini"},
+			want: []string{"gemini", "codex"},
+		},
+		"unknown target": {
+			args:    []string{"--target", "vim"},
+			wantErr: `unknown target "vim"`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+
+			cmd := &cobra.Command{Use: "init"}
+			cmd.Flags().StringSlice("target", []string{commands.DefaultTarget}, "")
+			require.NoError(t, cmd.Flags().Parse(tt.args))
+
+			targets, err := commandTargets(cmd)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			var names []string
+			for _, target := range targets {
+				names = append(names, target.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
 func TestPromptYesNo(t *testing.T) {
 
 	tests := map[string]struct {
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+
 	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/spf13/cobra"
 )
@@ -8,14 +10,37 @@ import (
 var migrateCmd = &cobra.Command{
 	Use:   "migrate",
 	Short: "Migrate artifacts between formats",
-	Long:  `Commands for migrating spec artifacts between markdown and YAML formats.`,
+	Long: `Commands for migrating spec artifacts between markdown and YAML formats.
+
+With --from speckit, migrates a project set up with GitHub's spec-kit
+(.specify/, memory/constitution.md, templates and scripts) to autospec.`,
 	Example: `  # Migrate markdown spec to YAML
   autospec migrate md-to-yaml
 
+  # Migrate a spec-kit project (preview first with --dry-run)
+  autospec migrate --from speckit --dry-run
+  autospec migrate --from speckit
+
   # List available migration commands
   autospec migrate --help`,
 }
 
 func init() {
 	migrateCmd.GroupID = shared.GroupInternal
+	migrateCmd.RunE = runMigrate
+	migrateCmd.Flags().String("from", "", "Migrate a project from another tool (speckit)")
+	migrateCmd.Flags().Bool("dry-run", false, "Show what would be migrated without writing files")
+	_ = migrateCmd.RegisterFlagCompletionFunc("from", cobra.FixedCompletions([]string{"speckit"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	from, _ := cmd.Flags().GetString("from")
+	switch from {
+	case "":
+		return cmd.Help()
+	case "speckit", "spec-kit":
+		return runMigrateSpeckit(cmd)
+	default:
+		return fmt.Errorf("unsupported --from %q: must be speckit", from)
+	}
 }
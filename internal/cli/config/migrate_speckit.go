@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"io"
+
+	cfgpkg "github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/speckit"
+	"github.com/spf13/cobra"
+)
+
+// runMigrateSpeckit migrates the spec-kit project in the current directory.
+func runMigrateSpeckit(cmd *cobra.Command) error {
+	out := cmd.OutOrStdout()
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	project, err := speckit.Detect(".")
+	if err != nil {
+		return fmt.Errorf("detecting spec-kit project: %w", err)
+	}
+	if project == nil {
+		return fmt.Errorf("no spec-kit project found: expected .specify/ or memory/constitution.md in the current directory")
+	}
+
+	opts := speckit.Options{SpecsDir: "specs", DryRun: dryRun}
+	configPath, _ := cmd.Flags().GetString("config")
+	if cfg, err := cfgpkg.Load(configPath); err == nil && cfg.SpecsDir != "" {
+		opts.SpecsDir = cfg.SpecsDir
+	}
+
+	actions, err := speckit.Migrate(project, opts)
+	printMigrateActions(out, actions, dryRun)
+	if err != nil {
+		return fmt.Errorf("migrating spec-kit project: %w", err)
+	}
+	return nil
+}
+
+// printMigrateActions prints converted and copied files, then skipped ones
+// with the reason.
+func printMigrateActions(out io.Writer, actions []speckit.Action, dryRun bool) {
+	if dryRun {
+		fmt.Fprintf(out, "%s\n\n", cYellow("Dry run: no files will be written"))
+	}
+
+	var converted, copied, skipped int
+	for _, a := range actions {
+		switch a.Kind {
+		case speckit.ActionConvert:
+			converted++
+			fmt.Fprintf(out, "%s Converted %s → %s\n", cGreen("✓"), a.Source, cDim(a.Dest))
+		case speckit.ActionCopy:
+			copied++
+			fmt.Fprintf(out, "%s Copied %s → %s\n", cGreen("✓"), a.Source, cDim(a.Dest))
+		}
+	}
+	for _, a := range actions {
+		if a.Kind == speckit.ActionSkip {
+			skipped++
+			fmt.Fprintf(out, "%s Skipped %s: %s\n", cYellow("-"), a.Source, a.Note)
+		}
+	}
+
+	fmt.Fprintf(out, "\nDone: %d converted, %d copied, %d skipped\n", converted, copied, skipped)
+	if !dryRun && converted > 0 {
+		fmt.Fprintf(out, "Run 'autospec init' to install autospec's commands, then 'autospec artifact <file>' to check the converted artifacts.\n")
+	}
+}
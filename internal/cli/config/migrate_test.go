@@ -5,9 +5,14 @@
 package config
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMigrateCmd_Structure(t *testing.T) {
@@ -69,3 +74,70 @@ func TestMigrateCmd_HasValidFields(t *testing.T) {
 		})
 	}
 }
+
+// newMigrateTestCmd returns a command with the migrate flags.
+func newMigrateTestCmd(args ...string) (*cobra.Command, *bytes.Buffer) {
+	cmd := &cobra.Command{Use: "migrate", RunE: runMigrate}
+	cmd.Flags().String("from", "", "")
+	cmd.Flags().Bool("dry-run", false, "")
+	cmd.Flags().String("config", "", "")
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(args)
+	return cmd, &buf
+}
+
+func TestRunMigrate_From(t *testing.T) {
+	// Not parallel: changes directory
+
+	tests := map[string]struct {
+		setup      func(t *testing.T)
+		args       []string
+		wantErr    string
+		wantOutput []string
+		wantFile   string
+	}{
+		"unsupported source": {
+			args:    []string{"--from", "openspec"},
+			wantErr: `unsupported --from "openspec"`,
+		},
+		"not a spec-kit project": {
+			args:    []string{"--from", "speckit"},
+			wantErr: "no spec-kit project found",
+		},
+		"migrates constitution": {
+			setup: func(t *testing.T) {
+				require.NoError(t, os.MkdirAll(filepath.Join(".specify", "memory"), 0755))
+				require.NoError(t, os.WriteFile(filepath.Join(".specify", "memory", "constitution.md"),
+					[]byte("# Demo Constitution\n\n## Core Principles\n\n### I. Simplicity\nKeep it simple.\n"), 0644))
+			},
+			args:       []string{"--from", "speckit"},
+			wantOutput: []string{"Converted .specify/memory/constitution.md", "Done: 1 converted, 0 copied, 0 skipped"},
+			wantFile:   filepath.Join(".autospec", "memory", "constitution.yaml"),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			if tt.setup != nil {
+				tt.setup(t)
+			}
+
+			cmd, buf := newMigrateTestCmd(tt.args...)
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			for _, want := range tt.wantOutput {
+				assert.Contains(t, buf.String(), want)
+			}
+			assert.FileExists(t, tt.wantFile)
+		})
+	}
+}
@@ -1,5 +1,5 @@
 // Package config provides CLI commands for autospec configuration management.
-// Includes: init, config, migrate, doctor
+// Includes: init, config, migrate, convert, doctor
 package config
 
 import (
@@ -12,5 +12,6 @@ func Register(rootCmd *cobra.Command) {
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(convertCmd)
 	rootCmd.AddCommand(doctorCmd)
 }
@@ -36,6 +36,7 @@ func TestRegister(t *testing.T) {
 	assert.True(t, commandNames["init"], "Should have 'init' command")
 	assert.True(t, commandNames["config"], "Should have 'config' command")
 	assert.True(t, commandNames["migrate"], "Should have 'migrate' command")
+	assert.True(t, commandNames["convert"], "Should have 'convert' command")
 	assert.True(t, commandNames["doctor"], "Should have 'doctor' command")
 }
 
@@ -186,8 +187,8 @@ func TestRegister_CommandCount(t *testing.T) {
 
 	Register(rootCmd)
 
-	// Should register exactly 4 commands: init, config, migrate, doctor
-	assert.Equal(t, 4, len(rootCmd.Commands()))
+	// Should register exactly 5 commands: init, config, migrate, convert, doctor
+	assert.Equal(t, 5, len(rootCmd.Commands()))
 }
 
 func TestConfigCmd_RunsWithoutArgs(t *testing.T) {
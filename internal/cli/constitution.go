@@ -57,7 +57,8 @@ This command has no prerequisites - it can be run at any time.`,
 
 		// Create notification handler and history logger
 		notifHandler := notify.NewHandler(cfg.Notifications)
-		historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
+		historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+		historyLogger.Shared = cfg.SharedHistory()
 
 		// Wrap command execution with lifecycle for timing, notification, and history
 		// Note: constitution is project-level, no spec name
@@ -75,10 +76,20 @@ This command has no prerequisites - it can be run at any time.`,
 			// Create workflow orchestrator
 			orch := workflow.NewWorkflowOrchestrator(cfg)
 			orch.Executor.NotificationHandler = notifHandler
+			orch.Executor.UsageRecorder = historyLogger
 
 			// Apply output style from CLI flag (overrides config)
 			shared.ApplyOutputStyle(cmd, orch)
 
+			// Let the user review or replace the prompt (--edit-prompt, --prompt-file)
+			if err := shared.ApplyPromptFlags(cmd, orch); err != nil {
+				return err
+			}
+
+			// Stream agent output into the live view when --tui is set
+			defer shared.StartLiveOutput(cmd, orch)()
+			defer shared.StartReport(cmd, orch)()
+
 			// Execute constitution stage
 			if err := orch.ExecuteConstitution(prompt); err != nil {
 				return fmt.Errorf("constitution stage failed: %w", err)
@@ -95,4 +106,7 @@ func init() {
 
 	// Command-specific flags
 	constitutionCmd.Flags().IntP("max-retries", "r", 0, "Override max retry attempts (overrides config when set)")
+
+	// Prompt review flags
+	shared.AddPromptFlags(constitutionCmd)
 }
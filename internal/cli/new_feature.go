@@ -8,9 +8,11 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/ariel-frischer/autospec/internal/cli/util"
-	"github.com/ariel-frischer/autospec/internal/git"
+	"github.com/ariel-frischer/autospec/internal/config"
 	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/vcs"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +20,7 @@ var (
 	newFeatureJSON      bool
 	newFeatureShortName string
 	newFeatureNumber    string
+	newFeatureNamespace string
 )
 
 // NewFeatureOutput is the JSON output structure for the new-feature command
@@ -36,9 +39,12 @@ var newFeatureCmd = &cobra.Command{
 
 This command:
 1. Generates a branch name from the feature description (or uses --short-name)
-2. Determines the next available feature number (or uses --number)
-3. Creates a git branch (if in a git repository)
-4. Creates the feature directory under specs/
+2. Determines the next available feature number (or uses --number), counted
+   separately per namespace when spec_namespace or --namespace is set
+   (e.g. API-004)
+3. Creates a git branch, or a bookmark in a jj repo, named by branch_template
+   (default {number}-{slug}); set skip_branch to create no branch
+4. Creates the feature directory {number}-{slug} under specs/
 
 The command outputs the created branch name, spec file path, and metadata.`,
 	Example: `  # Create a new feature from description
@@ -50,6 +56,9 @@ The command outputs the created branch name, spec file path, and metadata.`,
   # Create with a specific number
   autospec new-feature --number 5 "OAuth2 integration"
 
+  # Number within a team namespace (API-001, API-002, ...)
+  autospec new-feature --namespace API "Rate limiting"
+
   # JSON output for scripting
   autospec new-feature --json "Add dark mode support"`,
 	Args: cobra.ExactArgs(1),
@@ -61,6 +70,7 @@ func init() {
 	newFeatureCmd.Flags().BoolVar(&newFeatureJSON, "json", false, "Output in JSON format")
 	newFeatureCmd.Flags().StringVar(&newFeatureShortName, "short-name", "", "Custom short name for the branch (2-4 words)")
 	newFeatureCmd.Flags().StringVar(&newFeatureNumber, "number", "", "Specify branch number manually (overrides auto-detection)")
+	newFeatureCmd.Flags().StringVar(&newFeatureNamespace, "namespace", "", "Spec number namespace, e.g. API for API-001 (overrides spec_namespace)")
 	rootCmd.AddCommand(newFeatureCmd)
 }
 
@@ -72,31 +82,55 @@ func runNewFeature(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("resolving specs directory: %w", err)
 	}
 
-	hasGit := initGitForNewFeature()
+	cfg := loadNewFeatureConfig(cmd)
+	namespace, err := resolveNamespace(cfg, newFeatureNamespace)
+	if err != nil {
+		return err
+	}
+
+	repo := initVCSForNewFeature()
 
-	branchNumber, err := determineBranchNumber(specsDir)
+	branchNumber, err := determineBranchNumber(specsDir, namespace)
 	if err != nil {
 		return fmt.Errorf("determining branch number: %w", err)
 	}
 
-	branchName := generateBranchName(featureDescription, branchNumber)
+	slug := generateBranchSlug(featureDescription, cfg.BranchMaxWords)
+	specName := spec.TruncateBranchName(spec.FormatBranchName(branchNumber, slug))
+	branchName := specName
+	if cfg.BranchTemplate != spec.DefaultBranchTemplate {
+		branchName = spec.TruncateBranchName(spec.ApplyBranchTemplate(cfg.BranchTemplate, branchNumber, slug))
+	}
 
-	if err := createGitBranch(branchName, hasGit); err != nil {
-		return fmt.Errorf("creating git branch: %w", err)
+	if cfg.SkipBranch {
+		fmt.Fprintf(os.Stderr, "[specify] skip_branch is set; skipped branch creation for %s\n", branchName)
+	} else if err := createFeatureBranch(branchName, repo); err != nil {
+		return fmt.Errorf("creating branch: %w", err)
 	}
 
-	specFile, err := setupFeatureDirectory(specsDir, branchName)
+	specFile, err := setupFeatureDirectory(specsDir, specName)
 	if err != nil {
 		return fmt.Errorf("setting up feature directory: %w", err)
 	}
 
-	return outputNewFeatureResult(branchName, specFile, branchNumber)
+	return outputNewFeatureResult(branchName, specFile, branchNumber, specName)
+}
+
+// loadNewFeatureConfig loads the config for branch naming and numbering, or
+// the defaults if it can't be loaded.
+func loadNewFeatureConfig(cmd *cobra.Command) *config.Configuration {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return &config.Configuration{BranchTemplate: spec.DefaultBranchTemplate}
+	}
+	return cfg
 }
 
 // resolveSpecsDir gets and resolves the specs directory to an absolute path
 func resolveSpecsDir(cmd *cobra.Command) (string, error) {
-	specsDir, err := cmd.Flags().GetString("specs-dir")
-	if err != nil || specsDir == "" {
+	specsDir := shared.ConfiguredSpecsDir(cmd)
+	if specsDir == "" {
 		specsDir = "./specs"
 	}
 
@@ -111,53 +145,60 @@ func resolveSpecsDir(cmd *cobra.Command) (string, error) {
 	return specsDir, nil
 }
 
-// initGitForNewFeature checks for git and fetches remotes
-func initGitForNewFeature() bool {
-	hasGit := git.IsGitRepository()
-	if hasGit {
-		git.FetchAllRemotes() // Ignore errors, just try to get latest
+// initVCSForNewFeature returns the version control backend after fetching
+// its remotes, or nil if the directory isn't under version control
+func initVCSForNewFeature() vcs.VCS {
+	repo := vcs.Current()
+	if !repo.InRepository() {
+		return nil
 	}
-	return hasGit
+	repo.Fetch() // Ignore errors, just try to get latest
+	return repo
+}
+
+// resolveNamespace returns the spec number namespace from flagValue, or
+// else spec_namespace from config.
+func resolveNamespace(cfg *config.Configuration, flagValue string) (string, error) {
+	if flagValue == "" {
+		flagValue = cfg.SpecNamespace
+	}
+	return spec.NormalizeNamespace(flagValue)
 }
 
 // determineBranchNumber determines the branch number from flag or auto-detection
-func determineBranchNumber(specsDir string) (string, error) {
+func determineBranchNumber(specsDir, namespace string) (string, error) {
 	if newFeatureNumber != "" {
 		num, err := strconv.Atoi(newFeatureNumber)
 		if err != nil || num < 0 {
 			return "", fmt.Errorf("invalid --number value: must be a positive integer")
 		}
-		return fmt.Sprintf("%03d", num), nil
+		return spec.FormatSpecNumber(namespace, num), nil
 	}
 
-	branchNumber, err := spec.GetNextBranchNumber(specsDir)
+	branchNumber, err := spec.GetNextBranchNumber(specsDir, namespace)
 	if err != nil {
 		return "", fmt.Errorf("failed to determine next branch number: %w", err)
 	}
 	return branchNumber, nil
 }
 
-// generateBranchName creates the full branch name from description and number
-func generateBranchName(featureDescription, branchNumber string) string {
-	var branchSuffix string
+// generateBranchSlug creates the branch name suffix from --short-name or the
+// description, keeping at most maxWords words of a generated one
+func generateBranchSlug(featureDescription string, maxWords int) string {
 	if newFeatureShortName != "" {
-		branchSuffix = spec.CleanBranchName(newFeatureShortName)
-	} else {
-		branchSuffix = spec.GenerateBranchName(featureDescription)
+		return spec.CleanBranchName(newFeatureShortName)
 	}
-
-	branchName := spec.FormatBranchName(branchNumber, branchSuffix)
-	return spec.TruncateBranchName(branchName)
+	return spec.GenerateBranchNameWords(featureDescription, maxWords)
 }
 
-// createGitBranch creates the git branch if in a git repository
-func createGitBranch(branchName string, hasGit bool) error {
-	if hasGit {
-		if err := git.CreateBranch(branchName); err != nil {
+// createFeatureBranch creates the branch (a bookmark in jj) if under version control
+func createFeatureBranch(branchName string, repo vcs.VCS) error {
+	if repo != nil {
+		if err := repo.CreateBranch(branchName); err != nil {
 			fmt.Fprintf(os.Stderr, "[specify] Warning: %v\n", err)
 		}
 	} else {
-		fmt.Fprintf(os.Stderr, "[specify] Warning: Git repository not detected; skipped branch creation for %s\n", branchName)
+		fmt.Fprintf(os.Stderr, "[specify] Warning: Version control not detected; skipped branch creation for %s\n", branchName)
 	}
 	return nil
 }
@@ -176,7 +217,7 @@ func setupFeatureDirectory(specsDir, branchName string) (string, error) {
 }
 
 // outputNewFeatureResult formats and outputs the result
-func outputNewFeatureResult(branchName, specFile, branchNumber string) error {
+func outputNewFeatureResult(branchName, specFile, branchNumber, specName string) error {
 	output := NewFeatureOutput{
 		BranchName:      branchName,
 		SpecFile:        specFile,
@@ -191,18 +232,18 @@ func outputNewFeatureResult(branchName, specFile, branchNumber string) error {
 			return fmt.Errorf("failed to encode JSON: %w", err)
 		}
 	} else {
-		printNewFeatureText(output, branchName)
+		printNewFeatureText(output, specName)
 	}
 
 	return nil
 }
 
 // printNewFeatureText prints the output in text format
-func printNewFeatureText(output NewFeatureOutput, branchName string) {
+func printNewFeatureText(output NewFeatureOutput, specName string) {
 	fmt.Printf("BRANCH_NAME: %s\n", output.BranchName)
 	fmt.Printf("SPEC_FILE: %s\n", output.SpecFile)
 	fmt.Printf("FEATURE_NUM: %s\n", output.FeatureNum)
 	fmt.Printf("AUTOSPEC_VERSION: %s\n", output.AutospecVersion)
 	fmt.Printf("CREATED_DATE: %s\n", output.CreatedDate)
-	fmt.Printf("SPECIFY_FEATURE environment variable set to: %s\n", branchName)
+	fmt.Printf("SPECIFY_FEATURE environment variable set to: %s\n", specName)
 }
@@ -11,6 +11,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -228,3 +230,59 @@ func TestNewFeatureShortName_Cleaning(t *testing.T) {
 	newFeatureShortName = ""
 	newFeatureNumber = ""
 }
+
+func TestNewFeature_BranchNaming(t *testing.T) {
+	// Not parallel: changes directory and package-level flags
+
+	tests := map[string]struct {
+		env        map[string]string
+		wantBranch string
+		wantDir    string
+	}{
+		"default template": {
+			wantBranch: "001-implement-oauth2-integration",
+			wantDir:    "001-implement-oauth2-integration",
+		},
+		"branch template and word limit": {
+			env:        map[string]string{"AUTOSPEC_BRANCH_TEMPLATE": "feat/{number}-{slug}", "AUTOSPEC_BRANCH_MAX_WORDS": "2"},
+			wantBranch: "feat/001-implement-oauth2",
+			wantDir:    "001-implement-oauth2",
+		},
+		"skip branch": {
+			env:     map[string]string{"AUTOSPEC_SKIP_BRANCH": "true"},
+			wantDir: "001-implement-oauth2-integration",
+		},
+		"no version control": {
+			env:     map[string]string{"AUTOSPEC_VCS": "none"},
+			wantDir: "001-implement-oauth2-integration",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			gi := testutil.NewGitIsolation(t)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			t.Setenv("SPECIFY_FEATURE", "")
+			startBranch := gi.CurrentBranch()
+
+			newFeatureJSON = true
+			t.Cleanup(func() { newFeatureJSON = false })
+			require.NoError(t, runNewFeature(newFeatureCmd, []string{"Implement OAuth2 integration for API access"}))
+
+			specsDir := filepath.Join(gi.TempRepoDir(), "specs")
+			assert.DirExists(t, filepath.Join(specsDir, tt.wantDir))
+			if tt.wantBranch == "" {
+				assert.Equal(t, startBranch, gi.CurrentBranch())
+				return
+			}
+			assert.Equal(t, tt.wantBranch, gi.CurrentBranch())
+
+			meta, err := spec.DetectCurrentSpec(specsDir)
+			require.NoError(t, err)
+			assert.Equal(t, spec.DetectionGitBranch, meta.Detection)
+			assert.Equal(t, filepath.Join(specsDir, tt.wantDir), meta.Directory)
+		})
+	}
+}
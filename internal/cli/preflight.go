@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+var preflightCmd = &cobra.Command{
+	Use:   "preflight",
+	Short: "Check the project is ready for an unattended run",
+	Long: `Run the checks autospec needs to pass before starting a workflow, one by one:
+  git_repo             inside a git repository
+  clean_tree           no uncommitted changes (warns unless --require-clean)
+  project_initialized  .autospec and .claude/commands exist ('autospec init')
+  agent_auth           the agent is installed and has credentials
+  specs_dir_writable   the specs directory, or the directory it will be created in, is writable
+  disk_space           at least --min-free-disk MB free for the specs directory
+
+Each check passes, warns, fails or is skipped. Warnings don't fail preflight.
+With --output json the report is printed as JSON, so scripts can gate
+automation on individual checks.
+
+Exit codes:
+  0  every check passed or warned
+  1  a check failed`,
+	Example: `  # Check before starting work
+  autospec preflight
+
+  # Gate a script on a clean tree and an authenticated agent
+  autospec preflight --require-clean && autospec run -a "Add login"
+
+  # Machine-readable report
+  autospec preflight --output json | jq '.checks[] | select(.status == "fail")'`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runPreflight,
+}
+
+func init() {
+	preflightCmd.GroupID = GroupConfiguration
+	preflightCmd.Flags().Bool("require-clean", false, "Fail if the working tree has uncommitted changes")
+	preflightCmd.Flags().Uint64("min-free-disk", workflow.DefaultMinFreeDiskMB, "Free disk space in MB required for the specs directory (0 = skip)")
+	shared.AddAgentFlag(preflightCmd)
+	rootCmd.AddCommand(preflightCmd)
+}
+
+// runPreflight runs the preflight checks and prints the report.
+func runPreflight(cmd *cobra.Command, args []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+	agent, err := shared.ResolveAgent(cmd, cfg)
+	if err != nil {
+		return err
+	}
+
+	requireClean, _ := cmd.Flags().GetBool("require-clean")
+	minFreeDisk, _ := cmd.Flags().GetUint64("min-free-disk")
+	report := workflow.RunPreflight(workflow.PreflightOptions{
+		SpecsDir:      cfg.SpecsDir,
+		Agent:         agent,
+		RequireClean:  requireClean,
+		MinFreeDiskMB: minFreeDisk,
+	})
+
+	if shared.IsJSONOutput(cmd) {
+		if err := shared.WriteJSON(cmd.OutOrStdout(), report); err != nil {
+			return err
+		}
+	} else {
+		printPreflightReport(cmd.OutOrStdout(), report)
+	}
+	if !report.Passed {
+		return shared.NewExitError(shared.ExitValidationFailed)
+	}
+	return nil
+}
+
+// printPreflightReport writes one line per check.
+func printPreflightReport(w io.Writer, report *workflow.PreflightReport) {
+	marks := map[workflow.PreflightStatus]string{
+		workflow.PreflightPass: "✓",
+		workflow.PreflightWarn: "⚠",
+		workflow.PreflightFail: "✗",
+		workflow.PreflightSkip: "-",
+	}
+	for _, check := range report.Checks {
+		fmt.Fprintf(w, "%s %s: %s\n", marks[check.Status], check.Name, check.Message)
+	}
+	if report.Passed {
+		fmt.Fprintln(w, "\nPreflight passed.")
+	} else {
+		fmt.Fprintln(w, "\nPreflight failed.")
+	}
+}
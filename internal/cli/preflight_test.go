@@ -0,0 +1,61 @@
+// Package cli_test tests the preflight command registration, flags, and report output.
+// Related: internal/cli/preflight.go
+// Tags: cli, preflight, command, json
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreflightCmdRegistration(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Use == "preflight" {
+			found = true
+			assert.Equal(t, GroupConfiguration, cmd.GroupID)
+			break
+		}
+	}
+	assert.True(t, found, "preflight command should be registered")
+}
+
+func TestPreflightCmdFlags(t *testing.T) {
+	tests := map[string]struct {
+		flag     string
+		defValue string
+	}{
+		"require-clean": {flag: "require-clean", defValue: "false"},
+		"min-free-disk": {flag: "min-free-disk", defValue: "500"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := preflightCmd.Flags().Lookup(tt.flag)
+			require.NotNil(t, f, "flag %s should exist", tt.flag)
+			assert.Equal(t, tt.defValue, f.DefValue)
+		})
+	}
+}
+
+func TestPrintPreflightReport(t *testing.T) {
+	var buf bytes.Buffer
+	printPreflightReport(&buf, &workflow.PreflightReport{
+		Passed: false,
+		Checks: []workflow.PreflightCheckResult{
+			{Name: "git_repo", Status: workflow.PreflightPass, Message: "/repo"},
+			{Name: "clean_tree", Status: workflow.PreflightWarn, Message: "2 uncommitted change(s)"},
+			{Name: "agent_auth", Status: workflow.PreflightFail, Message: "not authenticated"},
+		},
+	})
+
+	output := buf.String()
+	assert.Contains(t, output, "✓ git_repo: /repo")
+	assert.Contains(t, output, "⚠ clean_tree: 2 uncommitted change(s)")
+	assert.Contains(t, output, "✗ agent_auth: not authenticated")
+	assert.Contains(t, output, "Preflight failed.")
+}
@@ -57,7 +57,8 @@ This is useful when you want to review the generated artifacts before implementa
 
 		// Create notification handler and history logger
 		notifHandler := notify.NewHandler(cfg.Notifications)
-		historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
+		historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+		historyLogger.Shared = cfg.SharedHistory()
 
 		// Show security notice (once per user)
 		shared.ShowSecurityNotice(cmd.OutOrStdout(), cfg)
@@ -80,6 +81,7 @@ This is useful when you want to review the generated artifacts before implementa
 			if _, err := shared.ApplyAgentOverride(cmd, cfg); err != nil {
 				return err
 			}
+			shared.ApplyModelOverride(cmd, cfg)
 
 			// Apply auto-commit override from flags
 			shared.ApplyAutoCommitOverride(cmd, cfg)
@@ -88,7 +90,7 @@ This is useful when you want to review the generated artifacts before implementa
 			lifecycle.ShowAutoCommitNoticeIfNeeded(cfg.StateDir, cfg.AutoCommitSource)
 
 			// Check if constitution exists (required for all workflow stages)
-			constitutionCheck := workflow.CheckConstitutionExists()
+			constitutionCheck := workflow.CheckConstitutionAt(cfg.Constitution)
 			if !constitutionCheck.Exists {
 				fmt.Fprint(os.Stderr, constitutionCheck.ErrorMessage)
 				return fmt.Errorf("constitution required")
@@ -97,10 +99,15 @@ This is useful when you want to review the generated artifacts before implementa
 			// Create workflow orchestrator
 			orchestrator := workflow.NewWorkflowOrchestrator(cfg)
 			orchestrator.Executor.NotificationHandler = notifHandler
+			orchestrator.Executor.UsageRecorder = historyLogger
 
 			// Apply output style from CLI flag (overrides config)
 			shared.ApplyOutputStyle(cmd, orchestrator)
 
+			// Stream agent output into the live view when --tui is set
+			defer shared.StartLiveOutput(cmd, orchestrator)()
+			defer shared.StartReport(cmd, orchestrator)()
+
 			// Run complete workflow (specify → plan → tasks, no implementation)
 			if err := orchestrator.RunCompleteWorkflow(featureDescription); err != nil {
 				return fmt.Errorf("prep workflow failed: %w", err)
@@ -120,6 +127,7 @@ func init() {
 
 	// Agent override flag
 	shared.AddAgentFlag(prepCmd)
+	shared.AddModelFlag(prepCmd)
 
 	// Auto-commit flags
 	shared.AddAutoCommitFlags(prepCmd)
@@ -7,9 +7,12 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/ariel-frischer/autospec/internal/cli/util"
-	"github.com/ariel-frischer/autospec/internal/git"
+	"github.com/ariel-frischer/autospec/internal/config"
 	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/vcs"
+	"github.com/ariel-frischer/autospec/internal/workflow"
 	"github.com/spf13/cobra"
 )
 
@@ -29,6 +32,7 @@ type PrereqsOutput struct {
 	ImplPlan        string   `json:"IMPL_PLAN"`
 	Tasks           string   `json:"TASKS"`
 	AvailableDocs   []string `json:"AVAILABLE_DOCS"`
+	Constitution    string   `json:"CONSTITUTION,omitempty"`
 	AutospecVersion string   `json:"AUTOSPEC_VERSION"`
 	CreatedDate     string   `json:"CREATED_DATE"`
 }
@@ -70,17 +74,14 @@ func init() {
 }
 
 func runPrereqs(cmd *cobra.Command, args []string) error {
-	// Get specs directory
-	specsDir, err := cmd.Flags().GetString("specs-dir")
-	if err != nil || specsDir == "" {
+	// Get specs directory (the selected package's in a monorepo)
+	specsDir := shared.ConfiguredSpecsDir(cmd)
+	if specsDir == "" {
 		specsDir = "./specs"
 	}
 
-	// Check if we have git
-	hasGit := git.IsGitRepository()
-
 	// Detect current spec
-	specMeta, err := detectCurrentFeature(specsDir, hasGit)
+	specMeta, err := detectCurrentFeature(specsDir, vcs.Current().InRepository())
 	if err != nil && !prereqsPathsOnly {
 		return fmt.Errorf("detecting current feature: %w", err)
 	}
@@ -186,6 +187,7 @@ func runPrereqs(cmd *cobra.Command, args []string) error {
 		ImplPlan:        implPlan,
 		Tasks:           tasks,
 		AvailableDocs:   docs,
+		Constitution:    prereqsConstitution(cmd),
 		AutospecVersion: autospecVersion,
 		CreatedDate:     createdDate,
 	}
@@ -204,12 +206,31 @@ func runPrereqs(cmd *cobra.Command, args []string) error {
 	for _, doc := range docs {
 		fmt.Printf("  ✓ %s\n", doc)
 	}
+	if output.Constitution != "" {
+		fmt.Printf("CONSTITUTION:%s\n", output.Constitution)
+	}
 
 	return nil
 }
 
-// detectCurrentFeature attempts to detect the current feature from environment, git, or spec directories
-func detectCurrentFeature(specsDir string, hasGit bool) (*spec.Metadata, error) {
+// prereqsConstitution returns the project constitution (the selected
+// package's in a monorepo), or "" if there is none.
+func prereqsConstitution(cmd *cobra.Command) string {
+	configPath, _ := cmd.Flags().GetString("config")
+	constitution := ""
+	if cfg, err := config.Load(configPath); err == nil {
+		constitution = cfg.Constitution
+	}
+	if check := workflow.CheckConstitutionAt(constitution); check.Exists {
+		return check.Path
+	}
+	return ""
+}
+
+// detectCurrentFeature attempts to detect the current feature from environment, the current
+// branch (jj bookmark), or spec directories. inRepo reports whether the directory is under
+// version control.
+func detectCurrentFeature(specsDir string, inRepo bool) (*spec.Metadata, error) {
 	// First check SPECIFY_FEATURE environment variable
 	if envFeature := os.Getenv("SPECIFY_FEATURE"); envFeature != "" {
 		// Try to find this feature in specs directory
@@ -223,12 +244,12 @@ func detectCurrentFeature(specsDir string, hasGit bool) (*spec.Metadata, error)
 		}
 	}
 
-	// Try to detect from git branch or specs directory
+	// Try to detect from branch or specs directory
 	meta, err := spec.DetectCurrentSpec(specsDir)
 	if err != nil {
 		// Provide helpful error message
-		if hasGit {
-			branch, _ := git.GetCurrentBranch()
+		if inRepo {
+			branch, _ := vcs.Current().CurrentBranch()
 			if branch != "" {
 				return nil, fmt.Errorf("not on a feature branch. Current branch: %s\nFeature branches should be named like: 001-feature-name", branch)
 			}
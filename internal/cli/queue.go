@@ -0,0 +1,374 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/queue"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// queuePollInterval is how often a waiting worker checks for new items.
+const queuePollInterval = 5 * time.Second
+
+var queueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "Queue spec stages and run them one at a time",
+	Long: `Commands for queueing work across specs.
+
+'queue add' records a spec and the stages to run for it; 'queue work' runs
+queued items in order. Only one worker runs at a time, so two agents never
+edit the same working tree. With --workers N, up to N specs run at once,
+each in its own "<spec>-implement" git worktree.
+
+Stages whose agent couldn't reach its provider are deferred to the queue
+(see on_unreachable) until 'queue retry-pending'.
+
+Available subcommands:
+  add            Queue stages of a spec
+  list           Show queued, running and finished items
+  work           Run queued items
+  retry-pending  Run stages deferred while the agent was unreachable
+  remove         Remove an item
+  clear          Remove finished items`,
+	Example: `  # Queue the plan and tasks stages of one spec and implement of another
+  autospec queue add 003-auth plan tasks
+  autospec queue add 004-search implement
+
+  # Queue whatever stages a spec still needs
+  autospec queue add 005-export
+
+  # Run the queue in the current tree, then stop
+  autospec queue work
+
+  # Keep a worker running that takes new items as they are queued
+  autospec queue work --wait`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return queueListCmd.RunE(cmd, args)
+	},
+}
+
+var queueAddCmd = &cobra.Command{
+	Use:   "add <spec> [stage...]",
+	Short: "Queue stages of a spec",
+	Long: `Queue a spec with the stages to run for it: clarify, plan, tasks,
+checklist, analyze or implement. Stages run in workflow order. Without
+stages, the worker runs the ones the spec still needs when the item starts
+(plan, tasks and implement as for 'autospec batch run').`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeQueueAdd,
+	SilenceUsage:      true,
+	RunE:              runQueueAdd,
+}
+
+var queueListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "Show queued, running and finished items",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runQueueList,
+}
+
+var queueWorkCmd = &cobra.Command{
+	Use:   "work",
+	Short: "Run queued items",
+	Long: `Run pending items in the order they were queued, then exit. Items of
+the same spec never run at the same time.
+
+By default items run one at a time in the current tree with their output
+streamed. With --workers N, up to N items run at once, each in its spec's
+worktree with output written to a log in the state directory, as with
+'autospec batch run --parallel'.
+
+With --wait, the worker keeps running and takes items as they are queued
+until Ctrl+C. An interrupted item goes back to pending. The command exits
+non-zero if any item failed.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runQueueWork,
+}
+
+var queueRetryPendingCmd = &cobra.Command{
+	Use:   "retry-pending",
+	Short: "Run stages deferred while the agent was unreachable",
+	Long: `Check that the agent is available again, make deferred items pending and
+run the queue as 'autospec queue work' does.
+
+Stages are deferred when the agent fails because it can't reach its
+provider or its credentials were rejected, with on_unreachable set to
+"queue" or confirmed at the "ask" prompt. If the agent is still
+unavailable, the items stay deferred.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runQueueRetryPending,
+}
+
+var queueRemoveCmd = &cobra.Command{
+	Use:          "remove <id>",
+	Aliases:      []string{"rm"},
+	Short:        "Remove a queued or finished item",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			cliErr := clierrors.NewArgumentError(fmt.Sprintf("invalid queue item ID %q", args[0]))
+			clierrors.PrintError(cliErr)
+			return cliErr
+		}
+		cfg, err := loadQueueConfig(cmd)
+		if err != nil {
+			return err
+		}
+		if err := queue.Remove(cfg.StateDir, id); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Removed queue item %d.\n", id)
+		return nil
+	},
+}
+
+var queueClearCmd = &cobra.Command{
+	Use:          "clear",
+	Short:        "Remove finished items",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadQueueConfig(cmd)
+		if err != nil {
+			return err
+		}
+		all, _ := cmd.Flags().GetBool("all")
+		removed, err := queue.Clear(cfg.StateDir, all)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Removed %d queue item(s).\n", removed)
+		return nil
+	},
+}
+
+func init() {
+	queueCmd.GroupID = GroupWorkflows
+	queueCmd.AddCommand(queueAddCmd, queueListCmd, queueWorkCmd, queueRetryPendingCmd, queueRemoveCmd, queueClearCmd)
+	rootCmd.AddCommand(queueCmd)
+
+	queueWorkCmd.Flags().Int("workers", 1, "Maximum items to run at once, each in its spec's worktree (1 = sequential in the current tree)")
+	queueWorkCmd.Flags().Bool("wait", false, "Keep running and take new items until interrupted")
+	shared.AddAgentFlag(queueWorkCmd)
+	shared.AddModelFlag(queueWorkCmd)
+	queueRetryPendingCmd.Flags().Int("workers", 1, "Maximum items to run at once, each in its spec's worktree (1 = sequential in the current tree)")
+	shared.AddAgentFlag(queueRetryPendingCmd)
+	shared.AddModelFlag(queueRetryPendingCmd)
+	queueClearCmd.Flags().Bool("all", false, "Also remove pending items")
+}
+
+// loadQueueConfig loads the configuration for the queue commands.
+func loadQueueConfig(cmd *cobra.Command) (*config.Configuration, error) {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return nil, cliErr
+	}
+	return cfg, nil
+}
+
+// runQueueAdd validates the spec and stages and appends an item.
+func runQueueAdd(cmd *cobra.Command, args []string) error {
+	stages, err := queue.ValidateStages(args[1:])
+	if err != nil {
+		cliErr := clierrors.NewArgumentError(err.Error())
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+	cfg, err := loadQueueConfig(cmd)
+	if err != nil {
+		return err
+	}
+	dir, err := resolveBatchSpec(cfg.SpecsDir, args[0])
+	if err != nil {
+		return err
+	}
+
+	item, err := queue.Add(cfg.StateDir, filepath.Base(dir), stages, time.Now())
+	if err != nil {
+		return err
+	}
+	if shared.IsJSONOutput(cmd) {
+		return shared.WriteJSON(cmd.OutOrStdout(), item)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Queued #%d: %s (%s)\n", item.ID, item.Spec, item.StagesLabel())
+	if queue.WorkerPID(cfg.StateDir) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "Run 'autospec queue work' to process the queue.")
+	}
+	return nil
+}
+
+// runQueueList prints the queue.
+func runQueueList(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadQueueConfig(cmd)
+	if err != nil {
+		return err
+	}
+	items, err := queue.List(cfg.StateDir)
+	if err != nil {
+		return err
+	}
+	if shared.IsJSONOutput(cmd) {
+		return shared.WriteJSON(cmd.OutOrStdout(), items)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(items) == 0 {
+		fmt.Fprintln(out, "Queue is empty.")
+		return nil
+	}
+	width := len("SPEC")
+	for _, item := range items {
+		width = max(width, len(item.Spec))
+	}
+	fmt.Fprintf(out, "%4s  %-*s  %-9s  %-28s  %s\n", "ID", width, "SPEC", "STATUS", "STAGES", "DETAILS")
+	for _, item := range items {
+		fmt.Fprintf(out, "%4d  %-*s  %s  %-28s  %s\n", item.ID, width, item.Spec,
+			queueStatusColor(item.Status), item.StagesLabel(), queueItemDetails(item))
+	}
+	if pid := queue.WorkerPID(cfg.StateDir); pid > 0 {
+		fmt.Fprintf(out, "\nWorker running (pid %d).\n", pid)
+	}
+	return nil
+}
+
+// queueStatusColor pads and colors a status for the list table.
+func queueStatusColor(status queue.Status) string {
+	padded := fmt.Sprintf("%-9s", status)
+	switch status {
+	case queue.StatusCompleted:
+		return color.GreenString(padded)
+	case queue.StatusFailed:
+		return color.RedString(padded)
+	case queue.StatusRunning:
+		return color.CyanString(padded)
+	case queue.StatusDeferred:
+		return color.YellowString(padded)
+	default:
+		return padded
+	}
+}
+
+// queueItemDetails describes when an item was queued or how it ended.
+func queueItemDetails(item *queue.Item) string {
+	switch {
+	case item.Status == queue.StatusRunning && item.StartedAt != nil:
+		return fmt.Sprintf("running for %s", time.Since(*item.StartedAt).Round(time.Second))
+	case item.Finished() && item.StartedAt != nil && item.FinishedAt != nil:
+		details := fmt.Sprintf("%s, %d retries", item.FinishedAt.Sub(*item.StartedAt).Round(time.Second), item.Retries)
+		if item.Error != "" {
+			details += ": " + item.Error
+		}
+		if item.LogPath != "" {
+			details += " (log: " + item.LogPath + ")"
+		}
+		return details
+	case item.Status == queue.StatusDeferred:
+		return "deferred " + item.AddedAt.Local().Format("2006-01-02 15:04") + ": " + item.Error
+	default:
+		return "queued " + item.AddedAt.Local().Format("2006-01-02 15:04")
+	}
+}
+
+// runQueueWork runs the queue with a batch runner, isolating specs in
+// worktrees when more than one worker is requested.
+func runQueueWork(cmd *cobra.Command, _ []string) error {
+	workers, _ := cmd.Flags().GetInt("workers")
+	wait, _ := cmd.Flags().GetBool("wait")
+	if workers < 1 {
+		cliErr := clierrors.NewArgumentError("--workers must be at least 1")
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+	cfg, err := loadQueueConfig(cmd)
+	if err != nil {
+		return err
+	}
+	runner, err := newBatchRunner(cmd, cfg, workers)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	worker := &queue.Worker{
+		StateDir:     cfg.StateDir,
+		SpecsDir:     cfg.SpecsDir,
+		Runner:       runner,
+		Out:          cmd.OutOrStdout(),
+		Workers:      workers,
+		Wait:         wait,
+		PollInterval: queuePollInterval,
+	}
+	failed, err := worker.Run(ctx)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "\n%d queue item(s) failed; see 'autospec queue list'\n", failed)
+		return shared.NewExitError(shared.ExitValidationFailed)
+	}
+	return nil
+}
+
+// runQueueRetryPending makes deferred items pending again once the agent is
+// available and runs the queue.
+func runQueueRetryPending(cmd *cobra.Command, args []string) error {
+	cfg, err := loadQueueConfig(cmd)
+	if err != nil {
+		return err
+	}
+	agent, err := shared.ResolveAgent(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	if err := agent.Validate(); err != nil {
+		return fmt.Errorf("agent still unavailable, deferred items left in the queue: %w", err)
+	}
+
+	items, err := queue.RetryPending(cfg.StateDir)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No deferred queue items.")
+		return nil
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Retrying %d deferred queue item(s).\n", len(items))
+	return runQueueWork(cmd, args)
+}
+
+// completeQueueAdd completes the spec, then the stages not yet given.
+func completeQueueAdd(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return shared.CompleteSpecNames(cmd, args, toComplete)
+	}
+	var completions []cobra.Completion
+	for _, stage := range queue.QueueableStages {
+		if strings.HasPrefix(stage, toComplete) && !slices.Contains(args[1:], stage) {
+			completions = append(completions, stage)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
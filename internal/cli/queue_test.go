@@ -0,0 +1,80 @@
+// Package cli tests the queue command which runs queued spec stages.
+// Related: internal/cli/queue.go, internal/queue/queue.go
+// Tags: cli, queue, batch, worker
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/queue"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueCmdRegistration(t *testing.T) {
+	names := make(map[string]*cobra.Command)
+	for _, cmd := range queueCmd.Commands() {
+		names[cmd.Name()] = cmd
+	}
+	for _, name := range []string{"add", "list", "work", "retry-pending", "remove", "clear"} {
+		assert.NotNil(t, names[name], "queue %s should be registered", name)
+	}
+	for _, flag := range []string{"workers", "wait"} {
+		assert.NotNil(t, names["work"].Flags().Lookup(flag), "queue work should have --%s", flag)
+	}
+}
+
+func TestRunQueueAdd_RejectsUnknownStage(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("config", "", "")
+
+	err := runQueueAdd(cmd, []string{"001-auth", "deploy"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `cannot queue stage "deploy"`)
+}
+
+func TestRunQueueWork_RejectsZeroWorkers(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("config", "", "")
+	cmd.Flags().Int("workers", 0, "")
+	cmd.Flags().Bool("wait", false, "")
+
+	err := runQueueWork(cmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--workers must be at least 1")
+}
+
+func TestQueueItemDetails(t *testing.T) {
+	start := time.Date(2026, 3, 4, 10, 0, 0, 0, time.Local)
+	end := start.Add(90 * time.Second)
+
+	tests := map[string]struct {
+		item *queue.Item
+		want string
+	}{
+		"pending": {
+			item: &queue.Item{Status: queue.StatusPending, AddedAt: start},
+			want: "queued 2026-03-04 10:00",
+		},
+		"failed": {
+			item: &queue.Item{Status: queue.StatusFailed, StartedAt: &start, FinishedAt: &end, Retries: 2, Error: "exit status 1", LogPath: "/state/batch/001-auth.log"},
+			want: "1m30s, 2 retries: exit status 1 (log: /state/batch/001-auth.log)",
+		},
+		"completed": {
+			item: &queue.Item{Status: queue.StatusCompleted, StartedAt: &start, FinishedAt: &end},
+			want: "1m30s, 0 retries",
+		},
+		"deferred": {
+			item: &queue.Item{Status: queue.StatusDeferred, AddedAt: start, Error: "agent claude was not authenticated"},
+			want: "deferred 2026-03-04 10:00: agent claude was not authenticated",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, queueItemDetails(tt.item))
+		})
+	}
+}
@@ -9,11 +9,16 @@
 package cli
 
 import (
+	"fmt"
+	"os"
+	"time"
+
 	"github.com/ariel-frischer/autospec/internal/cli/admin"
 	"github.com/ariel-frischer/autospec/internal/cli/config"
 	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/ariel-frischer/autospec/internal/cli/stages"
 	"github.com/ariel-frischer/autospec/internal/cli/util"
+	"github.com/ariel-frischer/autospec/internal/runs"
 	"github.com/spf13/cobra"
 )
 
@@ -54,11 +59,30 @@ Source: https://github.com/ariel-frischer/autospec`,
   autospec plan
   autospec tasks
   autospec implement`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := shared.ValidateOutputFlag(cmd); err != nil {
+			return err
+		}
+		if err := shared.ValidateReportFlag(cmd); err != nil {
+			return err
+		}
+		if err := shared.ApplyConfigFlags(cmd); err != nil {
+			return err
+		}
+		shared.ApplyBackendConfig(cmd)
+		return nil
+	},
 }
 
-// Execute runs the root command
+// Execute runs the root command and records it in the usage metrics
 func Execute() error {
-	return rootCmd.Execute()
+	start := time.Now()
+	cmd, err := rootCmd.ExecuteC()
+	shared.RecordCommandMetrics(cmd, err, time.Since(start), util.Version)
+	if finishErr := runs.FinishFromEnv(ExitCode(err)); finishErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: recording detached run result: %v\n", finishErr)
+	}
+	return err
 }
 
 func init() {
@@ -81,6 +105,11 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("debug", "d", false, "Enable debug logging")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
 	rootCmd.PersistentFlags().String("output-style", "", "Output formatting style: default, compact, minimal, plain, raw")
+	shared.AddOutputFlag(rootCmd)
+	shared.AddTUIFlag(rootCmd)
+	shared.AddReportFlag(rootCmd)
+	shared.AddProfileFlag(rootCmd)
+	shared.AddPackageFlag(rootCmd)
 
 	// Register commands from subpackages
 	stages.Register(rootCmd)
@@ -35,9 +35,10 @@ Optional stage flags:
   -r, --clarify       Include clarify stage
   -l, --checklist     Include checklist stage (note: -c is used for --config)
   -z, --analyze       Include analyze stage
+      --verify        Include verify stage (checks acceptance criteria after implement)
 
 Stages are always executed in canonical order:
-  constitution -> specify -> clarify -> plan -> tasks -> checklist -> analyze -> implement`,
+  constitution -> specify -> clarify -> plan -> tasks -> checklist -> analyze -> implement -> verify`,
 	Example: `  # Run all core stages for a new feature
   autospec run -a "Add user authentication"
 
@@ -66,6 +67,7 @@ Stages are always executed in canonical order:
 		clarify, _ := cmd.Flags().GetBool("clarify")
 		checklist, _ := cmd.Flags().GetBool("checklist")
 		analyze, _ := cmd.Flags().GetBool("analyze")
+		verify, _ := cmd.Flags().GetBool("verify")
 
 		// Get other flags
 		specName, _ := cmd.Flags().GetString("spec")
@@ -93,6 +95,7 @@ Stages are always executed in canonical order:
 		stageConfig.Clarify = clarify
 		stageConfig.Checklist = checklist
 		stageConfig.Analyze = analyze
+		stageConfig.Verify = verify
 
 		// Validate at least one stage is selected
 		if !stageConfig.HasAnyStage() {
@@ -134,6 +137,7 @@ Stages are always executed in canonical order:
 		if _, err := shared.ApplyAgentOverride(cmd, cfg); err != nil {
 			return err
 		}
+		shared.ApplyModelOverride(cmd, cfg)
 
 		// Apply auto-commit override from flags
 		shared.ApplyAutoCommitOverride(cmd, cfg)
@@ -149,7 +153,7 @@ Stages are always executed in canonical order:
 		// Check if constitution exists (required unless only running constitution stage)
 		if !stageConfig.Constitution || stageConfig.Count() > 1 {
 			// Either not running constitution at all, or running other stages too
-			constitutionCheck := workflow.CheckConstitutionExists()
+			constitutionCheck := workflow.CheckConstitutionAt(cfg.Constitution)
 			if !constitutionCheck.Exists {
 				fmt.Fprint(os.Stderr, constitutionCheck.ErrorMessage)
 				return fmt.Errorf("constitution required")
@@ -202,6 +206,10 @@ Stages are always executed in canonical order:
 		// Apply output style from CLI flag (overrides config)
 		shared.ApplyOutputStyle(cmd, orchestrator)
 
+		// Stream agent output into the live view when --tui is set
+		defer shared.StartLiveOutput(cmd, orchestrator)()
+		defer shared.StartReport(cmd, orchestrator)()
+
 		if debug {
 			fmt.Println("[DEBUG] Debug mode enabled")
 			fmt.Printf("[DEBUG] Config: %+v\n", cfg)
@@ -214,7 +222,8 @@ Stages are always executed in canonical order:
 		}
 
 		// Create history logger
-		historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
+		historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+		historyLogger.Shared = cfg.SharedHistory()
 
 		// Execute stages in canonical order with context for cancellation support
 		// Pass 'all' flag as isFullWorkflow to control description propagation
@@ -265,6 +274,8 @@ func printDryRunPreview(stageConfig *workflow.StageConfig, featureDescription st
 			fmt.Println("  - (analysis output, no file changes)")
 		case workflow.StageImplement:
 			fmt.Println("  - (implementation changes to codebase)")
+		case workflow.StageVerify:
+			fmt.Println("  - specs/*/tasks.yaml (verification results)")
 		}
 	}
 	fmt.Println()
@@ -302,6 +313,7 @@ func executeStages(cmdCtx context.Context, orchestrator *workflow.WorkflowOrches
 	// Create notification handler from config
 	notifHandler := notify.NewHandler(orchestrator.Config.Notifications)
 	orchestrator.Executor.NotificationHandler = notifHandler
+	orchestrator.Executor.UsageRecorder = historyLogger
 
 	ctx := &stageExecutionContext{
 		orchestrator:        orchestrator,
@@ -362,6 +374,8 @@ func (ctx *stageExecutionContext) executeStage(stage workflow.Stage) error {
 		return ctx.executeChecklist()
 	case workflow.StageAnalyze:
 		return ctx.executeAnalyze()
+	case workflow.StageVerify:
+		return ctx.executeVerify()
 	default:
 		return fmt.Errorf("unknown stage: %s", stage)
 	}
@@ -455,6 +469,13 @@ func (ctx *stageExecutionContext) executeAnalyze() error {
 	return nil
 }
 
+func (ctx *stageExecutionContext) executeVerify() error {
+	if err := ctx.orchestrator.ExecuteVerify(ctx.specName, "", false); err != nil {
+		return fmt.Errorf("verify stage failed: %w", err)
+	}
+	return nil
+}
+
 // printWorkflowSummary prints a comprehensive summary after workflow completion
 func printWorkflowSummary(stages []workflow.Stage, specName, specDir string, ranImplement bool) {
 	fmt.Println()
@@ -516,9 +537,11 @@ func init() {
 	runCmd.Flags().BoolP("clarify", "r", false, "Include clarify stage")
 	runCmd.Flags().BoolP("checklist", "l", false, "Include checklist stage")
 	runCmd.Flags().BoolP("analyze", "z", false, "Include analyze stage")
+	runCmd.Flags().Bool("verify", false, "Include verify stage")
 
 	// Spec selection
 	runCmd.Flags().String("spec", "", "Specify which spec to work with (overrides branch detection)")
+	_ = runCmd.RegisterFlagCompletionFunc("spec", shared.CompleteSpecNames)
 
 	// Skip confirmation
 	runCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompts")
@@ -530,6 +553,7 @@ func init() {
 
 	// Agent override flag
 	shared.AddAgentFlag(runCmd)
+	shared.AddModelFlag(runCmd)
 
 	// Auto-commit flags
 	shared.AddAutoCommitFlags(runCmd)
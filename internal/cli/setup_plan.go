@@ -7,7 +7,9 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/ariel-frischer/autospec/internal/git"
+	"github.com/ariel-frischer/autospec/internal/vcs"
 	"github.com/spf13/cobra"
 )
 
@@ -53,9 +55,9 @@ func init() {
 }
 
 func runSetupPlan(cmd *cobra.Command, args []string) error {
-	// Get specs directory
-	specsDir, err := cmd.Flags().GetString("specs-dir")
-	if err != nil || specsDir == "" {
+	// Get specs directory (the selected package's in a monorepo)
+	specsDir := shared.ConfiguredSpecsDir(cmd)
+	if specsDir == "" {
 		specsDir = "./specs"
 	}
 
@@ -67,15 +69,17 @@ func runSetupPlan(cmd *cobra.Command, args []string) error {
 	}
 
 	// Detect current spec
-	specMeta, err := detectCurrentFeature(specsDir, hasGit)
+	repo := vcs.Current()
+	inRepo := repo.InRepository()
+	specMeta, err := detectCurrentFeature(specsDir, inRepo)
 	if err != nil {
 		return fmt.Errorf("detecting current feature: %w", err)
 	}
 
 	featureDir := specMeta.Directory
 	branch := specMeta.Branch
-	if branch == "" && hasGit {
-		branch, _ = git.GetCurrentBranch()
+	if branch == "" && inRepo {
+		branch, _ = repo.CurrentBranch()
 	}
 
 	// Ensure feature directory exists
@@ -89,8 +93,8 @@ func runSetupPlan(cmd *cobra.Command, args []string) error {
 
 	// Get repository root
 	repoRoot := filepath.Dir(specsDir)
-	if git.IsGitRepository() {
-		if root, err := git.GetRepositoryRoot(); err == nil {
+	if inRepo {
+		if root, err := repo.Root(); err == nil {
 			repoRoot = root
 		}
 	}
@@ -22,6 +22,7 @@ func AddAgentFlag(cmd *cobra.Command) {
 		return // No flag in production - Claude is always used
 	}
 	cmd.Flags().String(AgentFlagName, "", fmt.Sprintf("[DEV] Override agent (available: %s)", strings.Join(cliagent.List(), ", ")))
+	_ = cmd.RegisterFlagCompletionFunc(AgentFlagName, CompleteAgentNames)
 }
 
 // ResolveAgent resolves the agent to use based on CLI flag and config.
@@ -0,0 +1,144 @@
+package shared
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+// Dynamic shell completions. Cobra calls these through the hidden __complete
+// command, which skips the root PersistentPreRunE, so they apply the global
+// config flags themselves. Errors yield no completions rather than output.
+
+// CompleteSpecNames completes the names of specs in the specs directory,
+// skipping specs already given as arguments.
+func CompleteSpecNames(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	specsDir, ok := completionSpecsDir(cmd)
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names, err := spec.ListSpecs(specsDir)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return filterCompletions(names, args, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteArchivedSpecNames completes the names of archived specs.
+func CompleteArchivedSpecNames(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	specsDir, ok := completionSpecsDir(cmd)
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names, err := spec.ListArchivedSpecs(specsDir)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return filterCompletions(names, args, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteTaskIDs completes the task IDs in the tasks.yaml of the spec named
+// by --spec or the first argument (as in "implement 003-auth --from-task"),
+// or else of the current spec, with each task's title and status as its
+// description.
+func CompleteTaskIDs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	specsDir, ok := completionSpecsDir(cmd)
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	metadata, err := completionSpec(cmd, args, specsDir)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	tasksFile, err := validation.ParseTasksYAML(validation.GetTasksFilePath(metadata.Directory))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var completions []cobra.Completion
+	for _, phase := range tasksFile.Phases {
+		for _, task := range phase.Tasks {
+			if strings.HasPrefix(task.ID, toComplete) {
+				completions = append(completions, cobra.CompletionWithDesc(task.ID, fmt.Sprintf("%s [%s]", task.Title, task.Status)))
+			}
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteAgentNames completes the names of registered agents.
+func CompleteAgentNames(_ *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return filterCompletions(cliagent.List(), nil, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompletePackageNames completes the package names defined in the
+// workspace config.
+func CompletePackageNames(cmd *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for name := range cfg.Packages {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return filterCompletions(names, nil, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// FirstArg limits a completion function to a command's first positional
+// argument, for commands like "rename <spec> <new-name>".
+func FirstArg(complete cobra.CompletionFunc) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, args, toComplete)
+	}
+}
+
+// completionSpec returns the spec a task completion refers to.
+func completionSpec(cmd *cobra.Command, args []string, specsDir string) (*spec.Metadata, error) {
+	if name, _ := cmd.Flags().GetString("spec"); name != "" {
+		return spec.GetSpecMetadata(specsDir, name)
+	}
+	if len(args) > 0 {
+		if metadata, err := spec.GetSpecMetadata(specsDir, args[0]); err == nil {
+			return metadata, nil
+		}
+	}
+	return spec.DetectCurrentSpec(specsDir)
+}
+
+// completionSpecsDir resolves the specs directory the way commands do:
+// --specs-dir, then env and config files.
+func completionSpecsDir(cmd *cobra.Command) (string, bool) {
+	if err := ApplyConfigFlags(cmd); err != nil {
+		return "", false
+	}
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return "", false
+	}
+	return cfg.SpecsDir, true
+}
+
+// filterCompletions returns the candidates that start with toComplete and
+// aren't already among args.
+func filterCompletions(candidates, args []string, toComplete string) []cobra.Completion {
+	var completions []cobra.Completion
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, toComplete) && !slices.Contains(args, candidate) {
+			completions = append(completions, candidate)
+		}
+	}
+	return completions
+}
@@ -0,0 +1,133 @@
+package shared
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const completionTasksYAML = `phases:
+  - number: 1
+    title: "Setup"
+    tasks:
+      - id: T001
+        title: "Add login"
+        status: Completed
+      - id: T002
+        title: "Wire OAuth"
+        status: Pending
+      - id: T010
+        title: "Add logout"
+        status: Pending
+`
+
+// setupCompletionSpecs creates a specs directory with two specs and one
+// archived spec, and returns a command whose --specs-dir points at it.
+// The command exports --specs-dir to the environment, so tests using it
+// must not run in parallel.
+func setupCompletionSpecs(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	t.Setenv("AUTOSPEC_SPECS_DIR", "")
+	specsDir := t.TempDir()
+	for _, dir := range []string{"001-auth", "002-search", "archive/000-old"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(specsDir, dir), 0o755))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(specsDir, "001-auth", "tasks.yaml"), []byte(completionTasksYAML), 0o644))
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("config", "", "")
+	cmd.Flags().String("specs-dir", "./specs", "")
+	cmd.Flags().String("spec", "", "")
+	require.NoError(t, cmd.ParseFlags(append([]string{"--specs-dir", specsDir}, args...)))
+	return cmd
+}
+
+func TestCompleteSpecNames(t *testing.T) {
+	tests := map[string]struct {
+		args       []string
+		toComplete string
+		want       []cobra.Completion
+	}{
+		"all specs":         {want: []cobra.Completion{"001-auth", "002-search"}},
+		"prefix":            {toComplete: "002", want: []cobra.Completion{"002-search"}},
+		"skips given specs": {args: []string{"001-auth"}, want: []cobra.Completion{"002-search"}},
+		"no match":          {toComplete: "9", want: nil},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmd := setupCompletionSpecs(t)
+			got, directive := CompleteSpecNames(cmd, tt.args, tt.toComplete)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+		})
+	}
+}
+
+func TestCompleteArchivedSpecNames(t *testing.T) {
+	cmd := setupCompletionSpecs(t)
+	got, _ := CompleteArchivedSpecNames(cmd, nil, "")
+	assert.Equal(t, []cobra.Completion{"000-old"}, got)
+}
+
+func TestCompleteTaskIDs(t *testing.T) {
+	tests := map[string]struct {
+		flags      []string
+		args       []string
+		toComplete string
+		want       []cobra.Completion
+	}{
+		"spec from flag": {
+			flags: []string{"--spec", "001-auth"},
+			want: []cobra.Completion{
+				"T001\tAdd login [Completed]",
+				"T002\tWire OAuth [Pending]",
+				"T010\tAdd logout [Pending]",
+			},
+		},
+		"spec from first argument": {
+			args:       []string{"001"},
+			toComplete: "T01",
+			want:       []cobra.Completion{"T010\tAdd logout [Pending]"},
+		},
+		"spec without tasks": {
+			flags: []string{"--spec", "002-search"},
+			want:  nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmd := setupCompletionSpecs(t, tt.flags...)
+			got, directive := CompleteTaskIDs(cmd, tt.args, tt.toComplete)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+		})
+	}
+}
+
+func TestCompleteAgentNames(t *testing.T) {
+	t.Parallel()
+
+	got, directive := CompleteAgentNames(nil, nil, "gem")
+	assert.Equal(t, []cobra.Completion{"gemini"}, got)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
+
+func TestFirstArg(t *testing.T) {
+	t.Parallel()
+
+	complete := FirstArg(func(*cobra.Command, []string, string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return []cobra.Completion{"001-auth"}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	got, _ := complete(nil, nil, "")
+	assert.Equal(t, []cobra.Completion{"001-auth"}, got)
+	got, directive := complete(nil, []string{"001-auth"}, "")
+	assert.Nil(t, got, "only the first argument is completed")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
@@ -0,0 +1,77 @@
+package shared
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/statestore"
+	"github.com/ariel-frischer/autospec/internal/vcs"
+	"github.com/spf13/cobra"
+)
+
+// configFlagEnv maps global flags to the AUTOSPEC_* variable that sets the
+// same config key. Exporting a changed flag to its variable lets every config
+// load in this process see it, giving flags precedence over env and files.
+var configFlagEnv = map[string]string{
+	"profile":        "AUTOSPEC_PROFILE",
+	"package":        "AUTOSPEC_PACKAGE",
+	"specs-dir":      "AUTOSPEC_SPECS_DIR",
+	"skip-preflight": "AUTOSPEC_SKIP_PREFLIGHT",
+}
+
+// AddProfileFlag registers the global --profile flag on the root command.
+func AddProfileFlag(root *cobra.Command) {
+	root.PersistentFlags().String("profile", "", "Config profile to apply (overrides AUTOSPEC_PROFILE)")
+}
+
+// AddPackageFlag registers the global --package flag on the root command.
+func AddPackageFlag(root *cobra.Command) {
+	root.PersistentFlags().String("package", "", "Monorepo package to use (overrides AUTOSPEC_PACKAGE and the current directory)")
+	_ = root.RegisterFlagCompletionFunc("package", CompletePackageNames)
+}
+
+// ApplyConfigFlags exports explicitly set global config flags (--profile,
+// --package, --specs-dir, --skip-preflight) to their AUTOSPEC_* variables. Flags left at
+// their defaults are not exported, so env and config values still apply.
+func ApplyConfigFlags(cmd *cobra.Command) error {
+	for name, envVar := range configFlagEnv {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || !flag.Changed {
+			continue
+		}
+		if err := os.Setenv(envVar, flag.Value.String()); err != nil {
+			return fmt.Errorf("exporting --%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ApplyBackendConfig selects the version control backend and the state
+// store from the vcs and state_store config keys for the rest of the
+// process. If the config can't be loaded, the defaults apply; commands that
+// need the config report the error.
+func ApplyBackendConfig(cmd *cobra.Command) {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.LoadWithOptions(config.LoadOptions{ProjectConfigPath: configPath, SkipWarnings: true})
+	if err != nil {
+		return
+	}
+	// Both are validated with the rest of the config
+	_ = vcs.Use(cfg.VCS)
+	_ = statestore.Use(cfg.StateStore)
+}
+
+// ConfiguredSpecsDir returns the specs directory from --specs-dir, env and
+// config files, including the selected monorepo package. Falls back to the
+// flag's value if the config can't be loaded.
+func ConfiguredSpecsDir(cmd *cobra.Command) string {
+	flagValue, _ := cmd.Flags().GetString("specs-dir")
+	if flag := cmd.Flags().Lookup("specs-dir"); flag != nil && flag.Changed {
+		return flagValue
+	}
+	if specsDir, ok := completionSpecsDir(cmd); ok && specsDir != "" {
+		return specsDir
+	}
+	return flagValue
+}
@@ -0,0 +1,53 @@
+package shared
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyConfigFlags(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Setenv
+
+	tests := map[string]struct {
+		args    []string
+		env     map[string]string
+		wantEnv map[string]string
+	}{
+		"flags unset keep env": {
+			env:     map[string]string{"AUTOSPEC_PROFILE": "oss", "AUTOSPEC_SPECS_DIR": "./env-specs"},
+			wantEnv: map[string]string{"AUTOSPEC_PROFILE": "oss", "AUTOSPEC_SPECS_DIR": "./env-specs", "AUTOSPEC_SKIP_PREFLIGHT": ""},
+		},
+		"flags set env": {
+			args:    []string{"--profile", "work", "--specs-dir", "./flag-specs", "--skip-preflight"},
+			wantEnv: map[string]string{"AUTOSPEC_PROFILE": "work", "AUTOSPEC_SPECS_DIR": "./flag-specs", "AUTOSPEC_SKIP_PREFLIGHT": "true"},
+		},
+		"flags override env": {
+			args:    []string{"--profile", "work", "--skip-preflight=false"},
+			env:     map[string]string{"AUTOSPEC_PROFILE": "oss", "AUTOSPEC_SKIP_PREFLIGHT": "true"},
+			wantEnv: map[string]string{"AUTOSPEC_PROFILE": "work", "AUTOSPEC_SKIP_PREFLIGHT": "false"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, envVar := range configFlagEnv {
+				t.Setenv(envVar, tt.env[envVar])
+			}
+
+			cmd := &cobra.Command{}
+			AddProfileFlag(cmd)
+			cmd.Flags().String("specs-dir", "./specs", "")
+			cmd.Flags().Bool("skip-preflight", false, "")
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			require.NoError(t, ApplyConfigFlags(cmd))
+			for envVar, want := range tt.wantEnv {
+				assert.Equal(t, want, os.Getenv(envVar), envVar)
+			}
+		})
+	}
+}
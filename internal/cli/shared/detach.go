@@ -0,0 +1,66 @@
+package shared
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/runs"
+	"github.com/spf13/cobra"
+)
+
+// AddDetachFlag registers --detach on a long-running stage command.
+func AddDetachFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("detach", false, "Run in the background; monitor with 'autospec runs'")
+}
+
+// Detach re-runs the current command as a background process without
+// --detach and reports how to follow it. Flags that need a terminal or
+// this process's stdout are rejected, since the run has neither.
+func Detach(cmd *cobra.Command, stateDir, specName string) error {
+	for _, flag := range []string{"tui", "edit-prompt"} {
+		if on, _ := cmd.Flags().GetBool(flag); on {
+			cliErr := clierrors.NewArgumentError(fmt.Sprintf("--detach cannot be combined with --%s", flag))
+			clierrors.PrintError(cliErr)
+			return cliErr
+		}
+	}
+	if IsJSONOutput(cmd) {
+		cliErr := clierrors.NewArgumentError("--detach cannot be combined with --output json")
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating autospec executable: %w", err)
+	}
+	run, err := runs.Start(stateDir, runs.StartOptions{
+		Executable: executable,
+		Args:       withoutDetachFlag(os.Args[1:]),
+		Command:    cmd.Name(),
+		Spec:       specName,
+	})
+	if err != nil {
+		return fmt.Errorf("starting detached run: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Started run %s (pid %d) in the background\n", run.ID, run.PID)
+	fmt.Fprintf(out, "  Output:  autospec runs attach %s\n", run.ID)
+	fmt.Fprintf(out, "  Stop:    autospec runs kill %s\n", run.ID)
+	return nil
+}
+
+// withoutDetachFlag returns args with any --detach flag removed.
+func withoutDetachFlag(args []string) []string {
+	filtered := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "--detach" || strings.HasPrefix(arg, "--detach=") {
+			continue
+		}
+		filtered = append(filtered, arg)
+	}
+	return filtered
+}
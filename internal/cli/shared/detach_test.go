@@ -0,0 +1,40 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithoutDetachFlag(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		args []string
+		want []string
+	}{
+		"flag removed": {
+			args: []string{"implement", "--detach", "--tasks"},
+			want: []string{"implement", "--tasks"},
+		},
+		"explicit value removed": {
+			args: []string{"implement", "003-auth", "--detach=true"},
+			want: []string{"implement", "003-auth"},
+		},
+		"other flags kept": {
+			args: []string{"implement", "--detached-note"},
+			want: []string{"implement", "--detached-note"},
+		},
+		"no args": {
+			args: []string{},
+			want: []string{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, withoutDetachFlag(tt.args))
+		})
+	}
+}
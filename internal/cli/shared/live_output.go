@@ -0,0 +1,38 @@
+package shared
+
+import (
+	"fmt"
+
+	"github.com/ariel-frischer/autospec/internal/tui"
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+// AddTUIFlag registers the global --tui flag on the root command.
+func AddTUIFlag(root *cobra.Command) {
+	root.PersistentFlags().Bool("tui", false, "Stream agent output into a live terminal view with per-phase scrollback")
+}
+
+// StartLiveOutput starts the live terminal view when --tui is set and attaches
+// it to the orchestrator's executor. It returns a function that restores the
+// terminal; callers should defer it. When --tui is unset, --output json is
+// requested, or the terminal is not interactive, it returns a no-op and
+// output is printed as usual.
+func StartLiveOutput(cmd *cobra.Command, orch *workflow.WorkflowOrchestrator) func() {
+	enabled, _ := cmd.Flags().GetBool("tui")
+	if !enabled || IsJSONOutput(cmd) {
+		return func() {}
+	}
+	if !tui.Supported() {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Warning: --tui requires an interactive terminal; using plain output")
+		return func() {}
+	}
+
+	program, err := tui.Start()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to start live output: %v\n", err)
+		return func() {}
+	}
+	orch.Executor.LiveOutput = program
+	return program.Stop
+}
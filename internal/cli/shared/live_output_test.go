@@ -0,0 +1,49 @@
+package shared
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartLiveOutput_FallsBack(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		args        []string
+		wantWarning bool
+	}{
+		"flag not set": {},
+		"json output":  {args: []string{"--tui", "--output", "json"}},
+		"no terminal":  {args: []string{"--tui"}, wantWarning: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cmd := &cobra.Command{}
+			AddTUIFlag(cmd)
+			AddOutputFlag(cmd)
+			require.NoError(t, cmd.ParseFlags(tt.args))
+			var errOut bytes.Buffer
+			cmd.SetErr(&errOut)
+
+			orch := workflow.NewWorkflowOrchestrator(&config.Configuration{})
+			stop := StartLiveOutput(cmd, orch)
+			require.NotNil(t, stop)
+			stop()
+
+			assert.Nil(t, orch.Executor.LiveOutput)
+			if tt.wantWarning {
+				assert.Contains(t, errOut.String(), "--tui requires an interactive terminal")
+			} else {
+				assert.Empty(t, errOut.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package shared
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/metrics"
+	"github.com/spf13/cobra"
+)
+
+// RecordCommandMetrics records a finished command in the local usage
+// metrics, together with the stage sessions the executor recorded while it
+// ran, and uploads anonymized aggregates when metrics.upload is enabled and
+// the last upload is a day old. Metrics never fail the command: problems are
+// reported as warnings.
+func RecordCommandMetrics(cmd *cobra.Command, cmdErr error, duration time.Duration, version string) {
+	if !metricsCommand(cmd) {
+		return
+	}
+	if err := ApplyConfigFlags(cmd); err != nil {
+		return
+	}
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.LoadWithOptions(config.LoadOptions{ProjectConfigPath: configPath, SkipWarnings: true})
+	if err != nil || !cfg.Metrics.Enabled {
+		return
+	}
+
+	name := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	metrics.Default.RecordCommand(name, cmdErr == nil, duration)
+	m, err := metrics.Default.Flush(cfg.StateDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: recording metrics: %v\n", err)
+		return
+	}
+
+	now := time.Now().UTC()
+	if !cfg.Metrics.Upload || cfg.Metrics.UploadURL == "" || !m.UploadDue(now) {
+		return
+	}
+	// Count the attempt even when it fails, so an unreachable endpoint is
+	// retried daily rather than after every command.
+	m.LastUpload = &now
+	if err := metrics.Save(cfg.StateDir, m); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: recording metrics: %v\n", err)
+		return
+	}
+	if err := metrics.Upload(cfg.Metrics.UploadURL, metrics.NewPayload(m, version)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: uploading metrics: %v\n", err)
+	}
+}
+
+// metricsCommand reports whether runs of cmd are counted. Help, shell
+// completion and the metrics commands themselves are not.
+func metricsCommand(cmd *cobra.Command) bool {
+	if cmd == nil || !cmd.HasParent() || cmd.Hidden || cmd.Name() == "help" {
+		return false
+	}
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		if c.Name() == "completion" || c.Name() == "metrics" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,46 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsCommand(t *testing.T) {
+	t.Parallel()
+
+	root := &cobra.Command{Use: "autospec"}
+	plan := &cobra.Command{Use: "plan"}
+	task := &cobra.Command{Use: "task"}
+	block := &cobra.Command{Use: "block"}
+	task.AddCommand(block)
+	completion := &cobra.Command{Use: "completion"}
+	bash := &cobra.Command{Use: "bash"}
+	completion.AddCommand(bash)
+	metricsCmd := &cobra.Command{Use: "metrics"}
+	show := &cobra.Command{Use: "show"}
+	metricsCmd.AddCommand(show)
+	hidden := &cobra.Command{Use: "__complete", Hidden: true}
+	root.AddCommand(plan, task, completion, metricsCmd, hidden, &cobra.Command{Use: "help"})
+
+	tests := map[string]struct {
+		cmd  *cobra.Command
+		want bool
+	}{
+		"stage command":   {cmd: plan, want: true},
+		"subcommand":      {cmd: block, want: true},
+		"root":            {cmd: root},
+		"nil":             {},
+		"completion":      {cmd: bash},
+		"metrics command": {cmd: show},
+		"hidden command":  {cmd: hidden},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, metricsCommand(tt.cmd))
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package shared
+
+import (
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// ModelFlagName is the flag name for selecting the agent's model.
+const ModelFlagName = "model"
+
+// AddModelFlag adds the --model flag to a command.
+func AddModelFlag(cmd *cobra.Command) {
+	cmd.Flags().String(ModelFlagName, "", "Model for every stage, passed to the agent's --model flag (overrides the model config)")
+}
+
+// ApplyModelOverride replaces the configured model, including per-stage
+// overrides, with the --model flag value. Returns true if an override was applied.
+func ApplyModelOverride(cmd *cobra.Command, cfg *config.Configuration) bool {
+	if cmd.Flags().Lookup(ModelFlagName) == nil {
+		return false
+	}
+	model, _ := cmd.Flags().GetString(ModelFlagName)
+	if model == "" {
+		return false
+	}
+	cfg.Model = cliagent.ModelConfig{Name: model}
+	return true
+}
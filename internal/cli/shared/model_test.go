@@ -0,0 +1,52 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyModelOverride(t *testing.T) {
+	t.Parallel()
+
+	configured := cliagent.ModelConfig{Name: "sonnet", Stages: map[string]string{"implement": "opus"}}
+	tests := map[string]struct {
+		args        []string
+		addFlag     bool
+		wantApplied bool
+		wantModel   cliagent.ModelConfig
+	}{
+		"flag replaces stage overrides": {
+			args:        []string{"--model", "haiku"},
+			addFlag:     true,
+			wantApplied: true,
+			wantModel:   cliagent.ModelConfig{Name: "haiku"},
+		},
+		"flag not set keeps config": {
+			addFlag:   true,
+			wantModel: configured,
+		},
+		"command without flag keeps config": {
+			wantModel: configured,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cmd := &cobra.Command{}
+			if tt.addFlag {
+				AddModelFlag(cmd)
+			}
+			require.NoError(t, cmd.ParseFlags(tt.args))
+			cfg := &config.Configuration{Model: configured}
+
+			assert.Equal(t, tt.wantApplied, ApplyModelOverride(cmd, cfg))
+			assert.Equal(t, tt.wantModel, cfg.Model)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Output formats accepted by the global --output flag.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// ValidOutputFormats lists the accepted --output values.
+var ValidOutputFormats = []string{OutputText, OutputJSON}
+
+// AddOutputFlag registers the global --output flag on the root command.
+func AddOutputFlag(root *cobra.Command) {
+	root.PersistentFlags().String("output", OutputText, "Output format: text, json (json is machine-readable for scripts and CI)")
+}
+
+// ValidateOutputFlag returns an error if --output has an unsupported value.
+func ValidateOutputFlag(cmd *cobra.Command) error {
+	format, _ := cmd.Flags().GetString("output")
+	if format == "" || slices.Contains(ValidOutputFormats, format) {
+		return nil
+	}
+	return fmt.Errorf("invalid --output %q: must be one of: %s", format, strings.Join(ValidOutputFormats, ", "))
+}
+
+// IsJSONOutput reports whether --output json was requested.
+func IsJSONOutput(cmd *cobra.Command) bool {
+	format, _ := cmd.Flags().GetString("output")
+	return format == OutputJSON
+}
+
+// WriteJSON writes v to w as indented JSON followed by a newline.
+func WriteJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("encoding JSON output: %w", err)
+	}
+	return nil
+}
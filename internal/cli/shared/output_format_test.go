@@ -0,0 +1,80 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createOutputTestCommand creates a cobra command with the global --output flag parsed from args.
+func createOutputTestCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	AddOutputFlag(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	return cmd
+}
+
+func TestValidateOutputFlag(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		args     []string
+		wantJSON bool
+		wantErr  bool
+	}{
+		"default is text": {
+			args: nil,
+		},
+		"explicit text": {
+			args: []string{"--output", "text"},
+		},
+		"json": {
+			args:     []string{"--output", "json"},
+			wantJSON: true,
+		},
+		"unsupported format": {
+			args:    []string{"--output", "yaml"},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cmd := createOutputTestCommand(t, tt.args...)
+
+			err := ValidateOutputFlag(cmd)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "text, json")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantJSON, IsJSONOutput(cmd))
+		})
+	}
+}
+
+func TestIsJSONOutput_FlagNotDefined(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, IsJSONOutput(&cobra.Command{}))
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, map[string]int{"count": 2}))
+
+	assert.Equal(t, "{\n  \"count\": 2\n}\n", buf.String())
+
+	var decoded map[string]int
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, 2, decoded["count"])
+}
@@ -0,0 +1,92 @@
+package shared
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+// AddPromptFlags registers --edit-prompt and --prompt-file on a stage command.
+func AddPromptFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("edit-prompt", false, "Open the constructed prompt in $EDITOR before sending it to the agent")
+	cmd.Flags().String("prompt-file", "", "Send this file to the agent instead of the constructed prompt")
+	cmd.MarkFlagsMutuallyExclusive("edit-prompt", "prompt-file")
+}
+
+// ApplyPromptFlags installs a prompt hook on the orchestrator's executor when
+// --edit-prompt or --prompt-file is set. The prompt file is read up front so
+// a missing file fails before any agent runs.
+func ApplyPromptFlags(cmd *cobra.Command, orch *workflow.WorkflowOrchestrator) error {
+	if path, _ := cmd.Flags().GetString("prompt-file"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading prompt file: %w", err)
+		}
+		prompt := string(data)
+		if strings.TrimSpace(prompt) == "" {
+			return fmt.Errorf("prompt file %s is empty", path)
+		}
+		orch.Executor.PromptHook = func(workflow.Stage, string) (string, error) {
+			return prompt, nil
+		}
+		return nil
+	}
+	if edit, _ := cmd.Flags().GetBool("edit-prompt"); edit {
+		orch.Executor.PromptHook = func(stage workflow.Stage, prompt string) (string, error) {
+			return EditPrompt(stage, prompt, editorCommand())
+		}
+	}
+	return nil
+}
+
+// EditPrompt writes prompt to a temporary file, opens it with editor (a
+// command line such as "code --wait"), and returns the saved content.
+// Clearing the file aborts the stage.
+func EditPrompt(stage workflow.Stage, prompt, editor string) (string, error) {
+	f, err := os.CreateTemp("", fmt.Sprintf("autospec-%s-*.md", stage))
+	if err != nil {
+		return "", fmt.Errorf("creating prompt file: %w", err)
+	}
+	path := f.Name()
+	defer os.Remove(path)
+	if _, err := f.WriteString(prompt); err != nil {
+		f.Close()
+		return "", fmt.Errorf("writing prompt file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("writing prompt file: %w", err)
+	}
+
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		return "", fmt.Errorf("no editor configured; set $VISUAL or $EDITOR")
+	}
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("running editor %q: %w", editor, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading edited prompt: %w", err)
+	}
+	if strings.TrimSpace(string(data)) == "" {
+		return "", fmt.Errorf("prompt for %s is empty; aborting", stage)
+	}
+	return string(data), nil
+}
+
+// editorCommand returns the user's editor: $VISUAL, then $EDITOR, then vi.
+func editorCommand() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	return "vi"
+}
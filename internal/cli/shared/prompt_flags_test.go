@@ -0,0 +1,96 @@
+package shared
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPromptFlags(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	promptPath := filepath.Join(dir, "prompt.md")
+	require.NoError(t, os.WriteFile(promptPath, []byte("custom prompt"), 0o644))
+	emptyPath := filepath.Join(dir, "empty.md")
+	require.NoError(t, os.WriteFile(emptyPath, []byte("\n"), 0o644))
+
+	tests := map[string]struct {
+		args       []string
+		wantHook   bool
+		wantPrompt string
+		wantErr    string
+	}{
+		"no flags":           {},
+		"prompt file":        {args: []string{"--prompt-file", promptPath}, wantHook: true, wantPrompt: "custom prompt"},
+		"missing file":       {args: []string{"--prompt-file", filepath.Join(dir, "missing.md")}, wantErr: "reading prompt file"},
+		"empty file":         {args: []string{"--prompt-file", emptyPath}, wantErr: "is empty"},
+		"edit prompt":        {args: []string{"--edit-prompt"}, wantHook: true},
+		"mutually exclusive": {args: []string{"--edit-prompt", "--prompt-file", promptPath}, wantErr: "none of the others can be"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cmd := &cobra.Command{RunE: func(*cobra.Command, []string) error { return nil }}
+			AddPromptFlags(cmd)
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			orch := workflow.NewWorkflowOrchestrator(&config.Configuration{})
+			err := cmd.ValidateFlagGroups()
+			if err == nil {
+				err = ApplyPromptFlags(cmd, orch)
+			}
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if !tt.wantHook {
+				assert.Nil(t, orch.Executor.PromptHook)
+				return
+			}
+			require.NotNil(t, orch.Executor.PromptHook)
+			if tt.wantPrompt != "" {
+				prompt, err := orch.Executor.PromptHook(workflow.StagePlan, "/autospec.plan")
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantPrompt, prompt)
+			}
+		})
+	}
+}
+
+func TestEditPrompt(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		editor  string
+		want    string
+		wantErr string
+	}{
+		"edited":         {editor: "sed -i s/plan/plan-edited/", want: "/autospec.plan-edited\n"},
+		"unchanged":      {editor: "true", want: "/autospec.plan\n"},
+		"cleared aborts": {editor: "truncate -s 0", wantErr: "prompt for plan is empty"},
+		"editor fails":   {editor: "false", wantErr: "running editor"},
+		"no editor":      {editor: " ", wantErr: "no editor configured"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := EditPrompt(workflow.StagePlan, "/autospec.plan\n", tt.editor)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
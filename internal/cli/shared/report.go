@@ -0,0 +1,79 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/report"
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+// Report formats accepted by --report.
+const (
+	ReportJUnit = "junit"
+	ReportSARIF = "sarif"
+)
+
+// AddReportFlag registers the global, repeatable --report flag on the root command.
+func AddReportFlag(root *cobra.Command) {
+	root.PersistentFlags().StringArray("report", nil,
+		"Write a report file when the command finishes: junit=<path> (stage and task validation) or sarif=<path> (analyze findings); repeatable")
+}
+
+// reportRequest is one parsed --report value.
+type reportRequest struct {
+	format string
+	path   string
+}
+
+// parseReportFlag returns the requested reports, or none when --report is unset.
+func parseReportFlag(cmd *cobra.Command) ([]reportRequest, error) {
+	values, _ := cmd.Flags().GetStringArray("report")
+	var requests []reportRequest
+	for _, value := range values {
+		format, path, found := strings.Cut(value, "=")
+		if !found || (format != ReportJUnit && format != ReportSARIF) || path == "" {
+			return nil, fmt.Errorf("invalid --report %q: expected %s=<path> or %s=<path>", value, ReportJUnit, ReportSARIF)
+		}
+		requests = append(requests, reportRequest{format: format, path: path})
+	}
+	return requests, nil
+}
+
+// ValidateReportFlag returns an error if a --report value is malformed.
+func ValidateReportFlag(cmd *cobra.Command) error {
+	_, err := parseReportFlag(cmd)
+	return err
+}
+
+// StartReport attaches the reports requested with --report to the
+// orchestrator's executor. It returns a function that writes them; callers
+// should defer it, so the reports are written even when a stage fails. When
+// --report is unset it returns a no-op.
+func StartReport(cmd *cobra.Command, orch *workflow.WorkflowOrchestrator) func() {
+	requests, err := parseReportFlag(cmd)
+	if err != nil || len(requests) == 0 {
+		return func() {}
+	}
+	collectors := make(report.Collectors, len(requests))
+	for i, request := range requests {
+		if request.format == ReportSARIF {
+			collectors[i] = &report.SARIF{}
+		} else {
+			collectors[i] = &report.JUnit{}
+		}
+	}
+	if len(collectors) == 1 {
+		orch.Executor.Validations = collectors[0]
+	} else {
+		orch.Executor.Validations = collectors
+	}
+	return func() {
+		for i, request := range requests {
+			if err := collectors[i].Write(request.path, orch.SpecsDir); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
+			}
+		}
+	}
+}
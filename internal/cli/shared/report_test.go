@@ -0,0 +1,97 @@
+package shared
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/report"
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createReportTestCommand creates a cobra command with the global --report flag parsed from args.
+func createReportTestCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	AddReportFlag(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	return cmd
+}
+
+func TestValidateReportFlag(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		args    []string
+		wantErr bool
+	}{
+		"unset":          {},
+		"junit":          {args: []string{"--report", "junit=reports/autospec.xml"}},
+		"sarif":          {args: []string{"--report", "sarif=reports/autospec.sarif"}},
+		"both":           {args: []string{"--report", "junit=autospec.xml", "--report", "sarif=autospec.sarif"}},
+		"one malformed":  {args: []string{"--report", "junit=autospec.xml", "--report", "sarif"}, wantErr: true},
+		"unknown format": {args: []string{"--report", "tap=out.tap"}, wantErr: true},
+		"missing path":   {args: []string{"--report", "junit="}, wantErr: true},
+		"bare path":      {args: []string{"--report", "out.xml"}, wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateReportFlag(createReportTestCommand(t, tt.args...))
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "junit=<path> or sarif=<path>")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStartReport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unset leaves the executor alone", func(t *testing.T) {
+		t.Parallel()
+		orch := &workflow.WorkflowOrchestrator{Executor: &workflow.Executor{}}
+		StartReport(createReportTestCommand(t), orch)()
+		assert.Nil(t, orch.Executor.Validations)
+	})
+
+	t.Run("writes the report when finished", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "autospec.xml")
+		orch := &workflow.WorkflowOrchestrator{Executor: &workflow.Executor{}, SpecsDir: t.TempDir()}
+
+		finish := StartReport(createReportTestCommand(t, "--report", "junit="+path), orch)
+		require.NotNil(t, orch.Executor.Validations)
+		orch.Executor.Validations.RecordValidation(report.ValidationResult{Spec: "001-auth", Stage: "plan", Attempt: 1, Message: "missing field"})
+		finish()
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `<failure message="missing field" type="validation">`)
+	})
+	t.Run("writes every requested report", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		junitPath := filepath.Join(dir, "autospec.xml")
+		sarifPath := filepath.Join(dir, "autospec.sarif")
+		orch := &workflow.WorkflowOrchestrator{Executor: &workflow.Executor{}, SpecsDir: t.TempDir()}
+
+		finish := StartReport(createReportTestCommand(t, "--report", "junit="+junitPath, "--report", "sarif="+sarifPath), orch)
+		assert.IsType(t, report.Collectors{}, orch.Executor.Validations)
+		orch.Executor.Validations.RecordValidation(report.ValidationResult{Spec: "001-auth", Stage: "analyze", Attempt: 1, Passed: true})
+		finish()
+
+		junit, err := os.ReadFile(junitPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(junit), `<testcase name="analyze"`)
+		sarif, err := os.ReadFile(sarifPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(sarif), `"version": "2.1.0"`)
+	})
+}
@@ -0,0 +1,117 @@
+package shared
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+// StageReport is the --output json result of a stage command.
+type StageReport struct {
+	Command    string                `json:"command"`
+	Stage      string                `json:"stage"`
+	Spec       string                `json:"spec,omitempty"`
+	Success    bool                  `json:"success"`
+	ExitCode   int                   `json:"exit_code"`
+	Error      string                `json:"error,omitempty"`
+	Duration   string                `json:"duration"`
+	RetryCount int                   `json:"retry_count"`
+	Tasks      *validation.TaskStats `json:"tasks,omitempty"`
+}
+
+// stageReportKey is the context key for the in-flight stage report.
+type stageReportKey struct{}
+
+// stageReportState collects what a stage command learns while it runs.
+type stageReportState struct {
+	report   StageReport
+	stateDir string
+	specDir  string
+}
+
+// WithStageReport wraps a stage command's RunE for --output json. While the
+// command runs, stdout is redirected to stderr so agent output and progress
+// messages don't mix with the JSON document; afterwards a StageReport is
+// written to stdout. In text mode the command runs unchanged.
+func WithStageReport(stage string, runE func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if !IsJSONOutput(cmd) {
+			return runE(cmd, args)
+		}
+
+		state := &stageReportState{report: StageReport{Command: cmd.Name(), Stage: stage}}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		cmd.SetContext(context.WithValue(ctx, stageReportKey{}, state))
+
+		out := cmd.OutOrStdout()
+		restore := redirectStdout(os.Stderr)
+		start := time.Now()
+		err := runE(cmd, args)
+		restore()
+
+		state.finish(err, time.Since(start))
+		if writeErr := WriteJSON(out, state.report); writeErr != nil {
+			return writeErr
+		}
+		return err
+	}
+}
+
+// SetStageReportSpec records the spec a stage command operates on so the JSON
+// report can include its retry count and task progress. No-op in text mode.
+func SetStageReportSpec(cmd *cobra.Command, stateDir, specName, specDir string) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		return
+	}
+	state, ok := ctx.Value(stageReportKey{}).(*stageReportState)
+	if !ok {
+		return
+	}
+	state.report.Spec = specName
+	state.stateDir = stateDir
+	state.specDir = specDir
+}
+
+// finish fills in the outcome, retry count, and task stats after the command returns.
+func (s *stageReportState) finish(err error, elapsed time.Duration) {
+	s.report.Success = err == nil
+	s.report.ExitCode = ExitCode(err)
+	s.report.Duration = elapsed.Round(time.Millisecond).String()
+	// Bare exit-code errors carry no message; details were already printed to stderr
+	if _, isExitErr := err.(*exitError); err != nil && !isExitErr {
+		s.report.Error = err.Error()
+	}
+
+	if s.stateDir != "" {
+		// specify tracks retries before the spec exists, under an empty spec name
+		retrySpec := s.report.Spec
+		if s.report.Stage == string(workflow.StageSpecify) {
+			retrySpec = ""
+		}
+		if state, loadErr := retry.LoadRetryState(s.stateDir, retrySpec, s.report.Stage, 0); loadErr == nil {
+			s.report.RetryCount = state.Count
+		}
+	}
+
+	if s.specDir != "" {
+		if stats, statsErr := validation.GetTaskStats(validation.GetTasksFilePath(s.specDir)); statsErr == nil {
+			s.report.Tasks = stats
+		}
+	}
+}
+
+// redirectStdout points os.Stdout at w and returns a function restoring it.
+func redirectStdout(w *os.File) func() {
+	orig := os.Stdout
+	os.Stdout = w
+	return func() { os.Stdout = orig }
+}
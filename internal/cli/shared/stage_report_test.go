@@ -0,0 +1,115 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runStageReportCommand runs runE through WithStageReport and returns the captured stdout.
+// Not parallel-safe: WithStageReport swaps os.Stdout in JSON mode.
+func runStageReportCommand(t *testing.T, stage string, args []string, runE func(*cobra.Command, []string) error) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	cmd := &cobra.Command{Use: stage}
+	AddOutputFlag(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	cmd.SetOut(&out)
+
+	err := WithStageReport(stage, runE)(cmd, nil)
+	return out.String(), err
+}
+
+func TestWithStageReport_TextModePassesThrough(t *testing.T) {
+	called := false
+	out, err := runStageReportCommand(t, "plan", nil, func(cmd *cobra.Command, args []string) error {
+		called = true
+		SetStageReportSpec(cmd, t.TempDir(), "001-test", "") // no-op in text mode
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.True(t, called)
+	assert.Empty(t, out)
+}
+
+func TestWithStageReport_JSON(t *testing.T) {
+	stateDir := t.TempDir()
+	specDir := t.TempDir()
+	tasks := `tasks:
+  branch: "001-test"
+  created: "2025-01-01"
+  spec_path: "specs/001-test/spec.yaml"
+  plan_path: "specs/001-test/plan.yaml"
+phases:
+  - number: 1
+    title: "Setup"
+    purpose: "Setup"
+    tasks:
+      - id: "T001"
+        title: "Init"
+        status: "Completed"
+        type: "setup"
+        parallel: false
+        story_id: ""
+        file_path: "go.mod"
+        dependencies: []
+        acceptance_criteria: ["done"]
+`
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "tasks.yaml"), []byte(tasks), 0644))
+	_, err := retry.IncrementRetryCount(stateDir, "001-test", "tasks", 3)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		runErr      error
+		wantSuccess bool
+		wantCode    int
+		wantError   string
+	}{
+		"success": {
+			wantSuccess: true,
+			wantCode:    ExitSuccess,
+		},
+		"failure with message": {
+			runErr:    errors.New("tasks stage failed: validation"),
+			wantCode:  ExitValidationFailed,
+			wantError: "tasks stage failed: validation",
+		},
+		"bare exit code": {
+			runErr:   NewExitError(ExitInvalidArguments),
+			wantCode: ExitInvalidArguments,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := runStageReportCommand(t, "tasks", []string{"--output", "json"}, func(cmd *cobra.Command, args []string) error {
+				fmt.Println("agent output that must not reach the JSON stream")
+				SetStageReportSpec(cmd, stateDir, "001-test", specDir)
+				return tt.runErr
+			})
+			assert.Equal(t, tt.runErr, err)
+
+			var report StageReport
+			require.NoError(t, json.Unmarshal([]byte(out), &report), "stdout must be a single JSON document: %s", out)
+			assert.Equal(t, "tasks", report.Command)
+			assert.Equal(t, "tasks", report.Stage)
+			assert.Equal(t, "001-test", report.Spec)
+			assert.Equal(t, tt.wantSuccess, report.Success)
+			assert.Equal(t, tt.wantCode, report.ExitCode)
+			assert.Equal(t, tt.wantError, report.Error)
+			assert.Equal(t, 1, report.RetryCount)
+			require.NotNil(t, report.Tasks)
+			assert.Equal(t, 1, report.Tasks.CompletedTasks)
+		})
+	}
+}
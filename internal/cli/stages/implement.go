@@ -2,6 +2,7 @@ package stages
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
@@ -15,6 +16,7 @@ import (
 	"github.com/ariel-frischer/autospec/internal/lifecycle"
 	"github.com/ariel-frischer/autospec/internal/notify"
 	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/validation"
 	"github.com/ariel-frischer/autospec/internal/workflow"
 	"github.com/spf13/cobra"
 )
@@ -38,6 +40,7 @@ Execution Modes:
 - --from-phase N: Run phases N through end, each in a fresh session
 - --tasks: Run each task in a separate Claude session (finest granularity)
 - --from-task T003: Start task-level execution from a specific task ID
+- --only FILTER: Run only the matching tasks, each in a separate session
 - --single-session: Run all tasks in one Claude session (legacy mode)
 
 The default execution mode can be configured in config.yml:
@@ -58,7 +61,19 @@ The --tasks mode provides maximum context isolation:
 - Each task gets a completely fresh Claude session
 - Ideal for complex or long-running tasks
 - Finest-grained recovery points
-- Can combine with --from-task to resume from specific task`,
+- Can combine with --from-task to resume from specific task
+- With --resume, restarts from the task an interrupted run was on
+- Can combine with --task-commits to commit after each completed task
+
+The --only flag runs a subset of tasks in task mode. FILTER is a list of
+task IDs and ranges (T010,T012-T015) or key=value terms on id, type,
+status and story (type=test). Space-separated terms must all match. Each
+selected task is validated on its own; other tasks are left untouched.
+
+The --worktree flag runs the implementation in a dedicated git worktree
+(branch <spec>-implement) so the agent cannot touch your working tree.
+The worktree is reused by later runs and removed after a successful run
+unless it has uncommitted changes or --keep-worktree is set.`,
 	Example: `  # Auto-detect spec and implement
   autospec implement
 
@@ -86,8 +101,21 @@ The --tasks mode provides maximum context isolation:
   # Resume task execution from a specific task
   autospec implement --tasks --from-task T003
 
+  # Run only the test tasks, or a set of task IDs
+  autospec implement --only type=test
+  autospec implement --only T010,T012-T015
+
+  # Commit after each task, e.g. "feat(T014): add retry policy"
+  autospec implement --tasks --task-commits
+
   # Run all tasks in a single Claude session (legacy mode)
-  autospec implement --single-session`,
+  autospec implement --single-session
+
+  # Implement in a dedicated git worktree, leaving your working tree untouched
+  autospec implement --worktree
+
+  # Run in the background and check on it later with 'autospec runs'
+  autospec implement --detach`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true // Don't show help for execution errors
 		// Parse args to distinguish between spec-name and prompt
@@ -107,10 +135,20 @@ The --tasks mode provides maximum context isolation:
 		// Get task execution flags
 		taskMode, _ := cmd.Flags().GetBool("tasks")
 		fromTask, _ := cmd.Flags().GetString("from-task")
+		only, _ := cmd.Flags().GetString("only")
 
 		// Get single-session flag
 		singleSession, _ := cmd.Flags().GetBool("single-session")
 
+		// Get worktree isolation flags
+		isolate, _ := cmd.Flags().GetBool("worktree")
+		keepWorktree, _ := cmd.Flags().GetBool("keep-worktree")
+		if keepWorktree && !isolate {
+			cliErr := clierrors.NewArgumentError("--keep-worktree requires --worktree flag")
+			clierrors.PrintError(cliErr)
+			return cliErr
+		}
+
 		// Get parallel execution flags (dev builds only)
 		var parallelMode, useWorktrees, dryRun, skipConfirmation bool
 		var maxParallel int
@@ -158,6 +196,17 @@ The --tasks mode provides maximum context isolation:
 			return cliErr
 		}
 
+		// Parse the task filter before any work is done
+		var taskFilter *validation.TaskFilter
+		if cmd.Flags().Changed("only") {
+			var err error
+			if taskFilter, err = validation.ParseTaskFilter(only); err != nil {
+				cliErr := clierrors.NewArgumentError(fmt.Sprintf("invalid --only filter: %v", err))
+				clierrors.PrintError(cliErr)
+				return cliErr
+			}
+		}
+
 		// Load configuration
 		cfg, err := config.Load(configPath)
 		if err != nil {
@@ -180,6 +229,7 @@ The --tasks mode provides maximum context isolation:
 		if _, err := shared.ApplyAgentOverride(cmd, cfg); err != nil {
 			return err
 		}
+		shared.ApplyModelOverride(cmd, cfg)
 
 		// Apply auto-commit override from flags
 		shared.ApplyAutoCommitOverride(cmd, cfg)
@@ -193,6 +243,7 @@ The --tasks mode provides maximum context isolation:
 			cmd.Flags().Changed("phase") ||
 			cmd.Flags().Changed("from-phase") ||
 			cmd.Flags().Changed("from-task") ||
+			cmd.Flags().Changed("only") ||
 			cmd.Flags().Changed("single-session") ||
 			(util.IsDevBuild() && cmd.Flags().Changed("parallel"))
 
@@ -204,6 +255,7 @@ The --tasks mode provides maximum context isolation:
 				PhaseFlag:         singlePhase,
 				FromPhaseFlag:     fromPhase,
 				FromTaskFlag:      fromTask,
+				OnlyFlag:          taskFilter != nil,
 				ParallelFlag:      parallelMode,
 				MaxParallelFlag:   maxParallel,
 				WorktreesFlag:     useWorktrees,
@@ -221,8 +273,20 @@ The --tasks mode provides maximum context isolation:
 		dryRun = execMode.DryRun
 		skipConfirmation = execMode.SkipConfirmation
 
+		// Per-task commits only apply when each task runs in its own session
+		if cmd.Flags().Changed("task-commits") {
+			cfg.TaskCommits, _ = cmd.Flags().GetBool("task-commits")
+			if cfg.TaskCommits && !taskMode {
+				cliErr := clierrors.NewArgumentError("--task-commits requires task-level execution (--tasks, --only or implement_method: tasks)")
+				clierrors.PrintError(cliErr)
+				return cliErr
+			}
+		} else if cfg.TaskCommits && !taskMode {
+			fmt.Fprintln(cmd.ErrOrStderr(), "Warning: task_commits only applies to task-level execution (--tasks); no per-task commits will be made")
+		}
+
 		// Check if constitution exists (required for implement)
-		constitutionCheck := workflow.CheckConstitutionExists()
+		constitutionCheck := workflow.CheckConstitutionAt(cfg.Constitution)
 		if !constitutionCheck.Exists {
 			fmt.Fprint(os.Stderr, constitutionCheck.ErrorMessage)
 			return shared.NewExitError(shared.ExitInvalidArguments)
@@ -242,24 +306,60 @@ The --tasks mode provides maximum context isolation:
 			return shared.NewExitError(shared.ExitInvalidArguments)
 		}
 
+		historySpecName := fmt.Sprintf("%s-%s", metadata.Number, metadata.Name)
+
+		// Hand the run to a background process when --detach is set
+		if detach, _ := cmd.Flags().GetBool("detach"); detach {
+			return shared.Detach(cmd, cfg.StateDir, historySpecName)
+		}
+
 		// Create notification handler and history logger
 		notifHandler := notify.NewHandler(cfg.Notifications)
-		historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
-		historySpecName := fmt.Sprintf("%s-%s", metadata.Number, metadata.Name)
+		historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+		historyLogger.Shared = cfg.SharedHistory()
+		shared.SetStageReportSpec(cmd, cfg.StateDir, historySpecName, metadata.Directory)
 
 		// Show security notice (once per user)
 		shared.ShowSecurityNotice(cmd.OutOrStdout(), cfg)
 
 		// Wrap command execution with lifecycle for timing, notification, and history
 		// Use RunWithHistoryContext to support context cancellation (e.g., Ctrl+C)
-		return lifecycle.RunWithHistoryContext(cmd.Context(), notifHandler, historyLogger, "implement", historySpecName, func(_ context.Context) error {
+		return lifecycle.RunWithHistoryContext(cmd.Context(), notifHandler, historyLogger, "implement", historySpecName, func(_ context.Context) (runErr error) {
+			// Run in a dedicated worktree when --worktree is set. The spec is
+			// named explicitly since the worktree branch is not the spec branch.
+			var worktreeDir string
+			if isolate {
+				iw, err := enterImplementWorktree(cfg, historySpecName, metadata.Directory, cmd.OutOrStdout())
+				if err != nil {
+					return err
+				}
+				specName = historySpecName
+				worktreeDir = iw.wt.Path
+				defer func() {
+					runErr = errors.Join(runErr, iw.leave(cfg, runErr, keepWorktree))
+				}()
+			}
+
 			// Create workflow orchestrator
 			orch := workflow.NewWorkflowOrchestrator(cfg)
 			orch.Executor.NotificationHandler = notifHandler
+			orch.Executor.UsageRecorder = historyLogger
+			if worktreeDir != "" {
+				orch.SetWorkDir(worktreeDir)
+			}
 
 			// Apply output style from CLI flag (overrides config)
 			shared.ApplyOutputStyle(cmd, orch)
 
+			// Let the user review or replace the prompt (--edit-prompt, --prompt-file)
+			if err := shared.ApplyPromptFlags(cmd, orch); err != nil {
+				return err
+			}
+
+			// Stream agent output into the live view when --tui is set
+			defer shared.StartLiveOutput(cmd, orch)()
+			defer shared.StartReport(cmd, orch)()
+
 			// Build phase execution options
 			phaseOpts := workflow.PhaseExecutionOptions{
 				RunAllPhases:     runAllPhases,
@@ -267,6 +367,7 @@ The --tasks mode provides maximum context isolation:
 				FromPhase:        fromPhase,
 				TaskMode:         taskMode,
 				FromTask:         fromTask,
+				TaskFilter:       taskFilter,
 				ParallelMode:     parallelMode,
 				MaxParallel:      maxParallel,
 				UseWorktrees:     useWorktrees,
@@ -314,6 +415,7 @@ type ExecutionModeFlags struct {
 	PhaseFlag         int
 	FromPhaseFlag     int
 	FromTaskFlag      string
+	OnlyFlag          bool
 	ParallelFlag      bool
 	MaxParallelFlag   int
 	WorktreesFlag     bool
@@ -363,6 +465,13 @@ func ResolveExecutionMode(flags ExecutionModeFlags, flagsChanged bool, configMet
 		return result
 	}
 
+	// --only selects tasks, which are run in task mode
+	if flags.OnlyFlag {
+		result.RunAllPhases = false
+		result.TaskMode = true
+		return result
+	}
+
 	// If --single-session flag is explicitly set, ensure phase/task modes are disabled
 	if flags.SingleSessionFlag {
 		result.RunAllPhases = false
@@ -389,6 +498,8 @@ func ResolveExecutionMode(flags ExecutionModeFlags, flagsChanged bool, configMet
 
 func init() {
 	implementCmd.GroupID = shared.GroupCoreStages
+	implementCmd.ValidArgsFunction = shared.FirstArg(shared.CompleteSpecNames)
+	implementCmd.RunE = shared.WithStageReport(string(workflow.StageImplement), implementCmd.RunE)
 
 	// Command-specific flags
 	implementCmd.Flags().Bool("resume", false, "Resume implementation from where it left off")
@@ -402,10 +513,18 @@ func init() {
 	// Task execution flags
 	implementCmd.Flags().Bool("tasks", false, "Run each task in a separate Claude session (finest granularity)")
 	implementCmd.Flags().String("from-task", "", "Start execution from a specific task ID (e.g., --from-task T003)")
+	_ = implementCmd.RegisterFlagCompletionFunc("from-task", shared.CompleteTaskIDs)
+	implementCmd.Flags().String("only", "", "Run only tasks matching a filter (e.g., --only type=test, --only T010,T012-T015)")
+	_ = implementCmd.RegisterFlagCompletionFunc("only", shared.CompleteTaskIDs)
+	implementCmd.Flags().Bool("task-commits", false, "Commit after each completed task with a conventional message (e.g., feat(T014): ...)")
 
 	// Single-session flag (legacy mode)
 	implementCmd.Flags().Bool("single-session", false, "Run all tasks in one Claude session (legacy mode)")
 
+	// Worktree isolation flags
+	implementCmd.Flags().Bool("worktree", false, "Run implementation in a dedicated git worktree for the spec")
+	implementCmd.Flags().Bool("keep-worktree", false, "Keep the implement worktree after a successful run (requires --worktree)")
+
 	// Mark phase flags as mutually exclusive
 	implementCmd.MarkFlagsMutuallyExclusive("phases", "phase", "from-phase")
 
@@ -415,6 +534,12 @@ func init() {
 	implementCmd.MarkFlagsMutuallyExclusive("tasks", "phase")
 	implementCmd.MarkFlagsMutuallyExclusive("tasks", "from-phase")
 
+	// --only runs in task mode, so it excludes the other execution modes
+	implementCmd.MarkFlagsMutuallyExclusive("only", "phases")
+	implementCmd.MarkFlagsMutuallyExclusive("only", "phase")
+	implementCmd.MarkFlagsMutuallyExclusive("only", "from-phase")
+	implementCmd.MarkFlagsMutuallyExclusive("only", "single-session")
+
 	// Mark single-session as mutually exclusive with all other execution modes
 	implementCmd.MarkFlagsMutuallyExclusive("single-session", "phases")
 	implementCmd.MarkFlagsMutuallyExclusive("single-session", "phase")
@@ -435,11 +560,20 @@ func init() {
 		implementCmd.MarkFlagsMutuallyExclusive("parallel", "phase")
 		implementCmd.MarkFlagsMutuallyExclusive("parallel", "from-phase")
 		implementCmd.MarkFlagsMutuallyExclusive("parallel", "single-session")
+		implementCmd.MarkFlagsMutuallyExclusive("parallel", "only")
+		implementCmd.MarkFlagsMutuallyExclusive("parallel", "worktree")
 	}
 
 	// Agent override flag
 	shared.AddAgentFlag(implementCmd)
+	shared.AddModelFlag(implementCmd)
 
 	// Auto-commit flags
 	shared.AddAutoCommitFlags(implementCmd)
+
+	// Prompt review flags
+	shared.AddPromptFlags(implementCmd)
+
+	// Background execution flag
+	shared.AddDetachFlag(implementCmd)
 }
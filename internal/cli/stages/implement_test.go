@@ -159,6 +159,17 @@ func TestResolveExecutionMode(t *testing.T) {
 				MaxParallel:  4,
 			},
 		},
+		"only flag implies task mode over config": {
+			flags: ExecutionModeFlags{
+				OnlyFlag: true,
+			},
+			flagsChanged: true,
+			configMethod: "phases",
+			want: ExecutionModeResult{
+				TaskMode:    true,
+				MaxParallel: 4,
+			},
+		},
 		"single-session flag disables phase mode": {
 			flags: ExecutionModeFlags{
 				SingleSessionFlag: true,
@@ -587,6 +598,11 @@ func TestImplementCmd_FlagDefaults(t *testing.T) {
 			wantDefault: "",
 			checkType:   "string",
 		},
+		"task-commits default false": {
+			flagName:    "task-commits",
+			wantBoolVal: false,
+			checkType:   "bool",
+		},
 		"max-retries default 0": {
 			flagName:   "max-retries",
 			wantIntVal: 0,
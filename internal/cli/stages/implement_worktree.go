@@ -0,0 +1,100 @@
+package stages
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/worktree"
+)
+
+// isolationManager is the subset of worktree.DefaultManager used by --worktree.
+type isolationManager interface {
+	Isolate(specName, specDir string) (*worktree.Worktree, bool, error)
+	Release(wt *worktree.Worktree, specDir string) (bool, error)
+}
+
+// newIsolationManager builds the worktree manager for --worktree.
+func newIsolationManager(cfg *config.Configuration, repoRoot string, out io.Writer) isolationManager {
+	wtConfig := cfg.Worktree
+	if wtConfig == nil {
+		wtConfig = worktree.DefaultConfig()
+	}
+	return worktree.NewManager(wtConfig, cfg.StateDir, repoRoot, worktree.WithStdout(out))
+}
+
+// implementWorktree is an isolated worktree the implement stage runs in.
+// The process stays in its original directory; the agent and per-task
+// commits are pointed at the worktree via WorkflowOrchestrator.SetWorkDir.
+type implementWorktree struct {
+	manager  isolationManager
+	wt       *worktree.Worktree
+	out      io.Writer
+	specDir  string // Spec directory in the main checkout
+	specsDir string // cfg.SpecsDir before it was rebased into the worktree
+}
+
+// enterImplementWorktree creates (or reuses) the spec's implement worktree and
+// points cfg.SpecsDir at the worktree's copy of the specs.
+func enterImplementWorktree(cfg *config.Configuration, specName, specDir string, out io.Writer) (*implementWorktree, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("getting working directory: %w", err)
+	}
+	repoRoot, err := worktree.GetRepoRoot(cwd)
+	if err != nil {
+		return nil, fmt.Errorf("getting repository root: %w", err)
+	}
+
+	manager := newIsolationManager(cfg, repoRoot, out)
+	wt, reused, err := manager.Isolate(specName, specDir)
+	if err != nil {
+		return nil, fmt.Errorf("creating implement worktree: %w", err)
+	}
+	verb := "Created"
+	if reused {
+		verb = "Reusing"
+	}
+	fmt.Fprintf(out, "%s implement worktree: %s (branch %s)\n", verb, wt.Path, wt.Branch)
+
+	iw := &implementWorktree{manager: manager, wt: wt, out: out, specDir: specDir, specsDir: cfg.SpecsDir}
+	cfg.SpecsDir = rebaseIntoWorktree(cfg.SpecsDir, cwd, repoRoot, wt.Path)
+	return iw, nil
+}
+
+// rebaseIntoWorktree maps a path inside the repository to the same location
+// in the worktree. Relative paths are resolved against cwd first. Paths
+// outside the repository are returned unchanged.
+func rebaseIntoWorktree(path, cwd, repoRoot, worktreePath string) string {
+	abs := path
+	if !filepath.IsAbs(abs) {
+		abs = filepath.Join(cwd, path)
+	}
+	rel, err := filepath.Rel(repoRoot, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.Join(worktreePath, rel)
+}
+
+// leave restores cfg.SpecsDir. After a successful run the spec directory is
+// copied back and the worktree removed, unless keep is set or it has other
+// uncommitted changes; otherwise its location is printed for review.
+func (iw *implementWorktree) leave(cfg *config.Configuration, runErr error, keep bool) error {
+	cfg.SpecsDir = iw.specsDir
+	if runErr == nil && !keep {
+		removed, err := iw.manager.Release(iw.wt, iw.specDir)
+		if err != nil {
+			return fmt.Errorf("removing implement worktree: %w", err)
+		}
+		if removed {
+			fmt.Fprintf(iw.out, "Removed implement worktree; changes are on branch %s\n", iw.wt.Branch)
+			return nil
+		}
+	}
+	fmt.Fprintf(iw.out, "Kept implement worktree: %s (branch %s)\n", iw.wt.Path, iw.wt.Branch)
+	return nil
+}
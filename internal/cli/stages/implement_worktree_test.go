@@ -0,0 +1,127 @@
+// Package stages tests implement --worktree isolation for autospec.
+// Related: internal/cli/stages/implement_worktree.go
+// Tags: stages, cli, implement, worktree
+
+package stages
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/worktree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeIsolationManager struct {
+	released   bool
+	removed    bool
+	releaseErr error
+}
+
+func (f *fakeIsolationManager) Isolate(specName, _ string) (*worktree.Worktree, bool, error) {
+	name := worktree.IsolatedName(specName)
+	return &worktree.Worktree{Name: name, Branch: name}, false, nil
+}
+
+func (f *fakeIsolationManager) Release(*worktree.Worktree, string) (bool, error) {
+	f.released = true
+	return f.removed, f.releaseErr
+}
+
+func TestRebaseIntoWorktree(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		path string
+		want string
+	}{
+		"relative path resolves from cwd": {path: "./specs", want: "/wt/app/specs"},
+		"absolute path inside repo":       {path: "/repo/specs", want: "/wt/specs"},
+		"absolute path outside repo":      {path: "/elsewhere/specs", want: "/elsewhere/specs"},
+		"repo root maps to worktree root": {path: "/repo", want: "/wt"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, rebaseIntoWorktree(tt.path, "/repo/app", "/repo", "/wt"))
+		})
+	}
+}
+
+func TestImplementWorktree_Leave(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		runErr      error
+		keep        bool
+		manager     *fakeIsolationManager
+		wantRelease bool
+		wantErr     bool
+		wantOutput  string
+	}{
+		"successful run removes clean worktree": {
+			manager:     &fakeIsolationManager{removed: true},
+			wantRelease: true,
+			wantOutput:  "Removed implement worktree",
+		},
+		"dirty worktree is kept": {
+			manager:     &fakeIsolationManager{},
+			wantRelease: true,
+			wantOutput:  "Kept implement worktree",
+		},
+		"failed run keeps worktree": {
+			runErr:     errors.New("agent failed"),
+			manager:    &fakeIsolationManager{removed: true},
+			wantOutput: "Kept implement worktree",
+		},
+		"keep flag skips removal": {
+			keep:       true,
+			manager:    &fakeIsolationManager{removed: true},
+			wantOutput: "Kept implement worktree",
+		},
+		"release error is returned": {
+			manager:     &fakeIsolationManager{releaseErr: errors.New("locked")},
+			wantRelease: true,
+			wantErr:     true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			cfg := &config.Configuration{SpecsDir: "/wt/specs"}
+			iw := &implementWorktree{
+				manager:  tt.manager,
+				wt:       &worktree.Worktree{Path: "/wt", Branch: "003-auth-implement"},
+				specDir:  "/repo/specs/003-auth",
+				out:      &out,
+				specsDir: "/repo/specs",
+			}
+
+			err := iw.leave(cfg, tt.runErr, tt.keep)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Contains(t, out.String(), tt.wantOutput)
+			}
+			assert.Equal(t, tt.wantRelease, tt.manager.released)
+			assert.Equal(t, "/repo/specs", cfg.SpecsDir, "specs dir is restored")
+		})
+	}
+}
+
+func TestImplementCmd_WorktreeFlags(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"worktree", "keep-worktree"} {
+		flag := implementCmd.Flags().Lookup(name)
+		require.NotNil(t, flag, "flag %s should be registered", name)
+		assert.Equal(t, "false", flag.DefValue)
+	}
+}
@@ -35,7 +35,10 @@ You can optionally provide a prompt to guide the planning process.`,
   autospec plan "Focus on security best practices"
 
   # Run planning with performance considerations
-  autospec plan "Optimize for low-latency API responses"`,
+  autospec plan "Optimize for low-latency API responses"
+
+  # Update plan.yaml after spec.yaml changed
+  autospec plan --refresh`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true // Don't show help for execution errors
 		// Get optional prompt from args
@@ -43,6 +46,9 @@ You can optionally provide a prompt to guide the planning process.`,
 		if len(args) > 0 {
 			prompt = strings.Join(args, " ")
 		}
+		if refresh, _ := cmd.Flags().GetBool("refresh"); refresh {
+			prompt = workflow.RefreshGuidance(workflow.StagePlan, prompt)
+		}
 
 		// Get flags
 		configPath, _ := cmd.Flags().GetString("config")
@@ -71,6 +77,7 @@ You can optionally provide a prompt to guide the planning process.`,
 		if _, err := shared.ApplyAgentOverride(cmd, cfg); err != nil {
 			return err
 		}
+		shared.ApplyModelOverride(cmd, cfg)
 
 		// Apply auto-commit override from flags
 		shared.ApplyAutoCommitOverride(cmd, cfg)
@@ -79,7 +86,7 @@ You can optionally provide a prompt to guide the planning process.`,
 		lifecycle.ShowAutoCommitNoticeIfNeeded(cfg.StateDir, cfg.AutoCommitSource)
 
 		// Check if constitution exists (required for plan)
-		constitutionCheck := workflow.CheckConstitutionExists()
+		constitutionCheck := workflow.CheckConstitutionAt(cfg.Constitution)
 		if !constitutionCheck.Exists {
 			fmt.Fprint(os.Stderr, constitutionCheck.ErrorMessage)
 			cmd.SilenceUsage = true
@@ -104,18 +111,30 @@ You can optionally provide a prompt to guide the planning process.`,
 
 		// Create notification handler and history logger
 		notifHandler := notify.NewHandler(cfg.Notifications)
-		historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
+		historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+		historyLogger.Shared = cfg.SharedHistory()
 		specName := fmt.Sprintf("%s-%s", metadata.Number, metadata.Name)
+		shared.SetStageReportSpec(cmd, cfg.StateDir, specName, metadata.Directory)
 
 		// Wrap command execution with lifecycle for timing, notification, and history
 		return lifecycle.RunWithHistory(notifHandler, historyLogger, "plan", specName, func() error {
 			// Create workflow orchestrator
 			orch := workflow.NewWorkflowOrchestrator(cfg)
 			orch.Executor.NotificationHandler = notifHandler
+			orch.Executor.UsageRecorder = historyLogger
 
 			// Apply output style from CLI flag (overrides config)
 			shared.ApplyOutputStyle(cmd, orch)
 
+			// Let the user review or replace the prompt (--edit-prompt, --prompt-file)
+			if err := shared.ApplyPromptFlags(cmd, orch); err != nil {
+				return err
+			}
+
+			// Stream agent output into the live view when --tui is set
+			defer shared.StartLiveOutput(cmd, orch)()
+			defer shared.StartReport(cmd, orch)()
+
 			// Execute plan stage
 			if err := orch.ExecutePlan("", prompt); err != nil {
 				return fmt.Errorf("plan stage failed: %w", err)
@@ -128,13 +147,19 @@ You can optionally provide a prompt to guide the planning process.`,
 
 func init() {
 	planCmd.GroupID = shared.GroupCoreStages
+	planCmd.RunE = shared.WithStageReport(string(workflow.StagePlan), planCmd.RunE)
 
 	// Command-specific flags
 	planCmd.Flags().IntP("max-retries", "r", 0, "Override max retry attempts (overrides config when set)")
+	planCmd.Flags().Bool("refresh", false, "Update the existing plan.yaml for changes to spec.yaml instead of starting over")
 
 	// Agent override flag
 	shared.AddAgentFlag(planCmd)
+	shared.AddModelFlag(planCmd)
 
 	// Auto-commit flags
 	shared.AddAutoCommitFlags(planCmd)
+
+	// Prompt review flags
+	shared.AddPromptFlags(planCmd)
 }
@@ -3,14 +3,17 @@ package stages
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/ariel-frischer/autospec/internal/config"
 	clierrors "github.com/ariel-frischer/autospec/internal/errors"
 	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/issue"
 	"github.com/ariel-frischer/autospec/internal/lifecycle"
 	"github.com/ariel-frischer/autospec/internal/notify"
+	"github.com/ariel-frischer/autospec/internal/spec"
 	"github.com/ariel-frischer/autospec/internal/workflow"
 	"github.com/spf13/cobra"
 )
@@ -26,7 +29,17 @@ The specify command will:
 - Generate the specification based on your feature description
 - Output the spec name for use in subsequent commands
 
-The feature description should be a clear, concise description of what you want to build.`,
+The feature description should be a clear, concise description of what you want to build.
+
+With --from-issue, the description is taken from a GitHub issue (title, body,
+labels and comments), fetched with the gh CLI or the GitHub API. Any arguments
+are appended as extra guidance, and the issue URL is recorded in spec.yaml
+under _meta.source_issue.
+
+With --template, a project template from .autospec/templates/<name>.yaml is
+added to the description: its guidance, items to seed into spec sections, and
+a checklist the spec must cover. The template name is recorded under
+_meta.template.`,
 	Example: `  # Create a new feature specification
   autospec specify "Add user authentication feature"
 
@@ -34,9 +47,17 @@ The feature description should be a clear, concise description of what you want
   autospec specify "Implement dark mode with system preference detection"
 
   # Feature with quotes in the description
-  autospec specify 'Add "remember me" checkbox to login form'`,
+  autospec specify 'Add "remember me" checkbox to login form'
+
+  # Create a specification from a GitHub issue
+  autospec specify --from-issue 42
+  autospec specify --from-issue https://github.com/owner/repo/issues/42 "Skip the admin UI"
+
+  # Use the project's API endpoint template (.autospec/templates/api.yaml)
+  autospec specify --template api "Add GET /users/{id} endpoint"`,
 	Args: func(cmd *cobra.Command, args []string) error {
-		if len(args) < 1 {
+		fromIssue, _ := cmd.Flags().GetString("from-issue")
+		if len(args) < 1 && fromIssue == "" {
 			cliErr := clierrors.MissingFeatureDescription()
 			clierrors.PrintError(cliErr)
 			return cliErr
@@ -45,8 +66,19 @@ The feature description should be a clear, concise description of what you want
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true // Don't show help for execution errors
-		// Join all args as the feature description
-		featureDescription := strings.Join(args, " ")
+		// Join all args as the feature description, or import it from an issue
+		templateName, _ := cmd.Flags().GetString("template")
+		tmpl, err := loadSpecTemplate(templateName)
+		if err != nil {
+			return err
+		}
+		featureDescription, sourceIssue, err := resolveFeatureDescription(cmd, args)
+		if err != nil {
+			return err
+		}
+		if tmpl != nil {
+			featureDescription = tmpl.Apply(featureDescription)
+		}
 
 		// Get flags
 		configPath, _ := cmd.Flags().GetString("config")
@@ -63,7 +95,8 @@ The feature description should be a clear, concise description of what you want
 
 		// Create notification handler and history logger
 		notifHandler := notify.NewHandler(cfg.Notifications)
-		historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
+		historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+		historyLogger.Shared = cfg.SharedHistory()
 
 		// Show security notice (once per user)
 		shared.ShowSecurityNotice(cmd.OutOrStdout(), cfg)
@@ -85,6 +118,7 @@ The feature description should be a clear, concise description of what you want
 			if _, err := shared.ApplyAgentOverride(cmd, cfg); err != nil {
 				return err
 			}
+			shared.ApplyModelOverride(cmd, cfg)
 
 			// Apply auto-commit override from flags
 			shared.ApplyAutoCommitOverride(cmd, cfg)
@@ -93,7 +127,7 @@ The feature description should be a clear, concise description of what you want
 			lifecycle.ShowAutoCommitNoticeIfNeeded(cfg.StateDir, cfg.AutoCommitSource)
 
 			// Check if constitution exists (required for specify)
-			constitutionCheck := workflow.CheckConstitutionExists()
+			constitutionCheck := workflow.CheckConstitutionAt(cfg.Constitution)
 			if !constitutionCheck.Exists {
 				fmt.Fprint(os.Stderr, constitutionCheck.ErrorMessage)
 				return shared.NewExitError(shared.ExitInvalidArguments)
@@ -102,15 +136,29 @@ The feature description should be a clear, concise description of what you want
 			// Create workflow orchestrator
 			orch := workflow.NewWorkflowOrchestrator(cfg)
 			orch.Executor.NotificationHandler = notifHandler
+			orch.Executor.UsageRecorder = historyLogger
 
 			// Apply output style from CLI flag (overrides config)
 			shared.ApplyOutputStyle(cmd, orch)
 
+			// Let the user review or replace the prompt (--edit-prompt, --prompt-file)
+			if err := shared.ApplyPromptFlags(cmd, orch); err != nil {
+				return err
+			}
+
+			// Stream agent output into the live view when --tui is set
+			defer shared.StartLiveOutput(cmd, orch)()
+			defer shared.StartReport(cmd, orch)()
+
 			// Execute specify stage
+			shared.SetStageReportSpec(cmd, cfg.StateDir, "", "")
 			specName, execErr := orch.ExecuteSpecify(featureDescription)
 			if execErr != nil {
 				return fmt.Errorf("specify stage failed: %w", execErr)
 			}
+			shared.SetStageReportSpec(cmd, cfg.StateDir, specName, filepath.Join(cfg.SpecsDir, specName))
+			linkSourceIssue(filepath.Join(cfg.SpecsDir, specName), sourceIssue)
+			recordSpecTemplate(filepath.Join(cfg.SpecsDir, specName), templateName)
 
 			fmt.Printf("\nSpec created: %s\n", specName)
 			return nil
@@ -120,13 +168,90 @@ The feature description should be a clear, concise description of what you want
 
 func init() {
 	specifyCmd.GroupID = shared.GroupCoreStages
+	specifyCmd.RunE = shared.WithStageReport(string(workflow.StageSpecify), specifyCmd.RunE)
 
 	// Command-specific flags
 	specifyCmd.Flags().IntP("max-retries", "r", 0, "Override max retry attempts (overrides config when set)")
+	specifyCmd.Flags().String("from-issue", "", "Create the spec from a GitHub issue (number, owner/repo#N, or URL)")
+	specifyCmd.Flags().String("template", "", "Seed the spec from a project template in .autospec/templates/<name>.yaml")
 
 	// Agent override flag
 	shared.AddAgentFlag(specifyCmd)
+	shared.AddModelFlag(specifyCmd)
 
 	// Auto-commit flags
 	shared.AddAutoCommitFlags(specifyCmd)
+
+	// Prompt review flags
+	shared.AddPromptFlags(specifyCmd)
+}
+
+// resolveFeatureDescription returns the feature description from args, or from
+// the GitHub issue named by --from-issue with any args appended as guidance.
+// The fetched issue is returned so its URL can be recorded in spec.yaml.
+func resolveFeatureDescription(cmd *cobra.Command, args []string) (string, *issue.Issue, error) {
+	fromIssue, _ := cmd.Flags().GetString("from-issue")
+	if fromIssue == "" {
+		return strings.Join(args, " "), nil, nil
+	}
+
+	ref, err := issue.ParseRef(fromIssue)
+	if err != nil {
+		return "", nil, err
+	}
+	src, err := issueFetcher().Fetch(cmd.Context(), ref)
+	if err != nil {
+		return "", nil, fmt.Errorf("importing issue: %w", err)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Imported issue #%d: %s\n", src.Number, src.Title)
+
+	description := src.FeatureDescription()
+	if len(args) > 0 {
+		description += "\nAdditional guidance:\n" + strings.Join(args, " ") + "\n"
+	}
+	return description, src, nil
+}
+
+// issueFetcher creates the fetcher used by --from-issue. Replaced in tests.
+var issueFetcher = func() *issue.Fetcher {
+	return issue.NewFetcher(issue.DefaultHTTPTimeout)
+}
+
+// linkSourceIssue records the issue URL in spec.yaml. Failure only warns,
+// since the spec itself was created successfully.
+func linkSourceIssue(specDir string, src *issue.Issue) {
+	if src == nil || src.URL == "" {
+		return
+	}
+	if err := spec.RecordSourceIssue(specDir, src.URL); err != nil {
+		fmt.Printf("Warning: failed to record source issue in spec.yaml: %v\n", err)
+		return
+	}
+	fmt.Printf("Linked issue: %s\n", src.URL)
+}
+
+// loadSpecTemplate loads the spec template selected with --template, before
+// any issue is fetched. An empty name selects no template.
+func loadSpecTemplate(name string) (*spec.Template, error) {
+	if name == "" {
+		return nil, nil
+	}
+	tmpl, err := spec.LoadTemplate(spec.TemplatesDir(), name)
+	if err != nil {
+		cliErr := clierrors.NewArgumentError(err.Error(), "Spec templates are YAML files in .autospec/templates/, selected by file name")
+		clierrors.PrintError(cliErr)
+		return nil, cliErr
+	}
+	return tmpl, nil
+}
+
+// recordSpecTemplate records the template name in spec.yaml. Failure only
+// warns, since the spec itself was created successfully.
+func recordSpecTemplate(specDir, name string) {
+	if name == "" {
+		return
+	}
+	if err := spec.RecordTemplate(specDir, name); err != nil {
+		fmt.Printf("Warning: failed to record spec template in spec.yaml: %v\n", err)
+	}
 }
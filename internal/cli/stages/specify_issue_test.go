@@ -0,0 +1,93 @@
+package stages
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/issue"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecifyArgsWithFromIssue(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("from-issue", "", "")
+	cmd.SetErr(&bytes.Buffer{})
+
+	assert.Error(t, specifyCmd.Args(cmd, []string{}), "description required without --from-issue")
+
+	require.NoError(t, cmd.Flags().Set("from-issue", "42"))
+	assert.NoError(t, specifyCmd.Args(cmd, []string{}), "--from-issue replaces the description")
+}
+
+func TestResolveFeatureDescription(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/acme/widgets/issues/7/comments" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"number": 7, "title": "Add dark mode", "body": "Theme please",
+			"html_url": "https://github.com/acme/widgets/issues/7"}`))
+	}))
+	defer server.Close()
+
+	original := issueFetcher
+	issueFetcher = func() *issue.Fetcher {
+		f := issue.NewFetcher(0)
+		f.SetAPIURL(server.URL)
+		return f
+	}
+	defer func() { issueFetcher = original }()
+
+	tests := map[string]struct {
+		fromIssue    string
+		args         []string
+		wantContains []string
+		wantIssue    bool
+		wantErr      bool
+	}{
+		"plain description": {
+			args:         []string{"Add", "login"},
+			wantContains: []string{"Add login"},
+		},
+		"from issue": {
+			fromIssue:    "acme/widgets#7",
+			wantContains: []string{"Add dark mode", "Theme please", "https://github.com/acme/widgets/issues/7"},
+			wantIssue:    true,
+		},
+		"from issue with guidance": {
+			fromIssue:    "https://github.com/acme/widgets/issues/7",
+			args:         []string{"Skip", "settings", "UI"},
+			wantContains: []string{"Add dark mode", "Additional guidance:\nSkip settings UI"},
+			wantIssue:    true,
+		},
+		"invalid reference": {
+			fromIssue: "not an issue",
+			wantErr:   true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("from-issue", tt.fromIssue, "")
+			cmd.SetContext(context.Background())
+			cmd.SetErr(&bytes.Buffer{})
+
+			description, src, err := resolveFeatureDescription(cmd, tt.args)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, want := range tt.wantContains {
+				assert.Contains(t, description, want)
+			}
+			assert.Equal(t, tt.wantIssue, src != nil)
+		})
+	}
+}
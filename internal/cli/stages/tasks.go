@@ -27,7 +27,11 @@ The tasks command will:
 - Execute the task generation workflow
 - Create tasks.yaml with actionable, dependency-ordered tasks
 
-You can optionally provide a prompt to guide the task generation.`,
+You can optionally provide a prompt to guide the task generation.
+
+With --phase N --regenerate, only phase N of the existing tasks.yaml is
+broken down again. The agent's version of that phase is merged into the
+file and every other phase is kept as it was, with its task statuses.`,
 	Example: `  # Generate tasks with default granularity
   autospec tasks
 
@@ -35,7 +39,13 @@ You can optionally provide a prompt to guide the task generation.`,
   autospec tasks "Break into small incremental steps"
 
   # Generate tasks with testing focus
-  autospec tasks "Prioritize testing tasks first"`,
+  autospec tasks "Prioritize testing tasks first"
+
+  # Update tasks.yaml after spec.yaml changed, keeping completed tasks
+  autospec tasks --refresh
+
+  # Re-break down only phase 3, leaving the other phases untouched
+  autospec tasks --phase 3 --regenerate "Split the migration into smaller steps"`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true // Don't show help for execution errors
 		// Get optional prompt from args
@@ -43,6 +53,17 @@ You can optionally provide a prompt to guide the task generation.`,
 		if len(args) > 0 {
 			prompt = strings.Join(args, " ")
 		}
+		phase, _ := cmd.Flags().GetInt("phase")
+		regenerate, _ := cmd.Flags().GetBool("regenerate")
+		if regenerate && phase < 1 {
+			return fmt.Errorf("--regenerate requires --phase N")
+		}
+		if phase != 0 && !regenerate {
+			return fmt.Errorf("--phase is only supported with --regenerate")
+		}
+		if refresh, _ := cmd.Flags().GetBool("refresh"); refresh {
+			prompt = workflow.RefreshGuidance(workflow.StageTasks, prompt)
+		}
 
 		// Get flags
 		configPath, _ := cmd.Flags().GetString("config")
@@ -71,6 +92,7 @@ You can optionally provide a prompt to guide the task generation.`,
 		if _, err := shared.ApplyAgentOverride(cmd, cfg); err != nil {
 			return err
 		}
+		shared.ApplyModelOverride(cmd, cfg)
 
 		// Apply auto-commit override from flags
 		shared.ApplyAutoCommitOverride(cmd, cfg)
@@ -79,7 +101,7 @@ You can optionally provide a prompt to guide the task generation.`,
 		lifecycle.ShowAutoCommitNoticeIfNeeded(cfg.StateDir, cfg.AutoCommitSource)
 
 		// Check if constitution exists (required for tasks)
-		constitutionCheck := workflow.CheckConstitutionExists()
+		constitutionCheck := workflow.CheckConstitutionAt(cfg.Constitution)
 		if !constitutionCheck.Exists {
 			fmt.Fprint(os.Stderr, constitutionCheck.ErrorMessage)
 			cmd.SilenceUsage = true
@@ -104,18 +126,38 @@ You can optionally provide a prompt to guide the task generation.`,
 
 		// Create notification handler and history logger
 		notifHandler := notify.NewHandler(cfg.Notifications)
-		historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
+		historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+		historyLogger.Shared = cfg.SharedHistory()
 		specName := fmt.Sprintf("%s-%s", metadata.Number, metadata.Name)
+		shared.SetStageReportSpec(cmd, cfg.StateDir, specName, metadata.Directory)
 
 		// Wrap command execution with lifecycle for timing, notification, and history
 		return lifecycle.RunWithHistory(notifHandler, historyLogger, "tasks", specName, func() error {
 			// Create workflow orchestrator
 			orch := workflow.NewWorkflowOrchestrator(cfg)
 			orch.Executor.NotificationHandler = notifHandler
+			orch.Executor.UsageRecorder = historyLogger
 
 			// Apply output style from CLI flag (overrides config)
 			shared.ApplyOutputStyle(cmd, orch)
 
+			// Let the user review or replace the prompt (--edit-prompt, --prompt-file)
+			if err := shared.ApplyPromptFlags(cmd, orch); err != nil {
+				return err
+			}
+
+			// Stream agent output into the live view when --tui is set
+			defer shared.StartLiveOutput(cmd, orch)()
+			defer shared.StartReport(cmd, orch)()
+
+			// Regenerate one phase, merging it into the existing tasks.yaml
+			if regenerate {
+				if err := orch.ExecuteTasksPhase("", prompt, phase); err != nil {
+					return fmt.Errorf("tasks stage failed: %w", err)
+				}
+				return nil
+			}
+
 			// Execute tasks stage
 			if err := orch.ExecuteTasks("", prompt); err != nil {
 				return fmt.Errorf("tasks stage failed: %w", err)
@@ -128,13 +170,22 @@ You can optionally provide a prompt to guide the task generation.`,
 
 func init() {
 	tasksCmd.GroupID = shared.GroupCoreStages
+	tasksCmd.RunE = shared.WithStageReport(string(workflow.StageTasks), tasksCmd.RunE)
 
 	// Command-specific flags
 	tasksCmd.Flags().IntP("max-retries", "r", 0, "Override max retry attempts (overrides config when set)")
+	tasksCmd.Flags().Bool("refresh", false, "Update the existing tasks.yaml for changes to spec.yaml instead of starting over")
+	tasksCmd.Flags().Int("phase", 0, "Phase whose tasks to regenerate (with --regenerate)")
+	tasksCmd.Flags().Bool("regenerate", false, "Regenerate only the tasks of --phase, keeping all other phases")
+	tasksCmd.MarkFlagsMutuallyExclusive("refresh", "regenerate")
 
 	// Agent override flag
 	shared.AddAgentFlag(tasksCmd)
+	shared.AddModelFlag(tasksCmd)
 
 	// Auto-commit flags
 	shared.AddAutoCommitFlags(tasksCmd)
+
+	// Prompt review flags
+	shared.AddPromptFlags(tasksCmd)
 }
@@ -10,21 +10,42 @@ var taskCmd = &cobra.Command{
 	Long: `Commands for managing tasks in the current feature's tasks.yaml file.
 
 Available subcommands:
+  add       Add a task to a phase
+  edit      Edit a task's title, type, dependencies and other fields
+  remove    Remove a task and drop it from other tasks' dependencies
+  start     Mark a task as InProgress
+  complete  Mark a task as Completed
   block     Block a task with a reason
-  unblock   Unblock a task and set its status
+  unblock   Unblock a task and restore or set its status
+  blocked   List blocked tasks across all specs
+  skip      Skip (defer) a task with a reason
   list      List tasks with optional status filters
 
-These commands provide a convenient way to update task statuses and track
-blocking reasons without manually editing the YAML file.`,
+These commands provide a convenient way to update tasks, statuses and
+blocking reasons without manually editing the YAML file. Comments and key
+order in tasks.yaml are preserved.`,
 	Example: `  # Block a task with a reason
   autospec task block T001 --reason "Waiting for API access"
 
-  # Unblock a task (defaults to Pending status)
+  # Unblock a task, restoring its status from before it was blocked
   autospec task unblock T001
 
+  # Triage blocked tasks across all specs
+  autospec task blocked
+
   # Unblock a task and set to InProgress
   autospec task unblock T001 --status InProgress
 
+  # Defer a task so the workflow can finish without it
+  autospec task skip T012 --reason "Deferred to 004-metrics"
+
+  # Add a task to phase 2
+  autospec task add --title "Add retry metrics" --phase 2 --depends T004
+
+  # Start and complete a task
+  autospec task start T005
+  autospec task complete T005
+
   # List all blocked tasks
   autospec task list --blocked
 
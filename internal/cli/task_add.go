@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var taskAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a task to tasks.yaml",
+	Long: `Add a new Pending task to a phase of the current feature's tasks.yaml.
+
+The task ID defaults to the next free ID (e.g. T013 after T012). The task is
+appended to the last phase unless --phase is given. Dependencies must refer to
+existing tasks. summary.total_tasks is updated when present.`,
+	Example: `  # Add a task to the last phase
+  autospec task add --title "Add retry metrics"
+
+  # Add a test task to phase 2 that depends on T004
+  autospec task add --title "Test retry metrics" --type test --phase 2 --depends T004
+
+  # Add a task with a file path and acceptance criteria
+  autospec task add --title "Document metrics" --type documentation \
+    --file docs/metrics.md --criteria "Lists every metric" --criteria "Has examples"`,
+	Args: cobra.NoArgs,
+	RunE: runTaskAdd,
+}
+
+func init() {
+	taskAddCmd.Flags().String("title", "", "Task title (required)")
+	taskAddCmd.Flags().String("id", "", "Task ID (default: next free ID)")
+	taskAddCmd.Flags().String("type", "implementation", "Task type (setup, implementation, test, documentation, refactor)")
+	taskAddCmd.Flags().Int("phase", 0, "Phase number to add the task to (default: last phase)")
+	taskAddCmd.Flags().StringSlice("depends", nil, "IDs of tasks this task depends on")
+	taskAddCmd.Flags().Bool("parallel", false, "Mark the task as safe to run in parallel")
+	taskAddCmd.Flags().String("file", "", "Primary file path for the task")
+	taskAddCmd.Flags().String("story", "", "Related user story ID (e.g. US-001)")
+	taskAddCmd.Flags().StringArray("criteria", nil, "Acceptance criterion (repeatable)")
+	_ = taskAddCmd.MarkFlagRequired("title")
+	taskCmd.AddCommand(taskAddCmd)
+}
+
+// newTask holds the fields of a task created by 'autospec task add'.
+type newTask struct {
+	ID           string
+	Title        string
+	Type         string
+	Phase        int
+	Parallel     bool
+	StoryID      string
+	FilePath     string
+	Dependencies []string
+	Criteria     []string
+}
+
+func runTaskAdd(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	task := newTask{}
+	task.Title, _ = flags.GetString("title")
+	task.ID, _ = flags.GetString("id")
+	task.Type, _ = flags.GetString("type")
+	task.Phase, _ = flags.GetInt("phase")
+	task.Parallel, _ = flags.GetBool("parallel")
+	task.StoryID, _ = flags.GetString("story")
+	task.FilePath, _ = flags.GetString("file")
+	task.Dependencies, _ = flags.GetStringSlice("depends")
+	task.Criteria, _ = flags.GetStringArray("criteria")
+
+	if task.Title == "" {
+		return fmt.Errorf("task title cannot be empty")
+	}
+
+	tasksPath, root, unlock, err := loadTasksDocument(cmd)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	id, err := addTask(root, task)
+	if err != nil {
+		return err
+	}
+	if err := writeTasksDocument(tasksPath, root); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Task %s added: %s\n", id, task.Title)
+	return nil
+}
+
+// addTask appends a Pending task to its phase and returns the task's ID.
+func addTask(root *yaml.Node, task newTask) (string, error) {
+	existing := collectTaskNodes(root)
+	if task.ID == "" {
+		task.ID = nextTaskID(existing)
+	} else if !taskIDPattern.MatchString(task.ID) {
+		return "", fmt.Errorf("invalid task ID format: %s (expected T followed by digits, e.g., T001)", task.ID)
+	} else if findTaskRef(root, task.ID) != nil {
+		return "", fmt.Errorf("task already exists: %s", task.ID)
+	}
+	if err := validateTaskType(task.Type); err != nil {
+		return "", err
+	}
+	if err := validateDependencies(root, task.ID, task.Dependencies); err != nil {
+		return "", err
+	}
+
+	list, err := findPhaseTasks(root, task.Phase)
+	if err != nil {
+		return "", err
+	}
+
+	style := scalarStyle(existing)
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	setMappingNode(node, "id", stringNode(task.ID, style))
+	setMappingNode(node, "title", stringNode(task.Title, style))
+	setMappingNode(node, "status", stringNode("Pending", style))
+	setMappingNode(node, "type", stringNode(task.Type, style))
+	setMappingNode(node, "parallel", boolNode(task.Parallel))
+	if task.StoryID != "" {
+		setMappingNode(node, "story_id", stringNode(task.StoryID, style))
+	}
+	if task.FilePath != "" {
+		setMappingNode(node, "file_path", stringNode(task.FilePath, style))
+	}
+	setMappingNode(node, "dependencies", stringListNode(task.Dependencies, style, true))
+	setMappingNode(node, "acceptance_criteria", stringListNode(task.Criteria, style, len(task.Criteria) == 0))
+
+	list.Content = append(list.Content, node)
+	updateTaskSummary(root)
+	return task.ID, nil
+}
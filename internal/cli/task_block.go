@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/ariel-frischer/autospec/internal/config"
 	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/filelock"
 	"github.com/ariel-frischer/autospec/internal/spec"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -25,6 +29,10 @@ This command sets the task status to 'Blocked' and stores the blocking reason
 in the blocked_reason field. The reason helps track external dependencies,
 waiting conditions, or other blockers that prevent task completion.
 
+The time the task was blocked and its previous status are recorded in
+blocked_at and blocked_from: 'autospec task blocked' shows how long tasks
+have been blocked, and 'autospec task unblock' restores the status.
+
 If the task is already blocked, the reason is updated with the new value.`,
 	Example: `  # Block a task waiting for API access
   autospec task block T001 --reason "Waiting for API access from third-party"
@@ -34,8 +42,9 @@ If the task is already blocked, the reason is updated with the new value.`,
 
   # Update the reason for an already blocked task
   autospec task block T001 --reason "Updated: API access approved, waiting for credentials"`,
-	Args: cobra.ExactArgs(1),
-	RunE: runTaskBlock,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteTaskIDs),
+	RunE:              runTaskBlock,
 }
 
 func init() {
@@ -79,6 +88,13 @@ func runTaskBlock(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("tasks.yaml not found: %s\nRun /autospec.tasks first to generate tasks", tasksPath)
 	}
 
+	// Lock tasks.yaml against other autospec processes until written back
+	unlock, err := filelock.Lock(tasksPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Read and parse tasks.yaml
 	data, err := os.ReadFile(tasksPath)
 	if err != nil {
@@ -96,6 +112,9 @@ func runTaskBlock(cmd *cobra.Command, args []string) error {
 	if !result.found {
 		return fmt.Errorf("task not found: %s\nCheck that the task ID exists in: %s", taskID, tasksPath)
 	}
+	if result.previousStatus != "Blocked" {
+		recordBlockedState(&root, taskID, result.previousStatus, time.Now())
+	}
 
 	// Write back the updated YAML
 	output, err := yaml.Marshal(&root)
@@ -103,7 +122,7 @@ func runTaskBlock(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("serializing tasks.yaml: %w", err)
 	}
 
-	if err := os.WriteFile(tasksPath, output, 0644); err != nil {
+	if err := atomicfile.WriteFile(tasksPath, output, 0644); err != nil {
 		return fmt.Errorf("writing tasks.yaml: %w", err)
 	}
 
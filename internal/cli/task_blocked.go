@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var taskBlockedCmd = &cobra.Command{
+	Use:   "blocked",
+	Short: "List blocked tasks across all specs",
+	Long: `List the blocked tasks of every spec in the specs directory, with their
+reasons and how long they have been blocked.
+
+The age is known for tasks blocked with 'autospec task block' or
+'autospec update-task', which record when the task was blocked and its
+status before. 'autospec task unblock' restores that status.`,
+	Example: `  # Triage blocked tasks
+  autospec task blocked`,
+	Args: cobra.NoArgs,
+	RunE: runTaskBlocked,
+}
+
+func init() {
+	taskCmd.AddCommand(taskBlockedCmd)
+}
+
+// blockedSpecTasks holds the blocked tasks of one spec.
+type blockedSpecTasks struct {
+	Spec  string
+	Tasks []validation.TaskItem
+}
+
+func runTaskBlocked(cmd *cobra.Command, args []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
+	groups, err := collectBlockedTasks(cfg.SpecsDir, cmd.ErrOrStderr())
+	if err != nil {
+		return err
+	}
+	printBlockedTasks(cmd.OutOrStdout(), groups, time.Now())
+	return nil
+}
+
+// collectBlockedTasks returns the blocked tasks of each spec in specsDir,
+// skipping specs without blocked tasks. Unreadable tasks files are reported
+// to warn and skipped.
+func collectBlockedTasks(specsDir string, warn io.Writer) ([]blockedSpecTasks, error) {
+	specs, err := spec.ListSpecs(specsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []blockedSpecTasks
+	for _, name := range specs {
+		tasksPath := validation.GetTasksFilePath(filepath.Join(specsDir, name))
+		if _, err := os.Stat(tasksPath); err != nil {
+			continue
+		}
+		tasks, err := validation.GetAllTasks(tasksPath)
+		if err != nil {
+			fmt.Fprintf(warn, "Warning: skipping %s: %v\n", name, err)
+			continue
+		}
+		group := blockedSpecTasks{Spec: name}
+		for _, task := range tasks {
+			if task.Status == "Blocked" {
+				group.Tasks = append(group.Tasks, task)
+			}
+		}
+		if len(group.Tasks) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+// printBlockedTasks prints the blocked tasks grouped by spec, with their age
+// as of now.
+func printBlockedTasks(w io.Writer, groups []blockedSpecTasks, now time.Time) {
+	total := 0
+	for _, group := range groups {
+		total += len(group.Tasks)
+	}
+	if total == 0 {
+		fmt.Fprintln(w, "No blocked tasks.")
+		return
+	}
+
+	fmt.Fprintf(w, "Blocked tasks (%d):\n", total)
+	for _, group := range groups {
+		fmt.Fprintf(w, "\n  %s\n", group.Spec)
+		for _, task := range group.Tasks {
+			fmt.Fprintf(w, "    [!] %s %s (%s)\n", task.ID, task.Title, blockedAge(task.BlockedAt, now))
+			reason := task.BlockedReason
+			if reason == "" {
+				reason = "(no reason provided)"
+			}
+			fmt.Fprintf(w, "        Reason: %s\n", truncateReason(reason, 80))
+		}
+	}
+	fmt.Fprintln(w, "\nUnblock with: autospec task unblock <task-id>")
+}
+
+// blockedAge describes how long ago blockedAt (RFC 3339) was, e.g.
+// "blocked 3d ago".
+func blockedAge(blockedAt string, now time.Time) string {
+	at, err := time.Parse(time.RFC3339, blockedAt)
+	if err != nil {
+		return "blocked, age unknown"
+	}
+	age := now.Sub(at)
+	switch {
+	case age < time.Minute:
+		return "blocked just now"
+	case age < time.Hour:
+		return fmt.Sprintf("blocked %dm ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("blocked %dh ago", int(age.Hours()))
+	default:
+		return fmt.Sprintf("blocked %dd ago", int(age.Hours()/24))
+	}
+}
+
+// recordBlockedState stores when the task was blocked and its status before,
+// so task blocked can show its age and task unblock can restore the status.
+func recordBlockedState(root *yaml.Node, taskID, previous string, now time.Time) {
+	ref := findTaskRef(root, taskID)
+	if ref == nil {
+		return
+	}
+	style := mappingValue(ref.node, "status").Style
+	after := "status"
+	if mappingValue(ref.node, "blocked_reason") != nil {
+		after = "blocked_reason"
+	}
+	insertMappingScalar(ref.node, after, "blocked_at", now.UTC().Format(time.RFC3339), style)
+	insertMappingScalar(ref.node, "blocked_at", "blocked_from", previous, style)
+}
+
+// clearBlockedState removes the fields written by recordBlockedState.
+func clearBlockedState(task *yaml.Node) {
+	removeMappingKey(task, "blocked_at")
+	removeMappingKey(task, "blocked_from")
+}
+
+// blockedFromStatus returns the status a blocked task had before it was
+// blocked, or Pending if that wasn't recorded.
+func blockedFromStatus(root *yaml.Node, taskID string) string {
+	ref := findTaskRef(root, taskID)
+	if ref == nil {
+		return "Pending"
+	}
+	from := mappingValue(ref.node, "blocked_from")
+	if from == nil || from.Value == "Blocked" || !slices.Contains(validStatuses, from.Value) {
+		return "Pending"
+	}
+	return from.Value
+}
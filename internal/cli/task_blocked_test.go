@@ -0,0 +1,212 @@
+// Package cli_test tests the task blocked subcommand and the blocked state kept for task unblock.
+// Related: internal/cli/task_blocked.go, internal/cli/task_block.go, internal/cli/task_unblock.go
+// Tags: cli, task, blocked, unblock, triage
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockedAge(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		blockedAt string
+		want      string
+	}{
+		"just now":    {blockedAt: "2026-03-10T11:59:30Z", want: "blocked just now"},
+		"minutes":     {blockedAt: "2026-03-10T11:45:00Z", want: "blocked 15m ago"},
+		"hours":       {blockedAt: "2026-03-10T07:00:00Z", want: "blocked 5h ago"},
+		"days":        {blockedAt: "2026-03-07T10:00:00Z", want: "blocked 3d ago"},
+		"other zone":  {blockedAt: "2026-03-10T13:00:00+02:00", want: "blocked 1h ago"},
+		"not set":     {blockedAt: "", want: "blocked, age unknown"},
+		"unparseable": {blockedAt: "last week", want: "blocked, age unknown"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, blockedAge(tt.blockedAt, now))
+		})
+	}
+}
+
+func TestRecordBlockedState(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		taskID   string
+		previous string
+		want     string
+	}{
+		"after reason": {
+			taskID:   "T002",
+			previous: "InProgress",
+			want: `blocked_reason: "Waiting for API"
+        blocked_at: "2026-03-10T12:00:00Z"
+        blocked_from: "InProgress"`,
+		},
+		"after status": {
+			taskID:   "T003",
+			previous: "Pending",
+			want: `status: "Pending"
+        blocked_at: "2026-03-10T12:00:00Z"
+        blocked_from: "Pending"`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			root := parseCrudTasks(t)
+			recordBlockedState(root, tt.taskID, tt.previous, time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC))
+			assert.Contains(t, writeAndRead(t, root), tt.want)
+			assert.Equal(t, tt.previous, blockedFromStatus(root, tt.taskID))
+
+			clearBlockedState(findTaskRef(root, tt.taskID).node)
+			assert.NotContains(t, writeAndRead(t, root), "blocked_at")
+			assert.Equal(t, "Pending", blockedFromStatus(root, tt.taskID))
+		})
+	}
+}
+
+func TestBlockedFromStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		taskID   string
+		recorded string
+		want     string
+	}{
+		"restores in progress": {taskID: "T002", recorded: "InProgress", want: "InProgress"},
+		"restores completed":   {taskID: "T002", recorded: "Completed", want: "Completed"},
+		"not recorded":         {taskID: "T002", want: "Pending"},
+		"blocked falls back":   {taskID: "T002", recorded: "Blocked", want: "Pending"},
+		"invalid falls back":   {taskID: "T002", recorded: "Waiting", want: "Pending"},
+		"missing task":         {taskID: "T404", want: "Pending"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			root := parseCrudTasks(t)
+			if tt.recorded != "" {
+				recordBlockedState(root, tt.taskID, tt.recorded, time.Now())
+			}
+			assert.Equal(t, tt.want, blockedFromStatus(root, tt.taskID))
+		})
+	}
+}
+
+func TestUnblockRestoresPreviousStatus(t *testing.T) {
+	t.Parallel()
+
+	root := parseCrudTasks(t)
+	result := findAndBlockTask(root, "T003", "Waiting for review")
+	require.True(t, result.found)
+	recordBlockedState(root, "T003", result.previousStatus, time.Now())
+
+	target := blockedFromStatus(root, "T003")
+	unblocked := findAndUnblockTask(root, "T003", target)
+	require.True(t, unblocked.found)
+	clearBlockedState(findTaskRef(root, "T003").node)
+
+	task := findTaskRef(root, "T003").node
+	assert.Equal(t, "Pending", mappingValue(task, "status").Value)
+	for _, key := range []string{"blocked_reason", "blocked_at", "blocked_from"} {
+		assert.Nil(t, mappingValue(task, key), key)
+	}
+}
+
+func TestCollectBlockedTasks(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	writeSpec := func(name, content string) {
+		dir := filepath.Join(specsDir, name)
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "tasks.yaml"), []byte(content), 0o644))
+	}
+	writeSpec("001-core", crudTasksYAML)
+	writeSpec("002-done", `phases:
+  - number: 1
+    title: "Setup"
+    tasks:
+      - id: "T001"
+        title: "Done"
+        status: "Completed"
+        type: "setup"
+`)
+	require.NoError(t, os.MkdirAll(filepath.Join(specsDir, "003-empty"), 0o755))
+
+	var warn bytes.Buffer
+	groups, err := collectBlockedTasks(specsDir, &warn)
+	require.NoError(t, err)
+	assert.Empty(t, warn.String())
+	require.Len(t, groups, 1)
+	assert.Equal(t, "001-core", groups[0].Spec)
+	require.Len(t, groups[0].Tasks, 1)
+	assert.Equal(t, "T002", groups[0].Tasks[0].ID)
+	assert.Equal(t, "Waiting for API", groups[0].Tasks[0].BlockedReason)
+}
+
+func TestPrintBlockedTasks(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		groups []blockedSpecTasks
+		want   []string
+	}{
+		"none": {
+			want: []string{"No blocked tasks."},
+		},
+		"grouped by spec": {
+			groups: []blockedSpecTasks{
+				{Spec: "001-core", Tasks: []validation.TaskItem{
+					{ID: "T002", Title: "Implement core", Status: "Blocked", BlockedReason: "Waiting for API", BlockedAt: "2026-03-08T12:00:00Z"},
+				}},
+				{Spec: "002-auth", Tasks: []validation.TaskItem{
+					{ID: "T005", Title: "Add login", Status: "Blocked"},
+				}},
+			},
+			want: []string{
+				"Blocked tasks (2):",
+				"  001-core\n    [!] T002 Implement core (blocked 2d ago)\n        Reason: Waiting for API",
+				"  002-auth\n    [!] T005 Add login (blocked, age unknown)\n        Reason: (no reason provided)",
+				"Unblock with: autospec task unblock <task-id>",
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+			printBlockedTasks(&out, tt.groups, now)
+			for _, want := range tt.want {
+				assert.Contains(t, out.String(), want)
+			}
+		})
+	}
+}
+
+func TestTaskBlockedCmdRegistration(t *testing.T) {
+	t.Parallel()
+
+	cmd, _, err := taskCmd.Find([]string{"blocked"})
+	require.NoError(t, err)
+	assert.Equal(t, "blocked", cmd.Name())
+}
@@ -0,0 +1,340 @@
+// Package cli_test tests the task add, edit, remove, start, complete and skip subcommands.
+// Related: internal/cli/task_add.go, internal/cli/task_edit.go, internal/cli/task_remove.go, internal/cli/task_status.go, internal/cli/task_skip.go, internal/cli/task_nodes.go
+// Tags: cli, task, crud, yaml, comments
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const crudTasksYAML = `# Generated by autospec
+summary:
+  total_tasks: 3
+phases:
+  - number: 1
+    title: "Setup"
+    tasks:
+      - id: "T001"
+        title: "Create scaffolding" # keep me
+        status: "Completed"
+        type: "setup"
+        parallel: false
+        dependencies: []
+        acceptance_criteria: []
+  - number: 2
+    title: "Core"
+    tasks:
+      - id: "T002"
+        title: "Implement core"
+        status: "Blocked"
+        blocked_reason: "Waiting for API"
+        type: "implementation"
+        parallel: false
+        dependencies: ["T001"]
+        acceptance_criteria:
+          - "Core works"
+      - id: "T003"
+        title: "Test core"
+        status: "Pending"
+        type: "test"
+        parallel: false
+        dependencies: ["T001", "T002"]
+        acceptance_criteria: []
+`
+
+func parseCrudTasks(t *testing.T) *yaml.Node {
+	t.Helper()
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(crudTasksYAML), &root))
+	return &root
+}
+
+// writeAndRead round-trips the document through writeTasksDocument.
+func writeAndRead(t *testing.T, root *yaml.Node) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tasks.yaml")
+	require.NoError(t, writeTasksDocument(path, root))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestAddTask(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		task      newTask
+		wantID    string
+		wantPhase int
+		wantErr   string
+	}{
+		"next id in last phase": {
+			task:      newTask{Title: "Document core", Type: "documentation"},
+			wantID:    "T004",
+			wantPhase: 2,
+		},
+		"explicit phase and dependencies": {
+			task:      newTask{Title: "More setup", Type: "setup", Phase: 1, Dependencies: []string{"T001"}},
+			wantID:    "T004",
+			wantPhase: 1,
+		},
+		"explicit id": {
+			task:      newTask{ID: "T010", Title: "Later", Type: "test"},
+			wantID:    "T010",
+			wantPhase: 2,
+		},
+		"duplicate id": {
+			task:    newTask{ID: "T002", Title: "Dup", Type: "test"},
+			wantErr: "task already exists: T002",
+		},
+		"invalid type": {
+			task:    newTask{Title: "Bad", Type: "chore"},
+			wantErr: "invalid task type: chore",
+		},
+		"unknown dependency": {
+			task:    newTask{Title: "Bad", Type: "test", Dependencies: []string{"T099"}},
+			wantErr: "dependency not found: T099",
+		},
+		"unknown phase": {
+			task:    newTask{Title: "Bad", Type: "test", Phase: 7},
+			wantErr: "phase not found: 7",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			root := parseCrudTasks(t)
+			id, err := addTask(root, tt.task)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, id)
+
+			phaseTasks, err := findPhaseTasks(root, tt.wantPhase)
+			require.NoError(t, err)
+			added := phaseTasks.Content[len(phaseTasks.Content)-1]
+			assert.Equal(t, tt.wantID, mappingValue(added, "id").Value)
+			assert.Equal(t, "Pending", mappingValue(added, "status").Value)
+
+			output := writeAndRead(t, root)
+			assert.Contains(t, output, "total_tasks: 4")
+			assert.Contains(t, output, `- id: "`+tt.wantID+`"`, "new values should match the file's quoting")
+		})
+	}
+}
+
+func TestNextTaskID(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		ids  []string
+		want string
+	}{
+		"no tasks":      {want: "T001"},
+		"padded ids":    {ids: []string{"T001", "T009"}, want: "T010"},
+		"unpadded ids":  {ids: []string{"T1", "T2"}, want: "T3"},
+		"out of order":  {ids: []string{"T012", "T003"}, want: "T013"},
+		"wide ids grow": {ids: []string{"T999"}, want: "T1000"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var tasks []*yaml.Node
+			for _, id := range tt.ids {
+				node := &yaml.Node{Kind: yaml.MappingNode}
+				setMappingNode(node, "id", stringNode(id, 0))
+				tasks = append(tasks, node)
+			}
+			assert.Equal(t, tt.want, nextTaskID(tasks))
+		})
+	}
+}
+
+func TestEditTask(t *testing.T) {
+	t.Parallel()
+
+	title, empty := "Create project scaffolding", ""
+	parallel := true
+	deps := []string{"T002"}
+
+	tests := map[string]struct {
+		taskID       string
+		edit         taskEdit
+		wantChanged  []string
+		wantContains []string
+		wantMissing  []string
+		wantErr      string
+	}{
+		"title keeps line comment": {
+			taskID:       "T001",
+			edit:         taskEdit{Title: &title},
+			wantChanged:  []string{"title"},
+			wantContains: []string{`title: "Create project scaffolding" # keep me`},
+		},
+		"parallel and dependencies": {
+			taskID:       "T003",
+			edit:         taskEdit{Parallel: &parallel, Dependencies: &deps},
+			wantChanged:  []string{"parallel", "dependencies"},
+			wantContains: []string{"parallel: true", `dependencies: ["T002"]`},
+		},
+		"empty notes removes field": {
+			taskID:      "T001",
+			edit:        taskEdit{Notes: &empty},
+			wantChanged: []string{"notes"},
+			wantMissing: []string{"notes:"},
+		},
+		"empty title rejected": {
+			taskID:  "T001",
+			edit:    taskEdit{Title: &empty},
+			wantErr: "task title cannot be empty",
+		},
+		"self dependency rejected": {
+			taskID:  "T002",
+			edit:    taskEdit{Dependencies: &deps},
+			wantErr: "cannot depend on itself",
+		},
+		"unknown task": {
+			taskID:  "T042",
+			edit:    taskEdit{Title: &title},
+			wantErr: "task not found: T042",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			root := parseCrudTasks(t)
+			changed, err := editTask(root, tt.taskID, tt.edit)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChanged, changed)
+
+			output := writeAndRead(t, root)
+			for _, want := range tt.wantContains {
+				assert.Contains(t, output, want)
+			}
+			for _, missing := range tt.wantMissing {
+				assert.NotContains(t, output, missing)
+			}
+		})
+	}
+}
+
+func TestRemoveTask(t *testing.T) {
+	t.Parallel()
+
+	root := parseCrudTasks(t)
+	title, dependents, found := removeTask(root, "T002")
+	require.True(t, found)
+	assert.Equal(t, "Implement core", title)
+	assert.Equal(t, []string{"T003"}, dependents)
+
+	assert.Nil(t, findTaskRef(root, "T002"))
+	output := writeAndRead(t, root)
+	assert.Contains(t, output, "total_tasks: 2")
+	assert.Contains(t, output, `dependencies: ["T001"]`)
+	assert.Contains(t, output, "# Generated by autospec", "comments are preserved")
+
+	_, _, found = removeTask(root, "T002")
+	assert.False(t, found)
+}
+
+func TestSetTaskStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		taskID       string
+		status       string
+		wantPrevious string
+		wantFound    bool
+		wantReason   bool
+	}{
+		"start pending":          {taskID: "T003", status: "InProgress", wantPrevious: "Pending", wantFound: true},
+		"complete blocked":       {taskID: "T002", status: "Completed", wantPrevious: "Blocked", wantFound: true},
+		"already completed":      {taskID: "T001", status: "Completed", wantPrevious: "Completed", wantFound: true},
+		"blocked keeps reason":   {taskID: "T002", status: "Blocked", wantPrevious: "Blocked", wantFound: true, wantReason: true},
+		"missing task not found": {taskID: "T404", status: "Completed"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			root := parseCrudTasks(t)
+			previous, found := setTaskStatus(root, tt.taskID, tt.status)
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.wantPrevious, previous)
+			if !found {
+				return
+			}
+
+			ref := findTaskRef(root, tt.taskID)
+			assert.Equal(t, tt.status, mappingValue(ref.node, "status").Value)
+			assert.Equal(t, tt.wantReason, mappingValue(ref.node, "blocked_reason") != nil)
+		})
+	}
+}
+
+func TestSkipTask(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		taskID       string
+		wantPrevious string
+		wantFound    bool
+	}{
+		"skip pending":           {taskID: "T003", wantPrevious: "Pending", wantFound: true},
+		"skip blocked":           {taskID: "T002", wantPrevious: "Blocked", wantFound: true},
+		"missing task not found": {taskID: "T404"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			root := parseCrudTasks(t)
+			previous, found := skipTask(root, tt.taskID, "Deferred to 004-metrics")
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.wantPrevious, previous)
+			if !found {
+				return
+			}
+
+			task := findTaskRef(root, tt.taskID).node
+			assert.Equal(t, "Skipped", mappingValue(task, "status").Value)
+			assert.Nil(t, mappingValue(task, "blocked_reason"))
+			assert.Contains(t, writeAndRead(t, root), `status: "Skipped"
+        skip_reason: "Deferred to 004-metrics"`)
+
+			// Starting the task again drops the reason
+			_, _ = setTaskStatus(root, tt.taskID, "InProgress")
+			assert.Nil(t, mappingValue(findTaskRef(root, tt.taskID).node, "skip_reason"))
+		})
+	}
+}
+
+func TestTaskCRUDCmdRegistration(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"add", "edit", "remove", "start", "complete", "skip"} {
+		cmd, _, err := taskCmd.Find([]string{name})
+		require.NoError(t, err)
+		assert.Equal(t, name, cmd.Name())
+	}
+}
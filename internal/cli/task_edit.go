@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var taskEditCmd = &cobra.Command{
+	Use:   "edit <task-id>",
+	Short: "Edit fields of a task in tasks.yaml",
+	Long: `Edit the fields of an existing task. Only the flags you pass are changed;
+comments and the rest of tasks.yaml are left as they are.
+
+--depends and --criteria replace the whole list. Passing an empty value to
+--file, --story or --notes removes that field. Use 'task start', 'task complete',
+'task block' and 'task unblock' to change the status.`,
+	Example: `  # Rename a task
+  autospec task edit T003 --title "Create GitIsolation test helper"
+
+  # Replace the dependencies
+  autospec task edit T005 --depends T003,T004
+
+  # Add a note and allow parallel execution
+  autospec task edit T005 --notes "Split out of T004" --parallel`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteTaskIDs),
+	RunE:              runTaskEdit,
+}
+
+func init() {
+	taskEditCmd.Flags().String("title", "", "New task title")
+	taskEditCmd.Flags().String("type", "", "New task type (setup, implementation, test, documentation, refactor)")
+	taskEditCmd.Flags().Bool("parallel", false, "Whether the task can run in parallel")
+	taskEditCmd.Flags().String("file", "", "Primary file path (empty to remove)")
+	taskEditCmd.Flags().String("story", "", "Related user story ID (empty to remove)")
+	taskEditCmd.Flags().String("notes", "", "Free-form notes (empty to remove)")
+	taskEditCmd.Flags().StringSlice("depends", nil, "Replace the dependencies with these task IDs")
+	taskEditCmd.Flags().StringArray("criteria", nil, "Replace the acceptance criteria (repeatable)")
+	taskCmd.AddCommand(taskEditCmd)
+}
+
+// taskEdit lists the task fields to change; nil fields are left untouched.
+type taskEdit struct {
+	Title        *string
+	Type         *string
+	Parallel     *bool
+	FilePath     *string
+	StoryID      *string
+	Notes        *string
+	Dependencies *[]string
+	Criteria     *[]string
+}
+
+func runTaskEdit(cmd *cobra.Command, args []string) error {
+	taskID := args[0]
+	if !taskIDPattern.MatchString(taskID) {
+		return fmt.Errorf("invalid task ID format: %s (expected T followed by digits, e.g., T001)", taskID)
+	}
+
+	edit := taskEditFromFlags(cmd)
+	if edit == (taskEdit{}) {
+		return fmt.Errorf("nothing to edit: pass at least one of --title, --type, --parallel, --file, --story, --notes, --depends, --criteria")
+	}
+
+	tasksPath, root, unlock, err := loadTasksDocument(cmd)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if findTaskRef(root, taskID) == nil {
+		return fmt.Errorf("task not found: %s\nCheck that the task ID exists in: %s", taskID, tasksPath)
+	}
+	changed, err := editTask(root, taskID, edit)
+	if err != nil {
+		return err
+	}
+	if err := writeTasksDocument(tasksPath, root); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Task %s updated: %s\n", taskID, strings.Join(changed, ", "))
+	return nil
+}
+
+// taskEditFromFlags collects the flags that were explicitly set.
+func taskEditFromFlags(cmd *cobra.Command) taskEdit {
+	flags := cmd.Flags()
+	var edit taskEdit
+	stringFlag := func(name string) *string {
+		if !flags.Changed(name) {
+			return nil
+		}
+		v, _ := flags.GetString(name)
+		return &v
+	}
+	edit.Title = stringFlag("title")
+	edit.Type = stringFlag("type")
+	edit.FilePath = stringFlag("file")
+	edit.StoryID = stringFlag("story")
+	edit.Notes = stringFlag("notes")
+	if flags.Changed("parallel") {
+		v, _ := flags.GetBool("parallel")
+		edit.Parallel = &v
+	}
+	if flags.Changed("depends") {
+		v, _ := flags.GetStringSlice("depends")
+		edit.Dependencies = &v
+	}
+	if flags.Changed("criteria") {
+		v, _ := flags.GetStringArray("criteria")
+		edit.Criteria = &v
+	}
+	return edit
+}
+
+// editTask applies edit to the task and returns the names of the changed fields.
+func editTask(root *yaml.Node, taskID string, edit taskEdit) ([]string, error) {
+	ref := findTaskRef(root, taskID)
+	if ref == nil {
+		return nil, fmt.Errorf("task not found: %s", taskID)
+	}
+	if edit.Title != nil && *edit.Title == "" {
+		return nil, fmt.Errorf("task title cannot be empty")
+	}
+	if edit.Type != nil {
+		if err := validateTaskType(*edit.Type); err != nil {
+			return nil, err
+		}
+	}
+	if edit.Dependencies != nil {
+		if err := validateDependencies(root, taskID, *edit.Dependencies); err != nil {
+			return nil, err
+		}
+	}
+
+	task := ref.node
+	style := scalarStyle([]*yaml.Node{task})
+	var changed []string
+	setString := func(key string, value *string, removeEmpty bool) {
+		if value == nil {
+			return
+		}
+		if *value == "" && removeEmpty {
+			removeMappingKey(task, key)
+		} else {
+			setMappingScalar(task, key, *value, style)
+		}
+		changed = append(changed, key)
+	}
+	setList := func(key string, values *[]string, flow bool) {
+		if values == nil {
+			return
+		}
+		setMappingNode(task, key, stringListNode(*values, style, flow || len(*values) == 0))
+		changed = append(changed, key)
+	}
+
+	setString("title", edit.Title, false)
+	setString("type", edit.Type, false)
+	if edit.Parallel != nil {
+		setMappingNode(task, "parallel", boolNode(*edit.Parallel))
+		changed = append(changed, "parallel")
+	}
+	setString("story_id", edit.StoryID, true)
+	setString("file_path", edit.FilePath, true)
+	setList("dependencies", edit.Dependencies, true)
+	setList("acceptance_criteria", edit.Criteria, false)
+	setString("notes", edit.Notes, true)
+	return changed, nil
+}
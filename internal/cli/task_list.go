@@ -16,6 +16,7 @@ var (
 	listPending    bool
 	listInProgress bool
 	listCompleted  bool
+	listSkipped    bool
 )
 
 var taskListCmd = &cobra.Command{
@@ -28,6 +29,7 @@ By default, all tasks are listed. Use flags to filter by status:
   --pending     Show only pending tasks
   --in-progress Show only in-progress tasks
   --completed   Show only completed tasks
+  --skipped     Show only skipped tasks
 
 Multiple filters can be combined to show tasks matching any of the specified statuses.`,
 	Example: `  # List all tasks
@@ -49,6 +51,7 @@ func init() {
 	taskListCmd.Flags().BoolVar(&listPending, "pending", false, "Show only pending tasks")
 	taskListCmd.Flags().BoolVar(&listInProgress, "in-progress", false, "Show only in-progress tasks")
 	taskListCmd.Flags().BoolVar(&listCompleted, "completed", false, "Show only completed tasks")
+	taskListCmd.Flags().BoolVar(&listSkipped, "skipped", false, "Show only skipped tasks")
 	taskCmd.AddCommand(taskListCmd)
 }
 
@@ -98,7 +101,7 @@ func loadTasksConfig(cmd *cobra.Command) (*config.Configuration, string, error)
 // If no flags are set, all tasks are returned
 func filterTasksByStatus(tasks []validation.TaskItem) []validation.TaskItem {
 	// If no filters specified, return all tasks
-	if !listBlocked && !listPending && !listInProgress && !listCompleted {
+	if !listBlocked && !listPending && !listInProgress && !listCompleted && !listSkipped {
 		return tasks
 	}
 
@@ -127,6 +130,9 @@ func matchesStatusFilter(status string) bool {
 	if listCompleted && (statusLower == "completed" || statusLower == "done" || statusLower == "complete") {
 		return true
 	}
+	if listSkipped && statusLower == "skipped" {
+		return true
+	}
 	return false
 }
 
@@ -148,6 +154,9 @@ func printTaskList(tasks []validation.TaskItem) {
 			}
 			fmt.Printf("       Reason: %s\n", reason)
 		}
+		if task.IsSkipped() && task.SkipReason != "" {
+			fmt.Printf("       Skipped: %s\n", truncateReason(task.SkipReason, 80))
+		}
 	}
 }
 
@@ -160,6 +169,8 @@ func getStatusIcon(status string) string {
 		return "[~]"
 	case "blocked":
 		return "[!]"
+	case "skipped":
+		return "[-]"
 	default: // Pending
 		return "[ ]"
 	}
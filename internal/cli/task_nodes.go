@@ -0,0 +1,297 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+	"github.com/ariel-frischer/autospec/internal/filelock"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// loadTasksDocument detects the current spec and parses its tasks.yaml into a
+// node tree, so edits keep comments, key order and quoting intact. The file
+// stays locked against other autospec processes until the returned unlock
+// function is called, which the caller defers past writeTasksDocument.
+func loadTasksDocument(cmd *cobra.Command) (string, *yaml.Node, func(), error) {
+	_, tasksPath, err := loadTasksConfig(cmd)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if _, err := os.Stat(tasksPath); os.IsNotExist(err) {
+		return "", nil, nil, fmt.Errorf("tasks.yaml not found: %s\nRun /autospec.tasks first to generate tasks", tasksPath)
+	}
+
+	unlock, err := filelock.Lock(tasksPath)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	data, err := os.ReadFile(tasksPath)
+	if err != nil {
+		unlock()
+		return "", nil, nil, fmt.Errorf("reading tasks.yaml: %w", err)
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		unlock()
+		return "", nil, nil, fmt.Errorf("parsing tasks.yaml: %w", err)
+	}
+	return tasksPath, &root, unlock, nil
+}
+
+// writeTasksDocument writes the node tree back to tasksPath using the
+// two-space indentation of generated tasks.yaml files.
+func writeTasksDocument(tasksPath string, root *yaml.Node) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return fmt.Errorf("serializing tasks.yaml: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("serializing tasks.yaml: %w", err)
+	}
+	if err := atomicfile.WriteFile(tasksPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("writing tasks.yaml: %w", err)
+	}
+	return nil
+}
+
+// taskRef locates a task mapping and the sequence that contains it.
+type taskRef struct {
+	node  *yaml.Node
+	list  *yaml.Node
+	index int
+}
+
+// findTaskRef returns the task with the given ID, or nil if it does not exist.
+func findTaskRef(node *yaml.Node, taskID string) *taskRef {
+	if node == nil {
+		return nil
+	}
+	if node.Kind == yaml.SequenceNode {
+		for i, child := range node.Content {
+			if isTaskNode(child) && mappingValue(child, "id").Value == taskID {
+				return &taskRef{node: child, list: node, index: i}
+			}
+		}
+	}
+	for _, child := range node.Content {
+		if ref := findTaskRef(child, taskID); ref != nil {
+			return ref
+		}
+	}
+	return nil
+}
+
+// collectTaskNodes returns every task mapping in document order.
+func collectTaskNodes(node *yaml.Node) []*yaml.Node {
+	if node == nil {
+		return nil
+	}
+	if isTaskNode(node) {
+		return []*yaml.Node{node}
+	}
+	var tasks []*yaml.Node
+	for _, child := range node.Content {
+		tasks = append(tasks, collectTaskNodes(child)...)
+	}
+	return tasks
+}
+
+// isTaskNode reports whether node is a mapping with a task ID and a status.
+func isTaskNode(node *yaml.Node) bool {
+	if node.Kind != yaml.MappingNode {
+		return false
+	}
+	id := mappingValue(node, "id")
+	return id != nil && taskIDPattern.MatchString(id.Value) && mappingValue(node, "status") != nil
+}
+
+// findPhaseTasks returns the tasks sequence of the phase with the given
+// number, or of the last phase when number is 0. A missing tasks key is created.
+func findPhaseTasks(root *yaml.Node, number int) (*yaml.Node, error) {
+	doc := root
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		doc = doc.Content[0]
+	}
+	phases := mappingValue(doc, "phases")
+	if phases == nil || phases.Kind != yaml.SequenceNode || len(phases.Content) == 0 {
+		return nil, fmt.Errorf("tasks.yaml has no phases")
+	}
+
+	phase := phases.Content[len(phases.Content)-1]
+	if number != 0 {
+		phase = nil
+		for _, p := range phases.Content {
+			if n := mappingValue(p, "number"); n != nil && n.Value == strconv.Itoa(number) {
+				phase = p
+				break
+			}
+		}
+		if phase == nil {
+			return nil, fmt.Errorf("phase not found: %d", number)
+		}
+	}
+
+	tasks := mappingValue(phase, "tasks")
+	if tasks == nil {
+		tasks = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		setMappingNode(phase, "tasks", tasks)
+	}
+	return tasks, nil
+}
+
+// nextTaskID returns the ID after the highest existing one, zero-padded like
+// the existing IDs (T001 by default).
+func nextTaskID(tasks []*yaml.Node) string {
+	highest, width := 0, 3
+	for _, task := range tasks {
+		digits := strings.TrimPrefix(mappingValue(task, "id").Value, "T")
+		if n, err := strconv.Atoi(digits); err == nil && n > highest {
+			highest, width = n, len(digits)
+		}
+	}
+	return fmt.Sprintf("T%0*d", width, highest+1)
+}
+
+// updateTaskSummary keeps summary.total_tasks in step with the task count.
+func updateTaskSummary(root *yaml.Node) {
+	doc := root
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		doc = doc.Content[0]
+	}
+	summary := mappingValue(doc, "summary")
+	if summary == nil {
+		return
+	}
+	if total := mappingValue(summary, "total_tasks"); total != nil {
+		total.Value = strconv.Itoa(len(collectTaskNodes(root)))
+	}
+}
+
+// validateTaskType checks a task type against the tasks.yaml schema.
+func validateTaskType(taskType string) error {
+	for _, field := range validation.TaskFieldSchema {
+		if field.Name == "type" && !slices.Contains(field.Enum, taskType) {
+			return fmt.Errorf("invalid task type: %s (must be one of: %s)", taskType, strings.Join(field.Enum, ", "))
+		}
+	}
+	return nil
+}
+
+// validateDependencies checks that every dependency is an existing task other than self.
+func validateDependencies(root *yaml.Node, self string, deps []string) error {
+	for _, dep := range deps {
+		if dep == self {
+			return fmt.Errorf("task %s cannot depend on itself", self)
+		}
+		if findTaskRef(root, dep) == nil {
+			return fmt.Errorf("dependency not found: %s", dep)
+		}
+	}
+	return nil
+}
+
+// mappingValue returns the value node for key in a mapping, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingNode replaces the value for key, or appends the pair if absent.
+// Comments attached to a replaced value are kept.
+func setMappingNode(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == key {
+			old := node.Content[i+1]
+			value.HeadComment, value.LineComment, value.FootComment = old.HeadComment, old.LineComment, old.FootComment
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// setMappingScalar sets key to a string value, keeping the quoting style of
+// an existing value.
+func setMappingScalar(node *yaml.Node, key, value string, style yaml.Style) {
+	if existing := mappingValue(node, key); existing != nil && existing.Kind == yaml.ScalarNode {
+		existing.Value, existing.Tag = value, "!!str"
+		return
+	}
+	setMappingNode(node, key, stringNode(value, style))
+}
+
+// insertMappingScalar sets key to a string value. An absent key is inserted
+// right after the after key (appended if that is missing too), so related
+// fields stay together.
+func insertMappingScalar(node *yaml.Node, after, key, value string, style yaml.Style) {
+	if existing := mappingValue(node, key); existing != nil && existing.Kind == yaml.ScalarNode {
+		existing.Value, existing.Tag = value, "!!str"
+		return
+	}
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == after {
+			node.Content = slices.Insert(node.Content, i+2, stringNode(key, 0), stringNode(value, style))
+			return
+		}
+	}
+	setMappingNode(node, key, stringNode(value, style))
+}
+
+// removeMappingKey deletes key and its value, reporting whether it was present.
+func removeMappingKey(node *yaml.Node, key string) bool {
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func stringNode(value string, style yaml.Style) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Style: style}
+}
+
+func boolNode(value bool) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(value)}
+}
+
+// stringListNode builds a sequence of strings; flow renders it inline like
+// dependencies: ["T001", "T002"].
+func stringListNode(values []string, style yaml.Style, flow bool) *yaml.Node {
+	list := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	if flow {
+		list.Style = yaml.FlowStyle
+	}
+	for _, v := range values {
+		list.Content = append(list.Content, stringNode(v, style))
+	}
+	return list
+}
+
+// scalarStyle returns the quoting style used for task titles, so new values
+// match the rest of the file.
+func scalarStyle(tasks []*yaml.Node) yaml.Style {
+	for _, task := range tasks {
+		if title := mappingValue(task, "title"); title != nil {
+			return title.Style
+		}
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var taskRemoveCmd = &cobra.Command{
+	Use:     "remove <task-id>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a task from tasks.yaml",
+	Long: `Remove a task from the current feature's tasks.yaml.
+
+The task ID is also dropped from the dependencies of other tasks, and
+summary.total_tasks is updated when present. Remaining task IDs are not
+renumbered.`,
+	Example: `  # Remove a task
+  autospec task remove T007`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteTaskIDs),
+	RunE:              runTaskRemove,
+}
+
+func init() {
+	taskCmd.AddCommand(taskRemoveCmd)
+}
+
+func runTaskRemove(cmd *cobra.Command, args []string) error {
+	taskID := args[0]
+	if !taskIDPattern.MatchString(taskID) {
+		return fmt.Errorf("invalid task ID format: %s (expected T followed by digits, e.g., T001)", taskID)
+	}
+
+	tasksPath, root, unlock, err := loadTasksDocument(cmd)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	title, dependents, found := removeTask(root, taskID)
+	if !found {
+		return fmt.Errorf("task not found: %s\nCheck that the task ID exists in: %s", taskID, tasksPath)
+	}
+	if err := writeTasksDocument(tasksPath, root); err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "✓ Task %s removed: %s\n", taskID, title)
+	if len(dependents) > 0 {
+		fmt.Fprintf(out, "  Removed from dependencies of: %s\n", strings.Join(dependents, ", "))
+	}
+	return nil
+}
+
+// removeTask deletes the task and strips its ID from other tasks'
+// dependencies. It returns the removed task's title and the IDs of the
+// tasks that depended on it.
+func removeTask(root *yaml.Node, taskID string) (string, []string, bool) {
+	ref := findTaskRef(root, taskID)
+	if ref == nil {
+		return "", nil, false
+	}
+
+	title := ""
+	if t := mappingValue(ref.node, "title"); t != nil {
+		title = t.Value
+	}
+	ref.list.Content = append(ref.list.Content[:ref.index], ref.list.Content[ref.index+1:]...)
+
+	var dependents []string
+	for _, task := range collectTaskNodes(root) {
+		deps := mappingValue(task, "dependencies")
+		if deps == nil || deps.Kind != yaml.SequenceNode {
+			continue
+		}
+		kept := deps.Content[:0]
+		for _, dep := range deps.Content {
+			if dep.Value != taskID {
+				kept = append(kept, dep)
+			}
+		}
+		if len(kept) != len(deps.Content) {
+			deps.Content = kept
+			dependents = append(dependents, mappingValue(task, "id").Value)
+		}
+	}
+
+	updateTaskSummary(root)
+	return title, dependents, true
+}
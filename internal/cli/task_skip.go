@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var taskSkipCmd = &cobra.Command{
+	Use:   "skip <task-id>",
+	Short: "Skip (defer) a task with a reason",
+	Long: `Mark a task as Skipped and record why it was deferred.
+
+Skipped tasks are counted separately from completed ones. They don't keep
+implement from finishing, a phase from completing, or tasks that depend on
+them from running, so the workflow can complete without them.
+
+To pick a skipped task up again, set another status with 'autospec task
+start', 'complete' or 'unblock'; this removes its skip_reason.`,
+	Example: `  # Defer a task to a later spec
+  autospec task skip T012 --reason "Deferred to 004-metrics"
+
+  # Update the reason for an already skipped task
+  autospec task skip T012 --reason "Not needed after switching to SQLite"`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteTaskIDs),
+	RunE:              runTaskSkip,
+}
+
+func init() {
+	taskSkipCmd.Flags().StringP("reason", "r", "", "Reason for skipping the task (required)")
+	_ = taskSkipCmd.MarkFlagRequired("reason")
+	taskCmd.AddCommand(taskSkipCmd)
+}
+
+func runTaskSkip(cmd *cobra.Command, args []string) error {
+	taskID := args[0]
+	if !taskIDPattern.MatchString(taskID) {
+		return fmt.Errorf("invalid task ID format: %s (expected T followed by digits, e.g., T001)", taskID)
+	}
+	reason, _ := cmd.Flags().GetString("reason")
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("skip reason cannot be empty")
+	}
+
+	tasksPath, root, unlock, err := loadTasksDocument(cmd)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	previous, found := skipTask(root, taskID, reason)
+	if !found {
+		return fmt.Errorf("task not found: %s\nCheck that the task ID exists in: %s", taskID, tasksPath)
+	}
+	if err := writeTasksDocument(tasksPath, root); err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if previous == "Skipped" {
+		fmt.Fprintf(out, "✓ Task %s: updated skip reason\n", taskID)
+	} else {
+		fmt.Fprintf(out, "✓ Task %s: %s -> Skipped\n", taskID, previous)
+	}
+	fmt.Fprintf(out, "  Reason: %s\n", truncateReason(reason, 60))
+	return nil
+}
+
+// skipTask sets the task's status to Skipped and its skip_reason, which is
+// added after the status. Returns the previous status and whether the task
+// exists.
+func skipTask(root *yaml.Node, taskID, reason string) (string, bool) {
+	previous, found := setTaskStatus(root, taskID, "Skipped")
+	if !found {
+		return "", false
+	}
+	task := findTaskRef(root, taskID).node
+	insertMappingScalar(task, "status", "skip_reason", reason, mappingValue(task, "status").Style)
+	return previous, true
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var taskStartCmd = &cobra.Command{
+	Use:   "start <task-id>",
+	Short: "Mark a task as InProgress",
+	Long: `Set a task's status to InProgress.
+
+Starting a blocked or skipped task also removes its blocked_reason or
+skip_reason.`,
+	Example: `  # Start working on a task
+  autospec task start T004`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteTaskIDs),
+	RunE:              taskStatusRunner("InProgress"),
+}
+
+var taskCompleteCmd = &cobra.Command{
+	Use:     "complete <task-id>",
+	Aliases: []string{"done"},
+	Short:   "Mark a task as Completed",
+	Long: `Set a task's status to Completed.
+
+Completing a blocked or skipped task also removes its blocked_reason or
+skip_reason.`,
+	Example: `  # Mark a task as done
+  autospec task complete T004`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteTaskIDs),
+	RunE:              taskStatusRunner("Completed"),
+}
+
+func init() {
+	taskCmd.AddCommand(taskStartCmd)
+	taskCmd.AddCommand(taskCompleteCmd)
+}
+
+// taskStatusRunner returns a RunE that sets the task given as the first
+// argument to status.
+func taskStatusRunner(status string) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		taskID := args[0]
+		if !taskIDPattern.MatchString(taskID) {
+			return fmt.Errorf("invalid task ID format: %s (expected T followed by digits, e.g., T001)", taskID)
+		}
+
+		tasksPath, root, unlock, err := loadTasksDocument(cmd)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		previous, found := setTaskStatus(root, taskID, status)
+		if !found {
+			return fmt.Errorf("task not found: %s\nCheck that the task ID exists in: %s", taskID, tasksPath)
+		}
+		out := cmd.OutOrStdout()
+		if previous == status {
+			fmt.Fprintf(out, "Task %s already has status: %s (no change needed)\n", taskID, status)
+			return nil
+		}
+		if err := writeTasksDocument(tasksPath, root); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "✓ Task %s: %s -> %s\n", taskID, previous, status)
+		return nil
+	}
+}
+
+// setTaskStatus sets the task's status, dropping blocked_reason (and the
+// recorded blocked state) when it leaves Blocked and skip_reason when it
+// leaves Skipped. Returns the previous status and whether the task exists.
+func setTaskStatus(root *yaml.Node, taskID, status string) (string, bool) {
+	ref := findTaskRef(root, taskID)
+	if ref == nil {
+		return "", false
+	}
+	statusNode := mappingValue(ref.node, "status")
+	previous := statusNode.Value
+	statusNode.Value = status
+	if status != "Blocked" {
+		removeMappingKey(ref.node, "blocked_reason")
+		clearBlockedState(ref.node)
+	}
+	if status != "Skipped" {
+		removeMappingKey(ref.node, "skip_reason")
+	}
+	return previous, true
+}
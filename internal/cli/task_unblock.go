@@ -5,8 +5,11 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/ariel-frischer/autospec/internal/config"
 	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/filelock"
 	"github.com/ariel-frischer/autospec/internal/spec"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -18,14 +21,16 @@ var (
 
 var taskUnblockCmd = &cobra.Command{
 	Use:   "unblock <task-id>",
-	Short: "Unblock a task and set its status",
+	Short: "Unblock a task and restore or set its status",
 	Long: `Unblock a task and optionally specify the new status.
 
-This command changes a blocked task's status back to Pending (default) or
-another specified status, and removes the blocked_reason field.
+This command changes a blocked task's status back to the status it had
+before it was blocked (recorded by 'autospec task block'; Pending if not
+recorded) or to the status given with --status, and removes the
+blocked_reason field.
 
 If the task is not currently blocked, a warning is shown and no changes are made.`,
-	Example: `  # Unblock a task (defaults to Pending status)
+	Example: `  # Unblock a task, restoring its status from before it was blocked
   autospec task unblock T001
 
   # Unblock and set to InProgress to immediately start working
@@ -34,12 +39,13 @@ If the task is not currently blocked, a warning is shown and no changes are made
   # Unblock multiple tasks
   autospec task unblock T001
   autospec task unblock T002`,
-	Args: cobra.ExactArgs(1),
-	RunE: runTaskUnblock,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteTaskIDs),
+	RunE:              runTaskUnblock,
 }
 
 func init() {
-	taskUnblockCmd.Flags().StringVarP(&unblockStatus, "status", "s", "Pending", "Status to set after unblocking (Pending or InProgress)")
+	taskUnblockCmd.Flags().StringVarP(&unblockStatus, "status", "s", "", "Status to set after unblocking (Pending or InProgress; default: the status before blocking)")
 	taskCmd.AddCommand(taskUnblockCmd)
 }
 
@@ -52,8 +58,10 @@ func runTaskUnblock(cmd *cobra.Command, args []string) error {
 	}
 
 	// Validate target status (only Pending or InProgress allowed for unblock)
-	if err := validateUnblockStatus(unblockStatus); err != nil {
-		return err
+	if cmd.Flags().Changed("status") {
+		if err := validateUnblockStatus(unblockStatus); err != nil {
+			return err
+		}
 	}
 
 	// Load config
@@ -78,6 +86,13 @@ func runTaskUnblock(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("tasks.yaml not found: %s\nRun /autospec.tasks first to generate tasks", tasksPath)
 	}
 
+	// Lock tasks.yaml against other autospec processes until written back
+	unlock, err := filelock.Lock(tasksPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Read and parse tasks.yaml
 	data, err := os.ReadFile(tasksPath)
 	if err != nil {
@@ -90,8 +105,12 @@ func runTaskUnblock(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("parsing tasks.yaml: %w", err)
 	}
 
-	// Find and unblock the task
-	result := findAndUnblockTask(&root, taskID, unblockStatus)
+	// Find and unblock the task, restoring its previous status by default
+	targetStatus := unblockStatus
+	if !cmd.Flags().Changed("status") {
+		targetStatus = blockedFromStatus(&root, taskID)
+	}
+	result := findAndUnblockTask(&root, taskID, targetStatus)
 	if !result.found {
 		return fmt.Errorf("task not found: %s\nCheck that the task ID exists in: %s", taskID, tasksPath)
 	}
@@ -101,6 +120,7 @@ func runTaskUnblock(cmd *cobra.Command, args []string) error {
 		fmt.Printf("⚠ Task %s is not blocked (status: %s) - no changes made\n", taskID, result.previousStatus)
 		return nil
 	}
+	clearBlockedState(findTaskRef(&root, taskID).node)
 
 	// Write back the updated YAML
 	output, err := yaml.Marshal(&root)
@@ -108,11 +128,11 @@ func runTaskUnblock(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("serializing tasks.yaml: %w", err)
 	}
 
-	if err := os.WriteFile(tasksPath, output, 0644); err != nil {
+	if err := atomicfile.WriteFile(tasksPath, output, 0644); err != nil {
 		return fmt.Errorf("writing tasks.yaml: %w", err)
 	}
 
-	printUnblockResult(taskID, result)
+	printUnblockResult(taskID, targetStatus, result)
 	return nil
 }
 
@@ -134,8 +154,8 @@ type unblockResult struct {
 }
 
 // printUnblockResult prints a user-friendly message about the unblock operation
-func printUnblockResult(taskID string, result unblockResult) {
-	fmt.Printf("✓ Task %s: Blocked -> %s\n", taskID, unblockStatus)
+func printUnblockResult(taskID, targetStatus string, result unblockResult) {
+	fmt.Printf("✓ Task %s: Blocked -> %s\n", taskID, targetStatus)
 	if result.hadReason {
 		fmt.Printf("  Previous reason: %s\n", truncateReason(result.previousReason, 60))
 	}
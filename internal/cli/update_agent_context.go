@@ -10,8 +10,8 @@ import (
 	"github.com/ariel-frischer/autospec/internal/agent"
 	"github.com/ariel-frischer/autospec/internal/config"
 	clierrors "github.com/ariel-frischer/autospec/internal/errors"
-	"github.com/ariel-frischer/autospec/internal/git"
 	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/vcs"
 	"github.com/spf13/cobra"
 )
 
@@ -66,9 +66,9 @@ func runUpdateAgentContext(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("loading config: %w", err)
 	}
 
-	repoRoot, err := getGitRepoRoot()
+	repoRoot, err := getRepoRoot()
 	if err != nil {
-		return fmt.Errorf("getting git repo root: %w", err)
+		return fmt.Errorf("getting repo root: %w", err)
 	}
 
 	metadata, err := detectSpecForAgentContext(cfg.SpecsDir)
@@ -111,11 +111,11 @@ func loadAgentContextConfig(configPath string) (*config.Configuration, error) {
 	return cfg, nil
 }
 
-// getGitRepoRoot gets the git repository root
-func getGitRepoRoot() (string, error) {
-	repoRoot, err := git.GetRepositoryRoot()
+// getRepoRoot gets the git (or jj) repository root
+func getRepoRoot() (string, error) {
+	repoRoot, err := vcs.Current().Root()
 	if err != nil {
-		cliErr := fmt.Errorf("not in a git repository: %w. Run this command from within a git repository", err)
+		cliErr := fmt.Errorf("not in a repository: %w. Run this command from within a git or jj repository", err)
 		if !updateAgentContextJSONFlag {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", cliErr)
 		}
@@ -5,9 +5,13 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"time"
 
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/ariel-frischer/autospec/internal/config"
 	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/filelock"
 	"github.com/ariel-frischer/autospec/internal/spec"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -40,8 +44,9 @@ Valid status values:
 
   # Mark a task as blocked
   autospec update-task T015 Blocked`,
-	Args: cobra.ExactArgs(2),
-	RunE: runUpdateTask,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeUpdateTaskArgs,
+	RunE:              runUpdateTask,
 }
 
 func init() {
@@ -49,6 +54,18 @@ func init() {
 	rootCmd.AddCommand(updateTaskCmd)
 }
 
+// completeUpdateTaskArgs completes the task ID, then the status.
+func completeUpdateTaskArgs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return shared.CompleteTaskIDs(cmd, args, toComplete)
+	case 1:
+		return validStatuses, cobra.ShellCompDirectiveNoFileComp
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
 func runUpdateTask(cmd *cobra.Command, args []string) error {
 	taskID := args[0]
 	newStatus := args[1]
@@ -87,6 +104,13 @@ func runUpdateTask(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("tasks.yaml not found: %s\nRun /autospec.tasks first to generate tasks", tasksPath)
 	}
 
+	// Lock tasks.yaml against other autospec processes until written back
+	unlock, err := filelock.Lock(tasksPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Read and parse tasks.yaml
 	data, err := os.ReadFile(tasksPath)
 	if err != nil {
@@ -110,6 +134,11 @@ func runUpdateTask(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Task %s already has status: %s (no change needed)\n", taskID, newStatus)
 		return nil
 	}
+	if newStatus == "Blocked" {
+		recordBlockedState(&root, taskID, previousStatus, time.Now())
+	} else if ref := findTaskRef(&root, taskID); ref != nil {
+		clearBlockedState(ref.node)
+	}
 
 	// Write back the updated YAML
 	output, err := yaml.Marshal(&root)
@@ -117,7 +146,7 @@ func runUpdateTask(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to serialize tasks.yaml: %w", err)
 	}
 
-	if err := os.WriteFile(tasksPath, output, 0644); err != nil {
+	if err := atomicfile.WriteFile(tasksPath, output, 0644); err != nil {
 		return fmt.Errorf("failed to write tasks.yaml: %w", err)
 	}
 
@@ -0,0 +1,121 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/spf13/cobra"
+)
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive <spec>",
+	Short: "Move a completed spec to specs/archive/",
+	Long: `Move a completed spec into the archive directory under the specs directory.
+
+Archived specs are skipped by spec auto-detection, 'autospec view',
+'autospec batch' and the MCP spec listing, which keeps the active specs list
+short on large repos. Their numbers stay reserved, so new specs never reuse
+them. Use 'autospec unarchive' to bring a spec back.
+
+The spec can be given as its full directory name, its number, or its name.
+Only specs whose spec.yaml status is Completed are archived unless --force
+is set.`,
+	Example: `  # Archive a completed spec by number
+  autospec archive 003
+
+  # Archive an abandoned spec that never completed
+  autospec archive 007-old-experiment --force
+
+  # List archived specs
+  autospec archive --list`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if list, _ := cmd.Flags().GetBool("list"); list {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	SilenceUsage: true,
+	RunE:         runArchive,
+}
+
+var unarchiveCmd = &cobra.Command{
+	Use:   "unarchive <spec>",
+	Short: "Move an archived spec back to the specs directory",
+	Long: `Move a spec out of the archive directory and back into the specs directory,
+so it is detected and listed again.
+
+The spec can be given as its full directory name, its number, or its name.`,
+	Example: `  # Restore an archived spec
+  autospec unarchive 003`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteArchivedSpecNames),
+	SilenceUsage:      true,
+	RunE:              runUnarchive,
+}
+
+func init() {
+	archiveCmd.GroupID = shared.GroupConfiguration
+	archiveCmd.Flags().BoolP("force", "f", false, "Archive the spec even if it is not completed")
+	archiveCmd.Flags().BoolP("list", "l", false, "List archived specs")
+	unarchiveCmd.GroupID = shared.GroupConfiguration
+}
+
+func runArchive(cmd *cobra.Command, args []string) error {
+	specsDir, err := loadSpecsDir(cmd)
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+
+	if list, _ := cmd.Flags().GetBool("list"); list {
+		names, err := spec.ListArchivedSpecs(specsDir)
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			fmt.Fprintln(out, "No archived specs.")
+			return nil
+		}
+		for _, name := range names {
+			fmt.Fprintln(out, name)
+		}
+		return nil
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+	archived, err := spec.ArchiveSpec(specsDir, args[0], force)
+	if err != nil {
+		return fmt.Errorf("archiving spec: %w", err)
+	}
+	fmt.Fprintf(out, "✓ Archived %s\n", archived)
+	return nil
+}
+
+func runUnarchive(cmd *cobra.Command, args []string) error {
+	specsDir, err := loadSpecsDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	restored, err := spec.UnarchiveSpec(specsDir, args[0])
+	if err != nil {
+		return fmt.Errorf("unarchiving spec: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Restored %s\n", restored)
+	return nil
+}
+
+// loadSpecsDir loads the configuration and returns the specs directory.
+func loadSpecsDir(cmd *cobra.Command) (string, error) {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return "", cliErr
+	}
+	return resolveSpecsDir(cmd, cfg.SpecsDir), nil
+}
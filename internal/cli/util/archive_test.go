@@ -0,0 +1,114 @@
+// Package util tests the archive and unarchive commands.
+// Related: internal/cli/util/archive.go
+// Tags: util, cli, archive, unarchive, specs
+
+package util
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newArchiveTestCmd returns a fresh command running runE with the flags the
+// archive commands read, so tests don't share the global commands' state.
+func newArchiveTestCmd(runE func(*cobra.Command, []string) error, specsDir string) (*cobra.Command, *bytes.Buffer) {
+	cmd := &cobra.Command{Use: "test", RunE: runE, SilenceUsage: true, SilenceErrors: true}
+	cmd.Flags().String("config", "", "")
+	cmd.Flags().String("specs-dir", specsDir, "")
+	cmd.Flags().Bool("force", false, "")
+	cmd.Flags().Bool("list", false, "")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	return cmd, &out
+}
+
+func writeArchiveTestSpec(t *testing.T, dir, status string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	content := "feature:\n  status: \"" + status + "\"\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "spec.yaml"), []byte(content), 0o644))
+}
+
+func TestRunArchive(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		args      []string
+		status    string
+		wantOut   string
+		wantErr   string
+		wantMoved bool
+	}{
+		"archives completed spec": {
+			args:      []string{"001"},
+			status:    "Completed",
+			wantOut:   "✓ Archived",
+			wantMoved: true,
+		},
+		"refuses incomplete spec": {
+			args:    []string{"001"},
+			status:  "Draft",
+			wantErr: "not completed",
+		},
+		"force archives incomplete spec": {
+			args:      []string{"001", "--force"},
+			status:    "Draft",
+			wantOut:   "✓ Archived",
+			wantMoved: true,
+		},
+		"list without archive": {
+			args:    []string{"--list"},
+			status:  "Completed",
+			wantOut: "No archived specs.",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specsDir := filepath.Join(t.TempDir(), "specs")
+			writeArchiveTestSpec(t, filepath.Join(specsDir, "001-feature"), tt.status)
+
+			cmd, out := newArchiveTestCmd(runArchive, specsDir)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), tt.wantOut)
+			if tt.wantMoved {
+				assert.DirExists(t, filepath.Join(specsDir, "archive", "001-feature"))
+				assert.NoDirExists(t, filepath.Join(specsDir, "001-feature"))
+			}
+		})
+	}
+}
+
+func TestRunArchive_ListAndUnarchive(t *testing.T) {
+	t.Parallel()
+
+	specsDir := filepath.Join(t.TempDir(), "specs")
+	writeArchiveTestSpec(t, filepath.Join(specsDir, "archive", "002-old"), "Completed")
+
+	cmd, out := newArchiveTestCmd(runArchive, specsDir)
+	cmd.SetArgs([]string{"--list"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "002-old\n", out.String())
+
+	cmd, out = newArchiveTestCmd(runUnarchive, specsDir)
+	cmd.SetArgs([]string{"002"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "✓ Restored")
+	assert.DirExists(t, filepath.Join(specsDir, "002-old"))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/ariel-frischer/autospec/internal/update"
@@ -11,17 +12,30 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var ckPlain bool
+var (
+	ckPlain   bool
+	ckChannel string
+)
+
+// maxNoteLines caps how much of each release's notes is printed.
+const maxNoteLines = 15
 
 // ckCmd is the command for checking if an update is available.
 var ckCmd = &cobra.Command{
 	Use:     "ck",
 	Aliases: []string{"check"},
 	Short:   "Check if an update is available",
-	Long:    "Check if a newer version of autospec is available on GitHub releases.",
+	Long: `Check if a newer version of autospec is available on GitHub releases.
+
+When an update is available, the release notes of every release between the
+running version and the latest are shown. Use --channel prerelease to also
+consider release candidates.`,
 	Example: `  # Check for available updates
   autospec ck
 
+  # Include prereleases such as v0.7.0-rc.1
+  autospec ck --channel prerelease
+
   # Plain output (for scripts)
   autospec ck --plain
 
@@ -33,6 +47,24 @@ var ckCmd = &cobra.Command{
 func init() {
 	ckCmd.GroupID = shared.GroupGettingStarted
 	ckCmd.Flags().BoolVar(&ckPlain, "plain", false, "Plain output without formatting")
+	addChannelFlag(ckCmd, &ckChannel)
+}
+
+// addChannelFlag registers the --channel flag shared by ck and update.
+func addChannelFlag(cmd *cobra.Command, channel *string) {
+	cmd.Flags().StringVar(channel, "channel", string(update.ChannelStable),
+		"Release channel: stable or prerelease")
+}
+
+// newChecker creates an update checker for the given --channel value.
+func newChecker(timeout time.Duration, channel string) (*update.Checker, error) {
+	parsed, err := update.ParseChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+	checker := update.NewChecker(timeout)
+	checker.SetChannel(parsed)
+	return checker, nil
 }
 
 // runCheck executes the update check command.
@@ -42,7 +74,10 @@ func runCheck(cmd *cobra.Command, _ []string) error {
 		ctx = context.Background()
 	}
 
-	checker := update.NewChecker(update.DefaultHTTPTimeout)
+	checker, err := newChecker(update.DefaultHTTPTimeout, ckChannel)
+	if err != nil {
+		return err
+	}
 	output, err := executeCheck(ctx, checker, Version, ckPlain)
 	if err != nil {
 		return err
@@ -130,11 +165,55 @@ func formatUpdateAvailable(check *update.UpdateCheck, plain bool) string {
 	cyan := color.New(color.FgCyan).SprintFunc()
 	dim := color.New(color.Faint).SprintFunc()
 
-	return fmt.Sprintf("%s Update available: %s → %s\n%s\n",
+	upgrade := "autospec update"
+	if check.Channel == update.ChannelPrerelease {
+		upgrade += " --channel prerelease"
+	}
+	return fmt.Sprintf("%s Update available: %s → %s\n%s%s\n",
 		green("✓"),
 		dim(check.CurrentVersion),
 		cyan(check.LatestVersion),
-		dim("  Run 'autospec update' to upgrade"))
+		formatReleaseNotes(check.Notes),
+		dim(fmt.Sprintf("  Run '%s' to upgrade", upgrade)))
+}
+
+// formatReleaseNotes renders release notes, newest first, with each
+// release's notes indented under its version and capped at maxNoteLines.
+func formatReleaseNotes(notes []update.ReleaseNote) string {
+	if len(notes) == 0 {
+		return ""
+	}
+	bold := color.New(color.Bold).SprintFunc()
+	dim := color.New(color.Faint).SprintFunc()
+
+	var b strings.Builder
+	b.WriteString("\n")
+	for _, note := range notes {
+		header := note.Version
+		if !note.PublishedAt.IsZero() {
+			header += dim(" (" + note.PublishedAt.Format("2006-01-02") + ")")
+		}
+		fmt.Fprintf(&b, "  %s\n", bold(header))
+
+		lines := strings.Split(note.Body, "\n")
+		if note.Body == "" {
+			lines = []string{dim("No release notes")}
+		}
+		truncated := len(lines) > maxNoteLines
+		if truncated {
+			lines = lines[:maxNoteLines]
+		}
+		for _, line := range lines {
+			fmt.Fprintf(&b, "    %s\n", strings.TrimRight(line, " \r"))
+		}
+		if truncated && note.URL != "" {
+			fmt.Fprintf(&b, "    %s\n", dim("… full notes: "+note.URL))
+		} else if truncated {
+			fmt.Fprintf(&b, "    %s\n", dim("…"))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
 }
 
 // formatUpToDate returns output when already on latest version.
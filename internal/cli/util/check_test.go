@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -203,6 +204,54 @@ func TestCheckCommand_CobraIntegration(t *testing.T) {
 	// Verify --plain flag is registered
 	flag := ckCmd.Flags().Lookup("plain")
 	assert.NotNil(t, flag, "--plain flag should be registered")
+
+	// Verify --channel flag is registered on ck and update
+	for _, cmd := range []*cobra.Command{ckCmd, updateCmd} {
+		channel := cmd.Flags().Lookup("channel")
+		require.NotNil(t, channel, "--channel flag should be registered on %s", cmd.Name())
+		assert.Equal(t, "stable", channel.DefValue)
+	}
+}
+
+// TestNewChecker tests --channel validation.
+func TestNewChecker(t *testing.T) {
+	t.Parallel()
+
+	_, err := newChecker(time.Second, "prerelease")
+	require.NoError(t, err)
+
+	_, err = newChecker(time.Second, "nightly")
+	assert.ErrorContains(t, err, `invalid channel "nightly"`)
+}
+
+// TestFormatUpdateAvailable_ReleaseNotes tests the changelog shown with an update.
+func TestFormatUpdateAvailable_ReleaseNotes(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("- change\n", maxNoteLines+5)
+	check := &update.UpdateCheck{
+		CurrentVersion:  "v0.6.0",
+		LatestVersion:   "v0.8.0-rc.1",
+		UpdateAvailable: true,
+		Channel:         update.ChannelPrerelease,
+		Notes: []update.ReleaseNote{
+			{Version: "v0.8.0-rc.1", PublishedAt: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), Body: "### Added\n- Prerelease channel", URL: "https://example.com/rc1"},
+			{Version: "v0.7.0", Body: long, URL: "https://example.com/v0.7.0"},
+			{Version: "v0.6.1"},
+		},
+	}
+
+	output := formatUpdateAvailable(check, false)
+
+	assert.Contains(t, output, "v0.8.0-rc.1 (2026-01-02)")
+	assert.Contains(t, output, "    ### Added\n    - Prerelease channel\n")
+	assert.Equal(t, maxNoteLines, strings.Count(output, "- change"), "long notes are capped")
+	assert.Contains(t, output, "… full notes: https://example.com/v0.7.0")
+	assert.Contains(t, output, "v0.6.1\n    No release notes")
+	assert.Contains(t, output, "Run 'autospec update --channel prerelease' to upgrade")
+
+	plain := formatUpdateAvailable(check, true)
+	assert.NotContains(t, plain, "Prerelease channel", "plain output has no notes")
 }
 
 // TestCheckCommand_DevBuildMessage tests dev build specific messaging.
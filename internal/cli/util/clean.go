@@ -3,10 +3,14 @@ package util
 import (
 	"bufio"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/ariel-frischer/autospec/internal/clean"
 	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/spec"
 	"github.com/spf13/cobra"
 )
 
@@ -28,8 +32,17 @@ Use --yes to skip the confirmation prompt (specs/ will NOT be removed with --yes
 Use --keep-specs to skip the specs prompt and preserve specs/.
 Use --remove-specs to skip the specs prompt and remove specs/.
 
-Note: This does not remove user-level config (~/.config/autospec/) or
-global state (~/.autospec/). Use 'rm -rf' manually if needed.`,
+To remove only part of it, use one of:
+  --state-only     generated state in .autospec/ (state/, context/, tmp/),
+                   keeping configuration, scripts and memory
+  --commands-only  the .claude/commands/autospec*.md slash commands
+  --spec <spec>    one spec's directory (by number, name or full name)
+  --global         global state and history in ~/.autospec/
+
+Each lists what it would remove with --dry-run and asks for confirmation
+unless --yes is given.
+
+Note: This does not remove user-level config (~/.config/autospec/).`,
 	Example: `  # Preview what would be removed
   autospec clean --dry-run
 
@@ -43,7 +56,16 @@ global state (~/.autospec/). Use 'rm -rf' manually if needed.`,
   autospec clean --yes --remove-specs
 
   # Remove autospec files, explicitly preserve specs/
-  autospec clean --keep-specs`,
+  autospec clean --keep-specs
+
+  # Reset generated state but keep configuration
+  autospec clean --state-only
+
+  # Remove one spec
+  autospec clean --spec 003
+
+  # Preview global cleanup of ~/.autospec
+  autospec clean --global --dry-run`,
 	RunE: runClean,
 }
 
@@ -53,7 +75,17 @@ func init() {
 	cleanCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt (specs/ will be preserved)")
 	cleanCmd.Flags().BoolP("keep-specs", "k", false, "Skip specs prompt and preserve specs/")
 	cleanCmd.Flags().BoolP("remove-specs", "r", false, "Skip specs prompt and remove specs/")
+	cleanCmd.Flags().Bool("state-only", false, "Remove only generated state in .autospec/ (state, context, tmp)")
+	cleanCmd.Flags().Bool("commands-only", false, "Remove only the .claude/commands/autospec*.md slash commands")
+	cleanCmd.Flags().String("spec", "", "Remove only this spec's directory (e.g. 003 or 003-my-feature)")
+	cleanCmd.Flags().Bool("global", false, "Remove global state and history in ~/.autospec/")
 	cleanCmd.MarkFlagsMutuallyExclusive("keep-specs", "remove-specs")
+	cleanCmd.MarkFlagsMutuallyExclusive("state-only", "commands-only", "spec", "global")
+	for _, selective := range []string{"state-only", "commands-only", "spec", "global"} {
+		cleanCmd.MarkFlagsMutuallyExclusive(selective, "keep-specs")
+		cleanCmd.MarkFlagsMutuallyExclusive(selective, "remove-specs")
+	}
+	_ = cleanCmd.RegisterFlagCompletionFunc("spec", shared.CompleteSpecNames)
 }
 
 func runClean(cmd *cobra.Command, args []string) error {
@@ -64,8 +96,16 @@ func runClean(cmd *cobra.Command, args []string) error {
 
 	out := cmd.OutOrStdout()
 
+	targets, selective, err := selectiveCleanTargets(cmd)
+	if err != nil {
+		return err
+	}
+	if selective != "" {
+		return runSelectiveClean(cmd, targets, selective, dryRun, yes)
+	}
+
 	// Find autospec files (keep specs by default)
-	targets, err := clean.FindAutospecFiles(true)
+	targets, err = clean.FindAutospecFiles(true)
 	if err != nil {
 		return fmt.Errorf("failed to find autospec files: %w", err)
 	}
@@ -86,13 +126,7 @@ func runClean(cmd *cobra.Command, args []string) error {
 		fmt.Fprintln(out, "Files to be removed:")
 	}
 
-	for _, target := range targets {
-		typeStr := "file"
-		if target.Type == clean.TypeDirectory {
-			typeStr = "dir"
-		}
-		fmt.Fprintf(out, "  [%s] %s (%s)\n", typeStr, target.Path, target.Description)
-	}
+	printCleanTargets(out, targets)
 
 	// Show specs/ status based on flags
 	if specsExists {
@@ -212,6 +246,105 @@ func runClean(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// selectiveCleanTargets returns the targets of --state-only, --commands-only,
+// --spec or --global and a label naming what they are, or an empty label
+// when none was given.
+func selectiveCleanTargets(cmd *cobra.Command) ([]clean.CleanTarget, string, error) {
+	stateOnly, _ := cmd.Flags().GetBool("state-only")
+	commandsOnly, _ := cmd.Flags().GetBool("commands-only")
+	specName, _ := cmd.Flags().GetString("spec")
+	global, _ := cmd.Flags().GetBool("global")
+
+	switch {
+	case stateOnly:
+		return clean.FindStateFiles(), "autospec state", nil
+	case commandsOnly:
+		targets, err := clean.FindCommandFiles()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to find autospec commands: %w", err)
+		}
+		return targets, "autospec commands", nil
+	case specName != "":
+		configPath, _ := cmd.Flags().GetString("config")
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			return nil, "", fmt.Errorf("loading config: %w", err)
+		}
+		metadata, err := spec.GetSpecMetadata(cfg.SpecsDir, specName)
+		if err != nil {
+			return nil, "", err
+		}
+		return []clean.CleanTarget{clean.SpecTarget(metadata.Directory)}, "spec " + metadata.Name, nil
+	case global:
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, "", fmt.Errorf("finding home directory: %w", err)
+		}
+		return clean.FindGlobalFiles(homeDir), "global autospec files", nil
+	}
+	return nil, "", nil
+}
+
+// runSelectiveClean lists targets and, unless dryRun, removes them after
+// confirmation.
+func runSelectiveClean(cmd *cobra.Command, targets []clean.CleanTarget, label string, dryRun, yes bool) error {
+	out := cmd.OutOrStdout()
+	if len(targets) == 0 {
+		fmt.Fprintf(out, "No %s found.\n", label)
+		return nil
+	}
+
+	if dryRun {
+		fmt.Fprintln(out, "Would remove:")
+	} else {
+		fmt.Fprintln(out, "Files to be removed:")
+	}
+	printCleanTargets(out, targets)
+	if dryRun {
+		return nil
+	}
+
+	if !yes {
+		fmt.Fprintln(out)
+		if !promptYesNo(cmd, fmt.Sprintf("Remove %s?", label)) {
+			fmt.Fprintln(out, "Aborted.")
+			return nil
+		}
+	}
+
+	var successCount, failCount int
+	for _, result := range clean.RemoveFiles(targets) {
+		if result.Success {
+			successCount++
+			fmt.Fprintf(out, "✓ Removed: %s\n", result.Target.Path)
+		} else {
+			failCount++
+			fmt.Fprintf(out, "✗ Failed: %s (%v)\n", result.Target.Path, result.Error)
+		}
+	}
+	fmt.Fprintf(out, "\nSummary: %d removed", successCount)
+	if failCount > 0 {
+		fmt.Fprintf(out, ", %d failed", failCount)
+	}
+	fmt.Fprintln(out)
+
+	if failCount > 0 {
+		return fmt.Errorf("%d files could not be removed", failCount)
+	}
+	return nil
+}
+
+// printCleanTargets lists targets with their type and description.
+func printCleanTargets(out io.Writer, targets []clean.CleanTarget) {
+	for _, target := range targets {
+		typeStr := "file"
+		if target.Type == clean.TypeDirectory {
+			typeStr = "dir"
+		}
+		fmt.Fprintf(out, "  [%s] %s (%s)\n", typeStr, target.Path, target.Description)
+	}
+}
+
 // promptYesNo prompts the user for a yes/no answer
 func promptYesNo(cmd *cobra.Command, question string) bool {
 	fmt.Fprintf(cmd.OutOrStdout(), "%s [y/N]: ", question)
@@ -380,3 +380,109 @@ func TestRunClean_AutospecAndSpecs(t *testing.T) {
 	runErr := runClean(cmd, []string{})
 	assert.NoError(t, runErr)
 }
+
+// newSelectiveCleanCmd returns a command with the clean flags and the given
+// selective flag values.
+func newSelectiveCleanCmd(dryRun bool, set map[string]string) (*cobra.Command, *strings.Builder) {
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("dry-run", dryRun, "")
+	cmd.Flags().Bool("yes", true, "")
+	cmd.Flags().Bool("keep-specs", false, "")
+	cmd.Flags().Bool("remove-specs", false, "")
+	cmd.Flags().Bool("state-only", false, "")
+	cmd.Flags().Bool("commands-only", false, "")
+	cmd.Flags().String("spec", "", "")
+	cmd.Flags().Bool("global", false, "")
+	cmd.Flags().String("config", filepath.Join(".autospec", "config.yml"), "")
+	for name, value := range set {
+		_ = cmd.Flags().Set(name, value)
+	}
+	var outBuf strings.Builder
+	cmd.SetOut(&outBuf)
+	return cmd, &outBuf
+}
+
+func TestRunClean_Selective(t *testing.T) {
+	// Cannot run in parallel - changes working directory
+
+	tests := map[string]struct {
+		flags       map[string]string
+		dryRun      bool
+		wantRemoved []string
+		wantKept    []string
+		wantOutput  string
+	}{
+		"state only": {
+			flags:       map[string]string{"state-only": "true"},
+			wantRemoved: []string{".autospec/context", ".autospec/tmp"},
+			wantKept:    []string{".autospec/config.yml", ".claude/commands/autospec.plan.md", "specs/003-search"},
+		},
+		"commands only": {
+			flags:       map[string]string{"commands-only": "true"},
+			wantRemoved: []string{".claude/commands/autospec.plan.md"},
+			wantKept:    []string{".autospec/config.yml", ".autospec/context", "specs/003-search"},
+		},
+		"one spec": {
+			flags:       map[string]string{"spec": "003"},
+			wantRemoved: []string{"specs/003-search"},
+			wantKept:    []string{"specs/004-cache", ".autospec/config.yml"},
+		},
+		"dry run lists without removing": {
+			flags:      map[string]string{"spec": "003"},
+			dryRun:     true,
+			wantKept:   []string{"specs/003-search"},
+			wantOutput: "Would remove:\n  [dir] specs/003-search (Feature specification 003-search)",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			oldWd, err := os.Getwd()
+			require.NoError(t, err)
+			defer func() { _ = os.Chdir(oldWd) }()
+			require.NoError(t, os.Chdir(tmpDir))
+
+			for _, dir := range []string{".autospec/context", ".autospec/tmp", ".claude/commands", "specs/003-search", "specs/004-cache"} {
+				require.NoError(t, os.MkdirAll(dir, 0755))
+			}
+			require.NoError(t, os.WriteFile(".autospec/config.yml", []byte("specs_dir: ./specs\n"), 0644))
+			require.NoError(t, os.WriteFile(".claude/commands/autospec.plan.md", []byte("plan"), 0644))
+
+			cmd, out := newSelectiveCleanCmd(tt.dryRun, tt.flags)
+			require.NoError(t, runClean(cmd, nil))
+
+			for _, path := range tt.wantRemoved {
+				assert.NoFileExists(t, path)
+				assert.NoDirExists(t, path)
+			}
+			for _, path := range tt.wantKept {
+				_, err := os.Stat(path)
+				assert.NoError(t, err, "%s should be kept", path)
+			}
+			if tt.wantOutput != "" {
+				assert.Contains(t, out.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestRunClean_Global(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".autospec", "state"), 0755))
+
+	cmd, out := newSelectiveCleanCmd(true, map[string]string{"global": "true"})
+	require.NoError(t, runClean(cmd, nil))
+	assert.Contains(t, out.String(), filepath.Join(home, ".autospec"))
+	assert.DirExists(t, filepath.Join(home, ".autospec"), "dry run keeps files")
+
+	cmd, out = newSelectiveCleanCmd(false, map[string]string{"global": "true"})
+	require.NoError(t, runClean(cmd, nil))
+	assert.Contains(t, out.String(), "Summary: 1 removed")
+	assert.NoDirExists(t, filepath.Join(home, ".autospec"))
+
+	cmd, out = newSelectiveCleanCmd(false, map[string]string{"global": "true"})
+	require.NoError(t, runClean(cmd, nil))
+	assert.Contains(t, out.String(), "No global autospec files found.")
+}
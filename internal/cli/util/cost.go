@@ -0,0 +1,241 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var costCmd = &cobra.Command{
+	Use:   "cost [spec]",
+	Short: "Summarize agent token usage and estimated cost",
+	Long: `Summarize agent token usage and estimated cost per spec and per phase.
+
+Usage is recorded in the command history whenever the agent reports it
+(Claude's --output-format json/stream-json output). Costs are the agent's own
+estimates. Pass a spec name to show only that spec.`,
+	Example: `  # Spend across all specs
+  autospec cost
+
+  # Per-phase breakdown for one spec
+  autospec cost 003-user-auth
+
+  # Machine-readable totals
+  autospec cost --output json`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteSpecNames),
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCostWithStateDir(cmd, args, getDefaultStateDir())
+	},
+}
+
+func init() {
+	costCmd.GroupID = shared.GroupConfiguration
+}
+
+// phaseOrder is the canonical display order of workflow phases.
+var phaseOrder = []string{"constitution", "specify", "clarify", "plan", "tasks", "checklist", "analyze", "implement", "verify"}
+
+// costTotals accumulates token counts and cost.
+type costTotals struct {
+	Runs                int     `json:"runs"`
+	InputTokens         int     `json:"input_tokens"`
+	OutputTokens        int     `json:"output_tokens"`
+	CacheCreationTokens int     `json:"cache_creation_tokens"`
+	CacheReadTokens     int     `json:"cache_read_tokens"`
+	TotalTokens         int     `json:"total_tokens"`
+	CostUSD             float64 `json:"cost_usd"`
+}
+
+// phaseCost is the spend for one phase of a spec.
+type phaseCost struct {
+	Phase string `json:"phase"`
+	costTotals
+}
+
+// specCost is the spend for one spec, broken down by phase.
+type specCost struct {
+	Spec string `json:"spec"`
+	costTotals
+	Phases []phaseCost `json:"phases"`
+}
+
+// costReport is the cost summary across specs.
+type costReport struct {
+	Specs []specCost `json:"specs"`
+	Total costTotals `json:"total"`
+}
+
+// add accumulates one phase usage record.
+func (c *costTotals) add(u history.PhaseUsage) {
+	c.merge(costTotals{
+		Runs:                1,
+		InputTokens:         u.InputTokens,
+		OutputTokens:        u.OutputTokens,
+		CacheCreationTokens: u.CacheCreationTokens,
+		CacheReadTokens:     u.CacheReadTokens,
+		TotalTokens:         u.TotalTokens(),
+		CostUSD:             u.CostUSD,
+	})
+}
+
+// runCostWithStateDir runs the cost command against a custom state directory.
+func runCostWithStateDir(cmd *cobra.Command, args []string, stateDir string) error {
+	specFilter := ""
+	if len(args) > 0 {
+		specFilter = args[0]
+	}
+
+	histFile, err := history.LoadHistory(stateDir)
+	if err != nil {
+		return fmt.Errorf("loading history: %w", err)
+	}
+
+	report := buildCostReport(histFile.Entries, specFilter)
+	if shared.IsJSONOutput(cmd) {
+		return shared.WriteJSON(cmd.OutOrStdout(), report)
+	}
+
+	if len(report.Specs) == 0 {
+		if specFilter != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "No token usage recorded for spec '%s'.\n", specFilter)
+			return nil
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), "No token usage recorded.")
+		return nil
+	}
+	displayCostReport(cmd.OutOrStdout(), report)
+	return nil
+}
+
+// buildCostReport aggregates usage from history entries per spec and phase.
+// Usage without a spec (e.g., a failed specify) falls back to the entry's spec.
+func buildCostReport(entries []history.HistoryEntry, specFilter string) costReport {
+	bySpec := make(map[string]map[string]*costTotals)
+	for _, entry := range entries {
+		for _, u := range entry.Usage {
+			specName := usageSpec(entry, u)
+			if specFilter != "" && specName != specFilter {
+				continue
+			}
+			if bySpec[specName] == nil {
+				bySpec[specName] = make(map[string]*costTotals)
+			}
+			if bySpec[specName][u.Phase] == nil {
+				bySpec[specName][u.Phase] = &costTotals{}
+			}
+			bySpec[specName][u.Phase].add(u)
+		}
+	}
+
+	report := costReport{Specs: []specCost{}}
+	for _, specName := range sortedKeys(bySpec) {
+		sc := specCost{Spec: specName}
+		for _, phase := range sortedPhases(bySpec[specName]) {
+			totals := *bySpec[specName][phase]
+			sc.Phases = append(sc.Phases, phaseCost{Phase: phase, costTotals: totals})
+			sc.costTotals.merge(totals)
+		}
+		report.Specs = append(report.Specs, sc)
+		report.Total.merge(sc.costTotals)
+	}
+	return report
+}
+
+// merge adds other's totals into c.
+func (c *costTotals) merge(other costTotals) {
+	c.Runs += other.Runs
+	c.InputTokens += other.InputTokens
+	c.OutputTokens += other.OutputTokens
+	c.CacheCreationTokens += other.CacheCreationTokens
+	c.CacheReadTokens += other.CacheReadTokens
+	c.TotalTokens += other.TotalTokens
+	c.CostUSD += other.CostUSD
+}
+
+// usageSpec returns the spec a usage record is attributed to.
+func usageSpec(entry history.HistoryEntry, u history.PhaseUsage) string {
+	if u.Spec != "" {
+		return u.Spec
+	}
+	if entry.Spec != "" {
+		return entry.Spec
+	}
+	return "(unknown)"
+}
+
+// sortedKeys returns the spec names in alphabetical order.
+func sortedKeys(m map[string]map[string]*costTotals) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedPhases returns phases in workflow order, with unknown phases last.
+func sortedPhases(m map[string]*costTotals) []string {
+	phases := make([]string, 0, len(m))
+	for p := range m {
+		phases = append(phases, p)
+	}
+	sort.Slice(phases, func(i, j int) bool {
+		oi, oj := phaseRank(phases[i]), phaseRank(phases[j])
+		if oi != oj {
+			return oi < oj
+		}
+		return phases[i] < phases[j]
+	})
+	return phases
+}
+
+// phaseRank returns a phase's position in phaseOrder, or len(phaseOrder) if unknown.
+func phaseRank(phase string) int {
+	if idx := slices.Index(phaseOrder, phase); idx >= 0 {
+		return idx
+	}
+	return len(phaseOrder)
+}
+
+// displayCostReport prints per-spec and per-phase spend.
+func displayCostReport(out io.Writer, report costReport) {
+	bold := color.New(color.Bold).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	for _, sc := range report.Specs {
+		fmt.Fprintf(out, "%s  %s tokens  %s\n", bold(fmt.Sprintf("%-40s", sc.Spec)), formatTokens(sc.TotalTokens), cyan(formatCost(sc.CostUSD)))
+		for _, pc := range sc.Phases {
+			fmt.Fprintf(out, "  %-12s %3d run(s)  %8s in  %8s out  %8s cache  %s\n",
+				pc.Phase, pc.Runs,
+				formatTokens(pc.InputTokens), formatTokens(pc.OutputTokens),
+				formatTokens(pc.CacheCreationTokens+pc.CacheReadTokens),
+				formatCost(pc.CostUSD))
+		}
+	}
+	fmt.Fprintf(out, "\nTotal: %s tokens, %s (estimated)\n", formatTokens(report.Total.TotalTokens), formatCost(report.Total.CostUSD))
+}
+
+// formatTokens formats a token count compactly (e.g., 950, 12.3k, 1.2M).
+func formatTokens(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+// formatCost formats a USD cost estimate.
+func formatCost(usd float64) string {
+	return fmt.Sprintf("$%.2f", usd)
+}
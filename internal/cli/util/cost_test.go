@@ -0,0 +1,181 @@
+// Package util tests the cost command implementation.
+// Related: internal/cli/util/cost.go
+// Tags: util, cli, cost, tokens, history
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// costTestEntries returns history entries covering two specs and a spec-less specify.
+func costTestEntries() []history.HistoryEntry {
+	return []history.HistoryEntry{
+		{
+			Command: "all",
+			Usage: []history.PhaseUsage{
+				{Spec: "002-api", Phase: "implement", InputTokens: 5000, OutputTokens: 1000, CostUSD: 1.5},
+				{Spec: "002-api", Phase: "specify", InputTokens: 1000, OutputTokens: 200, CostUSD: 0.25},
+				{Spec: "002-api", Phase: "plan", InputTokens: 2000, OutputTokens: 300, CacheReadTokens: 700, CostUSD: 0.5},
+			},
+		},
+		{
+			Command: "implement",
+			Spec:    "002-api",
+			Usage: []history.PhaseUsage{
+				{Spec: "002-api", Phase: "implement", InputTokens: 1000, OutputTokens: 500, CostUSD: 0.5},
+			},
+		},
+		{
+			Command: "plan",
+			Spec:    "001-auth",
+			Usage:   []history.PhaseUsage{{Phase: "plan", InputTokens: 100, OutputTokens: 50, CostUSD: 0.01}},
+		},
+		{Command: "history"},
+	}
+}
+
+func TestBuildCostReport(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		specFilter string
+		wantSpecs  []string
+		wantPhases map[string][]string
+		wantCost   float64
+		wantTokens int
+	}{
+		"all specs": {
+			wantSpecs: []string{"001-auth", "002-api"},
+			wantPhases: map[string][]string{
+				"001-auth": {"plan"},
+				"002-api":  {"specify", "plan", "implement"},
+			},
+			wantCost:   2.76,
+			wantTokens: 11850,
+		},
+		"filtered by spec": {
+			specFilter: "002-api",
+			wantSpecs:  []string{"002-api"},
+			wantPhases: map[string][]string{"002-api": {"specify", "plan", "implement"}},
+			wantCost:   2.75,
+			wantTokens: 11700,
+		},
+		"unknown spec": {
+			specFilter: "999-missing",
+			wantSpecs:  []string{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			report := buildCostReport(costTestEntries(), tt.specFilter)
+
+			specs := []string{}
+			for _, sc := range report.Specs {
+				specs = append(specs, sc.Spec)
+				phases := []string{}
+				for _, pc := range sc.Phases {
+					phases = append(phases, pc.Phase)
+				}
+				assert.Equal(t, tt.wantPhases[sc.Spec], phases)
+			}
+			assert.Equal(t, tt.wantSpecs, specs)
+			assert.InDelta(t, tt.wantCost, report.Total.CostUSD, 0.0001)
+			assert.Equal(t, tt.wantTokens, report.Total.TotalTokens)
+		})
+	}
+}
+
+func TestBuildCostReport_AggregatesRuns(t *testing.T) {
+	t.Parallel()
+
+	report := buildCostReport(costTestEntries(), "002-api")
+	require.Len(t, report.Specs, 1)
+
+	implement := report.Specs[0].Phases[2]
+	assert.Equal(t, "implement", implement.Phase)
+	assert.Equal(t, 2, implement.Runs)
+	assert.Equal(t, 6000, implement.InputTokens)
+	assert.InDelta(t, 2.0, implement.CostUSD, 0.0001)
+	assert.Equal(t, 4, report.Specs[0].Runs)
+}
+
+func TestRunCostWithStateDir(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	entries := costTestEntries()
+	for i := range entries {
+		entries[i].Timestamp = time.Now()
+	}
+	require.NoError(t, history.SaveHistory(stateDir, &history.HistoryFile{Entries: entries}))
+
+	tests := map[string]struct {
+		args         []string
+		flags        []string
+		wantContains []string
+	}{
+		"text summary": {
+			wantContains: []string{"001-auth", "002-api", "implement", "Total: 11.8k tokens, $2.76 (estimated)"},
+		},
+		"no usage for spec": {
+			args:         []string{"999-missing"},
+			wantContains: []string{"No token usage recorded for spec '999-missing'."},
+		},
+		"json output": {
+			args:         []string{"001-auth"},
+			flags:        []string{"--output", "json"},
+			wantContains: []string{`"spec": "001-auth"`, `"cost_usd": 0.01`},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cmd := &cobra.Command{}
+			shared.AddOutputFlag(cmd)
+			require.NoError(t, cmd.ParseFlags(tt.flags))
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+
+			require.NoError(t, runCostWithStateDir(cmd, tt.args, stateDir))
+			for _, want := range tt.wantContains {
+				assert.Contains(t, out.String(), want)
+			}
+			if shared.IsJSONOutput(cmd) {
+				assert.True(t, json.Valid(out.Bytes()))
+			}
+		})
+	}
+}
+
+func TestFormatTokens(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		n    int
+		want string
+	}{
+		"small":     {n: 950, want: "950"},
+		"thousands": {n: 12345, want: "12.3k"},
+		"millions":  {n: 1250000, want: "1.2M"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, formatTokens(tt.n))
+		})
+	}
+}
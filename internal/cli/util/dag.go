@@ -13,12 +13,13 @@ import (
 )
 
 var dagCmd = &cobra.Command{
-	Use:          "dag [spec-name]",
-	Short:        "Visualize task dependency graph and execution waves",
-	Long:         `Display an ASCII visualization of the task dependency graph showing which tasks can run in parallel and the execution order.`,
-	Args:         cobra.MaximumNArgs(1),
-	SilenceUsage: true,
-	RunE:         runDagCmd,
+	Use:               "dag [spec-name]",
+	Short:             "Visualize task dependency graph and execution waves",
+	Long:              `Display an ASCII visualization of the task dependency graph showing which tasks can run in parallel and the execution order.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteSpecNames),
+	SilenceUsage:      true,
+	RunE:              runDagCmd,
 }
 
 func init() {
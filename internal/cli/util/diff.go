@@ -0,0 +1,186 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/git"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/yaml"
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [spec-name]",
+	Short: "Show how a spec's artifacts changed since a git revision",
+	Long: `Compare the current spec.yaml, plan.yaml and tasks.yaml of a spec with
+their versions at a git revision, field by field instead of line by line.
+
+Each change is listed by its YAML path, with list items identified by their
+id (user_stories[US-002], phases[3].tasks[T014]) so inserted or reordered
+items don't show up as changes to everything after them:
+
+  ~ changed field, with the old and new value
+  + added field or list item
+  - removed field or list item
+
+The revision defaults to HEAD, showing uncommitted changes. The _meta
+section is ignored.`,
+	Example: `  # What changed in the current spec since the last commit
+  autospec diff
+
+  # How requirements evolved since main
+  autospec diff 003-auth --rev main
+
+  # Only the task list, as JSON
+  autospec diff --artifact tasks --output json`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteSpecNames),
+	SilenceUsage:      true,
+	RunE:              runDiff,
+}
+
+func init() {
+	diffCmd.GroupID = shared.GroupConfiguration
+	diffCmd.Flags().StringP("rev", "r", "HEAD", "Git revision to compare against")
+	diffCmd.Flags().StringSliceP("artifact", "a", nil, "Artifacts to compare: spec, plan, tasks (default: all)")
+}
+
+// diffArtifacts lists the artifacts compared, in document order.
+var diffArtifacts = []string{"spec", "plan", "tasks"}
+
+// artifactDiff is the comparison of one artifact file.
+type artifactDiff struct {
+	Artifact string        `json:"artifact"`
+	Status   string        `json:"status"` // unchanged, changed, added or removed
+	Changes  []yaml.Change `json:"changes"`
+}
+
+// specDiff is the JSON output of the diff command.
+type specDiff struct {
+	Spec      string         `json:"spec"`
+	Rev       string         `json:"rev"`
+	Artifacts []artifactDiff `json:"artifacts"`
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	artifacts, _ := cmd.Flags().GetStringSlice("artifact")
+	for _, a := range artifacts {
+		if !slices.Contains(diffArtifacts, a) {
+			return fmt.Errorf("invalid --artifact %q: must be one of spec, plan, tasks", a)
+		}
+	}
+	rev, _ := cmd.Flags().GetString("rev")
+
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
+	var metadata *spec.Metadata
+	if len(args) > 0 {
+		metadata, err = spec.GetSpecMetadata(cfg.SpecsDir, args[0])
+	} else {
+		metadata, err = spec.DetectCurrentSpec(cfg.SpecsDir)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find spec: %w", err)
+	}
+
+	result, err := diffSpec(metadata.Directory, rev, artifacts)
+	if err != nil {
+		return err
+	}
+	if shared.IsJSONOutput(cmd) {
+		return shared.WriteJSON(cmd.OutOrStdout(), result)
+	}
+	printSpecDiff(cmd.OutOrStdout(), result)
+	return nil
+}
+
+// diffSpec compares the artifacts of specDir with their versions at rev.
+// With no artifacts given, all of them are compared; artifacts that exist
+// neither now nor at rev are left out.
+func diffSpec(specDir, rev string, artifacts []string) (*specDiff, error) {
+	if len(artifacts) == 0 {
+		artifacts = diffArtifacts
+	}
+	result := &specDiff{Spec: filepath.Base(specDir), Rev: rev, Artifacts: []artifactDiff{}}
+	for _, artifact := range diffArtifacts {
+		if !slices.Contains(artifacts, artifact) {
+			continue
+		}
+		path := filepath.Join(specDir, artifact+".yaml")
+		current, err := os.ReadFile(path)
+		currentExists := err == nil
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		previous, err := git.ShowFile(rev, path)
+		previousExists := err == nil
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		if !currentExists && !previousExists {
+			continue
+		}
+
+		d := artifactDiff{Artifact: artifact, Status: "changed"}
+		switch {
+		case !previousExists:
+			d.Status, previous = "added", []byte("{}")
+		case !currentExists:
+			d.Status, current = "removed", []byte("{}")
+		}
+		if d.Changes, err = yaml.Diff(previous, current); err != nil {
+			return nil, fmt.Errorf("comparing %s.yaml: %w", artifact, err)
+		}
+		if d.Changes == nil {
+			d.Status, d.Changes = "unchanged", []yaml.Change{}
+		}
+		result.Artifacts = append(result.Artifacts, d)
+	}
+	return result, nil
+}
+
+// printSpecDiff prints the changes of each artifact.
+func printSpecDiff(out io.Writer, result *specDiff) {
+	fmt.Fprintf(out, "Comparing %s with %s\n", result.Spec, result.Rev)
+	if len(result.Artifacts) == 0 {
+		fmt.Fprintln(out, "\nNo artifacts found.")
+		return
+	}
+	for _, d := range result.Artifacts {
+		switch d.Status {
+		case "unchanged":
+			fmt.Fprintf(out, "\n%s.yaml: no changes\n", d.Artifact)
+			continue
+		case "added":
+			fmt.Fprintf(out, "\n%s.yaml (new since %s)\n", d.Artifact, result.Rev)
+		case "removed":
+			fmt.Fprintf(out, "\n%s.yaml (deleted since %s)\n", d.Artifact, result.Rev)
+		default:
+			fmt.Fprintf(out, "\n%s.yaml\n", d.Artifact)
+		}
+		for _, c := range d.Changes {
+			switch c.Kind {
+			case yaml.ChangeAdded:
+				fmt.Fprintf(out, "  + %s: %s\n", c.Path, yaml.Summarize(c.New))
+			case yaml.ChangeRemoved:
+				fmt.Fprintf(out, "  - %s: %s\n", c.Path, yaml.Summarize(c.Old))
+			default:
+				fmt.Fprintf(out, "  ~ %s: %s → %s\n", c.Path, yaml.Summarize(c.Old), yaml.Summarize(c.New))
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Package util tests the diff command implementation.
+// Related: internal/cli/util/diff.go
+// Tags: util, cli, diff, git, artifacts
+
+package util
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// diffTestRepo creates a git repository with a committed spec directory
+// containing the given artifacts and returns the spec directory.
+func diffTestRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	specDir := filepath.Join(root, "specs", "003-auth")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(specDir, name), []byte(content), 0o644))
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "test@test.com"},
+		{"config", "user.name", "Test User"},
+		{"add", "."},
+		{"commit", "-q", "--allow-empty", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		require.NoError(t, cmd.Run(), "git %v", args)
+	}
+	return specDir
+}
+
+func TestDiffSpec(t *testing.T) {
+	t.Parallel()
+
+	const tasksV1 = "phases:\n  - number: 1\n    tasks:\n      - id: T001\n        title: Create module\n        status: Pending\n"
+	const tasksV2 = "phases:\n  - number: 1\n    tasks:\n      - id: T001\n        title: Create module\n        status: Completed\n      - id: T002\n        title: Add parser\n        status: Pending\n"
+
+	specDir := diffTestRepo(t, map[string]string{
+		"spec.yaml":  "feature:\n  status: Draft\n",
+		"plan.yaml":  "summary: Plan\n",
+		"tasks.yaml": tasksV1,
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "tasks.yaml"), []byte(tasksV2), 0o644))
+	require.NoError(t, os.Remove(filepath.Join(specDir, "plan.yaml")))
+
+	result, err := diffSpec(specDir, "HEAD", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "003-auth", result.Spec)
+	require.Len(t, result.Artifacts, 3)
+	assert.Equal(t, "unchanged", result.Artifacts[0].Status)
+	assert.Equal(t, "removed", result.Artifacts[1].Status)
+	assert.Equal(t, "changed", result.Artifacts[2].Status)
+
+	var out bytes.Buffer
+	printSpecDiff(&out, result)
+	assert.Equal(t, `Comparing 003-auth with HEAD
+
+spec.yaml: no changes
+
+plan.yaml (deleted since HEAD)
+  - summary: Plan
+
+tasks.yaml
+  ~ phases[1].tasks[T001].status: Pending → Completed
+  + phases[1].tasks[T002]: Add parser
+`, out.String())
+}
+
+func TestDiffSpec_Artifacts(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		artifacts  []string
+		wantStatus map[string]string
+		wantErr    string
+		rev        string
+	}{
+		"new artifact": {
+			artifacts:  []string{"plan"},
+			wantStatus: map[string]string{"plan": "added"},
+		},
+		"missing everywhere is skipped": {
+			artifacts:  []string{"tasks"},
+			wantStatus: map[string]string{},
+		},
+		"unknown revision": {
+			rev:     "no-such-rev",
+			wantErr: "unknown revision",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			specDir := diffTestRepo(t, map[string]string{"spec.yaml": "feature:\n  status: Draft\n"})
+			require.NoError(t, os.WriteFile(filepath.Join(specDir, "plan.yaml"), []byte("summary: Plan\n"), 0o644))
+
+			rev := tt.rev
+			if rev == "" {
+				rev = "HEAD"
+			}
+			result, err := diffSpec(specDir, rev, tt.artifacts)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			got := map[string]string{}
+			for _, d := range result.Artifacts {
+				got[d.Artifact] = d.Status
+			}
+			assert.Equal(t, tt.wantStatus, got)
+		})
+	}
+}
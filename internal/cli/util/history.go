@@ -12,11 +12,17 @@ import (
 )
 
 var historyCmd = &cobra.Command{
-	Use:          "history",
-	Short:        "View command execution history",
-	Long:         `View a log of all autospec command executions with timestamp, command name, spec, exit code, and duration.`,
+	Use:   "history",
+	Short: "View command execution history",
+	Long: `View a log of all autospec command executions with timestamp, command name, spec, exit code, and duration.
+
+With --team, list the shared history written by everyone on the team
+(history_backend.type: sqlite), including who ran each command.`,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if team, _ := cmd.Flags().GetBool("team"); team {
+			return runTeamHistory(cmd)
+		}
 		stateDir := getDefaultStateDir()
 		return runHistoryWithStateDir(cmd, stateDir)
 	},
@@ -25,9 +31,12 @@ var historyCmd = &cobra.Command{
 func init() {
 	historyCmd.GroupID = shared.GroupConfiguration
 	historyCmd.Flags().StringP("spec", "s", "", "Filter by spec name")
+	_ = historyCmd.RegisterFlagCompletionFunc("spec", shared.CompleteSpecNames)
 	historyCmd.Flags().IntP("limit", "n", 0, "Limit to last N entries (most recent)")
 	historyCmd.Flags().Bool("clear", false, "Clear all history")
 	historyCmd.Flags().String("status", "", "Filter by status (running, completed, failed, cancelled)")
+	historyCmd.Flags().Bool("team", false, "List the shared team history (history_backend)")
+	historyCmd.Flags().String("user", "", "Filter --team entries by user (substring of the git email or name)")
 }
 
 // getDefaultStateDir returns the default state directory path.
@@ -53,11 +62,7 @@ func runHistoryWithStateDir(cmd *cobra.Command, stateDir string) error {
 
 	// Handle clear flag
 	if clearFlag {
-		if err := history.ClearHistory(stateDir); err != nil {
-			return fmt.Errorf("clearing history: %w", err)
-		}
-		fmt.Fprintln(cmd.OutOrStdout(), "History cleared.")
-		return nil
+		return clearHistory(cmd, stateDir)
 	}
 
 	// Load history
@@ -69,6 +74,13 @@ func runHistoryWithStateDir(cmd *cobra.Command, stateDir string) error {
 	// Get filtered entries
 	entries := filterEntries(histFile.Entries, specFilter, statusFilter, limit)
 
+	if shared.IsJSONOutput(cmd) {
+		if entries == nil {
+			entries = []history.HistoryEntry{}
+		}
+		return shared.WriteJSON(cmd.OutOrStdout(), entries)
+	}
+
 	// Handle empty result
 	if len(entries) == 0 {
 		msg := buildEmptyMessage(specFilter, statusFilter)
@@ -81,6 +93,18 @@ func runHistoryWithStateDir(cmd *cobra.Command, stateDir string) error {
 	return nil
 }
 
+// clearHistory removes all history entries and reports the result.
+func clearHistory(cmd *cobra.Command, stateDir string) error {
+	if err := history.ClearHistory(stateDir); err != nil {
+		return fmt.Errorf("clearing history: %w", err)
+	}
+	if shared.IsJSONOutput(cmd) {
+		return shared.WriteJSON(cmd.OutOrStdout(), map[string]bool{"cleared": true})
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), "History cleared.")
+	return nil
+}
+
 // buildEmptyMessage creates an appropriate message when no entries match filters.
 func buildEmptyMessage(specFilter, statusFilter string) string {
 	if specFilter != "" && statusFilter != "" {
@@ -0,0 +1,90 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/spf13/cobra"
+)
+
+var historyExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export command history as CSV or JSONL",
+	Long: `Export command history for spreadsheets or data pipelines.
+
+Each row has the entry ID, start time, command, spec, status, exit code,
+duration in seconds, agent, and token counts and estimated cost when the
+agent reported usage. Columns without data are left empty in CSV and
+omitted in JSONL.`,
+	Example: `  # Last 30 days as CSV
+  autospec history export --since 30d > history.csv
+
+  # Everything as JSON Lines for a data pipeline
+  autospec history export --format jsonl --file history.jsonl
+
+  # One spec since a date
+  autospec history export --spec 003-auth --since 2026-01-01`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runHistoryExportWithStateDir(cmd, getDefaultStateDir(), time.Now())
+	},
+}
+
+func init() {
+	historyExportCmd.Flags().String("format", history.ExportCSV, "Export format: csv, jsonl")
+	historyExportCmd.Flags().String("since", "", "Only entries newer than this (e.g. 30d, 2w, 12h, 2026-01-31)")
+	historyExportCmd.Flags().StringP("spec", "s", "", "Filter by spec name")
+	_ = historyExportCmd.RegisterFlagCompletionFunc("spec", shared.CompleteSpecNames)
+	historyExportCmd.Flags().StringP("file", "f", "", "Write to this file instead of stdout")
+	historyCmd.AddCommand(historyExportCmd)
+}
+
+// runHistoryExportWithStateDir exports history from the given state directory.
+// now anchors relative --since values.
+func runHistoryExportWithStateDir(cmd *cobra.Command, stateDir string, now time.Time) error {
+	format, _ := cmd.Flags().GetString("format")
+	since, _ := cmd.Flags().GetString("since")
+	specFilter, _ := cmd.Flags().GetString("spec")
+	file, _ := cmd.Flags().GetString("file")
+
+	if format != history.ExportCSV && format != history.ExportJSONL {
+		return fmt.Errorf("invalid --format %q: must be csv or jsonl", format)
+	}
+
+	histFile, err := history.LoadHistory(stateDir)
+	if err != nil {
+		return fmt.Errorf("loading history: %w", err)
+	}
+
+	entries := filterEntries(histFile.Entries, specFilter, "", 0)
+	if since != "" {
+		cutoff, err := history.ParseSince(since, now)
+		if err != nil {
+			return err
+		}
+		entries = history.FilterSince(entries, cutoff)
+	}
+
+	var out io.Writer = cmd.OutOrStdout()
+	if file != "" {
+		f, err := os.Create(file)
+		if err != nil {
+			return fmt.Errorf("creating export file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := history.Export(out, entries, format); err != nil {
+		return fmt.Errorf("exporting history: %w", err)
+	}
+	if file != "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d entries to %s\n", len(entries), file)
+	}
+	return nil
+}
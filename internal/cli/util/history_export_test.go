@@ -0,0 +1,117 @@
+// Package util tests the history export command implementation.
+// Related: internal/cli/util/history_export.go, internal/history/export.go
+// Tags: util, cli, history, export
+
+package util
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunHistoryExportWithStateDir(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+	stateDir := t.TempDir()
+	require.NoError(t, history.SaveHistory(stateDir, &history.HistoryFile{Entries: []history.HistoryEntry{
+		{ID: "old_elk_20251201_090000", Timestamp: now.AddDate(0, 0, -70), Command: "plan", Spec: "001-auth", Duration: "10s"},
+		{ID: "brave_fox_20260201_090000", Timestamp: now.AddDate(0, 0, -9), Command: "plan", Spec: "001-auth", Duration: "12s",
+			Usage: []history.PhaseUsage{{Phase: "plan", Agent: "claude", InputTokens: 10, OutputTokens: 5, CostUSD: 0.1}}},
+		{ID: "calm_owl_20260209_100000", Timestamp: now.AddDate(0, 0, -1), Command: "implement", Spec: "002-api", ExitCode: 1, Duration: "1m"},
+	}}))
+
+	tests := map[string]struct {
+		flags     []string
+		wantRows  []string
+		wantLines int
+		wantErr   string
+	}{
+		"csv by default": {
+			wantRows:  []string{"id,timestamp,command", "old_elk", "brave_fox_20260201_090000,2026-02-01T12:00:00Z,plan,001-auth,,0,12,claude,10,5,0,0,15,0.1", "calm_owl"},
+			wantLines: 4,
+		},
+		"since relative": {
+			flags:     []string{"--since", "30d"},
+			wantRows:  []string{"brave_fox", "calm_owl"},
+			wantLines: 3,
+		},
+		"jsonl with spec filter": {
+			flags:     []string{"--format", "jsonl", "--spec", "002-api"},
+			wantRows:  []string{`"id":"calm_owl_20260209_100000"`, `"exit_code":1`, `"duration_seconds":60`},
+			wantLines: 1,
+		},
+		"invalid format": {
+			flags:   []string{"--format", "xlsx"},
+			wantErr: `invalid --format "xlsx"`,
+		},
+		"invalid since": {
+			flags:   []string{"--since", "soon"},
+			wantErr: `invalid --since value "soon"`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cmd := newHistoryExportTestCmd(t, tt.flags...)
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+
+			err := runHistoryExportWithStateDir(cmd, stateDir, now)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			assert.Len(t, lines, tt.wantLines)
+			for _, want := range tt.wantRows {
+				assert.Contains(t, out.String(), want)
+			}
+		})
+	}
+}
+
+func TestRunHistoryExportWithStateDir_File(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	require.NoError(t, history.SaveHistory(stateDir, &history.HistoryFile{Entries: []history.HistoryEntry{
+		{ID: "brave_fox_20260201_090000", Timestamp: time.Now(), Command: "plan"},
+	}}))
+
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	cmd := newHistoryExportTestCmd(t, "--format", "jsonl", "--file", path)
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+
+	require.NoError(t, runHistoryExportWithStateDir(cmd, stateDir, time.Now()))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"command":"plan"`)
+	assert.Empty(t, out.String())
+	assert.Contains(t, errOut.String(), "Exported 1 entries to "+path)
+}
+
+func newHistoryExportTestCmd(t *testing.T, flags ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.Flags().String("format", history.ExportCSV, "")
+	cmd.Flags().String("since", "", "")
+	cmd.Flags().StringP("spec", "s", "", "")
+	cmd.Flags().StringP("file", "f", "", "")
+	require.NoError(t, cmd.ParseFlags(flags))
+	return cmd
+}
@@ -0,0 +1,92 @@
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/spf13/cobra"
+)
+
+var historyPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove old history entries now",
+	Long: `Apply history retention now instead of waiting for the next command.
+
+Limits default to max_history_entries, max_history_age and history_archive
+from the config; flags override them for this run. Removed entries are
+appended to compressed monthly files in <state_dir>/history-archive/
+unless archiving is off.`,
+	Example: `  # Apply the configured limits
+  autospec history prune
+
+  # Drop everything older than 30 days, keeping an archive
+  autospec history prune --older-than 30d
+
+  # Keep only the last 100 entries and discard the rest
+  autospec history prune --max-entries 100 --no-archive`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configPath, _ := cmd.Flags().GetString("config")
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			cliErr := clierrors.ConfigParseError(configPath, err)
+			clierrors.PrintError(cliErr)
+			return cliErr
+		}
+		return runHistoryPruneWithStateDir(cmd, cfg.StateDir, cfg.HistoryRetention(), time.Now())
+	},
+}
+
+func init() {
+	historyPruneCmd.Flags().Int("max-entries", 0, "Keep at most N entries (default: max_history_entries)")
+	historyPruneCmd.Flags().String("older-than", "", "Remove entries older than this, e.g. 90d, 2w (default: max_history_age)")
+	historyPruneCmd.Flags().Bool("no-archive", false, "Discard removed entries instead of archiving them")
+	historyCmd.AddCommand(historyPruneCmd)
+}
+
+// runHistoryPruneWithStateDir prunes history in stateDir using retention,
+// overridden by any limits passed as flags.
+func runHistoryPruneWithStateDir(cmd *cobra.Command, stateDir string, retention history.Retention, now time.Time) error {
+	if cmd.Flags().Changed("max-entries") {
+		maxEntries, _ := cmd.Flags().GetInt("max-entries")
+		if maxEntries < 0 {
+			return fmt.Errorf("--max-entries must be 0 (unlimited) or greater, got %d", maxEntries)
+		}
+		retention.MaxEntries = maxEntries
+	}
+	if olderThan, _ := cmd.Flags().GetString("older-than"); olderThan != "" {
+		age, err := history.ParseAge(olderThan)
+		if err != nil {
+			return fmt.Errorf("--older-than: %w", err)
+		}
+		retention.MaxAge = age
+	}
+	if noArchive, _ := cmd.Flags().GetBool("no-archive"); noArchive {
+		retention.Archive = false
+	}
+
+	result, err := history.Prune(stateDir, retention, now)
+	if err != nil {
+		return fmt.Errorf("pruning history: %w", err)
+	}
+
+	if shared.IsJSONOutput(cmd) {
+		return shared.WriteJSON(cmd.OutOrStdout(), result)
+	}
+
+	out := cmd.OutOrStdout()
+	if result.Removed == 0 {
+		fmt.Fprintf(out, "Nothing to prune (%d entries kept).\n", result.Remaining)
+		return nil
+	}
+	fmt.Fprintf(out, "Pruned %d entries, %d kept.\n", result.Removed, result.Remaining)
+	for _, path := range result.Archives {
+		fmt.Fprintf(out, "Archived to %s\n", path)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Package util tests the history prune command implementation.
+// Related: internal/cli/util/history_prune.go, internal/history/retention.go
+// Tags: util, cli, history, prune
+
+package util
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunHistoryPruneWithStateDir(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+	entries := []history.HistoryEntry{
+		{ID: "old_elk_20251201_090000", Timestamp: now.AddDate(0, 0, -70), Command: "plan", Status: history.StatusCompleted},
+		{ID: "brave_fox_20260201_090000", Timestamp: now.AddDate(0, 0, -9), Command: "plan", Status: history.StatusCompleted},
+		{ID: "calm_owl_20260209_100000", Timestamp: now.AddDate(0, 0, -1), Command: "implement", Status: history.StatusFailed},
+	}
+
+	tests := map[string]struct {
+		retention    history.Retention
+		flags        []string
+		wantOut      string
+		wantKept     int
+		wantArchived bool
+		wantErr      string
+	}{
+		"configured limits": {
+			retention:    history.Retention{MaxEntries: 2, Archive: true},
+			wantOut:      "Pruned 1 entries, 2 kept.",
+			wantKept:     2,
+			wantArchived: true,
+		},
+		"older-than overrides config": {
+			retention:    history.Retention{Archive: true},
+			flags:        []string{"--older-than", "7d"},
+			wantOut:      "Pruned 2 entries, 1 kept.",
+			wantKept:     1,
+			wantArchived: true,
+		},
+		"no-archive discards": {
+			retention: history.Retention{Archive: true},
+			flags:     []string{"--max-entries", "1", "--no-archive"},
+			wantOut:   "Pruned 2 entries, 1 kept.",
+			wantKept:  1,
+		},
+		"nothing to prune": {
+			retention: history.Retention{MaxEntries: 10},
+			wantOut:   "Nothing to prune (3 entries kept).",
+			wantKept:  3,
+		},
+		"invalid older-than": {
+			flags:   []string{"--older-than", "soon"},
+			wantErr: `invalid age "soon"`,
+		},
+		"negative max-entries": {
+			flags:   []string{"--max-entries", "-1"},
+			wantErr: "--max-entries must be 0",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			stateDir := t.TempDir()
+			require.NoError(t, history.SaveHistory(stateDir, &history.HistoryFile{Entries: entries}))
+
+			cmd := newHistoryPruneTestCmd(t, tt.flags...)
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+
+			err := runHistoryPruneWithStateDir(cmd, stateDir, tt.retention, now)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), tt.wantOut)
+
+			loaded, err := history.LoadHistory(stateDir)
+			require.NoError(t, err)
+			assert.Len(t, loaded.Entries, tt.wantKept)
+
+			_, statErr := os.Stat(history.ArchiveDir(stateDir))
+			assert.Equal(t, tt.wantArchived, statErr == nil)
+		})
+	}
+}
+
+func newHistoryPruneTestCmd(t *testing.T, flags ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.Flags().Int("max-entries", 0, "")
+	cmd.Flags().String("older-than", "", "")
+	cmd.Flags().Bool("no-archive", false, "")
+	require.NoError(t, cmd.ParseFlags(flags))
+	return cmd
+}
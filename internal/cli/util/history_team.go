@@ -0,0 +1,89 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// runTeamHistory lists the shared team history configured by
+// history_backend instead of the local history.
+func runTeamHistory(cmd *cobra.Command) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+	backend, ok := cfg.SharedHistory().(*history.SQLiteBackend)
+	if !ok {
+		if cfg.HistoryBackend.Type == "" {
+			return fmt.Errorf("--team needs a shared history: set history_backend.type to sqlite")
+		}
+		return fmt.Errorf("--team can only list the sqlite history backend; %s entries are kept by the endpoint", cfg.HistoryBackend.Type)
+	}
+	return runTeamHistoryWithBackend(cmd, backend)
+}
+
+// runTeamHistoryWithBackend lists the entries of the sqlite backend,
+// applying the --spec, --status, --user and --limit filters.
+func runTeamHistoryWithBackend(cmd *cobra.Command, backend *history.SQLiteBackend) error {
+	specFilter, _ := cmd.Flags().GetString("spec")
+	statusFilter, _ := cmd.Flags().GetString("status")
+	userFilter, _ := cmd.Flags().GetString("user")
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return fmt.Errorf("limit must be positive, got %d", limit)
+	}
+
+	all, err := backend.List()
+	if err != nil {
+		return fmt.Errorf("loading team history: %w", err)
+	}
+	entries := []history.SharedEntry{}
+	for _, e := range all {
+		if matchesFilters(e.HistoryEntry, specFilter, statusFilter) &&
+			strings.Contains(strings.ToLower(e.User), strings.ToLower(userFilter)) {
+			entries = append(entries, e)
+		}
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+
+	if shared.IsJSONOutput(cmd) {
+		return shared.WriteJSON(cmd.OutOrStdout(), entries)
+	}
+	if len(entries) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), buildEmptyMessage(specFilter, statusFilter))
+		return nil
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+	for _, e := range entries {
+		spec := e.Spec
+		if spec == "" {
+			spec = "-"
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s  %-24s  %s  %-12s  %-15s  exit=%d  %s\n",
+			cyan(e.Timestamp.Local().Format("2006-01-02 15:04:05")),
+			e.User,
+			formatStatus(e.Status, green, yellow, red),
+			e.Command,
+			spec,
+			e.ExitCode,
+			e.Duration,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Package util tests listing the shared team history.
+// Related: internal/cli/util/history_team.go, internal/history/shared.go
+// Tags: util, cli, history, team, sqlite
+
+package util
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunTeamHistoryWithBackend(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		t.Skip("sqlite3 not installed")
+	}
+
+	backend := &history.SQLiteBackend{Path: filepath.Join(t.TempDir(), "history.db")}
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, e := range []history.SharedEntry{
+		{HistoryEntry: history.HistoryEntry{ID: "a", Command: "specify", Spec: "001-login", Status: history.StatusCompleted}, User: "sam@example.com"},
+		{HistoryEntry: history.HistoryEntry{ID: "b", Command: "plan", Spec: "001-login", Status: history.StatusFailed, ExitCode: 1}, User: "kim@example.com"},
+		{HistoryEntry: history.HistoryEntry{ID: "c", Command: "implement", Spec: "002-search", Status: history.StatusRunning}, User: "sam@example.com"},
+	} {
+		e.Timestamp = at.Add(time.Duration(i) * time.Hour)
+		require.NoError(t, backend.Record(e))
+	}
+
+	tests := map[string]struct {
+		flags     []string
+		wantLines []string
+	}{
+		"all":      {wantLines: []string{"specify", "plan", "implement"}},
+		"by user":  {flags: []string{"--user", "SAM"}, wantLines: []string{"specify", "implement"}},
+		"by spec":  {flags: []string{"--spec", "001-login", "--status", "failed"}, wantLines: []string{"kim@example.com"}},
+		"limit":    {flags: []string{"--limit", "1"}, wantLines: []string{"implement"}},
+		"no match": {flags: []string{"--spec", "003-billing"}, wantLines: []string{"No matching entries for spec '003-billing'."}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := &cobra.Command{
+				Use:  "history",
+				RunE: func(cmd *cobra.Command, _ []string) error { return runTeamHistoryWithBackend(cmd, backend) },
+			}
+			cmd.Flags().String("spec", "", "")
+			cmd.Flags().String("status", "", "")
+			cmd.Flags().String("user", "", "")
+			cmd.Flags().Int("limit", 0, "")
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetArgs(tt.flags)
+			require.NoError(t, cmd.Execute())
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			require.Len(t, lines, len(tt.wantLines))
+			for i, want := range tt.wantLines {
+				assert.Contains(t, lines[i], want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/lifecycle"
+	"github.com/ariel-frischer/autospec/internal/notify"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <dir>",
+	Short: "Import existing markdown specs as autospec specs",
+	Long: `Import a folder of existing markdown specs into the specs directory.
+
+Each markdown file directly in <dir> (other than README.md), and each
+subfolder containing markdown, becomes one spec. A subfolder's main document
+is its spec.md, README.md or index.md, or else its first markdown file.
+
+Every spec gets the next free number (within spec_namespace or --namespace,
+if set) and a branch name made from its title (e.g. 012-user-login or
+API-012-user-login), and a feature directory holding a spec.yaml skeleton
+and copies of its documents. The originals are left in place; no git
+branches are created.
+
+The skeleton takes user stories ("As a ..., I want ..., so that ..."),
+Given/When/Then scenarios and the list items under headings such as
+Requirements, Acceptance criteria, Assumptions and Out of scope. Required
+fields it can't fill start with "TODO:". With --extract, the agent then
+reads each document and fills in the spec.yaml.`,
+	Example: `  # Preview what would be imported
+  autospec import docs/features --dry-run
+
+  # Import, then let the agent extract stories and requirements
+  autospec import docs/features --extract`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runImport,
+}
+
+func init() {
+	importCmd.GroupID = shared.GroupConfiguration
+	importCmd.Flags().Bool("dry-run", false, "Show the specs that would be created without writing them")
+	importCmd.Flags().Bool("extract", false, "Have the agent fill in each spec.yaml from its document")
+	importCmd.Flags().String("namespace", "", "Spec number namespace, e.g. API for API-001 (overrides spec_namespace)")
+	shared.AddAgentFlag(importCmd)
+	shared.AddModelFlag(importCmd)
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+	specsDir := resolveSpecsDir(cmd, cfg.SpecsDir)
+
+	sources, err := spec.FindImportSources(args[0])
+	if err != nil {
+		return fmt.Errorf("finding specs to import: %w", err)
+	}
+	if len(sources) == 0 {
+		return fmt.Errorf("no markdown specs found in %s", args[0])
+	}
+	namespace, _ := cmd.Flags().GetString("namespace")
+	if namespace == "" {
+		namespace = cfg.SpecNamespace
+	}
+	if namespace, err = spec.NormalizeNamespace(namespace); err != nil {
+		return err
+	}
+	imports, err := spec.PlanImport(sources, specsDir, namespace)
+	if err != nil {
+		return fmt.Errorf("numbering imported specs: %w", err)
+	}
+
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		for _, imp := range imports {
+			if imp.Existing {
+				fmt.Fprintf(out, "Already imported %s → %s\n", imp.Source.Path, imp.Dir)
+			} else {
+				fmt.Fprintf(out, "Would import %s → %s\n", imp.Source.Path, imp.Dir)
+			}
+		}
+		return nil
+	}
+
+	var imported []spec.ImportedSpec
+	for _, imp := range imports {
+		if imp.Existing {
+			fmt.Fprintf(out, "- Already imported %s → %s\n", imp.Source.Path, imp.Dir)
+			imported = append(imported, imp)
+			continue
+		}
+		if err := spec.WriteImport(imp); err != nil {
+			fmt.Fprintf(out, "✗ %s: %v\n", imp.Source.Path, err)
+			continue
+		}
+		fmt.Fprintf(out, "✓ Imported %s → %s\n", imp.Source.Path, imp.Dir)
+		imported = append(imported, imp)
+	}
+
+	if extract, _ := cmd.Flags().GetBool("extract"); extract && len(imported) > 0 {
+		if _, err := shared.ApplyAgentOverride(cmd, cfg); err != nil {
+			return err
+		}
+		shared.ApplyModelOverride(cmd, cfg)
+		for _, imp := range imported {
+			if n, _ := spec.CountImportTODOs(imp.Dir); imp.Existing && n == 0 {
+				continue
+			}
+			fmt.Fprintf(out, "\nExtracting %s from %s...\n", imp.Branch, imp.Source.Path)
+			if err := ImportExtractor(cmd, cfg, imp); err != nil {
+				fmt.Fprintf(out, "⚠ Extraction failed for %s: %v\n", imp.Branch, err)
+			}
+		}
+	}
+
+	printImportSummary(out, imported, len(imports))
+	if len(imported) < len(imports) {
+		return fmt.Errorf("%d of %d specs could not be imported", len(imports)-len(imported), len(imports))
+	}
+	return nil
+}
+
+// ImportExtractor runs the agent that fills in an imported spec.yaml.
+// Replaced in tests to avoid running a real agent.
+var ImportExtractor = runImportExtract
+
+func runImportExtract(cmd *cobra.Command, cfg *config.Configuration, imp spec.ImportedSpec) error {
+	notifHandler := notify.NewHandler(cfg.Notifications)
+	historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+	historyLogger.Shared = cfg.SharedHistory()
+
+	return lifecycle.RunWithHistory(notifHandler, historyLogger, "import", imp.Branch, func() error {
+		orch := workflow.NewWorkflowOrchestrator(cfg)
+		orch.Executor.NotificationHandler = notifHandler
+		orch.Executor.UsageRecorder = historyLogger
+		shared.ApplyOutputStyle(cmd, orch)
+		return orch.Executor.Claude.Execute(spec.ImportExtractPrompt(imp))
+	})
+}
+
+// printImportSummary reports whether each imported spec.yaml is valid and
+// how many placeholders are left in it.
+func printImportSummary(out io.Writer, imported []spec.ImportedSpec, total int) {
+	fmt.Fprintf(out, "\nImported %d of %d specs\n", len(imported), total)
+	todos := 0
+	for _, imp := range imported {
+		if err := validation.ValidateArtifactFile(filepath.Join(imp.Dir, "spec.yaml")); err != nil {
+			fmt.Fprintf(out, "  ⚠ %s: %v\n", imp.Branch, err)
+		}
+		if n, err := spec.CountImportTODOs(imp.Dir); err == nil && n > 0 {
+			fmt.Fprintf(out, "  %s: %d TODO fields\n", imp.Branch, n)
+			todos += n
+		}
+	}
+	if todos > 0 {
+		fmt.Fprintf(out, "\nFill in the TODO fields, or re-run the import with --extract to have the agent do it.\n")
+	}
+}
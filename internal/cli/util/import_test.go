@@ -0,0 +1,128 @@
+// Package util tests the import command.
+// Related: internal/cli/util/import.go, internal/spec/import.go
+// Tags: util, cli, import, specs
+
+package util
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newImportTestCmd(specsDir string) (*cobra.Command, *bytes.Buffer) {
+	cmd := &cobra.Command{Use: "import", RunE: runImport, SilenceUsage: true, SilenceErrors: true}
+	cmd.Flags().String("config", "", "")
+	cmd.Flags().String("specs-dir", specsDir, "")
+	cmd.Flags().Bool("dry-run", false, "")
+	cmd.Flags().Bool("extract", false, "")
+	cmd.Flags().String("namespace", "", "")
+	cmd.Flags().String("agent", "", "")
+	cmd.Flags().String("model", "", "")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	return cmd, &out
+}
+
+func TestRunImport(t *testing.T) {
+	// Not parallel: replaces ImportExtractor
+
+	tests := map[string]struct {
+		args        []string
+		wantOutput  []string
+		wantSpecs   []string
+		wantExtract []string
+	}{
+		"dry run writes nothing": {
+			args:       []string{"--dry-run"},
+			wantOutput: []string{"Would import", "001-login", "002-search"},
+		},
+		"import": {
+			wantOutput: []string{"✓ Imported", "Imported 2 of 2 specs", "TODO fields"},
+			wantSpecs:  []string{"001-login", "002-search"},
+		},
+		"import into namespace": {
+			args:       []string{"--namespace", "api"},
+			wantOutput: []string{"✓ Imported", "API-001-login", "API-002-search"},
+			wantSpecs:  []string{"API-001-login", "API-002-search"},
+		},
+		"import with extract": {
+			args:        []string{"--extract"},
+			wantOutput:  []string{"Extracting 001-login", "Extracting 002-search"},
+			wantSpecs:   []string{"001-login", "002-search"},
+			wantExtract: []string{"001-login", "002-search"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			docs := filepath.Join(dir, "docs")
+			specsDir := filepath.Join(dir, "specs")
+			require.NoError(t, os.MkdirAll(docs, 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(docs, "login.md"), []byte("# Login\n"), 0o644))
+			require.NoError(t, os.WriteFile(filepath.Join(docs, "search.md"), []byte("# Search\n"), 0o644))
+
+			var extracted []string
+			orig := ImportExtractor
+			ImportExtractor = func(_ *cobra.Command, _ *config.Configuration, imp spec.ImportedSpec) error {
+				extracted = append(extracted, imp.Branch)
+				return nil
+			}
+			t.Cleanup(func() { ImportExtractor = orig })
+
+			cmd, out := newImportTestCmd(specsDir)
+			cmd.SetArgs(append([]string{docs}, tt.args...))
+			require.NoError(t, cmd.Execute())
+
+			for _, want := range tt.wantOutput {
+				assert.Contains(t, out.String(), want)
+			}
+			for _, branch := range tt.wantSpecs {
+				assert.FileExists(t, filepath.Join(specsDir, branch, "spec.yaml"))
+			}
+			if tt.wantSpecs == nil {
+				assert.NoDirExists(t, specsDir)
+			}
+			assert.Equal(t, tt.wantExtract, extracted)
+		})
+	}
+}
+
+func TestRunImport_Again(t *testing.T) {
+	dir := t.TempDir()
+	docs := filepath.Join(dir, "docs")
+	specsDir := filepath.Join(dir, "specs")
+	require.NoError(t, os.MkdirAll(docs, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(docs, "login.md"), []byte("# Login\n"), 0o644))
+
+	cmd, _ := newImportTestCmd(specsDir)
+	cmd.SetArgs([]string{docs})
+	require.NoError(t, cmd.Execute())
+
+	require.NoError(t, os.WriteFile(filepath.Join(docs, "search.md"), []byte("# Search\n"), 0o644))
+	cmd, out := newImportTestCmd(specsDir)
+	cmd.SetArgs([]string{docs})
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, out.String(), "Already imported")
+	assert.Contains(t, out.String(), "002-search")
+	entries, err := os.ReadDir(specsDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestRunImport_NoSpecs(t *testing.T) {
+	t.Parallel()
+
+	cmd, _ := newImportTestCmd(filepath.Join(t.TempDir(), "specs"))
+	cmd.SetArgs([]string{t.TempDir()})
+	assert.ErrorContains(t, cmd.Execute(), "no markdown specs found")
+}
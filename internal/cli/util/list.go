@@ -0,0 +1,194 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List all specs with artifacts, task progress and activity (ls)",
+	Long: `List every spec in the specs directory, ordered by number, with its branch,
+status, which artifacts exist, task completion, blocked task count and last
+activity (the newest file modification in the spec directory).
+
+Specs that declare depends_on in spec.yaml are followed by their dependency
+chain, marking each dependency complete (✓) or not (✗).
+
+Archived specs (see 'autospec archive') are not listed.
+Use --output json for machine-readable output.`,
+	Example: `  # List all specs
+  autospec list
+
+  # List specs as JSON for scripts
+  autospec list --output json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runList,
+}
+
+func init() {
+	listCmd.GroupID = shared.GroupGettingStarted
+}
+
+// specListEntry is the JSON shape of a spec in list output.
+type specListEntry struct {
+	Number         string    `json:"number"`
+	Name           string    `json:"name"`
+	Branch         string    `json:"branch,omitempty"`
+	Status         string    `json:"status"`
+	Artifacts      []string  `json:"artifacts"`
+	CompletedTasks int       `json:"completed_tasks"`
+	TotalTasks     int       `json:"total_tasks"`
+	PercentDone    int       `json:"percent_done"`
+	BlockedTasks   int       `json:"blocked_tasks"`
+	LastActivity   time.Time `json:"last_activity"`
+	DependsOn      []string  `json:"depends_on,omitempty"`
+}
+
+func runList(cmd *cobra.Command, _ []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
+	specsDir := resolveSpecsDir(cmd, cfg.SpecsDir)
+	summaries, err := scanSpecsDir(specsDir)
+	if err != nil {
+		return fmt.Errorf("scanning specs directory: %w", err)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	entries := make([]specListEntry, 0, len(summaries))
+	for _, s := range summaries {
+		entries = append(entries, newSpecListEntry(s))
+	}
+
+	if shared.IsJSONOutput(cmd) {
+		return shared.WriteJSON(cmd.OutOrStdout(), entries)
+	}
+	if len(entries) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No specs found in %s/\n", specsDir)
+		return nil
+	}
+	renderSpecList(cmd.OutOrStdout(), entries)
+	renderDependencyChains(cmd.OutOrStdout(), specsDir, entries)
+	return nil
+}
+
+// newSpecListEntry converts a spec summary into a list entry.
+func newSpecListEntry(s SpecSummary) specListEntry {
+	number, name, found := strings.Cut(s.Name, "-")
+	if !found {
+		number, name = "", s.Name
+	}
+	artifacts := make([]string, 0, len(s.ArtifactsPresent))
+	for _, a := range s.ArtifactsPresent {
+		artifacts = append(artifacts, strings.TrimSuffix(a, ".yaml"))
+	}
+	entry := specListEntry{
+		Number:         number,
+		Name:           name,
+		Branch:         s.Branch,
+		Status:         s.Status,
+		Artifacts:      artifacts,
+		CompletedTasks: s.CompletedTasks,
+		TotalTasks:     s.TotalTasks,
+		BlockedTasks:   s.BlockedTasks,
+		LastActivity:   s.LastModified,
+		DependsOn:      s.DependsOn,
+	}
+	if s.TotalTasks > 0 {
+		entry.PercentDone = s.CompletedTasks * 100 / s.TotalTasks
+	}
+	return entry
+}
+
+// renderSpecList writes the spec list as an aligned table.
+func renderSpecList(w io.Writer, entries []specListEntry) {
+	fmt.Fprintf(w, "%-4s %-28s %-32s %-12s %-16s %-12s %-7s %s\n",
+		"NUM", "NAME", "BRANCH", "STATUS", "ARTIFACTS", "TASKS", "BLOCKED", "LAST ACTIVITY")
+	for _, e := range entries {
+		branch := e.Branch
+		if branch == "" {
+			branch = "-"
+		}
+		tasks := "-"
+		if e.TotalTasks > 0 {
+			tasks = fmt.Sprintf("%d/%d %d%%", e.CompletedTasks, e.TotalTasks, e.PercentDone)
+		}
+		activity := "-"
+		if !e.LastActivity.IsZero() {
+			activity = e.LastActivity.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "%-4s %-28s %-32s %-12s %-16s %-12s %-7d %s\n",
+			e.Number, truncate(e.Name, 28), truncate(branch, 32), truncate(e.Status, 12),
+			strings.Join(e.Artifacts, ","), tasks, e.BlockedTasks, activity)
+	}
+}
+
+// renderDependencyChains prints the depends_on tree of every spec that
+// declares dependencies, with each dependency's task progress.
+func renderDependencyChains(w io.Writer, specsDir string, entries []specListEntry) {
+	header := false
+	for _, e := range entries {
+		if len(e.DependsOn) == 0 {
+			continue
+		}
+		if !header {
+			fmt.Fprintln(w, "\nDependencies:")
+			header = true
+		}
+		name := e.Number + "-" + e.Name
+		fmt.Fprintf(w, "  %s\n", name)
+		renderDependencyTree(w, specsDir, filepath.Join(specsDir, name), "  ", map[string]bool{name: true})
+	}
+}
+
+// renderDependencyTree prints the dependencies of the spec in specDir and
+// recurses into them. seen holds the specs on the current path, so cycles
+// are reported instead of followed.
+func renderDependencyTree(w io.Writer, specsDir, specDir, indent string, seen map[string]bool) {
+	statuses, err := spec.CheckDependencies(specsDir, specDir)
+	if err != nil {
+		fmt.Fprintf(w, "%s└─ %v\n", indent, err)
+		return
+	}
+	for i, dep := range statuses {
+		branch, next := "├─ ", "│  "
+		if i == len(statuses)-1 {
+			branch, next = "└─ ", "   "
+		}
+		mark := "✗"
+		if dep.Complete() {
+			mark = "✓"
+		}
+		key := filepath.Base(dep.Directory)
+		if dep.Found() && seen[key] {
+			fmt.Fprintf(w, "%s%s%s %s (cycle)\n", indent, branch, mark, dep.Name)
+			continue
+		}
+		fmt.Fprintf(w, "%s%s%s %s\n", indent, branch, mark, dep)
+		if dep.Found() && !dep.Archived {
+			seen[key] = true
+			renderDependencyTree(w, specsDir, dep.Directory, indent+next, seen)
+			delete(seen, key)
+		}
+	}
+}
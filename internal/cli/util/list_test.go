@@ -0,0 +1,151 @@
+// Package util tests the list command.
+// Related: internal/cli/util/list.go
+// Tags: util, cli, list, specs, json
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSpecListEntry(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		summary SpecSummary
+		want    specListEntry
+	}{
+		"spec with tasks": {
+			summary: SpecSummary{
+				Name:             "003-user-auth",
+				Branch:           "003-user-auth",
+				Status:           "In Progress",
+				ArtifactsPresent: []string{"spec.yaml", "plan.yaml", "tasks.yaml"},
+				CompletedTasks:   2,
+				TotalTasks:       8,
+				BlockedTasks:     1,
+			},
+			want: specListEntry{
+				Number:         "003",
+				Name:           "user-auth",
+				Branch:         "003-user-auth",
+				Status:         "In Progress",
+				Artifacts:      []string{"spec", "plan", "tasks"},
+				CompletedTasks: 2,
+				TotalTasks:     8,
+				PercentDone:    25,
+				BlockedTasks:   1,
+			},
+		},
+		"spec without tasks": {
+			summary: SpecSummary{
+				Name:             "004-cache",
+				Status:           "Draft",
+				ArtifactsPresent: []string{"spec.yaml"},
+			},
+			want: specListEntry{
+				Number:    "004",
+				Name:      "cache",
+				Status:    "Draft",
+				Artifacts: []string{"spec"},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, newSpecListEntry(tt.summary))
+		})
+	}
+}
+
+func TestRenderSpecList(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	renderSpecList(&buf, []specListEntry{
+		{Number: "001", Name: "user-auth", Branch: "001-user-auth", Status: "In Progress",
+			Artifacts: []string{"spec", "plan", "tasks"}, CompletedTasks: 1, TotalTasks: 3, PercentDone: 33, BlockedTasks: 1},
+		{Number: "002", Name: "cache", Status: "Draft", Artifacts: []string{"spec"}},
+	})
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 3)
+	assert.Contains(t, string(lines[0]), "BLOCKED")
+	assert.Regexp(t, `^001\s+user-auth\s+001-user-auth\s+In Progress\s+spec,plan,tasks\s+1/3 33%\s+1\s+-$`, string(lines[1]))
+	assert.Regexp(t, `^002\s+cache\s+-\s+Draft\s+spec\s+-\s+0\s+-$`, string(lines[2]))
+}
+
+func TestRunList_JSON(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	for name, status := range map[string]string{"002-cache": "Draft", "001-user-auth": "Completed"} {
+		dir := filepath.Join(specsDir, name)
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		content := "feature:\n  branch: \"" + name + "\"\n  status: \"" + status + "\"\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "spec.yaml"), []byte(content), 0o644))
+	}
+	// Archived specs are not listed
+	require.NoError(t, os.MkdirAll(filepath.Join(specsDir, "archive", "000-old"), 0o755))
+
+	cmd := &cobra.Command{Use: "list", RunE: runList}
+	cmd.Flags().String("config", "", "")
+	cmd.Flags().String("specs-dir", specsDir, "")
+	cmd.Flags().String("output", "json", "")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+
+	var entries []specListEntry
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entries))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "001", entries[0].Number)
+	assert.Equal(t, "Completed", entries[0].Status)
+	assert.Equal(t, "cache", entries[1].Name)
+	assert.WithinDuration(t, time.Now(), entries[1].LastActivity, time.Minute)
+}
+
+func TestRenderDependencyChains(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	writeSpec := func(name, extra string) string {
+		dir := filepath.Join(specsDir, name)
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		content := "feature:\n  branch: \"" + name + "\"\n" + extra
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "spec.yaml"), []byte(content), 0o644))
+		return dir
+	}
+	auth := writeSpec("002-auth", "depends_on: [\"004\"]\n")
+	tasks := "phases:\n  - number: 1\n    title: Setup\n    tasks:\n      - id: T001\n        title: Task\n        status: Completed\n"
+	require.NoError(t, os.WriteFile(filepath.Join(auth, "tasks.yaml"), []byte(tasks), 0o644))
+	writeSpec("003-billing", "depends_on: [002-auth, 009-missing]\n")
+	writeSpec("004-loop", "depends_on: [003-billing]\n")
+
+	var buf bytes.Buffer
+	renderDependencyChains(&buf, specsDir, []specListEntry{
+		{Number: "001", Name: "standalone"},
+		{Number: "003", Name: "billing", DependsOn: []string{"002-auth", "009-missing"}},
+	})
+
+	assert.Equal(t, `
+Dependencies:
+  003-billing
+  ├─ ✓ 002-auth (1/1 tasks)
+  │  └─ ✗ 004 (no tasks)
+  │     └─ ✗ 003-billing (cycle)
+  └─ ✗ 009-missing (not found)
+`, buf.String())
+}
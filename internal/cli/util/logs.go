@@ -0,0 +1,365 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/session"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// logsPollInterval is how often --follow checks for new output.
+const logsPollInterval = 500 * time.Millisecond
+
+var logsCmd = &cobra.Command{
+	Use:   "logs [spec]",
+	Short: "Show or follow the agent output of a spec's latest run",
+	Long: `Show the agent output of the current or most recent run for a spec:
+every stage attempt, phase and task the run started, in order.
+
+With --follow (-f), keep printing output as the agent writes it, including
+sessions the run starts later, until the run finishes or you press Ctrl+C.
+Use it to watch an implement run started in another terminal, in the
+background or by 'autospec ci'.
+
+The spec defaults to the one detected from the git branch or the most
+recently modified spec. Output is read from the session transcripts, so
+nothing is shown when max_sessions is 0.`,
+	Example: `  # Output of the latest run for the current spec
+  autospec logs
+
+  # Follow a running implement from another terminal
+  autospec logs -f
+
+  # Follow a specific spec, printing raw stream-json events
+  autospec logs 003-auth -f --raw`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteSpecNames),
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configPath, _ := cmd.Flags().GetString("config")
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			cliErr := clierrors.ConfigParseError(configPath, err)
+			clierrors.PrintError(cliErr)
+			return cliErr
+		}
+
+		specName := ""
+		if len(args) > 0 {
+			specName = args[0]
+		} else if metadata, err := spec.DetectCurrentSpec(cfg.SpecsDir); err == nil {
+			specName = fmt.Sprintf("%s-%s", metadata.Number, metadata.Name)
+		}
+
+		follow, _ := cmd.Flags().GetBool("follow")
+		raw, _ := cmd.Flags().GetBool("raw")
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		logs := newRunLogs(cfg.StateDir, specName, cmd.OutOrStdout(), cmd.ErrOrStderr(), raw)
+		return logs.run(ctx, follow, logsPollInterval)
+	},
+}
+
+func init() {
+	logsCmd.GroupID = shared.GroupConfiguration
+	logsCmd.Flags().BoolP("follow", "f", false, "Keep printing output until the run finishes")
+	logsCmd.Flags().Bool("raw", false, "Print stream-json output without formatting")
+}
+
+// runLogs prints the sessions of the latest run for a spec.
+type runLogs struct {
+	stateDir string
+	spec     string // Empty matches every spec
+	out      io.Writer
+	errOut   io.Writer
+	raw      bool
+
+	entry *history.HistoryEntry    // History entry of the run (nil if unknown)
+	shown map[string]bool          // Sessions already printed or being followed
+	live  map[string]*followedLive // Sessions being followed, by ID
+	order []string                 // IDs in live, in the order they started
+}
+
+// followedLive is a running session whose output is being printed.
+type followedLive struct {
+	log    *session.LiveLog
+	stdout io.Writer // Created with the first output, once StreamJSON is known
+}
+
+func newRunLogs(stateDir, specName string, out, errOut io.Writer, raw bool) *runLogs {
+	return &runLogs{
+		stateDir: stateDir,
+		spec:     specName,
+		out:      out,
+		errOut:   errOut,
+		raw:      raw,
+		shown:    make(map[string]bool),
+		live:     make(map[string]*followedLive),
+	}
+}
+
+// run prints the run's sessions and, with follow, polls for new output at
+// interval until the run finishes or ctx is cancelled.
+func (l *runLogs) run(ctx context.Context, follow bool, interval time.Duration) error {
+	defer l.closeAll()
+
+	l.entry = latestRun(l.stateDir, l.spec)
+	if l.entry != nil {
+		fmt.Fprintf(l.out, "%s %s\n", color.New(color.Bold).Sprint("Run:"), l.describeRun())
+	}
+	found, err := l.poll()
+	if err != nil {
+		return err
+	}
+	if !found {
+		if !follow || !l.running() {
+			l.printNoSessions()
+			return nil
+		}
+		fmt.Fprintln(l.out, "Waiting for the agent to start...")
+	}
+	if !follow {
+		return nil
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		if _, err := l.poll(); err != nil {
+			return err
+		}
+		if len(l.live) == 0 && !l.running() {
+			if l.entry != nil {
+				fmt.Fprintf(l.out, "\n%s %s\n", color.New(color.Bold).Sprint("Run:"), l.describeRun())
+			}
+			return nil
+		}
+	}
+}
+
+// poll prints sessions that finished or started since the last call and new
+// output of followed sessions. It reports whether the run has any sessions.
+func (l *runLogs) poll() (bool, error) {
+	for _, id := range append([]string(nil), l.order...) {
+		l.printLive(id)
+	}
+
+	finished, err := session.List(l.stateDir)
+	if err != nil {
+		return false, fmt.Errorf("loading sessions: %w", err)
+	}
+	running, err := session.ListLive(l.stateDir)
+	if err != nil {
+		return false, fmt.Errorf("loading sessions: %w", err)
+	}
+	finished, running = l.inRun(finished), l.inRun(running)
+	if l.entry == nil && len(running) > 0 {
+		finished = nil // Only the running session is the latest
+	}
+
+	for _, s := range finished {
+		if !l.shown[s.ID] {
+			l.shown[s.ID] = true
+			l.printSession(s)
+		}
+	}
+	for _, s := range running {
+		if l.shown[s.ID] {
+			continue
+		}
+		liveLog, err := session.OpenLive(l.stateDir, s.ID)
+		if err != nil {
+			continue // Finished in the meantime; printed on the next poll
+		}
+		l.shown[s.ID] = true
+		l.printBanner(liveLog.Session)
+		l.live[s.ID] = &followedLive{log: liveLog}
+		l.order = append(l.order, s.ID)
+		l.printLive(s.ID)
+	}
+	return len(l.shown) > 0, nil
+}
+
+// inRun returns the sessions that belong to the run. Without a history entry
+// only the latest session of the spec is shown.
+func (l *runLogs) inRun(sessions []*session.Session) []*session.Session {
+	var matched []*session.Session
+	for _, s := range sessions {
+		if l.spec != "" && s.Spec != l.spec && s.Spec != "" {
+			continue
+		}
+		if l.entry != nil && s.StartedAt.Before(l.entry.Timestamp) {
+			continue
+		}
+		matched = append(matched, s)
+	}
+	if l.entry == nil && len(matched) > 1 {
+		matched = matched[len(matched)-1:]
+	}
+	return matched
+}
+
+// printLive prints the new output of a followed session, and its result
+// once its transcript is saved.
+func (l *runLogs) printLive(id string) {
+	followed := l.live[id]
+	done := session.Finished(l.stateDir, id)
+	chunks, _ := followed.log.Next()
+	l.writeChunks(followed, chunks)
+	if !done {
+		return
+	}
+
+	if fw, ok := followed.stdout.(*workflow.FormatterWriter); ok {
+		fw.Flush()
+	}
+	followed.log.Close()
+	delete(l.live, id)
+	for i, o := range l.order {
+		if o == id {
+			l.order = append(l.order[:i], l.order[i+1:]...)
+			break
+		}
+	}
+	if s, err := session.Load(l.stateDir, id); err == nil {
+		l.printResult(s)
+	}
+}
+
+// writeChunks writes output to stdout, formatted unless raw, or stderr.
+func (l *runLogs) writeChunks(followed *followedLive, chunks []session.Chunk) {
+	for _, c := range chunks {
+		if c.Stream == session.StreamStderr {
+			_, _ = io.WriteString(l.errOut, c.Text)
+			continue
+		}
+		if followed.stdout == nil {
+			followed.stdout = sessionStdout(l.out, followed.log.Session, l.raw)
+		}
+		_, _ = io.WriteString(followed.stdout, c.Text)
+	}
+}
+
+// printSession prints a finished session with its banner and result.
+func (l *runLogs) printSession(s *session.Session) {
+	l.printBanner(s)
+	stdout := sessionStdout(l.out, s, l.raw)
+	for _, c := range s.Output {
+		if c.Stream == session.StreamStderr {
+			_, _ = io.WriteString(l.errOut, c.Text)
+			continue
+		}
+		_, _ = io.WriteString(stdout, c.Text)
+	}
+	if fw, ok := stdout.(*workflow.FormatterWriter); ok {
+		fw.Flush()
+	}
+	l.printResult(s)
+}
+
+// printBanner introduces a session's output.
+func (l *runLogs) printBanner(s *session.Session) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	fmt.Fprintf(l.out, "\n%s\n", cyan(fmt.Sprintf("==> %s (attempt %d) %s <==", s.Label(), s.Attempt, s.ID)))
+}
+
+// printResult prints how a session ended.
+func (l *runLogs) printResult(s *session.Session) {
+	fmt.Fprintf(l.out, "%s %s exit=%s in %s\n", color.New(color.Faint).Sprint("==>"), s.Label(), formatSessionExit(s), s.Duration)
+}
+
+// printNoSessions explains why there is nothing to show.
+func (l *runLogs) printNoSessions() {
+	if l.spec != "" {
+		fmt.Fprintf(l.out, "No agent output recorded for spec '%s'.\n", l.spec)
+		return
+	}
+	fmt.Fprintln(l.out, "No agent output recorded.")
+}
+
+// running reports whether the run's history entry is still running,
+// re-reading history so a follower notices when the run ends.
+func (l *runLogs) running() bool {
+	if l.entry == nil {
+		return false
+	}
+	if latest := findEntry(l.stateDir, l.entry.ID); latest != nil {
+		l.entry = latest
+	}
+	return l.entry.Status == history.StatusRunning
+}
+
+// describeRun summarizes the run's command, spec and state.
+func (l *runLogs) describeRun() string {
+	e := l.entry
+	desc := e.Command
+	if e.Spec != "" {
+		desc += " " + e.Spec
+	}
+	switch e.Status {
+	case history.StatusRunning:
+		return fmt.Sprintf("%s (running since %s)", desc, e.Timestamp.Local().Format("15:04:05"))
+	case "":
+		return fmt.Sprintf("%s (started %s)", desc, e.Timestamp.Local().Format("2006-01-02 15:04:05"))
+	default:
+		return fmt.Sprintf("%s (%s in %s, exit %d)", desc, e.Status, e.Duration, e.ExitCode)
+	}
+}
+
+// latestRun returns the most recent history entry for specName (any spec
+// when empty), or nil when there is none.
+func latestRun(stateDir, specName string) *history.HistoryEntry {
+	histFile, err := history.LoadHistory(stateDir)
+	if err != nil {
+		return nil
+	}
+	for i := len(histFile.Entries) - 1; i >= 0; i-- {
+		if e := histFile.Entries[i]; specName == "" || e.Spec == specName {
+			return &e
+		}
+	}
+	return nil
+}
+
+// findEntry returns the history entry with the given ID, or nil.
+func findEntry(stateDir, id string) *history.HistoryEntry {
+	if id == "" {
+		return nil
+	}
+	histFile, err := history.LoadHistory(stateDir)
+	if err != nil {
+		return nil
+	}
+	for i := range histFile.Entries {
+		if histFile.Entries[i].ID == id {
+			return &histFile.Entries[i]
+		}
+	}
+	return nil
+}
+
+// closeAll closes the live logs still being followed.
+func (l *runLogs) closeAll() {
+	for _, followed := range l.live {
+		followed.log.Close()
+	}
+}
@@ -0,0 +1,129 @@
+// Package util tests the logs command implementation.
+// Related: internal/cli/util/logs.go
+// Tags: util, cli, logs, sessions, follow
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var logsRunStart = time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+
+// saveLogsSession saves a finished session with a line of stdout.
+func saveLogsSession(t *testing.T, stateDir, id, spec, stage string, start time.Time, text string) {
+	t.Helper()
+	s := &session.Session{
+		ID: id, Spec: spec, Stage: stage, Attempt: 1, StartedAt: start, Duration: "1s",
+		Output: []session.Chunk{{Stream: session.StreamStdout, Text: text}},
+	}
+	require.NoError(t, session.Save(stateDir, s, 0))
+}
+
+func TestRunLogs_FinishedRun(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	require.NoError(t, history.SaveHistory(stateDir, &history.HistoryFile{Entries: []history.HistoryEntry{
+		{ID: "old_run", Command: "plan", Spec: "003-auth", Timestamp: logsRunStart.Add(-time.Hour), Status: history.StatusCompleted},
+		{ID: "this_run", Command: "implement", Spec: "003-auth", Timestamp: logsRunStart, Status: history.StatusFailed, ExitCode: 1, Duration: "2m"},
+	}}))
+	saveLogsSession(t, stateDir, "s1", "003-auth", "plan", logsRunStart.Add(-time.Hour), "previous run\n")
+	saveLogsSession(t, stateDir, "s2", "003-auth", "implement", logsRunStart.Add(time.Second), "phase one output\n")
+	saveLogsSession(t, stateDir, "s3", "004-other", "implement", logsRunStart.Add(2*time.Second), "other spec\n")
+
+	tests := map[string]struct {
+		spec        string
+		wantContain []string
+		wantMissing []string
+	}{
+		"latest run of the spec": {
+			spec:        "003-auth",
+			wantContain: []string{"implement 003-auth (failed in 2m, exit 1)", "==> implement (attempt 1) s2 <==", "phase one output"},
+			wantMissing: []string{"previous run", "other spec"},
+		},
+		"spec without runs": {
+			spec:        "009-none",
+			wantContain: []string{"No agent output recorded for spec '009-none'."},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var out, errOut bytes.Buffer
+			logs := newRunLogs(stateDir, tt.spec, &out, &errOut, false)
+
+			require.NoError(t, logs.run(context.Background(), false, time.Millisecond))
+			for _, want := range tt.wantContain {
+				assert.Contains(t, out.String(), want)
+			}
+			for _, missing := range tt.wantMissing {
+				assert.NotContains(t, out.String(), missing)
+			}
+		})
+	}
+}
+
+func TestRunLogs_WithoutHistoryShowsLatestSession(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	saveLogsSession(t, stateDir, "s1", "003-auth", "plan", logsRunStart, "plan output\n")
+	saveLogsSession(t, stateDir, "s2", "003-auth", "tasks", logsRunStart.Add(time.Minute), "tasks output\n")
+
+	var out, errOut bytes.Buffer
+	require.NoError(t, newRunLogs(stateDir, "003-auth", &out, &errOut, false).run(context.Background(), false, time.Millisecond))
+	assert.Contains(t, out.String(), "tasks output")
+	assert.NotContains(t, out.String(), "plan output")
+}
+
+func TestRunLogs_FollowsRunningSession(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	entries := []history.HistoryEntry{
+		{ID: "this_run", Command: "implement", Spec: "003-auth", Timestamp: time.Now().Add(-time.Second), Status: history.StatusRunning},
+	}
+	require.NoError(t, history.SaveHistory(stateDir, &history.HistoryFile{Entries: entries}))
+
+	rec := session.NewRecorder(&session.Session{Spec: "003-auth", Stage: "implement", Phase: 1, Attempt: 1})
+	require.NoError(t, rec.StartLive(stateDir))
+	_, _ = rec.Stdout().Write([]byte("already written\n"))
+
+	var out, errOut bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		done <- newRunLogs(stateDir, "003-auth", &out, &errOut, false).run(context.Background(), true, 5*time.Millisecond)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	_, _ = rec.Stdout().Write([]byte("written while following\n"))
+	_, _ = rec.Stderr().Write([]byte("a warning\n"))
+	time.Sleep(20 * time.Millisecond)
+	rec.SetExitCode(0)
+	require.NoError(t, session.Save(stateDir, rec.Finish(nil), 0))
+	entries[0].Status = history.StatusCompleted
+	entries[0].Duration = "3s"
+	require.NoError(t, history.SaveHistory(stateDir, &history.HistoryFile{Entries: entries}))
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("logs --follow did not stop after the run finished")
+	}
+	assert.Contains(t, out.String(), "implement 003-auth (running since")
+	assert.Contains(t, out.String(), "==> implement phase 1 (attempt 1)")
+	assert.Contains(t, out.String(), "already written\nwritten while following\n")
+	assert.Contains(t, out.String(), "implement 003-auth (completed in 3s, exit 0)")
+	assert.Equal(t, "a warning\n", errOut.String())
+}
@@ -0,0 +1,220 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/metrics"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Show local usage metrics",
+	Long: `Show how you use autospec: command runs and failures, stage sessions and
+retries, and agent backends, with average durations.
+
+Metrics are recorded in <state_dir>/metrics.yaml while metrics.enabled is
+true (the default) and stay on this machine. Setting metrics.upload and
+metrics.upload_url additionally sends anonymized aggregates once a day:
+counts and durations per command, stage and agent, plus the autospec
+version, OS and a random install ID. Spec names, paths, prompts and agent
+output are never sent; 'autospec metrics upload --dry-run' prints the exact
+payload.`,
+	Example: `  # Show metrics
+  autospec metrics show
+
+  # Preview what an upload would send
+  autospec metrics upload --dry-run
+
+  # Stop recording
+  autospec config set metrics.enabled false`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return metricsShowCmd.RunE(cmd, args)
+	},
+}
+
+var metricsShowCmd = &cobra.Command{
+	Use:          "show",
+	Short:        "Show command, stage and agent metrics",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadMetricsConfig(cmd)
+		if err != nil {
+			return err
+		}
+		return runMetricsShow(cmd, cfg.StateDir, cfg.Metrics)
+	},
+}
+
+var metricsUploadCmd = &cobra.Command{
+	Use:   "upload",
+	Short: "Upload anonymized metric aggregates now",
+	Long: `Send the anonymized aggregates to metrics.upload_url now instead of
+waiting for the daily upload. With --dry-run the payload is printed and
+nothing is sent, whether or not uploads are enabled.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadMetricsConfig(cmd)
+		if err != nil {
+			return err
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		return runMetricsUpload(cmd, cfg.StateDir, cfg.Metrics, dryRun, time.Now().UTC())
+	},
+}
+
+var metricsResetCmd = &cobra.Command{
+	Use:          "reset",
+	Short:        "Clear recorded metrics and start a new install ID",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadMetricsConfig(cmd)
+		if err != nil {
+			return err
+		}
+		if err := metrics.Reset(cfg.StateDir); err != nil {
+			return fmt.Errorf("resetting metrics: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), "Metrics reset.")
+		return nil
+	},
+}
+
+func init() {
+	metricsCmd.GroupID = shared.GroupConfiguration
+	metricsUploadCmd.Flags().Bool("dry-run", false, "Print the payload instead of sending it")
+	metricsCmd.AddCommand(metricsShowCmd, metricsUploadCmd, metricsResetCmd)
+}
+
+// loadMetricsConfig loads the configuration for the metrics commands.
+func loadMetricsConfig(cmd *cobra.Command) (*config.Configuration, error) {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return nil, cliErr
+	}
+	return cfg, nil
+}
+
+// runMetricsShow prints the metrics recorded in stateDir.
+func runMetricsShow(cmd *cobra.Command, stateDir string, mc config.MetricsConfig) error {
+	m, err := metrics.Load(stateDir)
+	if err != nil {
+		return fmt.Errorf("loading metrics: %w", err)
+	}
+	if shared.IsJSONOutput(cmd) {
+		return shared.WriteJSON(cmd.OutOrStdout(), m)
+	}
+
+	out := cmd.OutOrStdout()
+	if !mc.Enabled {
+		fmt.Fprintln(out, "Metrics recording is off (metrics.enabled: false).")
+	}
+	if len(m.Commands) == 0 && len(m.Stages) == 0 {
+		fmt.Fprintln(out, "No metrics recorded.")
+		return nil
+	}
+	displayMetrics(out, m, mc)
+	return nil
+}
+
+// runMetricsUpload sends, or with dryRun prints, the upload payload.
+func runMetricsUpload(cmd *cobra.Command, stateDir string, mc config.MetricsConfig, dryRun bool, now time.Time) error {
+	m, err := metrics.Load(stateDir)
+	if err != nil {
+		return fmt.Errorf("loading metrics: %w", err)
+	}
+	payload := metrics.NewPayload(m, Version)
+	if dryRun {
+		return shared.WriteJSON(cmd.OutOrStdout(), payload)
+	}
+	if !mc.Upload || mc.UploadURL == "" {
+		return fmt.Errorf("uploads are off; set metrics.upload and metrics.upload_url to enable them")
+	}
+
+	if err := metrics.Upload(mc.UploadURL, payload); err != nil {
+		return fmt.Errorf("uploading metrics: %w", err)
+	}
+	m.LastUpload = &now
+	if err := metrics.Save(stateDir, m); err != nil {
+		return fmt.Errorf("saving metrics: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Uploaded metrics to %s\n", mc.UploadURL)
+	return nil
+}
+
+// displayMetrics prints the command, stage and agent tables.
+func displayMetrics(out io.Writer, m *metrics.Metrics, mc config.MetricsConfig) {
+	bold := color.New(color.Bold).SprintFunc()
+
+	upload := "upload off"
+	if mc.Upload {
+		upload = "upload on"
+	}
+	fmt.Fprintf(out, "Metrics since %s (%s)\n", m.Since.Local().Format("2006-01-02"), upload)
+
+	if len(m.Commands) > 0 {
+		fmt.Fprintf(out, "\n%s\n", bold(fmt.Sprintf("%-24s %8s %8s %10s", "COMMAND", "RUNS", "FAILED", "AVG TIME")))
+		for _, name := range byCount(m.Commands) {
+			s := m.Commands[name]
+			fmt.Fprintf(out, "%-24s %8d %8d %10s\n", name, s.Count, s.Failures, averageDuration(s))
+		}
+	}
+	if len(m.Stages) > 0 {
+		fmt.Fprintf(out, "\n%s\n", bold(fmt.Sprintf("%-24s %8s %8s %8s %10s", "STAGE", "SESSIONS", "RETRIES", "FAILED", "AVG TIME")))
+		stages := byCount(m.Stages)
+		sort.SliceStable(stages, func(i, j int) bool { return phaseRank(stages[i]) < phaseRank(stages[j]) })
+		for _, name := range stages {
+			s := m.Stages[name]
+			fmt.Fprintf(out, "%-24s %8d %8d %8d %10s\n", name, s.Count, s.Retries, s.Failures, averageDuration(s))
+		}
+	}
+	if len(m.Agents) > 0 {
+		fmt.Fprintf(out, "\n%s\n", bold(fmt.Sprintf("%-24s %8s %8s %8s %10s", "AGENT", "SESSIONS", "RETRIES", "FAILED", "AVG TIME")))
+		for _, name := range byCount(m.Agents) {
+			s := m.Agents[name]
+			fmt.Fprintf(out, "%-24s %8d %8d %8d %10s\n", name, s.Count, s.Retries, s.Failures, averageDuration(s))
+		}
+	}
+}
+
+// byCount returns the keys of stats, most frequent first, then by name.
+func byCount(stats map[string]metrics.Stats) []string {
+	keys := make([]string, 0, len(stats))
+	for k := range stats {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if stats[keys[i]].Count != stats[keys[j]].Count {
+			return stats[keys[i]].Count > stats[keys[j]].Count
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// averageDuration returns the mean duration of the runs in s, rounded for display.
+func averageDuration(s metrics.Stats) string {
+	if s.Count == 0 {
+		return "-"
+	}
+	avg := time.Duration(s.Seconds / float64(s.Count) * float64(time.Second))
+	if avg >= time.Minute {
+		return avg.Round(time.Second).String()
+	}
+	return avg.Round(100 * time.Millisecond).String()
+}
@@ -0,0 +1,167 @@
+// Package util tests the metrics command implementation.
+// Related: internal/cli/util/metrics.go
+// Tags: util, cli, metrics, telemetry
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/metrics"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// metricsTestStateDir returns a state directory with recorded metrics.
+func metricsTestStateDir(t *testing.T) string {
+	t.Helper()
+	stateDir := t.TempDir()
+	r := &metrics.Recorder{}
+	r.RecordStage("implement", "claude", 1, false, 90*time.Second)
+	r.RecordStage("implement", "claude", 2, true, 30*time.Second)
+	r.RecordStage("plan", "claude", 1, true, 20*time.Second)
+	r.RecordCommand("run", false, 3*time.Minute)
+	r.RecordCommand("status", true, 200*time.Millisecond)
+	_, err := r.Flush(stateDir)
+	require.NoError(t, err)
+	return stateDir
+}
+
+func TestRunMetricsShow(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		empty        bool
+		config       config.MetricsConfig
+		flags        []string
+		wantContains []string
+	}{
+		"tables": {
+			config: config.MetricsConfig{Enabled: true},
+			wantContains: []string{
+				"(upload off)",
+				"COMMAND", "run", "3m0s",
+				"STAGE", "implement", "1m0s",
+				"AGENT", "claude",
+			},
+		},
+		"recording off": {
+			config:       config.MetricsConfig{},
+			wantContains: []string{"Metrics recording is off", "COMMAND"},
+		},
+		"nothing recorded": {
+			empty:        true,
+			config:       config.MetricsConfig{Enabled: true},
+			wantContains: []string{"No metrics recorded."},
+		},
+		"json output": {
+			config:       config.MetricsConfig{Enabled: true},
+			flags:        []string{"--output", "json"},
+			wantContains: []string{`"install_id"`, `"retries": 1`},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			stateDir := t.TempDir()
+			if !tt.empty {
+				stateDir = metricsTestStateDir(t)
+			}
+			cmd := &cobra.Command{}
+			shared.AddOutputFlag(cmd)
+			require.NoError(t, cmd.ParseFlags(tt.flags))
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+
+			require.NoError(t, runMetricsShow(cmd, stateDir, tt.config))
+			for _, want := range tt.wantContains {
+				assert.Contains(t, out.String(), want)
+			}
+			if shared.IsJSONOutput(cmd) {
+				assert.True(t, json.Valid(out.Bytes()))
+			}
+		})
+	}
+}
+
+func TestRunMetricsShow_StageOrder(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, runMetricsShow(cmd, metricsTestStateDir(t), config.MetricsConfig{Enabled: true}))
+	// Stages are listed in workflow order even when implement ran more often
+	assert.Less(t, bytes.Index(out.Bytes(), []byte("plan ")), bytes.Index(out.Bytes(), []byte("implement ")))
+}
+
+func TestRunMetricsUpload(t *testing.T) {
+	t.Parallel()
+
+	var received metrics.Payload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		config       config.MetricsConfig
+		dryRun       bool
+		wantErr      string
+		wantContains string
+		wantUploaded bool
+	}{
+		"dry run prints payload without uploading": {
+			dryRun:       true,
+			wantContains: `"os":`,
+		},
+		"uploads off": {
+			config:  config.MetricsConfig{Enabled: true},
+			wantErr: "uploads are off",
+		},
+		"upload": {
+			config:       config.MetricsConfig{Enabled: true, Upload: true, UploadURL: server.URL},
+			wantContains: "Uploaded metrics to",
+			wantUploaded: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Not parallel: subtests share the test server's received payload
+			stateDir := metricsTestStateDir(t)
+			cmd := &cobra.Command{}
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+
+			err := runMetricsUpload(cmd, stateDir, tt.config, tt.dryRun, now)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), tt.wantContains)
+
+			m, err := metrics.Load(stateDir)
+			require.NoError(t, err)
+			if tt.wantUploaded {
+				assert.Equal(t, m.InstallID, received.InstallID)
+				require.NotNil(t, m.LastUpload)
+				assert.True(t, m.LastUpload.Equal(now))
+			} else {
+				assert.Nil(t, m.LastUpload)
+			}
+		})
+	}
+}
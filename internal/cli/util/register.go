@@ -1,5 +1,5 @@
 // Package util provides utility CLI commands for autospec.
-// Includes: status, history, version, clean, worktree
+// Includes: status, list, ui, serve, history, cost, sessions, snapshots, runs, undo, render, diff, version, clean, archive, import, sync, state, spec, worktree
 package util
 
 import (
@@ -12,11 +12,29 @@ import (
 func Register(rootCmd *cobra.Command) {
 	rootCmd.AddCommand(statusCmd)
 	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(costCmd)
+	rootCmd.AddCommand(sessionsCmd)
+	rootCmd.AddCommand(snapshotsCmd)
+	rootCmd.AddCommand(logsCmd)
+	rootCmd.AddCommand(metricsCmd)
+	rootCmd.AddCommand(runsCmd)
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(renderCmd)
+	rootCmd.AddCommand(diffCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(sauceCmd)
 	rootCmd.AddCommand(cleanCmd)
+	rootCmd.AddCommand(archiveCmd)
+	rootCmd.AddCommand(unarchiveCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(stateCmd)
+	rootCmd.AddCommand(specCmd)
 	rootCmd.AddCommand(viewCmd)
+	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(uiCmd)
+	rootCmd.AddCommand(serveCmd)
 	rootCmd.AddCommand(ckCmd)
 	rootCmd.AddCommand(worktree.WorktreeCmd)
 
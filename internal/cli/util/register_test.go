@@ -36,10 +36,27 @@ func TestRegister(t *testing.T) {
 	// Should have status, history, version, sauce, clean, view, worktree, ck commands
 	assert.True(t, commandNames["status"], "Should have 'status' command")
 	assert.True(t, commandNames["history"], "Should have 'history' command")
+	assert.True(t, commandNames["cost"], "Should have 'cost' command")
+	assert.True(t, commandNames["sessions"], "Should have 'sessions' command")
+	assert.True(t, commandNames["logs"], "Should have 'logs' command")
+	assert.True(t, commandNames["metrics"], "Should have 'metrics' command")
+	assert.True(t, commandNames["runs"], "Should have 'runs' command")
+	assert.True(t, commandNames["undo"], "Should have 'undo' command")
+	assert.True(t, commandNames["render"], "Should have 'render' command")
+	assert.True(t, commandNames["diff"], "Should have 'diff' command")
+	assert.True(t, commandNames["snapshots"], "Should have 'snapshots' command")
 	assert.True(t, commandNames["version"], "Should have 'version' command")
 	assert.True(t, commandNames["sauce"], "Should have 'sauce' command")
 	assert.True(t, commandNames["clean"], "Should have 'clean' command")
+	assert.True(t, commandNames["archive"], "Should have 'archive' command")
+	assert.True(t, commandNames["unarchive"], "Should have 'unarchive' command")
+	assert.True(t, commandNames["import"], "Should have 'import' command")
+	assert.True(t, commandNames["sync"], "Should have 'sync' command")
+	assert.True(t, commandNames["state"], "Should have 'state' command")
 	assert.True(t, commandNames["view"], "Should have 'view' command")
+	assert.True(t, commandNames["list"], "Should have 'list' command")
+	assert.True(t, commandNames["ui"], "Should have 'ui' command")
+	assert.True(t, commandNames["serve"], "Should have 'serve' command")
 	assert.True(t, commandNames["worktree"], "Should have 'worktree' command")
 	assert.True(t, commandNames["ck"], "Should have 'ck' command")
 }
@@ -111,8 +128,8 @@ func TestRegister_CommandCount(t *testing.T) {
 
 	Register(rootCmd)
 
-	// Should register exactly 10 commands (status, history, version, update, sauce, clean, view, dag, worktree, ck)
-	assert.Equal(t, 10, len(rootCmd.Commands()))
+	// Should register exactly 28 commands (status, history, cost, sessions, snapshots, logs, metrics, runs, undo, render, diff, version, update, sauce, clean, archive, unarchive, import, sync, state, spec, view, list, ui, serve, dag, worktree, ck)
+	assert.Equal(t, 28, len(rootCmd.Commands()))
 }
 
 func TestStatusCmd_Structure(t *testing.T) {
@@ -184,6 +201,14 @@ func TestUtilCommands_HaveRunE(t *testing.T) {
 			cmd:     ckCmd,
 			hasRunE: true,
 		},
+		"archive has RunE": {
+			cmd:     archiveCmd,
+			hasRunE: true,
+		},
+		"unarchive has RunE": {
+			cmd:     unarchiveCmd,
+			hasRunE: true,
+		},
 	}
 
 	for name, tt := range tests {
@@ -0,0 +1,138 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/render"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/spf13/cobra"
+)
+
+var renderCmd = &cobra.Command{
+	Use:   "render [spec-name]",
+	Short: "Render spec artifacts as readable markdown or HTML",
+	Long: `Render a spec's spec.yaml, plan.yaml and tasks.yaml as one polished
+markdown document (or a standalone HTML page with --html) for sharing with
+people who don't use the CLI.
+
+By default every artifact the spec has is rendered, in spec, plan, tasks
+order; --artifact picks a subset. Output goes to stdout unless --file is
+given.
+
+Each artifact is rendered with a Go text/template that receives the parsed
+YAML document, so fields are addressed by their YAML names, e.g.
+{{ .feature.branch }}. To customize the output for a project, add
+.autospec/render/<artifact>.md.tmpl (spec.md.tmpl, plan.md.tmpl or
+tasks.md.tmpl); artifacts without one use the built-in template. Templates
+can use the helpers join, inline, checkbox and progress.`,
+	Example: `  # Render all artifacts of the current spec to stdout
+  autospec render
+
+  # Write an HTML page for a specific spec
+  autospec render 003-auth --html -f auth.html
+
+  # Render only the plan and tasks
+  autospec render --artifact plan,tasks -f handoff.md`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteSpecNames),
+	SilenceUsage:      true,
+	RunE:              runRender,
+}
+
+func init() {
+	renderCmd.GroupID = shared.GroupConfiguration
+	renderCmd.Flags().StringSliceP("artifact", "a", nil, "Artifacts to render: spec, plan, tasks (default: all that exist)")
+	renderCmd.Flags().Bool("html", false, "Render a standalone HTML page instead of markdown")
+	renderCmd.Flags().StringP("file", "f", "", "Write to this file instead of stdout")
+}
+
+func runRender(cmd *cobra.Command, args []string) error {
+	artifacts, _ := cmd.Flags().GetStringSlice("artifact")
+	for _, a := range artifacts {
+		if !render.IsArtifact(a) {
+			return fmt.Errorf("invalid --artifact %q: must be one of %s", a, strings.Join(render.Artifacts, ", "))
+		}
+	}
+
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
+	var metadata *spec.Metadata
+	if len(args) > 0 {
+		metadata, err = spec.GetSpecMetadata(cfg.SpecsDir, args[0])
+	} else {
+		metadata, err = spec.DetectCurrentSpec(cfg.SpecsDir)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find spec: %w", err)
+	}
+
+	doc, err := renderSpec(metadata.Directory, artifacts, render.TemplatesDir())
+	if err != nil {
+		return err
+	}
+	if html, _ := cmd.Flags().GetBool("html"); html {
+		doc = render.HTML(filepath.Base(metadata.Directory), doc)
+	}
+
+	output, _ := cmd.Flags().GetString("file")
+	if output == "" {
+		_, err := fmt.Fprint(cmd.OutOrStdout(), doc)
+		return err
+	}
+	if dir := filepath.Dir(output); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("creating output directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(output, []byte(doc), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", output, err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Rendered %s → %s\n", filepath.Base(metadata.Directory), output)
+	return nil
+}
+
+// renderSpec renders the requested artifacts of specDir as one markdown
+// document. With no artifacts requested, every existing one is rendered.
+func renderSpec(specDir string, artifacts []string, templatesDir string) (string, error) {
+	explicit := len(artifacts) > 0
+	if !explicit {
+		artifacts = render.Artifacts
+	}
+
+	var sections []string
+	for _, artifact := range render.Artifacts {
+		if !slices.Contains(artifacts, artifact) {
+			continue
+		}
+		path := filepath.Join(specDir, artifact+".yaml")
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) && !explicit {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", path, err)
+		}
+		md, err := render.Markdown(artifact, data, templatesDir)
+		if err != nil {
+			return "", err
+		}
+		sections = append(sections, md)
+	}
+	if len(sections) == 0 {
+		return "", fmt.Errorf("no artifacts to render in %s: expected spec.yaml, plan.yaml or tasks.yaml", specDir)
+	}
+	return strings.Join(sections, "\n"), nil
+}
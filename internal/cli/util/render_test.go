@@ -0,0 +1,85 @@
+// Package util tests the render command implementation.
+// Related: internal/cli/util/render.go
+// Tags: util, cli, render, markdown, templates
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		artifacts []string
+		files     []string
+		want      []string
+		notWant   []string
+		wantErr   string
+	}{
+		"all existing artifacts in order": {
+			files:   []string{"tasks", "spec"},
+			want:    []string{"# Specification: 003-auth", "# Tasks: 003-auth"},
+			notWant: []string{"# Implementation Plan"},
+		},
+		"selected artifact only": {
+			artifacts: []string{"tasks"},
+			files:     []string{"spec", "tasks"},
+			want:      []string{"# Tasks: 003-auth"},
+			notWant:   []string{"# Specification"},
+		},
+		"selected artifact missing": {
+			artifacts: []string{"plan"},
+			files:     []string{"spec"},
+			wantErr:   "reading",
+		},
+		"no artifacts": {
+			wantErr: "no artifacts to render",
+		},
+	}
+	contents := map[string]string{
+		"spec":  "feature:\n  branch: 003-auth\n",
+		"tasks": "tasks:\n  branch: 003-auth\nphases:\n  - number: 1\n    title: Setup\n    tasks:\n      - id: T001\n        title: Create module\n        status: Completed\n",
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			specDir := t.TempDir()
+			for _, f := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(specDir, f+".yaml"), []byte(contents[f]), 0o644))
+			}
+
+			doc, err := renderSpec(specDir, tt.artifacts, "")
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			for _, want := range tt.want {
+				assert.Contains(t, doc, want)
+			}
+			for _, notWant := range tt.notWant {
+				assert.NotContains(t, doc, notWant)
+			}
+		})
+	}
+}
+
+func TestRenderSpec_OrderFollowsDocument(t *testing.T) {
+	t.Parallel()
+	specDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "spec.yaml"), []byte("feature:\n  branch: x\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "plan.yaml"), []byte("plan:\n  branch: x\n"), 0o644))
+
+	doc, err := renderSpec(specDir, []string{"plan", "spec"}, "")
+	require.NoError(t, err)
+	assert.Less(t, strings.Index(doc, "# Specification"), strings.Index(doc, "# Implementation Plan"))
+}
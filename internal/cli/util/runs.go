@@ -0,0 +1,260 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/runs"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// attachPollInterval is how often attach checks for new output.
+const attachPollInterval = 500 * time.Millisecond
+
+// killWait is how long kill waits for a run to exit after SIGTERM.
+const killWait = 10 * time.Second
+
+var runsCmd = &cobra.Command{
+	Use:   "runs",
+	Short: "List, attach to or stop background runs",
+	Long: `Manage runs started in the background with 'autospec implement --detach'.
+
+A detached run keeps going after the terminal closes. Its output is written
+to <state_dir>/runs/<id>.log; 'runs attach' prints it and follows it until
+the run finishes, and 'runs kill' stops the run along with the agent it
+started. Run IDs accept a unique prefix.`,
+	Example: `  # Start a long implement in the background
+  autospec implement --tasks --detach
+
+  # List background runs
+  autospec runs
+
+  # Follow the output of the latest running run (Ctrl+C detaches)
+  autospec runs attach
+
+  # Stop a run
+  autospec runs kill brave_fox`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runsListCmd.RunE(cmd, args)
+	},
+}
+
+var runsListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List background runs",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stateDir, err := loadRunsStateDir(cmd)
+		if err != nil {
+			return err
+		}
+		return runRunsList(cmd, stateDir)
+	},
+}
+
+var runsAttachCmd = &cobra.Command{
+	Use:   "attach [id]",
+	Short: "Print and follow the output of a background run",
+	Long: `Print a background run's output and keep following it until the run
+finishes. Ctrl+C stops following; the run keeps going. Without an ID, the
+latest running run (or the latest run) is used.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: shared.FirstArg(completeRunIDs),
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stateDir, err := loadRunsStateDir(cmd)
+		if err != nil {
+			return err
+		}
+		run, err := findRun(stateDir, args)
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return attachRun(ctx, stateDir, run, cmd.OutOrStdout(), attachPollInterval)
+	},
+}
+
+var runsKillCmd = &cobra.Command{
+	Use:   "kill [id]",
+	Short: "Stop a background run",
+	Long: `Stop a background run and the agent it started. The run gets SIGTERM
+and up to 10s to exit; --force sends SIGKILL instead. Without an ID, the
+latest running run is stopped.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: shared.FirstArg(completeRunIDs),
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stateDir, err := loadRunsStateDir(cmd)
+		if err != nil {
+			return err
+		}
+		run, err := findRun(stateDir, args)
+		if err != nil {
+			return err
+		}
+		force, _ := cmd.Flags().GetBool("force")
+		return killRun(stateDir, run, force, cmd.OutOrStdout(), killWait)
+	},
+}
+
+func init() {
+	runsCmd.GroupID = shared.GroupConfiguration
+	runsKillCmd.Flags().Bool("force", false, "Send SIGKILL instead of SIGTERM")
+	runsCmd.AddCommand(runsListCmd, runsAttachCmd, runsKillCmd)
+}
+
+// loadRunsStateDir returns the configured state directory.
+func loadRunsStateDir(cmd *cobra.Command) (string, error) {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return "", cliErr
+	}
+	return cfg.StateDir, nil
+}
+
+// findRun returns the run named by args, or the latest run when args is empty.
+func findRun(stateDir string, args []string) (*runs.Run, error) {
+	if len(args) > 0 {
+		return runs.Load(stateDir, args[0])
+	}
+	run, err := runs.Latest(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, fmt.Errorf("no background runs; start one with 'autospec implement --detach'")
+	}
+	return run, nil
+}
+
+// runRunsList prints the runs recorded in stateDir.
+func runRunsList(cmd *cobra.Command, stateDir string) error {
+	all, err := runs.List(stateDir)
+	if err != nil {
+		return fmt.Errorf("loading runs: %w", err)
+	}
+	if shared.IsJSONOutput(cmd) {
+		return shared.WriteJSON(cmd.OutOrStdout(), all)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(all) == 0 {
+		fmt.Fprintln(out, "No background runs.")
+		return nil
+	}
+	cyan := color.New(color.FgCyan).SprintFunc()
+	for _, run := range all {
+		spec := run.Spec
+		if spec == "" {
+			spec = "-"
+		}
+		fmt.Fprintf(out, "%s  %-30s  %-10s  %-15s  pid=%-7d  %s\n",
+			cyan(run.StartedAt.Local().Format("2006-01-02 15:04:05")),
+			run.ID,
+			run.Command,
+			spec,
+			run.PID,
+			describeRunStatus(run),
+		)
+	}
+	return nil
+}
+
+// attachRun prints the run's log and follows it at interval until the run
+// finishes or ctx is cancelled.
+func attachRun(ctx context.Context, stateDir string, run *runs.Run, out io.Writer, interval time.Duration) error {
+	f, err := os.Open(run.Log)
+	if err != nil {
+		return fmt.Errorf("opening run log: %w", err)
+	}
+	defer f.Close()
+
+	fmt.Fprintf(out, "%s %s %s (%s)\n", color.New(color.Bold).Sprint("Run:"), run.ID, run.Command, describeRunStatus(run))
+	for {
+		// Read the status before the log so output written before the run
+		// finished is always printed
+		if latest, err := runs.Load(stateDir, run.ID); err == nil {
+			run = latest
+		}
+		if _, err := io.Copy(out, f); err != nil {
+			return fmt.Errorf("reading run log: %w", err)
+		}
+		if !run.Running() {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			fmt.Fprintf(out, "\nDetached; run %s keeps running.\n", run.ID)
+			return nil
+		case <-time.After(interval):
+		}
+	}
+	fmt.Fprintf(out, "\n%s %s %s\n", color.New(color.Bold).Sprint("Run:"), run.ID, describeRunStatus(run))
+	return nil
+}
+
+// killRun stops a running run and waits up to wait for it to exit.
+func killRun(stateDir string, run *runs.Run, force bool, out io.Writer, wait time.Duration) error {
+	if err := runs.Kill(stateDir, run, force); err != nil {
+		return err
+	}
+	deadline := time.Now().Add(wait)
+	for !run.Exited() {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("run %s (pid %d) is still running; use --force to kill it", run.ID, run.PID)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	fmt.Fprintf(out, "Stopped run %s.\n", run.ID)
+	return nil
+}
+
+// describeRunStatus summarizes a run's state for listings.
+func describeRunStatus(run *runs.Run) string {
+	switch {
+	case run.Running():
+		return fmt.Sprintf("running for %s", time.Since(run.StartedAt).Round(time.Second))
+	case run.ExitCode != nil && run.FinishedAt != nil:
+		return fmt.Sprintf("%s in %s, exit %d", run.Status, run.FinishedAt.Sub(run.StartedAt).Round(time.Second), *run.ExitCode)
+	default:
+		return string(run.Status)
+	}
+}
+
+// completeRunIDs completes the IDs of recorded runs, newest first.
+func completeRunIDs(cmd *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	all, err := runs.List(cfg.StateDir)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []cobra.Completion
+	for i := len(all) - 1; i >= 0; i-- {
+		if strings.HasPrefix(all[i].ID, toComplete) {
+			completions = append(completions, cobra.CompletionWithDesc(all[i].ID, string(all[i].Status)))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
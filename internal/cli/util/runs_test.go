@@ -0,0 +1,130 @@
+// Package util tests the runs command implementation.
+// Related: internal/cli/util/runs.go
+// Tags: util, cli, runs, detach, background
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/runs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// saveRunLog records a run with the given log output.
+func saveRunLog(t *testing.T, stateDir string, run *runs.Run, output string) {
+	t.Helper()
+	run.Log = runs.LogPath(stateDir, run.ID)
+	require.NoError(t, runs.Save(stateDir, run))
+	require.NoError(t, os.WriteFile(run.Log, []byte(output), 0600))
+}
+
+func TestRunRunsList(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	code := 1
+	started := time.Now().Add(-time.Hour)
+	finished := started.Add(90 * time.Second)
+	saveRunLog(t, stateDir, &runs.Run{
+		ID: "brave_fox", Command: "implement", Spec: "003-auth", PID: 1 << 30,
+		Status: runs.StatusFailed, ExitCode: &code, StartedAt: started, FinishedAt: &finished,
+	}, "")
+	saveRunLog(t, stateDir, &runs.Run{
+		ID: "calm_owl", Command: "implement", PID: os.Getpid(), Status: runs.StatusRunning, StartedAt: time.Now(),
+	}, "")
+
+	tests := map[string]struct {
+		stateDir    string
+		wantContain []string
+	}{
+		"runs": {
+			stateDir:    stateDir,
+			wantContain: []string{"brave_fox", "003-auth", "failed in 1m30s, exit 1", "calm_owl", "running for"},
+		},
+		"no runs": {
+			stateDir:    t.TempDir(),
+			wantContain: []string{"No background runs."},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cmd := &cobra.Command{}
+			cmd.Flags().String("output", "text", "")
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+
+			require.NoError(t, runRunsList(cmd, tt.stateDir))
+			for _, want := range tt.wantContain {
+				assert.Contains(t, out.String(), want)
+			}
+		})
+	}
+}
+
+func TestAttachRun_FollowsUntilFinished(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	run := &runs.Run{ID: "brave_fox", Command: "implement", PID: os.Getpid(), Status: runs.StatusRunning, StartedAt: time.Now()}
+	saveRunLog(t, stateDir, run, "phase 1 started\n")
+
+	var out bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		done <- attachRun(context.Background(), stateDir, run, &out, 5*time.Millisecond)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	f, err := os.OpenFile(run.Log, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString("phase 1 done\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	code := 0
+	finished := time.Now()
+	require.NoError(t, runs.Save(stateDir, &runs.Run{
+		ID: run.ID, Command: run.Command, PID: run.PID, Log: run.Log, Status: runs.StatusCompleted,
+		ExitCode: &code, StartedAt: run.StartedAt, FinishedAt: &finished,
+	}))
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("attach did not stop after the run finished")
+	}
+	assert.Contains(t, out.String(), "brave_fox implement (running for")
+	assert.Contains(t, out.String(), "phase 1 started\nphase 1 done\n")
+	assert.Contains(t, out.String(), "brave_fox completed in 0s, exit 0")
+}
+
+func TestAttachRun_CancelDetaches(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	run := &runs.Run{ID: "brave_fox", Command: "implement", PID: os.Getpid(), Status: runs.StatusRunning, StartedAt: time.Now()}
+	saveRunLog(t, stateDir, run, "working\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var out bytes.Buffer
+	require.NoError(t, attachRun(ctx, stateDir, run, &out, time.Millisecond))
+	assert.Contains(t, out.String(), "working\n")
+	assert.Contains(t, out.String(), "Detached; run brave_fox keeps running.")
+}
+
+func TestFindRun(t *testing.T) {
+	t.Parallel()
+
+	_, err := findRun(t.TempDir(), nil)
+	assert.ErrorContains(t, err, "no background runs")
+}
@@ -0,0 +1,287 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/web"
+	"github.com/spf13/cobra"
+)
+
+// serveShutdownTimeout bounds how long open requests get to finish on exit.
+const serveShutdownTimeout = 5 * time.Second
+
+// apiTokenEnv holds the bearer token for --api. It is read from the
+// environment rather than a flag so it doesn't show up in process listings.
+const apiTokenEnv = "AUTOSPEC_API_TOKEN"
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a web dashboard and JSON API",
+	Long: `Start an HTTP server with a read-only web dashboard of the specs in this
+repository: task progress, active runs and recent history, updated live
+while autospec commands run elsewhere.
+
+Endpoints:
+  GET /                          web dashboard
+  GET /api/specs                 specs with status, artifacts and task stats
+  GET /api/specs/{name}/tasks    tasks of a spec
+  GET /api/specs/{name}/status   stage progress, retries and next command
+  GET /api/history?limit=N       command history, newest first (default 100)
+  GET /api/runs                  commands still running
+  GET /api/events                server-sent events: "update" with specs and runs
+
+With --api, endpoints for driving the workflow remotely are added. They
+require the header "Authorization: Bearer $AUTOSPEC_API_TOKEN":
+  POST /api/specify                        start specify ({"description": "..."})
+  POST /api/specs/{name}/run/{stage}       start plan, tasks or implement
+                                           (implement accepts {"phase": N, "resume": true})
+  GET  /api/jobs                           started jobs, newest first
+  GET  /api/jobs/{id}                      one job's status and exit code
+  GET  /api/jobs/{id}/logs                 server-sent events: "log" per line, then "done"
+  POST /api/jobs/{id}/cancel               interrupt a running job
+
+Jobs run as separate autospec processes with confirmations skipped
+(AUTOSPEC_YES=1), one at a time per spec.
+
+The server listens on localhost by default. Without --api it never modifies
+specs, but it exposes their contents, so bind to other interfaces only on
+trusted networks.`,
+	Example: `  # Serve on http://127.0.0.1:7878
+  autospec serve
+
+  # Share with the team on the local network
+  autospec serve --addr 0.0.0.0:8080
+
+  # Let CI trigger phases
+  export AUTOSPEC_API_TOKEN=$(openssl rand -hex 32)
+  autospec serve --api
+  curl -X POST -H "Authorization: Bearer $AUTOSPEC_API_TOKEN" \
+    http://127.0.0.1:7878/api/specs/001-auth/run/plan`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runServe,
+}
+
+func init() {
+	serveCmd.GroupID = shared.GroupGettingStarted
+	serveCmd.Flags().String("addr", "127.0.0.1:7878", "Address to listen on")
+	serveCmd.Flags().Bool("api", false, "Enable endpoints that run workflow stages (requires "+apiTokenEnv+")")
+}
+
+func runServe(cmd *cobra.Command, _ []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	addr, _ := cmd.Flags().GetString("addr")
+	enableAPI, _ := cmd.Flags().GetBool("api")
+	token := os.Getenv(apiTokenEnv)
+	if enableAPI && token == "" {
+		return fmt.Errorf("--api requires %s to be set to the token clients must send", apiTokenEnv)
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", addr, err)
+	}
+	source := &serveSource{specsDir: resolveSpecsDir(cmd, cfg.SpecsDir), stateDir: cfg.StateDir, maxRetries: cfg.MaxRetries}
+	handler := web.NewServer(source, web.DefaultInterval)
+	var runner *web.JobRunner
+	if enableAPI {
+		executable, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("locating autospec binary: %w", err)
+		}
+		runner = &web.JobRunner{Executable: executable}
+		// Jobs must see the same configuration as the server
+		for _, name := range []string{"config", "specs-dir"} {
+			if cmd.Flags().Changed(name) {
+				value, _ := cmd.Flags().GetString(name)
+				runner.GlobalArgs = append(runner.GlobalArgs, "--"+name, value)
+			}
+		}
+		handler.EnableAPI(runner, token)
+	}
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		// Cancelling ctx ends open event streams so shutdown doesn't wait on them
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Serving autospec dashboard at http://%s (Ctrl+C to stop)\n", listener.Addr())
+	errc := make(chan error, 1)
+	go func() { errc <- server.Serve(listener) }()
+
+	select {
+	case err := <-errc:
+		return fmt.Errorf("serving dashboard: %w", err)
+	case <-ctx.Done():
+	}
+	if runner != nil {
+		runner.CancelAll()
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("stopping dashboard: %w", err)
+	}
+	return nil
+}
+
+// serveSource reads dashboard data from the specs directory and history file.
+type serveSource struct {
+	specsDir   string
+	stateDir   string
+	maxRetries int
+}
+
+// Specs implements web.Source.
+func (s *serveSource) Specs() ([]web.Spec, error) {
+	summaries, err := scanSpecsDir(s.specsDir)
+	if err != nil {
+		return nil, fmt.Errorf("scanning specs directory: %w", err)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	specs := make([]web.Spec, 0, len(summaries))
+	for _, summary := range summaries {
+		item := web.Spec{
+			Name:         summary.Name,
+			Status:       summary.Status,
+			Branch:       summary.Branch,
+			Artifacts:    summary.ArtifactsPresent,
+			LastActivity: summary.LastModified,
+		}
+		tasksPath := validation.GetTasksFilePath(filepath.Join(s.specsDir, summary.Name))
+		if stats, err := validation.GetTaskStats(tasksPath); err == nil {
+			item.Tasks = stats
+		}
+		specs = append(specs, item)
+	}
+	return specs, nil
+}
+
+// specDir resolves a spec name from a request to its directory. Only spec
+// directory names are accepted, so requests can't reach files outside the
+// specs directory.
+func (s *serveSource) specDir(name string) (string, error) {
+	if name != filepath.Base(name) || name == "." || name == ".." {
+		return "", fmt.Errorf("%w: %s", web.ErrSpecNotFound, name)
+	}
+	specDir := filepath.Join(s.specsDir, name)
+	if _, err := os.Stat(filepath.Join(specDir, "spec.yaml")); err != nil {
+		return "", fmt.Errorf("%w: %s", web.ErrSpecNotFound, name)
+	}
+	return specDir, nil
+}
+
+// Tasks implements web.Source.
+func (s *serveSource) Tasks(name string) ([]web.Task, error) {
+	specDir, err := s.specDir(name)
+	if err != nil {
+		return nil, err
+	}
+
+	tasksPath := validation.GetTasksFilePath(specDir)
+	if _, err := os.Stat(tasksPath); os.IsNotExist(err) {
+		return []web.Task{}, nil
+	}
+	tasksFile, err := validation.ParseTasksYAML(tasksPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading tasks of %s: %w", name, err)
+	}
+	tasks := []web.Task{}
+	for _, phase := range tasksFile.Phases {
+		for _, task := range phase.Tasks {
+			tasks = append(tasks, web.Task{
+				ID:            task.ID,
+				Title:         task.Title,
+				Status:        task.Status,
+				Phase:         phase.Number,
+				PhaseTitle:    phase.Title,
+				Dependencies:  task.Dependencies,
+				BlockedReason: task.BlockedReason,
+			})
+		}
+	}
+	return tasks, nil
+}
+
+// Status implements web.Source.
+func (s *serveSource) Status(name string) (*web.SpecStatus, error) {
+	specDir, err := s.specDir(name)
+	if err != nil {
+		return nil, err
+	}
+	number, title, found := strings.Cut(name, "-")
+	if !found {
+		number, title = "", name
+	}
+	detail := buildSpecProgress(&spec.Metadata{Number: number, Name: title, Directory: specDir}, s.stateDir, s.maxRetries)
+
+	status := &web.SpecStatus{
+		Name:      name,
+		Artifacts: detail.Artifacts,
+		Tasks:     detail.Tasks,
+		Next:      detail.Next,
+		Stages: []web.StageStatus{
+			{Stage: "specify", Done: slices.Contains(detail.Artifacts, "spec.yaml")},
+			{Stage: "plan", Done: slices.Contains(detail.Artifacts, "plan.yaml")},
+			{Stage: "tasks", Done: slices.Contains(detail.Artifacts, "tasks.yaml")},
+			{Stage: "implement", Done: detail.Tasks != nil && detail.Tasks.TotalTasks > 0 && detail.Tasks.IsComplete()},
+		},
+	}
+	for _, state := range detail.RetryStates {
+		for i := range status.Stages {
+			if status.Stages[i].Stage == state.Phase {
+				status.Stages[i].Retries = state.Count
+				status.Stages[i].MaxRetries = state.MaxRetries
+			}
+		}
+	}
+	if detail.PhaseState != nil {
+		implement := &status.Stages[len(status.Stages)-1]
+		implement.CompletedPhases = len(detail.PhaseState.CompletedPhases)
+		implement.TotalPhases = detail.PhaseState.TotalPhases
+	}
+	if detail.Checkpoint != nil {
+		status.CurrentTask = detail.Checkpoint.CurrentTask
+	}
+	return status, nil
+}
+
+// History implements web.Source.
+func (s *serveSource) History() ([]history.HistoryEntry, error) {
+	file, err := history.LoadHistory(s.stateDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading history: %w", err)
+	}
+	return file.Entries, nil
+}
@@ -0,0 +1,88 @@
+// Package util tests the serve command's dashboard data source.
+// Related: internal/cli/util/serve.go
+// Tags: util, cli, serve, web
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeSource(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	stateDir := t.TempDir()
+	authDir := filepath.Join(specsDir, "001-auth")
+	require.NoError(t, os.MkdirAll(authDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(authDir, "spec.yaml"), []byte("feature:\n  status: Draft\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(authDir, "tasks.yaml"), []byte(`phases:
+  - number: 1
+    title: "Setup"
+    tasks:
+      - id: "T001"
+        title: "Add login"
+        status: "Blocked"
+        blocked_reason: "Needs OAuth app"
+`), 0644))
+	cacheDir := filepath.Join(specsDir, "002-cache")
+	require.NoError(t, os.MkdirAll(cacheDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(cacheDir, "spec.yaml"), []byte("feature:\n  status: Draft\n"), 0644))
+	require.NoError(t, history.SaveHistory(stateDir, &history.HistoryFile{Entries: []history.HistoryEntry{
+		{Command: "plan", Spec: "001-auth", Status: history.StatusRunning},
+	}}))
+
+	require.NoError(t, retry.SaveRetryState(stateDir, &retry.RetryState{SpecName: "001-auth", Phase: "plan", Count: 1, MaxRetries: 3}))
+	require.NoError(t, retry.SaveStageState(stateDir, &retry.StageExecutionState{SpecName: "001-auth", TotalPhases: 2, CompletedPhases: []int{1}}))
+
+	source := &serveSource{specsDir: specsDir, stateDir: stateDir, maxRetries: 3}
+
+	specs, err := source.Specs()
+	require.NoError(t, err)
+	require.Len(t, specs, 2)
+	assert.Equal(t, "001-auth", specs[0].Name)
+	assert.Equal(t, "Draft", specs[0].Status)
+	require.NotNil(t, specs[0].Tasks)
+	assert.Equal(t, 1, specs[0].Tasks.BlockedTasks)
+	assert.Nil(t, specs[1].Tasks)
+
+	tasks, err := source.Tasks("001-auth")
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, web.Task{ID: "T001", Title: "Add login", Status: "Blocked", Phase: 1, PhaseTitle: "Setup", BlockedReason: "Needs OAuth app"}, tasks[0])
+
+	tasks, err = source.Tasks("002-cache")
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+
+	for _, name := range []string{"999-missing", "..", "../001-auth"} {
+		_, err = source.Tasks(name)
+		assert.ErrorIs(t, err, web.ErrSpecNotFound, name)
+	}
+
+	status, err := source.Status("001-auth")
+	require.NoError(t, err)
+	assert.Equal(t, []web.StageStatus{
+		{Stage: "specify", Done: true},
+		{Stage: "plan", Retries: 1, MaxRetries: 3},
+		{Stage: "tasks", Done: true, MaxRetries: 3},
+		{Stage: "implement", MaxRetries: 3, CompletedPhases: 1, TotalPhases: 2},
+	}, status.Stages)
+	assert.Equal(t, "autospec run --spec 001-auth --plan", status.Next)
+
+	_, err = source.Status("../001-auth")
+	assert.ErrorIs(t, err, web.ErrSpecNotFound)
+
+	entries, err := source.History()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, history.StatusRunning, entries[0].Status)
+}
@@ -0,0 +1,256 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/session"
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// replayMaxPause caps silent stretches during replay.
+const replayMaxPause = 2 * time.Second
+
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "Inspect recorded agent transcripts",
+	Long: `Inspect the transcripts of recent agent runs.
+
+Every non-interactive agent run (each stage attempt, phase or task) is saved
+under ~/.autospec/state/sessions/ with its prompt, stdout, stderr, exit code
+and duration. Use it to see what the agent actually did during a failed phase.
+The number of kept transcripts is set by max_sessions (0 disables capture).`,
+	Example: `  # Recent sessions, newest last
+  autospec sessions
+
+  # Only failed runs of one spec
+  autospec sessions list --spec 003-auth --failed
+
+  # Prompt and full output of a session (a unique ID prefix is enough)
+  autospec sessions show brave_fox_20260102_030405
+
+  # Play the output back at twice the recorded speed
+  autospec sessions replay brave_fox --speed 2`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSessionsListWithStateDir(cmd, getDefaultStateDir())
+	},
+}
+
+var sessionsListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List recorded agent sessions",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSessionsListWithStateDir(cmd, getDefaultStateDir())
+	},
+}
+
+var sessionsShowCmd = &cobra.Command{
+	Use:          "show <id>",
+	Short:        "Show the prompt, output and result of a session",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSessionsShowWithStateDir(cmd, args[0], getDefaultStateDir())
+	},
+}
+
+var sessionsReplayCmd = &cobra.Command{
+	Use:   "replay <id>",
+	Short: "Play back a session's output with its original timing",
+	Long: `Play back a session's stdout and stderr in the order they were written,
+pausing between writes as the agent did. Pauses longer than 2s are shortened.
+Use --speed 0 to print everything at once.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSessionsReplayWithStateDir(cmd, args[0], getDefaultStateDir())
+	},
+}
+
+func init() {
+	sessionsCmd.GroupID = shared.GroupConfiguration
+	for _, c := range []*cobra.Command{sessionsCmd, sessionsListCmd} {
+		c.Flags().StringP("spec", "s", "", "Filter by spec name")
+		_ = c.RegisterFlagCompletionFunc("spec", shared.CompleteSpecNames)
+		c.Flags().Bool("failed", false, "Show only failed sessions")
+		c.Flags().IntP("limit", "n", 20, "Limit to last N sessions (0 = all)")
+	}
+	sessionsShowCmd.Flags().Bool("raw", false, "Print stream-json output without formatting")
+	sessionsReplayCmd.Flags().Bool("raw", false, "Print stream-json output without formatting")
+	sessionsReplayCmd.Flags().Float64("speed", 1, "Playback speed multiplier (0 = no pauses)")
+
+	sessionsCmd.AddCommand(sessionsListCmd)
+	sessionsCmd.AddCommand(sessionsShowCmd)
+	sessionsCmd.AddCommand(sessionsReplayCmd)
+}
+
+// sessionSummary is the JSON shape of a session in list output.
+type sessionSummary struct {
+	ID        string    `json:"id"`
+	Spec      string    `json:"spec,omitempty"`
+	Stage     string    `json:"stage"`
+	Phase     int       `json:"phase,omitempty"`
+	TaskID    string    `json:"task_id,omitempty"`
+	Attempt   int       `json:"attempt"`
+	StartedAt time.Time `json:"started_at"`
+	Duration  string    `json:"duration"`
+	ExitCode  int       `json:"exit_code"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// runSessionsListWithStateDir lists sessions stored in the given state directory.
+func runSessionsListWithStateDir(cmd *cobra.Command, stateDir string) error {
+	specFilter, _ := cmd.Flags().GetString("spec")
+	failedOnly, _ := cmd.Flags().GetBool("failed")
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return fmt.Errorf("limit must be positive, got %d", limit)
+	}
+
+	all, err := session.List(stateDir)
+	if err != nil {
+		return fmt.Errorf("loading sessions: %w", err)
+	}
+
+	var sessions []*session.Session
+	for _, s := range all {
+		if specFilter != "" && s.Spec != specFilter {
+			continue
+		}
+		if failedOnly && !s.Failed() {
+			continue
+		}
+		sessions = append(sessions, s)
+	}
+	if limit > 0 && len(sessions) > limit {
+		sessions = sessions[len(sessions)-limit:]
+	}
+
+	if shared.IsJSONOutput(cmd) {
+		summaries := make([]sessionSummary, 0, len(sessions))
+		for _, s := range sessions {
+			summaries = append(summaries, sessionSummary{
+				ID: s.ID, Spec: s.Spec, Stage: s.Stage, Phase: s.Phase, TaskID: s.TaskID,
+				Attempt: s.Attempt, StartedAt: s.StartedAt, Duration: s.Duration,
+				ExitCode: s.ExitCode, Error: s.Error,
+			})
+		}
+		return shared.WriteJSON(cmd.OutOrStdout(), summaries)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(sessions) == 0 {
+		fmt.Fprintln(out, "No sessions recorded.")
+		return nil
+	}
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	for _, s := range sessions {
+		spec := s.Spec
+		if spec == "" {
+			spec = "-"
+		}
+		fmt.Fprintf(out, "%s  %-30s  %-20s  %-15s  #%d  exit=%s  %s\n",
+			cyan(s.StartedAt.Local().Format("2006-01-02 15:04:05")),
+			s.ID,
+			s.Label(),
+			spec,
+			s.Attempt,
+			formatSessionExit(s),
+			s.Duration,
+		)
+	}
+	return nil
+}
+
+// runSessionsShowWithStateDir prints a session's metadata, prompt and output.
+func runSessionsShowWithStateDir(cmd *cobra.Command, id, stateDir string) error {
+	s, err := session.Load(stateDir, id)
+	if err != nil {
+		return err
+	}
+	if shared.IsJSONOutput(cmd) {
+		return shared.WriteJSON(cmd.OutOrStdout(), s)
+	}
+
+	raw, _ := cmd.Flags().GetBool("raw")
+	out := cmd.OutOrStdout()
+	bold := color.New(color.Bold).SprintFunc()
+
+	fmt.Fprintf(out, "%s %s\n", bold("Session:"), s.ID)
+	fmt.Fprintf(out, "%s %s (attempt %d)\n", bold("Stage:"), s.Label(), s.Attempt)
+	if s.Spec != "" {
+		fmt.Fprintf(out, "%s %s\n", bold("Spec:"), s.Spec)
+	}
+	fmt.Fprintf(out, "%s %s\n", bold("Started:"), s.StartedAt.Local().Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(out, "%s %s\n", bold("Duration:"), s.Duration)
+	fmt.Fprintf(out, "%s %s\n", bold("Exit code:"), formatSessionExit(s))
+	if s.Error != "" {
+		fmt.Fprintf(out, "%s %s\n", bold("Error:"), s.Error)
+	}
+	if s.Command != "" {
+		fmt.Fprintf(out, "%s %s\n", bold("Command:"), s.Command)
+	}
+
+	fmt.Fprintf(out, "\n%s\n%s\n", bold("Prompt:"), s.Prompt)
+
+	fmt.Fprintf(out, "\n%s\n", bold("Output:"))
+	writeSessionStdout(out, s, s.Text(session.StreamStdout), raw)
+	if stderr := s.Text(session.StreamStderr); stderr != "" {
+		fmt.Fprintf(out, "\n%s\n%s", bold("Stderr:"), stderr)
+	}
+	return nil
+}
+
+// runSessionsReplayWithStateDir plays back a session's output with its recorded timing.
+func runSessionsReplayWithStateDir(cmd *cobra.Command, id, stateDir string) error {
+	s, err := session.Load(stateDir, id)
+	if err != nil {
+		return err
+	}
+	speed, _ := cmd.Flags().GetFloat64("speed")
+	raw, _ := cmd.Flags().GetBool("raw")
+
+	stdout := sessionStdout(cmd.OutOrStdout(), s, raw)
+	err = session.Replay(s, stdout, cmd.ErrOrStderr(), session.ReplayOptions{Speed: speed, MaxPause: replayMaxPause})
+	if fw, ok := stdout.(*workflow.FormatterWriter); ok {
+		fw.Flush()
+	}
+	return err
+}
+
+// writeSessionStdout writes recorded stdout, formatted like the live run unless raw is set.
+func writeSessionStdout(out io.Writer, s *session.Session, text string, raw bool) {
+	w := sessionStdout(out, s, raw)
+	_, _ = io.WriteString(w, text)
+	if fw, ok := w.(*workflow.FormatterWriter); ok {
+		fw.Flush()
+	}
+}
+
+// sessionStdout returns a writer that formats stream-json output for display,
+// or out unchanged for plain output or when raw is set.
+func sessionStdout(out io.Writer, s *session.Session, raw bool) io.Writer {
+	if raw || !s.StreamJSON {
+		return out
+	}
+	return workflow.NewFormatterWriter(config.OutputStyleDefault, out)
+}
+
+// formatSessionExit returns a color-coded exit code.
+func formatSessionExit(s *session.Session) string {
+	code := fmt.Sprintf("%d", s.ExitCode)
+	if s.Failed() {
+		return color.New(color.FgRed).Sprint(code)
+	}
+	return color.New(color.FgGreen).Sprint(code)
+}
@@ -0,0 +1,225 @@
+// Package util tests the sessions command implementation.
+// Related: internal/cli/util/sessions.go, internal/session/session.go
+// Tags: util, cli, sessions, transcripts
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/session"
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sessionsTestStateDir stores a passing plan session and a failed implement phase.
+func sessionsTestStateDir(t *testing.T) string {
+	t.Helper()
+	stateDir := t.TempDir()
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	sessions := []*session.Session{
+		{
+			ID: "brave_fox_20260102_030405", Spec: "001-auth", Stage: "plan", Attempt: 1,
+			Prompt: "/autospec.plan", StartedAt: start, Duration: "12s",
+			Output: []session.Chunk{{Stream: session.StreamStdout, Text: "plan written\n"}},
+		},
+		{
+			ID: "calm_owl_20260102_031000", Spec: "002-api", Stage: "implement", Phase: 2, Attempt: 2,
+			Command: "claude -p /autospec.implement --phase 2", Prompt: "/autospec.implement --phase 2",
+			StartedAt: start.Add(5 * time.Minute), Duration: "1m3s", ExitCode: 1,
+			Error: "agent claude exited with code 1",
+			Output: []session.Chunk{
+				{OffsetMS: 0, Stream: session.StreamStdout, Text: "editing api.go\n"},
+				{OffsetMS: 900, Stream: session.StreamStderr, Text: "rate limited\n"},
+			},
+		},
+	}
+	for _, s := range sessions {
+		require.NoError(t, session.Save(stateDir, s, 0))
+	}
+	return stateDir
+}
+
+func newSessionsTestCmd(t *testing.T, flags ...string) (*cobra.Command, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	cmd := &cobra.Command{}
+	shared.AddOutputFlag(cmd)
+	cmd.Flags().StringP("spec", "s", "", "")
+	cmd.Flags().Bool("failed", false, "")
+	cmd.Flags().IntP("limit", "n", 20, "")
+	cmd.Flags().Bool("raw", false, "")
+	cmd.Flags().Float64("speed", 0, "")
+	require.NoError(t, cmd.ParseFlags(flags))
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	return cmd, &out, &errOut
+}
+
+func TestRunSessionsListWithStateDir(t *testing.T) {
+	t.Parallel()
+
+	stateDir := sessionsTestStateDir(t)
+
+	tests := map[string]struct {
+		stateDir       string
+		flags          []string
+		wantContains   []string
+		wantNotContain []string
+		wantErr        string
+	}{
+		"all sessions": {
+			wantContains: []string{"brave_fox_20260102_030405", "plan", "calm_owl_20260102_031000", "implement phase 2", "#2"},
+		},
+		"failed only": {
+			flags:          []string{"--failed"},
+			wantContains:   []string{"calm_owl"},
+			wantNotContain: []string{"brave_fox"},
+		},
+		"spec filter": {
+			flags:          []string{"--spec", "001-auth"},
+			wantContains:   []string{"brave_fox"},
+			wantNotContain: []string{"calm_owl"},
+		},
+		"limit keeps newest": {
+			flags:          []string{"--limit", "1"},
+			wantContains:   []string{"calm_owl"},
+			wantNotContain: []string{"brave_fox"},
+		},
+		"json output": {
+			flags:          []string{"--output", "json", "--failed"},
+			wantContains:   []string{`"id": "calm_owl_20260102_031000"`, `"phase": 2`, `"exit_code": 1`},
+			wantNotContain: []string{"editing api.go"},
+		},
+		"no sessions": {
+			stateDir:     t.TempDir(),
+			wantContains: []string{"No sessions recorded."},
+		},
+		"negative limit": {
+			flags:   []string{"--limit", "-1"},
+			wantErr: "limit must be positive",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cmd, out, _ := newSessionsTestCmd(t, tt.flags...)
+			dir := stateDir
+			if tt.stateDir != "" {
+				dir = tt.stateDir
+			}
+
+			err := runSessionsListWithStateDir(cmd, dir)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			for _, want := range tt.wantContains {
+				assert.Contains(t, out.String(), want)
+			}
+			for _, unwanted := range tt.wantNotContain {
+				assert.NotContains(t, out.String(), unwanted)
+			}
+			if shared.IsJSONOutput(cmd) {
+				assert.True(t, json.Valid(out.Bytes()))
+			}
+		})
+	}
+}
+
+func TestRunSessionsShowWithStateDir(t *testing.T) {
+	t.Parallel()
+
+	stateDir := sessionsTestStateDir(t)
+
+	tests := map[string]struct {
+		id           string
+		flags        []string
+		wantContains []string
+		wantErr      string
+	}{
+		"failed phase": {
+			id: "calm_owl",
+			wantContains: []string{
+				"calm_owl_20260102_031000", "implement phase 2 (attempt 2)", "002-api", "1m3s",
+				"agent claude exited with code 1", "claude -p /autospec.implement --phase 2",
+				"Prompt:", "/autospec.implement --phase 2",
+				"Output:", "editing api.go", "Stderr:", "rate limited",
+			},
+		},
+		"json output": {
+			id:           "brave_fox_20260102_030405",
+			flags:        []string{"--output", "json"},
+			wantContains: []string{`"prompt": "/autospec.plan"`, `"text": "plan written\n"`},
+		},
+		"unknown id": {
+			id:      "quiet_elk",
+			wantErr: `session "quiet_elk" not found`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cmd, out, _ := newSessionsTestCmd(t, tt.flags...)
+
+			err := runSessionsShowWithStateDir(cmd, tt.id, stateDir)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			for _, want := range tt.wantContains {
+				assert.Contains(t, out.String(), want)
+			}
+		})
+	}
+}
+
+func TestRunSessionsReplayWithStateDir(t *testing.T) {
+	t.Parallel()
+
+	stateDir := sessionsTestStateDir(t)
+	cmd, out, errOut := newSessionsTestCmd(t, "--speed", "0")
+
+	require.NoError(t, runSessionsReplayWithStateDir(cmd, "calm_owl", stateDir))
+	assert.Equal(t, "editing api.go\n", out.String())
+	assert.Equal(t, "rate limited\n", errOut.String())
+
+	err := runSessionsReplayWithStateDir(cmd, "missing", stateDir)
+	require.Error(t, err)
+}
+
+func TestSessionStdout(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		streamJSON    bool
+		raw           bool
+		wantFormatter bool
+	}{
+		"plain output unchanged":   {},
+		"stream-json formatted":    {streamJSON: true, wantFormatter: true},
+		"raw flag skips formatter": {streamJSON: true, raw: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			w := sessionStdout(&out, &session.Session{StreamJSON: tt.streamJSON}, tt.raw)
+			_, isFormatter := w.(*workflow.FormatterWriter)
+			assert.Equal(t, tt.wantFormatter, isFormatter)
+		})
+	}
+}
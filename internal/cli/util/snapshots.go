@@ -0,0 +1,188 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/snapshot"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var snapshotsCmd = &cobra.Command{
+	Use:   "snapshots [spec-name]",
+	Short: "List or restore earlier versions of a spec's artifacts",
+	Long: `List or restore the artifact snapshots of a spec.
+
+Before each stage that can change them (clarify, plan, tasks, implement, ...),
+autospec copies the spec's spec.yaml, plan.yaml and tasks.yaml to
+~/.autospec/state/snapshots/<spec>/. A snapshot is only taken when the files
+changed since the previous one. Restore a snapshot to roll back a bad
+regeneration without going through git history.
+
+The number of snapshots kept per spec is set by max_snapshots (0 disables
+automatic snapshots).`,
+	Example: `  # Snapshots of the current spec, oldest first
+  autospec snapshots
+
+  # Roll back plan.yaml to its version before the last plan run
+  autospec snapshots restore brave_fox --artifact plan
+
+  # Restore every file of a snapshot of another spec
+  autospec snapshots restore brave_fox_20260102_030405 003-auth`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteSpecNames),
+	SilenceUsage:      true,
+	RunE:              runSnapshotsList,
+}
+
+var snapshotsListCmd = &cobra.Command{
+	Use:               "list [spec-name]",
+	Short:             "List a spec's artifact snapshots",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteSpecNames),
+	SilenceUsage:      true,
+	RunE:              runSnapshotsList,
+}
+
+var snapshotsRestoreCmd = &cobra.Command{
+	Use:   "restore <id> [spec-name]",
+	Short: "Restore a spec's artifacts from a snapshot",
+	Long: `Write the artifacts saved in a snapshot back into the spec directory.
+A unique ID prefix is enough. The current artifacts are snapshotted first,
+so a restore can itself be rolled back.`,
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 1 {
+			return shared.CompleteSpecNames(cmd, args, toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: runSnapshotsRestore,
+}
+
+func init() {
+	snapshotsCmd.GroupID = shared.GroupConfiguration
+	snapshotsRestoreCmd.Flags().StringSliceP("artifact", "a", nil, "Artifacts to restore: spec, plan, tasks (default: all in the snapshot)")
+
+	snapshotsCmd.AddCommand(snapshotsListCmd)
+	snapshotsCmd.AddCommand(snapshotsRestoreCmd)
+}
+
+// loadSnapshotsContext loads the config and resolves the spec directory from
+// the optional spec name argument.
+func loadSnapshotsContext(cmd *cobra.Command, specArg string) (*config.Configuration, string, error) {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return nil, "", cliErr
+	}
+
+	var metadata *spec.Metadata
+	if specArg != "" {
+		metadata, err = spec.GetSpecMetadata(cfg.SpecsDir, specArg)
+	} else {
+		metadata, err = spec.DetectCurrentSpec(cfg.SpecsDir)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to find spec: %w", err)
+	}
+	return cfg, metadata.Directory, nil
+}
+
+func runSnapshotsList(cmd *cobra.Command, args []string) error {
+	var specArg string
+	if len(args) > 0 {
+		specArg = args[0]
+	}
+	cfg, specDir, err := loadSnapshotsContext(cmd, specArg)
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := snapshot.List(cfg.StateDir, filepath.Base(specDir))
+	if err != nil {
+		return fmt.Errorf("loading snapshots: %w", err)
+	}
+	if shared.IsJSONOutput(cmd) {
+		return shared.WriteJSON(cmd.OutOrStdout(), snapshots)
+	}
+	printSnapshots(cmd.OutOrStdout(), filepath.Base(specDir), snapshots)
+	return nil
+}
+
+// printSnapshots prints one line per snapshot, oldest first.
+func printSnapshots(out io.Writer, specName string, snapshots []*snapshot.Snapshot) {
+	if len(snapshots) == 0 {
+		fmt.Fprintf(out, "No snapshots recorded for %s.\n", specName)
+		return
+	}
+	cyan := color.New(color.FgCyan).SprintFunc()
+	for _, s := range snapshots {
+		fmt.Fprintf(out, "%s  %-30s  before %-12s  %s\n",
+			cyan(s.CreatedAt.Local().Format("2006-01-02 15:04:05")),
+			s.ID,
+			s.Stage,
+			strings.Join(s.Files, ", "),
+		)
+	}
+}
+
+func runSnapshotsRestore(cmd *cobra.Command, args []string) error {
+	artifacts, _ := cmd.Flags().GetStringSlice("artifact")
+	files, err := snapshotFiles(artifacts)
+	if err != nil {
+		return err
+	}
+	var specArg string
+	if len(args) > 1 {
+		specArg = args[1]
+	}
+	cfg, specDir, err := loadSnapshotsContext(cmd, specArg)
+	if err != nil {
+		return err
+	}
+	return restoreSnapshot(cmd.OutOrStdout(), cfg.StateDir, specDir, args[0], files, cfg.MaxSnapshots)
+}
+
+// snapshotFiles maps --artifact names to snapshot file names.
+func snapshotFiles(artifacts []string) ([]string, error) {
+	files := make([]string, 0, len(artifacts))
+	for _, a := range artifacts {
+		if !slices.Contains(diffArtifacts, a) {
+			return nil, fmt.Errorf("invalid --artifact %q: must be one of spec, plan, tasks", a)
+		}
+		files = append(files, a+".yaml")
+	}
+	return files, nil
+}
+
+// restoreSnapshot restores files (nil = all) of snapshot id into specDir and
+// prints what was restored.
+func restoreSnapshot(out io.Writer, stateDir, specDir, id string, files []string, maxSnapshots int) error {
+	s, err := snapshot.Load(stateDir, filepath.Base(specDir), id)
+	if err != nil {
+		return err
+	}
+	restored, err := snapshot.Restore(stateDir, specDir, s, files, maxSnapshots)
+	if err != nil {
+		return err
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Fprintf(out, "%s Restored %s of %s from snapshot %s (taken before %s, %s)\n",
+		green("✓"), strings.Join(restored, ", "), s.Spec, s.ID, s.Stage,
+		s.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	fmt.Fprintln(out, "The replaced versions are kept as a snapshot; run 'autospec snapshots' to list them.")
+	return nil
+}
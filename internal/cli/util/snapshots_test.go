@@ -0,0 +1,121 @@
+// Package util tests the snapshots command implementation.
+// Related: internal/cli/util/snapshots.go, internal/snapshot/snapshot.go
+// Tags: util, cli, snapshots, artifacts, restore
+
+package util
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintSnapshots(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		snapshots    []*snapshot.Snapshot
+		wantContains []string
+	}{
+		"no snapshots": {
+			wantContains: []string{"No snapshots recorded for 003-auth."},
+		},
+		"lists snapshots": {
+			snapshots: []*snapshot.Snapshot{
+				{ID: "brave_fox_20260102_030405", Spec: "003-auth", Stage: "plan", Files: []string{"spec.yaml", "plan.yaml"}},
+			},
+			wantContains: []string{"brave_fox_20260102_030405", "before plan", "spec.yaml, plan.yaml"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			printSnapshots(&out, "003-auth", tt.snapshots)
+			for _, want := range tt.wantContains {
+				assert.Contains(t, out.String(), want)
+			}
+		})
+	}
+}
+
+func TestSnapshotFiles(t *testing.T) {
+	t.Parallel()
+
+	files, err := snapshotFiles([]string{"plan", "tasks"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"plan.yaml", "tasks.yaml"}, files)
+
+	_, err = snapshotFiles([]string{"checklist"})
+	assert.ErrorContains(t, err, `invalid --artifact "checklist"`)
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		id       string
+		files    []string
+		wantPlan string
+		wantSpec string
+		wantOut  string
+		wantErr  string
+	}{
+		"restores all files by ID prefix": {
+			wantPlan: "plan v1",
+			wantSpec: "spec v1",
+			wantOut:  "Restored spec.yaml, plan.yaml of 003-auth",
+		},
+		"restores one artifact": {
+			files:    []string{"plan.yaml"},
+			wantPlan: "plan v1",
+			wantSpec: "spec v2",
+			wantOut:  "Restored plan.yaml of 003-auth",
+		},
+		"unknown snapshot": {
+			id:      "missing",
+			wantErr: `snapshot "missing" not found`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			stateDir := t.TempDir()
+			specDir := filepath.Join(t.TempDir(), "003-auth")
+			require.NoError(t, os.MkdirAll(specDir, 0o755))
+			write := func(name, content string) {
+				require.NoError(t, os.WriteFile(filepath.Join(specDir, name), []byte(content), 0o644))
+			}
+			write("spec.yaml", "spec v1")
+			write("plan.yaml", "plan v1")
+			s, err := snapshot.Take(stateDir, specDir, "plan", 10)
+			require.NoError(t, err)
+			write("spec.yaml", "spec v2")
+			write("plan.yaml", "plan v2")
+
+			id := tt.id
+			if id == "" {
+				id = s.ID[:len(s.ID)-2]
+			}
+			var out bytes.Buffer
+			err = restoreSnapshot(&out, stateDir, specDir, id, tt.files, 10)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), tt.wantOut)
+			plan, _ := os.ReadFile(filepath.Join(specDir, "plan.yaml"))
+			spec, _ := os.ReadFile(filepath.Join(specDir, "spec.yaml"))
+			assert.Equal(t, tt.wantPlan, string(plan))
+			assert.Equal(t, tt.wantSpec, string(spec))
+		})
+	}
+}
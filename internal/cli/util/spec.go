@@ -0,0 +1,85 @@
+package util
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/vcs"
+	"github.com/spf13/cobra"
+)
+
+var specCmd = &cobra.Command{
+	Use:   "spec",
+	Short: "Manage spec directories",
+	Long: `Commands for managing spec directories in the specs directory.
+
+Available subcommands:
+  rename    Rename or renumber a spec and update references to it`,
+	Example: `  # Rename spec 003, keeping its number
+  autospec spec rename 003 user-login`,
+}
+
+var specRenameCmd = &cobra.Command{
+	Use:   "rename <spec> <new-name>",
+	Short: "Rename or renumber a spec and update references to it",
+	Long: `Rename a spec directory and rewrite references to its old name (such as
+feature.branch and spec paths) in the spec's YAML and markdown artifacts.
+
+The spec can be given as its full directory name, its number, or its name.
+A plain new name keeps the spec's number ("user-login" turns 003-user-auth
+into 003-user-login). A new name with a number prefix renumbers the spec
+("007-user-login"); the number must not be used by another spec, including
+archived ones. Names are sanitized the same way as branch names.
+
+With --branch, the local git branch (or jj bookmark) named after the spec is
+renamed too.`,
+	Example: `  # Rename a spec, keeping its number
+  autospec spec rename 003 user-login
+
+  # Renumber a spec
+  autospec spec rename 003-user-auth 007-user-auth
+
+  # Also rename the matching git branch
+  autospec spec rename 003 user-login --branch`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteSpecNames),
+	SilenceUsage:      true,
+	RunE:              runSpecRename,
+}
+
+func init() {
+	specCmd.GroupID = shared.GroupConfiguration
+	specRenameCmd.Flags().BoolP("branch", "b", false, "Also rename the local git branch (jj bookmark) named after the spec")
+	specCmd.AddCommand(specRenameCmd)
+}
+
+func runSpecRename(cmd *cobra.Command, args []string) error {
+	specsDir, err := loadSpecsDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	result, err := spec.RenameSpec(specsDir, args[0], args[1])
+	if err != nil {
+		return fmt.Errorf("renaming spec: %w", err)
+	}
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "✓ Renamed %s to %s\n", result.OldName, result.NewName)
+	for _, path := range result.UpdatedFiles {
+		rel, err := filepath.Rel(result.Directory, path)
+		if err != nil {
+			rel = path
+		}
+		fmt.Fprintf(out, "  updated %s\n", rel)
+	}
+
+	if renameBranch, _ := cmd.Flags().GetBool("branch"); renameBranch {
+		if err := vcs.Current().RenameBranch(result.OldName, result.NewName); err != nil {
+			return fmt.Errorf("spec renamed but branch was not: %w", err)
+		}
+		fmt.Fprintf(out, "✓ Renamed branch %s to %s\n", result.OldName, result.NewName)
+	}
+	return nil
+}
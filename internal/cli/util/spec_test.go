@@ -0,0 +1,53 @@
+// Package util tests the spec rename command.
+// Related: internal/cli/util/spec.go
+// Tags: util, cli, spec, rename
+
+package util
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecCmd_Structure(t *testing.T) {
+	t.Parallel()
+
+	var rename *cobra.Command
+	for _, sub := range specCmd.Commands() {
+		if sub.Name() == "rename" {
+			rename = sub
+		}
+	}
+	require.NotNil(t, rename, "spec should have a rename subcommand")
+	assert.NotNil(t, rename.Flags().Lookup("branch"))
+	assert.Error(t, rename.Args(rename, []string{"003"}), "rename needs a spec and a new name")
+}
+
+func TestRunSpecRename(t *testing.T) {
+	t.Parallel()
+
+	specsDir := filepath.Join(t.TempDir(), "specs")
+	dir := filepath.Join(specsDir, "003-user-auth")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "spec.yaml"),
+		[]byte("feature:\n  branch: \"003-user-auth\"\n"), 0o644))
+
+	cmd := &cobra.Command{Use: "test", RunE: runSpecRename, SilenceUsage: true, SilenceErrors: true}
+	cmd.Flags().String("config", "", "")
+	cmd.Flags().String("specs-dir", specsDir, "")
+	cmd.Flags().Bool("branch", false, "")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"003", "user-login"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "✓ Renamed 003-user-auth to 003-user-login")
+	assert.Contains(t, out.String(), "updated spec.yaml")
+	assert.FileExists(t, filepath.Join(specsDir, "003-user-login", "spec.yaml"))
+}
@@ -0,0 +1,180 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/git"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/stategc"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var stateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Manage the state autospec keeps in state_dir",
+	Long: `Manage the per-spec state autospec keeps in <state_dir>
+(~/.autospec/state): retry counts, phase and task progress, workflow
+checkpoints, agent sessions and artifact snapshots.`,
+}
+
+var stateGCCmd = &cobra.Command{
+	Use:   "gc [spec-name...]",
+	Short: "Remove the state of archived and deleted specs",
+	Long: `Remove the retry entries, progress, checkpoints, sessions and snapshots
+kept for specs that are finished, and report the space reclaimed.
+
+A spec is finished when it was moved to the specs archive ('autospec
+archive'), or when its directory was deleted in git and no longer exists.
+Name other deleted specs as arguments, by their full directory name.
+Specs in the specs directory keep their state.
+
+state_dir is shared by every project by default, so state of specs this
+project doesn't know about is left alone. Command history is kept; see
+'autospec history prune'.`,
+	Example: `  # Show what would be removed
+  autospec state gc --dry-run
+
+  # Remove the state of archived and deleted specs
+  autospec state gc
+
+  # Also remove the state of a spec deleted without git
+  autospec state gc 007-old-experiment`,
+	SilenceUsage: true,
+	RunE:         runStateGC,
+}
+
+func init() {
+	stateCmd.GroupID = shared.GroupConfiguration
+	stateGCCmd.Flags().BoolP("dry-run", "n", false, "Show what would be removed without removing it")
+	stateCmd.AddCommand(stateGCCmd)
+}
+
+func runStateGC(cmd *cobra.Command, args []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
+	finished, err := finishedSpecs(resolveSpecsDir(cmd, cfg.SpecsDir), args)
+	if err != nil {
+		return err
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	result, err := stategc.Collect(cfg.StateDir, finished, dryRun)
+	if err != nil {
+		return fmt.Errorf("collecting state: %w", err)
+	}
+
+	if shared.IsJSONOutput(cmd) {
+		return shared.WriteJSON(cmd.OutOrStdout(), result)
+	}
+	printStateGCResult(cmd.OutOrStdout(), result)
+	return nil
+}
+
+// finishedSpecs returns the specs whose state gc removes, mapped to the
+// reason: archived specs, spec directories deleted in git that don't exist
+// anymore, and the named specs, which must not be in the specs directory.
+func finishedSpecs(specsDir string, named []string) (map[string]string, error) {
+	active, err := spec.ListSpecs(specsDir)
+	if err != nil {
+		return nil, err
+	}
+	isActive := make(map[string]bool, len(active))
+	for _, name := range active {
+		isActive[name] = true
+	}
+
+	finished := make(map[string]string)
+	archived, err := spec.ListArchivedSpecs(specsDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range archived {
+		if !isActive[name] {
+			finished[name] = stategc.ReasonArchived
+		}
+	}
+
+	// A missing specs directory has nothing deleted from it either
+	if len(active) > 0 || len(archived) > 0 {
+		if deleted, err := git.DeletedPaths(specsDir); err == nil {
+			for _, path := range deleted {
+				name, _, _ := strings.Cut(filepath.ToSlash(path), "/")
+				if _, ok := finished[name]; !ok && !isActive[name] && spec.IsSpecDirName(name) {
+					finished[name] = stategc.ReasonDeleted
+				}
+			}
+		}
+	}
+
+	for _, name := range named {
+		if isActive[name] {
+			return nil, fmt.Errorf("spec %s is still in %s; archive it first with 'autospec archive %s'", name, specsDir, name)
+		}
+		if _, ok := finished[name]; !ok {
+			finished[name] = stategc.ReasonDeleted
+		}
+	}
+	return finished, nil
+}
+
+func printStateGCResult(out io.Writer, result *stategc.Result) {
+	if len(result.Specs) == 0 {
+		fmt.Fprintln(out, "No state of archived or deleted specs to remove.")
+		return
+	}
+
+	verb := "Removed"
+	if result.DryRun {
+		verb = "Would remove"
+	}
+	fmt.Fprintf(out, "%s state of %d specs:\n", verb, len(result.Specs))
+	for _, s := range result.Specs {
+		fmt.Fprintf(out, "  %s (%s): %s, %s\n", s.Spec, s.Reason, describeSpecState(s), formatBytes(s.Bytes))
+	}
+	if result.DryRun {
+		fmt.Fprintf(out, "Would reclaim %s.\n", formatBytes(result.Bytes))
+		return
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Fprintf(out, "%s Reclaimed %s.\n", green("✓"), formatBytes(result.Bytes))
+}
+
+// describeSpecState lists the kinds of state kept for a spec.
+func describeSpecState(s stategc.SpecState) string {
+	var parts []string
+	if s.Retries > 0 {
+		parts = append(parts, plural(s.Retries, "retry entry", "retry entries"))
+	}
+	if s.Checkpoint {
+		parts = append(parts, "checkpoint")
+	}
+	if s.Sessions > 0 {
+		parts = append(parts, plural(s.Sessions, "session", "sessions"))
+	}
+	if s.Snapshots > 0 {
+		parts = append(parts, plural(s.Snapshots, "snapshot", "snapshots"))
+	}
+	if len(parts) == 0 {
+		return "empty snapshot directory"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return "1 " + one
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
@@ -0,0 +1,87 @@
+// Package util tests the state gc command.
+// Related: internal/cli/util/state.go, internal/stategc/stategc.go
+// Tags: util, cli, state, gc
+
+package util
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStateGCTestCmd() (*cobra.Command, *bytes.Buffer) {
+	cmd := &cobra.Command{Use: "gc", RunE: runStateGC, SilenceUsage: true, SilenceErrors: true}
+	cmd.Flags().String("config", "", "")
+	cmd.Flags().String("specs-dir", "./specs", "")
+	cmd.Flags().Bool("dry-run", false, "")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	return cmd, &out
+}
+
+func TestRunStateGC(t *testing.T) {
+	// Not parallel: changes directory and env
+
+	dir := t.TempDir()
+	stateDir := filepath.Join(dir, "state")
+	t.Chdir(dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AUTOSPEC_STATE_DIR", stateDir)
+
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	for _, name := range []string{"001-done", "002-active", "003-dropped"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "specs", name), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "specs", name, "spec.yaml"), []byte("feature: {}\n"), 0o644))
+	}
+	git("add", "specs")
+	git("commit", "-qm", "add specs")
+	git("rm", "-rq", "specs/003-dropped")
+	git("commit", "-qm", "drop spec")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "specs", "archive"), 0o755))
+	require.NoError(t, os.Rename(filepath.Join(dir, "specs", "001-done"), filepath.Join(dir, "specs", "archive", "001-done")))
+
+	for _, name := range []string{"001-done", "002-active", "003-dropped", "004-manual", "005-other-project"} {
+		_, err := retry.IncrementRetryCount(stateDir, name, "implement", 3)
+		require.NoError(t, err)
+	}
+
+	cmd, out := newStateGCTestCmd()
+	cmd.SetArgs([]string{"--dry-run"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Would remove state of 2 specs")
+	assert.Contains(t, out.String(), "001-done (archived): 1 retry entry")
+	assert.Contains(t, out.String(), "003-dropped (deleted)")
+	assert.Contains(t, out.String(), "Would reclaim")
+
+	cmd, _ = newStateGCTestCmd()
+	cmd.SetArgs([]string{"002-active"})
+	assert.ErrorContains(t, cmd.Execute(), "spec 002-active is still in")
+
+	cmd, out = newStateGCTestCmd()
+	cmd.SetArgs([]string{"004-manual"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Removed state of 3 specs")
+	assert.Contains(t, out.String(), "Reclaimed")
+
+	names, err := retry.SpecNames(stateDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"002-active", "005-other-project"}, names)
+
+	cmd, out = newStateGCTestCmd()
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "No state of archived or deleted specs to remove")
+}
@@ -5,21 +5,32 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/ariel-frischer/autospec/internal/config"
 	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/retry"
 	"github.com/ariel-frischer/autospec/internal/spec"
 	"github.com/ariel-frischer/autospec/internal/validation"
 	"github.com/spf13/cobra"
 )
 
 var statusCmd = &cobra.Command{
-	Use:          "status [spec-name]",
-	Aliases:      []string{"st"},
-	Short:        "Show implementation progress for current feature (st)",
-	Args:         cobra.MaximumNArgs(1),
-	SilenceUsage: true,
+	Use:               "status [spec-name]",
+	Aliases:           []string{"st"},
+	Short:             "Show implementation progress for current feature (st)",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteSpecNames),
+	SilenceUsage:      true,
+	Example: `  # Show progress for the current spec
+  autospec status
+
+  # Detailed view of one spec: retries, phases, history and next step
+  autospec status 003-feature
+
+  # Machine-readable progress for scripts and CI
+  autospec status --output json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		configPath, _ := cmd.Flags().GetString("config")
 		verbose, _ := cmd.Flags().GetBool("verbose")
@@ -45,18 +56,21 @@ var statusCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to detect spec: %w", err)
 		}
+
+		if shared.IsJSONOutput(cmd) {
+			return shared.WriteJSON(cmd.OutOrStdout(), buildStatusReport(metadata, cfg.StateDir))
+		}
 		shared.PrintSpecInfo(metadata)
 
-		// Check which artifact files exist
-		artifacts := []string{"spec.yaml", "plan.yaml", "tasks.yaml"}
-		var existing []string
-		for _, artifact := range artifacts {
-			path := filepath.Join(metadata.Directory, artifact)
-			if _, err := os.Stat(path); err == nil {
-				existing = append(existing, artifact)
-			}
+		// An explicitly named spec gets the detailed panel
+		if len(args) > 0 {
+			printSpecDetail(cmd.OutOrStdout(), buildSpecDetail(metadata, cfg.StateDir, cfg.MaxRetries))
+			return nil
 		}
 
+		// Check which artifact files exist
+		existing := existingArtifacts(metadata.Directory)
+
 		// Show artifacts
 		if len(existing) > 0 {
 			fmt.Printf("  artifacts: %v\n", existing)
@@ -85,6 +99,13 @@ var statusCmd = &cobra.Command{
 			displayBlockedTasks(tasksPath)
 		}
 
+		// Show where an interrupted --tasks run will resume
+		if checkpoint := loadTaskCheckpoint(cfg.StateDir, metadata); checkpoint != nil {
+			fmt.Printf("\n  resume point: task %s (%d done, last active %s)\n",
+				checkpoint.CurrentTask, checkpoint.CompletedTasks, checkpoint.LastAttempt.Local().Format("2006-01-02 15:04"))
+			fmt.Println("  continue with: autospec implement --tasks --resume")
+		}
+
 		// Show phase details in verbose mode
 		if verbose && stats != nil {
 			fmt.Println()
@@ -109,6 +130,102 @@ func init() {
 	statusCmd.Flags().BoolP("verbose", "v", false, "Show all tasks, not just unchecked")
 }
 
+// statusArtifacts lists the core artifacts reported by the status command.
+var statusArtifacts = []string{"spec.yaml", "plan.yaml", "tasks.yaml"}
+
+// statusRetryStages lists the stages whose retry counts appear in JSON status.
+// Specify is omitted because its retries are tracked before the spec exists.
+var statusRetryStages = []string{"plan", "tasks", "implement"}
+
+// statusReport is the --output json form of the status command.
+type statusReport struct {
+	Spec         string                `json:"spec"`
+	Directory    string                `json:"directory"`
+	Branch       string                `json:"branch,omitempty"`
+	Detection    spec.DetectionMethod  `json:"detection"`
+	Artifacts    []string              `json:"artifacts"`
+	Tasks        *validation.TaskStats `json:"tasks,omitempty"`
+	Risks        *validation.RiskStats `json:"risks,omitempty"`
+	BlockedTasks []blockedTaskReport   `json:"blocked_tasks,omitempty"`
+	Retries      map[string]int        `json:"retries"`
+	Checkpoint   *taskCheckpointReport `json:"task_checkpoint,omitempty"`
+}
+
+// taskCheckpointReport describes the task an interrupted --tasks run was on.
+type taskCheckpointReport struct {
+	CurrentTask    string    `json:"current_task"`
+	CompletedTasks int       `json:"completed_tasks"`
+	TotalTasks     int       `json:"total_tasks"`
+	LastAttempt    time.Time `json:"last_attempt"`
+}
+
+// loadTaskCheckpoint returns the spec's --tasks checkpoint, or nil if no
+// task-level run is in progress.
+func loadTaskCheckpoint(stateDir string, metadata *spec.Metadata) *taskCheckpointReport {
+	state, err := retry.LoadTaskState(stateDir, fmt.Sprintf("%s-%s", metadata.Number, metadata.Name))
+	if err != nil || state == nil || state.CurrentTaskID == "" {
+		return nil
+	}
+	return &taskCheckpointReport{
+		CurrentTask:    state.CurrentTaskID,
+		CompletedTasks: len(state.CompletedTaskIDs),
+		TotalTasks:     state.TotalTasks,
+		LastAttempt:    state.LastTaskAttempt,
+	}
+}
+
+// blockedTaskReport describes a blocked task in JSON status output.
+type blockedTaskReport struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// existingArtifacts returns the core artifacts present in a spec directory.
+func existingArtifacts(specDir string) []string {
+	existing := []string{}
+	for _, artifact := range statusArtifacts {
+		if _, err := os.Stat(filepath.Join(specDir, artifact)); err == nil {
+			existing = append(existing, artifact)
+		}
+	}
+	return existing
+}
+
+// buildStatusReport gathers artifacts, task and risk stats, blocked tasks,
+// and per-stage retry counts for a spec.
+func buildStatusReport(metadata *spec.Metadata, stateDir string) *statusReport {
+	specName := fmt.Sprintf("%s-%s", metadata.Number, metadata.Name)
+	report := &statusReport{
+		Spec:      specName,
+		Directory: metadata.Directory,
+		Branch:    metadata.Branch,
+		Detection: metadata.Detection,
+		Artifacts: existingArtifacts(metadata.Directory),
+		Retries:   make(map[string]int),
+	}
+
+	tasksPath := validation.GetTasksFilePath(metadata.Directory)
+	if stats, err := validation.GetTaskStats(tasksPath); err == nil {
+		report.Tasks = stats
+	}
+	report.Risks, _ = validation.GetRiskStats(validation.GetPlanFilePath(metadata.Directory))
+
+	if tasks, err := validation.GetAllTasks(tasksPath); err == nil {
+		for _, task := range filterBlockedTasks(tasks) {
+			report.BlockedTasks = append(report.BlockedTasks, blockedTaskReport{ID: task.ID, Title: task.Title, Reason: task.BlockedReason})
+		}
+	}
+
+	for _, stage := range statusRetryStages {
+		if state, err := retry.LoadRetryState(stateDir, specName, stage, 0); err == nil && state.Count > 0 {
+			report.Retries[stage] = state.Count
+		}
+	}
+	report.Checkpoint = loadTaskCheckpoint(stateDir, metadata)
+	return report
+}
+
 // displayBlockedTasks shows blocked tasks with their reasons
 func displayBlockedTasks(tasksPath string) {
 	tasks, err := validation.GetAllTasks(tasksPath)
@@ -0,0 +1,177 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/spec"
+)
+
+// statusHistoryLimit is the number of history entries shown in the detail view.
+const statusHistoryLimit = 5
+
+// specDetail is the deep view `autospec status <spec>` prints for one spec.
+type specDetail struct {
+	*statusReport
+	RetryStates []*retry.RetryState
+	PhaseState  *retry.StageExecutionState // nil unless implement --phases ran
+	History     []history.HistoryEntry     // newest first
+	Next        string                     // recommended command ("" when done)
+}
+
+// buildSpecDetail extends the status report with retry states, implement
+// phase progress, recent history and the next recommended command.
+func buildSpecDetail(metadata *spec.Metadata, stateDir string, maxRetries int) *specDetail {
+	detail := buildSpecProgress(metadata, stateDir, maxRetries)
+	detail.History = specHistory(stateDir, detail.Spec, statusHistoryLimit)
+	return detail
+}
+
+// buildSpecProgress is buildSpecDetail without the history lookup, for
+// callers that show history across all specs.
+func buildSpecProgress(metadata *spec.Metadata, stateDir string, maxRetries int) *specDetail {
+	detail := &specDetail{statusReport: buildStatusReport(metadata, stateDir)}
+
+	for _, stage := range statusRetryStages {
+		if state, err := retry.LoadRetryState(stateDir, detail.Spec, stage, maxRetries); err == nil {
+			detail.RetryStates = append(detail.RetryStates, state)
+		}
+	}
+	if state, err := retry.LoadStageState(stateDir, detail.Spec); err == nil && state != nil && state.TotalPhases > 0 {
+		detail.PhaseState = state
+	}
+	detail.Next = nextCommand(detail)
+	return detail
+}
+
+// specHistory returns up to limit history entries for specName, newest first.
+// Entries may record the spec by name or by path.
+func specHistory(stateDir, specName string, limit int) []history.HistoryEntry {
+	file, err := history.LoadHistory(stateDir)
+	if err != nil {
+		return nil
+	}
+	var entries []history.HistoryEntry
+	for i := len(file.Entries) - 1; i >= 0 && len(entries) < limit; i-- {
+		entry := file.Entries[i]
+		if entry.Spec != "" && filepath.Base(entry.Spec) == specName {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// nextStep returns the autospec arguments that move the spec forward, or nil
+// when the next step needs the user (no spec yet, only blocked tasks left) or
+// nothing is left to do.
+func nextStep(detail *specDetail) []string {
+	switch {
+	case !slices.Contains(detail.Artifacts, "spec.yaml"):
+		return nil
+	case !slices.Contains(detail.Artifacts, "plan.yaml"):
+		return []string{"run", "--spec", detail.Spec, "--plan"}
+	case !slices.Contains(detail.Artifacts, "tasks.yaml"):
+		return []string{"run", "--spec", detail.Spec, "--tasks"}
+	case detail.Checkpoint != nil:
+		return []string{"implement", detail.Spec, "--tasks", "--resume"}
+	case detail.Tasks == nil:
+		return []string{"implement", detail.Spec}
+	case detail.Tasks.PendingTasks+detail.Tasks.InProgressTasks > 0:
+		if detail.PhaseState != nil {
+			return []string{"implement", detail.Spec, "--resume"}
+		}
+		return []string{"implement", detail.Spec}
+	default:
+		return nil
+	}
+}
+
+// nextCommand recommends the command that moves the spec forward.
+func nextCommand(detail *specDetail) string {
+	if args := nextStep(detail); args != nil {
+		return "autospec " + strings.Join(args, " ")
+	}
+	switch {
+	case !slices.Contains(detail.Artifacts, "spec.yaml"):
+		return `autospec specify "<feature description>"`
+	case detail.Tasks != nil && detail.Tasks.BlockedTasks > 0:
+		return fmt.Sprintf("autospec task unblock <id> (%d blocked)", detail.Tasks.BlockedTasks)
+	default:
+		return ""
+	}
+}
+
+// printSpecDetail writes the detail view below the spec info header.
+func printSpecDetail(w io.Writer, detail *specDetail) {
+	fmt.Fprintln(w, "\n  Artifacts:")
+	for _, artifact := range statusArtifacts {
+		mark := "✗"
+		if slices.Contains(detail.Artifacts, artifact) {
+			mark = "✓"
+		}
+		fmt.Fprintf(w, "    %s %s\n", mark, artifact)
+	}
+
+	fmt.Fprintln(w, "\n  Retries:")
+	for _, state := range detail.RetryStates {
+		line := fmt.Sprintf("    %-10s %d/%d", state.Phase, state.Count, state.MaxRetries)
+		if !state.LastAttempt.IsZero() {
+			line += fmt.Sprintf("  (last attempt %s)", state.LastAttempt.Local().Format("2006-01-02 15:04"))
+		}
+		fmt.Fprintln(w, line)
+	}
+	if detail.PhaseState != nil {
+		fmt.Fprintf(w, "    implement phases: %d/%d completed\n",
+			len(detail.PhaseState.CompletedPhases), detail.PhaseState.TotalPhases)
+	}
+	if detail.Checkpoint != nil {
+		fmt.Fprintf(w, "    implement tasks: resume at %s (%d done)\n",
+			detail.Checkpoint.CurrentTask, detail.Checkpoint.CompletedTasks)
+	}
+
+	if detail.Tasks != nil {
+		fmt.Fprintf(w, "\n  Tasks: %d/%d completed (%.0f%%)\n",
+			detail.Tasks.CompletedTasks, detail.Tasks.TotalTasks, detail.Tasks.CompletionPercentage())
+		for _, phase := range detail.Tasks.PhaseStats {
+			status := "[ ]"
+			if phase.IsComplete {
+				status = "[✓]"
+			} else if phase.CompletedTasks > 0 {
+				status = "[~]"
+			}
+			fmt.Fprintf(w, "    %s Phase %d: %s (%d/%d)\n",
+				status, phase.Number, phase.Title, phase.CompletedTasks, phase.TotalTasks)
+		}
+	}
+
+	if len(detail.BlockedTasks) > 0 {
+		fmt.Fprintln(w, "\n  Blocked tasks:")
+		for _, task := range detail.BlockedTasks {
+			fmt.Fprintf(w, "    %s: %s\n", task.ID, truncateStatusReason(task.Title, 50))
+			fmt.Fprintf(w, "       Reason: %s\n", formatBlockedReason(task.Reason))
+		}
+	}
+
+	if len(detail.History) > 0 {
+		fmt.Fprintln(w, "\n  Recent history:")
+		for _, entry := range detail.History {
+			status := entry.Status
+			if status == "" {
+				status = "-"
+			}
+			fmt.Fprintf(w, "    %s  %-10s %-10s %s\n",
+				entry.Timestamp.Local().Format("2006-01-02 15:04"), entry.Command, status, entry.Duration)
+		}
+	}
+
+	if detail.Next == "" {
+		fmt.Fprintln(w, "\n  Next: nothing left to do, all tasks completed")
+		return
+	}
+	fmt.Fprintf(w, "\n  Next: %s\n", detail.Next)
+}
@@ -0,0 +1,126 @@
+// Package util tests the status command's detailed spec view.
+// Related: internal/cli/util/status_detail.go
+// Tags: util, cli, status, commands
+
+package util
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSpecDetail(t *testing.T) {
+	t.Parallel()
+
+	specDir := t.TempDir()
+	stateDir := t.TempDir()
+	tasksContent := `phases:
+  - number: 1
+    title: "Setup"
+    tasks:
+      - id: "T1"
+        title: "Task 1"
+        status: "Completed"
+  - number: 2
+    title: "Core"
+    tasks:
+      - id: "T2"
+        title: "Task 2"
+        status: "Blocked"
+        blocked_reason: "Waiting for API access"
+      - id: "T3"
+        title: "Task 3"
+        status: "Pending"
+`
+	for _, name := range []string{"spec.yaml", "plan.yaml"} {
+		require.NoError(t, os.WriteFile(filepath.Join(specDir, name), []byte("{}"), 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "tasks.yaml"), []byte(tasksContent), 0644))
+	_, err := retry.IncrementRetryCount(stateDir, "001-demo", "implement", 3)
+	require.NoError(t, err)
+	now := time.Now()
+	require.NoError(t, history.SaveHistory(stateDir, &history.HistoryFile{Entries: []history.HistoryEntry{
+		{Timestamp: now.Add(-3 * time.Hour), Command: "plan", Spec: "001-demo", Status: history.StatusCompleted},
+		{Timestamp: now.Add(-2 * time.Hour), Command: "specify", Spec: "002-other", Status: history.StatusCompleted},
+		{Timestamp: now.Add(-time.Hour), Command: "implement", Spec: "specs/001-demo", Status: history.StatusFailed},
+	}}))
+
+	detail := buildSpecDetail(&spec.Metadata{Number: "001", Name: "demo", Directory: specDir}, stateDir, 3)
+
+	require.Len(t, detail.RetryStates, len(statusRetryStages))
+	assert.Equal(t, 1, detail.RetryStates[2].Count)
+	assert.Nil(t, detail.PhaseState)
+	require.Len(t, detail.History, 2)
+	assert.Equal(t, "implement", detail.History[0].Command)
+	assert.Equal(t, "plan", detail.History[1].Command)
+	assert.Equal(t, "autospec implement 001-demo", detail.Next)
+
+	var buf bytes.Buffer
+	printSpecDetail(&buf, detail)
+	out := buf.String()
+	assert.Contains(t, out, "✓ plan.yaml")
+	assert.Contains(t, out, "implement  1/3")
+	assert.Contains(t, out, "[✓] Phase 1: Setup (1/1)")
+	assert.Contains(t, out, "[ ] Phase 2: Core (0/2)")
+	assert.Contains(t, out, "Reason: Waiting for API access")
+	assert.Contains(t, out, "Next: autospec implement 001-demo")
+	assert.NotContains(t, out, "specify")
+}
+
+func TestNextCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		detail *specDetail
+		want   string
+	}{
+		"missing plan": {
+			detail: &specDetail{statusReport: &statusReport{Spec: "001-demo", Artifacts: []string{"spec.yaml"}}},
+			want:   "autospec run --spec 001-demo --plan",
+		},
+		"missing tasks": {
+			detail: &specDetail{statusReport: &statusReport{Spec: "001-demo", Artifacts: []string{"spec.yaml", "plan.yaml"}}},
+			want:   "autospec run --spec 001-demo --tasks",
+		},
+		"task checkpoint": {
+			detail: &specDetail{statusReport: &statusReport{
+				Spec:       "001-demo",
+				Artifacts:  statusArtifacts,
+				Checkpoint: &taskCheckpointReport{CurrentTask: "T2"},
+			}},
+			want: "autospec implement 001-demo --tasks --resume",
+		},
+		"interrupted phases run": {
+			detail: &specDetail{
+				statusReport: &statusReport{Spec: "001-demo", Artifacts: statusArtifacts, Tasks: &validation.TaskStats{TotalTasks: 2, PendingTasks: 1}},
+				PhaseState:   &retry.StageExecutionState{TotalPhases: 2},
+			},
+			want: "autospec implement 001-demo --resume",
+		},
+		"only blocked tasks left": {
+			detail: &specDetail{statusReport: &statusReport{Spec: "001-demo", Artifacts: statusArtifacts, Tasks: &validation.TaskStats{TotalTasks: 2, CompletedTasks: 1, BlockedTasks: 1}}},
+			want:   "autospec task unblock <id> (1 blocked)",
+		},
+		"all tasks completed": {
+			detail: &specDetail{statusReport: &statusReport{Spec: "001-demo", Artifacts: statusArtifacts, Tasks: &validation.TaskStats{TotalTasks: 2, CompletedTasks: 2}}},
+			want:   "",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, nextCommand(tt.detail))
+		})
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -91,3 +94,45 @@ func TestDisplayBlockedTasks_EmptyBlockedReason(t *testing.T) {
 	// Should handle empty blocked_reason gracefully
 	displayBlockedTasks(tasksPath)
 }
+
+func TestBuildStatusReport(t *testing.T) {
+	t.Parallel()
+
+	specDir := t.TempDir()
+	stateDir := t.TempDir()
+	tasksContent := `phases:
+  - number: 1
+    title: "Setup"
+    tasks:
+      - id: "T1"
+        title: "Task 1"
+        status: "Completed"
+      - id: "T2"
+        title: "Task 2"
+        status: "Blocked"
+        blocked_reason: "Waiting for API access"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "spec.yaml"), []byte("{}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "tasks.yaml"), []byte(tasksContent), 0644))
+	_, err := retry.IncrementRetryCount(stateDir, "001-demo", "tasks", 3)
+	require.NoError(t, err)
+
+	report := buildStatusReport(&spec.Metadata{Number: "001", Name: "demo", Directory: specDir}, stateDir)
+
+	assert.Equal(t, "001-demo", report.Spec)
+	assert.Equal(t, []string{"spec.yaml", "tasks.yaml"}, report.Artifacts)
+	require.NotNil(t, report.Tasks)
+	assert.Equal(t, 2, report.Tasks.TotalTasks)
+	assert.Equal(t, 1, report.Tasks.BlockedTasks)
+	require.Len(t, report.BlockedTasks, 1)
+	assert.Equal(t, blockedTaskReport{ID: "T2", Title: "Task 2", Reason: "Waiting for API access"}, report.BlockedTasks[0])
+	assert.Equal(t, map[string]int{"tasks": 1}, report.Retries)
+	assert.Nil(t, report.Risks)
+	assert.Nil(t, report.Checkpoint)
+
+	require.NoError(t, retry.SetCurrentTask(stateDir, "001-demo", "T2", 2))
+	report = buildStatusReport(&spec.Metadata{Number: "001", Name: "demo", Directory: specDir}, stateDir)
+	require.NotNil(t, report.Checkpoint)
+	assert.Equal(t, "T2", report.Checkpoint.CurrentTask)
+	assert.Equal(t, 2, report.Checkpoint.TotalTasks)
+}
@@ -0,0 +1,165 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/git"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/statesync"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Share spec run state with your other machines",
+	Long: `Push or pull the run state autospec keeps in <state_dir> for each spec:
+retry counts, phase and task progress, workflow checkpoints and history.
+Push on one machine and pull on another to continue an implement run where
+it stopped.
+
+By default state is kept on the autospec-state branch of the project's git
+repository and pushed to origin. The branch has no history in common with
+your code and is never checked out. Set sync.store to dir to use a shared
+directory instead.
+
+State is handed over per spec, not merged: a push replaces the stored state
+of each spec and a pull replaces the local state. Both refuse when the other
+side has newer state; --force overwrites it anyway. History entries are
+added on both sides, never removed.`,
+	Example: `  # On the laptop: share the state of every spec
+  autospec sync push
+
+  # On the desktop: pick it up and continue
+  autospec sync pull
+  autospec implement
+
+  # Only one spec, overwriting newer stored state
+  autospec sync push 003-auth --force`,
+}
+
+var syncPushCmd = &cobra.Command{
+	Use:               "push [spec-name...]",
+	Short:             "Upload local spec state to the sync store",
+	Long:              `Upload the local state of the named specs, or of every spec in the specs directory, to the sync store.`,
+	ValidArgsFunction: shared.CompleteSpecNames,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSync(cmd, args, true)
+	},
+}
+
+var syncPullCmd = &cobra.Command{
+	Use:               "pull [spec-name...]",
+	Short:             "Replace local spec state with the sync store's",
+	Long:              `Download the state of the named specs, or of every spec in the sync store, replacing the local state.`,
+	ValidArgsFunction: shared.CompleteSpecNames,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSync(cmd, args, false)
+	},
+}
+
+func init() {
+	syncCmd.GroupID = shared.GroupConfiguration
+	for _, c := range []*cobra.Command{syncPushCmd, syncPullCmd} {
+		c.Flags().Bool("force", false, "Overwrite state even if the other side is newer")
+		syncCmd.AddCommand(c)
+	}
+}
+
+func runSync(cmd *cobra.Command, args []string, push bool) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+	store, err := newSyncStore(cfg.Sync)
+	if err != nil {
+		return err
+	}
+
+	opts := statesync.Options{}
+	opts.Force, _ = cmd.Flags().GetBool("force")
+	opts.Specs, err = syncSpecNames(cfg.SpecsDir, args, push)
+	if err != nil {
+		return err
+	}
+
+	var result statesync.Result
+	if push {
+		result, err = statesync.Push(cfg.StateDir, store, opts)
+	} else {
+		result, err = statesync.Pull(cfg.StateDir, store, opts)
+	}
+	if err != nil {
+		var conflict *statesync.ConflictError
+		if errors.As(err, &conflict) {
+			return err
+		}
+		return fmt.Errorf("sync with %s: %w", store, err)
+	}
+	printSyncResult(cmd.OutOrStdout(), result, store, push, len(args) > 0)
+	return nil
+}
+
+// newSyncStore returns the store configured by sc.
+func newSyncStore(sc config.SyncConfig) (statesync.Store, error) {
+	if sc.Store == statesync.StoreDir {
+		return statesync.NewDirStore(sc.Dir), nil
+	}
+	if !git.IsGitRepository() {
+		return nil, fmt.Errorf("sync.store is git but the current directory is not in a git repository; set sync.store: dir and sync.dir to use a shared directory")
+	}
+	return statesync.NewGitBranchStore("", sc.Branch, sc.Remote), nil
+}
+
+// syncSpecNames resolves spec arguments to spec directory names. With no
+// arguments a push covers every spec in specsDir and a pull every spec in
+// the store. A pull may name specs that don't exist locally yet.
+func syncSpecNames(specsDir string, args []string, push bool) ([]string, error) {
+	if len(args) == 0 {
+		if !push {
+			return nil, nil
+		}
+		return spec.ListSpecs(specsDir)
+	}
+	names := make([]string, 0, len(args))
+	for _, arg := range args {
+		metadata, err := spec.GetSpecMetadata(specsDir, arg)
+		switch {
+		case err == nil:
+			names = append(names, filepath.Base(metadata.Directory))
+		case push:
+			return nil, fmt.Errorf("failed to find spec: %w", err)
+		default:
+			names = append(names, arg)
+		}
+	}
+	return names, nil
+}
+
+func printSyncResult(out io.Writer, result statesync.Result, store statesync.Store, push, named bool) {
+	action, verb, prep := "pull", "Pulled", "from"
+	if push {
+		action, verb, prep = "push", "Pushed", "to"
+	}
+	if len(result.Synced) == 0 {
+		fmt.Fprintf(out, "Nothing to %s.\n", action)
+		return
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Fprintf(out, "%s %s state of %s %s %s\n", green("✓"), verb, strings.Join(result.Synced, ", "), prep, store)
+	if named && len(result.Skipped) > 0 {
+		fmt.Fprintf(out, "No state to sync for %s\n", strings.Join(result.Skipped, ", "))
+	}
+}
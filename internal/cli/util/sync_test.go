@@ -0,0 +1,90 @@
+// Package util tests the sync command.
+// Related: internal/cli/util/sync.go, internal/statesync/statesync.go
+// Tags: util, cli, sync, state
+
+package util
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSyncTestCmd(push bool) (*cobra.Command, *bytes.Buffer) {
+	cmd := &cobra.Command{
+		Use:           "sync",
+		RunE:          func(cmd *cobra.Command, args []string) error { return runSync(cmd, args, push) },
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	cmd.Flags().String("config", "", "")
+	cmd.Flags().Bool("force", false, "")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	return cmd, &out
+}
+
+func TestRunSync_Dir(t *testing.T) {
+	// Not parallel: changes directory and env
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AUTOSPEC_SPECS_DIR", filepath.Join(dir, "specs"))
+	t.Setenv("AUTOSPEC_SYNC_STORE", "dir")
+	t.Setenv("AUTOSPEC_SYNC_DIR", filepath.Join(dir, "shared"))
+	for _, name := range []string{"001-login", "002-search"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "specs", name), 0o755))
+	}
+
+	laptop, desktop := filepath.Join(dir, "laptop"), filepath.Join(dir, "desktop")
+	require.NoError(t, retry.SaveTaskState(laptop, &retry.TaskExecutionState{
+		SpecName: "001-login", CurrentTaskID: "T002", LastTaskAttempt: time.Now(),
+	}))
+
+	t.Setenv("AUTOSPEC_STATE_DIR", laptop)
+	cmd, out := newSyncTestCmd(true)
+	cmd.SetArgs(nil)
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Pushed state of 001-login to "+filepath.Join(dir, "shared"))
+
+	cmd, out = newSyncTestCmd(true)
+	cmd.SetArgs([]string{"002"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Nothing to push")
+
+	t.Setenv("AUTOSPEC_STATE_DIR", desktop)
+	cmd, out = newSyncTestCmd(false)
+	cmd.SetArgs(nil)
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Pulled state of 001-login")
+	task, err := retry.LoadTaskState(desktop, "001-login")
+	require.NoError(t, err)
+	assert.Equal(t, "T002", task.CurrentTaskID)
+
+	t.Run("unknown spec", func(t *testing.T) {
+		cmd, _ := newSyncTestCmd(true)
+		cmd.SetArgs([]string{"009"})
+		assert.ErrorContains(t, cmd.Execute(), "failed to find spec")
+	})
+}
+
+func TestRunSync_GitStoreOutsideRepository(t *testing.T) {
+	// Not parallel: changes directory and env
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+
+	cmd, _ := newSyncTestCmd(false)
+	cmd.SetArgs(nil)
+	assert.ErrorContains(t, cmd.Execute(), "not in a git repository")
+}
@@ -0,0 +1,109 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// uiHistoryLimit is the number of history entries the dashboard tails.
+const uiHistoryLimit = 50
+
+var uiCmd = &cobra.Command{
+	Use:   "ui",
+	Short: "Interactive dashboard of specs, task progress and agent output",
+	Long: `Open a full-screen dashboard listing every spec with its artifacts, task
+progress by phase and next recommended command. The view refreshes every few
+seconds, so progress made by other autospec processes shows up as it happens.
+
+Keys:
+  ↑/↓, j/k   select a spec
+  enter, n   run the selected spec's next phase; its output streams into the view
+  x          cancel the running phase
+  b          show the selected spec's blocked tasks
+  h          tail the command history
+  r          reload now
+  q          quit (a running phase is cancelled)
+
+Phases run as separate autospec processes with confirmations skipped
+(AUTOSPEC_YES=1). Requires an interactive terminal.`,
+	Example: `  # Open the dashboard
+  autospec ui`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runUI,
+}
+
+func init() {
+	uiCmd.GroupID = shared.GroupGettingStarted
+}
+
+func runUI(cmd *cobra.Command, _ []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating autospec binary: %w", err)
+	}
+	specsDir := resolveSpecsDir(cmd, cfg.SpecsDir)
+	load := func() (tui.DashboardData, error) {
+		return loadDashboardData(specsDir, cfg.StateDir, cfg.MaxRetries)
+	}
+	return tui.RunDashboard(tui.NewDashboard(load, executable))
+}
+
+// loadDashboardData gathers every spec's progress and next step, plus the
+// newest history entries across all specs.
+func loadDashboardData(specsDir, stateDir string, maxRetries int) (tui.DashboardData, error) {
+	summaries, err := scanSpecsDir(specsDir)
+	if err != nil {
+		return tui.DashboardData{}, fmt.Errorf("scanning specs directory: %w", err)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	var data tui.DashboardData
+	for _, s := range summaries {
+		number, name, found := strings.Cut(s.Name, "-")
+		if !found {
+			number, name = "", s.Name
+		}
+		metadata := &spec.Metadata{Number: number, Name: name, Directory: filepath.Join(specsDir, s.Name)}
+		detail := buildSpecProgress(metadata, stateDir, maxRetries)
+		view := tui.SpecView{
+			Name:      s.Name,
+			Status:    s.Status,
+			Artifacts: detail.Artifacts,
+			Tasks:     detail.Tasks,
+			Next:      nextStep(detail),
+		}
+		for _, task := range detail.BlockedTasks {
+			view.Blocked = append(view.Blocked, tui.BlockedTask{ID: task.ID, Title: task.Title, Reason: task.Reason})
+		}
+		data.Specs = append(data.Specs, view)
+	}
+
+	if file, err := history.LoadHistory(stateDir); err == nil {
+		for i := len(file.Entries) - 1; i >= 0 && len(data.History) < uiHistoryLimit; i-- {
+			data.History = append(data.History, file.Entries[i])
+		}
+	}
+	return data, nil
+}
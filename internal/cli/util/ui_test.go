@@ -0,0 +1,70 @@
+// Package util tests the ui command's dashboard data loading.
+// Related: internal/cli/util/ui.go
+// Tags: util, cli, ui, dashboard
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDashboardData(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	stateDir := t.TempDir()
+	writeSpec := func(name string, files map[string]string) {
+		dir := filepath.Join(specsDir, name)
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		for file, content := range files {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0644))
+		}
+	}
+	writeSpec("002-cache", map[string]string{
+		"spec.yaml": "feature:\n  status: Draft\n",
+		"plan.yaml": "{}",
+		"tasks.yaml": `phases:
+  - number: 1
+    title: "Setup"
+    tasks:
+      - id: "T1"
+        title: "Wire Redis"
+        status: "Blocked"
+        blocked_reason: "Waiting for credentials"
+      - id: "T2"
+        title: "Add cache"
+        status: "Pending"
+`,
+	})
+	writeSpec("001-auth", map[string]string{"spec.yaml": "feature:\n  status: Draft\n"})
+	require.NoError(t, history.SaveHistory(stateDir, &history.HistoryFile{Entries: []history.HistoryEntry{
+		{Timestamp: time.Now().Add(-time.Hour), Command: "specify", Spec: "001-auth"},
+		{Timestamp: time.Now(), Command: "plan", Spec: "002-cache"},
+	}}))
+
+	data, err := loadDashboardData(specsDir, stateDir, 3)
+	require.NoError(t, err)
+
+	require.Len(t, data.Specs, 2)
+	assert.Equal(t, "001-auth", data.Specs[0].Name)
+	assert.Nil(t, data.Specs[0].Tasks)
+	assert.Equal(t, []string{"run", "--spec", "001-auth", "--plan"}, data.Specs[0].Next)
+
+	cache := data.Specs[1]
+	assert.Equal(t, "002-cache", cache.Name)
+	require.NotNil(t, cache.Tasks)
+	assert.Equal(t, 2, cache.Tasks.TotalTasks)
+	require.Len(t, cache.Blocked, 1)
+	assert.Equal(t, "Waiting for credentials", cache.Blocked[0].Reason)
+	assert.Equal(t, []string{"implement", "002-cache"}, cache.Next)
+
+	require.Len(t, data.History, 2)
+	assert.Equal(t, "plan", data.History[0].Command)
+}
@@ -0,0 +1,306 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/statestore"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [spec-name]",
+	Short: "Revert the last autospec change to tasks.yaml or retry state",
+	Long: `Revert the most recent change autospec made to the current spec's
+tasks.yaml or to the retry state (retry.json), such as a task blocked or
+completed by accident or a retry count reset.
+
+autospec keeps the version before each of its edits as <file>.bak (in
+state.db with state_store: sqlite); undo restores the more recently changed
+of the two files from its backup and prints what changes. The reverted version becomes the new backup, so
+running undo again redoes the change. Edits made to tasks.yaml by the agent
+or by hand since autospec's last edit are reverted too; check the summary
+or use --dry-run first.`,
+	Example: `  # Undo the last task status change or retry state update
+  autospec undo
+
+  # Show what would be reverted without changing anything
+  autospec undo --dry-run
+
+  # Undo for a specific spec
+  autospec undo 003-auth`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteSpecNames),
+	SilenceUsage:      true,
+	RunE:              runUndo,
+}
+
+func init() {
+	undoCmd.GroupID = shared.GroupConfiguration
+	undoCmd.Flags().BoolP("dry-run", "n", false, "Show what would be reverted without changing anything")
+}
+
+// undoTarget is a file autospec changed that can be restored from its backup.
+type undoTarget struct {
+	label string
+	// store holds the file as name.
+	store   statestore.Storage
+	name    string
+	modTime time.Time
+	// summarize describes the changes from the current file to the backup.
+	summarize func(current, backup []byte) []string
+}
+
+func runUndo(cmd *cobra.Command, args []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+
+	// Without a detectable spec, only retry state can be undone
+	var specDir string
+	if len(args) > 0 {
+		metadata, err := spec.GetSpecMetadata(cfg.SpecsDir, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to find spec: %w", err)
+		}
+		specDir = metadata.Directory
+	} else if metadata, err := spec.DetectCurrentSpec(cfg.SpecsDir); err == nil {
+		specDir = metadata.Directory
+	}
+
+	target := latestUndoTarget(undoTargets(specDir, cfg.StateDir))
+	if target == nil {
+		return fmt.Errorf("nothing to undo: no backup of tasks.yaml or retry state found")
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	return undoChange(cmd.OutOrStdout(), target, dryRun)
+}
+
+// undoTargets returns the files undo can restore: the spec's tasks.yaml and
+// retry.json, where a backup exists.
+func undoTargets(specDir, stateDir string) []*undoTarget {
+	var candidates []*undoTarget
+	if specDir != "" {
+		candidates = append(candidates, &undoTarget{
+			label:     fmt.Sprintf("tasks.yaml of %s", filepath.Base(specDir)),
+			store:     &statestore.FileStorage{Dir: specDir},
+			name:      "tasks.yaml",
+			summarize: summarizeTasksUndo,
+		})
+	}
+	candidates = append(candidates, &undoTarget{
+		label:     "retry state",
+		store:     statestore.For(stateDir),
+		name:      "retry.json",
+		summarize: summarizeRetryUndo,
+	})
+
+	var targets []*undoTarget
+	for _, c := range candidates {
+		if _, err := c.store.Read(c.name); err != nil {
+			continue
+		}
+		_, modTime, err := c.store.Backup(c.name)
+		if err != nil {
+			continue
+		}
+		c.modTime = modTime
+		targets = append(targets, c)
+	}
+	return targets
+}
+
+// latestUndoTarget returns the most recently changed target, or nil.
+func latestUndoTarget(targets []*undoTarget) *undoTarget {
+	var latest *undoTarget
+	for _, t := range targets {
+		if latest == nil || t.modTime.After(latest.modTime) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// undoChange restores target from its backup, keeping the current version as
+// the new backup, and prints what changed.
+func undoChange(out io.Writer, target *undoTarget, dryRun bool) error {
+	unlock, err := target.store.Lock(target.name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	current, err := target.store.Read(target.name)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", target.name, err)
+	}
+	backup, _, err := target.store.Backup(target.name)
+	if err != nil {
+		return fmt.Errorf("reading backup: %w", err)
+	}
+
+	verb := "Reverted"
+	if dryRun {
+		verb = "Would revert"
+	}
+	fmt.Fprintf(out, "%s %s (changed %s)\n", verb, target.label, target.modTime.Local().Format("2006-01-02 15:04:05"))
+	changes := target.summarize(current, backup)
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "  (no status or count changes)")
+	}
+	for _, change := range changes {
+		fmt.Fprintf(out, "  %s\n", change)
+	}
+	if dryRun {
+		return nil
+	}
+
+	if err := target.store.Write(target.name, backup); err != nil {
+		return fmt.Errorf("restoring %s: %w", target.label, err)
+	}
+	fmt.Fprintln(out, "Run 'autospec undo' again to redo.")
+	return nil
+}
+
+// summarizeTasksUndo lists the task changes undo makes: added and removed
+// tasks and status changes.
+func summarizeTasksUndo(current, backup []byte) []string {
+	now, err := parseTasks(current)
+	if err != nil {
+		return []string{fmt.Sprintf("(current tasks.yaml unreadable: %v)", err)}
+	}
+	before, err := parseTasks(backup)
+	if err != nil {
+		return []string{fmt.Sprintf("(backup unreadable: %v)", err)}
+	}
+
+	previous := make(map[string]validation.TaskItem, len(before))
+	for _, task := range before {
+		previous[task.ID] = task
+	}
+	var changes []string
+	seen := make(map[string]bool, len(now))
+	for _, task := range now {
+		seen[task.ID] = true
+		old, ok := previous[task.ID]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("%s: removed (%s)", task.ID, task.Title))
+		case old.Status != task.Status:
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", task.ID, task.Status, old.Status))
+		case old.Title != task.Title || old.BlockedReason != task.BlockedReason:
+			changes = append(changes, fmt.Sprintf("%s: edited", task.ID))
+		}
+	}
+	for _, task := range before {
+		if !seen[task.ID] {
+			changes = append(changes, fmt.Sprintf("%s: restored (%s)", task.ID, task.Title))
+		}
+	}
+	return changes
+}
+
+// summarizeRetryUndo lists the retry counts and task progress undo changes.
+func summarizeRetryUndo(current, backup []byte) []string {
+	now, err := readRetryStore(current)
+	if err != nil {
+		return []string{fmt.Sprintf("(current retry state unreadable: %v)", err)}
+	}
+	before, err := readRetryStore(backup)
+	if err != nil {
+		return []string{fmt.Sprintf("(backup unreadable: %v)", err)}
+	}
+
+	var changes []string
+	for _, key := range unionKeys(now.Retries, before.Retries) {
+		oldCount, newCount := 0, 0
+		if state := before.Retries[key]; state != nil {
+			oldCount = state.Count
+		}
+		if state := now.Retries[key]; state != nil {
+			newCount = state.Count
+		}
+		if oldCount != newCount {
+			changes = append(changes, fmt.Sprintf("retries %s: %d -> %d", key, newCount, oldCount))
+		}
+	}
+	for _, key := range unionKeys(now.TaskStates, before.TaskStates) {
+		oldDone, newDone := 0, 0
+		if state := before.TaskStates[key]; state != nil {
+			oldDone = len(state.CompletedTaskIDs)
+		}
+		if state := now.TaskStates[key]; state != nil {
+			newDone = len(state.CompletedTaskIDs)
+		}
+		if oldDone != newDone {
+			changes = append(changes, fmt.Sprintf("completed tasks %s: %d -> %d", key, newDone, oldDone))
+		}
+	}
+	for _, key := range unionKeys(now.StageStates, before.StageStates) {
+		oldDone, newDone := 0, 0
+		if state := before.StageStates[key]; state != nil {
+			oldDone = len(state.CompletedPhases)
+		}
+		if state := now.StageStates[key]; state != nil {
+			newDone = len(state.CompletedPhases)
+		}
+		if oldDone != newDone {
+			changes = append(changes, fmt.Sprintf("completed phases %s: %d -> %d", key, newDone, oldDone))
+		}
+	}
+	return changes
+}
+
+// parseTasks returns the tasks of every phase in a tasks.yaml file.
+func parseTasks(data []byte) ([]validation.TaskItem, error) {
+	var tasks validation.TasksYAML
+	if err := yaml.Unmarshal(data, &tasks); err != nil {
+		return nil, err
+	}
+	var all []validation.TaskItem
+	for _, phase := range tasks.Phases {
+		all = append(all, phase.Tasks...)
+	}
+	return all, nil
+}
+
+// readRetryStore parses a retry.json file.
+func readRetryStore(data []byte) (*retry.RetryStore, error) {
+	var store retry.RetryStore
+	if err := json.Unmarshal(data, &store); err != nil {
+		return nil, err
+	}
+	return &store, nil
+}
+
+// unionKeys returns the keys of both maps, sorted.
+func unionKeys[V any](a, b map[string]V) []string {
+	set := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		set[k] = true
+	}
+	for k := range b {
+		set[k] = true
+	}
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
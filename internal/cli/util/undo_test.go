@@ -0,0 +1,170 @@
+// Package util tests the undo command implementation.
+// Related: internal/cli/util/undo.go
+// Tags: util, cli, undo, backup, tasks, retry
+
+package util
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// undoTasksYAML returns a tasks.yaml with T001 and T002 at the given statuses.
+func undoTasksYAML(status1, status2 string) string {
+	return `phases:
+  - number: 1
+    title: Setup
+    tasks:
+      - id: T001
+        title: Create module
+        status: ` + status1 + `
+      - id: T002
+        title: Add parser
+        status: ` + status2 + `
+`
+}
+
+func TestUndoChange_TasksYAML(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		dryRun      bool
+		wantContent string
+	}{
+		"restores backup": {
+			wantContent: undoTasksYAML("Completed", "Pending"),
+		},
+		"dry run keeps file": {
+			dryRun:      true,
+			wantContent: undoTasksYAML("Completed", "Blocked"),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			specDir := filepath.Join(t.TempDir(), "003-auth")
+			require.NoError(t, os.MkdirAll(specDir, 0o755))
+			tasksPath := filepath.Join(specDir, "tasks.yaml")
+			require.NoError(t, atomicfile.WriteFile(tasksPath, []byte(undoTasksYAML("Completed", "Pending")), 0o644))
+			require.NoError(t, atomicfile.WriteFile(tasksPath, []byte(undoTasksYAML("Completed", "Blocked")), 0o644))
+
+			targets := undoTargets(specDir, t.TempDir())
+			require.Len(t, targets, 1)
+			var out bytes.Buffer
+			require.NoError(t, undoChange(&out, targets[0], tt.dryRun))
+
+			assert.Contains(t, out.String(), "tasks.yaml of 003-auth")
+			assert.Contains(t, out.String(), "T002: Blocked -> Pending")
+			assert.NotContains(t, out.String(), "T001")
+			data, err := os.ReadFile(tasksPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantContent, string(data))
+		})
+	}
+}
+
+func TestUndoChange_RunTwiceRedoes(t *testing.T) {
+	t.Parallel()
+	specDir := t.TempDir()
+	tasksPath := filepath.Join(specDir, "tasks.yaml")
+	require.NoError(t, atomicfile.WriteFile(tasksPath, []byte(undoTasksYAML("Pending", "Pending")), 0o644))
+	require.NoError(t, atomicfile.WriteFile(tasksPath, []byte(undoTasksYAML("Completed", "Pending")), 0o644))
+
+	for range 2 {
+		targets := undoTargets(specDir, t.TempDir())
+		require.Len(t, targets, 1)
+		require.NoError(t, undoChange(&bytes.Buffer{}, targets[0], false))
+	}
+
+	data, err := os.ReadFile(tasksPath)
+	require.NoError(t, err)
+	assert.Equal(t, undoTasksYAML("Completed", "Pending"), string(data))
+}
+
+func TestUndoChange_RetryState(t *testing.T) {
+	t.Parallel()
+	stateDir := t.TempDir()
+	_, err := retry.IncrementRetryCount(stateDir, "003-auth", "implement", 3)
+	require.NoError(t, err)
+	_, err = retry.IncrementRetryCount(stateDir, "003-auth", "implement", 3)
+	require.NoError(t, err)
+	require.NoError(t, retry.ResetRetryCount(stateDir, "003-auth", "implement"))
+
+	targets := undoTargets("", stateDir)
+	require.Len(t, targets, 1)
+	var out bytes.Buffer
+	require.NoError(t, undoChange(&out, targets[0], false))
+
+	assert.Contains(t, out.String(), "retries 003-auth:implement: 0 -> 2")
+	state, err := retry.LoadRetryState(stateDir, "003-auth", "implement", 3)
+	require.NoError(t, err)
+	assert.Equal(t, 2, state.Count)
+}
+
+func TestUndoTargets(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		tasksBackup bool
+		retryBackup bool
+		tasksNewer  bool
+		wantLabel   string
+	}{
+		"no backups": {},
+		"only tasks backup": {
+			tasksBackup: true,
+			wantLabel:   "tasks.yaml of 003-auth",
+		},
+		"tasks changed last": {
+			tasksBackup: true,
+			retryBackup: true,
+			tasksNewer:  true,
+			wantLabel:   "tasks.yaml of 003-auth",
+		},
+		"retry changed last": {
+			tasksBackup: true,
+			retryBackup: true,
+			wantLabel:   "retry state",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			specDir := filepath.Join(t.TempDir(), "003-auth")
+			stateDir := t.TempDir()
+			require.NoError(t, os.MkdirAll(specDir, 0o755))
+			tasksPath := filepath.Join(specDir, "tasks.yaml")
+			retryPath := filepath.Join(stateDir, "retry.json")
+			require.NoError(t, os.WriteFile(tasksPath, []byte(undoTasksYAML("Pending", "Pending")), 0o644))
+			require.NoError(t, os.WriteFile(retryPath, []byte(`{"retries":{}}`), 0o644))
+			if tt.tasksBackup {
+				require.NoError(t, os.WriteFile(tasksPath+atomicfile.BackupSuffix, nil, 0o644))
+			}
+			if tt.retryBackup {
+				require.NoError(t, os.WriteFile(retryPath+atomicfile.BackupSuffix, nil, 0o644))
+			}
+			older, newer := time.Now().Add(-time.Hour), time.Now()
+			if tt.tasksNewer {
+				older, newer = newer, older
+			}
+			require.NoError(t, os.Chtimes(tasksPath, older, older))
+			require.NoError(t, os.Chtimes(retryPath, newer, newer))
+
+			target := latestUndoTarget(undoTargets(specDir, stateDir))
+			if tt.wantLabel == "" {
+				assert.Nil(t, target)
+				return
+			}
+			require.NotNil(t, target)
+			assert.Equal(t, tt.wantLabel, target.label)
+		})
+	}
+}
@@ -20,14 +20,25 @@ const (
 var updateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update autospec to the latest version",
-	Long:  "Download and install the latest version of autospec from GitHub releases.",
+	Long: `Download and install the latest version of autospec from GitHub releases.
+
+The release notes of the new versions are shown first. The download is
+verified against the release's checksums.txt before the running binary is
+replaced; the previous binary is restored if installation fails. Use
+--channel prerelease to update to release candidates.`,
 	Example: `  # Update to latest version
-  autospec update`,
+  autospec update
+
+  # Update to the newest prerelease
+  autospec update --channel prerelease`,
 	RunE: runUpdate,
 }
 
+var updateChannel string
+
 func init() {
 	updateCmd.GroupID = shared.GroupGettingStarted
+	addChannelFlag(updateCmd, &updateChannel)
 }
 
 // runUpdate executes the update command.
@@ -42,10 +53,14 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("cannot update dev builds; please build from source or use a release version")
 	}
 
+	checker, err := newChecker(updateHTTPTimeout, updateChannel)
+	if err != nil {
+		return err
+	}
+
 	fmt.Printf("%s Checking for updates...\n", yellow("→"))
 
 	// Check for update
-	checker := update.NewChecker(updateHTTPTimeout)
 	check, err := checker.CheckForUpdate(ctx, Version)
 	if err != nil {
 		return fmt.Errorf("checking for update: %w", err)
@@ -58,6 +73,7 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("%s New version available: %s → %s\n",
 		green("→"), Version, green(check.LatestVersion))
+	fmt.Print(formatReleaseNotes(check.Notes))
 
 	// Create installer and check permissions
 	installer, err := update.NewInstaller()
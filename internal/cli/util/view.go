@@ -11,6 +11,7 @@ import (
 	"github.com/ariel-frischer/autospec/internal/cli/shared"
 	"github.com/ariel-frischer/autospec/internal/config"
 	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/spec"
 	"github.com/ariel-frischer/autospec/internal/validation"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -20,11 +21,14 @@ import (
 type SpecSummary struct {
 	Name             string    // Spec directory name (e.g., '063-view-dashboard')
 	Status           string    // Spec status from spec.yaml (Draft, In Progress, Completed, etc.)
+	Branch           string    // Git branch from spec.yaml (empty if not set)
 	TaskProgress     string    // Task completion formatted as 'X/Y tasks' or 'no tasks'
 	CompletedTasks   int       // Number of completed tasks
 	TotalTasks       int       // Total number of tasks
+	BlockedTasks     int       // Number of blocked tasks
 	LastModified     time.Time // Most recent modification time of files in spec directory
 	ArtifactsPresent []string  // List of existing artifacts (spec.yaml, plan.yaml, tasks.yaml)
+	DependsOn        []string  // Specs listed in spec.yaml depends_on
 }
 
 // DashboardStats contains project-wide statistics for the dashboard header.
@@ -152,9 +156,12 @@ func getSpecSummary(specDir, name string) (SpecSummary, error) {
 	}
 
 	summary.Status = parseSpecStatus(specPath)
+	summary.Branch = parseSpecBranch(specPath)
 	summary.ArtifactsPresent = detectArtifacts(specDir)
 	summary.LastModified = getLatestModTime(specDir)
 	summary.CompletedTasks, summary.TotalTasks, summary.TaskProgress = getTaskProgress(specDir)
+	summary.BlockedTasks = getBlockedTasks(specDir)
+	summary.DependsOn, _ = spec.ReadDependencies(specDir)
 
 	return summary, nil
 }
@@ -181,6 +188,24 @@ func parseSpecStatus(specPath string) string {
 	return spec.Feature.Status
 }
 
+// parseSpecBranch extracts the branch field from spec.yaml.
+func parseSpecBranch(specPath string) string {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return ""
+	}
+
+	var spec struct {
+		Feature struct {
+			Branch string `yaml:"branch"`
+		} `yaml:"feature"`
+	}
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return ""
+	}
+	return spec.Feature.Branch
+}
+
 // detectArtifacts checks which artifact files exist in the spec directory.
 func detectArtifacts(specDir string) []string {
 	artifacts := []string{"spec.yaml", "plan.yaml", "tasks.yaml"}
@@ -229,6 +254,15 @@ func getTaskProgress(specDir string) (completed, total int, progress string) {
 	return stats.CompletedTasks, stats.TotalTasks, fmt.Sprintf("%d/%d tasks", stats.CompletedTasks, stats.TotalTasks)
 }
 
+// getBlockedTasks returns the number of blocked tasks in tasks.yaml, or 0 if it doesn't exist.
+func getBlockedTasks(specDir string) int {
+	stats, err := validation.GetTaskStats(validation.GetTasksFilePath(specDir))
+	if err != nil {
+		return 0
+	}
+	return stats.BlockedTasks
+}
+
 // computeDashboardStats computes aggregate statistics from all spec summaries.
 func computeDashboardStats(summaries []SpecSummary) DashboardStats {
 	stats := DashboardStats{TotalSpecs: len(summaries)}
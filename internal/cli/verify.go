@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/lifecycle"
+	"github.com/ariel-frischer/autospec/internal/notify"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify [optional-prompt]",
+	Short: "Check completed tasks' acceptance criteria against the code",
+	Long: `Execute the /autospec.verify command for the current specification.
+
+The verify command will:
+- Auto-detect the current spec from git branch or most recent spec
+- Check each completed task's acceptance_criteria against the actual code
+- Record the result in tasks.yaml as a verification block per task
+  (status: Verified or Failed, with the unmet criteria)
+- List the tasks whose criteria are not met
+
+The command fails if any acceptance criterion is not met. With --strict it
+also fails unless every task is completed and verified.
+
+Prerequisites:
+- tasks.yaml must exist (run 'autospec tasks' first)
+- Tasks should be implemented (run 'autospec implement' first)`,
+	Example: `  # Verify the acceptance criteria of completed tasks
+  autospec verify
+
+  # Focus on a part of the code
+  autospec verify "Pay attention to error handling in the API layer"
+
+  # Fail unless every task is completed and verified (for CI)
+  autospec verify --strict`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true // Don't show help for execution errors
+		// Get optional prompt from args
+		var prompt string
+		if len(args) > 0 {
+			prompt = strings.Join(args, " ")
+		}
+
+		// Get flags
+		configPath, _ := cmd.Flags().GetString("config")
+		skipPreflight, _ := cmd.Flags().GetBool("skip-preflight")
+		maxRetries, _ := cmd.Flags().GetInt("max-retries")
+		strict, _ := cmd.Flags().GetBool("strict")
+
+		// Load configuration
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			cliErr := clierrors.ConfigParseError(configPath, err)
+			clierrors.PrintError(cliErr)
+			return cliErr
+		}
+
+		// Override skip-preflight from flag if set
+		if cmd.Flags().Changed("skip-preflight") {
+			cfg.SkipPreflight = skipPreflight
+		}
+
+		// Override max-retries from flag if set
+		if cmd.Flags().Changed("max-retries") {
+			cfg.MaxRetries = maxRetries
+		}
+
+		// Check if constitution exists (required for verify)
+		constitutionCheck := workflow.CheckConstitutionAt(cfg.Constitution)
+		if !constitutionCheck.Exists {
+			fmt.Fprint(os.Stderr, constitutionCheck.ErrorMessage)
+			cmd.SilenceUsage = true
+			return NewExitError(ExitInvalidArguments)
+		}
+
+		// Auto-detect current spec and verify tasks.yaml exists
+		metadata, err := spec.DetectCurrentSpec(cfg.SpecsDir)
+		if err != nil {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("failed to detect current spec: %w\n\nRun 'autospec specify' to create a new spec first", err)
+		}
+		PrintSpecInfo(metadata)
+
+		prereqResult := workflow.ValidateStagePrerequisites(workflow.StageVerify, metadata.Directory)
+		if !prereqResult.Valid {
+			fmt.Fprint(os.Stderr, prereqResult.ErrorMessage)
+			cmd.SilenceUsage = true
+			return NewExitError(ExitInvalidArguments)
+		}
+
+		// Create notification handler and history logger
+		notifHandler := notify.NewHandler(cfg.Notifications)
+		historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+		historyLogger.Shared = cfg.SharedHistory()
+		specName := fmt.Sprintf("%s-%s", metadata.Number, metadata.Name)
+
+		// Wrap command execution with lifecycle for timing, notification, and history
+		return lifecycle.RunWithHistory(notifHandler, historyLogger, "verify", specName, func() error {
+			// Create workflow orchestrator
+			orch := workflow.NewWorkflowOrchestrator(cfg)
+			orch.Executor.NotificationHandler = notifHandler
+			orch.Executor.UsageRecorder = historyLogger
+
+			// Apply output style from CLI flag (overrides config)
+			shared.ApplyOutputStyle(cmd, orch)
+
+			// Let the user review or replace the prompt (--edit-prompt, --prompt-file)
+			if err := shared.ApplyPromptFlags(cmd, orch); err != nil {
+				return err
+			}
+
+			// Stream agent output into the live view when --tui is set
+			defer shared.StartLiveOutput(cmd, orch)()
+			defer shared.StartReport(cmd, orch)()
+
+			// Execute verify stage
+			if err := orch.ExecuteVerify(specName, prompt, strict); err != nil {
+				return fmt.Errorf("verify stage failed: %w", err)
+			}
+
+			return nil
+		})
+	},
+}
+
+func init() {
+	verifyCmd.GroupID = GroupOptionalStages
+	rootCmd.AddCommand(verifyCmd)
+
+	// Command-specific flags
+	verifyCmd.Flags().IntP("max-retries", "r", 0, "Override max retry attempts (overrides config when set)")
+	verifyCmd.Flags().Bool("strict", false, "Fail unless every task is completed and its acceptance criteria verified")
+
+	// Prompt review flags
+	shared.AddPromptFlags(verifyCmd)
+}
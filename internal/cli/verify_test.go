@@ -0,0 +1,48 @@
+// Package cli_test tests the verify command registration, flags, and examples.
+// Related: internal/cli/verify.go
+// Tags: cli, verify, command, acceptance-criteria
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCmdRegistration(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Use == "verify [optional-prompt]" {
+			found = true
+			assert.Equal(t, GroupOptionalStages, cmd.GroupID)
+			break
+		}
+	}
+	assert.True(t, found, "verify command should be registered")
+}
+
+func TestVerifyCmdFlags(t *testing.T) {
+	tests := map[string]struct {
+		flag     string
+		defValue string
+	}{
+		"strict":      {flag: "strict", defValue: "false"},
+		"max-retries": {flag: "max-retries", defValue: "0"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := verifyCmd.Flags().Lookup(tt.flag)
+			require.NotNil(t, f, "flag %s should exist", tt.flag)
+			assert.Equal(t, tt.defValue, f.DefValue)
+		})
+	}
+}
+
+func TestVerifyCmdExamples(t *testing.T) {
+	for _, example := range []string{"autospec verify", "autospec verify --strict"} {
+		assert.Contains(t, verifyCmd.Example, example)
+	}
+	assert.Contains(t, verifyCmd.Long, "acceptance_criteria")
+}
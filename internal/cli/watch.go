@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ariel-frischer/autospec/internal/cli/shared"
+	"github.com/ariel-frischer/autospec/internal/config"
+	clierrors "github.com/ariel-frischer/autospec/internal/errors"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/lifecycle"
+	"github.com/ariel-frischer/autospec/internal/notify"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/watch"
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// watchNextStages maps an artifact to the stage --auto runs when it appears.
+// Implementation is never triggered automatically since it edits source code.
+var watchNextStages = map[validation.ArtifactType]workflow.Stage{
+	validation.ArtifactTypeSpec: workflow.StagePlan,
+	validation.ArtifactTypePlan: workflow.StageTasks,
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [spec-name]",
+	Short: "Watch specs and re-validate artifacts as they change",
+	Long: `Watch the specs directory and re-validate spec artifacts whenever they change.
+
+Runs until interrupted (Ctrl+C). Every spec.yaml, plan.yaml, tasks.yaml,
+analysis.yaml and checklists/*.yaml under the specs directory is validated
+after each save, so you can edit artifacts by hand alongside the CLI and see
+schema errors immediately.
+
+With --auto, the next stage runs when an artifact first appears and is valid:
+  spec.yaml appears  → plan
+  plan.yaml appears  → tasks
+Implementation is never triggered automatically. Pass a spec name to only
+watch that spec.`,
+	Example: `  # Re-validate artifacts of every spec on change
+  autospec watch
+
+  # Watch one spec and run plan/tasks as soon as the previous artifact appears
+  autospec watch 001-user-auth --auto`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: shared.FirstArg(shared.CompleteSpecNames),
+	RunE:              runWatch,
+}
+
+func init() {
+	watchCmd.GroupID = GroupWorkflows
+	rootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().Bool("auto", false, "Run the next stage when spec.yaml or plan.yaml appears and is valid")
+	watchCmd.Flags().Duration("debounce", watch.DefaultDebounce, "How long writes must settle before an artifact is validated")
+	shared.AddAgentFlag(watchCmd)
+	shared.AddModelFlag(watchCmd)
+}
+
+// runWatch loads configuration and watches the specs directory until interrupted.
+func runWatch(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	configPath, _ := cmd.Flags().GetString("config")
+	auto, _ := cmd.Flags().GetBool("auto")
+	debounce, _ := cmd.Flags().GetDuration("debounce")
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		cliErr := clierrors.ConfigParseError(configPath, err)
+		clierrors.PrintError(cliErr)
+		return cliErr
+	}
+	if _, err := shared.ApplyAgentOverride(cmd, cfg); err != nil {
+		return err
+	}
+	shared.ApplyModelOverride(cmd, cfg)
+
+	watcher, err := watch.New(cfg.SpecsDir, debounce)
+	if err != nil {
+		return fmt.Errorf("watching specs directory: %w", err)
+	}
+	defer watcher.Close()
+
+	session := &watchSession{out: cmd.OutOrStdout(), auto: auto, runStage: stageRunner(cmd, cfg)}
+	if len(args) == 1 {
+		session.specFilter = args[0]
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	fmt.Fprintf(session.out, "Watching %s for artifact changes (Ctrl+C to stop)\n", cfg.SpecsDir)
+	return watcher.Run(ctx, session.handle)
+}
+
+// watchSession reacts to artifact changes reported by the watcher.
+type watchSession struct {
+	out        io.Writer
+	specFilter string // Only handle this spec when set
+	auto       bool   // Run the next stage when an artifact appears
+	runStage   func(stage workflow.Stage, specName string) error
+}
+
+// handle validates a changed artifact and, with --auto, starts the next stage
+// when the artifact is new and valid.
+func (s *watchSession) handle(ev watch.Event) {
+	if s.specFilter != "" && ev.SpecName != s.specFilter {
+		return
+	}
+	if !s.validate(ev) || !s.auto || !ev.Created {
+		return
+	}
+	next, ok := watchNextStages[ev.Type]
+	if !ok {
+		return
+	}
+
+	fmt.Fprintf(s.out, "→ %s/%s.yaml appeared, running %s\n", ev.SpecName, ev.Type, next)
+	if err := s.runStage(next, ev.SpecName); err != nil {
+		fmt.Fprintf(s.out, "%s %s failed for %s: %v\n", color.RedString("✗"), next, ev.SpecName, err)
+		return
+	}
+	fmt.Fprintf(s.out, "%s %s completed for %s\n", color.GreenString("✓"), next, ev.SpecName)
+}
+
+// validate prints a one-line result for the artifact, followed by any errors.
+// Returns true if the artifact is valid.
+func (s *watchSession) validate(ev watch.Event) bool {
+	validator, err := validation.NewArtifactValidator(ev.Type)
+	if err != nil {
+		fmt.Fprintf(s.out, "Warning: %v\n", err)
+		return false
+	}
+	result := validator.Validate(ev.Path)
+	if result.Valid {
+		fmt.Fprintf(s.out, "%s %s is valid\n", color.GreenString("✓"), ev.Path)
+		return true
+	}
+
+	fmt.Fprintf(s.out, "%s %s has %d error(s)\n", color.RedString("✗"), ev.Path, len(result.Errors))
+	for _, vErr := range result.Errors {
+		fmt.Fprintf(s.out, "  %s\n", vErr.Error())
+	}
+	return false
+}
+
+// stageRunner returns a function that runs plan or tasks for a spec with the
+// same lifecycle, history and notification handling as the stage commands.
+func stageRunner(cmd *cobra.Command, cfg *config.Configuration) func(workflow.Stage, string) error {
+	notifHandler := notify.NewHandler(cfg.Notifications)
+	historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+	historyLogger.Shared = cfg.SharedHistory()
+
+	return func(stage workflow.Stage, specName string) error {
+		if check := workflow.CheckConstitutionAt(cfg.Constitution); !check.Exists {
+			return fmt.Errorf("constitution required")
+		}
+		return lifecycle.RunWithHistoryContext(cmd.Context(), notifHandler, historyLogger, string(stage), specName, func(_ context.Context) error {
+			orch := workflow.NewWorkflowOrchestrator(cfg)
+			orch.Executor.NotificationHandler = notifHandler
+			orch.Executor.UsageRecorder = historyLogger
+			shared.ApplyOutputStyle(cmd, orch)
+
+			if stage == workflow.StageTasks {
+				return orch.ExecuteTasks(specName, "")
+			}
+			return orch.ExecutePlan(specName, "")
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Package cli tests the watch command which re-validates artifacts on change.
+// Related: internal/cli/watch.go, internal/watch/watch.go
+// Tags: cli, watch, validation, auto-trigger
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/ariel-frischer/autospec/internal/watch"
+	"github.com/ariel-frischer/autospec/internal/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchCmdRegistration(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Use == "watch [spec-name]" {
+			found = true
+			break
+		}
+	}
+	assert.True(t, found, "watch command should be registered")
+
+	for _, flag := range []string{"auto", "debounce"} {
+		assert.NotNil(t, watchCmd.Flags().Lookup(flag), "watch should have --%s", flag)
+	}
+}
+
+// copyTestdata copies a validation fixture into dir under name.
+func copyTestdata(t *testing.T, fixture, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "validation", "testdata", fixture))
+	require.NoError(t, err)
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	return path
+}
+
+func TestWatchSession_Handle(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	validSpec := copyTestdata(t, "spec/valid.yaml", dir, "spec.yaml")
+	invalidSpec := copyTestdata(t, "spec/missing_feature.yaml", dir, "invalid-spec.yaml")
+	validTasks := copyTestdata(t, "tasks/valid.yaml", dir, "tasks.yaml")
+
+	tests := map[string]struct {
+		auto         bool
+		specFilter   string
+		runErr       error
+		event        watch.Event
+		wantRuns     []string
+		wantContains []string
+	}{
+		"valid artifact is reported": {
+			event:        watch.Event{Path: validSpec, SpecName: "001-auth", Type: validation.ArtifactTypeSpec, Created: true},
+			wantContains: []string{"✓", "is valid"},
+		},
+		"invalid artifact lists errors": {
+			auto:         true,
+			event:        watch.Event{Path: invalidSpec, SpecName: "001-auth", Type: validation.ArtifactTypeSpec, Created: true},
+			wantContains: []string{"error(s)", "feature"},
+		},
+		"auto runs plan when spec appears": {
+			auto:         true,
+			event:        watch.Event{Path: validSpec, SpecName: "001-auth", Type: validation.ArtifactTypeSpec, Created: true},
+			wantRuns:     []string{"plan 001-auth"},
+			wantContains: []string{"running plan", "plan completed for 001-auth"},
+		},
+		"auto ignores edits to existing artifacts": {
+			auto:  true,
+			event: watch.Event{Path: validSpec, SpecName: "001-auth", Type: validation.ArtifactTypeSpec},
+		},
+		"auto never triggers implement": {
+			auto:  true,
+			event: watch.Event{Path: validTasks, SpecName: "001-auth", Type: validation.ArtifactTypeTasks, Created: true},
+		},
+		"stage failure is reported": {
+			auto:         true,
+			runErr:       errors.New("agent crashed"),
+			event:        watch.Event{Path: validSpec, SpecName: "001-auth", Type: validation.ArtifactTypeSpec, Created: true},
+			wantRuns:     []string{"plan 001-auth"},
+			wantContains: []string{"plan failed for 001-auth: agent crashed"},
+		},
+		"other specs filtered out": {
+			auto:       true,
+			specFilter: "002-api",
+			event:      watch.Event{Path: validSpec, SpecName: "001-auth", Type: validation.ArtifactTypeSpec, Created: true},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			var runs []string
+			session := &watchSession{
+				out:        &out,
+				auto:       tt.auto,
+				specFilter: tt.specFilter,
+				runStage: func(stage workflow.Stage, specName string) error {
+					runs = append(runs, string(stage)+" "+specName)
+					return tt.runErr
+				},
+			}
+
+			session.handle(tt.event)
+
+			assert.Equal(t, tt.wantRuns, runs)
+			for _, want := range tt.wantContains {
+				assert.Contains(t, out.String(), want)
+			}
+			if tt.specFilter != "" {
+				assert.Empty(t, out.String())
+			}
+		})
+	}
+}
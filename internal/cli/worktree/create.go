@@ -49,7 +49,8 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	notifHandler := notify.NewHandler(cfg.Notifications)
-	historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
+	historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+	historyLogger.Shared = cfg.SharedHistory()
 
 	return lifecycle.RunWithHistory(notifHandler, historyLogger, "worktree-create", name, func() error {
 		return executeCreate(cfg, name, branch, customPath)
@@ -52,7 +52,8 @@ func runGenScript(cmd *cobra.Command, _ []string) error {
 	}
 
 	notifHandler := notify.NewHandler(cfg.Notifications)
-	historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
+	historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+	historyLogger.Shared = cfg.SharedHistory()
 
 	return lifecycle.RunWithHistory(notifHandler, historyLogger, "worktree-gen-script", "", func() error {
 		return executeGenScript(cfg, includeEnv)
@@ -46,7 +46,8 @@ func runRemove(cmd *cobra.Command, args []string) error {
 	}
 
 	notifHandler := notify.NewHandler(cfg.Notifications)
-	historyLogger := history.NewWriter(cfg.StateDir, cfg.MaxHistoryEntries)
+	historyLogger := history.NewWriter(cfg.StateDir, cfg.HistoryRetention())
+	historyLogger.Shared = cfg.SharedHistory()
 
 	return lifecycle.RunWithHistory(notifHandler, historyLogger, "worktree-remove", name, func() error {
 		return executeRemove(cfg, name, force)
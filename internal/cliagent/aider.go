@@ -0,0 +1,37 @@
+package cliagent
+
+// Aider implements the Agent interface for Aider CLI.
+// Command: aider --message <prompt> --no-pretty --no-auto-commits [--yes-always]
+// Large prompts are passed as a file instead: aider --message-file <file> ...
+type Aider struct {
+	BaseAgent
+}
+
+// NewAider creates a new Aider agent.
+// Aider picks its model from whichever provider key is set (or AIDER_MODEL),
+// so no single environment variable is required.
+func NewAider() *Aider {
+	return &Aider{
+		BaseAgent: BaseAgent{
+			AgentName:   "aider",
+			Cmd:         "aider",
+			VersionFlag: "--version",
+			AgentCaps: Caps{
+				Automatable: true,
+				PromptDelivery: PromptDelivery{
+					Method:   PromptMethodArg,
+					Flag:     "--message",
+					FileFlag: "--message-file",
+				},
+				// --yes-always (formerly --yes) answers every confirmation prompt
+				AutonomousFlag: "--yes-always",
+				ModelFlag:      "--model",
+				RequiredEnv:    []string{},
+				OptionalEnv:    []string{"ANTHROPIC_API_KEY", "OPENAI_API_KEY", "GEMINI_API_KEY", "AIDER_MODEL"},
+				// --no-pretty keeps output parseable when captured.
+				// --no-auto-commits leaves committing to autospec's auto_commit setting.
+				DefaultArgs: []string{"--no-pretty", "--no-auto-commits"},
+			},
+		},
+	}
+}
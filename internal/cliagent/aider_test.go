@@ -0,0 +1,71 @@
+package cliagent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestAider_ExecOptions verifies WorkDir, Env, and Timeout are applied to aider runs.
+func TestAider_ExecOptions(t *testing.T) {
+	binDir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"if [ \"$AIDER_TEST_SLEEP\" = \"1\" ]; then exec sleep 5; fi\n" +
+		"pwd\n" +
+		"echo \"model=$AIDER_MODEL\"\n" +
+		"echo \"args=$*\"\n"
+	if err := os.WriteFile(filepath.Join(binDir, "aider"), []byte(script), 0o755); err != nil {
+		t.Fatalf("writing fake aider: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	workDir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("resolving work dir: %v", err)
+	}
+
+	tests := map[string]struct {
+		opts         ExecOptions
+		wantContains []string
+		wantErr      bool
+	}{
+		"workdir and env": {
+			opts: ExecOptions{
+				WorkDir:    workDir,
+				Env:        map[string]string{"AIDER_MODEL": "sonnet"},
+				Autonomous: true,
+			},
+			wantContains: []string{workDir, "model=sonnet", "args=--message implement T001 --no-pretty --no-auto-commits --yes-always"},
+		},
+		"timeout": {
+			opts: ExecOptions{
+				Env:     map[string]string{"AIDER_TEST_SLEEP": "1"},
+				Timeout: 100 * time.Millisecond,
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := NewAider().Execute(context.Background(), "implement T001", tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Execute() expected timeout error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(result.Stdout, want) {
+					t.Errorf("stdout %q should contain %q", result.Stdout, want)
+				}
+			}
+		})
+	}
+}
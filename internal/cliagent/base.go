@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
@@ -53,8 +55,8 @@ func (b *BaseAgent) Version() (string, error) {
 
 // Validate checks if the CLI is in PATH and required environment variables are set.
 func (b *BaseAgent) Validate() error {
-	if _, err := exec.LookPath(b.Cmd); err != nil {
-		return fmt.Errorf("%s: CLI %q not found in PATH (install it or check your PATH)", b.AgentName, b.Cmd)
+	if err := b.validateInstalled(); err != nil {
+		return err
 	}
 	for _, envVar := range b.AgentCaps.RequiredEnv {
 		if os.Getenv(envVar) == "" {
@@ -64,20 +66,49 @@ func (b *BaseAgent) Validate() error {
 	return nil
 }
 
-// BuildCommand constructs an exec.Cmd based on the agent's PromptDelivery method.
+// validateInstalled checks that the agent's CLI command is in PATH.
+func (b *BaseAgent) validateInstalled() error {
+	if _, err := exec.LookPath(b.Cmd); err != nil {
+		return fmt.Errorf("%s: CLI %q not found in PATH (install it or check your PATH)", b.AgentName, b.Cmd)
+	}
+	return nil
+}
+
+// BuildCommand constructs an exec.Cmd based on the agent's PromptDelivery method,
+// piping prompts over MaxArgLen on stdin when the agent supports it.
+// BuildCommand never writes files: prompt-file delivery happens only in Execute,
+// which owns the file's lifetime.
 func (b *BaseAgent) BuildCommand(prompt string, opts ExecOptions) (*exec.Cmd, error) {
-	args := b.buildArgs(prompt, opts)
+	delivery := b.AgentCaps.PromptDelivery.Negotiate(len(prompt), opts.Interactive)
+	if delivery.ViaFile {
+		delivery = Delivery{PromptDelivery: b.AgentCaps.PromptDelivery}
+	}
+	return b.sandboxed(b.buildCommand(prompt, opts, delivery.PromptDelivery), opts)
+}
+
+// sandboxed wraps cmd to run in opts.Sandbox, if set, forwarding the agent's
+// environment variables into the container.
+func (b *BaseAgent) sandboxed(cmd *exec.Cmd, opts ExecOptions) (*exec.Cmd, error) {
+	return opts.Sandbox.wrap(cmd, slices.Concat(b.AgentCaps.RequiredEnv, b.AgentCaps.OptionalEnv), opts.Interactive)
+}
+
+// buildCommand constructs an exec.Cmd for a negotiated prompt delivery.
+func (b *BaseAgent) buildCommand(prompt string, opts ExecOptions, pd PromptDelivery) *exec.Cmd {
+	args := b.buildArgs(prompt, opts, pd)
 	cmd := exec.Command(b.Cmd, args...)
 	b.configureCmd(cmd, opts)
-	return cmd, nil
+	// Stdin delivery: the prompt is piped rather than passed as an argument
+	if !opts.Interactive && pd.Method == PromptMethodStdin {
+		cmd.Stdin = strings.NewReader(prompt)
+	}
+	return cmd
 }
 
 // buildArgs constructs the command arguments based on prompt delivery method.
 // For interactive mode, uses positional argument instead of -p flag to enable
 // multi-turn conversation in Claude Code.
-func (b *BaseAgent) buildArgs(prompt string, opts ExecOptions) []string {
+func (b *BaseAgent) buildArgs(prompt string, opts ExecOptions, pd PromptDelivery) []string {
 	var args []string
-	pd := b.AgentCaps.PromptDelivery
 
 	// Interactive mode: use positional argument (enables multi-turn conversation)
 	// Automated mode: use configured prompt delivery method (e.g., -p flag)
@@ -93,6 +124,15 @@ func (b *BaseAgent) buildArgs(prompt string, opts ExecOptions) []string {
 			args = append(args, pd.Flag, prompt)
 		case PromptMethodSubcommandArg:
 			args = append(args, pd.Flag, pd.PromptFlag, prompt)
+		case PromptMethodStdin:
+			// Prompt is written to stdin by buildCommand
+			args = append(args, pd.StdinArgs...)
+		case PromptMethodFile:
+			// Prompt is the path of the prompt file; Flag is the subcommand, if any
+			if pd.Flag != "" {
+				args = append(args, pd.Flag)
+			}
+			args = append(args, pd.FileFlag, prompt)
 		}
 		// Add default args (e.g., --verbose --output-format stream-json for Claude)
 		// Only in automated mode - interactive mode omits these for conversation
@@ -100,6 +140,10 @@ func (b *BaseAgent) buildArgs(prompt string, opts ExecOptions) []string {
 	}
 
 	args = b.appendAutonomousArgs(args, opts)
+	args = b.appendSubAgentArgs(args, opts)
+	args = b.appendModelArgs(args, opts)
+	args = b.appendResumeArgs(args, opts)
+	args = b.appendPermissionArgs(args, opts)
 	args = append(args, opts.ExtraArgs...)
 	return args
 }
@@ -115,6 +159,33 @@ func (b *BaseAgent) appendAutonomousArgs(args []string, opts ExecOptions) []stri
 	return args
 }
 
+// appendSubAgentArgs adds the sub-agent selection flag if one was requested
+// and the agent supports it.
+func (b *BaseAgent) appendSubAgentArgs(args []string, opts ExecOptions) []string {
+	if opts.SubAgent == "" || b.AgentCaps.SubAgentFlag == "" {
+		return args
+	}
+	return append(args, b.AgentCaps.SubAgentFlag, opts.SubAgent)
+}
+
+// appendModelArgs adds the model selection flag if a model was requested and
+// the agent supports it.
+func (b *BaseAgent) appendModelArgs(args []string, opts ExecOptions) []string {
+	if opts.Model == "" || b.AgentCaps.ModelFlag == "" {
+		return args
+	}
+	return append(args, b.AgentCaps.ModelFlag, opts.Model)
+}
+
+// appendResumeArgs adds the session resume flag if a session was requested
+// and the agent supports it.
+func (b *BaseAgent) appendResumeArgs(args []string, opts ExecOptions) []string {
+	if opts.ResumeSession == "" || b.AgentCaps.ResumeFlag == "" {
+		return args
+	}
+	return append(args, b.AgentCaps.ResumeFlag, opts.ResumeSession)
+}
+
 // configureCmd sets working directory and environment on the command.
 func (b *BaseAgent) configureCmd(cmd *exec.Cmd, opts ExecOptions) {
 	if opts.WorkDir != "" {
@@ -148,12 +219,23 @@ func (b *BaseAgent) buildEnv(opts ExecOptions) []string {
 }
 
 // Execute builds and runs the command, returning the result.
+// Prompts too large for a command-line argument are delivered as negotiated
+// by PromptDelivery.Negotiate; a prompt file is removed once the agent exits.
 func (b *BaseAgent) Execute(ctx context.Context, prompt string, opts ExecOptions) (*Result, error) {
-	cmd, err := b.BuildCommand(prompt, opts)
+	delivery := b.AgentCaps.PromptDelivery.Negotiate(len(prompt), opts.Interactive)
+	if delivery.ViaFile {
+		arg, cleanup, err := writePromptFile(prompt, opts.WorkDir, delivery.Method)
+		if err != nil {
+			return nil, fmt.Errorf("building command: %w", err)
+		}
+		defer cleanup()
+		prompt = arg
+	}
+
+	cmd, err := b.sandboxed(b.buildCommand(prompt, opts, delivery.PromptDelivery), opts)
 	if err != nil {
 		return nil, fmt.Errorf("building command: %w", err)
 	}
-
 	return b.runCommand(ctx, cmd, opts)
 }
 
@@ -177,14 +259,18 @@ func (b *BaseAgent) runCommand(ctx context.Context, cmd *exec.Cmd, opts ExecOpti
 	defer cancel()
 
 	var stdoutBuf, stderrBuf bytes.Buffer
-	cmd.Stdout = opts.Stdout
-	if cmd.Stdout == nil {
-		cmd.Stdout = &stdoutBuf
+	var stdout io.Writer = &stdoutBuf
+	if opts.Stdout != nil {
+		stdout = opts.Stdout
 	}
-	cmd.Stderr = opts.Stderr
-	if cmd.Stderr == nil {
-		cmd.Stderr = &stderrBuf
+	var tail tailBuffer
+	usage := newUsageWriter(io.MultiWriter(stdout, &tail))
+	cmd.Stdout = usage
+	var stderr io.Writer = &stderrBuf
+	if opts.Stderr != nil {
+		stderr = opts.Stderr
 	}
+	cmd.Stderr = io.MultiWriter(stderr, &tail)
 
 	if err := cmd.Start(); err != nil {
 		return nil, fmt.Errorf("starting %s: %w", b.AgentName, err)
@@ -199,22 +285,24 @@ func (b *BaseAgent) runCommand(ctx context.Context, cmd *exec.Cmd, opts ExecOpti
 	var err error
 	select {
 	case <-ctx.Done():
-		_ = cmd.Process.Kill()
-		<-done // Wait for goroutine to exit
+		terminate(cmd, done, terminateGracePeriod)
 		return nil, fmt.Errorf("executing %s: %w", b.AgentName, ctx.Err())
 	case err = <-done:
 	}
 	duration := time.Since(start)
 
 	result := &Result{
-		Duration: duration,
-		Stdout:   stdoutBuf.String(),
-		Stderr:   stderrBuf.String(),
+		Duration:  duration,
+		Stdout:    stdoutBuf.String(),
+		Stderr:    stderrBuf.String(),
+		Usage:     usage.Usage(),
+		SessionID: usage.SessionID(),
 	}
 
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			result.ExitCode = exitErr.ExitCode()
+			result.classifyFailure(tail.String(), time.Now())
 		} else {
 			return nil, fmt.Errorf("executing %s: %w", b.AgentName, err)
 		}
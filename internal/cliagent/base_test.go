@@ -128,6 +128,20 @@ func TestBaseAgent_BuildCommand(t *testing.T) {
 			opts:     ExecOptions{},
 			wantArgs: []string{"fix bug"},
 		},
+		"stdin method": {
+			agent: &BaseAgent{
+				Cmd: "agent",
+				AgentCaps: Caps{
+					PromptDelivery: PromptDelivery{
+						Method: PromptMethodStdin,
+					},
+					AutonomousFlag: "--yolo",
+				},
+			},
+			prompt:   "fix bug",
+			opts:     ExecOptions{Autonomous: true},
+			wantArgs: []string{"--yolo"},
+		},
 		"subcommand method": {
 			agent: &BaseAgent{
 				Cmd: "agent",
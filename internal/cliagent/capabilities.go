@@ -20,6 +20,16 @@ const (
 	// Example: goose run -t "fix the bug"
 	PromptMethodSubcommandArg PromptMethod = "subcommand-arg"
 
+	// PromptMethodStdin pipes the prompt to the agent's standard input.
+	// Avoids OS argument length limits for large prompts.
+	// Example: echo "fix the bug" | gemini
+	PromptMethodStdin PromptMethod = "stdin"
+
+	// PromptMethodFile passes the path of a file holding the prompt via
+	// PromptDelivery.FileFlag. Chosen by Negotiate for oversized prompts.
+	// Example: aider --message-file /tmp/prompt.md
+	PromptMethodFile PromptMethod = "file"
+
 	// PromptMethodTemplate uses {{PROMPT}} placeholder expansion.
 	// Example: aider --message {{PROMPT}}
 	PromptMethodTemplate PromptMethod = "template"
@@ -38,6 +48,24 @@ type PromptDelivery struct {
 	// PromptFlag is the secondary flag for the prompt after the subcommand.
 	// Only used with PromptMethodSubcommandArg (e.g., "-t" for "goose run -t").
 	PromptFlag string
+
+	// MaxArgLen is the largest prompt, in bytes, passed as a command-line
+	// argument (0 = DefaultMaxArgLen). Larger prompts are delivered by
+	// Negotiate on stdin, through FileFlag, or as a prompt file the agent is
+	// told to read, whichever the agent supports first.
+	MaxArgLen int
+
+	// Stdin reports whether the agent reads the prompt from standard input
+	// when it is not passed as an argument.
+	Stdin bool
+
+	// StdinArgs replace the prompt arguments when the prompt is piped on stdin
+	// (e.g., ["-p"] for "claude -p", ["exec", "-"] for "codex exec -").
+	StdinArgs []string
+
+	// FileFlag is the flag that reads the prompt from a file
+	// (e.g., "--message-file" for aider). Empty if unsupported.
+	FileFlag string
 }
 
 // Caps contains self-describing feature flags for agent discovery and automation.
@@ -69,4 +97,26 @@ type Caps struct {
 	// Added after prompt delivery args but before AutonomousFlag and ExtraArgs.
 	// Example: ["--verbose", "--output-format", "stream-json"]
 	DefaultArgs []string
+
+	// SubAgentFlag is the CLI flag that selects a named agent defined inside the
+	// agent CLI itself (e.g., "--agent" for opencode). Empty if unsupported,
+	// in which case ExecOptions.SubAgent is ignored.
+	SubAgentFlag string
+
+	// ModelFlag is the CLI flag that selects the model (e.g., "--model" for
+	// claude and codex). Empty if unsupported, in which case ExecOptions.Model
+	// is ignored.
+	ModelFlag string
+
+	// ResumeFlag is the CLI flag that continues an earlier session by ID
+	// (e.g., "--resume" for claude). Empty if unsupported, in which case
+	// ExecOptions.ResumeSession is ignored and every run starts fresh.
+	ResumeFlag string
+
+	// AllowToolsFlag and DenyToolsFlag pass a ToolPolicy's rules, in Claude
+	// Code's permission-rule syntax (e.g., "--allowedTools" and
+	// "--disallowedTools" for claude). Empty if unsupported, in which case
+	// ExecOptions.ToolPolicy is ignored.
+	AllowToolsFlag string
+	DenyToolsFlag  string
 }
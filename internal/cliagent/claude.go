@@ -8,6 +8,7 @@ import (
 
 // Claude implements the Agent interface for Claude Code CLI.
 // Command: claude -p <prompt> [--dangerously-skip-permissions]
+// Large prompts are piped on stdin instead: echo <prompt> | claude -p
 type Claude struct {
 	BaseAgent
 }
@@ -26,8 +27,15 @@ func NewClaude() *Claude {
 				PromptDelivery: PromptDelivery{
 					Method: PromptMethodArg,
 					Flag:   "-p",
+					// claude -p reads the prompt from stdin when none is given
+					Stdin:     true,
+					StdinArgs: []string{"-p"},
 				},
 				AutonomousFlag: "--dangerously-skip-permissions",
+				ModelFlag:      "--model",
+				ResumeFlag:     "--resume",
+				AllowToolsFlag: "--allowedTools",
+				DenyToolsFlag:  "--disallowedTools",
 				RequiredEnv:    []string{}, // No required env - works with subscription or API
 				OptionalEnv:    []string{"ANTHROPIC_API_KEY", "CLAUDE_MODEL"},
 				// DefaultArgs enables stream-json output for better terminal parsing.
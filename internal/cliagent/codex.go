@@ -1,12 +1,22 @@
 package cliagent
 
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
 // Codex implements the Agent interface for OpenAI Codex CLI.
-// Command: codex exec <prompt>
+// Command: codex exec <prompt> [--full-auto]
+// Large prompts are piped on stdin instead: echo <prompt> | codex exec - [--full-auto]
+// Auth: OPENAI_API_KEY or a ChatGPT login stored in $CODEX_HOME/auth.json (default ~/.codex)
 type Codex struct {
 	BaseAgent
 }
 
 // NewCodex creates a new Codex CLI agent.
+// Note: OPENAI_API_KEY is optional - Codex also works with a ChatGPT login created by 'codex login'.
 func NewCodex() *Codex {
 	return &Codex{
 		BaseAgent: BaseAgent{
@@ -18,12 +28,78 @@ func NewCodex() *Codex {
 				PromptDelivery: PromptDelivery{
 					Method: PromptMethodSubcommand,
 					Flag:   "exec",
+					// "-" makes exec read the prompt from stdin
+					Stdin:     true,
+					StdinArgs: []string{"exec", "-"},
 				},
-				// exec mode is inherently autonomous, no extra flag needed
-				AutonomousFlag: "",
-				RequiredEnv:    []string{"OPENAI_API_KEY"},
-				OptionalEnv:    []string{},
+				// exec defaults to a read-only sandbox; --full-auto allows workspace writes
+				// without approval prompts, which implement needs to edit files headlessly.
+				AutonomousFlag: "--full-auto",
+				ModelFlag:      "--model",
+				RequiredEnv:    []string{}, // No required env - works with API key or ChatGPT login
+				OptionalEnv:    []string{"OPENAI_API_KEY", "CODEX_HOME"},
 			},
 		},
 	}
 }
+
+// Version returns the installed Codex CLI version.
+// Codex prints "codex-cli 0.46.0"; only the version number is returned.
+func (c *Codex) Version() (string, error) {
+	raw, err := c.BaseAgent.Version()
+	if err != nil {
+		return "", fmt.Errorf("getting codex version: %w", err)
+	}
+	return parseCodexVersion(raw), nil
+}
+
+// Validate checks that the codex CLI is in PATH and that some form of
+// authentication is available (OPENAI_API_KEY or a stored ChatGPT login).
+func (c *Codex) Validate() error {
+	if err := c.validateInstalled(); err != nil {
+		return err
+	}
+	if os.Getenv("OPENAI_API_KEY") == "" && !codexLoggedIn() {
+		return fmt.Errorf("%s: not authenticated (set OPENAI_API_KEY or run 'codex login')", c.AgentName)
+	}
+	return nil
+}
+
+// parseCodexVersion extracts the version number from codex --version output.
+// Returns the trimmed input unchanged if it does not match the expected format.
+func parseCodexVersion(raw string) string {
+	raw = strings.TrimSpace(raw)
+	fields := strings.Fields(raw)
+	if len(fields) == 0 {
+		return raw
+	}
+	last := fields[len(fields)-1]
+	if last[0] >= '0' && last[0] <= '9' {
+		return last
+	}
+	return raw
+}
+
+// codexLoggedIn reports whether a Codex auth file exists.
+// The file is written by 'codex login' and holds either a ChatGPT token or an API key.
+func codexLoggedIn() bool {
+	path := codexAuthPath()
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// codexAuthPath returns the Codex auth file location, honoring CODEX_HOME.
+// Returns empty string if the home directory cannot be determined.
+func codexAuthPath() string {
+	if codexHome := os.Getenv("CODEX_HOME"); codexHome != "" {
+		return filepath.Join(codexHome, "auth.json")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".codex", "auth.json")
+}
@@ -0,0 +1,108 @@
+package cliagent
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseCodexVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		raw  string
+		want string
+	}{
+		"codex-cli prefix":  {raw: "codex-cli 0.46.0\n", want: "0.46.0"},
+		"bare version":      {raw: "0.46.0", want: "0.46.0"},
+		"unexpected format": {raw: "codex dev build", want: "codex dev build"},
+		"empty output":      {raw: "", want: ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := parseCodexVersion(tt.raw); got != tt.want {
+				t.Errorf("parseCodexVersion(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodex_Validate(t *testing.T) {
+	// Note: Cannot use t.Parallel() when subtests use t.Setenv
+
+	binDir := t.TempDir()
+	fakeCodex := filepath.Join(binDir, "codex")
+	if err := os.WriteFile(fakeCodex, []byte("#!/bin/sh\necho codex-cli 0.46.0\n"), 0o755); err != nil {
+		t.Fatalf("writing fake codex: %v", err)
+	}
+
+	tests := map[string]struct {
+		path      string
+		apiKey    string
+		writeAuth bool
+		wantErr   string
+	}{
+		"not installed": {
+			path:    t.TempDir(),
+			apiKey:  "sk-test",
+			wantErr: "not found in PATH",
+		},
+		"api key": {
+			path:   binDir,
+			apiKey: "sk-test",
+		},
+		"chatgpt login": {
+			path:      binDir,
+			writeAuth: true,
+		},
+		"no auth": {
+			path:    binDir,
+			wantErr: "not authenticated",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			codexHome := t.TempDir()
+			t.Setenv("PATH", tt.path)
+			t.Setenv("OPENAI_API_KEY", tt.apiKey)
+			t.Setenv("CODEX_HOME", codexHome)
+			if tt.writeAuth {
+				if err := os.WriteFile(filepath.Join(codexHome, "auth.json"), []byte("{}"), 0o600); err != nil {
+					t.Fatalf("writing auth file: %v", err)
+				}
+			}
+
+			err := NewCodex().Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCodex_Version(t *testing.T) {
+	binDir := t.TempDir()
+	fakeCodex := filepath.Join(binDir, "codex")
+	if err := os.WriteFile(fakeCodex, []byte("#!/bin/sh\necho codex-cli 0.46.0\n"), 0o755); err != nil {
+		t.Fatalf("writing fake codex: %v", err)
+	}
+	t.Setenv("PATH", binDir)
+
+	got, err := NewCodex().Version()
+	if err != nil {
+		t.Fatalf("Version() error = %v", err)
+	}
+	if got != "0.46.0" {
+		t.Errorf("Version() = %q, want %q", got, "0.46.0")
+	}
+}
@@ -9,7 +9,7 @@ import (
 func TestAllAgentsRegistered(t *testing.T) {
 	t.Parallel()
 
-	expected := []string{"claude", "cline", "codex", "gemini", "goose", "opencode"}
+	expected := []string{"aider", "claude", "cline", "codex", "gemini", "goose", "ollama", "opencode"}
 	registered := List()
 
 	if len(registered) != len(expected) {
@@ -65,7 +65,7 @@ func TestAgentInterface(t *testing.T) {
 			wantCmd:     "codex",
 			wantMethod:  PromptMethodSubcommand,
 			wantFlag:    "exec",
-			wantAutonom: "",
+			wantAutonom: "--full-auto",
 		},
 		"opencode": {
 			agent:       NewOpenCode(),
@@ -75,6 +75,14 @@ func TestAgentInterface(t *testing.T) {
 			wantFlag:    "run",
 			wantAutonom: "",
 		},
+		"aider": {
+			agent:       NewAider(),
+			wantName:    "aider",
+			wantCmd:     "aider",
+			wantMethod:  PromptMethodArg,
+			wantFlag:    "--message",
+			wantAutonom: "--yes-always",
+		},
 		"goose": {
 			agent:       NewGoose(),
 			wantName:    "goose",
@@ -163,11 +171,11 @@ func TestBuildCommand(t *testing.T) {
 			opts:     ExecOptions{},
 			wantArgs: []string{"exec", "fix tests"},
 		},
-		"codex autonomous (no extra flag)": {
+		"codex autonomous": {
 			agent:    NewCodex(),
 			prompt:   "fix tests",
 			opts:     ExecOptions{Autonomous: true},
-			wantArgs: []string{"exec", "fix tests"},
+			wantArgs: []string{"exec", "fix tests", "--full-auto"},
 		},
 		"opencode basic": {
 			agent:    NewOpenCode(),
@@ -175,6 +183,78 @@ func TestBuildCommand(t *testing.T) {
 			opts:     ExecOptions{},
 			wantArgs: []string{"run", "update deps"},
 		},
+		"opencode sub-agent": {
+			agent:    NewOpenCode(),
+			prompt:   "update deps",
+			opts:     ExecOptions{SubAgent: "build"},
+			wantArgs: []string{"run", "update deps", "--agent", "build"},
+		},
+		"codex ignores sub-agent": {
+			agent:    NewCodex(),
+			prompt:   "fix tests",
+			opts:     ExecOptions{SubAgent: "build"},
+			wantArgs: []string{"exec", "fix tests"},
+		},
+		"claude model": {
+			agent:    NewClaude(),
+			prompt:   "fix the bug",
+			opts:     ExecOptions{Model: "opus"},
+			wantArgs: []string{"-p", "fix the bug", "--verbose", "--output-format", "stream-json", "--model", "opus"},
+		},
+		"codex model": {
+			agent:    NewCodex(),
+			prompt:   "fix tests",
+			opts:     ExecOptions{Model: "gpt-5-codex", Autonomous: true},
+			wantArgs: []string{"exec", "fix tests", "--full-auto", "--model", "gpt-5-codex"},
+		},
+		"claude resume": {
+			agent:    NewClaude(),
+			prompt:   "fix the bug",
+			opts:     ExecOptions{ResumeSession: "abc-123"},
+			wantArgs: []string{"-p", "fix the bug", "--verbose", "--output-format", "stream-json", "--resume", "abc-123"},
+		},
+		"claude tool policy": {
+			agent:  NewClaude(),
+			prompt: "fix the bug",
+			opts: ExecOptions{ToolPolicy: &ToolPolicy{
+				Allow: ToolRules{Commands: []string{"go test"}},
+				Deny:  ToolRules{Network: []string{"*"}},
+			}},
+			wantArgs: []string{
+				"-p", "fix the bug", "--verbose", "--output-format", "stream-json",
+				"--allowedTools", "Bash(go test:*)", "--disallowedTools", "WebFetch", "WebSearch",
+			},
+		},
+		"codex ignores tool policy": {
+			agent:    NewCodex(),
+			prompt:   "fix tests",
+			opts:     ExecOptions{ToolPolicy: &ToolPolicy{Deny: ToolRules{Commands: []string{"*"}}}},
+			wantArgs: []string{"exec", "fix tests"},
+		},
+		"codex ignores resume": {
+			agent:    NewCodex(),
+			prompt:   "fix tests",
+			opts:     ExecOptions{ResumeSession: "abc-123"},
+			wantArgs: []string{"exec", "fix tests"},
+		},
+		"goose ignores model": {
+			agent:    NewGoose(),
+			prompt:   "add feature",
+			opts:     ExecOptions{Model: "gpt-4o"},
+			wantArgs: []string{"run", "-t", "add feature"},
+		},
+		"aider basic": {
+			agent:    NewAider(),
+			prompt:   "add tests",
+			opts:     ExecOptions{},
+			wantArgs: []string{"--message", "add tests", "--no-pretty", "--no-auto-commits"},
+		},
+		"aider autonomous": {
+			agent:    NewAider(),
+			prompt:   "add tests",
+			opts:     ExecOptions{Autonomous: true},
+			wantArgs: []string{"--message", "add tests", "--no-pretty", "--no-auto-commits", "--yes-always"},
+		},
 		"goose basic": {
 			agent:    NewGoose(),
 			prompt:   "add feature",
@@ -265,27 +345,51 @@ func TestClaudeRequiredEnv(t *testing.T) {
 	}
 }
 
-// TestGeminiRequiredEnv verifies Gemini requires GEMINI_API_KEY.
+// TestGeminiRequiredEnv verifies Gemini has no required env vars.
+// Gemini works with either an API key or gcloud ADC, so GEMINI_API_KEY is optional.
 func TestGeminiRequiredEnv(t *testing.T) {
 	t.Parallel()
 
 	agent := NewGemini()
 	caps := agent.Capabilities()
 
-	if len(caps.RequiredEnv) != 1 || caps.RequiredEnv[0] != "GEMINI_API_KEY" {
-		t.Errorf("Gemini RequiredEnv = %v, want [GEMINI_API_KEY]", caps.RequiredEnv)
+	if len(caps.RequiredEnv) != 0 {
+		t.Errorf("Gemini RequiredEnv = %v, want [] (empty - no required env vars)", caps.RequiredEnv)
+	}
+
+	hasAPIKey := false
+	for _, env := range caps.OptionalEnv {
+		if env == "GEMINI_API_KEY" {
+			hasAPIKey = true
+			break
+		}
+	}
+	if !hasAPIKey {
+		t.Errorf("Gemini OptionalEnv = %v, want to contain GEMINI_API_KEY", caps.OptionalEnv)
 	}
 }
 
-// TestCodexRequiredEnv verifies Codex requires OPENAI_API_KEY.
+// TestCodexRequiredEnv verifies Codex has no required env vars.
+// Codex works with either a ChatGPT login or an API key, so OPENAI_API_KEY is optional.
 func TestCodexRequiredEnv(t *testing.T) {
 	t.Parallel()
 
 	agent := NewCodex()
 	caps := agent.Capabilities()
 
-	if len(caps.RequiredEnv) != 1 || caps.RequiredEnv[0] != "OPENAI_API_KEY" {
-		t.Errorf("Codex RequiredEnv = %v, want [OPENAI_API_KEY]", caps.RequiredEnv)
+	if len(caps.RequiredEnv) != 0 {
+		t.Errorf("Codex RequiredEnv = %v, want [] (empty - no required env vars)", caps.RequiredEnv)
+	}
+
+	hasAPIKey := false
+	for _, env := range caps.OptionalEnv {
+		if env == "OPENAI_API_KEY" {
+			hasAPIKey = true
+			break
+		}
+	}
+	if !hasAPIKey {
+		t.Errorf("Codex OptionalEnv = %v, want to contain OPENAI_API_KEY", caps.OptionalEnv)
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 	"time"
 )
@@ -140,7 +141,7 @@ func (c *CustomAgent) BuildCommand(prompt string, opts ExecOptions) (*exec.Cmd,
 	}
 
 	c.configureCmd(cmd, opts)
-	return cmd, nil
+	return opts.Sandbox.wrap(cmd, slices.Concat(c.caps.RequiredEnv, c.caps.OptionalEnv), opts.Interactive)
 }
 
 // buildShellCommand constructs a shell command string with proper escaping.
@@ -194,7 +195,17 @@ func (c *CustomAgent) configureCmd(cmd *exec.Cmd, opts ExecOptions) {
 }
 
 // Execute builds and runs the command, returning the result.
+// A prompt too large for a command-line argument is saved to a file and
+// replaced by an instruction to read it, since {{PROMPT}} can only expand inline.
 func (c *CustomAgent) Execute(ctx context.Context, prompt string, opts ExecOptions) (*Result, error) {
+	if delivery := c.caps.PromptDelivery.Negotiate(len(prompt), opts.Interactive); delivery.ViaFile {
+		ref, cleanup, err := writePromptFile(prompt, opts.WorkDir, delivery.Method)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		prompt = ref
+	}
 	cmd, err := c.BuildCommand(prompt, opts)
 	if err != nil {
 		return nil, err
@@ -217,8 +228,9 @@ func (c *CustomAgent) runCommand(ctx context.Context, cmd *exec.Cmd, opts ExecOp
 	if opts.Stderr != nil {
 		stderr = io.MultiWriter(opts.Stderr, &stderrBuf)
 	}
-	cmd.Stdout = stdout
-	cmd.Stderr = stderr
+	var tail tailBuffer
+	cmd.Stdout = io.MultiWriter(stdout, &tail)
+	cmd.Stderr = io.MultiWriter(stderr, &tail)
 
 	if err := cmd.Start(); err != nil {
 		return nil, fmt.Errorf("starting custom agent: %w", err)
@@ -233,8 +245,7 @@ func (c *CustomAgent) runCommand(ctx context.Context, cmd *exec.Cmd, opts ExecOp
 	var err error
 	select {
 	case <-ctx.Done():
-		_ = cmd.Process.Kill()
-		<-done
+		terminate(cmd, done, terminateGracePeriod)
 		return nil, fmt.Errorf("executing custom agent: %w", ctx.Err())
 	case err = <-done:
 	}
@@ -244,11 +255,13 @@ func (c *CustomAgent) runCommand(ctx context.Context, cmd *exec.Cmd, opts ExecOp
 		Duration: duration,
 		Stdout:   stdoutBuf.String(),
 		Stderr:   stderrBuf.String(),
+		Usage:    ParseUsage(stdoutBuf.String()),
 	}
 
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			result.ExitCode = exitErr.ExitCode()
+			result.classifyFailure(tail.String(), time.Now())
 		} else {
 			return nil, fmt.Errorf("executing custom agent: %w", err)
 		}
@@ -0,0 +1,92 @@
+package cliagent
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultMaxArgLen is the prompt size, in bytes, above which prompts are not
+// passed as a command-line argument. Linux rejects any single argument over
+// 128 KiB (MAX_ARG_STRLEN) with E2BIG, whatever the total ARG_MAX is.
+const DefaultMaxArgLen = 100 * 1024
+
+// promptFileDir is where oversized prompts are written, relative to the
+// agent's working directory. Inside the project, agents can read it without
+// extra sandbox permissions.
+const promptFileDir = ".autospec/tmp"
+
+// Delivery is the negotiated way to pass one prompt to an agent.
+type Delivery struct {
+	PromptDelivery
+
+	// ViaFile is true when the prompt is written to a file first. With
+	// PromptMethodFile the file's path is passed via FileFlag; with any other
+	// method the agent receives a short prompt telling it to read the file.
+	ViaFile bool
+}
+
+// Negotiate picks how to deliver a prompt of promptLen bytes. Prompts within
+// MaxArgLen use the agent's normal method. Larger ones go, in order of
+// preference, on stdin, through FileFlag, or into a file the agent is told to
+// read. Interactive sessions keep stdin for the user, so only the last applies.
+func (pd PromptDelivery) Negotiate(promptLen int, interactive bool) Delivery {
+	limit := pd.MaxArgLen
+	if limit <= 0 {
+		limit = DefaultMaxArgLen
+	}
+	if promptLen <= limit || pd.Method == PromptMethodStdin {
+		return Delivery{PromptDelivery: pd}
+	}
+	if interactive {
+		return Delivery{PromptDelivery: pd, ViaFile: true}
+	}
+
+	switch {
+	case pd.Stdin:
+		negotiated := pd
+		negotiated.Method = PromptMethodStdin
+		return Delivery{PromptDelivery: negotiated}
+	case pd.FileFlag != "":
+		negotiated := pd
+		negotiated.Method = PromptMethodFile
+		// Keep the subcommand (e.g., "goose run -i <file>"); drop prompt flags
+		if pd.Method != PromptMethodSubcommand && pd.Method != PromptMethodSubcommandArg {
+			negotiated.Flag = ""
+		}
+		return Delivery{PromptDelivery: negotiated, ViaFile: true}
+	default:
+		return Delivery{PromptDelivery: pd, ViaFile: true}
+	}
+}
+
+// writePromptFile saves prompt under promptFileDir in workDir and returns the
+// argument to pass instead of the prompt: the file's path for
+// PromptMethodFile, otherwise a short prompt referring to the file. The
+// returned cleanup removes the file.
+func writePromptFile(prompt, workDir string, method PromptMethod) (string, func(), error) {
+	dir := filepath.Join(workDir, promptFileDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", nil, fmt.Errorf("creating prompt file directory: %w", err)
+	}
+	f, err := os.CreateTemp(dir, "prompt-*.md")
+	if err != nil {
+		return "", nil, fmt.Errorf("creating prompt file: %w", err)
+	}
+	cleanup := func() { os.Remove(f.Name()) }
+	_, err = f.WriteString(prompt)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("writing prompt file: %w", err)
+	}
+
+	if method == PromptMethodFile {
+		return f.Name(), cleanup, nil
+	}
+	rel := filepath.Join(promptFileDir, filepath.Base(f.Name()))
+	return fmt.Sprintf("Your instructions are too long for the command line and were saved to %s. "+
+		"Read that file in full and follow its instructions exactly.", rel), cleanup, nil
+}
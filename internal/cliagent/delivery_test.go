@@ -0,0 +1,164 @@
+package cliagent
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPromptDelivery_Negotiate(t *testing.T) {
+	t.Parallel()
+
+	claude := NewClaude().Capabilities().PromptDelivery
+	tests := map[string]struct {
+		pd          PromptDelivery
+		promptLen   int
+		interactive bool
+		want        PromptMethod
+		wantFlag    string
+		wantViaFile bool
+	}{
+		"small prompt keeps method": {
+			pd:        claude,
+			promptLen: 1000,
+			want:      PromptMethodArg,
+			wantFlag:  "-p",
+		},
+		"large prompt piped on stdin": {
+			pd:        claude,
+			promptLen: DefaultMaxArgLen + 1,
+			want:      PromptMethodStdin,
+			wantFlag:  "-p",
+		},
+		"agent limit below default": {
+			pd:        NewGemini().Capabilities().PromptDelivery,
+			promptLen: geminiMaxArgPrompt + 1,
+			want:      PromptMethodStdin,
+			wantFlag:  "-p",
+		},
+		"file flag replaces prompt flag": {
+			pd:          NewAider().Capabilities().PromptDelivery,
+			promptLen:   DefaultMaxArgLen + 1,
+			want:        PromptMethodFile,
+			wantViaFile: true,
+		},
+		"file flag keeps subcommand": {
+			pd:          NewGoose().Capabilities().PromptDelivery,
+			promptLen:   DefaultMaxArgLen + 1,
+			want:        PromptMethodFile,
+			wantFlag:    "run",
+			wantViaFile: true,
+		},
+		"no stdin or file flag uses prompt file reference": {
+			pd:          NewCline().Capabilities().PromptDelivery,
+			promptLen:   DefaultMaxArgLen + 1,
+			want:        PromptMethodPositional,
+			wantViaFile: true,
+		},
+		"interactive never uses stdin": {
+			pd:          claude,
+			promptLen:   DefaultMaxArgLen + 1,
+			interactive: true,
+			want:        PromptMethodArg,
+			wantFlag:    "-p",
+			wantViaFile: true,
+		},
+		"stdin method is never limited": {
+			pd:        PromptDelivery{Method: PromptMethodStdin},
+			promptLen: 10 * DefaultMaxArgLen,
+			want:      PromptMethodStdin,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.pd.Negotiate(tt.promptLen, tt.interactive)
+			if got.Method != tt.want || got.Flag != tt.wantFlag || got.ViaFile != tt.wantViaFile {
+				t.Errorf("Negotiate() = {%s %q viaFile=%v}, want {%s %q viaFile=%v}",
+					got.Method, got.Flag, got.ViaFile, tt.want, tt.wantFlag, tt.wantViaFile)
+			}
+		})
+	}
+}
+
+// TestBaseAgent_Execute_LargePromptDelivery runs real commands to check that
+// an oversized prompt reaches the agent by each negotiated route and that any
+// prompt file is removed afterwards.
+func TestBaseAgent_Execute_LargePromptDelivery(t *testing.T) {
+	t.Parallel()
+
+	prompt := strings.Repeat("x", 2048)
+	tests := map[string]struct {
+		cmd        string
+		pd         PromptDelivery
+		wantOutput string
+	}{
+		"stdin": {
+			cmd:        "cat",
+			pd:         PromptDelivery{Method: PromptMethodArg, Flag: "-u", MaxArgLen: 1024, Stdin: true},
+			wantOutput: prompt,
+		},
+		"file flag": {
+			// cat -- <file> prints the prompt file
+			cmd:        "cat",
+			pd:         PromptDelivery{Method: PromptMethodPositional, MaxArgLen: 1024, FileFlag: "--"},
+			wantOutput: prompt,
+		},
+		"prompt file reference": {
+			cmd:        "echo",
+			pd:         PromptDelivery{Method: PromptMethodPositional, MaxArgLen: 1024},
+			wantOutput: "were saved to .autospec/tmp/prompt-",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			workDir := t.TempDir()
+			agent := &BaseAgent{AgentName: "test", Cmd: tt.cmd, AgentCaps: Caps{PromptDelivery: tt.pd}}
+
+			var stdout bytes.Buffer
+			result, err := agent.Execute(context.Background(), prompt, ExecOptions{WorkDir: workDir, Stdout: &stdout})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if result.ExitCode != 0 {
+				t.Fatalf("ExitCode = %d, want 0", result.ExitCode)
+			}
+			if !strings.Contains(stdout.String(), tt.wantOutput) {
+				t.Errorf("output = %.120q, want containing %.120q", stdout.String(), tt.wantOutput)
+			}
+
+			leftover, _ := filepath.Glob(filepath.Join(workDir, promptFileDir, "*"))
+			if len(leftover) != 0 {
+				t.Errorf("prompt files not removed: %v", leftover)
+			}
+		})
+	}
+}
+
+func TestCustomAgent_Execute_LargePrompt(t *testing.T) {
+	t.Parallel()
+
+	agent, err := NewCustomAgentFromConfig(CustomAgentConfig{Command: "echo", Args: []string{"{{PROMPT}}"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	workDir := t.TempDir()
+
+	var stdout bytes.Buffer
+	_, err = agent.Execute(context.Background(), strings.Repeat("x", DefaultMaxArgLen+1), ExecOptions{WorkDir: workDir, Stdout: &stdout})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Read that file in full") {
+		t.Errorf("output = %.120q, want prompt file reference", stdout.String())
+	}
+	if entries, _ := os.ReadDir(filepath.Join(workDir, promptFileDir)); len(entries) != 0 {
+		t.Errorf("prompt file not removed: %v", entries)
+	}
+}
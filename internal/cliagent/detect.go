@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 // AuthType represents the type of Claude authentication.
@@ -33,6 +34,10 @@ type ClaudeAuthStatus struct {
 	SubscriptionType string
 	// APIKeySet indicates if ANTHROPIC_API_KEY env var is set.
 	APIKeySet bool
+	// OAuthExpired indicates the stored OAuth access token is past its expiry.
+	// Claude Code normally refreshes it on the next run; a token that stays
+	// expired usually means the refresh token was revoked and a new login is needed.
+	OAuthExpired bool
 }
 
 // claudeCredentials represents the structure of ~/.claude/.credentials.json.
@@ -44,6 +49,13 @@ type claudeCredentials struct {
 type claudeOAuthData struct {
 	AccessToken      string `json:"accessToken,omitempty"`
 	SubscriptionType string `json:"subscriptionType,omitempty"`
+	// ExpiresAt is the access token expiry in Unix milliseconds (0 if absent).
+	ExpiresAt int64 `json:"expiresAt,omitempty"`
+}
+
+// expired reports whether the access token expiry is known and in the past.
+func (d *claudeOAuthData) expired(now time.Time) bool {
+	return d.ExpiresAt > 0 && now.UnixMilli() >= d.ExpiresAt
 }
 
 // credentialsPathOverride allows tests to override the credentials file location.
@@ -55,7 +67,8 @@ var credentialsPathOverride string
 // environment variables. The detection is read-only with no side effects.
 //
 // For OAuth auth, presence of credentials file with access token is sufficient -
-// Claude Code auto-refreshes tokens, so we don't check expiry.
+// Claude Code auto-refreshes tokens. Expiry is only reported via OAuthExpired
+// so 'autospec doctor' can suggest a fresh login.
 //
 // Note: This reads an undocumented internal file that may change in future
 // Claude Code versions. The function degrades gracefully if the file format changes.
@@ -74,6 +87,7 @@ func DetectClaudeAuth() ClaudeAuthStatus {
 	if oauthData := readOAuthCredentials(); oauthData != nil {
 		status.AuthType = AuthTypeOAuth
 		status.SubscriptionType = oauthData.SubscriptionType
+		status.OAuthExpired = oauthData.expired(time.Now())
 	} else if status.APIKeySet {
 		// Fall back to API key if no OAuth
 		status.AuthType = AuthTypeAPI
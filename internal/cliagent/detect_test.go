@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestClaudeAuthStatus_IsAuthenticated(t *testing.T) {
@@ -271,6 +272,32 @@ func TestReadOAuthCredentials_WithMockFile(t *testing.T) {
 	}
 }
 
+func TestClaudeOAuthData_Expired(t *testing.T) {
+	t.Parallel()
+
+	now := time.UnixMilli(1_700_000_000_000)
+
+	tests := map[string]struct {
+		expiresAt int64
+		want      bool
+	}{
+		"no expiry recorded": {expiresAt: 0, want: false},
+		"in the future":      {expiresAt: now.UnixMilli() + 60_000, want: false},
+		"in the past":        {expiresAt: now.UnixMilli() - 60_000, want: true},
+		"exactly now":        {expiresAt: now.UnixMilli(), want: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			data := &claudeOAuthData{AccessToken: "token", ExpiresAt: tt.expiresAt}
+			if got := data.expired(now); got != tt.want {
+				t.Errorf("expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestReadOAuthCredentials_FileNotExists(t *testing.T) {
 	// Override to non-existent path
 	oldPath := credentialsPathOverride
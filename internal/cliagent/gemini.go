@@ -1,12 +1,25 @@
 package cliagent
 
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// geminiMaxArgPrompt is the prompt size (in bytes) above which Gemini receives
+// the prompt on stdin instead of via -p, staying well under OS argument limits.
+const geminiMaxArgPrompt = 64 * 1024
+
 // Gemini implements the Agent interface for Google Gemini CLI.
 // Command: gemini -p <prompt> [--yolo]
+// Large prompts are piped on stdin instead: echo <prompt> | gemini [--yolo]
+// Auth: GEMINI_API_KEY, GOOGLE_API_KEY, or gcloud Application Default Credentials
 type Gemini struct {
 	BaseAgent
 }
 
 // NewGemini creates a new Gemini CLI agent.
+// Note: GEMINI_API_KEY is optional - Gemini also works with Vertex AI via gcloud ADC.
 func NewGemini() *Gemini {
 	return &Gemini{
 		BaseAgent: BaseAgent{
@@ -16,13 +29,79 @@ func NewGemini() *Gemini {
 			AgentCaps: Caps{
 				Automatable: true,
 				PromptDelivery: PromptDelivery{
-					Method: PromptMethodArg,
-					Flag:   "-p",
+					Method:    PromptMethodArg,
+					Flag:      "-p",
+					MaxArgLen: geminiMaxArgPrompt,
+					Stdin:     true,
 				},
 				AutonomousFlag: "--yolo",
-				RequiredEnv:    []string{"GEMINI_API_KEY"},
-				OptionalEnv:    []string{},
+				ModelFlag:      "--model",
+				RequiredEnv:    []string{}, // No required env - works with API key or gcloud ADC
+				OptionalEnv: []string{
+					"GEMINI_API_KEY",
+					"GOOGLE_API_KEY",
+					"GOOGLE_APPLICATION_CREDENTIALS",
+					"GOOGLE_CLOUD_PROJECT",
+				},
 			},
 		},
 	}
 }
+
+// Validate checks that the gemini CLI is in PATH and that credentials are available.
+func (g *Gemini) Validate() error {
+	if err := g.validateInstalled(); err != nil {
+		return err
+	}
+	if DetectGeminiAuth() == GeminiAuthNone {
+		return fmt.Errorf("%s: not authenticated (set GEMINI_API_KEY or run 'gcloud auth application-default login')", g.AgentName)
+	}
+	return nil
+}
+
+// GeminiAuthType identifies which Gemini credential source was detected.
+type GeminiAuthType string
+
+const (
+	// GeminiAuthAPIKey indicates GEMINI_API_KEY or GOOGLE_API_KEY is set.
+	GeminiAuthAPIKey GeminiAuthType = "api-key"
+	// GeminiAuthADC indicates gcloud Application Default Credentials are available.
+	GeminiAuthADC GeminiAuthType = "adc"
+	// GeminiAuthNone indicates no credentials were detected.
+	GeminiAuthNone GeminiAuthType = "none"
+)
+
+// DetectGeminiAuth detects which Gemini credential source is available.
+// API keys take precedence over ADC, matching the Gemini CLI's own resolution order.
+// The detection is read-only with no side effects.
+func DetectGeminiAuth() GeminiAuthType {
+	if os.Getenv("GEMINI_API_KEY") != "" || os.Getenv("GOOGLE_API_KEY") != "" {
+		return GeminiAuthAPIKey
+	}
+	if path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); path != "" && fileExists(path) {
+		return GeminiAuthADC
+	}
+	if path := gcloudADCPath(); path != "" && fileExists(path) {
+		return GeminiAuthADC
+	}
+	return GeminiAuthNone
+}
+
+// gcloudADCPath returns the well-known gcloud ADC file location, honoring CLOUDSDK_CONFIG.
+// Returns empty string if the home directory cannot be determined.
+func gcloudADCPath() string {
+	if dir := os.Getenv("CLOUDSDK_CONFIG"); dir != "" {
+		return filepath.Join(dir, "application_default_credentials.json")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gcloud", "application_default_credentials.json")
+}
+
+// fileExists reports whether path exists.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
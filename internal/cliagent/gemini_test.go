@@ -0,0 +1,133 @@
+package cliagent
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDetectGeminiAuth(t *testing.T) {
+	// Note: Cannot use t.Parallel() when subtests use t.Setenv
+
+	tests := map[string]struct {
+		env      map[string]string
+		writeADC bool
+		want     GeminiAuthType
+	}{
+		"gemini api key":  {env: map[string]string{"GEMINI_API_KEY": "key"}, want: GeminiAuthAPIKey},
+		"google api key":  {env: map[string]string{"GOOGLE_API_KEY": "key"}, want: GeminiAuthAPIKey},
+		"gcloud adc file": {writeADC: true, want: GeminiAuthADC},
+		"no credentials":  {want: GeminiAuthNone},
+		"missing creds file": {
+			env:  map[string]string{"GOOGLE_APPLICATION_CREDENTIALS": "/nonexistent/creds.json"},
+			want: GeminiAuthNone,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			gcloudDir := t.TempDir()
+			t.Setenv("CLOUDSDK_CONFIG", gcloudDir)
+			for _, key := range []string{"GEMINI_API_KEY", "GOOGLE_API_KEY", "GOOGLE_APPLICATION_CREDENTIALS"} {
+				t.Setenv(key, "")
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if tt.writeADC {
+				adc := filepath.Join(gcloudDir, "application_default_credentials.json")
+				if err := os.WriteFile(adc, []byte("{}"), 0o600); err != nil {
+					t.Fatalf("writing ADC file: %v", err)
+				}
+			}
+
+			if got := DetectGeminiAuth(); got != tt.want {
+				t.Errorf("DetectGeminiAuth() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGemini_Validate(t *testing.T) {
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "gemini"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatalf("writing fake gemini: %v", err)
+	}
+	t.Setenv("PATH", binDir)
+	t.Setenv("CLOUDSDK_CONFIG", t.TempDir())
+	t.Setenv("GOOGLE_API_KEY", "")
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+
+	t.Setenv("GEMINI_API_KEY", "")
+	if err := NewGemini().Validate(); err == nil || !strings.Contains(err.Error(), "not authenticated") {
+		t.Errorf("Validate() without credentials error = %v, want not authenticated", err)
+	}
+
+	t.Setenv("GEMINI_API_KEY", "key")
+	if err := NewGemini().Validate(); err != nil {
+		t.Errorf("Validate() with GEMINI_API_KEY error = %v, want nil", err)
+	}
+}
+
+func TestGemini_BuildCommand_PromptDelivery(t *testing.T) {
+	t.Parallel()
+
+	largePrompt := strings.Repeat("x", geminiMaxArgPrompt+1)
+
+	tests := map[string]struct {
+		prompt    string
+		opts      ExecOptions
+		wantArgs  []string
+		wantStdin bool
+	}{
+		"small prompt uses -p flag": {
+			prompt:   "analyze code",
+			opts:     ExecOptions{Autonomous: true},
+			wantArgs: []string{"-p", "analyze code", "--yolo"},
+		},
+		"large prompt uses stdin": {
+			prompt:    largePrompt,
+			opts:      ExecOptions{Autonomous: true},
+			wantArgs:  []string{"--yolo"},
+			wantStdin: true,
+		},
+		"interactive ignores stdin delivery": {
+			prompt:   largePrompt,
+			opts:     ExecOptions{Interactive: true},
+			wantArgs: []string{largePrompt},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd, err := NewGemini().BuildCommand(tt.prompt, tt.opts)
+			if err != nil {
+				t.Fatalf("BuildCommand() error = %v", err)
+			}
+			gotArgs := cmd.Args[1:]
+			if strings.Join(gotArgs, "\x00") != strings.Join(tt.wantArgs, "\x00") {
+				t.Errorf("args = %.80q, want %.80q", gotArgs, tt.wantArgs)
+			}
+			if !tt.wantStdin {
+				if cmd.Stdin != nil {
+					t.Error("expected no stdin for argument delivery")
+				}
+				return
+			}
+			if cmd.Stdin == nil {
+				t.Fatal("expected prompt on stdin")
+			}
+			data, err := io.ReadAll(cmd.Stdin)
+			if err != nil {
+				t.Fatalf("reading stdin: %v", err)
+			}
+			if string(data) != tt.prompt {
+				t.Errorf("stdin length = %d, want %d", len(data), len(tt.prompt))
+			}
+		})
+	}
+}
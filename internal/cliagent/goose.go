@@ -2,6 +2,7 @@ package cliagent
 
 // Goose implements the Agent interface for Goose CLI (Block/Linux Foundation).
 // Command: goose run -t <prompt> [--no-session]
+// Large prompts are passed as an instructions file instead: goose run -i <file>
 // Env: GOOSE_MODE=auto for autonomous mode
 type Goose struct {
 	BaseAgent
@@ -20,6 +21,7 @@ func NewGoose() *Goose {
 					Method:     PromptMethodSubcommandArg,
 					Flag:       "run",
 					PromptFlag: "-t",
+					FileFlag:   "-i",
 				},
 				AutonomousFlag: "--no-session",
 				AutonomousEnv: map[string]string{
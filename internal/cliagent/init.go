@@ -1,6 +1,6 @@
 package cliagent
 
-// init registers all built-in Tier 1 agents with the default registry.
+// init registers all built-in agents with the default registry.
 // This is called automatically when the package is imported.
 func init() {
 	Register(NewClaude())
@@ -9,4 +9,6 @@ func init() {
 	Register(NewCodex())
 	Register(NewOpenCode())
 	Register(NewGoose())
+	Register(NewAider())
+	Register(NewOllama())
 }
@@ -0,0 +1,30 @@
+package cliagent
+
+// ModelConfig selects the model the agent runs, passed with the agent's
+// Caps.ModelFlag. Name applies to every stage; Stages holds per-stage
+// overrides keyed by stage name, e.g. a cheaper model for specify and a
+// stronger one for implement. Model names are agent-specific.
+//
+// Example:
+//
+//	model:
+//	  name: sonnet
+//	  stages:
+//	    specify: haiku
+//	    implement: opus
+type ModelConfig struct {
+	// Name is the model used when a stage has no override ("" = agent default).
+	Name string `koanf:"name" yaml:"name" json:"name"`
+
+	// Stages overrides the model for individual stages.
+	Stages map[string]string `koanf:"stages" yaml:"stages,omitempty" json:"stages,omitempty"`
+}
+
+// ForStage returns the model for a stage: its override if set, otherwise the
+// global Name. Returns "" when the agent's default model should be used.
+func (c ModelConfig) ForStage(stage string) string {
+	if name := c.Stages[stage]; name != "" {
+		return name
+	}
+	return c.Name
+}
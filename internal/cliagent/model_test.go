@@ -0,0 +1,30 @@
+package cliagent
+
+import "testing"
+
+func TestModelConfig_ForStage(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		cfg   ModelConfig
+		stage string
+		want  string
+	}{
+		"unset":           {cfg: ModelConfig{}, stage: "plan", want: ""},
+		"global":          {cfg: ModelConfig{Name: "sonnet"}, stage: "plan", want: "sonnet"},
+		"stage override":  {cfg: ModelConfig{Name: "sonnet", Stages: map[string]string{"specify": "haiku"}}, stage: "specify", want: "haiku"},
+		"other stage":     {cfg: ModelConfig{Name: "sonnet", Stages: map[string]string{"specify": "haiku"}}, stage: "implement", want: "sonnet"},
+		"override only":   {cfg: ModelConfig{Stages: map[string]string{"implement": "opus"}}, stage: "implement", want: "opus"},
+		"empty override":  {cfg: ModelConfig{Name: "sonnet", Stages: map[string]string{"plan": ""}}, stage: "plan", want: "sonnet"},
+		"no global match": {cfg: ModelConfig{Stages: map[string]string{"implement": "opus"}}, stage: "tasks", want: ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.cfg.ForStage(tt.stage); got != tt.want {
+				t.Errorf("ForStage(%q) = %q, want %q", tt.stage, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,330 @@
+package cliagent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/commands"
+)
+
+const (
+	// DefaultOllamaHost is the Ollama server used when neither ollama.host nor
+	// OLLAMA_HOST is set.
+	DefaultOllamaHost = "http://localhost:11434"
+
+	// DefaultOllamaModel is the model used when ollama.model is not set.
+	DefaultOllamaModel = "qwen2.5-coder:7b"
+
+	// ollamaMaxTurns limits the model's tool calls in one execution so a model
+	// that loops can't run forever.
+	ollamaMaxTurns = 50
+)
+
+// OllamaConfig configures the ollama agent.
+//
+// Example:
+//
+//	ollama:
+//	  host: http://localhost:11434
+//	  model: qwen2.5-coder:14b
+type OllamaConfig struct {
+	// Host is the Ollama server URL. Empty uses OLLAMA_HOST, then DefaultOllamaHost.
+	Host string `koanf:"host" yaml:"host,omitempty" json:"host,omitempty"`
+
+	// Model is the model to run. Empty uses DefaultOllamaModel.
+	Model string `koanf:"model" yaml:"model,omitempty" json:"model,omitempty"`
+}
+
+// Ollama implements the Agent interface for a model served by a local Ollama
+// server. Unlike the other agents it is not a CLI: Execute talks to the
+// server's chat API and runs the model's tool calls itself. The tools read
+// and write files in the working directory and run autospec commands, which
+// is what the specify, plan and tasks stages need; implement should use a
+// full coding agent.
+type Ollama struct {
+	Host  string
+	Model string
+
+	// Client sends the API requests (nil = http.DefaultClient).
+	Client *http.Client
+}
+
+// NewOllama creates an Ollama agent with the default host and model.
+func NewOllama() *Ollama {
+	return NewOllamaFromConfig(OllamaConfig{})
+}
+
+// NewOllamaFromConfig creates an Ollama agent from the ollama config section.
+func NewOllamaFromConfig(cfg OllamaConfig) *Ollama {
+	host := cfg.Host
+	if host == "" {
+		host = os.Getenv("OLLAMA_HOST")
+	}
+	if host == "" {
+		host = DefaultOllamaHost
+	}
+	// OLLAMA_HOST is often set without a scheme, e.g. 127.0.0.1:11434
+	if !strings.Contains(host, "://") {
+		host = "http://" + host
+	}
+	model := cfg.Model
+	if model == "" {
+		model = DefaultOllamaModel
+	}
+	return &Ollama{Host: strings.TrimRight(host, "/"), Model: model}
+}
+
+// Name returns the agent's unique identifier.
+func (o *Ollama) Name() string {
+	return "ollama"
+}
+
+// Capabilities returns the agent's capability flags.
+func (o *Ollama) Capabilities() Caps {
+	return Caps{
+		Automatable: true,
+		RequiredEnv: []string{},
+		OptionalEnv: []string{"OLLAMA_HOST"},
+	}
+}
+
+// Version returns the version reported by the Ollama server.
+func (o *Ollama) Version() (string, error) {
+	var resp struct {
+		Version string `json:"version"`
+	}
+	if err := o.getJSON(2*time.Second, "/api/version", &resp); err != nil {
+		return "", fmt.Errorf("getting version for ollama: %w", err)
+	}
+	return resp.Version, nil
+}
+
+// Validate checks that the Ollama server answers at Host.
+func (o *Ollama) Validate() error {
+	var resp struct{}
+	if err := o.getJSON(100*time.Millisecond, "/api/version", &resp); err != nil {
+		return fmt.Errorf("ollama: server not reachable at %s (start it with 'ollama serve' or set ollama.host)", o.Host)
+	}
+	return nil
+}
+
+// BuildCommand returns the equivalent 'ollama run' command, for display only.
+// Execute uses the server's chat API instead so the model can call tools.
+func (o *Ollama) BuildCommand(prompt string, opts ExecOptions) (*exec.Cmd, error) {
+	model := o.Model
+	if opts.Model != "" {
+		model = opts.Model
+	}
+	cmd := exec.Command("ollama", "run", model, prompt)
+	if opts.WorkDir != "" {
+		cmd.Dir = opts.WorkDir
+	}
+	return cmd, nil
+}
+
+// Execute sends prompt to the model and runs its tool calls until it answers
+// without one. A leading /autospec.<command> is expanded from the embedded
+// command templates first, since the model has no slash commands of its own.
+func (o *Ollama) Execute(ctx context.Context, prompt string, opts ExecOptions) (*Result, error) {
+	if opts.Interactive {
+		return nil, fmt.Errorf("ollama agent does not support interactive stages such as clarify and analyze; use a CLI agent for them")
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	var stdoutBuf, stderrBuf bytes.Buffer
+	stdout, stderr := opts.Stdout, opts.Stderr
+	if stdout == nil {
+		stdout = &stdoutBuf
+	}
+	if stderr == nil {
+		stderr = &stderrBuf
+	}
+
+	workDir := opts.WorkDir
+	if workDir == "" {
+		var err error
+		if workDir, err = os.Getwd(); err != nil {
+			return nil, fmt.Errorf("getting working directory: %w", err)
+		}
+	}
+	tools := &ollamaTools{workDir: workDir, env: opts.Env}
+	model := o.Model
+	if opts.Model != "" {
+		model = opts.Model
+	}
+
+	start := time.Now()
+	usage, err := o.chat(ctx, model, expandSlashCommand(prompt), tools, stdout)
+	result := &Result{
+		Stdout:   stdoutBuf.String(),
+		Stderr:   stderrBuf.String(),
+		Duration: time.Since(start),
+		Usage:    usage,
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "ollama: %v\n", err)
+		result.ExitCode = 1
+		result.Stderr = stderrBuf.String()
+		return result, err
+	}
+	result.Stdout = stdoutBuf.String()
+	return result, nil
+}
+
+// ollamaMessage is a chat message of the Ollama API.
+type ollamaMessage struct {
+	Role      string           `json:"role"`
+	Content   string           `json:"content"`
+	ToolCalls []ollamaToolCall `json:"tool_calls,omitempty"`
+	ToolName  string           `json:"tool_name,omitempty"`
+}
+
+// ollamaToolCall is a tool call requested by the model.
+type ollamaToolCall struct {
+	Function struct {
+		Name      string         `json:"name"`
+		Arguments map[string]any `json:"arguments"`
+	} `json:"function"`
+}
+
+// ollamaChatResponse is a non-streaming /api/chat response.
+type ollamaChatResponse struct {
+	Message         ollamaMessage `json:"message"`
+	PromptEvalCount int           `json:"prompt_eval_count"`
+	EvalCount       int           `json:"eval_count"`
+	Error           string        `json:"error"`
+}
+
+// chat runs the conversation loop and returns the tokens it used.
+func (o *Ollama) chat(ctx context.Context, model, prompt string, tools *ollamaTools, out io.Writer) (*Usage, error) {
+	messages := []ollamaMessage{
+		{Role: "system", Content: tools.systemPrompt()},
+		{Role: "user", Content: prompt},
+	}
+	usage := &Usage{}
+
+	for turn := 0; turn < ollamaMaxTurns; turn++ {
+		resp, err := o.postChat(ctx, model, messages)
+		if err != nil {
+			return usage, err
+		}
+		usage.Add(Usage{InputTokens: resp.PromptEvalCount, OutputTokens: resp.EvalCount})
+
+		msg := resp.Message
+		if msg.Content != "" {
+			fmt.Fprintln(out, strings.TrimSpace(msg.Content))
+		}
+		messages = append(messages, msg)
+		if len(msg.ToolCalls) == 0 {
+			return usage, nil
+		}
+
+		for _, call := range msg.ToolCalls {
+			fmt.Fprintf(out, "→ %s %s\n", call.Function.Name, tools.describe(call.Function.Arguments))
+			output := tools.run(ctx, call.Function.Name, call.Function.Arguments)
+			messages = append(messages, ollamaMessage{Role: "tool", Content: output, ToolName: call.Function.Name})
+		}
+	}
+	return usage, fmt.Errorf("model %s did not finish within %d turns", model, ollamaMaxTurns)
+}
+
+// postChat sends the conversation to /api/chat.
+func (o *Ollama) postChat(ctx context.Context, model string, messages []ollamaMessage) (*ollamaChatResponse, error) {
+	body, err := json.Marshal(map[string]any{
+		"model":    model,
+		"messages": messages,
+		"tools":    ollamaToolDefinitions,
+		"stream":   false,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding chat request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.Host+"/api/chat", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating chat request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := o.client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling %s: %w", o.Host, err)
+	}
+	defer httpResp.Body.Close()
+
+	var resp ollamaChatResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("decoding chat response (HTTP %d): %w", httpResp.StatusCode, err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("ollama: %s", resp.Error)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("chat request failed: HTTP %d", httpResp.StatusCode)
+	}
+	return &resp, nil
+}
+
+// getJSON decodes the JSON response of a GET request to path.
+func (o *Ollama) getJSON(timeout time.Duration, path string, v any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.Host+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := o.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (o *Ollama) client() *http.Client {
+	if o.Client != nil {
+		return o.Client
+	}
+	return http.DefaultClient
+}
+
+// expandSlashCommand replaces a leading /autospec.<command> with the body of
+// its embedded template, substituting $ARGUMENTS with the quoted arguments
+// that follow it. Anything after the first line (retry context, injected
+// instructions) is kept. Unknown commands are returned unchanged.
+func expandSlashCommand(prompt string) string {
+	if !strings.HasPrefix(prompt, "/autospec.") {
+		return prompt
+	}
+	firstLine, rest, _ := strings.Cut(prompt, "\n")
+	name, args, _ := strings.Cut(firstLine, " ")
+	content, err := commands.GetTemplate(strings.TrimPrefix(name, "/"))
+	if err != nil {
+		return prompt
+	}
+
+	body := commands.StripFrontmatter(content)
+	args = strings.TrimSpace(args)
+	if len(args) >= 2 && strings.HasPrefix(args, `"`) && strings.HasSuffix(args, `"`) {
+		args = args[1 : len(args)-1]
+	}
+	body = strings.ReplaceAll(body, "$ARGUMENTS", args)
+	if rest != "" {
+		body += "\n" + rest
+	}
+	return strings.TrimSpace(body)
+}
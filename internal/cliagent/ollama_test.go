@@ -0,0 +1,297 @@
+package cliagent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeOllama serves /api/version and answers /api/chat with scripted messages,
+// recording the requests it receives.
+type fakeOllama struct {
+	mu       sync.Mutex
+	replies  []string // JSON chat responses, one per request
+	requests []map[string]any
+}
+
+func (f *fakeOllama) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/api/version":
+		w.Write([]byte(`{"version":"0.5.7"}`))
+	case "/api/chat":
+		var req map[string]any
+		json.NewDecoder(r.Body).Decode(&req)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.requests = append(f.requests, req)
+		if len(f.replies) == 0 {
+			w.Write([]byte(`{"message":{"role":"assistant","content":"done"}}`))
+			return
+		}
+		w.Write([]byte(f.replies[0]))
+		f.replies = f.replies[1:]
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestOllama(t *testing.T, fake *fakeOllama) *Ollama {
+	t.Helper()
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	return NewOllamaFromConfig(OllamaConfig{Host: srv.URL, Model: "test-model"})
+}
+
+func TestNewOllamaFromConfig(t *testing.T) {
+	tests := map[string]struct {
+		cfg       OllamaConfig
+		env       string
+		wantHost  string
+		wantModel string
+	}{
+		"defaults": {
+			wantHost:  DefaultOllamaHost,
+			wantModel: DefaultOllamaModel,
+		},
+		"OLLAMA_HOST without scheme": {
+			env:       "127.0.0.1:11500",
+			wantHost:  "http://127.0.0.1:11500",
+			wantModel: DefaultOllamaModel,
+		},
+		"config wins over env": {
+			cfg:       OllamaConfig{Host: "https://gpu-box:11434/", Model: "llama3.1"},
+			env:       "127.0.0.1:11500",
+			wantHost:  "https://gpu-box:11434",
+			wantModel: "llama3.1",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("OLLAMA_HOST", tt.env)
+			o := NewOllamaFromConfig(tt.cfg)
+			if o.Host != tt.wantHost {
+				t.Errorf("Host = %q, want %q", o.Host, tt.wantHost)
+			}
+			if o.Model != tt.wantModel {
+				t.Errorf("Model = %q, want %q", o.Model, tt.wantModel)
+			}
+		})
+	}
+}
+
+func TestOllama_ValidateAndVersion(t *testing.T) {
+	t.Parallel()
+
+	o := newTestOllama(t, &fakeOllama{})
+	if err := o.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	version, err := o.Version()
+	if err != nil || version != "0.5.7" {
+		t.Errorf("Version() = %q, %v; want 0.5.7", version, err)
+	}
+
+	down := NewOllamaFromConfig(OllamaConfig{Host: "http://127.0.0.1:1"})
+	if err := down.Validate(); err == nil || !strings.Contains(err.Error(), "ollama serve") {
+		t.Errorf("Validate() for unreachable server = %v, want hint to run 'ollama serve'", err)
+	}
+}
+
+func TestOllama_Execute_ToolLoop(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(workDir, "spec.yaml"), []byte("feature: login"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeOllama{replies: []string{
+		`{"message":{"role":"assistant","content":"","tool_calls":[{"function":{"name":"read_file","arguments":{"path":"spec.yaml"}}}]},"prompt_eval_count":100,"eval_count":10}`,
+		`{"message":{"role":"assistant","content":"","tool_calls":[{"function":{"name":"write_file","arguments":{"path":"specs/001/plan.yaml","content":"plan: ok"}}}]},"prompt_eval_count":120,"eval_count":30}`,
+		`{"message":{"role":"assistant","content":"Plan written."},"prompt_eval_count":150,"eval_count":5}`,
+	}}
+	o := newTestOllama(t, fake)
+
+	var stdout bytes.Buffer
+	result, err := o.Execute(context.Background(), "write the plan", ExecOptions{WorkDir: workDir, Stdout: &stdout})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(workDir, "specs", "001", "plan.yaml"))
+	if err != nil || string(data) != "plan: ok" {
+		t.Errorf("plan.yaml = %q, %v; want written by write_file", data, err)
+	}
+	if result.ExitCode != 0 {
+		t.Errorf("ExitCode = %d, want 0", result.ExitCode)
+	}
+	if result.Usage == nil || result.Usage.InputTokens != 370 || result.Usage.OutputTokens != 45 {
+		t.Errorf("Usage = %+v, want 370 input / 45 output tokens", result.Usage)
+	}
+	for _, want := range []string{"→ read_file spec.yaml", "→ write_file specs/001/plan.yaml", "Plan written."} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("stdout missing %q:\n%s", want, stdout.String())
+		}
+	}
+
+	// The file content read by the first tool call is sent back to the model
+	if len(fake.requests) != 3 {
+		t.Fatalf("chat requests = %d, want 3", len(fake.requests))
+	}
+	messages := fake.requests[1]["messages"].([]any)
+	last := messages[len(messages)-1].(map[string]any)
+	if last["role"] != "tool" || last["content"] != "feature: login" {
+		t.Errorf("tool result message = %v, want read_file content", last)
+	}
+	if fake.requests[0]["model"] != "test-model" {
+		t.Errorf("model = %v, want test-model", fake.requests[0]["model"])
+	}
+}
+
+func TestOllama_Execute_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		replies []string
+		opts    ExecOptions
+		wantErr string
+	}{
+		"interactive": {
+			opts:    ExecOptions{Interactive: true},
+			wantErr: "does not support interactive",
+		},
+		"model not pulled": {
+			replies: []string{`{"error":"model \"test-model\" not found, try pulling it first"}`},
+			wantErr: "not found, try pulling it first",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			o := newTestOllama(t, &fakeOllama{replies: tt.replies})
+			tt.opts.WorkDir = t.TempDir()
+			_, err := o.Execute(context.Background(), "prompt", tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Execute() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOllamaTools_Run(t *testing.T) {
+	t.Parallel()
+
+	workDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(workDir, "README.md"), []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tools := &ollamaTools{workDir: workDir}
+
+	tests := map[string]struct {
+		tool string
+		args map[string]any
+		want string
+	}{
+		"read file": {
+			tool: "read_file",
+			args: map[string]any{"path": "README.md"},
+			want: "hello",
+		},
+		"absolute path inside project": {
+			tool: "read_file",
+			args: map[string]any{"path": filepath.Join(workDir, "README.md")},
+			want: "hello",
+		},
+		"path outside project": {
+			tool: "read_file",
+			args: map[string]any{"path": "../secret"},
+			want: "error: path \"../secret\" is outside the project",
+		},
+		"write outside project": {
+			tool: "write_file",
+			args: map[string]any{"path": "/etc/passwd", "content": "x"},
+			want: "error: path \"/etc/passwd\" is outside the project",
+		},
+		"list files": {
+			tool: "list_files",
+			args: map[string]any{"path": "."},
+			want: "README.md",
+		},
+		"non-autospec command": {
+			tool: "run_command",
+			args: map[string]any{"command": "rm -rf /"},
+			want: "error: only autospec commands are allowed",
+		},
+		"chained command": {
+			tool: "run_command",
+			args: map[string]any{"command": "autospec prereqs --json; rm -rf /"},
+			want: "error: shell operators are not allowed in commands",
+		},
+		"unknown tool": {
+			tool: "bash",
+			want: `error: unknown tool "bash"`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := tools.run(context.Background(), tt.tool, tt.args); got != tt.want {
+				t.Errorf("run(%s) = %q, want %q", tt.tool, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandSlashCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		prompt   string
+		contains []string
+		excludes []string
+	}{
+		"plain prompt unchanged": {
+			prompt:   "summarize the repo",
+			contains: []string{"summarize the repo"},
+		},
+		"command with arguments": {
+			prompt:   `/autospec.verify "focus on auth"`,
+			contains: []string{"## User Input", "Context for verification: focus on auth"},
+			excludes: []string{"$ARGUMENTS", "description:", `"focus on auth"`},
+		},
+		"extra lines kept": {
+			prompt:   "/autospec.verify\n\nPrevious attempt failed: schema error",
+			contains: []string{"## Goal", "Previous attempt failed: schema error"},
+		},
+		"unknown command unchanged": {
+			prompt:   "/autospec.nope args",
+			contains: []string{"/autospec.nope args"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := expandSlashCommand(tt.prompt)
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("expandSlashCommand(%q) missing %q", tt.prompt, want)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("expandSlashCommand(%q) should not contain %q", tt.prompt, unwanted)
+				}
+			}
+		})
+	}
+}
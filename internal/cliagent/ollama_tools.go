@@ -0,0 +1,204 @@
+package cliagent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ollamaToolOutputLimit caps the size of a tool result sent back to the model,
+// keeping a large file or command output from filling its context window.
+const ollamaToolOutputLimit = 16 * 1024
+
+// ollamaToolDefinitions describes the tools offered to the model, in the
+// function-calling format of the Ollama chat API.
+var ollamaToolDefinitions = []map[string]any{
+	ollamaTool("read_file", "Read a file relative to the project root.",
+		map[string]string{"path": "File path relative to the project root"}, "path"),
+	ollamaTool("write_file", "Create or overwrite a file relative to the project root.",
+		map[string]string{"path": "File path relative to the project root", "content": "Full file content"}, "path", "content"),
+	ollamaTool("list_files", "List the files in a directory relative to the project root.",
+		map[string]string{"path": "Directory path relative to the project root (\".\" for the root)"}, "path"),
+	ollamaTool("run_command", "Run an autospec command, e.g. 'autospec prereqs --json --require-spec'. Only autospec commands are allowed.",
+		map[string]string{"command": "The command line to run"}, "command"),
+}
+
+func ollamaTool(name, description string, params map[string]string, required ...string) map[string]any {
+	properties := make(map[string]any, len(params))
+	for param, desc := range params {
+		properties[param] = map[string]string{"type": "string", "description": desc}
+	}
+	return map[string]any{
+		"type": "function",
+		"function": map[string]any{
+			"name":        name,
+			"description": description,
+			"parameters": map[string]any{
+				"type":       "object",
+				"properties": properties,
+				"required":   required,
+			},
+		},
+	}
+}
+
+// ollamaTools runs the model's tool calls. File access is confined to workDir
+// and only autospec commands may run, so a local model can generate spec
+// artifacts but cannot modify anything outside the project.
+type ollamaTools struct {
+	workDir string
+	env     map[string]string
+}
+
+// systemPrompt tells the model how to work with the tools.
+func (t *ollamaTools) systemPrompt() string {
+	return fmt.Sprintf(`You are a software specification assistant working in the project at %s.
+You cannot see the file system directly. Use the tools to read files, write files and run autospec commands.
+Paths are relative to the project root. Write every file the instructions ask for with write_file before you finish.
+When the work is done, reply with a short summary and no tool call.`, t.workDir)
+}
+
+// describe formats a tool call's arguments for the progress output,
+// leaving out file contents.
+func (t *ollamaTools) describe(args map[string]any) string {
+	if cmd := argString(args, "command"); cmd != "" {
+		return cmd
+	}
+	return argString(args, "path")
+}
+
+// run executes a tool call and returns its result for the model. Errors are
+// returned as text so the model can correct itself.
+func (t *ollamaTools) run(ctx context.Context, name string, args map[string]any) string {
+	var (
+		out string
+		err error
+	)
+	switch name {
+	case "read_file":
+		out, err = t.readFile(argString(args, "path"))
+	case "write_file":
+		out, err = t.writeFile(argString(args, "path"), argString(args, "content"))
+	case "list_files":
+		out, err = t.listFiles(argString(args, "path"))
+	case "run_command":
+		out, err = t.runCommand(ctx, argString(args, "command"))
+	default:
+		err = fmt.Errorf("unknown tool %q", name)
+	}
+	if err != nil {
+		out = "error: " + err.Error()
+	}
+	if len(out) > ollamaToolOutputLimit {
+		out = out[:ollamaToolOutputLimit] + "\n... (truncated)"
+	}
+	return out
+}
+
+// resolve returns the absolute path of a project-relative path, rejecting
+// paths that leave the project.
+func (t *ollamaTools) resolve(path string) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("path is required")
+	}
+	rel := path
+	if filepath.IsAbs(path) {
+		var err error
+		if rel, err = filepath.Rel(t.workDir, path); err != nil {
+			return "", fmt.Errorf("path %q is outside the project", path)
+		}
+	}
+	clean := filepath.Clean(rel)
+	if clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %q is outside the project", path)
+	}
+	return filepath.Join(t.workDir, clean), nil
+}
+
+func (t *ollamaTools) readFile(path string) (string, error) {
+	abs, err := t.resolve(path)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(abs)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", path, err)
+	}
+	return string(data), nil
+}
+
+func (t *ollamaTools) writeFile(path, content string) (string, error) {
+	abs, err := t.resolve(path)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(abs), 0o755); err != nil {
+		return "", fmt.Errorf("creating directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(abs, []byte(content), 0o644); err != nil {
+		return "", fmt.Errorf("writing %s: %w", path, err)
+	}
+	return fmt.Sprintf("wrote %d bytes to %s", len(content), path), nil
+}
+
+func (t *ollamaTools) listFiles(path string) (string, error) {
+	if path == "" {
+		path = "."
+	}
+	abs, err := t.resolve(path)
+	if err != nil {
+		return "", err
+	}
+	entries, err := os.ReadDir(abs)
+	if err != nil {
+		return "", fmt.Errorf("listing %s: %w", path, err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			name += "/"
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, "\n"), nil
+}
+
+// runCommand runs an autospec command in the project directory. Shell
+// metacharacters are rejected so the command can't be chained with another.
+func (t *ollamaTools) runCommand(ctx context.Context, command string) (string, error) {
+	command = strings.TrimSpace(command)
+	if !strings.HasPrefix(command, "autospec ") {
+		return "", fmt.Errorf("only autospec commands are allowed")
+	}
+	if strings.ContainsAny(command, ";&|`$<>()\n") {
+		return "", fmt.Errorf("shell operators are not allowed in commands")
+	}
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = t.workDir
+	cmd.Env = os.Environ()
+	// Prefer the running autospec binary over whatever is first on PATH
+	if exe, err := os.Executable(); err == nil {
+		cmd.Env = append(cmd.Env, "PATH="+filepath.Dir(exe)+string(os.PathListSeparator)+os.Getenv("PATH"))
+	}
+	for k, v := range t.env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Sprintf("%s\n(exit status: %v)", out, err), nil
+	}
+	return string(out), nil
+}
+
+func argString(args map[string]any, key string) string {
+	s, _ := args[key].(string)
+	return s
+}
@@ -1,7 +1,9 @@
 package cliagent
 
 // OpenCode implements the Agent interface for OpenCode CLI.
-// Command: opencode run <prompt>
+// Command: opencode run <prompt> [--agent <name>]
+// The --agent flag selects one of opencode's built-in or user-defined agents;
+// see SubAgentConfig for choosing one globally or per stage.
 type OpenCode struct {
 	BaseAgent
 }
@@ -23,6 +25,8 @@ func NewOpenCode() *OpenCode {
 				AutonomousFlag: "",
 				RequiredEnv:    []string{},
 				OptionalEnv:    []string{"ANTHROPIC_API_KEY", "OPENAI_API_KEY", "GEMINI_API_KEY"},
+				SubAgentFlag:   "--agent",
+				ModelFlag:      "--model",
 			},
 		},
 	}
@@ -19,6 +19,27 @@ type ExecOptions struct {
 	// Defaults to the current directory if empty.
 	WorkDir string
 
+	// SubAgent names an agent defined inside the agent CLI (e.g., an opencode
+	// custom agent). Passed via Caps.SubAgentFlag; ignored if the agent has none.
+	SubAgent string
+
+	// Model selects the model to run (e.g., "opus", "gpt-5-codex").
+	// Passed via Caps.ModelFlag; ignored if the agent has none.
+	Model string
+
+	// ResumeSession is the ID of an earlier session to continue, so the agent
+	// keeps its context from previous stages. Passed via Caps.ResumeFlag;
+	// ignored if the agent has none.
+	ResumeSession string
+
+	// Sandbox runs the agent inside a container (nil = run on the host).
+	// Ignored by agents that don't run a CLI, such as ollama.
+	Sandbox *SandboxConfig
+
+	// ToolPolicy restricts the tools the agent may use (nil = agent defaults).
+	// Passed via Caps.AllowToolsFlag/DenyToolsFlag; ignored if the agent has none.
+	ToolPolicy *ToolPolicy
+
 	// ExtraArgs are additional CLI arguments appended after standard args.
 	ExtraArgs []string
 
@@ -69,4 +90,21 @@ type Result struct {
 
 	// Duration is the execution time from command start to completion.
 	Duration time.Duration
+
+	// Usage holds token counts and cost parsed from the agent's JSON output.
+	// Nil when the agent did not report usage (e.g., non-JSON output formats).
+	Usage *Usage
+
+	// SessionID identifies the agent session that ran, for resuming it with
+	// ExecOptions.ResumeSession. Empty when the agent did not report one.
+	SessionID string
+
+	// RateLimit is set when the agent failed with a rate-limit or usage-limit
+	// error, detected from the end of its output.
+	RateLimit *RateLimit
+
+	// Unreachable is set when the agent failed because it could not reach or
+	// authenticate with its model provider, detected from the end of its
+	// output. Never set together with RateLimit.
+	Unreachable *Unreachable
 }
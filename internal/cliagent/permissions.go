@@ -0,0 +1,100 @@
+package cliagent
+
+import (
+	"slices"
+	"strings"
+)
+
+// ToolPolicy restricts the tools an agent may use with allow and deny rules
+// for shell commands, files and network access. Deny rules win over allow
+// rules. Passed via Caps.AllowToolsFlag and Caps.DenyToolsFlag; agents
+// without them can't enforce a policy.
+type ToolPolicy struct {
+	Allow ToolRules `yaml:"allow,omitempty" json:"allow,omitempty"`
+	Deny  ToolRules `yaml:"deny,omitempty" json:"deny,omitempty"`
+}
+
+// ToolRules lists what a ToolPolicy allows or denies. "*" matches anything
+// of its kind.
+type ToolRules struct {
+	// Commands are shell command prefixes, e.g. "go test" or "git push".
+	Commands []string `yaml:"commands,omitempty" json:"commands,omitempty"`
+
+	// Files are globs relative to the project, e.g. "src/**" or ".env".
+	// Allowed files may be edited; denied files may be neither edited nor read.
+	Files []string `yaml:"files,omitempty" json:"files,omitempty"`
+
+	// Network are domains the agent may fetch from, e.g. "pkg.go.dev".
+	Network []string `yaml:"network,omitempty" json:"network,omitempty"`
+}
+
+// IsZero reports whether the policy has no rules.
+func (p ToolPolicy) IsZero() bool {
+	return p.Allow.isZero() && p.Deny.isZero()
+}
+
+// Merge returns p with other's rules added.
+func (p ToolPolicy) Merge(other ToolPolicy) ToolPolicy {
+	return ToolPolicy{Allow: p.Allow.merge(other.Allow), Deny: p.Deny.merge(other.Deny)}
+}
+
+func (r ToolRules) isZero() bool {
+	return len(r.Commands) == 0 && len(r.Files) == 0 && len(r.Network) == 0
+}
+
+func (r ToolRules) merge(other ToolRules) ToolRules {
+	return ToolRules{
+		Commands: slices.Concat(r.Commands, other.Commands),
+		Files:    slices.Concat(r.Files, other.Files),
+		Network:  slices.Concat(r.Network, other.Network),
+	}
+}
+
+// permissionRules renders rules in Claude Code's permission-rule syntax,
+// e.g. Bash(go test:*), Edit(src/**) or WebFetch(domain:pkg.go.dev). Denied
+// files are also denied for reading.
+func (r ToolRules) permissionRules(deny bool) []string {
+	var rules []string
+	for _, command := range r.Commands {
+		rules = append(rules, toolRule("Bash", strings.TrimSpace(command), ":*"))
+	}
+	for _, glob := range r.Files {
+		rules = append(rules, toolRule("Edit", glob, ""))
+		if deny {
+			rules = append(rules, toolRule("Read", glob, ""))
+		}
+	}
+	for _, domain := range r.Network {
+		if domain == "*" {
+			rules = append(rules, "WebFetch", "WebSearch")
+			continue
+		}
+		rules = append(rules, "WebFetch(domain:"+domain+")")
+	}
+	return rules
+}
+
+// toolRule returns tool restricted to pattern, or the whole tool for "*".
+func toolRule(tool, pattern, suffix string) string {
+	if pattern == "*" {
+		return tool
+	}
+	return tool + "(" + pattern + suffix + ")"
+}
+
+// appendPermissionArgs adds the tool policy's allow and deny rules if the
+// agent supports them.
+func (b *BaseAgent) appendPermissionArgs(args []string, opts ExecOptions) []string {
+	if opts.ToolPolicy == nil {
+		return args
+	}
+	if allow := opts.ToolPolicy.Allow.permissionRules(false); len(allow) > 0 && b.AgentCaps.AllowToolsFlag != "" {
+		args = append(args, b.AgentCaps.AllowToolsFlag)
+		args = append(args, allow...)
+	}
+	if deny := opts.ToolPolicy.Deny.permissionRules(true); len(deny) > 0 && b.AgentCaps.DenyToolsFlag != "" {
+		args = append(args, b.AgentCaps.DenyToolsFlag)
+		args = append(args, deny...)
+	}
+	return args
+}
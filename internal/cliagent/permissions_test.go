@@ -0,0 +1,64 @@
+package cliagent
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestToolRules_PermissionRules(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		rules ToolRules
+		deny  bool
+		want  []string
+	}{
+		"commands": {
+			rules: ToolRules{Commands: []string{"go test", " git push ", "*"}},
+			want:  []string{"Bash(go test:*)", "Bash(git push:*)", "Bash"},
+		},
+		"allowed files are editable": {
+			rules: ToolRules{Files: []string{"src/**"}},
+			want:  []string{"Edit(src/**)"},
+		},
+		"denied files are neither editable nor readable": {
+			rules: ToolRules{Files: []string{".env"}},
+			deny:  true,
+			want:  []string{"Edit(.env)", "Read(.env)"},
+		},
+		"network": {
+			rules: ToolRules{Network: []string{"pkg.go.dev", "*"}},
+			want:  []string{"WebFetch(domain:pkg.go.dev)", "WebFetch", "WebSearch"},
+		},
+		"empty": {},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.rules.permissionRules(tt.deny); !slices.Equal(got, tt.want) {
+				t.Errorf("permissionRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToolPolicy_Merge(t *testing.T) {
+	t.Parallel()
+
+	base := ToolPolicy{Deny: ToolRules{Commands: []string{"git push"}}}
+	merged := base.Merge(ToolPolicy{Deny: ToolRules{Commands: []string{"rm -rf"}}, Allow: ToolRules{Files: []string{"src/**"}}})
+
+	if want := []string{"git push", "rm -rf"}; !slices.Equal(merged.Deny.Commands, want) {
+		t.Errorf("Deny.Commands = %v, want %v", merged.Deny.Commands, want)
+	}
+	if want := []string{"src/**"}; !slices.Equal(merged.Allow.Files, want) {
+		t.Errorf("Allow.Files = %v, want %v", merged.Allow.Files, want)
+	}
+	if len(base.Deny.Commands) != 1 {
+		t.Errorf("Merge() modified the receiver: %v", base.Deny.Commands)
+	}
+	if !(ToolPolicy{}).IsZero() || merged.IsZero() {
+		t.Error("IsZero() should report only a policy without rules")
+	}
+}
@@ -0,0 +1,30 @@
+package cliagent
+
+import (
+	"regexp"
+	"strings"
+)
+
+// inputPromptPattern matches lines asking the user to answer or approve
+// something: yes/no questions, permission and approval requests, and
+// "press enter" pauses, as printed by agents that stop for confirmation.
+var inputPromptPattern = regexp.MustCompile(`(?i)[(\[]y(?:es)?/n(?:o)?[)\]]|\by/n\b|do you want to (?:proceed|continue|allow|run|make this edit|create|apply)|allow (?:this|once|always|command|tool)|(?:approve|confirm) (?:this|the|command|edit|changes?)\b|permission (?:required|needed|to)\b|waiting for (?:your )?(?:input|approval|confirmation)|press enter`)
+
+// ansiEscape matches terminal color and cursor sequences around prompts.
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// DetectInputPrompt returns the last non-empty line of output if it asks the
+// user for input, or "" if it doesn't. Only the last line counts: a prompt
+// the agent printed and then moved past is not waiting for anyone.
+func DetectInputPrompt(output string) string {
+	lines := strings.Split(strings.TrimRight(output, "\r\n\t "), "\n")
+	line := lines[len(lines)-1]
+	if idx := strings.LastIndex(line, "\r"); idx >= 0 {
+		line = line[idx+1:]
+	}
+	line = strings.TrimSpace(ansiEscape.ReplaceAllString(line, ""))
+	if line == "" || isAgentMessage(line) || !inputPromptPattern.MatchString(line) {
+		return ""
+	}
+	return line
+}
@@ -0,0 +1,53 @@
+package cliagent
+
+import "testing"
+
+func TestDetectInputPrompt(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		output string
+		want   string
+	}{
+		"yes/no question": {
+			output: "Applying edit to main.go\nApply these changes? (y/n) ",
+			want:   "Apply these changes? (y/n)",
+		},
+		"default in brackets": {
+			output: "Add README.md to the chat? [Y/n]\n",
+			want:   "Add README.md to the chat? [Y/n]",
+		},
+		"permission request": {
+			output: "\x1b[1mDo you want to allow this command?\x1b[0m\r\n",
+			want:   "Do you want to allow this command?",
+		},
+		"approval wait": {
+			output: "Waiting for approval to run `rm -rf build`",
+			want:   "Waiting for approval to run `rm -rf build`",
+		},
+		"redrawn line": {
+			output: "Thinking...\rPress Enter to continue",
+			want:   "Press Enter to continue",
+		},
+		"prompt answered and moved past": {
+			output: "Apply these changes? (y/n) y\nEditing main.go",
+		},
+		"agent message mentioning a prompt": {
+			output: `{"type":"assistant","message":{"content":"Ask the user: continue? (y/n)"}}`,
+		},
+		"ordinary output": {
+			output: "Running tests...\nok  \tgithub.com/example/pkg\t0.2s\n",
+		},
+		"empty": {},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := DetectInputPrompt(tt.output); got != tt.want {
+				t.Errorf("DetectInputPrompt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
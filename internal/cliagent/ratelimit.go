@@ -0,0 +1,167 @@
+package cliagent
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit describes a rate-limit or usage-limit error reported by an agent.
+type RateLimit struct {
+	// RetryAfter is how long the agent said to wait (0 = no hint).
+	RetryAfter time.Duration
+
+	// Message is the output line that reported the limit.
+	Message string
+}
+
+// failureTailSize bounds how much trailing output is kept to classify a
+// failed run.
+const failureTailSize = 16 * 1024
+
+var (
+	// rateLimitPattern matches lines reporting a rate, usage or quota limit
+	// (Anthropic, OpenAI and Gemini wording, and HTTP 429).
+	rateLimitPattern = regexp.MustCompile(`(?i)rate[ _-]?limit|usage limit|limit reached|too many requests|\b429\b|quota exceeded|resource_exhausted`)
+
+	// resetEpochPattern matches Claude's "usage limit reached|<unix time>".
+	resetEpochPattern = regexp.MustCompile(`\|(\d{10})\b`)
+
+	// retryAfterPattern matches a Retry-After header or field in seconds.
+	retryAfterPattern = regexp.MustCompile(`(?i)retry[-_ ]after["':= ]*(\d+)\b`)
+
+	// retryInPattern matches "try again in 30s", "retry in 2 minutes" or
+	// "resets in 1h30m".
+	retryInPattern = regexp.MustCompile(`(?i)(?:try again|retry|wait|resets?) (?:in|after) ((?:\d+(?:\.\d+)?(?:h|ms|m|s))+|(\d+(?:\.\d+)?) ?([a-z]+))`)
+
+	// resetClockPattern matches "resets 3pm" or "resets at 10:30 am".
+	resetClockPattern = regexp.MustCompile(`(?i)resets? (?:at )?(\d{1,2})(?::(\d{2}))? ?(am|pm)\b`)
+)
+
+// DetectRateLimit scans agent output for a rate-limit error and any hint of
+// when to retry, relative to now. Returns nil if no line reports a limit.
+func DetectRateLimit(output string, now time.Time) *RateLimit {
+	var limit *RateLimit
+	for _, line := range strings.Split(output, "\n") {
+		if isAgentMessage(line) {
+			continue
+		}
+		if limit == nil && rateLimitPattern.MatchString(line) {
+			limit = &RateLimit{Message: strings.TrimSpace(line)}
+		}
+		if limit != nil && limit.RetryAfter == 0 {
+			limit.RetryAfter = retryHint(line, now)
+		}
+	}
+	return limit
+}
+
+// isAgentMessage reports whether line is a JSON event other than a final
+// result or an error, e.g. the agent's own messages in stream-json output,
+// which may mention rate limits without being one.
+func isAgentMessage(line string) bool {
+	line = strings.TrimSpace(line)
+	return strings.HasPrefix(line, "{") &&
+		!strings.Contains(line, `"type":"result"`) && !strings.Contains(line, `"type":"error"`)
+}
+
+// retryHint returns how long line says to wait, or 0 if it gives no hint.
+func retryHint(line string, now time.Time) time.Duration {
+	if m := resetEpochPattern.FindStringSubmatch(line); m != nil {
+		epoch, _ := strconv.ParseInt(m[1], 10, 64)
+		return positive(time.Unix(epoch, 0).Sub(now))
+	}
+	if m := retryInPattern.FindStringSubmatch(line); m != nil {
+		if m[2] == "" {
+			d, _ := time.ParseDuration(m[1])
+			return positive(d)
+		}
+		amount, _ := strconv.ParseFloat(m[2], 64)
+		return positive(time.Duration(amount * float64(durationUnit(m[3]))))
+	}
+	if m := retryAfterPattern.FindStringSubmatch(line); m != nil {
+		seconds, _ := strconv.Atoi(m[1])
+		return time.Duration(seconds) * time.Second
+	}
+	if m := resetClockPattern.FindStringSubmatch(line); m != nil {
+		return untilClock(m[1], m[2], m[3], now)
+	}
+	return 0
+}
+
+// durationUnit maps a spelled-out unit ("seconds", "min", "hrs") to its
+// duration, or 0 for anything else.
+func durationUnit(unit string) time.Duration {
+	unit = strings.ToLower(unit)
+	switch {
+	case unit == "ms" || strings.HasPrefix(unit, "milli"):
+		return time.Millisecond
+	case strings.HasPrefix(unit, "s"):
+		return time.Second
+	case strings.HasPrefix(unit, "m"):
+		return time.Minute
+	case strings.HasPrefix(unit, "h"):
+		return time.Hour
+	default:
+		return 0
+	}
+}
+
+// untilClock returns the time from now until the next hour:minute am/pm in
+// now's location.
+func untilClock(hour, minute, meridiem string, now time.Time) time.Duration {
+	h, _ := strconv.Atoi(hour)
+	m, _ := strconv.Atoi(minute)
+	if h < 1 || h > 12 || m > 59 {
+		return 0
+	}
+	h %= 12
+	if strings.EqualFold(meridiem, "pm") {
+		h += 12
+	}
+	reset := time.Date(now.Year(), now.Month(), now.Day(), h, m, 0, 0, now.Location())
+	if !reset.After(now) {
+		reset = reset.AddDate(0, 0, 1)
+	}
+	return reset.Sub(now)
+}
+
+func positive(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// classifyFailure records a rate limit or, failing that, an unreachable
+// provider reported in the tail of a failed agent's output.
+func (r *Result) classifyFailure(tail string, now time.Time) {
+	if r.RateLimit = DetectRateLimit(tail, now); r.RateLimit == nil {
+		r.Unreachable = DetectUnreachable(tail)
+	}
+}
+
+// tailBuffer keeps the last failureTailSize bytes written to it. Safe for
+// stdout and stderr to write concurrently.
+type tailBuffer struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.buf = append(t.buf, p...)
+	if excess := len(t.buf) - failureTailSize; excess > 0 {
+		t.buf = t.buf[excess:]
+	}
+	return len(p), nil
+}
+
+func (t *tailBuffer) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return string(t.buf)
+}
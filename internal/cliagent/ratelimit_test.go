@@ -0,0 +1,122 @@
+package cliagent
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDetectRateLimit(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		output         string
+		wantLimit      bool
+		wantRetryAfter time.Duration
+	}{
+		"claude usage limit with reset time": {
+			output:         `{"type":"result","subtype":"success","is_error":true,"result":"Claude AI usage limit reached|1773154800"}`,
+			wantLimit:      true,
+			wantRetryAfter: time.Hour,
+		},
+		"claude limit with reset clock": {
+			output:         "5-hour limit reached ∙ resets 3:30pm",
+			wantLimit:      true,
+			wantRetryAfter: 90 * time.Minute,
+		},
+		"reset clock already passed today": {
+			output:         "Usage limit reached, resets at 9am",
+			wantLimit:      true,
+			wantRetryAfter: 19 * time.Hour,
+		},
+		"api 429 with retry-after header": {
+			output:         "API Error: 429 {\"type\":\"rate_limit_error\"}\nretry-after: 42",
+			wantLimit:      true,
+			wantRetryAfter: 42 * time.Second,
+		},
+		"try again in spelled-out duration": {
+			output:         "ERROR: Rate limit reached for gpt-5. Please try again in 2 minutes.",
+			wantLimit:      true,
+			wantRetryAfter: 2 * time.Minute,
+		},
+		"try again in go duration": {
+			output:         "stream error: rate_limit_exceeded: try again in 1m30.5s",
+			wantLimit:      true,
+			wantRetryAfter: 90*time.Second + 500*time.Millisecond,
+		},
+		"gemini quota without hint": {
+			output:    "Error: RESOURCE_EXHAUSTED: Quota exceeded for model",
+			wantLimit: true,
+		},
+		"hint on a later line": {
+			output:         "Too Many Requests\nPlease retry after 20 seconds",
+			wantLimit:      true,
+			wantRetryAfter: 20 * time.Second,
+		},
+		"agent message mentioning rate limits": {
+			output: `{"type":"assistant","message":{"content":"Added rate limit handling, retry after 5s"}}`,
+		},
+		"unrelated failure": {
+			output: "Error: invalid API key",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := DetectRateLimit(tt.output, now)
+			if (got != nil) != tt.wantLimit {
+				t.Fatalf("DetectRateLimit() = %+v, want limit %v", got, tt.wantLimit)
+			}
+			if got != nil && got.RetryAfter != tt.wantRetryAfter {
+				t.Errorf("RetryAfter = %v, want %v", got.RetryAfter, tt.wantRetryAfter)
+			}
+		})
+	}
+}
+
+func TestBaseAgent_Execute_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	agent := &BaseAgent{
+		AgentName: "test",
+		Cmd:       "sh",
+		AgentCaps: Caps{PromptDelivery: PromptDelivery{Method: PromptMethodArg, Flag: "-c"}},
+	}
+
+	tests := map[string]struct {
+		script    string
+		wantLimit bool
+	}{
+		"rate limit on stderr": {
+			script:    "echo 'API Error: 429 Too Many Requests, retry after 7 seconds' >&2; exit 1",
+			wantLimit: true,
+		},
+		"rate limit text with zero exit": {
+			script: "echo 'rate limit reached'",
+		},
+		"other failure": {
+			script: "echo 'boom' >&2; exit 1",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var stdout, stderr bytes.Buffer
+			result, err := agent.Execute(context.Background(), tt.script, ExecOptions{Stdout: &stdout, Stderr: &stderr})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if (result.RateLimit != nil) != tt.wantLimit {
+				t.Fatalf("RateLimit = %+v, want limit %v", result.RateLimit, tt.wantLimit)
+			}
+			if tt.wantLimit && result.RateLimit.RetryAfter != 7*time.Second {
+				t.Errorf("RetryAfter = %v, want 7s", result.RateLimit.RetryAfter)
+			}
+		})
+	}
+}
@@ -126,11 +126,11 @@ func Automatable() []Agent {
 
 // AgentStatus represents the diagnostic status of an agent.
 type AgentStatus struct {
-	Name      string
-	Installed bool
-	Version   string
-	Valid     bool
-	Error     string
+	Name      string `json:"name"`
+	Installed bool   `json:"installed"`
+	Version   string `json:"version,omitempty"`
+	Valid     bool   `json:"valid"`
+	Error     string `json:"error,omitempty"`
 }
 
 // Doctor returns diagnostic status for all registered agents.
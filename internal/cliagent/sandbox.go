@@ -0,0 +1,167 @@
+package cliagent
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Sandbox runtimes.
+const (
+	SandboxRuntimeDocker = "docker"
+	SandboxRuntimePodman = "podman"
+)
+
+// SandboxConfig runs the agent inside a container with only the project
+// directory mounted, so an untrusted or experimental agent can't touch
+// anything else on the machine. The image must contain the agent CLI and
+// autospec, which agents call for task updates.
+//
+// Example:
+//
+//	sandbox:
+//	  enabled: true
+//	  image: ghcr.io/acme/agent-sandbox:latest
+//	  memory: 4g
+//	  cpus: "2"
+//	  stages: [implement]
+type SandboxConfig struct {
+	// Enabled turns the sandbox on.
+	Enabled bool `koanf:"enabled" yaml:"enabled" json:"enabled"`
+
+	// Runtime is the container CLI: docker (default) or podman.
+	Runtime string `koanf:"runtime" yaml:"runtime,omitempty" json:"runtime,omitempty"`
+
+	// Image is the container image the agent runs in. Required when enabled.
+	Image string `koanf:"image" yaml:"image,omitempty" json:"image,omitempty"`
+
+	// Memory limits the container's memory, e.g. "4g" (empty = no limit).
+	Memory string `koanf:"memory" yaml:"memory,omitempty" json:"memory,omitempty"`
+
+	// CPUs limits the container's CPUs, e.g. "2" or "1.5" (empty = no limit).
+	CPUs string `koanf:"cpus" yaml:"cpus,omitempty" json:"cpus,omitempty"`
+
+	// Network is the container network, e.g. "none" (empty = runtime default).
+	// Agents that call a hosted model need network access.
+	Network string `koanf:"network" yaml:"network,omitempty" json:"network,omitempty"`
+
+	// Mounts are extra volumes in the runtime's -v syntax, e.g.
+	// "~/.claude:/home/agent/.claude:ro" for the agent's credentials.
+	Mounts []string `koanf:"mounts" yaml:"mounts,omitempty" json:"mounts,omitempty"`
+
+	// Env names extra host environment variables passed into the container.
+	// The agent's own variables (e.g. ANTHROPIC_API_KEY) are always passed.
+	Env []string `koanf:"env" yaml:"env,omitempty" json:"env,omitempty"`
+
+	// Stages limits the sandbox to these stages (empty = every stage).
+	Stages []string `koanf:"stages" yaml:"stages,omitempty" json:"stages,omitempty"`
+}
+
+// ForStage returns the sandbox for a stage, or nil if the stage runs on the host.
+func (c SandboxConfig) ForStage(stage string) *SandboxConfig {
+	if !c.Enabled || (len(c.Stages) > 0 && !slices.Contains(c.Stages, stage)) {
+		return nil
+	}
+	return &c
+}
+
+// runtime returns the container CLI to run.
+func (c *SandboxConfig) runtime() string {
+	if c.Runtime == "" {
+		return SandboxRuntimeDocker
+	}
+	return c.Runtime
+}
+
+// wrap returns a command that runs cmd inside the sandbox container. The
+// working directory is mounted at the same path, so paths in prompts stay
+// valid. Variables autospec added to cmd's environment, plus passEnv and
+// c.Env when set on the host, are forwarded by name. A nil sandbox returns
+// cmd unchanged.
+func (c *SandboxConfig) wrap(cmd *exec.Cmd, passEnv []string, interactive bool) (*exec.Cmd, error) {
+	if c == nil {
+		return cmd, nil
+	}
+	dir, err := filepath.Abs(cmd.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving sandbox directory: %w", err)
+	}
+
+	args := []string{"run", "--rm", "-i"}
+	if interactive {
+		args = append(args, "-t")
+	}
+	args = append(args, c.userArgs()...)
+	if c.Memory != "" {
+		args = append(args, "--memory", c.Memory)
+	}
+	if c.CPUs != "" {
+		args = append(args, "--cpus", c.CPUs)
+	}
+	if c.Network != "" {
+		args = append(args, "--network", c.Network)
+	}
+	args = append(args, "-v", dir+":"+dir, "-w", dir)
+	for _, mount := range c.Mounts {
+		args = append(args, "-v", expandHome(mount))
+	}
+	for _, name := range sandboxEnvNames(cmd.Env, slices.Concat(passEnv, c.Env)) {
+		args = append(args, "-e", name)
+	}
+	args = append(args, c.Image)
+	args = append(args, cmd.Args...)
+
+	wrapped := exec.Command(c.runtime(), args...)
+	wrapped.Dir = cmd.Dir
+	// The runtime reads the forwarded -e values from its own environment
+	wrapped.Env = cmd.Env
+	wrapped.Stdin = cmd.Stdin
+	return wrapped, nil
+}
+
+// userArgs runs the container as the host user so files the agent writes
+// in the project stay owned by the user.
+func (c *SandboxConfig) userArgs() []string {
+	if c.runtime() == SandboxRuntimePodman {
+		return []string{"--userns=keep-id"}
+	}
+	uid, gid := os.Getuid(), os.Getgid()
+	if uid < 0 {
+		return nil
+	}
+	return []string{"--user", strconv.Itoa(uid) + ":" + strconv.Itoa(gid)}
+}
+
+// sandboxEnvNames returns the sorted names of the variables to forward into
+// the container: those env sets beyond the host environment, and those in
+// pass that env sets at all.
+func sandboxEnvNames(env, pass []string) []string {
+	host := make(map[string]bool)
+	for _, kv := range os.Environ() {
+		host[kv] = true
+	}
+	var names []string
+	for _, kv := range env {
+		name, _, _ := strings.Cut(kv, "=")
+		if name != "" && (!host[kv] || slices.Contains(pass, name)) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// expandHome expands a leading ~ in a mount's host path to the user's home
+// directory. The rest of the mount is kept as written, options included.
+func expandHome(mount string) string {
+	if strings.HasPrefix(mount, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return home + mount[1:]
+		}
+	}
+	return mount
+}
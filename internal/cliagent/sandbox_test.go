@@ -0,0 +1,130 @@
+package cliagent
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSandboxConfig_ForStage(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		cfg   SandboxConfig
+		stage string
+		want  bool
+	}{
+		"disabled":           {cfg: SandboxConfig{Image: "agent"}, stage: "implement", want: false},
+		"every stage":        {cfg: SandboxConfig{Enabled: true, Image: "agent"}, stage: "plan", want: true},
+		"listed stage":       {cfg: SandboxConfig{Enabled: true, Stages: []string{"implement"}}, stage: "implement", want: true},
+		"stage not in list":  {cfg: SandboxConfig{Enabled: true, Stages: []string{"implement"}}, stage: "plan", want: false},
+		"empty stage listed": {cfg: SandboxConfig{Enabled: true, Stages: []string{"implement"}}, stage: "", want: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.cfg.ForStage(tt.stage) != nil; got != tt.want {
+				t.Errorf("ForStage(%q) != nil = %v, want %v", tt.stage, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildCommand_Sandbox(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	sandbox := &SandboxConfig{
+		Enabled: true,
+		Runtime: SandboxRuntimePodman,
+		Image:   "agent:latest",
+		Memory:  "4g",
+		CPUs:    "2",
+		Network: "none",
+		Mounts:  []string{"/data:/data:ro"},
+	}
+	agent := &BaseAgent{
+		AgentName: "test",
+		Cmd:       "agent",
+		AgentCaps: Caps{PromptDelivery: PromptDelivery{Method: PromptMethodArg, Flag: "-p"}},
+	}
+	cmd, err := agent.BuildCommand("fix the bug", ExecOptions{
+		WorkDir: dir,
+		Sandbox: sandbox,
+		Env:     map[string]string{"AUTOSPEC_TEST_SANDBOX": "1"},
+	})
+	if err != nil {
+		t.Fatalf("BuildCommand() error = %v", err)
+	}
+
+	want := []string{
+		"podman", "run", "--rm", "-i", "--userns=keep-id",
+		"--memory", "4g", "--cpus", "2", "--network", "none",
+		"-v", dir + ":" + dir, "-w", dir, "-v", "/data:/data:ro",
+		"-e", "AUTOSPEC_TEST_SANDBOX", "agent:latest",
+		"agent", "-p", "fix the bug",
+	}
+	if !slices.Equal(cmd.Args, want) {
+		t.Errorf("Args = %v\nwant %v", cmd.Args, want)
+	}
+	if !slices.Contains(cmd.Env, "AUTOSPEC_TEST_SANDBOX=1") {
+		t.Error("runtime environment should carry the forwarded variable's value")
+	}
+}
+
+func TestBuildCommand_SandboxInteractive(t *testing.T) {
+	t.Parallel()
+
+	cmd, err := NewClaude().BuildCommand("clarify", ExecOptions{
+		Interactive: true,
+		Sandbox:     &SandboxConfig{Enabled: true, Image: "agent:latest"},
+	})
+	if err != nil {
+		t.Fatalf("BuildCommand() error = %v", err)
+	}
+	if cmd.Args[0] != "docker" || !slices.Contains(cmd.Args, "-t") {
+		t.Errorf("interactive sandbox should run docker with a TTY, got %v", cmd.Args)
+	}
+}
+
+func TestCustomAgent_Sandbox(t *testing.T) {
+	t.Parallel()
+
+	agent, err := NewCustomAgentFromConfig(CustomAgentConfig{Command: "my-agent", Args: []string{"{{PROMPT}}"}})
+	if err != nil {
+		t.Fatalf("NewCustomAgentFromConfig() error = %v", err)
+	}
+	cmd, err := agent.BuildCommand("hello", ExecOptions{Sandbox: &SandboxConfig{Enabled: true, Image: "agent:latest"}})
+	if err != nil {
+		t.Fatalf("BuildCommand() error = %v", err)
+	}
+	if got := strings.Join(cmd.Args[len(cmd.Args)-3:], " "); got != "agent:latest my-agent hello" {
+		t.Errorf("command inside the container = %q, want %q", got, "agent:latest my-agent hello")
+	}
+}
+
+func TestSandboxEnvNames(t *testing.T) {
+	t.Setenv("AUTOSPEC_SANDBOX_HOST", "x")
+
+	env := append(os.Environ(), "ANTHROPIC_API_KEY=", "GOOSE_MODE=auto", "GOOSE_MODE=auto")
+	got := sandboxEnvNames(env, []string{"AUTOSPEC_SANDBOX_HOST", "UNSET_VAR"})
+	want := []string{"ANTHROPIC_API_KEY", "AUTOSPEC_SANDBOX_HOST", "GOOSE_MODE"}
+	if !slices.Equal(got, want) {
+		t.Errorf("sandboxEnvNames() = %v, want %v", got, want)
+	}
+}
+
+func TestExpandHome(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	if got := expandHome("~/.claude:/root/.claude:ro"); got != home+"/.claude:/root/.claude:ro" {
+		t.Errorf("expandHome() = %q", got)
+	}
+	if got := expandHome("/data:/data"); got != "/data:/data" {
+		t.Errorf("expandHome() = %q, want unchanged", got)
+	}
+}
@@ -0,0 +1,28 @@
+package cliagent
+
+// SubAgentConfig selects a named agent inside the agent CLI, such as one of
+// opencode's custom agents. Name applies to every stage; Stages holds
+// per-stage overrides keyed by stage name (e.g., "plan", "implement").
+//
+// Example:
+//
+//	sub_agent:
+//	  name: build
+//	  stages:
+//	    plan: planner
+type SubAgentConfig struct {
+	// Name is the sub-agent used when a stage has no override.
+	Name string `koanf:"name" yaml:"name" json:"name"`
+
+	// Stages overrides the sub-agent for individual stages.
+	Stages map[string]string `koanf:"stages" yaml:"stages,omitempty" json:"stages,omitempty"`
+}
+
+// ForStage returns the sub-agent for a stage: its override if set, otherwise
+// the global Name. Returns "" when no sub-agent is configured.
+func (c SubAgentConfig) ForStage(stage string) string {
+	if name := c.Stages[stage]; name != "" {
+		return name
+	}
+	return c.Name
+}
@@ -0,0 +1,33 @@
+package cliagent
+
+import "testing"
+
+func TestSubAgentConfig_ForStage(t *testing.T) {
+	t.Parallel()
+
+	cfg := SubAgentConfig{
+		Name:   "build",
+		Stages: map[string]string{"plan": "planner", "tasks": ""},
+	}
+
+	tests := map[string]struct {
+		cfg   SubAgentConfig
+		stage string
+		want  string
+	}{
+		"stage override":         {cfg: cfg, stage: "plan", want: "planner"},
+		"falls back to name":     {cfg: cfg, stage: "implement", want: "build"},
+		"empty override ignored": {cfg: cfg, stage: "tasks", want: "build"},
+		"unset":                  {cfg: SubAgentConfig{}, stage: "plan", want: ""},
+		"override without name":  {cfg: SubAgentConfig{Stages: map[string]string{"plan": "planner"}}, stage: "specify", want: ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.cfg.ForStage(tt.stage); got != tt.want {
+				t.Errorf("ForStage(%q) = %q, want %q", tt.stage, got, tt.want)
+			}
+		})
+	}
+}
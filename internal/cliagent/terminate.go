@@ -0,0 +1,31 @@
+package cliagent
+
+import (
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// terminateGracePeriod is how long a cancelled agent gets to exit after
+// SIGTERM before it is killed.
+const terminateGracePeriod = 10 * time.Second
+
+// terminate stops a running command whose context was cancelled. The agent is
+// asked to exit with SIGTERM so it can flush output and clean up, and is
+// killed if it is still running after grace. done receives the result of
+// cmd.Wait and is drained before returning.
+func terminate(cmd *exec.Cmd, done <-chan error, grace time.Duration) {
+	if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		_ = cmd.Process.Kill()
+		<-done
+		return
+	}
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		_ = cmd.Process.Kill()
+		<-done
+	}
+}
@@ -0,0 +1,45 @@
+package cliagent
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerminate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		script       string
+		wantExitCode int // -1 when killed by a signal
+	}{
+		"agent exits on SIGTERM": {
+			script:       "trap 'exit 3' TERM; while :; do sleep 0.05; done",
+			wantExitCode: 3,
+		},
+		"agent ignoring SIGTERM is killed": {
+			script:       "trap '' TERM; while :; do sleep 0.05; done",
+			wantExitCode: -1,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cmd := exec.Command("sh", "-c", tt.script)
+			require.NoError(t, cmd.Start())
+			done := make(chan error, 1)
+			go func() { done <- cmd.Wait() }()
+			time.Sleep(100 * time.Millisecond) // Let the shell install its trap
+
+			start := time.Now()
+			terminate(cmd, done, 200*time.Millisecond)
+
+			assert.Less(t, time.Since(start), 5*time.Second)
+			assert.Equal(t, tt.wantExitCode, cmd.ProcessState.ExitCode())
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package cliagent
+
+import (
+	"regexp"
+	"strings"
+)
+
+// UnreachableReason is why an agent could not reach its model provider.
+type UnreachableReason string
+
+// Unreachable reasons.
+const (
+	// UnreachableNetwork means the provider's API could not be reached.
+	UnreachableNetwork UnreachableReason = "network"
+	// UnreachableAuth means the provider rejected the agent's credentials.
+	UnreachableAuth UnreachableReason = "auth"
+)
+
+// Unreachable describes an agent failing because it could not reach or
+// authenticate with its model provider, as opposed to failing at the task.
+type Unreachable struct {
+	Reason UnreachableReason
+
+	// Message is the output line that reported the failure.
+	Message string
+}
+
+var (
+	// networkErrorPattern matches connection failures as reported by the
+	// Node.js and Go HTTP clients agents are built on.
+	networkErrorPattern = regexp.MustCompile(`(?i)ENOTFOUND|ECONNREFUSED|ECONNRESET|ETIMEDOUT|EAI_AGAIN|getaddrinfo|connection (?:refused|reset|error|timed out)|network (?:error|is unreachable)|unable to connect|could not resolve host|no such host|fetch failed|socket hang up|tls handshake timeout`)
+
+	// authErrorPattern matches rejected or missing credentials.
+	authErrorPattern = regexp.MustCompile(`(?i)invalid (?:x-)?api[ _-]?key|authentication[_ ](?:error|failed)|\b401\b|unauthorized|not logged in|please run /login|oauth token (?:has )?expired|credentials? (?:are |is )?(?:missing|expired|invalid)`)
+)
+
+// DetectUnreachable scans agent output for a network or authentication
+// failure. Returns nil if no line reports one.
+func DetectUnreachable(output string) *Unreachable {
+	for _, line := range strings.Split(output, "\n") {
+		if isAgentMessage(line) {
+			continue
+		}
+		switch {
+		case authErrorPattern.MatchString(line):
+			return &Unreachable{Reason: UnreachableAuth, Message: strings.TrimSpace(line)}
+		case networkErrorPattern.MatchString(line):
+			return &Unreachable{Reason: UnreachableNetwork, Message: strings.TrimSpace(line)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package cliagent
+
+import "testing"
+
+func TestDetectUnreachable(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		output     string
+		wantReason UnreachableReason
+	}{
+		"dns failure": {
+			output:     "API Error: Connection error. (getaddrinfo ENOTFOUND api.anthropic.com)",
+			wantReason: UnreachableNetwork,
+		},
+		"connection refused": {
+			output:     "Error: connect ECONNREFUSED 127.0.0.1:11434",
+			wantReason: UnreachableNetwork,
+		},
+		"go client": {
+			output:     `stream error: Post "https://api.openai.com/v1/responses": dial tcp: lookup api.openai.com: no such host`,
+			wantReason: UnreachableNetwork,
+		},
+		"invalid api key": {
+			output:     "Invalid API key · Please run /login",
+			wantReason: UnreachableAuth,
+		},
+		"result event with 401": {
+			output:     `{"type":"result","is_error":true,"result":"API Error: 401 {\"type\":\"error\",\"error\":{\"type\":\"authentication_error\"}}"}`,
+			wantReason: UnreachableAuth,
+		},
+		"expired oauth token": {
+			output:     "OAuth token has expired. Please obtain a new token.",
+			wantReason: UnreachableAuth,
+		},
+		"agent message about networking": {
+			output: `{"type":"assistant","message":{"content":"Handle ECONNREFUSED when the server is down"}}`,
+		},
+		"task failure": {
+			output: "Error: tests failed",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := DetectUnreachable(tt.output)
+			if tt.wantReason == "" {
+				if got != nil {
+					t.Fatalf("DetectUnreachable() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Reason != tt.wantReason {
+				t.Fatalf("DetectUnreachable() = %+v, want reason %q", got, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package cliagent
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// Usage holds token counts and the estimated cost reported by an agent run.
+// Populated from Claude's --output-format json/stream-json "result" event;
+// agents that don't report usage leave Result.Usage nil.
+type Usage struct {
+	InputTokens         int     `json:"input_tokens"`
+	OutputTokens        int     `json:"output_tokens"`
+	CacheCreationTokens int     `json:"cache_creation_tokens"`
+	CacheReadTokens     int     `json:"cache_read_tokens"`
+	CostUSD             float64 `json:"cost_usd"`
+}
+
+// TotalTokens returns the sum of input, output, and cache tokens.
+func (u Usage) TotalTokens() int {
+	return u.InputTokens + u.OutputTokens + u.CacheCreationTokens + u.CacheReadTokens
+}
+
+// Add accumulates other into u.
+func (u *Usage) Add(other Usage) {
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+	u.CacheCreationTokens += other.CacheCreationTokens
+	u.CacheReadTokens += other.CacheReadTokens
+	u.CostUSD += other.CostUSD
+}
+
+// resultEvent is the subset of Claude's final "result" JSON event carrying
+// usage and the session ID.
+type resultEvent struct {
+	Type         string  `json:"type"`
+	SessionID    string  `json:"session_id"`
+	TotalCostUSD float64 `json:"total_cost_usd"`
+	Usage        struct {
+		InputTokens              int `json:"input_tokens"`
+		OutputTokens             int `json:"output_tokens"`
+		CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+		CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+	} `json:"usage"`
+}
+
+// ParseUsageLine extracts usage from a single JSON output line.
+// Returns false for lines that are not a Claude "result" event.
+func ParseUsageLine(line []byte) (Usage, bool) {
+	event, ok := parseResultEvent(line)
+	if !ok {
+		return Usage{}, false
+	}
+	return event.usage(), true
+}
+
+// parseResultEvent decodes line if it is a Claude "result" event.
+func parseResultEvent(line []byte) (resultEvent, bool) {
+	line = bytes.TrimSpace(line)
+	if !bytes.HasPrefix(line, []byte("{")) || !bytes.Contains(line, []byte(`"result"`)) {
+		return resultEvent{}, false
+	}
+	var event resultEvent
+	if err := json.Unmarshal(line, &event); err != nil || event.Type != "result" {
+		return resultEvent{}, false
+	}
+	return event, true
+}
+
+// usage converts the event's token counts to a Usage.
+func (event resultEvent) usage() Usage {
+	return Usage{
+		InputTokens:         event.Usage.InputTokens,
+		OutputTokens:        event.Usage.OutputTokens,
+		CacheCreationTokens: event.Usage.CacheCreationInputTokens,
+		CacheReadTokens:     event.Usage.CacheReadInputTokens,
+		CostUSD:             event.TotalCostUSD,
+	}
+}
+
+// ParseUsage sums usage from every "result" event in captured agent output.
+// Returns nil if the output contains no usage metadata.
+func ParseUsage(output string) *Usage {
+	var total *Usage
+	for _, line := range strings.Split(output, "\n") {
+		if u, ok := ParseUsageLine([]byte(line)); ok {
+			if total == nil {
+				total = &Usage{}
+			}
+			total.Add(u)
+		}
+	}
+	return total
+}
+
+// usageWriter passes output through to w while scanning complete lines for
+// usage and the agent's session ID.
+type usageWriter struct {
+	w         io.Writer
+	partial   []byte
+	total     *Usage
+	sessionID string
+}
+
+// newUsageWriter wraps w so usage can be captured from streamed output.
+func newUsageWriter(w io.Writer) *usageWriter {
+	return &usageWriter{w: w}
+}
+
+// Write forwards p unchanged and scans any completed lines.
+func (u *usageWriter) Write(p []byte) (int, error) {
+	u.partial = append(u.partial, p...)
+	for {
+		idx := bytes.IndexByte(u.partial, '\n')
+		if idx < 0 {
+			break
+		}
+		u.scan(u.partial[:idx])
+		u.partial = u.partial[idx+1:]
+	}
+	return u.w.Write(p)
+}
+
+// Usage returns the accumulated usage, including any unterminated final line.
+func (u *usageWriter) Usage() *Usage {
+	u.flush()
+	return u.total
+}
+
+// SessionID returns the session ID of the last result event, or "" if the
+// agent reported none.
+func (u *usageWriter) SessionID() string {
+	u.flush()
+	return u.sessionID
+}
+
+// flush scans an unterminated final line.
+func (u *usageWriter) flush() {
+	if len(u.partial) > 0 {
+		u.scan(u.partial)
+		u.partial = nil
+	}
+}
+
+// scan adds usage from line if it is a result event.
+func (u *usageWriter) scan(line []byte) {
+	event, ok := parseResultEvent(line)
+	if !ok {
+		return
+	}
+	if u.total == nil {
+		u.total = &Usage{}
+	}
+	u.total.Add(event.usage())
+	if event.SessionID != "" {
+		u.sessionID = event.SessionID
+	}
+}
@@ -0,0 +1,128 @@
+package cliagent
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const testResultEvent = `{"type":"result","subtype":"success","total_cost_usd":0.25,"usage":{"input_tokens":100,"output_tokens":50,"cache_creation_input_tokens":10,"cache_read_input_tokens":5}}`
+
+func TestParseUsageLine(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		line   string
+		want   Usage
+		wantOK bool
+	}{
+		"result event": {
+			line:   testResultEvent,
+			want:   Usage{InputTokens: 100, OutputTokens: 50, CacheCreationTokens: 10, CacheReadTokens: 5, CostUSD: 0.25},
+			wantOK: true,
+		},
+		"assistant event": {
+			line: `{"type":"assistant","message":{"content":"the result is ready"}}`,
+		},
+		"plain text": {
+			line: "Running tests... result: ok",
+		},
+		"malformed json": {
+			line: `{"type":"result",`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := ParseUsageLine([]byte(tt.line))
+			if ok != tt.wantOK {
+				t.Fatalf("ParseUsageLine() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("ParseUsageLine() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUsage(t *testing.T) {
+	t.Parallel()
+
+	if got := ParseUsage("no json here\n"); got != nil {
+		t.Errorf("ParseUsage() = %+v, want nil", got)
+	}
+
+	got := ParseUsage(`{"type":"system"}` + "\n" + testResultEvent + "\n" + testResultEvent)
+	if got == nil {
+		t.Fatal("ParseUsage() = nil, want usage")
+	}
+	if got.InputTokens != 200 || got.CostUSD != 0.5 || got.TotalTokens() != 330 {
+		t.Errorf("ParseUsage() = %+v, want summed usage of two events", got)
+	}
+}
+
+func TestUsageWriter_SplitWrites(t *testing.T) {
+	t.Parallel()
+
+	var out strings.Builder
+	w := newUsageWriter(&out)
+	stream := `{"type":"assistant"}` + "\n" + testResultEvent
+	for i := 0; i < len(stream); i += 7 {
+		end := min(i+7, len(stream))
+		if _, err := w.Write([]byte(stream[i:end])); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	if out.String() != stream {
+		t.Errorf("output was not passed through unchanged: %q", out.String())
+	}
+	usage := w.Usage()
+	if usage == nil || usage.OutputTokens != 50 {
+		t.Errorf("Usage() = %+v, want output tokens from unterminated result line", usage)
+	}
+}
+
+func TestUsageWriter_SessionID(t *testing.T) {
+	t.Parallel()
+
+	var out strings.Builder
+	w := newUsageWriter(&out)
+	stream := `{"type":"result","session_id":"first"}` + "\n" +
+		`{"type":"assistant","session_id":"ignored"}` + "\n" +
+		`{"type":"result","session_id":"second"}`
+	if _, err := w.Write([]byte(stream)); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	if got := w.SessionID(); got != "second" {
+		t.Errorf("SessionID() = %q, want the last result event's %q", got, "second")
+	}
+	if got := newUsageWriter(&out).SessionID(); got != "" {
+		t.Errorf("SessionID() without output = %q, want empty", got)
+	}
+}
+
+func TestBaseAgent_Execute_CapturesUsage(t *testing.T) {
+	t.Parallel()
+	agent := &BaseAgent{
+		AgentName: "test",
+		Cmd:       "echo",
+		AgentCaps: Caps{
+			PromptDelivery: PromptDelivery{Method: PromptMethodPositional},
+		},
+	}
+
+	var buf strings.Builder
+	result, err := agent.Execute(context.Background(), testResultEvent, ExecOptions{Stdout: &buf})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result.Usage == nil || result.Usage.CostUSD != 0.25 {
+		t.Errorf("Usage = %+v, want cost 0.25", result.Usage)
+	}
+	if !strings.Contains(buf.String(), `"total_cost_usd"`) {
+		t.Errorf("custom writer should still receive output, got %q", buf.String())
+	}
+}
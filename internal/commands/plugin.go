@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Markers delimiting the autospec section in CLAUDE.md.
+// Content between them is owned by autospec and replaced on every install.
+const (
+	ClaudeMDBeginMarker = "<!-- autospec:begin -->"
+	ClaudeMDEndMarker   = "<!-- autospec:end -->"
+)
+
+// PluginFS embeds the extra slash commands installed by 'autospec init --claude-plugin'.
+//
+//go:embed plugin/commands/*.md
+var PluginFS embed.FS
+
+//go:embed plugin/claude-md-snippet.md
+var claudeMDSnippet string
+
+// InstallPluginTemplates installs the Claude plugin slash commands to the target directory.
+// Returns a list of results describing what was done for each template.
+func InstallPluginTemplates(targetDir string) ([]InstallResult, error) {
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	entries, err := PluginFS.ReadDir("plugin/commands")
+	if err != nil {
+		return nil, fmt.Errorf("reading plugin templates: %w", err)
+	}
+
+	var results []InstallResult
+	for _, entry := range entries {
+		content, err := PluginFS.ReadFile("plugin/commands/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("reading plugin template %s: %w", entry.Name(), err)
+		}
+		result, err := writeTemplate(targetDir, entry.Name(), content)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// writeTemplate writes a single template file and reports whether it was new.
+func writeTemplate(targetDir, filename string, content []byte) (InstallResult, error) {
+	targetPath := filepath.Join(targetDir, filename)
+	action := "installed"
+	if _, err := os.Stat(targetPath); err == nil {
+		action = "updated"
+	}
+	if err := os.WriteFile(targetPath, content, 0644); err != nil {
+		return InstallResult{}, fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	return InstallResult{
+		CommandName: strings.TrimSuffix(filename, ".md"),
+		Action:      action,
+		Path:        targetPath,
+	}, nil
+}
+
+// ClaudeMDSnippet returns the autospec CLAUDE.md section, wrapped in markers,
+// with the specs directory filled in.
+func ClaudeMDSnippet(specsDir string) string {
+	body := strings.ReplaceAll(claudeMDSnippet, "{{SPECS_DIR}}", specsDir)
+	return ClaudeMDBeginMarker + "\n" + strings.TrimRight(body, "\n") + "\n" + ClaudeMDEndMarker + "\n"
+}
+
+// UpsertClaudeMD writes the autospec section into the CLAUDE.md file at path.
+// An existing autospec section is replaced in place; otherwise the section is
+// appended, preserving all other content. The file is created if missing.
+// Returns "installed", "updated", or "skipped" (already up to date).
+func UpsertClaudeMD(path, specsDir string) (string, error) {
+	snippet := ClaudeMDSnippet(specsDir)
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	updated, action := mergeClaudeMD(existing, snippet)
+	if action == "skipped" {
+		return action, nil
+	}
+	if err := os.WriteFile(path, updated, 0644); err != nil {
+		return "", fmt.Errorf("writing %s: %w", path, err)
+	}
+	return action, nil
+}
+
+// mergeClaudeMD returns content with the marked autospec section set to snippet.
+func mergeClaudeMD(content []byte, snippet string) ([]byte, string) {
+	begin := bytes.Index(content, []byte(ClaudeMDBeginMarker))
+	end := bytes.Index(content, []byte(ClaudeMDEndMarker))
+	if begin >= 0 && end > begin {
+		end += len(ClaudeMDEndMarker)
+		if end < len(content) && content[end] == '\n' {
+			end++
+		}
+		if string(content[begin:end]) == snippet {
+			return content, "skipped"
+		}
+		merged := append(append(append([]byte{}, content[:begin]...), snippet...), content[end:]...)
+		return merged, "updated"
+	}
+
+	var buf bytes.Buffer
+	buf.Write(content)
+	if len(content) > 0 {
+		if !bytes.HasSuffix(content, []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteString(snippet)
+	return buf.Bytes(), "installed"
+}
@@ -0,0 +1,123 @@
+// Package commands_test tests Claude plugin template installation and CLAUDE.md merging.
+// Related: internal/commands/plugin.go
+// Tags: commands, plugin, claude, templates
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallPluginTemplates(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), ".claude", "commands")
+
+	results, err := InstallPluginTemplates(dir)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+
+	names := make([]string, len(results))
+	for i, r := range results {
+		names[i] = r.CommandName
+		assert.Equal(t, "installed", r.Action)
+		content, err := os.ReadFile(r.Path)
+		require.NoError(t, err)
+		desc, version, err := ParseTemplateFrontmatter(content)
+		require.NoError(t, err, "%s should have frontmatter", r.CommandName)
+		assert.NotEmpty(t, desc)
+		assert.NotEmpty(t, version)
+	}
+	assert.Contains(t, names, "autospec.status")
+	assert.Contains(t, names, "autospec.next")
+	assert.Contains(t, names, "autospec.sync")
+
+	// Second install reports updates
+	results, err = InstallPluginTemplates(dir)
+	require.NoError(t, err)
+	for _, r := range results {
+		assert.Equal(t, "updated", r.Action)
+	}
+}
+
+func TestClaudeMDSnippet(t *testing.T) {
+	t.Parallel()
+
+	snippet := ClaudeMDSnippet("features")
+
+	assert.True(t, strings.HasPrefix(snippet, ClaudeMDBeginMarker+"\n"))
+	assert.True(t, strings.HasSuffix(snippet, ClaudeMDEndMarker+"\n"))
+	assert.Contains(t, snippet, "`features/<NNN-feature>/`")
+	assert.NotContains(t, snippet, "{{SPECS_DIR}}")
+}
+
+func TestUpsertClaudeMD(t *testing.T) {
+	t.Parallel()
+
+	snippet := ClaudeMDSnippet("specs")
+
+	tests := map[string]struct {
+		existing   *string
+		wantAction string
+		wantPrefix string
+		wantSuffix string
+	}{
+		"creates missing file": {
+			existing:   nil,
+			wantAction: "installed",
+			wantPrefix: ClaudeMDBeginMarker,
+		},
+		"appends to existing content": {
+			existing:   strPtr("# Project\n\nRules here."),
+			wantAction: "installed",
+			wantPrefix: "# Project\n\nRules here.\n\n" + ClaudeMDBeginMarker,
+		},
+		"replaces stale section keeping surrounding content": {
+			existing:   strPtr("# Top\n" + ClaudeMDBeginMarker + "\nold\n" + ClaudeMDEndMarker + "\n# Bottom\n"),
+			wantAction: "updated",
+			wantPrefix: "# Top\n" + ClaudeMDBeginMarker,
+			wantSuffix: ClaudeMDEndMarker + "\n# Bottom\n",
+		},
+		"skips up to date section": {
+			existing:   strPtr("# Top\n\n" + snippet),
+			wantAction: "skipped",
+			wantPrefix: "# Top\n\n" + ClaudeMDBeginMarker,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "CLAUDE.md")
+			if tt.existing != nil {
+				require.NoError(t, os.WriteFile(path, []byte(*tt.existing), 0644))
+			}
+
+			action, err := UpsertClaudeMD(path, "specs")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAction, action)
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			got := string(content)
+			assert.True(t, strings.HasPrefix(got, tt.wantPrefix), "got:\n%s", got)
+			assert.True(t, strings.HasSuffix(got, tt.wantSuffix), "got:\n%s", got)
+			assert.Equal(t, 1, strings.Count(got, ClaudeMDBeginMarker), "section must not be duplicated")
+
+			// Idempotent
+			action, err = UpsertClaudeMD(path, "specs")
+			require.NoError(t, err)
+			assert.Equal(t, "skipped", action)
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
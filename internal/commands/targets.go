@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Target is an agent tool whose slash-command (or custom prompt) files
+// 'autospec init --target' installs. Each tool expects its own directory
+// layout and file format.
+type Target struct {
+	// Name is the --target value, e.g. "cursor".
+	Name string
+	// Description names the files installed, for help and output.
+	Description string
+	// dir returns the directory the files are installed into.
+	dir func() (string, error)
+	// ext is the file extension, including the dot.
+	ext string
+	// render converts an embedded Claude command template to the tool's format.
+	render func(tpl CommandTemplate) []byte
+}
+
+// DefaultTarget is the target installed when none is given.
+const DefaultTarget = "claude"
+
+var targets = []Target{
+	{
+		Name:        "claude",
+		Description: "Claude Code slash commands in .claude/commands/",
+		dir:         projectDir(GetDefaultCommandsDir()),
+		ext:         ".md",
+		render:      func(tpl CommandTemplate) []byte { return tpl.Content },
+	},
+	{
+		Name:        "codex",
+		Description: "Codex custom prompts in $CODEX_HOME/prompts/ (default ~/.codex/prompts/)",
+		dir:         codexPromptsDir,
+		ext:         ".md",
+		render:      renderMarkdownWithFrontmatter,
+	},
+	{
+		Name:        "cursor",
+		Description: "Cursor commands in .cursor/commands/",
+		dir:         projectDir(filepath.Join(".cursor", "commands")),
+		ext:         ".md",
+		render:      renderCursor,
+	},
+	{
+		Name:        "opencode",
+		Description: "OpenCode commands in .opencode/command/",
+		dir:         projectDir(filepath.Join(".opencode", "command")),
+		ext:         ".md",
+		render:      renderMarkdownWithFrontmatter,
+	},
+	{
+		Name:        "gemini",
+		Description: "Gemini CLI custom commands in .gemini/commands/",
+		dir:         projectDir(filepath.Join(".gemini", "commands")),
+		ext:         ".toml",
+		render:      renderGemini,
+	},
+}
+
+// TargetNames returns the valid --target values.
+func TargetNames() []string {
+	names := make([]string, len(targets))
+	for i, t := range targets {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// GetTarget returns the target with the given name.
+func GetTarget(name string) (Target, error) {
+	i := slices.IndexFunc(targets, func(t Target) bool { return t.Name == name })
+	if i < 0 {
+		return Target{}, fmt.Errorf("unknown target %q: must be one of: %s", name, strings.Join(TargetNames(), ", "))
+	}
+	return targets[i], nil
+}
+
+// Dir returns the directory the target's files are installed into.
+func (t Target) Dir() (string, error) {
+	return t.dir()
+}
+
+// Install writes every embedded command template to the target's directory
+// in its format.
+func (t Target) Install() ([]InstallResult, error) {
+	dir, err := t.Dir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	templates, err := ListTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []InstallResult
+	for _, tpl := range templates {
+		targetPath := filepath.Join(dir, tpl.Name+t.ext)
+
+		action := "installed"
+		if _, err := os.Stat(targetPath); err == nil {
+			action = "updated"
+		}
+		if err := os.WriteFile(targetPath, t.render(tpl), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", targetPath, err)
+		}
+		results = append(results, InstallResult{
+			CommandName: tpl.Name,
+			Action:      action,
+			Path:        targetPath,
+		})
+	}
+	return results, nil
+}
+
+func projectDir(dir string) func() (string, error) {
+	return func() (string, error) { return dir, nil }
+}
+
+// codexPromptsDir returns the Codex prompts directory. Codex only reads
+// custom prompts from $CODEX_HOME/prompts, so they are installed per user.
+func codexPromptsDir() (string, error) {
+	if codexHome := os.Getenv("CODEX_HOME"); codexHome != "" {
+		return filepath.Join(codexHome, "prompts"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("finding home directory: %w", err)
+	}
+	return filepath.Join(home, ".codex", "prompts"), nil
+}
+
+// StripFrontmatter returns a template's markdown body without its YAML
+// frontmatter.
+func StripFrontmatter(content []byte) string {
+	body := string(content)
+	if strings.HasPrefix(body, "---\n") {
+		if _, after, found := strings.Cut(body[4:], "\n---\n"); found {
+			body = after
+		}
+	}
+	return body
+}
+
+// renderMarkdownWithFrontmatter keeps only the description in the
+// frontmatter; Codex and OpenCode substitute $ARGUMENTS like Claude Code.
+func renderMarkdownWithFrontmatter(tpl CommandTemplate) []byte {
+	return fmt.Appendf(nil, "---\ndescription: %q\n---\n%s", tpl.Description, StripFrontmatter(tpl.Content))
+}
+
+// renderCursor drops the frontmatter. Cursor has no argument placeholder; it
+// sends the text typed after the command along with the file.
+func renderCursor(tpl CommandTemplate) []byte {
+	body := strings.ReplaceAll(StripFrontmatter(tpl.Content), "$ARGUMENTS", "(the text entered after the command)")
+	return []byte(body)
+}
+
+// renderGemini writes a Gemini CLI TOML command, with {{args}} in place of
+// $ARGUMENTS. The prompt is a literal string, so no escaping is needed.
+func renderGemini(tpl CommandTemplate) []byte {
+	body := strings.ReplaceAll(StripFrontmatter(tpl.Content), "$ARGUMENTS", "{{args}}")
+	return fmt.Appendf(nil, "description = %q\nprompt = '''\n%s'''\n", tpl.Description, body)
+}
@@ -0,0 +1,99 @@
+// Package commands_test tests installing command templates for other agent tools.
+// Related: internal/commands/targets.go
+// Tags: commands, targets, codex, cursor, opencode, gemini
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTarget(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range TargetNames() {
+		target, err := GetTarget(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, target.Name)
+	}
+
+	_, err := GetTarget("vim")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be one of: claude, codex, cursor, opencode, gemini")
+}
+
+func TestTarget_Install(t *testing.T) {
+	// Not parallel: changes directory and sets CODEX_HOME.
+	tests := map[string]struct {
+		wantFile     string
+		wantContains []string
+		wantAbsent   []string
+	}{
+		"claude": {
+			wantFile:     ".claude/commands/autospec.specify.md",
+			wantContains: []string{"---\ndescription:", "$ARGUMENTS"},
+		},
+		"codex": {
+			wantFile:     "codex-home/prompts/autospec.specify.md",
+			wantContains: []string{"---\ndescription: \"", "$ARGUMENTS"},
+			wantAbsent:   []string{"version: \"1.0.0\"\n---"},
+		},
+		"cursor": {
+			wantFile:     ".cursor/commands/autospec.specify.md",
+			wantContains: []string{"(the text entered after the command)"},
+			wantAbsent:   []string{"---\ndescription:", "$ARGUMENTS"},
+		},
+		"opencode": {
+			wantFile:     ".opencode/command/autospec.specify.md",
+			wantContains: []string{"---\ndescription: \"", "$ARGUMENTS"},
+		},
+		"gemini": {
+			wantFile:     ".gemini/commands/autospec.specify.toml",
+			wantContains: []string{"description = \"", "prompt = '''\n", "{{args}}"},
+			wantAbsent:   []string{"$ARGUMENTS", "---\ndescription:"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Chdir(dir)
+			t.Setenv("CODEX_HOME", filepath.Join(dir, "codex-home"))
+
+			target, err := GetTarget(name)
+			require.NoError(t, err)
+
+			results, err := target.Install()
+			require.NoError(t, err)
+			templates, err := ListTemplates()
+			require.NoError(t, err)
+			assert.Len(t, results, len(templates))
+			assert.Equal(t, "installed", results[0].Action)
+
+			data, err := os.ReadFile(filepath.Join(dir, tt.wantFile))
+			require.NoError(t, err)
+			for _, want := range tt.wantContains {
+				assert.Contains(t, string(data), want)
+			}
+			for _, absent := range tt.wantAbsent {
+				assert.NotContains(t, string(data), absent)
+			}
+
+			results, err = target.Install()
+			require.NoError(t, err)
+			assert.Equal(t, "updated", results[0].Action)
+		})
+	}
+}
+
+func TestStripFrontmatter(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "body\n", StripFrontmatter([]byte("---\ndescription: x\n---\nbody\n")))
+	assert.Equal(t, "no frontmatter\n", StripFrontmatter([]byte("no frontmatter\n")))
+}
@@ -14,9 +14,14 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/ariel-frischer/autospec/internal/budget"
 	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/git"
+	"github.com/ariel-frischer/autospec/internal/history"
 	"github.com/ariel-frischer/autospec/internal/notify"
+	"github.com/ariel-frischer/autospec/internal/retry"
 	"github.com/ariel-frischer/autospec/internal/worktree"
 	"github.com/knadh/koanf/parsers/json"
 	"github.com/knadh/koanf/parsers/yaml"
@@ -54,6 +59,33 @@ type Configuration struct {
 	//     post_processor: "cclean"
 	CustomAgent *cliagent.CustomAgentConfig `koanf:"custom_agent"`
 
+	// SubAgent selects a named agent inside the agent CLI (opencode's --agent),
+	// globally via sub_agent.name or per stage via sub_agent.stages.<stage>.
+	// Ignored by agents that have no sub-agent flag.
+	// Example: sub_agent: {name: build, stages: {plan: planner}}
+	SubAgent cliagent.SubAgentConfig `koanf:"sub_agent"`
+
+	// Model selects the model the agent runs (claude/codex/gemini/aider/opencode
+	// --model), globally via model.name or per stage via model.stages.<stage>.
+	// Ignored by agents that have no model flag. --model overrides both.
+	// Example: model: {name: sonnet, stages: {specify: haiku, implement: opus}}
+	Model cliagent.ModelConfig `koanf:"model"`
+
+	// StageAgents runs individual stages with a different built-in agent than
+	// the one selected by agent_preset/custom_agent, e.g. a local model for the
+	// document-generation stages while implement uses a cloud agent.
+	// Example: stage_agents: {specify: ollama, plan: ollama, tasks: ollama}
+	StageAgents map[string]string `koanf:"stage_agents"`
+
+	// Ollama configures the ollama agent (local model served by Ollama).
+	// Example: ollama: {host: http://localhost:11434, model: qwen2.5-coder:14b}
+	Ollama cliagent.OllamaConfig `koanf:"ollama"`
+
+	// Sandbox runs the agent inside a docker or podman container with only the
+	// project mounted, for every stage or those in sandbox.stages.
+	// Example: sandbox: {enabled: true, image: my/agent:latest, memory: 4g, stages: [implement]}
+	Sandbox cliagent.SandboxConfig `koanf:"sandbox"`
+
 	// UseSubscription forces Claude to use subscription (Pro/Max) instead of API credits.
 	// When true, ANTHROPIC_API_KEY is set to empty string at execution time,
 	// and validation is skipped for this environment variable.
@@ -61,27 +93,169 @@ type Configuration struct {
 	// Can be set via AUTOSPEC_USE_SUBSCRIPTION env var.
 	UseSubscription bool `koanf:"use_subscription"`
 
+	// Profile names the active entry in Profiles. Set via the --profile flag,
+	// AUTOSPEC_PROFILE env var, or a top-level profile key in a config file.
+	Profile string `koanf:"profile"`
+
+	// Profiles holds named sets of config overrides (agent, specs_dir,
+	// max_retries, timeout, ...) applied over user and project config when
+	// selected, so one machine can switch between setups.
+	// Example: profiles: {work: {agent_preset: claude, specs_dir: ./specs, timeout: 3600}}
+	Profiles map[string]map[string]interface{} `koanf:"profiles"`
+
+	// Package names the active entry in Packages. Set via the --package flag,
+	// AUTOSPEC_PACKAGE env var, the package containing the current directory,
+	// or a top-level package key in the workspace config.
+	Package string `koanf:"package"`
+
+	// Packages maps the packages of a monorepo to their directories, specs
+	// dirs and constitutions. The selected package's specs_dir and
+	// constitution replace the top-level ones, and its own
+	// <path>/.autospec/config.yml is applied over the workspace config.
+	// Example: packages: {api: {path: services/api}, web: {path: services/web, specs_dir: docs/web-specs}}
+	Packages map[string]PackageConfig `koanf:"packages"`
+
+	// Constitution is the project constitution file. Empty uses
+	// .autospec/memory/constitution.yaml (or .yml, or the .specify/ equivalents).
+	Constitution string `koanf:"constitution"`
+
 	MaxRetries        int    `koanf:"max_retries"`
 	SpecsDir          string `koanf:"specs_dir"`
 	StateDir          string `koanf:"state_dir"`
 	SkipPreflight     bool   `koanf:"skip_preflight"`
 	Timeout           int    `koanf:"timeout"`
 	SkipConfirmations bool   `koanf:"skip_confirmations"` // Skip confirmation prompts (can also be set via AUTOSPEC_YES env var)
+
+	// StateStore selects how retry state, history, sessions and checkpoints
+	// are stored: file (one file each in state_dir) or sqlite (state_dir/
+	// state.db, imported from the files on first use).
+	StateStore string `koanf:"state_store"`
+
+	// SpecNamespace prefixes the numbers of new specs, e.g. API gives
+	// API-001-rate-limits. Each namespace is numbered separately, so teams
+	// sharing a repo don't collide. Empty numbers specs 001, 002, ...
+	SpecNamespace string `koanf:"spec_namespace"`
+
+	// BranchTemplate names the git branch of a new spec from its {number}
+	// and {slug}, e.g. "feat/{number}-{slug}". The spec directory is always
+	// {number}-{slug}. Empty or "{number}-{slug}" names the branch after it.
+	BranchTemplate string `koanf:"branch_template"`
+	// BranchMaxWords caps the words in a generated {slug}. 0 keeps 3, or 4
+	// when a description has exactly 4 meaningful words.
+	BranchMaxWords int `koanf:"branch_max_words"`
+	// SkipBranch creates new specs without a git branch, for trunk-based
+	// workflows. The spec is then found from its directory.
+	SkipBranch bool `koanf:"skip_branch"`
+	// VCS selects the version control backend for spec branches: auto
+	// (detect), git, jj (bookmarks) or none (plain directories).
+	VCS string `koanf:"vcs"`
+
+	// PhaseTimeouts maps a stage name to the time limit of each agent session
+	// in that stage, overriding timeout. An agent over its limit is sent
+	// SIGTERM, then killed if it hasn't exited after a grace period.
+	// Example: phase_timeouts: {specify: 10m, implement: 2h}
+	PhaseTimeouts map[string]time.Duration `koanf:"phase_timeouts"`
+
 	// ImplementMethod sets the default execution mode for the implement command.
 	// Valid values: "single-session" (legacy), "phases" (default), "tasks"
 	// Can be overridden by CLI flags (--phases, --tasks) or env var AUTOSPEC_IMPLEMENT_METHOD
 	ImplementMethod string `koanf:"implement_method"`
 
+	// RetryPolicy controls the backoff between retry attempts: none, fixed, linear,
+	// or exponential, with optional per-stage overrides under retry_policy.stages.
+	// Only applies when max_retries > 0 (and to cool-down after failed runs).
+	RetryPolicy retry.PolicyConfig `koanf:"retry_policy"`
+
+	// Budget stops a run before the next agent session once the run, or the
+	// spec across runs, has used budget.run/budget.spec tokens or dollars.
+	// Only usage reported by the agent (claude) counts.
+	// Example: budget: {run: {cost_usd: 5}, spec: {cost_usd: 20, tokens: 5000000}}
+	Budget budget.Config `koanf:"budget"`
+
+	// PostValidate maps a stage name (e.g. "plan") to a shell command run after
+	// the stage's built-in validation passes. A non-zero exit fails validation
+	// and its stderr is included in the retry prompt.
+	// Example: post_validate: {plan: "./scripts/check-plan.sh"}
+	PostValidate map[string]string `koanf:"post_validate"`
+
+	// Hooks maps pre_<stage> and post_<stage> (e.g. "post_implement") to shell
+	// commands run before and after each stage session, including each phase or
+	// task session of implement. A failing hook is treated like a validation
+	// failure and its output is included in the retry prompt.
+	// Example: hooks: {pre_implement: "make lint", post_implement: "make test"}
+	Hooks map[string]string `koanf:"hooks"`
+
+	// Pipeline sets the steps `autospec full` runs, in order. Entries are stage
+	// names, which must keep their canonical relative order, or names defined
+	// in PipelineCommands or CustomPhases. Empty runs specify, plan, tasks,
+	// implement.
+	// Example: pipeline: [specify, clarify, plan, tasks, lint-plan, implement]
+	Pipeline []string `koanf:"pipeline"`
+
+	// PipelineCommands maps custom pipeline step names to shell commands. A
+	// step fails the workflow when its command exits non-zero.
+	// Example: pipeline_commands: {lint-plan: "./scripts/lint-plan.sh"}
+	PipelineCommands map[string]string `koanf:"pipeline_commands"`
+
+	// CustomPhases defines agent phases that can be added to Pipeline. Each
+	// runs an agent session with a prompt template and is checked by a
+	// validation command, with the same retries as built-in stages.
+	// Example: custom_phases: {security-review: {validate: "./scripts/check-security.sh"}}
+	CustomPhases map[string]CustomPhaseConfig `koanf:"custom_phases"`
+
+	// DoctorChecks adds project checks to 'autospec doctor', e.g. a tool
+	// version or a running service. Each runs a shell command and is reported
+	// with the built-in checks as pass, warn or fail.
+	// Example: doctor_checks: [{name: "Node.js", command: "node --version", min_version: "20"}]
+	DoctorChecks []DoctorCheck `koanf:"doctor_checks"`
+
+	// Validation configures extra checks that gate stage success.
+	// validation.tests runs the project's test suite after each implement session.
+	Validation ValidationConfig `koanf:"validation"`
+
 	// Notifications configures notification preferences for command and stage completion.
 	// Supports sound, visual, or both notification types across macOS, Linux, and Windows.
 	// Environment variable support via AUTOSPEC_NOTIFICATIONS_* prefix.
 	Notifications notify.NotificationConfig `koanf:"notifications"`
 
+	// Metrics configures the local usage metrics shown by 'autospec metrics show'
+	// and the opt-in upload of anonymized aggregates.
+	// Environment variable support via AUTOSPEC_METRICS_* prefix.
+	Metrics MetricsConfig `koanf:"metrics"`
+
+	// Sync configures where 'autospec sync' keeps spec state shared between
+	// machines. Environment variable support via AUTOSPEC_SYNC_* prefix.
+	Sync SyncConfig `koanf:"sync"`
+
 	// MaxHistoryEntries sets the maximum number of command history entries to retain.
 	// Oldest entries are pruned when this limit is exceeded.
 	// Default: 500. Can be set via AUTOSPEC_MAX_HISTORY_ENTRIES env var.
 	MaxHistoryEntries int `koanf:"max_history_entries"`
 
+	// MaxHistoryAge removes history entries older than this age (e.g. "90d", "2w", "720h").
+	// Empty keeps entries regardless of age. Can be set via AUTOSPEC_MAX_HISTORY_AGE env var.
+	MaxHistoryAge string `koanf:"max_history_age"`
+
+	// HistoryArchive moves pruned history entries into compressed monthly files under
+	// <state_dir>/history-archive/ instead of discarding them. Default: true.
+	HistoryArchive bool `koanf:"history_archive"`
+
+	// HistoryBackend also writes history entries to a store the team shares,
+	// so everyone can see who ran which phases for which specs.
+	// Environment variable support via AUTOSPEC_HISTORY_BACKEND_* prefix.
+	HistoryBackend HistoryBackendConfig `koanf:"history_backend"`
+
+	// MaxSessions sets how many agent transcripts are kept under <state_dir>/sessions.
+	// Oldest transcripts are pruned when this limit is exceeded; 0 disables capture.
+	// Default: 50. Can be set via AUTOSPEC_MAX_SESSIONS env var.
+	MaxSessions int `koanf:"max_sessions"`
+
+	// MaxSnapshots sets how many artifact snapshots are kept per spec under
+	// <state_dir>/snapshots. One is taken before each stage that may change
+	// spec.yaml, plan.yaml or tasks.yaml; 0 disables snapshots.
+	// Default: 20. Can be set via AUTOSPEC_MAX_SNAPSHOTS env var.
+	MaxSnapshots int `koanf:"max_snapshots"`
+
 	// ViewLimit sets the number of recent specs displayed by the view command.
 	// Default: 5. Can be set via AUTOSPEC_VIEW_LIMIT env var.
 	ViewLimit int `koanf:"view_limit"`
@@ -119,6 +293,157 @@ type Configuration struct {
 	// Used to determine if the user explicitly configured auto-commit.
 	// Set during config loading, not persisted.
 	AutoCommitSource ConfigSource `koanf:"-"`
+
+	// TaskCommits commits the working tree after each task completed in
+	// task-level implementation (--tasks), with a conventional-commit message
+	// derived from the task, e.g. "feat(T014): add retry policy".
+	// Default: false. Can be set via AUTOSPEC_TASK_COMMITS env var.
+	TaskCommits bool `koanf:"task_commits"`
+
+	// OnSpecChange sets what happens when plan.yaml or tasks.yaml were
+	// generated from an older spec.yaml: "warn" (default) prints a warning,
+	// "replan" regenerates them before tasks or implement run, "ignore" does
+	// nothing. Can be set via AUTOSPEC_ON_SPEC_CHANGE env var.
+	OnSpecChange string `koanf:"on_spec_change"`
+
+	// SessionMode sets whether each stage starts a new agent session ("fresh",
+	// default) or resumes the spec's previous one ("continuous"), so the agent
+	// keeps its context from specify through implement. Only agents with
+	// session resume (claude --resume) continue sessions; others start fresh.
+	// Can be set via AUTOSPEC_SESSION_MODE env var.
+	SessionMode string `koanf:"session_mode"`
+
+	// OnUnreachable sets what happens when the agent fails because it can't
+	// reach its provider (network) or its credentials were rejected (auth):
+	// "ask" (default) offers to queue the stage on a terminal and fails
+	// otherwise, "queue" defers the stage to the queue for
+	// 'autospec queue retry-pending', "fail" fails the stage. None of them
+	// use up a retry. Can be set via AUTOSPEC_ON_UNREACHABLE env var.
+	OnUnreachable string `koanf:"on_unreachable"`
+
+	// StallTimeout stops an agent session that prints nothing for this long
+	// (0 = never). The session's transcript is saved and the stage is retried
+	// or failed with a "stalled" reason, as set by OnStall.
+	// Can be set via AUTOSPEC_STALL_TIMEOUT env var.
+	StallTimeout time.Duration `koanf:"stall_timeout"`
+
+	// OnStall sets what happens after a stalled session is stopped: "retry"
+	// (default) uses up a retry if one is left and fails the stage otherwise,
+	// "fail" fails the stage. Can be set via AUTOSPEC_ON_STALL env var.
+	OnStall string `koanf:"on_stall"`
+
+	// EscalateBlockedAfter escalates tasks that implement finds blocked for
+	// longer than this (0 = never), once per block, as set by EscalateBlocked.
+	// Can be set via AUTOSPEC_ESCALATE_BLOCKED_AFTER env var.
+	EscalateBlockedAfter time.Duration `koanf:"escalate_blocked_after"`
+
+	// EscalateBlocked sets how a long-blocked task is escalated: "notify"
+	// (default) sends a notification and the task.escalated webhook event,
+	// "issue" opens a GitHub issue with its blocked_reason, "both" does both.
+	// Can be set via AUTOSPEC_ESCALATE_BLOCKED env var.
+	EscalateBlocked string `koanf:"escalate_blocked"`
+}
+
+// CustomPhaseConfig defines a custom agent phase.
+type CustomPhaseConfig struct {
+	// Prompt is the prompt template path, rendered like stage templates.
+	// Default: .autospec/prompts/<name>.tmpl
+	Prompt string `koanf:"prompt"`
+	// Validate is a shell command run after each session. A non-zero exit
+	// fails the phase and its output is fed into the retry prompt. Empty
+	// accepts any session that completes.
+	Validate string `koanf:"validate"`
+}
+
+// DoctorCheck is a project check run by 'autospec doctor'. It passes when
+// the command exits with ExpectExit and its output matches ExpectOutput and
+// MinVersion, whichever are set.
+type DoctorCheck struct {
+	// Name labels the check in the report.
+	Name string `koanf:"name" json:"name"`
+	// Command is the shell command run from the current directory.
+	Command string `koanf:"command" json:"command"`
+	// ExpectExit is the exit code the command must return. Default: 0.
+	ExpectExit int `koanf:"expect_exit" json:"expect_exit,omitempty"`
+	// ExpectOutput is a regular expression the command's stdout and stderr
+	// must match.
+	ExpectOutput string `koanf:"expect_output" json:"expect_output,omitempty"`
+	// MinVersion is the lowest version allowed, compared with the first
+	// version number in the output (e.g. "v20.11.1" for node --version).
+	MinVersion string `koanf:"min_version" json:"min_version,omitempty"`
+	// Severity is "fail" (default) to fail doctor when the check doesn't
+	// pass, or "warn" to only report it.
+	Severity string `koanf:"severity" json:"severity,omitempty"`
+	// Timeout limits the command. Default: 30s.
+	Timeout time.Duration `koanf:"timeout" json:"timeout,omitempty"`
+}
+
+// ValidationConfig configures extra checks that gate stage success.
+type ValidationConfig struct {
+	// Tests runs the project's test suite after each implement session.
+	Tests TestsConfig `koanf:"tests"`
+	// TaskGate lists build/lint commands run after each task the agent marks
+	// Completed in task mode (--tasks), e.g. ["go build ./...", "go vet ./..."].
+	// A failing command resets the task to InProgress and retries it with
+	// the command output.
+	TaskGate []string `koanf:"task_gate"`
+}
+
+// TestsConfig configures the test suite run after each implement session.
+// An implement phase only succeeds when the suite passes; failures are fed
+// back to the agent on retry.
+type TestsConfig struct {
+	// Command is the shell command that runs the tests, e.g. "go test ./...".
+	// Empty disables the test run.
+	Command string `koanf:"command"`
+	// Timeout bounds a test run (default 10m).
+	Timeout time.Duration `koanf:"timeout"`
+	// PassThreshold is the percentage of tests that must pass (1-100, default
+	// 100). Below 100, a failing run still passes when the pass rate parsed
+	// from the output (go test -v, pytest, jest, cargo) meets the threshold.
+	PassThreshold int `koanf:"pass_threshold"`
+}
+
+// MetricsConfig configures usage metrics. Metrics are recorded in
+// <state_dir>/metrics.yaml and never leave the machine unless Upload is set.
+type MetricsConfig struct {
+	// Enabled records command runs, stage sessions and agent backends
+	// locally. Default: true.
+	Enabled bool `koanf:"enabled"`
+	// Upload sends anonymized aggregates (counts and durations, no spec
+	// names or paths) to UploadURL at most once a day. Default: false.
+	Upload bool `koanf:"upload"`
+	// UploadURL is the endpoint aggregates are POSTed to as JSON.
+	UploadURL string `koanf:"upload_url"`
+}
+
+// HistoryBackendConfig configures the shared history backend. Entries are
+// always kept in the local history.yaml as well.
+type HistoryBackendConfig struct {
+	// Type is sqlite, http, or empty for none. Default: empty.
+	Type string `koanf:"type"`
+	// Path is the sqlite database, relative to the repository root.
+	// Default: .autospec/history.db.
+	Path string `koanf:"path"`
+	// URL receives each entry as a JSON POST for the http type.
+	URL string `koanf:"url"`
+	// Headers are extra HTTP headers, e.g. an Authorization token.
+	// Values may reference environment variables as ${VAR}.
+	Headers map[string]string `koanf:"headers"`
+}
+
+// SyncConfig configures the store 'autospec sync' pushes spec state to.
+type SyncConfig struct {
+	// Store is git (an orphan branch of the project repository) or dir.
+	// Default: git.
+	Store string `koanf:"store"`
+	// Branch is the git store's branch. Default: autospec-state.
+	Branch string `koanf:"branch"`
+	// Remote is the git remote the branch is shared through. Without it the
+	// branch stays local. Default: origin.
+	Remote string `koanf:"remote"`
+	// Dir is the dir store's directory, e.g. on a synced drive.
+	Dir string `koanf:"dir"`
 }
 
 // LoadOptions configures how configuration is loaded
@@ -132,10 +457,20 @@ type LoadOptions struct {
 	WarningWriter io.Writer
 	// SkipWarnings suppresses deprecation warnings
 	SkipWarnings bool
+	// Profile selects a named profile, overriding AUTOSPEC_PROFILE and the
+	// profile key from config files
+	Profile string
+	// Package selects a workspace package, overriding AUTOSPEC_PACKAGE and
+	// detection from the current directory
+	Package string
 }
 
 // Load loads configuration from user, project, and environment sources.
-// Priority: Environment variables > Project config > User config > Defaults
+// Priority: Environment variables > Profile > Package config > Project config >
+// Workspace config > User config > Defaults. In a monorepo workspace the
+// selected package's specs_dir and constitution apply over the profile.
+// CLI flags take precedence over all of these; global flags that map to config
+// keys are exported to their AUTOSPEC_* variables before loading.
 //
 // New YAML config paths:
 //   - User config: ~/.config/autospec/config.yml (XDG compliant)
@@ -159,10 +494,40 @@ func LoadWithOptions(opts LoadOptions) (*Configuration, error) {
 		return nil, err
 	}
 
+	ws, err := findWorkspace(opts.ProjectConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := resolvePackageName(ws, opts.Package)
+	if err != nil {
+		return nil, err
+	}
+	if pkg != "" && !ws.isProject {
+		if err := loadYAMLConfig(k, ws.configPath, "workspace"); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := loadProjectConfig(k, opts.ProjectConfigPath, warningWriter, opts.SkipWarnings); err != nil {
 		return nil, err
 	}
 
+	var pkgConfig *koanf.Koanf
+	if pkg != "" {
+		if pkgConfig, err = loadPackageConfig(k, ws, pkg, opts.ProjectConfigPath); err != nil {
+			return nil, err
+		}
+	}
+
+	profile := resolveProfileName(k, opts.Profile)
+	if err := applyProfile(k, profile); err != nil {
+		return nil, err
+	}
+
+	if pkg != "" {
+		applyPackage(k, ws, pkg, pkgConfig)
+	}
+
 	if err := loadEnvironmentConfig(k); err != nil {
 		return nil, err
 	}
@@ -171,6 +536,11 @@ func LoadWithOptions(opts LoadOptions) (*Configuration, error) {
 	if err != nil {
 		return nil, err
 	}
+	cfg.Profile = profile
+	cfg.Package = pkg
+	if ws != nil {
+		cfg.Packages = ws.packages
+	}
 
 	// Track AutoCommit source for migration notice
 	cfg.AutoCommitSource = detectAutoCommitSource(opts)
@@ -293,6 +663,38 @@ func loadEnvironmentConfig(k *koanf.Koanf) error {
 	return nil
 }
 
+// resolveProfileName returns the profile to apply.
+// Priority: LoadOptions.Profile > AUTOSPEC_PROFILE > profile key in config files.
+func resolveProfileName(k *koanf.Koanf, optProfile string) string {
+	if optProfile != "" {
+		return optProfile
+	}
+	if envProfile := os.Getenv("AUTOSPEC_PROFILE"); envProfile != "" {
+		return envProfile
+	}
+	return k.String("profile")
+}
+
+// applyProfile merges the named profile's settings over the loaded config.
+// An empty name is a no-op; an unknown name is an error listing the defined profiles.
+func applyProfile(k *koanf.Koanf, name string) error {
+	if name == "" {
+		return nil
+	}
+	key := "profiles." + name
+	if !k.Exists(key) {
+		available := k.MapKeys("profiles")
+		if len(available) == 0 {
+			return fmt.Errorf("unknown profile %q: no profiles defined in config", name)
+		}
+		return fmt.Errorf("unknown profile %q; available: %s", name, strings.Join(available, ", "))
+	}
+	if err := k.Merge(k.Cut(key)); err != nil {
+		return fmt.Errorf("applying profile %q: %w", name, err)
+	}
+	return nil
+}
+
 // detectAutoCommitSource determines where the auto_commit setting came from.
 // Checks in priority order: env > project > user > default.
 func detectAutoCommitSource(opts LoadOptions) ConfigSource {
@@ -362,7 +764,10 @@ func finalizeConfigWithWarnings(k *koanf.Koanf, warningWriter io.Writer, skipWar
 	}
 
 	cfg.StateDir = expandHomePath(cfg.StateDir)
+	cfg.Sync.Dir = expandHomePath(cfg.Sync.Dir)
 	cfg.SpecsDir = expandHomePath(cfg.SpecsDir)
+	cfg.Constitution = expandHomePath(cfg.Constitution)
+	cfg.SpecNamespace = strings.ToUpper(cfg.SpecNamespace)
 
 	if os.Getenv("AUTOSPEC_YES") != "" {
 		cfg.SkipConfirmations = true
@@ -380,10 +785,37 @@ func fileExists(path string) bool {
 	return err == nil
 }
 
+// envAliases maps shorthand environment variables to the config key they set.
+// The canonical AUTOSPEC_<KEY> variable wins when both are set.
+var envAliases = map[string]string{
+	"AUTOSPEC_AGENT": "agent_preset",
+}
+
+// nestedEnvSections lists config sections whose fields are set with
+// AUTOSPEC_<SECTION>_<FIELD>, e.g. AUTOSPEC_NOTIFICATIONS_ON_ERROR or
+// AUTOSPEC_VALIDATION_TESTS_COMMAND for the nested validation.tests section.
+var nestedEnvSections = []string{"notifications", "metrics", "sync", "history_backend", "retry_policy", "sub_agent", "model", "ollama", "sandbox", "worktree", "validation.tests", "budget.run", "budget.spec"}
+
 // envTransform converts environment variable names to config keys
 // Example: AUTOSPEC_MAX_RETRIES -> max_retries
+// Example: AUTOSPEC_NOTIFICATIONS_ON_ERROR -> notifications.on_error
+// Example: AUTOSPEC_AGENT -> agent_preset (ignored if AUTOSPEC_AGENT_PRESET is set)
+// Returns "" to skip a variable.
 func envTransform(s string) string {
-	return strings.Replace(strings.ToLower(strings.TrimPrefix(s, "AUTOSPEC_")), "_", "_", -1)
+	if key, ok := envAliases[s]; ok {
+		if os.Getenv("AUTOSPEC_"+strings.ToUpper(key)) != "" {
+			return ""
+		}
+		return key
+	}
+
+	key := strings.ToLower(strings.TrimPrefix(s, "AUTOSPEC_"))
+	for _, section := range nestedEnvSections {
+		if field, ok := strings.CutPrefix(key, strings.ReplaceAll(section, ".", "_")+"_"); ok && field != "" {
+			return section + "." + field
+		}
+	}
+	return key
 }
 
 // expandHomePath expands ~ to the user's home directory
@@ -397,6 +829,46 @@ func expandHomePath(path string) string {
 	return path
 }
 
+// HistoryRetention returns the history limits from max_history_entries,
+// max_history_age and history_archive. An invalid age is treated as no limit;
+// ValidateConfigValues reports it when the config is loaded.
+func (c *Configuration) HistoryRetention() history.Retention {
+	age, _ := history.ParseAge(c.MaxHistoryAge)
+	return history.Retention{
+		MaxEntries: c.MaxHistoryEntries,
+		MaxAge:     age,
+		Archive:    c.HistoryArchive,
+	}
+}
+
+// SharedHistory returns the shared history backend configured by
+// history_backend, or nil if none is.
+func (c *Configuration) SharedHistory() history.SharedBackend {
+	switch c.HistoryBackend.Type {
+	case history.BackendSQLite:
+		return &history.SQLiteBackend{Path: c.sharedHistoryPath()}
+	case history.BackendHTTP:
+		return history.NewHTTPBackend(c.HistoryBackend.URL, c.HistoryBackend.Headers)
+	}
+	return nil
+}
+
+// sharedHistoryPath resolves the sqlite history path against the
+// repository root, or the current directory outside a repository.
+func (c *Configuration) sharedHistoryPath() string {
+	path := c.HistoryBackend.Path
+	if path == "" {
+		path = history.DefaultSQLitePath
+	}
+	if filepath.IsAbs(path) {
+		return path
+	}
+	if root, err := git.GetRepositoryRoot(); err == nil {
+		return filepath.Join(root, path)
+	}
+	return path
+}
+
 // GetAgent returns a CLI agent based on configuration priority.
 // Priority: custom_agent > agent_preset > default (claude).
 // Returns error if the selected agent is invalid or not found in registry.
@@ -408,7 +880,7 @@ func (c *Configuration) GetAgent() (cliagent.Agent, error) {
 
 	// Second priority: agent_preset (built-in agent by name)
 	if c.AgentPreset != "" {
-		agent := cliagent.Get(c.AgentPreset)
+		agent := c.builtinAgent(c.AgentPreset)
 		if agent == nil {
 			return nil, fmt.Errorf("unknown agent preset %q; available: %v", c.AgentPreset, cliagent.List())
 		}
@@ -422,3 +894,29 @@ func (c *Configuration) GetAgent() (cliagent.Agent, error) {
 	}
 	return agent, nil
 }
+
+// GetStageAgents returns the agents configured in stage_agents, keyed by stage
+// name. Stages without an entry use GetAgent().
+func (c *Configuration) GetStageAgents() (map[string]cliagent.Agent, error) {
+	if len(c.StageAgents) == 0 {
+		return nil, nil
+	}
+	agents := make(map[string]cliagent.Agent, len(c.StageAgents))
+	for stage, name := range c.StageAgents {
+		agent := c.builtinAgent(name)
+		if agent == nil {
+			return nil, fmt.Errorf("unknown agent %q in stage_agents.%s; available: %v", name, stage, cliagent.List())
+		}
+		agents[stage] = agent
+	}
+	return agents, nil
+}
+
+// builtinAgent returns the registered agent with the given name, applying
+// agent-specific config sections. Returns nil for unknown names.
+func (c *Configuration) builtinAgent(name string) cliagent.Agent {
+	if name == "ollama" {
+		return cliagent.NewOllamaFromConfig(c.Ollama)
+	}
+	return cliagent.Get(name)
+}
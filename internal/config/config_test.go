@@ -9,8 +9,11 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/notify"
+	"github.com/ariel-frischer/autospec/internal/retry"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -209,6 +212,80 @@ func TestLoad_TimeoutZero(t *testing.T) {
 	assert.Equal(t, 0, cfg.Timeout, "Timeout=0 should be valid (no timeout)")
 }
 
+func TestLoad_RetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+
+	configContent := `retry_policy:
+  type: linear
+  initial_delay: 10s
+  max_delay: 1m
+  stages:
+    implement:
+      type: fixed
+      initial_delay: 30s
+`
+	err := os.WriteFile(configPath, []byte(configContent), 0644)
+	require.NoError(t, err)
+
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, retry.PolicyLinear, cfg.RetryPolicy.Type)
+	assert.Equal(t, 10*time.Second, cfg.RetryPolicy.InitialDelay)
+	assert.Equal(t, time.Minute, cfg.RetryPolicy.MaxDelay)
+
+	implement := cfg.RetryPolicy.ForStage("implement")
+	assert.Equal(t, retry.PolicyFixed, implement.Type)
+	assert.Equal(t, 30*time.Second, implement.InitialDelay)
+}
+
+func TestLoad_PhaseTimeouts(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+	configContent := `phase_timeouts:
+  specify: 10m
+  implement: 2h
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{
+		"specify":   10 * time.Minute,
+		"implement": 2 * time.Hour,
+	}, cfg.PhaseTimeouts)
+}
+
+func TestLoad_NotificationWebhooks(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+
+	configContent := `notifications:
+  webhooks:
+    - url: https://hooks.slack.com/services/T0/B0/x
+      format: slack
+      events: [phase.failed, retries.exhausted]
+    - url: https://dashboard.example.com/autospec
+      headers:
+        Authorization: Bearer ${DASHBOARD_TOKEN}
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	cfg, err := Load(configPath)
+	require.NoError(t, err)
+	require.Len(t, cfg.Notifications.Webhooks, 2)
+	assert.Equal(t, notify.FormatSlack, cfg.Notifications.Webhooks[0].Format)
+	assert.Equal(t, []string{"phase.failed", "retries.exhausted"}, cfg.Notifications.Webhooks[0].Events)
+	assert.Equal(t, "Bearer ${DASHBOARD_TOKEN}", cfg.Notifications.Webhooks[1].Headers["Authorization"])
+	assert.False(t, cfg.Notifications.Enabled, "webhooks do not turn on desktop notifications")
+}
+
 func TestLoad_TimeoutInvalid_Negative(t *testing.T) {
 	t.Parallel()
 
@@ -574,6 +651,105 @@ state_dir: "~/.autospec/state"
 	assert.Equal(t, "claude", cfg.AgentPreset)
 }
 
+func TestLoad_Profiles(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Setenv
+
+	userConfig := `agent_preset: claude
+max_retries: 1
+timeout: 600
+profiles:
+  work:
+    agent_preset: gemini
+    specs_dir: ./client-specs
+    max_retries: 3
+  oss:
+    agent_preset: opencode
+    timeout: 1200
+`
+	projectConfig := `timeout: 900
+`
+
+	tests := map[string]struct {
+		optProfile     string
+		envProfile     string
+		projectProfile string
+		envRetries     string
+		wantProfile    string
+		wantAgent      string
+		wantSpecsDir   string
+		wantRetries    int
+		wantTimeout    int
+		wantErr        string
+	}{
+		"no profile": {
+			wantAgent: "claude", wantSpecsDir: "./specs", wantRetries: 1, wantTimeout: 900,
+		},
+		"option selects profile": {
+			optProfile:  "work",
+			wantProfile: "work", wantAgent: "gemini", wantSpecsDir: "./client-specs", wantRetries: 3, wantTimeout: 900,
+		},
+		"env selects profile": {
+			envProfile:  "oss",
+			wantProfile: "oss", wantAgent: "opencode", wantSpecsDir: "./specs", wantRetries: 1, wantTimeout: 1200,
+		},
+		"option overrides env": {
+			optProfile: "work", envProfile: "oss",
+			wantProfile: "work", wantAgent: "gemini", wantSpecsDir: "./client-specs", wantRetries: 3, wantTimeout: 900,
+		},
+		"profile key in project config": {
+			projectProfile: "oss",
+			wantProfile:    "oss", wantAgent: "opencode", wantSpecsDir: "./specs", wantRetries: 1, wantTimeout: 1200,
+		},
+		"env vars override profile": {
+			optProfile: "work", envRetries: "7",
+			wantProfile: "work", wantAgent: "gemini", wantSpecsDir: "./client-specs", wantRetries: 7, wantTimeout: 900,
+		},
+		"unknown profile": {
+			optProfile: "missing",
+			wantErr:    `unknown profile "missing"; available: oss, work`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			userConfigPath := filepath.Join(tmpDir, "user.yml")
+			require.NoError(t, os.WriteFile(userConfigPath, []byte(userConfig), 0644))
+
+			project := projectConfig
+			if tt.projectProfile != "" {
+				project += "profile: " + tt.projectProfile + "\n"
+			}
+			projectConfigPath := filepath.Join(tmpDir, "project.yml")
+			require.NoError(t, os.WriteFile(projectConfigPath, []byte(project), 0644))
+
+			t.Setenv("AUTOSPEC_PROFILE", tt.envProfile)
+			if tt.envRetries != "" {
+				t.Setenv("AUTOSPEC_MAX_RETRIES", tt.envRetries)
+			}
+
+			cfg, err := LoadWithOptions(LoadOptions{
+				UserConfigPath:    userConfigPath,
+				ProjectConfigPath: projectConfigPath,
+				Profile:           tt.optProfile,
+				SkipWarnings:      true,
+			})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantProfile, cfg.Profile)
+			assert.Equal(t, tt.wantAgent, cfg.AgentPreset)
+			assert.Equal(t, tt.wantSpecsDir, cfg.SpecsDir)
+			assert.Equal(t, tt.wantRetries, cfg.MaxRetries)
+			assert.Equal(t, tt.wantTimeout, cfg.Timeout)
+		})
+	}
+}
+
 func TestLoad_UserYAMLWithLegacyJSONWarning(t *testing.T) {
 	// Test the case where user YAML exists alongside legacy JSON
 	tmpDir := t.TempDir()
@@ -782,7 +958,23 @@ func TestEnvTransform(t *testing.T) {
 		},
 		"nested": {
 			input:    "AUTOSPEC_NOTIFICATIONS_TYPE",
-			expected: "notifications_type",
+			expected: "notifications.type",
+		},
+		"nested with underscores": {
+			input:    "AUTOSPEC_NOTIFICATIONS_ON_LONG_RUNNING",
+			expected: "notifications.on_long_running",
+		},
+		"nested retry policy": {
+			input:    "AUTOSPEC_RETRY_POLICY_INITIAL_DELAY",
+			expected: "retry_policy.initial_delay",
+		},
+		"doubly nested validation tests": {
+			input:    "AUTOSPEC_VALIDATION_TESTS_PASS_THRESHOLD",
+			expected: "validation.tests.pass_threshold",
+		},
+		"agent alias": {
+			input:    "AUTOSPEC_AGENT",
+			expected: "agent_preset",
 		},
 	}
 
@@ -797,6 +989,73 @@ func TestEnvTransform(t *testing.T) {
 	}
 }
 
+func TestLoad_EnvLayer(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Setenv
+
+	tests := map[string]struct {
+		env   map[string]string
+		check func(t *testing.T, cfg *Configuration)
+	}{
+		"top-level keys": {
+			env: map[string]string{
+				"AUTOSPEC_MAX_RETRIES":    "4",
+				"AUTOSPEC_SPECS_DIR":      "./env-specs",
+				"AUTOSPEC_SKIP_PREFLIGHT": "true",
+			},
+			check: func(t *testing.T, cfg *Configuration) {
+				assert.Equal(t, 4, cfg.MaxRetries)
+				assert.Equal(t, "./env-specs", cfg.SpecsDir)
+				assert.True(t, cfg.SkipPreflight)
+			},
+		},
+		"agent alias": {
+			env: map[string]string{"AUTOSPEC_AGENT": "gemini"},
+			check: func(t *testing.T, cfg *Configuration) {
+				assert.Equal(t, "gemini", cfg.AgentPreset)
+			},
+		},
+		"canonical name beats alias": {
+			env: map[string]string{"AUTOSPEC_AGENT": "gemini", "AUTOSPEC_AGENT_PRESET": "codex"},
+			check: func(t *testing.T, cfg *Configuration) {
+				assert.Equal(t, "codex", cfg.AgentPreset)
+			},
+		},
+		"nested keys": {
+			env: map[string]string{
+				"AUTOSPEC_NOTIFICATIONS_ENABLED":                "true",
+				"AUTOSPEC_NOTIFICATIONS_LONG_RUNNING_THRESHOLD": "5m",
+				"AUTOSPEC_RETRY_POLICY_TYPE":                    "fixed",
+			},
+			check: func(t *testing.T, cfg *Configuration) {
+				assert.True(t, cfg.Notifications.Enabled)
+				assert.Equal(t, 5*time.Minute, cfg.Notifications.LongRunningThreshold)
+				assert.Equal(t, retry.PolicyFixed, cfg.RetryPolicy.Type)
+				// Unset fields in the section keep their defaults
+				assert.True(t, cfg.Notifications.OnError)
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			projectConfigPath := filepath.Join(t.TempDir(), "config.yml")
+			require.NoError(t, os.WriteFile(projectConfigPath, []byte("agent_preset: claude\nmax_retries: 1\n"), 0644))
+
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			cfg, err := LoadWithOptions(LoadOptions{
+				UserConfigPath:    filepath.Join(t.TempDir(), "missing.yml"),
+				ProjectConfigPath: projectConfigPath,
+				SkipWarnings:      true,
+			})
+			require.NoError(t, err)
+			tt.check(t, cfg)
+		})
+	}
+}
+
 func TestGetWarningWriter(t *testing.T) {
 	t.Parallel()
 
@@ -879,7 +1138,7 @@ func TestConfiguration_GetAgent_Priority(t *testing.T) {
 func TestConfiguration_GetAgent_AllPresets(t *testing.T) {
 	t.Parallel()
 
-	presets := []string{"claude", "cline", "gemini", "codex", "opencode", "goose"}
+	presets := []string{"claude", "cline", "gemini", "codex", "opencode", "goose", "aider", "ollama"}
 	for _, preset := range presets {
 		t.Run(preset, func(t *testing.T) {
 			t.Parallel()
@@ -891,6 +1150,66 @@ func TestConfiguration_GetAgent_AllPresets(t *testing.T) {
 	}
 }
 
+func TestConfiguration_GetAgent_OllamaConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := Configuration{
+		AgentPreset: "ollama",
+		Ollama:      cliagent.OllamaConfig{Host: "http://gpu-box:11434", Model: "qwen2.5-coder:32b"},
+	}
+	agent, err := cfg.GetAgent()
+	require.NoError(t, err)
+
+	ollama, ok := agent.(*cliagent.Ollama)
+	require.True(t, ok, "expected *cliagent.Ollama, got %T", agent)
+	assert.Equal(t, "http://gpu-box:11434", ollama.Host)
+	assert.Equal(t, "qwen2.5-coder:32b", ollama.Model)
+}
+
+func TestConfiguration_GetStageAgents(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		stageAgents map[string]string
+		want        map[string]string // stage -> agent name
+		wantErr     string
+	}{
+		"unset": {
+			want: map[string]string{},
+		},
+		"local model for document stages": {
+			stageAgents: map[string]string{"specify": "ollama", "plan": "ollama", "tasks": "gemini"},
+			want:        map[string]string{"specify": "ollama", "plan": "ollama", "tasks": "gemini"},
+		},
+		"unknown agent": {
+			stageAgents: map[string]string{"plan": "llama"},
+			wantErr:     `unknown agent "llama" in stage_agents.plan`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cfg := Configuration{StageAgents: tt.stageAgents, Ollama: cliagent.OllamaConfig{Model: "llama3.1"}}
+			agents, err := cfg.GetStageAgents()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			got := make(map[string]string, len(agents))
+			for stage, agent := range agents {
+				got[stage] = agent.Name()
+			}
+			assert.Equal(t, tt.want, got)
+			if ollama, ok := agents["specify"].(*cliagent.Ollama); ok {
+				assert.Equal(t, "llama3.1", ollama.Model, "ollama config should apply to stage agents")
+			}
+		})
+	}
+}
+
 func TestLoad_AgentPresetFromYAML(t *testing.T) {
 	t.Parallel()
 
@@ -31,21 +31,188 @@ func GetDefaultConfigTemplate() string {
 # ============================================================================
 
 # Agent settings
-agent_preset: ""                      # Built-in agent: claude | gemini | cline | codex | opencode | goose
+agent_preset: ""                      # Built-in agent: claude | gemini | cline | codex | opencode | goose | aider | ollama
 use_subscription: true                # Force subscription mode (no API charges); set false to use API key
+# sub_agent:                          # Named agent inside the agent CLI (opencode --agent)
+#   name: build                       # Used for every stage without an override
+#   stages:
+#     plan: plan
+# model:                              # Model passed with the agent's --model flag
+#   name: sonnet                      # Used for every stage without an override
+#   stages:
+#     specify: haiku                  # Cheap model for document generation
+#     implement: opus                 # Stronger model for code
+# stage_agents:                       # Run stages with a different built-in agent
+#   specify: ollama
+#   plan: ollama
+#   tasks: ollama
+ollama:                               # Local models served by Ollama (agent_preset/stage_agents: ollama)
+  host: ""                            # Server URL (empty = $OLLAMA_HOST or http://localhost:11434)
+  model: qwen2.5-coder:7b             # Model to run; must be pulled first (ollama pull <model>)
+# sandbox:                            # Run the agent in a container with only the project mounted
+#   enabled: true
+#   runtime: docker                   # docker | podman
+#   image: my/agent-sandbox:latest    # Must contain the agent CLI and autospec
+#   memory: 4g
+#   cpus: "2"
+#   network: ""                       # e.g. none (agents calling a hosted model need network)
+#   mounts:                           # Extra volumes, e.g. agent credentials
+#     - ~/.claude:/home/agent/.claude
+#   env: []                           # Extra host variables passed into the container
+#   stages: [implement]               # Empty = every stage
 
 # Workflow settings
 max_retries: 0                        # Max retry attempts per stage (0-10)
 specs_dir: ./specs                    # Directory for feature specs
+# spec_namespace: API                 # Prefix spec numbers (API-001); each namespace is numbered separately
+branch_template: "{number}-{slug}"    # Git branch for new specs, e.g. "feat/{number}-{slug}"
+branch_max_words: 0                   # Max words in {slug} (0 = 3, or 4 if exactly 4)
+skip_branch: false                    # Don't create git branches (trunk-based workflows)
+vcs: auto                             # Version control: auto | git | jj | none
 state_dir: ~/.autospec/state          # Directory for state files
+state_store: file                     # State storage: file | sqlite (state_dir/state.db, needs sqlite3)
 skip_preflight: false                 # Skip preflight checks
 timeout: 2400                         # Timeout in seconds (40 min default, 0 = no timeout)
 skip_confirmations: false             # Skip confirmation prompts
 implement_method: phases              # Default: phases | tasks | single-session
 auto_commit: false                    # Auto-create git commit after workflow (disabled by default)
+task_commits: false                   # Commit after each task in --tasks mode (feat(T014): ...)
+on_spec_change: warn                  # When spec.yaml changes after plan/tasks: warn | replan | ignore
+session_mode: fresh                   # Agent session per stage: fresh | continuous (resume across a spec's stages)
+on_unreachable: ask                   # Agent offline or logged out: ask | queue (for 'autospec queue retry-pending') | fail
+stall_timeout: 0                      # Stop an agent that prints nothing for this long (e.g. 15m; 0 = never)
+on_stall: retry                       # After stopping a stalled agent: retry (uses max_retries) | fail
+escalate_blocked_after: 0             # Escalate tasks implement finds blocked longer than this (e.g. 24h; 0 = never)
+escalate_blocked: notify              # How: notify (notification + task.escalated webhook) | issue (GitHub issue) | both
+
+# Per-stage agent time limits, overriding timeout for that stage.
+# phase_timeouts:
+#   specify: 10m
+#   implement: 2h
+
+# Retry backoff between attempts (used when max_retries > 0)
+retry_policy:
+  type: none                          # none | fixed | linear | exponential
+  initial_delay: 5s                   # Delay before the first retry
+  max_delay: 2m                       # Upper bound on any delay (0 = no cap)
+  multiplier: 2                       # Growth factor for exponential backoff
+  jitter: false                       # Randomize delays to 50-100% of computed value
+  rate_limit_waits: 3                 # Rate limits per stage waited out without using a retry
+  rate_limit_delay: 1m                # Wait when the agent gives no retry-after hint
+  # stages:                           # Per-stage overrides (unset fields inherit)
+  #   implement:
+  #     type: fixed
+  #     initial_delay: 30s
+
+# Token/cost ceilings; a run stops before the next agent session once one is
+# reached (0 = unlimited). Re-run with --resume after raising the limit.
+# budget:
+#   run:                              # One autospec command
+#     cost_usd: 5
+#     tokens: 0
+#   spec:                             # Everything spent on one spec, across runs
+#     cost_usd: 20
+#     tokens: 0
+
+# Named profiles override any setting above when selected with --profile <name>,
+# AUTOSPEC_PROFILE=<name>, or a top-level "profile: <name>" key.
+# profiles:
+#   work:
+#     agent_preset: claude
+#     specs_dir: ./specs
+#     max_retries: 3
+#     timeout: 3600
+#   oss:
+#     agent_preset: opencode
+#     max_retries: 1
+
+# Monorepo packages, each with its own specs dir and constitution (paths are
+# relative to this directory). Commands run inside a package directory use it
+# automatically; elsewhere select one with --package <name> or
+# AUTOSPEC_PACKAGE=<name>. A package's own <path>/.autospec/config.yml is
+# applied over this file.
+# packages:
+#   api:
+#     path: services/api              # specs_dir defaults to services/api/specs
+#   web:
+#     path: services/web
+#     specs_dir: services/web/docs/specs
+#     constitution: services/web/.autospec/memory/constitution.yaml
+
+# Extra validation per stage, run after built-in validation passes.
+# A non-zero exit fails validation and stderr is fed into the retry prompt.
+# post_validate:
+#   plan: ./scripts/check-plan.sh
+
+# Test suite run after each implement session (each phase or task).
+# The session only succeeds when tests pass; failures are fed into the retry.
+validation:
+  tests:
+    command: ""                       # e.g. "go test ./..." (empty = disabled)
+    timeout: 10m                      # Upper bound on a test run
+    pass_threshold: 100               # Percent of tests that must pass (1-100)
+  # Build/lint commands run after each task is marked Completed (--tasks only).
+  # A failure resets the task to InProgress and retries it with the output.
+  # task_gate:
+  #   - go build ./...
+  #   - go vet ./...
+
+# Commands run before/after each stage session (each phase or task of implement).
+# A failing hook is treated like a validation failure and retried with its output.
+# hooks:
+#   pre_implement: make lint
+#   post_implement: make test
+
+# Steps run by 'autospec full' (default: specify, plan, tasks, implement).
+# Stages keep their canonical order; custom steps run a shell command.
+# pipeline: [specify, clarify, plan, tasks, lint-plan, implement]
+# pipeline_commands:
+#   lint-plan: ./scripts/lint-plan.sh
+# Custom agent phases for the pipeline: a prompt template plus a validation command.
+# custom_phases:
+#   security-review:
+#     prompt: .autospec/prompts/security-review.tmpl   # default path
+#     validate: ./scripts/check-security-report.sh
+
+# Project checks added to 'autospec doctor'. A check passes when its command
+# exits with expect_exit (default 0), its output matches expect_output and the
+# first version in its output is at least min_version. severity: warn reports
+# a failure without failing doctor.
+# doctor_checks:
+#   - name: Node.js
+#     command: node --version
+#     min_version: "20"
+#   - name: Docker daemon
+#     command: docker info
+#     severity: warn
 
 # History settings
 max_history_entries: 500              # Max command history entries to retain
+max_history_age: ""                   # Drop entries older than this, e.g. 90d (empty = no limit)
+history_archive: true                 # Move pruned entries to history-archive/*.jsonl.gz
+
+# Shared team history, written in addition to the local history
+# history_backend:
+#   type: sqlite                      # sqlite (file in the repo) or http
+#   path: .autospec/history.db        # sqlite: path from the repository root
+#   url: https://example.com/history  # http: entries are POSTed here as JSON
+#   headers:
+#     Authorization: "Bearer ${HISTORY_TOKEN}"
+max_sessions: 50                      # Agent transcripts kept for 'autospec sessions' (0 = off)
+max_snapshots: 20                     # Artifact snapshots kept per spec for 'autospec snapshots' (0 = off)
+
+# Usage metrics ('autospec metrics show'); nothing is sent unless upload is on
+metrics:
+  enabled: true                       # Record command and stage metrics locally
+  upload: false                       # Send anonymized aggregates daily (opt-in)
+  upload_url: ""                      # Endpoint for uploads
+
+# Where 'autospec sync' shares spec state between machines
+sync:
+  store: git                          # git (orphan branch) or dir
+  branch: autospec-state              # Branch for the git store
+  remote: origin                      # Remote the branch is pushed to
+  dir: ""                             # Directory for the dir store
 
 # View dashboard settings
 view_limit: 5                         # Number of recent specs to display
@@ -77,6 +244,15 @@ notifications:
   on_error: true                      # Notify on failures
   on_long_running: false              # Enable duration-based notifications
   long_running_threshold: 2m          # Threshold for long-running notification
+  on_input_required: true             # Notify when the agent seems to wait for input
+  input_idle_threshold: 10m           # Silence that counts as waiting for input (0 = prompts only)
+  bell: true                          # Ring the terminal bell when input is required (even if disabled)
+  # slack_webhook: https://hooks.slack.com/services/...  # or discord_webhook
+  # on: [phase_failed, workflow_complete]                # events for slack/discord
+  # webhooks:                         # POST workflow events (also in CI, no 'enabled' needed)
+  #   - url: https://hooks.slack.com/services/...
+  #     format: slack                 # json | slack | discord
+  #     events: [phase.failed, task.blocked, retries.exhausted]  # omit for all events
 `
 }
 
@@ -89,28 +265,95 @@ func GetDefaults() map[string]interface{} {
 		"max_retries":        0,
 		"specs_dir":          "./specs",
 		"state_dir":          "~/.autospec/state",
+		"state_store":        "file",
 		"skip_preflight":     false,
 		"timeout":            2400,  // 40 minutes default
 		"skip_confirmations": false, // Confirmation prompts enabled by default
+		// Branch naming for new specs: template, slug word limit (0 = 3-4 words), or no branch at all
+		"branch_template":  "{number}-{slug}",
+		"branch_max_words": 0,
+		"skip_branch":      false,
+		"vcs":              "auto", // auto detects jj, then git, else none
 		// implement_method: Default to "phases" for cost-efficient execution with context isolation.
 		// This changes the legacy behavior (single-session) to run each phase in a separate Claude session.
 		// Valid values: "single-session", "phases", "tasks"
 		"implement_method": "phases",
+		// ollama: Local model server for the ollama agent.
+		"ollama": map[string]interface{}{
+			"host":  "",
+			"model": "qwen2.5-coder:7b",
+		},
+		// retry_policy: Backoff between retry attempts. Defaults to retrying immediately.
+		// Per-stage overrides go under retry_policy.stages.<stage>.
+		"retry_policy": map[string]interface{}{
+			"type":             "none",
+			"initial_delay":    (5 * time.Second).String(),
+			"max_delay":        (2 * time.Minute).String(),
+			"multiplier":       2,
+			"jitter":           false,
+			"rate_limit_waits": 3,
+			"rate_limit_delay": time.Minute.String(),
+		},
+		// validation.tests: Test suite run after each implement session.
+		// Disabled until a command is set.
+		"validation": map[string]interface{}{
+			"tests": map[string]interface{}{
+				"command":        "",
+				"timeout":        (10 * time.Minute).String(),
+				"pass_threshold": 100,
+			},
+		},
 		// notifications: Notification settings for command and stage completion.
 		// Disabled by default (opt-in). When enabled, defaults to both sound and visual notifications.
 		"notifications": map[string]interface{}{
-			"enabled":                false,                      // Disabled by default (opt-in)
-			"type":                   "both",                     // Both sound and visual when enabled
-			"sound_file":             "",                         // Use system default sound
-			"on_command_complete":    true,                       // Notify when command finishes (default when enabled)
-			"on_stage_complete":      false,                      // Don't notify on each stage by default
-			"on_error":               true,                       // Notify on failures (default when enabled)
-			"on_long_running":        false,                      // Don't use duration threshold by default
-			"long_running_threshold": (2 * time.Minute).String(), // 2 minutes threshold
+			"enabled":                false,                       // Disabled by default (opt-in)
+			"type":                   "both",                      // Both sound and visual when enabled
+			"sound_file":             "",                          // Use system default sound
+			"on_command_complete":    true,                        // Notify when command finishes (default when enabled)
+			"on_stage_complete":      false,                       // Don't notify on each stage by default
+			"on_error":               true,                        // Notify on failures (default when enabled)
+			"on_long_running":        false,                       // Don't use duration threshold by default
+			"long_running_threshold": (2 * time.Minute).String(),  // 2 minutes threshold
+			"on_input_required":      true,                        // Notify when the agent seems to wait for input
+			"input_idle_threshold":   (10 * time.Minute).String(), // Silence that counts as waiting for input
+			"bell":                   true,                        // Ring the terminal bell when input is required
 		},
 		// max_history_entries: Maximum number of command history entries to retain.
 		// Oldest entries are pruned when this limit is exceeded.
 		"max_history_entries": 500,
+		// max_history_age: Entries older than this (e.g. "90d", "2w") are pruned.
+		// Empty keeps entries regardless of age.
+		"max_history_age": "",
+		// history_archive: Pruned entries are appended to compressed monthly
+		// archives in <state_dir>/history-archive/ instead of being discarded.
+		"history_archive": true,
+		// history_backend: Shared team history (sqlite or http), written in
+		// addition to history.yaml. Empty type disables it.
+		"history_backend": map[string]interface{}{
+			"type": "",
+			"path": ".autospec/history.db",
+			"url":  "",
+		},
+		// max_sessions: Number of agent transcripts kept under <state_dir>/sessions.
+		// Oldest transcripts are pruned first. 0 disables transcript capture.
+		"max_sessions": 50,
+		// max_snapshots: Snapshots of spec.yaml, plan.yaml and tasks.yaml kept per
+		// spec under <state_dir>/snapshots, taken before each stage. 0 disables them.
+		"max_snapshots": 20,
+		// metrics: Local usage metrics in <state_dir>/metrics.yaml.
+		// Uploading anonymized aggregates is opt-in.
+		"metrics": map[string]interface{}{
+			"enabled":    true,
+			"upload":     false,
+			"upload_url": "",
+		},
+		// sync: Store for 'autospec sync'; git keeps state on an orphan branch.
+		"sync": map[string]interface{}{
+			"store":  "git",
+			"branch": "autospec-state",
+			"remote": "origin",
+			"dir":    "",
+		},
 		// view_limit: Number of recent specs to display in the view command.
 		// Default: 5. Can be overridden with --limit flag.
 		"view_limit": 5,
@@ -135,5 +378,24 @@ func GetDefaults() map[string]interface{} {
 		// When true, instructions are injected to update .gitignore, stage files, and create commits.
 		// Default: false (disabled due to inconsistent behavior).
 		"auto_commit": false,
+		// task_commits: Commit after each completed task in task-level implementation.
+		"task_commits": false,
+		// on_spec_change: Warn when plan.yaml or tasks.yaml were generated from
+		// an older spec.yaml, rather than regenerating them unasked.
+		"on_spec_change": "warn",
+		// session_mode: Start every stage in a new agent session; continuous
+		// resumes the spec's previous session instead.
+		"session_mode": "fresh",
+		// on_unreachable: Offer to queue a stage whose agent can't reach its
+		// provider, instead of failing it outright.
+		"on_unreachable": "ask",
+		// stall_timeout: Stop agent sessions that print nothing for this long.
+		// Disabled by default; the overall timeout still applies.
+		"stall_timeout": "0s",
+		"on_stall":      "retry",
+		// escalate_blocked_after: Pull in a human when a task stays blocked.
+		// Disabled by default.
+		"escalate_blocked_after": "0s",
+		"escalate_blocked":       "notify",
 	}
 }
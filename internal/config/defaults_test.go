@@ -31,6 +31,8 @@ func TestGetDefaultConfigTemplate(t *testing.T) {
 		"skip_confirmations:",
 		"implement_method:",
 		"auto_commit:",
+		"retry_policy:",
+		"initial_delay:",
 		"History settings",
 		"max_history_entries:",
 		"Notifications",
@@ -69,6 +71,7 @@ func TestGetDefaults(t *testing.T) {
 		"timeout",
 		"skip_confirmations",
 		"implement_method",
+		"retry_policy",
 		"notifications",
 		"max_history_entries",
 		"default_agents",
@@ -0,0 +1,351 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"gopkg.in/yaml.v3"
+)
+
+// LintIssue describes one problem found in a config file by LintConfigFile.
+type LintIssue struct {
+	Key        string `json:"key,omitempty"`        // Dotted key path; empty for file-level problems
+	Line       int    `json:"line,omitempty"`       // 1-based line number, 0 if unknown
+	Message    string `json:"message"`              // What is wrong
+	Suggestion string `json:"suggestion,omitempty"` // How to fix it, if known
+}
+
+// String formats the issue as "line N: key: message".
+func (i LintIssue) String() string {
+	var b strings.Builder
+	if i.Line > 0 {
+		fmt.Fprintf(&b, "line %d: ", i.Line)
+	}
+	if i.Key != "" {
+		b.WriteString(i.Key + ": ")
+	}
+	b.WriteString(i.Message)
+	return b.String()
+}
+
+// stageKeyedMaps lists config maps whose keys must be stage names.
+var stageKeyedMaps = []string{"post_validate", "phase_timeouts", "sub_agent.stages", "model.stages", "stage_agents", "retry_policy.stages"}
+
+var (
+	configType   = reflect.TypeOf(Configuration{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// LintConfigFile checks a YAML (or legacy JSON) config file against the
+// Configuration schema without merging it with other sources. It reports
+// syntax errors, unknown keys, type mismatches, invalid enum values, unknown
+// agent names, and incomplete custom_agent templates. An error is returned
+// only when the file cannot be read.
+func LintConfigFile(path string) ([]LintIssue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	return LintConfigData(data), nil
+}
+
+// LintConfigData is LintConfigFile for in-memory config content.
+func LintConfigData(data []byte) []LintIssue {
+	if strings.TrimSpace(string(data)) == "" {
+		return nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		line, _ := extractLineColumn(err.Error())
+		return []LintIssue{{
+			Line:    line,
+			Message: "invalid syntax: " + cleanYAMLError(err.Error()),
+		}}
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+
+	l := &linter{}
+	l.lintValue(doc.Content[0], configType, "")
+	return l.issues
+}
+
+// linter accumulates issues while walking a YAML node tree alongside the Go
+// types its values decode into.
+type linter struct {
+	issues []LintIssue
+}
+
+func (l *linter) add(node *yaml.Node, key, message, suggestion string) {
+	l.issues = append(l.issues, LintIssue{Key: key, Line: node.Line, Message: message, Suggestion: suggestion})
+}
+
+// lintValue checks node against t, the type of the config field at key.
+func (l *linter) lintValue(node *yaml.Node, t reflect.Type, key string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Tag == "!!null" {
+		return // Unset values fall back to lower-priority sources
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == durationType:
+		l.lintDuration(node, key)
+	case t.Kind() == reflect.Struct:
+		l.lintStruct(node, t, key)
+	case t.Kind() == reflect.Map:
+		l.lintMap(node, t, key)
+	case t.Kind() == reflect.Slice:
+		if !l.expectKind(node, yaml.SequenceNode, key, "a list", "use a YAML list, e.g. [a, b]") {
+			return
+		}
+		for i, item := range node.Content {
+			l.lintValue(item, t.Elem(), fmt.Sprintf("%s[%d]", key, i))
+		}
+	case t.Kind() == reflect.Bool:
+		l.lintScalar(node, key, "!!bool", "a boolean", "use true or false without quotes")
+	case t.Kind() == reflect.Int:
+		l.lintScalar(node, key, "!!int", "an integer", "use a whole number without quotes")
+	case t.Kind() == reflect.String:
+		if l.expectKind(node, yaml.ScalarNode, key, "a string", "") {
+			l.lintStringValue(node, key)
+		}
+	}
+}
+
+// lintStruct checks a mapping against the koanf-tagged fields of t.
+func (l *linter) lintStruct(node *yaml.Node, t reflect.Type, key string) {
+	if !l.expectKind(node, yaml.MappingNode, key, "a mapping", "") {
+		return
+	}
+
+	fields := koanfFields(t)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		k, v := node.Content[i], node.Content[i+1]
+		child := joinKey(key, k.Value)
+		field, ok := fields[k.Value]
+		if !ok {
+			l.add(k, child, "unknown key", suggestName(k.Value, mapKeys(fields), "remove it or check the spelling"))
+			continue
+		}
+		l.lintValue(v, field.Type, child)
+	}
+
+	if schemaKey(key) == "custom_agent" {
+		l.lintCustomAgent(node, key)
+	}
+}
+
+// lintMap checks a mapping with free-form keys. Profiles are linted as full
+// configs, stage-keyed maps must use known stage names and hooks must use
+// pre_<stage>/post_<stage> names.
+func (l *linter) lintMap(node *yaml.Node, t reflect.Type, key string) {
+	if !l.expectKind(node, yaml.MappingNode, key, "a mapping", "") {
+		return
+	}
+
+	elem := t.Elem()
+	if key == "profiles" {
+		elem = configType
+	}
+	stageKeyed := slices.Contains(stageKeyedMaps, schemaKey(key))
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		k, v := node.Content[i], node.Content[i+1]
+		child := joinKey(key, k.Value)
+		if stageKeyed && !slices.Contains(configStages, k.Value) {
+			l.add(k, child, "unknown stage",
+				suggestName(k.Value, configStages, "valid stages: "+strings.Join(configStages, ", ")))
+			continue
+		}
+		if schemaKey(key) == "hooks" && !slices.Contains(hookNames, k.Value) {
+			l.add(k, child, "unknown hook",
+				suggestName(k.Value, hookNames, "use pre_<stage> or post_<stage>"))
+			continue
+		}
+		l.lintValue(v, elem, child)
+	}
+}
+
+// lintScalar checks that node is a scalar with the given YAML tag.
+func (l *linter) lintScalar(node *yaml.Node, key, tag, want, suggestion string) {
+	if node.Kind != yaml.ScalarNode || node.Tag != tag {
+		l.add(node, key, fmt.Sprintf("expected %s, got %s", want, describeNode(node)), suggestion)
+	}
+}
+
+// lintDuration accepts Go duration strings (5s, 1h30m) and a bare 0.
+func (l *linter) lintDuration(node *yaml.Node, key string) {
+	if node.Kind == yaml.ScalarNode {
+		if node.Tag == "!!int" && node.Value == "0" {
+			return
+		}
+		if _, err := time.ParseDuration(node.Value); err == nil && node.Tag == "!!str" {
+			return
+		}
+	}
+	l.add(node, key, fmt.Sprintf("expected a duration, got %s", describeNode(node)),
+		"use a duration such as 30s, 2m or 1h30m")
+}
+
+// lintStringValue checks string values that must be an enum option or an agent name.
+func (l *linter) lintStringValue(node *yaml.Node, key string) {
+	if node.Value == "" {
+		return // Empty means "use the default"
+	}
+	sk := schemaKey(key)
+	if sk == "agent_preset" || strings.HasPrefix(sk, "default_agents[") || strings.HasPrefix(sk, "stage_agents.") {
+		if cliagent.Get(node.Value) == nil {
+			agents := cliagent.List()
+			l.add(node, key, fmt.Sprintf("unknown agent %q", node.Value),
+				suggestName(node.Value, agents, "available agents: "+strings.Join(agents, ", ")))
+		}
+		return
+	}
+
+	schema, ok := KnownKeys[sk]
+	if !ok || schema.Type != TypeEnum || slices.Contains(schema.AllowedValues, node.Value) {
+		return
+	}
+	l.add(node, key, fmt.Sprintf("invalid value %q", node.Value),
+		suggestName(node.Value, schema.AllowedValues, "valid options: "+strings.Join(schema.AllowedValues, ", ")))
+}
+
+// lintCustomAgent checks that a custom_agent mapping has a command and an
+// args template containing the prompt placeholder.
+func (l *linter) lintCustomAgent(node *yaml.Node, key string) {
+	var agent cliagent.CustomAgentConfig
+	if err := node.Decode(&agent); err != nil {
+		return // Type errors were already reported field by field
+	}
+	if strings.TrimSpace(agent.Command) == "" {
+		l.add(node, key+".command", "is required", `set the agent executable, e.g. command: "claude"`)
+	}
+	for _, arg := range agent.Args {
+		if strings.Contains(arg, "{{PROMPT}}") {
+			return
+		}
+	}
+	l.add(node, key+".args", "must contain the {{PROMPT}} placeholder",
+		`add "{{PROMPT}}" where the prompt goes, e.g. args: ["-p", "{{PROMPT}}"]`)
+}
+
+// expectKind reports a type mismatch unless node has the given kind.
+func (l *linter) expectKind(node *yaml.Node, kind yaml.Kind, key, want, suggestion string) bool {
+	if node.Kind == kind {
+		return true
+	}
+	l.add(node, key, fmt.Sprintf("expected %s, got %s", want, describeNode(node)), suggestion)
+	return false
+}
+
+// koanfFields maps koanf tag names to the struct fields of t.
+func koanfFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("koanf"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+// schemaKey maps a linted key to its KnownKeys form by dropping profile and
+// per-stage segments, e.g. profiles.ci.retry_policy.stages.plan.type -> retry_policy.type.
+func schemaKey(key string) string {
+	parts := strings.Split(key, ".")
+	if len(parts) > 2 && parts[0] == "profiles" {
+		parts = parts[2:]
+	}
+	if len(parts) > 3 && parts[0] == "retry_policy" && parts[1] == "stages" {
+		parts = append([]string{"retry_policy"}, parts[3:]...)
+	}
+	return strings.Join(parts, ".")
+}
+
+func joinKey(parent, child string) string {
+	if parent == "" {
+		return child
+	}
+	return parent + "." + child
+}
+
+func mapKeys(fields map[string]reflect.StructField) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// describeNode names the YAML type of node for error messages.
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+	switch node.Tag {
+	case "!!bool":
+		return "boolean " + node.Value
+	case "!!int":
+		return "integer " + node.Value
+	case "!!float":
+		return "number " + node.Value
+	default:
+		return fmt.Sprintf("string %q", node.Value)
+	}
+}
+
+// suggestName returns "did you mean ...?" for the candidate closest to name,
+// or fallback when none is within a small edit distance.
+func suggestName(name string, candidates []string, fallback string) string {
+	best, bestDist := "", 3
+	for _, c := range candidates {
+		if d := editDistance(name, c); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	if best == "" {
+		return fallback
+	}
+	return fmt.Sprintf("did you mean %q?", best)
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,205 @@
+// Package config_test tests config file linting against the typed schema.
+// Related: internal/config/lint.go
+// Tags: config, lint, validation, schema
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintConfigData(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		content        string
+		wantKey        string
+		wantLine       int
+		wantMessage    string
+		wantSuggestion string
+	}{
+		"valid config": {
+			content: "agent_preset: claude\nmax_retries: 3\nnotifications:\n  enabled: true\n  long_running_threshold: 2m\n",
+		},
+		"empty file": {
+			content: "   \n",
+		},
+		"syntax error": {
+			content:     "max_retries: 3\n  bad: [\n",
+			wantMessage: "invalid syntax",
+		},
+		"unknown top-level key": {
+			content:        "specs_dir: ./specs\nmax_retires: 3\n",
+			wantKey:        "max_retires",
+			wantLine:       2,
+			wantMessage:    "unknown key",
+			wantSuggestion: `did you mean "max_retries"?`,
+		},
+		"unknown nested key": {
+			content:        "notifications:\n  enabeld: true\n",
+			wantKey:        "notifications.enabeld",
+			wantMessage:    "unknown key",
+			wantSuggestion: `did you mean "enabled"?`,
+		},
+		"unknown key without close match": {
+			content:        "frobnicate: true\n",
+			wantKey:        "frobnicate",
+			wantSuggestion: "remove it or check the spelling",
+		},
+		"quoted integer": {
+			content:        `max_retries: "3"` + "\n",
+			wantKey:        "max_retries",
+			wantMessage:    `expected an integer, got string "3"`,
+			wantSuggestion: "use a whole number without quotes",
+		},
+		"string for boolean": {
+			content:     "notifications:\n  enabled: on-error\n",
+			wantKey:     "notifications.enabled",
+			wantMessage: "expected a boolean",
+		},
+		"mapping for string": {
+			content:     "specs_dir:\n  path: ./specs\n",
+			wantKey:     "specs_dir",
+			wantMessage: "expected a string, got a mapping",
+		},
+		"scalar for list": {
+			content:     "default_agents: claude\n",
+			wantKey:     "default_agents",
+			wantMessage: "expected a list",
+		},
+		"invalid duration": {
+			content:        "retry_policy:\n  initial_delay: 5 seconds\n",
+			wantKey:        "retry_policy.initial_delay",
+			wantMessage:    "expected a duration",
+			wantSuggestion: "use a duration such as 30s, 2m or 1h30m",
+		},
+		"zero duration": {
+			content: "retry_policy:\n  max_delay: 0\n",
+		},
+		"invalid enum": {
+			content:        "implement_method: phase\n",
+			wantKey:        "implement_method",
+			wantMessage:    `invalid value "phase"`,
+			wantSuggestion: `did you mean "phases"?`,
+		},
+		"invalid per-stage enum": {
+			content:     "retry_policy:\n  stages:\n    plan:\n      type: random\n",
+			wantKey:     "retry_policy.stages.plan.type",
+			wantMessage: `invalid value "random"`,
+		},
+		"unknown agent preset": {
+			content:        "agent_preset: claud\n",
+			wantKey:        "agent_preset",
+			wantMessage:    `unknown agent "claud"`,
+			wantSuggestion: `did you mean "claude"?`,
+		},
+		"unknown default agent": {
+			content:     "default_agents: [claude, vscode-copilot-agent]\n",
+			wantKey:     "default_agents[1]",
+			wantMessage: `unknown agent "vscode-copilot-agent"`,
+		},
+		"unknown stage agent": {
+			content:     "stage_agents:\n  plan: olama\n",
+			wantKey:     "stage_agents.plan",
+			wantMessage: `unknown agent "olama"`,
+		},
+		"unknown stage": {
+			content:        "post_validate:\n  plans: ./check.sh\n",
+			wantKey:        "post_validate.plans",
+			wantMessage:    "unknown stage",
+			wantSuggestion: `did you mean "plan"?`,
+		},
+		"unknown hook": {
+			content:        "hooks:\n  post_implment: make test\n",
+			wantKey:        "hooks.post_implment",
+			wantMessage:    "unknown hook",
+			wantSuggestion: `did you mean "post_implement"?`,
+		},
+		"invalid phase timeout": {
+			content:     "phase_timeouts:\n  implement: 2 hours\n",
+			wantKey:     "phase_timeouts.implement",
+			wantMessage: "expected a duration",
+		},
+		"custom agent without prompt placeholder": {
+			content:     "custom_agent:\n  command: aider\n  args: [--yes]\n",
+			wantKey:     "custom_agent.args",
+			wantMessage: "must contain the {{PROMPT}} placeholder",
+		},
+		"custom agent without command": {
+			content:     "custom_agent:\n  args: [\"{{PROMPT}}\"]\n",
+			wantKey:     "custom_agent.command",
+			wantMessage: "is required",
+		},
+		"chat webhook shortcuts": {
+			content: `notifications: {slack_webhook: "https://hooks.slack.com/x", on: ["phase_failed", "workflow_complete"]}` + "\n",
+		},
+		"unknown webhook key": {
+			content:        "notifications:\n  webhooks:\n    - url: https://example.com\n      event: [phase.failed]\n",
+			wantKey:        "notifications.webhooks[0].event",
+			wantLine:       4,
+			wantMessage:    "unknown key",
+			wantSuggestion: `did you mean "events"?`,
+		},
+		"profile linted as config": {
+			content:        "profiles:\n  ci:\n    timeout: 1h\n",
+			wantKey:        "profiles.ci.timeout",
+			wantLine:       3,
+			wantMessage:    "expected an integer",
+			wantSuggestion: "use a whole number without quotes",
+		},
+		"legacy json config": {
+			content:     `{"max_retries": 3, "specs_dir": "./specs", "timout": 60}`,
+			wantKey:     "timout",
+			wantMessage: "unknown key",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			issues := LintConfigData([]byte(tt.content))
+			if tt.wantMessage == "" && tt.wantKey == "" {
+				assert.Empty(t, issues)
+				return
+			}
+
+			require.Len(t, issues, 1, "issues: %v", issues)
+			issue := issues[0]
+			assert.Equal(t, tt.wantKey, issue.Key)
+			assert.Contains(t, issue.Message, tt.wantMessage)
+			if tt.wantLine > 0 {
+				assert.Equal(t, tt.wantLine, issue.Line)
+			}
+			if tt.wantSuggestion != "" {
+				assert.Equal(t, tt.wantSuggestion, issue.Suggestion)
+			}
+		})
+	}
+}
+
+func TestLintConfigData_DefaultTemplate(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, LintConfigData([]byte(GetDefaultConfigTemplate())),
+		"the config written by 'autospec init' must lint cleanly")
+}
+
+func TestLintConfigFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte("max_retries: 3\nspecs_dir: [a]\n"), 0o644))
+
+	issues, err := LintConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "line 2: specs_dir: expected a string, got a list", issues[0].String())
+
+	_, err = LintConfigFile(filepath.Join(t.TempDir(), "missing.yml"))
+	assert.Error(t, err)
+}
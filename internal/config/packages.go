@@ -0,0 +1,263 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/file"
+	"github.com/knadh/koanf/v2"
+)
+
+// PackageConfig is one package of a monorepo workspace. Paths are relative
+// to the workspace root, the directory whose .autospec/config.yml defines
+// packages.
+type PackageConfig struct {
+	// Path is the package directory, e.g. services/api.
+	Path string `koanf:"path"`
+	// SpecsDir defaults to <path>/specs.
+	SpecsDir string `koanf:"specs_dir"`
+	// Constitution defaults to <path>/.autospec/memory/constitution.yaml if
+	// it exists, else the workspace root's constitution.
+	Constitution string `koanf:"constitution"`
+}
+
+// constitutionFiles are the constitution locations, in priority order,
+// relative to a project or package directory.
+var constitutionFiles = []string{
+	filepath.Join(".autospec", "memory", "constitution.yaml"),
+	filepath.Join(".autospec", "memory", "constitution.yml"),
+}
+
+// workspace is a monorepo root found while loading config.
+type workspace struct {
+	// root is the absolute workspace directory.
+	root string
+	// configPath is the root's config file and k its content.
+	configPath string
+	k          *koanf.Koanf
+	// isProject is set when configPath is the project config being loaded.
+	isProject bool
+	packages  map[string]PackageConfig
+}
+
+// findWorkspace returns the workspace the project config belongs to, or nil.
+// The project config is a workspace root if it defines packages. With the
+// default project config path, the parent directories are searched too, so
+// commands run inside a package directory find their workspace.
+func findWorkspace(projectPath string) (*workspace, error) {
+	if projectPath == "" {
+		projectPath = ProjectConfigPath()
+	}
+	if ws, err := loadWorkspace(projectPath, true); ws != nil || err != nil {
+		return ws, err
+	}
+	if projectPath != ProjectConfigPath() {
+		return nil, nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, nil
+	}
+	for dir := filepath.Dir(cwd); ; dir = filepath.Dir(dir) {
+		if ws, err := loadWorkspace(filepath.Join(dir, ProjectConfigPath()), false); ws != nil || err != nil {
+			return ws, err
+		}
+		if dir == filepath.Dir(dir) {
+			return nil, nil
+		}
+	}
+}
+
+// loadWorkspace returns the workspace defined by the config file at path,
+// or nil if the file doesn't exist or defines no packages.
+func loadWorkspace(path string, isProject bool) (*workspace, error) {
+	if !fileExists(path) {
+		return nil, nil
+	}
+	k := koanf.New(".")
+	if err := k.Load(file.Provider(path), yaml.Parser()); err != nil || !k.Exists("packages") {
+		// Syntax errors are reported when the file is loaded as config.
+		return nil, nil
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolving workspace config path: %w", err)
+	}
+	root := filepath.Dir(abs)
+	if filepath.Base(root) == ProjectConfigDir() {
+		root = filepath.Dir(root)
+	}
+
+	ws := &workspace{root: root, configPath: path, k: k, isProject: isProject}
+	if err := k.Unmarshal("packages", &ws.packages); err != nil {
+		return nil, fmt.Errorf("reading packages from %s: %w", path, err)
+	}
+	for name, pkg := range ws.packages {
+		if pkg.Path == "" {
+			return nil, fmt.Errorf("package %q in %s has no path", name, path)
+		}
+	}
+	return ws, nil
+}
+
+// packageNames returns the workspace's package names, sorted.
+func (ws *workspace) packageNames() []string {
+	names := make([]string, 0, len(ws.packages))
+	for name := range ws.packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolvePackageName returns the package to apply.
+// Priority: LoadOptions.Package > AUTOSPEC_PACKAGE > the package containing
+// the current directory > package key in the workspace config.
+// An unknown name is an error listing the defined packages.
+func resolvePackageName(ws *workspace, optPackage string) (string, error) {
+	name := optPackage
+	if name == "" {
+		name = os.Getenv("AUTOSPEC_PACKAGE")
+	}
+	if name == "" && ws == nil {
+		return "", nil
+	}
+	if name == "" {
+		name = ws.packageAt()
+	}
+	if name == "" {
+		name = ws.k.String("package")
+	}
+	if name == "" {
+		return "", nil
+	}
+
+	if ws == nil {
+		return "", fmt.Errorf("unknown package %q: no packages defined in config", name)
+	}
+	if _, ok := ws.packages[name]; !ok {
+		return "", fmt.Errorf("unknown package %q; available: %s", name, strings.Join(ws.packageNames(), ", "))
+	}
+	return name, nil
+}
+
+// packageAt returns the package whose directory contains the current
+// directory (the innermost one if packages are nested), or "".
+func (ws *workspace) packageAt() string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	match, depth := "", -1
+	for _, name := range ws.packageNames() {
+		dir := filepath.Join(ws.root, ws.packages[name].Path)
+		if within(dir, cwd) && len(dir) > depth {
+			match, depth = name, len(dir)
+		}
+	}
+	return match
+}
+
+// within reports whether path is dir or inside it.
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// loadPackageConfig merges the package's own .autospec/config.yml, unless it
+// is the project config already loaded. Returns its content, or nil if the
+// package has no config of its own.
+func loadPackageConfig(k *koanf.Koanf, ws *workspace, name, projectPath string) (*koanf.Koanf, error) {
+	path := filepath.Join(ws.root, ws.packages[name].Path, ProjectConfigPath())
+	if !fileExists(path) {
+		return nil, nil
+	}
+	own := koanf.New(".")
+	if err := loadYAMLConfig(own, path, "package"); err != nil {
+		return nil, err
+	}
+	if projectPath == "" {
+		projectPath = ProjectConfigPath()
+	}
+	if abs, err := filepath.Abs(projectPath); err != nil || abs != path {
+		if err := k.Merge(own); err != nil {
+			return nil, fmt.Errorf("applying package %q config: %w", name, err)
+		}
+	}
+	return own, nil
+}
+
+// applyPackage points specs_dir and constitution at the package. The
+// package's own config may set either relative to the package directory;
+// otherwise the workspace entry, then the defaults apply. Paths are made
+// relative to the current directory.
+func applyPackage(k *koanf.Koanf, ws *workspace, name string, own *koanf.Koanf) {
+	pkg := ws.packages[name]
+	pkgDir := filepath.Join(ws.root, pkg.Path)
+
+	specsDir := filepath.Join(pkgDir, "specs")
+	if pkg.SpecsDir != "" {
+		specsDir = resolveFrom(ws.root, pkg.SpecsDir)
+	}
+	if own != nil && own.String("specs_dir") != "" {
+		specsDir = resolveFrom(pkgDir, expandHomePath(own.String("specs_dir")))
+	}
+	k.Set("specs_dir", relToCwd(specsDir))
+
+	constitution := ""
+	switch {
+	case own != nil && own.String("constitution") != "":
+		constitution = resolveFrom(pkgDir, own.String("constitution"))
+	case pkg.Constitution != "":
+		constitution = resolveFrom(ws.root, pkg.Constitution)
+	default:
+		constitution = findConstitution(pkgDir)
+		if constitution == "" {
+			constitution = findConstitution(ws.root)
+		}
+		if constitution == "" && ws.k.String("constitution") != "" {
+			constitution = resolveFrom(ws.root, ws.k.String("constitution"))
+		}
+	}
+	if constitution != "" {
+		k.Set("constitution", relToCwd(constitution))
+	}
+}
+
+// findConstitution returns the first constitution file that exists in dir,
+// or "".
+func findConstitution(dir string) string {
+	for _, name := range constitutionFiles {
+		if path := filepath.Join(dir, name); fileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+func resolveFrom(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// relToCwd returns path relative to the current directory, or path itself
+// if it can't be.
+func relToCwd(path string) string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(cwd, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
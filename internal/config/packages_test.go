@@ -0,0 +1,132 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_Packages(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Chdir and t.Setenv
+
+	workspaceConfig := `timeout: 900
+packages:
+  api:
+    path: services/api
+  web:
+    path: services/web
+    specs_dir: docs/web-specs
+    constitution: docs/web-constitution.yaml
+`
+	files := map[string]string{
+		".autospec/memory/constitution.yaml":              "project_name: root",
+		"services/api/.autospec/config.yml":               "timeout: 1200\nspecs_dir: features\n",
+		"services/api/.autospec/memory/constitution.yaml": "project_name: api",
+		"services/web/main.go":                            "package main",
+		"tools/lint/main.go":                              "package main",
+	}
+
+	tests := map[string]struct {
+		dir              string
+		optPackage       string
+		envPackage       string
+		wantPackage      string
+		wantSpecsDir     string
+		wantConstitution string
+		wantTimeout      int
+		wantErr          string
+	}{
+		"workspace root without package": {
+			wantSpecsDir: "./specs", wantTimeout: 900,
+		},
+		"option selects package from root": {
+			optPackage:  "api",
+			wantPackage: "api", wantSpecsDir: "services/api/features",
+			wantConstitution: "services/api/.autospec/memory/constitution.yaml", wantTimeout: 1200,
+		},
+		"env selects package": {
+			envPackage:  "web",
+			wantPackage: "web", wantSpecsDir: "docs/web-specs",
+			wantConstitution: "docs/web-constitution.yaml", wantTimeout: 900,
+		},
+		"package directory detected": {
+			dir:         "services/api",
+			wantPackage: "api", wantSpecsDir: "features",
+			wantConstitution: ".autospec/memory/constitution.yaml", wantTimeout: 1200,
+		},
+		"subdirectory of package detected": {
+			dir:         "services/web",
+			wantPackage: "web", wantSpecsDir: "../../docs/web-specs",
+			wantConstitution: "../../docs/web-constitution.yaml", wantTimeout: 900,
+		},
+		"option overrides current directory": {
+			dir: "services/web", optPackage: "api",
+			wantPackage: "api", wantSpecsDir: "../api/features",
+			wantConstitution: "../api/.autospec/memory/constitution.yaml", wantTimeout: 1200,
+		},
+		"directory outside packages": {
+			dir:          "tools/lint",
+			wantSpecsDir: "./specs", wantTimeout: 0,
+		},
+		"unknown package": {
+			optPackage: "mobile",
+			wantErr:    `unknown package "mobile"; available: api, web`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(root, ".autospec"), 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(root, ".autospec", "config.yml"), []byte(workspaceConfig), 0o644))
+			for path, content := range files {
+				require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(root, path)), 0o755))
+				require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(content), 0o644))
+			}
+			t.Chdir(filepath.Join(root, tt.dir))
+			t.Setenv("AUTOSPEC_PACKAGE", tt.envPackage)
+
+			cfg, err := LoadWithOptions(LoadOptions{
+				UserConfigPath: filepath.Join(root, "no-user-config.yml"),
+				Package:        tt.optPackage,
+				SkipWarnings:   true,
+			})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantPackage, cfg.Package)
+			assert.Equal(t, tt.wantSpecsDir, cfg.SpecsDir)
+			assert.Equal(t, tt.wantConstitution, cfg.Constitution)
+			if tt.wantTimeout > 0 {
+				assert.Equal(t, tt.wantTimeout, cfg.Timeout)
+			}
+			assert.Len(t, cfg.Packages, 2)
+		})
+	}
+}
+
+func TestLoad_PackageSpecsDirFlag(t *testing.T) {
+	// Note: Cannot use t.Parallel() with t.Chdir and t.Setenv
+
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".autospec"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".autospec", "config.yml"),
+		[]byte("packages:\n  api:\n    path: services/api\n"), 0o644))
+	t.Chdir(root)
+	t.Setenv("AUTOSPEC_SPECS_DIR", "./other-specs")
+
+	cfg, err := LoadWithOptions(LoadOptions{
+		UserConfigPath: filepath.Join(root, "no-user-config.yml"),
+		Package:        "api",
+		SkipWarnings:   true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "./other-specs", cfg.SpecsDir, "--specs-dir (exported to env) should win over the package")
+}
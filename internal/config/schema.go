@@ -5,6 +5,10 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/ariel-frischer/autospec/internal/statestore"
+	"github.com/ariel-frischer/autospec/internal/statesync"
+	"github.com/ariel-frischer/autospec/internal/vcs"
 )
 
 // ConfigValueType defines the expected type for a configuration value.
@@ -53,12 +57,85 @@ var KnownKeys = map[string]ConfigKeySchema{
 		Description: "Path to the Claude CLI executable",
 		Default:     "claude",
 	},
+	"ollama.host": {
+		Path:        "ollama.host",
+		Type:        TypeString,
+		Description: "Ollama server URL for the ollama agent (empty = $OLLAMA_HOST or http://localhost:11434)",
+		Default:     "",
+	},
+	"ollama.model": {
+		Path:        "ollama.model",
+		Type:        TypeString,
+		Description: "Model run by the ollama agent",
+		Default:     "qwen2.5-coder:7b",
+	},
 	"max_retries": {
 		Path:        "max_retries",
 		Type:        TypeInt,
 		Description: "Maximum number of retry attempts",
 		Default:     0,
 	},
+	"retry_policy.type": {
+		Path:          "retry_policy.type",
+		Type:          TypeEnum,
+		AllowedValues: []string{"none", "fixed", "linear", "exponential"},
+		Description:   "Backoff strategy between retry attempts",
+		Default:       "none",
+	},
+	"retry_policy.initial_delay": {
+		Path:        "retry_policy.initial_delay",
+		Type:        TypeDuration,
+		Description: "Delay before the first retry (e.g., 5s, 1m)",
+		Default:     "5s",
+	},
+	"retry_policy.max_delay": {
+		Path:        "retry_policy.max_delay",
+		Type:        TypeDuration,
+		Description: "Maximum delay between retries (0 = no cap)",
+		Default:     "2m",
+	},
+	"retry_policy.multiplier": {
+		Path:        "retry_policy.multiplier",
+		Type:        TypeInt,
+		Description: "Growth factor for exponential backoff",
+		Default:     2,
+	},
+	"retry_policy.jitter": {
+		Path:        "retry_policy.jitter",
+		Type:        TypeBool,
+		Description: "Randomize retry delays to 50-100% of the computed value",
+		Default:     false,
+	},
+	"retry_policy.rate_limit_waits": {
+		Path:        "retry_policy.rate_limit_waits",
+		Type:        TypeInt,
+		Description: "Rate-limit errors per stage waited out without using a retry (0 = count as failures)",
+		Default:     3,
+	},
+	"retry_policy.rate_limit_delay": {
+		Path:        "retry_policy.rate_limit_delay",
+		Type:        TypeDuration,
+		Description: "Wait after a rate-limit error that has no retry-after hint",
+		Default:     "1m",
+	},
+	"validation.tests.command": {
+		Path:        "validation.tests.command",
+		Type:        TypeString,
+		Description: "Test command run after each implement session (empty = disabled)",
+		Default:     "",
+	},
+	"validation.tests.timeout": {
+		Path:        "validation.tests.timeout",
+		Type:        TypeDuration,
+		Description: "Upper bound on a test run (e.g., 10m)",
+		Default:     "10m",
+	},
+	"validation.tests.pass_threshold": {
+		Path:        "validation.tests.pass_threshold",
+		Type:        TypeInt,
+		Description: "Percentage of tests that must pass (1-100)",
+		Default:     100,
+	},
 	"timeout": {
 		Path:        "timeout",
 		Type:        TypeInt,
@@ -71,6 +148,62 @@ var KnownKeys = map[string]ConfigKeySchema{
 		Description: "Directory for spec files",
 		Default:     "./specs",
 	},
+	"spec_namespace": {
+		Path:        "spec_namespace",
+		Type:        TypeString,
+		Description: "Prefix for new spec numbers, e.g. API for API-001 (numbered per namespace)",
+		Default:     "",
+	},
+	"branch_template": {
+		Path:        "branch_template",
+		Type:        TypeString,
+		Description: "Git branch name for new specs from {number} and {slug}, e.g. feat/{number}-{slug}",
+		Default:     "{number}-{slug}",
+	},
+	"branch_max_words": {
+		Path:        "branch_max_words",
+		Type:        TypeInt,
+		Description: "Maximum words in a generated branch slug (0 = 3, or 4 if exactly 4)",
+		Default:     0,
+	},
+	"skip_branch": {
+		Path:        "skip_branch",
+		Type:        TypeBool,
+		Description: "Create new specs without a git branch (trunk-based workflows)",
+		Default:     false,
+	},
+	"vcs": {
+		Path:          "vcs",
+		Type:          TypeEnum,
+		AllowedValues: vcs.Names,
+		Description:   "Version control for spec branches: auto detects jj, then git, else none",
+		Default:       "auto",
+	},
+	"state_store": {
+		Path:          "state_store",
+		Type:          TypeEnum,
+		AllowedValues: statestore.Kinds,
+		Description:   "State storage: file, or sqlite to keep state in state_dir/state.db",
+		Default:       "file",
+	},
+	"profile": {
+		Path:        "profile",
+		Type:        TypeString,
+		Description: "Named profile from profiles to apply by default",
+		Default:     "",
+	},
+	"package": {
+		Path:        "package",
+		Type:        TypeString,
+		Description: "Named package from packages to apply when not run inside one",
+		Default:     "",
+	},
+	"constitution": {
+		Path:        "constitution",
+		Type:        TypeString,
+		Description: "Constitution file (default: .autospec/memory/constitution.yaml)",
+		Default:     "",
+	},
 	"skip_preflight": {
 		Path:        "skip_preflight",
 		Type:        TypeBool,
@@ -96,6 +229,91 @@ var KnownKeys = map[string]ConfigKeySchema{
 		Description: "Maximum number of command history entries to retain",
 		Default:     500,
 	},
+	"max_history_age": {
+		Path:        "max_history_age",
+		Type:        TypeString,
+		Description: "Prune history entries older than this age, e.g. 90d (empty = no limit)",
+		Default:     "",
+	},
+	"history_archive": {
+		Path:        "history_archive",
+		Type:        TypeBool,
+		Description: "Archive pruned history entries to compressed files",
+		Default:     true,
+	},
+	"history_backend.type": {
+		Path:        "history_backend.type",
+		Type:        TypeString,
+		Description: "Shared team history backend: sqlite, http, or empty for none",
+		Default:     "",
+	},
+	"history_backend.path": {
+		Path:        "history_backend.path",
+		Type:        TypeString,
+		Description: "SQLite history file, relative to the repository root",
+		Default:     ".autospec/history.db",
+	},
+	"history_backend.url": {
+		Path:        "history_backend.url",
+		Type:        TypeString,
+		Description: "Endpoint history entries are POSTed to for the http backend",
+		Default:     "",
+	},
+	"max_sessions": {
+		Path:        "max_sessions",
+		Type:        TypeInt,
+		Description: "Number of agent transcripts to keep (0 disables capture)",
+		Default:     50,
+	},
+	"max_snapshots": {
+		Path:        "max_snapshots",
+		Type:        TypeInt,
+		Description: "Number of artifact snapshots to keep per spec (0 disables them)",
+		Default:     20,
+	},
+	"metrics.enabled": {
+		Path:        "metrics.enabled",
+		Type:        TypeBool,
+		Description: "Record usage metrics locally for 'autospec metrics show'",
+		Default:     true,
+	},
+	"metrics.upload": {
+		Path:        "metrics.upload",
+		Type:        TypeBool,
+		Description: "Upload anonymized metric aggregates once a day (opt-in)",
+		Default:     false,
+	},
+	"metrics.upload_url": {
+		Path:        "metrics.upload_url",
+		Type:        TypeString,
+		Description: "Endpoint anonymized metric aggregates are sent to",
+		Default:     "",
+	},
+	"sync.store": {
+		Path:          "sync.store",
+		Type:          TypeEnum,
+		AllowedValues: statesync.StoreNames,
+		Description:   "Where 'autospec sync' keeps spec state: git (orphan branch) or dir",
+		Default:       "git",
+	},
+	"sync.branch": {
+		Path:        "sync.branch",
+		Type:        TypeString,
+		Description: "Orphan branch the git sync store keeps spec state on",
+		Default:     "autospec-state",
+	},
+	"sync.remote": {
+		Path:        "sync.remote",
+		Type:        TypeString,
+		Description: "Remote the sync branch is pushed to (kept local if missing)",
+		Default:     "origin",
+	},
+	"sync.dir": {
+		Path:        "sync.dir",
+		Type:        TypeString,
+		Description: "Directory of the dir sync store",
+		Default:     "",
+	},
 	"notifications.enabled": {
 		Path:        "notifications.enabled",
 		Type:        TypeBool,
@@ -139,6 +357,36 @@ var KnownKeys = map[string]ConfigKeySchema{
 		Description: "Threshold for long-running notifications (e.g., 2m, 1h30m)",
 		Default:     "2m",
 	},
+	"notifications.on_input_required": {
+		Path:        "notifications.on_input_required",
+		Type:        TypeBool,
+		Description: "Notify when the agent seems to be waiting for input",
+		Default:     true,
+	},
+	"notifications.input_idle_threshold": {
+		Path:        "notifications.input_idle_threshold",
+		Type:        TypeDuration,
+		Description: "Time without agent output that counts as waiting for input (0 = detect prompts only)",
+		Default:     "10m",
+	},
+	"notifications.bell": {
+		Path:        "notifications.bell",
+		Type:        TypeBool,
+		Description: "Ring the terminal bell when the agent seems to be waiting for input",
+		Default:     true,
+	},
+	"notifications.slack_webhook": {
+		Path:        "notifications.slack_webhook",
+		Type:        TypeString,
+		Description: "Slack incoming-webhook URL for workflow events",
+		Default:     "",
+	},
+	"notifications.discord_webhook": {
+		Path:        "notifications.discord_webhook",
+		Type:        TypeString,
+		Description: "Discord webhook URL for workflow events",
+		Default:     "",
+	},
 	"output_style": {
 		Path:          "output_style",
 		Type:          TypeEnum,
@@ -158,6 +406,59 @@ var KnownKeys = map[string]ConfigKeySchema{
 		Description: "Enable automatic git commit creation after workflow completion",
 		Default:     false,
 	},
+	"task_commits": {
+		Path:        "task_commits",
+		Type:        TypeBool,
+		Description: "Commit after each completed task in task-level implementation (--tasks)",
+		Default:     false,
+	},
+	"on_spec_change": {
+		Path:          "on_spec_change",
+		Type:          TypeEnum,
+		AllowedValues: []string{"warn", "replan", "ignore"},
+		Description:   "What to do when spec.yaml changed after plan/tasks were generated",
+		Default:       "warn",
+	},
+	"session_mode": {
+		Path:          "session_mode",
+		Type:          TypeEnum,
+		AllowedValues: []string{"fresh", "continuous"},
+		Description:   "Start each stage in a new agent session or resume the spec's previous one",
+		Default:       "fresh",
+	},
+	"on_unreachable": {
+		Path:          "on_unreachable",
+		Type:          TypeEnum,
+		AllowedValues: []string{"ask", "queue", "fail"},
+		Description:   "What to do when the agent can't reach its provider or its credentials are rejected",
+		Default:       "ask",
+	},
+	"stall_timeout": {
+		Path:        "stall_timeout",
+		Type:        TypeDuration,
+		Description: "Stop an agent session that prints nothing for this long (0 = never)",
+		Default:     "0s",
+	},
+	"on_stall": {
+		Path:          "on_stall",
+		Type:          TypeEnum,
+		AllowedValues: []string{"retry", "fail"},
+		Description:   "Retry or fail a stage whose agent was stopped for stalling",
+		Default:       "retry",
+	},
+	"escalate_blocked_after": {
+		Path:        "escalate_blocked_after",
+		Type:        TypeDuration,
+		Description: "Escalate tasks implement finds blocked for longer than this (0 = never)",
+		Default:     "0s",
+	},
+	"escalate_blocked": {
+		Path:          "escalate_blocked",
+		Type:          TypeEnum,
+		AllowedValues: []string{"notify", "issue", "both"},
+		Description:   "Escalate long-blocked tasks with a notification, a GitHub issue, or both",
+		Default:       "notify",
+	},
 }
 
 // ErrUnknownKey is returned when trying to access an unknown configuration key.
@@ -3,13 +3,26 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/history"
 	"github.com/ariel-frischer/autospec/internal/notify"
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/statestore"
+	"github.com/ariel-frischer/autospec/internal/statesync"
+	"github.com/ariel-frischer/autospec/internal/vcs"
 	"gopkg.in/yaml.v3"
 )
 
+// specNamespacePattern matches spec_namespace values like "API" or "web2".
+var specNamespacePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+
 // ValidationError represents a configuration validation error with context
 type ValidationError struct {
 	FilePath string
@@ -120,6 +133,43 @@ func ValidateConfigValues(cfg *Configuration, filePath string) error {
 		}
 	}
 
+	if cfg.SpecNamespace != "" && !specNamespacePattern.MatchString(cfg.SpecNamespace) {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "spec_namespace",
+			Message:  "must be a letter followed by letters or digits (e.g. API)",
+		}
+	}
+
+	if err := spec.ValidateBranchTemplate(cfg.BranchTemplate); err != nil {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "branch_template",
+			Message:  err.Error(),
+		}
+	}
+	if cfg.VCS != "" && !slices.Contains(vcs.Names, cfg.VCS) {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "vcs",
+			Message:  "must be one of: " + strings.Join(vcs.Names, ", "),
+		}
+	}
+	if cfg.StateStore != "" && !slices.Contains(statestore.Kinds, cfg.StateStore) {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "state_store",
+			Message:  "must be one of: " + strings.Join(statestore.Kinds, ", "),
+		}
+	}
+	if cfg.BranchMaxWords < 0 {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "branch_max_words",
+			Message:  "must be 0 or greater",
+		}
+	}
+
 	// MaxRetries: min=0, max=10
 	if cfg.MaxRetries < 0 || cfg.MaxRetries > 10 {
 		return &ValidationError{
@@ -129,6 +179,90 @@ func ValidateConfigValues(cfg *Configuration, filePath string) error {
 		}
 	}
 
+	if err := cfg.RetryPolicy.Validate(); err != nil {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "retry_policy",
+			Message:  err.Error(),
+		}
+	}
+
+	if err := cfg.Budget.Validate(); err != nil {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "budget",
+			Message:  err.Error(),
+		}
+	}
+
+	if err := validatePostValidate(cfg.PostValidate, filePath); err != nil {
+		return err
+	}
+	if err := validateHooks(cfg.Hooks, filePath); err != nil {
+		return err
+	}
+	if err := validatePipeline(cfg.Pipeline, cfg.PipelineCommands, cfg.CustomPhases, filePath); err != nil {
+		return err
+	}
+	if err := validateTests(cfg.Validation.Tests, filePath); err != nil {
+		return err
+	}
+	if err := validateTaskGate(cfg.Validation.TaskGate, filePath); err != nil {
+		return err
+	}
+	if err := validateDoctorChecks(cfg.DoctorChecks, filePath); err != nil {
+		return err
+	}
+
+	if err := validateSubAgent(cfg.SubAgent, filePath); err != nil {
+		return err
+	}
+
+	if err := validateModel(cfg.Model, filePath); err != nil {
+		return err
+	}
+
+	if err := validateStageAgents(cfg.StageAgents, filePath); err != nil {
+		return err
+	}
+
+	if err := validateSandbox(cfg.Sandbox, filePath); err != nil {
+		return err
+	}
+
+	if err := validatePhaseTimeouts(cfg.PhaseTimeouts, filePath); err != nil {
+		return err
+	}
+
+	if err := validateMetrics(cfg.Metrics, filePath); err != nil {
+		return err
+	}
+
+	if err := validateSync(cfg.Sync, filePath); err != nil {
+		return err
+	}
+
+	if err := validateHistoryBackend(cfg.HistoryBackend, filePath); err != nil {
+		return err
+	}
+
+	if cfg.MaxHistoryEntries < 0 {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "max_history_entries",
+			Message:  "must be 0 (unlimited) or greater",
+		}
+	}
+	if cfg.MaxHistoryAge != "" {
+		if _, err := history.ParseAge(cfg.MaxHistoryAge); err != nil {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "max_history_age",
+				Message:  err.Error(),
+			}
+		}
+	}
+
 	// Timeout: omitempty, min=1, max=604800 (0 means no timeout)
 	if cfg.Timeout != 0 && (cfg.Timeout < 1 || cfg.Timeout > 604800) {
 		return &ValidationError{
@@ -157,6 +291,67 @@ func ValidateConfigValues(cfg *Configuration, filePath string) error {
 		}
 	}
 
+	// OnSpecChange: must be one of "warn", "replan", "ignore", or empty (uses default)
+	if cfg.OnSpecChange != "" && !slices.Contains([]string{"warn", "replan", "ignore"}, cfg.OnSpecChange) {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "on_spec_change",
+			Message:  "must be one of: warn, replan, ignore",
+		}
+	}
+
+	// SessionMode: must be one of "fresh", "continuous", or empty (uses default)
+	if cfg.SessionMode != "" && !slices.Contains([]string{"fresh", "continuous"}, cfg.SessionMode) {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "session_mode",
+			Message:  "must be one of: fresh, continuous",
+		}
+	}
+
+	// OnUnreachable: must be one of "ask", "queue", "fail", or empty (uses default)
+	if cfg.OnUnreachable != "" && !slices.Contains([]string{"ask", "queue", "fail"}, cfg.OnUnreachable) {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "on_unreachable",
+			Message:  "must be one of: ask, queue, fail",
+		}
+	}
+
+	// OnStall: must be one of "retry", "fail", or empty (uses default)
+	if cfg.OnStall != "" && !slices.Contains([]string{"retry", "fail"}, cfg.OnStall) {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "on_stall",
+			Message:  "must be one of: retry, fail",
+		}
+	}
+
+	if cfg.StallTimeout < 0 {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "stall_timeout",
+			Message:  "must not be negative",
+		}
+	}
+
+	// EscalateBlocked: must be one of "notify", "issue", "both", or empty (uses default)
+	if cfg.EscalateBlocked != "" && !slices.Contains([]string{"notify", "issue", "both"}, cfg.EscalateBlocked) {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "escalate_blocked",
+			Message:  "must be one of: notify, issue, both",
+		}
+	}
+
+	if cfg.EscalateBlockedAfter < 0 {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "escalate_blocked_after",
+			Message:  "must not be negative",
+		}
+	}
+
 	// Validate notification settings
 	if err := validateNotificationConfig(&cfg.Notifications, filePath); err != nil {
 		return err
@@ -176,6 +371,322 @@ func ValidateConfigValues(cfg *Configuration, filePath string) error {
 	return nil
 }
 
+// configStages lists the stage names accepted as keys in per-stage config
+// such as post_validate, phase_timeouts and sub_agent.stages.
+var configStages = []string{"constitution", "specify", "clarify", "plan", "tasks", "checklist", "analyze", "implement", "verify"}
+
+// validatePostValidate checks that post_validate hooks use known stage names
+// and non-empty commands.
+func validatePostValidate(hooks map[string]string, filePath string) error {
+	for stage, command := range hooks {
+		if !slices.Contains(configStages, stage) {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "post_validate." + stage,
+				Message:  "unknown stage; must be one of: " + strings.Join(configStages, ", "),
+			}
+		}
+		if strings.TrimSpace(command) == "" {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "post_validate." + stage,
+				Message:  "command must not be empty",
+			}
+		}
+	}
+	return nil
+}
+
+// hookNames lists the keys accepted under hooks: pre_<stage> and post_<stage>.
+var hookNames = func() []string {
+	names := make([]string, 0, 2*len(configStages))
+	for _, stage := range configStages {
+		names = append(names, "pre_"+stage, "post_"+stage)
+	}
+	return names
+}()
+
+// validateHooks checks that hooks use pre_<stage>/post_<stage> names and
+// non-empty commands.
+func validateHooks(hooks map[string]string, filePath string) error {
+	for name, command := range hooks {
+		if !slices.Contains(hookNames, name) {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "hooks." + name,
+				Message:  "unknown hook; must be pre_<stage> or post_<stage> with a stage of: " + strings.Join(configStages, ", "),
+			}
+		}
+		if strings.TrimSpace(command) == "" {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "hooks." + name,
+				Message:  "command must not be empty",
+			}
+		}
+	}
+	return nil
+}
+
+// validatePipeline checks that pipeline steps are stages in canonical order,
+// custom commands or custom phases, and that the pipeline includes specify.
+func validatePipeline(pipeline []string, commands map[string]string, phases map[string]CustomPhaseConfig, filePath string) error {
+	for name := range phases {
+		field := "custom_phases." + name
+		if slices.Contains(configStages, name) {
+			return &ValidationError{FilePath: filePath, Field: field, Message: "name is a built-in stage; choose another name"}
+		}
+		if _, ok := commands[name]; ok {
+			return &ValidationError{FilePath: filePath, Field: field, Message: "name is already used in pipeline_commands"}
+		}
+	}
+	for name, command := range commands {
+		if slices.Contains(configStages, name) {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "pipeline_commands." + name,
+				Message:  "name is a built-in stage; choose another name",
+			}
+		}
+		if strings.TrimSpace(command) == "" {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "pipeline_commands." + name,
+				Message:  "command must not be empty",
+			}
+		}
+	}
+	if len(pipeline) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(pipeline))
+	lastStage := -1
+	for i, name := range pipeline {
+		field := fmt.Sprintf("pipeline[%d]", i)
+		if seen[name] {
+			return &ValidationError{FilePath: filePath, Field: field, Message: fmt.Sprintf("duplicate step %q", name)}
+		}
+		seen[name] = true
+		if _, ok := commands[name]; ok {
+			continue
+		}
+		if _, ok := phases[name]; ok {
+			continue
+		}
+		idx := slices.Index(configStages, name)
+		if idx < 0 {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    field,
+				Message:  fmt.Sprintf("unknown step %q; must be a stage (%s), a pipeline_commands name or a custom_phases name", name, strings.Join(configStages, ", ")),
+			}
+		}
+		if idx < lastStage {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    field,
+				Message:  fmt.Sprintf("stage %q is out of order; stages run in the order %s", name, strings.Join(configStages, ", ")),
+			}
+		}
+		lastStage = idx
+	}
+	if !seen["specify"] {
+		return &ValidationError{FilePath: filePath, Field: "pipeline", Message: "must include specify"}
+	}
+	return nil
+}
+
+// validateTests checks validation.tests settings when a test command is set.
+func validateTests(tests TestsConfig, filePath string) error {
+	if strings.TrimSpace(tests.Command) == "" {
+		return nil
+	}
+	if tests.PassThreshold < 1 || tests.PassThreshold > 100 {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "validation.tests.pass_threshold",
+			Message:  "must be between 1 and 100",
+		}
+	}
+	if tests.Timeout < 0 {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "validation.tests.timeout",
+			Message:  "must not be negative",
+		}
+	}
+	return nil
+}
+
+// validateTaskGate checks that validation.task_gate has no empty commands.
+func validateTaskGate(commands []string, filePath string) error {
+	for i, command := range commands {
+		if strings.TrimSpace(command) == "" {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    fmt.Sprintf("validation.task_gate[%d]", i),
+				Message:  "command must not be empty",
+			}
+		}
+	}
+	return nil
+}
+
+// versionPattern matches a doctor check's min_version, e.g. "20" or "1.22.3".
+var versionPattern = regexp.MustCompile(`^v?\d+(\.\d+)*$`)
+
+// validateDoctorChecks checks that each doctor check has a name and command,
+// a known severity, and a valid output pattern and minimum version.
+func validateDoctorChecks(checks []DoctorCheck, filePath string) error {
+	for i, check := range checks {
+		field := fmt.Sprintf("doctor_checks[%d]", i)
+		var message string
+		switch {
+		case strings.TrimSpace(check.Name) == "":
+			message = "name must not be empty"
+		case strings.TrimSpace(check.Command) == "":
+			message = "command must not be empty"
+		case check.Severity != "" && check.Severity != "fail" && check.Severity != "warn":
+			message = "severity must be one of: fail, warn"
+		case check.MinVersion != "" && !versionPattern.MatchString(check.MinVersion):
+			message = fmt.Sprintf("min_version %q is not a version like 20 or 1.22.3", check.MinVersion)
+		case check.Timeout < 0:
+			message = "timeout must not be negative"
+		}
+		if message == "" && check.ExpectOutput != "" {
+			if _, err := regexp.Compile(check.ExpectOutput); err != nil {
+				message = fmt.Sprintf("expect_output is not a valid regular expression: %v", err)
+			}
+		}
+		if message != "" {
+			return &ValidationError{FilePath: filePath, Field: field, Message: message}
+		}
+	}
+	return nil
+}
+
+// validateSubAgent checks that sub_agent stage overrides use known stage names
+// and non-empty agent names.
+func validateSubAgent(sa cliagent.SubAgentConfig, filePath string) error {
+	for stage, name := range sa.Stages {
+		if !slices.Contains(configStages, stage) {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "sub_agent.stages." + stage,
+				Message:  "unknown stage; must be one of: " + strings.Join(configStages, ", "),
+			}
+		}
+		if strings.TrimSpace(name) == "" {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "sub_agent.stages." + stage,
+				Message:  "agent name must not be empty",
+			}
+		}
+	}
+	return nil
+}
+
+// validateModel checks that model stage overrides use known stage names and
+// non-empty model names.
+func validateModel(m cliagent.ModelConfig, filePath string) error {
+	for stage, name := range m.Stages {
+		if !slices.Contains(configStages, stage) {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "model.stages." + stage,
+				Message:  "unknown stage; must be one of: " + strings.Join(configStages, ", "),
+			}
+		}
+		if strings.TrimSpace(name) == "" {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "model.stages." + stage,
+				Message:  "model name must not be empty",
+			}
+		}
+	}
+	return nil
+}
+
+// validateSandbox checks that an enabled sandbox names an image, a known
+// runtime and known stages.
+func validateSandbox(s cliagent.SandboxConfig, filePath string) error {
+	if !s.Enabled {
+		return nil
+	}
+	if strings.TrimSpace(s.Image) == "" {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "sandbox.image",
+			Message:  "required when sandbox is enabled",
+		}
+	}
+	runtimes := []string{cliagent.SandboxRuntimeDocker, cliagent.SandboxRuntimePodman}
+	if s.Runtime != "" && !slices.Contains(runtimes, s.Runtime) {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "sandbox.runtime",
+			Message:  "must be one of: " + strings.Join(runtimes, ", "),
+		}
+	}
+	for _, stage := range s.Stages {
+		if !slices.Contains(configStages, stage) {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "sandbox.stages",
+				Message:  fmt.Sprintf("unknown stage %q; must be one of: %s", stage, strings.Join(configStages, ", ")),
+			}
+		}
+	}
+	return nil
+}
+
+// validateStageAgents checks that stage_agents use known stage names and
+// registered agent names.
+func validateStageAgents(agents map[string]string, filePath string) error {
+	for stage, name := range agents {
+		if !slices.Contains(configStages, stage) {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "stage_agents." + stage,
+				Message:  "unknown stage; must be one of: " + strings.Join(configStages, ", "),
+			}
+		}
+		if cliagent.Get(name) == nil {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "stage_agents." + stage,
+				Message:  fmt.Sprintf("unknown agent %q; must be one of: %s", name, strings.Join(cliagent.List(), ", ")),
+			}
+		}
+	}
+	return nil
+}
+
+// validatePhaseTimeouts checks that phase_timeouts use known stage names and
+// positive durations.
+func validatePhaseTimeouts(timeouts map[string]time.Duration, filePath string) error {
+	for stage, timeout := range timeouts {
+		if !slices.Contains(configStages, stage) {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "phase_timeouts." + stage,
+				Message:  "unknown stage; must be one of: " + strings.Join(configStages, ", "),
+			}
+		}
+		if timeout <= 0 {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "phase_timeouts." + stage,
+				Message:  "must be a positive duration, e.g. 10m",
+			}
+		}
+	}
+	return nil
+}
+
 // validateNotificationConfig validates notification configuration values.
 // Returns nil if valid, or a ValidationError with field information if invalid.
 func validateNotificationConfig(nc *notify.NotificationConfig, filePath string) error {
@@ -210,6 +721,132 @@ func validateNotificationConfig(nc *notify.NotificationConfig, filePath string)
 	// Note: LongRunningThreshold of 0 or negative is valid and means "always notify"
 	// This is documented behavior per the spec, so no validation error is needed.
 
+	for i, hook := range nc.Webhooks {
+		if err := validateWebhook(hook, fmt.Sprintf("notifications.webhooks[%d]", i), filePath); err != nil {
+			return err
+		}
+	}
+	if nc.SlackWebhook != "" {
+		if err := validateWebhookURL(nc.SlackWebhook, "notifications.slack_webhook", filePath); err != nil {
+			return err
+		}
+	}
+	if nc.DiscordWebhook != "" {
+		if err := validateWebhookURL(nc.DiscordWebhook, "notifications.discord_webhook", filePath); err != nil {
+			return err
+		}
+	}
+	if err := validateEventNames(nc.On, "notifications.on", filePath); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateMetrics checks that uploads have an endpoint to go to.
+func validateMetrics(mc MetricsConfig, filePath string) error {
+	if mc.UploadURL != "" {
+		return validateWebhookURL(mc.UploadURL, "metrics.upload_url", filePath)
+	}
+	if mc.Upload {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "metrics.upload_url",
+			Message:  "is required when metrics.upload is enabled",
+		}
+	}
+	return nil
+}
+
+// validateHistoryBackend checks the shared history backend type and that
+// an http backend has a URL.
+func validateHistoryBackend(hb HistoryBackendConfig, filePath string) error {
+	switch hb.Type {
+	case "", history.BackendSQLite:
+		return nil
+	case history.BackendHTTP:
+		if hb.URL == "" {
+			return &ValidationError{
+				FilePath: filePath,
+				Field:    "history_backend.url",
+				Message:  "is required when history_backend.type is http",
+			}
+		}
+		return validateWebhookURL(hb.URL, "history_backend.url", filePath)
+	default:
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "history_backend.type",
+			Message:  "must be one of: " + strings.Join(history.BackendTypes, ", "),
+		}
+	}
+}
+
+// validateSync checks the sync store and that a dir store has a directory.
+func validateSync(sc SyncConfig, filePath string) error {
+	if sc.Store != "" && !slices.Contains(statesync.StoreNames, sc.Store) {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "sync.store",
+			Message:  "must be one of: " + strings.Join(statesync.StoreNames, ", "),
+		}
+	}
+	if sc.Store == statesync.StoreDir && sc.Dir == "" {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    "sync.dir",
+			Message:  "is required when sync.store is dir",
+		}
+	}
+	return nil
+}
+
+// validateWebhook checks a webhook's URL, event filter and format.
+func validateWebhook(hook notify.WebhookConfig, field, filePath string) error {
+	if err := validateWebhookURL(hook.URL, field+".url", filePath); err != nil {
+		return err
+	}
+	if err := validateEventNames(hook.Events, field+".events", filePath); err != nil {
+		return err
+	}
+	if !notify.ValidWebhookFormat(string(hook.Format)) {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    field + ".format",
+			Message:  "must be one of: json, slack, discord",
+		}
+	}
+	return nil
+}
+
+// validateWebhookURL checks that raw is an absolute http or https URL.
+func validateWebhookURL(raw, field, filePath string) error {
+	if u, err := url.Parse(raw); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    field,
+			Message:  "must be an http or https URL",
+		}
+	}
+	return nil
+}
+
+// validateEventNames checks that every entry names a known webhook event.
+func validateEventNames(events []string, field, filePath string) error {
+	for _, event := range events {
+		if notify.ValidEventType(event) {
+			continue
+		}
+		names := make([]string, len(notify.EventTypes))
+		for i, t := range notify.EventTypes {
+			names[i] = string(t)
+		}
+		return &ValidationError{
+			FilePath: filePath,
+			Field:    field,
+			Message:  fmt.Sprintf("unknown event %q; must be one of: %s", event, strings.Join(names, ", ")),
+		}
+	}
 	return nil
 }
 
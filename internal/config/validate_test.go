@@ -1,6 +1,6 @@
 // Package config_test tests configuration validation including YAML syntax, value constraints, and custom command templates.
 // Related: internal/config/validate.go
-// Tags: config, validation, yaml, syntax, notifications, implement-method
+// Tags: config, validation, yaml, syntax, notifications, webhooks, implement-method, retry-policy
 package config
 
 import (
@@ -8,6 +8,12 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/budget"
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/notify"
+	"github.com/ariel-frischer/autospec/internal/retry"
 )
 
 func TestValidateYAMLSyntax_ValidFile(t *testing.T) {
@@ -147,133 +153,1119 @@ func TestValidateYAMLSyntaxFromBytes_Invalid(t *testing.T) {
 		t.Error("ValidateYAMLSyntaxFromBytes() returned nil for invalid YAML")
 	}
 
-	validationErr, ok := err.(*ValidationError)
-	if !ok {
-		t.Errorf("Expected ValidationError, got %T", err)
-	}
-	if validationErr.FilePath != "test.yml" {
-		t.Errorf("ValidationError.FilePath = %q, want %q", validationErr.FilePath, "test.yml")
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Errorf("Expected ValidationError, got %T", err)
+	}
+	if validationErr.FilePath != "test.yml" {
+		t.Errorf("ValidationError.FilePath = %q, want %q", validationErr.FilePath, "test.yml")
+	}
+}
+
+func TestValidateYAMLSyntaxFromBytes_Empty(t *testing.T) {
+	err := ValidateYAMLSyntaxFromBytes([]byte(""), "test.yml")
+	if err != nil {
+		t.Errorf("ValidateYAMLSyntaxFromBytes() should return nil for empty data, got: %v", err)
+	}
+}
+
+func TestValidateConfigValues_Valid(t *testing.T) {
+	cfg := &Configuration{
+		AgentPreset: "claude",
+		MaxRetries:  3,
+		SpecsDir:    "./specs",
+		StateDir:    "~/.autospec/state",
+	}
+
+	err := ValidateConfigValues(cfg, "test.yml")
+	if err != nil {
+		t.Errorf("ValidateConfigValues() returned error for valid config: %v", err)
+	}
+}
+
+func TestValidateConfigValues_InvalidMaxRetries(t *testing.T) {
+	tests := map[string]struct {
+		maxRetries int
+		wantErr    bool
+	}{
+		"too low":       {maxRetries: -1, wantErr: true},
+		"minimum valid": {maxRetries: 0, wantErr: false},
+		"middle valid":  {maxRetries: 5, wantErr: false},
+		"maximum valid": {maxRetries: 10, wantErr: false},
+		"too high":      {maxRetries: 11, wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset: "claude",
+				MaxRetries:  tt.maxRetries,
+				SpecsDir:    "./specs",
+				StateDir:    "~/.autospec/state",
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfigValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_ImplementMethod(t *testing.T) {
+	tests := map[string]struct {
+		implementMethod string
+		wantErr         bool
+		wantErrContains string
+	}{
+		"valid single-session": {
+			implementMethod: "single-session",
+			wantErr:         false,
+		},
+		"valid phases": {
+			implementMethod: "phases",
+			wantErr:         false,
+		},
+		"valid tasks": {
+			implementMethod: "tasks",
+			wantErr:         false,
+		},
+		"empty string is valid (uses default)": {
+			implementMethod: "",
+			wantErr:         false,
+		},
+		"invalid value": {
+			implementMethod: "invalid-mode",
+			wantErr:         true,
+			wantErrContains: "single-session, phases, tasks",
+		},
+		"invalid value with typo": {
+			implementMethod: "phase", // missing 's'
+			wantErr:         true,
+			wantErrContains: "single-session, phases, tasks",
+		},
+		"invalid value - uppercase": {
+			implementMethod: "PHASES",
+			wantErr:         true,
+			wantErrContains: "single-session, phases, tasks",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset:     "claude",
+				MaxRetries:      3,
+				SpecsDir:        "./specs",
+				StateDir:        "~/.autospec/state",
+				ImplementMethod: tt.implementMethod,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfigValues() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr && err != nil {
+				validationErr, ok := err.(*ValidationError)
+				if !ok {
+					t.Fatalf("Expected ValidationError, got %T", err)
+				}
+
+				if validationErr.Field != "implement_method" {
+					t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, "implement_method")
+				}
+
+				if tt.wantErrContains != "" && !strings.Contains(validationErr.Message, tt.wantErrContains) {
+					t.Errorf("ValidationError.Message = %q, should contain %q", validationErr.Message, tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_SessionMode(t *testing.T) {
+	tests := map[string]struct {
+		sessionMode string
+		wantErr     bool
+	}{
+		"fresh":      {sessionMode: "fresh"},
+		"continuous": {sessionMode: "continuous"},
+		"empty":      {sessionMode: ""},
+		"invalid":    {sessionMode: "resume", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset: "claude",
+				SpecsDir:    "./specs",
+				StateDir:    "~/.autospec/state",
+				SessionMode: tt.sessionMode,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T", err)
+			}
+			if validationErr.Field != "session_mode" {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, "session_mode")
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_SpecNamespace(t *testing.T) {
+	tests := map[string]struct {
+		namespace string
+		wantErr   bool
+	}{
+		"empty":           {namespace: ""},
+		"uppercase":       {namespace: "API"},
+		"with digits":     {namespace: "WEB2"},
+		"leading digit":   {namespace: "2FA", wantErr: true},
+		"contains hyphen": {namespace: "API-V2", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset:   "claude",
+				SpecsDir:      "./specs",
+				StateDir:      "~/.autospec/state",
+				SpecNamespace: tt.namespace,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T", err)
+			}
+			if validationErr.Field != "spec_namespace" {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, "spec_namespace")
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_BranchNaming(t *testing.T) {
+	tests := map[string]struct {
+		template   string
+		maxWords   int
+		vcs        string
+		stateStore string
+		wantField  string
+	}{
+		"defaults":            {},
+		"prefixed template":   {template: "feat/{number}-{slug}", maxWords: 5},
+		"template no slug":    {template: "feat/{number}", wantField: "branch_template"},
+		"template no number":  {template: "feat/{slug}", wantField: "branch_template"},
+		"negative max words":  {maxWords: -1, wantField: "branch_max_words"},
+		"jj":                  {vcs: "jj"},
+		"unknown vcs":         {vcs: "hg", wantField: "vcs"},
+		"sqlite state store":  {stateStore: "sqlite"},
+		"unknown state store": {stateStore: "redis", wantField: "state_store"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset:    "claude",
+				SpecsDir:       "./specs",
+				StateDir:       "~/.autospec/state",
+				BranchTemplate: tt.template,
+				BranchMaxWords: tt.maxWords,
+				VCS:            tt.vcs,
+				StateStore:     tt.stateStore,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T", err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_Stall(t *testing.T) {
+	tests := map[string]struct {
+		onStall      string
+		stallTimeout time.Duration
+		wantField    string
+	}{
+		"retry":            {onStall: "retry", stallTimeout: 15 * time.Minute},
+		"fail":             {onStall: "fail"},
+		"empty":            {onStall: ""},
+		"invalid on_stall": {onStall: "kill", wantField: "on_stall"},
+		"negative timeout": {stallTimeout: -time.Minute, wantField: "stall_timeout"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset:  "claude",
+				SpecsDir:     "./specs",
+				StateDir:     "~/.autospec/state",
+				OnStall:      tt.onStall,
+				StallTimeout: tt.stallTimeout,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T", err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_EscalateBlocked(t *testing.T) {
+	tests := map[string]struct {
+		escalateBlocked string
+		after           time.Duration
+		wantField       string
+	}{
+		"notify":             {escalateBlocked: "notify", after: 24 * time.Hour},
+		"issue":              {escalateBlocked: "issue", after: time.Hour},
+		"both":               {escalateBlocked: "both"},
+		"empty":              {escalateBlocked: ""},
+		"invalid escalation": {escalateBlocked: "email", wantField: "escalate_blocked"},
+		"negative threshold": {after: -time.Hour, wantField: "escalate_blocked_after"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset:          "claude",
+				SpecsDir:             "./specs",
+				StateDir:             "~/.autospec/state",
+				EscalateBlocked:      tt.escalateBlocked,
+				EscalateBlockedAfter: tt.after,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T", err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_OnUnreachable(t *testing.T) {
+	tests := map[string]struct {
+		onUnreachable string
+		wantErr       bool
+	}{
+		"ask":     {onUnreachable: "ask"},
+		"queue":   {onUnreachable: "queue"},
+		"fail":    {onUnreachable: "fail"},
+		"empty":   {onUnreachable: ""},
+		"invalid": {onUnreachable: "wait", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset:   "claude",
+				SpecsDir:      "./specs",
+				StateDir:      "~/.autospec/state",
+				OnUnreachable: tt.onUnreachable,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T", err)
+			}
+			if validationErr.Field != "on_unreachable" {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, "on_unreachable")
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_RetryPolicy(t *testing.T) {
+	tests := map[string]struct {
+		policy          retry.PolicyConfig
+		wantErr         bool
+		wantErrContains string
+	}{
+		"zero value is valid": {
+			policy:  retry.PolicyConfig{},
+			wantErr: false,
+		},
+		"valid exponential with stage override": {
+			policy: retry.PolicyConfig{
+				Type:         retry.PolicyExponential,
+				InitialDelay: 5 * time.Second,
+				Stages:       map[string]retry.PolicyConfig{"implement": {Type: retry.PolicyFixed}},
+			},
+			wantErr: false,
+		},
+		"invalid type": {
+			policy:          retry.PolicyConfig{Type: "backoff"},
+			wantErr:         true,
+			wantErrContains: "none, fixed, linear, exponential",
+		},
+		"invalid stage override": {
+			policy:          retry.PolicyConfig{Stages: map[string]retry.PolicyConfig{"plan": {InitialDelay: -time.Second}}},
+			wantErr:         true,
+			wantErrContains: "stages.plan",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset: "claude",
+				MaxRetries:  3,
+				SpecsDir:    "./specs",
+				StateDir:    "~/.autospec/state",
+				RetryPolicy: tt.policy,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfigValues() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr && err != nil {
+				validationErr, ok := err.(*ValidationError)
+				if !ok {
+					t.Fatalf("Expected ValidationError, got %T", err)
+				}
+				if validationErr.Field != "retry_policy" {
+					t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, "retry_policy")
+				}
+				if !strings.Contains(validationErr.Message, tt.wantErrContains) {
+					t.Errorf("ValidationError.Message = %q, should contain %q", validationErr.Message, tt.wantErrContains)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_Budget(t *testing.T) {
+	tests := map[string]struct {
+		budget  budget.Config
+		wantErr string
+	}{
+		"zero value is valid": {},
+		"run and spec limits": {
+			budget: budget.Config{Run: budget.Limit{CostUSD: 5}, Spec: budget.Limit{CostUSD: 20, Tokens: 5000000}},
+		},
+		"negative cost": {
+			budget:  budget.Config{Run: budget.Limit{CostUSD: -1}},
+			wantErr: "run limits must not be negative",
+		},
+		"negative tokens": {
+			budget:  budget.Config{Spec: budget.Limit{Tokens: -100}},
+			wantErr: "spec limits must not be negative",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset: "claude",
+				MaxRetries:  3,
+				SpecsDir:    "./specs",
+				StateDir:    "~/.autospec/state",
+				Budget:      tt.budget,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T", err)
+			}
+			if validationErr.Field != "budget" {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, "budget")
+			}
+			if validationErr.Message != tt.wantErr {
+				t.Errorf("ValidationError.Message = %q, want %q", validationErr.Message, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_DoctorChecks(t *testing.T) {
+	tests := map[string]struct {
+		checks    []DoctorCheck
+		wantField string
+		wantErr   string
+	}{
+		"valid checks": {
+			checks: []DoctorCheck{
+				{Name: "Node.js", Command: "node --version", MinVersion: "v20.1"},
+				{Name: "Docker", Command: "docker info", ExpectOutput: "Server Version", Severity: "warn"},
+			},
+		},
+		"missing command": {
+			checks:    []DoctorCheck{{Name: "Node.js"}},
+			wantField: "doctor_checks[0]",
+			wantErr:   "command must not be empty",
+		},
+		"unknown severity": {
+			checks: []DoctorCheck{
+				{Name: "Node.js", Command: "node --version"},
+				{Name: "Docker", Command: "docker info", Severity: "error"},
+			},
+			wantField: "doctor_checks[1]",
+			wantErr:   "severity must be one of: fail, warn",
+		},
+		"bad min_version": {
+			checks:    []DoctorCheck{{Name: "Node.js", Command: "node --version", MinVersion: ">=20"}},
+			wantField: "doctor_checks[0]",
+			wantErr:   `min_version ">=20" is not a version like 20 or 1.22.3`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset:  "claude",
+				MaxRetries:   3,
+				SpecsDir:     "./specs",
+				StateDir:     "~/.autospec/state",
+				DoctorChecks: tt.checks,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T", err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+			if validationErr.Message != tt.wantErr {
+				t.Errorf("ValidationError.Message = %q, want %q", validationErr.Message, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_PostValidate(t *testing.T) {
+	tests := map[string]struct {
+		hooks     map[string]string
+		wantField string
+		wantMsg   string
+	}{
+		"no hooks": {},
+		"known stages": {
+			hooks: map[string]string{"plan": "./scripts/check-plan.sh", "implement": "make lint"},
+		},
+		"unknown stage": {
+			hooks:     map[string]string{"deploy": "./deploy.sh"},
+			wantField: "post_validate.deploy",
+			wantMsg:   "unknown stage",
+		},
+		"empty command": {
+			hooks:     map[string]string{"tasks": "  "},
+			wantField: "post_validate.tasks",
+			wantMsg:   "must not be empty",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset:  "claude",
+				MaxRetries:   3,
+				SpecsDir:     "./specs",
+				StateDir:     "~/.autospec/state",
+				PostValidate: tt.hooks,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+			if !strings.Contains(validationErr.Message, tt.wantMsg) {
+				t.Errorf("ValidationError.Message = %q, should contain %q", validationErr.Message, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_Hooks(t *testing.T) {
+	tests := map[string]struct {
+		hooks     map[string]string
+		wantField string
+		wantMsg   string
+	}{
+		"no hooks": {},
+		"pre and post hooks": {
+			hooks: map[string]string{"pre_implement": "make lint", "post_implement": "make test", "post_plan": "./check.sh"},
+		},
+		"bare stage name": {
+			hooks:     map[string]string{"implement": "make test"},
+			wantField: "hooks.implement",
+			wantMsg:   "unknown hook",
+		},
+		"unknown stage": {
+			hooks:     map[string]string{"post_deploy": "./deploy.sh"},
+			wantField: "hooks.post_deploy",
+			wantMsg:   "unknown hook",
+		},
+		"empty command": {
+			hooks:     map[string]string{"pre_tasks": " "},
+			wantField: "hooks.pre_tasks",
+			wantMsg:   "must not be empty",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset: "claude",
+				MaxRetries:  3,
+				SpecsDir:    "./specs",
+				StateDir:    "~/.autospec/state",
+				Hooks:       tt.hooks,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+			if !strings.Contains(validationErr.Message, tt.wantMsg) {
+				t.Errorf("ValidationError.Message = %q, should contain %q", validationErr.Message, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_Tests(t *testing.T) {
+	tests := map[string]struct {
+		tests     TestsConfig
+		wantField string
+	}{
+		"disabled ignores other fields": {
+			tests: TestsConfig{PassThreshold: 0},
+		},
+		"valid": {
+			tests: TestsConfig{Command: "go test ./...", Timeout: 5 * time.Minute, PassThreshold: 95},
+		},
+		"threshold too low": {
+			tests:     TestsConfig{Command: "go test ./...", PassThreshold: 0},
+			wantField: "validation.tests.pass_threshold",
+		},
+		"threshold too high": {
+			tests:     TestsConfig{Command: "go test ./...", PassThreshold: 101},
+			wantField: "validation.tests.pass_threshold",
+		},
+		"negative timeout": {
+			tests:     TestsConfig{Command: "go test ./...", PassThreshold: 100, Timeout: -time.Second},
+			wantField: "validation.tests.timeout",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset: "claude",
+				MaxRetries:  3,
+				SpecsDir:    "./specs",
+				StateDir:    "~/.autospec/state",
+				Validation:  ValidationConfig{Tests: tt.tests},
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_TaskGate(t *testing.T) {
+	tests := map[string]struct {
+		gate      []string
+		wantField string
+	}{
+		"unset":         {},
+		"valid":         {gate: []string{"go build ./...", "go vet ./..."}},
+		"empty command": {gate: []string{"go build ./...", "  "}, wantField: "validation.task_gate[1]"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset: "claude",
+				MaxRetries:  3,
+				SpecsDir:    "./specs",
+				StateDir:    "~/.autospec/state",
+				Validation:  ValidationConfig{TaskGate: tt.gate},
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_Pipeline(t *testing.T) {
+	tests := map[string]struct {
+		pipeline  []string
+		commands  map[string]string
+		phases    map[string]CustomPhaseConfig
+		wantField string
+	}{
+		"unset": {},
+		"optional stages and custom step": {
+			pipeline: []string{"constitution", "specify", "clarify", "plan", "tasks", "lint-plan", "analyze", "implement"},
+			commands: map[string]string{"lint-plan": "./scripts/lint-plan.sh"},
+		},
+		"stages skipped": {
+			pipeline: []string{"specify", "plan"},
+		},
+		"unknown step": {
+			pipeline:  []string{"specify", "review"},
+			wantField: "pipeline[1]",
+		},
+		"stage out of order": {
+			pipeline:  []string{"specify", "tasks", "plan"},
+			wantField: "pipeline[2]",
+		},
+		"duplicate step": {
+			pipeline:  []string{"specify", "lint", "plan", "lint"},
+			commands:  map[string]string{"lint": "make lint"},
+			wantField: "pipeline[3]",
+		},
+		"missing specify": {
+			pipeline:  []string{"plan", "tasks"},
+			wantField: "pipeline",
+		},
+		"command shadows a stage": {
+			commands:  map[string]string{"plan": "echo plan"},
+			wantField: "pipeline_commands.plan",
+		},
+		"custom phase": {
+			pipeline: []string{"specify", "plan", "tasks", "implement", "security-review"},
+			phases:   map[string]CustomPhaseConfig{"security-review": {Validate: "./check.sh"}},
+		},
+		"custom phase shadows a stage": {
+			phases:    map[string]CustomPhaseConfig{"analyze": {}},
+			wantField: "custom_phases.analyze",
+		},
+		"custom phase shadows a command": {
+			commands:  map[string]string{"review": "make review"},
+			phases:    map[string]CustomPhaseConfig{"review": {}},
+			wantField: "custom_phases.review",
+		},
+		"empty command": {
+			commands:  map[string]string{"lint": " "},
+			wantField: "pipeline_commands.lint",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset:      "claude",
+				MaxRetries:       3,
+				SpecsDir:         "./specs",
+				StateDir:         "~/.autospec/state",
+				Pipeline:         tt.pipeline,
+				PipelineCommands: tt.commands,
+				CustomPhases:     tt.phases,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_PhaseTimeouts(t *testing.T) {
+	tests := map[string]struct {
+		timeouts  map[string]time.Duration
+		wantField string
+		wantMsg   string
+	}{
+		"no timeouts": {},
+		"known stages": {
+			timeouts: map[string]time.Duration{"specify": 10 * time.Minute, "implement": 2 * time.Hour},
+		},
+		"unknown stage": {
+			timeouts:  map[string]time.Duration{"deploy": time.Minute},
+			wantField: "phase_timeouts.deploy",
+			wantMsg:   "unknown stage",
+		},
+		"zero duration": {
+			timeouts:  map[string]time.Duration{"plan": 0},
+			wantField: "phase_timeouts.plan",
+			wantMsg:   "positive duration",
+		},
 	}
-}
 
-func TestValidateYAMLSyntaxFromBytes_Empty(t *testing.T) {
-	err := ValidateYAMLSyntaxFromBytes([]byte(""), "test.yml")
-	if err != nil {
-		t.Errorf("ValidateYAMLSyntaxFromBytes() should return nil for empty data, got: %v", err)
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset:   "claude",
+				MaxRetries:    3,
+				SpecsDir:      "./specs",
+				StateDir:      "~/.autospec/state",
+				PhaseTimeouts: tt.timeouts,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+			if !strings.Contains(validationErr.Message, tt.wantMsg) {
+				t.Errorf("ValidationError.Message = %q, should contain %q", validationErr.Message, tt.wantMsg)
+			}
+		})
 	}
 }
 
-func TestValidateConfigValues_Valid(t *testing.T) {
-	cfg := &Configuration{
-		AgentPreset: "claude",
-		MaxRetries:  3,
-		SpecsDir:    "./specs",
-		StateDir:    "~/.autospec/state",
+func TestValidateConfigValues_SubAgent(t *testing.T) {
+	tests := map[string]struct {
+		subAgent  cliagent.SubAgentConfig
+		wantField string
+		wantMsg   string
+	}{
+		"unset": {},
+		"global and stage overrides": {
+			subAgent: cliagent.SubAgentConfig{Name: "build", Stages: map[string]string{"plan": "planner"}},
+		},
+		"unknown stage": {
+			subAgent:  cliagent.SubAgentConfig{Stages: map[string]string{"deploy": "ops"}},
+			wantField: "sub_agent.stages.deploy",
+			wantMsg:   "unknown stage",
+		},
+		"empty agent name": {
+			subAgent:  cliagent.SubAgentConfig{Stages: map[string]string{"plan": " "}},
+			wantField: "sub_agent.stages.plan",
+			wantMsg:   "must not be empty",
+		},
 	}
 
-	err := ValidateConfigValues(cfg, "test.yml")
-	if err != nil {
-		t.Errorf("ValidateConfigValues() returned error for valid config: %v", err)
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset: "opencode",
+				SpecsDir:    "./specs",
+				StateDir:    "~/.autospec/state",
+				SubAgent:    tt.subAgent,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+			if !strings.Contains(validationErr.Message, tt.wantMsg) {
+				t.Errorf("ValidationError.Message = %q, should contain %q", validationErr.Message, tt.wantMsg)
+			}
+		})
 	}
 }
 
-func TestValidateConfigValues_InvalidMaxRetries(t *testing.T) {
+func TestValidateConfigValues_Model(t *testing.T) {
 	tests := map[string]struct {
-		maxRetries int
-		wantErr    bool
+		model     cliagent.ModelConfig
+		wantField string
+		wantMsg   string
 	}{
-		"too low":       {maxRetries: -1, wantErr: true},
-		"minimum valid": {maxRetries: 0, wantErr: false},
-		"middle valid":  {maxRetries: 5, wantErr: false},
-		"maximum valid": {maxRetries: 10, wantErr: false},
-		"too high":      {maxRetries: 11, wantErr: true},
+		"unset": {},
+		"global and stage overrides": {
+			model: cliagent.ModelConfig{Name: "sonnet", Stages: map[string]string{"specify": "haiku", "implement": "opus"}},
+		},
+		"unknown stage": {
+			model:     cliagent.ModelConfig{Stages: map[string]string{"deploy": "opus"}},
+			wantField: "model.stages.deploy",
+			wantMsg:   "unknown stage",
+		},
+		"empty model name": {
+			model:     cliagent.ModelConfig{Stages: map[string]string{"plan": " "}},
+			wantField: "model.stages.plan",
+			wantMsg:   "must not be empty",
+		},
 	}
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
 			cfg := &Configuration{
 				AgentPreset: "claude",
-				MaxRetries:  tt.maxRetries,
 				SpecsDir:    "./specs",
 				StateDir:    "~/.autospec/state",
+				Model:       tt.model,
 			}
 
 			err := ValidateConfigValues(cfg, "test.yml")
-			if (err != nil) != tt.wantErr {
-				t.Errorf("ValidateConfigValues() error = %v, wantErr %v", err, tt.wantErr)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+			if !strings.Contains(validationErr.Message, tt.wantMsg) {
+				t.Errorf("ValidationError.Message = %q, should contain %q", validationErr.Message, tt.wantMsg)
 			}
 		})
 	}
 }
 
-func TestValidateConfigValues_ImplementMethod(t *testing.T) {
+func TestValidateConfigValues_Sandbox(t *testing.T) {
 	tests := map[string]struct {
-		implementMethod string
-		wantErr         bool
-		wantErrContains string
+		sandbox   cliagent.SandboxConfig
+		wantField string
+		wantMsg   string
 	}{
-		"valid single-session": {
-			implementMethod: "single-session",
-			wantErr:         false,
-		},
-		"valid phases": {
-			implementMethod: "phases",
-			wantErr:         false,
-		},
-		"valid tasks": {
-			implementMethod: "tasks",
-			wantErr:         false,
+		"disabled without image": {},
+		"enabled": {
+			sandbox: cliagent.SandboxConfig{Enabled: true, Runtime: "podman", Image: "agent:latest", Stages: []string{"implement"}},
 		},
-		"empty string is valid (uses default)": {
-			implementMethod: "",
-			wantErr:         false,
-		},
-		"invalid value": {
-			implementMethod: "invalid-mode",
-			wantErr:         true,
-			wantErrContains: "single-session, phases, tasks",
+		"missing image": {
+			sandbox:   cliagent.SandboxConfig{Enabled: true},
+			wantField: "sandbox.image",
+			wantMsg:   "required",
 		},
-		"invalid value with typo": {
-			implementMethod: "phase", // missing 's'
-			wantErr:         true,
-			wantErrContains: "single-session, phases, tasks",
+		"unknown runtime": {
+			sandbox:   cliagent.SandboxConfig{Enabled: true, Image: "agent:latest", Runtime: "lxc"},
+			wantField: "sandbox.runtime",
+			wantMsg:   "docker, podman",
 		},
-		"invalid value - uppercase": {
-			implementMethod: "PHASES",
-			wantErr:         true,
-			wantErrContains: "single-session, phases, tasks",
+		"unknown stage": {
+			sandbox:   cliagent.SandboxConfig{Enabled: true, Image: "agent:latest", Stages: []string{"deploy"}},
+			wantField: "sandbox.stages",
+			wantMsg:   `unknown stage "deploy"`,
 		},
 	}
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
 			cfg := &Configuration{
-				AgentPreset:     "claude",
-				MaxRetries:      3,
-				SpecsDir:        "./specs",
-				StateDir:        "~/.autospec/state",
-				ImplementMethod: tt.implementMethod,
+				AgentPreset: "claude",
+				SpecsDir:    "./specs",
+				StateDir:    "~/.autospec/state",
+				Sandbox:     tt.sandbox,
 			}
 
 			err := ValidateConfigValues(cfg, "test.yml")
-			if (err != nil) != tt.wantErr {
-				t.Errorf("ValidateConfigValues() error = %v, wantErr %v", err, tt.wantErr)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
 				return
 			}
 
-			if tt.wantErr && err != nil {
-				validationErr, ok := err.(*ValidationError)
-				if !ok {
-					t.Fatalf("Expected ValidationError, got %T", err)
-				}
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+			if !strings.Contains(validationErr.Message, tt.wantMsg) {
+				t.Errorf("ValidationError.Message = %q, should contain %q", validationErr.Message, tt.wantMsg)
+			}
+		})
+	}
+}
 
-				if validationErr.Field != "implement_method" {
-					t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, "implement_method")
-				}
+func TestValidateConfigValues_StageAgents(t *testing.T) {
+	tests := map[string]struct {
+		stageAgents map[string]string
+		wantField   string
+		wantMsg     string
+	}{
+		"unset": {},
+		"local model for document stages": {
+			stageAgents: map[string]string{"specify": "ollama", "plan": "ollama", "tasks": "ollama"},
+		},
+		"unknown stage": {
+			stageAgents: map[string]string{"deploy": "ollama"},
+			wantField:   "stage_agents.deploy",
+			wantMsg:     "unknown stage",
+		},
+		"unknown agent": {
+			stageAgents: map[string]string{"plan": "llama"},
+			wantField:   "stage_agents.plan",
+			wantMsg:     `unknown agent "llama"`,
+		},
+	}
 
-				if tt.wantErrContains != "" && !strings.Contains(validationErr.Message, tt.wantErrContains) {
-					t.Errorf("ValidationError.Message = %q, should contain %q", validationErr.Message, tt.wantErrContains)
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset: "claude",
+				SpecsDir:    "./specs",
+				StateDir:    "~/.autospec/state",
+				StageAgents: tt.stageAgents,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
 				}
+				return
+			}
+
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+			if !strings.Contains(validationErr.Message, tt.wantMsg) {
+				t.Errorf("ValidationError.Message = %q, should contain %q", validationErr.Message, tt.wantMsg)
 			}
 		})
 	}
@@ -348,6 +1340,136 @@ func TestValidateNotificationConfig_InvalidType(t *testing.T) {
 	}
 }
 
+func TestValidateNotificationConfig_Webhooks(t *testing.T) {
+	tests := map[string]struct {
+		webhooks  []notify.WebhookConfig
+		config    notify.NotificationConfig
+		wantField string
+		wantMsg   string
+	}{
+		"none": {},
+		"valid webhooks": {
+			webhooks: []notify.WebhookConfig{
+				{URL: "https://hooks.slack.com/services/T0/B0/x", Format: notify.FormatSlack, Events: []string{"phase.failed", "retries.exhausted"}},
+				{URL: "http://localhost:8080/autospec"},
+			},
+		},
+		"missing url": {
+			webhooks:  []notify.WebhookConfig{{Format: notify.FormatDiscord}},
+			wantField: "notifications.webhooks[0].url",
+			wantMsg:   "http or https URL",
+		},
+		"unsupported scheme": {
+			webhooks:  []notify.WebhookConfig{{URL: "https://ok.example"}, {URL: "ftp://example.com/hook"}},
+			wantField: "notifications.webhooks[1].url",
+			wantMsg:   "http or https URL",
+		},
+		"unknown event": {
+			webhooks:  []notify.WebhookConfig{{URL: "https://example.com", Events: []string{"task.started"}}},
+			wantField: "notifications.webhooks[0].events",
+			wantMsg:   `unknown event "task.started"`,
+		},
+		"unknown format": {
+			webhooks:  []notify.WebhookConfig{{URL: "https://example.com", Format: "teams"}},
+			wantField: "notifications.webhooks[0].format",
+			wantMsg:   "json, slack, discord",
+		},
+		"slack and discord shortcuts": {
+			config: notify.NotificationConfig{
+				SlackWebhook:   "https://hooks.slack.com/services/T0/B0/x",
+				DiscordWebhook: "https://discord.com/api/webhooks/1/abc",
+				On:             []string{"phase_failed", "workflow_complete", "task.blocked"},
+			},
+		},
+		"invalid slack webhook": {
+			config:    notify.NotificationConfig{SlackWebhook: "hooks.slack.com/services/x"},
+			wantField: "notifications.slack_webhook",
+			wantMsg:   "http or https URL",
+		},
+		"invalid discord webhook": {
+			config:    notify.NotificationConfig{DiscordWebhook: "discord"},
+			wantField: "notifications.discord_webhook",
+			wantMsg:   "http or https URL",
+		},
+		"unknown on event": {
+			config:    notify.NotificationConfig{SlackWebhook: "https://hooks.slack.com/x", On: []string{"phase_done"}},
+			wantField: "notifications.on",
+			wantMsg:   `unknown event "phase_done"`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset: "claude",
+				SpecsDir:    "./specs",
+				StateDir:    "~/.autospec/state",
+			}
+			cfg.Notifications = tt.config
+			cfg.Notifications.Webhooks = tt.webhooks
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+			if !strings.Contains(validationErr.Message, tt.wantMsg) {
+				t.Errorf("ValidationError.Message = %q, should contain %q", validationErr.Message, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_Metrics(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		metrics   MetricsConfig
+		wantError string
+	}{
+		"local only":         {metrics: MetricsConfig{Enabled: true}},
+		"upload with url":    {metrics: MetricsConfig{Enabled: true, Upload: true, UploadURL: "https://metrics.example.com/v1"}},
+		"url without upload": {metrics: MetricsConfig{UploadURL: "https://metrics.example.com/v1"}},
+		"upload without url": {metrics: MetricsConfig{Upload: true}, wantError: "is required when metrics.upload is enabled"},
+		"invalid upload url": {metrics: MetricsConfig{Upload: true, UploadURL: "metrics.example.com"}, wantError: "http or https URL"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Configuration{SpecsDir: "./specs", StateDir: "~/.autospec/state", Metrics: tt.metrics}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantError == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T (%v)", err, err)
+			}
+			if validationErr.Field != "metrics.upload_url" {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, "metrics.upload_url")
+			}
+			if !strings.Contains(validationErr.Message, tt.wantError) {
+				t.Errorf("ValidationError.Message = %q, should contain %q", validationErr.Message, tt.wantError)
+			}
+		})
+	}
+}
+
 func TestValidateNotificationConfig_NonExistentSoundFile(t *testing.T) {
 	t.Parallel()
 
@@ -589,3 +1711,82 @@ key3:
 		t.Errorf("ValidateYAMLSyntax() returned error for valid complex YAML: %v", err)
 	}
 }
+
+func TestValidateConfigValues_Sync(t *testing.T) {
+	tests := map[string]struct {
+		sync      SyncConfig
+		wantField string
+	}{
+		"unset":            {},
+		"git store":        {sync: SyncConfig{Store: "git", Branch: "autospec-state", Remote: "origin"}},
+		"dir store":        {sync: SyncConfig{Store: "dir", Dir: "/mnt/shared/autospec"}},
+		"dir store no dir": {sync: SyncConfig{Store: "dir"}, wantField: "sync.dir"},
+		"unknown store":    {sync: SyncConfig{Store: "s3"}, wantField: "sync.store"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset: "claude",
+				SpecsDir:    "./specs",
+				StateDir:    "~/.autospec/state",
+				Sync:        tt.sync,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T", err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestValidateConfigValues_HistoryBackend(t *testing.T) {
+	tests := map[string]struct {
+		backend   HistoryBackendConfig
+		wantField string
+	}{
+		"none":         {},
+		"sqlite":       {backend: HistoryBackendConfig{Type: "sqlite", Path: ".autospec/history.db"}},
+		"http":         {backend: HistoryBackendConfig{Type: "http", URL: "https://example.com/history"}},
+		"http no url":  {backend: HistoryBackendConfig{Type: "http"}, wantField: "history_backend.url"},
+		"http bad url": {backend: HistoryBackendConfig{Type: "http", URL: "example.com"}, wantField: "history_backend.url"},
+		"unknown type": {backend: HistoryBackendConfig{Type: "postgres"}, wantField: "history_backend.type"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{
+				AgentPreset:    "claude",
+				SpecsDir:       "./specs",
+				StateDir:       "~/.autospec/state",
+				HistoryBackend: tt.backend,
+			}
+
+			err := ValidateConfigValues(cfg, "test.yml")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateConfigValues() unexpected error = %v", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Expected ValidationError, got %T", err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+		})
+	}
+}
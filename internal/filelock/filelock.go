@@ -0,0 +1,168 @@
+// Package filelock serializes read-modify-write updates of state files
+// shared by concurrent autospec processes, such as retry.json, history.yaml
+// and tasks.yaml.
+//
+// A lock on a file is a lock file next to it (<path>.lock), created
+// exclusively and holding the owner's pid, host and start time. Locks are
+// advisory: every writer takes the lock before reading the file and releases
+// it after writing. Readers that don't write back don't need it, since state
+// files are replaced atomically. A lock whose owner has exited, or that is
+// older than StaleAge, is considered stale and taken over.
+package filelock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Suffix is appended to a file's path to name its lock file.
+const Suffix = ".lock"
+
+// DefaultTimeout is how long Lock waits for another process to release a lock.
+const DefaultTimeout = 10 * time.Second
+
+// StaleAge is how long a lock may be held before it is considered stale.
+// State files are locked only for a single read and write, so any lock this
+// old was left behind by a process that hung or was killed.
+const StaleAge = time.Minute
+
+// pollInterval is how often Lock retries while the lock is held.
+const pollInterval = 20 * time.Millisecond
+
+// owner is the content of a lock file.
+type owner struct {
+	PID        int       `json:"pid"`
+	Host       string    `json:"host"`
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+// LockedError is returned when a lock is still held after the timeout.
+type LockedError struct {
+	Path  string // File being locked
+	Owner *owner // Current holder; nil if the lock file could not be read
+}
+
+func (e *LockedError) Error() string {
+	lockPath := e.Path + Suffix
+	if e.Owner == nil {
+		return fmt.Sprintf("%s is locked by another autospec process; if none is running, delete %s", e.Path, lockPath)
+	}
+	return fmt.Sprintf("%s is locked by another autospec process (pid %d on %s since %s); if it is no longer running, delete %s",
+		e.Path, e.Owner.PID, e.Owner.Host, e.Owner.AcquiredAt.Local().Format("15:04:05"), lockPath)
+}
+
+// Lock acquires the lock on path, waiting up to DefaultTimeout, and returns
+// a function that releases it.
+func Lock(path string) (func(), error) {
+	return LockTimeout(path, DefaultTimeout)
+}
+
+// LockTimeout is Lock with a custom timeout.
+func LockTimeout(path string, timeout time.Duration) (func(), error) {
+	lockPath := path + Suffix
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("creating directory for lock file: %w", err)
+	}
+	host, _ := os.Hostname()
+	deadline := time.Now().Add(timeout)
+	for {
+		me := owner{PID: os.Getpid(), Host: host, AcquiredAt: time.Now()}
+		acquired, err := tryLock(lockPath, me)
+		if err != nil {
+			return nil, err
+		}
+		if acquired {
+			return func() { unlock(lockPath, me) }, nil
+		}
+
+		current, err := readOwner(lockPath)
+		if errors.Is(err, os.ErrNotExist) {
+			continue // Released in the meantime
+		}
+		if stale(lockPath, current, host) {
+			removeIfOwner(lockPath, current)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, &LockedError{Path: path, Owner: current}
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// With runs fn while holding the lock on path.
+func With(path string, fn func() error) error {
+	unlock, err := Lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return fn()
+}
+
+// tryLock creates the lock file for me. It reports false if the lock is held.
+func tryLock(lockPath string, me owner) (bool, error) {
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if errors.Is(err, os.ErrExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("creating lock file: %w", err)
+	}
+	defer f.Close()
+	if err := json.NewEncoder(f).Encode(me); err != nil {
+		os.Remove(lockPath)
+		return false, fmt.Errorf("writing lock file: %w", err)
+	}
+	return true, nil
+}
+
+// readOwner returns the holder recorded in a lock file.
+func readOwner(lockPath string) (*owner, error) {
+	data, err := os.ReadFile(lockPath)
+	if err != nil {
+		return nil, err
+	}
+	var o owner
+	if err := json.Unmarshal(data, &o); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// stale reports whether a lock can be taken over: its owner on this host
+// has exited, or it is older than StaleAge. A lock file that can't be read
+// yet is being written and only goes stale with age.
+func stale(lockPath string, current *owner, host string) bool {
+	if current != nil {
+		if current.Host == host && !ProcessAlive(current.PID) {
+			return true
+		}
+		return time.Since(current.AcquiredAt) > StaleAge
+	}
+	info, err := os.Stat(lockPath)
+	return err == nil && time.Since(info.ModTime()) > StaleAge
+}
+
+// removeIfOwner removes a stale lock file unless another process replaced
+// it since it was read.
+func removeIfOwner(lockPath string, expected *owner) {
+	current, err := readOwner(lockPath)
+	if expected != nil && (err != nil || *current != *expected) {
+		return
+	}
+	os.Remove(lockPath)
+}
+
+// unlock removes the lock file if it is still held by me.
+func unlock(lockPath string, me owner) {
+	current, err := readOwner(lockPath)
+	if err != nil || current.PID != me.PID || !current.AcquiredAt.Equal(me.AcquiredAt) {
+		return
+	}
+	os.Remove(lockPath)
+}
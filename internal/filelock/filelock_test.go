@@ -0,0 +1,132 @@
+package filelock
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeOwner plants a lock file for path as if held by o.
+func writeOwner(t *testing.T, path string, o owner) {
+	t.Helper()
+	data, err := json.Marshal(o)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path+Suffix, data, 0o644))
+}
+
+func TestLock_AcquireAndRelease(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "state", "retry.json")
+
+	unlock, err := Lock(path)
+	require.NoError(t, err)
+	o, err := readOwner(path + Suffix)
+	require.NoError(t, err)
+	assert.Equal(t, os.Getpid(), o.PID)
+
+	unlock()
+	assert.NoFileExists(t, path+Suffix)
+
+	unlock, err = Lock(path)
+	require.NoError(t, err, "lock can be taken again after release")
+	unlock()
+}
+
+func TestLockTimeout_HeldByLiveProcess(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "history.yaml")
+	host, _ := os.Hostname()
+	writeOwner(t, path, owner{PID: os.Getpid(), Host: host, AcquiredAt: time.Now()})
+
+	_, err := LockTimeout(path, 50*time.Millisecond)
+	var locked *LockedError
+	require.True(t, errors.As(err, &locked))
+	assert.Equal(t, os.Getpid(), locked.Owner.PID)
+	assert.Contains(t, err.Error(), "is locked by another autospec process")
+	assert.Contains(t, err.Error(), "delete "+path+Suffix)
+	assert.FileExists(t, path+Suffix)
+}
+
+func TestLockTimeout_TakesOverStaleLock(t *testing.T) {
+	t.Parallel()
+	host, _ := os.Hostname()
+
+	tests := map[string]owner{
+		"owner exited": {PID: 1 << 30, Host: host, AcquiredAt: time.Now()},
+		"lock too old": {PID: os.Getpid(), Host: "other-host", AcquiredAt: time.Now().Add(-2 * StaleAge)},
+	}
+	for name, stale := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "retry.json")
+			writeOwner(t, path, stale)
+
+			unlock, err := LockTimeout(path, 50*time.Millisecond)
+			require.NoError(t, err)
+			defer unlock()
+			o, err := readOwner(path + Suffix)
+			require.NoError(t, err)
+			assert.Equal(t, os.Getpid(), o.PID)
+			assert.NotEqual(t, stale, *o)
+		})
+	}
+}
+
+func TestLockTimeout_LiveOwnerOnOtherHostIsNotStale(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "retry.json")
+	// A pid on another host can't be checked, so only age makes it stale
+	writeOwner(t, path, owner{PID: 1 << 30, Host: "other-host", AcquiredAt: time.Now()})
+
+	_, err := LockTimeout(path, 50*time.Millisecond)
+	var locked *LockedError
+	assert.True(t, errors.As(err, &locked))
+}
+
+func TestUnlock_KeepsLockTakenOverByAnotherProcess(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "tasks.yaml")
+
+	unlock, err := Lock(path)
+	require.NoError(t, err)
+	other := owner{PID: os.Getpid(), Host: "other-host", AcquiredAt: time.Now().Add(time.Second)}
+	writeOwner(t, path, other)
+
+	unlock()
+	assert.FileExists(t, path+Suffix)
+}
+
+func TestWith_SerializesUpdates(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "counter")
+	require.NoError(t, os.WriteFile(path, []byte{}, 0o644))
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := With(path, func() error {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				return os.WriteFile(path, append(data, 'x'), 0o644)
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, data, 20)
+	assert.NoFileExists(t, path+Suffix)
+}
@@ -0,0 +1,133 @@
+package git
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Snapshot records the uncommitted files of a working tree and their content,
+// so that changes made afterwards (e.g. by one task) can be told apart from
+// changes that were already there.
+type Snapshot struct {
+	root  string            // Repository root; status paths are relative to it
+	files map[string]string // Path -> content digest ("" if deleted)
+}
+
+// TakeSnapshot records the uncommitted files of the working tree containing
+// dir (empty = current directory).
+func TakeSnapshot(dir string) (*Snapshot, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("not a git repository")
+	}
+	snap := &Snapshot{root: strings.TrimSpace(string(out))}
+	if snap.files, err = snap.dirtyFiles(); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// Changed returns the uncommitted paths whose content differs from the
+// snapshot: files that became dirty since it was taken, and files that were
+// already dirty and changed again. Files committed in the meantime are clean
+// and not returned.
+func (s *Snapshot) Changed() ([]string, error) {
+	current, err := s.dirtyFiles()
+	if err != nil {
+		return nil, err
+	}
+	var changed []string
+	for path, digest := range current {
+		if before, ok := s.files[path]; !ok || before != digest {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// CommitChanges commits the paths changed since the snapshot with the given
+// message. Other uncommitted or staged changes are left alone. Returns false
+// without committing when nothing changed, e.g. because the agent already
+// committed its work.
+func (s *Snapshot) CommitChanges(message string) (bool, error) {
+	paths, err := s.Changed()
+	if err != nil {
+		return false, err
+	}
+	if len(paths) == 0 {
+		return false, nil
+	}
+
+	add := append([]string{"add", "-A", "--"}, paths...)
+	if out, err := s.git(add...).CombinedOutput(); err != nil {
+		return false, fmt.Errorf("staging changes: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	// A pathspec limits the commit to these paths, even if others are staged
+	commit := append([]string{"commit", "-q", "-m", message, "--"}, paths...)
+	if out, err := s.git(commit...).CombinedOutput(); err != nil {
+		return false, fmt.Errorf("committing changes: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return true, nil
+}
+
+// dirtyFiles lists uncommitted files with a digest of their current content.
+func (s *Snapshot) dirtyFiles() (map[string]string, error) {
+	out, err := s.git("status", "--porcelain", "-z", "--untracked-files=all").Output()
+	if err != nil {
+		return nil, fmt.Errorf("git status: %w", err)
+	}
+	files := make(map[string]string)
+	for _, path := range parseStatusPaths(out) {
+		files[path] = fileDigest(filepath.Join(s.root, path))
+	}
+	return files, nil
+}
+
+// git builds a git command run from the repository root. Pathspecs are taken
+// literally so file names containing glob characters are matched exactly.
+func (s *Snapshot) git(args ...string) *exec.Cmd {
+	cmd := exec.Command("git", append([]string{"--literal-pathspecs"}, args...)...)
+	cmd.Dir = s.root
+	return cmd
+}
+
+// parseStatusPaths extracts paths from `git status --porcelain -z` output.
+// Renames and copies list both the new and the original path.
+func parseStatusPaths(out []byte) []string {
+	var paths []string
+	fields := bytes.Split(out, []byte{0})
+	for i := 0; i < len(fields); i++ {
+		entry := fields[i]
+		if len(entry) < 4 {
+			continue
+		}
+		paths = append(paths, string(entry[3:]))
+		if entry[0] == 'R' || entry[0] == 'C' {
+			if i+1 < len(fields) && len(fields[i+1]) > 0 {
+				paths = append(paths, string(fields[i+1]))
+			}
+			i++
+		}
+	}
+	return paths
+}
+
+// fileDigest returns a digest of the file's content, or "" if it cannot be read.
+func fileDigest(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
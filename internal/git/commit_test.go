@@ -0,0 +1,139 @@
+// Package git tests committing the changes made since a working tree snapshot.
+// Related: internal/git/commit.go
+// Tags: git, commit
+
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCommitTestRepo creates a repository with one committed file, a.txt.
+// It returns the repo path and a helper that runs git in it.
+func newCommitTestRepo(t *testing.T) (string, func(args ...string) string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	runGit := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tmpDir
+		out, err := cmd.Output()
+		require.NoError(t, err, "git %v", args)
+		return strings.TrimSpace(string(out))
+	}
+	runGit("init")
+	runGit("config", "user.email", "test@test.com")
+	runGit("config", "user.name", "Test User")
+	writeFile(t, tmpDir, "a.txt", "a")
+	runGit("add", ".")
+	runGit("commit", "-m", "initial commit")
+	return tmpDir, runGit
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+// TestSnapshot_CommitChanges tests that only paths changed after the snapshot are committed
+func TestSnapshot_CommitChanges(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		before      map[string]string // Uncommitted files before the snapshot
+		after       map[string]string // Files written after the snapshot
+		wantCommit  bool
+		wantFiles   []string // Files in the new commit
+		wantPending string   // git status --porcelain afterwards
+	}{
+		"nothing changed": {
+			before:      map[string]string{"wip.txt": "wip"},
+			wantPending: "?? wip.txt",
+		},
+		"new and modified files are committed": {
+			after:      map[string]string{"a.txt": "changed", "dir/b [1].txt": "b"},
+			wantCommit: true,
+			wantFiles:  []string{"a.txt", "dir/b [1].txt"},
+		},
+		"pre-existing changes are left alone": {
+			before:      map[string]string{"wip.txt": "wip", "a.txt": "user edit"},
+			after:       map[string]string{"c.txt": "c"},
+			wantCommit:  true,
+			wantFiles:   []string{"c.txt"},
+			wantPending: "M a.txt\n?? wip.txt",
+		},
+		"dirty file changed again is committed": {
+			before:      map[string]string{"a.txt": "user edit", "wip.txt": "wip"},
+			after:       map[string]string{"a.txt": "task edit"},
+			wantCommit:  true,
+			wantFiles:   []string{"a.txt"},
+			wantPending: "?? wip.txt",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, runGit := newCommitTestRepo(t)
+			for file, content := range tt.before {
+				writeFile(t, dir, file, content)
+			}
+			snap, err := TakeSnapshot(dir)
+			require.NoError(t, err)
+			for file, content := range tt.after {
+				writeFile(t, dir, file, content)
+			}
+
+			committed, err := snap.CommitChanges("feat(T002): task")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCommit, committed)
+			if tt.wantCommit {
+				assert.Equal(t, "feat(T002): task", runGit("log", "-1", "--format=%s"))
+				files := strings.Split(runGit("-c", "core.quotePath=false", "show", "--name-only", "--format=", "HEAD"), "\n")
+				assert.ElementsMatch(t, tt.wantFiles, files)
+			}
+			assert.Equal(t, tt.wantPending, runGit("status", "--porcelain"))
+		})
+	}
+}
+
+// TestSnapshot_CommitChanges_KeepsStagedChanges tests that unrelated staged changes stay staged
+func TestSnapshot_CommitChanges_KeepsStagedChanges(t *testing.T) {
+	t.Parallel()
+
+	dir, runGit := newCommitTestRepo(t)
+	writeFile(t, dir, "staged.txt", "staged")
+	runGit("add", "staged.txt")
+	snap, err := TakeSnapshot(dir)
+	require.NoError(t, err)
+	writeFile(t, dir, "task.txt", "task")
+
+	committed, err := snap.CommitChanges("feat(T003): task")
+	require.NoError(t, err)
+	assert.True(t, committed)
+	assert.Equal(t, "task.txt", runGit("show", "--name-only", "--format=", "HEAD"))
+	assert.Equal(t, "A  staged.txt", runGit("status", "--porcelain"))
+}
+
+// TestTakeSnapshot_NotGitRepo tests TakeSnapshot fails outside a git repo
+func TestTakeSnapshot_NotGitRepo(t *testing.T) {
+	t.Parallel()
+
+	_, err := TakeSnapshot(t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestParseStatusPaths(t *testing.T) {
+	t.Parallel()
+
+	out := []byte(" M a.txt\x00?? dir/new file.txt\x00R  new.txt\x00old.txt\x00 D gone.txt\x00")
+	assert.Equal(t, []string{"a.txt", "dir/new file.txt", "new.txt", "old.txt", "gone.txt"}, parseStatusPaths(out))
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"sort"
 	"strings"
 )
@@ -203,6 +204,80 @@ func CreateBranch(name string) error {
 	return nil
 }
 
+// RenameBranch renames a local git branch
+// Returns an error if the old branch does not exist locally or the new name is taken
+func RenameBranch(oldName, newName string) error {
+	if !IsGitRepository() {
+		return fmt.Errorf("not a git repository")
+	}
+
+	if err := exec.Command("git", "rev-parse", "--verify", "--quiet", "refs/heads/"+oldName).Run(); err != nil {
+		return fmt.Errorf("branch '%s' does not exist", oldName)
+	}
+	if err := exec.Command("git", "rev-parse", "--verify", "--quiet", "refs/heads/"+newName).Run(); err == nil {
+		return fmt.Errorf("branch '%s' already exists", newName)
+	}
+
+	output, err := exec.Command("git", "branch", "-m", oldName, newName).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to rename branch '%s': %s", oldName, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// ShowFile returns the content of path as of revision rev (e.g. "HEAD",
+// "HEAD~3", a branch or tag). The error wraps os.ErrNotExist when the file
+// did not exist at that revision.
+func ShowFile(rev, path string) ([]byte, error) {
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	verify := exec.Command("git", "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	verify.Dir = dir
+	if err := verify.Run(); err != nil {
+		return nil, fmt.Errorf("unknown revision '%s'", rev)
+	}
+	// "./" makes the path relative to dir rather than the repository root
+	object := rev + ":./" + name
+	exists := exec.Command("git", "cat-file", "-e", object)
+	exists.Dir = dir
+	if err := exists.Run(); err != nil {
+		return nil, fmt.Errorf("%s at %s: %w", path, rev, os.ErrNotExist)
+	}
+	show := exec.Command("git", "show", object)
+	show.Dir = dir
+	output, err := show.Output()
+	if err != nil {
+		return nil, fmt.Errorf("reading %s at %s: %w", path, rev, err)
+	}
+	return output, nil
+}
+
+// DeletedPaths returns the files under dir that were deleted in any commit
+// reachable from a branch or tag, relative to dir. Files deleted and later
+// re-added are included; callers check whether they exist now.
+func DeletedPaths(dir string) ([]string, error) {
+	cmd := exec.Command("git", "log", "--all", "--no-renames", "--diff-filter=D",
+		"--name-only", "--relative", "--format=", "--", ".")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing deleted files: %w", err)
+	}
+	seen := make(map[string]bool)
+	var paths []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !seen[line] {
+			seen[line] = true
+			paths = append(paths, line)
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
 // FetchAllRemotes fetches from all configured remotes
 // It continues on failure and returns true if all fetches succeeded
 // Network failures are handled gracefully (returns false but no error for transient failures)
@@ -490,3 +490,136 @@ func TestCreateBranch_NotGitRepo(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "not a git repository")
 }
+
+// TestRenameBranch_InTempRepo tests RenameBranch in a temporary git repository
+// Note: Cannot use t.Parallel() as this test changes the working directory
+func TestRenameBranch_InTempRepo(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	runGit := func(args ...string) error {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tmpDir
+		return cmd.Run()
+	}
+
+	require.NoError(t, runGit("init"))
+	require.NoError(t, runGit("config", "user.email", "test@test.com"))
+	require.NoError(t, runGit("config", "user.name", "Test User"))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "test.txt"), []byte("test"), 0644))
+	require.NoError(t, runGit("add", "."))
+	require.NoError(t, runGit("commit", "-m", "initial commit"))
+	require.NoError(t, runGit("branch", "003-old-name"))
+	require.NoError(t, runGit("branch", "004-taken"))
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	t.Cleanup(func() {
+		_ = os.Chdir(origDir)
+	})
+
+	tests := map[string]struct {
+		oldName    string
+		newName    string
+		errContain string
+	}{
+		"missing branch": {
+			oldName:    "003-missing",
+			newName:    "003-other",
+			errContain: "does not exist",
+		},
+		"target exists": {
+			oldName:    "003-old-name",
+			newName:    "004-taken",
+			errContain: "already exists",
+		},
+		"renames branch": {
+			oldName: "003-old-name",
+			newName: "003-new-name",
+		},
+	}
+
+	// Run in a fixed order: the rename case consumes 003-old-name
+	for _, name := range []string{"missing branch", "target exists", "renames branch"} {
+		tt := tests[name]
+		t.Run(name, func(t *testing.T) {
+			err := RenameBranch(tt.oldName, tt.newName)
+			if tt.errContain != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContain)
+				return
+			}
+			require.NoError(t, err)
+
+			names, err := GetBranchNames()
+			require.NoError(t, err)
+			assert.Contains(t, names, tt.newName)
+			assert.NotContains(t, names, tt.oldName)
+		})
+	}
+}
+
+func TestShowFile_InTempRepo(t *testing.T) {
+	tmpDir := t.TempDir()
+	specDir := filepath.Join(tmpDir, "specs", "003-auth")
+	require.NoError(t, os.MkdirAll(specDir, 0755))
+
+	runGit := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tmpDir
+		require.NoError(t, cmd.Run())
+	}
+	runGit("init")
+	runGit("config", "user.email", "test@test.com")
+	runGit("config", "user.name", "Test User")
+	specPath := filepath.Join(specDir, "spec.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte("v1"), 0644))
+	runGit("add", ".")
+	runGit("commit", "-m", "first")
+	require.NoError(t, os.WriteFile(specPath, []byte("v2"), 0644))
+	runGit("commit", "-am", "second")
+
+	tests := map[string]struct {
+		rev        string
+		path       string
+		want       string
+		notExist   bool
+		errContain string
+	}{
+		"head": {
+			rev:  "HEAD",
+			path: specPath,
+			want: "v2",
+		},
+		"earlier revision": {
+			rev:  "HEAD~1",
+			path: specPath,
+			want: "v1",
+		},
+		"missing file": {
+			rev:      "HEAD",
+			path:     filepath.Join(specDir, "plan.yaml"),
+			notExist: true,
+		},
+		"unknown revision": {
+			rev:        "no-such-rev",
+			path:       specPath,
+			errContain: "unknown revision",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, err := ShowFile(tt.rev, tt.path)
+			switch {
+			case tt.notExist:
+				assert.ErrorIs(t, err, os.ErrNotExist)
+			case tt.errContain != "":
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContain)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, string(data))
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+)
+
+// CheckStatus is the outcome of a check in the report.
+type CheckStatus string
+
+const (
+	// StatusPass means the check passed.
+	StatusPass CheckStatus = "pass"
+	// StatusWarn means the check didn't pass but only warns (severity: warn).
+	StatusWarn CheckStatus = "warn"
+	// StatusFail means the check didn't pass and fails doctor.
+	StatusFail CheckStatus = "fail"
+)
+
+// defaultCustomCheckTimeout limits doctor_checks commands without a timeout.
+const defaultCustomCheckTimeout = 30 * time.Second
+
+// versionNumberPattern finds the first version number in command output.
+var versionNumberPattern = regexp.MustCompile(`\d+(?:\.\d+)+|\d+`)
+
+// RunCustomChecks runs the project's doctor_checks in order.
+func RunCustomChecks(checks []config.DoctorCheck) []CheckResult {
+	results := make([]CheckResult, 0, len(checks))
+	for _, check := range checks {
+		results = append(results, RunCustomCheck(check))
+	}
+	return results
+}
+
+// RunCustomCheck runs one doctor check's command and compares its exit code,
+// output and version with what the check expects.
+func RunCustomCheck(check config.DoctorCheck) CheckResult {
+	timeout := check.Timeout
+	if timeout == 0 {
+		timeout = defaultCustomCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", check.Command)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.WaitDelay = time.Second // don't wait on children that outlive the shell
+	err := cmd.Run()
+
+	message, passed := evaluateCustomCheck(check, output.String(), ctx.Err(), err)
+	result := CheckResult{Name: check.Name, Passed: passed, Message: message, Status: StatusPass}
+	if !passed {
+		result.Status = StatusFail
+		if check.Severity == "warn" {
+			result.Status = StatusWarn
+		}
+	}
+	return result
+}
+
+// evaluateCustomCheck compares a finished command with the check and
+// describes the result.
+func evaluateCustomCheck(check config.DoctorCheck, output string, ctxErr, runErr error) (string, bool) {
+	if errors.Is(ctxErr, context.DeadlineExceeded) {
+		return fmt.Sprintf("%q timed out", check.Command), false
+	}
+	exitCode := 0
+	var exitErr *exec.ExitError
+	if errors.As(runErr, &exitErr) {
+		exitCode = exitErr.ExitCode()
+	} else if runErr != nil {
+		return fmt.Sprintf("%q could not run: %v", check.Command, runErr), false
+	}
+	if exitCode != check.ExpectExit {
+		return fmt.Sprintf("%q exited with code %d, want %d%s", check.Command, exitCode, check.ExpectExit, outputSuffix(output)), false
+	}
+	if check.ExpectOutput != "" {
+		if re, err := regexp.Compile(check.ExpectOutput); err != nil || !re.MatchString(output) {
+			return fmt.Sprintf("%q output does not match %q%s", check.Command, check.ExpectOutput, outputSuffix(output)), false
+		}
+	}
+	if check.MinVersion != "" {
+		version := versionNumberPattern.FindString(output)
+		if version == "" {
+			return fmt.Sprintf("%q printed no version%s", check.Command, outputSuffix(output)), false
+		}
+		if compareVersions(version, check.MinVersion) < 0 {
+			return fmt.Sprintf("version %s is older than %s", version, strings.TrimPrefix(check.MinVersion, "v")), false
+		}
+		return fmt.Sprintf("version %s", version), true
+	}
+	return "ok", true
+}
+
+// outputSuffix quotes the first line of output for failure messages.
+func outputSuffix(output string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	if line == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", line)
+}
+
+// compareVersions compares dotted version numbers component by component,
+// treating missing components as 0. Returns -1, 0 or 1.
+func compareVersions(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := range max(len(as), len(bs)) {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
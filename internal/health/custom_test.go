@@ -0,0 +1,98 @@
+// Package health_test tests project-defined doctor checks.
+// Related: internal/health/custom.go
+// Tags: health, doctor, custom-checks
+
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunCustomCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		check       config.DoctorCheck
+		wantStatus  CheckStatus
+		wantMessage string
+	}{
+		"exit code passes": {
+			check:       config.DoctorCheck{Name: "true", Command: "true"},
+			wantStatus:  StatusPass,
+			wantMessage: "ok",
+		},
+		"unexpected exit code fails": {
+			check:       config.DoctorCheck{Name: "false", Command: "echo boom; exit 3"},
+			wantStatus:  StatusFail,
+			wantMessage: `"echo boom; exit 3" exited with code 3, want 0 (boom)`,
+		},
+		"expected non-zero exit passes": {
+			check:      config.DoctorCheck{Name: "absent", Command: "exit 1", ExpectExit: 1},
+			wantStatus: StatusPass,
+		},
+		"output mismatch with warn severity warns": {
+			check:       config.DoctorCheck{Name: "docker", Command: "echo stopped", ExpectOutput: "^running", Severity: "warn"},
+			wantStatus:  StatusWarn,
+			wantMessage: `"echo stopped" output does not match "^running" (stopped)`,
+		},
+		"version at minimum passes": {
+			check:       config.DoctorCheck{Name: "node", Command: "echo v20.11.1", MinVersion: "20"},
+			wantStatus:  StatusPass,
+			wantMessage: "version 20.11.1",
+		},
+		"older version fails": {
+			check:       config.DoctorCheck{Name: "go", Command: "echo go version go1.21.5 linux/amd64", MinVersion: "1.22"},
+			wantStatus:  StatusFail,
+			wantMessage: "version 1.21.5 is older than 1.22",
+		},
+		"timeout fails": {
+			check:       config.DoctorCheck{Name: "slow", Command: "sleep 5", Timeout: 50 * time.Millisecond},
+			wantStatus:  StatusFail,
+			wantMessage: `"sleep 5" timed out`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result := RunCustomCheck(tt.check)
+			assert.Equal(t, tt.check.Name, result.Name)
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Equal(t, tt.wantStatus == StatusPass, result.Passed)
+			if tt.wantMessage != "" {
+				assert.Equal(t, tt.wantMessage, result.Message)
+			}
+		})
+	}
+}
+
+func TestHealthReport_AddChecks(t *testing.T) {
+	t.Parallel()
+
+	report := &HealthReport{Passed: true}
+	report.AddChecks(CheckResult{Name: "Git", Passed: true})
+	report.AddChecks(CheckResult{Name: "Docker", Message: "not running", Status: StatusWarn})
+	assert.True(t, report.Passed, "warnings don't fail the report")
+	assert.Equal(t, StatusPass, report.Checks[0].Status)
+
+	output := FormatReport(report)
+	assert.Contains(t, output, "✓ Git")
+	assert.Contains(t, output, "⚠ Docker: not running")
+
+	report.AddChecks(CheckResult{Name: "Node.js", Message: "missing"})
+	assert.False(t, report.Passed)
+	assert.Equal(t, StatusFail, report.Checks[2].Status)
+}
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0, compareVersions("20", "20.0.0"))
+	assert.Equal(t, 1, compareVersions("1.10", "1.9"))
+	assert.Equal(t, -1, compareVersions("v1.2.3", "1.3"))
+}
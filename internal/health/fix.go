@@ -0,0 +1,215 @@
+package health
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/commands"
+	"github.com/ariel-frischer/autospec/internal/config"
+)
+
+// FixStatus is the outcome of a single remediation.
+type FixStatus string
+
+const (
+	// FixApplied means the problem was found and fixed.
+	FixApplied FixStatus = "applied"
+	// FixFailed means the problem was found but fixing it failed.
+	FixFailed FixStatus = "failed"
+	// FixSkipped means the problem was found but left alone (e.g., user declined).
+	FixSkipped FixStatus = "skipped"
+)
+
+// FixResult describes one remediation attempted by 'autospec doctor --fix'.
+type FixResult struct {
+	Name    string    `json:"name"`
+	Status  FixStatus `json:"status"`
+	Message string    `json:"message"`
+}
+
+// FixOptions configures RunFixes.
+type FixOptions struct {
+	// ProjectDir is the project root; relative paths resolve against the
+	// current directory when empty.
+	ProjectDir string
+
+	// ConfirmLogin is asked before launching 'claude login' for an expired
+	// OAuth token. When nil, the login fix is skipped.
+	ConfirmLogin func() bool
+}
+
+// detectClaudeAuth and runClaudeLogin are replaced in tests.
+var (
+	detectClaudeAuth = cliagent.DetectClaudeAuth
+	runClaudeLogin   = func() error {
+		cmd := exec.Command("claude", "login")
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		return cmd.Run()
+	}
+)
+
+// RunFixes remediates the problems doctor knows how to repair: a missing
+// project config, missing or outdated command templates, non-executable
+// project scripts, and an expired Claude OAuth login. Only problems that were
+// found are reported; an empty result means nothing needed fixing.
+func RunFixes(opts FixOptions) []FixResult {
+	fixes := []func(FixOptions) *FixResult{
+		fixProjectConfig,
+		fixCommandTemplates,
+		fixScriptPermissions,
+		fixClaudeLogin,
+	}
+
+	var results []FixResult
+	for _, fix := range fixes {
+		if result := fix(opts); result != nil {
+			results = append(results, *result)
+		}
+	}
+	return results
+}
+
+// fixProjectConfig writes the default config template to .autospec/config.yml
+// when the project has neither it nor a legacy config.json.
+func fixProjectConfig(opts FixOptions) *FixResult {
+	configPath := filepath.Join(opts.ProjectDir, config.ProjectConfigPath())
+	legacyPath := filepath.Join(opts.ProjectDir, config.LegacyProjectConfigPath())
+	if fileExists(configPath) || fileExists(legacyPath) {
+		return nil
+	}
+
+	result := &FixResult{Name: "Project config"}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		return failed(result, fmt.Errorf("creating config directory: %w", err))
+	}
+	if err := os.WriteFile(configPath, []byte(config.GetDefaultConfigTemplate()), 0o644); err != nil {
+		return failed(result, fmt.Errorf("writing config: %w", err))
+	}
+	result.Status = FixApplied
+	result.Message = fmt.Sprintf("created %s with defaults", configPath)
+	return result
+}
+
+// fixCommandTemplates reinstalls the embedded command templates when any are
+// missing or older than the embedded version.
+func fixCommandTemplates(opts FixOptions) *FixResult {
+	cmdDir := filepath.Join(opts.ProjectDir, commands.GetDefaultCommandsDir())
+	mismatches, err := commands.CheckVersions(cmdDir)
+	if err != nil {
+		return failed(&FixResult{Name: "Command templates"}, err)
+	}
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	var missing, outdated int
+	for _, m := range mismatches {
+		if m.Action == "install" {
+			missing++
+		} else {
+			outdated++
+		}
+	}
+
+	result := &FixResult{Name: "Command templates"}
+	if _, err := commands.InstallTemplates(cmdDir); err != nil {
+		return failed(result, err)
+	}
+	result.Status = FixApplied
+	result.Message = fmt.Sprintf("installed %d missing, updated %d outdated in %s", missing, outdated, cmdDir)
+	return result
+}
+
+// fixScriptPermissions makes shell scripts under .autospec/scripts executable.
+func fixScriptPermissions(opts FixOptions) *FixResult {
+	scriptsDir := filepath.Join(opts.ProjectDir, ".autospec", "scripts")
+	entries, err := os.ReadDir(scriptsDir)
+	if err != nil {
+		return nil // No scripts directory - nothing to fix
+	}
+
+	result := &FixResult{Name: "Script permissions"}
+	var fixed []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sh") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return failed(result, err)
+		}
+		if info.Mode().Perm()&0o111 == 0o111 {
+			continue
+		}
+		path := filepath.Join(scriptsDir, entry.Name())
+		if err := os.Chmod(path, info.Mode().Perm()|0o111); err != nil {
+			return failed(result, fmt.Errorf("chmod %s: %w", path, err))
+		}
+		fixed = append(fixed, entry.Name())
+	}
+
+	if len(fixed) == 0 {
+		return nil
+	}
+	result.Status = FixApplied
+	result.Message = "made executable: " + strings.Join(fixed, ", ")
+	return result
+}
+
+// fixClaudeLogin offers to run 'claude login' when the stored OAuth token has expired.
+func fixClaudeLogin(opts FixOptions) *FixResult {
+	auth := detectClaudeAuth()
+	if !auth.Installed || auth.AuthType != cliagent.AuthTypeOAuth || !auth.OAuthExpired {
+		return nil
+	}
+
+	result := &FixResult{Name: "Claude login"}
+	if opts.ConfirmLogin == nil || !opts.ConfirmLogin() {
+		result.Status = FixSkipped
+		result.Message = "OAuth token expired; run 'claude login' to refresh it"
+		return result
+	}
+	if err := runClaudeLogin(); err != nil {
+		return failed(result, fmt.Errorf("claude login: %w", err))
+	}
+	result.Status = FixApplied
+	result.Message = "refreshed OAuth login"
+	return result
+}
+
+// failed marks result as failed with err as its message.
+func failed(result *FixResult, err error) *FixResult {
+	result.Status = FixFailed
+	result.Message = err.Error()
+	return result
+}
+
+// fileExists reports whether path exists.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// FormatFixResults formats remediation results for console output.
+func FormatFixResults(results []FixResult) string {
+	if len(results) == 0 {
+		return "Fixes:\n  nothing to fix\n"
+	}
+
+	output := "Fixes:\n"
+	for _, r := range results {
+		switch r.Status {
+		case FixApplied:
+			output += fmt.Sprintf("  ✓ %s: %s\n", r.Name, r.Message)
+		case FixFailed:
+			output += fmt.Sprintf("  ✗ %s: %s\n", r.Name, r.Message)
+		default:
+			output += fmt.Sprintf("  ○ %s: %s\n", r.Name, r.Message)
+		}
+	}
+	return output
+}
@@ -0,0 +1,181 @@
+// Package health_test tests doctor --fix remediation.
+// Related: internal/health/fix.go
+// Tags: health, doctor, fix
+
+package health
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/commands"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixProjectConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		existing   string
+		wantResult bool
+	}{
+		"creates missing config":     {wantResult: true},
+		"keeps existing yaml config": {existing: "config.yml"},
+		"keeps existing legacy json": {existing: "config.json"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			if tt.existing != "" {
+				require.NoError(t, os.MkdirAll(filepath.Join(dir, ".autospec"), 0o755))
+				require.NoError(t, os.WriteFile(filepath.Join(dir, ".autospec", tt.existing), []byte("specs_dir: ./custom\n"), 0o644))
+			}
+
+			result := fixProjectConfig(FixOptions{ProjectDir: dir})
+			if !tt.wantResult {
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NotNil(t, result)
+			assert.Equal(t, FixApplied, result.Status)
+			content, err := os.ReadFile(filepath.Join(dir, ".autospec", "config.yml"))
+			require.NoError(t, err)
+			assert.Contains(t, string(content), "specs_dir:")
+		})
+	}
+}
+
+func TestFixCommandTemplates(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cmdDir := filepath.Join(dir, ".claude", "commands")
+	require.NoError(t, os.MkdirAll(cmdDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(cmdDir, "autospec.plan.md"), []byte("---\nversion: \"0.0.1\"\n---\nold"), 0o644))
+
+	result := fixCommandTemplates(FixOptions{ProjectDir: dir})
+	require.NotNil(t, result)
+	assert.Equal(t, FixApplied, result.Status)
+	assert.Contains(t, result.Message, "updated 1 outdated")
+
+	mismatches, err := commands.CheckVersions(cmdDir)
+	require.NoError(t, err)
+	assert.Empty(t, mismatches)
+
+	assert.Nil(t, fixCommandTemplates(FixOptions{ProjectDir: dir}), "second run should find nothing to fix")
+}
+
+func TestFixScriptPermissions(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	scriptsDir := filepath.Join(dir, ".autospec", "scripts")
+	require.NoError(t, os.MkdirAll(scriptsDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(scriptsDir, "setup-worktree.sh"), []byte("#!/bin/sh\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(scriptsDir, "ok.sh"), []byte("#!/bin/sh\n"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(scriptsDir, "notes.txt"), []byte("notes"), 0o644))
+
+	result := fixScriptPermissions(FixOptions{ProjectDir: dir})
+	require.NotNil(t, result)
+	assert.Equal(t, FixApplied, result.Status)
+	assert.Equal(t, "made executable: setup-worktree.sh", result.Message)
+
+	info, err := os.Stat(filepath.Join(scriptsDir, "setup-worktree.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+
+	info, err = os.Stat(filepath.Join(scriptsDir, "notes.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm(), "non-script files are left alone")
+
+	assert.Nil(t, fixScriptPermissions(FixOptions{ProjectDir: t.TempDir()}), "no scripts directory")
+}
+
+func TestFixClaudeLogin(t *testing.T) {
+	// Not parallel: replaces package-level detectClaudeAuth and runClaudeLogin.
+
+	expired := cliagent.ClaudeAuthStatus{Installed: true, AuthType: cliagent.AuthTypeOAuth, OAuthExpired: true}
+
+	tests := map[string]struct {
+		auth       cliagent.ClaudeAuthStatus
+		confirm    func() bool
+		loginErr   error
+		wantStatus FixStatus
+		wantLogin  bool
+	}{
+		"valid login": {
+			auth: cliagent.ClaudeAuthStatus{Installed: true, AuthType: cliagent.AuthTypeOAuth},
+		},
+		"api key auth": {
+			auth: cliagent.ClaudeAuthStatus{Installed: true, AuthType: cliagent.AuthTypeAPI},
+		},
+		"no prompt available": {
+			auth:       expired,
+			wantStatus: FixSkipped,
+		},
+		"user declines": {
+			auth:       expired,
+			confirm:    func() bool { return false },
+			wantStatus: FixSkipped,
+		},
+		"user accepts": {
+			auth:       expired,
+			confirm:    func() bool { return true },
+			wantStatus: FixApplied,
+			wantLogin:  true,
+		},
+		"login fails": {
+			auth:       expired,
+			confirm:    func() bool { return true },
+			loginErr:   errors.New("exit status 1"),
+			wantStatus: FixFailed,
+			wantLogin:  true,
+		},
+	}
+
+	origDetect, origLogin := detectClaudeAuth, runClaudeLogin
+	t.Cleanup(func() { detectClaudeAuth, runClaudeLogin = origDetect, origLogin })
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			loggedIn := false
+			detectClaudeAuth = func() cliagent.ClaudeAuthStatus { return tt.auth }
+			runClaudeLogin = func() error {
+				loggedIn = true
+				return tt.loginErr
+			}
+
+			result := fixClaudeLogin(FixOptions{ConfirmLogin: tt.confirm})
+			assert.Equal(t, tt.wantLogin, loggedIn)
+			if tt.wantStatus == "" {
+				assert.Nil(t, result)
+				return
+			}
+			require.NotNil(t, result)
+			assert.Equal(t, tt.wantStatus, result.Status)
+		})
+	}
+}
+
+func TestFormatFixResults(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Fixes:\n  nothing to fix\n", FormatFixResults(nil))
+
+	output := FormatFixResults([]FixResult{
+		{Name: "Project config", Status: FixApplied, Message: "created"},
+		{Name: "Command templates", Status: FixFailed, Message: "disk full"},
+		{Name: "Claude login", Status: FixSkipped, Message: "expired"},
+	})
+	assert.Contains(t, output, "✓ Project config: created")
+	assert.Contains(t, output, "✗ Command templates: disk full")
+	assert.Contains(t, output, "○ Claude login: expired")
+}
@@ -14,17 +14,43 @@ import (
 
 // CheckResult represents the result of a single health check
 type CheckResult struct {
-	Name    string
-	Passed  bool
-	Message string
+	Name    string      `json:"name"`
+	Passed  bool        `json:"passed"`
+	Message string      `json:"message"`
+	Status  CheckStatus `json:"status,omitempty"` // Empty is derived from Passed
+}
+
+// status returns the check's status, deriving it from Passed when unset.
+func (c CheckResult) status() CheckStatus {
+	switch {
+	case c.Status != "":
+		return c.Status
+	case c.Passed:
+		return StatusPass
+	default:
+		return StatusFail
+	}
+}
+
+// AddChecks appends results to the report. Only failing checks, not
+// warnings, mark the report as failed.
+func (r *HealthReport) AddChecks(results ...CheckResult) {
+	for _, check := range results {
+		check.Status = check.status()
+		r.Checks = append(r.Checks, check)
+		if check.Status == StatusFail {
+			r.Passed = false
+		}
+	}
 }
 
 // HealthReport contains all health check results
 type HealthReport struct {
-	Checks       []CheckResult
-	AgentChecks  []cliagent.AgentStatus
-	Passed       bool
-	AgentsPassed bool
+	Checks       []CheckResult          `json:"checks"`
+	AgentChecks  []cliagent.AgentStatus `json:"agents"`
+	Passed       bool                   `json:"passed"`
+	AgentsPassed bool                   `json:"agents_passed"`
+	Fixes        []FixResult            `json:"fixes,omitempty"` // Set by 'doctor --fix'
 }
 
 // RunHealthChecks runs all health checks and returns a report
@@ -36,26 +62,7 @@ func RunHealthChecks() *HealthReport {
 		AgentsPassed: true,
 	}
 
-	// Check Claude CLI
-	claudeCheck := CheckClaudeCLI()
-	report.Checks = append(report.Checks, claudeCheck)
-	if !claudeCheck.Passed {
-		report.Passed = false
-	}
-
-	// Check Git
-	gitCheck := CheckGit()
-	report.Checks = append(report.Checks, gitCheck)
-	if !gitCheck.Passed {
-		report.Passed = false
-	}
-
-	// Check Claude settings
-	settingsCheck := CheckClaudeSettings()
-	report.Checks = append(report.Checks, settingsCheck)
-	if !settingsCheck.Passed {
-		report.Passed = false
-	}
+	report.AddChecks(CheckClaudeCLI(), CheckGit(), CheckClaudeSettings())
 
 	// Check registered agents
 	report.AgentChecks = cliagent.Doctor()
@@ -109,11 +116,14 @@ func CheckGit() CheckResult {
 func FormatReport(report *HealthReport) string {
 	var output string
 
-	// Core checks
+	// Core and project checks
 	for _, check := range report.Checks {
-		if check.Passed {
+		switch check.status() {
+		case StatusPass:
 			output += fmt.Sprintf("✓ %s: %s\n", check.Name, check.Message)
-		} else {
+		case StatusWarn:
+			output += fmt.Sprintf("⚠ %s: %s\n", check.Name, check.Message)
+		default:
 			output += fmt.Sprintf("✗ %s: %s\n", check.Name, check.Message)
 		}
 	}
@@ -0,0 +1,101 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// EstimateSamples is how many recent runs of a phase an estimate is based on.
+	EstimateSamples = 10
+	// MinEstimateSamples is how many runs a phase needs before it gets an estimate.
+	MinEstimateSamples = 2
+)
+
+// PhaseEstimates returns the usual duration of each phase: the median of its
+// most recent successful runs. Phase durations recorded in Usage are used
+// first; entries without them count as one run of the phase named by their
+// command (e.g., a "plan" command). If specs is non-nil, only runs on those
+// specs are considered, which keeps the global history to the current repo.
+// Phases with fewer than MinEstimateSamples runs are left out.
+func PhaseEstimates(entries []HistoryEntry, specs map[string]bool) map[string]time.Duration {
+	samples := make(map[string][]time.Duration)
+	add := func(phase, spec string, d time.Duration) {
+		if d <= 0 || (specs != nil && !specs[filepath.Base(spec)]) {
+			return
+		}
+		samples[phase] = append(samples[phase], d)
+	}
+
+	for _, entry := range entries {
+		timed := false
+		for _, u := range entry.Usage {
+			if d, err := time.ParseDuration(u.Duration); err == nil {
+				add(u.Phase, u.Spec, d)
+				timed = true
+			}
+		}
+		if timed || !succeeded(entry) {
+			continue
+		}
+		if d, err := time.ParseDuration(entry.Duration); err == nil {
+			add(entry.Command, entry.Spec, d)
+		}
+	}
+
+	estimates := make(map[string]time.Duration, len(samples))
+	for phase, ds := range samples {
+		if len(ds) < MinEstimateSamples {
+			continue
+		}
+		if len(ds) > EstimateSamples {
+			ds = ds[len(ds)-EstimateSamples:]
+		}
+		estimates[phase] = median(ds)
+	}
+	return estimates
+}
+
+// LoadPhaseEstimates returns PhaseEstimates for the history in stateDir,
+// limited to the specs in specsDir. Estimates are best-effort: unreadable
+// history yields none.
+func LoadPhaseEstimates(stateDir, specsDir string) map[string]time.Duration {
+	history, err := LoadHistory(stateDir)
+	if err != nil || len(history.Entries) == 0 {
+		return nil
+	}
+	dirs, err := os.ReadDir(specsDir)
+	if err != nil {
+		return nil
+	}
+	specs := make(map[string]bool, len(dirs))
+	for _, dir := range dirs {
+		if dir.IsDir() {
+			specs[dir.Name()] = true
+		}
+	}
+	return PhaseEstimates(history.Entries, specs)
+}
+
+// succeeded reports whether an entry finished without error. Entries written
+// before statuses were recorded only have an exit code.
+func succeeded(entry HistoryEntry) bool {
+	if entry.Status != "" {
+		return entry.Status == StatusCompleted
+	}
+	return entry.ExitCode == 0
+}
+
+// median returns the middle value of ds, averaging the two middle values
+// for an even count.
+func median(ds []time.Duration) time.Duration {
+	sorted := append([]time.Duration(nil), ds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
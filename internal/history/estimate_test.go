@@ -0,0 +1,111 @@
+// Package history tests phase duration estimates built from history.
+// Related: internal/history/estimate.go
+// Tags: history, estimate, duration, eta
+
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhaseEstimates(t *testing.T) {
+	t.Parallel()
+
+	run := func(command, spec, status, duration string, usage ...PhaseUsage) HistoryEntry {
+		return HistoryEntry{Command: command, Spec: spec, Status: status, Duration: duration, Usage: usage}
+	}
+	phase := func(name, spec, duration string) PhaseUsage {
+		return PhaseUsage{Phase: name, Spec: spec, Duration: duration}
+	}
+
+	tests := map[string]struct {
+		entries []HistoryEntry
+		specs   map[string]bool
+		want    map[string]time.Duration
+	}{
+		"median of single-phase commands": {
+			entries: []HistoryEntry{
+				run("plan", "001-a", StatusCompleted, "3m"),
+				run("plan", "002-b", StatusCompleted, "5m"),
+				run("plan", "003-c", StatusCompleted, "4m"),
+			},
+			want: map[string]time.Duration{"plan": 4 * time.Minute},
+		},
+		"phase durations of multi-phase runs": {
+			entries: []HistoryEntry{
+				run("run", "001-a", StatusCompleted, "10m", phase("plan", "001-a", "2m"), phase("tasks", "001-a", "1m")),
+				run("run", "002-b", StatusCompleted, "12m", phase("plan", "002-b", "4m"), phase("tasks", "002-b", "3m")),
+			},
+			want: map[string]time.Duration{"plan": 3 * time.Minute, "tasks": 2 * time.Minute},
+		},
+		"failed and cancelled runs are skipped": {
+			entries: []HistoryEntry{
+				run("plan", "001-a", StatusCompleted, "4m"),
+				run("plan", "001-a", StatusFailed, "30s"),
+				run("plan", "001-a", StatusCancelled, "10s"),
+				run("plan", "001-a", "", "6m"),
+			},
+			want: map[string]time.Duration{"plan": 5 * time.Minute},
+		},
+		"phases with one run get no estimate": {
+			entries: []HistoryEntry{
+				run("plan", "001-a", StatusCompleted, "4m"),
+				run("tasks", "001-a", StatusCompleted, "2m"),
+				run("tasks", "001-a", StatusCompleted, "2m"),
+			},
+			want: map[string]time.Duration{"tasks": 2 * time.Minute},
+		},
+		"only specs of this repo": {
+			entries: []HistoryEntry{
+				run("plan", "specs/001-a", StatusCompleted, "4m"),
+				run("plan", "001-a", StatusCompleted, "4m"),
+				run("plan", "900-elsewhere", StatusCompleted, "60m"),
+				run("plan", "", StatusCompleted, "60m"),
+			},
+			specs: map[string]bool{"001-a": true},
+			want:  map[string]time.Duration{"plan": 4 * time.Minute},
+		},
+		"recent runs only": {
+			entries: func() []HistoryEntry {
+				entries := []HistoryEntry{run("plan", "001-a", StatusCompleted, "60m"), run("plan", "001-a", StatusCompleted, "60m")}
+				for range EstimateSamples {
+					entries = append(entries, run("plan", "001-a", StatusCompleted, "2m"))
+				}
+				return entries
+			}(),
+			want: map[string]time.Duration{"plan": 2 * time.Minute},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, PhaseEstimates(tt.entries, tt.specs))
+		})
+	}
+}
+
+func TestLoadPhaseEstimates(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	specsDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(specsDir, "001-a"), 0o755))
+
+	assert.Nil(t, LoadPhaseEstimates(stateDir, specsDir), "no history")
+
+	require.NoError(t, SaveHistory(stateDir, &HistoryFile{Entries: []HistoryEntry{
+		{Command: "plan", Spec: "001-a", Status: StatusCompleted, Duration: "3m"},
+		{Command: "plan", Spec: "001-a", Status: StatusCompleted, Duration: "5m"},
+		{Command: "plan", Spec: "002-gone", Status: StatusCompleted, Duration: "90m"},
+	}}))
+
+	assert.Equal(t, map[string]time.Duration{"plan": 4 * time.Minute}, LoadPhaseEstimates(stateDir, specsDir))
+	assert.Nil(t, LoadPhaseEstimates(stateDir, filepath.Join(specsDir, "missing")))
+}
@@ -0,0 +1,170 @@
+package history
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Export formats supported by Export.
+const (
+	// ExportCSV writes a header row followed by one row per entry.
+	ExportCSV = "csv"
+	// ExportJSONL writes one JSON object per line.
+	ExportJSONL = "jsonl"
+)
+
+// ExportFormats lists the supported export formats.
+var ExportFormats = []string{ExportCSV, ExportJSONL}
+
+// ExportRecord is one history entry flattened for spreadsheets and data pipelines.
+// Token fields sum the entry's phase usage and are nil when the agent reported none.
+type ExportRecord struct {
+	ID                  string   `json:"id,omitempty"`
+	Timestamp           string   `json:"timestamp"`
+	Command             string   `json:"command"`
+	Spec                string   `json:"spec,omitempty"`
+	Status              string   `json:"status,omitempty"`
+	ExitCode            int      `json:"exit_code"`
+	DurationSeconds     *float64 `json:"duration_seconds,omitempty"`
+	Agent               string   `json:"agent,omitempty"`
+	InputTokens         *int     `json:"input_tokens,omitempty"`
+	OutputTokens        *int     `json:"output_tokens,omitempty"`
+	CacheCreationTokens *int     `json:"cache_creation_tokens,omitempty"`
+	CacheReadTokens     *int     `json:"cache_read_tokens,omitempty"`
+	TotalTokens         *int     `json:"total_tokens,omitempty"`
+	CostUSD             *float64 `json:"cost_usd,omitempty"`
+}
+
+// exportColumns is the CSV header, in ExportRecord field order.
+var exportColumns = []string{
+	"id", "timestamp", "command", "spec", "status", "exit_code", "duration_seconds", "agent",
+	"input_tokens", "output_tokens", "cache_creation_tokens", "cache_read_tokens", "total_tokens", "cost_usd",
+}
+
+// NewExportRecord flattens a history entry. Agents from the entry's phase usage
+// are joined with "+" when a command ran more than one.
+func NewExportRecord(entry HistoryEntry) ExportRecord {
+	record := ExportRecord{
+		ID:        entry.ID,
+		Timestamp: entry.Timestamp.UTC().Format(time.RFC3339),
+		Command:   entry.Command,
+		Spec:      entry.Spec,
+		Status:    entry.Status,
+		ExitCode:  entry.ExitCode,
+	}
+	if d, err := time.ParseDuration(entry.Duration); err == nil {
+		seconds := d.Seconds()
+		record.DurationSeconds = &seconds
+	}
+
+	if len(entry.Usage) == 0 {
+		return record
+	}
+
+	var agents []string
+	var total PhaseUsage
+	for _, u := range entry.Usage {
+		if u.Agent != "" && !slices.Contains(agents, u.Agent) {
+			agents = append(agents, u.Agent)
+		}
+		total.InputTokens += u.InputTokens
+		total.OutputTokens += u.OutputTokens
+		total.CacheCreationTokens += u.CacheCreationTokens
+		total.CacheReadTokens += u.CacheReadTokens
+		total.CostUSD += u.CostUSD
+	}
+	totalTokens := total.TotalTokens()
+
+	record.Agent = strings.Join(agents, "+")
+	record.InputTokens = &total.InputTokens
+	record.OutputTokens = &total.OutputTokens
+	record.CacheCreationTokens = &total.CacheCreationTokens
+	record.CacheReadTokens = &total.CacheReadTokens
+	record.TotalTokens = &totalTokens
+	record.CostUSD = &total.CostUSD
+	return record
+}
+
+// csvRow returns the record as CSV cells; missing values are empty cells.
+func (r ExportRecord) csvRow() []string {
+	return []string{
+		r.ID, r.Timestamp, r.Command, r.Spec, r.Status, strconv.Itoa(r.ExitCode),
+		formatFloatCell(r.DurationSeconds), r.Agent,
+		formatIntCell(r.InputTokens), formatIntCell(r.OutputTokens),
+		formatIntCell(r.CacheCreationTokens), formatIntCell(r.CacheReadTokens),
+		formatIntCell(r.TotalTokens), formatFloatCell(r.CostUSD),
+	}
+}
+
+// Export writes entries to w in the given format (csv or jsonl).
+func Export(w io.Writer, entries []HistoryEntry, format string) error {
+	switch format {
+	case ExportCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(exportColumns); err != nil {
+			return fmt.Errorf("writing csv header: %w", err)
+		}
+		for _, entry := range entries {
+			if err := cw.Write(NewExportRecord(entry).csvRow()); err != nil {
+				return fmt.Errorf("writing csv row: %w", err)
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case ExportJSONL:
+		enc := json.NewEncoder(w)
+		for _, entry := range entries {
+			if err := enc.Encode(NewExportRecord(entry)); err != nil {
+				return fmt.Errorf("writing jsonl record: %w", err)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported export format %q (valid: %s)", format, strings.Join(ExportFormats, ", "))
+	}
+}
+
+// ParseSince parses a --since value into a cutoff time relative to now.
+// Accepts ages ("30d", "2w", "12h", see ParseAge) and dates ("2026-01-31").
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return t, nil
+	}
+	age, err := ParseAge(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since value %q (e.g. 30d, 2w, 12h or 2026-01-31)", value)
+	}
+	return now.Add(-age), nil
+}
+
+// FilterSince returns the entries that started at or after cutoff.
+func FilterSince(entries []HistoryEntry, cutoff time.Time) []HistoryEntry {
+	var result []HistoryEntry
+	for _, entry := range entries {
+		if !entry.Timestamp.Before(cutoff) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+func formatIntCell(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func formatFloatCell(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
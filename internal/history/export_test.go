@@ -0,0 +1,153 @@
+// Package history tests CSV/JSONL export and --since parsing.
+// Related: internal/history/export.go
+// Tags: history, export, csv, jsonl
+
+package history
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var exportNow = time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+
+func exportTestEntries() []HistoryEntry {
+	return []HistoryEntry{
+		{
+			ID: "brave_fox_20260201_090000", Timestamp: time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC),
+			Command: "all", Status: StatusCompleted, Duration: "2m30.5s",
+			Usage: []PhaseUsage{
+				{Spec: "001-auth", Phase: "plan", Agent: "claude", InputTokens: 100, OutputTokens: 50, CostUSD: 0.25},
+				{Spec: "001-auth", Phase: "implement", Agent: "claude", InputTokens: 900, OutputTokens: 450, CacheReadTokens: 500, CostUSD: 1.5},
+			},
+		},
+		{
+			ID: "calm_owl_20260209_100000", Timestamp: time.Date(2026, 2, 9, 10, 0, 0, 0, time.UTC),
+			Command: "implement", Spec: "002-api, v2", Status: StatusFailed, ExitCode: 1, Duration: "45s",
+		},
+	}
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		format string
+		check  func(t *testing.T, out string)
+	}{
+		"csv": {
+			format: ExportCSV,
+			check: func(t *testing.T, out string) {
+				rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+				require.NoError(t, err)
+				require.Len(t, rows, 3)
+				assert.Equal(t, exportColumns, rows[0])
+				assert.Equal(t, []string{
+					"brave_fox_20260201_090000", "2026-02-01T09:00:00Z", "all", "", "completed", "0", "150.5", "claude",
+					"1000", "500", "0", "500", "2000", "1.75",
+				}, rows[1])
+				assert.Equal(t, []string{
+					"calm_owl_20260209_100000", "2026-02-09T10:00:00Z", "implement", "002-api, v2", "failed", "1", "45", "",
+					"", "", "", "", "", "",
+				}, rows[2], "entries without usage leave token cells empty")
+			},
+		},
+		"jsonl": {
+			format: ExportJSONL,
+			check: func(t *testing.T, out string) {
+				lines := strings.Split(strings.TrimSpace(out), "\n")
+				require.Len(t, lines, 2)
+
+				var first map[string]any
+				require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+				assert.Equal(t, "all", first["command"])
+				assert.Equal(t, "claude", first["agent"])
+				assert.Equal(t, 2000.0, first["total_tokens"])
+				assert.Equal(t, 150.5, first["duration_seconds"])
+
+				var second map[string]any
+				require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+				assert.Equal(t, 1.0, second["exit_code"])
+				assert.NotContains(t, second, "total_tokens")
+				assert.NotContains(t, second, "agent")
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			require.NoError(t, Export(&out, exportTestEntries(), tt.format))
+			tt.check(t, out.String())
+		})
+	}
+}
+
+func TestExport_UnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	err := Export(&bytes.Buffer{}, nil, "xlsx")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported export format "xlsx"`)
+}
+
+func TestNewExportRecord_MultipleAgents(t *testing.T) {
+	t.Parallel()
+
+	record := NewExportRecord(HistoryEntry{
+		Command: "all",
+		Usage:   []PhaseUsage{{Agent: "claude"}, {Agent: "opencode"}, {Agent: "claude"}, {}},
+	})
+	assert.Equal(t, "claude+opencode", record.Agent)
+	assert.Nil(t, record.DurationSeconds, "running entries have no duration")
+}
+
+func TestParseSince(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		"days":         {value: "30d", want: exportNow.AddDate(0, 0, -30)},
+		"weeks":        {value: "2w", want: exportNow.AddDate(0, 0, -14)},
+		"hours":        {value: "12h", want: exportNow.Add(-12 * time.Hour)},
+		"date":         {value: "2026-01-31", want: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)},
+		"empty":        {value: "", wantErr: true},
+		"bad days":     {value: "xd", wantErr: true},
+		"negative":     {value: "-5h", wantErr: true},
+		"unknown unit": {value: "3y", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseSince(tt.value, exportNow)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilterSince(t *testing.T) {
+	t.Parallel()
+
+	entries := exportTestEntries()
+	got := FilterSince(entries, time.Date(2026, 2, 5, 0, 0, 0, 0, time.UTC))
+	require.Len(t, got, 1)
+	assert.Equal(t, "calm_owl_20260209_100000", got[0].ID)
+	assert.Len(t, FilterSince(entries, time.Time{}), 2)
+}
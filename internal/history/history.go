@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/ariel-frischer/autospec/internal/statestore"
 	"gopkg.in/yaml.v3"
 )
 
@@ -33,27 +34,57 @@ const (
 type HistoryEntry struct {
 	// ID is a unique identifier in adjective_noun_YYYYMMDD_HHMMSS format.
 	// Optional for backward compatibility with old entries.
-	ID string `yaml:"id,omitempty"`
+	ID string `yaml:"id,omitempty" json:"id,omitempty"`
 	// Timestamp is when the command started executing (RFC3339 format in YAML).
 	// Kept for backward compatibility with existing entries.
-	Timestamp time.Time `yaml:"timestamp"`
+	Timestamp time.Time `yaml:"timestamp" json:"timestamp"`
 	// Command is the name of the autospec command (e.g., "specify", "run").
-	Command string `yaml:"command"`
+	Command string `yaml:"command" json:"command"`
 	// Spec is the name or path of the spec being worked on (may be empty).
-	Spec string `yaml:"spec,omitempty"`
+	Spec string `yaml:"spec,omitempty" json:"spec,omitempty"`
 	// Status is the current state: running, completed, failed, cancelled.
 	// Optional for backward compatibility with old entries.
-	Status string `yaml:"status,omitempty"`
+	Status string `yaml:"status,omitempty" json:"status,omitempty"`
 	// CreatedAt is when the command started (explicit field, same as Timestamp).
 	// Optional for backward compatibility with old entries.
-	CreatedAt time.Time `yaml:"created_at,omitempty"`
+	CreatedAt time.Time `yaml:"created_at,omitempty" json:"created_at,omitempty"`
 	// CompletedAt is when the command finished (nil if still running).
 	// Pointer allows distinguishing between "not set" and "zero time".
-	CompletedAt *time.Time `yaml:"completed_at,omitempty"`
+	CompletedAt *time.Time `yaml:"completed_at,omitempty" json:"completed_at,omitempty"`
 	// ExitCode is the exit code of the command (0=success).
-	ExitCode int `yaml:"exit_code"`
+	ExitCode int `yaml:"exit_code" json:"exit_code"`
 	// Duration is the execution duration in Go duration format (e.g., "2m15.123s").
-	Duration string `yaml:"duration"`
+	Duration string `yaml:"duration" json:"duration"`
+	// Usage lists token counts and estimated cost for each agent-backed phase the
+	// command ran. Empty when the agent did not report usage.
+	Usage []PhaseUsage `yaml:"usage,omitempty" json:"usage,omitempty"`
+}
+
+// PhaseUsage records agent token usage and estimated cost for one workflow phase.
+type PhaseUsage struct {
+	// Spec is the spec the phase ran against (may be empty for specify on older runs).
+	Spec string `yaml:"spec,omitempty" json:"spec,omitempty"`
+	// Phase is the workflow stage name (e.g., "plan", "implement").
+	Phase string `yaml:"phase" json:"phase"`
+	// Agent is the CLI agent that ran the phase (e.g., "claude"). Empty on older entries.
+	Agent string `yaml:"agent,omitempty" json:"agent,omitempty"`
+	// InputTokens is the number of uncached input tokens.
+	InputTokens int `yaml:"input_tokens" json:"input_tokens"`
+	// OutputTokens is the number of generated output tokens.
+	OutputTokens int `yaml:"output_tokens" json:"output_tokens"`
+	// CacheCreationTokens is the number of tokens written to the prompt cache.
+	CacheCreationTokens int `yaml:"cache_creation_tokens,omitempty" json:"cache_creation_tokens,omitempty"`
+	// CacheReadTokens is the number of tokens read from the prompt cache.
+	CacheReadTokens int `yaml:"cache_read_tokens,omitempty" json:"cache_read_tokens,omitempty"`
+	// CostUSD is the agent's cost estimate in US dollars.
+	CostUSD float64 `yaml:"cost_usd" json:"cost_usd"`
+	// Duration is how long the agent session ran (e.g., "3m12s"). Empty on older entries.
+	Duration string `yaml:"duration,omitempty" json:"duration,omitempty"`
+}
+
+// TotalTokens returns the sum of input, output, and cache tokens.
+func (u PhaseUsage) TotalTokens() int {
+	return u.InputTokens + u.OutputTokens + u.CacheCreationTokens + u.CacheReadTokens
 }
 
 // HistoryFile represents the YAML file containing all history entries.
@@ -76,9 +107,9 @@ func DefaultHistoryPath() (string, error) {
 // Returns empty history if file doesn't exist.
 // Handles corrupted files by backing them up and creating a fresh history.
 func LoadHistory(stateDir string) (*HistoryFile, error) {
-	historyPath := filepath.Join(stateDir, HistoryFileName)
+	store := statestore.For(stateDir)
 
-	data, err := os.ReadFile(historyPath)
+	data, err := store.Read(HistoryFileName)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return &HistoryFile{Entries: []HistoryEntry{}}, nil
@@ -88,7 +119,7 @@ func LoadHistory(stateDir string) (*HistoryFile, error) {
 
 	var history HistoryFile
 	if err := yaml.Unmarshal(data, &history); err != nil {
-		if backupErr := backupCorruptedFile(historyPath); backupErr != nil {
+		if backupErr := backupCorruptedFile(store, HistoryFileName, data); backupErr != nil {
 			return nil, fmt.Errorf("backing up corrupted history file: %w", backupErr)
 		}
 		return &HistoryFile{Entries: []HistoryEntry{}}, nil
@@ -101,37 +132,32 @@ func LoadHistory(stateDir string) (*HistoryFile, error) {
 	return &history, nil
 }
 
-// backupCorruptedFile renames a corrupted file with a .backup suffix.
-func backupCorruptedFile(path string) error {
-	backupPath := path + BackupSuffix
-	if err := os.Rename(path, backupPath); err != nil {
-		return fmt.Errorf("renaming corrupted file to backup: %w", err)
+// backupCorruptedFile moves a corrupted document to its name with a .backup
+// suffix.
+func backupCorruptedFile(store statestore.Storage, name string, data []byte) error {
+	if err := store.Write(name+BackupSuffix, data); err != nil {
+		return fmt.Errorf("saving corrupted file as backup: %w", err)
 	}
-	return nil
+	return store.Delete(name)
+}
+
+// lockHistory locks history.yaml for a load-modify-save sequence, so
+// concurrent autospec processes don't drop each other's entries.
+func lockHistory(stateDir string) (func(), error) {
+	return statestore.For(stateDir).Lock(HistoryFileName)
 }
 
 // SaveHistory saves the history file to the given state directory using atomic writes.
-// Creates parent directories if needed.
+// Callers that loaded the history to
+// modify it should hold lockHistory across both steps.
 func SaveHistory(stateDir string, history *HistoryFile) error {
-	if err := os.MkdirAll(stateDir, 0755); err != nil {
-		return fmt.Errorf("creating state directory: %w", err)
-	}
-
 	data, err := yaml.Marshal(history)
 	if err != nil {
 		return fmt.Errorf("marshaling history: %w", err)
 	}
 
-	historyPath := filepath.Join(stateDir, HistoryFileName)
-	tmpPath := historyPath + ".tmp"
-
-	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
-		return fmt.Errorf("writing temp history file: %w", err)
-	}
-
-	if err := os.Rename(tmpPath, historyPath); err != nil {
-		os.Remove(tmpPath)
-		return fmt.Errorf("renaming temp history file: %w", err)
+	if err := statestore.For(stateDir).Write(HistoryFileName, data); err != nil {
+		return fmt.Errorf("writing history file: %w", err)
 	}
 
 	return nil
@@ -139,5 +165,11 @@ func SaveHistory(stateDir string, history *HistoryFile) error {
 
 // ClearHistory removes all entries from the history file.
 func ClearHistory(stateDir string) error {
+	unlock, err := lockHistory(stateDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	return SaveHistory(stateDir, &HistoryFile{Entries: []HistoryEntry{}})
 }
@@ -0,0 +1,87 @@
+package history
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"time"
+)
+
+// EntriesForSpec returns the entries recorded for the named spec. Entries
+// that store the spec as a path match on its last element.
+func EntriesForSpec(entries []HistoryEntry, specName string) []HistoryEntry {
+	var matched []HistoryEntry
+	for _, e := range entries {
+		if e.Spec != "" && (e.Spec == specName || filepath.Base(e.Spec) == specName) {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
+
+// MergeEntries adds the entries history.yaml doesn't have yet and returns
+// how many were added. See UnionEntries.
+func MergeEntries(stateDir string, entries []HistoryEntry) (int, error) {
+	if len(entries) == 0 {
+		return 0, nil
+	}
+	unlock, err := lockHistory(stateDir)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	history, err := LoadHistory(stateDir)
+	if err != nil {
+		return 0, fmt.Errorf("loading history: %w", err)
+	}
+	merged, added := UnionEntries(history.Entries, entries)
+	if reflect.DeepEqual(merged, history.Entries) {
+		return 0, nil
+	}
+	history.Entries = merged
+	if err := SaveHistory(stateDir, history); err != nil {
+		return 0, fmt.Errorf("saving history: %w", err)
+	}
+	return added, nil
+}
+
+// UnionEntries returns existing plus the entries it doesn't have, ordered by
+// timestamp, and how many were added. Entries are matched by ID, or by
+// timestamp, command and spec for older entries without one. An entry still
+// running in existing takes the finished version.
+func UnionEntries(existing, entries []HistoryEntry) ([]HistoryEntry, int) {
+	merged := slices.Clone(existing)
+	index := make(map[string]int, len(merged))
+	for i, e := range merged {
+		index[entryKey(e)] = i
+	}
+	added := 0
+	for _, e := range entries {
+		key := entryKey(e)
+		i, ok := index[key]
+		switch {
+		case !ok:
+			index[key] = len(merged)
+			merged = append(merged, e)
+			added++
+		case merged[i].Status == StatusRunning && e.Status != StatusRunning && e.Status != "":
+			// The command finished on the other machine
+			merged[i] = e
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Timestamp.Before(merged[j].Timestamp)
+	})
+	return merged, added
+}
+
+// entryKey identifies an entry across machines.
+func entryKey(e HistoryEntry) string {
+	if e.ID != "" {
+		return "id:" + e.ID
+	}
+	return fmt.Sprintf("%s|%s|%s", e.Timestamp.UTC().Format(time.RFC3339Nano), e.Command, e.Spec)
+}
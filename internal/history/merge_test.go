@@ -0,0 +1,56 @@
+// Package history tests merging history entries from another machine.
+// Related: internal/history/merge.go
+// Tags: history, merge, sync
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntriesForSpec(t *testing.T) {
+	t.Parallel()
+
+	entries := []HistoryEntry{
+		{ID: "a", Spec: "001-login"},
+		{ID: "b", Spec: "specs/001-login"},
+		{ID: "c", Spec: "002-search"},
+		{ID: "d"},
+	}
+	assert.Equal(t, []string{"a", "b"}, entryIDs(EntriesForSpec(entries, "001-login")))
+	assert.Empty(t, EntriesForSpec(entries, "003-billing"))
+}
+
+func TestMergeEntries(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	require.NoError(t, SaveHistory(stateDir, &HistoryFile{Entries: []HistoryEntry{
+		{ID: "one", Timestamp: day(1), Command: "plan", Status: StatusCompleted},
+		{ID: "three", Timestamp: day(3), Command: "implement", Status: StatusRunning},
+		{Timestamp: day(4), Command: "tasks", Spec: "001-login"},
+	}}))
+
+	added, err := MergeEntries(stateDir, []HistoryEntry{
+		{ID: "one", Timestamp: day(1), Command: "plan", Status: StatusCompleted},
+		{ID: "two", Timestamp: day(2), Command: "specify", Status: StatusCompleted},
+		{ID: "three", Timestamp: day(3), Command: "implement", Status: StatusFailed},
+		{Timestamp: day(4), Command: "tasks", Spec: "001-login"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+
+	history, err := LoadHistory(stateDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one", "two", "three", ""}, entryIDs(history.Entries))
+	assert.Equal(t, StatusFailed, history.Entries[2].Status, "running entry should take the finished status")
+
+	added, err = MergeEntries(stateDir, history.Entries)
+	require.NoError(t, err)
+	assert.Zero(t, added)
+}
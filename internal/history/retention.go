@@ -0,0 +1,220 @@
+package history
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ArchiveDirName is the directory under the state directory holding rotated history.
+const ArchiveDirName = "history-archive"
+
+// Retention controls which history entries stay in history.yaml.
+// Zero values mean unlimited.
+type Retention struct {
+	// MaxEntries is the maximum number of entries to retain.
+	MaxEntries int
+	// MaxAge removes entries that started longer ago than this.
+	MaxAge time.Duration
+	// Archive moves removed entries into compressed monthly archive files
+	// instead of discarding them.
+	Archive bool
+}
+
+// PruneResult reports what a prune removed.
+type PruneResult struct {
+	// Removed is the number of entries taken out of history.yaml.
+	Removed int `json:"removed"`
+	// Remaining is the number of entries left in history.yaml.
+	Remaining int `json:"remaining"`
+	// Archives lists the archive files the removed entries were appended to.
+	Archives []string `json:"archives,omitempty"`
+}
+
+// splitRetained separates entries into those to keep and those past the
+// retention limits. Age is applied first, then the oldest entries beyond
+// MaxEntries are removed. Running entries are never removed, so an in-flight
+// command can still find its entry when it completes; they may leave the
+// file above MaxEntries until they finish.
+func splitRetained(entries []HistoryEntry, r Retention, now time.Time) (kept, removed []HistoryEntry) {
+	for _, entry := range entries {
+		if r.MaxAge > 0 && entry.Status != StatusRunning && now.Sub(entry.Timestamp) > r.MaxAge {
+			removed = append(removed, entry)
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	if r.MaxEntries > 0 && len(kept) > r.MaxEntries {
+		excess := len(kept) - r.MaxEntries
+		retained := make([]HistoryEntry, 0, len(kept))
+		for i, entry := range kept {
+			if i < excess && entry.Status != StatusRunning {
+				removed = append(removed, entry)
+				continue
+			}
+			retained = append(retained, entry)
+		}
+		kept = retained
+	}
+	if kept == nil {
+		kept = []HistoryEntry{}
+	}
+	return kept, removed
+}
+
+// Prune applies the retention limits to history.yaml, archiving removed
+// entries when r.Archive is set.
+func Prune(stateDir string, r Retention, now time.Time) (PruneResult, error) {
+	unlock, err := lockHistory(stateDir)
+	if err != nil {
+		return PruneResult{}, err
+	}
+	defer unlock()
+
+	history, err := LoadHistory(stateDir)
+	if err != nil {
+		return PruneResult{}, fmt.Errorf("loading history: %w", err)
+	}
+
+	kept, removed := splitRetained(history.Entries, r, now)
+	result := PruneResult{Removed: len(removed), Remaining: len(kept)}
+	if len(removed) == 0 {
+		return result, nil
+	}
+
+	if r.Archive {
+		archives, err := archiveEntries(stateDir, removed)
+		if err != nil {
+			return PruneResult{}, err
+		}
+		result.Archives = archives
+	}
+
+	history.Entries = kept
+	if err := SaveHistory(stateDir, history); err != nil {
+		return PruneResult{}, fmt.Errorf("saving history: %w", err)
+	}
+	return result, nil
+}
+
+// ArchiveDir returns the archive directory inside the given state directory.
+func ArchiveDir(stateDir string) string {
+	return filepath.Join(stateDir, ArchiveDirName)
+}
+
+// archiveEntries appends entries to history-archive/history-YYYY-MM.jsonl.gz,
+// grouped by the month they started in. Each call adds a gzip member, which
+// standard gzip readers treat as one continuous stream.
+func archiveEntries(stateDir string, entries []HistoryEntry) ([]string, error) {
+	byMonth := make(map[string][]HistoryEntry)
+	for _, entry := range entries {
+		month := entry.Timestamp.Format("2006-01")
+		byMonth[month] = append(byMonth[month], entry)
+	}
+
+	if err := os.MkdirAll(ArchiveDir(stateDir), 0755); err != nil {
+		return nil, fmt.Errorf("creating archive directory: %w", err)
+	}
+
+	months := make([]string, 0, len(byMonth))
+	for month := range byMonth {
+		months = append(months, month)
+	}
+	sort.Strings(months)
+
+	var paths []string
+	for _, month := range months {
+		path := filepath.Join(ArchiveDir(stateDir), "history-"+month+".jsonl.gz")
+		if err := appendArchive(path, byMonth[month]); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// appendArchive writes entries as a new gzip member at the end of path.
+func appendArchive(path string, entries []HistoryEntry) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening archive: %w", err)
+	}
+	defer f.Close()
+
+	zw := gzip.NewWriter(f)
+	enc := json.NewEncoder(zw)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return fmt.Errorf("writing archive %s: %w", filepath.Base(path), err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("writing archive %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// LoadArchive reads every entry from a history archive file.
+func LoadArchive(path string) ([]HistoryEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening archive: %w", err)
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading archive %s: %w", filepath.Base(path), err)
+	}
+	defer zr.Close()
+
+	var entries []HistoryEntry
+	dec := json.NewDecoder(bufio.NewReader(zr))
+	for {
+		var entry HistoryEntry
+		if err := dec.Decode(&entry); err != nil {
+			if errors.Is(err, io.EOF) {
+				return entries, nil
+			}
+			return nil, fmt.Errorf("reading archive %s: %w", filepath.Base(path), err)
+		}
+		entries = append(entries, entry)
+	}
+}
+
+// ParseAge parses a retention age or lookback such as "90d", "2w" or "12h".
+// Days and weeks are accepted in addition to Go durations.
+func ParseAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	d, err := parseAge(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q (e.g. 90d, 2w, 12h)", value)
+	}
+	return d, nil
+}
+
+func parseAge(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, errors.New("empty age")
+	}
+	day := 24 * time.Hour
+	switch value[len(value)-1] {
+	case 'd':
+		n, err := strconv.Atoi(value[:len(value)-1])
+		return time.Duration(n) * day, err
+	case 'w':
+		n, err := strconv.Atoi(value[:len(value)-1])
+		return time.Duration(n) * 7 * day, err
+	default:
+		return time.ParseDuration(value)
+	}
+}
@@ -0,0 +1,230 @@
+// Package history tests retention limits, pruning and archive rotation.
+// Related: internal/history/retention.go, internal/history/writer.go
+// Tags: history, retention, prune, archive
+
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var retentionNow = time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+func retentionEntries() []HistoryEntry {
+	return []HistoryEntry{
+		{ID: "jan", Timestamp: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), Command: "plan", Status: StatusCompleted},
+		{ID: "jan_running", Timestamp: time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC), Command: "implement", Status: StatusRunning},
+		{ID: "feb", Timestamp: time.Date(2026, 2, 5, 0, 0, 0, 0, time.UTC), Command: "tasks", Status: StatusFailed},
+		{ID: "mar", Timestamp: time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC), Command: "specify", Status: StatusCompleted},
+	}
+}
+
+func entryIDs(entries []HistoryEntry) []string {
+	var ids []string
+	for _, e := range entries {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func TestSplitRetained(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		retention   Retention
+		wantKept    []string
+		wantRemoved []string
+	}{
+		"no limits": {
+			wantKept: []string{"jan", "jan_running", "feb", "mar"},
+		},
+		"max entries keeps running entries": {
+			retention:   Retention{MaxEntries: 2},
+			wantKept:    []string{"jan_running", "feb", "mar"},
+			wantRemoved: []string{"jan"},
+		},
+		"max age keeps running entries": {
+			retention:   Retention{MaxAge: 30 * 24 * time.Hour},
+			wantKept:    []string{"jan_running", "mar"},
+			wantRemoved: []string{"jan", "feb"},
+		},
+		"age then count": {
+			retention:   Retention{MaxAge: 60 * 24 * time.Hour, MaxEntries: 1},
+			wantKept:    []string{"jan_running", "mar"},
+			wantRemoved: []string{"jan", "feb"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			kept, removed := splitRetained(retentionEntries(), tt.retention, retentionNow)
+			assert.Equal(t, tt.wantKept, entryIDs(kept))
+			assert.Equal(t, tt.wantRemoved, entryIDs(removed))
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		retention    Retention
+		wantResult   PruneResult
+		wantKept     []string
+		wantArchives map[string][]string
+	}{
+		"nothing to prune": {
+			retention:  Retention{MaxEntries: 10, Archive: true},
+			wantResult: PruneResult{Remaining: 4},
+			wantKept:   []string{"jan", "jan_running", "feb", "mar"},
+		},
+		"archives by month": {
+			retention:  Retention{MaxEntries: 1, Archive: true},
+			wantResult: PruneResult{Removed: 2, Remaining: 2},
+			wantKept:   []string{"jan_running", "mar"},
+			wantArchives: map[string][]string{
+				"history-2026-01.jsonl.gz": {"jan"},
+				"history-2026-02.jsonl.gz": {"feb"},
+			},
+		},
+		"discard without archive": {
+			retention:  Retention{MaxAge: 7 * 24 * time.Hour},
+			wantResult: PruneResult{Removed: 2, Remaining: 2},
+			wantKept:   []string{"jan_running", "mar"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			stateDir := t.TempDir()
+			require.NoError(t, SaveHistory(stateDir, &HistoryFile{Entries: retentionEntries()}))
+
+			result, err := Prune(stateDir, tt.retention, retentionNow)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantResult.Removed, result.Removed)
+			assert.Equal(t, tt.wantResult.Remaining, result.Remaining)
+			assert.Len(t, result.Archives, len(tt.wantArchives))
+
+			history, err := LoadHistory(stateDir)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKept, entryIDs(history.Entries))
+
+			for file, wantIDs := range tt.wantArchives {
+				archived, err := LoadArchive(filepath.Join(ArchiveDir(stateDir), file))
+				require.NoError(t, err)
+				assert.Equal(t, wantIDs, entryIDs(archived))
+			}
+			if len(tt.wantArchives) == 0 {
+				assert.NoDirExists(t, ArchiveDir(stateDir))
+			}
+		})
+	}
+}
+
+func TestArchiveEntries_AppendsToExistingArchive(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	first := []HistoryEntry{{ID: "a", Timestamp: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Command: "plan"}}
+	second := []HistoryEntry{{ID: "b", Timestamp: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), Command: "tasks"}}
+
+	_, err := archiveEntries(stateDir, first)
+	require.NoError(t, err)
+	paths, err := archiveEntries(stateDir, second)
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+
+	archived, err := LoadArchive(paths[0])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, entryIDs(archived))
+}
+
+func TestLoadArchive_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	_, err := LoadArchive(filepath.Join(dir, "missing.jsonl.gz"))
+	assert.Error(t, err)
+
+	notGzip := filepath.Join(dir, "plain.jsonl.gz")
+	require.NoError(t, os.WriteFile(notGzip, []byte("{}"), 0644))
+	_, err = LoadArchive(notGzip)
+	assert.Error(t, err)
+}
+
+func TestHistoryWriter_ArchivesPrunedEntries(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	writer := NewWriter(stateDir, Retention{MaxEntries: 2, Archive: true})
+	for _, cmd := range []string{"specify", "plan", "tasks"} {
+		writer.LogEntry(HistoryEntry{ID: cmd, Timestamp: time.Now(), Command: cmd})
+	}
+
+	history, err := LoadHistory(stateDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"plan", "tasks"}, entryIDs(history.Entries))
+
+	archive := filepath.Join(ArchiveDir(stateDir), "history-"+time.Now().Format("2006-01")+".jsonl.gz")
+	archived, err := LoadArchive(archive)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"specify"}, entryIDs(archived))
+}
+
+func TestHistoryWriter_KeepsRunningEntryPastMaxEntries(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	writer := NewWriter(stateDir, Retention{MaxEntries: 2})
+	id, err := writer.WriteStart("implement", "001-auth")
+	require.NoError(t, err)
+	for _, cmd := range []string{"plan", "tasks"} {
+		writer.LogEntry(HistoryEntry{ID: cmd, Timestamp: time.Now(), Command: cmd, Status: StatusCompleted})
+	}
+
+	require.NoError(t, writer.UpdateComplete(id, 0, StatusCompleted, time.Minute))
+	history, err := LoadHistory(stateDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{id, "plan", "tasks"}, entryIDs(history.Entries))
+}
+
+func TestParseAge(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		"days":     {value: "90d", want: 90 * 24 * time.Hour},
+		"weeks":    {value: "2w", want: 14 * 24 * time.Hour},
+		"duration": {value: "36h", want: 36 * time.Hour},
+		"spaces":   {value: " 1d ", want: 24 * time.Hour},
+		"empty":    {value: "", wantErr: true},
+		"bad days": {value: "xd", wantErr: true},
+		"negative": {value: "-1d", wantErr: true},
+		"unknown":  {value: "3y", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseAge(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/sqlite"
+)
+
+// Shared history backend types for history_backend.type.
+const (
+	// BackendSQLite writes entries to an SQLite file, typically committed
+	// to the repository, through the sqlite3 command.
+	BackendSQLite = "sqlite"
+	// BackendHTTP POSTs entries as JSON to an endpoint.
+	BackendHTTP = "http"
+)
+
+// BackendTypes lists the valid shared backend types.
+var BackendTypes = []string{BackendSQLite, BackendHTTP}
+
+// DefaultSQLitePath is the SQLite backend's file, relative to the
+// repository root.
+const DefaultSQLitePath = ".autospec/history.db"
+
+// sharedTimeout bounds each write to a shared backend so a slow endpoint
+// or a locked database cannot stall a command.
+const sharedTimeout = 5 * time.Second
+
+// SharedEntry is a history entry as written to a shared backend, with who
+// ran the command and where.
+type SharedEntry struct {
+	HistoryEntry
+	// User is the git user email of whoever ran the command, else their
+	// login name.
+	User string `json:"user"`
+	// Host is the machine the command ran on.
+	Host string `json:"host,omitempty"`
+}
+
+// SharedBackend receives history entries in addition to the local
+// history.yaml, so a team can see who ran which phases for which specs.
+// An entry is recorded when its command starts and again when it ends;
+// backends replace earlier versions by ID.
+type SharedBackend interface {
+	Record(entry SharedEntry) error
+}
+
+// SQLiteBackend keeps entries in an SQLite database file.
+type SQLiteBackend struct {
+	Path string
+}
+
+// sqliteSchema creates the history table. Usage is stored as JSON.
+const sqliteSchema = `CREATE TABLE IF NOT EXISTS history (
+  id TEXT PRIMARY KEY,
+  timestamp TEXT NOT NULL,
+  command TEXT NOT NULL,
+  spec TEXT NOT NULL DEFAULT '',
+  status TEXT NOT NULL DEFAULT '',
+  exit_code INTEGER NOT NULL DEFAULT 0,
+  duration TEXT NOT NULL DEFAULT '',
+  completed_at TEXT,
+  user TEXT NOT NULL DEFAULT '',
+  host TEXT NOT NULL DEFAULT '',
+  usage TEXT
+);
+`
+
+// Record inserts the entry, replacing an earlier version with the same ID.
+func (b *SQLiteBackend) Record(entry SharedEntry) error {
+	if err := os.MkdirAll(filepath.Dir(b.Path), 0755); err != nil {
+		return fmt.Errorf("creating history database directory: %w", err)
+	}
+	usage := "NULL"
+	if len(entry.Usage) > 0 {
+		data, err := json.Marshal(entry.Usage)
+		if err != nil {
+			return fmt.Errorf("encoding usage: %w", err)
+		}
+		usage = sqlite.Quote(string(data))
+	}
+	completedAt := "NULL"
+	if entry.CompletedAt != nil {
+		completedAt = sqlite.Quote(entry.CompletedAt.UTC().Format(time.RFC3339Nano))
+	}
+	stmt := fmt.Sprintf("INSERT OR REPLACE INTO history "+
+		"(id, timestamp, command, spec, status, exit_code, duration, completed_at, user, host, usage) "+
+		"VALUES (%s, %s, %s, %s, %s, %d, %s, %s, %s, %s, %s);\n",
+		sqlite.Quote(entry.ID), sqlite.Quote(entry.Timestamp.UTC().Format(time.RFC3339Nano)),
+		sqlite.Quote(entry.Command), sqlite.Quote(entry.Spec), sqlite.Quote(entry.Status), entry.ExitCode,
+		sqlite.Quote(entry.Duration), completedAt, sqlite.Quote(entry.User), sqlite.Quote(entry.Host), usage)
+	_, err := sqlite.Exec(b.Path, sqliteSchema+stmt)
+	return err
+}
+
+// List returns the recorded entries, oldest first.
+func (b *SQLiteBackend) List() ([]SharedEntry, error) {
+	if _, err := os.Stat(b.Path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	out, err := sqlite.Exec(b.Path, ".mode json\n"+sqliteSchema+
+		"SELECT id, timestamp, command, spec, status, exit_code, duration, completed_at, user, host, usage "+
+		"FROM history ORDER BY timestamp;\n")
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, nil
+	}
+
+	var rows []struct {
+		ID          string  `json:"id"`
+		Timestamp   string  `json:"timestamp"`
+		Command     string  `json:"command"`
+		Spec        string  `json:"spec"`
+		Status      string  `json:"status"`
+		ExitCode    int     `json:"exit_code"`
+		Duration    string  `json:"duration"`
+		CompletedAt *string `json:"completed_at"`
+		User        string  `json:"user"`
+		Host        string  `json:"host"`
+		Usage       *string `json:"usage"`
+	}
+	if err := json.Unmarshal(out, &rows); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", b.Path, err)
+	}
+	entries := make([]SharedEntry, 0, len(rows))
+	for _, r := range rows {
+		e := SharedEntry{
+			HistoryEntry: HistoryEntry{
+				ID: r.ID, Command: r.Command, Spec: r.Spec, Status: r.Status,
+				ExitCode: r.ExitCode, Duration: r.Duration,
+			},
+			User: r.User,
+			Host: r.Host,
+		}
+		e.Timestamp, _ = time.Parse(time.RFC3339Nano, r.Timestamp)
+		e.CreatedAt = e.Timestamp
+		if r.CompletedAt != nil {
+			if t, err := time.Parse(time.RFC3339Nano, *r.CompletedAt); err == nil {
+				e.CompletedAt = &t
+			}
+		}
+		if r.Usage != nil {
+			_ = json.Unmarshal([]byte(*r.Usage), &e.Usage)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// HTTPBackend POSTs each entry as JSON to URL.
+type HTTPBackend struct {
+	URL string
+	// Headers are extra HTTP headers, e.g. an Authorization token.
+	// Values are expanded with environment variables.
+	Headers map[string]string
+
+	client *http.Client
+}
+
+// NewHTTPBackend returns a backend posting to url.
+func NewHTTPBackend(url string, headers map[string]string) *HTTPBackend {
+	return &HTTPBackend{URL: url, Headers: headers, client: &http.Client{Timeout: sharedTimeout}}
+}
+
+// Record POSTs the entry.
+func (b *HTTPBackend) Record(entry SharedEntry) error {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encoding entry: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, b.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "autospec")
+	for k, v := range b.Headers {
+		req.Header.Set(k, os.ExpandEnv(v))
+	}
+
+	client := b.client
+	if client == nil {
+		client = &http.Client{Timeout: sharedTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// CurrentUser identifies whoever runs autospec: their git user email,
+// else name, else their login name.
+func CurrentUser() string {
+	for _, key := range []string{"user.email", "user.name"} {
+		if out, err := exec.Command("git", "config", key).Output(); err == nil {
+			if name := strings.TrimSpace(string(out)); name != "" {
+				return name
+			}
+		}
+	}
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
@@ -0,0 +1,122 @@
+// Package history tests writing history entries to shared team backends.
+// Related: internal/history/shared.go, internal/history/writer.go
+// Tags: history, shared, sqlite, http, team
+
+package history
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingBackend keeps the entries it is given.
+type recordingBackend struct {
+	mu      sync.Mutex
+	entries []SharedEntry
+}
+
+func (b *recordingBackend) Record(entry SharedEntry) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries = append(b.entries, entry)
+	return nil
+}
+
+func TestHistoryWriter_Shared(t *testing.T) {
+	t.Parallel()
+
+	backend := &recordingBackend{}
+	w := NewWriter(t.TempDir(), Retention{MaxEntries: 500})
+	w.Shared = backend
+
+	id, err := w.WriteStart("implement", "001-login")
+	require.NoError(t, err)
+	require.NoError(t, w.RecordUsage(PhaseUsage{Phase: "implement", InputTokens: 10}))
+	require.NoError(t, w.UpdateComplete(id, 0, StatusCompleted, time.Minute))
+	w.LogCommand("status", "", 0, time.Second)
+
+	require.Len(t, backend.entries, 3)
+	assert.Equal(t, StatusRunning, backend.entries[0].Status)
+	assert.Equal(t, id, backend.entries[1].ID)
+	assert.Equal(t, StatusCompleted, backend.entries[1].Status)
+	assert.Len(t, backend.entries[1].Usage, 1)
+	assert.NotEmpty(t, backend.entries[2].ID, "entries without an ID get one when shared")
+	for _, e := range backend.entries {
+		assert.NotEmpty(t, e.User)
+	}
+}
+
+func TestSQLiteBackend(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		t.Skip("sqlite3 not installed")
+	}
+
+	b := &SQLiteBackend{Path: filepath.Join(t.TempDir(), ".autospec", "history.db")}
+	entries, err := b.List()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	done := start.Add(time.Minute)
+	running := SharedEntry{
+		HistoryEntry: HistoryEntry{ID: "brave_fox", Timestamp: start, Command: "plan", Spec: "001-o'brien", Status: StatusRunning},
+		User:         "sam@example.com",
+		Host:         "laptop",
+	}
+	require.NoError(t, b.Record(running))
+	finished := running
+	finished.Status, finished.Duration, finished.CompletedAt = StatusCompleted, "1m0s", &done
+	finished.Usage = []PhaseUsage{{Phase: "plan", InputTokens: 100, CostUSD: 0.5}}
+	require.NoError(t, b.Record(finished))
+	require.NoError(t, b.Record(SharedEntry{
+		HistoryEntry: HistoryEntry{ID: "calm_owl", Timestamp: start.Add(-time.Hour), Command: "specify"},
+		User:         "kim@example.com",
+	}))
+
+	entries, err = b.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "calm_owl", entries[0].ID)
+	got := entries[1]
+	assert.Equal(t, "001-o'brien", got.Spec)
+	assert.Equal(t, StatusCompleted, got.Status, "second record should replace the first")
+	assert.Equal(t, "sam@example.com", got.User)
+	require.NotNil(t, got.CompletedAt)
+	assert.True(t, done.Equal(*got.CompletedAt))
+	assert.Equal(t, finished.Usage, got.Usage)
+}
+
+func TestHTTPBackend(t *testing.T) {
+	t.Parallel()
+
+	var got SharedEntry
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		if got.Command == "fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	b := NewHTTPBackend(server.URL, map[string]string{"Authorization": "Bearer token"})
+	entry := SharedEntry{HistoryEntry: HistoryEntry{ID: "brave_fox", Command: "plan", Spec: "001-login"}, User: "sam"}
+	require.NoError(t, b.Record(entry))
+	assert.Equal(t, "Bearer token", auth)
+	assert.Equal(t, "brave_fox", got.ID)
+	assert.Equal(t, "sam", got.User)
+
+	entry.Command = "fail"
+	assert.ErrorContains(t, b.Record(entry), "500")
+}
@@ -10,15 +10,25 @@ import (
 type Writer struct {
 	// StateDir is the directory containing the history file.
 	StateDir string
-	// MaxEntries is the maximum number of entries to retain.
-	MaxEntries int
+	// Retention limits the entries kept in the history file. Entries past the
+	// limits are pruned (and optionally archived) whenever a new entry is logged.
+	Retention
+	// Shared, if set, also receives every entry logged, started or
+	// completed. Failures are reported as warnings.
+	Shared SharedBackend
+
+	// entryID is the ID of the entry created by the last WriteStart call.
+	// RecordUsage attaches phase usage to this entry.
+	entryID string
+	// user and host identify the entries shared, resolved on first use.
+	user, host string
 }
 
-// NewWriter creates a new history writer.
-func NewWriter(stateDir string, maxEntries int) *Writer {
+// NewWriter creates a new history writer that applies the given retention limits.
+func NewWriter(stateDir string, retention Retention) *Writer {
 	return &Writer{
-		StateDir:   stateDir,
-		MaxEntries: maxEntries,
+		StateDir:  stateDir,
+		Retention: retention,
 	}
 }
 
@@ -29,24 +39,54 @@ func (w *Writer) LogEntry(entry HistoryEntry) {
 	if err := w.logEntryInternal(entry); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to log history: %v\n", err)
 	}
+	w.share(entry)
+}
+
+// share records the entry in the shared backend, if any. Entries logged
+// without an ID get one, since backends key entries by ID.
+func (w *Writer) share(entry HistoryEntry) {
+	if w.Shared == nil {
+		return
+	}
+	if entry.ID == "" {
+		id, err := GenerateID()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to share history: %v\n", err)
+			return
+		}
+		entry.ID = id
+	}
+	if w.user == "" {
+		w.user = CurrentUser()
+		w.host, _ = os.Hostname()
+	}
+	if err := w.Shared.Record(SharedEntry{HistoryEntry: entry, User: w.user, Host: w.host}); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to share history: %v\n", err)
+	}
 }
 
 // logEntryInternal handles the actual logging logic.
-// Pipeline: load → append → prune (FIFO) → save.
-// Pruning removes oldest entries when over MaxEntries limit.
+// Pipeline: load → append → prune (age, then FIFO) → archive → save.
+// Pruning removes entries older than MaxAge and the oldest entries over MaxEntries.
 func (w *Writer) logEntryInternal(entry HistoryEntry) error {
+	unlock, err := lockHistory(w.StateDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	history, err := LoadHistory(w.StateDir)
 	if err != nil {
 		return fmt.Errorf("loading history: %w", err)
 	}
 
-	history.Entries = append(history.Entries, entry)
-
-	// Prune oldest entries if over limit
-	if w.MaxEntries > 0 && len(history.Entries) > w.MaxEntries {
-		excess := len(history.Entries) - w.MaxEntries
-		history.Entries = history.Entries[excess:]
+	kept, removed := splitRetained(append(history.Entries, entry), w.Retention, time.Now())
+	if w.Archive && len(removed) > 0 {
+		if _, err := archiveEntries(w.StateDir, removed); err != nil {
+			return fmt.Errorf("archiving history: %w", err)
+		}
 	}
+	history.Entries = kept
 
 	if err := SaveHistory(w.StateDir, history); err != nil {
 		return fmt.Errorf("saving history: %w", err)
@@ -91,9 +131,42 @@ func (w *Writer) WriteStart(command, spec string) (string, error) {
 		return "", fmt.Errorf("writing start entry: %w", err)
 	}
 
+	w.entryID = id
+	w.share(entry)
 	return id, nil
 }
 
+// RecordUsage appends phase token usage to the entry started by this writer.
+// Called by the workflow executor after each agent run that reported usage,
+// so a multi-stage command (e.g., "all") accumulates one record per phase.
+// Returns an error if WriteStart has not been called or the entry was pruned.
+func (w *Writer) RecordUsage(usage PhaseUsage) error {
+	if w.entryID == "" {
+		return fmt.Errorf("no running history entry to record usage on")
+	}
+	unlock, err := lockHistory(w.StateDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	history, err := LoadHistory(w.StateDir)
+	if err != nil {
+		return fmt.Errorf("loading history for usage: %w", err)
+	}
+
+	for i := range history.Entries {
+		if history.Entries[i].ID == w.entryID {
+			history.Entries[i].Usage = append(history.Entries[i].Usage, usage)
+			if err := SaveHistory(w.StateDir, history); err != nil {
+				return fmt.Errorf("saving usage: %w", err)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("entry not found with ID: %s", w.entryID)
+}
+
 // UpdateComplete updates a running history entry with final status when a command completes.
 // Parameters:
 //   - id: the unique entry ID returned by WriteStart
@@ -103,20 +176,41 @@ func (w *Writer) WriteStart(command, spec string) (string, error) {
 //
 // Returns an error if the entry with the given ID is not found.
 func (w *Writer) UpdateComplete(id string, exitCode int, status string, duration time.Duration) error {
+	entry, err := w.updateComplete(id, exitCode, status, duration)
+	if err != nil {
+		return err
+	}
+	// Shared after unlocking so a slow backend doesn't hold up other processes
+	w.share(entry)
+	return nil
+}
+
+func (w *Writer) updateComplete(id string, exitCode int, status string, duration time.Duration) (HistoryEntry, error) {
+	unlock, err := lockHistory(w.StateDir)
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+	defer unlock()
+
 	history, err := LoadHistory(w.StateDir)
 	if err != nil {
-		return fmt.Errorf("loading history for update: %w", err)
+		return HistoryEntry{}, fmt.Errorf("loading history for update: %w", err)
 	}
 
 	if err := w.updateEntry(history, id, exitCode, status, duration); err != nil {
-		return err
+		return HistoryEntry{}, err
 	}
 
 	if err := SaveHistory(w.StateDir, history); err != nil {
-		return fmt.Errorf("saving updated history: %w", err)
+		return HistoryEntry{}, fmt.Errorf("saving updated history: %w", err)
 	}
 
-	return nil
+	for _, entry := range history.Entries {
+		if entry.ID == id {
+			return entry, nil
+		}
+	}
+	return HistoryEntry{}, nil
 }
 
 // updateEntry finds and updates the entry with the given ID in place.
@@ -47,7 +47,7 @@ func TestHistoryWriter_LogEntry(t *testing.T) {
 			stateDir := t.TempDir()
 			tc.setupStore(t, stateDir)
 
-			writer := NewWriter(stateDir, tc.maxEntries)
+			writer := NewWriter(stateDir, Retention{MaxEntries: tc.maxEntries})
 			entry := HistoryEntry{
 				Timestamp: time.Now(),
 				Command:   "test",
@@ -114,7 +114,7 @@ func TestHistoryWriter_Pruning(t *testing.T) {
 			require.NoError(t, SaveHistory(stateDir, history))
 
 			// Log new entry
-			writer := NewWriter(stateDir, tc.maxEntries)
+			writer := NewWriter(stateDir, Retention{MaxEntries: tc.maxEntries})
 			writer.LogEntry(HistoryEntry{
 				Timestamp: time.Now().Add(time.Hour),
 				Command:   "new-cmd",
@@ -142,7 +142,7 @@ func TestHistoryWriter_LogCommand(t *testing.T) {
 	t.Parallel()
 
 	stateDir := t.TempDir()
-	writer := NewWriter(stateDir, 500)
+	writer := NewWriter(stateDir, Retention{MaxEntries: 500})
 
 	writer.LogCommand("specify", "test-feature", 0, 2*time.Minute+30*time.Second)
 
@@ -163,7 +163,7 @@ func TestHistoryWriter_ConcurrentAccess(t *testing.T) {
 	t.Parallel()
 
 	stateDir := t.TempDir()
-	writer := NewWriter(stateDir, 100)
+	writer := NewWriter(stateDir, Retention{MaxEntries: 100})
 
 	// Run multiple goroutines writing concurrently
 	var wg sync.WaitGroup
@@ -202,7 +202,7 @@ func TestHistoryWriter_NonFatalErrors(t *testing.T) {
 	t.Parallel()
 
 	// Use an invalid path that can't be created
-	writer := NewWriter("/nonexistent/deeply/nested/path/that/cannot/exist", 500)
+	writer := NewWriter("/nonexistent/deeply/nested/path/that/cannot/exist", Retention{MaxEntries: 500})
 
 	// This should not panic, just print a warning
 	writer.LogEntry(HistoryEntry{
@@ -218,7 +218,7 @@ func TestHistoryWriter_NonFatalErrors(t *testing.T) {
 func TestNewWriter(t *testing.T) {
 	t.Parallel()
 
-	writer := NewWriter("/test/path", 100)
+	writer := NewWriter("/test/path", Retention{MaxEntries: 100})
 
 	assert.Equal(t, "/test/path", writer.StateDir)
 	assert.Equal(t, 100, writer.MaxEntries)
@@ -230,7 +230,7 @@ func TestHistoryWriter_ZeroMaxEntries(t *testing.T) {
 	stateDir := t.TempDir()
 
 	// Zero max entries means unlimited
-	writer := NewWriter(stateDir, 0)
+	writer := NewWriter(stateDir, Retention{MaxEntries: 0})
 
 	// Log 5 entries
 	for i := 0; i < 5; i++ {
@@ -282,7 +282,7 @@ func TestHistoryWriter_WriteStart(t *testing.T) {
 			t.Parallel()
 
 			stateDir := t.TempDir()
-			writer := NewWriter(stateDir, 500)
+			writer := NewWriter(stateDir, Retention{MaxEntries: 500})
 
 			id, err := writer.WriteStart(tc.command, tc.spec)
 			require.NoError(t, err)
@@ -312,7 +312,7 @@ func TestHistoryWriter_WriteStart_UniqueIDs(t *testing.T) {
 	t.Parallel()
 
 	stateDir := t.TempDir()
-	writer := NewWriter(stateDir, 500)
+	writer := NewWriter(stateDir, Retention{MaxEntries: 500})
 
 	ids := make(map[string]bool)
 	const numIDs = 10
@@ -333,14 +333,14 @@ func TestHistoryWriter_WriteStart_RunningEntryPersistsAfterCrash(t *testing.T) {
 	// Simulate a crash scenario: WriteStart is called, then process "crashes"
 	// (we just don't call UpdateComplete). The entry should remain with 'running' status.
 	stateDir := t.TempDir()
-	writer := NewWriter(stateDir, 500)
+	writer := NewWriter(stateDir, Retention{MaxEntries: 500})
 
 	id, err := writer.WriteStart("implement", "crash-test-feature")
 	require.NoError(t, err)
 
 	// Simulate crash by not calling UpdateComplete and creating a new writer
 	// (as if the process restarted)
-	newWriter := NewWriter(stateDir, 500)
+	newWriter := NewWriter(stateDir, Retention{MaxEntries: 500})
 	_ = newWriter // Just to show we have a "new" instance
 
 	// Verify the running entry is still there
@@ -360,7 +360,7 @@ func TestHistoryWriter_WriteStart_Error(t *testing.T) {
 	t.Parallel()
 
 	// Use an invalid path that can't be created
-	writer := NewWriter("/nonexistent/deeply/nested/path/that/cannot/exist", 500)
+	writer := NewWriter("/nonexistent/deeply/nested/path/that/cannot/exist", Retention{MaxEntries: 500})
 
 	id, err := writer.WriteStart("test", "spec")
 	assert.Error(t, err)
@@ -418,7 +418,7 @@ func TestHistoryWriter_UpdateComplete(t *testing.T) {
 			t.Parallel()
 
 			stateDir := t.TempDir()
-			writer := NewWriter(stateDir, 500)
+			writer := NewWriter(stateDir, Retention{MaxEntries: 500})
 
 			// First create a running entry
 			id, err := writer.WriteStart("implement", "test-feature")
@@ -449,7 +449,7 @@ func TestHistoryWriter_UpdateComplete_EntryNotFound(t *testing.T) {
 	t.Parallel()
 
 	stateDir := t.TempDir()
-	writer := NewWriter(stateDir, 500)
+	writer := NewWriter(stateDir, Retention{MaxEntries: 500})
 
 	// Try to update a non-existent entry
 	err := writer.UpdateComplete("nonexistent_id_20251217_120000", 0, StatusCompleted, time.Minute)
@@ -461,7 +461,7 @@ func TestHistoryWriter_UpdateComplete_MultipleEntries(t *testing.T) {
 	t.Parallel()
 
 	stateDir := t.TempDir()
-	writer := NewWriter(stateDir, 500)
+	writer := NewWriter(stateDir, Retention{MaxEntries: 500})
 
 	// Create multiple running entries
 	id1, err := writer.WriteStart("specify", "feature-1")
@@ -503,7 +503,7 @@ func TestHistoryWriter_UpdateComplete_EmptyHistory(t *testing.T) {
 	t.Parallel()
 
 	stateDir := t.TempDir()
-	writer := NewWriter(stateDir, 500)
+	writer := NewWriter(stateDir, Retention{MaxEntries: 500})
 
 	// Try to update an entry in an empty history (no entries have been written)
 	err := writer.UpdateComplete("some_id_20251217_120000", 0, StatusCompleted, time.Minute)
@@ -515,7 +515,7 @@ func TestHistoryWriter_TwoPhaseWorkflow(t *testing.T) {
 	t.Parallel()
 
 	stateDir := t.TempDir()
-	writer := NewWriter(stateDir, 500)
+	writer := NewWriter(stateDir, Retention{MaxEntries: 500})
 
 	// Phase 1: Start command
 	startTime := time.Now()
@@ -549,3 +549,52 @@ func TestHistoryWriter_TwoPhaseWorkflow(t *testing.T) {
 	assert.False(t, entry.CreatedAt.IsZero())
 	assert.True(t, entry.CompletedAt.After(entry.CreatedAt) || entry.CompletedAt.Equal(entry.CreatedAt))
 }
+
+func TestHistoryWriter_RecordUsage(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		start   bool
+		usages  []PhaseUsage
+		wantErr bool
+	}{
+		"appends usage per phase to the started entry": {
+			start: true,
+			usages: []PhaseUsage{
+				{Spec: "001-auth", Phase: "plan", InputTokens: 100, OutputTokens: 20, CostUSD: 0.1},
+				{Spec: "001-auth", Phase: "tasks", InputTokens: 50, OutputTokens: 10, CostUSD: 0.05},
+			},
+		},
+		"errors without a started entry": {
+			usages:  []PhaseUsage{{Phase: "plan"}},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			stateDir := t.TempDir()
+			writer := NewWriter(stateDir, Retention{MaxEntries: 100})
+			if tt.start {
+				_, err := writer.WriteStart("all", "")
+				require.NoError(t, err)
+			}
+
+			for _, u := range tt.usages {
+				err := writer.RecordUsage(u)
+				if tt.wantErr {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+			}
+
+			history, err := LoadHistory(stateDir)
+			require.NoError(t, err)
+			require.Len(t, history.Entries, 1)
+			assert.Equal(t, tt.usages, history.Entries[0].Usage)
+			assert.Equal(t, 120, history.Entries[0].Usage[0].TotalTokens())
+		})
+	}
+}
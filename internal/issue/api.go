@@ -0,0 +1,105 @@
+package issue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// maxComments is the number of comments requested from the API (one page).
+const maxComments = 100
+
+// apiIssue is the JSON shape of a GitHub REST API issue.
+type apiIssue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	Labels  []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+// apiComment is the JSON shape of a GitHub REST API issue comment.
+type apiComment struct {
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Body string `json:"body"`
+}
+
+// fetchWithAPI retrieves the issue and its comments from the REST API.
+// Bare issue numbers are resolved against the origin remote.
+func (f *Fetcher) fetchWithAPI(ctx context.Context, ref Ref) (*Issue, error) {
+	ref, err := f.resolveRepo(ref)
+	if err != nil {
+		return nil, err
+	}
+	base := fmt.Sprintf("%s/repos/%s/%s/issues/%d", f.apiURL, ref.Owner, ref.Repo, ref.Number)
+
+	var raw apiIssue
+	if err := f.getJSON(ctx, base, &raw); err != nil {
+		return nil, fmt.Errorf("fetching issue %s: %w", ref, err)
+	}
+	var comments []apiComment
+	if err := f.getJSON(ctx, fmt.Sprintf("%s/comments?per_page=%d", base, maxComments), &comments); err != nil {
+		return nil, fmt.Errorf("fetching comments for issue %s: %w", ref, err)
+	}
+
+	issue := &Issue{Number: raw.Number, Title: raw.Title, Body: raw.Body, URL: raw.HTMLURL}
+	for _, l := range raw.Labels {
+		issue.Labels = append(issue.Labels, l.Name)
+	}
+	for _, c := range comments {
+		issue.Comments = append(issue.Comments, Comment{Author: c.User.Login, Body: c.Body})
+	}
+	return issue, nil
+}
+
+// resolveRepo fills in owner and repo from the origin remote for bare numbers.
+func (f *Fetcher) resolveRepo(ref Ref) (Ref, error) {
+	if ref.HasRepo() {
+		return ref, nil
+	}
+	url, err := f.originURL()
+	if err != nil {
+		return ref, fmt.Errorf("resolving repository for issue %s (use owner/repo#N or an issue URL): %w", ref, err)
+	}
+	owner, repo, ok := ParseRemote(url)
+	if !ok {
+		return ref, fmt.Errorf("origin remote %q is not a GitHub repository (use owner/repo#N or an issue URL)", url)
+	}
+	ref.Owner, ref.Repo = owner, repo
+	return ref, nil
+}
+
+// getJSON performs an authenticated GET request and decodes the JSON response.
+func (f *Fetcher) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "autospec")
+	if f.token != "" {
+		req.Header.Set("Authorization", "Bearer "+f.token)
+	}
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("not found (private repositories need GITHUB_TOKEN or gh auth login)")
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package issue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// NewIssue is an issue to open.
+type NewIssue struct {
+	Title  string
+	Body   string
+	Labels []string
+}
+
+// Create opens an issue in the repository of the current directory and
+// returns its URL. With gh it uses gh's authentication; the API fallback
+// needs GITHUB_TOKEN (or GH_TOKEN).
+func (f *Fetcher) Create(ctx context.Context, n NewIssue) (string, error) {
+	if f.runGH != nil {
+		return f.createWithGH(ctx, n)
+	}
+	return f.createWithAPI(ctx, n)
+}
+
+// createWithGH runs `gh issue create`, which prints the new issue's URL.
+func (f *Fetcher) createWithGH(ctx context.Context, n NewIssue) (string, error) {
+	args := []string{"issue", "create", "--title", n.Title, "--body", n.Body}
+	for _, label := range n.Labels {
+		args = append(args, "--label", label)
+	}
+	out, err := f.runGH(ctx, args...)
+	if err != nil {
+		return "", fmt.Errorf("opening issue with gh: %w", err)
+	}
+	// gh may print progress lines before the URL
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1]), nil
+}
+
+// createWithAPI opens the issue through the REST API in the repository of
+// the origin remote.
+func (f *Fetcher) createWithAPI(ctx context.Context, n NewIssue) (string, error) {
+	if f.token == "" {
+		return "", fmt.Errorf("opening issues needs the gh CLI or GITHUB_TOKEN")
+	}
+	url, err := f.originURL()
+	if err != nil {
+		return "", err
+	}
+	owner, repo, ok := ParseRemote(url)
+	if !ok {
+		return "", fmt.Errorf("origin remote %q is not a GitHub repository", url)
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{"title": n.Title, "body": n.Body, "labels": n.Labels})
+	if err != nil {
+		return "", fmt.Errorf("encoding issue: %w", err)
+	}
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/issues", f.apiURL, owner, repo)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "autospec")
+	req.Header.Set("Authorization", "Bearer "+f.token)
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("opening issue in %s/%s: %w", owner, repo, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("opening issue in %s/%s: unexpected status code: %d", owner, repo, resp.StatusCode)
+	}
+
+	var created apiIssue
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return "", fmt.Errorf("decoding response: %w", err)
+	}
+	return created.HTMLURL, nil
+}
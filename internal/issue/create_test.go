@@ -0,0 +1,122 @@
+package issue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreate_WithGH(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		issue    NewIssue
+		ghOut    string
+		ghErr    error
+		wantArgs string
+		wantURL  string
+		wantErr  string
+	}{
+		"title and body": {
+			issue:    NewIssue{Title: "Blocked", Body: "Waiting"},
+			ghOut:    "https://github.com/acme/widgets/issues/9\n",
+			wantArgs: "issue create --title Blocked --body Waiting",
+			wantURL:  "https://github.com/acme/widgets/issues/9",
+		},
+		"labels and progress output": {
+			issue:    NewIssue{Title: "Blocked", Body: "Waiting", Labels: []string{"blocked"}},
+			ghOut:    "Creating issue in acme/widgets\n\nhttps://github.com/acme/widgets/issues/9\n",
+			wantArgs: "issue create --title Blocked --body Waiting --label blocked",
+			wantURL:  "https://github.com/acme/widgets/issues/9",
+		},
+		"gh failure": {
+			issue:   NewIssue{Title: "Blocked"},
+			ghErr:   errors.New("exit status 1: not logged in"),
+			wantErr: "opening issue with gh: exit status 1: not logged in",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var gotArgs []string
+			f := &Fetcher{runGH: func(_ context.Context, args ...string) ([]byte, error) {
+				gotArgs = args
+				return []byte(tt.ghOut), tt.ghErr
+			}}
+
+			url, err := f.Create(context.Background(), tt.issue)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantArgs, strings.Join(gotArgs, " "))
+			assert.Equal(t, tt.wantURL, url)
+		})
+	}
+}
+
+func TestCreate_WithAPI(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		token   string
+		origin  string
+		wantErr string
+	}{
+		"opens issue in origin repository": {
+			token:  "secret",
+			origin: "git@github.com:acme/widgets.git",
+		},
+		"token required": {
+			origin:  "git@github.com:acme/widgets.git",
+			wantErr: "opening issues needs the gh CLI or GITHUB_TOKEN",
+		},
+		"origin not on GitHub": {
+			token:   "secret",
+			origin:  "https://gitlab.com/acme/widgets.git",
+			wantErr: "is not a GitHub repository",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var gotAuth string
+			var gotBody map[string]interface{}
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /repos/acme/widgets/issues", func(w http.ResponseWriter, r *http.Request) {
+				gotAuth = r.Header.Get("Authorization")
+				_ = json.NewDecoder(r.Body).Decode(&gotBody)
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"number": 9, "html_url": "https://github.com/acme/widgets/issues/9"}`))
+			})
+			server := httptest.NewServer(mux)
+			t.Cleanup(server.Close)
+
+			f := NewFetcher(0)
+			f.SetAPIURL(server.URL)
+			f.token = tt.token
+			f.originURL = func() (string, error) { return tt.origin, nil }
+
+			url, err := f.Create(context.Background(), NewIssue{Title: "Blocked", Body: "Waiting", Labels: []string{"blocked"}})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "https://github.com/acme/widgets/issues/9", url)
+			assert.Equal(t, "Bearer secret", gotAuth)
+			assert.Equal(t, map[string]interface{}{"title": "Blocked", "body": "Waiting", "labels": []interface{}{"blocked"}}, gotBody)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package issue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// GitHubAPIURL is the base URL of the GitHub REST API.
+	GitHubAPIURL = "https://api.github.com"
+	// DefaultHTTPTimeout is the default timeout for API requests.
+	DefaultHTTPTimeout = 10 * time.Second
+)
+
+// ghFields are the issue fields requested from `gh issue view --json`.
+const ghFields = "number,title,body,url,labels,comments"
+
+// remotePattern extracts owner and repo from GitHub HTTPS and SSH remote URLs.
+var remotePattern = regexp.MustCompile(`github\.com[:/]([\w.-]+)/([\w.-]+?)(?:\.git)?/?$`)
+
+// Fetcher retrieves and opens issues with the gh CLI, or the REST API when gh
+// is missing.
+type Fetcher struct {
+	httpClient *http.Client
+	apiURL     string
+	token      string
+	runGH      func(ctx context.Context, args ...string) ([]byte, error) // nil when gh is not installed
+	originURL  func() (string, error)
+}
+
+// NewFetcher creates a fetcher. The API fallback authenticates with
+// GITHUB_TOKEN (or GH_TOKEN) when set, which private repositories require.
+func NewFetcher(timeout time.Duration) *Fetcher {
+	if timeout == 0 {
+		timeout = DefaultHTTPTimeout
+	}
+	f := &Fetcher{
+		httpClient: &http.Client{Timeout: timeout},
+		apiURL:     GitHubAPIURL,
+		token:      firstEnv("GITHUB_TOKEN", "GH_TOKEN"),
+		originURL:  gitOriginURL,
+	}
+	if _, err := exec.LookPath("gh"); err == nil {
+		f.runGH = runGH
+	}
+	return f
+}
+
+// SetAPIURL sets the API base URL and disables gh. This is intended for testing purposes.
+func (f *Fetcher) SetAPIURL(url string) {
+	f.apiURL = url
+	f.runGH = nil
+}
+
+// Fetch retrieves the issue with its labels and comments.
+func (f *Fetcher) Fetch(ctx context.Context, ref Ref) (*Issue, error) {
+	if f.runGH != nil {
+		return f.fetchWithGH(ctx, ref)
+	}
+	return f.fetchWithAPI(ctx, ref)
+}
+
+// ghIssue is the JSON shape printed by `gh issue view --json`.
+type ghIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	URL    string `json:"url"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Comments []struct {
+		Author struct {
+			Login string `json:"login"`
+		} `json:"author"`
+		Body string `json:"body"`
+	} `json:"comments"`
+}
+
+// fetchWithGH runs `gh issue view`, which resolves bare numbers against the
+// current repository and uses gh's own authentication.
+func (f *Fetcher) fetchWithGH(ctx context.Context, ref Ref) (*Issue, error) {
+	args := []string{"issue", "view", strconv.Itoa(ref.Number), "--json", ghFields}
+	if ref.HasRepo() {
+		args = append(args, "--repo", ref.Owner+"/"+ref.Repo)
+	}
+	out, err := f.runGH(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("fetching issue %s with gh: %w", ref, err)
+	}
+
+	var raw ghIssue
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, fmt.Errorf("parsing gh output for issue %s: %w", ref, err)
+	}
+	issue := &Issue{Number: raw.Number, Title: raw.Title, Body: raw.Body, URL: raw.URL}
+	for _, l := range raw.Labels {
+		issue.Labels = append(issue.Labels, l.Name)
+	}
+	for _, c := range raw.Comments {
+		issue.Comments = append(issue.Comments, Comment{Author: c.Author.Login, Body: c.Body})
+	}
+	return issue, nil
+}
+
+// runGH executes the gh CLI and returns its stdout, including stderr in errors.
+func runGH(ctx context.Context, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "gh", args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// ParseRemote extracts the owner and repository from a GitHub remote URL.
+func ParseRemote(url string) (owner, repo string, ok bool) {
+	m := remotePattern.FindStringSubmatch(strings.TrimSpace(url))
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
+// gitOriginURL returns the URL of the origin remote of the current repository.
+func gitOriginURL() (string, error) {
+	out, err := exec.Command("git", "remote", "get-url", "origin").Output()
+	if err != nil {
+		return "", fmt.Errorf("reading git origin remote: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// firstEnv returns the first non-empty environment variable among names.
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
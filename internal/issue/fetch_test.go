@@ -0,0 +1,184 @@
+package issue
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRemote(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		url       string
+		wantOwner string
+		wantRepo  string
+		wantOK    bool
+	}{
+		"https":         {url: "https://github.com/acme/widgets.git", wantOwner: "acme", wantRepo: "widgets", wantOK: true},
+		"https no .git": {url: "https://github.com/acme/widgets", wantOwner: "acme", wantRepo: "widgets", wantOK: true},
+		"ssh scp-style": {url: "git@github.com:acme/widgets.git", wantOwner: "acme", wantRepo: "widgets", wantOK: true},
+		"ssh url":       {url: "ssh://git@github.com/acme/my.repo.git", wantOwner: "acme", wantRepo: "my.repo", wantOK: true},
+		"other host":    {url: "https://gitlab.com/acme/widgets.git"},
+		"empty":         {url: ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			owner, repo, ok := ParseRemote(tt.url)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantOwner, owner)
+			assert.Equal(t, tt.wantRepo, repo)
+		})
+	}
+}
+
+const ghOutput = `{
+  "number": 7,
+  "title": "Add dark mode",
+  "body": "Users want a dark theme.",
+  "url": "https://github.com/acme/widgets/issues/7",
+  "labels": [{"name": "enhancement"}],
+  "comments": [{"author": {"login": "sam"}, "body": "Follow the OS setting."}]
+}`
+
+func TestFetch_WithGH(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		ref      Ref
+		ghErr    error
+		wantArgs string
+		wantErr  string
+	}{
+		"current repository": {
+			ref:      Ref{Number: 7},
+			wantArgs: "issue view 7 --json " + ghFields,
+		},
+		"explicit repository": {
+			ref:      Ref{Owner: "acme", Repo: "widgets", Number: 7},
+			wantArgs: "issue view 7 --json " + ghFields + " --repo acme/widgets",
+		},
+		"gh failure": {
+			ref:     Ref{Number: 7},
+			ghErr:   errors.New("exit status 1: could not resolve to an Issue"),
+			wantErr: "fetching issue #7 with gh",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var gotArgs []string
+			f := &Fetcher{runGH: func(_ context.Context, args ...string) ([]byte, error) {
+				gotArgs = args
+				return []byte(ghOutput), tt.ghErr
+			}}
+
+			got, err := f.Fetch(context.Background(), tt.ref)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantArgs, strings.Join(gotArgs, " "))
+			assert.Equal(t, &Issue{
+				Number:   7,
+				Title:    "Add dark mode",
+				Body:     "Users want a dark theme.",
+				URL:      "https://github.com/acme/widgets/issues/7",
+				Labels:   []string{"enhancement"},
+				Comments: []Comment{{Author: "sam", Body: "Follow the OS setting."}},
+			}, got)
+		})
+	}
+}
+
+// newAPIServer serves issue 7 of acme/widgets and records the auth header.
+func newAPIServer(t *testing.T, gotAuth *string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/widgets/issues/7", func(w http.ResponseWriter, r *http.Request) {
+		*gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"number": 7, "title": "Add dark mode", "body": "Body",
+			"html_url": "https://github.com/acme/widgets/issues/7", "labels": [{"name": "ui"}]}`))
+	})
+	mux.HandleFunc("/repos/acme/widgets/issues/7/comments", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"user": {"login": "sam"}, "body": "+1"}]`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetch_WithAPI(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		ref       Ref
+		token     string
+		origin    string
+		originErr error
+		wantAuth  string
+		wantErr   string
+	}{
+		"explicit repository": {
+			ref: Ref{Owner: "acme", Repo: "widgets", Number: 7},
+		},
+		"repository from origin remote": {
+			ref:    Ref{Number: 7},
+			origin: "git@github.com:acme/widgets.git",
+		},
+		"token sent when set": {
+			ref:      Ref{Owner: "acme", Repo: "widgets", Number: 7},
+			token:    "secret",
+			wantAuth: "Bearer secret",
+		},
+		"origin not on GitHub": {
+			ref:     Ref{Number: 7},
+			origin:  "https://gitlab.com/acme/widgets.git",
+			wantErr: "is not a GitHub repository",
+		},
+		"no origin remote": {
+			ref:       Ref{Number: 7},
+			originErr: errors.New("no such remote"),
+			wantErr:   "resolving repository for issue #7",
+		},
+		"issue not found": {
+			ref:     Ref{Owner: "acme", Repo: "widgets", Number: 8},
+			wantErr: "not found",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var gotAuth string
+			server := newAPIServer(t, &gotAuth)
+			f := NewFetcher(0)
+			f.SetAPIURL(server.URL)
+			f.token = tt.token
+			f.originURL = func() (string, error) { return tt.origin, tt.originErr }
+
+			got, err := f.Fetch(context.Background(), tt.ref)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "https://github.com/acme/widgets/issues/7", got.URL)
+			assert.Equal(t, []string{"ui"}, got.Labels)
+			assert.Equal(t, []Comment{{Author: "sam", Body: "+1"}}, got.Comments)
+			assert.Equal(t, tt.wantAuth, gotAuth)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Package issue imports GitHub issues as feature descriptions for specify,
+// and opens issues for blocked tasks that need a human.
+// Related: internal/cli/stages/specify.go, internal/workflow/blocked_escalation.go
+// Tags: issue, github, gh, specify, traceability, escalation
+//
+// Issues are fetched and opened with the gh CLI when it is installed (reusing
+// its authentication), falling back to the GitHub REST API with GITHUB_TOKEN.
+package issue
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Issue is the subset of a GitHub issue used to write a specification.
+type Issue struct {
+	Number   int
+	Title    string
+	Body     string
+	URL      string
+	Labels   []string
+	Comments []Comment
+}
+
+// Comment is a single issue comment.
+type Comment struct {
+	Author string
+	Body   string
+}
+
+// Ref identifies an issue. Owner and Repo are empty for a bare number,
+// which refers to the repository of the current directory.
+type Ref struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+// refPatterns match the accepted --from-issue forms, capturing owner, repo and number.
+var refPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^#?()()(\d+)$`),
+	regexp.MustCompile(`^([\w.-]+)/([\w.-]+)#(\d+)$`),
+	regexp.MustCompile(`^(?:https?://)?github\.com/([\w.-]+)/([\w.-]+)/issues/(\d+)(?:[/?#].*)?$`),
+}
+
+// ParseRef parses an issue number ("42", "#42"), "owner/repo#42", or an
+// issue URL ("https://github.com/owner/repo/issues/42").
+func ParseRef(s string) (Ref, error) {
+	s = strings.TrimSpace(s)
+	for _, pattern := range refPatterns {
+		m := pattern.FindStringSubmatch(s)
+		if m == nil {
+			continue
+		}
+		number, err := strconv.Atoi(m[3])
+		if err != nil || number <= 0 {
+			break
+		}
+		return Ref{Owner: m[1], Repo: m[2], Number: number}, nil
+	}
+	return Ref{}, fmt.Errorf("invalid issue reference %q: use a number, owner/repo#number, or an issue URL", s)
+}
+
+// HasRepo reports whether the reference names its repository explicitly.
+func (r Ref) HasRepo() bool {
+	return r.Owner != "" && r.Repo != ""
+}
+
+// String formats the reference as "owner/repo#N", or "#N" without a repository.
+func (r Ref) String() string {
+	if r.HasRepo() {
+		return fmt.Sprintf("%s/%s#%d", r.Owner, r.Repo, r.Number)
+	}
+	return fmt.Sprintf("#%d", r.Number)
+}
+
+// FeatureDescription renders the issue as the feature description passed to
+// the specify stage: title, labels, body, then comments as discussion.
+func (i *Issue) FeatureDescription() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\nSource: GitHub issue #%d (%s)\n", i.Title, i.Number, i.URL)
+	if len(i.Labels) > 0 {
+		fmt.Fprintf(&b, "Labels: %s\n", strings.Join(i.Labels, ", "))
+	}
+	if body := strings.TrimSpace(i.Body); body != "" {
+		fmt.Fprintf(&b, "\n%s\n", body)
+	}
+	if len(i.Comments) > 0 {
+		b.WriteString("\nDiscussion:\n")
+		for _, c := range i.Comments {
+			fmt.Fprintf(&b, "\n@%s:\n%s\n", c.Author, strings.TrimSpace(c.Body))
+		}
+	}
+	return b.String()
+}
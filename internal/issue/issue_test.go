@@ -0,0 +1,87 @@
+package issue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRef(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input   string
+		want    Ref
+		wantErr bool
+	}{
+		"bare number":      {input: "42", want: Ref{Number: 42}},
+		"hash number":      {input: "#42", want: Ref{Number: 42}},
+		"owner/repo#N":     {input: "acme/widgets#7", want: Ref{Owner: "acme", Repo: "widgets", Number: 7}},
+		"issue URL":        {input: "https://github.com/acme/widgets/issues/7", want: Ref{Owner: "acme", Repo: "widgets", Number: 7}},
+		"URL with anchor":  {input: "https://github.com/acme/my.repo/issues/7#issuecomment-1", want: Ref{Owner: "acme", Repo: "my.repo", Number: 7}},
+		"URL no scheme":    {input: "github.com/acme/widgets/issues/7", want: Ref{Owner: "acme", Repo: "widgets", Number: 7}},
+		"whitespace":       {input: "  12 ", want: Ref{Number: 12}},
+		"zero":             {input: "0", wantErr: true},
+		"pull request URL": {input: "https://github.com/acme/widgets/pull/7", wantErr: true},
+		"text":             {input: "add login", wantErr: true},
+		"empty":            {input: "", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseRef(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRefString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "#42", Ref{Number: 42}.String())
+	assert.Equal(t, "acme/widgets#42", Ref{Owner: "acme", Repo: "widgets", Number: 42}.String())
+}
+
+func TestFeatureDescription(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		issue Issue
+		want  string
+	}{
+		"full issue": {
+			issue: Issue{
+				Number:   7,
+				Title:    "Add dark mode",
+				Body:     "Users want a dark theme.\n",
+				URL:      "https://github.com/acme/widgets/issues/7",
+				Labels:   []string{"enhancement", "ui"},
+				Comments: []Comment{{Author: "sam", Body: "Follow the OS setting please."}},
+			},
+			want: "Add dark mode\n\n" +
+				"Source: GitHub issue #7 (https://github.com/acme/widgets/issues/7)\n" +
+				"Labels: enhancement, ui\n\n" +
+				"Users want a dark theme.\n\n" +
+				"Discussion:\n\n" +
+				"@sam:\nFollow the OS setting please.\n",
+		},
+		"title only": {
+			issue: Issue{Number: 8, Title: "Fix typo", URL: "https://github.com/acme/widgets/issues/8"},
+			want:  "Fix typo\n\nSource: GitHub issue #8 (https://github.com/acme/widgets/issues/8)\n",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.issue.FeatureDescription())
+		})
+	}
+}
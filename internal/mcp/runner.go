@@ -0,0 +1,58 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// maxOutputBytes caps how much subprocess output is returned to the client.
+// Stage runs can print megabytes of agent output; the tail holds the summary.
+const maxOutputBytes = 16 * 1024
+
+// CommandRunner runs autospec subcommands on behalf of MCP tools.
+// Workflow stages and task mutations run out-of-process so their terminal
+// output never interleaves with the JSON-RPC stream on stdout.
+type CommandRunner interface {
+	Run(ctx context.Context, args ...string) (string, error)
+}
+
+// ExecRunner runs autospec subcommands by re-executing the autospec binary.
+type ExecRunner struct {
+	// Executable is the path to the autospec binary.
+	Executable string
+	// WorkDir is the working directory for subcommands (empty = current directory).
+	WorkDir string
+	// GlobalArgs are prepended to every invocation (e.g., ["--config", "path"]).
+	GlobalArgs []string
+}
+
+// Run executes autospec with the given arguments and returns its combined
+// output, truncated to the last maxOutputBytes bytes.
+func (r *ExecRunner) Run(ctx context.Context, args ...string) (string, error) {
+	fullArgs := append(append([]string{}, r.GlobalArgs...), args...)
+	cmd := exec.CommandContext(ctx, r.Executable, fullArgs...)
+	cmd.Dir = r.WorkDir
+	// No TTY is attached, so confirmation prompts must be skipped
+	cmd.Env = append(os.Environ(), "AUTOSPEC_YES=1", "NO_COLOR=1")
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	output := tailOutput(out.String(), maxOutputBytes)
+	if err != nil {
+		return output, fmt.Errorf("autospec %v: %w\n%s", args, err, output)
+	}
+	return output, nil
+}
+
+// tailOutput returns the last max bytes of s, marking truncation.
+func tailOutput(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return "...(output truncated)...\n" + s[len(s)-max:]
+}
@@ -0,0 +1,189 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// maxMessageSize bounds a single incoming JSON-RPC line (10 MB).
+const maxMessageSize = 10 * 1024 * 1024
+
+// ToolHandler executes a tool with raw JSON arguments and returns text output.
+// A returned error is reported to the client as a tool error (isError: true).
+type ToolHandler func(ctx context.Context, args json.RawMessage) (string, error)
+
+// Tool is an MCP tool exposed by the server.
+type Tool struct {
+	Name        string
+	Description string
+	InputSchema Schema
+	Handler     ToolHandler
+}
+
+// Server is an MCP server that serves registered tools over a line-delimited
+// JSON-RPC stream. Requests are handled sequentially in arrival order.
+type Server struct {
+	name    string
+	version string
+
+	mu    sync.Mutex
+	tools []Tool
+}
+
+// NewServer creates an MCP server that reports the given name and version
+// in the initialize handshake.
+func NewServer(name, version string) *Server {
+	return &Server{name: name, version: version}
+}
+
+// AddTool registers a tool. Tools are listed in registration order.
+// Registering a tool with an existing name replaces it.
+func (s *Server) AddTool(tool Tool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, existing := range s.tools {
+		if existing.Name == tool.Name {
+			s.tools[i] = tool
+			return
+		}
+	}
+	s.tools = append(s.tools, tool)
+}
+
+// Serve reads JSON-RPC messages from r and writes responses to w until r is
+// exhausted or ctx is cancelled. Returns nil on clean EOF.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+	enc := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("serving mcp: %w", err)
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		resp := s.handleMessage(ctx, line)
+		if resp == nil {
+			continue
+		}
+		if err := enc.Encode(resp); err != nil {
+			return fmt.Errorf("writing mcp response: %w", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading mcp request: %w", err)
+	}
+	return nil
+}
+
+// handleMessage decodes and dispatches a single message.
+// Returns nil for notifications, which never receive a response.
+func (s *Server) handleMessage(ctx context.Context, line []byte) *response {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return errorResponse(json.RawMessage("null"), codeParseError, "parse error: "+err.Error())
+	}
+	if req.isNotification() {
+		return nil
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, codeInvalidRequest, "invalid request")
+	}
+
+	result, rpcErr := s.dispatch(ctx, &req)
+	if rpcErr != nil {
+		return &response{JSONRPC: "2.0", ID: req.ID, Error: rpcErr}
+	}
+	return &response{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+// dispatch routes a request to its method handler.
+func (s *Server) dispatch(ctx context.Context, req *request) (interface{}, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		return s.handleInitialize(req.Params), nil
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return s.handleListTools(), nil
+	case "tools/call":
+		return s.handleCallTool(ctx, req.Params)
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+}
+
+// handleInitialize answers the initialize handshake. The client's protocol
+// version is echoed when provided so older clients keep working.
+func (s *Server) handleInitialize(params json.RawMessage) initializeResult {
+	version := ProtocolVersion
+	var p initializeParams
+	if err := json.Unmarshal(params, &p); err == nil && p.ProtocolVersion != "" {
+		version = p.ProtocolVersion
+	}
+	return initializeResult{
+		ProtocolVersion: version,
+		ServerInfo:      serverInfo{Name: s.name, Version: s.version},
+	}
+}
+
+// handleListTools returns descriptors for all registered tools.
+func (s *Server) handleListTools() listToolsResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := listToolsResult{Tools: make([]toolDescriptor, 0, len(s.tools))}
+	for _, tool := range s.tools {
+		result.Tools = append(result.Tools, toolDescriptor{
+			Name:        tool.Name,
+			Description: tool.Description,
+			InputSchema: tool.InputSchema,
+		})
+	}
+	return result
+}
+
+// handleCallTool runs the named tool and wraps its output as text content.
+func (s *Server) handleCallTool(ctx context.Context, params json.RawMessage) (interface{}, *rpcError) {
+	var p callToolParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "invalid tools/call params: " + err.Error()}
+	}
+	tool, ok := s.lookupTool(p.Name)
+	if !ok {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "unknown tool: " + p.Name}
+	}
+	args := p.Arguments
+	if len(args) == 0 {
+		args = json.RawMessage("{}")
+	}
+
+	text, err := tool.Handler(ctx, args)
+	if err != nil {
+		return callToolResult{Content: []textContent{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+	}
+	return callToolResult{Content: []textContent{{Type: "text", Text: text}}}, nil
+}
+
+// lookupTool finds a registered tool by name.
+func (s *Server) lookupTool(name string) (Tool, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, tool := range s.tools {
+		if tool.Name == name {
+			return tool, true
+		}
+	}
+	return Tool{}, false
+}
+
+// errorResponse builds a JSON-RPC error response.
+func errorResponse(id json.RawMessage, code int, message string) *response {
+	return &response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: message}}
+}
@@ -0,0 +1,167 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// serveLines runs the server over the given request lines and returns decoded responses.
+func serveLines(t *testing.T, s *Server, lines ...string) []map[string]interface{} {
+	t.Helper()
+	var out strings.Builder
+	if err := s.Serve(context.Background(), strings.NewReader(strings.Join(lines, "\n")), &out); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+	var responses []map[string]interface{}
+	scanner := bufio.NewScanner(strings.NewReader(out.String()))
+	for scanner.Scan() {
+		var resp map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			t.Fatalf("decoding response %q: %v", scanner.Text(), err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func newTestServer() *Server {
+	s := NewServer("autospec", "1.2.3")
+	s.AddTool(Tool{
+		Name:        "echo",
+		Description: "Echo the message",
+		InputSchema: Schema{Type: "object", Properties: map[string]Property{"msg": {Type: "string"}}},
+		Handler: func(_ context.Context, raw json.RawMessage) (string, error) {
+			var args struct {
+				Msg string `json:"msg"`
+			}
+			if err := decodeArgs(raw, &args); err != nil {
+				return "", err
+			}
+			return args.Msg, nil
+		},
+	})
+	s.AddTool(Tool{
+		Name: "fail",
+		Handler: func(context.Context, json.RawMessage) (string, error) {
+			return "", errors.New("boom")
+		},
+	})
+	return s
+}
+
+func TestServer_Methods(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		line      string
+		wantError float64
+		check     func(t *testing.T, result map[string]interface{})
+	}{
+		"initialize echoes client protocol version": {
+			line: `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26"}}`,
+			check: func(t *testing.T, result map[string]interface{}) {
+				if result["protocolVersion"] != "2025-03-26" {
+					t.Errorf("protocolVersion = %v, want 2025-03-26", result["protocolVersion"])
+				}
+				info := result["serverInfo"].(map[string]interface{})
+				if info["name"] != "autospec" || info["version"] != "1.2.3" {
+					t.Errorf("serverInfo = %v", info)
+				}
+			},
+		},
+		"ping": {
+			line:  `{"jsonrpc":"2.0","id":2,"method":"ping"}`,
+			check: func(t *testing.T, result map[string]interface{}) {},
+		},
+		"tools/list": {
+			line: `{"jsonrpc":"2.0","id":3,"method":"tools/list"}`,
+			check: func(t *testing.T, result map[string]interface{}) {
+				tools := result["tools"].([]interface{})
+				if len(tools) != 2 || tools[0].(map[string]interface{})["name"] != "echo" {
+					t.Errorf("tools = %v, want [echo fail]", tools)
+				}
+			},
+		},
+		"tools/call success": {
+			line: `{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"echo","arguments":{"msg":"hi"}}}`,
+			check: func(t *testing.T, result map[string]interface{}) {
+				content := result["content"].([]interface{})[0].(map[string]interface{})
+				if content["text"] != "hi" || result["isError"] != nil {
+					t.Errorf("result = %v, want text hi without isError", result)
+				}
+			},
+		},
+		"tools/call handler error": {
+			line: `{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"fail"}}`,
+			check: func(t *testing.T, result map[string]interface{}) {
+				if result["isError"] != true {
+					t.Errorf("isError = %v, want true", result["isError"])
+				}
+			},
+		},
+		"unknown tool":   {line: `{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"nope"}}`, wantError: codeInvalidParams},
+		"unknown method": {line: `{"jsonrpc":"2.0","id":7,"method":"resources/list"}`, wantError: codeMethodNotFound},
+		"parse error":    {line: `{not json`, wantError: codeParseError},
+		"invalid request": {
+			line:      `{"jsonrpc":"1.0","id":8,"method":"ping"}`,
+			wantError: codeInvalidRequest,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			responses := serveLines(t, newTestServer(), tt.line)
+			if len(responses) != 1 {
+				t.Fatalf("got %d responses, want 1", len(responses))
+			}
+			resp := responses[0]
+			if tt.wantError != 0 {
+				errObj, ok := resp["error"].(map[string]interface{})
+				if !ok || errObj["code"] != tt.wantError {
+					t.Errorf("error = %v, want code %v", resp["error"], tt.wantError)
+				}
+				return
+			}
+			result, ok := resp["result"].(map[string]interface{})
+			if !ok {
+				t.Fatalf("missing result in %v", resp)
+			}
+			tt.check(t, result)
+		})
+	}
+}
+
+func TestServer_NotificationsGetNoResponse(t *testing.T) {
+	t.Parallel()
+
+	responses := serveLines(t, newTestServer(),
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		``,
+		`{"jsonrpc":"2.0","id":1,"method":"ping"}`,
+	)
+	if len(responses) != 1 {
+		t.Fatalf("got %d responses, want 1 (notifications and blank lines ignored)", len(responses))
+	}
+	if responses[0]["id"] != float64(1) {
+		t.Errorf("id = %v, want 1", responses[0]["id"])
+	}
+}
+
+func TestServer_AddToolReplaces(t *testing.T) {
+	t.Parallel()
+
+	s := newTestServer()
+	s.AddTool(Tool{Name: "echo", Handler: func(context.Context, json.RawMessage) (string, error) { return "replaced", nil }})
+	if got := len(s.handleListTools().Tools); got != 2 {
+		t.Errorf("tool count = %d, want 2", got)
+	}
+	tool, _ := s.lookupTool("echo")
+	if out, _ := tool.Handler(context.Background(), nil); out != "replaced" {
+		t.Errorf("handler output = %q, want replaced", out)
+	}
+}
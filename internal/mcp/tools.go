@@ -0,0 +1,481 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+// specDirPattern matches spec directory names like "001-user-auth" or
+// "API-001-user-auth".
+var specDirPattern = regexp.MustCompile(`^(?:[A-Z][A-Z0-9]*-)?\d{3}-.+$`)
+
+// artifactNames lists the artifacts exposed by read_artifact.
+var artifactNames = []string{"spec", "plan", "tasks"}
+
+// taskStatuses lists the valid task statuses accepted by update_task and list_tasks.
+var taskStatuses = []string{"Pending", "InProgress", "Completed", "Blocked"}
+
+// unblockStatuses lists the statuses a task can return to when unblocked.
+var unblockStatuses = []string{"Pending", "InProgress"}
+
+// taskIDPattern matches task IDs like T001, as accepted by update-task.
+var taskIDPattern = regexp.MustCompile(`^T\d+$`)
+
+// ToolOptions configures the autospec tool set.
+type ToolOptions struct {
+	// SpecsDir is the directory containing feature specs.
+	SpecsDir string
+	// Runner executes workflow stages and task mutations out-of-process.
+	Runner CommandRunner
+}
+
+// RegisterTools adds all autospec tools to the server.
+func RegisterTools(s *Server, opts ToolOptions) {
+	t := &toolSet{specsDir: opts.SpecsDir, runner: opts.Runner}
+	for _, tool := range t.queryTools() {
+		s.AddTool(tool)
+	}
+	for _, tool := range t.taskTools() {
+		s.AddTool(tool)
+	}
+	for _, tool := range t.stageTools() {
+		s.AddTool(tool)
+	}
+}
+
+// toolSet holds shared state for tool handlers.
+type toolSet struct {
+	specsDir string
+	runner   CommandRunner
+}
+
+// specProperty is the optional spec selector shared by read-only tools.
+var specProperty = Property{
+	Type:        "string",
+	Description: "Spec identifier (e.g. \"001\", \"user-auth\", or \"001-user-auth\"). Defaults to the current spec.",
+}
+
+// queryTools returns read-only tools for inspecting specs and tasks.
+func (t *toolSet) queryTools() []Tool {
+	return []Tool{
+		{
+			Name:        "list_specs",
+			Description: "List all feature specs with their artifacts and task progress.",
+			InputSchema: Schema{Type: "object", Properties: map[string]Property{}},
+			Handler:     t.listSpecs,
+		},
+		{
+			Name:        "status",
+			Description: "Show artifacts and task progress for a spec.",
+			InputSchema: Schema{Type: "object", Properties: map[string]Property{"spec": specProperty}},
+			Handler:     t.status,
+		},
+		{
+			Name:        "read_artifact",
+			Description: "Read a spec's spec.yaml, plan.yaml, or tasks.yaml.",
+			InputSchema: Schema{
+				Type: "object",
+				Properties: map[string]Property{
+					"spec":     specProperty,
+					"artifact": {Type: "string", Description: "Artifact to read", Enum: artifactNames},
+				},
+				Required: []string{"artifact"},
+			},
+			Handler: t.readArtifact,
+		},
+		{
+			Name:        "list_tasks",
+			Description: "List tasks from a spec's tasks.yaml, optionally filtered by status.",
+			InputSchema: Schema{
+				Type: "object",
+				Properties: map[string]Property{
+					"spec":   specProperty,
+					"status": {Type: "string", Description: "Only list tasks with this status", Enum: taskStatuses},
+				},
+			},
+			Handler: t.listTasks,
+		},
+	}
+}
+
+// taskTools returns tools that mutate task status in the current spec.
+func (t *toolSet) taskTools() []Tool {
+	taskIDProperty := Property{Type: "string", Description: "Task ID (e.g. T001)"}
+	return []Tool{
+		{
+			Name:        "update_task",
+			Description: "Set the status of a task in the current spec's tasks.yaml.",
+			InputSchema: Schema{
+				Type: "object",
+				Properties: map[string]Property{
+					"task_id": taskIDProperty,
+					"status":  {Type: "string", Description: "New task status", Enum: taskStatuses},
+				},
+				Required: []string{"task_id", "status"},
+			},
+			Handler: t.updateTask,
+		},
+		{
+			Name:        "block_task",
+			Description: "Mark a task in the current spec as Blocked with a reason.",
+			InputSchema: Schema{
+				Type: "object",
+				Properties: map[string]Property{
+					"task_id": taskIDProperty,
+					"reason":  {Type: "string", Description: "Why the task is blocked"},
+				},
+				Required: []string{"task_id", "reason"},
+			},
+			Handler: t.blockTask,
+		},
+		{
+			Name:        "unblock_task",
+			Description: "Unblock a task in the current spec and clear its blocked reason.",
+			InputSchema: Schema{
+				Type: "object",
+				Properties: map[string]Property{
+					"task_id": taskIDProperty,
+					"status":  {Type: "string", Description: "Status after unblocking (default: the status before blocking, else Pending)", Enum: unblockStatuses},
+				},
+				Required: []string{"task_id"},
+			},
+			Handler: t.unblockTask,
+		},
+	}
+}
+
+// stageTools returns tools that execute workflow stages.
+func (t *toolSet) stageTools() []Tool {
+	promptProperty := Property{Type: "string", Description: "Optional guidance for the stage"}
+	promptOnly := Schema{Type: "object", Properties: map[string]Property{"prompt": promptProperty}}
+	return []Tool{
+		{
+			Name:        "specify",
+			Description: "Create a new feature spec (spec.yaml) from a description.",
+			InputSchema: Schema{
+				Type:       "object",
+				Properties: map[string]Property{"feature_description": {Type: "string", Description: "Natural-language feature description"}},
+				Required:   []string{"feature_description"},
+			},
+			Handler: t.specify,
+		},
+		{
+			Name:        "plan",
+			Description: "Generate plan.yaml for the current spec.",
+			InputSchema: promptOnly,
+			Handler:     t.stageHandler("plan"),
+		},
+		{
+			Name:        "tasks",
+			Description: "Generate tasks.yaml for the current spec.",
+			InputSchema: promptOnly,
+			Handler:     t.stageHandler("tasks"),
+		},
+		{
+			Name:        "implement",
+			Description: "Implement tasks for the current spec, optionally limited to one phase.",
+			InputSchema: Schema{
+				Type: "object",
+				Properties: map[string]Property{
+					"prompt": promptProperty,
+					"phase":  {Type: "integer", Description: "Run only this phase number"},
+				},
+			},
+			Handler: t.implement,
+		},
+	}
+}
+
+// decodeArgs unmarshals tool arguments into v.
+func decodeArgs(raw json.RawMessage, v interface{}) error {
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	return nil
+}
+
+// resolveSpec returns metadata for an explicit spec identifier or the current spec.
+func (t *toolSet) resolveSpec(identifier string) (*spec.Metadata, error) {
+	if identifier != "" {
+		metadata, err := spec.GetSpecMetadata(t.specsDir, identifier)
+		if err != nil {
+			return nil, fmt.Errorf("resolving spec %q: %w", identifier, err)
+		}
+		return metadata, nil
+	}
+	metadata, err := spec.DetectCurrentSpec(t.specsDir)
+	if err != nil {
+		return nil, fmt.Errorf("detecting current spec: %w", err)
+	}
+	return metadata, nil
+}
+
+func (t *toolSet) listSpecs(_ context.Context, _ json.RawMessage) (string, error) {
+	entries, err := os.ReadDir(t.specsDir)
+	if err != nil {
+		return "", fmt.Errorf("reading specs directory %s: %w", t.specsDir, err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && specDirPattern.MatchString(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	if len(names) == 0 {
+		return "No specs found in " + t.specsDir, nil
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		dir := filepath.Join(t.specsDir, name)
+		fmt.Fprintf(&sb, "%s  artifacts: %s%s\n", name, strings.Join(existingArtifacts(dir), ", "), taskProgress(dir))
+	}
+	return sb.String(), nil
+}
+
+func (t *toolSet) status(_ context.Context, raw json.RawMessage) (string, error) {
+	var args struct {
+		Spec string `json:"spec"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return "", err
+	}
+	metadata, err := t.resolveSpec(args.Spec)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "spec: %s-%s\n", metadata.Number, metadata.Name)
+	fmt.Fprintf(&sb, "directory: %s\n", metadata.Directory)
+	artifacts := existingArtifacts(metadata.Directory)
+	if len(artifacts) == 0 {
+		artifacts = []string{"none"}
+	}
+	fmt.Fprintf(&sb, "artifacts: %s\n", strings.Join(artifacts, ", "))
+	if stats, err := validation.GetTaskStats(validation.GetTasksFilePath(metadata.Directory)); err == nil {
+		sb.WriteString(validation.FormatTaskSummary(stats))
+	}
+	return sb.String(), nil
+}
+
+func (t *toolSet) readArtifact(_ context.Context, raw json.RawMessage) (string, error) {
+	var args struct {
+		Spec     string `json:"spec"`
+		Artifact string `json:"artifact"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return "", err
+	}
+	if !contains(artifactNames, args.Artifact) {
+		return "", fmt.Errorf("unknown artifact %q (expected one of: %s)", args.Artifact, strings.Join(artifactNames, ", "))
+	}
+	metadata, err := t.resolveSpec(args.Spec)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(metadata.Directory, args.Artifact+".yaml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", path, err)
+	}
+	return string(data), nil
+}
+
+func (t *toolSet) listTasks(_ context.Context, raw json.RawMessage) (string, error) {
+	var args struct {
+		Spec   string `json:"spec"`
+		Status string `json:"status"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return "", err
+	}
+	metadata, err := t.resolveSpec(args.Spec)
+	if err != nil {
+		return "", err
+	}
+	tasks, err := validation.GetAllTasks(filepath.Join(metadata.Directory, "tasks.yaml"))
+	if err != nil {
+		return "", fmt.Errorf("loading tasks: %w", err)
+	}
+	return formatTasks(tasks, args.Status), nil
+}
+
+func (t *toolSet) updateTask(ctx context.Context, raw json.RawMessage) (string, error) {
+	var args struct {
+		TaskID string `json:"task_id"`
+		Status string `json:"status"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return "", err
+	}
+	if err := validateTaskID(args.TaskID); err != nil {
+		return "", err
+	}
+	if err := validateStatus(args.Status, taskStatuses); err != nil {
+		return "", err
+	}
+	return t.runner.Run(ctx, "update-task", "--", args.TaskID, args.Status)
+}
+
+func (t *toolSet) blockTask(ctx context.Context, raw json.RawMessage) (string, error) {
+	var args struct {
+		TaskID string `json:"task_id"`
+		Reason string `json:"reason"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return "", err
+	}
+	if err := validateTaskID(args.TaskID); err != nil {
+		return "", err
+	}
+	return t.runner.Run(ctx, "task", "block", "--reason="+args.Reason, "--", args.TaskID)
+}
+
+func (t *toolSet) unblockTask(ctx context.Context, raw json.RawMessage) (string, error) {
+	var args struct {
+		TaskID string `json:"task_id"`
+		Status string `json:"status"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return "", err
+	}
+	if err := validateTaskID(args.TaskID); err != nil {
+		return "", err
+	}
+	cmdArgs := []string{"task", "unblock"}
+	if args.Status != "" {
+		if err := validateStatus(args.Status, unblockStatuses); err != nil {
+			return "", err
+		}
+		cmdArgs = append(cmdArgs, "--status", args.Status)
+	}
+	return t.runner.Run(ctx, append(cmdArgs, "--", args.TaskID)...)
+}
+
+func (t *toolSet) specify(ctx context.Context, raw json.RawMessage) (string, error) {
+	var args struct {
+		FeatureDescription string `json:"feature_description"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(args.FeatureDescription) == "" {
+		return "", fmt.Errorf("feature_description is required")
+	}
+	return t.runner.Run(ctx, "specify", "--", args.FeatureDescription)
+}
+
+// stageHandler returns a handler that runs a prompt-only stage command.
+func (t *toolSet) stageHandler(stage string) ToolHandler {
+	return func(ctx context.Context, raw json.RawMessage) (string, error) {
+		var args struct {
+			Prompt string `json:"prompt"`
+		}
+		if err := decodeArgs(raw, &args); err != nil {
+			return "", err
+		}
+		cmdArgs := []string{stage}
+		if args.Prompt != "" {
+			cmdArgs = append(cmdArgs, "--", args.Prompt)
+		}
+		return t.runner.Run(ctx, cmdArgs...)
+	}
+}
+
+func (t *toolSet) implement(ctx context.Context, raw json.RawMessage) (string, error) {
+	var args struct {
+		Prompt string `json:"prompt"`
+		Phase  int    `json:"phase"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return "", err
+	}
+	cmdArgs := []string{"implement"}
+	if args.Phase > 0 {
+		cmdArgs = append(cmdArgs, "--phase", strconv.Itoa(args.Phase))
+	}
+	if args.Prompt != "" {
+		cmdArgs = append(cmdArgs, "--", args.Prompt)
+	}
+	return t.runner.Run(ctx, cmdArgs...)
+}
+
+// validateTaskID rejects anything but a task ID, so client input is never
+// parsed as a flag by the autospec subprocess.
+func validateTaskID(id string) error {
+	if !taskIDPattern.MatchString(id) {
+		return fmt.Errorf("invalid task_id %q (expected T followed by digits, e.g. T001)", id)
+	}
+	return nil
+}
+
+// validateStatus checks status against the allowed task statuses.
+func validateStatus(status string, allowed []string) error {
+	for _, s := range allowed {
+		if status == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid status %q (must be one of: %s)", status, strings.Join(allowed, ", "))
+}
+
+// existingArtifacts returns the YAML artifacts present in a spec directory.
+func existingArtifacts(dir string) []string {
+	var found []string
+	for _, name := range artifactNames {
+		if _, err := os.Stat(filepath.Join(dir, name+".yaml")); err == nil {
+			found = append(found, name+".yaml")
+		}
+	}
+	return found
+}
+
+// taskProgress returns a "  tasks: done/total" suffix, or "" without tasks.
+func taskProgress(dir string) string {
+	stats, err := validation.GetTaskStats(validation.GetTasksFilePath(dir))
+	if err != nil || stats.TotalTasks == 0 {
+		return ""
+	}
+	return fmt.Sprintf("  tasks: %d/%d", stats.CompletedTasks, stats.TotalTasks)
+}
+
+// formatTasks renders one line per task, filtered by status when non-empty.
+func formatTasks(tasks []validation.TaskItem, status string) string {
+	var sb strings.Builder
+	for _, task := range tasks {
+		if status != "" && task.Status != status {
+			continue
+		}
+		fmt.Fprintf(&sb, "%s [%s] %s", task.ID, task.Status, task.Title)
+		if task.BlockedReason != "" {
+			fmt.Fprintf(&sb, " (blocked: %s)", task.BlockedReason)
+		}
+		sb.WriteString("\n")
+	}
+	if sb.Len() == 0 {
+		return "No matching tasks"
+	}
+	return sb.String()
+}
+
+// contains reports whether list contains s.
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
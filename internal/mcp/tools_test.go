@@ -0,0 +1,275 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeRunner records autospec invocations instead of executing them.
+type fakeRunner struct {
+	calls [][]string
+}
+
+func (f *fakeRunner) Run(_ context.Context, args ...string) (string, error) {
+	f.calls = append(f.calls, args)
+	return "ran " + strings.Join(args, " "), nil
+}
+
+const testTasksYAML = `phases:
+  - number: 1
+    title: Setup
+    tasks:
+      - id: T001
+        title: Create project
+        status: Completed
+      - id: T002
+        title: Add config
+        status: Blocked
+        blocked_reason: Waiting on API key
+`
+
+// setupSpecsDir creates a specs directory with one spec containing spec.yaml and tasks.yaml.
+func setupSpecsDir(t *testing.T) string {
+	t.Helper()
+	specsDir := t.TempDir()
+	specDir := filepath.Join(specsDir, "001-user-auth")
+	if err := os.MkdirAll(specDir, 0o755); err != nil {
+		t.Fatalf("creating spec dir: %v", err)
+	}
+	files := map[string]string{
+		"spec.yaml":  "feature:\n  branch: 001-user-auth\n",
+		"tasks.yaml": testTasksYAML,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(specDir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("writing %s: %v", name, err)
+		}
+	}
+	return specsDir
+}
+
+func callTool(t *testing.T, s *Server, name, args string) (string, bool) {
+	t.Helper()
+	tool, ok := s.lookupTool(name)
+	if !ok {
+		t.Fatalf("tool %q not registered", name)
+	}
+	out, err := tool.Handler(context.Background(), json.RawMessage(args))
+	if err != nil {
+		return err.Error(), true
+	}
+	return out, false
+}
+
+func TestRegisterTools_QueryTools(t *testing.T) {
+	t.Parallel()
+
+	specsDir := setupSpecsDir(t)
+	s := NewServer("autospec", "test")
+	RegisterTools(s, ToolOptions{SpecsDir: specsDir, Runner: &fakeRunner{}})
+
+	tests := map[string]struct {
+		tool         string
+		args         string
+		wantContains []string
+		wantMissing  []string
+		wantErr      bool
+	}{
+		"list_specs": {
+			tool:         "list_specs",
+			args:         `{}`,
+			wantContains: []string{"001-user-auth", "spec.yaml, tasks.yaml", "tasks: 1/2"},
+		},
+		"status by number": {
+			tool:         "status",
+			args:         `{"spec":"001"}`,
+			wantContains: []string{"spec: 001-user-auth", "artifacts: spec.yaml, tasks.yaml"},
+		},
+		"read_artifact": {
+			tool:         "read_artifact",
+			args:         `{"spec":"user-auth","artifact":"tasks"}`,
+			wantContains: []string{"id: T001"},
+		},
+		"read_artifact unknown type": {
+			tool:    "read_artifact",
+			args:    `{"spec":"001","artifact":"readme"}`,
+			wantErr: true,
+		},
+		"read_artifact missing file": {
+			tool:    "read_artifact",
+			args:    `{"spec":"001","artifact":"plan"}`,
+			wantErr: true,
+		},
+		"list_tasks all": {
+			tool:         "list_tasks",
+			args:         `{"spec":"001"}`,
+			wantContains: []string{"T001 [Completed] Create project", "T002 [Blocked] Add config (blocked: Waiting on API key)"},
+		},
+		"list_tasks filtered": {
+			tool:         "list_tasks",
+			args:         `{"spec":"001","status":"Blocked"}`,
+			wantContains: []string{"T002"},
+			wantMissing:  []string{"T001"},
+		},
+		"unknown spec": {
+			tool:    "status",
+			args:    `{"spec":"999"}`,
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			out, isErr := callTool(t, s, tt.tool, tt.args)
+			if isErr != tt.wantErr {
+				t.Fatalf("isErr = %v, want %v (output: %s)", isErr, tt.wantErr, out)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(out, want) {
+					t.Errorf("output %q should contain %q", out, want)
+				}
+			}
+			for _, missing := range tt.wantMissing {
+				if strings.Contains(out, missing) {
+					t.Errorf("output %q should not contain %q", out, missing)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterTools_RunnerTools(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		tool     string
+		args     string
+		wantArgs []string
+		wantErr  bool
+	}{
+		"update_task": {
+			tool:     "update_task",
+			args:     `{"task_id":"T001","status":"Completed"}`,
+			wantArgs: []string{"update-task", "--", "T001", "Completed"},
+		},
+		"block_task": {
+			tool:     "block_task",
+			args:     `{"task_id":"T002","reason":"needs review"}`,
+			wantArgs: []string{"task", "block", "--reason=needs review", "--", "T002"},
+		},
+		"unblock_task with status": {
+			tool:     "unblock_task",
+			args:     `{"task_id":"T002","status":"InProgress"}`,
+			wantArgs: []string{"task", "unblock", "--status", "InProgress", "--", "T002"},
+		},
+		"specify": {
+			tool:     "specify",
+			args:     `{"feature_description":"Add login"}`,
+			wantArgs: []string{"specify", "--", "Add login"},
+		},
+		"specify requires description": {
+			tool:    "specify",
+			args:    `{"feature_description":"  "}`,
+			wantErr: true,
+		},
+		"plan without prompt": {
+			tool:     "plan",
+			args:     `{}`,
+			wantArgs: []string{"plan"},
+		},
+		"tasks with prompt": {
+			tool:     "tasks",
+			args:     `{"prompt":"small tasks"}`,
+			wantArgs: []string{"tasks", "--", "small tasks"},
+		},
+		"implement single phase": {
+			tool:     "implement",
+			args:     `{"phase":2,"prompt":"focus on tests"}`,
+			wantArgs: []string{"implement", "--phase", "2", "--", "focus on tests"},
+		},
+		"block_task reason that looks like a flag": {
+			tool:     "block_task",
+			args:     `{"task_id":"T002","reason":"--help"}`,
+			wantArgs: []string{"task", "block", "--reason=--help", "--", "T002"},
+		},
+		"specify description that looks like a flag": {
+			tool:     "specify",
+			args:     `{"feature_description":"--skip-preflight"}`,
+			wantArgs: []string{"specify", "--", "--skip-preflight"},
+		},
+		"update_task rejects flag as task_id": {
+			tool:    "update_task",
+			args:    `{"task_id":"--help","status":"Completed"}`,
+			wantErr: true,
+		},
+		"update_task rejects unknown status": {
+			tool:    "update_task",
+			args:    `{"task_id":"T001","status":"-h"}`,
+			wantErr: true,
+		},
+		"block_task rejects invalid task_id": {
+			tool:    "block_task",
+			args:    `{"task_id":"T1 --x","reason":"r"}`,
+			wantErr: true,
+		},
+		"unblock_task rejects completed status": {
+			tool:    "unblock_task",
+			args:    `{"task_id":"T002","status":"Completed"}`,
+			wantErr: true,
+		},
+		"invalid arguments": {
+			tool:    "update_task",
+			args:    `{"task_id":5}`,
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			runner := &fakeRunner{}
+			s := NewServer("autospec", "test")
+			RegisterTools(s, ToolOptions{SpecsDir: t.TempDir(), Runner: runner})
+
+			out, isErr := callTool(t, s, tt.tool, tt.args)
+			if isErr != tt.wantErr {
+				t.Fatalf("isErr = %v, want %v (output: %s)", isErr, tt.wantErr, out)
+			}
+			if tt.wantErr {
+				if len(runner.calls) != 0 {
+					t.Errorf("runner should not be called on error, got %v", runner.calls)
+				}
+				return
+			}
+			if len(runner.calls) != 1 || strings.Join(runner.calls[0], "|") != strings.Join(tt.wantArgs, "|") {
+				t.Errorf("runner calls = %v, want [%v]", runner.calls, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestTailOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input string
+		max   int
+		want  string
+	}{
+		"short output unchanged": {input: "done", max: 10, want: "done"},
+		"long output keeps tail": {input: "0123456789", max: 4, want: "...(output truncated)...\n6789"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if got := tailOutput(tt.input, tt.max); got != tt.want {
+				t.Errorf("tailOutput() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Package metrics records local usage metrics: how often each command runs
+// and fails, how long it takes, and how many sessions, retries and failures
+// each stage and agent backend accumulates.
+// Related: internal/cli/shared/metrics.go, internal/cli/util/metrics.go, internal/workflow/report.go
+// Tags: metrics, telemetry, usage, retries, agents
+//
+// Metrics are kept in <state_dir>/metrics.yaml and are only read by
+// 'autospec metrics show'. When metrics.upload is enabled, anonymized
+// aggregates (see Payload) are also sent to metrics.upload_url once a day.
+package metrics
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the metrics file in the state directory.
+const FileName = "metrics.yaml"
+
+// Stats aggregates the runs of a command, stage or agent.
+type Stats struct {
+	// Count is the number of command runs or agent sessions.
+	Count int `yaml:"count" json:"count"`
+	// Failures is how many of them failed.
+	Failures int `yaml:"failures" json:"failures"`
+	// Retries is how many sessions were retries of a failed attempt.
+	Retries int `yaml:"retries,omitempty" json:"retries,omitempty"`
+	// Seconds is the total wall-clock time spent.
+	Seconds float64 `yaml:"seconds" json:"seconds"`
+}
+
+// add merges other into s.
+func (s *Stats) add(other Stats) {
+	s.Count += other.Count
+	s.Failures += other.Failures
+	s.Retries += other.Retries
+	s.Seconds += other.Seconds
+}
+
+// Metrics is the content of the metrics file.
+type Metrics struct {
+	// InstallID is a random identifier for this installation, used only to
+	// deduplicate uploads. It is not derived from the user or machine.
+	InstallID string `yaml:"install_id" json:"install_id"`
+	// Since is when metrics were first recorded or last reset.
+	Since time.Time `yaml:"since" json:"since"`
+	// LastUpload is when aggregates were last uploaded (nil if never).
+	LastUpload *time.Time `yaml:"last_upload,omitempty" json:"last_upload,omitempty"`
+	// Commands is keyed by command path, e.g. "run" or "task block".
+	Commands map[string]Stats `yaml:"commands,omitempty" json:"commands,omitempty"`
+	// Stages is keyed by stage name, e.g. "plan" or "implement".
+	Stages map[string]Stats `yaml:"stages,omitempty" json:"stages,omitempty"`
+	// Agents is keyed by agent name, e.g. "claude".
+	Agents map[string]Stats `yaml:"agents,omitempty" json:"agents,omitempty"`
+}
+
+// merge adds the counters of other to m.
+func (m *Metrics) merge(other *Metrics) {
+	m.Commands = mergeStats(m.Commands, other.Commands)
+	m.Stages = mergeStats(m.Stages, other.Stages)
+	m.Agents = mergeStats(m.Agents, other.Agents)
+}
+
+// mergeStats adds each entry of src to dst, allocating dst if needed.
+func mergeStats(dst, src map[string]Stats) map[string]Stats {
+	if len(src) > 0 && dst == nil {
+		dst = make(map[string]Stats, len(src))
+	}
+	for key, stats := range src {
+		merged := dst[key]
+		merged.add(stats)
+		dst[key] = merged
+	}
+	return dst
+}
+
+// Load reads the metrics file from stateDir. A missing file yields empty
+// metrics with a fresh install ID.
+func Load(stateDir string) (*Metrics, error) {
+	data, err := os.ReadFile(filepath.Join(stateDir, FileName))
+	if os.IsNotExist(err) {
+		return newMetrics(time.Now())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading metrics file: %w", err)
+	}
+
+	var m Metrics
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing metrics file: %w", err)
+	}
+	if m.InstallID == "" {
+		if m.InstallID, err = newInstallID(); err != nil {
+			return nil, err
+		}
+	}
+	return &m, nil
+}
+
+// Save writes m to the metrics file in stateDir atomically.
+func Save(stateDir string, m *Metrics) error {
+	if err := os.MkdirAll(stateDir, 0o755); err != nil {
+		return fmt.Errorf("creating state directory: %w", err)
+	}
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshaling metrics: %w", err)
+	}
+
+	path := filepath.Join(stateDir, FileName)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("writing temp metrics file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("renaming temp metrics file: %w", err)
+	}
+	return nil
+}
+
+// Reset clears all counters in stateDir and starts over with a new install ID.
+func Reset(stateDir string) error {
+	m, err := newMetrics(time.Now())
+	if err != nil {
+		return err
+	}
+	return Save(stateDir, m)
+}
+
+// newMetrics returns empty metrics starting at now.
+func newMetrics(now time.Time) (*Metrics, error) {
+	id, err := newInstallID()
+	if err != nil {
+		return nil, err
+	}
+	return &Metrics{InstallID: id, Since: now.UTC().Truncate(time.Second)}, nil
+}
+
+// newInstallID returns a random 128-bit hex identifier.
+func newInstallID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating install ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
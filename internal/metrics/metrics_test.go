@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	m, err := Load(t.TempDir())
+	require.NoError(t, err)
+	assert.Len(t, m.InstallID, 32)
+	assert.False(t, m.Since.IsZero())
+	assert.Empty(t, m.Commands)
+}
+
+func TestLoad_InvalidFile(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(stateDir, FileName), []byte("commands: [\n"), 0o644))
+
+	_, err := Load(stateDir)
+	assert.ErrorContains(t, err, "parsing metrics file")
+}
+
+func TestRecorder_Flush(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	r := &Recorder{}
+	r.RecordStage("plan", "claude", 1, false, 2*time.Second)
+	r.RecordStage("plan", "claude", 2, true, 3*time.Second)
+	r.RecordStage("review", "", 1, true, time.Second)
+	r.RecordCommand("plan", true, 6*time.Second)
+
+	m, err := r.Flush(stateDir)
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Count: 1, Seconds: 6}, m.Commands["plan"])
+	assert.Equal(t, Stats{Count: 2, Failures: 1, Retries: 1, Seconds: 5}, m.Stages["plan"])
+	assert.Equal(t, Stats{Count: 1, Seconds: 1}, m.Stages["review"])
+	assert.Equal(t, map[string]Stats{"claude": {Count: 2, Failures: 1, Retries: 1, Seconds: 5}}, m.Agents)
+
+	// A second command adds to the totals on disk and keeps the install ID
+	r.RecordCommand("plan", false, 4*time.Second)
+	again, err := r.Flush(stateDir)
+	require.NoError(t, err)
+	assert.Equal(t, m.InstallID, again.InstallID)
+	assert.Equal(t, Stats{Count: 2, Failures: 1, Seconds: 10}, again.Commands["plan"])
+	assert.Equal(t, 2, again.Stages["plan"].Count)
+
+	loaded, err := Load(stateDir)
+	require.NoError(t, err)
+	assert.Equal(t, again.Commands, loaded.Commands)
+}
+
+func TestReset(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	r := &Recorder{}
+	r.RecordCommand("status", true, time.Second)
+	before, err := r.Flush(stateDir)
+	require.NoError(t, err)
+
+	require.NoError(t, Reset(stateDir))
+	after, err := Load(stateDir)
+	require.NoError(t, err)
+	assert.Empty(t, after.Commands)
+	assert.NotEqual(t, before.InstallID, after.InstallID)
+}
+
+func TestNewPayload_Anonymizes(t *testing.T) {
+	t.Parallel()
+
+	m := &Metrics{
+		InstallID: "abc",
+		Commands:  map[string]Stats{"run": {Count: 3}},
+		Stages: map[string]Stats{
+			"plan":            {Count: 2, Retries: 1},
+			"security-review": {Count: 1},
+			"acme-audit":      {Count: 2, Failures: 1},
+		},
+		Agents: map[string]Stats{"claude": {Count: 2}, "my-internal-bot": {Count: 1}},
+	}
+
+	payload := NewPayload(m, "1.2.3")
+	assert.Equal(t, "abc", payload.InstallID)
+	assert.Equal(t, "1.2.3", payload.Version)
+	assert.Equal(t, map[string]Stats{"run": {Count: 3}}, payload.Commands)
+	assert.Equal(t, map[string]Stats{
+		"plan":  {Count: 2, Retries: 1},
+		"other": {Count: 3, Failures: 1},
+	}, payload.Stages)
+	assert.Equal(t, map[string]Stats{"claude": {Count: 2}, "other": {Count: 1}}, payload.Agents)
+}
+
+func TestMetrics_UploadDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-time.Hour)
+	old := now.Add(-25 * time.Hour)
+
+	tests := map[string]struct {
+		lastUpload *time.Time
+		want       bool
+	}{
+		"never uploaded":     {want: true},
+		"uploaded today":     {lastUpload: &recent},
+		"uploaded a day ago": {lastUpload: &old, want: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			m := &Metrics{LastUpload: tt.lastUpload}
+			assert.Equal(t, tt.want, m.UploadDue(now))
+		})
+	}
+}
+
+func TestUpload(t *testing.T) {
+	t.Parallel()
+
+	var got Payload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	payload := Payload{InstallID: "abc", Commands: map[string]Stats{"run": {Count: 1}}}
+	require.NoError(t, upload(server.Client(), server.URL, payload))
+	assert.Equal(t, payload.InstallID, got.InstallID)
+	assert.Equal(t, payload.Commands, got.Commands)
+}
+
+func TestUpload_ErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := upload(server.Client(), server.URL, Payload{})
+	assert.ErrorContains(t, err, "503")
+}
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// Default is the recorder for the running process. The executor records
+// stage sessions into it and the CLI flushes it when the command finishes.
+var Default = &Recorder{}
+
+// Recorder collects metrics in memory until they are flushed to the metrics
+// file, so a command touches the file once however many sessions it runs.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu      sync.Mutex
+	pending Metrics
+}
+
+// RecordCommand records a finished command run.
+func (r *Recorder) RecordCommand(name string, success bool, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending.Commands = mergeStats(r.pending.Commands, map[string]Stats{name: outcome(success, duration)})
+}
+
+// RecordStage records an agent session of a stage. attempt is 1 for the
+// first session; later attempts count as retries.
+func (r *Recorder) RecordStage(stage, agent string, attempt int, passed bool, duration time.Duration) {
+	stats := outcome(passed, duration)
+	if attempt > 1 {
+		stats.Retries = 1
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending.Stages = mergeStats(r.pending.Stages, map[string]Stats{stage: stats})
+	if agent != "" {
+		r.pending.Agents = mergeStats(r.pending.Agents, map[string]Stats{agent: stats})
+	}
+}
+
+// Flush adds the recorded metrics to the metrics file in stateDir and
+// returns the updated totals. Recorded metrics are kept if saving fails.
+func (r *Recorder) Flush(stateDir string) (*Metrics, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, err := Load(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	m.merge(&r.pending)
+	if err := Save(stateDir, m); err != nil {
+		return nil, err
+	}
+	r.pending = Metrics{}
+	return m, nil
+}
+
+// outcome returns the stats of a single run.
+func outcome(success bool, duration time.Duration) Stats {
+	stats := Stats{Count: 1, Seconds: duration.Seconds()}
+	if !success {
+		stats.Failures = 1
+	}
+	return stats
+}
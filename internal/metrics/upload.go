@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+)
+
+// UploadInterval is the minimum time between two uploads.
+const UploadInterval = 24 * time.Hour
+
+// uploadTimeout bounds an upload so a slow endpoint cannot stall the CLI.
+const uploadTimeout = 3 * time.Second
+
+// otherName replaces stage and agent names that are not built in, since
+// custom phase and agent names are chosen by the user.
+const otherName = "other"
+
+// builtinStages are the stage names sent as-is in uploads.
+var builtinStages = []string{
+	"specify", "plan", "tasks", "implement",
+	"constitution", "clarify", "checklist", "analyze", "verify",
+}
+
+// Payload is the anonymized aggregate sent by Upload. It holds counts and
+// durations only: no spec names, paths, prompts, arguments or agent output.
+type Payload struct {
+	InstallID string           `json:"install_id"`
+	Version   string           `json:"version"`
+	OS        string           `json:"os"`
+	Arch      string           `json:"arch"`
+	Since     time.Time        `json:"since"`
+	Commands  map[string]Stats `json:"commands"`
+	Stages    map[string]Stats `json:"stages"`
+	Agents    map[string]Stats `json:"agents"`
+}
+
+// NewPayload builds the upload payload for m. Custom stage and agent names
+// are folded into "other".
+func NewPayload(m *Metrics, version string) Payload {
+	return Payload{
+		InstallID: m.InstallID,
+		Version:   version,
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Since:     m.Since,
+		Commands:  mergeStats(map[string]Stats{}, m.Commands),
+		Stages:    anonymize(m.Stages, builtinStages),
+		Agents:    anonymize(m.Agents, cliagent.List()),
+	}
+}
+
+// anonymize copies stats, folding keys not in known into otherName.
+func anonymize(stats map[string]Stats, known []string) map[string]Stats {
+	out := make(map[string]Stats, len(stats))
+	for key, s := range stats {
+		if !slices.Contains(known, key) {
+			key = otherName
+		}
+		merged := out[key]
+		merged.add(s)
+		out[key] = merged
+	}
+	return out
+}
+
+// UploadDue reports whether the last upload is at least UploadInterval ago.
+func (m *Metrics) UploadDue(now time.Time) bool {
+	return m.LastUpload == nil || now.Sub(*m.LastUpload) >= UploadInterval
+}
+
+// Upload POSTs the payload to url as JSON.
+func Upload(url string, payload Payload) error {
+	return upload(&http.Client{Timeout: uploadTimeout}, url, payload)
+}
+
+func upload(client *http.Client, url string, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding metrics: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "autospec")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -12,6 +12,7 @@
 //   - Configurable notification hooks (on_command_complete, on_stage_complete, on_error, on_long_running)
 //   - Graceful degradation when notification tools are unavailable
 //   - Non-blocking async dispatch with configurable timeout
+//   - Webhooks that POST JSON workflow events (phase, task and retry updates)
 //
 // # Platform Support
 //
@@ -278,6 +278,64 @@ func (h *Handler) OnInteractiveSessionStart(stageName string) {
 	h.dispatch(n)
 }
 
+// OnInputRequired is called when the agent seems to be waiting for input,
+// e.g. stopped at a permission prompt in a headless run. It rings the
+// terminal bell if bell is enabled, and sends a notification if the
+// on_input_required hook is enabled.
+//
+// stageName: the stage whose agent is waiting
+// reason: what gave it away (the prompt line, or how long it has been silent)
+//
+// TEST COVERAGE BLOCKED: isEnabled() requires TTY; dispatch() calls OS notification APIs.
+func (h *Handler) OnInputRequired(stageName, reason string) {
+	if h.config.Bell {
+		ringBell()
+	}
+
+	if !h.isEnabled() || !h.config.OnInputRequired {
+		return
+	}
+
+	n := NewNotification(
+		"autospec",
+		fmt.Sprintf("Stage '%s' may be waiting for your input: %s", stageName, reason),
+		TypeInfo,
+	)
+	h.dispatch(n)
+}
+
+// OnBlockedTaskEscalated is called when a task has been blocked for longer
+// than escalate_blocked_after, so a human looks at it. Unlike the other
+// hooks it has no switch of its own: escalate_blocked opts in to it.
+//
+// message: how long and why the task has been blocked
+//
+// TEST COVERAGE BLOCKED: isEnabled() requires TTY; dispatch() calls OS notification APIs.
+func (h *Handler) OnBlockedTaskEscalated(specName, taskID, message string) {
+	if !h.isEnabled() {
+		return
+	}
+
+	n := NewNotification(
+		"autospec",
+		fmt.Sprintf("Task %s in %s has been %s", taskID, specName, message),
+		TypeFailure,
+	)
+	h.dispatch(n)
+}
+
+// ringBell writes the bell character to the controlling terminal, which
+// still reaches the user while the live view captures stdout and stderr.
+// No-op without a terminal.
+func ringBell() {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return
+	}
+	defer tty.Close()
+	_, _ = tty.WriteString("\a")
+}
+
 // formatDuration formats a duration for display in notifications
 func formatDuration(d time.Duration) string {
 	if d < time.Second {
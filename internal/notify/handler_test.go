@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -50,7 +51,7 @@ func TestNewHandler(t *testing.T) {
 		t.Fatal("NewHandler returned nil")
 	}
 
-	if handler.Config() != config {
+	if !reflect.DeepEqual(handler.Config(), config) {
 		t.Error("handler config doesn't match input")
 	}
 }
@@ -93,7 +94,7 @@ func TestHandler_Config(t *testing.T) {
 	handler := NewHandler(config)
 
 	gotConfig := handler.Config()
-	if gotConfig != config {
+	if !reflect.DeepEqual(gotConfig, config) {
 		t.Error("Config() returned different config")
 	}
 }
@@ -67,6 +67,49 @@ type NotificationConfig struct {
 	// OnInteractiveSession notifies when an interactive stage is about to begin (default: true when enabled)
 	// This alerts users to return to the terminal after automated stages complete.
 	OnInteractiveSession bool `koanf:"on_interactive_session" yaml:"on_interactive_session" json:"on_interactive_session"`
+
+	// OnInputRequired notifies when the agent seems to be waiting for input (default: true when enabled)
+	OnInputRequired bool `koanf:"on_input_required" yaml:"on_input_required" json:"on_input_required"`
+
+	// InputIdleThreshold is how long an agent may print nothing before it is
+	// treated as waiting for input (default: 10m). 0 only detects prompts.
+	InputIdleThreshold time.Duration `koanf:"input_idle_threshold" yaml:"input_idle_threshold" json:"input_idle_threshold"`
+
+	// Bell rings the terminal bell when the agent seems to be waiting for input.
+	// Independent of Enabled (default: true)
+	Bell bool `koanf:"bell" yaml:"bell" json:"bell"`
+
+	// Webhooks receive JSON workflow events (phase, task and retry updates).
+	// They fire independently of Enabled and also in CI and non-interactive runs.
+	Webhooks []WebhookConfig `koanf:"webhooks" yaml:"webhooks" json:"webhooks"`
+
+	// SlackWebhook is a Slack incoming-webhook URL that receives events as chat messages
+	SlackWebhook string `koanf:"slack_webhook" yaml:"slack_webhook" json:"slack_webhook"`
+
+	// DiscordWebhook is a Discord webhook URL that receives events as chat messages
+	DiscordWebhook string `koanf:"discord_webhook" yaml:"discord_webhook" json:"discord_webhook"`
+
+	// On selects the events sent to SlackWebhook and DiscordWebhook (default: DefaultChatEvents)
+	On []string `koanf:"on" yaml:"on" json:"on"`
+}
+
+// AllWebhooks returns Webhooks followed by entries for SlackWebhook and DiscordWebhook.
+func (c NotificationConfig) AllWebhooks() []WebhookConfig {
+	hooks := append([]WebhookConfig(nil), c.Webhooks...)
+	events := c.On
+	if len(events) == 0 {
+		events = make([]string, len(DefaultChatEvents))
+		for i, e := range DefaultChatEvents {
+			events[i] = string(e)
+		}
+	}
+	if c.SlackWebhook != "" {
+		hooks = append(hooks, WebhookConfig{URL: c.SlackWebhook, Format: FormatSlack, Events: events})
+	}
+	if c.DiscordWebhook != "" {
+		hooks = append(hooks, WebhookConfig{URL: c.DiscordWebhook, Format: FormatDiscord, Events: events})
+	}
+	return hooks
 }
 
 // DefaultConfig returns a NotificationConfig with default values
@@ -81,6 +124,9 @@ func DefaultConfig() NotificationConfig {
 		OnLongRunning:        false,
 		LongRunningThreshold: 2 * time.Minute,
 		OnInteractiveSession: true,
+		OnInputRequired:      true,
+		InputIdleThreshold:   10 * time.Minute,
+		Bell:                 true,
 	}
 }
 
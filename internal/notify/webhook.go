@@ -0,0 +1,266 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// EventType identifies a workflow event delivered to webhooks.
+type EventType string
+
+const (
+	// EventPhaseStarted fires when an implementation phase session starts
+	EventPhaseStarted EventType = "phase.started"
+	// EventPhaseCompleted fires when all tasks of a phase are done
+	EventPhaseCompleted EventType = "phase.completed"
+	// EventPhaseFailed fires when a phase session fails or leaves tasks incomplete
+	EventPhaseFailed EventType = "phase.failed"
+	// EventTaskCompleted fires when a task's status changes to Completed
+	EventTaskCompleted EventType = "task.completed"
+	// EventTaskBlocked fires when a task's status changes to Blocked
+	EventTaskBlocked EventType = "task.blocked"
+	// EventTaskEscalated fires when a task has been blocked for escalate_blocked_after
+	EventTaskEscalated EventType = "task.escalated"
+	// EventRetriesExhausted fires when a stage fails validation after its last retry
+	EventRetriesExhausted EventType = "retries.exhausted"
+	// EventWorkflowCompleted fires when an implement run or full workflow succeeds
+	EventWorkflowCompleted EventType = "workflow.completed"
+	// EventWorkflowFailed fires when an implement run or full workflow fails
+	EventWorkflowFailed EventType = "workflow.failed"
+)
+
+// EventTypes lists every event type in the order they are documented.
+var EventTypes = []EventType{
+	EventPhaseStarted,
+	EventPhaseCompleted,
+	EventPhaseFailed,
+	EventTaskCompleted,
+	EventTaskBlocked,
+	EventTaskEscalated,
+	EventRetriesExhausted,
+	EventWorkflowCompleted,
+	EventWorkflowFailed,
+}
+
+// DefaultChatEvents are sent to slack_webhook and discord_webhook when
+// notifications.on is unset: a run finished or needs intervention.
+var DefaultChatEvents = []EventType{
+	EventPhaseFailed,
+	EventTaskBlocked,
+	EventTaskEscalated,
+	EventRetriesExhausted,
+	EventWorkflowCompleted,
+	EventWorkflowFailed,
+}
+
+// WebhookFormat selects the JSON body shape sent to a webhook.
+type WebhookFormat string
+
+const (
+	// FormatJSON sends the Event as-is (default)
+	FormatJSON WebhookFormat = "json"
+	// FormatSlack sends a Slack incoming-webhook body ({"text": ...})
+	FormatSlack WebhookFormat = "slack"
+	// FormatDiscord sends a Discord webhook body ({"content": ...})
+	FormatDiscord WebhookFormat = "discord"
+)
+
+// ValidWebhookFormat checks if the given string is a valid webhook format.
+// An empty string is valid and means FormatJSON.
+func ValidWebhookFormat(s string) bool {
+	switch WebhookFormat(s) {
+	case "", FormatJSON, FormatSlack, FormatDiscord:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseEventType resolves an event name from config. Besides the canonical
+// dotted names it accepts underscores and "complete" for "completed",
+// e.g. phase_failed or workflow_complete.
+func ParseEventType(s string) (EventType, bool) {
+	name := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "_", ".")
+	if strings.HasSuffix(name, ".complete") {
+		name += "d"
+	}
+	t := EventType(name)
+	return t, slices.Contains(EventTypes, t)
+}
+
+// ValidEventType checks if the given string is a known event type.
+func ValidEventType(s string) bool {
+	_, ok := ParseEventType(s)
+	return ok
+}
+
+// WebhookConfig describes one webhook endpoint.
+type WebhookConfig struct {
+	// URL receives an HTTP POST for every matching event
+	URL string `koanf:"url" yaml:"url" json:"url"`
+
+	// Events filters which event types are sent (empty = all events)
+	Events []string `koanf:"events" yaml:"events" json:"events"`
+
+	// Format is the body shape: json, slack, or discord (default: json)
+	Format WebhookFormat `koanf:"format" yaml:"format" json:"format"`
+
+	// Headers are extra HTTP headers, e.g. an Authorization token
+	Headers map[string]string `koanf:"headers" yaml:"headers" json:"headers"`
+}
+
+// wants reports whether the webhook subscribes to the event type.
+func (c WebhookConfig) wants(t EventType) bool {
+	if len(c.Events) == 0 {
+		return true
+	}
+	for _, name := range c.Events {
+		if parsed, _ := ParseEventType(name); parsed == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is the JSON payload POSTed to webhooks in the json format.
+type Event struct {
+	Event     EventType `json:"event"`
+	Spec      string    `json:"spec"`
+	Stage     string    `json:"stage,omitempty"`
+	Phase     int       `json:"phase,omitempty"`
+	TaskID    string    `json:"task_id,omitempty"`
+	TaskTitle string    `json:"task_title,omitempty"`
+	Retries   int       `json:"retries,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Summary returns a one-line human-readable description of the event,
+// used as the message text for Slack and Discord.
+func (e Event) Summary() string {
+	var subject string
+	switch {
+	case e.TaskID != "" && e.TaskTitle != "":
+		subject = fmt.Sprintf("task %s (%s)", e.TaskID, e.TaskTitle)
+	case e.TaskID != "":
+		subject = "task " + e.TaskID
+	case e.Phase > 0:
+		subject = fmt.Sprintf("phase %d", e.Phase)
+	case e.Stage != "":
+		subject = "stage " + e.Stage
+	}
+
+	text := fmt.Sprintf("autospec [%s] %s", e.Spec, e.Event)
+	if subject != "" {
+		text += ": " + subject
+	}
+	if e.Message != "" {
+		text += " - " + e.Message
+	}
+	return text
+}
+
+// webhookTimeout bounds each POST so a slow endpoint cannot stall a workflow.
+const webhookTimeout = 5 * time.Second
+
+// WebhookNotifier POSTs workflow events to the configured webhooks.
+// Unlike Handler it is not gated by notifications.enabled, CI detection or a TTY:
+// webhooks are meant for unattended runs. Delivery failures are reported as
+// warnings and never fail the workflow. A nil *WebhookNotifier is a no-op.
+type WebhookNotifier struct {
+	hooks  []WebhookConfig
+	client *http.Client
+	warn   io.Writer
+}
+
+// NewWebhookNotifier creates a notifier for the given webhooks.
+// Returns nil when no webhooks are configured.
+func NewWebhookNotifier(hooks []WebhookConfig) *WebhookNotifier {
+	return newWebhookNotifier(hooks, &http.Client{Timeout: webhookTimeout})
+}
+
+func newWebhookNotifier(hooks []WebhookConfig, client *http.Client) *WebhookNotifier {
+	if len(hooks) == 0 {
+		return nil
+	}
+	return &WebhookNotifier{hooks: hooks, client: client, warn: os.Stderr}
+}
+
+// Send delivers the event to every webhook subscribed to its type.
+// Delivery is synchronous so events are not lost when the process exits.
+func (w *WebhookNotifier) Send(event Event) {
+	if w == nil {
+		return
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+	for _, hook := range w.hooks {
+		if !hook.wants(event.Event) {
+			continue
+		}
+		if err := w.post(hook, event); err != nil {
+			fmt.Fprintf(w.warn, "Warning: webhook %s failed: %v\n", redactURL(hook.URL), err)
+		}
+	}
+}
+
+// post sends a single event to a single webhook.
+func (w *WebhookNotifier) post(hook WebhookConfig, event Event) error {
+	body, err := webhookBody(hook.Format, event)
+	if err != nil {
+		return fmt.Errorf("encoding event: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "autospec")
+	for k, v := range hook.Headers {
+		req.Header.Set(k, os.ExpandEnv(v))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// webhookBody encodes the event in the webhook's format.
+func webhookBody(format WebhookFormat, event Event) ([]byte, error) {
+	switch format {
+	case FormatSlack:
+		return json.Marshal(map[string]string{"text": event.Summary()})
+	case FormatDiscord:
+		return json.Marshal(map[string]string{"content": event.Summary()})
+	default:
+		return json.Marshal(event)
+	}
+}
+
+// redactURL strips the path from a webhook URL for log output, since
+// Slack and Discord embed their secret token in it.
+func redactURL(raw string) string {
+	scheme, rest, ok := strings.Cut(raw, "://")
+	if !ok {
+		return "(invalid url)"
+	}
+	host, _, _ := strings.Cut(rest, "/")
+	return scheme + "://" + host + "/…"
+}
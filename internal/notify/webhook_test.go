@@ -0,0 +1,266 @@
+// Package notify_test tests webhook delivery, event filtering and body formats.
+// Related: internal/notify/webhook.go
+// Tags: notify, webhooks, http, events
+
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type capturedRequest struct {
+	body   map[string]any
+	header http.Header
+}
+
+func TestWebhookNotifier_Send(t *testing.T) {
+	event := Event{
+		Event:     EventTaskBlocked,
+		Spec:      "001-auth",
+		TaskID:    "T004",
+		TaskTitle: "Wire API",
+		Message:   "Waiting for API key",
+		Timestamp: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	tests := map[string]struct {
+		hook      WebhookConfig
+		status    int
+		wantBody  map[string]any
+		wantSent  bool
+		wantWarn  string
+		wantToken string
+	}{
+		"json body": {
+			hook:     WebhookConfig{},
+			wantSent: true,
+			wantBody: map[string]any{
+				"event":      "task.blocked",
+				"spec":       "001-auth",
+				"task_id":    "T004",
+				"task_title": "Wire API",
+				"message":    "Waiting for API key",
+				"timestamp":  "2026-01-02T03:04:05Z",
+			},
+		},
+		"slack body": {
+			hook:     WebhookConfig{Format: FormatSlack},
+			wantSent: true,
+			wantBody: map[string]any{"text": "autospec [001-auth] task.blocked: task T004 (Wire API) - Waiting for API key"},
+		},
+		"discord body": {
+			hook:     WebhookConfig{Format: FormatDiscord},
+			wantSent: true,
+			wantBody: map[string]any{"content": "autospec [001-auth] task.blocked: task T004 (Wire API) - Waiting for API key"},
+		},
+		"subscribed event": {
+			hook:     WebhookConfig{Events: []string{"task.blocked"}, Format: FormatSlack},
+			wantSent: true,
+			wantBody: map[string]any{"text": "autospec [001-auth] task.blocked: task T004 (Wire API) - Waiting for API key"},
+		},
+		"filtered event": {
+			hook: WebhookConfig{Events: []string{"phase.failed"}},
+		},
+		"custom headers expand env": {
+			hook:      WebhookConfig{Format: FormatSlack, Headers: map[string]string{"Authorization": "Bearer ${AUTOSPEC_TEST_WEBHOOK_TOKEN}"}},
+			wantSent:  true,
+			wantBody:  map[string]any{"text": "autospec [001-auth] task.blocked: task T004 (Wire API) - Waiting for API key"},
+			wantToken: "Bearer s3cret",
+		},
+		"error status warns": {
+			hook:     WebhookConfig{Format: FormatSlack},
+			status:   http.StatusNotFound,
+			wantSent: true,
+			wantBody: map[string]any{"text": "autospec [001-auth] task.blocked: task T004 (Wire API) - Waiting for API key"},
+			wantWarn: "unexpected status 404 Not Found",
+		},
+	}
+
+	t.Setenv("AUTOSPEC_TEST_WEBHOOK_TOKEN", "s3cret")
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got []capturedRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]any
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				got = append(got, capturedRequest{body: body, header: r.Header})
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+			}))
+			defer server.Close()
+
+			tt.hook.URL = server.URL + "/hooks/secret-token"
+			var warn bytes.Buffer
+			notifier := newWebhookNotifier([]WebhookConfig{tt.hook}, server.Client())
+			notifier.warn = &warn
+
+			notifier.Send(event)
+
+			if !tt.wantSent {
+				assert.Empty(t, got)
+				return
+			}
+			require.Len(t, got, 1)
+			assert.Equal(t, tt.wantBody, got[0].body)
+			assert.Equal(t, "application/json", got[0].header.Get("Content-Type"))
+			if tt.wantToken != "" {
+				assert.Equal(t, tt.wantToken, got[0].header.Get("Authorization"))
+			}
+			if tt.wantWarn != "" {
+				assert.Contains(t, warn.String(), tt.wantWarn)
+				assert.NotContains(t, warn.String(), "secret-token", "webhook URLs are redacted")
+			} else {
+				assert.Empty(t, warn.String())
+			}
+		})
+	}
+}
+
+func TestWebhookNotifier_NilIsNoop(t *testing.T) {
+	t.Parallel()
+
+	notifier := NewWebhookNotifier(nil)
+	assert.Nil(t, notifier)
+	notifier.Send(Event{Event: EventPhaseStarted})
+}
+
+func TestWebhookNotifier_UnreachableWarns(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	var warn bytes.Buffer
+	notifier := newWebhookNotifier([]WebhookConfig{{URL: url}}, server.Client())
+	notifier.warn = &warn
+	notifier.Send(Event{Event: EventPhaseFailed, Spec: "001-auth", Phase: 2})
+
+	assert.Contains(t, warn.String(), "Warning: webhook http://127.0.0.1")
+}
+
+func TestEvent_Summary(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		event Event
+		want  string
+	}{
+		"phase":            {event: Event{Event: EventPhaseStarted, Spec: "001-auth", Phase: 2}, want: "autospec [001-auth] phase.started: phase 2"},
+		"task id":          {event: Event{Event: EventTaskCompleted, Spec: "001-auth", TaskID: "T003"}, want: "autospec [001-auth] task.completed: task T003"},
+		"stage":            {event: Event{Event: EventRetriesExhausted, Spec: "001-auth", Stage: "plan", Message: "plan.yaml missing"}, want: "autospec [001-auth] retries.exhausted: stage plan - plan.yaml missing"},
+		"bare event":       {event: Event{Event: EventPhaseFailed, Spec: "001-auth"}, want: "autospec [001-auth] phase.failed"},
+		"task beats phase": {event: Event{Event: EventTaskBlocked, Spec: "x", Phase: 1, TaskID: "T001"}, want: "autospec [x] task.blocked: task T001"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.event.Summary())
+		})
+	}
+}
+
+func TestValidWebhookFormatAndEventType(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range []string{"", "json", "slack", "discord"} {
+		assert.True(t, ValidWebhookFormat(f), f)
+	}
+	assert.False(t, ValidWebhookFormat("teams"))
+
+	for _, e := range EventTypes {
+		assert.True(t, ValidEventType(string(e)), e)
+	}
+	assert.False(t, ValidEventType("task.started"))
+}
+
+func TestParseEventType(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input  string
+		want   EventType
+		wantOK bool
+	}{
+		"canonical":          {input: "phase.failed", want: EventPhaseFailed, wantOK: true},
+		"underscore":         {input: "phase_failed", want: EventPhaseFailed, wantOK: true},
+		"complete shorthand": {input: "workflow_complete", want: EventWorkflowCompleted, wantOK: true},
+		"mixed case":         {input: " Task_Blocked ", want: EventTaskBlocked, wantOK: true},
+		"unknown":            {input: "task_started", want: "task.started"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := ParseEventType(tt.input)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestNotificationConfig_AllWebhooks(t *testing.T) {
+	t.Parallel()
+
+	defaults := make([]string, len(DefaultChatEvents))
+	for i, e := range DefaultChatEvents {
+		defaults[i] = string(e)
+	}
+	custom := WebhookConfig{URL: "https://example.com/hook"}
+
+	tests := map[string]struct {
+		config NotificationConfig
+		want   []WebhookConfig
+	}{
+		"nothing configured": {},
+		"webhooks only": {
+			config: NotificationConfig{Webhooks: []WebhookConfig{custom}},
+			want:   []WebhookConfig{custom},
+		},
+		"slack with default events": {
+			config: NotificationConfig{SlackWebhook: "https://hooks.slack.com/x"},
+			want:   []WebhookConfig{{URL: "https://hooks.slack.com/x", Format: FormatSlack, Events: defaults}},
+		},
+		"slack and discord share on": {
+			config: NotificationConfig{
+				Webhooks:       []WebhookConfig{custom},
+				SlackWebhook:   "https://hooks.slack.com/x",
+				DiscordWebhook: "https://discord.com/api/webhooks/y",
+				On:             []string{"phase_failed", "workflow_complete"},
+			},
+			want: []WebhookConfig{
+				custom,
+				{URL: "https://hooks.slack.com/x", Format: FormatSlack, Events: []string{"phase_failed", "workflow_complete"}},
+				{URL: "https://discord.com/api/webhooks/y", Format: FormatDiscord, Events: []string{"phase_failed", "workflow_complete"}},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.config.AllWebhooks())
+		})
+	}
+}
+
+func TestWebhookConfig_WantsAliases(t *testing.T) {
+	t.Parallel()
+
+	hook := WebhookConfig{Events: []string{"phase_failed", "workflow_complete"}}
+	assert.True(t, hook.wants(EventPhaseFailed))
+	assert.True(t, hook.wants(EventWorkflowCompleted))
+	assert.False(t, hook.wants(EventWorkflowFailed))
+	assert.True(t, WebhookConfig{}.wants(EventTaskCompleted), "no filter sends everything")
+}
@@ -0,0 +1,85 @@
+// Package policy loads the project's permission policy for agent tool use
+// from .autospec/policy.yaml.
+// Related: internal/cliagent/permissions.go, internal/workflow/executor.go
+// Tags: policy, permissions, tools, security
+//
+// The policy allows or denies shell commands, file globs and network domains,
+// for every stage or per stage:
+//
+//	allow:
+//	  commands: ["go test", "make"]
+//	  files: ["internal/**"]
+//	deny:
+//	  commands: ["git push", "rm -rf"]
+//	  files: [".env", "secrets/**"]
+//	  network: ["*"]
+//	stages:
+//	  specify:
+//	    deny:
+//	      commands: ["*"]
+//
+// Stage rules are added to the top-level rules. The agent enforces the
+// result, so only agents with permission flags (claude) apply a policy.
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the policy file in the .autospec directory.
+const FileName = "policy.yaml"
+
+// Path returns the project's policy file.
+func Path() string {
+	return filepath.Join(".autospec", FileName)
+}
+
+// Policy is the parsed policy file.
+type Policy struct {
+	cliagent.ToolPolicy `yaml:",inline"`
+
+	// Stages adds rules for individual stages, keyed by stage name.
+	Stages map[string]cliagent.ToolPolicy `yaml:"stages,omitempty"`
+}
+
+// Load reads the policy file at path. Returns nil without error if the file
+// doesn't exist. Unknown keys are rejected so that a typo can't silently
+// drop a deny rule.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading policy: %w", err)
+	}
+
+	var p Policy
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// ForStage returns the rules that apply to stage, or nil if there are none.
+func (p *Policy) ForStage(stage string) *cliagent.ToolPolicy {
+	if p == nil {
+		return nil
+	}
+	rules := p.ToolPolicy.Merge(p.Stages[stage])
+	if rules.IsZero() {
+		return nil
+	}
+	return &rules
+}
@@ -0,0 +1,103 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), FileName)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		content string
+		want    *Policy
+		wantErr string
+	}{
+		"rules and stages": {
+			content: `
+allow:
+  commands: ["go test"]
+deny:
+  files: [".env"]
+stages:
+  specify:
+    deny:
+      commands: ["*"]
+`,
+			want: &Policy{
+				ToolPolicy: cliagent.ToolPolicy{
+					Allow: cliagent.ToolRules{Commands: []string{"go test"}},
+					Deny:  cliagent.ToolRules{Files: []string{".env"}},
+				},
+				Stages: map[string]cliagent.ToolPolicy{
+					"specify": {Deny: cliagent.ToolRules{Commands: []string{"*"}}},
+				},
+			},
+		},
+		"empty file": {
+			content: "",
+			want:    &Policy{},
+		},
+		"unknown key": {
+			content: "deny:\n  command: [\"git push\"]\n",
+			wantErr: "field command not found",
+		},
+		"invalid yaml": {
+			content: "allow: [",
+			wantErr: "parsing",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Load(writePolicy(t, tt.content))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoad_Missing(t *testing.T) {
+	t.Parallel()
+
+	got, err := Load(filepath.Join(t.TempDir(), FileName))
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestPolicy_ForStage(t *testing.T) {
+	t.Parallel()
+
+	p := &Policy{
+		ToolPolicy: cliagent.ToolPolicy{Deny: cliagent.ToolRules{Commands: []string{"git push"}}},
+		Stages: map[string]cliagent.ToolPolicy{
+			"specify": {Deny: cliagent.ToolRules{Commands: []string{"*"}}},
+		},
+	}
+
+	assert.Equal(t, []string{"git push", "*"}, p.ForStage("specify").Deny.Commands)
+	assert.Equal(t, []string{"git push"}, p.ForStage("implement").Deny.Commands)
+
+	var none *Policy
+	assert.Nil(t, none.ForStage("plan"))
+	assert.Nil(t, (&Policy{Stages: map[string]cliagent.ToolPolicy{"plan": {}}}).ForStage("plan"))
+}
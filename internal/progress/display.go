@@ -14,8 +14,13 @@ type ProgressDisplay struct {
 	currentStage *StageInfo
 	spinner      *spinner.Spinner
 	symbols      ProgressSymbols
+	started      time.Time     // When the current stage started
+	stopTicker   chan struct{} // Closed to stop updating the remaining time
 }
 
+// remainingInterval is how often the spinner's remaining time is refreshed
+const remainingInterval = time.Second
+
 // NewProgressDisplay creates a new progress display with the given terminal capabilities
 func NewProgressDisplay(caps TerminalCapabilities) *ProgressDisplay {
 	return &ProgressDisplay{
@@ -33,7 +38,9 @@ func (p *ProgressDisplay) StartStage(stage StageInfo) error {
 		return fmt.Errorf("validating stage info: %w", err)
 	}
 
+	p.stopRemaining()
 	p.currentStage = &stage
+	p.started = time.Now()
 
 	// Build the stage message
 	msg := buildStageMessage(stage, "Running")
@@ -47,6 +54,9 @@ func (p *ProgressDisplay) StartStage(stage StageInfo) error {
 		p.spinner.Writer = os.Stderr // Write to stderr to avoid interfering with Claude's stdout
 		p.spinner.Suffix = " " + msg
 		p.spinner.Start()
+		if stage.Estimate > 0 {
+			p.startRemaining(msg, stage.Estimate)
+		}
 	} else {
 		// Non-interactive mode: Just print the message
 		fmt.Println(msg)
@@ -55,6 +65,36 @@ func (p *ProgressDisplay) StartStage(stage StageInfo) error {
 	return nil
 }
 
+// startRemaining refreshes the spinner with the time left until the stage
+// reaches its usual duration, until stopRemaining is called
+func (p *ProgressDisplay) startRemaining(msg string, estimate time.Duration) {
+	stop := make(chan struct{})
+	p.stopTicker = stop
+	s, started := p.spinner, p.started
+	go func() {
+		ticker := time.NewTicker(remainingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				s.Lock()
+				s.Suffix = fmt.Sprintf(" %s · %s", msg, formatRemaining(estimate, time.Since(started)))
+				s.Unlock()
+			}
+		}
+	}()
+}
+
+// stopRemaining stops refreshing the remaining time, if it is running
+func (p *ProgressDisplay) stopRemaining() {
+	if p.stopTicker != nil {
+		close(p.stopTicker)
+		p.stopTicker = nil
+	}
+}
+
 // UpdateRetry updates the display with retry count information
 func (p *ProgressDisplay) UpdateRetry(stage StageInfo) error {
 	return p.StartStage(stage)
@@ -63,6 +103,7 @@ func (p *ProgressDisplay) UpdateRetry(stage StageInfo) error {
 // CompleteStage stops the spinner and displays completion status
 func (p *ProgressDisplay) CompleteStage(stage StageInfo) error {
 	// Stop spinner if running
+	p.stopRemaining()
 	if p.spinner != nil {
 		p.spinner.Stop()
 		p.spinner = nil
@@ -71,15 +112,25 @@ func (p *ProgressDisplay) CompleteStage(stage StageInfo) error {
 	// Display completion message
 	mark := checkmark(p.symbols, p.capabilities.SupportsColor)
 	counter := formatStageCounter(stage.Number, stage.TotalStages)
-	fmt.Printf("%s %s %s stage complete\n", mark, counter, capitalize(stage.Name))
+	fmt.Printf("%s %s %s stage complete%s\n", mark, counter, capitalize(stage.Name), p.elapsedNote(stage))
 
 	p.currentStage = nil
 	return nil
 }
 
+// elapsedNote returns how long the stage took next to its usual duration,
+// or "" when the stage has no estimate
+func (p *ProgressDisplay) elapsedNote(stage StageInfo) string {
+	if stage.Estimate <= 0 || p.started.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf(" in %s (usually %s)", time.Since(p.started).Round(time.Second), FormatEstimate(stage.Estimate))
+}
+
 // FailStage stops the spinner and displays failure status
 func (p *ProgressDisplay) FailStage(stage StageInfo, err error) error {
 	// Stop spinner if running
+	p.stopRemaining()
 	if p.spinner != nil {
 		p.spinner.Stop()
 		p.spinner = nil
@@ -97,6 +148,7 @@ func (p *ProgressDisplay) FailStage(stage StageInfo, err error) error {
 // StopSpinner stops the spinner without showing completion/failure
 // This is useful when you want to pause progress display during interactive output
 func (p *ProgressDisplay) StopSpinner() {
+	p.stopRemaining()
 	if p.spinner != nil {
 		p.spinner.Stop()
 		p.spinner = nil
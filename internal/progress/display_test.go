@@ -10,6 +10,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ariel-frischer/autospec/internal/progress"
 )
@@ -92,6 +93,21 @@ func TestProgressDisplay_StartStage(t *testing.T) {
 			wantContains: []string{"[3/3]", "tasks", "(retry 2/3)"},
 			wantErr:      false,
 		},
+		"stage with a historical estimate": {
+			capabilities: progress.TerminalCapabilities{
+				IsTTY: false,
+			},
+			stage: progress.StageInfo{
+				Name:        "plan",
+				Number:      2,
+				TotalStages: 3,
+				Status:      progress.StageInProgress,
+				MaxRetries:  3,
+				Estimate:    4*time.Minute + 10*time.Second,
+			},
+			wantContains: []string{"[2/3]", "Plan", "(usually ~4m on this repo)"},
+			wantErr:      false,
+		},
 		"invalid stage - empty name": {
 			capabilities: progress.TerminalCapabilities{
 				IsTTY:           true,
@@ -266,6 +282,28 @@ func TestProgressDisplay_CompleteStage(t *testing.T) {
 	}
 }
 
+// TestProgressDisplay_CompleteStageWithEstimate tests that completion shows the
+// time taken next to the usual duration
+func TestProgressDisplay_CompleteStageWithEstimate(t *testing.T) {
+	display := progress.NewProgressDisplay(progress.TerminalCapabilities{IsTTY: false})
+	stage := progress.StageInfo{
+		Name:        "plan",
+		Number:      1,
+		TotalStages: 1,
+		Status:      progress.StageInProgress,
+		Estimate:    4 * time.Minute,
+	}
+
+	output := captureOutput(func() {
+		_ = display.StartStage(stage)
+		_ = display.CompleteStage(stage)
+	})
+
+	if !strings.Contains(output, "Plan stage complete in 0s (usually ~4m)") {
+		t.Errorf("CompleteStage() output = %q, want time taken and usual duration", output)
+	}
+}
+
 // TestProgressDisplay_FailStage tests failure indicators (User Story 3)
 func TestProgressDisplay_FailStage(t *testing.T) {
 	tests := map[string]struct {
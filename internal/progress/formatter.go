@@ -3,6 +3,7 @@ package progress
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // formatStageCounter returns the [N/Total] stage counter string
@@ -19,9 +20,36 @@ func buildStageMessage(stage StageInfo, action string) string {
 		msg += fmt.Sprintf(" (retry %d/%d)", stage.RetryCount+1, stage.MaxRetries)
 	}
 
+	if stage.Estimate > 0 {
+		msg += fmt.Sprintf(" (usually %s on this repo)", FormatEstimate(stage.Estimate))
+	}
+
 	return msg
 }
 
+// FormatEstimate returns an approximate duration: seconds under a minute,
+// whole minutes otherwise (e.g., "~45s", "~4m", "~1h20m")
+func FormatEstimate(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("~%ds", int(d.Round(time.Second).Seconds()))
+	}
+	d = d.Round(time.Minute)
+	if d < time.Hour {
+		return fmt.Sprintf("~%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("~%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// formatRemaining returns the time left on a stage given its usual duration
+// and how long it has been running
+func formatRemaining(estimate, elapsed time.Duration) string {
+	remaining := estimate - elapsed
+	if remaining <= 0 {
+		return "taking longer than usual"
+	}
+	return FormatEstimate(remaining) + " left"
+}
+
 // capitalize returns the string with the first letter capitalized
 func capitalize(s string) string {
 	if len(s) == 0 {
@@ -0,0 +1,61 @@
+// Package progress tests duration estimate and remaining time formatting.
+// Related: internal/progress/formatter.go
+// Tags: progress, formatter, estimate, eta
+package progress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatEstimate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		d    time.Duration
+		want string
+	}{
+		"seconds":            {d: 45 * time.Second, want: "~45s"},
+		"rounds to minutes":  {d: 4*time.Minute + 40*time.Second, want: "~5m"},
+		"exactly one minute": {d: time.Minute, want: "~1m"},
+		"hours and minutes":  {d: 80 * time.Minute, want: "~1h20m"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, FormatEstimate(tt.d))
+		})
+	}
+}
+
+func TestFormatRemaining(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		estimate time.Duration
+		elapsed  time.Duration
+		want     string
+	}{
+		"just started":      {estimate: 4 * time.Minute, want: "~4m left"},
+		"part way":          {estimate: 4 * time.Minute, elapsed: 90 * time.Second, want: "~3m left"},
+		"nearly done":       {estimate: 4 * time.Minute, elapsed: 3*time.Minute + 40*time.Second, want: "~20s left"},
+		"past the estimate": {estimate: 4 * time.Minute, elapsed: 5 * time.Minute, want: "taking longer than usual"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, formatRemaining(tt.estimate, tt.elapsed))
+		})
+	}
+}
+
+func TestBuildStageMessage_Estimate(t *testing.T) {
+	t.Parallel()
+
+	stage := StageInfo{Name: "plan", Number: 2, TotalStages: 3, RetryCount: 1, MaxRetries: 3, Estimate: 4 * time.Minute}
+	assert.Equal(t, "[2/3] Running Plan stage (retry 2/3) (usually ~4m on this repo)", buildStageMessage(stage, "Running"))
+}
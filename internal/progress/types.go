@@ -3,7 +3,11 @@
 // and terminal display helpers including spinners and formatted output.
 package progress
 
-import apperrors "github.com/ariel-frischer/autospec/internal/errors"
+import (
+	"time"
+
+	apperrors "github.com/ariel-frischer/autospec/internal/errors"
+)
 
 // StageStatus represents the execution state of a workflow stage
 type StageStatus int
@@ -49,6 +53,8 @@ type StageInfo struct {
 	RetryCount int
 	// MaxRetries is the maximum retry attempts allowed
 	MaxRetries int
+	// Estimate is how long the stage usually takes, from history (0 if unknown)
+	Estimate time.Duration
 }
 
 // Validate checks that all StageInfo fields meet validation requirements
@@ -0,0 +1,266 @@
+// Package queue keeps a persistent queue of spec stages to run.
+// Related: internal/cli/queue.go, internal/batch/batch.go
+// Tags: queue, batch, worker, worktree
+//
+// Each item is a file in <state_dir>/queue/ named by its number, so adding
+// work never conflicts with a worker updating other items. A single worker
+// process at a time (see Worker) takes items in order and runs them through
+// a batch.Runner: in the current tree one after another, or with several
+// workers each in the spec's own worktree.
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DirName is the directory under the state dir holding queue items.
+const DirName = "queue"
+
+// Status is the state of a queue item.
+type Status string
+
+// Item states.
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	// StatusDeferred items were queued because the agent was unreachable.
+	// Workers skip them until RetryPending makes them pending again.
+	StatusDeferred Status = "deferred"
+)
+
+// QueueableStages are the stages an item can run, in workflow order.
+// Specify and constitution are not queued since they don't operate on an
+// existing spec.
+var QueueableStages = []string{"clarify", "plan", "tasks", "checklist", "analyze", "implement"}
+
+// Item is a spec, and optionally the stages to run for it, waiting in the
+// queue or already processed.
+type Item struct {
+	ID         int        `yaml:"id" json:"id"`
+	Spec       string     `yaml:"spec" json:"spec"`
+	Stages     []string   `yaml:"stages,omitempty" json:"stages,omitempty"` // Empty runs the stages the spec still needs
+	Status     Status     `yaml:"status" json:"status"`
+	AddedAt    time.Time  `yaml:"added_at" json:"added_at"`
+	StartedAt  *time.Time `yaml:"started_at,omitempty" json:"started_at,omitempty"`
+	FinishedAt *time.Time `yaml:"finished_at,omitempty" json:"finished_at,omitempty"`
+	Retries    int        `yaml:"retries,omitempty" json:"retries,omitempty"`
+	Error      string     `yaml:"error,omitempty" json:"error,omitempty"`
+	LogPath    string     `yaml:"log,omitempty" json:"log,omitempty"`
+	Worktree   string     `yaml:"worktree,omitempty" json:"worktree,omitempty"`
+}
+
+// Finished reports whether the item has been processed.
+func (it *Item) Finished() bool {
+	return it.Status == StatusCompleted || it.Status == StatusFailed
+}
+
+// StagesLabel describes the item's stages for listings.
+func (it *Item) StagesLabel() string {
+	if len(it.Stages) == 0 {
+		return "remaining"
+	}
+	return strings.Join(it.Stages, ",")
+}
+
+// Dir returns the directory holding queue items.
+func Dir(stateDir string) string {
+	return filepath.Join(stateDir, DirName)
+}
+
+// itemPath returns the file of the item with the given ID.
+func itemPath(stateDir string, id int) string {
+	return filepath.Join(Dir(stateDir), fmt.Sprintf("%04d.yaml", id))
+}
+
+// ValidateStages checks stage names against QueueableStages and returns
+// them in workflow order without duplicates.
+func ValidateStages(stages []string) ([]string, error) {
+	var ordered []string
+	for _, stage := range stages {
+		if !slices.Contains(QueueableStages, stage) {
+			return nil, fmt.Errorf("cannot queue stage %q (valid: %s)", stage, strings.Join(QueueableStages, ", "))
+		}
+	}
+	for _, stage := range QueueableStages {
+		if slices.Contains(stages, stage) {
+			ordered = append(ordered, stage)
+		}
+	}
+	return ordered, nil
+}
+
+// Add appends a pending item for spec and returns it. The item gets the next
+// free number, so concurrent adds never overwrite each other.
+func Add(stateDir, spec string, stages []string, now time.Time) (*Item, error) {
+	return add(stateDir, &Item{Spec: spec, Stages: stages, Status: StatusPending, AddedAt: now})
+}
+
+// Defer appends a deferred item for spec, recording why it couldn't run now.
+// Workers leave it alone until RetryPending.
+func Defer(stateDir, spec string, stages []string, reason string, now time.Time) (*Item, error) {
+	return add(stateDir, &Item{Spec: spec, Stages: stages, Status: StatusDeferred, AddedAt: now, Error: reason})
+}
+
+// RetryPending makes deferred items pending again and returns them.
+func RetryPending(stateDir string) ([]*Item, error) {
+	items, err := List(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	retried := []*Item{}
+	for _, item := range items {
+		if item.Status != StatusDeferred {
+			continue
+		}
+		item.Status = StatusPending
+		item.Error = ""
+		if err := Save(stateDir, item); err != nil {
+			return retried, err
+		}
+		retried = append(retried, item)
+	}
+	return retried, nil
+}
+
+// add gives item the next free number and writes it.
+func add(stateDir string, item *Item) (*Item, error) {
+	if err := os.MkdirAll(Dir(stateDir), 0o755); err != nil {
+		return nil, fmt.Errorf("creating queue directory: %w", err)
+	}
+	items, err := List(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	id := 1
+	if len(items) > 0 {
+		id = items[len(items)-1].ID + 1
+	}
+
+	for ; ; id++ {
+		f, err := os.OpenFile(itemPath(stateDir, id), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("creating queue item: %w", err)
+		}
+		f.Close()
+		item.ID = id
+		break
+	}
+	if err := Save(stateDir, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Save writes an item atomically.
+func Save(stateDir string, item *Item) error {
+	data, err := yaml.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("marshaling queue item: %w", err)
+	}
+	path := itemPath(stateDir, item.ID)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("writing queue item: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("writing queue item: %w", err)
+	}
+	return nil
+}
+
+// Load returns the item with the given ID.
+func Load(stateDir string, id int) (*Item, error) {
+	data, err := os.ReadFile(itemPath(stateDir, id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("queue item %d not found", id)
+		}
+		return nil, fmt.Errorf("reading queue item: %w", err)
+	}
+	var item Item
+	if err := yaml.Unmarshal(data, &item); err != nil {
+		return nil, fmt.Errorf("parsing queue item %d: %w", id, err)
+	}
+	return &item, nil
+}
+
+// List returns all items in queue order. Items still being written by Add
+// are skipped.
+func List(stateDir string) ([]*Item, error) {
+	entries, err := os.ReadDir(Dir(stateDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Item{}, nil
+		}
+		return nil, fmt.Errorf("reading queue directory: %w", err)
+	}
+
+	items := []*Item{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".yaml" {
+			continue
+		}
+		id, err := strconv.Atoi(strings.TrimSuffix(name, ".yaml"))
+		if err != nil {
+			continue
+		}
+		item, err := Load(stateDir, id)
+		if err != nil || item.Spec == "" {
+			continue
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	return items, nil
+}
+
+// Remove deletes a pending or finished item. Running items can't be removed.
+func Remove(stateDir string, id int) error {
+	item, err := Load(stateDir, id)
+	if err != nil {
+		return err
+	}
+	if item.Status == StatusRunning {
+		return fmt.Errorf("queue item %d is running", id)
+	}
+	if err := os.Remove(itemPath(stateDir, id)); err != nil {
+		return fmt.Errorf("removing queue item: %w", err)
+	}
+	return nil
+}
+
+// Clear removes finished items, or with all also pending and deferred ones,
+// and returns how many were removed. Running items are kept.
+func Clear(stateDir string, all bool) (int, error) {
+	items, err := List(stateDir)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, item := range items {
+		if item.Finished() || (all && item.Status != StatusRunning) {
+			if err := os.Remove(itemPath(stateDir, item.ID)); err != nil {
+				return removed, fmt.Errorf("removing queue item: %w", err)
+			}
+			removed++
+		}
+	}
+	return removed, nil
+}
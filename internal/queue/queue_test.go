@@ -0,0 +1,143 @@
+package queue
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var queueNow = time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+
+func TestValidateStages(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		stages  []string
+		want    []string
+		wantErr string
+	}{
+		"workflow order": {stages: []string{"implement", "plan", "tasks"}, want: []string{"plan", "tasks", "implement"}},
+		"duplicates":     {stages: []string{"plan", "plan"}, want: []string{"plan"}},
+		"none":           {stages: nil, want: nil},
+		"specify":        {stages: []string{"specify"}, wantErr: `cannot queue stage "specify"`},
+		"unknown":        {stages: []string{"plan", "deploy"}, wantErr: `cannot queue stage "deploy"`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ValidateStages(tt.stages)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAddListRemove(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	first, err := Add(stateDir, "003-auth", []string{"plan", "tasks"}, queueNow)
+	require.NoError(t, err)
+	second, err := Add(stateDir, "004-search", nil, queueNow.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.ID)
+	assert.Equal(t, 2, second.ID)
+	assert.Equal(t, "plan,tasks", first.StagesLabel())
+	assert.Equal(t, "remaining", second.StagesLabel())
+
+	// An item file still being written by a concurrent Add is skipped
+	require.NoError(t, os.WriteFile(itemPath(stateDir, 3), nil, 0o644))
+	third, err := Add(stateDir, "005-export", []string{"implement"}, queueNow)
+	require.NoError(t, err)
+	assert.Equal(t, 4, third.ID)
+
+	items, err := List(stateDir)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, []int{1, 2, 4}, []int{items[0].ID, items[1].ID, items[2].ID})
+	assert.Equal(t, StatusPending, items[0].Status)
+
+	require.NoError(t, Remove(stateDir, 2))
+	assert.ErrorContains(t, Remove(stateDir, 2), "queue item 2 not found")
+
+	third.Status = StatusRunning
+	require.NoError(t, Save(stateDir, third))
+	assert.ErrorContains(t, Remove(stateDir, 4), "is running")
+}
+
+func TestDeferRetryPending(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	_, err := Add(stateDir, "003-auth", nil, queueNow)
+	require.NoError(t, err)
+	deferred, err := Defer(stateDir, "004-search", []string{"plan"}, "agent claude could not reach its provider", queueNow)
+	require.NoError(t, err)
+	assert.Equal(t, 2, deferred.ID)
+	assert.Equal(t, StatusDeferred, deferred.Status)
+
+	loaded, err := Load(stateDir, deferred.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "agent claude could not reach its provider", loaded.Error)
+	assert.False(t, loaded.Finished())
+
+	retried, err := RetryPending(stateDir)
+	require.NoError(t, err)
+	require.Len(t, retried, 1)
+	assert.Equal(t, deferred.ID, retried[0].ID)
+
+	loaded, err = Load(stateDir, deferred.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, loaded.Status)
+	assert.Empty(t, loaded.Error)
+
+	retried, err = RetryPending(stateDir)
+	require.NoError(t, err)
+	assert.Empty(t, retried)
+}
+
+func TestClear(t *testing.T) {
+	t.Parallel()
+
+	statuses := []Status{StatusCompleted, StatusFailed, StatusPending, StatusRunning, StatusDeferred}
+	tests := map[string]struct {
+		all         bool
+		wantRemoved int
+		wantLeft    []Status
+	}{
+		"finished only":   {wantRemoved: 2, wantLeft: []Status{StatusPending, StatusRunning, StatusDeferred}},
+		"pending as well": {all: true, wantRemoved: 4, wantLeft: []Status{StatusRunning}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			stateDir := t.TempDir()
+			for _, status := range statuses {
+				item, err := Add(stateDir, "003-auth", nil, queueNow)
+				require.NoError(t, err)
+				item.Status = status
+				require.NoError(t, Save(stateDir, item))
+			}
+
+			removed, err := Clear(stateDir, tt.all)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemoved, removed)
+			items, err := List(stateDir)
+			require.NoError(t, err)
+			var left []Status
+			for _, item := range items {
+				left = append(left, item.Status)
+			}
+			assert.Equal(t, tt.wantLeft, left)
+		})
+	}
+}
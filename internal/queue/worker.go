@@ -0,0 +1,240 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/batch"
+	"github.com/ariel-frischer/autospec/internal/filelock"
+)
+
+// workerLockName is the file holding the pid of the running worker.
+const workerLockName = "worker.lock"
+
+// Runner executes a single queue item. Implemented by batch.Runner.
+type Runner interface {
+	RunJob(ctx context.Context, job batch.Job) batch.Result
+}
+
+// Worker processes the queue. Only one worker runs per state directory, so
+// two agents never work in the same tree at once.
+type Worker struct {
+	StateDir string
+	SpecsDir string
+	Runner   Runner
+	Out      io.Writer
+	// Workers is how many items run at once. With more than one, the Runner
+	// must isolate each spec in its own worktree.
+	Workers int
+	// Wait keeps the worker polling for new items once the queue is empty,
+	// until ctx is cancelled.
+	Wait         bool
+	PollInterval time.Duration
+
+	mu   sync.Mutex
+	busy map[string]bool // Specs with an item running
+}
+
+// Run takes pending items in order until none are left (or, with Wait,
+// until ctx is cancelled) and returns how many failed. Items of the same
+// spec never run at the same time. An item interrupted by cancelling ctx
+// goes back to pending.
+func (w *Worker) Run(ctx context.Context) (int, error) {
+	release, err := acquireWorkerLock(w.StateDir)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+	if err := resetInterrupted(w.StateDir); err != nil {
+		return 0, err
+	}
+
+	w.busy = make(map[string]bool)
+	var failed int
+	var wg sync.WaitGroup
+	for range max(w.Workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				item, more, err := w.claim(ctx)
+				if err != nil {
+					fmt.Fprintf(w.Out, "Warning: %v\n", err)
+					return
+				}
+				if item == nil {
+					if !more && !w.Wait {
+						return
+					}
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(w.PollInterval):
+					}
+					continue
+				}
+				if !w.process(ctx, item) {
+					w.mu.Lock()
+					failed++
+					w.mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return failed, nil
+}
+
+// claim marks the next pending item whose spec is not busy as running. When
+// there is none, more reports whether pending items are waiting on a busy
+// spec.
+func (w *Worker) claim(ctx context.Context) (item *Item, more bool, err error) {
+	if ctx.Err() != nil {
+		return nil, false, nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	items, err := List(w.StateDir)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, it := range items {
+		if it.Status != StatusPending {
+			continue
+		}
+		if w.busy[it.Spec] {
+			more = true
+			continue
+		}
+		now := time.Now()
+		it.Status = StatusRunning
+		it.StartedAt = &now
+		if err := Save(w.StateDir, it); err != nil {
+			return nil, false, err
+		}
+		w.busy[it.Spec] = true
+		return it, true, nil
+	}
+	return nil, more, nil
+}
+
+// process runs a claimed item and records its outcome. It reports whether
+// the item succeeded or was put back after an interruption.
+func (w *Worker) process(ctx context.Context, item *Item) bool {
+	defer func() {
+		w.mu.Lock()
+		delete(w.busy, item.Spec)
+		w.mu.Unlock()
+	}()
+
+	fmt.Fprintf(w.Out, "\n=== [queue #%d] %s (%s) ===\n", item.ID, item.Spec, item.StagesLabel())
+	result := w.run(ctx, item)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if ctx.Err() != nil {
+		item.Status = StatusPending
+		item.StartedAt = nil
+		_ = Save(w.StateDir, item)
+		fmt.Fprintf(w.Out, "Interrupted; queue #%d %s is pending again\n", item.ID, item.Spec)
+		return true
+	}
+
+	now := time.Now()
+	item.FinishedAt = &now
+	item.Retries = result.Retries
+	item.LogPath = result.LogPath
+	item.Worktree = result.Worktree
+	item.Status = StatusCompleted
+	item.Error = ""
+	if !result.Success {
+		item.Status = StatusFailed
+		item.Error = result.Err.Error()
+	}
+	if err := Save(w.StateDir, item); err != nil {
+		fmt.Fprintf(w.Out, "Warning: %v\n", err)
+	}
+	if result.Success {
+		fmt.Fprintf(w.Out, "✓ queue #%d %s completed in %s\n", item.ID, item.Spec, result.Duration)
+	} else {
+		fmt.Fprintf(w.Out, "✗ queue #%d %s failed after %s: %v\n", item.ID, item.Spec, result.Duration, result.Err)
+	}
+	return result.Success
+}
+
+// run resolves the item's spec directory and runs it.
+func (w *Worker) run(ctx context.Context, item *Item) batch.Result {
+	dir := filepath.Join(w.SpecsDir, item.Spec)
+	if _, err := os.Stat(dir); err != nil {
+		return batch.Result{Spec: item.Spec, Err: fmt.Errorf("spec directory %s not found", dir)}
+	}
+	return w.Runner.RunJob(ctx, batch.Job{Spec: item.Spec, Dir: dir, Stages: slices.Clone(item.Stages)})
+}
+
+// resetInterrupted puts items left running by a worker that was killed back
+// to pending. It is only called while holding the worker lock.
+func resetInterrupted(stateDir string) error {
+	items, err := List(stateDir)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if item.Status == StatusRunning {
+			item.Status = StatusPending
+			item.StartedAt = nil
+			if err := Save(stateDir, item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// acquireWorkerLock records this process as the queue's worker. A lock left
+// by a process that no longer exists is taken over.
+func acquireWorkerLock(stateDir string) (func(), error) {
+	if err := os.MkdirAll(Dir(stateDir), 0o755); err != nil {
+		return nil, fmt.Errorf("creating queue directory: %w", err)
+	}
+	path := filepath.Join(Dir(stateDir), workerLockName)
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			fmt.Fprintf(f, "%d\n", os.Getpid())
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("creating worker lock: %w", err)
+		}
+		if pid := WorkerPID(stateDir); pid > 0 {
+			return nil, fmt.Errorf("another queue worker is running (pid %d)", pid)
+		}
+		os.Remove(path)
+	}
+	return nil, fmt.Errorf("another queue worker started at the same time")
+}
+
+// WorkerPID returns the pid of the running queue worker, or 0 when there is
+// none.
+func WorkerPID(stateDir string) int {
+	data, err := os.ReadFile(filepath.Join(Dir(stateDir), workerLockName))
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 || !filelock.ProcessAlive(pid) {
+		return 0
+	}
+	return pid
+}
@@ -0,0 +1,215 @@
+package queue
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/batch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRunner records the jobs it runs and fails specs listed in failures.
+type fakeRunner struct {
+	mu       sync.Mutex
+	jobs     []batch.Job
+	running  map[string]int // Concurrent jobs per spec
+	overlap  bool           // Set when two jobs of a spec ran at once
+	failures map[string]bool
+	onRun    func(job batch.Job)
+}
+
+func (f *fakeRunner) RunJob(_ context.Context, job batch.Job) batch.Result {
+	f.mu.Lock()
+	f.jobs = append(f.jobs, job)
+	if f.running == nil {
+		f.running = make(map[string]int)
+	}
+	f.running[job.Spec]++
+	if f.running[job.Spec] > 1 {
+		f.overlap = true
+	}
+	f.mu.Unlock()
+
+	if f.onRun != nil {
+		f.onRun(job)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	f.mu.Lock()
+	f.running[job.Spec]--
+	f.mu.Unlock()
+	result := batch.Result{Spec: job.Spec, Success: !f.failures[job.Spec], Retries: 1}
+	if !result.Success {
+		result.Err = errors.New("exit status 1")
+	}
+	return result
+}
+
+// newTestWorker creates specs and a worker over a fresh state directory.
+func newTestWorker(t *testing.T, runner Runner, specs ...string) *Worker {
+	t.Helper()
+	specsDir := t.TempDir()
+	for _, spec := range specs {
+		require.NoError(t, os.MkdirAll(filepath.Join(specsDir, spec), 0o755))
+	}
+	return &Worker{
+		StateDir:     t.TempDir(),
+		SpecsDir:     specsDir,
+		Runner:       runner,
+		Out:          &bytes.Buffer{},
+		Workers:      1,
+		PollInterval: time.Millisecond,
+	}
+}
+
+func TestWorker_RunsItemsInOrder(t *testing.T) {
+	t.Parallel()
+
+	runner := &fakeRunner{failures: map[string]bool{"004-search": true}}
+	w := newTestWorker(t, runner, "003-auth", "004-search")
+	_, err := Add(w.StateDir, "003-auth", []string{"plan"}, queueNow)
+	require.NoError(t, err)
+	_, err = Add(w.StateDir, "004-search", []string{"implement"}, queueNow)
+	require.NoError(t, err)
+	_, err = Add(w.StateDir, "009-missing", nil, queueNow)
+	require.NoError(t, err)
+
+	failed, err := w.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, failed)
+
+	require.Len(t, runner.jobs, 2)
+	assert.Equal(t, "003-auth", runner.jobs[0].Spec)
+	assert.Equal(t, []string{"plan"}, runner.jobs[0].Stages)
+	assert.Equal(t, "004-search", runner.jobs[1].Spec)
+
+	items, err := List(w.StateDir)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, items[0].Status)
+	assert.Equal(t, 1, items[0].Retries)
+	assert.NotNil(t, items[0].FinishedAt)
+	assert.Equal(t, StatusFailed, items[1].Status)
+	assert.Equal(t, "exit status 1", items[1].Error)
+	assert.Equal(t, StatusFailed, items[2].Status)
+	assert.Contains(t, items[2].Error, "not found")
+
+	out := w.Out.(*bytes.Buffer).String()
+	assert.Contains(t, out, "=== [queue #1] 003-auth (plan) ===")
+	assert.Contains(t, out, "✗ queue #2 004-search failed")
+	assert.NoFileExists(t, filepath.Join(Dir(w.StateDir), workerLockName))
+}
+
+func TestWorker_SkipsDeferredItems(t *testing.T) {
+	t.Parallel()
+
+	runner := &fakeRunner{}
+	w := newTestWorker(t, runner, "003-auth", "004-search")
+	_, err := Defer(w.StateDir, "003-auth", []string{"plan"}, "agent claude could not reach its provider", queueNow)
+	require.NoError(t, err)
+	_, err = Add(w.StateDir, "004-search", nil, queueNow)
+	require.NoError(t, err)
+
+	_, err = w.Run(context.Background())
+	require.NoError(t, err)
+	require.Len(t, runner.jobs, 1)
+	assert.Equal(t, "004-search", runner.jobs[0].Spec)
+
+	item, err := Load(w.StateDir, 1)
+	require.NoError(t, err)
+	assert.Equal(t, StatusDeferred, item.Status)
+}
+
+func TestWorker_ParallelNeverOverlapsASpec(t *testing.T) {
+	t.Parallel()
+
+	runner := &fakeRunner{}
+	w := newTestWorker(t, runner, "003-auth", "004-search", "005-export")
+	w.Workers = 3
+	for _, spec := range []string{"003-auth", "003-auth", "004-search", "003-auth", "005-export"} {
+		_, err := Add(w.StateDir, spec, nil, queueNow)
+		require.NoError(t, err)
+	}
+
+	failed, err := w.Run(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, failed)
+	assert.Len(t, runner.jobs, 5)
+	assert.False(t, runner.overlap, "two items of one spec ran at once")
+}
+
+func TestWorker_WaitPicksUpNewItems(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runner := &fakeRunner{}
+	w := newTestWorker(t, runner, "003-auth", "004-search")
+	w.Wait = true
+	runner.onRun = func(job batch.Job) {
+		if job.Spec == "004-search" {
+			cancel()
+		}
+	}
+	_, err := Add(w.StateDir, "003-auth", nil, queueNow)
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := w.Run(ctx)
+		done <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	_, err = Add(w.StateDir, "004-search", nil, queueNow)
+	require.NoError(t, err)
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("worker did not stop after cancel")
+	}
+	items, err := List(w.StateDir)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, items[0].Status)
+	// Interrupted items go back to pending
+	assert.Equal(t, StatusPending, items[1].Status)
+	assert.Nil(t, items[1].StartedAt)
+}
+
+func TestWorker_SingleWorker(t *testing.T) {
+	t.Parallel()
+
+	w := newTestWorker(t, &fakeRunner{})
+	require.NoError(t, os.MkdirAll(Dir(w.StateDir), 0o755))
+	lock := filepath.Join(Dir(w.StateDir), workerLockName)
+
+	// A live worker holds the queue
+	require.NoError(t, os.WriteFile(lock, []byte(fmt.Sprintf("%d\n", os.Getpid())), 0o644))
+	_, err := w.Run(context.Background())
+	assert.ErrorContains(t, err, fmt.Sprintf("another queue worker is running (pid %d)", os.Getpid()))
+	assert.Equal(t, os.Getpid(), WorkerPID(w.StateDir))
+
+	// A lock left by a dead worker is taken over, and its item reset
+	require.NoError(t, os.WriteFile(lock, []byte("1073741824\n"), 0o644))
+	item, err := Add(w.StateDir, "003-auth", nil, queueNow)
+	require.NoError(t, err)
+	item.Status = StatusRunning
+	require.NoError(t, Save(w.StateDir, item))
+	require.NoError(t, os.MkdirAll(filepath.Join(w.SpecsDir, "003-auth"), 0o755))
+
+	failed, err := w.Run(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, failed)
+	loaded, err := Load(w.StateDir, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, loaded.Status)
+	assert.Zero(t, WorkerPID(w.StateDir))
+}
@@ -0,0 +1,141 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlHeadingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	htmlListPattern     = regexp.MustCompile(`^[-*]\s+(.*)$`)
+	htmlCheckboxPattern = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	htmlCodePattern     = regexp.MustCompile("`([^`]+)`")
+	htmlStrongPattern   = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	htmlEmPattern       = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+)
+
+// HTML converts rendered markdown into a standalone HTML page titled title.
+// It understands the subset the templates produce: headings, paragraphs,
+// block quotes, bullet and checkbox lists, fenced code, and bold, italic and
+// code spans.
+func HTML(title, markdown string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, pageHeader, html.EscapeString(title))
+
+	var paragraph []string
+	inList, inCode := false, false
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			fmt.Fprintf(&b, "<p>%s</p>\n", inlineHTML(strings.Join(paragraph, " ")))
+			paragraph = nil
+		}
+	}
+	closeList := func() {
+		if inList {
+			b.WriteString("</ul>\n")
+			inList = false
+		}
+	}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		if strings.HasPrefix(line, "```") {
+			flushParagraph()
+			closeList()
+			if inCode {
+				b.WriteString("</code></pre>\n")
+			} else {
+				b.WriteString("<pre><code>")
+			}
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			b.WriteString(html.EscapeString(line) + "\n")
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch m := htmlHeadingPattern.FindStringSubmatch(trimmed); {
+		case trimmed == "":
+			flushParagraph()
+			closeList()
+		case m != nil:
+			flushParagraph()
+			closeList()
+			level := len(m[1])
+			fmt.Fprintf(&b, "<h%d>%s</h%d>\n", level, inlineHTML(m[2]), level)
+		case htmlListPattern.MatchString(trimmed):
+			flushParagraph()
+			if !inList {
+				b.WriteString("<ul>\n")
+				inList = true
+			}
+			item := htmlListPattern.FindStringSubmatch(trimmed)[1]
+			if c := htmlCheckboxPattern.FindStringSubmatch(item); c != nil {
+				checked := ""
+				if c[1] != " " {
+					checked = " checked"
+				}
+				fmt.Fprintf(&b, "<li class=\"task\"><input type=\"checkbox\" disabled%s> %s</li>\n", checked, inlineHTML(c[2]))
+			} else {
+				fmt.Fprintf(&b, "<li>%s</li>\n", inlineHTML(item))
+			}
+		case strings.HasPrefix(trimmed, ">"):
+			flushParagraph()
+			closeList()
+			fmt.Fprintf(&b, "<blockquote>%s</blockquote>\n", inlineHTML(strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))))
+		default:
+			closeList()
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flushParagraph()
+	closeList()
+	if inCode {
+		b.WriteString("</code></pre>\n")
+	}
+	b.WriteString(pageFooter)
+	return b.String()
+}
+
+// inlineHTML escapes text and converts code, bold and italic spans.
+func inlineHTML(text string) string {
+	// Code spans first, so their content isn't treated as emphasis
+	var codes []string
+	text = htmlCodePattern.ReplaceAllStringFunc(text, func(s string) string {
+		codes = append(codes, "<code>"+html.EscapeString(s[1:len(s)-1])+"</code>")
+		return fmt.Sprintf("\x00%d\x00", len(codes)-1)
+	})
+	text = html.EscapeString(text)
+	text = htmlStrongPattern.ReplaceAllString(text, "<strong>$1</strong>")
+	text = htmlEmPattern.ReplaceAllString(text, "<em>$1</em>")
+	for i, code := range codes {
+		text = strings.Replace(text, fmt.Sprintf("\x00%d\x00", i), code, 1)
+	}
+	return text
+}
+
+const pageHeader = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>%s</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; line-height: 1.5; color: #1f2328; max-width: 52rem; margin: 2rem auto; padding: 0 1rem; }
+h1, h2 { border-bottom: 1px solid #d1d9e0; padding-bottom: .3em; }
+code { background: #f6f8fa; padding: .1em .3em; border-radius: 4px; }
+pre { background: #f6f8fa; padding: 1em; overflow: auto; }
+pre code { padding: 0; }
+blockquote { color: #59636e; border-left: .25em solid #d1d9e0; margin: 0; padding: 0 1em; }
+li.task { list-style: none; margin-left: -1.3em; }
+</style>
+</head>
+<body>
+`
+
+const pageFooter = `</body>
+</html>
+`
@@ -0,0 +1,164 @@
+// Package render turns spec artifacts (spec.yaml, plan.yaml, tasks.yaml)
+// into human-readable markdown or HTML for readers who don't use the CLI.
+// Related: internal/cli/util/render.go
+// Tags: render, markdown, html, templates, artifacts
+//
+// Each artifact is rendered with a text/template that receives the parsed
+// YAML document as a map, so templates address fields by their YAML names
+// ({{ .feature.branch }}). Built-in templates are embedded; a project
+// overrides one by adding <TemplatesDir>/<artifact>.md.tmpl. HTML output
+// converts the rendered markdown and wraps it in a standalone page.
+package render
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed templates/*.md.tmpl
+var builtinTemplates embed.FS
+
+// Artifacts lists the artifacts that can be rendered, in document order.
+var Artifacts = []string{"spec", "plan", "tasks"}
+
+// TemplatesDir returns the directory of project render templates.
+func TemplatesDir() string {
+	return filepath.Join(".autospec", "render")
+}
+
+// IsArtifact reports whether name is one of Artifacts.
+func IsArtifact(name string) bool {
+	return slices.Contains(Artifacts, name)
+}
+
+// Markdown renders an artifact's YAML content as markdown, using the project
+// template in templatesDir when one exists. templatesDir may be empty.
+func Markdown(artifact string, data []byte, templatesDir string) (string, error) {
+	if !IsArtifact(artifact) {
+		return "", fmt.Errorf("unknown artifact %q: must be one of %s", artifact, strings.Join(Artifacts, ", "))
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", fmt.Errorf("parsing %s.yaml: %w", artifact, err)
+	}
+	if doc == nil {
+		doc = map[string]any{}
+	}
+
+	name, content, err := loadTemplate(artifact, templatesDir)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := template.New(name).Funcs(funcs).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("parsing render template %s: %w", name, err)
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, doc); err != nil {
+		return "", fmt.Errorf("rendering %s: %w", name, err)
+	}
+	return tidy(out.String()), nil
+}
+
+// loadTemplate returns the name and content of the template for artifact.
+func loadTemplate(artifact, templatesDir string) (string, string, error) {
+	file := artifact + ".md.tmpl"
+	if templatesDir != "" {
+		path := filepath.Join(templatesDir, file)
+		content, err := os.ReadFile(path)
+		if err == nil {
+			return path, string(content), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", "", fmt.Errorf("reading render template: %w", err)
+		}
+	}
+	content, err := builtinTemplates.ReadFile("templates/" + file)
+	if err != nil {
+		return "", "", fmt.Errorf("reading built-in template %s: %w", file, err)
+	}
+	return file, string(content), nil
+}
+
+// tidy collapses the runs of blank lines that template actions leave behind.
+func tidy(s string) string {
+	lines := strings.Split(s, "\n")
+	out := make([]string, 0, len(lines))
+	blank := true
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			if blank {
+				continue
+			}
+			blank = true
+		} else {
+			blank = false
+		}
+		out = append(out, line)
+	}
+	return strings.TrimRight(strings.Join(out, "\n"), "\n") + "\n"
+}
+
+// funcs are the helpers available to render templates.
+var funcs = template.FuncMap{
+	// join joins a list's items with sep
+	"join": func(sep string, list any) string {
+		items, _ := list.([]any)
+		parts := make([]string, 0, len(items))
+		for _, item := range items {
+			parts = append(parts, fmt.Sprint(item))
+		}
+		return strings.Join(parts, sep)
+	},
+	// inline flattens a multi-line value onto one line
+	"inline": func(v any) string {
+		if v == nil {
+			return ""
+		}
+		return strings.Join(strings.Fields(fmt.Sprint(v)), " ")
+	},
+	// checkbox returns "x" for a completed task status and " " otherwise
+	"checkbox": func(status any) string {
+		if isDone(status) {
+			return "x"
+		}
+		return " "
+	},
+	// progress returns "done/total" for the tasks in a tasks.yaml phases list
+	"progress": func(phases any) string {
+		done, total := 0, 0
+		list, _ := phases.([]any)
+		for _, p := range list {
+			phase, _ := p.(map[string]any)
+			tasks, _ := phase["tasks"].([]any)
+			for _, t := range tasks {
+				task, _ := t.(map[string]any)
+				total++
+				if isDone(task["status"]) {
+					done++
+				}
+			}
+		}
+		return fmt.Sprintf("%d/%d", done, total)
+	},
+}
+
+// isDone reports whether a task status counts as completed.
+func isDone(status any) bool {
+	switch strings.ToLower(fmt.Sprint(status)) {
+	case "completed", "done", "complete":
+		return true
+	}
+	return false
+}
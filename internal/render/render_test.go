@@ -0,0 +1,149 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdown_BuiltinTemplates(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		want []string
+	}{
+		"spec": {
+			want: []string{
+				"# Specification: 001-example-feature",
+				"### US-001: User can log in with email and password (P1)",
+				"- **Given** I have a registered account, **when** I submit valid credentials",
+				"- **FR-002**: MUST hash passwords before storage",
+				"## Out of Scope",
+			},
+		},
+		"plan": {
+			want: []string{
+				"# Implementation Plan: 001-example-feature",
+				"- **Language**: Go 1.25.1",
+				"### Phase 2: Core Authentication",
+				"- `POST /api/v1/auth/login` — Authenticate user",
+			},
+		},
+		"tasks": {
+			want: []string{
+				"# Tasks: 001-example-feature",
+				"**Progress**: 0/6 tasks completed",
+				"- [ ] **T001** Create user model and database migration",
+			},
+		},
+	}
+	for artifact, tt := range tests {
+		t.Run(artifact, func(t *testing.T) {
+			t.Parallel()
+			data, err := os.ReadFile(filepath.Join("..", "validation", "testdata", artifact, "valid.yaml"))
+			require.NoError(t, err)
+
+			md, err := Markdown(artifact, data, "")
+			require.NoError(t, err)
+			for _, want := range tt.want {
+				assert.Contains(t, md, want)
+			}
+			assert.NotContains(t, md, "<no value>")
+			assert.NotContains(t, md, "\n\n\n")
+		})
+	}
+}
+
+func TestMarkdown_TaskStates(t *testing.T) {
+	t.Parallel()
+
+	md, err := Markdown("tasks", []byte(`phases:
+  - number: 1
+    title: Setup
+    tasks:
+      - id: T001
+        title: Create module
+        status: Completed
+      - id: T002
+        title: Wire OAuth
+        status: Blocked
+        blocked_reason: Needs client secret
+      - id: T003
+`), "")
+	require.NoError(t, err)
+	assert.Contains(t, md, "**Progress**: 1/3 tasks completed")
+	assert.Contains(t, md, "- [x] **T001** Create module\n")
+	assert.Contains(t, md, "- [ ] **T002** Wire OAuth *(Blocked: Needs client secret)*")
+	assert.Contains(t, md, "- [ ] **T003**\n")
+}
+
+func TestMarkdown_ProjectTemplate(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "spec.md.tmpl"),
+		[]byte(`# {{ .feature.branch }} for {{ join ", " .stakeholders }}`), 0o644))
+
+	md, err := Markdown("spec", []byte("feature:\n  branch: 003-auth\nstakeholders: [Ops, Legal]\n"), dir)
+	require.NoError(t, err)
+	assert.Equal(t, "# 003-auth for Ops, Legal\n", md)
+
+	// Artifacts without a project template fall back to the built-in one
+	md, err = Markdown("plan", []byte("plan:\n  branch: 003-auth\n"), dir)
+	require.NoError(t, err)
+	assert.Contains(t, md, "# Implementation Plan: 003-auth")
+}
+
+func TestMarkdown_Errors(t *testing.T) {
+	t.Parallel()
+	badTemplates := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(badTemplates, "tasks.md.tmpl"), []byte("{{ .phases"), 0o644))
+
+	tests := map[string]struct {
+		artifact     string
+		data         string
+		templatesDir string
+		wantErr      string
+	}{
+		"unknown artifact": {
+			artifact: "checklist",
+			wantErr:  "unknown artifact",
+		},
+		"invalid yaml": {
+			artifact: "spec",
+			data:     "feature: [",
+			wantErr:  "parsing spec.yaml",
+		},
+		"broken project template": {
+			artifact:     "tasks",
+			data:         "phases: []",
+			templatesDir: badTemplates,
+			wantErr:      "parsing render template",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Markdown(tt.artifact, []byte(tt.data), tt.templatesDir)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestHTML(t *testing.T) {
+	t.Parallel()
+
+	page := HTML("Spec <003>", "# Tasks: 003-auth\n\n> Add `login` & logout\n\n- [x] **T001** Create *module*\n- [ ] T002 Wire OAuth\n\nSome\ntext\n\n```\n<b>raw</b>\n```\n")
+
+	assert.Contains(t, page, "<title>Spec &lt;003&gt;</title>")
+	assert.Contains(t, page, "<h1>Tasks: 003-auth</h1>")
+	assert.Contains(t, page, "<blockquote>Add <code>login</code> &amp; logout</blockquote>")
+	assert.Contains(t, page, `<li class="task"><input type="checkbox" disabled checked> <strong>T001</strong> Create <em>module</em></li>`)
+	assert.Contains(t, page, `<li class="task"><input type="checkbox" disabled> T002 Wire OAuth</li>`)
+	assert.Contains(t, page, "<p>Some text</p>")
+	assert.Contains(t, page, "<pre><code>&lt;b&gt;raw&lt;/b&gt;\n</code></pre>")
+	assert.Contains(t, page, "</ul>\n<p>")
+}
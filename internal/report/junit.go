@@ -0,0 +1,225 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+// JUnit collects validation results and writes them as JUnit XML. It is safe
+// for concurrent use, since parallel implement runs phases concurrently.
+type JUnit struct {
+	mu      sync.Mutex
+	results []ValidationResult
+}
+
+// RecordValidation adds a result to the report.
+func (j *JUnit) RecordValidation(result ValidationResult) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.results = append(j.results, result)
+}
+
+// Write writes the report to path, creating its directory if needed.
+// Each reported spec's tasks.yaml under specsDir is read for the task suite.
+func (j *JUnit) Write(path, specsDir string) error {
+	j.mu.Lock()
+	suites := buildSuites(j.results, specsDir)
+	j.mu.Unlock()
+
+	doc := junitTestSuites{Name: "autospec", Suites: suites}
+	for _, suite := range suites {
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Errors += suite.Errors
+		doc.Skipped += suite.Skipped
+		doc.seconds += suite.seconds
+	}
+	doc.Time = formatSeconds(doc.seconds)
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding JUnit report: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating report directory: %w", err)
+	}
+	if err := os.WriteFile(path, append([]byte(xml.Header), append(data, '\n')...), 0o644); err != nil {
+		return fmt.Errorf("writing JUnit report: %w", err)
+	}
+	return nil
+}
+
+// buildSuites groups results by spec, in the order specs were first seen.
+func buildSuites(results []ValidationResult, specsDir string) []junitTestSuite {
+	var specs []string
+	bySpec := make(map[string][]ValidationResult)
+	for _, result := range results {
+		if _, seen := bySpec[result.Spec]; !seen {
+			specs = append(specs, result.Spec)
+		}
+		bySpec[result.Spec] = append(bySpec[result.Spec], result)
+	}
+
+	var suites []junitTestSuite
+	for _, spec := range specs {
+		suites = append(suites, stageSuite(spec, bySpec[spec]))
+		if spec == "" {
+			continue
+		}
+		if suite, ok := taskSuite(spec, filepath.Join(specsDir, spec)); ok {
+			suites = append(suites, suite)
+		}
+	}
+	return suites
+}
+
+// stageSuite has one test case per stage session. Retries of a session are
+// folded into its test case: the last attempt decides the outcome, and
+// earlier failures are listed in its output.
+func stageSuite(spec string, results []ValidationResult) junitTestSuite {
+	suite := junitTestSuite{Name: suiteName(spec, "stages")}
+	var order []string
+	attempts := make(map[string][]ValidationResult)
+	for _, result := range results {
+		name := result.name()
+		if _, seen := attempts[name]; !seen {
+			order = append(order, name)
+		}
+		attempts[name] = append(attempts[name], result)
+	}
+
+	for _, name := range order {
+		runs := attempts[name]
+		last := runs[len(runs)-1]
+		tc := junitTestCase{Name: name, ClassName: suite.Name}
+		var elapsed time.Duration
+		var earlier []string
+		for i, attempt := range runs {
+			elapsed += attempt.Duration
+			if i < len(runs)-1 {
+				earlier = append(earlier, fmt.Sprintf("attempt %d: %s", attempt.Attempt, attempt.Message))
+			}
+		}
+		switch {
+		case last.Passed:
+		case last.Execution:
+			tc.Error = &junitMessage{Message: firstLine(last.Message), Type: "execution", Text: last.Message}
+			suite.Errors++
+		default:
+			tc.Failure = &junitMessage{Message: firstLine(last.Message), Type: "validation", Text: last.Message}
+			suite.Failures++
+		}
+		if len(earlier) > 0 {
+			tc.SystemOut = fmt.Sprintf("%d attempts; earlier attempts failed:\n%s", len(runs), strings.Join(earlier, "\n"))
+		}
+		tc.Time = formatSeconds(elapsed)
+		suite.seconds += elapsed
+		suite.Cases = append(suite.Cases, tc)
+	}
+	suite.Tests = len(suite.Cases)
+	suite.Time = formatSeconds(suite.seconds)
+	return suite
+}
+
+// taskSuite has one test case per task: completed tasks pass, blocked tasks
+// fail with their reason, and unfinished tasks are skipped. It reports false
+// when the spec has no readable tasks.yaml.
+func taskSuite(spec, specDir string) (junitTestSuite, bool) {
+	tasksFile, err := validation.ParseTasksYAML(validation.GetTasksFilePath(specDir))
+	if err != nil {
+		return junitTestSuite{}, false
+	}
+	suite := junitTestSuite{Name: suiteName(spec, "tasks"), Time: formatSeconds(0)}
+	for _, phase := range tasksFile.Phases {
+		for _, task := range phase.Tasks {
+			tc := junitTestCase{
+				Name:      fmt.Sprintf("%s %s", task.ID, task.Title),
+				ClassName: fmt.Sprintf("%s.phase%d", suite.Name, phase.Number),
+				Time:      formatSeconds(0),
+			}
+			switch {
+			case task.IsCompleted():
+			case strings.EqualFold(task.Status, "Blocked"):
+				tc.Failure = &junitMessage{Message: "blocked: " + task.BlockedReason, Type: "blocked"}
+				suite.Failures++
+			case task.IsSkipped():
+				tc.Skipped = &junitMessage{Message: "skipped: " + task.SkipReason}
+				suite.Skipped++
+			default:
+				tc.Skipped = &junitMessage{Message: "status: " + task.Status}
+				suite.Skipped++
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+	}
+	suite.Tests = len(suite.Cases)
+	return suite, true
+}
+
+// suiteName names a spec's suite, e.g. "autospec.001-auth.stages". Specify
+// runs before its spec exists, so its results may have no spec name.
+func suiteName(spec, kind string) string {
+	if spec == "" {
+		return "autospec." + kind
+	}
+	return fmt.Sprintf("autospec.%s.%s", spec, kind)
+}
+
+// firstLine returns the first line of a possibly multi-line message.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+// formatSeconds formats a duration the way JUnit expects: fractional seconds.
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+
+	seconds time.Duration
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+
+	seconds time.Duration
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
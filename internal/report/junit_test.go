@@ -0,0 +1,123 @@
+package report
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tasksYAML = `phases:
+  - number: 1
+    title: "Setup"
+    tasks:
+      - id: T001
+        title: "Add login"
+        status: Completed
+      - id: T002
+        title: "Wire OAuth"
+        status: Blocked
+        blocked_reason: "Needs client secret"
+      - id: T003
+        title: "Add logout"
+        status: Pending
+`
+
+// readReport writes the report and parses it back.
+func readReport(t *testing.T, junit *JUnit, specsDir string) junitTestSuites {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "reports", "autospec.xml")
+	require.NoError(t, junit.Write(path, specsDir))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `<?xml version="1.0" encoding="UTF-8"?>`)
+
+	var doc junitTestSuites
+	require.NoError(t, xml.Unmarshal(data, &doc))
+	return doc
+}
+
+func TestJUnit_Write(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	specDir := filepath.Join(specsDir, "001-auth")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "tasks.yaml"), []byte(tasksYAML), 0o644))
+
+	junit := &JUnit{}
+	junit.RecordValidation(ValidationResult{Spec: "001-auth", Stage: "plan", Attempt: 1, Passed: true, Duration: 2 * time.Second})
+	junit.RecordValidation(ValidationResult{Spec: "001-auth", Stage: "implement", Phase: 1, Attempt: 1, Message: "phase 1 has incomplete tasks\n- T003", Duration: time.Second})
+	junit.RecordValidation(ValidationResult{Spec: "001-auth", Stage: "implement", Phase: 1, Attempt: 2, Message: "phase 1 has incomplete tasks\n- T003", Duration: time.Second})
+	junit.RecordValidation(ValidationResult{Spec: "001-auth", Stage: "implement", TaskID: "T002", Attempt: 1, Execution: true, Message: "agent crashed"})
+
+	doc := readReport(t, junit, specsDir)
+
+	assert.Equal(t, "autospec", doc.Name)
+	assert.Equal(t, 6, doc.Tests)
+	assert.Equal(t, 2, doc.Failures)
+	assert.Equal(t, 1, doc.Errors)
+	assert.Equal(t, 1, doc.Skipped)
+	assert.Equal(t, "4.000", doc.Time)
+	require.Len(t, doc.Suites, 2)
+
+	stages := doc.Suites[0]
+	assert.Equal(t, "autospec.001-auth.stages", stages.Name)
+	require.Len(t, stages.Cases, 3)
+	assert.Equal(t, "plan", stages.Cases[0].Name)
+	assert.Nil(t, stages.Cases[0].Failure)
+	assert.Equal(t, "2.000", stages.Cases[0].Time)
+
+	phase := stages.Cases[1]
+	assert.Equal(t, "implement phase 1", phase.Name)
+	require.NotNil(t, phase.Failure)
+	assert.Equal(t, "phase 1 has incomplete tasks", phase.Failure.Message)
+	assert.Equal(t, "validation", phase.Failure.Type)
+	assert.Contains(t, phase.Failure.Text, "- T003")
+	assert.Contains(t, phase.SystemOut, "2 attempts; earlier attempts failed:\nattempt 1: phase 1")
+	assert.Equal(t, "2.000", phase.Time)
+
+	task := stages.Cases[2]
+	assert.Equal(t, "implement task T002", task.Name)
+	require.NotNil(t, task.Error)
+	assert.Equal(t, "execution", task.Error.Type)
+
+	tasks := doc.Suites[1]
+	assert.Equal(t, "autospec.001-auth.tasks", tasks.Name)
+	require.Len(t, tasks.Cases, 3)
+	assert.Equal(t, "T001 Add login", tasks.Cases[0].Name)
+	assert.Equal(t, "autospec.001-auth.tasks.phase1", tasks.Cases[0].ClassName)
+	assert.Nil(t, tasks.Cases[0].Failure)
+	require.NotNil(t, tasks.Cases[1].Failure)
+	assert.Equal(t, "blocked: Needs client secret", tasks.Cases[1].Failure.Message)
+	require.NotNil(t, tasks.Cases[2].Skipped)
+	assert.Equal(t, "status: Pending", tasks.Cases[2].Skipped.Message)
+}
+
+func TestJUnit_WriteWithoutTasks(t *testing.T) {
+	t.Parallel()
+
+	junit := &JUnit{}
+	junit.RecordValidation(ValidationResult{Stage: "specify", Attempt: 1, Passed: true})
+	junit.RecordValidation(ValidationResult{Spec: "002-cache", Stage: "plan", Attempt: 1, Passed: true})
+
+	doc := readReport(t, junit, t.TempDir())
+
+	require.Len(t, doc.Suites, 2)
+	assert.Equal(t, "autospec.stages", doc.Suites[0].Name)
+	assert.Equal(t, "autospec.002-cache.stages", doc.Suites[1].Name)
+	assert.Equal(t, 2, doc.Tests)
+	assert.Zero(t, doc.Failures)
+}
+
+func TestJUnit_WriteEmpty(t *testing.T) {
+	t.Parallel()
+
+	doc := readReport(t, &JUnit{}, t.TempDir())
+	assert.Zero(t, doc.Tests)
+	assert.Empty(t, doc.Suites)
+}
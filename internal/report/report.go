@@ -0,0 +1,59 @@
+// Package report writes workflow results in formats CI systems understand,
+// so autospec failures show up next to test results and code scanning alerts.
+// Related: internal/workflow/report.go, internal/cli/shared/report.go
+// Tags: report, junit, sarif, ci, validation
+//
+// The executor records every validated stage attempt while a command runs.
+// When it finishes, each requested report is written from those results:
+// JUnit XML with two test suites per spec (one test case per stage session,
+// one per task in tasks.yaml), and SARIF with the findings of every spec
+// that was analyzed.
+package report
+
+import (
+	"fmt"
+	"time"
+)
+
+// ValidationResult is the outcome of one validated stage attempt.
+type ValidationResult struct {
+	Spec      string
+	Stage     string
+	Phase     int    // Phase an implement --phases session worked on
+	TaskID    string // Task an implement --tasks session worked on
+	Attempt   int    // 1 for the first attempt
+	Passed    bool
+	Execution bool   // The agent itself failed, so nothing was validated
+	Message   string // Failure message, including every validation error
+	Duration  time.Duration
+}
+
+// name returns the test case name of the session the result belongs to.
+func (r ValidationResult) name() string {
+	switch {
+	case r.TaskID != "":
+		return fmt.Sprintf("%s task %s", r.Stage, r.TaskID)
+	case r.Phase > 0:
+		return fmt.Sprintf("%s phase %d", r.Stage, r.Phase)
+	default:
+		return r.Stage
+	}
+}
+
+// Collector is a report that collects validation results while a command
+// runs and is written to a file when it finishes.
+type Collector interface {
+	RecordValidation(result ValidationResult)
+	Write(path, specsDir string) error
+}
+
+// Collectors passes each validation result to several reports, for commands
+// run with more than one --report flag.
+type Collectors []Collector
+
+// RecordValidation passes the result to every collector.
+func (c Collectors) RecordValidation(result ValidationResult) {
+	for _, collector := range c {
+		collector.RecordValidation(result)
+	}
+}
@@ -0,0 +1,310 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// sarifSchema and sarifVersion identify the SARIF format GitHub code scanning accepts.
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+// analysisRules describes each analysis.yaml finding category as a SARIF rule.
+var analysisRules = []sarifRule{
+	{ID: "duplication", Name: "Duplication", ShortDescription: sarifText{Text: "Duplicate or overlapping requirements"}},
+	{ID: "ambiguity", Name: "Ambiguity", ShortDescription: sarifText{Text: "Ambiguous or unmeasurable requirement"}},
+	{ID: "coverage", Name: "CoverageGap", ShortDescription: sarifText{Text: "Requirement or task without coverage"}},
+	{ID: "constitution", Name: "ConstitutionViolation", ShortDescription: sarifText{Text: "Conflicts with a project constitution principle"}},
+	{ID: "inconsistency", Name: "Inconsistency", ShortDescription: sarifText{Text: "Artifacts disagree with each other"}},
+	{ID: "underspecification", Name: "Underspecification", ShortDescription: sarifText{Text: "Requirement or edge case is underspecified"}},
+}
+
+// locationPattern matches the artifact references in a finding's location,
+// e.g. "spec.yaml:requirements.functional[2]" or
+// "plan.yaml:data_model vs spec.yaml:key_entities".
+var locationPattern = regexp.MustCompile(`([\w./-]+\.(?:yaml|yml|md))(?::([\w.\[\]-]+))?`)
+
+// SARIF collects the specs analyzed during a command and writes their
+// analysis.yaml findings as SARIF, so GitHub code scanning can annotate the
+// spec artifacts in a pull request. It is safe for concurrent use.
+type SARIF struct {
+	mu    sync.Mutex
+	specs []string
+}
+
+// RecordValidation notes the spec of every analyze attempt. The findings are
+// read when the report is written, so failed attempts are reported too: a
+// CRITICAL constitution finding fails the stage but is still in analysis.yaml.
+func (s *SARIF) RecordValidation(result ValidationResult) {
+	if result.Stage != "analyze" || result.Spec == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, spec := range s.specs {
+		if spec == result.Spec {
+			return
+		}
+	}
+	s.specs = append(s.specs, result.Spec)
+}
+
+// Write writes the findings of each analyzed spec under specsDir to path,
+// creating its directory if needed. Specs without a readable analysis.yaml
+// are left out; when nothing was analyzed the report has no results.
+func (s *SARIF) Write(path, specsDir string) error {
+	s.mu.Lock()
+	specs := append([]string(nil), s.specs...)
+	s.mu.Unlock()
+
+	results := []sarifResult{}
+	for _, spec := range specs {
+		results = append(results, analysisResults(filepath.Join(specsDir, spec))...)
+	}
+	doc := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "autospec",
+				InformationURI: "https://github.com/ariel-frischer/autospec",
+				Rules:          analysisRules,
+			}},
+			Results: results,
+		}},
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding SARIF report: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating report directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing SARIF report: %w", err)
+	}
+	return nil
+}
+
+// analysisFinding is a finding in analysis.yaml.
+type analysisFinding struct {
+	ID             string `yaml:"id"`
+	Category       string `yaml:"category"`
+	Severity       string `yaml:"severity"`
+	Location       string `yaml:"location"`
+	Summary        string `yaml:"summary"`
+	Details        string `yaml:"details"`
+	Recommendation string `yaml:"recommendation"`
+}
+
+// analysisResults converts the findings in a spec's analysis.yaml to SARIF
+// results. It returns nil when the file is missing or unparseable.
+func analysisResults(specDir string) []sarifResult {
+	data, err := os.ReadFile(filepath.Join(specDir, "analysis.yaml"))
+	if err != nil {
+		return nil
+	}
+	var analysis struct {
+		Findings []analysisFinding `yaml:"findings"`
+	}
+	if err := yaml.Unmarshal(data, &analysis); err != nil {
+		return nil
+	}
+
+	var results []sarifResult
+	for _, finding := range analysis.Findings {
+		result := sarifResult{
+			RuleID:     finding.Category,
+			Level:      sarifLevel(finding.Severity),
+			Message:    sarifText{Text: findingMessage(finding)},
+			Properties: map[string]string{"severity": finding.Severity, "finding": finding.ID},
+		}
+		locations := findingLocations(specDir, finding.Location)
+		if len(locations) == 0 {
+			locations = []sarifLocation{artifactLocation(filepath.Join(specDir, "analysis.yaml"), 1)}
+		}
+		result.Locations = locations[:1]
+		for i, related := range locations[1:] {
+			related.ID = i + 1
+			result.RelatedLocations = append(result.RelatedLocations, related)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// findingMessage joins a finding's summary, details and recommendation.
+func findingMessage(finding analysisFinding) string {
+	message := fmt.Sprintf("%s: %s", finding.ID, finding.Summary)
+	if finding.Details != "" {
+		message += "\n\n" + finding.Details
+	}
+	if finding.Recommendation != "" {
+		message += "\n\nRecommendation: " + finding.Recommendation
+	}
+	return message
+}
+
+// sarifLevel maps an analysis severity to a SARIF result level.
+func sarifLevel(severity string) string {
+	switch severity {
+	case "CRITICAL", "HIGH":
+		return "error"
+	case "LOW":
+		return "note"
+	default:
+		return "warning"
+	}
+}
+
+// findingLocations resolves the artifact references in a finding's location
+// to files and lines. Bare file names are artifacts in the spec directory;
+// paths with a directory are relative to the repository root.
+func findingLocations(specDir, location string) []sarifLocation {
+	var locations []sarifLocation
+	for _, match := range locationPattern.FindAllStringSubmatch(location, -1) {
+		file := match[1]
+		if !strings.Contains(file, "/") {
+			file = filepath.Join(specDir, file)
+		}
+		locations = append(locations, artifactLocation(file, yamlPathLine(file, match[2])))
+	}
+	return locations
+}
+
+// artifactLocation builds a SARIF location for a file, relative to the
+// working directory (the repository root in CI) when the file is under it.
+func artifactLocation(file string, line int) sarifLocation {
+	uri := filepath.ToSlash(filepath.Clean(file))
+	if filepath.IsAbs(file) {
+		uri = "file://" + uri
+		if cwd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(cwd, file); err == nil && !strings.HasPrefix(rel, "..") {
+				uri = filepath.ToSlash(rel)
+			}
+		}
+	}
+	return sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: uri},
+		Region:           sarifRegion{StartLine: line},
+	}}
+}
+
+// yamlPathLine returns the line of the node a path like
+// "requirements.functional[2]" refers to in a YAML file, or of the deepest
+// part of the path that exists. It returns 1 when nothing can be resolved.
+func yamlPathLine(file, path string) int {
+	data, err := os.ReadFile(file)
+	if err != nil || path == "" {
+		return 1
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return 1
+	}
+	node, line := doc.Content[0], 1
+	for _, segment := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(segment, "[")
+		if key != "" {
+			node = mappingValue(node, key)
+			if node == nil {
+				return line
+			}
+			line = node.Line
+		}
+		for _, index := range strings.Split(rest, "[") {
+			i, err := strconv.Atoi(strings.TrimSuffix(index, "]"))
+			if err != nil {
+				continue
+			}
+			if node.Kind != yaml.SequenceNode || i < 0 || i >= len(node.Content) {
+				return line
+			}
+			node = node.Content[i]
+			line = node.Line
+		}
+	}
+	return line
+}
+
+// mappingValue returns the value of key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string    `json:"id"`
+	Name             string    `json:"name"`
+	ShortDescription sarifText `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID           string            `json:"ruleId"`
+	Level            string            `json:"level"`
+	Message          sarifText         `json:"message"`
+	Locations        []sarifLocation   `json:"locations"`
+	RelatedLocations []sarifLocation   `json:"relatedLocations,omitempty"`
+	Properties       map[string]string `json:"properties,omitempty"`
+}
+
+type sarifText struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	ID               int                   `json:"id,omitempty"`
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
@@ -0,0 +1,145 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const specYAML = `feature:
+  branch: "001-auth"
+requirements:
+  functional:
+    - id: FR-001
+      description: "Users can log in"
+    - id: FR-002
+      description: "Users can log out"
+`
+
+const analysisYAML = `analysis:
+  branch: "001-auth"
+  timestamp: "2026-01-01T00:00:00Z"
+findings:
+  - id: "CON-001"
+    category: "constitution"
+    severity: "CRITICAL"
+    location: "spec.yaml:requirements.functional[1]"
+    summary: "Logout is not tested first"
+    details: "Constitution requires test-first development"
+    recommendation: "Add a test task"
+  - id: "INC-001"
+    category: "inconsistency"
+    severity: "MEDIUM"
+    location: "plan.yaml:data_model vs spec.yaml:feature"
+    summary: "Entity naming mismatch"
+  - id: "AMB-001"
+    category: "ambiguity"
+    severity: "LOW"
+    location: "overall"
+    summary: "Vague wording"
+summary:
+  overall_status: "FAIL"
+`
+
+// readSARIF writes the report and parses it back.
+func readSARIF(t *testing.T, sarif *SARIF, specsDir string) sarifLog {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "reports", "autospec.sarif")
+	require.NoError(t, sarif.Write(path, specsDir))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var doc sarifLog
+	require.NoError(t, json.Unmarshal(data, &doc))
+	return doc
+}
+
+func TestSARIF_Write(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	specDir := filepath.Join(specsDir, "001-auth")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "spec.yaml"), []byte(specYAML), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "analysis.yaml"), []byte(analysisYAML), 0o644))
+
+	sarif := &SARIF{}
+	sarif.RecordValidation(ValidationResult{Spec: "001-auth", Stage: "plan", Attempt: 1, Passed: true})
+	sarif.RecordValidation(ValidationResult{Spec: "001-auth", Stage: "analyze", Attempt: 1, Message: "1 critical constitution violation"})
+	sarif.RecordValidation(ValidationResult{Spec: "001-auth", Stage: "analyze", Attempt: 2, Message: "1 critical constitution violation"})
+
+	doc := readSARIF(t, sarif, specsDir)
+
+	assert.Equal(t, "2.1.0", doc.Version)
+	require.Len(t, doc.Runs, 1)
+	assert.Equal(t, "autospec", doc.Runs[0].Tool.Driver.Name)
+	assert.Len(t, doc.Runs[0].Tool.Driver.Rules, 6)
+
+	results := doc.Runs[0].Results
+	require.Len(t, results, 3, "analyzed once, despite two attempts")
+
+	critical := results[0]
+	assert.Equal(t, "constitution", critical.RuleID)
+	assert.Equal(t, "error", critical.Level)
+	assert.Equal(t, "CON-001: Logout is not tested first\n\nConstitution requires test-first development\n\nRecommendation: Add a test task", critical.Message.Text)
+	assert.Equal(t, map[string]string{"severity": "CRITICAL", "finding": "CON-001"}, critical.Properties)
+	require.Len(t, critical.Locations, 1)
+	assert.Equal(t, filepath.ToSlash(filepath.Join(specDir, "spec.yaml")), strings.TrimPrefix(critical.Locations[0].PhysicalLocation.ArtifactLocation.URI, "file://"))
+	assert.Equal(t, 7, critical.Locations[0].PhysicalLocation.Region.StartLine, "line of functional[1]")
+
+	inconsistency := results[1]
+	assert.Equal(t, "warning", inconsistency.Level)
+	assert.Contains(t, inconsistency.Locations[0].PhysicalLocation.ArtifactLocation.URI, "plan.yaml")
+	assert.Equal(t, 1, inconsistency.Locations[0].PhysicalLocation.Region.StartLine, "missing artifact")
+	require.Len(t, inconsistency.RelatedLocations, 1)
+	assert.Equal(t, 1, inconsistency.RelatedLocations[0].ID)
+	assert.Equal(t, 2, inconsistency.RelatedLocations[0].PhysicalLocation.Region.StartLine, "line of feature")
+
+	unresolved := results[2]
+	assert.Equal(t, "note", unresolved.Level)
+	assert.Contains(t, unresolved.Locations[0].PhysicalLocation.ArtifactLocation.URI, "analysis.yaml")
+}
+
+func TestSARIF_WriteWithoutAnalysis(t *testing.T) {
+	t.Parallel()
+
+	sarif := &SARIF{}
+	sarif.RecordValidation(ValidationResult{Spec: "001-auth", Stage: "analyze", Attempt: 1, Execution: true, Message: "agent crashed"})
+
+	doc := readSARIF(t, sarif, t.TempDir())
+
+	require.Len(t, doc.Runs, 1)
+	assert.NotNil(t, doc.Runs[0].Results, "results must be an empty array, not null")
+	assert.Empty(t, doc.Runs[0].Results)
+}
+
+func TestYAMLPathLine(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "spec.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(specYAML), 0o644))
+
+	tests := map[string]struct {
+		path string
+		want int
+	}{
+		"empty path":         {path: "", want: 1},
+		"mapping key":        {path: "requirements", want: 4},
+		"sequence item":      {path: "requirements.functional[0]", want: 5},
+		"nested field":       {path: "requirements.functional[1].description", want: 8},
+		"missing key":        {path: "requirements.non_functional[0]", want: 4},
+		"index out of range": {path: "requirements.functional[5]", want: 5},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, yamlPathLine(file, tt.path))
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package retry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/statestore"
+)
+
+// checkpointFile is the workflow checkpoint document in the state store.
+const checkpointFile = "checkpoint.json"
+
+// WorkflowCheckpoint records which workflow stages completed for a spec so an
+// interrupted full workflow run can resume at the first unfinished stage.
+type WorkflowCheckpoint struct {
+	SpecName           string    `json:"spec_name"`
+	FeatureDescription string    `json:"feature_description,omitempty"`
+	CompletedStages    []string  `json:"completed_stages"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// checkpointStore contains all workflow checkpoints persisted to disk, keyed by spec name
+type checkpointStore struct {
+	Checkpoints map[string]*WorkflowCheckpoint `json:"checkpoints"`
+}
+
+// IsStageCompleted checks if a stage is in the completed stages list
+func (c *WorkflowCheckpoint) IsStageCompleted(stage string) bool {
+	return slices.Contains(c.CompletedStages, stage)
+}
+
+// LoadCheckpoint returns the checkpoint for a spec, or nil if none exists.
+func LoadCheckpoint(stateDir, specName string) (*WorkflowCheckpoint, error) {
+	store, err := loadCheckpointStore(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	return store.Checkpoints[specName], nil
+}
+
+// FindCheckpoint returns the most recently updated checkpoint created for the
+// given feature description, or nil if none exists. Used by full workflow runs,
+// where the spec name is only known after the specify stage.
+func FindCheckpoint(stateDir, featureDescription string) (*WorkflowCheckpoint, error) {
+	store, err := loadCheckpointStore(stateDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *WorkflowCheckpoint
+	for _, cp := range store.Checkpoints {
+		if cp.FeatureDescription != featureDescription {
+			continue
+		}
+		if latest == nil || cp.UpdatedAt.After(latest.UpdatedAt) {
+			latest = cp
+		}
+	}
+	return latest, nil
+}
+
+// MarkCheckpointStage records a completed stage for a spec, creating the
+// checkpoint if needed. Idempotent: skips stages already recorded.
+func MarkCheckpointStage(stateDir, specName, featureDescription, stage string) error {
+	unlock, err := statestore.For(stateDir).Lock(checkpointFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	store, err := loadCheckpointStore(stateDir)
+	if err != nil {
+		return err
+	}
+
+	cp, exists := store.Checkpoints[specName]
+	if !exists {
+		cp = &WorkflowCheckpoint{SpecName: specName, CompletedStages: []string{}}
+		store.Checkpoints[specName] = cp
+	}
+	if featureDescription != "" {
+		cp.FeatureDescription = featureDescription
+	}
+	if !cp.IsStageCompleted(stage) {
+		cp.CompletedStages = append(cp.CompletedStages, stage)
+	}
+	cp.UpdatedAt = time.Now()
+
+	return saveCheckpointStore(stateDir, store)
+}
+
+// ClearCheckpoint removes the checkpoint for a spec
+func ClearCheckpoint(stateDir, specName string) error {
+	unlock, err := statestore.For(stateDir).Lock(checkpointFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	store, err := loadCheckpointStore(stateDir)
+	if err != nil {
+		return err
+	}
+	if _, exists := store.Checkpoints[specName]; !exists {
+		return nil
+	}
+	delete(store.Checkpoints, specName)
+	return saveCheckpointStore(stateDir, store)
+}
+
+// loadCheckpointStore reads the checkpoint file, returning an empty store if it doesn't exist
+func loadCheckpointStore(stateDir string) (*checkpointStore, error) {
+	store := &checkpointStore{Checkpoints: make(map[string]*WorkflowCheckpoint)}
+
+	data, err := statestore.For(stateDir).Read(checkpointFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint file: %w", err)
+	}
+	if store.Checkpoints == nil {
+		store.Checkpoints = make(map[string]*WorkflowCheckpoint)
+	}
+	return store, nil
+}
+
+// saveCheckpointStore writes the checkpoint file atomically
+func saveCheckpointStore(stateDir string, store *checkpointStore) error {
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	if err := statestore.For(stateDir).Write(checkpointFile, data); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Package retry_test tests workflow checkpoint persistence for resuming full runs.
+// Related: internal/retry/checkpoint.go
+// Tags: retry, checkpoint, resume, persistence
+
+package retry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkCheckpointStage(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+
+	require.NoError(t, MarkCheckpointStage(stateDir, "001-auth", "Add auth", "specify"))
+	require.NoError(t, MarkCheckpointStage(stateDir, "001-auth", "", "plan"))
+	require.NoError(t, MarkCheckpointStage(stateDir, "001-auth", "", "plan")) // idempotent
+
+	cp, err := LoadCheckpoint(stateDir, "001-auth")
+	require.NoError(t, err)
+	require.NotNil(t, cp)
+	assert.Equal(t, "001-auth", cp.SpecName)
+	assert.Equal(t, "Add auth", cp.FeatureDescription, "empty description keeps the recorded one")
+	assert.Equal(t, []string{"specify", "plan"}, cp.CompletedStages)
+	assert.True(t, cp.IsStageCompleted("plan"))
+	assert.False(t, cp.IsStageCompleted("tasks"))
+	assert.False(t, cp.UpdatedAt.IsZero())
+}
+
+func TestLoadCheckpoint_Missing(t *testing.T) {
+	t.Parallel()
+
+	cp, err := LoadCheckpoint(t.TempDir(), "001-auth")
+	require.NoError(t, err)
+	assert.Nil(t, cp)
+}
+
+func TestLoadCheckpoint_Corrupt(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(stateDir, checkpointFile), []byte("{not json"), 0644))
+
+	_, err := LoadCheckpoint(stateDir, "001-auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse checkpoint file")
+}
+
+func TestFindCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	require.NoError(t, MarkCheckpointStage(stateDir, "001-auth", "Add auth", "specify"))
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, MarkCheckpointStage(stateDir, "002-auth", "Add auth", "specify"))
+	require.NoError(t, MarkCheckpointStage(stateDir, "003-search", "Add search", "specify"))
+
+	tests := map[string]struct {
+		description string
+		wantSpec    string
+	}{
+		"most recent match wins": {description: "Add auth", wantSpec: "002-auth"},
+		"single match":           {description: "Add search", wantSpec: "003-search"},
+		"no match":               {description: "Add billing", wantSpec: ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cp, err := FindCheckpoint(stateDir, tt.description)
+			require.NoError(t, err)
+			if tt.wantSpec == "" {
+				assert.Nil(t, cp)
+				return
+			}
+			require.NotNil(t, cp)
+			assert.Equal(t, tt.wantSpec, cp.SpecName)
+		})
+	}
+}
+
+func TestClearCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	require.NoError(t, MarkCheckpointStage(stateDir, "001-auth", "Add auth", "specify"))
+	require.NoError(t, MarkCheckpointStage(stateDir, "002-search", "Add search", "specify"))
+
+	require.NoError(t, ClearCheckpoint(stateDir, "001-auth"))
+	require.NoError(t, ClearCheckpoint(stateDir, "999-missing"))
+
+	cp, err := LoadCheckpoint(stateDir, "001-auth")
+	require.NoError(t, err)
+	assert.Nil(t, cp)
+
+	other, err := LoadCheckpoint(stateDir, "002-search")
+	require.NoError(t, err)
+	assert.NotNil(t, other, "clearing one spec preserves others")
+}
@@ -0,0 +1,196 @@
+package retry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/statestore"
+)
+
+// SpecState is all the retry, progress and checkpoint state of one spec, as
+// moved between machines by 'autospec sync'.
+type SpecState struct {
+	// Retries is keyed like the store, "<spec>:<phase>".
+	Retries    map[string]*RetryState `json:"retries,omitempty"`
+	Stage      *StageExecutionState   `json:"stage,omitempty"`
+	Task       *TaskExecutionState    `json:"task,omitempty"`
+	Checkpoint *WorkflowCheckpoint    `json:"checkpoint,omitempty"`
+}
+
+// IsEmpty reports whether the spec has no state.
+func (s *SpecState) IsEmpty() bool {
+	return s == nil || (len(s.Retries) == 0 && s.Stage == nil && s.Task == nil && s.Checkpoint == nil)
+}
+
+// LastUpdated returns the latest attempt or update time in the state, or the
+// zero time if there is none.
+func (s *SpecState) LastUpdated() time.Time {
+	var latest time.Time
+	if s == nil {
+		return latest
+	}
+	later := func(t time.Time) {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	for _, r := range s.Retries {
+		later(r.LastAttempt)
+	}
+	if s.Stage != nil {
+		later(s.Stage.LastPhaseAttempt)
+	}
+	if s.Task != nil {
+		later(s.Task.LastTaskAttempt)
+	}
+	if s.Checkpoint != nil {
+		later(s.Checkpoint.UpdatedAt)
+	}
+	return latest
+}
+
+// ExportSpecState returns the state of specName from the state directory.
+func ExportSpecState(stateDir, specName string) (*SpecState, error) {
+	state := &SpecState{}
+
+	store, err := loadStore(stateDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if store != nil {
+		for key, r := range store.Retries {
+			if strings.HasPrefix(key, specName+":") {
+				if state.Retries == nil {
+					state.Retries = make(map[string]*RetryState)
+				}
+				state.Retries[key] = r
+			}
+		}
+		state.Stage = store.StageStates[specName]
+		state.Task = store.TaskStates[specName]
+	}
+
+	checkpoints, err := loadCheckpointStore(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	state.Checkpoint = checkpoints.Checkpoints[specName]
+	return state, nil
+}
+
+// SpecNames returns the specs that have retry, progress or checkpoint state,
+// sorted.
+func SpecNames(stateDir string) ([]string, error) {
+	names := make(map[string]bool)
+	store, err := loadStore(stateDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if store != nil {
+		for key := range store.Retries {
+			if i := strings.LastIndex(key, ":"); i > 0 {
+				names[key[:i]] = true
+			}
+		}
+		for name := range store.StageStates {
+			names[name] = true
+		}
+		for name := range store.TaskStates {
+			names[name] = true
+		}
+	}
+
+	checkpoints, err := loadCheckpointStore(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	for name := range checkpoints.Checkpoints {
+		names[name] = true
+	}
+	return slices.Sorted(maps.Keys(names)), nil
+}
+
+// ReplaceSpecState replaces the state of specName in the state directory
+// with state, removing entries state doesn't have. A nil state clears it.
+func ReplaceSpecState(stateDir, specName string, state *SpecState) error {
+	if state == nil {
+		state = &SpecState{}
+	}
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	if err := replaceRetryEntries(stateDir, specName, state); err != nil {
+		return err
+	}
+
+	unlock, err := statestore.For(stateDir).Lock(checkpointFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	checkpoints, err := loadCheckpointStore(stateDir)
+	if err != nil {
+		return err
+	}
+	if state.Checkpoint != nil {
+		checkpoints.Checkpoints[specName] = state.Checkpoint
+	} else {
+		delete(checkpoints.Checkpoints, specName)
+	}
+	return saveCheckpointStore(stateDir, checkpoints)
+}
+
+// replaceRetryEntries replaces the spec's retries and stage and task state
+// in retry.json.
+func replaceRetryEntries(stateDir, specName string, state *SpecState) error {
+	unlock, err := lockStore(stateDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	store, err := loadStore(stateDir)
+	if err != nil {
+		store = &RetryStore{Retries: make(map[string]*RetryState)}
+	}
+	for key := range store.Retries {
+		if strings.HasPrefix(key, specName+":") {
+			delete(store.Retries, key)
+		}
+	}
+	for key, r := range state.Retries {
+		store.Retries[key] = r
+	}
+	store.StageStates = setOrDelete(store.StageStates, specName, state.Stage)
+	store.TaskStates = setOrDelete(store.TaskStates, specName, state.Task)
+
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal retry state: %w", err)
+	}
+	// Atomic write, keeping the previous version for 'autospec undo'
+	if err := statestore.For(stateDir).Write(storeName, data); err != nil {
+		return fmt.Errorf("failed to write retry state: %w", err)
+	}
+	return nil
+}
+
+// setOrDelete sets m[key] to value, or deletes it if value is nil.
+func setOrDelete[T any](m map[string]*T, key string, value *T) map[string]*T {
+	if value == nil {
+		delete(m, key)
+		return m
+	}
+	if m == nil {
+		m = make(map[string]*T)
+	}
+	m[key] = value
+	return m
+}
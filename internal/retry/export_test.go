@@ -0,0 +1,75 @@
+// Package retry_test tests exporting and replacing a spec's state for sync.
+// Related: internal/retry/export.go
+// Tags: retry, checkpoint, sync, persistence
+
+package retry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportSpecState(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	_, err := IncrementRetryCount(stateDir, "001-login", "implement", 3)
+	require.NoError(t, err)
+	_, err = IncrementRetryCount(stateDir, "002-search", "plan", 3)
+	require.NoError(t, err)
+	require.NoError(t, MarkTaskComplete(stateDir, "001-login", "T001"))
+	require.NoError(t, MarkCheckpointStage(stateDir, "001-login", "login", "plan"))
+
+	state, err := ExportSpecState(stateDir, "001-login")
+	require.NoError(t, err)
+	assert.Len(t, state.Retries, 1)
+	assert.Contains(t, state.Retries, "001-login:implement")
+	assert.Nil(t, state.Stage)
+	require.NotNil(t, state.Task)
+	assert.Equal(t, []string{"T001"}, state.Task.CompletedTaskIDs)
+	require.NotNil(t, state.Checkpoint)
+	assert.False(t, state.IsEmpty())
+	assert.False(t, state.LastUpdated().IsZero())
+
+	empty, err := ExportSpecState(t.TempDir(), "001-login")
+	require.NoError(t, err)
+	assert.True(t, empty.IsEmpty())
+	assert.True(t, empty.LastUpdated().IsZero())
+}
+
+func TestReplaceSpecState(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	_, err := IncrementRetryCount(stateDir, "001-login", "plan", 3)
+	require.NoError(t, err)
+	_, err = IncrementRetryCount(stateDir, "002-search", "plan", 3)
+	require.NoError(t, err)
+	require.NoError(t, MarkCheckpointStage(stateDir, "001-login", "login", "plan"))
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	incoming := &SpecState{
+		Retries: map[string]*RetryState{
+			"001-login:implement": {SpecName: "001-login", Phase: "implement", Count: 2, LastAttempt: at, MaxRetries: 3},
+		},
+		Stage: &StageExecutionState{SpecName: "001-login", CurrentPhase: 2, TotalPhases: 3, CompletedPhases: []int{1}, LastPhaseAttempt: at.Add(time.Hour)},
+	}
+	require.NoError(t, ReplaceSpecState(stateDir, "001-login", incoming))
+
+	got, err := ExportSpecState(stateDir, "001-login")
+	require.NoError(t, err)
+	assert.Equal(t, incoming, got, "old retries and checkpoint should be replaced")
+	assert.Equal(t, at.Add(time.Hour), got.LastUpdated())
+
+	other, err := LoadRetryState(stateDir, "002-search", "plan", 3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, other.Count, "other specs should be untouched")
+
+	require.NoError(t, ReplaceSpecState(stateDir, "001-login", nil))
+	got, err = ExportSpecState(stateDir, "001-login")
+	require.NoError(t, err)
+	assert.True(t, got.IsEmpty())
+}
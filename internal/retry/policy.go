@@ -0,0 +1,200 @@
+package retry
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// PolicyType selects how the delay between retry attempts grows.
+type PolicyType string
+
+const (
+	// PolicyNone retries immediately (legacy behavior).
+	PolicyNone PolicyType = "none"
+	// PolicyFixed waits InitialDelay before every retry.
+	PolicyFixed PolicyType = "fixed"
+	// PolicyLinear waits InitialDelay * attempt.
+	PolicyLinear PolicyType = "linear"
+	// PolicyExponential waits InitialDelay * Multiplier^(attempt-1).
+	PolicyExponential PolicyType = "exponential"
+)
+
+// ValidPolicyTypes lists the accepted retry_policy.type values.
+var ValidPolicyTypes = []string{string(PolicyNone), string(PolicyFixed), string(PolicyLinear), string(PolicyExponential)}
+
+// defaultMultiplier is used by exponential policies when Multiplier is unset.
+const defaultMultiplier = 2
+
+// PolicyConfig configures the backoff between retry attempts.
+// The top-level fields apply to every stage; Stages holds per-stage overrides
+// keyed by stage name (e.g., "plan", "implement") where only set fields override.
+type PolicyConfig struct {
+	// Type is the backoff strategy: none, fixed, linear, or exponential.
+	Type PolicyType `koanf:"type" yaml:"type" json:"type"`
+
+	// InitialDelay is the delay before the first retry.
+	InitialDelay time.Duration `koanf:"initial_delay" yaml:"initial_delay" json:"initial_delay"`
+
+	// MaxDelay caps the delay between attempts (0 = no cap).
+	MaxDelay time.Duration `koanf:"max_delay" yaml:"max_delay" json:"max_delay"`
+
+	// Multiplier is the growth factor for exponential backoff (default: 2).
+	Multiplier int `koanf:"multiplier" yaml:"multiplier" json:"multiplier"`
+
+	// Jitter randomizes each delay between 50% and 100% of its computed value
+	// so parallel runs against the same rate limit spread out. Nil means unset,
+	// so a stage override can turn jitter off as well as on.
+	Jitter *bool `koanf:"jitter" yaml:"jitter,omitempty" json:"jitter,omitempty"`
+
+	// RateLimitWaits is how many rate-limit errors per stage are waited out
+	// without using up a retry (0 = a rate limit counts as a failed attempt).
+	RateLimitWaits int `koanf:"rate_limit_waits" yaml:"rate_limit_waits" json:"rate_limit_waits"`
+
+	// RateLimitDelay is the wait after a rate-limit error when the agent gave
+	// no hint of when to retry.
+	RateLimitDelay time.Duration `koanf:"rate_limit_delay" yaml:"rate_limit_delay" json:"rate_limit_delay"`
+
+	// Stages overrides the policy for individual stages.
+	Stages map[string]PolicyConfig `koanf:"stages" yaml:"stages,omitempty" json:"stages,omitempty"`
+}
+
+// ForStage returns the effective policy for a stage: the stage override's set
+// fields layered over the global policy.
+func (c PolicyConfig) ForStage(stage string) PolicyConfig {
+	effective := c
+	effective.Stages = nil
+
+	override, ok := c.Stages[stage]
+	if !ok {
+		return effective
+	}
+	if override.Type != "" {
+		effective.Type = override.Type
+	}
+	if override.InitialDelay != 0 {
+		effective.InitialDelay = override.InitialDelay
+	}
+	if override.MaxDelay != 0 {
+		effective.MaxDelay = override.MaxDelay
+	}
+	if override.Multiplier != 0 {
+		effective.Multiplier = override.Multiplier
+	}
+	if override.Jitter != nil {
+		effective.Jitter = override.Jitter
+	}
+	if override.RateLimitWaits != 0 {
+		effective.RateLimitWaits = override.RateLimitWaits
+	}
+	if override.RateLimitDelay != 0 {
+		effective.RateLimitDelay = override.RateLimitDelay
+	}
+	return effective
+}
+
+// JitterEnabled reports whether delays are randomized.
+func (c PolicyConfig) JitterEnabled() bool {
+	return c.Jitter != nil && *c.Jitter
+}
+
+// Validate checks the policy and all stage overrides for invalid values.
+func (c PolicyConfig) Validate() error {
+	if err := c.validateFields(); err != nil {
+		return err
+	}
+	for stage, override := range c.Stages {
+		if err := override.validateFields(); err != nil {
+			return fmt.Errorf("stages.%s: %w", stage, err)
+		}
+	}
+	return nil
+}
+
+// validateFields checks a single policy level, ignoring Stages.
+func (c PolicyConfig) validateFields() error {
+	switch c.Type {
+	case "", PolicyNone, PolicyFixed, PolicyLinear, PolicyExponential:
+	default:
+		return fmt.Errorf("type must be one of: none, fixed, linear, exponential (got %q)", c.Type)
+	}
+	if c.InitialDelay < 0 || c.MaxDelay < 0 || c.RateLimitDelay < 0 {
+		return fmt.Errorf("delays must not be negative")
+	}
+	if c.Multiplier < 0 {
+		return fmt.Errorf("multiplier must not be negative")
+	}
+	if c.RateLimitWaits < 0 {
+		return fmt.Errorf("rate_limit_waits must not be negative")
+	}
+	return nil
+}
+
+// Delay returns how long to wait before the given retry attempt (1-based).
+// Returns 0 for attempt < 1 and for the none policy.
+func (c PolicyConfig) Delay(attempt int) time.Duration {
+	if attempt < 1 {
+		return 0
+	}
+
+	delay := c.baseDelay(attempt)
+	if c.MaxDelay > 0 && delay > c.MaxDelay {
+		delay = c.MaxDelay
+	}
+	if c.JitterEnabled() && delay > 0 {
+		half := delay / 2
+		delay = half + time.Duration(rand.Int63n(int64(half)+1))
+	}
+	return delay
+}
+
+// RateLimitWait returns how long to wait after a rate-limit error: the
+// agent's retry-after hint when it gave one, RateLimitDelay otherwise. The
+// hint is not capped by MaxDelay, since retrying sooner would fail again.
+func (c PolicyConfig) RateLimitWait(hint time.Duration) time.Duration {
+	if hint > 0 {
+		return hint
+	}
+	return c.RateLimitDelay
+}
+
+// baseDelay computes the un-jittered, uncapped delay for an attempt.
+func (c PolicyConfig) baseDelay(attempt int) time.Duration {
+	switch c.Type {
+	case PolicyFixed:
+		return c.InitialDelay
+	case PolicyLinear:
+		return c.InitialDelay * time.Duration(attempt)
+	case PolicyExponential:
+		multiplier := c.Multiplier
+		if multiplier == 0 {
+			multiplier = defaultMultiplier
+		}
+		delay := c.InitialDelay
+		for i := 1; i < attempt; i++ {
+			delay *= time.Duration(multiplier)
+			// Stop growing once past the cap (or on overflow)
+			if delay <= 0 || (c.MaxDelay > 0 && delay > c.MaxDelay) {
+				return c.MaxDelay
+			}
+		}
+		return delay
+	default:
+		return 0
+	}
+}
+
+// Cooldown returns how much longer to wait before the next attempt, given
+// the policy delay for the current retry count and the time already elapsed
+// since LastAttempt. The wait persists across runs because LastAttempt is saved
+// with the retry state, so re-running a rate-limited stage does not skip it.
+func (r *RetryState) Cooldown(policy PolicyConfig, now time.Time) time.Duration {
+	if r.Count == 0 || r.LastAttempt.IsZero() {
+		return 0
+	}
+	remaining := policy.Delay(r.Count) - now.Sub(r.LastAttempt)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
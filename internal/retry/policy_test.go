@@ -0,0 +1,221 @@
+// Package retry_test tests retry backoff policies and cool-down calculation.
+// Related: internal/retry/policy.go
+// Tags: retry, backoff, policy, jitter, cooldown
+
+package retry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyConfig_Delay(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		policy PolicyConfig
+		want   []time.Duration // delays for attempts 1..len(want)
+	}{
+		"none retries immediately": {
+			policy: PolicyConfig{Type: PolicyNone, InitialDelay: time.Second},
+			want:   []time.Duration{0, 0, 0},
+		},
+		"empty type retries immediately": {
+			policy: PolicyConfig{InitialDelay: time.Second},
+			want:   []time.Duration{0, 0},
+		},
+		"fixed": {
+			policy: PolicyConfig{Type: PolicyFixed, InitialDelay: 3 * time.Second},
+			want:   []time.Duration{3 * time.Second, 3 * time.Second, 3 * time.Second},
+		},
+		"linear": {
+			policy: PolicyConfig{Type: PolicyLinear, InitialDelay: 2 * time.Second},
+			want:   []time.Duration{2 * time.Second, 4 * time.Second, 6 * time.Second},
+		},
+		"exponential default multiplier": {
+			policy: PolicyConfig{Type: PolicyExponential, InitialDelay: time.Second},
+			want:   []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second},
+		},
+		"exponential custom multiplier capped": {
+			policy: PolicyConfig{Type: PolicyExponential, InitialDelay: time.Second, Multiplier: 3, MaxDelay: 5 * time.Second},
+			want:   []time.Duration{time.Second, 3 * time.Second, 5 * time.Second, 5 * time.Second},
+		},
+		"linear capped": {
+			policy: PolicyConfig{Type: PolicyLinear, InitialDelay: 4 * time.Second, MaxDelay: 6 * time.Second},
+			want:   []time.Duration{4 * time.Second, 6 * time.Second},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			for i, want := range tt.want {
+				assert.Equal(t, want, tt.policy.Delay(i+1), "attempt %d", i+1)
+			}
+			assert.Zero(t, tt.policy.Delay(0))
+		})
+	}
+}
+
+func TestPolicyConfig_DelayJitter(t *testing.T) {
+	t.Parallel()
+
+	policy := PolicyConfig{Type: PolicyExponential, InitialDelay: 4 * time.Second, Jitter: boolPtr(true)}
+	for i := 0; i < 50; i++ {
+		d := policy.Delay(2) // base 8s
+		assert.GreaterOrEqual(t, d, 4*time.Second)
+		assert.LessOrEqual(t, d, 8*time.Second)
+	}
+}
+
+func TestPolicyConfig_ForStage(t *testing.T) {
+	t.Parallel()
+
+	global := PolicyConfig{
+		Type:         PolicyExponential,
+		InitialDelay: 5 * time.Second,
+		MaxDelay:     time.Minute,
+		Jitter:       boolPtr(true),
+		Stages: map[string]PolicyConfig{
+			"implement": {Type: PolicyFixed, InitialDelay: 30 * time.Second, Jitter: boolPtr(true)},
+			"tasks":     {Jitter: boolPtr(false)},
+			"plan":      {MaxDelay: 10 * time.Second},
+			"specify":   {RateLimitWaits: 5},
+		},
+	}
+
+	tests := map[string]struct {
+		stage string
+		want  PolicyConfig
+	}{
+		"no override uses global": {
+			stage: "clarify",
+			want:  PolicyConfig{Type: PolicyExponential, InitialDelay: 5 * time.Second, MaxDelay: time.Minute, Jitter: boolPtr(true)},
+		},
+		"full override": {
+			stage: "implement",
+			want:  PolicyConfig{Type: PolicyFixed, InitialDelay: 30 * time.Second, MaxDelay: time.Minute, Jitter: boolPtr(true)},
+		},
+		"override can disable global jitter": {
+			stage: "tasks",
+			want:  PolicyConfig{Type: PolicyExponential, InitialDelay: 5 * time.Second, MaxDelay: time.Minute, Jitter: boolPtr(false)},
+		},
+		"partial override keeps global fields": {
+			stage: "plan",
+			want:  PolicyConfig{Type: PolicyExponential, InitialDelay: 5 * time.Second, MaxDelay: 10 * time.Second, Jitter: boolPtr(true)},
+		},
+		"rate limit override": {
+			stage: "specify",
+			want:  PolicyConfig{Type: PolicyExponential, InitialDelay: 5 * time.Second, MaxDelay: time.Minute, Jitter: boolPtr(true), RateLimitWaits: 5},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, global.ForStage(tt.stage))
+		})
+	}
+}
+
+func TestPolicyConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		policy  PolicyConfig
+		wantErr string
+	}{
+		"zero value is valid": {
+			policy: PolicyConfig{},
+		},
+		"valid exponential": {
+			policy: PolicyConfig{Type: PolicyExponential, InitialDelay: time.Second, Multiplier: 2},
+		},
+		"unknown type": {
+			policy:  PolicyConfig{Type: "random"},
+			wantErr: "type must be one of",
+		},
+		"negative delay": {
+			policy:  PolicyConfig{Type: PolicyFixed, InitialDelay: -time.Second},
+			wantErr: "must not be negative",
+		},
+		"negative rate limit waits": {
+			policy:  PolicyConfig{RateLimitWaits: -1},
+			wantErr: "rate_limit_waits must not be negative",
+		},
+		"negative rate limit delay": {
+			policy:  PolicyConfig{RateLimitDelay: -time.Second},
+			wantErr: "must not be negative",
+		},
+		"invalid stage override": {
+			policy:  PolicyConfig{Stages: map[string]PolicyConfig{"plan": {Multiplier: -1}}},
+			wantErr: "stages.plan: multiplier",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.policy.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestPolicyConfig_RateLimitWait(t *testing.T) {
+	t.Parallel()
+
+	policy := PolicyConfig{RateLimitDelay: time.Minute, MaxDelay: 10 * time.Second}
+	assert.Equal(t, time.Minute, policy.RateLimitWait(0), "no hint uses rate_limit_delay")
+	assert.Equal(t, time.Hour, policy.RateLimitWait(time.Hour), "hint is not capped by max_delay")
+}
+
+func TestRetryState_Cooldown(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := PolicyConfig{Type: PolicyExponential, InitialDelay: 10 * time.Second}
+
+	tests := map[string]struct {
+		state RetryState
+		want  time.Duration
+	}{
+		"no attempts yet": {
+			state: RetryState{Count: 0},
+			want:  0,
+		},
+		"missing timestamp": {
+			state: RetryState{Count: 1},
+			want:  0,
+		},
+		"just failed": {
+			state: RetryState{Count: 1, LastAttempt: now},
+			want:  10 * time.Second,
+		},
+		"partially elapsed": {
+			state: RetryState{Count: 2, LastAttempt: now.Add(-5 * time.Second)},
+			want:  15 * time.Second,
+		},
+		"fully elapsed": {
+			state: RetryState{Count: 1, LastAttempt: now.Add(-time.Minute)},
+			want:  0,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.state.Cooldown(policy, now))
+		})
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
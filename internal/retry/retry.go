@@ -1,15 +1,18 @@
 // Package retry provides persistent retry state management for autospec workflows.
 // It tracks retry attempts per spec:stage combination, stage execution progress for
 // phased implementation, and task-level execution state. State is persisted to
-// ~/.autospec/state/retry.json with atomic writes for concurrency safety.
+// the retry.json document of the state store (~/.autospec/state by default),
+// whose writes are atomic and keep the previous version, and updates hold a
+// file lock so concurrent autospec processes don't lose each other's changes.
 package retry
 
 import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"time"
+
+	"github.com/ariel-frischer/autospec/internal/statestore"
 )
 
 // RetryState represents retry tracking for a specific spec and phase combination
@@ -19,6 +22,7 @@ type RetryState struct {
 	Count       int       `json:"count"`
 	LastAttempt time.Time `json:"last_attempt"`
 	MaxRetries  int       `json:"max_retries"`
+	Total       int       `json:"total,omitempty"` // Retries ever made; unlike Count, not cleared by Reset
 }
 
 // RetryStore contains all retry states persisted to disk
@@ -85,8 +89,26 @@ func LoadRetryState(stateDir, specName, phase string, maxRetries int) (*RetrySta
 	}, nil
 }
 
+// storeName is the retry store's document in the state store.
+const storeName = "retry.json"
+
+// lockStore locks retry.json for a load-modify-save sequence.
+func lockStore(stateDir string) (func(), error) {
+	return statestore.For(stateDir).Lock(storeName)
+}
+
 // SaveRetryState saves retry state to persistent storage using atomic write
 func SaveRetryState(stateDir string, state *RetryState) error {
+	unlock, err := lockStore(stateDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return saveRetryState(stateDir, state)
+}
+
+// saveRetryState is SaveRetryState for callers holding the store lock.
+func saveRetryState(stateDir string, state *RetryState) error {
 	// Ensure state directory exists
 	if err := os.MkdirAll(stateDir, 0755); err != nil {
 		return fmt.Errorf("failed to create state directory: %w", err)
@@ -111,16 +133,9 @@ func SaveRetryState(stateDir string, state *RetryState) error {
 		return fmt.Errorf("failed to marshal retry state: %w", err)
 	}
 
-	// Write to temp file
-	retryPath := filepath.Join(stateDir, "retry.json")
-	tmpPath := retryPath + ".tmp"
-	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temp file: %w", err)
-	}
-
-	// Atomic rename
-	if err := os.Rename(tmpPath, retryPath); err != nil {
-		return fmt.Errorf("failed to rename temp file: %w", err)
+	// Atomic write, keeping the previous version for 'autospec undo'
+	if err := statestore.For(stateDir).Write(storeName, data); err != nil {
+		return fmt.Errorf("failed to write retry state: %w", err)
 	}
 
 	return nil
@@ -143,6 +158,7 @@ func (r *RetryState) Increment() error {
 		}
 	}
 	r.Count++
+	r.Total++
 	r.LastAttempt = time.Now()
 	return nil
 }
@@ -155,6 +171,12 @@ func (r *RetryState) Reset() {
 
 // IncrementRetryCount is a convenience function that loads, increments, and saves
 func IncrementRetryCount(stateDir, specName, phase string, maxRetries int) (*RetryState, error) {
+	unlock, err := lockStore(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	state, err := LoadRetryState(stateDir, specName, phase, maxRetries)
 	if err != nil {
 		return nil, err
@@ -164,7 +186,7 @@ func IncrementRetryCount(stateDir, specName, phase string, maxRetries int) (*Ret
 		return nil, err
 	}
 
-	if err := SaveRetryState(stateDir, state); err != nil {
+	if err := saveRetryState(stateDir, state); err != nil {
 		return nil, err
 	}
 
@@ -173,6 +195,12 @@ func IncrementRetryCount(stateDir, specName, phase string, maxRetries int) (*Ret
 
 // ResetRetryCount is a convenience function that loads, resets, and saves
 func ResetRetryCount(stateDir, specName, phase string) error {
+	unlock, err := lockStore(stateDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Load with default maxRetries (it doesn't matter since we're resetting)
 	state, err := LoadRetryState(stateDir, specName, phase, 3)
 	if err != nil {
@@ -181,7 +209,20 @@ func ResetRetryCount(stateDir, specName, phase string) error {
 	}
 
 	state.Reset()
-	return SaveRetryState(stateDir, state)
+	return saveRetryState(stateDir, state)
+}
+
+// TotalRetries sums the lifetime retry totals of a spec's phases. Comparing
+// the sum before and after a run gives the retries made by that run, even
+// though each phase's Count is reset when it succeeds.
+func TotalRetries(stateDir, specName string, phases []string) int {
+	total := 0
+	for _, phase := range phases {
+		if state, err := LoadRetryState(stateDir, specName, phase, 0); err == nil {
+			total += state.Total
+		}
+	}
+	return total
 }
 
 // loadStore loads the retry store from disk with backward-compatible parsing.
@@ -192,8 +233,7 @@ func ResetRetryCount(stateDir, specName, phase string) error {
 //  2. Copy legacy.PhaseStates entries to store.StageStates if not already present
 //  3. This allows old retry.json files to work without manual migration
 func loadStore(stateDir string) (*RetryStore, error) {
-	retryPath := filepath.Join(stateDir, "retry.json")
-	data, err := os.ReadFile(retryPath)
+	data, err := statestore.For(stateDir).Read(storeName)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, err
@@ -256,6 +296,16 @@ func LoadStageState(stateDir, specName string) (*StageExecutionState, error) {
 // This prevents partial writes from corrupting state on crash/interrupt.
 // Merges with existing store to preserve other specs' states.
 func SaveStageState(stateDir string, state *StageExecutionState) error {
+	unlock, err := lockStore(stateDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return saveStageState(stateDir, state)
+}
+
+// saveStageState is SaveStageState for callers holding the store lock.
+func saveStageState(stateDir string, state *StageExecutionState) error {
 	// Ensure state directory exists
 	if err := os.MkdirAll(stateDir, 0755); err != nil {
 		return fmt.Errorf("failed to create state directory: %w", err)
@@ -285,16 +335,9 @@ func SaveStageState(stateDir string, state *StageExecutionState) error {
 		return fmt.Errorf("failed to marshal stage state: %w", err)
 	}
 
-	// Write to temp file
-	retryPath := filepath.Join(stateDir, "retry.json")
-	tmpPath := retryPath + ".tmp"
-	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temp file: %w", err)
-	}
-
-	// Atomic rename
-	if err := os.Rename(tmpPath, retryPath); err != nil {
-		return fmt.Errorf("failed to rename temp file: %w", err)
+	// Atomic write, keeping the previous version for 'autospec undo'
+	if err := statestore.For(stateDir).Write(storeName, data); err != nil {
+		return fmt.Errorf("failed to write retry state: %w", err)
 	}
 
 	return nil
@@ -304,6 +347,12 @@ func SaveStageState(stateDir string, state *StageExecutionState) error {
 // Updates are persisted immediately. Idempotent: skips if phase already complete.
 // Linear search for duplicate detection (CompletedPhases typically <10 items).
 func MarkStageComplete(stateDir, specName string, phaseNumber int) error {
+	unlock, err := lockStore(stateDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	state, err := LoadStageState(stateDir, specName)
 	if err != nil {
 		return fmt.Errorf("loading stage state: %w", err)
@@ -328,7 +377,7 @@ func MarkStageComplete(stateDir, specName string, phaseNumber int) error {
 	state.CompletedPhases = append(state.CompletedPhases, phaseNumber)
 	state.LastPhaseAttempt = time.Now()
 
-	return SaveStageState(stateDir, state)
+	return saveStageState(stateDir, state)
 }
 
 // ResetStageState clears all stage tracking for a spec
@@ -337,6 +386,11 @@ func ResetStageState(stateDir, specName string) error {
 	if err := os.MkdirAll(stateDir, 0755); err != nil {
 		return fmt.Errorf("failed to create state directory: %w", err)
 	}
+	unlock, err := lockStore(stateDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	// Load existing store
 	store, err := loadStore(stateDir)
@@ -358,16 +412,9 @@ func ResetStageState(stateDir, specName string) error {
 		return fmt.Errorf("failed to marshal stage state: %w", err)
 	}
 
-	// Write to temp file
-	retryPath := filepath.Join(stateDir, "retry.json")
-	tmpPath := retryPath + ".tmp"
-	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temp file: %w", err)
-	}
-
-	// Atomic rename
-	if err := os.Rename(tmpPath, retryPath); err != nil {
-		return fmt.Errorf("failed to rename temp file: %w", err)
+	// Atomic write, keeping the previous version for 'autospec undo'
+	if err := statestore.For(stateDir).Write(storeName, data); err != nil {
+		return fmt.Errorf("failed to write retry state: %w", err)
 	}
 
 	return nil
@@ -400,6 +447,16 @@ func LoadTaskState(stateDir, specName string) (*TaskExecutionState, error) {
 
 // SaveTaskState persists task state atomically via temp file + rename
 func SaveTaskState(stateDir string, state *TaskExecutionState) error {
+	unlock, err := lockStore(stateDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return saveTaskState(stateDir, state)
+}
+
+// saveTaskState is SaveTaskState for callers holding the store lock.
+func saveTaskState(stateDir string, state *TaskExecutionState) error {
 	// Ensure state directory exists
 	if err := os.MkdirAll(stateDir, 0755); err != nil {
 		return fmt.Errorf("failed to create state directory: %w", err)
@@ -429,16 +486,9 @@ func SaveTaskState(stateDir string, state *TaskExecutionState) error {
 		return fmt.Errorf("failed to marshal task state: %w", err)
 	}
 
-	// Write to temp file
-	retryPath := filepath.Join(stateDir, "retry.json")
-	tmpPath := retryPath + ".tmp"
-	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temp file: %w", err)
-	}
-
-	// Atomic rename
-	if err := os.Rename(tmpPath, retryPath); err != nil {
-		return fmt.Errorf("failed to rename temp file: %w", err)
+	// Atomic write, keeping the previous version for 'autospec undo'
+	if err := statestore.For(stateDir).Write(storeName, data); err != nil {
+		return fmt.Errorf("failed to write retry state: %w", err)
 	}
 
 	return nil
@@ -447,6 +497,12 @@ func SaveTaskState(stateDir string, state *TaskExecutionState) error {
 // MarkTaskComplete adds a task ID to the completed_task_ids list
 // Updates are persisted immediately
 func MarkTaskComplete(stateDir, specName, taskID string) error {
+	unlock, err := lockStore(stateDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	state, err := LoadTaskState(stateDir, specName)
 	if err != nil {
 		return fmt.Errorf("loading task state: %w", err)
@@ -471,7 +527,36 @@ func MarkTaskComplete(stateDir, specName, taskID string) error {
 	state.CompletedTaskIDs = append(state.CompletedTaskIDs, taskID)
 	state.LastTaskAttempt = time.Now()
 
-	return SaveTaskState(stateDir, state)
+	return saveTaskState(stateDir, state)
+}
+
+// SetCurrentTask records the task the agent is working on in task-level
+// execution mode, so an interrupted run can resume from it.
+// Updates are persisted immediately
+func SetCurrentTask(stateDir, specName, taskID string, totalTasks int) error {
+	unlock, err := lockStore(stateDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := LoadTaskState(stateDir, specName)
+	if err != nil {
+		return fmt.Errorf("loading task state: %w", err)
+	}
+
+	if state == nil {
+		state = &TaskExecutionState{
+			SpecName:         specName,
+			CompletedTaskIDs: []string{},
+		}
+	}
+
+	state.CurrentTaskID = taskID
+	state.TotalTasks = totalTasks
+	state.LastTaskAttempt = time.Now()
+
+	return saveTaskState(stateDir, state)
 }
 
 // ResetTaskState clears all task tracking for a spec
@@ -480,6 +565,11 @@ func ResetTaskState(stateDir, specName string) error {
 	if err := os.MkdirAll(stateDir, 0755); err != nil {
 		return fmt.Errorf("failed to create state directory: %w", err)
 	}
+	unlock, err := lockStore(stateDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	// Load existing store
 	store, err := loadStore(stateDir)
@@ -501,16 +591,9 @@ func ResetTaskState(stateDir, specName string) error {
 		return fmt.Errorf("failed to marshal task state: %w", err)
 	}
 
-	// Write to temp file
-	retryPath := filepath.Join(stateDir, "retry.json")
-	tmpPath := retryPath + ".tmp"
-	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temp file: %w", err)
-	}
-
-	// Atomic rename
-	if err := os.Rename(tmpPath, retryPath); err != nil {
-		return fmt.Errorf("failed to rename temp file: %w", err)
+	// Atomic write, keeping the previous version for 'autospec undo'
+	if err := statestore.For(stateDir).Write(storeName, data); err != nil {
+		return fmt.Errorf("failed to write retry state: %w", err)
 	}
 
 	return nil
@@ -6,8 +6,10 @@ package retry
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -996,6 +998,22 @@ func TestMarkTaskComplete(t *testing.T) {
 	}
 }
 
+func TestSetCurrentTask(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	require.NoError(t, MarkTaskComplete(stateDir, "001-test", "T001"))
+	require.NoError(t, SetCurrentTask(stateDir, "001-test", "T002", 5))
+
+	state, err := LoadTaskState(stateDir, "001-test")
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.Equal(t, "T002", state.CurrentTaskID)
+	assert.Equal(t, 5, state.TotalTasks)
+	assert.Equal(t, []string{"T001"}, state.CompletedTaskIDs)
+	assert.False(t, state.LastTaskAttempt.IsZero())
+}
+
 func TestResetTaskState(t *testing.T) {
 	t.Run("reset existing state", func(t *testing.T) {
 		t.Parallel()
@@ -1364,10 +1382,28 @@ func TestResetRetryCount_AfterIncrements(t *testing.T) {
 	err = ResetRetryCount(stateDir, "test", "phase")
 	require.NoError(t, err)
 
-	// Load should show count=0
+	// Load should show count=0 but keep the lifetime total
 	loaded, err := LoadRetryState(stateDir, "test", "phase", 5)
 	require.NoError(t, err)
 	assert.Equal(t, 0, loaded.Count)
+	assert.Equal(t, 2, loaded.Total)
+}
+
+func TestTotalRetries(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	for _, phase := range []string{"plan", "implement", "implement"} {
+		_, err := IncrementRetryCount(stateDir, "001-auth", phase, 5)
+		require.NoError(t, err)
+	}
+	require.NoError(t, ResetRetryCount(stateDir, "001-auth", "implement"))
+	_, err := IncrementRetryCount(stateDir, "002-other", "plan", 5)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, TotalRetries(stateDir, "001-auth", []string{"plan", "tasks", "implement"}))
+	assert.Equal(t, 1, TotalRetries(stateDir, "001-auth", []string{"plan"}))
+	assert.Zero(t, TotalRetries(t.TempDir(), "001-auth", []string{"plan"}))
 }
 
 func TestSaveRetryState_DirectoryCreation(t *testing.T) {
@@ -1494,3 +1530,36 @@ func TestLoadStore_NilRetries(t *testing.T) {
 	require.NotNil(t, store)
 	assert.NotNil(t, store.Retries) // Should be initialized
 }
+
+// TestConcurrentUpdates_NoLostWrites checks that the store lock keeps
+// concurrent load-modify-save updates from overwriting each other.
+func TestConcurrentUpdates_NoLostWrites(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	const n = 10
+
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := IncrementRetryCount(stateDir, "001-spec", "implement", 2*n)
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, MarkTaskComplete(stateDir, "001-spec", fmt.Sprintf("T%03d", i)))
+		}()
+	}
+	wg.Wait()
+
+	state, err := LoadRetryState(stateDir, "001-spec", "implement", 2*n)
+	require.NoError(t, err)
+	assert.Equal(t, n, state.Count)
+	tasks, err := LoadTaskState(stateDir, "001-spec")
+	require.NoError(t, err)
+	require.NotNil(t, tasks)
+	assert.Len(t, tasks.CompletedTaskIDs, n)
+	assert.NoFileExists(t, filepath.Join(stateDir, "retry.json.lock"))
+}
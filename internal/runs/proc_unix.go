@@ -0,0 +1,23 @@
+//go:build !windows
+
+package runs
+
+import (
+	"syscall"
+)
+
+// detachAttr starts the process in a new session so it survives the
+// terminal closing and can be signalled as a process group.
+func detachAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
+
+// terminate signals the process group led by pid, so agents started by
+// the run are stopped with it.
+func terminate(pid int, force bool) error {
+	sig := syscall.SIGTERM
+	if force {
+		sig = syscall.SIGKILL
+	}
+	return syscall.Kill(-pid, sig)
+}
@@ -0,0 +1,64 @@
+//go:build !windows
+
+package runs
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waitExited waits for the run's process to exit.
+func waitExited(t *testing.T, run *Run) {
+	t.Helper()
+	require.Eventually(t, run.Exited, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestStart(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	run, err := Start(stateDir, StartOptions{
+		Executable: "sh",
+		Args:       []string{"-c", `echo "yes=$AUTOSPEC_YES"; echo "file=$` + EnvRunFile + `"; echo oops >&2`},
+		Command:    "implement",
+		Spec:       "003-auth",
+	})
+	require.NoError(t, err)
+	assert.Positive(t, run.PID)
+	assert.Equal(t, StatusRunning, run.Status)
+	waitExited(t, run)
+
+	data, err := os.ReadFile(run.Log)
+	require.NoError(t, err)
+	out := string(data)
+	assert.Contains(t, out, "yes=1\n")
+	assert.Contains(t, out, "oops\n")
+	assert.Contains(t, out, "file="+Path(stateDir, run.ID)+"\n")
+
+	// The script never recorded a result
+	loaded, err := Load(stateDir, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusLost, loaded.Status)
+	assert.Equal(t, "003-auth", loaded.Spec)
+}
+
+func TestKill(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	run, err := Start(stateDir, StartOptions{Executable: "sleep", Args: []string{"30"}, Command: "implement"})
+	require.NoError(t, err)
+
+	require.NoError(t, Kill(stateDir, run, false))
+	waitExited(t, run)
+	loaded, err := Load(stateDir, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusKilled, loaded.Status)
+	assert.NotNil(t, loaded.FinishedAt)
+
+	assert.ErrorContains(t, Kill(stateDir, loaded, true), "is not running (killed)")
+}
@@ -0,0 +1,334 @@
+// Package runs manages workflow runs started in the background with --detach.
+//
+// A detached run is a separate autospec process in its own session, with its
+// output written to a log file. Its metadata (pid, arguments, status) is kept
+// in <state_dir>/runs/<id>.yaml so later invocations can list, attach to or
+// stop it. The run records its own exit code when it finishes; a run whose
+// process is gone without doing so is reported as lost.
+package runs
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/filelock"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"gopkg.in/yaml.v3"
+)
+
+// DirName is the directory under the state dir holding run files and logs.
+const DirName = "runs"
+
+// EnvRunFile names the run file of a detached process. It is set in the
+// child's environment so it can record its exit code (see FinishFromEnv).
+const EnvRunFile = "AUTOSPEC_RUN_FILE"
+
+// maxFinishedRuns is how many finished runs are kept; older ones are removed
+// along with their logs when a new run starts.
+const maxFinishedRuns = 20
+
+// Status is the state of a detached run.
+type Status string
+
+// Run states.
+const (
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusKilled    Status = "killed"
+	// StatusLost marks a run whose process exited without recording a
+	// result, e.g. after a crash or SIGKILL.
+	StatusLost Status = "lost"
+)
+
+// Run is a detached autospec process.
+type Run struct {
+	ID         string     `yaml:"id" json:"id"`
+	Command    string     `yaml:"command" json:"command"`
+	Spec       string     `yaml:"spec,omitempty" json:"spec,omitempty"`
+	Args       []string   `yaml:"args" json:"args"`
+	Dir        string     `yaml:"dir" json:"dir"`
+	PID        int        `yaml:"pid" json:"pid"`
+	Log        string     `yaml:"log" json:"log"`
+	Status     Status     `yaml:"status" json:"status"`
+	ExitCode   *int       `yaml:"exit_code,omitempty" json:"exit_code,omitempty"`
+	StartedAt  time.Time  `yaml:"started_at" json:"started_at"`
+	FinishedAt *time.Time `yaml:"finished_at,omitempty" json:"finished_at,omitempty"`
+}
+
+// Dir returns the directory holding run files and logs.
+func Dir(stateDir string) string {
+	return filepath.Join(stateDir, DirName)
+}
+
+// Path returns the run file of the run with the given ID.
+func Path(stateDir, id string) string {
+	return filepath.Join(Dir(stateDir), id+".yaml")
+}
+
+// LogPath returns the output log of the run with the given ID.
+func LogPath(stateDir, id string) string {
+	return filepath.Join(Dir(stateDir), id+".log")
+}
+
+// StartOptions describe the process Start launches.
+type StartOptions struct {
+	// Executable is the autospec binary to run.
+	Executable string
+	// Args are the arguments, without the executable.
+	Args []string
+	// Command and Spec describe the run in listings.
+	Command string
+	Spec    string
+}
+
+// Start launches a detached process and records it as a run. The process
+// runs in its own session with stdin closed and stdout/stderr written to
+// the run's log, and skips confirmation prompts since nobody can answer them.
+func Start(stateDir string, opts StartOptions) (*Run, error) {
+	id, err := history.GenerateID()
+	if err != nil {
+		return nil, fmt.Errorf("generating run ID: %w", err)
+	}
+	if err := os.MkdirAll(Dir(stateDir), 0700); err != nil {
+		return nil, fmt.Errorf("creating runs directory: %w", err)
+	}
+	pruneFinished(stateDir)
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("getting working directory: %w", err)
+	}
+	run := &Run{
+		ID:        id,
+		Command:   opts.Command,
+		Spec:      opts.Spec,
+		Args:      opts.Args,
+		Dir:       dir,
+		Log:       LogPath(stateDir, id),
+		Status:    StatusRunning,
+		StartedAt: time.Now(),
+	}
+
+	logFile, err := os.OpenFile(run.Log, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("creating run log: %w", err)
+	}
+	defer logFile.Close()
+
+	runFile, err := filepath.Abs(Path(stateDir, id))
+	if err != nil {
+		return nil, fmt.Errorf("resolving run file: %w", err)
+	}
+	cmd := exec.Command(opts.Executable, opts.Args...)
+	cmd.Env = append(os.Environ(), "AUTOSPEC_YES=1", EnvRunFile+"="+runFile)
+	cmd.Stdout, cmd.Stderr = logFile, logFile
+	cmd.SysProcAttr = detachAttr()
+	// Save before starting so a fast child finds its run file
+	if err := Save(stateDir, run); err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		os.Remove(Path(stateDir, id))
+		return nil, fmt.Errorf("starting %s: %w", opts.Executable, err)
+	}
+	run.PID = cmd.Process.Pid
+	if err := Save(stateDir, run); err != nil {
+		return nil, err
+	}
+	// Reap the child if this process outlives it
+	go func() { _ = cmd.Wait() }()
+	return run, nil
+}
+
+// Save writes the run file atomically.
+func Save(stateDir string, run *Run) error {
+	return save(Path(stateDir, run.ID), run)
+}
+
+func save(path string, run *Run) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating runs directory: %w", err)
+	}
+	data, err := yaml.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("marshaling run: %w", err)
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("writing run file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("writing run file: %w", err)
+	}
+	return nil
+}
+
+func load(path string) (*Run, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var run Run
+	if err := yaml.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("parsing run file %s: %w", filepath.Base(path), err)
+	}
+	run.refresh()
+	return &run, nil
+}
+
+// Load returns the run with the given ID or unique ID prefix.
+func Load(stateDir, id string) (*Run, error) {
+	if run, err := load(Path(stateDir, id)); err == nil {
+		return run, nil
+	}
+	all, err := List(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	var matches []*Run
+	for _, run := range all {
+		if strings.HasPrefix(run.ID, id) {
+			matches = append(matches, run)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("run '%s' not found", id)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("run prefix '%s' is ambiguous (%d matches)", id, len(matches))
+	}
+}
+
+// List returns all runs, oldest first.
+func List(stateDir string) ([]*Run, error) {
+	entries, err := os.ReadDir(Dir(stateDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Run{}, nil
+		}
+		return nil, fmt.Errorf("reading runs directory: %w", err)
+	}
+
+	runs := []*Run{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".yaml" {
+			continue
+		}
+		run, err := load(filepath.Join(Dir(stateDir), entry.Name()))
+		if err != nil {
+			continue
+		}
+		runs = append(runs, run)
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].StartedAt.Before(runs[j].StartedAt)
+	})
+	return runs, nil
+}
+
+// Latest returns the most recent run that is still running, or the most
+// recent run when none is. It returns nil when there are no runs.
+func Latest(stateDir string) (*Run, error) {
+	all, err := List(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(all) - 1; i >= 0; i-- {
+		if all[i].Running() {
+			return all[i], nil
+		}
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	return all[len(all)-1], nil
+}
+
+// Running reports whether the run's process is still running.
+func (r *Run) Running() bool {
+	return r.Status == StatusRunning
+}
+
+// Exited reports whether the run's process is gone, whatever its status.
+func (r *Run) Exited() bool {
+	return r.PID <= 0 || !filelock.ProcessAlive(r.PID)
+}
+
+// refresh marks a running run as lost when its process no longer exists.
+func (r *Run) refresh() {
+	if r.Status == StatusRunning && r.PID > 0 && !filelock.ProcessAlive(r.PID) {
+		r.Status = StatusLost
+	}
+}
+
+// Kill stops the run's process and everything it started, with SIGTERM or,
+// when force is set, SIGKILL, and records the run as killed.
+func Kill(stateDir string, run *Run, force bool) error {
+	if !run.Running() {
+		return fmt.Errorf("run %s is not running (%s)", run.ID, run.Status)
+	}
+	if err := terminate(run.PID, force); err != nil {
+		return fmt.Errorf("stopping run %s (pid %d): %w", run.ID, run.PID, err)
+	}
+	now := time.Now()
+	run.Status = StatusKilled
+	run.FinishedAt = &now
+	return Save(stateDir, run)
+}
+
+// FinishFromEnv records exitCode in the run file named by EnvRunFile, if
+// this process is a detached run. Processes that inherit the variable, such
+// as autospec commands run by the agent, are ignored. A run already marked
+// killed keeps that status.
+func FinishFromEnv(exitCode int) error {
+	path := os.Getenv(EnvRunFile)
+	if path == "" {
+		return nil
+	}
+	run, err := load(path)
+	if err != nil {
+		return fmt.Errorf("loading run file: %w", err)
+	}
+	if run.PID != os.Getpid() {
+		return nil
+	}
+	now := time.Now()
+	run.ExitCode = &exitCode
+	run.FinishedAt = &now
+	switch {
+	case run.Status == StatusKilled:
+	case exitCode == 0:
+		run.Status = StatusCompleted
+	default:
+		run.Status = StatusFailed
+	}
+	return save(path, run)
+}
+
+// pruneFinished removes the oldest finished runs and their logs beyond
+// maxFinishedRuns.
+func pruneFinished(stateDir string) {
+	all, err := List(stateDir)
+	if err != nil {
+		return
+	}
+	var finished []*Run
+	for _, run := range all {
+		if !run.Running() {
+			finished = append(finished, run)
+		}
+	}
+	for i := 0; i < len(finished)-maxFinishedRuns; i++ {
+		os.Remove(Path(stateDir, finished[i].ID))
+		os.Remove(LogPath(stateDir, finished[i].ID))
+	}
+}
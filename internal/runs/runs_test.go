@@ -0,0 +1,156 @@
+package runs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var runsStart = time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+
+// finishedRun returns a completed run started offset after runsStart.
+func finishedRun(id string, offset time.Duration) *Run {
+	code := 0
+	finished := runsStart.Add(offset + time.Minute)
+	return &Run{
+		ID: id, Command: "implement", Status: StatusCompleted, ExitCode: &code,
+		StartedAt: runsStart.Add(offset), FinishedAt: &finished,
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	for i, id := range []string{"brave_fox_1", "brave_fox_2", "calm_owl_1"} {
+		require.NoError(t, Save(stateDir, finishedRun(id, time.Duration(i)*time.Hour)))
+	}
+
+	tests := map[string]struct {
+		id      string
+		wantID  string
+		wantErr string
+	}{
+		"exact ID":      {id: "brave_fox_2", wantID: "brave_fox_2"},
+		"unique prefix": {id: "calm", wantID: "calm_owl_1"},
+		"ambiguous":     {id: "brave", wantErr: "ambiguous (2 matches)"},
+		"unknown":       {id: "quiet", wantErr: "run 'quiet' not found"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			run, err := Load(stateDir, tt.id)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, run.ID)
+		})
+	}
+}
+
+func TestList_MarksDeadRunsLost(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	dead := &Run{ID: "dead_run", Status: StatusRunning, PID: 1 << 30, StartedAt: runsStart}
+	alive := &Run{ID: "this_run", Status: StatusRunning, PID: os.Getpid(), StartedAt: runsStart.Add(time.Minute)}
+	require.NoError(t, Save(stateDir, dead))
+	require.NoError(t, Save(stateDir, alive))
+	require.NoError(t, Save(stateDir, finishedRun("done_run", -time.Hour)))
+
+	all, err := List(stateDir)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, []string{"done_run", "dead_run", "this_run"}, []string{all[0].ID, all[1].ID, all[2].ID})
+	assert.Equal(t, StatusLost, all[1].Status)
+	assert.Equal(t, StatusRunning, all[2].Status)
+
+	latest, err := Latest(stateDir)
+	require.NoError(t, err)
+	assert.Equal(t, "this_run", latest.ID)
+}
+
+func TestLatest_NoRuns(t *testing.T) {
+	t.Parallel()
+
+	latest, err := Latest(t.TempDir())
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+}
+
+func TestFinishFromEnv(t *testing.T) {
+	// Cannot run in parallel - sets AUTOSPEC_RUN_FILE
+
+	tests := map[string]struct {
+		status   Status
+		exitCode int
+		want     Status
+	}{
+		"success":           {status: StatusRunning, exitCode: 0, want: StatusCompleted},
+		"failure":           {status: StatusRunning, exitCode: 3, want: StatusFailed},
+		"killed stays kill": {status: StatusKilled, exitCode: 1, want: StatusKilled},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			stateDir := t.TempDir()
+			require.NoError(t, Save(stateDir, &Run{ID: "brave_fox", Status: tt.status, PID: os.Getpid(), StartedAt: runsStart}))
+			t.Setenv(EnvRunFile, Path(stateDir, "brave_fox"))
+
+			require.NoError(t, FinishFromEnv(tt.exitCode))
+			run, err := Load(stateDir, "brave_fox")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, run.Status)
+			require.NotNil(t, run.ExitCode)
+			assert.Equal(t, tt.exitCode, *run.ExitCode)
+			assert.NotNil(t, run.FinishedAt)
+		})
+	}
+}
+
+func TestFinishFromEnv_IgnoresOtherProcesses(t *testing.T) {
+	// Cannot run in parallel - sets AUTOSPEC_RUN_FILE
+
+	t.Setenv(EnvRunFile, "")
+	assert.NoError(t, FinishFromEnv(1))
+
+	// An autospec command started by the run's agent inherits the variable
+	stateDir := t.TempDir()
+	require.NoError(t, Save(stateDir, &Run{ID: "brave_fox", Status: StatusRunning, PID: os.Getppid(), StartedAt: runsStart}))
+	t.Setenv(EnvRunFile, Path(stateDir, "brave_fox"))
+	require.NoError(t, FinishFromEnv(0))
+	run, err := Load(stateDir, "brave_fox")
+	require.NoError(t, err)
+	assert.Equal(t, StatusRunning, run.Status)
+	assert.Nil(t, run.ExitCode)
+}
+
+func TestPruneFinished(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	for i := 0; i < maxFinishedRuns+2; i++ {
+		id := "run_" + string(rune('a'+i))
+		require.NoError(t, Save(stateDir, finishedRun(id, time.Duration(i)*time.Minute)))
+		require.NoError(t, os.WriteFile(LogPath(stateDir, id), []byte("output\n"), 0600))
+	}
+	running := &Run{ID: "run_running", Status: StatusRunning, PID: os.Getpid(), StartedAt: runsStart.Add(-time.Hour)}
+	require.NoError(t, Save(stateDir, running))
+
+	pruneFinished(stateDir)
+
+	all, err := List(stateDir)
+	require.NoError(t, err)
+	assert.Len(t, all, maxFinishedRuns+1)
+	assert.NoFileExists(t, Path(stateDir, "run_a"))
+	assert.NoFileExists(t, filepath.Join(Dir(stateDir), "run_b.log"))
+	assert.FileExists(t, Path(stateDir, "run_c"))
+	assert.FileExists(t, Path(stateDir, "run_running"))
+}
@@ -0,0 +1,296 @@
+// Package scaffold detects a project's language and framework so that
+// 'autospec init' can seed configuration for its stack.
+// Related: internal/cli/config/init_scaffold.go, internal/spec/template.go
+// Tags: init, scaffold, detection, templates, constitution
+//
+// Detection looks for go.mod, package.json and pyproject.toml, in that
+// order, and reads them for a framework and the project's own test and lint
+// scripts. A detected Stack provides validation commands for the config,
+// spec templates for .autospec/templates and principles that guide the
+// constitution session.
+package scaffold
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// Stack describes a detected project type.
+type Stack struct {
+	// Language is "go", "node" or "python".
+	Language string
+	// Framework is the detected framework (e.g. "Cobra", "Next.js"), or empty.
+	Framework string
+	// Marker is the file the stack was detected from.
+	Marker string
+	// TestCommand runs the test suite (validation.tests.command).
+	TestCommand string
+	// TaskGate lists build/lint commands run after each task (validation.task_gate).
+	TaskGate []string
+	// Principles are suggested constitution principles for the stack.
+	Principles []string
+}
+
+// languageNames are the display names of the supported languages.
+var languageNames = map[string]string{
+	"go":     "Go",
+	"node":   "Node.js",
+	"python": "Python",
+}
+
+// Label returns a display name such as "Go (Cobra)".
+func (s *Stack) Label() string {
+	name := languageNames[s.Language]
+	if s.Framework != "" {
+		return fmt.Sprintf("%s (%s)", name, s.Framework)
+	}
+	return name
+}
+
+// Detect returns the stack of the project in dir, or nil if none of the
+// known manifests is present.
+func Detect(dir string) *Stack {
+	detectors := []struct {
+		marker string
+		detect func(dir string, data []byte) *Stack
+	}{
+		{"go.mod", detectGo},
+		{"package.json", detectNode},
+		{"pyproject.toml", detectPython},
+	}
+	for _, d := range detectors {
+		data, err := os.ReadFile(filepath.Join(dir, d.marker))
+		if err != nil {
+			continue
+		}
+		if stack := d.detect(dir, data); stack != nil {
+			stack.Marker = d.marker
+			return stack
+		}
+	}
+	return nil
+}
+
+// firstMatch returns the name paired with the first needle found in haystack.
+func firstMatch(haystack string, candidates [][2]string) string {
+	for _, c := range candidates {
+		if strings.Contains(haystack, c[0]) {
+			return c[1]
+		}
+	}
+	return ""
+}
+
+func detectGo(dir string, data []byte) *Stack {
+	stack := &Stack{
+		Language:    "go",
+		TestCommand: "go test ./...",
+		TaskGate:    []string{"go build ./...", "go vet ./..."},
+		Framework: firstMatch(string(data), [][2]string{
+			{"github.com/gin-gonic/gin", "Gin"},
+			{"github.com/labstack/echo", "Echo"},
+			{"github.com/go-chi/chi", "chi"},
+			{"github.com/gofiber/fiber", "Fiber"},
+			{"github.com/spf13/cobra", "Cobra"},
+		}),
+		Principles: []string{
+			"Errors are returned and wrapped with context (fmt.Errorf with %w), never ignored or panicked on",
+			"Packages stay small and focused; interfaces are defined where they are consumed",
+			"Every change keeps go build, go vet and go test ./... passing",
+			"Tests are table-driven and live next to the code they test",
+			"Code is gofmt-formatted and exported identifiers have doc comments",
+		},
+	}
+	for _, name := range []string{".golangci.yml", ".golangci.yaml", ".golangci.toml"} {
+		if fileExists(filepath.Join(dir, name)) {
+			stack.TaskGate = append(stack.TaskGate, "golangci-lint run")
+			break
+		}
+	}
+	return stack
+}
+
+// packageJSON holds the parts of package.json detection reads.
+type packageJSON struct {
+	Scripts         map[string]string `json:"scripts"`
+	Dependencies    map[string]string `json:"dependencies"`
+	DevDependencies map[string]string `json:"devDependencies"`
+}
+
+// npmDefaultTest is the test script npm init writes, which always fails.
+const npmDefaultTest = `echo "Error: no test specified"`
+
+func detectNode(dir string, data []byte) *Stack {
+	var pkg packageJSON
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil
+	}
+	stack := &Stack{
+		Language: "node",
+		Principles: []string{
+			"Code is type-checked; avoid any and unchecked type assertions",
+			"Async code handles rejections; no floating promises",
+			"Every change keeps the test, lint and type-check scripts passing",
+			"Dependencies are added deliberately and pinned by the lockfile",
+			"Modules export small, focused functions and components",
+		},
+	}
+	for _, c := range [][2]string{
+		{"next", "Next.js"},
+		{"@nestjs/core", "NestJS"},
+		{"nuxt", "Nuxt"},
+		{"@sveltejs/kit", "SvelteKit"},
+		{"react", "React"},
+		{"vue", "Vue"},
+		{"express", "Express"},
+		{"fastify", "Fastify"},
+	} {
+		if _, ok := pkg.Dependencies[c[0]]; ok {
+			stack.Framework = c[1]
+			break
+		}
+		if _, ok := pkg.DevDependencies[c[0]]; ok {
+			stack.Framework = c[1]
+			break
+		}
+	}
+
+	runner := nodePackageManager(dir)
+	if test := pkg.Scripts["test"]; test != "" && !strings.HasPrefix(test, npmDefaultTest) {
+		stack.TestCommand = runner + " run test"
+	}
+	for _, script := range []string{"lint", "typecheck", "type-check"} {
+		if pkg.Scripts[script] != "" {
+			stack.TaskGate = append(stack.TaskGate, runner+" run "+script)
+		}
+	}
+	return stack
+}
+
+// nodePackageManager picks the package manager from the lockfile.
+func nodePackageManager(dir string) string {
+	for _, lock := range [][2]string{
+		{"pnpm-lock.yaml", "pnpm"},
+		{"yarn.lock", "yarn"},
+		{"bun.lock", "bun"},
+		{"bun.lockb", "bun"},
+	} {
+		if fileExists(filepath.Join(dir, lock[0])) {
+			return lock[1]
+		}
+	}
+	return "npm"
+}
+
+func detectPython(dir string, data []byte) *Stack {
+	content := strings.ToLower(string(data))
+	stack := &Stack{
+		Language: "python",
+		Framework: firstMatch(content, [][2]string{
+			{"django", "Django"},
+			{"fastapi", "FastAPI"},
+			{"flask", "Flask"},
+			{"typer", "Typer"},
+			{"click", "Click"},
+		}),
+		Principles: []string{
+			"Public functions have type hints and docstrings",
+			"Errors are raised as specific exceptions, never silently swallowed",
+			"Every change keeps the test suite and linters passing",
+			"Tests use pytest with fixtures; no network or real services in unit tests",
+			"Dependencies are declared in pyproject.toml",
+		},
+	}
+
+	prefix := ""
+	switch {
+	case fileExists(filepath.Join(dir, "uv.lock")):
+		prefix = "uv run "
+	case fileExists(filepath.Join(dir, "poetry.lock")):
+		prefix = "poetry run "
+	}
+	if strings.Contains(content, "pytest") || fileExists(filepath.Join(dir, "tests")) {
+		stack.TestCommand = prefix + "pytest"
+	}
+	if strings.Contains(content, "ruff") {
+		stack.TaskGate = append(stack.TaskGate, prefix+"ruff check .")
+	}
+	if strings.Contains(content, "mypy") {
+		stack.TaskGate = append(stack.TaskGate, prefix+"mypy .")
+	}
+	return stack
+}
+
+// ConstitutionGuidance returns guidance for the constitution session that
+// seeds it with the stack's principles.
+func (s *Stack) ConstitutionGuidance() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "This is a %s project (detected from %s). Start from these principles, adapted to what the codebase actually does:\n", s.Label(), s.Marker)
+	for _, p := range s.Principles {
+		fmt.Fprintf(&b, "- %s\n", p)
+	}
+	if s.TestCommand != "" {
+		fmt.Fprintf(&b, "Tests run with: %s\n", s.TestCommand)
+	}
+	return b.String()
+}
+
+// Templates returns the stack's spec templates as file name → content.
+func (s *Stack) Templates() (map[string][]byte, error) {
+	dir := path.Join("templates", s.Language)
+	entries, err := fs.ReadDir(templateFS, dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s spec templates: %w", s.Language, err)
+	}
+	templates := make(map[string][]byte, len(entries))
+	for _, entry := range entries {
+		data, err := templateFS.ReadFile(path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading spec template %s: %w", entry.Name(), err)
+		}
+		templates[entry.Name()] = data
+	}
+	return templates, nil
+}
+
+// WriteTemplates writes the stack's spec templates to dir, leaving existing
+// files alone. Returns the names of the templates written.
+func (s *Stack) WriteTemplates(dir string) ([]string, error) {
+	templates, err := s.Templates()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating templates directory: %w", err)
+	}
+
+	var written []string
+	for _, name := range slices.Sorted(maps.Keys(templates)) {
+		target := filepath.Join(dir, name)
+		if _, err := os.Stat(target); err == nil || !errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err := os.WriteFile(target, templates[name], 0644); err != nil {
+			return written, fmt.Errorf("writing spec template %s: %w", name, err)
+		}
+		written = append(written, strings.TrimSuffix(name, filepath.Ext(name)))
+	}
+	return written, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,152 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		files map[string]string
+		want  *Stack
+	}{
+		"go with cobra and golangci": {
+			files: map[string]string{
+				"go.mod":        "module example.com/tool\n\nrequire github.com/spf13/cobra v1.10.1\n",
+				".golangci.yml": "linters: {}\n",
+			},
+			want: &Stack{
+				Language:    "go",
+				Framework:   "Cobra",
+				Marker:      "go.mod",
+				TestCommand: "go test ./...",
+				TaskGate:    []string{"go build ./...", "go vet ./...", "golangci-lint run"},
+			},
+		},
+		"next.js with pnpm": {
+			files: map[string]string{
+				"package.json":   `{"scripts": {"test": "vitest run", "lint": "next lint", "typecheck": "tsc --noEmit"}, "dependencies": {"next": "15.0.0", "react": "19.0.0"}}`,
+				"pnpm-lock.yaml": "",
+			},
+			want: &Stack{
+				Language:    "node",
+				Framework:   "Next.js",
+				Marker:      "package.json",
+				TestCommand: "pnpm run test",
+				TaskGate:    []string{"pnpm run lint", "pnpm run typecheck"},
+			},
+		},
+		"npm default test script is ignored": {
+			files: map[string]string{
+				"package.json": `{"scripts": {"test": "echo \"Error: no test specified\" && exit 1"}}`,
+			},
+			want: &Stack{Language: "node", Marker: "package.json"},
+		},
+		"fastapi with uv": {
+			files: map[string]string{
+				"pyproject.toml": "[project]\ndependencies = [\"fastapi\"]\n[dependency-groups]\ndev = [\"pytest\", \"ruff\"]\n",
+				"uv.lock":        "",
+			},
+			want: &Stack{
+				Language:    "python",
+				Framework:   "FastAPI",
+				Marker:      "pyproject.toml",
+				TestCommand: "uv run pytest",
+				TaskGate:    []string{"uv run ruff check ."},
+			},
+		},
+		"invalid package.json falls through": {
+			files: map[string]string{"package.json": "{"},
+		},
+		"unknown project": {
+			files: map[string]string{"README.md": "# hi\n"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Detect(writeFiles(t, tt.files))
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			got.Principles = nil
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStack_Label(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Go (Cobra)", (&Stack{Language: "go", Framework: "Cobra"}).Label())
+	assert.Equal(t, "Python", (&Stack{Language: "python"}).Label())
+}
+
+func TestStack_ConstitutionGuidance(t *testing.T) {
+	t.Parallel()
+
+	stack := Detect(writeFiles(t, map[string]string{"go.mod": "module example.com/svc\n"}))
+	require.NotNil(t, stack)
+
+	guidance := stack.ConstitutionGuidance()
+	assert.Contains(t, guidance, "This is a Go project (detected from go.mod)")
+	assert.Contains(t, guidance, "- Every change keeps go build, go vet and go test ./... passing")
+	assert.Contains(t, guidance, "Tests run with: go test ./...")
+}
+
+func TestStack_WriteTemplates(t *testing.T) {
+	t.Parallel()
+
+	for _, language := range []string{"go", "node", "python"} {
+		t.Run(language, func(t *testing.T) {
+			t.Parallel()
+
+			dir := filepath.Join(t.TempDir(), "templates")
+			written, err := (&Stack{Language: language}).WriteTemplates(dir)
+			require.NoError(t, err)
+			require.NotEmpty(t, written)
+
+			for _, name := range written {
+				tmpl, err := spec.LoadTemplate(dir, name)
+				require.NoError(t, err, "template %s must be a valid spec template", name)
+				assert.NotEmpty(t, tmpl.Description)
+				assert.NotEmpty(t, tmpl.Checklist)
+			}
+		})
+	}
+}
+
+func TestStack_WriteTemplatesKeepsExisting(t *testing.T) {
+	t.Parallel()
+
+	dir := writeFiles(t, map[string]string{"http-handler.yaml": "description: mine\n"})
+	written, err := (&Stack{Language: "go"}).WriteTemplates(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cli-command"}, written)
+
+	data, err := os.ReadFile(filepath.Join(dir, "http-handler.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "description: mine\n", string(data))
+}
@@ -0,0 +1,238 @@
+package session
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/statestore"
+)
+
+// liveExt is the extension of live logs. A live log exists while an agent
+// runs and is removed once its transcript is saved.
+const liveExt = ".live"
+
+// staleLiveAge is how long a live log may go unmodified before Prune treats
+// it as left behind by a killed process and removes it.
+const staleLiveAge = 24 * time.Hour
+
+// liveRecord is one line of a live log: the session metadata (written at the
+// start and again when it changes) or a chunk of output.
+type liveRecord struct {
+	Session *Session `json:"session,omitempty"`
+	Chunk   *Chunk   `json:"chunk,omitempty"`
+}
+
+// LivePath returns the live log of the session with the given ID.
+func LivePath(stateDir, id string) string {
+	return filepath.Join(Dir(stateDir), id+liveExt)
+}
+
+// StartLive mirrors the transcript to a live log in stateDir while the agent
+// runs, so another process can follow the output (see OpenLive). It assigns
+// the session its ID, which Save then keeps.
+func (r *Recorder) StartLive(stateDir string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.session.ID == "" {
+		id, err := history.GenerateID()
+		if err != nil {
+			return fmt.Errorf("generating session ID: %w", err)
+		}
+		r.session.ID = id
+	}
+	if err := os.MkdirAll(Dir(stateDir), 0700); err != nil {
+		return fmt.Errorf("creating sessions directory: %w", err)
+	}
+	f, err := os.OpenFile(LivePath(stateDir, r.session.ID), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("creating live log: %w", err)
+	}
+	r.live = f
+	r.writeLiveSession()
+	return nil
+}
+
+// writeLiveSession appends the session metadata to the live log.
+// The caller must hold r.mu.
+func (r *Recorder) writeLiveSession() {
+	header := *r.session
+	header.Output = nil
+	r.writeLive(liveRecord{Session: &header})
+}
+
+// writeLive appends a record to the live log, if any. A failed write stops
+// the live log rather than the recording. The caller must hold r.mu.
+func (r *Recorder) writeLive(record liveRecord) {
+	if r.live == nil {
+		return
+	}
+	data, err := json.Marshal(record)
+	if err == nil {
+		_, err = r.live.Write(append(data, '\n'))
+	}
+	if err != nil {
+		r.closeLive()
+	}
+}
+
+// closeLive closes the live log. The caller must hold r.mu.
+func (r *Recorder) closeLive() {
+	if r.live != nil {
+		r.live.Close()
+		r.live = nil
+	}
+}
+
+// ListLive returns the sessions whose agent is still running, oldest first.
+// Each holds the metadata from its live log but no output.
+func ListLive(stateDir string) ([]*Session, error) {
+	entries, err := os.ReadDir(Dir(stateDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Session{}, nil
+		}
+		return nil, fmt.Errorf("reading sessions directory: %w", err)
+	}
+
+	var sessions []*Session
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != liveExt {
+			continue
+		}
+		id := strings.TrimSuffix(entry.Name(), liveExt)
+		if Finished(stateDir, id) {
+			continue
+		}
+		s, err := readLiveHeader(LivePath(stateDir, id))
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, s)
+	}
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].StartedAt.Before(sessions[j].StartedAt)
+	})
+	return sessions, nil
+}
+
+// readLiveHeader reads the session metadata from the first line of a live
+// log, without reading its output.
+func readLiveHeader(path string) (*Session, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	line, err := bufio.NewReader(f).ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("reading live log: %w", err)
+	}
+	var record liveRecord
+	if err := json.Unmarshal(line, &record); err != nil || record.Session == nil {
+		return nil, fmt.Errorf("live log %s has no session header", filepath.Base(path))
+	}
+	return record.Session, nil
+}
+
+// Finished reports whether the transcript of the session has been saved.
+func Finished(stateDir, id string) bool {
+	_, err := statestore.For(stateDir).Read(docName(id))
+	return err == nil
+}
+
+// LiveLog reads a live log while the agent writes to it.
+type LiveLog struct {
+	// Session is the latest metadata recorded in the log.
+	Session *Session
+
+	f       *os.File
+	r       *bufio.Reader
+	partial []byte  // Start of a line the agent has not finished writing
+	pending []Chunk // Output read with the metadata, returned by the first Next
+}
+
+// OpenLive opens the live log of a running session and reads its metadata.
+func OpenLive(stateDir, id string) (*LiveLog, error) {
+	f, err := os.Open(LivePath(stateDir, id))
+	if err != nil {
+		return nil, err
+	}
+	l := &LiveLog{f: f, r: bufio.NewReader(f)}
+	chunks, err := l.Next()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if l.Session == nil {
+		f.Close()
+		return nil, fmt.Errorf("live log %s has no session header", id)
+	}
+	l.pending = chunks
+	return l, nil
+}
+
+// Next returns the output written since the last call. Lines still being
+// written are held back until they are complete. The log is removed once
+// the transcript is saved, but an open LiveLog can still read to its end.
+func (l *LiveLog) Next() ([]Chunk, error) {
+	chunks := l.pending
+	l.pending = nil
+	for {
+		line, err := l.r.ReadBytes('\n')
+		if err == io.EOF {
+			l.partial = append(l.partial, line...)
+			return chunks, nil
+		}
+		if err != nil {
+			return chunks, fmt.Errorf("reading live log: %w", err)
+		}
+		if len(l.partial) > 0 {
+			line = append(l.partial, line...)
+			l.partial = nil
+		}
+
+		var record liveRecord
+		if err := json.Unmarshal(bytes.TrimSpace(line), &record); err != nil {
+			continue
+		}
+		if record.Session != nil {
+			l.Session = record.Session
+		}
+		if record.Chunk != nil {
+			chunks = append(chunks, *record.Chunk)
+		}
+	}
+}
+
+// Close closes the live log.
+func (l *LiveLog) Close() error {
+	return l.f.Close()
+}
+
+// pruneStaleLive removes live logs of agents that were killed before their
+// transcript could be saved.
+func pruneStaleLive(stateDir string, now time.Time) {
+	entries, err := os.ReadDir(Dir(stateDir))
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) != liveExt {
+			continue
+		}
+		if info, err := entry.Info(); err == nil && now.Sub(info.ModTime()) > staleLiveAge {
+			os.Remove(filepath.Join(Dir(stateDir), entry.Name()))
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Package session tests live logs of running agent sessions.
+// Related: internal/session/live.go, internal/session/recorder.go
+// Tags: session, transcripts, live, follow
+
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_StartLive(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	rec := NewRecorder(&Session{Spec: "003-auth", Stage: "implement", Phase: 2, Attempt: 1})
+	require.NoError(t, rec.StartLive(stateDir))
+
+	running, err := ListLive(stateDir)
+	require.NoError(t, err)
+	require.Len(t, running, 1)
+	id := running[0].ID
+	assert.NotEmpty(t, id)
+	assert.Equal(t, "implement phase 2", running[0].Label())
+
+	live, err := OpenLive(stateDir, id)
+	require.NoError(t, err)
+	defer live.Close()
+	assert.False(t, live.Session.StreamJSON)
+
+	rec.SetStreamJSON()
+	_, _ = rec.Stdout().Write([]byte("hello\n"))
+	_, _ = rec.Stderr().Write([]byte("warning\n"))
+	chunks, err := live.Next()
+	require.NoError(t, err)
+	assert.True(t, live.Session.StreamJSON)
+	require.Len(t, chunks, 2)
+	assert.Equal(t, "hello\n", chunks[0].Text)
+	assert.Equal(t, StreamStderr, chunks[1].Stream)
+
+	// Nothing new until the agent writes again
+	chunks, err = live.Next()
+	require.NoError(t, err)
+	assert.Empty(t, chunks)
+
+	_, _ = rec.Stdout().Write([]byte("done\n"))
+	s := rec.Finish(nil)
+	require.NoError(t, Save(stateDir, s, 0))
+	assert.Equal(t, id, s.ID)
+	assert.True(t, Finished(stateDir, id))
+	assert.NoFileExists(t, LivePath(stateDir, id))
+
+	// An open live log still reads to its end after it is removed
+	chunks, err = live.Next()
+	require.NoError(t, err)
+	require.Len(t, chunks, 1)
+	assert.Equal(t, "done\n", chunks[0].Text)
+
+	running, err = ListLive(stateDir)
+	require.NoError(t, err)
+	assert.Empty(t, running)
+}
+
+func TestLiveLog_HoldsBackPartialLines(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(Dir(stateDir), 0700))
+	path := LivePath(stateDir, "brave_fox")
+	require.NoError(t, os.WriteFile(path, []byte(`{"session":{"id":"brave_fox","stage":"plan","attempt":1}}`+"\n"+`{"chunk":{"stream":"stdout","te`), 0600))
+
+	live, err := OpenLive(stateDir, "brave_fox")
+	require.NoError(t, err)
+	defer live.Close()
+	chunks, err := live.Next()
+	require.NoError(t, err)
+	assert.Empty(t, chunks)
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`xt":"plan ready\n"}}` + "\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	chunks, err = live.Next()
+	require.NoError(t, err)
+	require.Len(t, chunks, 1)
+	assert.Equal(t, "plan ready\n", chunks[0].Text)
+}
+
+func TestPrune_RemovesStaleLiveLogs(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(Dir(stateDir), 0700))
+	stale := LivePath(stateDir, "old_run")
+	fresh := LivePath(stateDir, "new_run")
+	require.NoError(t, os.WriteFile(stale, []byte("{}\n"), 0600))
+	require.NoError(t, os.WriteFile(fresh, []byte("{}\n"), 0600))
+	old := time.Now().Add(-2 * staleLiveAge)
+	require.NoError(t, os.Chtimes(stale, old, old))
+
+	require.NoError(t, Prune(stateDir, 10))
+	assert.NoFileExists(t, stale)
+	assert.FileExists(t, filepath.Join(Dir(stateDir), "new_run.live"))
+}
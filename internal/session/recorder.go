@@ -0,0 +1,164 @@
+package session
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxOutputBytes caps the output kept per transcript. Beyond it, the first
+// and last half are kept and the middle is dropped, so a long run still
+// shows how it started and how it ended.
+const MaxOutputBytes = 4 << 20
+
+// Recorder captures agent output into a Session while the agent runs.
+// Stdout and Stderr may be written concurrently.
+type Recorder struct {
+	mu       sync.Mutex
+	session  *Session
+	exitCode *int
+	now      func() time.Time
+
+	limit     int     // Output bytes kept; half for the head and half for the tail
+	headBytes int     // Bytes in session.Output, the head of the transcript
+	tail      []Chunk // Most recent output once the head is full
+	tailBytes int
+
+	live *os.File // Live log mirroring every write, while set (see StartLive)
+}
+
+// NewRecorder starts recording the given session. StartedAt is set to now.
+func NewRecorder(s *Session) *Recorder {
+	return newRecorder(s, time.Now, MaxOutputBytes)
+}
+
+func newRecorder(s *Session, now func() time.Time, limit int) *Recorder {
+	s.StartedAt = now()
+	return &Recorder{session: s, now: now, limit: limit}
+}
+
+// Stdout returns a writer that appends to the transcript as stdout.
+func (r *Recorder) Stdout() io.Writer {
+	return streamWriter{r: r, stream: StreamStdout}
+}
+
+// Stderr returns a writer that appends to the transcript as stderr.
+func (r *Recorder) Stderr() io.Writer {
+	return streamWriter{r: r, stream: StreamStderr}
+}
+
+// SetExitCode records the exit code reported by the agent process.
+func (r *Recorder) SetExitCode(code int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exitCode = &code
+}
+
+// SetStreamJSON marks stdout as raw stream-json events, so viewers can
+// format it the way it was displayed.
+func (r *Recorder) SetStreamJSON() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.session.StreamJSON = true
+	r.writeLiveSession()
+}
+
+// Finish stops recording and returns the completed session. When the agent
+// reported no exit code, a failed run is recorded as -1.
+func (r *Recorder) Finish(err error) *Session {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closeLive()
+	s := r.session
+	if s.OmittedBytes > 0 && len(r.tail) > 0 {
+		s.Output = append(s.Output, Chunk{
+			OffsetMS: r.tail[0].OffsetMS,
+			Stream:   StreamStderr,
+			Text:     fmt.Sprintf("\n[autospec: %d bytes of output omitted]\n", s.OmittedBytes),
+		})
+	}
+	s.Output = append(s.Output, r.tail...)
+	r.tail, r.tailBytes = nil, 0
+	s.Duration = r.now().Sub(s.StartedAt).String()
+	switch {
+	case r.exitCode != nil:
+		s.ExitCode = *r.exitCode
+	case err != nil:
+		s.ExitCode = -1
+	}
+	if err != nil {
+		s.Error = err.Error()
+	}
+	return s
+}
+
+// write appends a chunk to the head until it holds half the limit, then to
+// the tail, which drops its oldest output to stay within the other half.
+func (r *Recorder) write(stream Stream, p []byte) {
+	if len(p) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	offset := r.now().Sub(r.session.StartedAt).Milliseconds()
+	text := string(p)
+	r.writeLive(liveRecord{Chunk: &Chunk{OffsetMS: offset, Stream: stream, Text: text}})
+	if r.limit <= 0 || r.headBytes < r.limit/2 {
+		r.session.Output = appendChunk(r.session.Output, offset, stream, text)
+		r.headBytes += len(text)
+		return
+	}
+	r.tail = appendChunk(r.tail, offset, stream, text)
+	r.tailBytes += len(text)
+	r.trimTail(r.limit - r.headBytes)
+}
+
+// trimTail drops the oldest tail output until the tail fits in budget bytes.
+func (r *Recorder) trimTail(budget int) {
+	budget = max(budget, 0)
+	for r.tailBytes > budget && len(r.tail) > 0 {
+		excess := r.tailBytes - budget
+		first := &r.tail[0]
+		if len(first.Text) <= excess {
+			r.tailBytes -= len(first.Text)
+			r.session.OmittedBytes += int64(len(first.Text))
+			r.tail = r.tail[1:]
+			continue
+		}
+		// Cut on a rune boundary so the transcript stays valid UTF-8
+		for excess < len(first.Text) && !utf8.RuneStart(first.Text[excess]) {
+			excess++
+		}
+		first.Text = first.Text[excess:]
+		r.tailBytes -= excess
+		r.session.OmittedBytes += int64(excess)
+	}
+}
+
+// appendChunk appends text, merging it into the last chunk when both are on
+// the same stream within the same millisecond.
+func appendChunk(chunks []Chunk, offset int64, stream Stream, text string) []Chunk {
+	if n := len(chunks); n > 0 {
+		last := &chunks[n-1]
+		if last.Stream == stream && last.OffsetMS == offset {
+			last.Text += text
+			return chunks
+		}
+	}
+	return append(chunks, Chunk{OffsetMS: offset, Stream: stream, Text: text})
+}
+
+type streamWriter struct {
+	r      *Recorder
+	stream Stream
+}
+
+func (w streamWriter) Write(p []byte) (int, error) {
+	w.r.write(w.stream, p)
+	return len(p), nil
+}
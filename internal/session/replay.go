@@ -0,0 +1,51 @@
+package session
+
+import (
+	"io"
+	"time"
+)
+
+// ReplayOptions controls how a transcript is played back.
+type ReplayOptions struct {
+	// Speed multiplies playback speed: 1 replays in real time, 2 twice as fast.
+	// Zero or negative writes all output at once.
+	Speed float64
+	// MaxPause caps the wait between two chunks so long silent stretches
+	// (e.g. an agent thinking) don't stall playback. Zero means no cap.
+	MaxPause time.Duration
+
+	// sleep is replaced in tests to avoid real delays.
+	sleep func(time.Duration)
+}
+
+// Replay writes the session's output to stdout and stderr in recorded order,
+// pausing between chunks according to their recorded offsets.
+func Replay(s *Session, stdout, stderr io.Writer, opts ReplayOptions) error {
+	sleep := opts.sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+
+	var prev int64
+	for _, c := range s.Output {
+		if opts.Speed > 0 {
+			pause := time.Duration(float64(time.Duration(c.OffsetMS-prev)*time.Millisecond) / opts.Speed)
+			if opts.MaxPause > 0 && pause > opts.MaxPause {
+				pause = opts.MaxPause
+			}
+			if pause > 0 {
+				sleep(pause)
+			}
+		}
+		prev = c.OffsetMS
+
+		w := stdout
+		if c.Stream == StreamStderr {
+			w = stderr
+		}
+		if _, err := io.WriteString(w, c.Text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
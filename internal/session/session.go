@@ -0,0 +1,245 @@
+// Package session persists agent transcripts so a failed phase can be
+// inspected after the fact. Each agent run is stored as one JSON document,
+// sessions/<id>.json in the state store (see statestore), holding the prompt,
+// the timestamped stdout and stderr output, the exit code and the duration. While the agent runs, its output
+// is also mirrored to a <id>.live log that 'autospec logs -f' follows.
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/statestore"
+)
+
+// DirName is the directory under the state directory that holds transcripts.
+const DirName = "sessions"
+
+// Stream identifies which output stream a chunk was written to.
+type Stream string
+
+const (
+	// StreamStdout is the agent's standard output
+	StreamStdout Stream = "stdout"
+	// StreamStderr is the agent's standard error
+	StreamStderr Stream = "stderr"
+)
+
+// Chunk is one write of agent output, timestamped relative to the session start.
+type Chunk struct {
+	// OffsetMS is the number of milliseconds since the session started.
+	OffsetMS int64 `json:"offset_ms"`
+	// Stream is stdout or stderr.
+	Stream Stream `json:"stream"`
+	// Text is the written output.
+	Text string `json:"text"`
+}
+
+// Session is the transcript of a single agent run.
+type Session struct {
+	// ID is a unique identifier in adjective_noun_YYYYMMDD_HHMMSS format.
+	ID string `json:"id"`
+	// Spec is the spec the agent ran against (may be empty for specify).
+	Spec string `json:"spec,omitempty"`
+	// Stage is the workflow stage name (e.g., "plan", "implement").
+	Stage string `json:"stage"`
+	// Phase is the implementation phase number, when running per phase.
+	Phase int `json:"phase,omitempty"`
+	// TaskID is the task identifier, when running per task.
+	TaskID string `json:"task_id,omitempty"`
+	// Attempt is 1 for the first run of a stage and increases with each retry.
+	Attempt int `json:"attempt"`
+	// Command is the agent command line as displayed to the user.
+	Command string `json:"command,omitempty"`
+	// Prompt is the full prompt sent to the agent, including retry context.
+	Prompt string `json:"prompt"`
+	// StartedAt is when the agent was started.
+	StartedAt time.Time `json:"started_at"`
+	// Duration is the run time in Go duration format (e.g., "2m15.123s").
+	Duration string `json:"duration"`
+	// ExitCode is the agent's exit code (-1 when it could not be started or timed out).
+	ExitCode int `json:"exit_code"`
+	// Error is the execution error, empty on success.
+	Error string `json:"error,omitempty"`
+	// StreamJSON is true when stdout holds the agent's raw stream-json events.
+	StreamJSON bool `json:"stream_json,omitempty"`
+	// Output is the interleaved stdout and stderr in the order it was written.
+	Output []Chunk `json:"output"`
+	// OmittedBytes counts output dropped from the middle of a transcript that
+	// exceeded MaxOutputBytes. A marker chunk shows where it was cut.
+	OmittedBytes int64 `json:"omitted_bytes,omitempty"`
+}
+
+// Failed reports whether the agent run ended with an error.
+func (s *Session) Failed() bool {
+	return s.Error != "" || s.ExitCode != 0
+}
+
+// Label describes what the session ran, e.g. "implement phase 2".
+func (s *Session) Label() string {
+	switch {
+	case s.TaskID != "":
+		return fmt.Sprintf("%s task %s", s.Stage, s.TaskID)
+	case s.Phase > 0:
+		return fmt.Sprintf("%s phase %d", s.Stage, s.Phase)
+	default:
+		return s.Stage
+	}
+}
+
+// Text returns all output written to the given stream.
+func (s *Session) Text(stream Stream) string {
+	var b strings.Builder
+	for _, c := range s.Output {
+		if c.Stream == stream {
+			b.WriteString(c.Text)
+		}
+	}
+	return b.String()
+}
+
+// Dir returns the sessions directory inside the given state directory.
+func Dir(stateDir string) string {
+	return filepath.Join(stateDir, DirName)
+}
+
+// Save writes the session to <stateDir>/sessions/<id>.json, assigning an ID
+// if the session has none, and prunes the oldest sessions beyond maxSessions.
+// A maxSessions of 0 keeps every session.
+func Save(stateDir string, s *Session, maxSessions int) error {
+	if s.ID == "" {
+		id, err := history.GenerateID()
+		if err != nil {
+			return fmt.Errorf("generating session ID: %w", err)
+		}
+		s.ID = id
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling session: %w", err)
+	}
+
+	// The store keeps transcripts private, since they can contain secrets
+	// the agent printed
+	if err := statestore.For(stateDir).Write(docName(s.ID), data); err != nil {
+		return fmt.Errorf("writing session file: %w", err)
+	}
+	os.Remove(LivePath(stateDir, s.ID))
+
+	return Prune(stateDir, maxSessions)
+}
+
+// List returns every stored session, oldest first.
+// Returns an empty list if the sessions directory does not exist.
+// Unreadable files are skipped.
+func List(stateDir string) ([]*Session, error) {
+	docs, err := statestore.For(stateDir).List(DirName)
+	if err != nil {
+		return nil, fmt.Errorf("reading sessions directory: %w", err)
+	}
+
+	names := make([]string, 0, len(docs))
+	for name := range docs {
+		if filepath.Ext(name) == ".json" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	sessions := make([]*Session, 0, len(names))
+	for _, name := range names {
+		s, err := decode(name, docs[name])
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, s)
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].StartedAt.Before(sessions[j].StartedAt)
+	})
+	return sessions, nil
+}
+
+// Load returns the session with the given ID. A unique ID prefix is accepted,
+// so "brave_fox" finds "brave_fox_20260102_030405".
+func Load(stateDir, id string) (*Session, error) {
+	if id == "" {
+		return nil, fmt.Errorf("session ID is required")
+	}
+	if data, err := statestore.For(stateDir).Read(docName(id)); err == nil {
+		if s, err := decode(id+".json", data); err == nil {
+			return s, nil
+		}
+	}
+
+	sessions, err := List(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	var matches []*Session
+	for _, s := range sessions {
+		if strings.HasPrefix(s.ID, id) {
+			matches = append(matches, s)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("session %q not found", id)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("session ID %q is ambiguous (%d matches)", id, len(matches))
+	}
+}
+
+// Prune removes the oldest sessions so that at most maxSessions remain.
+// A maxSessions of 0 disables pruning.
+func Prune(stateDir string, maxSessions int) error {
+	if maxSessions <= 0 {
+		return nil
+	}
+	pruneStaleLive(stateDir, time.Now())
+	sessions, err := List(stateDir)
+	if err != nil {
+		return err
+	}
+	for len(sessions) > maxSessions {
+		if err := statestore.For(stateDir).Delete(docName(sessions[0].ID)); err != nil {
+			return fmt.Errorf("removing old session: %w", err)
+		}
+		sessions = sessions[1:]
+	}
+	return nil
+}
+
+// Remove deletes the stored session with the given ID and its live log, if
+// any. Removing a missing session is not an error.
+func Remove(stateDir, id string) error {
+	if err := statestore.For(stateDir).Delete(docName(id)); err != nil {
+		return fmt.Errorf("removing session: %w", err)
+	}
+	os.Remove(LivePath(stateDir, id))
+	return nil
+}
+
+// docName returns the state store document of the session with the given ID.
+func docName(id string) string {
+	return DirName + "/" + id + ".json"
+}
+
+// decode parses a single session file.
+func decode(name string, data []byte) (*Session, error) {
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing session file %s: %w", name, err)
+	}
+	return &s, nil
+}
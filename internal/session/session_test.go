@@ -0,0 +1,241 @@
+// Package session tests transcript storage, recording and replay.
+// Related: internal/session/session.go, internal/session/recorder.go, internal/session/replay.go
+// Tags: session, transcripts, storage, replay
+
+package session
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStart = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func saveTestSession(t *testing.T, stateDir, id string, startOffset time.Duration) {
+	t.Helper()
+	s := &Session{ID: id, Stage: "plan", StartedAt: testStart.Add(startOffset)}
+	require.NoError(t, Save(stateDir, s, 0))
+}
+
+func TestSaveAndList(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	saveTestSession(t, stateDir, "late_owl_20260102_030505", time.Minute)
+	saveTestSession(t, stateDir, "brave_fox_20260102_030405", 0)
+
+	s := &Session{Stage: "implement", Phase: 2, StartedAt: testStart.Add(2 * time.Minute)}
+	require.NoError(t, Save(stateDir, s, 0))
+	assert.NotEmpty(t, s.ID, "Save assigns an ID")
+	assert.FileExists(t, filepath.Join(stateDir, DirName, s.ID+".json"))
+
+	// Non-session files are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(Dir(stateDir), "broken.json"), []byte("{"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(Dir(stateDir), "notes.txt"), []byte("x"), 0644))
+
+	sessions, err := List(stateDir)
+	require.NoError(t, err)
+	require.Len(t, sessions, 3)
+	assert.Equal(t, "brave_fox_20260102_030405", sessions[0].ID, "oldest first")
+	assert.Equal(t, "late_owl_20260102_030505", sessions[1].ID)
+	assert.Equal(t, "implement phase 2", sessions[2].Label())
+}
+
+func TestList_MissingDir(t *testing.T) {
+	t.Parallel()
+
+	sessions, err := List(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	saveTestSession(t, stateDir, "brave_fox_20260102_030405", 0)
+	saveTestSession(t, stateDir, "brave_fox_20260102_030406", time.Second)
+	saveTestSession(t, stateDir, "calm_owl_20260102_030407", 2*time.Second)
+
+	tests := map[string]struct {
+		id      string
+		wantID  string
+		wantErr string
+	}{
+		"exact id":         {id: "brave_fox_20260102_030405", wantID: "brave_fox_20260102_030405"},
+		"unique prefix":    {id: "calm", wantID: "calm_owl_20260102_030407"},
+		"ambiguous prefix": {id: "brave_fox", wantErr: "ambiguous (2 matches)"},
+		"not found":        {id: "quiet_elk", wantErr: `session "quiet_elk" not found`},
+		"empty id":         {id: "", wantErr: "session ID is required"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			s, err := Load(stateDir, tt.id)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, s.ID)
+		})
+	}
+}
+
+func TestSave_PrunesOldest(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	for i, id := range []string{"a_a_1", "b_b_2", "c_c_3"} {
+		s := &Session{ID: id, Stage: "plan", StartedAt: testStart.Add(time.Duration(i) * time.Minute)}
+		require.NoError(t, Save(stateDir, s, 2))
+	}
+
+	sessions, err := List(stateDir)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, "b_b_2", sessions[0].ID)
+	assert.Equal(t, "c_c_3", sessions[1].ID)
+}
+
+func TestRecorder_TruncatesMiddleOfLongOutput(t *testing.T) {
+	t.Parallel()
+
+	now := testStart
+	rec := newRecorder(&Session{Stage: "implement"}, func() time.Time { return now }, 20)
+	for i := range 10 {
+		now = now.Add(time.Millisecond)
+		_, _ = rec.Stdout().Write([]byte(fmt.Sprintf("line %d\n", i)))
+	}
+	s := rec.Finish(nil)
+
+	var text strings.Builder
+	for _, c := range s.Output {
+		text.WriteString(c.Text)
+	}
+	assert.Equal(t, "line 0\nline 1\n\n[autospec: 50 bytes of output omitted]\nine 9\n", text.String())
+	assert.Equal(t, int64(50), s.OmittedBytes)
+}
+
+func TestRecorder_TruncationKeepsValidUTF8(t *testing.T) {
+	t.Parallel()
+
+	rec := newRecorder(&Session{}, time.Now, 5)
+	_, _ = rec.Stdout().Write([]byte("ab"))
+	_, _ = rec.Stdout().Write([]byte("ééé"))
+	s := rec.Finish(nil)
+
+	for _, c := range s.Output {
+		assert.True(t, utf8.ValidString(c.Text), "chunk %q", c.Text)
+	}
+	assert.Equal(t, "é", s.Output[len(s.Output)-1].Text)
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		exitCode     *int
+		err          error
+		wantExitCode int
+		wantError    string
+		wantFailed   bool
+	}{
+		"success": {exitCode: intPtr(0)},
+		"agent exit code": {
+			exitCode:     intPtr(2),
+			err:          errors.New("agent claude exited with code 2"),
+			wantExitCode: 2,
+			wantError:    "agent claude exited with code 2",
+			wantFailed:   true,
+		},
+		"no exit code on error": {
+			err:          errors.New("timed out"),
+			wantExitCode: -1,
+			wantError:    "timed out",
+			wantFailed:   true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			now := testStart
+			rec := newRecorder(&Session{Stage: "implement"}, func() time.Time { return now }, MaxOutputBytes)
+			_, _ = rec.Stdout().Write([]byte("hello "))
+			_, _ = rec.Stdout().Write([]byte("world\n"))
+			now = now.Add(1500 * time.Millisecond)
+			_, _ = rec.Stderr().Write([]byte("warning\n"))
+			_, _ = rec.Stdout().Write([]byte("done\n"))
+			if tt.exitCode != nil {
+				rec.SetExitCode(*tt.exitCode)
+			}
+			now = now.Add(time.Second)
+
+			s := rec.Finish(tt.err)
+			assert.Equal(t, testStart, s.StartedAt)
+			assert.Equal(t, "2.5s", s.Duration)
+			assert.Equal(t, tt.wantExitCode, s.ExitCode)
+			assert.Equal(t, tt.wantError, s.Error)
+			assert.Equal(t, tt.wantFailed, s.Failed())
+			assert.Equal(t, []Chunk{
+				{OffsetMS: 0, Stream: StreamStdout, Text: "hello world\n"},
+				{OffsetMS: 1500, Stream: StreamStderr, Text: "warning\n"},
+				{OffsetMS: 1500, Stream: StreamStdout, Text: "done\n"},
+			}, s.Output)
+			assert.Equal(t, "hello world\ndone\n", s.Text(StreamStdout))
+			assert.Equal(t, "warning\n", s.Text(StreamStderr))
+		})
+	}
+}
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+
+	s := &Session{Output: []Chunk{
+		{OffsetMS: 0, Stream: StreamStdout, Text: "a"},
+		{OffsetMS: 1000, Stream: StreamStderr, Text: "b"},
+		{OffsetMS: 61000, Stream: StreamStdout, Text: "c"},
+	}}
+
+	tests := map[string]struct {
+		opts       ReplayOptions
+		wantPauses []time.Duration
+	}{
+		"instant":        {opts: ReplayOptions{}},
+		"real time":      {opts: ReplayOptions{Speed: 1}, wantPauses: []time.Duration{time.Second, time.Minute}},
+		"double speed":   {opts: ReplayOptions{Speed: 2}, wantPauses: []time.Duration{500 * time.Millisecond, 30 * time.Second}},
+		"capped pauses":  {opts: ReplayOptions{Speed: 1, MaxPause: 2 * time.Second}, wantPauses: []time.Duration{time.Second, 2 * time.Second}},
+		"negative speed": {opts: ReplayOptions{Speed: -1}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var pauses []time.Duration
+			tt.opts.sleep = func(d time.Duration) { pauses = append(pauses, d) }
+			var stdout, stderr bytes.Buffer
+
+			require.NoError(t, Replay(s, &stdout, &stderr, tt.opts))
+			assert.Equal(t, "ac", stdout.String())
+			assert.Equal(t, "b", stderr.String())
+			assert.Equal(t, tt.wantPauses, pauses)
+		})
+	}
+}
+
+func intPtr(n int) *int { return &n }
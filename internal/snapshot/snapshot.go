@@ -0,0 +1,358 @@
+// Package snapshot keeps copies of a spec's artifacts from before each
+// workflow stage changed them, so a bad regeneration of spec.yaml, plan.yaml
+// or tasks.yaml can be rolled back without digging through git history.
+// Related: internal/workflow/snapshot.go, internal/cli/util/snapshots.go
+// Tags: snapshot, artifacts, restore, rollback, state
+//
+// Each snapshot is a directory under <state_dir>/snapshots/<spec>/<id>/
+// holding the artifact files as they were and a snapshot.json describing
+// them. Snapshots identical to the spec's latest one are not stored again,
+// and the oldest are pruned beyond the configured limit.
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+	"github.com/ariel-frischer/autospec/internal/filelock"
+	"github.com/ariel-frischer/autospec/internal/history"
+)
+
+// DirName is the directory under the state directory that holds snapshots.
+const DirName = "snapshots"
+
+// metaFile describes a snapshot inside its directory.
+const metaFile = "snapshot.json"
+
+// Artifacts lists the files that are snapshotted, in document order.
+var Artifacts = []string{"spec.yaml", "plan.yaml", "tasks.yaml"}
+
+// Snapshot describes one saved set of artifacts.
+type Snapshot struct {
+	// ID is a unique identifier in adjective_noun_YYYYMMDD_HHMMSS format.
+	ID string `json:"id"`
+	// Spec is the spec directory name, e.g. "003-auth".
+	Spec string `json:"spec"`
+	// Stage is the stage about to run when the snapshot was taken, or
+	// "restore" for the state saved before a restore.
+	Stage string `json:"stage"`
+	// CreatedAt is when the snapshot was taken.
+	CreatedAt time.Time `json:"created_at"`
+	// Files are the artifacts saved, a subset of Artifacts.
+	Files []string `json:"files"`
+}
+
+// Dir returns the directory holding the snapshots of spec.
+func Dir(stateDir, spec string) string {
+	return filepath.Join(stateDir, DirName, spec)
+}
+
+// path returns the directory of snapshot s.
+func (s *Snapshot) path(stateDir string) string {
+	return filepath.Join(Dir(stateDir, s.Spec), s.ID)
+}
+
+// Take saves the artifacts currently in specDir before stage runs and prunes
+// the spec's oldest snapshots beyond maxSnapshots. It returns nil without
+// saving when maxSnapshots is 0, the spec has no artifacts yet, or they are
+// unchanged since the latest snapshot.
+func Take(stateDir, specDir, stage string, maxSnapshots int) (*Snapshot, error) {
+	if maxSnapshots <= 0 {
+		return nil, nil
+	}
+	s, err := take(stateDir, specDir, stage)
+	if err != nil || s == nil {
+		return s, err
+	}
+	return s, Prune(stateDir, s.Spec, maxSnapshots)
+}
+
+// take saves the artifacts in specDir unless they match the latest snapshot.
+func take(stateDir, specDir, stage string) (*Snapshot, error) {
+	contents := readArtifacts(specDir)
+	if len(contents) == 0 {
+		return nil, nil
+	}
+
+	spec := filepath.Base(specDir)
+	existing, err := List(stateDir, spec)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(existing); n > 0 && sameContents(existing[n-1].read(stateDir), contents) {
+		return nil, nil
+	}
+
+	id, err := history.GenerateID()
+	if err != nil {
+		return nil, fmt.Errorf("generating snapshot ID: %w", err)
+	}
+	s := &Snapshot{ID: id, Spec: spec, Stage: stage, CreatedAt: time.Now()}
+	for _, name := range Artifacts {
+		if _, ok := contents[name]; ok {
+			s.Files = append(s.Files, name)
+		}
+	}
+	if err := s.write(stateDir, contents); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// write stores the snapshot directory. The metadata is written last, so a
+// directory without it is an interrupted snapshot that List ignores.
+func (s *Snapshot) write(stateDir string, contents map[string][]byte) error {
+	dir := s.path(stateDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating snapshot directory: %w", err)
+	}
+	for _, name := range s.Files {
+		if err := os.WriteFile(filepath.Join(dir, name), contents[name], 0644); err != nil {
+			os.RemoveAll(dir)
+			return fmt.Errorf("writing snapshot of %s: %w", name, err)
+		}
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		os.RemoveAll(dir)
+		return fmt.Errorf("marshaling snapshot: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, metaFile), data, 0644); err != nil {
+		os.RemoveAll(dir)
+		return fmt.Errorf("writing snapshot metadata: %w", err)
+	}
+	return nil
+}
+
+// List returns the snapshots of spec, oldest first. Returns an empty list if
+// the spec has none; unreadable snapshots are skipped.
+func List(stateDir, spec string) ([]*Snapshot, error) {
+	entries, err := os.ReadDir(Dir(stateDir, spec))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Snapshot{}, nil
+		}
+		return nil, fmt.Errorf("reading snapshots directory: %w", err)
+	}
+
+	snapshots := make([]*Snapshot, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		s, err := readMeta(filepath.Join(Dir(stateDir, spec), entry.Name(), metaFile))
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, s)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedAt.Before(snapshots[j].CreatedAt)
+	})
+	return snapshots, nil
+}
+
+// Load returns the snapshot of spec with the given ID. A unique ID prefix is
+// accepted, so "brave_fox" finds "brave_fox_20260102_030405".
+func Load(stateDir, spec, id string) (*Snapshot, error) {
+	if id == "" {
+		return nil, fmt.Errorf("snapshot ID is required")
+	}
+	snapshots, err := List(stateDir, spec)
+	if err != nil {
+		return nil, err
+	}
+	var matches []*Snapshot
+	for _, s := range snapshots {
+		if s.ID == id {
+			return s, nil
+		}
+		if strings.HasPrefix(s.ID, id) {
+			matches = append(matches, s)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("snapshot %q not found for spec %s", id, spec)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("snapshot ID %q is ambiguous (%d matches)", id, len(matches))
+	}
+}
+
+// ReadFile returns the saved content of one of the snapshot's files.
+func (s *Snapshot) ReadFile(stateDir, name string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(s.path(stateDir), name))
+}
+
+// read returns the content of every file in the snapshot.
+func (s *Snapshot) read(stateDir string) map[string][]byte {
+	contents := make(map[string][]byte, len(s.Files))
+	for _, name := range s.Files {
+		if data, err := s.ReadFile(stateDir, name); err == nil {
+			contents[name] = data
+		}
+	}
+	return contents
+}
+
+// Restore writes the snapshot's files back into specDir. files limits the
+// restore to some of them (nil = all). The current artifacts are snapshotted
+// first, even when snapshots are disabled, so a restore can itself be rolled
+// back. Returns the restored files.
+func Restore(stateDir, specDir string, s *Snapshot, files []string, maxSnapshots int) ([]string, error) {
+	if len(files) == 0 {
+		files = s.Files
+	}
+	for _, name := range files {
+		if !slices.Contains(s.Files, name) {
+			return nil, fmt.Errorf("snapshot %s has no %s (it has %s)", s.ID, name, strings.Join(s.Files, ", "))
+		}
+	}
+
+	if _, err := take(stateDir, specDir, "restore"); err != nil {
+		return nil, fmt.Errorf("saving current artifacts before restore: %w", err)
+	}
+	var restored []string
+	for _, name := range Artifacts {
+		if !slices.Contains(files, name) {
+			continue
+		}
+		data, err := s.ReadFile(stateDir, name)
+		if err != nil {
+			return restored, fmt.Errorf("reading snapshot of %s: %w", name, err)
+		}
+		path := filepath.Join(specDir, name)
+		err = filelock.With(path, func() error {
+			return atomicfile.WriteFile(path, data, 0644)
+		})
+		if err != nil {
+			return restored, fmt.Errorf("restoring %s: %w", name, err)
+		}
+		restored = append(restored, name)
+	}
+	// Prune only now, since the restored snapshot may be the oldest
+	return restored, Prune(stateDir, s.Spec, maxSnapshots)
+}
+
+// Prune removes the oldest snapshots of spec so that at most maxSnapshots
+// remain. A maxSnapshots of 0 disables pruning.
+func Prune(stateDir, spec string, maxSnapshots int) error {
+	if maxSnapshots <= 0 {
+		return nil
+	}
+	snapshots, err := List(stateDir, spec)
+	if err != nil {
+		return err
+	}
+	for len(snapshots) > maxSnapshots {
+		if err := os.RemoveAll(snapshots[0].path(stateDir)); err != nil {
+			return fmt.Errorf("removing old snapshot: %w", err)
+		}
+		snapshots = snapshots[1:]
+	}
+	return nil
+}
+
+// Specs returns the specs that have snapshots, sorted.
+func Specs(stateDir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(stateDir, DirName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading snapshots directory: %w", err)
+	}
+	var specs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			specs = append(specs, entry.Name())
+		}
+	}
+	return specs, nil
+}
+
+// DiskUsage returns the bytes taken by the snapshots of spec.
+func DiskUsage(stateDir, spec string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(Dir(stateDir, spec), func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("measuring snapshots of %s: %w", spec, err)
+	}
+	return size, nil
+}
+
+// RemoveAll deletes every snapshot of spec.
+func RemoveAll(stateDir, spec string) error {
+	if err := os.RemoveAll(Dir(stateDir, spec)); err != nil {
+		return fmt.Errorf("removing snapshots of %s: %w", spec, err)
+	}
+	return nil
+}
+
+// readArtifacts returns the content of each artifact present in specDir.
+func readArtifacts(specDir string) map[string][]byte {
+	contents := make(map[string][]byte, len(Artifacts))
+	for _, name := range Artifacts {
+		if data, err := os.ReadFile(filepath.Join(specDir, name)); err == nil {
+			contents[name] = data
+		}
+	}
+	return contents
+}
+
+// sameContents reports whether two sets of artifact contents are identical.
+func sameContents(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, data := range a {
+		other, ok := b[name]
+		if !ok || !bytes.Equal(data, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// readMeta decodes a snapshot.json file.
+func readMeta(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing snapshot metadata %s: %w", path, err)
+	}
+	if s.ID == "" || s.Spec == "" {
+		return nil, errors.New("snapshot metadata is missing its ID or spec")
+	}
+	return &s, nil
+}
@@ -0,0 +1,192 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSpec creates a spec directory with the given artifacts.
+func newSpec(t *testing.T, files map[string]string) string {
+	t.Helper()
+	specDir := filepath.Join(t.TempDir(), "003-auth")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(specDir, name), []byte(content), 0o644))
+	}
+	return specDir
+}
+
+func writeArtifact(t *testing.T, specDir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, name), []byte(content), 0o644))
+}
+
+func TestTake(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		files        map[string]string
+		maxSnapshots int
+		wantFiles    []string
+	}{
+		"saves existing artifacts": {
+			files:        map[string]string{"tasks.yaml": "t", "spec.yaml": "s", "notes.md": "n"},
+			maxSnapshots: 5,
+			wantFiles:    []string{"spec.yaml", "tasks.yaml"},
+		},
+		"disabled": {
+			files:        map[string]string{"spec.yaml": "s"},
+			maxSnapshots: 0,
+		},
+		"no artifacts yet": {
+			files:        map[string]string{},
+			maxSnapshots: 5,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			stateDir := t.TempDir()
+			specDir := newSpec(t, tt.files)
+
+			s, err := Take(stateDir, specDir, "plan", tt.maxSnapshots)
+			require.NoError(t, err)
+			if tt.wantFiles == nil {
+				assert.Nil(t, s)
+				return
+			}
+			require.NotNil(t, s)
+			assert.Equal(t, "003-auth", s.Spec)
+			assert.Equal(t, "plan", s.Stage)
+			assert.Equal(t, tt.wantFiles, s.Files)
+
+			loaded, err := Load(stateDir, "003-auth", s.ID[:len(s.ID)-3])
+			require.NoError(t, err)
+			assert.Equal(t, s.ID, loaded.ID)
+			data, err := loaded.ReadFile(stateDir, "spec.yaml")
+			require.NoError(t, err)
+			assert.Equal(t, "s", string(data))
+		})
+	}
+}
+
+func TestTake_SkipsUnchanged(t *testing.T) {
+	t.Parallel()
+	stateDir := t.TempDir()
+	specDir := newSpec(t, map[string]string{"spec.yaml": "v1"})
+
+	first, err := Take(stateDir, specDir, "plan", 5)
+	require.NoError(t, err)
+	require.NotNil(t, first)
+	again, err := Take(stateDir, specDir, "tasks", 5)
+	require.NoError(t, err)
+	assert.Nil(t, again)
+
+	writeArtifact(t, specDir, "plan.yaml", "p1")
+	changed, err := Take(stateDir, specDir, "tasks", 5)
+	require.NoError(t, err)
+	require.NotNil(t, changed)
+
+	snapshots, err := List(stateDir, "003-auth")
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 2)
+}
+
+func TestTake_Prunes(t *testing.T) {
+	t.Parallel()
+	stateDir := t.TempDir()
+	specDir := newSpec(t, nil)
+
+	var ids []string
+	for _, content := range []string{"v1", "v2", "v3", "v4"} {
+		writeArtifact(t, specDir, "spec.yaml", content)
+		s, err := Take(stateDir, specDir, "clarify", 2)
+		require.NoError(t, err)
+		require.NotNil(t, s)
+		ids = append(ids, s.ID)
+		time.Sleep(2 * time.Millisecond) // Keep CreatedAt ordering unambiguous
+	}
+
+	snapshots, err := List(stateDir, "003-auth")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, ids[2:], []string{snapshots[0].ID, snapshots[1].ID})
+}
+
+func TestRestore(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		files        []string
+		wantRestored []string
+		wantSpec     string
+		wantPlan     string
+		wantErr      string
+	}{
+		"all files": {
+			wantRestored: []string{"spec.yaml", "plan.yaml"},
+			wantSpec:     "spec v1",
+			wantPlan:     "plan v1",
+		},
+		"only plan": {
+			files:        []string{"plan.yaml"},
+			wantRestored: []string{"plan.yaml"},
+			wantSpec:     "spec v2",
+			wantPlan:     "plan v1",
+		},
+		"file not in snapshot": {
+			files:   []string{"tasks.yaml"},
+			wantErr: "has no tasks.yaml",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			stateDir := t.TempDir()
+			specDir := newSpec(t, map[string]string{"spec.yaml": "spec v1", "plan.yaml": "plan v1"})
+			s, err := Take(stateDir, specDir, "plan", 5)
+			require.NoError(t, err)
+			writeArtifact(t, specDir, "spec.yaml", "spec v2")
+			writeArtifact(t, specDir, "plan.yaml", "plan v2")
+
+			restored, err := Restore(stateDir, specDir, s, tt.files, 5)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRestored, restored)
+			spec, _ := os.ReadFile(filepath.Join(specDir, "spec.yaml"))
+			plan, _ := os.ReadFile(filepath.Join(specDir, "plan.yaml"))
+			assert.Equal(t, tt.wantSpec, string(spec))
+			assert.Equal(t, tt.wantPlan, string(plan))
+
+			// The replaced artifacts were saved so the restore can be undone
+			snapshots, err := List(stateDir, "003-auth")
+			require.NoError(t, err)
+			require.Len(t, snapshots, 2)
+			assert.Equal(t, "restore", snapshots[1].Stage)
+			saved, err := snapshots[1].ReadFile(stateDir, "plan.yaml")
+			require.NoError(t, err)
+			assert.Equal(t, "plan v2", string(saved))
+		})
+	}
+}
+
+func TestList_SkipsIncompleteSnapshots(t *testing.T) {
+	t.Parallel()
+	stateDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(Dir(stateDir, "003-auth"), "interrupted"), 0o755))
+
+	snapshots, err := List(stateDir, "003-auth")
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+
+	_, err = Load(stateDir, "003-auth", "interrupted")
+	assert.ErrorContains(t, err, "not found")
+}
@@ -0,0 +1,97 @@
+package spec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ArchiveDirName is the subdirectory of the specs directory that holds
+// archived specs. Its name doesn't match the NNN-name pattern, so archived
+// specs are skipped by DetectCurrentSpec, GetSpecDirectory and spec listings.
+const ArchiveDirName = "archive"
+
+// ArchiveDir returns the directory holding archived specs.
+func ArchiveDir(specsDir string) string {
+	return filepath.Join(specsDir, ArchiveDirName)
+}
+
+// ArchiveSpec moves a spec directory into the archive and returns its new
+// path. Unless force is set, only specs whose feature.status is Completed
+// can be archived.
+func ArchiveSpec(specsDir, specIdentifier string, force bool) (string, error) {
+	directory, err := GetSpecDirectory(specsDir, specIdentifier)
+	if err != nil {
+		return "", err
+	}
+	if !specDirPattern.MatchString(filepath.Base(directory)) {
+		return "", fmt.Errorf("%s is not a spec directory", directory)
+	}
+	if !force {
+		status, err := readFeatureStatus(directory)
+		if err != nil {
+			return "", err
+		}
+		if status != "Completed" {
+			return "", fmt.Errorf("spec %s is not completed (status: %s); use --force to archive it anyway",
+				filepath.Base(directory), status)
+		}
+	}
+
+	if err := os.MkdirAll(ArchiveDir(specsDir), 0755); err != nil {
+		return "", fmt.Errorf("creating archive directory: %w", err)
+	}
+	return moveSpec(directory, filepath.Join(ArchiveDir(specsDir), filepath.Base(directory)))
+}
+
+// UnarchiveSpec moves an archived spec back into the specs directory and
+// returns its restored path.
+func UnarchiveSpec(specsDir, specIdentifier string) (string, error) {
+	directory, err := GetSpecDirectory(ArchiveDir(specsDir), specIdentifier)
+	if err != nil {
+		return "", fmt.Errorf("finding archived spec: %w", err)
+	}
+	return moveSpec(directory, filepath.Join(specsDir, filepath.Base(directory)))
+}
+
+// ListArchivedSpecs returns the names of archived specs, sorted.
+func ListArchivedSpecs(specsDir string) ([]string, error) {
+	names, err := listSpecDirs(ArchiveDir(specsDir))
+	if err != nil {
+		return nil, fmt.Errorf("reading archive directory: %w", err)
+	}
+	return names, nil
+}
+
+// moveSpec renames a spec directory, refusing to overwrite an existing one.
+func moveSpec(from, to string) (string, error) {
+	if _, err := os.Stat(to); err == nil {
+		return "", fmt.Errorf("%s already exists", to)
+	}
+	if err := os.Rename(from, to); err != nil {
+		return "", fmt.Errorf("moving spec: %w", err)
+	}
+	return to, nil
+}
+
+// readFeatureStatus returns feature.status from a spec's spec.yaml.
+func readFeatureStatus(specDir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(specDir, "spec.yaml"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read spec.yaml: %w", err)
+	}
+	var doc struct {
+		Feature struct {
+			Status string `yaml:"status"`
+		} `yaml:"feature"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", fmt.Errorf("failed to parse spec.yaml: %w", err)
+	}
+	if doc.Feature.Status == "" {
+		return "unknown", nil
+	}
+	return doc.Feature.Status, nil
+}
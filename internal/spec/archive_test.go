@@ -0,0 +1,165 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSpecDir creates specsDir/name/spec.yaml with the given feature status.
+func writeSpecDir(t *testing.T, specsDir, name, status string) string {
+	t.Helper()
+	dir := filepath.Join(specsDir, name)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	content := "feature:\n  branch: \"" + name + "\"\n  status: \"" + status + "\"\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "spec.yaml"), []byte(content), 0644))
+	return dir
+}
+
+func TestArchiveSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status     string
+		identifier string
+		force      bool
+		existing   bool // An archived spec with the same name already exists
+		wantErr    string
+	}{
+		"completed spec by number": {
+			status:     "Completed",
+			identifier: "003",
+		},
+		"completed spec by name": {
+			status:     "Completed",
+			identifier: "user-auth",
+		},
+		"incomplete spec is refused": {
+			status:     "In Progress",
+			identifier: "003",
+			wantErr:    "not completed (status: In Progress)",
+		},
+		"incomplete spec with force": {
+			status:     "Draft",
+			identifier: "003",
+			force:      true,
+		},
+		"unknown spec": {
+			status:     "Completed",
+			identifier: "999",
+			wantErr:    "spec directory not found",
+		},
+		"archive directory itself": {
+			status:     "Completed",
+			identifier: ArchiveDirName,
+			existing:   true,
+			wantErr:    "not a spec directory",
+		},
+		"already archived": {
+			status:     "Completed",
+			identifier: "003",
+			existing:   true,
+			wantErr:    "already exists",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specsDir := t.TempDir()
+			source := writeSpecDir(t, specsDir, "003-user-auth", tt.status)
+			if tt.existing {
+				writeSpecDir(t, ArchiveDir(specsDir), "003-user-auth", "Completed")
+			}
+
+			got, err := ArchiveSpec(specsDir, tt.identifier, tt.force)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.DirExists(t, source)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(specsDir, ArchiveDirName, "003-user-auth"), got)
+			assert.DirExists(t, got)
+			assert.NoDirExists(t, source)
+		})
+	}
+}
+
+func TestUnarchiveSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		identifier string
+		conflict   bool // An active spec with the same name exists
+		wantErr    string
+	}{
+		"by number":     {identifier: "003"},
+		"by full name":  {identifier: "003-user-auth"},
+		"not archived":  {identifier: "004", wantErr: "finding archived spec"},
+		"name conflict": {identifier: "003", conflict: true, wantErr: "already exists"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specsDir := t.TempDir()
+			archived := writeSpecDir(t, ArchiveDir(specsDir), "003-user-auth", "Completed")
+			if tt.conflict {
+				writeSpecDir(t, specsDir, "003-user-auth", "Draft")
+			}
+
+			got, err := UnarchiveSpec(specsDir, tt.identifier)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.DirExists(t, archived)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(specsDir, "003-user-auth"), got)
+			assert.FileExists(t, filepath.Join(got, "spec.yaml"))
+			assert.NoDirExists(t, archived)
+		})
+	}
+}
+
+func TestListArchivedSpecs(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	names, err := ListArchivedSpecs(specsDir)
+	require.NoError(t, err)
+	assert.Empty(t, names, "missing archive directory lists nothing")
+
+	writeSpecDir(t, ArchiveDir(specsDir), "010-later", "Completed")
+	writeSpecDir(t, ArchiveDir(specsDir), "002-earlier", "Completed")
+	require.NoError(t, os.WriteFile(filepath.Join(ArchiveDir(specsDir), "README.md"), nil, 0644))
+
+	names, err = ListArchivedSpecs(specsDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"002-earlier", "010-later"}, names)
+}
+
+func TestDetectCurrentSpec_SkipsArchived(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	writeSpecDir(t, specsDir, "001-active", "Draft")
+	writeSpecDir(t, ArchiveDir(specsDir), "002-done", "Completed")
+
+	// Make the archive the most recently modified entry
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(ArchiveDir(specsDir), future, future))
+
+	metadata, err := DetectCurrentSpec(specsDir)
+	require.NoError(t, err)
+	assert.Equal(t, "001", metadata.Number)
+}
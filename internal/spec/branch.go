@@ -8,7 +8,7 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/ariel-frischer/autospec/internal/git"
+	"github.com/ariel-frischer/autospec/internal/vcs"
 )
 
 // MaxBranchLength is GitHub's maximum branch name length in bytes
@@ -28,12 +28,37 @@ var StopWords = map[string]bool{
 	"want": true, "need": true, "add": true, "get": true, "set": true,
 }
 
-// branchNumberPattern matches feature branch numbers like "001", "002", etc.
-var branchNumberPattern = regexp.MustCompile(`^(\d{3})-`)
+// specNumberPattern matches spec numbers: three digits, optionally after an
+// uppercase namespace ("001", "API-001", "WEB2-014").
+const specNumberPattern = `(?:[A-Z][A-Z0-9]*-)?\d{3}`
+
+// branchNumberPattern matches feature branch numbers like "001" or "API-001"
+var branchNumberPattern = regexp.MustCompile(`^(` + specNumberPattern + `)-`)
+
+// namespacePattern matches a valid spec namespace like "API" or "WEB2".
+var namespacePattern = regexp.MustCompile(`^[A-Z][A-Z0-9]*$`)
+
+// DefaultBranchTemplate names feature branches after their spec directory.
+const DefaultBranchTemplate = "{number}-{slug}"
+
+// templatedBranchPattern finds the spec number in a branch made from a branch
+// template, where {number} starts the name or follows a "/" ("feat/001-x").
+var templatedBranchPattern = regexp.MustCompile(`(?:^|/)(` + specNumberPattern + `)-`)
+
+// branchTemplateCharsPattern matches the characters allowed in a branch
+// template once its placeholders are removed.
+var branchTemplateCharsPattern = regexp.MustCompile(`^[A-Za-z0-9/._-]*$`)
 
 // GenerateBranchName generates a branch name suffix from a feature description
 // It filters stop words and keeps only meaningful words (3+ characters)
 func GenerateBranchName(description string) string {
+	return GenerateBranchNameWords(description, 0)
+}
+
+// GenerateBranchNameWords is GenerateBranchName keeping at most maxWords
+// meaningful words. With maxWords 0, it keeps 3, or all 4 if there are
+// exactly 4.
+func GenerateBranchNameWords(description string, maxWords int) string {
 	// Convert to lowercase and extract words
 	lower := strings.ToLower(description)
 	// Replace non-alphanumeric characters with spaces
@@ -61,10 +86,12 @@ func GenerateBranchName(description string) string {
 		}
 	}
 
-	// Use first 3-4 meaningful words
-	maxWords := 3
-	if len(meaningfulWords) == 4 {
-		maxWords = 4
+	// Use first 3-4 meaningful words unless a limit is given
+	if maxWords <= 0 {
+		maxWords = 3
+		if len(meaningfulWords) == 4 {
+			maxWords = 4
+		}
 	}
 	if len(meaningfulWords) > maxWords {
 		meaningfulWords = meaningfulWords[:maxWords]
@@ -149,48 +176,87 @@ func TruncateBranchName(branchName string) string {
 	return truncated
 }
 
-// GetNextBranchNumber scans git branches and spec directories to find the next available number
-// It returns a zero-padded three-digit string (e.g., "004")
-func GetNextBranchNumber(specsDir string) (string, error) {
+// GetNextBranchNumber scans branches (jj bookmarks) and spec directories to find the next available number
+// in namespace. It returns a zero-padded three-digit string (e.g., "004"), prefixed by the
+// namespace if one is given (e.g., "API-004"). Each namespace, and specs without one, is
+// numbered separately.
+func GetNextBranchNumber(specsDir, namespace string) (string, error) {
+	next, err := nextSpecNumber(specsDir, namespace)
+	if err != nil {
+		return "", err
+	}
+	return FormatSpecNumber(namespace, next), nil
+}
+
+// NormalizeNamespace uppercases a spec namespace and checks that it is a
+// letter followed by letters or digits. An empty namespace is valid.
+func NormalizeNamespace(namespace string) (string, error) {
+	namespace = strings.ToUpper(strings.TrimSpace(namespace))
+	if namespace != "" && !namespacePattern.MatchString(namespace) {
+		return "", fmt.Errorf("invalid spec namespace %q: must be a letter followed by letters or digits (e.g. API)", namespace)
+	}
+	return namespace, nil
+}
+
+// FormatSpecNumber formats n as a three-digit spec number in namespace,
+// e.g. "004" or "API-004".
+func FormatSpecNumber(namespace string, n int) string {
+	if namespace == "" {
+		return fmt.Sprintf("%03d", n)
+	}
+	return fmt.Sprintf("%s-%03d", namespace, n)
+}
+
+// splitSpecNumber returns the namespace and numeric part of a spec number
+// matched by specNumberPattern.
+func splitSpecNumber(number string) (string, int) {
+	namespace := ""
+	if i := strings.LastIndex(number, "-"); i >= 0 {
+		namespace, number = number[:i], number[i+1:]
+	}
+	n, _ := strconv.Atoi(number)
+	return namespace, n
+}
+
+// nextSpecNumber returns one more than the highest number used in namespace
+// by spec directories (archived ones included) and git branches.
+func nextSpecNumber(specsDir, namespace string) (int, error) {
 	highest := 0
+	record := func(name string) {
+		if match := branchNumberPattern.FindStringSubmatch(name); match != nil {
+			if ns, num := splitSpecNumber(match[1]); ns == namespace && num > highest {
+				highest = num
+			}
+		}
+	}
 
-	// Scan spec directories
-	if info, err := os.Stat(specsDir); err == nil && info.IsDir() {
-		entries, err := os.ReadDir(specsDir)
-		if err == nil {
-			for _, entry := range entries {
-				if !entry.IsDir() {
-					continue
-				}
-				name := entry.Name()
-				if match := branchNumberPattern.FindStringSubmatch(name); match != nil {
-					num, err := strconv.Atoi(match[1])
-					if err == nil && num > highest {
-						highest = num
-					}
-				}
+	// Scan spec directories, including archived specs so their numbers aren't reused
+	for _, dir := range []string{specsDir, ArchiveDir(specsDir)} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				record(entry.Name())
 			}
 		}
 	}
 
-	// Scan git branches if available
-	if git.IsGitRepository() {
-		branches, err := git.GetBranchNames()
+	// Scan branches (jj bookmarks in a jj repo) if available, including ones
+	// made from a branch template
+	if repo := vcs.Current(); repo.InRepository() {
+		branches, err := repo.BranchNames()
 		if err == nil {
 			for _, branch := range branches {
-				if match := branchNumberPattern.FindStringSubmatch(branch); match != nil {
-					num, err := strconv.Atoi(match[1])
-					if err == nil && num > highest {
-						highest = num
-					}
+				if number := branchSpecNumber(branch); number != "" {
+					record(number + "-")
 				}
 			}
 		}
 	}
 
-	// Return next number, zero-padded to 3 digits
-	next := highest + 1
-	return fmt.Sprintf("%03d", next), nil
+	return highest + 1, nil
 }
 
 // FormatBranchName creates a full branch name from a number and suffix
@@ -198,6 +264,46 @@ func FormatBranchName(number, suffix string) string {
 	return fmt.Sprintf("%s-%s", number, suffix)
 }
 
+// ApplyBranchTemplate creates a git branch name from a branch template by
+// replacing {number} and {slug}. An empty template is DefaultBranchTemplate,
+// which gives the same name as FormatBranchName.
+func ApplyBranchTemplate(template, number, slug string) string {
+	if template == "" {
+		template = DefaultBranchTemplate
+	}
+	return strings.NewReplacer("{number}", number, "{slug}", slug).Replace(template)
+}
+
+// ValidateBranchTemplate checks that a branch template contains {slug}, and
+// {number} followed by "-" at the start or after a "/", so the spec can be
+// found again from the branch name. An empty template is valid.
+func ValidateBranchTemplate(template string) error {
+	if template == "" {
+		return nil
+	}
+	if !strings.Contains(template, "{slug}") {
+		return fmt.Errorf("branch template %q must contain {slug}", template)
+	}
+	if !strings.HasPrefix(template, "{number}-") && !strings.Contains(template, "/{number}-") {
+		return fmt.Errorf("branch template %q must contain {number}- at the start or after a /", template)
+	}
+	rest := strings.NewReplacer("{number}", "", "{slug}", "").Replace(template)
+	if !branchTemplateCharsPattern.MatchString(rest) || strings.Contains(rest, "..") || strings.Contains(rest, "//") {
+		return fmt.Errorf("branch template %q may only contain letters, digits, /, ., _ and - besides {number} and {slug}", template)
+	}
+	return nil
+}
+
+// branchSpecNumber returns the spec number in a branch named with a branch
+// template, or "".
+func branchSpecNumber(branch string) string {
+	matches := templatedBranchPattern.FindAllStringSubmatch(branch, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+	return matches[len(matches)-1][1]
+}
+
 // GetFeatureDirectory returns the path to a feature's spec directory
 func GetFeatureDirectory(specsDir, branchName string) string {
 	return filepath.Join(specsDir, branchName)
@@ -269,7 +269,7 @@ func TestGetNextBranchNumber(t *testing.T) {
 	// existing branches. The tests verify relative behavior.
 
 	t.Run("returns valid number format", func(t *testing.T) {
-		num, err := GetNextBranchNumber(specsDir)
+		num, err := GetNextBranchNumber(specsDir, "")
 		require.NoError(t, err)
 		// Should be a 3-digit zero-padded number
 		assert.Len(t, num, 3)
@@ -278,7 +278,7 @@ func TestGetNextBranchNumber(t *testing.T) {
 
 	t.Run("with existing specs increases number", func(t *testing.T) {
 		// Get baseline
-		baseNum, err := GetNextBranchNumber(specsDir)
+		baseNum, err := GetNextBranchNumber(specsDir, "")
 		require.NoError(t, err)
 
 		// Create spec directories with higher numbers
@@ -287,7 +287,7 @@ func TestGetNextBranchNumber(t *testing.T) {
 		err = os.MkdirAll(filepath.Join(specsDir, "101-second-feature"), 0755)
 		require.NoError(t, err)
 
-		num, err := GetNextBranchNumber(specsDir)
+		num, err := GetNextBranchNumber(specsDir, "")
 		require.NoError(t, err)
 
 		// Should be at least 102 (or higher if git branches exist)
@@ -300,12 +300,85 @@ func TestGetNextBranchNumber(t *testing.T) {
 		assert.GreaterOrEqual(t, numInt, 102)
 	})
 
+	t.Run("archived specs are counted", func(t *testing.T) {
+		err := os.MkdirAll(filepath.Join(specsDir, ArchiveDirName, "200-archived-feature"), 0755)
+		require.NoError(t, err)
+
+		num, err := GetNextBranchNumber(specsDir, "")
+		require.NoError(t, err)
+
+		numInt := 0
+		fmt.Sscanf(num, "%d", &numInt)
+		assert.GreaterOrEqual(t, numInt, 201)
+	})
+
 	t.Run("non-existent directory returns valid number", func(t *testing.T) {
-		num, err := GetNextBranchNumber("/nonexistent/path")
+		num, err := GetNextBranchNumber("/nonexistent/path", "")
 		require.NoError(t, err)
 		// Should still return a valid format (from git branches if available)
 		assert.Regexp(t, `^\d{3}$`, num)
 	})
+
+	t.Run("namespaces are numbered separately", func(t *testing.T) {
+		for _, dir := range []string{"API-001-rate-limits", "API-007-pagination", "WEB-003-dark-mode"} {
+			require.NoError(t, os.MkdirAll(filepath.Join(specsDir, dir), 0755))
+		}
+
+		api, err := GetNextBranchNumber(specsDir, "API")
+		require.NoError(t, err)
+		web, err := GetNextBranchNumber(specsDir, "WEB")
+		require.NoError(t, err)
+		mobile, err := GetNextBranchNumber(specsDir, "MOBILE")
+		require.NoError(t, err)
+		plain, err := GetNextBranchNumber(specsDir, "")
+		require.NoError(t, err)
+
+		// Branches of the real repo have no namespace, so these are exact
+		assert.Equal(t, "API-008", api)
+		assert.Equal(t, "WEB-004", web)
+		assert.Equal(t, "MOBILE-001", mobile)
+		numInt := 0
+		fmt.Sscanf(plain, "%d", &numInt)
+		assert.GreaterOrEqual(t, numInt, 201, "namespaced specs don't affect plain numbers")
+	})
+}
+
+func TestNormalizeNamespace(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"empty":           {input: "", want: ""},
+		"uppercase":       {input: "API", want: "API"},
+		"lowercased":      {input: "web2", want: "WEB2"},
+		"trimmed":         {input: " ops ", want: "OPS"},
+		"leading digit":   {input: "2FA", wantErr: true},
+		"contains hyphen": {input: "API-V2", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NormalizeNamespace(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatSpecNumber(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "004", FormatSpecNumber("", 4))
+	assert.Equal(t, "API-014", FormatSpecNumber("API", 14))
+	assert.Equal(t, "WEB-1000", FormatSpecNumber("WEB", 1000))
 }
 
 func TestFormatBranchName(t *testing.T) {
@@ -327,6 +400,102 @@ func TestFormatBranchName(t *testing.T) {
 	}
 }
 
+func TestGenerateBranchNameWords(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		maxWords int
+		expected string
+	}{
+		"default heuristic": {maxWords: 0, expected: "implement-oauth2-integration"},
+		"two words":         {maxWords: 2, expected: "implement-oauth2"},
+		"five words":        {maxWords: 5, expected: "implement-oauth2-integration-api-access"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, GenerateBranchNameWords("Implement OAuth2 integration for API access", tt.maxWords))
+		})
+	}
+}
+
+func TestApplyBranchTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		template string
+		number   string
+		expected string
+	}{
+		"empty uses default":  {template: "", number: "001", expected: "001-user-auth"},
+		"default":             {template: DefaultBranchTemplate, number: "001", expected: "001-user-auth"},
+		"prefix":              {template: "feat/{number}-{slug}", number: "014", expected: "feat/014-user-auth"},
+		"namespaced number":   {template: "feat/{number}-{slug}", number: "API-014", expected: "feat/API-014-user-auth"},
+		"text after the slug": {template: "team/{number}-{slug}-wip", number: "002", expected: "team/002-user-auth-wip"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, ApplyBranchTemplate(tt.template, tt.number, "user-auth"))
+		})
+	}
+}
+
+func TestValidateBranchTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		template string
+		wantErr  string
+	}{
+		"empty":                   {template: ""},
+		"default":                 {template: DefaultBranchTemplate},
+		"prefix":                  {template: "feat/{number}-{slug}"},
+		"nested prefix":           {template: "users/jo/{number}-{slug}"},
+		"missing slug":            {template: "feat/{number}-x", wantErr: "must contain {slug}"},
+		"missing number":          {template: "feat/{slug}", wantErr: "must contain {number}-"},
+		"number not at a segment": {template: "feat-{number}-{slug}", wantErr: "must contain {number}-"},
+		"invalid characters":      {template: "feat:{slug}/{number}-x", wantErr: "may only contain"},
+		"double dot":              {template: "feat../{number}-{slug}", wantErr: "may only contain"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateBranchTemplate(tt.template)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestBranchSpecNumber(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		branch   string
+		expected string
+	}{
+		"plain":          {branch: "003-user-auth", expected: "003"},
+		"prefixed":       {branch: "feat/003-user-auth", expected: "003"},
+		"namespaced":     {branch: "feat/API-012-rate-limits", expected: "API-012"},
+		"not a spec":     {branch: "main", expected: ""},
+		"number in slug": {branch: "fix-003-thing", expected: ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, branchSpecNumber(tt.branch))
+		})
+	}
+}
+
 func TestGetFeatureDirectory(t *testing.T) {
 	result := GetFeatureDirectory("/home/user/project/specs", "001-my-feature")
 	assert.Equal(t, "/home/user/project/specs/001-my-feature", result)
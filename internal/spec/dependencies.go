@@ -0,0 +1,104 @@
+package spec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"gopkg.in/yaml.v3"
+)
+
+// DependencyStatus describes the progress of a spec listed in another spec's
+// depends_on.
+type DependencyStatus struct {
+	Name           string // Dependency as declared in depends_on, e.g. "002-auth"
+	Directory      string // Resolved spec directory (empty if not found)
+	Archived       bool   // Dependency lives in the archive directory
+	CompletedTasks int
+	TotalTasks     int
+}
+
+// Found reports whether the dependency resolved to a spec directory.
+func (d DependencyStatus) Found() bool {
+	return d.Directory != ""
+}
+
+// Complete reports whether the dependency is done: it is archived, or its
+// tasks.yaml exists and every task is completed.
+func (d DependencyStatus) Complete() bool {
+	if d.Archived {
+		return true
+	}
+	return d.Found() && d.TotalTasks > 0 && d.CompletedTasks == d.TotalTasks
+}
+
+// String formats the dependency with its progress, e.g. "002-auth (3/5 tasks)".
+func (d DependencyStatus) String() string {
+	switch {
+	case !d.Found():
+		return d.Name + " (not found)"
+	case d.Archived:
+		return d.Name + " (archived)"
+	case d.TotalTasks == 0:
+		return d.Name + " (no tasks)"
+	default:
+		return fmt.Sprintf("%s (%d/%d tasks)", d.Name, d.CompletedTasks, d.TotalTasks)
+	}
+}
+
+// ReadDependencies returns the specs listed under depends_on in a spec's
+// spec.yaml. A missing spec.yaml or depends_on key yields no dependencies.
+func ReadDependencies(specDir string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(specDir, "spec.yaml"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec.yaml: %w", err)
+	}
+	var doc struct {
+		DependsOn []string `yaml:"depends_on"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse depends_on in spec.yaml: %w", err)
+	}
+	return doc.DependsOn, nil
+}
+
+// CheckDependencies resolves each depends_on entry of the spec in specDir
+// against specsDir (and its archive) and reports its task progress.
+func CheckDependencies(specsDir, specDir string) ([]DependencyStatus, error) {
+	names, err := ReadDependencies(specDir)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]DependencyStatus, 0, len(names))
+	for _, name := range names {
+		status := DependencyStatus{Name: name}
+		if dir, err := GetSpecDirectory(specsDir, name); err == nil && specDirPattern.MatchString(filepath.Base(dir)) {
+			status.Directory = dir
+			if stats, err := validation.GetTaskStats(validation.GetTasksFilePath(dir)); err == nil {
+				status.CompletedTasks = stats.CompletedTasks
+				status.TotalTasks = stats.TotalTasks
+			}
+		} else if dir, err := GetSpecDirectory(ArchiveDir(specsDir), name); err == nil {
+			status.Directory = dir
+			status.Archived = true
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// IncompleteDependencies returns the dependencies that are not Complete.
+func IncompleteDependencies(statuses []DependencyStatus) []DependencyStatus {
+	var incomplete []DependencyStatus
+	for _, s := range statuses {
+		if !s.Complete() {
+			incomplete = append(incomplete, s)
+		}
+	}
+	return incomplete
+}
@@ -0,0 +1,91 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeDependencyTasks writes a tasks.yaml with one task per status.
+func writeDependencyTasks(t *testing.T, dir string, statuses ...string) {
+	t.Helper()
+	content := "tasks:\n  branch: test\nphases:\n  - number: 1\n    title: Phase\n    tasks:\n"
+	for i, status := range statuses {
+		content += "      - id: T00" + string(rune('1'+i)) + "\n        title: Task\n        status: " + status + "\n"
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tasks.yaml"), []byte(content), 0644))
+}
+
+func TestReadDependencies(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		content string
+		want    []string
+		wantErr bool
+	}{
+		"no depends_on":     {content: "feature:\n  branch: x\n"},
+		"list":              {content: "depends_on: [002-auth, \"004\"]\n", want: []string{"002-auth", "004"}},
+		"not a list":        {content: "depends_on: {a: b}\n", wantErr: true},
+		"missing spec.yaml": {},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			if tt.content != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "spec.yaml"), []byte(tt.content), 0644))
+			}
+			got, err := ReadDependencies(dir)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCheckDependencies(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	done := writeSpecDir(t, specsDir, "001-base", "Completed")
+	writeDependencyTasks(t, done, "Completed", "Completed")
+	partial := writeSpecDir(t, specsDir, "002-auth", "In Progress")
+	writeDependencyTasks(t, partial, "Completed", "Pending", "InProgress")
+	writeSpecDir(t, specsDir, "003-untasked", "Draft")
+	writeSpecDir(t, ArchiveDir(specsDir), "000-legacy", "Completed")
+
+	dir := writeSpecDir(t, specsDir, "005-billing", "Draft")
+	specYAML := "feature:\n  branch: \"005-billing\"\ndepends_on:\n  - 001-base\n  - auth\n  - \"003\"\n  - 000-legacy\n  - 009-missing\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "spec.yaml"), []byte(specYAML), 0644))
+
+	statuses, err := CheckDependencies(specsDir, dir)
+	require.NoError(t, err)
+	require.Len(t, statuses, 5)
+
+	var got []string
+	for _, s := range statuses {
+		got = append(got, s.String())
+	}
+	assert.Equal(t, []string{
+		"001-base (2/2 tasks)",
+		"auth (1/3 tasks)",
+		"003 (no tasks)",
+		"000-legacy (archived)",
+		"009-missing (not found)",
+	}, got)
+
+	incomplete := IncompleteDependencies(statuses)
+	require.Len(t, incomplete, 3)
+	assert.Equal(t, "auth", incomplete[0].Name)
+	assert.Equal(t, "003", incomplete[1].Name)
+	assert.Equal(t, "009-missing", incomplete[2].Name)
+}
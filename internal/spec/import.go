@@ -0,0 +1,488 @@
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/build"
+	"gopkg.in/yaml.v3"
+)
+
+// ImportTODO starts every placeholder in an imported spec.yaml skeleton, so
+// the fields left to fill in are easy to find.
+const ImportTODO = "TODO:"
+
+// ImportSource is a markdown spec found by FindImportSources.
+type ImportSource struct {
+	// Path is the markdown file the spec is built from.
+	Path string
+	// Extras are the other files in the spec's folder, copied alongside it.
+	Extras []string
+}
+
+// mainDocNames are the file names, in order of preference, taken as the
+// main document of a spec folder.
+var mainDocNames = []string{"spec.md", "readme.md", "index.md"}
+
+// FindImportSources returns the markdown specs under dir: each top-level
+// markdown file other than a README, and each subfolder containing markdown.
+// A folder's main document is its spec.md, README.md or index.md, or else
+// its first markdown file; the rest of its files are extras. Folders that
+// already hold a spec.yaml are skipped.
+func FindImportSources(dir string) ([]ImportSource, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", dir, err)
+	}
+
+	var sources []ImportSource
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if !entry.IsDir() {
+			if isMarkdown(entry.Name()) && !strings.EqualFold(entry.Name(), "README.md") {
+				sources = append(sources, ImportSource{Path: path})
+			}
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(path, "spec.yaml")); err == nil {
+			continue
+		}
+		source, err := folderSource(path)
+		if err != nil {
+			return nil, err
+		}
+		if source.Path != "" {
+			sources = append(sources, source)
+		}
+	}
+	return sources, nil
+}
+
+// folderSource picks the main document of a spec folder. Returns a zero
+// ImportSource if the folder has no markdown.
+func folderSource(dir string) (ImportSource, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return ImportSource{}, fmt.Errorf("reading %s: %w", dir, err)
+	}
+
+	doc := -1
+	for _, name := range mainDocNames {
+		if doc = slices.IndexFunc(files, func(f string) bool {
+			return filepath.Dir(f) == dir && strings.EqualFold(filepath.Base(f), name)
+		}); doc >= 0 {
+			break
+		}
+	}
+	if doc < 0 {
+		doc = slices.IndexFunc(files, isMarkdown)
+	}
+	if doc < 0 {
+		return ImportSource{}, nil
+	}
+	path := files[doc]
+	return ImportSource{Path: path, Extras: slices.Delete(files, doc, doc+1)}, nil
+}
+
+func isMarkdown(name string) bool {
+	return strings.EqualFold(filepath.Ext(name), ".md")
+}
+
+// ImportedSpec is a source assigned a number and branch by PlanImport.
+type ImportedSpec struct {
+	Source ImportSource
+	Title  string
+	Branch string
+	// Dir is the feature directory.
+	Dir string
+	// Existing is set when an earlier import already created Dir from Source.
+	Existing bool
+}
+
+// PlanImport assigns each source the next free spec number in namespace,
+// after the specs, archived specs and git branches that already exist, and
+// a branch named from its title. Sources recorded in a spec's _meta.imported_from
+// map to that spec instead, so importing a folder again adds only new
+// documents.
+func PlanImport(sources []ImportSource, specsDir, namespace string) ([]ImportedSpec, error) {
+	number, err := nextSpecNumber(specsDir, namespace)
+	if err != nil {
+		return nil, err
+	}
+	previous := importedSources(specsDir)
+
+	var specs []ImportedSpec
+	for _, source := range sources {
+		if dir, ok := previous[filepath.ToSlash(source.Path)]; ok {
+			specs = append(specs, ImportedSpec{Source: source, Branch: filepath.Base(dir), Dir: dir, Existing: true})
+			continue
+		}
+		content, err := os.ReadFile(source.Path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", source.Path, err)
+		}
+		title := importTitle(string(content), source)
+		branch := TruncateBranchName(FormatBranchName(FormatSpecNumber(namespace, number), GenerateBranchName(title)))
+		number++
+		specs = append(specs, ImportedSpec{
+			Source: source,
+			Title:  title,
+			Branch: branch,
+			Dir:    GetFeatureDirectory(specsDir, branch),
+		})
+	}
+	return specs, nil
+}
+
+// importedSources maps the _meta.imported_from of each spec in specsDir to
+// its directory.
+func importedSources(specsDir string) map[string]string {
+	sources := make(map[string]string)
+	dirs, err := listSpecDirs(specsDir)
+	if err != nil {
+		return sources
+	}
+	for _, name := range dirs {
+		dir := filepath.Join(specsDir, name)
+		data, err := os.ReadFile(filepath.Join(dir, "spec.yaml"))
+		if err != nil {
+			continue
+		}
+		var doc struct {
+			Meta struct {
+				ImportedFrom string `yaml:"imported_from"`
+			} `yaml:"_meta"`
+		}
+		if yaml.Unmarshal(data, &doc) == nil && doc.Meta.ImportedFrom != "" {
+			sources[doc.Meta.ImportedFrom] = dir
+		}
+	}
+	return sources
+}
+
+// importTitle returns the document's first "# " heading, or a title made
+// from its file or folder name.
+func importTitle(content string, source ImportSource) string {
+	for _, line := range strings.Split(content, "\n") {
+		if title, ok := strings.CutPrefix(line, "# "); ok && strings.TrimSpace(title) != "" {
+			return strings.TrimSpace(title)
+		}
+	}
+	name := strings.TrimSuffix(filepath.Base(source.Path), filepath.Ext(source.Path))
+	if len(source.Extras) > 0 || slices.Contains(mainDocNames, strings.ToLower(filepath.Base(source.Path))) {
+		name = filepath.Base(filepath.Dir(source.Path))
+	}
+	return strings.NewReplacer("-", " ", "_", " ").Replace(name)
+}
+
+// WriteImport creates the feature directory with a spec.yaml skeleton and
+// copies of the source documents. The sources are left in place, and an
+// existing feature directory is an error.
+func WriteImport(imp ImportedSpec) error {
+	if _, err := os.Stat(imp.Dir); err == nil {
+		return fmt.Errorf("feature directory already exists: %s", imp.Dir)
+	}
+	content, err := os.ReadFile(imp.Source.Path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", imp.Source.Path, err)
+	}
+	skeleton, err := ImportSkeleton(content, imp.Title, imp.Branch, imp.Source.Path)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(imp.Dir, 0755); err != nil {
+		return fmt.Errorf("creating feature directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(imp.Dir, "spec.yaml"), skeleton, 0644); err != nil {
+		return fmt.Errorf("writing spec.yaml: %w", err)
+	}
+
+	base := filepath.Dir(imp.Source.Path)
+	for _, path := range append([]string{imp.Source.Path}, imp.Source.Extras...) {
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+		if err := copyImportFile(path, filepath.Join(imp.Dir, rel)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyImportFile(from, to string) error {
+	data, err := os.ReadFile(from)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", from, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		return fmt.Errorf("creating directory for %s: %w", to, err)
+	}
+	if err := os.WriteFile(to, data, 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", to, err)
+	}
+	return nil
+}
+
+type importSpec struct {
+	Feature         importFeature          `yaml:"feature"`
+	UserStories     []importStory          `yaml:"user_stories"`
+	Requirements    importRequirements     `yaml:"requirements"`
+	SuccessCriteria *importSuccessCriteria `yaml:"success_criteria,omitempty"`
+	EdgeCases       []importEdgeCase       `yaml:"edge_cases,omitempty"`
+	Assumptions     []string               `yaml:"assumptions,omitempty"`
+	Constraints     []string               `yaml:"constraints,omitempty"`
+	OutOfScope      []string               `yaml:"out_of_scope,omitempty"`
+	Meta            importMeta             `yaml:"_meta"`
+}
+
+type importFeature struct {
+	Branch  string `yaml:"branch"`
+	Created string `yaml:"created"`
+	Status  string `yaml:"status"`
+	Input   string `yaml:"input"`
+}
+
+type importStory struct {
+	ID                  string           `yaml:"id"`
+	Title               string           `yaml:"title"`
+	Priority            string           `yaml:"priority"`
+	AsA                 string           `yaml:"as_a"`
+	IWant               string           `yaml:"i_want"`
+	SoThat              string           `yaml:"so_that"`
+	AcceptanceScenarios []importScenario `yaml:"acceptance_scenarios"`
+}
+
+type importScenario struct {
+	Given string `yaml:"given"`
+	When  string `yaml:"when"`
+	Then  string `yaml:"then"`
+}
+
+type importRequirements struct {
+	Functional []importRequirement `yaml:"functional"`
+}
+
+type importRequirement struct {
+	ID          string `yaml:"id"`
+	Description string `yaml:"description"`
+	Testable    bool   `yaml:"testable"`
+}
+
+type importSuccessCriteria struct {
+	MeasurableOutcomes []importOutcome `yaml:"measurable_outcomes"`
+}
+
+type importOutcome struct {
+	ID          string `yaml:"id"`
+	Description string `yaml:"description"`
+}
+
+type importEdgeCase struct {
+	Scenario string `yaml:"scenario"`
+}
+
+type importMeta struct {
+	Version          string `yaml:"version"`
+	Generator        string `yaml:"generator"`
+	GeneratorVersion string `yaml:"generator_version"`
+	Created          string `yaml:"created"`
+	ArtifactType     string `yaml:"artifact_type"`
+	ImportedFrom     string `yaml:"imported_from"`
+}
+
+var (
+	// importListItemPattern matches "- item", "* [ ] item" and "1. item".
+	importListItemPattern = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+(?:\[[ xX]\]\s+)?(.+)$`)
+	// importStoryPattern matches "As a <role>, I want <goal> so that <value>".
+	importStoryPattern = regexp.MustCompile(`(?i)\bas an? (.+?),?\s+I (?:want|need|can) (?:to )?(.+?),?\s+so that (.+?)\.?$`)
+	// importScenarioPattern matches "Given <x>, when <y>, then <z>".
+	importScenarioPattern = regexp.MustCompile(`(?i)\bgiven\b\s*(.+?),?\s+\bwhen\b\s*(.+?),?\s+\bthen\b\s*(.+?)\.?$`)
+)
+
+// importSection maps a heading to the part of the spec its list items go to.
+func importSection(heading string) string {
+	heading = strings.ToLower(heading)
+	for _, s := range []struct{ keyword, section string }{
+		{"out of scope", "out_of_scope"},
+		{"non-goal", "out_of_scope"},
+		{"edge case", "edge_cases"},
+		{"assumption", "assumptions"},
+		{"constraint", "constraints"},
+		{"requirement", "requirements"},
+		{"acceptance", "criteria"},
+		{"success", "criteria"},
+		{"criteria", "criteria"},
+		{"user stor", "stories"},
+	} {
+		if strings.Contains(heading, s.keyword) {
+			return s.section
+		}
+	}
+	return ""
+}
+
+// ImportSkeleton builds a spec.yaml from a free-form markdown spec. List
+// items under headings such as "Requirements", "Acceptance criteria",
+// "Out of scope" and "Assumptions" fill the matching fields, "As a ..., I
+// want ..., so that ..." sentences become user stories and "Given ..., when
+// ..., then ..." sentences acceptance scenarios. Required fields with nothing
+// to fill them start with ImportTODO.
+func ImportSkeleton(content []byte, title, branch, source string) ([]byte, error) {
+	s := importSpec{
+		Feature: importFeature{
+			Branch:  branch,
+			Created: time.Now().Format("2006-01-02"),
+			Status:  "Draft",
+			Input:   title,
+		},
+		Requirements: importRequirements{Functional: []importRequirement{}},
+		Meta: importMeta{
+			Version:          "1.0.0",
+			Generator:        "autospec",
+			GeneratorVersion: build.Version,
+			Created:          time.Now().Format(time.RFC3339),
+			ArtifactType:     "spec",
+			ImportedFrom:     filepath.ToSlash(source),
+		},
+	}
+
+	var scenarios []importScenario
+	var criteria []string
+	section, inCode, intro := "", false, true
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			if !strings.HasPrefix(trimmed, "# ") {
+				intro = false
+			}
+			section = importSection(strings.TrimLeft(trimmed, "# "))
+			continue
+		}
+		plain := strings.ReplaceAll(strings.ReplaceAll(trimmed, "**", ""), "__", "")
+		if intro && plain != "" && !importListItemPattern.MatchString(plain) {
+			s.Feature.Input += "\n" + plain
+		}
+
+		if m := importStoryPattern.FindStringSubmatch(plain); m != nil {
+			s.UserStories = append(s.UserStories, importStory{AsA: m[1], IWant: m[2], SoThat: m[3]})
+			continue
+		}
+		if m := importScenarioPattern.FindStringSubmatch(plain); m != nil {
+			scenarios = append(scenarios, importScenario{Given: m[1], When: m[2], Then: m[3]})
+			continue
+		}
+		m := importListItemPattern.FindStringSubmatch(plain)
+		if m == nil {
+			continue
+		}
+		item := strings.TrimSpace(m[1])
+		switch section {
+		case "requirements":
+			s.Requirements.Functional = append(s.Requirements.Functional, importRequirement{
+				ID: fmt.Sprintf("FR-%03d", len(s.Requirements.Functional)+1), Description: item, Testable: true,
+			})
+		case "criteria":
+			criteria = append(criteria, item)
+		case "edge_cases":
+			s.EdgeCases = append(s.EdgeCases, importEdgeCase{Scenario: item})
+		case "assumptions":
+			s.Assumptions = append(s.Assumptions, item)
+		case "constraints":
+			s.Constraints = append(s.Constraints, item)
+		case "out_of_scope":
+			s.OutOfScope = append(s.OutOfScope, item)
+		case "stories":
+			s.UserStories = append(s.UserStories, importStory{AsA: ImportTODO + " role", IWant: item, SoThat: ImportTODO + " value"})
+		}
+	}
+
+	if len(s.UserStories) == 0 {
+		s.UserStories = []importStory{{AsA: ImportTODO + " role", IWant: title, SoThat: ImportTODO + " value"}}
+	}
+	for i := range s.UserStories {
+		story := &s.UserStories[i]
+		story.ID = fmt.Sprintf("US-%03d", i+1)
+		story.Title = story.IWant
+		story.Priority = "P2"
+		if i == 0 {
+			story.Priority = "P1"
+			story.AcceptanceScenarios = scenarios
+		}
+		if len(story.AcceptanceScenarios) == 0 {
+			story.AcceptanceScenarios = []importScenario{{
+				Given: ImportTODO + " precondition", When: ImportTODO + " action", Then: ImportTODO + " outcome",
+			}}
+		}
+	}
+	if len(criteria) > 0 {
+		s.SuccessCriteria = &importSuccessCriteria{}
+		for _, c := range criteria {
+			s.SuccessCriteria.MeasurableOutcomes = append(s.SuccessCriteria.MeasurableOutcomes,
+				importOutcome{ID: fmt.Sprintf("SC-%03d", len(s.SuccessCriteria.MeasurableOutcomes)+1), Description: c})
+		}
+	}
+
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("serializing spec.yaml: %w", err)
+	}
+	return data, nil
+}
+
+// CountImportTODOs returns how many placeholders remain in a spec.yaml.
+func CountImportTODOs(specDir string) (int, error) {
+	data, err := os.ReadFile(filepath.Join(specDir, "spec.yaml"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("reading spec.yaml: %w", err)
+	}
+	return strings.Count(string(data), ImportTODO), nil
+}
+
+// ImportExtractPrompt asks the agent to fill an imported spec.yaml from its
+// source document.
+func ImportExtractPrompt(imp ImportedSpec) string {
+	specPath := filepath.Join(imp.Dir, "spec.yaml")
+	return fmt.Sprintf(`Rewrite %[1]s from the feature document %[2]s.
+
+%[1]s is a skeleton imported from that document. Read the document and any
+other files in %[3]s, then fill in user_stories (with Given/When/Then
+acceptance_scenarios), requirements.functional, success_criteria,
+key_entities, edge_cases, assumptions, constraints and out_of_scope, in the
+same structure /autospec.specify writes. Replace every %[4]q placeholder.
+Only record what the document says or clearly implies; mark real gaps with
+clarification_needed instead of inventing requirements.
+
+Keep feature.branch (%[5]s), feature.created and the _meta section unchanged.
+Do not create branches or other spec directories.
+
+When done, run: autospec artifact %[1]s
+and fix any errors it reports.`, specPath, imp.Source.Path, imp.Dir, ImportTODO, imp.Branch)
+}
@@ -0,0 +1,201 @@
+package spec
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const importTestDoc = `# User Login
+
+Let people sign in with their email address.
+
+## Stories
+
+As a returning user, I want to log in with my email, so that I can see my saved carts.
+
+Given a registered email, when the user enters the right password, then they see the dashboard.
+
+## Requirements
+
+- Passwords are checked against the stored hash
+- Lock the account after 5 failed attempts
+
+## Acceptance Criteria
+
+- 95% of logins complete in under 1 second
+
+## Out of Scope
+
+- Social login
+`
+
+func writeImportFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestFindImportSources(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeImportFile(t, filepath.Join(dir, "README.md"), "# Specs\n")
+	writeImportFile(t, filepath.Join(dir, "login.md"), importTestDoc)
+	writeImportFile(t, filepath.Join(dir, "notes.txt"), "not markdown")
+	writeImportFile(t, filepath.Join(dir, "billing", "overview.md"), "# Billing\n")
+	writeImportFile(t, filepath.Join(dir, "billing", "README.md"), "# Billing\n")
+	writeImportFile(t, filepath.Join(dir, "billing", "diagrams", "flow.png"), "png")
+	writeImportFile(t, filepath.Join(dir, "search", "design.md"), "# Search\n")
+	writeImportFile(t, filepath.Join(dir, "done", "spec.md"), "# Done\n")
+	writeImportFile(t, filepath.Join(dir, "done", "spec.yaml"), "feature: {}\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "empty"), 0o755))
+
+	sources, err := FindImportSources(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []ImportSource{
+		{
+			Path: filepath.Join(dir, "billing", "README.md"),
+			Extras: []string{
+				filepath.Join(dir, "billing", "diagrams", "flow.png"),
+				filepath.Join(dir, "billing", "overview.md"),
+			},
+		},
+		{Path: filepath.Join(dir, "login.md")},
+		{Path: filepath.Join(dir, "search", "design.md"), Extras: []string{}},
+	}, sources)
+}
+
+func TestPlanImport(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	specsDir := filepath.Join(dir, "specs")
+	require.NoError(t, os.MkdirAll(filepath.Join(specsDir, "004-existing"), 0o755))
+	writeImportFile(t, filepath.Join(dir, "docs", "login.md"), importTestDoc)
+	writeImportFile(t, filepath.Join(dir, "docs", "dark_mode.md"), "No heading here.\n")
+	writeImportFile(t, filepath.Join(dir, "docs", "billing", "README.md"), "Intro only.\n")
+
+	sources, err := FindImportSources(filepath.Join(dir, "docs"))
+	require.NoError(t, err)
+	imports, err := PlanImport(sources, specsDir, "")
+	require.NoError(t, err)
+
+	require.Len(t, imports, 3)
+	assert.Equal(t, "billing", imports[0].Title)
+	assert.Equal(t, "005-billing", imports[0].Branch)
+	assert.Equal(t, "dark mode", imports[1].Title)
+	assert.Equal(t, "006-dark-mode", imports[1].Branch)
+	assert.Equal(t, "User Login", imports[2].Title)
+	assert.Equal(t, "007-user-login", imports[2].Branch)
+	assert.Equal(t, filepath.Join(specsDir, "007-user-login"), imports[2].Dir)
+
+	t.Run("importing again reuses earlier specs", func(t *testing.T) {
+		require.NoError(t, WriteImport(imports[2]))
+		writeImportFile(t, filepath.Join(dir, "docs", "search.md"), "# Search\n")
+
+		sources, err := FindImportSources(filepath.Join(dir, "docs"))
+		require.NoError(t, err)
+		again, err := PlanImport(sources, specsDir, "")
+		require.NoError(t, err)
+
+		require.Len(t, again, 4)
+		assert.True(t, again[2].Existing)
+		assert.Equal(t, imports[2].Dir, again[2].Dir)
+		// billing and dark_mode weren't written, so they take 008 and 009
+		assert.False(t, again[3].Existing)
+		assert.Equal(t, "010-search", again[3].Branch)
+	})
+}
+
+func TestWriteImport(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	source := ImportSource{
+		Path:   filepath.Join(dir, "docs", "login", "spec.md"),
+		Extras: []string{filepath.Join(dir, "docs", "login", "img", "flow.png")},
+	}
+	writeImportFile(t, source.Path, importTestDoc)
+	writeImportFile(t, source.Extras[0], "png")
+	imp := ImportedSpec{Source: source, Title: "User Login", Branch: "001-user-login", Dir: filepath.Join(dir, "specs", "001-user-login")}
+
+	require.NoError(t, WriteImport(imp))
+
+	assert.FileExists(t, filepath.Join(imp.Dir, "spec.yaml"))
+	assert.FileExists(t, filepath.Join(imp.Dir, "spec.md"))
+	assert.FileExists(t, filepath.Join(imp.Dir, "img", "flow.png"))
+	assert.FileExists(t, source.Path, "source should be left in place")
+	assert.NoError(t, validation.ValidateArtifactFile(filepath.Join(imp.Dir, "spec.yaml")))
+
+	err := WriteImport(imp)
+	assert.ErrorContains(t, err, "already exists")
+}
+
+func TestImportSkeleton(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		content   string
+		wantTODOs bool
+		check     func(t *testing.T, s importSpec)
+	}{
+		"structured document": {
+			content: importTestDoc,
+			check: func(t *testing.T, s importSpec) {
+				assert.Equal(t, "User Login\nLet people sign in with their email address.", s.Feature.Input)
+				require.Len(t, s.UserStories, 1)
+				story := s.UserStories[0]
+				assert.Equal(t, "returning user", story.AsA)
+				assert.Equal(t, "log in with my email", story.IWant)
+				assert.Equal(t, "I can see my saved carts", story.SoThat)
+				assert.Equal(t, "P1", story.Priority)
+				require.Len(t, story.AcceptanceScenarios, 1)
+				assert.Equal(t, "a registered email", story.AcceptanceScenarios[0].Given)
+				require.Len(t, s.Requirements.Functional, 2)
+				assert.Equal(t, "FR-002", s.Requirements.Functional[1].ID)
+				assert.Equal(t, "Lock the account after 5 failed attempts", s.Requirements.Functional[1].Description)
+				require.NotNil(t, s.SuccessCriteria)
+				assert.Equal(t, "SC-001", s.SuccessCriteria.MeasurableOutcomes[0].ID)
+				assert.Equal(t, []string{"Social login"}, s.OutOfScope)
+				assert.Equal(t, "docs/login.md", s.Meta.ImportedFrom)
+			},
+		},
+		"free-form notes": {
+			content:   "Some loose notes about search.\n\n```\n- not a requirement\n```\n",
+			wantTODOs: true,
+			check: func(t *testing.T, s importSpec) {
+				require.Len(t, s.UserStories, 1)
+				assert.Equal(t, ImportTODO+" role", s.UserStories[0].AsA)
+				assert.Empty(t, s.Requirements.Functional)
+				assert.Nil(t, s.SuccessCriteria)
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := ImportSkeleton([]byte(tt.content), "User Login", "001-user-login", "docs/login.md")
+			require.NoError(t, err)
+
+			var s importSpec
+			require.NoError(t, yaml.Unmarshal(data, &s))
+			assert.Equal(t, "001-user-login", s.Feature.Branch)
+			assert.Equal(t, tt.wantTODOs, bytes.Contains(data, []byte(ImportTODO)))
+			tt.check(t, s)
+
+			path := filepath.Join(t.TempDir(), "spec.yaml")
+			require.NoError(t, os.WriteFile(path, data, 0o644))
+			assert.NoError(t, validation.ValidateArtifactFile(path))
+		})
+	}
+}
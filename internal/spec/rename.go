@@ -0,0 +1,110 @@
+package spec
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+)
+
+// RenameResult describes a spec rename performed by RenameSpec.
+type RenameResult struct {
+	OldName      string   // Previous directory name, e.g. "003-user-login"
+	NewName      string   // New directory name, e.g. "003-user-auth"
+	Directory    string   // Path of the renamed spec directory
+	UpdatedFiles []string // Artifacts whose references to OldName were rewritten
+}
+
+// RenameSpec renames a spec directory and rewrites references to its old
+// name (such as feature.branch and spec paths) in the spec's YAML and
+// markdown files.
+//
+// newName is a name suffix ("user-auth"), which keeps the spec's number, or a
+// full "NNN-name", which also renumbers the spec. Names are sanitized like
+// branch names.
+func RenameSpec(specsDir, specIdentifier, newName string) (*RenameResult, error) {
+	directory, err := GetSpecDirectory(specsDir, specIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	oldName := filepath.Base(directory)
+	match := specDirPattern.FindStringSubmatch(oldName)
+	if match == nil {
+		return nil, fmt.Errorf("%s is not a spec directory", directory)
+	}
+
+	number := match[1]
+	if m := branchNumberPattern.FindStringSubmatch(newName); m != nil {
+		number = m[1]
+		newName = strings.TrimPrefix(newName, m[0])
+	}
+	suffix := CleanBranchName(newName)
+	if suffix == "" {
+		return nil, fmt.Errorf("invalid spec name %q", newName)
+	}
+	name := FormatBranchName(number, suffix)
+	if name == oldName {
+		return nil, fmt.Errorf("spec is already named %s", name)
+	}
+	if number != match[1] {
+		// Archived specs keep their numbers reserved
+		for _, dir := range []string{specsDir, ArchiveDir(specsDir)} {
+			if taken, _ := filepath.Glob(filepath.Join(dir, number+"-*")); len(taken) > 0 {
+				return nil, fmt.Errorf("spec number %s is already used by %s", number, filepath.Base(taken[0]))
+			}
+		}
+	}
+
+	target := filepath.Join(specsDir, name)
+	if _, err := moveSpec(directory, target); err != nil {
+		return nil, err
+	}
+	updated, err := replaceReferences(target, oldName, name)
+	if err != nil {
+		return nil, fmt.Errorf("renamed to %s but updating references failed: %w", name, err)
+	}
+
+	return &RenameResult{OldName: oldName, NewName: name, Directory: target, UpdatedFiles: updated}, nil
+}
+
+// replaceReferences rewrites oldName to newName in the YAML and markdown
+// files under dir and returns the paths of the files that changed.
+func replaceReferences(dir, oldName, newName string) ([]string, error) {
+	var updated []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".md":
+		default:
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		replaced := bytes.ReplaceAll(data, []byte(oldName), []byte(newName))
+		if bytes.Equal(replaced, data) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if err := atomicfile.WriteFile(path, replaced, info.Mode().Perm()); err != nil {
+			return err
+		}
+		updated = append(updated, path)
+		return nil
+	})
+	return updated, err
+}
@@ -0,0 +1,136 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		identifier string
+		newName    string
+		wantName   string
+		wantErr    string
+	}{
+		"keeps number": {
+			identifier: "003",
+			newName:    "user-login",
+			wantName:   "003-user-login",
+		},
+		"sanitizes name": {
+			identifier: "user-auth",
+			newName:    "User Login!",
+			wantName:   "003-user-login",
+		},
+		"renumbers with prefix": {
+			identifier: "003-user-auth",
+			newName:    "007-user-login",
+			wantName:   "007-user-login",
+		},
+		"renumber keeping name": {
+			identifier: "003",
+			newName:    "008-user-auth",
+			wantName:   "008-user-auth",
+		},
+		"number taken by active spec": {
+			identifier: "003",
+			newName:    "005-user-login",
+			wantErr:    "already used by 005-other",
+		},
+		"number taken by archived spec": {
+			identifier: "003",
+			newName:    "006-user-login",
+			wantErr:    "already used by 006-archived",
+		},
+		"same name": {
+			identifier: "003",
+			newName:    "user-auth",
+			wantErr:    "already named",
+		},
+		"empty name": {
+			identifier: "003",
+			newName:    "!!!",
+			wantErr:    "invalid spec name",
+		},
+		"unknown spec": {
+			identifier: "999",
+			newName:    "anything",
+			wantErr:    "spec directory not found",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specsDir := t.TempDir()
+			source := writeSpecDir(t, specsDir, "003-user-auth", "Draft")
+			writeSpecDir(t, specsDir, "005-other", "Draft")
+			writeSpecDir(t, ArchiveDir(specsDir), "006-archived", "Completed")
+
+			result, err := RenameSpec(specsDir, tt.identifier, tt.newName)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.DirExists(t, source)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "003-user-auth", result.OldName)
+			assert.Equal(t, tt.wantName, result.NewName)
+			assert.Equal(t, filepath.Join(specsDir, tt.wantName), result.Directory)
+			assert.NoDirExists(t, source)
+
+			data, err := os.ReadFile(filepath.Join(result.Directory, "spec.yaml"))
+			require.NoError(t, err)
+			assert.Contains(t, string(data), `branch: "`+tt.wantName+`"`)
+			assert.Equal(t, []string{filepath.Join(result.Directory, "spec.yaml")}, result.UpdatedFiles)
+		})
+	}
+}
+
+func TestRenameSpec_UpdatesNestedArtifacts(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	dir := writeSpecDir(t, specsDir, "003-user-auth", "Draft")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "checklists"), 0755))
+	files := map[string]string{
+		"plan.yaml":            "plan:\n  spec_path: \"specs/003-user-auth/spec.yaml\"\n",
+		"checklists/ux.yaml":   "checklist:\n  feature: \"003-user-auth\"\n",
+		"notes.md":             "# 003-user-auth notes\n",
+		"tasks.yaml":           "tasks:\n  branch: \"003-user-auth\"\n",
+		"diagram.png":          "003-user-auth",
+		"unrelated/other.yaml": "name: something-else\n",
+	}
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	result, err := RenameSpec(specsDir, "003", "user-login")
+	require.NoError(t, err)
+
+	var updated []string
+	for _, path := range result.UpdatedFiles {
+		rel, err := filepath.Rel(result.Directory, path)
+		require.NoError(t, err)
+		updated = append(updated, rel)
+	}
+	assert.ElementsMatch(t, []string{"spec.yaml", "plan.yaml", filepath.Join("checklists", "ux.yaml"), "notes.md", "tasks.yaml"}, updated)
+
+	plan, err := os.ReadFile(filepath.Join(result.Directory, "plan.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(plan), "specs/003-user-login/spec.yaml")
+
+	binary, err := os.ReadFile(filepath.Join(result.Directory, "diagram.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "003-user-auth", string(binary), "non-text artifacts are left alone")
+}
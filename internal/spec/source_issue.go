@@ -0,0 +1,77 @@
+package spec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+	"gopkg.in/yaml.v3"
+)
+
+// RecordSourceIssue sets _meta.source_issue in spec.yaml to the URL of the
+// issue the spec was created from, adding the _meta section if needed.
+func RecordSourceIssue(specDir, issueURL string) error {
+	return recordMeta(specDir, "source_issue", issueURL)
+}
+
+// recordMeta sets _meta.<key> in spec.yaml, adding the _meta section if needed.
+// This preserves the existing YAML structure and comments using yaml.Node parsing.
+func recordMeta(specDir, key, value string) error {
+	specPath := filepath.Join(specDir, "spec.yaml")
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return fmt.Errorf("failed to read spec.yaml: %w", err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("failed to parse spec.yaml: %w", err)
+	}
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("spec.yaml is not a mapping")
+	}
+
+	meta := ensureMapping(root.Content[0], "_meta")
+	setScalar(meta, key, value)
+
+	output, err := yaml.Marshal(&root)
+	if err != nil {
+		return fmt.Errorf("failed to serialize spec.yaml: %w", err)
+	}
+	if err := atomicfile.WriteFile(specPath, output, 0644); err != nil {
+		return fmt.Errorf("failed to write spec.yaml: %w", err)
+	}
+	return nil
+}
+
+// ensureMapping returns the mapping stored under key, appending an empty one
+// if the key is missing. A non-mapping value is replaced.
+func ensureMapping(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value != key {
+			continue
+		}
+		if node.Content[i+1].Kind != yaml.MappingNode {
+			node.Content[i+1] = &yaml.Node{Kind: yaml.MappingNode}
+		}
+		return node.Content[i+1]
+	}
+	value := &yaml.Node{Kind: yaml.MappingNode}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	return value
+}
+
+// setScalar sets key to a string value in a mapping, adding the key if missing.
+func setScalar(node *yaml.Node, key, value string) {
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+			return
+		}
+	}
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Value: value},
+	)
+}
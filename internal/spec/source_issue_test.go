@@ -0,0 +1,68 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRecordSourceIssue(t *testing.T) {
+	t.Parallel()
+
+	const url = "https://github.com/acme/widgets/issues/7"
+	tests := map[string]struct {
+		content string
+		wantErr bool
+	}{
+		"adds field to existing _meta": {
+			content: "feature:\n  branch: 001-x\n_meta:\n  version: \"1.0.0\"\n",
+		},
+		"creates _meta when missing": {
+			content: "feature:\n  branch: 001-x\n",
+		},
+		"replaces previous link": {
+			content: "feature:\n  branch: 001-x\n_meta:\n  source_issue: https://github.com/acme/widgets/issues/1\n",
+		},
+		"rejects non-mapping document": {
+			content: "- just\n- a list\n",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			specPath := filepath.Join(dir, "spec.yaml")
+			require.NoError(t, os.WriteFile(specPath, []byte(tt.content), 0644))
+
+			err := RecordSourceIssue(dir, url)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			data, err := os.ReadFile(specPath)
+			require.NoError(t, err)
+			var parsed struct {
+				Feature map[string]string `yaml:"feature"`
+				Meta    map[string]string `yaml:"_meta"`
+			}
+			require.NoError(t, yaml.Unmarshal(data, &parsed))
+			assert.Equal(t, url, parsed.Meta["source_issue"])
+			assert.Equal(t, "001-x", parsed.Feature["branch"], "existing content preserved")
+		})
+	}
+}
+
+func TestRecordSourceIssue_MissingSpec(t *testing.T) {
+	t.Parallel()
+
+	err := RecordSourceIssue(t.TempDir(), "https://github.com/acme/widgets/issues/7")
+	assert.Error(t, err)
+}
@@ -4,22 +4,29 @@
 package spec
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"time"
 
-	"github.com/ariel-frischer/autospec/internal/git"
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+	"github.com/ariel-frischer/autospec/internal/vcs"
 	"gopkg.in/yaml.v3"
 )
 
 var (
 	// specBranchPattern matches branch names like "002-go-binary-migration"
-	specBranchPattern = regexp.MustCompile(`^(\d{3})-(.+)$`)
+	// or, with a namespace, "API-002-rate-limits"
+	specBranchPattern = regexp.MustCompile(`^(` + specNumberPattern + `)-(.+)$`)
 	// specDirPattern matches directory names like "002-go-binary-migration"
-	specDirPattern = regexp.MustCompile(`^(\d{3})-(.+)$`)
+	// or "API-002-rate-limits"
+	specDirPattern = regexp.MustCompile(`^(` + specNumberPattern + `)-(.+)$`)
+	// specNumberOnlyPattern matches a bare spec number like "002" or "API-002"
+	specNumberOnlyPattern = regexp.MustCompile(`^` + specNumberPattern + `$`)
 )
 
 // DetectionMethod indicates how the spec was detected
@@ -39,7 +46,7 @@ const (
 // Metadata represents information about a feature specification
 type Metadata struct {
 	Name      string          // Feature name (e.g., "go-binary-migration")
-	Number    string          // Spec number (e.g., "002")
+	Number    string          // Spec number (e.g., "002", or "API-002" with a namespace)
 	Directory string          // Full path to spec directory
 	Branch    string          // Git branch name (if in git repo)
 	Detection DetectionMethod // How the spec was detected
@@ -67,15 +74,17 @@ func (m *Metadata) FormatInfo() string {
 // DetectCurrentSpec attempts to detect the current spec from git branch or directory.
 //
 // Two-strategy detection:
-//  1. Git branch: Parse branch name matching "NNN-name" pattern, verify directory exists
+//  1. Git branch: Parse branch name matching "NNN-name" pattern, verify directory exists;
+//     for a branch made from a branch template ("feat/NNN-name"), look the spec up by number
 //  2. Fallback: Glob all spec directories, sort by modification time, return most recent
 //
-// Strategy 1 provides branch-based workflow; Strategy 2 handles detached HEAD or non-git.
+// Strategy 1 provides branch-based workflow (jj bookmarks in a jj repo); Strategy 2 handles
+// detached HEAD or no version control.
 // Returns Detection field indicating which strategy succeeded.
 func DetectCurrentSpec(specsDir string) (*Metadata, error) {
-	// Strategy 1: Try git branch name
-	if git.IsGitRepository() {
-		branch, err := git.GetCurrentBranch()
+	// Strategy 1: Try branch name
+	if repo := vcs.Current(); repo.InRepository() {
+		branch, err := repo.CurrentBranch()
 		if err == nil {
 			if match := specBranchPattern.FindStringSubmatch(branch); match != nil {
 				number := match[1]
@@ -93,6 +102,14 @@ func DetectCurrentSpec(specsDir string) (*Metadata, error) {
 					}, nil
 				}
 			}
+			// Branches named with a branch template, e.g. "feat/002-name"
+			if number := branchSpecNumber(branch); number != "" {
+				if meta, err := GetSpecMetadata(specsDir, number); err == nil {
+					meta.Branch = branch
+					meta.Detection = DetectionGitBranch
+					return meta, nil
+				}
+			}
 		}
 	}
 
@@ -161,8 +178,8 @@ func GetSpecDirectory(specsDir, specIdentifier string) (string, error) {
 		return exactPath, nil
 	}
 
-	// Try number match (e.g., "002" -> "002-*")
-	if regexp.MustCompile(`^\d{3}$`).MatchString(specIdentifier) {
+	// Try number match (e.g., "002" -> "002-*", "API-002" -> "API-002-*")
+	if specNumberOnlyPattern.MatchString(specIdentifier) {
 		pattern := filepath.Join(specsDir, specIdentifier+"-*")
 		matches, err := filepath.Glob(pattern)
 		if err != nil {
@@ -208,9 +225,9 @@ func GetSpecMetadata(specsDir, specIdentifier string) (*Metadata, error) {
 			Directory: directory,
 		}
 
-		// Try to get branch if in git repo
-		if git.IsGitRepository() {
-			if branch, err := git.GetCurrentBranch(); err == nil {
+		// Try to get branch if under version control
+		if repo := vcs.Current(); repo.InRepository() {
+			if branch, err := repo.CurrentBranch(); err == nil {
 				metadata.Branch = branch
 			}
 		}
@@ -271,7 +288,7 @@ func UpdateSpecStatus(specDir string, newStatus string, completedAt time.Time) (
 		return nil, fmt.Errorf("failed to serialize spec.yaml: %w", err)
 	}
 
-	if err := os.WriteFile(specPath, output, 0644); err != nil {
+	if err := atomicfile.WriteFile(specPath, output, 0644); err != nil {
 		return nil, fmt.Errorf("failed to write spec.yaml: %w", err)
 	}
 
@@ -406,3 +423,40 @@ func updateFeatureStatus(node *yaml.Node, newStatus string, completedAt time.Tim
 
 	return result, nil
 }
+
+// ListSpecs returns the names of the specs in the specs directory, sorted.
+// Archived specs are not included.
+func ListSpecs(specsDir string) ([]string, error) {
+	names, err := listSpecDirs(specsDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading specs directory: %w", err)
+	}
+	return names, nil
+}
+
+// IsSpecDirName reports whether name has the form of a spec directory, e.g.
+// "003-auth" or "API-003-auth".
+func IsSpecDirName(name string) bool {
+	return specDirPattern.MatchString(name)
+}
+
+// listSpecDirs returns the sorted names of the spec directories in dir, or
+// none if dir doesn't exist.
+func listSpecDirs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && specDirPattern.MatchString(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -376,6 +376,20 @@ func TestGetSpecMetadata(t *testing.T) {
 			wantName:    "feature-name",
 			wantErr:     false,
 		},
+		"namespaced number match": {
+			specDirName: "API-002-rate-limits",
+			identifier:  "API-002",
+			wantNumber:  "API-002",
+			wantName:    "rate-limits",
+			wantErr:     false,
+		},
+		"namespaced exact match": {
+			specDirName: "WEB-014-dark-mode",
+			identifier:  "WEB-014-dark-mode",
+			wantNumber:  "WEB-014",
+			wantName:    "dark-mode",
+			wantErr:     false,
+		},
 		"not found": {
 			specDirName:    "001-existing",
 			identifier:     "999-nonexistent",
@@ -613,3 +627,21 @@ func TestUpdateSpecStatus_FeatureNotMapping(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "feature section is not a mapping")
 }
+
+func TestListSpecs(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	writeSpecDir(t, specsDir, "002-search", "Draft")
+	writeSpecDir(t, specsDir, "001-auth", "Completed")
+	writeSpecDir(t, ArchiveDir(specsDir), "000-old", "Completed")
+	require.NoError(t, os.MkdirAll(filepath.Join(specsDir, "notes"), 0755))
+
+	names, err := ListSpecs(specsDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"001-auth", "002-search"}, names, "archived and non-spec directories are skipped")
+
+	names, err = ListSpecs(filepath.Join(specsDir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
@@ -0,0 +1,153 @@
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TemplatesDir returns the directory of project-defined spec templates, one
+// <name>.yaml file per template.
+func TemplatesDir() string {
+	return filepath.Join(".autospec", "templates")
+}
+
+// templateSections are the spec.yaml sections a template may pre-seed, in the
+// order they appear in spec.yaml.
+var templateSections = []string{
+	"user_stories",
+	"requirements",
+	"key_entities",
+	"success_criteria",
+	"edge_cases",
+	"assumptions",
+	"constraints",
+	"out_of_scope",
+}
+
+// Template is a project-defined spec template selected with
+// `autospec specify --template <name>`. It guides the agent toward a kind of
+// feature (API endpoint, bugfix, migration, ...) by pre-seeding spec sections
+// and listing checklist items the spec must cover.
+type Template struct {
+	Name        string              `yaml:"-"`
+	Description string              `yaml:"description"`
+	Guidance    string              `yaml:"guidance"`
+	Sections    map[string][]string `yaml:"sections"`
+	Checklist   []string            `yaml:"checklist"`
+}
+
+// LoadTemplate reads the template <name>.yaml (or .yml) from dir. If it does
+// not exist, the error lists the available templates.
+func LoadTemplate(dir, name string) (*Template, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("invalid spec template name %q", name)
+	}
+	for _, ext := range []string{".yaml", ".yml"} {
+		data, err := os.ReadFile(filepath.Join(dir, name+ext))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading spec template %q: %w", name, err)
+		}
+		return parseTemplate(name, data)
+	}
+
+	available, _ := ListTemplates(dir)
+	if len(available) == 0 {
+		return nil, fmt.Errorf("spec template %q not found: no templates in %s", name, dir)
+	}
+	return nil, fmt.Errorf("spec template %q not found in %s (available: %s)", name, dir, strings.Join(available, ", "))
+}
+
+// parseTemplate decodes a template and checks its section names.
+func parseTemplate(name string, data []byte) (*Template, error) {
+	tmpl := &Template{Name: name}
+	if err := yaml.Unmarshal(data, tmpl); err != nil {
+		return nil, fmt.Errorf("parsing spec template %q: %w", name, err)
+	}
+	for section := range tmpl.Sections {
+		if !slices.Contains(templateSections, section) {
+			return nil, fmt.Errorf("spec template %q: unknown section %q (valid: %s)",
+				name, section, strings.Join(templateSections, ", "))
+		}
+	}
+	return tmpl, nil
+}
+
+// ListTemplates returns the sorted names of the templates in dir. A missing
+// directory has no templates.
+func ListTemplates(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading templates directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), ext))
+	}
+	slices.Sort(names)
+	return slices.Compact(names), nil
+}
+
+// Apply appends the template to a feature description: its guidance, the
+// items to seed into each spec.yaml section, and the required checklist.
+func (t *Template) Apply(description string) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(description, "\n"))
+	fmt.Fprintf(&b, "\n\nSpec template: %s", t.Name)
+	if t.Description != "" {
+		fmt.Fprintf(&b, " (%s)", t.Description)
+	}
+	b.WriteString("\n")
+	if guidance := strings.TrimSpace(t.Guidance); guidance != "" {
+		fmt.Fprintf(&b, "\n%s\n", guidance)
+	}
+	t.writeSections(&b)
+	if len(t.Checklist) > 0 {
+		b.WriteString("\nRequired checklist - the spec must address every item:\n")
+		for _, item := range t.Checklist {
+			fmt.Fprintf(&b, "- [ ] %s\n", item)
+		}
+	}
+	return b.String()
+}
+
+// writeSections lists the seeded items per section in spec.yaml order.
+func (t *Template) writeSections(b *strings.Builder) {
+	if len(t.Sections) == 0 {
+		return
+	}
+	b.WriteString("\nInclude these items in spec.yaml, adapted to the feature:\n")
+	for _, section := range templateSections {
+		items := t.Sections[section]
+		if len(items) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "%s:\n", section)
+		for _, item := range items {
+			fmt.Fprintf(b, "  - %s\n", item)
+		}
+	}
+}
+
+// RecordTemplate sets _meta.template in spec.yaml to the template name the
+// spec was created from.
+func RecordTemplate(specDir, name string) error {
+	return recordMeta(specDir, "template", name)
+}
@@ -0,0 +1,148 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const apiTemplate = `description: REST API endpoint
+guidance: |
+  Describe the method, path, request and response of every endpoint.
+sections:
+  edge_cases:
+    - Request exceeds the rate limit
+  requirements:
+    - Invalid input returns 400 with a field-level error body
+checklist:
+  - Authentication and authorization rules are specified
+  - Error responses are documented
+`
+
+func writeTemplates(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestLoadTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		files       map[string]string
+		name        string
+		wantErr     string
+		wantSection string
+	}{
+		"yaml template": {
+			files:       map[string]string{"api.yaml": apiTemplate},
+			name:        "api",
+			wantSection: "requirements",
+		},
+		"yml extension": {
+			files:       map[string]string{"bugfix.yml": "sections:\n  assumptions:\n    - Bug is reproducible\n"},
+			name:        "bugfix",
+			wantSection: "assumptions",
+		},
+		"missing template lists available": {
+			files:   map[string]string{"api.yaml": apiTemplate, "migration.yaml": "description: x\n", "notes.txt": "x"},
+			name:    "ui",
+			wantErr: "available: api, migration",
+		},
+		"no templates": {
+			name:    "api",
+			wantErr: "no templates in",
+		},
+		"unknown section": {
+			files:   map[string]string{"api.yaml": "sections:\n  endpoints:\n    - GET /users\n"},
+			name:    "api",
+			wantErr: `unknown section "endpoints"`,
+		},
+		"invalid yaml": {
+			files:   map[string]string{"api.yaml": "sections: [unclosed\n"},
+			name:    "api",
+			wantErr: `parsing spec template "api"`,
+		},
+		"path in name": {
+			name:    "../secrets",
+			wantErr: "invalid spec template name",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir := writeTemplates(t, tt.files)
+
+			tmpl, err := LoadTemplate(dir, tt.name)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.name, tmpl.Name)
+			assert.NotEmpty(t, tmpl.Sections[tt.wantSection])
+		})
+	}
+}
+
+func TestListTemplates_MissingDir(t *testing.T) {
+	t.Parallel()
+
+	names, err := ListTemplates(filepath.Join(t.TempDir(), "templates"))
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
+
+func TestTemplateApply(t *testing.T) {
+	t.Parallel()
+
+	tmpl, err := LoadTemplate(writeTemplates(t, map[string]string{"api.yaml": apiTemplate}), "api")
+	require.NoError(t, err)
+
+	got := tmpl.Apply("Add GET /users/{id}\n")
+
+	assert.Equal(t, `Add GET /users/{id}
+
+Spec template: api (REST API endpoint)
+
+Describe the method, path, request and response of every endpoint.
+
+Include these items in spec.yaml, adapted to the feature:
+requirements:
+  - Invalid input returns 400 with a field-level error body
+edge_cases:
+  - Request exceeds the rate limit
+
+Required checklist - the spec must address every item:
+- [ ] Authentication and authorization rules are specified
+- [ ] Error responses are documented
+`, got)
+}
+
+func TestRecordTemplate(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "spec.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte("feature:\n  branch: 001-x\n_meta:\n  version: \"1.0.0\"\n"), 0644))
+
+	require.NoError(t, RecordTemplate(dir, "api"))
+
+	data, err := os.ReadFile(specPath)
+	require.NoError(t, err)
+	var parsed struct {
+		Meta map[string]string `yaml:"_meta"`
+	}
+	require.NoError(t, yaml.Unmarshal(data, &parsed))
+	assert.Equal(t, "api", parsed.Meta["template"])
+	assert.Equal(t, "1.0.0", parsed.Meta["version"])
+}
@@ -0,0 +1,501 @@
+package speckit
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/build"
+	"gopkg.in/yaml.v3"
+)
+
+// meta is the _meta block of a converted artifact.
+type meta struct {
+	Version          string `yaml:"version"`
+	Generator        string `yaml:"generator"`
+	GeneratorVersion string `yaml:"generator_version"`
+	Created          string `yaml:"created"`
+	ArtifactType     string `yaml:"artifact_type"`
+}
+
+func newMeta(artifactType string) meta {
+	return meta{
+		Version:          "1.0.0",
+		Generator:        "autospec",
+		GeneratorVersion: build.Version,
+		Created:          time.Now().Format(time.RFC3339),
+		ArtifactType:     artifactType,
+	}
+}
+
+// section is a markdown heading and the text under it, up to the next
+// heading of the same or a higher level.
+type section struct {
+	Title string
+	Body  string
+}
+
+// sections splits text at headings of the given level (e.g. "## ").
+// Text before the first heading, or under a higher-level heading, is dropped.
+func sections(text, level string) []section {
+	var result []section
+	var current *section
+	var body strings.Builder
+	flush := func() {
+		if current != nil {
+			current.Body = strings.TrimSpace(body.String())
+			result = append(result, *current)
+		}
+		body.Reset()
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, level) {
+			flush()
+			current = &section{Title: strings.TrimSpace(strings.TrimPrefix(line, level))}
+			continue
+		}
+		if depth := headingDepth(line); depth > 0 && depth < len(level)-1 {
+			flush()
+			current = nil
+			continue
+		}
+		body.WriteString(line)
+		body.WriteString("\n")
+	}
+	flush()
+	return result
+}
+
+// headingDepth returns the level of a markdown heading line, or 0.
+func headingDepth(line string) int {
+	depth := len(line) - len(strings.TrimLeft(line, "#"))
+	if depth == 0 || !strings.HasPrefix(line[depth:], " ") {
+		return 0
+	}
+	return depth
+}
+
+// findSection returns the body of the first section whose title starts with
+// prefix (case-insensitive), or "".
+func findSection(secs []section, prefix string) string {
+	for _, s := range secs {
+		if strings.HasPrefix(strings.ToLower(s.Title), strings.ToLower(prefix)) {
+			return s.Body
+		}
+	}
+	return ""
+}
+
+var (
+	commentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+	// fieldPattern matches "**Name**: value" metadata, several of which may
+	// share a line separated by " | ".
+	fieldPattern = regexp.MustCompile(`\*\*([^*]+)\*\*:\s*([^|\n]*)`)
+	// labeledItemPattern matches "- **FR-001**: text" list items.
+	labeledItemPattern = regexp.MustCompile(`^\s*[-*]\s+\*\*([^*]+)\*\*:?\s*(.*)$`)
+	// bulletPattern matches "- text" list items.
+	bulletPattern = regexp.MustCompile(`^\s*[-*]\s+(.+)$`)
+)
+
+// field returns the value of the first "**name**: value" in text, without
+// surrounding backticks.
+func field(text, name string) string {
+	for _, m := range fieldPattern.FindAllStringSubmatch(text, -1) {
+		if strings.EqualFold(strings.TrimSpace(m[1]), name) {
+			return strings.Trim(strings.TrimSpace(m[2]), "`")
+		}
+	}
+	return ""
+}
+
+// labeledItems returns the "- **label**: text" items in text.
+func labeledItems(text string) [][2]string {
+	var items [][2]string
+	for _, line := range strings.Split(text, "\n") {
+		if m := labeledItemPattern.FindStringSubmatch(line); m != nil {
+			items = append(items, [2]string{strings.TrimSpace(m[1]), strings.TrimSpace(m[2])})
+		}
+	}
+	return items
+}
+
+// bullets returns the "- text" items in text.
+func bullets(text string) []string {
+	var items []string
+	for _, line := range strings.Split(text, "\n") {
+		if m := bulletPattern.FindStringSubmatch(line); m != nil {
+			items = append(items, strings.TrimSpace(m[1]))
+		}
+	}
+	return items
+}
+
+// firstParagraph returns text up to the first blank line or "**" field.
+func firstParagraph(text string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "**") {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, " ")
+}
+
+// storyID converts a spec-kit story number ("1", "US1") to "US-001".
+func storyID(n string) string {
+	num, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(n), "US"))
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("US-%03d", num)
+}
+
+type specArtifact struct {
+	Feature         specFeature     `yaml:"feature"`
+	UserStories     []userStory     `yaml:"user_stories"`
+	Requirements    requirements    `yaml:"requirements"`
+	SuccessCriteria successCriteria `yaml:"success_criteria,omitempty"`
+	KeyEntities     []keyEntity     `yaml:"key_entities,omitempty"`
+	EdgeCases       []edgeCase      `yaml:"edge_cases,omitempty"`
+	Assumptions     []string        `yaml:"assumptions,omitempty"`
+	Meta            meta            `yaml:"_meta"`
+}
+
+type specFeature struct {
+	Branch  string `yaml:"branch"`
+	Created string `yaml:"created"`
+	Status  string `yaml:"status"`
+	Input   string `yaml:"input,omitempty"`
+}
+
+type userStory struct {
+	ID                  string     `yaml:"id"`
+	Title               string     `yaml:"title"`
+	Priority            string     `yaml:"priority"`
+	AsA                 string     `yaml:"as_a"`
+	IWant               string     `yaml:"i_want"`
+	SoThat              string     `yaml:"so_that"`
+	WhyThisPriority     string     `yaml:"why_this_priority,omitempty"`
+	IndependentTest     string     `yaml:"independent_test,omitempty"`
+	AcceptanceScenarios []scenario `yaml:"acceptance_scenarios"`
+}
+
+type scenario struct {
+	Given string `yaml:"given"`
+	When  string `yaml:"when"`
+	Then  string `yaml:"then"`
+}
+
+type requirements struct {
+	Functional []requirement `yaml:"functional"`
+}
+
+type requirement struct {
+	ID          string `yaml:"id"`
+	Description string `yaml:"description"`
+	Testable    bool   `yaml:"testable"`
+}
+
+type successCriteria struct {
+	MeasurableOutcomes []outcome `yaml:"measurable_outcomes,omitempty"`
+}
+
+type outcome struct {
+	ID          string `yaml:"id"`
+	Description string `yaml:"description"`
+}
+
+type keyEntity struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+type edgeCase struct {
+	Scenario string `yaml:"scenario"`
+}
+
+var (
+	// storyHeadingPattern matches "User Story 1 - Title (Priority: P1)".
+	storyHeadingPattern = regexp.MustCompile(`(?i)^User Story (\d+)\s*[-–—:]\s*(.+?)\s*(?:\(Priority:\s*(P\d)\))?(?:\s*🎯.*)?$`)
+	// scenarioPattern matches "1. **Given** x, **When** y, **Then** z".
+	scenarioPattern = regexp.MustCompile(`(?i)\*\*Given\*\*\s*(.+?),?\s*\*\*When\*\*\s*(.+?),?\s*\*\*Then\*\*\s*(.+)`)
+	// asAPattern matches a narrative written as a classic user story.
+	asAPattern = regexp.MustCompile(`(?i)^As an? (.+?),\s*I (?:want|need|can) (?:to )?(.+?),?\s*so that (.+?)\.?$`)
+)
+
+// ConvertSpec converts a spec-kit spec.md to autospec's spec.yaml. branch is
+// used when the markdown doesn't name the feature branch.
+func ConvertSpec(content []byte, branch string) ([]byte, error) {
+	text := commentPattern.ReplaceAllString(string(content), "")
+
+	spec := specArtifact{
+		Feature: specFeature{
+			Branch:  orDefault(field(text, "Feature Branch"), branch),
+			Created: orDefault(field(text, "Created"), time.Now().Format("2006-01-02")),
+			Status:  specStatus(field(text, "Status")),
+			Input:   strings.Trim(strings.TrimSpace(strings.TrimPrefix(field(text, "Input"), "User description:")), `"`),
+		},
+		Meta: newMeta("spec"),
+	}
+
+	subsections := sections(text, "### ")
+	for _, s := range subsections {
+		if story, ok := parseUserStory(s); ok {
+			spec.UserStories = append(spec.UserStories, story)
+		}
+	}
+	if len(spec.UserStories) == 0 {
+		return nil, fmt.Errorf("no user stories found (expected \"### User Story 1 - Title (Priority: P1)\")")
+	}
+
+	spec.Requirements.Functional = []requirement{}
+	for _, item := range labeledItems(findSection(subsections, "Functional Requirements")) {
+		spec.Requirements.Functional = append(spec.Requirements.Functional,
+			requirement{ID: item[0], Description: item[1], Testable: true})
+	}
+	for _, item := range labeledItems(findSection(subsections, "Measurable Outcomes")) {
+		spec.SuccessCriteria.MeasurableOutcomes = append(spec.SuccessCriteria.MeasurableOutcomes,
+			outcome{ID: item[0], Description: item[1]})
+	}
+	for _, item := range labeledItems(findSection(subsections, "Key Entities")) {
+		spec.KeyEntities = append(spec.KeyEntities, keyEntity{Name: item[0], Description: item[1]})
+	}
+	for _, item := range bullets(findSection(subsections, "Edge Cases")) {
+		spec.EdgeCases = append(spec.EdgeCases, edgeCase{Scenario: item})
+	}
+	spec.Assumptions = bullets(findSection(sections(text, "## "), "Assumptions"))
+
+	return marshal(spec)
+}
+
+// parseUserStory converts a "### User Story N - Title (Priority: PN)"
+// section. The narrative becomes i_want unless it is an "As a ..., I want
+// ..., so that ..." sentence.
+func parseUserStory(s section) (userStory, bool) {
+	m := storyHeadingPattern.FindStringSubmatch(s.Title)
+	if m == nil {
+		return userStory{}, false
+	}
+	story := userStory{
+		ID:              storyID(m[1]),
+		Title:           m[2],
+		Priority:        orDefault(strings.ToUpper(m[3]), "P2"),
+		AsA:             "user",
+		WhyThisPriority: field(s.Body, "Why this priority"),
+		IndependentTest: field(s.Body, "Independent Test"),
+	}
+
+	narrative := firstParagraph(s.Body)
+	if n := asAPattern.FindStringSubmatch(narrative); n != nil {
+		story.AsA, story.IWant, story.SoThat = n[1], n[2], n[3]
+	} else {
+		story.IWant = orDefault(narrative, story.Title)
+		story.SoThat = orDefault(story.WhyThisPriority, story.Title)
+	}
+
+	story.AcceptanceScenarios = []scenario{}
+	for _, line := range strings.Split(s.Body, "\n") {
+		if sc := scenarioPattern.FindStringSubmatch(line); sc != nil {
+			story.AcceptanceScenarios = append(story.AcceptanceScenarios,
+				scenario{Given: strings.TrimSpace(sc[1]), When: strings.TrimSpace(sc[2]), Then: strings.TrimSpace(sc[3])})
+		}
+	}
+	return story, true
+}
+
+// specStatus maps a spec-kit status to autospec's, defaulting to Draft.
+func specStatus(status string) string {
+	for _, s := range []string{"Draft", "Review", "Approved", "Completed"} {
+		if strings.EqualFold(status, s) {
+			return s
+		}
+	}
+	return "Draft"
+}
+
+type planArtifact struct {
+	Plan             planInfo         `yaml:"plan"`
+	Summary          string           `yaml:"summary"`
+	TechnicalContext technicalContext `yaml:"technical_context"`
+	Meta             meta             `yaml:"_meta"`
+}
+
+type planInfo struct {
+	Branch   string `yaml:"branch"`
+	Created  string `yaml:"created,omitempty"`
+	SpecPath string `yaml:"spec_path"`
+}
+
+type technicalContext struct {
+	Language            string       `yaml:"language,omitempty"`
+	PrimaryDependencies []string     `yaml:"primary_dependencies,omitempty"`
+	Storage             string       `yaml:"storage,omitempty"`
+	Testing             *testingInfo `yaml:"testing,omitempty"`
+	TargetPlatform      string       `yaml:"target_platform,omitempty"`
+	ProjectType         string       `yaml:"project_type,omitempty"`
+	PerformanceGoals    string       `yaml:"performance_goals,omitempty"`
+	Constraints         []string     `yaml:"constraints,omitempty"`
+	ScaleScope          string       `yaml:"scale_scope,omitempty"`
+}
+
+type testingInfo struct {
+	Framework string `yaml:"framework"`
+}
+
+// ConvertPlan converts a spec-kit plan.md to autospec's plan.yaml.
+func ConvertPlan(content []byte, branch string) ([]byte, error) {
+	text := commentPattern.ReplaceAllString(string(content), "")
+	secs := sections(text, "## ")
+
+	summary := findSection(secs, "Summary")
+	if summary == "" {
+		return nil, fmt.Errorf("no summary found (expected \"## Summary\")")
+	}
+
+	context := findSection(secs, "Technical Context")
+	tc := technicalContext{
+		Language:            notApplicable(field(context, "Language/Version")),
+		PrimaryDependencies: splitList(notApplicable(field(context, "Primary Dependencies"))),
+		Storage:             notApplicable(field(context, "Storage")),
+		TargetPlatform:      notApplicable(field(context, "Target Platform")),
+		ProjectType:         notApplicable(field(context, "Project Type")),
+		PerformanceGoals:    notApplicable(field(context, "Performance Goals")),
+		Constraints:         splitList(notApplicable(field(context, "Constraints"))),
+		ScaleScope:          notApplicable(field(context, "Scale/Scope")),
+	}
+	if framework := notApplicable(field(context, "Testing")); framework != "" {
+		tc.Testing = &testingInfo{Framework: framework}
+	}
+
+	return marshal(planArtifact{
+		Plan: planInfo{
+			Branch:   orDefault(field(text, "Branch"), branch),
+			Created:  field(text, "Date"),
+			SpecPath: "spec.yaml",
+		},
+		Summary:          summary,
+		TechnicalContext: tc,
+		Meta:             newMeta("plan"),
+	})
+}
+
+// notApplicable returns "" for spec-kit's "N/A" and unfilled placeholders.
+func notApplicable(value string) string {
+	if strings.EqualFold(value, "N/A") || strings.HasPrefix(value, "[") {
+		return ""
+	}
+	return value
+}
+
+// splitList splits a comma-separated value.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+type constitutionArtifact struct {
+	Constitution constitutionInfo `yaml:"constitution"`
+	Principles   []principle      `yaml:"principles"`
+	Sections     []namedSection   `yaml:"sections,omitempty"`
+	Meta         meta             `yaml:"_meta"`
+}
+
+type constitutionInfo struct {
+	ProjectName string `yaml:"project_name"`
+	Version     string `yaml:"version"`
+	Ratified    string `yaml:"ratified,omitempty"`
+	LastAmended string `yaml:"last_amended,omitempty"`
+}
+
+type principle struct {
+	Name        string `yaml:"name"`
+	ID          string `yaml:"id"`
+	Priority    string `yaml:"priority"`
+	Description string `yaml:"description"`
+}
+
+type namedSection struct {
+	Name    string `yaml:"name"`
+	Content string `yaml:"content"`
+}
+
+var (
+	// projectNamePattern matches "# Project Constitution".
+	projectNamePattern = regexp.MustCompile(`(?m)^# (.+?) Constitution\s*$`)
+	// principleNumberPattern matches a roman or arabic numeral prefix ("IV. ").
+	principleNumberPattern = regexp.MustCompile(`^(?:[IVXLC]+|\d+)\.\s+`)
+	// footerPattern matches the "**Version**: ... | **Ratified**: ..." line.
+	footerPattern = regexp.MustCompile(`(?m)^\*\*Version\*\*:.*$`)
+)
+
+// ConvertConstitution converts a spec-kit constitution.md to autospec's
+// constitution.yaml. Principles are MUST unless marked NON-NEGOTIABLE; the
+// other top-level sections are kept as text.
+func ConvertConstitution(content []byte) ([]byte, error) {
+	text := commentPattern.ReplaceAllString(string(content), "")
+
+	c := constitutionArtifact{
+		Constitution: constitutionInfo{
+			ProjectName: "Project",
+			Version:     orDefault(field(text, "Version"), "1.0.0"),
+			Ratified:    field(text, "Ratified"),
+			LastAmended: field(text, "Last Amended"),
+		},
+		Meta: newMeta("constitution"),
+	}
+	if m := projectNamePattern.FindStringSubmatch(text); m != nil {
+		c.Constitution.ProjectName = m[1]
+	}
+
+	for _, s := range sections(text, "## ") {
+		if !strings.EqualFold(s.Title, "Core Principles") {
+			if body := strings.TrimSpace(footerPattern.ReplaceAllString(s.Body, "")); body != "" {
+				c.Sections = append(c.Sections, namedSection{Name: s.Title, Content: body})
+			}
+			continue
+		}
+		for _, p := range sections(s.Body, "### ") {
+			name := principleNumberPattern.ReplaceAllString(p.Title, "")
+			priority := "MUST"
+			if strings.Contains(strings.ToUpper(p.Title+p.Body), "NON-NEGOTIABLE") {
+				priority = "NON-NEGOTIABLE"
+				name = strings.TrimSpace(strings.ReplaceAll(name, "(NON-NEGOTIABLE)", ""))
+			}
+			c.Principles = append(c.Principles, principle{
+				Name:        name,
+				ID:          fmt.Sprintf("PRIN-%03d", len(c.Principles)+1),
+				Priority:    priority,
+				Description: orDefault(p.Body, name),
+			})
+		}
+	}
+	if len(c.Principles) == 0 {
+		return nil, fmt.Errorf("no principles found (expected \"### I. Name\" under \"## Core Principles\")")
+	}
+
+	return marshal(c)
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func marshal(v any) ([]byte, error) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling YAML: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,129 @@
+// Package speckit tests converting spec-kit markdown artifacts to YAML.
+// Related: internal/speckit/convert.go
+// Tags: speckit, migrate, convert, yaml
+
+package speckit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const fixtureFeature = "testdata/project/specs/001-photo-albums"
+
+// convertFixture converts a fixture file and checks that the result passes
+// autospec's artifact validation.
+func convertFixture(t *testing.T, path, artifact string, convert func([]byte) ([]byte, error)) []byte {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	data, err := convert(content)
+	require.NoError(t, err)
+
+	out := filepath.Join(t.TempDir(), artifact+".yaml")
+	require.NoError(t, os.WriteFile(out, data, 0644))
+	require.NoError(t, validation.ValidateArtifactFile(out))
+	return data
+}
+
+func TestConvertSpec(t *testing.T) {
+	t.Parallel()
+
+	data := convertFixture(t, filepath.Join(fixtureFeature, "spec.md"), "spec", func(c []byte) ([]byte, error) {
+		return ConvertSpec(c, "fallback-branch")
+	})
+
+	var spec specArtifact
+	require.NoError(t, yaml.Unmarshal(data, &spec))
+	assert.Equal(t, specFeature{
+		Branch:  "001-photo-albums",
+		Created: "2025-09-01",
+		Status:  "Draft",
+		Input:   "Organize photos into albums grouped by date",
+	}, spec.Feature)
+
+	require.Len(t, spec.UserStories, 2)
+	first := spec.UserStories[0]
+	assert.Equal(t, "US-001", first.ID)
+	assert.Equal(t, "Create an album", first.Title)
+	assert.Equal(t, "P1", first.Priority)
+	assert.Equal(t, "A user groups photos from one trip into an album.", first.IWant)
+	assert.Equal(t, "Albums are the core of the feature.", first.SoThat)
+	assert.Equal(t, "Create an album and see it listed.", first.IndependentTest)
+	assert.Equal(t, []scenario{
+		{Given: "a library with photos", When: "the user creates an album", Then: "the album is listed on the main page"},
+		{Given: "an album", When: "the user drags a photo onto it", Then: "the photo is added"},
+	}, first.AcceptanceScenarios)
+
+	second := spec.UserStories[1]
+	assert.Equal(t, "user", second.AsA)
+	assert.Equal(t, "drag albums into a new order", second.IWant)
+	assert.Equal(t, "my favorite albums come first", second.SoThat)
+
+	assert.Equal(t, []requirement{
+		{ID: "FR-001", Description: "System MUST let users create albums", Testable: true},
+		{ID: "FR-002", Description: "System MUST keep the album order between sessions", Testable: true},
+	}, spec.Requirements.Functional)
+	assert.Equal(t, []keyEntity{{Name: "Album", Description: "A named group of photos with a position"}}, spec.KeyEntities)
+	assert.Equal(t, []outcome{{ID: "SC-001", Description: "Users create an album in under 30 seconds"}}, spec.SuccessCriteria.MeasurableOutcomes)
+	assert.Len(t, spec.EdgeCases, 2)
+}
+
+func TestConvertSpec_NoStories(t *testing.T) {
+	t.Parallel()
+
+	_, err := ConvertSpec([]byte("# Feature Specification: Empty\n"), "001-empty")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no user stories found")
+}
+
+func TestConvertPlan(t *testing.T) {
+	t.Parallel()
+
+	data := convertFixture(t, filepath.Join(fixtureFeature, "plan.md"), "plan", func(c []byte) ([]byte, error) {
+		return ConvertPlan(c, "fallback-branch")
+	})
+
+	var plan planArtifact
+	require.NoError(t, yaml.Unmarshal(data, &plan))
+	assert.Equal(t, planInfo{Branch: "001-photo-albums", Created: "2025-09-02", SpecPath: "spec.yaml"}, plan.Plan)
+	assert.Equal(t, "A Vite app that stores album metadata in local SQLite.", plan.Summary)
+	assert.Equal(t, technicalContext{
+		Language:            "TypeScript 5.4",
+		PrimaryDependencies: []string{"Vite", "better-sqlite3"},
+		Storage:             "SQLite",
+		Testing:             &testingInfo{Framework: "Vitest"},
+		TargetPlatform:      "Modern browsers",
+		ProjectType:         "web",
+		Constraints:         []string{"offline-capable"},
+	}, plan.TechnicalContext)
+}
+
+func TestConvertConstitution(t *testing.T) {
+	t.Parallel()
+
+	data := convertFixture(t, "testdata/project/.specify/memory/constitution.md", "constitution", ConvertConstitution)
+
+	var c constitutionArtifact
+	require.NoError(t, yaml.Unmarshal(data, &c))
+	assert.Equal(t, constitutionInfo{
+		ProjectName: "Photo Albums",
+		Version:     "1.1.0",
+		Ratified:    "2025-06-13",
+		LastAmended: "2025-07-16",
+	}, c.Constitution)
+	assert.Equal(t, []principle{
+		{Name: "Library-First", ID: "PRIN-001", Priority: "MUST", Description: "Every feature starts as a standalone library with its own tests."},
+		{Name: "Test-First", ID: "PRIN-002", Priority: "NON-NEGOTIABLE", Description: "Tests are written and fail before the implementation."},
+	}, c.Principles)
+	assert.Equal(t, []namedSection{
+		{Name: "Development Workflow", Content: "Every change is reviewed before merge."},
+		{Name: "Governance", Content: "The constitution supersedes other practices."},
+	}, c.Sections)
+}
@@ -0,0 +1,279 @@
+// Package speckit migrates projects set up with GitHub's spec-kit to
+// autospec.
+// Related: internal/cli/config/migrate_speckit.go, internal/yaml/migrate.go
+// Tags: migrate, speckit, specs, constitution, scripts
+//
+// Detect finds a spec-kit layout: .specify/ (memory/, templates/, scripts/)
+// or, in older projects, memory/constitution.md with templates/ and scripts/
+// at the root, plus feature directories under specs/. Migrate converts each
+// feature's spec.md, plan.md and tasks.md to autospec's YAML artifacts, the
+// constitution to .autospec/memory/constitution.yaml, and copies scripts that
+// have no autospec command equivalent to .autospec/scripts/. Nothing is
+// overwritten or deleted, so the spec-kit files stay usable.
+package speckit
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+// Project is a detected spec-kit layout. Paths are relative to Root.
+type Project struct {
+	Root string
+	// ConstitutionPath is the constitution.md, or empty if there is none.
+	ConstitutionPath string
+	// TemplatesDir and ScriptsDir are empty when the project has none.
+	TemplatesDir string
+	ScriptsDir   string
+	// FeatureDirs are the directories under specs/ with a spec.md.
+	FeatureDirs []string
+}
+
+// Detect returns the spec-kit layout under root, or nil if root has neither
+// a .specify directory nor a memory/constitution.md.
+func Detect(root string) (*Project, error) {
+	base := ""
+	switch {
+	case isDir(filepath.Join(root, ".specify")):
+		base = ".specify"
+	case isFile(filepath.Join(root, "memory", "constitution.md")):
+	default:
+		return nil, nil
+	}
+
+	p := &Project{Root: root}
+	if path := filepath.Join(base, "memory", "constitution.md"); isFile(filepath.Join(root, path)) {
+		p.ConstitutionPath = path
+	}
+	if dir := filepath.Join(base, "templates"); isDir(filepath.Join(root, dir)) {
+		p.TemplatesDir = dir
+	}
+	if dir := filepath.Join(base, "scripts"); isDir(filepath.Join(root, dir)) {
+		p.ScriptsDir = dir
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, "specs"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("reading specs directory: %w", err)
+	}
+	for _, entry := range entries {
+		dir := filepath.Join("specs", entry.Name())
+		if entry.IsDir() && isFile(filepath.Join(root, dir, "spec.md")) {
+			p.FeatureDirs = append(p.FeatureDirs, dir)
+		}
+	}
+	return p, nil
+}
+
+// Action kinds reported by Migrate.
+const (
+	ActionConvert = "convert"
+	ActionCopy    = "copy"
+	ActionSkip    = "skip"
+)
+
+// Action is one file Migrate converted, copied or left alone.
+type Action struct {
+	Kind   string
+	Source string
+	// Dest is empty for skipped files with no autospec counterpart.
+	Dest string
+	// Note explains a skip.
+	Note string
+}
+
+// Options configures Migrate.
+type Options struct {
+	// SpecsDir is autospec's specs directory, relative to the project root.
+	SpecsDir string
+	// DryRun reports what would be done without writing anything.
+	DryRun bool
+}
+
+// scriptNotes explains why spec-kit's own scripts aren't copied: autospec
+// commands replace them.
+var scriptNotes = map[string]string{
+	"create-new-feature":       "replaced by 'autospec new-feature'",
+	"setup-plan":               "replaced by 'autospec setup-plan'",
+	"check-prerequisites":      "replaced by 'autospec prereqs'",
+	"check-task-prerequisites": "replaced by 'autospec prereqs'",
+	"get-feature-paths":        "replaced by 'autospec prereqs --paths-only'",
+	"update-agent-context":     "replaced by 'autospec update-agent-context'",
+	"common":                   "only used by the spec-kit scripts",
+}
+
+// converters convert a feature's spec-kit markdown artifacts. tasks.md uses
+// the same checkbox format as 'autospec convert tasks' reads.
+var converters = []struct {
+	name    string
+	convert func(content []byte, branch string) ([]byte, error)
+}{
+	{"spec", ConvertSpec},
+	{"plan", ConvertPlan},
+	{"tasks", validation.TasksMarkdownToYAML},
+}
+
+// Migrate converts p into autospec's structure. Existing files are never
+// overwritten; a file that fails to convert is skipped with the reason.
+func Migrate(p *Project, opts Options) ([]Action, error) {
+	m := migration{root: p.Root, dryRun: opts.DryRun}
+
+	if p.ConstitutionPath != "" {
+		m.convert(p.ConstitutionPath, filepath.Join(".autospec", "memory", "constitution.yaml"),
+			func(content []byte) ([]byte, error) { return ConvertConstitution(content) })
+	}
+
+	specsDir := filepath.Clean(opts.SpecsDir)
+	if opts.SpecsDir == "" {
+		specsDir = "specs"
+	}
+	for _, dir := range p.FeatureDirs {
+		branch := filepath.Base(dir)
+		dest := filepath.Join(specsDir, branch)
+		if dest != dir {
+			if err := m.copyTree(dir, dest, func(string) bool { return true }); err != nil {
+				return m.actions, err
+			}
+		}
+		for _, c := range converters {
+			source := filepath.Join(dir, c.name+".md")
+			if !isFile(filepath.Join(p.Root, source)) {
+				continue
+			}
+			convert := c.convert
+			m.convert(source, filepath.Join(dest, c.name+".yaml"),
+				func(content []byte) ([]byte, error) { return convert(content, branch) })
+		}
+	}
+
+	if p.ScriptsDir != "" {
+		err := m.copyTree(p.ScriptsDir, filepath.Join(".autospec", "scripts"), func(path string) bool {
+			name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+			if note, ok := scriptNotes[name]; ok {
+				m.skip(path, note)
+				return false
+			}
+			return true
+		})
+		if err != nil {
+			return m.actions, err
+		}
+	}
+
+	if p.TemplatesDir != "" {
+		m.skip(p.TemplatesDir, "autospec generates YAML artifacts from its own command templates; 'autospec init' installs them")
+	}
+	return m.actions, nil
+}
+
+// migration records actions and writes files under root.
+type migration struct {
+	root    string
+	dryRun  bool
+	actions []Action
+}
+
+func (m *migration) skip(source, note string) {
+	m.actions = append(m.actions, Action{Kind: ActionSkip, Source: source, Note: note})
+}
+
+// convert writes convert(source) to dest unless dest, or its .yml
+// alternative, already exists.
+func (m *migration) convert(source, dest string, convert func([]byte) ([]byte, error)) {
+	if m.exists(dest) || m.exists(strings.TrimSuffix(dest, ".yaml")+".yml") {
+		m.actions = append(m.actions, Action{Kind: ActionSkip, Source: source, Dest: dest, Note: "already exists"})
+		return
+	}
+	content, err := os.ReadFile(filepath.Join(m.root, source))
+	if err == nil {
+		content, err = convert(content)
+	}
+	if err == nil && !m.dryRun {
+		err = m.write(dest, content, 0644)
+	}
+	if err != nil {
+		m.actions = append(m.actions, Action{Kind: ActionSkip, Source: source, Dest: dest, Note: err.Error()})
+		return
+	}
+	m.actions = append(m.actions, Action{Kind: ActionConvert, Source: source, Dest: dest})
+}
+
+// copyTree copies the files under source that include accepts to the same
+// relative paths under dest, keeping existing files and file modes.
+func (m *migration) copyTree(source, dest string, include func(path string) bool) error {
+	var paths []string
+	err := filepath.WalkDir(filepath.Join(m.root, source), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			rel, _ := filepath.Rel(m.root, path)
+			paths = append(paths, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", source, err)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if !include(path) {
+			continue
+		}
+		rel, _ := filepath.Rel(source, path)
+		target := filepath.Join(dest, rel)
+		if m.exists(target) {
+			m.actions = append(m.actions, Action{Kind: ActionSkip, Source: path, Dest: target, Note: "already exists"})
+			continue
+		}
+		if !m.dryRun {
+			info, err := os.Stat(filepath.Join(m.root, path))
+			if err != nil {
+				return err
+			}
+			content, err := os.ReadFile(filepath.Join(m.root, path))
+			if err != nil {
+				return fmt.Errorf("reading %s: %w", path, err)
+			}
+			if err := m.write(target, content, info.Mode().Perm()); err != nil {
+				return err
+			}
+		}
+		m.actions = append(m.actions, Action{Kind: ActionCopy, Source: path, Dest: target})
+	}
+	return nil
+}
+
+func (m *migration) exists(path string) bool {
+	_, err := os.Stat(filepath.Join(m.root, path))
+	return err == nil
+}
+
+func (m *migration) write(path string, content []byte, perm os.FileMode) error {
+	full := filepath.Join(m.root, path)
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return fmt.Errorf("creating directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(full, content, perm); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
@@ -0,0 +1,142 @@
+// Package speckit tests detecting and migrating spec-kit projects.
+// Related: internal/speckit/speckit.go
+// Tags: speckit, migrate, detect
+
+package speckit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// copyFixture copies the spec-kit fixture project to a temp directory.
+func copyFixture(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	require.NoError(t, os.CopyFS(root, os.DirFS("testdata/project")))
+	return root
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	root := copyFixture(t)
+	p, err := Detect(root)
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Equal(t, &Project{
+		Root:             root,
+		ConstitutionPath: filepath.Join(".specify", "memory", "constitution.md"),
+		TemplatesDir:     filepath.Join(".specify", "templates"),
+		ScriptsDir:       filepath.Join(".specify", "scripts"),
+		FeatureDirs:      []string{filepath.Join("specs", "001-photo-albums")},
+	}, p)
+}
+
+func TestDetect_LegacyLayout(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "memory"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "memory", "constitution.md"), []byte("# X Constitution\n"), 0644))
+
+	p, err := Detect(root)
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Equal(t, filepath.Join("memory", "constitution.md"), p.ConstitutionPath)
+	assert.Empty(t, p.ScriptsDir)
+}
+
+func TestDetect_NotSpeckit(t *testing.T) {
+	t.Parallel()
+
+	p, err := Detect(t.TempDir())
+	require.NoError(t, err)
+	assert.Nil(t, p)
+}
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+
+	root := copyFixture(t)
+	p, err := Detect(root)
+	require.NoError(t, err)
+
+	actions, err := Migrate(p, Options{SpecsDir: "./specs"})
+	require.NoError(t, err)
+
+	byKind := make(map[string][]string)
+	for _, a := range actions {
+		byKind[a.Kind] = append(byKind[a.Kind], a.Source)
+	}
+	assert.ElementsMatch(t, []string{
+		filepath.Join(".specify", "memory", "constitution.md"),
+		filepath.Join("specs", "001-photo-albums", "spec.md"),
+		filepath.Join("specs", "001-photo-albums", "plan.md"),
+		filepath.Join("specs", "001-photo-albums", "tasks.md"),
+	}, byKind[ActionConvert])
+	assert.Equal(t, []string{filepath.Join(".specify", "scripts", "bash", "deploy-preview.sh")}, byKind[ActionCopy])
+	assert.Len(t, byKind[ActionSkip], 3, "spec-kit's own scripts and the templates")
+
+	for _, path := range []string{
+		".autospec/memory/constitution.yaml",
+		"specs/001-photo-albums/spec.yaml",
+		"specs/001-photo-albums/plan.yaml",
+		"specs/001-photo-albums/tasks.yaml",
+		"specs/001-photo-albums/spec.md",
+		".specify/memory/constitution.md",
+	} {
+		assert.FileExists(t, filepath.Join(root, path))
+		if filepath.Ext(path) == ".yaml" {
+			assert.NoError(t, validation.ValidateArtifactFile(filepath.Join(root, path)), path)
+		}
+	}
+	info, err := os.Stat(filepath.Join(root, ".autospec", "scripts", "bash", "deploy-preview.sh"))
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode().Perm()&0100, "scripts stay executable")
+	assert.NoFileExists(t, filepath.Join(root, ".autospec", "scripts", "bash", "create-new-feature.sh"))
+
+	// A second run leaves everything in place.
+	require.NoError(t, os.WriteFile(filepath.Join(root, "specs", "001-photo-albums", "spec.yaml"), []byte("edited"), 0644))
+	actions, err = Migrate(p, Options{SpecsDir: "specs"})
+	require.NoError(t, err)
+	for _, a := range actions {
+		assert.Equal(t, ActionSkip, a.Kind, a.Source)
+	}
+	data, err := os.ReadFile(filepath.Join(root, "specs", "001-photo-albums", "spec.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "edited", string(data))
+}
+
+func TestMigrate_OtherSpecsDir(t *testing.T) {
+	t.Parallel()
+
+	root := copyFixture(t)
+	p, err := Detect(root)
+	require.NoError(t, err)
+
+	_, err = Migrate(p, Options{SpecsDir: filepath.Join("docs", "specs")})
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(root, "docs", "specs", "001-photo-albums", "spec.yaml"))
+	assert.FileExists(t, filepath.Join(root, "docs", "specs", "001-photo-albums", "research.md"))
+	assert.NoFileExists(t, filepath.Join(root, "specs", "001-photo-albums", "spec.yaml"))
+}
+
+func TestMigrate_DryRun(t *testing.T) {
+	t.Parallel()
+
+	root := copyFixture(t)
+	p, err := Detect(root)
+	require.NoError(t, err)
+
+	actions, err := Migrate(p, Options{DryRun: true})
+	require.NoError(t, err)
+	assert.NotEmpty(t, actions)
+	assert.NoDirExists(t, filepath.Join(root, ".autospec"))
+	assert.NoFileExists(t, filepath.Join(root, "specs", "001-photo-albums", "spec.yaml"))
+}
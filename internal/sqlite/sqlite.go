@@ -0,0 +1,54 @@
+// Package sqlite runs SQL against SQLite database files through the sqlite3
+// command, so autospec can offer SQLite-backed storage without a cgo
+// driver. Statements are passed on stdin; values must be quoted with Quote
+// or encoded with Blob.
+package sqlite
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// BusyTimeout is how long sqlite3 waits for another process's write lock.
+const BusyTimeout = 5 * time.Second
+
+// Available reports an error if the sqlite3 command isn't on PATH.
+func Available() error {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		return fmt.Errorf("the sqlite3 command is required but was not found on PATH")
+	}
+	return nil
+}
+
+// Exec runs sql against the database at path, creating it if needed, and
+// returns the output. Execution stops at the first failing statement.
+func Exec(path, sql string) ([]byte, error) {
+	if err := Available(); err != nil {
+		return nil, err
+	}
+	cmd := exec.Command("sqlite3", "-bail", "-cmd", fmt.Sprintf(".timeout %d", BusyTimeout.Milliseconds()), path)
+	cmd.Stdin = strings.NewReader(sql)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("sqlite3 %s: %s", path, msg)
+		}
+		return nil, fmt.Errorf("sqlite3 %s: %w", path, err)
+	}
+	return out, nil
+}
+
+// Quote returns s as an SQL string literal.
+func Quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// Blob returns data as an SQL blob literal.
+func Blob(data []byte) string {
+	return "X'" + hex.EncodeToString(data) + "'"
+}
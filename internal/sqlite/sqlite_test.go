@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteAndBlob(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "'it''s'", Quote("it's"))
+	assert.Equal(t, "X'00ff'", Blob([]byte{0, 255}))
+}
+
+func TestExec(t *testing.T) {
+	t.Parallel()
+	if Available() != nil {
+		t.Skip("sqlite3 not installed")
+	}
+
+	path := filepath.Join(t.TempDir(), "test.db")
+	data := []byte("line one\n'quoted'|\x00binary")
+	_, err := Exec(path, "CREATE TABLE t (name TEXT, data BLOB);\n"+
+		"INSERT INTO t VALUES ("+Quote("a'b")+", "+Blob(data)+");\n")
+	require.NoError(t, err)
+
+	out, err := Exec(path, "SELECT name, hex(data) FROM t;\n")
+	require.NoError(t, err)
+	name, encoded, ok := strings.Cut(strings.TrimSpace(string(out)), "|")
+	require.True(t, ok)
+	assert.Equal(t, "a'b", name)
+	decoded, err := hex.DecodeString(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	_, err = Exec(path, "SELECT * FROM missing;\n")
+	assert.ErrorContains(t, err, "no such table")
+}
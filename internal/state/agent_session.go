@@ -0,0 +1,123 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+	"github.com/ariel-frischer/autospec/internal/filelock"
+)
+
+// AgentSessionFileName is the name of the file that stores the agent session
+// of each spec for session_mode: continuous.
+const AgentSessionFileName = "agent_sessions.json"
+
+// Values of the session_mode setting.
+const (
+	// SessionModeFresh starts every stage in a new agent session (default).
+	SessionModeFresh = "fresh"
+	// SessionModeContinuous resumes the spec's previous agent session, so the
+	// agent keeps its context from specify through implement.
+	SessionModeContinuous = "continuous"
+)
+
+// AgentSession is the last agent session that worked on a spec.
+type AgentSession struct {
+	// Agent is the name of the agent that owns the session; a session is only
+	// resumed by the same agent.
+	Agent string `json:"agent"`
+	// ID is the session ID reported by the agent.
+	ID string `json:"id"`
+	// Stage is the stage the session last ran.
+	Stage string `json:"stage"`
+	// UpdatedAt is when the session last ran.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SessionManager threads an agent session ID across the stages of a spec.
+// A nil SessionManager never resumes and records nothing, which is how
+// session_mode: fresh is represented.
+type SessionManager struct {
+	// StateDir is where AgentSessionFileName is stored.
+	StateDir string
+}
+
+// NewSessionManager returns a SessionManager for the session_mode setting, or
+// nil when sessions should not be continued.
+func NewSessionManager(stateDir, mode string) *SessionManager {
+	if mode != SessionModeContinuous || stateDir == "" {
+		return nil
+	}
+	return &SessionManager{StateDir: stateDir}
+}
+
+// Resume returns the session ID to continue for specName when run by agent,
+// or "" to start a fresh session.
+func (m *SessionManager) Resume(specName, agent string) string {
+	if m == nil || specName == "" {
+		return ""
+	}
+	session, ok := m.load()[specName]
+	if !ok || session.Agent != agent {
+		return ""
+	}
+	return session.ID
+}
+
+// Record stores id as the session of specName. An empty id, as reported by
+// agents without session support, leaves the stored session unchanged.
+func (m *SessionManager) Record(specName, agent, stage, id string) error {
+	if m == nil || specName == "" || id == "" {
+		return nil
+	}
+	return m.update(func(store map[string]AgentSession) {
+		store[specName] = AgentSession{Agent: agent, ID: id, Stage: stage, UpdatedAt: time.Now()}
+	})
+}
+
+// Clear forgets the session of specName so its next stage starts fresh.
+func (m *SessionManager) Clear(specName string) error {
+	if m == nil {
+		return nil
+	}
+	return m.update(func(store map[string]AgentSession) {
+		delete(store, specName)
+	})
+}
+
+// update applies fn to the stored sessions under a file lock.
+func (m *SessionManager) update(fn func(map[string]AgentSession)) error {
+	if err := os.MkdirAll(m.StateDir, 0755); err != nil {
+		return fmt.Errorf("creating state directory: %w", err)
+	}
+	path := m.path()
+	return filelock.With(path, func() error {
+		store := m.load()
+		fn(store)
+		data, err := json.MarshalIndent(store, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling agent sessions: %w", err)
+		}
+		return atomicfile.WriteFile(path, data, 0644)
+	})
+}
+
+// load reads the stored sessions, treating a missing or corrupted file as empty.
+func (m *SessionManager) load() map[string]AgentSession {
+	store := make(map[string]AgentSession)
+	data, err := os.ReadFile(m.path())
+	if err != nil {
+		return store
+	}
+	if err := json.Unmarshal(data, &store); err != nil {
+		return make(map[string]AgentSession)
+	}
+	return store
+}
+
+func (m *SessionManager) path() string {
+	return filepath.Join(m.StateDir, AgentSessionFileName)
+}
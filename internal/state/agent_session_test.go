@@ -0,0 +1,64 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSessionManager(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, NewSessionManager(t.TempDir(), SessionModeFresh))
+	assert.Nil(t, NewSessionManager(t.TempDir(), ""))
+	assert.Nil(t, NewSessionManager("", SessionModeContinuous))
+	assert.NotNil(t, NewSessionManager(t.TempDir(), SessionModeContinuous))
+}
+
+func TestSessionManager_ResumeAndRecord(t *testing.T) {
+	t.Parallel()
+
+	m := &SessionManager{StateDir: filepath.Join(t.TempDir(), "state")}
+	assert.Empty(t, m.Resume("001-auth", "claude"), "nothing recorded yet")
+
+	require.NoError(t, m.Record("001-auth", "claude", "specify", "sess-1"))
+	require.NoError(t, m.Record("002-cache", "claude", "plan", "sess-2"))
+	assert.Equal(t, "sess-1", m.Resume("001-auth", "claude"))
+	assert.Equal(t, "sess-2", m.Resume("002-cache", "claude"))
+	assert.Empty(t, m.Resume("001-auth", "codex"), "another agent can't resume the session")
+	assert.Empty(t, m.Resume("", "claude"))
+
+	require.NoError(t, m.Record("001-auth", "claude", "plan", "sess-3"))
+	assert.Equal(t, "sess-3", m.Resume("001-auth", "claude"))
+
+	require.NoError(t, m.Record("001-auth", "claude", "tasks", ""))
+	assert.Equal(t, "sess-3", m.Resume("001-auth", "claude"), "empty ID keeps the session")
+
+	require.NoError(t, m.Clear("001-auth"))
+	assert.Empty(t, m.Resume("001-auth", "claude"))
+	assert.Equal(t, "sess-2", m.Resume("002-cache", "claude"))
+}
+
+func TestSessionManager_Nil(t *testing.T) {
+	t.Parallel()
+
+	var m *SessionManager
+	assert.Empty(t, m.Resume("001-auth", "claude"))
+	assert.NoError(t, m.Record("001-auth", "claude", "plan", "sess-1"))
+	assert.NoError(t, m.Clear("001-auth"))
+}
+
+func TestSessionManager_CorruptedFile(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(stateDir, AgentSessionFileName), []byte("{not json"), 0644))
+
+	m := &SessionManager{StateDir: stateDir}
+	assert.Empty(t, m.Resume("001-auth", "claude"))
+	require.NoError(t, m.Record("001-auth", "claude", "plan", "sess-1"))
+	assert.Equal(t, "sess-1", m.Resume("001-auth", "claude"))
+}
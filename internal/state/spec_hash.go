@@ -0,0 +1,112 @@
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+	"github.com/ariel-frischer/autospec/internal/filelock"
+)
+
+// SpecHashFileName is the name of the file that stores the spec.yaml hashes
+// recorded when downstream artifacts were generated.
+const SpecHashFileName = "spec_hashes.json"
+
+// SpecHashRecord is the hash of spec.yaml when a stage last generated its
+// artifact from it.
+type SpecHashRecord struct {
+	// Hash is the hex SHA-256 of spec.yaml.
+	Hash string `json:"hash"`
+	// RecordedAt is when the stage completed.
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// specHashStore maps a spec name to the records of its stages ("plan", "tasks").
+type specHashStore map[string]map[string]SpecHashRecord
+
+// HashFile returns the hex SHA-256 of the file at path.
+func HashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// RecordSpecHash stores the current hash of specDir's spec.yaml as the one
+// stage generated its artifact from.
+func RecordSpecHash(stateDir, specDir, stage string) error {
+	hash, err := HashFile(filepath.Join(specDir, "spec.yaml"))
+	if err != nil {
+		return fmt.Errorf("hashing spec.yaml: %w", err)
+	}
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		return fmt.Errorf("creating state directory: %w", err)
+	}
+
+	path := filepath.Join(stateDir, SpecHashFileName)
+	return filelock.With(path, func() error {
+		store := loadSpecHashStore(path)
+		spec := filepath.Base(specDir)
+		if store[spec] == nil {
+			store[spec] = make(map[string]SpecHashRecord)
+		}
+		store[spec][stage] = SpecHashRecord{Hash: hash, RecordedAt: time.Now()}
+
+		data, err := json.MarshalIndent(store, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling spec hashes: %w", err)
+		}
+		return atomicfile.WriteFile(path, data, 0644)
+	})
+}
+
+// LoadSpecHashes returns the recorded spec.yaml hashes of a spec by stage.
+// Returns an empty map if none were recorded.
+func LoadSpecHashes(stateDir, specName string) map[string]SpecHashRecord {
+	records := loadSpecHashStore(filepath.Join(stateDir, SpecHashFileName))[specName]
+	if records == nil {
+		return map[string]SpecHashRecord{}
+	}
+	return records
+}
+
+// StaleStages returns, sorted, the stages whose artifact was generated from a
+// different spec.yaml than the one now in specDir. Stages without a recorded
+// hash, such as artifacts generated before hashes were tracked, are not
+// reported. Returns nil if spec.yaml doesn't exist.
+func StaleStages(stateDir, specDir string) []string {
+	hash, err := HashFile(filepath.Join(specDir, "spec.yaml"))
+	if err != nil {
+		return nil
+	}
+	var stale []string
+	for stage, record := range LoadSpecHashes(stateDir, filepath.Base(specDir)) {
+		if record.Hash != hash {
+			stale = append(stale, stage)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
+
+// loadSpecHashStore reads the hash store, treating a missing or corrupted
+// file as empty.
+func loadSpecHashStore(path string) specHashStore {
+	store := make(specHashStore)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return store
+	}
+	if err := json.Unmarshal(data, &store); err != nil {
+		return make(specHashStore)
+	}
+	return store
+}
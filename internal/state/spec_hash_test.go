@@ -0,0 +1,91 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleStages(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		setup     func(t *testing.T, stateDir, specDir string)
+		wantStale []string
+	}{
+		"nothing recorded": {
+			setup: func(t *testing.T, stateDir, specDir string) {},
+		},
+		"spec unchanged": {
+			setup: func(t *testing.T, stateDir, specDir string) {
+				require.NoError(t, RecordSpecHash(stateDir, specDir, "plan"))
+				require.NoError(t, RecordSpecHash(stateDir, specDir, "tasks"))
+			},
+		},
+		"spec changed after plan and tasks": {
+			setup: func(t *testing.T, stateDir, specDir string) {
+				require.NoError(t, RecordSpecHash(stateDir, specDir, "tasks"))
+				require.NoError(t, RecordSpecHash(stateDir, specDir, "plan"))
+				writeSpec(t, specDir, "feature: v2\n")
+			},
+			wantStale: []string{"plan", "tasks"},
+		},
+		"plan refreshed, tasks not": {
+			setup: func(t *testing.T, stateDir, specDir string) {
+				require.NoError(t, RecordSpecHash(stateDir, specDir, "plan"))
+				require.NoError(t, RecordSpecHash(stateDir, specDir, "tasks"))
+				writeSpec(t, specDir, "feature: v2\n")
+				require.NoError(t, RecordSpecHash(stateDir, specDir, "plan"))
+			},
+			wantStale: []string{"tasks"},
+		},
+		"corrupted state file": {
+			setup: func(t *testing.T, stateDir, specDir string) {
+				require.NoError(t, os.WriteFile(filepath.Join(stateDir, SpecHashFileName), []byte("{not json"), 0644))
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			stateDir := t.TempDir()
+			specDir := filepath.Join(t.TempDir(), "003-auth")
+			require.NoError(t, os.MkdirAll(specDir, 0755))
+			writeSpec(t, specDir, "feature: v1\n")
+
+			tt.setup(t, stateDir, specDir)
+			assert.Equal(t, tt.wantStale, StaleStages(stateDir, specDir))
+		})
+	}
+}
+
+func TestRecordSpecHash_KeepsOtherSpecs(t *testing.T) {
+	t.Parallel()
+	stateDir := t.TempDir()
+	root := t.TempDir()
+	for _, name := range []string{"001-a", "002-b"} {
+		specDir := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(specDir, 0755))
+		writeSpec(t, specDir, name)
+		require.NoError(t, RecordSpecHash(stateDir, specDir, "plan"))
+	}
+
+	assert.Contains(t, LoadSpecHashes(stateDir, "001-a"), "plan")
+	assert.Contains(t, LoadSpecHashes(stateDir, "002-b"), "plan")
+	assert.Empty(t, LoadSpecHashes(stateDir, "003-c"))
+}
+
+func TestRecordSpecHash_MissingSpec(t *testing.T) {
+	t.Parallel()
+	err := RecordSpecHash(t.TempDir(), t.TempDir(), "plan")
+	assert.ErrorContains(t, err, "hashing spec.yaml")
+}
+
+func writeSpec(t *testing.T, specDir, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "spec.yaml"), []byte(content), 0644))
+}
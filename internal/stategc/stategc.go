@@ -0,0 +1,155 @@
+// Package stategc removes the state autospec keeps for specs that are done
+// with: retry counts, phase and task progress, workflow checkpoints, agent
+// sessions and artifact snapshots. Without it, <state_dir> grows with every
+// spec ever run. Command history is left alone; history retention
+// (max_history_entries, max_history_age) handles it.
+package stategc
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/session"
+	"github.com/ariel-frischer/autospec/internal/snapshot"
+)
+
+// Reasons a spec's state is collected.
+const (
+	// ReasonArchived marks a spec moved to the specs archive.
+	ReasonArchived = "archived"
+	// ReasonDeleted marks a spec whose directory was deleted.
+	ReasonDeleted = "deleted"
+)
+
+// SpecState is the state collected for one spec.
+type SpecState struct {
+	Spec   string `json:"spec"`
+	Reason string `json:"reason"`
+	// Retries counts retry entries plus phase and task progress.
+	Retries    int  `json:"retries"`
+	Checkpoint bool `json:"checkpoint"`
+	Sessions   int  `json:"sessions"`
+	Snapshots  int  `json:"snapshots"`
+	// Bytes is the space the state took.
+	Bytes int64 `json:"bytes"`
+}
+
+// Result is the outcome of Collect.
+type Result struct {
+	Specs []SpecState `json:"specs"`
+	// Bytes is the space reclaimed, or that would be with DryRun.
+	Bytes  int64 `json:"bytes"`
+	DryRun bool  `json:"dry_run"`
+}
+
+// Collect removes the state of the specs in finished, which maps spec names
+// to the reason they are finished. Specs without state are skipped. With
+// dryRun, the state is measured but not removed.
+func Collect(stateDir string, finished map[string]string, dryRun bool) (*Result, error) {
+	sessions, err := session.List(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	sessionsBySpec := make(map[string][]*session.Session)
+	for _, s := range sessions {
+		sessionsBySpec[s.Spec] = append(sessionsBySpec[s.Spec], s)
+	}
+
+	result := &Result{Specs: []SpecState{}, DryRun: dryRun}
+	for _, name := range specsWithState(stateDir, finished, sessionsBySpec) {
+		state, err := collectSpec(stateDir, name, sessionsBySpec[name], dryRun)
+		if err != nil {
+			return result, err
+		}
+		state.Reason = finished[name]
+		result.Specs = append(result.Specs, *state)
+		result.Bytes += state.Bytes
+	}
+	return result, nil
+}
+
+// specsWithState returns the finished specs that have any state, sorted.
+func specsWithState(stateDir string, finished map[string]string, sessionsBySpec map[string][]*session.Session) []string {
+	var names []string
+	for name := range sessionsBySpec {
+		names = append(names, name)
+	}
+	if retried, err := retry.SpecNames(stateDir); err == nil {
+		names = append(names, retried...)
+	}
+	if snapshotted, err := snapshot.Specs(stateDir); err == nil {
+		names = append(names, snapshotted...)
+	}
+
+	var specs []string
+	for _, name := range names {
+		if _, ok := finished[name]; ok && name != "" && !slices.Contains(specs, name) {
+			specs = append(specs, name)
+		}
+	}
+	slices.Sort(specs)
+	return specs
+}
+
+// collectSpec measures and, unless dryRun, removes the state of one spec.
+func collectSpec(stateDir, name string, sessions []*session.Session, dryRun bool) (*SpecState, error) {
+	state := &SpecState{Spec: name, Sessions: len(sessions)}
+
+	exported, err := retry.ExportSpecState(stateDir, name)
+	if err != nil {
+		return nil, fmt.Errorf("reading state of %s: %w", name, err)
+	}
+	if !exported.IsEmpty() {
+		state.Retries = len(exported.Retries)
+		if exported.Stage != nil {
+			state.Retries++
+		}
+		if exported.Task != nil {
+			state.Retries++
+		}
+		state.Checkpoint = exported.Checkpoint != nil
+		state.Bytes += encodedSize(exported)
+	}
+	for _, s := range sessions {
+		state.Bytes += encodedSize(s)
+	}
+	snapshots, err := snapshot.List(stateDir, name)
+	if err != nil {
+		return nil, err
+	}
+	state.Snapshots = len(snapshots)
+	size, err := snapshot.DiskUsage(stateDir, name)
+	if err != nil {
+		return nil, err
+	}
+	state.Bytes += size
+
+	if dryRun {
+		return state, nil
+	}
+	if !exported.IsEmpty() {
+		if err := retry.ReplaceSpecState(stateDir, name, nil); err != nil {
+			return nil, fmt.Errorf("removing state of %s: %w", name, err)
+		}
+	}
+	for _, s := range sessions {
+		if err := session.Remove(stateDir, s.ID); err != nil {
+			return nil, err
+		}
+	}
+	if err := snapshot.RemoveAll(stateDir, name); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// encodedSize returns the size of v as stored, indented JSON.
+func encodedSize(v any) int64 {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return 0
+	}
+	return int64(len(data))
+}
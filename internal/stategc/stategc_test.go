@@ -0,0 +1,96 @@
+// Package stategc tests removing the state of finished specs.
+// Related: internal/stategc/stategc.go
+// Tags: state, gc, retry, sessions, snapshots
+
+package stategc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/session"
+	"github.com/ariel-frischer/autospec/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupState records retries, a checkpoint, a session and a snapshot for
+// each spec.
+func setupState(t *testing.T, specs ...string) string {
+	t.Helper()
+	stateDir := t.TempDir()
+	for _, name := range specs {
+		_, err := retry.IncrementRetryCount(stateDir, name, "plan", 3)
+		require.NoError(t, err)
+		require.NoError(t, retry.MarkTaskComplete(stateDir, name, "T001"))
+		require.NoError(t, retry.MarkCheckpointStage(stateDir, name, "", "specify"))
+		require.NoError(t, session.Save(stateDir, &session.Session{Spec: name, Stage: "plan", StartedAt: time.Now()}, 0))
+
+		specDir := filepath.Join(t.TempDir(), name)
+		require.NoError(t, os.MkdirAll(specDir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(specDir, "spec.yaml"), []byte("feature: {}\n"), 0o644))
+		_, err = snapshot.Take(stateDir, specDir, "plan", 5)
+		require.NoError(t, err)
+	}
+	return stateDir
+}
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		dryRun bool
+	}{
+		"remove":  {},
+		"dry run": {dryRun: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			stateDir := setupState(t, "001-login", "002-search")
+			finished := map[string]string{"001-login": ReasonArchived, "009-never-ran": ReasonDeleted}
+
+			result, err := Collect(stateDir, finished, tt.dryRun)
+			require.NoError(t, err)
+
+			require.Len(t, result.Specs, 1)
+			got := result.Specs[0]
+			assert.Equal(t, "001-login", got.Spec)
+			assert.Equal(t, ReasonArchived, got.Reason)
+			assert.Equal(t, 2, got.Retries)
+			assert.True(t, got.Checkpoint)
+			assert.Equal(t, 1, got.Sessions)
+			assert.Equal(t, 1, got.Snapshots)
+			assert.Positive(t, got.Bytes)
+			assert.Equal(t, got.Bytes, result.Bytes)
+			assert.Equal(t, tt.dryRun, result.DryRun)
+
+			names, err := retry.SpecNames(stateDir)
+			require.NoError(t, err)
+			sessions, err := session.List(stateDir)
+			require.NoError(t, err)
+			snapshotted, err := snapshot.Specs(stateDir)
+			require.NoError(t, err)
+			if tt.dryRun {
+				assert.Equal(t, []string{"001-login", "002-search"}, names)
+				assert.Len(t, sessions, 2)
+				assert.Equal(t, []string{"001-login", "002-search"}, snapshotted)
+				return
+			}
+			assert.Equal(t, []string{"002-search"}, names)
+			require.Len(t, sessions, 1)
+			assert.Equal(t, "002-search", sessions[0].Spec)
+			assert.Equal(t, []string{"002-search"}, snapshotted)
+
+			again, err := Collect(stateDir, finished, false)
+			require.NoError(t, err)
+			assert.Empty(t, again.Specs)
+			assert.Zero(t, again.Bytes)
+		})
+	}
+}
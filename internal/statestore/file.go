@@ -0,0 +1,103 @@
+package statestore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+	"github.com/ariel-frischer/autospec/internal/filelock"
+)
+
+// FileStorage keeps each document as a file under Dir.
+type FileStorage struct {
+	Dir string
+}
+
+func (s *FileStorage) path(name string) string {
+	return filepath.Join(s.Dir, filepath.FromSlash(name))
+}
+
+// Read implements Storage.
+func (s *FileStorage) Read(name string) ([]byte, error) {
+	return os.ReadFile(s.path(name))
+}
+
+// Write implements Storage. New files are private to the user, since
+// transcripts and history can contain secrets the agent printed.
+func (s *FileStorage) Write(name string, data []byte) error {
+	path := s.path(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("creating state directory: %w", err)
+	}
+	if err := atomicfile.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
+
+// Backup implements Storage. The replace time is the document's
+// modification time.
+func (s *FileStorage) Backup(name string) ([]byte, time.Time, error) {
+	info, err := os.Stat(s.path(name))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	data, err := os.ReadFile(s.path(name) + atomicfile.BackupSuffix)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return data, info.ModTime(), nil
+}
+
+// Delete implements Storage.
+func (s *FileStorage) Delete(name string) error {
+	if err := os.Remove(s.path(name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing %s: %w", name, err)
+	}
+	return nil
+}
+
+// List implements Storage. Backups, temp files and directories are
+// skipped.
+func (s *FileStorage) List(dir string) (map[string][]byte, error) {
+	entries, err := os.ReadDir(s.path(dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string][]byte{}, nil
+		}
+		return nil, fmt.Errorf("reading %s: %w", dir, err)
+	}
+	docs := make(map[string][]byte, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || skipFile(name) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.path(dir), name))
+		if err != nil {
+			continue // Removed in the meantime
+		}
+		docs[name] = data
+	}
+	return docs, nil
+}
+
+// skipFile reports whether a file in a listed directory isn't a document.
+func skipFile(name string) bool {
+	switch filepath.Ext(name) {
+	case atomicfile.BackupSuffix, filelock.Suffix, ".tmp", migratedSuffix:
+		return true
+	}
+	return name[0] == '.'
+}
+
+// Lock implements Storage.
+func (s *FileStorage) Lock(name string) (func(), error) {
+	return filelock.Lock(s.path(name))
+}
+
+func (s *FileStorage) String() string {
+	return s.Dir
+}
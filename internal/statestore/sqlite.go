@@ -0,0 +1,237 @@
+package statestore
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+	"github.com/ariel-frischer/autospec/internal/filelock"
+	"github.com/ariel-frischer/autospec/internal/sqlite"
+)
+
+// DatabaseName is the sqlite store's database file in the state directory.
+const DatabaseName = "state.db"
+
+// migratedSuffix is appended to state files imported into a new database.
+const migratedSuffix = ".migrated"
+
+// migrateFiles are the state files imported into a new database, as glob
+// patterns relative to the state directory.
+var migrateFiles = []string{"retry.json", "checkpoint.json", "history.yaml", "sessions/*.json"}
+
+// sqliteSchema creates the documents table. Backup holds the version the
+// last write replaced.
+const sqliteSchema = `CREATE TABLE IF NOT EXISTS documents (
+  name TEXT PRIMARY KEY,
+  data BLOB NOT NULL,
+  backup BLOB,
+  updated_at TEXT NOT NULL
+);
+`
+
+// SQLiteStorage keeps documents in Dir/state.db through the sqlite3
+// command.
+type SQLiteStorage struct {
+	Dir string
+}
+
+// Path returns the database file.
+func (s *SQLiteStorage) Path() string {
+	return filepath.Join(s.Dir, DatabaseName)
+}
+
+// Read implements Storage.
+func (s *SQLiteStorage) Read(name string) ([]byte, error) {
+	// The 'x' prefix tells an empty document from a missing one
+	out, err := s.exec(fmt.Sprintf("SELECT 'x' || hex(data) FROM documents WHERE name = %s;\n", sqlite.Quote(name)))
+	if err != nil {
+		return nil, err
+	}
+	row := strings.TrimSpace(string(out))
+	if row == "" {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+	return decodeHex(name, row[1:])
+}
+
+// Write implements Storage.
+func (s *SQLiteStorage) Write(name string, data []byte) error {
+	_, err := s.exec(fmt.Sprintf("INSERT INTO documents (name, data, updated_at) VALUES (%s, %s, %s)\n"+
+		"ON CONFLICT (name) DO UPDATE SET backup = data, data = excluded.data, updated_at = excluded.updated_at;\n",
+		sqlite.Quote(name), sqlite.Blob(data), sqlite.Quote(time.Now().UTC().Format(time.RFC3339Nano))))
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
+
+// Backup implements Storage.
+func (s *SQLiteStorage) Backup(name string) ([]byte, time.Time, error) {
+	out, err := s.exec(fmt.Sprintf("SELECT updated_at || '|' || hex(backup) FROM documents "+
+		"WHERE name = %s AND backup IS NOT NULL;\n", sqlite.Quote(name)))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	updatedAt, encoded, ok := strings.Cut(strings.TrimSpace(string(out)), "|")
+	if !ok {
+		return nil, time.Time{}, &fs.PathError{Op: "read", Path: name + atomicfile.BackupSuffix, Err: fs.ErrNotExist}
+	}
+	data, err := decodeHex(name, encoded)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	replacedAt, _ := time.Parse(time.RFC3339Nano, updatedAt)
+	return data, replacedAt, nil
+}
+
+// Delete implements Storage.
+func (s *SQLiteStorage) Delete(name string) error {
+	if _, err := s.exec(fmt.Sprintf("DELETE FROM documents WHERE name = %s;\n", sqlite.Quote(name))); err != nil {
+		return fmt.Errorf("removing %s: %w", name, err)
+	}
+	return nil
+}
+
+// List implements Storage.
+func (s *SQLiteStorage) List(dir string) (map[string][]byte, error) {
+	prefix := strings.TrimSuffix(dir, "/") + "/"
+	out, err := s.exec(fmt.Sprintf("SELECT hex(name) || '|' || hex(data) FROM documents "+
+		"WHERE substr(name, 1, %d) = %s AND instr(substr(name, %d), '/') = 0;\n",
+		len(prefix), sqlite.Quote(prefix), len(prefix)+1))
+	if err != nil {
+		return nil, err
+	}
+	docs := make(map[string][]byte)
+	for _, row := range strings.Fields(string(out)) {
+		encodedName, encoded, _ := strings.Cut(row, "|")
+		name, err := decodeHex(dir, encodedName)
+		if err != nil {
+			return nil, err
+		}
+		data, err := decodeHex(string(name), encoded)
+		if err != nil {
+			return nil, err
+		}
+		docs[strings.TrimPrefix(string(name), prefix)] = data
+	}
+	return docs, nil
+}
+
+// Lock implements Storage. Documents are locked by path, as in the file
+// store, so that a lock is held across the sqlite3 invocations of a
+// read-modify-write sequence.
+func (s *SQLiteStorage) Lock(name string) (func(), error) {
+	return filelock.Lock(filepath.Join(s.Dir, filepath.FromSlash(name)))
+}
+
+func (s *SQLiteStorage) String() string {
+	return s.Path()
+}
+
+// exec runs sql against the database, creating it first if needed.
+func (s *SQLiteStorage) exec(sql string) ([]byte, error) {
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return sqlite.Exec(s.Path(), sqliteSchema+sql)
+}
+
+// open creates the database if it doesn't exist, importing the state files
+// in the state directory. The database is built under a temp name and
+// renamed into place, so a failed import leaves the files in use.
+func (s *SQLiteStorage) open() error {
+	if _, err := os.Stat(s.Path()); err == nil {
+		return nil
+	}
+	if err := sqlite.Available(); err != nil {
+		return fmt.Errorf("state_store sqlite: %w", err)
+	}
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return fmt.Errorf("creating state directory: %w", err)
+	}
+	unlock, err := filelock.Lock(s.Path())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if _, err := os.Stat(s.Path()); err == nil {
+		return nil // Created by another process while we waited
+	}
+
+	files, sql, err := s.importSQL()
+	if err != nil {
+		return err
+	}
+	tmpPath := s.Path() + ".tmp"
+	os.Remove(tmpPath)
+	if _, err := sqlite.Exec(tmpPath, sql); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("migrating state files to %s: %w", s.Path(), err)
+	}
+	if err := os.Chmod(tmpPath, 0o600); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("setting permissions on %s: %w", s.Path(), err)
+	}
+	if err := os.Rename(tmpPath, s.Path()); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("creating %s: %w", s.Path(), err)
+	}
+
+	for _, path := range files {
+		if err := os.Rename(path, path+migratedSuffix); err != nil {
+			return fmt.Errorf("renaming migrated state file: %w", err)
+		}
+	}
+	return nil
+}
+
+// importSQL returns the state files to migrate and the SQL that creates
+// the database with their content. A file's backup becomes the document's
+// backup.
+func (s *SQLiteStorage) importSQL() ([]string, string, error) {
+	var files []string
+	for _, pattern := range migrateFiles {
+		matches, err := filepath.Glob(filepath.Join(s.Dir, filepath.FromSlash(pattern)))
+		if err != nil {
+			return nil, "", fmt.Errorf("finding state files: %w", err)
+		}
+		files = append(files, matches...)
+	}
+
+	var sql strings.Builder
+	sql.WriteString(sqliteSchema)
+	sql.WriteString("BEGIN;\n")
+	for _, path := range files {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, "", fmt.Errorf("reading state file: %w", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, "", fmt.Errorf("reading state file: %w", err)
+		}
+		backup := "NULL"
+		if previous, err := os.ReadFile(path + atomicfile.BackupSuffix); err == nil {
+			backup = sqlite.Blob(previous)
+		}
+		rel, _ := filepath.Rel(s.Dir, path)
+		fmt.Fprintf(&sql, "INSERT INTO documents (name, data, backup, updated_at) VALUES (%s, %s, %s, %s);\n",
+			sqlite.Quote(filepath.ToSlash(rel)), sqlite.Blob(data), backup,
+			sqlite.Quote(info.ModTime().UTC().Format(time.RFC3339Nano)))
+	}
+	sql.WriteString("COMMIT;\n")
+	return files, sql.String(), nil
+}
+
+func decodeHex(name, encoded string) ([]byte, error) {
+	data, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", name, err)
+	}
+	return data, nil
+}
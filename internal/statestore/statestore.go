@@ -0,0 +1,115 @@
+// Package statestore stores autospec's state documents: retry.json,
+// checkpoint.json, history.yaml and the session transcripts under
+// sessions/. Documents are named by their path relative to the state
+// directory.
+//
+// The default file store keeps each document as a file, replaced
+// atomically with the previous version kept in <name>.bak. The sqlite store
+// keeps them all in <state_dir>/state.db, which avoids rewriting large
+// files and scanning the sessions directory on big repositories. The first
+// time the sqlite store opens a state directory it imports the existing
+// state files and renames them to <name>.migrated.
+//
+// In both stores, updates take a file lock on the document's path (see
+// filelock) so concurrent autospec processes don't lose each other's
+// changes.
+package statestore
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Store kinds for the state_store config key.
+const (
+	// KindFile keeps each state document as a file.
+	KindFile = "file"
+	// KindSQLite keeps state documents in an SQLite database.
+	KindSQLite = "sqlite"
+)
+
+// Kinds lists the valid store kinds.
+var Kinds = []string{KindFile, KindSQLite}
+
+// EnvVar selects the store kind when Use hasn't been called, e.g. in
+// processes started by autospec.
+const EnvVar = "AUTOSPEC_STATE_STORE"
+
+// Storage reads and writes the documents of one state directory.
+type Storage interface {
+	// Read returns the document's content. A missing document is an error
+	// satisfying os.IsNotExist.
+	Read(name string) ([]byte, error)
+	// Write replaces the document, keeping the previous version as its
+	// backup.
+	Write(name string, data []byte) error
+	// Backup returns the version Write last replaced and when it was
+	// replaced. A document that was never replaced is an error satisfying
+	// os.IsNotExist.
+	Backup(name string) ([]byte, time.Time, error)
+	// Delete removes the document. Deleting a missing document is not an
+	// error.
+	Delete(name string) error
+	// List returns the documents directly inside dir, keyed by base name.
+	List(dir string) (map[string][]byte, error)
+	// Lock locks the document for a read-modify-write sequence and returns
+	// a function that releases it.
+	Lock(name string) (func(), error)
+	// String describes where the documents are stored.
+	String() string
+}
+
+var (
+	mu       sync.Mutex
+	selected string
+)
+
+// Use selects the store kind returned by For. An empty kind selects the
+// default, the value of AUTOSPEC_STATE_STORE or else the file store.
+func Use(kind string) error {
+	if err := Validate(kind); err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	selected = kind
+	return nil
+}
+
+// Validate returns an error if kind isn't empty or one of Kinds.
+func Validate(kind string) error {
+	if kind == "" {
+		return nil
+	}
+	for _, k := range Kinds {
+		if kind == k {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown state store %q (valid: %s)", kind, strings.Join(Kinds, ", "))
+}
+
+// Current returns the selected store kind.
+func Current() string {
+	mu.Lock()
+	kind := selected
+	mu.Unlock()
+	if kind == "" {
+		kind = os.Getenv(EnvVar)
+	}
+	if kind == KindSQLite {
+		return KindSQLite
+	}
+	return KindFile
+}
+
+// For returns the selected store for stateDir.
+func For(stateDir string) Storage {
+	if Current() == KindSQLite {
+		return &SQLiteStorage{Dir: stateDir}
+	}
+	return &FileStorage{Dir: stateDir}
+}
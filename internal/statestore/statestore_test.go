@@ -0,0 +1,138 @@
+// Package statestore tests the file and sqlite state stores.
+// Related: internal/statestore/statestore.go, internal/statestore/file.go, internal/statestore/sqlite.go
+// Tags: state, storage, sqlite, migration
+
+package statestore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]func(dir string) Storage{
+		"file":   func(dir string) Storage { return &FileStorage{Dir: dir} },
+		"sqlite": func(dir string) Storage { return &SQLiteStorage{Dir: dir} },
+	}
+
+	for name, newStorage := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if name == KindSQLite && sqlite.Available() != nil {
+				t.Skip("sqlite3 not installed")
+			}
+			s := newStorage(t.TempDir())
+
+			_, err := s.Read("retry.json")
+			assert.True(t, os.IsNotExist(err), "missing document: %v", err)
+			_, _, err = s.Backup("retry.json")
+			assert.True(t, os.IsNotExist(err), "missing backup: %v", err)
+
+			require.NoError(t, s.Write("retry.json", []byte(`{"v":1}`)))
+			require.NoError(t, s.Write("retry.json", []byte(`{"v":2}`)))
+			data, err := s.Read("retry.json")
+			require.NoError(t, err)
+			assert.Equal(t, `{"v":2}`, string(data))
+			backup, replacedAt, err := s.Backup("retry.json")
+			require.NoError(t, err)
+			assert.Equal(t, `{"v":1}`, string(backup))
+			assert.False(t, replacedAt.IsZero())
+
+			require.NoError(t, s.Write("empty.json", nil))
+			data, err = s.Read("empty.json")
+			require.NoError(t, err)
+			assert.Empty(t, data)
+
+			require.NoError(t, s.Write("sessions/a.json", []byte("a")))
+			require.NoError(t, s.Write("sessions/b.json", []byte("b")))
+			require.NoError(t, s.Write("sessions/old/c.json", []byte("c")))
+			docs, err := s.List("sessions")
+			require.NoError(t, err)
+			assert.Equal(t, map[string][]byte{"a.json": []byte("a"), "b.json": []byte("b")}, docs)
+
+			require.NoError(t, s.Delete("sessions/a.json"))
+			require.NoError(t, s.Delete("sessions/a.json"))
+			docs, err = s.List("sessions")
+			require.NoError(t, err)
+			assert.Len(t, docs, 1)
+
+			unlock, err := s.Lock("retry.json")
+			require.NoError(t, err)
+			unlock()
+		})
+	}
+}
+
+func TestSQLiteStorage_Migration(t *testing.T) {
+	t.Parallel()
+	if sqlite.Available() != nil {
+		t.Skip("sqlite3 not installed")
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"retry.json":         `{"retries":{}}`,
+		"retry.json.bak":     `{"old":true}`,
+		"history.yaml":       "entries: []\n",
+		"sessions/s1.json":   `{"id":"s1"}`,
+		"sessions/s1.live":   "live output",
+		"history-2026.yaml":  "entries: []\n",
+		"checkpoint.json":    `{}`,
+		"sessions/notes.txt": "unrelated",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	s := &SQLiteStorage{Dir: dir}
+	data, err := s.Read("retry.json")
+	require.NoError(t, err)
+	assert.Equal(t, `{"retries":{}}`, string(data))
+	backup, _, err := s.Backup("retry.json")
+	require.NoError(t, err)
+	assert.Equal(t, `{"old":true}`, string(backup))
+	docs, err := s.List("sessions")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"s1.json": []byte(`{"id":"s1"}`)}, docs)
+
+	assert.FileExists(t, filepath.Join(dir, DatabaseName))
+	for _, name := range []string{"retry.json", "history.yaml", "checkpoint.json", "sessions/s1.json"} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoFileExists(t, path)
+		assert.FileExists(t, path+migratedSuffix)
+	}
+	for _, name := range []string{"sessions/s1.live", "history-2026.yaml", "sessions/notes.txt"} {
+		assert.FileExists(t, filepath.Join(dir, filepath.FromSlash(name)), "%s is not migrated", name)
+	}
+
+	// Files written after the migration aren't imported again
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "retry.json"), []byte(`{"stale":true}`), 0o644))
+	data, err = s.Read("retry.json")
+	require.NoError(t, err)
+	assert.Equal(t, `{"retries":{}}`, string(data))
+}
+
+func TestUse(t *testing.T) {
+	// Not parallel: changes the selected store
+	t.Cleanup(func() { _ = Use("") })
+
+	t.Setenv(EnvVar, "")
+	assert.IsType(t, &FileStorage{}, For(t.TempDir()))
+
+	t.Setenv(EnvVar, KindSQLite)
+	assert.IsType(t, &SQLiteStorage{}, For(t.TempDir()))
+
+	require.NoError(t, Use(KindFile))
+	assert.Equal(t, KindFile, Current())
+
+	assert.ErrorContains(t, Use("postgres"), `unknown state store "postgres"`)
+}
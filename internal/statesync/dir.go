@@ -0,0 +1,64 @@
+package statesync
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+)
+
+// DirStore keeps bundles in a directory, e.g. on a network or synced drive.
+type DirStore struct {
+	Dir string
+}
+
+// NewDirStore returns a store in dir.
+func NewDirStore(dir string) *DirStore {
+	return &DirStore{Dir: dir}
+}
+
+func (s *DirStore) String() string { return s.Dir }
+
+func (s *DirStore) Read() (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(s.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == s.Dir && os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.Dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading sync dir %s: %w", s.Dir, err)
+	}
+	return files, nil
+}
+
+func (s *DirStore) Write(files map[string][]byte, _ string) error {
+	for name, data := range files {
+		path := filepath.Join(s.Dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("creating sync dir: %w", err)
+		}
+		if err := atomicfile.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+	}
+	return nil
+}
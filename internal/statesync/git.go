@@ -0,0 +1,215 @@
+package statesync
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// GitBranchStore keeps bundles on an orphan branch of the project's git
+// repository. The branch is never checked out, so its files stay out of
+// the working tree, and it shares no history with the project's branches.
+type GitBranchStore struct {
+	// Dir is any directory inside the repository.
+	Dir string
+	// Branch is the state branch (default DefaultBranch).
+	Branch string
+	// Remote is fetched from and pushed to. If the repository has no such
+	// remote, the branch is kept locally.
+	Remote string
+}
+
+// NewGitBranchStore returns a store on branch, shared through remote.
+func NewGitBranchStore(dir, branch, remote string) *GitBranchStore {
+	if branch == "" {
+		branch = DefaultBranch
+	}
+	return &GitBranchStore{Dir: dir, Branch: branch, Remote: remote}
+}
+
+func (s *GitBranchStore) String() string {
+	if s.hasRemote() {
+		return s.Remote + "/" + s.Branch
+	}
+	return s.Branch
+}
+
+// Read fetches the branch from the remote and returns its files.
+func (s *GitBranchStore) Read() (map[string][]byte, error) {
+	tip, err := s.tip()
+	if err != nil || tip == "" {
+		return map[string][]byte{}, err
+	}
+	out, err := s.git(nil, "ls-tree", "-r", "-z", "--name-only", tip)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string][]byte)
+	for _, name := range strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00") {
+		if name == "" {
+			continue
+		}
+		data, err := s.git(nil, "cat-file", "blob", tip+":"+name)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = data
+	}
+	return files, nil
+}
+
+// Write commits files on top of the branch and pushes it.
+func (s *GitBranchStore) Write(files map[string][]byte, message string) error {
+	tip, err := s.tip()
+	if err != nil {
+		return err
+	}
+
+	index, err := os.CreateTemp("", "autospec-sync-index-*")
+	if err != nil {
+		return fmt.Errorf("creating temp index: %w", err)
+	}
+	index.Close()
+	os.Remove(index.Name()) // git wants to create the index itself
+	defer os.Remove(index.Name())
+	env := []string{"GIT_INDEX_FILE=" + index.Name()}
+
+	readTree := []string{"read-tree", "--empty"}
+	if tip != "" {
+		readTree = []string{"read-tree", tip}
+	}
+	if _, err := s.git(env, readTree...); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		blob, err := s.gitInput(files[name], "hash-object", "-w", "--stdin")
+		if err != nil {
+			return err
+		}
+		cacheInfo := fmt.Sprintf("100644,%s,%s", strings.TrimSpace(string(blob)), name)
+		if _, err := s.git(env, "update-index", "--add", "--cacheinfo", cacheInfo); err != nil {
+			return err
+		}
+	}
+	tree, err := s.git(env, "write-tree")
+	if err != nil {
+		return err
+	}
+	treeID := strings.TrimSpace(string(tree))
+
+	if tip != "" {
+		oldTree, err := s.git(nil, "rev-parse", tip+"^{tree}")
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(string(oldTree)) == treeID {
+			return nil
+		}
+	}
+
+	commitArgs := []string{"commit-tree", treeID, "-m", message}
+	if tip != "" {
+		commitArgs = append(commitArgs, "-p", tip)
+	}
+	commit, err := s.gitCommit(commitArgs...)
+	if err != nil {
+		return err
+	}
+	commitID := strings.TrimSpace(string(commit))
+	if _, err := s.git(nil, "update-ref", "refs/heads/"+s.Branch, commitID); err != nil {
+		return err
+	}
+	if s.hasRemote() {
+		if _, err := s.git(nil, "push", "--quiet", s.Remote, "refs/heads/"+s.Branch+":refs/heads/"+s.Branch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tip returns the commit to read from and build on: the remote branch
+// after fetching it, else the local branch, or "" if neither exists.
+func (s *GitBranchStore) tip() (string, error) {
+	if s.hasRemote() {
+		remoteRef := "refs/remotes/" + s.Remote + "/" + s.Branch
+		heads, err := s.git(nil, "ls-remote", "--heads", s.Remote, "refs/heads/"+s.Branch)
+		if err != nil {
+			return "", err
+		}
+		if len(bytes.TrimSpace(heads)) > 0 {
+			if _, err := s.git(nil, "fetch", "--quiet", s.Remote, "+refs/heads/"+s.Branch+":"+remoteRef); err != nil {
+				return "", err
+			}
+			return s.resolve(remoteRef), nil
+		}
+	}
+	return s.resolve("refs/heads/" + s.Branch), nil
+}
+
+// resolve returns the commit ref points to, or "" if it doesn't exist.
+func (s *GitBranchStore) resolve(ref string) string {
+	out, err := s.git(nil, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func (s *GitBranchStore) hasRemote() bool {
+	if s.Remote == "" {
+		return false
+	}
+	_, err := s.git(nil, "remote", "get-url", s.Remote)
+	return err == nil
+}
+
+// gitCommit runs a commit command, falling back to a placeholder identity
+// when git has none configured.
+func (s *GitBranchStore) gitCommit(args ...string) ([]byte, error) {
+	var env []string
+	if _, err := s.git(nil, "var", "GIT_COMMITTER_IDENT"); err != nil {
+		env = []string{
+			"GIT_AUTHOR_NAME=autospec", "GIT_AUTHOR_EMAIL=autospec@localhost",
+			"GIT_COMMITTER_NAME=autospec", "GIT_COMMITTER_EMAIL=autospec@localhost",
+		}
+	}
+	return s.git(env, args...)
+}
+
+func (s *GitBranchStore) git(env []string, args ...string) ([]byte, error) {
+	return s.run(env, nil, args...)
+}
+
+func (s *GitBranchStore) gitInput(stdin []byte, args ...string) ([]byte, error) {
+	return s.run(nil, stdin, args...)
+}
+
+// run runs git in the store's directory and returns its stdout.
+func (s *GitBranchStore) run(env []string, stdin []byte, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = s.Dir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return out, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return out, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
@@ -0,0 +1,231 @@
+// Package statesync moves per-spec run state (retry counts, phase and task
+// progress, workflow checkpoints and history) between machines through a
+// shared store, so a run started on one machine can continue on another.
+//
+// State is handed over whole per spec rather than merged: a push replaces
+// the store's copy of each spec, and a pull replaces the local copy. Either
+// refuses when the other side has newer state, unless forced. History is
+// the exception; entries are only ever added, so both sides keep the union.
+package statesync
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/retry"
+)
+
+// Store kinds for the sync.store config key.
+const (
+	StoreGit = "git"
+	StoreDir = "dir"
+)
+
+// StoreNames lists the valid store kinds.
+var StoreNames = []string{StoreGit, StoreDir}
+
+// DefaultBranch is the orphan branch the git store keeps state on.
+const DefaultBranch = "autospec-state"
+
+// specsPrefix is the store directory holding one bundle per spec.
+const specsPrefix = "specs/"
+
+// Store holds bundle files, keyed by slash-separated path.
+type Store interface {
+	// Read returns every file in the store.
+	Read() (map[string][]byte, error)
+	// Write adds or replaces files, leaving the others in place.
+	Write(files map[string][]byte, message string) error
+	// String describes the store for messages.
+	String() string
+}
+
+// Bundle is the stored state of one spec.
+type Bundle struct {
+	Spec       string                 `json:"spec"`
+	PushedAt   time.Time              `json:"pushed_at"`
+	PushedFrom string                 `json:"pushed_from,omitempty"`
+	State      *retry.SpecState       `json:"state,omitempty"`
+	History    []history.HistoryEntry `json:"history,omitempty"`
+}
+
+// Options configures a push or pull.
+type Options struct {
+	// Specs limits the sync to these specs. Empty pushes nothing and pulls
+	// every spec in the store.
+	Specs []string
+	// Force overwrites the other side even when its state is newer.
+	Force bool
+	// Now is the push time (default time.Now).
+	Now time.Time
+}
+
+// Result reports what a push or pull did.
+type Result struct {
+	// Synced lists the specs whose state was pushed or pulled.
+	Synced []string
+	// Skipped lists the specs with nothing to sync.
+	Skipped []string
+}
+
+// ConflictError is returned when the destination has newer state for some
+// specs. Nothing is written.
+type ConflictError struct {
+	// Specs lists the specs with newer state at the destination.
+	Specs []string
+	// Push is set for a push, where the store is newer.
+	Push bool
+}
+
+func (e *ConflictError) Error() string {
+	where, hint := "local", "push it from this machine first, or"
+	if e.Push {
+		where, hint = "stored", "pull first, or"
+	}
+	return fmt.Sprintf("%s state is newer for %s; %s use --force to overwrite it",
+		where, strings.Join(e.Specs, ", "), hint)
+}
+
+// Push uploads the local state of opts.Specs from stateDir to the store.
+func Push(stateDir string, store Store, opts Options) (Result, error) {
+	var result Result
+	stored, err := readBundles(store)
+	if err != nil {
+		return result, err
+	}
+	localHistory, err := history.LoadHistory(stateDir)
+	if err != nil {
+		return result, err
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	host, _ := os.Hostname()
+
+	files := make(map[string][]byte)
+	var conflicts []string
+	for _, name := range opts.Specs {
+		state, err := retry.ExportSpecState(stateDir, name)
+		if err != nil {
+			return result, fmt.Errorf("reading state of %s: %w", name, err)
+		}
+		entries := history.EntriesForSpec(localHistory.Entries, name)
+		if state.IsEmpty() && len(entries) == 0 {
+			result.Skipped = append(result.Skipped, name)
+			continue
+		}
+
+		bundle := &Bundle{Spec: name, PushedAt: opts.Now.UTC(), PushedFrom: host, State: state, History: entries}
+		if prev, ok := stored[name]; ok {
+			if !opts.Force && prev.State.LastUpdated().After(state.LastUpdated()) {
+				conflicts = append(conflicts, name)
+				continue
+			}
+			bundle.History, _ = history.UnionEntries(prev.History, entries)
+		}
+		data, err := json.MarshalIndent(bundle, "", "  ")
+		if err != nil {
+			return result, fmt.Errorf("encoding state of %s: %w", name, err)
+		}
+		files[bundlePath(name)] = append(data, '\n')
+		result.Synced = append(result.Synced, name)
+	}
+	if len(conflicts) > 0 {
+		return Result{}, &ConflictError{Specs: conflicts, Push: true}
+	}
+	if len(files) == 0 {
+		return result, nil
+	}
+
+	message := fmt.Sprintf("Push state of %s", strings.Join(result.Synced, ", "))
+	if host != "" {
+		message += " from " + host
+	}
+	if err := store.Write(files, message); err != nil {
+		return Result{}, err
+	}
+	return result, nil
+}
+
+// Pull replaces the local state of opts.Specs in stateDir with the store's
+// copy and adds the stored history entries to the local history.
+func Pull(stateDir string, store Store, opts Options) (Result, error) {
+	var result Result
+	stored, err := readBundles(store)
+	if err != nil {
+		return result, err
+	}
+
+	names := opts.Specs
+	if len(names) == 0 {
+		for name := range stored {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	var pulls []*Bundle
+	var conflicts []string
+	for _, name := range names {
+		bundle, ok := stored[name]
+		if !ok {
+			result.Skipped = append(result.Skipped, name)
+			continue
+		}
+		local, err := retry.ExportSpecState(stateDir, name)
+		if err != nil {
+			return result, fmt.Errorf("reading state of %s: %w", name, err)
+		}
+		if !opts.Force && local.LastUpdated().After(bundle.State.LastUpdated()) {
+			conflicts = append(conflicts, name)
+			continue
+		}
+		pulls = append(pulls, bundle)
+	}
+	if len(conflicts) > 0 {
+		return Result{}, &ConflictError{Specs: conflicts}
+	}
+
+	for _, bundle := range pulls {
+		if err := retry.ReplaceSpecState(stateDir, bundle.Spec, bundle.State); err != nil {
+			return result, fmt.Errorf("writing state of %s: %w", bundle.Spec, err)
+		}
+		if _, err := history.MergeEntries(stateDir, bundle.History); err != nil {
+			return result, fmt.Errorf("merging history of %s: %w", bundle.Spec, err)
+		}
+		result.Synced = append(result.Synced, bundle.Spec)
+	}
+	return result, nil
+}
+
+// readBundles returns the store's bundles keyed by spec name.
+func readBundles(store Store) (map[string]*Bundle, error) {
+	files, err := store.Read()
+	if err != nil {
+		return nil, err
+	}
+	bundles := make(map[string]*Bundle)
+	for name, data := range files {
+		if !strings.HasPrefix(name, specsPrefix) || path.Ext(name) != ".json" {
+			continue
+		}
+		var bundle Bundle
+		if err := json.Unmarshal(data, &bundle); err != nil {
+			return nil, fmt.Errorf("reading %s from %s: %w", name, store, err)
+		}
+		if bundle.Spec != "" {
+			bundles[bundle.Spec] = &bundle
+		}
+	}
+	return bundles, nil
+}
+
+func bundlePath(spec string) string {
+	return specsPrefix + spec + ".json"
+}
@@ -0,0 +1,165 @@
+// Package statesync tests pushing and pulling spec state through the git
+// branch and directory stores.
+// Related: internal/statesync/statesync.go, internal/statesync/git.go, internal/statesync/dir.go
+// Tags: sync, state, retry, history, git
+
+package statesync
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runGit runs git in dir and returns its trimmed output.
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %v: %s", args, out)
+	return strings.TrimSpace(string(out))
+}
+
+// newClone creates a repository with one commit whose origin is remote.
+func newClone(t *testing.T, remote string) string {
+	t.Helper()
+	dir := t.TempDir()
+	runGit(t, dir, "init", "--quiet")
+	runGit(t, dir, "config", "user.email", "test@test.com")
+	runGit(t, dir, "config", "user.name", "Test User")
+	runGit(t, dir, "commit", "--quiet", "--allow-empty", "-m", "initial commit")
+	runGit(t, dir, "remote", "add", "origin", remote)
+	return dir
+}
+
+// startRun records an implement run of spec in stateDir at the given time.
+func startRun(t *testing.T, stateDir, spec, task string, at time.Time) {
+	t.Helper()
+	require.NoError(t, retry.SaveTaskState(stateDir, &retry.TaskExecutionState{
+		SpecName: spec, CurrentTaskID: task, TotalTasks: 5, LastTaskAttempt: at,
+	}))
+	_, err := history.MergeEntries(stateDir, []history.HistoryEntry{
+		{ID: spec + "_" + task, Timestamp: at, Command: "implement", Spec: spec, Status: history.StatusRunning},
+	})
+	require.NoError(t, err)
+}
+
+func TestPushPull_GitBranch(t *testing.T) {
+	t.Parallel()
+
+	remote := t.TempDir()
+	runGit(t, remote, "init", "--quiet", "--bare")
+	laptop, desktop := newClone(t, remote), newClone(t, remote)
+	laptopState, desktopState := t.TempDir(), t.TempDir()
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	startRun(t, laptopState, "001-login", "T003", at)
+	result, err := Push(laptopState, NewGitBranchStore(laptop, "", "origin"), Options{Specs: []string{"001-login", "002-search"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"001-login"}, result.Synced)
+	assert.Equal(t, []string{"002-search"}, result.Skipped)
+
+	assert.Equal(t, "specs/001-login.json", runGit(t, remote, "ls-tree", "-r", "--name-only", DefaultBranch))
+	assert.Empty(t, runGit(t, laptop, "status", "--porcelain"), "state branch should not touch the working tree")
+	mergeBase := exec.Command("git", "merge-base", "HEAD", DefaultBranch)
+	mergeBase.Dir = laptop
+	assert.Error(t, mergeBase.Run(), "state branch should be an orphan")
+
+	store := NewGitBranchStore(desktop, "", "origin")
+	result, err = Pull(desktopState, store, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"001-login"}, result.Synced)
+	task, err := retry.LoadTaskState(desktopState, "001-login")
+	require.NoError(t, err)
+	assert.Equal(t, "T003", task.CurrentTaskID)
+	desktopHistory, err := history.LoadHistory(desktopState)
+	require.NoError(t, err)
+	assert.Len(t, desktopHistory.Entries, 1)
+
+	t.Run("stale push is refused", func(t *testing.T) {
+		startRun(t, desktopState, "001-login", "T004", at.Add(time.Hour))
+		_, err := Push(desktopState, store, Options{Specs: []string{"001-login"}})
+		require.NoError(t, err)
+
+		_, err = Push(laptopState, NewGitBranchStore(laptop, "", "origin"), Options{Specs: []string{"001-login"}})
+		var conflict *ConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, []string{"001-login"}, conflict.Specs)
+		assert.ErrorContains(t, err, "pull first")
+
+		_, err = Pull(laptopState, NewGitBranchStore(laptop, "", "origin"), Options{})
+		require.NoError(t, err)
+		task, err := retry.LoadTaskState(laptopState, "001-login")
+		require.NoError(t, err)
+		assert.Equal(t, "T004", task.CurrentTaskID)
+		laptopHistory, err := history.LoadHistory(laptopState)
+		require.NoError(t, err)
+		assert.Len(t, laptopHistory.Entries, 2)
+	})
+
+	t.Run("pull over newer local state needs force", func(t *testing.T) {
+		startRun(t, desktopState, "001-login", "T005", at.Add(2*time.Hour))
+		_, err := Pull(desktopState, store, Options{})
+		assert.ErrorContains(t, err, "local state is newer for 001-login")
+
+		_, err = Pull(desktopState, store, Options{Force: true})
+		require.NoError(t, err)
+		task, err := retry.LoadTaskState(desktopState, "001-login")
+		require.NoError(t, err)
+		assert.Equal(t, "T004", task.CurrentTaskID)
+	})
+}
+
+func TestGitBranchStore_LocalOnly(t *testing.T) {
+	t.Parallel()
+
+	repo := newClone(t, filepath.Join(t.TempDir(), "missing"))
+	runGit(t, repo, "remote", "remove", "origin")
+	store := NewGitBranchStore(repo, "state", "origin")
+	assert.Equal(t, "state", store.String())
+
+	files, err := store.Read()
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	require.NoError(t, store.Write(map[string][]byte{"specs/a.json": []byte("{}")}, "first"))
+	require.NoError(t, store.Write(map[string][]byte{"specs/b.json": []byte("{}")}, "second"))
+	require.NoError(t, store.Write(map[string][]byte{"specs/b.json": []byte("{}")}, "unchanged"))
+
+	files, err = store.Read()
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+	assert.Equal(t, "2", runGit(t, repo, "rev-list", "--count", "state"), "unchanged write should not commit")
+}
+
+func TestPushPull_Dir(t *testing.T) {
+	t.Parallel()
+
+	store := NewDirStore(filepath.Join(t.TempDir(), "shared"))
+	from, to := t.TempDir(), t.TempDir()
+	startRun(t, from, "001-login", "T001", time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	result, err := Pull(to, store, Options{})
+	require.NoError(t, err)
+	assert.Empty(t, result.Synced, "empty store has nothing to pull")
+
+	_, err = Push(from, store, Options{Specs: []string{"001-login"}})
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(store.Dir, "specs", "001-login.json"))
+
+	result, err = Pull(to, store, Options{Specs: []string{"001-login", "002-search"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"001-login"}, result.Synced)
+	assert.Equal(t, []string{"002-search"}, result.Skipped)
+	task, err := retry.LoadTaskState(to, "001-login")
+	require.NoError(t, err)
+	assert.Equal(t, "T001", task.CurrentTaskID)
+}
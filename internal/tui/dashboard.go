@@ -0,0 +1,298 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// dashboardRefresh is how often the dashboard reloads specs and history.
+const dashboardRefresh = 3 * time.Second
+
+// dashboardOutputLines is the agent output scrollback kept by the dashboard.
+const dashboardOutputLines = 1000
+
+// SpecView is one spec as shown by the dashboard.
+type SpecView struct {
+	Name      string
+	Status    string // Status from spec.yaml
+	Artifacts []string
+	Tasks     *validation.TaskStats // nil when tasks.yaml is missing
+	Blocked   []BlockedTask
+	Next      []string // autospec arguments for the next phase; nil if none can run
+}
+
+// BlockedTask is a blocked task listed by the dashboard.
+type BlockedTask struct {
+	ID     string
+	Title  string
+	Reason string
+}
+
+// DashboardData is what the dashboard displays, reloaded periodically.
+type DashboardData struct {
+	Specs   []SpecView
+	History []history.HistoryEntry // Newest first
+}
+
+// DashboardPanel selects what the right-hand panel shows.
+type DashboardPanel int
+
+const (
+	// PanelOverview shows the selected spec's progress and agent output.
+	PanelOverview DashboardPanel = iota
+	// PanelBlocked lists the selected spec's blocked tasks.
+	PanelBlocked
+	// PanelHistory tails the command history.
+	PanelHistory
+)
+
+// dashboardBindings maps bubbletea key names to dashboard actions.
+var dashboardBindings = map[string]string{
+	"up":     "up",
+	"k":      "up",
+	"down":   "down",
+	"j":      "down",
+	"enter":  "run",
+	"n":      "run",
+	"x":      "cancel",
+	"b":      "blocked",
+	"h":      "history",
+	"esc":    "overview",
+	"r":      "reload",
+	"q":      "quit",
+	"ctrl+c": "quit",
+}
+
+// Dashboard is the state of `autospec ui`: the spec list, the selected
+// spec's panel and the output of the phase it started, if any.
+type Dashboard struct {
+	Data   DashboardData
+	Panel  DashboardPanel
+	Output []string // Output of the most recent run
+	Status string   // One-line notice, e.g. a load error or run result
+
+	load       func() (DashboardData, error)
+	executable string // autospec binary used to run phases
+	selected   int
+	running    *exec.Cmd
+	runEvents  chan tea.Msg // Output lines, then runDoneMsg
+	runLabel   string
+	width      int
+	height     int
+}
+
+// Messages handled by Dashboard.Update.
+type (
+	dashboardTickMsg struct{}
+	dashboardDataMsg struct {
+		data DashboardData
+		err  error
+	}
+	runOutputMsg string
+	runDoneMsg   struct{ err error }
+)
+
+// NewDashboard creates a dashboard that loads its data with load and runs
+// phases with the autospec binary at executable.
+func NewDashboard(load func() (DashboardData, error), executable string) *Dashboard {
+	return &Dashboard{load: load, executable: executable, width: 80, height: 24}
+}
+
+// RunDashboard shows the dashboard until the user quits.
+func RunDashboard(d *Dashboard) error {
+	if !Supported() {
+		return fmt.Errorf("the dashboard requires an interactive terminal")
+	}
+	_, err := tea.NewProgram(d, tea.WithAltScreen()).Run()
+	d.cancelRun()
+	return err
+}
+
+// Init implements tea.Model, loading data and starting the refresh timer.
+func (d *Dashboard) Init() tea.Cmd {
+	return tea.Batch(d.reload, tickDashboard())
+}
+
+// Update implements tea.Model.
+func (d *Dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case dashboardTickMsg:
+		return d, tea.Batch(d.reload, tickDashboard())
+	case dashboardDataMsg:
+		if msg.err != nil {
+			d.Status = fmt.Sprintf("✗ loading specs: %v", msg.err)
+			break
+		}
+		d.SetData(msg.data)
+	case runOutputMsg:
+		d.appendOutput(string(msg))
+		return d, d.waitForRun
+	case runDoneMsg:
+		d.running = nil
+		d.Status = fmt.Sprintf("✓ %s finished", d.runLabel)
+		if msg.err != nil {
+			d.Status = fmt.Sprintf("✗ %s: %v", d.runLabel, msg.err)
+		}
+		return d, d.reload
+	case tea.WindowSizeMsg:
+		d.width, d.height = max(msg.Width, 20), max(msg.Height, 5)
+	case tea.KeyMsg:
+		return d, d.handleKey(dashboardBindings[msg.String()])
+	}
+	return d, nil
+}
+
+// handleKey applies a key action.
+func (d *Dashboard) handleKey(action string) tea.Cmd {
+	switch action {
+	case "up":
+		d.selected = max(d.selected-1, 0)
+	case "down":
+		d.selected = min(d.selected+1, max(len(d.Data.Specs)-1, 0))
+	case "run":
+		return d.startNext()
+	case "cancel":
+		d.cancelRun()
+	case "blocked":
+		d.togglePanel(PanelBlocked)
+	case "history":
+		d.togglePanel(PanelHistory)
+	case "overview":
+		d.Panel = PanelOverview
+	case "reload":
+		return d.reload
+	case "quit":
+		return tea.Quit
+	}
+	return nil
+}
+
+// togglePanel shows panel, or returns to the overview if it is already shown.
+func (d *Dashboard) togglePanel(panel DashboardPanel) {
+	if d.Panel == panel {
+		d.Panel = PanelOverview
+		return
+	}
+	d.Panel = panel
+}
+
+// SetData replaces the displayed data, keeping the selection on the same spec.
+func (d *Dashboard) SetData(data DashboardData) {
+	name := ""
+	if spec := d.Selected(); spec != nil {
+		name = spec.Name
+	}
+	d.Data = data
+	d.selected = min(d.selected, max(len(data.Specs)-1, 0))
+	for i, spec := range data.Specs {
+		if spec.Name == name {
+			d.selected = i
+		}
+	}
+}
+
+// Selected returns the selected spec, or nil when there are no specs.
+func (d *Dashboard) Selected() *SpecView {
+	if d.selected >= len(d.Data.Specs) {
+		return nil
+	}
+	return &d.Data.Specs[d.selected]
+}
+
+// reload loads fresh data in the background.
+func (d *Dashboard) reload() tea.Msg {
+	data, err := d.load()
+	return dashboardDataMsg{data: data, err: err}
+}
+
+// tickDashboard schedules the next periodic reload.
+func tickDashboard() tea.Cmd {
+	return tea.Tick(dashboardRefresh, func(time.Time) tea.Msg { return dashboardTickMsg{} })
+}
+
+// startNext runs the selected spec's next phase, streaming its output into
+// the overview panel. Only one phase runs at a time.
+func (d *Dashboard) startNext() tea.Cmd {
+	spec := d.Selected()
+	switch {
+	case d.running != nil:
+		d.Status = fmt.Sprintf("%s is still running (x to cancel)", d.runLabel)
+		return nil
+	case spec == nil || spec.Next == nil:
+		d.Status = "Nothing to run for this spec"
+		return nil
+	}
+
+	cmd := exec.Command(d.executable, spec.Next...)
+	// Confirmation prompts can't be answered from the dashboard
+	cmd.Env = append(os.Environ(), "AUTOSPEC_YES=1")
+	pipeR, pipeW := io.Pipe()
+	cmd.Stdout, cmd.Stderr = pipeW, pipeW
+	d.runLabel = "autospec " + strings.Join(spec.Next, " ")
+	d.Output, d.Panel = nil, PanelOverview
+	if err := cmd.Start(); err != nil {
+		d.Status = fmt.Sprintf("✗ starting %s: %v", d.runLabel, err)
+		return nil
+	}
+	d.running = cmd
+	d.Status = "● " + d.runLabel
+
+	events := make(chan tea.Msg)
+	d.runEvents = events
+	go func() {
+		waitErr := make(chan error, 1)
+		go func() {
+			waitErr <- cmd.Wait()
+			_ = pipeW.Close()
+		}()
+		streamLines(pipeR, events)
+		events <- runDoneMsg{err: <-waitErr}
+	}()
+	return d.waitForRun
+}
+
+// streamLines forwards each output line to events until r is closed.
+func streamLines(r io.Reader, events chan<- tea.Msg) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		events <- runOutputMsg(scanner.Text())
+	}
+	// Keep the process from blocking on a line too long to scan
+	_, _ = io.Copy(io.Discard, r)
+}
+
+// waitForRun waits for the next event from the running phase.
+func (d *Dashboard) waitForRun() tea.Msg {
+	return <-d.runEvents
+}
+
+// cancelRun interrupts the running phase, if any.
+func (d *Dashboard) cancelRun() {
+	if d.running != nil && d.running.Process != nil {
+		_ = d.running.Process.Signal(syscall.SIGINT)
+		d.Status = fmt.Sprintf("Cancelling %s…", d.runLabel)
+	}
+}
+
+// appendOutput adds a line of run output, enforcing the scrollback limit.
+func (d *Dashboard) appendOutput(line string) {
+	line = ansiPattern.ReplaceAllString(line, "")
+	if idx := strings.LastIndex(line, "\r"); idx >= 0 {
+		line = line[idx+1:]
+	}
+	d.Output = append(d.Output, strings.ReplaceAll(line, "\t", "    "))
+	if excess := len(d.Output) - dashboardOutputLines; excess > 0 {
+		d.Output = d.Output[excess:]
+	}
+}
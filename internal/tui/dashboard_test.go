@@ -0,0 +1,183 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDashboardData returns two specs, the second with a blocked task.
+func testDashboardData() DashboardData {
+	return DashboardData{
+		Specs: []SpecView{
+			{
+				Name:      "001-auth",
+				Status:    "Completed",
+				Artifacts: []string{"spec.yaml", "plan.yaml", "tasks.yaml"},
+				Tasks:     &validation.TaskStats{TotalTasks: 2, CompletedTasks: 2},
+			},
+			{
+				Name:      "002-cache",
+				Status:    "In Progress",
+				Artifacts: []string{"spec.yaml", "plan.yaml", "tasks.yaml"},
+				Tasks: &validation.TaskStats{TotalTasks: 4, CompletedTasks: 1, BlockedTasks: 1, PhaseStats: []validation.PhaseStats{
+					{Number: 1, Title: "Setup", TotalTasks: 4, CompletedTasks: 1},
+				}},
+				Blocked: []BlockedTask{{ID: "T3", Title: "Wire Redis", Reason: "Waiting for credentials"}},
+				Next:    []string{"implement", "002-cache"},
+			},
+		},
+		History: []history.HistoryEntry{
+			{Timestamp: time.Now(), Command: "implement", Spec: "002-cache", Status: history.StatusFailed},
+		},
+	}
+}
+
+func TestDashboard_Keys(t *testing.T) {
+	t.Parallel()
+
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	tests := map[string]struct {
+		keys         []tea.KeyMsg
+		wantSelected string
+		wantPanel    DashboardPanel
+	}{
+		"j selects next spec": {
+			keys:         []tea.KeyMsg{runes("j")},
+			wantSelected: "002-cache",
+		},
+		"selection stops at the last spec": {
+			keys:         []tea.KeyMsg{{Type: tea.KeyDown}, {Type: tea.KeyDown}, {Type: tea.KeyDown}},
+			wantSelected: "002-cache",
+		},
+		"up stops at the first spec": {
+			keys:         []tea.KeyMsg{{Type: tea.KeyDown}, runes("k"), {Type: tea.KeyUp}},
+			wantSelected: "001-auth",
+		},
+		"b shows blocked tasks": {
+			keys:         []tea.KeyMsg{runes("b")},
+			wantSelected: "001-auth",
+			wantPanel:    PanelBlocked,
+		},
+		"h toggles history": {
+			keys:         []tea.KeyMsg{runes("h"), runes("h")},
+			wantSelected: "001-auth",
+			wantPanel:    PanelOverview,
+		},
+		"esc returns to the overview": {
+			keys:         []tea.KeyMsg{runes("h"), {Type: tea.KeyEsc}},
+			wantSelected: "001-auth",
+			wantPanel:    PanelOverview,
+		},
+		"unknown key is ignored": {
+			keys:         []tea.KeyMsg{runes("z")},
+			wantSelected: "001-auth",
+			wantPanel:    PanelOverview,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			d := NewDashboard(nil, "")
+			d.SetData(testDashboardData())
+			for _, key := range tt.keys {
+				d.Update(key)
+			}
+			assert.Equal(t, tt.wantSelected, d.Selected().Name)
+			assert.Equal(t, tt.wantPanel, d.Panel)
+		})
+	}
+}
+
+func TestDashboard_SetDataKeepsSelection(t *testing.T) {
+	t.Parallel()
+
+	d := NewDashboard(nil, "")
+	d.SetData(testDashboardData())
+	d.handleKey("down")
+
+	data := testDashboardData()
+	data.Specs = append([]SpecView{{Name: "000-first"}}, data.Specs...)
+	d.SetData(data)
+	assert.Equal(t, "002-cache", d.Selected().Name)
+
+	d.SetData(DashboardData{Specs: data.Specs[:1]})
+	assert.Equal(t, "000-first", d.Selected().Name)
+
+	d.SetData(DashboardData{})
+	assert.Nil(t, d.Selected())
+}
+
+func TestDashboard_LoadError(t *testing.T) {
+	t.Parallel()
+
+	d := NewDashboard(func() (DashboardData, error) { return DashboardData{}, errors.New("boom") }, "")
+	d.Update(d.reload())
+	assert.Equal(t, "✗ loading specs: boom", d.Status)
+}
+
+func TestDashboard_View(t *testing.T) {
+	t.Parallel()
+
+	d := NewDashboard(nil, "")
+	d.Update(tea.WindowSizeMsg{Width: 120, Height: 20})
+	d.SetData(testDashboardData())
+	d.handleKey("down")
+
+	view := d.View()
+	assert.Contains(t, view, "autospec ui  2 specs")
+	assert.Contains(t, view, "> 002-cache")
+	assert.Contains(t, view, "tasks: 1/4 completed, 0 in progress, 1 blocked")
+	assert.Contains(t, view, "Phase 1: Setup")
+	assert.Contains(t, view, "next: autospec implement 002-cache")
+
+	d.handleKey("blocked")
+	assert.Contains(t, d.View(), "Reason: Waiting for credentials")
+
+	d.handleKey("history")
+	assert.Contains(t, d.View(), "implement  failed")
+}
+
+func TestDashboard_RunNext(t *testing.T) {
+	t.Parallel()
+
+	d := NewDashboard(func() (DashboardData, error) { return testDashboardData(), nil }, "/bin/sh")
+	d.SetData(DashboardData{Specs: []SpecView{{Name: "001-auth", Next: []string{"-c", "echo one; echo two; exit 3"}}}})
+
+	cmd := d.handleKey("run")
+	require.NotNil(t, cmd)
+	assert.Equal(t, "● autospec -c echo one; echo two; exit 3", d.Status)
+
+	// A second run is refused while the first is active
+	assert.Nil(t, d.handleKey("run"))
+
+	for msg := cmd(); ; msg = cmd() {
+		_, cmd = d.Update(msg)
+		if _, done := msg.(runDoneMsg); done {
+			break
+		}
+	}
+	assert.Equal(t, []string{"one", "two"}, d.Output)
+	assert.Contains(t, d.Status, "✗ autospec -c echo one; echo two; exit 3: exit status 3")
+	assert.Nil(t, d.running)
+
+	// The run's completion reloads the data
+	d.Update(cmd())
+	assert.Len(t, d.Data.Specs, 2)
+}
+
+func TestDashboard_RunNothing(t *testing.T) {
+	t.Parallel()
+
+	d := NewDashboard(nil, "/bin/sh")
+	d.SetData(testDashboardData())
+	assert.Nil(t, d.handleKey("run"))
+	assert.Equal(t, "Nothing to run for this spec", d.Status)
+}
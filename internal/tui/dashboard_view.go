@@ -0,0 +1,176 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// dashboardHelp is the key reference shown in the dashboard footer.
+const dashboardHelp = "↑/↓ select  enter run next phase  x cancel  b blocked  h history  r reload  q quit"
+
+// dashboardListWidth is the widest the spec list column gets.
+const dashboardListWidth = 32
+
+// View implements tea.Model: a title, the spec list beside the selected
+// panel, and a footer with key help.
+func (d *Dashboard) View() string {
+	bodyHeight := max(d.height-2, 1)
+	listWidth := min(dashboardListWidth, d.width/3)
+	panelWidth := max(d.width-listWidth-3, 1)
+
+	// Scroll the list so the selected spec stays visible
+	list := d.specList(listWidth)
+	listStart := max(d.selected-bodyHeight+1, 0)
+	list = list[min(listStart, len(list)):]
+	panel := d.panel(panelWidth, bodyHeight)
+
+	lines := make([]string, 0, d.height)
+	lines = append(lines, titleStyle.Render(truncate(d.title(), d.width)))
+	for i := range bodyHeight {
+		left, right := "", ""
+		if i < len(list) {
+			left = list[i]
+		}
+		if i < len(panel) {
+			right = truncate(panel[i], panelWidth)
+		}
+		cell := pad(truncate(left, listWidth), listWidth)
+		if listStart+i == d.selected && i < len(list) && len(d.Data.Specs) > 0 {
+			cell = selectedStyle.Render(cell)
+		}
+		lines = append(lines, cell+" │ "+right)
+	}
+	lines = append(lines, footerStyle.Render(truncate(dashboardHelp, d.width)))
+	return strings.Join(lines, "\n")
+}
+
+// title shows the dashboard name and the current notice.
+func (d *Dashboard) title() string {
+	if d.Status == "" {
+		return fmt.Sprintf("autospec ui  %d specs", len(d.Data.Specs))
+	}
+	return fmt.Sprintf("autospec ui  %d specs  %s", len(d.Data.Specs), d.Status)
+}
+
+// specList renders one row per spec with its task completion.
+func (d *Dashboard) specList(width int) []string {
+	if len(d.Data.Specs) == 0 {
+		return []string{"no specs"}
+	}
+	rows := make([]string, len(d.Data.Specs))
+	for i, spec := range d.Data.Specs {
+		cursor := "  "
+		if i == d.selected {
+			cursor = "> "
+		}
+		progress := "  -"
+		if spec.Tasks != nil {
+			progress = fmt.Sprintf("%3.0f%%", spec.Tasks.CompletionPercentage())
+		}
+		name := truncate(spec.Name, max(width-len(cursor)-6, 1))
+		rows[i] = cursor + pad(name, max(width-len(cursor)-5, 1)) + " " + progress
+	}
+	return rows
+}
+
+// panel renders the right-hand panel for the selected spec.
+func (d *Dashboard) panel(width, height int) []string {
+	if d.Panel == PanelHistory {
+		return d.historyPanel(height)
+	}
+	spec := d.Selected()
+	if spec == nil {
+		return []string{"Create a spec with: autospec specify \"<feature description>\""}
+	}
+	if d.Panel == PanelBlocked {
+		return blockedPanel(spec)
+	}
+
+	lines := []string{fmt.Sprintf("%s (%s)", spec.Name, spec.Status), "artifacts: " + artifactMarks(spec.Artifacts)}
+	if spec.Tasks != nil {
+		lines = append(lines, fmt.Sprintf("tasks: %d/%d completed, %d in progress, %d blocked",
+			spec.Tasks.CompletedTasks, spec.Tasks.TotalTasks, spec.Tasks.InProgressTasks, spec.Tasks.BlockedTasks))
+		for _, phase := range spec.Tasks.PhaseStats {
+			lines = append(lines, fmt.Sprintf("  %s %d/%d  Phase %d: %s",
+				progressBar(phase.CompletedTasks, phase.TotalTasks, 10), phase.CompletedTasks, phase.TotalTasks, phase.Number, phase.Title))
+		}
+	}
+	next := "nothing to run"
+	if spec.Next != nil {
+		next = "autospec " + strings.Join(spec.Next, " ") + "  (enter)"
+	}
+	lines = append(lines, "next: "+next)
+
+	if d.runLabel == "" {
+		return lines
+	}
+	lines = append(lines, "", truncate("── "+d.runLabel+" "+strings.Repeat("─", width), width))
+	room := max(height-len(lines), 0)
+	return append(lines, d.Output[max(len(d.Output)-room, 0):]...)
+}
+
+// blockedPanel lists a spec's blocked tasks with their reasons.
+func blockedPanel(spec *SpecView) []string {
+	if len(spec.Blocked) == 0 {
+		return []string{fmt.Sprintf("%s has no blocked tasks", spec.Name)}
+	}
+	lines := []string{fmt.Sprintf("Blocked tasks in %s (%d)", spec.Name, len(spec.Blocked))}
+	for _, task := range spec.Blocked {
+		reason := task.Reason
+		if reason == "" {
+			reason = "(no reason provided)"
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s", task.ID, task.Title), "     Reason: "+reason)
+	}
+	return lines
+}
+
+// historyPanel tails the command history, newest first.
+func (d *Dashboard) historyPanel(height int) []string {
+	if len(d.Data.History) == 0 {
+		return []string{"No history yet"}
+	}
+	lines := []string{"Recent history"}
+	for _, entry := range d.Data.History[:min(len(d.Data.History), max(height-1, 0))] {
+		status := entry.Status
+		if status == "" {
+			status = "-"
+		}
+		lines = append(lines, fmt.Sprintf("  %s  %-10s %-10s %-24s %s",
+			entry.Timestamp.Local().Format("2006-01-02 15:04"), entry.Command, status, entry.Spec, entry.Duration))
+	}
+	return lines
+}
+
+// artifactMarks renders artifact presence, e.g. "✓ spec  ✓ plan  ✗ tasks".
+func artifactMarks(present []string) string {
+	marks := make([]string, 0, 3)
+	for _, name := range []string{"spec", "plan", "tasks"} {
+		mark := "✗"
+		for _, artifact := range present {
+			if strings.TrimSuffix(artifact, ".yaml") == name {
+				mark = "✓"
+			}
+		}
+		marks = append(marks, mark+" "+name)
+	}
+	return strings.Join(marks, "  ")
+}
+
+// progressBar renders done/total as a bar of the given width.
+func progressBar(done, total, width int) string {
+	filled := width
+	if total > 0 {
+		filled = done * width / total
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// pad right-pads s with spaces to width runes.
+func pad(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
@@ -0,0 +1,108 @@
+package tui
+
+// Key identifies a key press understood by the live view.
+type Key string
+
+// Keys handled by Model.HandleKey.
+const (
+	KeyUp        Key = "up"
+	KeyDown      Key = "down"
+	KeyPageUp    Key = "pgup"
+	KeyPageDown  Key = "pgdown"
+	KeyHome      Key = "home"
+	KeyEnd       Key = "end"
+	KeyTab       Key = "tab"
+	KeyBackTab   Key = "backtab"
+	KeyToggle    Key = "toggle"
+	KeyAll       Key = "all"
+	KeyInterrupt Key = "ctrl+c"
+)
+
+// keyBindings maps bubbletea key names to keys (vim-style aliases included).
+var keyBindings = map[string]Key{
+	"up":        KeyUp,
+	"k":         KeyUp,
+	"down":      KeyDown,
+	"j":         KeyDown,
+	"pgup":      KeyPageUp,
+	"pgdown":    KeyPageDown,
+	"home":      KeyHome,
+	"g":         KeyHome,
+	"end":       KeyEnd,
+	"G":         KeyEnd,
+	"tab":       KeyTab,
+	"shift+tab": KeyBackTab,
+	" ":         KeyToggle,
+	"enter":     KeyToggle,
+	"a":         KeyAll,
+	"ctrl+c":    KeyInterrupt,
+}
+
+// HandleKey applies a key press to the model.
+func (m *Model) HandleKey(key Key) {
+	total := len(m.rows())
+	switch key {
+	case KeyUp:
+		m.scrollBy(-1, total)
+	case KeyDown:
+		m.scrollBy(1, total)
+	case KeyPageUp:
+		m.scrollBy(-m.bodyHeight(), total)
+	case KeyPageDown:
+		m.scrollBy(m.bodyHeight(), total)
+	case KeyHome:
+		m.follow, m.offset = false, 0
+	case KeyEnd:
+		m.follow = true
+	case KeyTab:
+		m.selectPhase(1)
+	case KeyBackTab:
+		m.selectPhase(-1)
+	case KeyToggle:
+		if m.selected < len(m.Phases) {
+			m.Phases[m.selected].Collapsed = !m.Phases[m.selected].Collapsed
+		}
+	case KeyAll:
+		m.toggleAll()
+	}
+}
+
+// scrollBy moves the viewport; reaching the bottom resumes following.
+func (m *Model) scrollBy(delta, total int) {
+	maxOffset := max(total-m.bodyHeight(), 0)
+	if m.follow {
+		m.offset = maxOffset
+	}
+	m.offset = min(max(m.offset+delta, 0), maxOffset)
+	m.follow = m.offset == maxOffset
+}
+
+// selectPhase moves the selection and scrolls its header into view.
+func (m *Model) selectPhase(delta int) {
+	if len(m.Phases) == 0 {
+		return
+	}
+	m.selected = (m.selected + delta + len(m.Phases)) % len(m.Phases)
+	for i, r := range m.rows() {
+		if r.kind == rowHeader && r.phase == m.selected {
+			if i < m.offset || i >= m.offset+m.bodyHeight() {
+				m.follow, m.offset = false, i
+			}
+			return
+		}
+	}
+}
+
+// toggleAll collapses every phase, or expands all if they are already collapsed.
+func (m *Model) toggleAll() {
+	collapse := false
+	for _, p := range m.Phases {
+		if !p.Collapsed {
+			collapse = true
+			break
+		}
+	}
+	for _, p := range m.Phases {
+		p.Collapsed = collapse
+	}
+}
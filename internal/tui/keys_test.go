@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModel_UpdateKeys(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		msg  tea.KeyMsg
+		want Key
+	}{
+		"arrow up":    {msg: tea.KeyMsg{Type: tea.KeyUp}, want: KeyUp},
+		"vim down":    {msg: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")}, want: KeyDown},
+		"vim end":     {msg: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")}, want: KeyEnd},
+		"page down":   {msg: tea.KeyMsg{Type: tea.KeyPgDown}, want: KeyPageDown},
+		"shift+tab":   {msg: tea.KeyMsg{Type: tea.KeyShiftTab}, want: KeyBackTab},
+		"space":       {msg: tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}, want: KeyToggle},
+		"enter":       {msg: tea.KeyMsg{Type: tea.KeyEnter}, want: KeyToggle},
+		"unknown key": {msg: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, keyBindings[tt.msg.String()])
+		})
+	}
+}
+
+func TestModel_UpdateInterrupt(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel()
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	assert.NotNil(t, cmd, "ctrl+c is forwarded as SIGINT")
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	assert.Nil(t, cmd)
+}
+
+// scrollModel returns a 10-row model whose single phase has n output lines.
+func scrollModel(n int) *Model {
+	m := NewModel()
+	m.Resize(40, 10)
+	m.StartPhase("implement")
+	for i := range n {
+		m.Write([]byte(fmt.Sprintf("line %d\n", i)))
+	}
+	m.frame()
+	return m
+}
+
+func TestModel_HandleKeyScroll(t *testing.T) {
+	t.Parallel()
+
+	// 31 rows (header + 30 lines), 8 visible: bottom offset is 23
+	tests := map[string]struct {
+		keys       []Key
+		wantOffset int
+		wantFollow bool
+	}{
+		"follows by default":      {wantOffset: 23, wantFollow: true},
+		"up stops following":      {keys: []Key{KeyUp}, wantOffset: 22},
+		"page up":                 {keys: []Key{KeyPageUp}, wantOffset: 15},
+		"home jumps to top":       {keys: []Key{KeyHome}, wantOffset: 0},
+		"up clamps at top":        {keys: []Key{KeyHome, KeyUp}, wantOffset: 0},
+		"down to bottom follows":  {keys: []Key{KeyUp, KeyDown}, wantOffset: 23, wantFollow: true},
+		"end resumes following":   {keys: []Key{KeyHome, KeyEnd}, wantOffset: 23, wantFollow: true},
+		"page down clamps bottom": {keys: []Key{KeyHome, KeyPageDown, KeyPageDown, KeyPageDown, KeyPageDown}, wantOffset: 23, wantFollow: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			m := scrollModel(30)
+			for _, key := range tt.keys {
+				m.HandleKey(key)
+			}
+			m.frame()
+
+			assert.Equal(t, tt.wantOffset, m.offset)
+			assert.Equal(t, tt.wantFollow, m.follow)
+		})
+	}
+}
+
+func TestModel_HandleKeyToggle(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel()
+	m.StartPhase("specify")
+	m.EndPhase("specify", nil)
+	m.StartPhase("plan")
+
+	m.HandleKey(KeyToggle)
+	assert.True(t, m.Phases[1].Collapsed, "space collapses the selected phase")
+
+	m.HandleKey(KeyBackTab)
+	m.HandleKey(KeyToggle)
+	assert.False(t, m.Phases[0].Collapsed, "selection moved to the previous phase")
+
+	m.HandleKey(KeyTab)
+	assert.Equal(t, 1, m.selected, "tab wraps through phases")
+
+	m.HandleKey(KeyAll)
+	assert.True(t, m.Phases[0].Collapsed)
+	assert.True(t, m.Phases[1].Collapsed)
+
+	m.HandleKey(KeyAll)
+	assert.False(t, m.Phases[0].Collapsed, "all collapsed, so a expands everything")
+	assert.False(t, m.Phases[1].Collapsed)
+}
+
+func TestModel_SelectPhaseScrollsIntoView(t *testing.T) {
+	t.Parallel()
+
+	m := scrollModel(30)
+	m.StartPhase("checklist")
+	m.HandleKey(KeyBackTab)
+
+	assert.Equal(t, 0, m.selected)
+	assert.Equal(t, 0, m.offset)
+	assert.False(t, m.follow)
+}
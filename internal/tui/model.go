@@ -0,0 +1,195 @@
+// Package tui provides a live terminal view that streams agent output grouped
+// by workflow phase, with scrollback and per-phase collapsible transcripts.
+//
+// The live view is a bubbletea program: Model holds all state and implements
+// tea.Model, updated by phase events, output chunks, and key presses; View
+// renders it to a fixed-size frame styled with lipgloss; Program owns the
+// terminal, captures output, and feeds events to the bubbletea event loop.
+//
+// Dashboard is a second bubbletea model behind `autospec ui`: a spec list
+// with per-phase task progress that can start a spec's next phase and
+// stream its output.
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PhaseStatus is the state of a phase in the transcript.
+type PhaseStatus int
+
+const (
+	// PhaseRunning indicates the phase is in progress.
+	PhaseRunning PhaseStatus = iota
+	// PhaseDone indicates the phase completed successfully.
+	PhaseDone
+	// PhaseFailed indicates the phase failed.
+	PhaseFailed
+)
+
+// DefaultMaxLines is the per-phase scrollback limit.
+const DefaultMaxLines = 5000
+
+// generalPhase collects output written before any phase starts. It is marked
+// done up front so it collapses once the first real phase begins.
+const generalPhase = "autospec"
+
+// ansiPattern matches ANSI escape sequences, which are stripped from captured
+// output so line widths can be measured and truncated safely.
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07]*\x07`)
+
+// Phase is the transcript of one phase attempt.
+type Phase struct {
+	Name      string
+	Status    PhaseStatus
+	Lines     []string
+	Collapsed bool
+	Dropped   int // Lines discarded after exceeding the scrollback limit
+	partial   string
+}
+
+// Model is the state of the live view.
+type Model struct {
+	Phases   []*Phase
+	MaxLines int
+
+	selected int  // Index of the phase targeted by collapse toggles
+	offset   int  // First visible transcript row
+	follow   bool // Keep the viewport pinned to the newest output
+	width    int
+	height   int
+}
+
+// Messages sent by Program to the bubbletea event loop.
+type (
+	phaseStartMsg struct{ name string }
+	phaseEndMsg   struct {
+		name string
+		err  error
+	}
+	outputMsg []byte
+)
+
+// NewModel creates an empty model that follows new output.
+func NewModel() *Model {
+	return &Model{MaxLines: DefaultMaxLines, follow: true, width: 80, height: 24}
+}
+
+// Init implements tea.Model. The view is driven entirely by messages.
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model, applying phase events, captured output,
+// terminal resizes, and key presses.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case phaseStartMsg:
+		m.StartPhase(msg.name)
+	case phaseEndMsg:
+		m.EndPhase(msg.name, msg.err)
+	case outputMsg:
+		m.Write(msg)
+	case tea.WindowSizeMsg:
+		m.Resize(msg.Width, msg.Height)
+	case tea.KeyMsg:
+		key, ok := keyBindings[msg.String()]
+		if !ok {
+			break
+		}
+		if key == KeyInterrupt {
+			return m, interrupt
+		}
+		m.HandleKey(key)
+	}
+	return m, nil
+}
+
+// StartPhase begins a new phase transcript. Finished phases are collapsed so
+// the running phase gets the screen; they can be re-expanded at any time.
+func (m *Model) StartPhase(name string) {
+	m.flushPartial()
+	for _, p := range m.Phases {
+		if p.Status == PhaseDone {
+			p.Collapsed = true
+		}
+	}
+	m.Phases = append(m.Phases, &Phase{Name: name, Status: PhaseRunning})
+	m.selected = len(m.Phases) - 1
+}
+
+// EndPhase marks the most recent running phase with the given name as done or failed.
+func (m *Model) EndPhase(name string, err error) {
+	m.flushPartial()
+	for i := len(m.Phases) - 1; i >= 0; i-- {
+		p := m.Phases[i]
+		if p.Name != name || p.Status != PhaseRunning {
+			continue
+		}
+		p.Status = PhaseDone
+		if err != nil {
+			p.Status = PhaseFailed
+			p.Lines = append(p.Lines, fmt.Sprintf("✗ %v", err))
+		}
+		return
+	}
+}
+
+// Write appends captured output to the current phase.
+func (m *Model) Write(data []byte) {
+	phase := m.current()
+	text := phase.partial + ansiPattern.ReplaceAllString(string(data), "")
+	lines := strings.Split(text, "\n")
+	phase.partial = lines[len(lines)-1]
+	for _, line := range lines[:len(lines)-1] {
+		m.appendLine(phase, line)
+	}
+}
+
+// current returns the phase receiving output, creating a general one if needed.
+func (m *Model) current() *Phase {
+	if len(m.Phases) == 0 {
+		m.Phases = append(m.Phases, &Phase{Name: generalPhase, Status: PhaseDone})
+	}
+	return m.Phases[len(m.Phases)-1]
+}
+
+// appendLine adds a line, keeping only the text after the last carriage return
+// (progress bars redraw with \r) and enforcing the scrollback limit.
+func (m *Model) appendLine(p *Phase, line string) {
+	if idx := strings.LastIndex(line, "\r"); idx >= 0 {
+		line = line[idx+1:]
+	}
+	p.Lines = append(p.Lines, strings.ReplaceAll(line, "\t", "    "))
+	if m.MaxLines > 0 && len(p.Lines) > m.MaxLines {
+		excess := len(p.Lines) - m.MaxLines
+		p.Lines = p.Lines[excess:]
+		p.Dropped += excess
+	}
+}
+
+// flushPartial commits any unterminated output line of the current phase.
+func (m *Model) flushPartial() {
+	if len(m.Phases) == 0 {
+		return
+	}
+	p := m.Phases[len(m.Phases)-1]
+	if p.partial != "" {
+		m.appendLine(p, p.partial)
+		p.partial = ""
+	}
+}
+
+// Resize updates the frame dimensions.
+func (m *Model) Resize(width, height int) {
+	if width > 0 {
+		m.width = width
+	}
+	if height > 0 {
+		m.height = height
+	}
+}
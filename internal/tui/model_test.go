@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModel_Write(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		chunks      []string
+		wantLines   []string
+		wantPartial string
+	}{
+		"complete lines": {
+			chunks:    []string{"one\ntwo\n"},
+			wantLines: []string{"one", "two"},
+		},
+		"line split across chunks": {
+			chunks:      []string{"hel", "lo\nwor"},
+			wantLines:   []string{"hello"},
+			wantPartial: "wor",
+		},
+		"ansi escapes stripped": {
+			chunks:    []string{"\x1b[32m✓ passed\x1b[0m\n"},
+			wantLines: []string{"✓ passed"},
+		},
+		"carriage return keeps last redraw": {
+			chunks:    []string{"10%\r50%\r100%\n"},
+			wantLines: []string{"100%"},
+		},
+		"tabs expanded": {
+			chunks:    []string{"a\tb\n"},
+			wantLines: []string{"a    b"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			m := NewModel()
+			m.StartPhase("plan")
+			for _, chunk := range tt.chunks {
+				m.Write([]byte(chunk))
+			}
+
+			phase := m.Phases[0]
+			assert.Equal(t, tt.wantLines, phase.Lines)
+			assert.Equal(t, tt.wantPartial, phase.partial)
+		})
+	}
+}
+
+func TestModel_WriteBeforePhase(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel()
+	m.Write([]byte("preflight ok\n"))
+	m.StartPhase("specify")
+
+	require.Len(t, m.Phases, 2)
+	assert.Equal(t, generalPhase, m.Phases[0].Name)
+	assert.Equal(t, []string{"preflight ok"}, m.Phases[0].Lines)
+	assert.True(t, m.Phases[0].Collapsed)
+}
+
+func TestModel_ScrollbackLimit(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel()
+	m.MaxLines = 3
+	m.StartPhase("implement")
+	m.Write([]byte("1\n2\n3\n4\n5\n"))
+
+	phase := m.Phases[0]
+	assert.Equal(t, []string{"3", "4", "5"}, phase.Lines)
+	assert.Equal(t, 2, phase.Dropped)
+}
+
+func TestModel_PhaseLifecycle(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel()
+	m.StartPhase("specify")
+	m.Write([]byte("writing spec"))
+	m.EndPhase("specify", nil)
+	m.StartPhase("plan")
+	m.EndPhase("plan", errors.New("validation failed"))
+	m.StartPhase("plan (retry 1)")
+
+	require.Len(t, m.Phases, 3)
+	specify, plan, retry := m.Phases[0], m.Phases[1], m.Phases[2]
+
+	assert.Equal(t, PhaseDone, specify.Status)
+	assert.Equal(t, []string{"writing spec"}, specify.Lines, "partial line flushed on end")
+	assert.True(t, specify.Collapsed, "done phases collapse when the next starts")
+
+	assert.Equal(t, PhaseFailed, plan.Status)
+	assert.Equal(t, []string{"✗ validation failed"}, plan.Lines)
+	assert.False(t, plan.Collapsed, "failed phases stay expanded")
+
+	assert.Equal(t, PhaseRunning, retry.Status)
+	assert.Equal(t, 2, m.selected)
+}
+
+func TestModel_EndPhaseUnknownName(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel()
+	m.StartPhase("plan")
+	m.EndPhase("tasks", nil)
+
+	assert.Equal(t, PhaseRunning, m.Phases[0].Status)
+}
@@ -0,0 +1,190 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
+)
+
+// drainTimeout bounds how long Stop waits for captured output to flush.
+const drainTimeout = time.Second
+
+// summaryTailLines is how many lines of a failed phase are echoed after exit.
+const summaryTailLines = 20
+
+// Program owns the terminal while the live view is active. It runs the Model
+// in a bubbletea event loop and captures os.Stdout and os.Stderr through a
+// pipe so that agent output and autospec's own messages land in the current
+// phase transcript instead of the screen.
+type Program struct {
+	model   *Model
+	program *tea.Program
+	done    chan struct{} // Closed when the event loop exits
+
+	mu     sync.Mutex
+	active bool // The live view currently owns the terminal
+
+	out     *os.File // Terminal output (the original stdout)
+	origErr *os.File
+	pipeR   *os.File
+	pipeW   *os.File
+	drained chan struct{}
+	signals chan os.Signal
+}
+
+// Supported reports whether stdin and stdout are interactive terminals.
+func Supported() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// Start takes over the terminal and begins streaming captured output.
+// Call Stop when the command finishes to restore the terminal.
+func Start() (*Program, error) {
+	if !Supported() {
+		return nil, errors.New("live output requires an interactive terminal")
+	}
+	pipeR, pipeW, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("creating output pipe: %w", err)
+	}
+
+	p := &Program{
+		model:   NewModel(),
+		done:    make(chan struct{}),
+		out:     os.Stdout,
+		origErr: os.Stderr,
+		pipeR:   pipeR,
+		pipeW:   pipeW,
+		drained: make(chan struct{}),
+		signals: make(chan os.Signal, 1),
+	}
+	p.program = tea.NewProgram(p.model,
+		tea.WithAltScreen(),
+		tea.WithInput(os.Stdin),
+		tea.WithOutput(p.out),
+		// Signals are handled below so the summary is printed before exit
+		tea.WithoutSignalHandler(),
+	)
+	go func() {
+		defer close(p.done)
+		_, _ = p.program.Run()
+	}()
+	go p.readOutput()
+
+	signal.Notify(p.signals, syscall.SIGINT, syscall.SIGTERM)
+	go p.handleSignals()
+
+	p.mu.Lock()
+	p.active = true
+	os.Stdout, os.Stderr = p.pipeW, p.pipeW
+	p.mu.Unlock()
+	return p, nil
+}
+
+// StartPhase begins a new phase transcript.
+func (p *Program) StartPhase(name string) {
+	p.program.Send(phaseStartMsg{name: name})
+}
+
+// EndPhase marks a phase as completed or failed.
+func (p *Program) EndPhase(name string, err error) {
+	p.program.Send(phaseEndMsg{name: name, err: err})
+}
+
+// Suspend hands the terminal back (e.g., for an interactive agent session).
+// Output written while suspended goes straight to the terminal.
+func (p *Program) Suspend() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.active {
+		return
+	}
+	p.active = false
+	os.Stdout, os.Stderr = p.out, p.origErr
+	_ = p.program.ReleaseTerminal()
+}
+
+// Resume re-enters the live view after Suspend.
+func (p *Program) Resume() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.active || p.pipeW == nil {
+		return nil // Already running, or stopped for good
+	}
+	if err := p.program.RestoreTerminal(); err != nil {
+		return fmt.Errorf("restoring live view: %w", err)
+	}
+	p.active = true
+	os.Stdout, os.Stderr = p.pipeW, p.pipeW
+	return nil
+}
+
+// Stop restores the terminal and prints a plain-text summary of each phase.
+// Safe to call more than once.
+func (p *Program) Stop() {
+	p.mu.Lock()
+	pipeW := p.pipeW
+	p.pipeW = nil
+	p.active = false
+	os.Stdout, os.Stderr = p.out, p.origErr
+	p.mu.Unlock()
+	if pipeW == nil {
+		return
+	}
+	signal.Stop(p.signals)
+	close(p.signals)
+
+	// An agent killed by a signal may leave the pipe open briefly; don't hang on it
+	_ = pipeW.Close()
+	select {
+	case <-p.drained:
+	case <-time.After(drainTimeout):
+	}
+
+	p.program.Quit()
+	<-p.done
+	_, _ = io.WriteString(p.out, p.model.Summary(summaryTailLines))
+}
+
+// readOutput sends captured stdout/stderr to the event loop until the pipe closes.
+func (p *Program) readOutput() {
+	defer close(p.drained)
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := p.pipeR.Read(buf)
+		if n > 0 {
+			p.program.Send(outputMsg(append([]byte(nil), buf[:n]...)))
+		}
+		if err != nil {
+			_ = p.pipeR.Close()
+			return
+		}
+	}
+}
+
+// interrupt forwards Ctrl+C as SIGINT to the process group, since the
+// terminal doesn't send it while the live view holds it in raw mode.
+func interrupt() tea.Msg {
+	_ = syscall.Kill(0, syscall.SIGINT)
+	return nil
+}
+
+// handleSignals restores the terminal before the process exits on a signal,
+// then re-raises it so the default exit behavior still applies.
+func (p *Program) handleSignals() {
+	sig, ok := <-p.signals
+	if !ok {
+		return
+	}
+	p.Stop()
+	signal.Reset(sig)
+	_ = syscall.Kill(os.Getpid(), sig.(syscall.Signal))
+}
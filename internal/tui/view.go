@@ -0,0 +1,199 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// helpLine is the key reference shown in the footer.
+const helpLine = "↑/↓ scroll  PgUp/PgDn page  tab/shift+tab select phase  space collapse/expand  a all  End follow"
+
+// Row styles. lipgloss drops colors the terminal doesn't support.
+var (
+	plainStyle    = lipgloss.NewStyle()
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	selectedStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	failedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	footerStyle   = lipgloss.NewStyle().Faint(true)
+)
+
+// rowKind distinguishes the rows of a frame for styling.
+type rowKind int
+
+const (
+	rowLine rowKind = iota
+	rowHeader
+	rowTitle
+	rowFooter
+)
+
+// row is one rendered row: the title, a phase header, an output line, or the footer.
+type row struct {
+	text  string
+	phase int
+	kind  rowKind
+}
+
+// rows flattens the transcript into rows, omitting collapsed phase bodies.
+func (m *Model) rows() []row {
+	var rows []row
+	for i, p := range m.Phases {
+		rows = append(rows, row{text: m.phaseHeader(i, p), phase: i, kind: rowHeader})
+		if p.Collapsed {
+			continue
+		}
+		if p.Dropped > 0 {
+			rows = append(rows, row{text: fmt.Sprintf("    … %d earlier lines dropped", p.Dropped), phase: i})
+		}
+		for _, line := range p.Lines {
+			rows = append(rows, row{text: "    " + line, phase: i})
+		}
+		if p.partial != "" {
+			rows = append(rows, row{text: "    " + p.partial, phase: i})
+		}
+	}
+	return rows
+}
+
+// phaseHeader renders a phase's header row with its status and line count.
+func (m *Model) phaseHeader(idx int, p *Phase) string {
+	cursor := "  "
+	if idx == m.selected {
+		cursor = "> "
+	}
+	fold := "▾"
+	if p.Collapsed {
+		fold = "▸"
+	}
+	return fmt.Sprintf("%s%s %s %s (%d lines)", cursor, fold, statusSymbol(p.Status), p.Name, len(p.Lines)+p.Dropped)
+}
+
+// statusSymbol returns the marker for a phase status.
+func statusSymbol(s PhaseStatus) string {
+	switch s {
+	case PhaseDone:
+		return "✓"
+	case PhaseFailed:
+		return "✗"
+	default:
+		return "●"
+	}
+}
+
+// bodyHeight is the number of transcript rows that fit between title and footer.
+func (m *Model) bodyHeight() int {
+	return max(m.height-2, 1)
+}
+
+// clampOffset keeps the viewport within the transcript, pinning it to the
+// bottom while following.
+func (m *Model) clampOffset(total int) {
+	maxOffset := max(total-m.bodyHeight(), 0)
+	if m.follow || m.offset > maxOffset {
+		m.offset = maxOffset
+	}
+	m.offset = max(m.offset, 0)
+}
+
+// View implements tea.Model, rendering the styled frame.
+func (m *Model) View() string {
+	rows := m.frame()
+	lines := make([]string, len(rows))
+	for i, r := range rows {
+		lines[i] = m.style(r).Render(r.text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// frame lays out exactly height rows, each at most width columns.
+func (m *Model) frame() []row {
+	rows := m.rows()
+	m.clampOffset(len(rows))
+
+	frame := make([]row, 0, m.height)
+	frame = append(frame, row{text: truncate(m.title(), m.width), kind: rowTitle})
+	end := min(m.offset+m.bodyHeight(), len(rows))
+	for _, r := range rows[m.offset:end] {
+		r.text = truncate(r.text, m.width)
+		frame = append(frame, r)
+	}
+	for len(frame) < m.height-1 {
+		frame = append(frame, row{})
+	}
+	return append(frame, row{text: truncate(m.footer(len(rows)), m.width), kind: rowFooter})
+}
+
+// style picks the style for a frame row.
+func (m *Model) style(r row) lipgloss.Style {
+	switch r.kind {
+	case rowTitle:
+		return titleStyle
+	case rowFooter:
+		return footerStyle
+	case rowHeader:
+		if r.phase == m.selected {
+			return selectedStyle
+		}
+		if m.Phases[r.phase].Status == PhaseFailed {
+			return failedStyle
+		}
+	}
+	return plainStyle
+}
+
+// title summarizes phase progress, e.g. "autospec  ✓ specify  ● plan".
+func (m *Model) title() string {
+	var b strings.Builder
+	b.WriteString("autospec")
+	for _, p := range m.Phases {
+		if p.Name == generalPhase {
+			continue
+		}
+		fmt.Fprintf(&b, "  %s %s", statusSymbol(p.Status), p.Name)
+	}
+	return b.String()
+}
+
+// footer shows key help, or the scroll position when not following.
+func (m *Model) footer(total int) string {
+	if m.follow {
+		return helpLine
+	}
+	return fmt.Sprintf("[%d-%d of %d, End to follow]  %s", m.offset+1, min(m.offset+m.bodyHeight(), total), total, helpLine)
+}
+
+// truncate shortens s to at most width runes.
+func truncate(s string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	if width == 1 {
+		return string(runes[:1])
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// Summary renders a plain-text recap printed after the live view closes:
+// one line per phase, plus the tail of any failed phase.
+func (m *Model) Summary(tailLines int) string {
+	m.flushPartial()
+	var b strings.Builder
+	for _, p := range m.Phases {
+		if p.Name == generalPhase && p.Status == PhaseDone {
+			continue
+		}
+		fmt.Fprintf(&b, "%s %s (%d lines)\n", statusSymbol(p.Status), p.Name, len(p.Lines)+p.Dropped)
+		if p.Status != PhaseFailed {
+			continue
+		}
+		start := max(len(p.Lines)-tailLines, 0)
+		for _, line := range p.Lines[start:] {
+			fmt.Fprintf(&b, "    %s\n", line)
+		}
+	}
+	return b.String()
+}
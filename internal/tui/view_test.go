@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// frameText returns the unstyled text of each frame row.
+func frameText(m *Model) []string {
+	var lines []string
+	for _, r := range m.frame() {
+		lines = append(lines, r.text)
+	}
+	return lines
+}
+
+func TestModel_View(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel()
+	m.Resize(30, 6)
+	m.StartPhase("specify")
+	m.EndPhase("specify", nil)
+	m.StartPhase("plan")
+	m.Write([]byte("a very long line of agent output that must be truncated\nshort\n"))
+
+	frame := frameText(m)
+	require.Len(t, frame, 6)
+	for _, line := range frame {
+		assert.LessOrEqual(t, utf8.RuneCountInString(line), 30)
+	}
+
+	assert.Equal(t, "autospec  ✓ specify  ● plan", frame[0])
+	assert.Equal(t, "  ▸ ✓ specify (0 lines)", frame[1])
+	assert.Equal(t, "> ▾ ● plan (2 lines)", frame[2])
+	assert.Equal(t, "    a very long line of agent…", frame[3])
+	assert.Equal(t, "    short", frame[4])
+
+	view := m.View()
+	assert.Len(t, strings.Split(view, "\n"), 6)
+	assert.Contains(t, view, "plan (2 lines)")
+}
+
+func TestModel_ViewShowsDroppedLines(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel()
+	m.MaxLines = 1
+	m.StartPhase("implement")
+	m.Write([]byte("old\nnew\n"))
+
+	frame := frameText(m)
+	assert.Contains(t, frame, "    … 1 earlier lines dropped")
+	assert.Contains(t, frame, "    new")
+}
+
+func TestModel_ViewFooterWhenScrolled(t *testing.T) {
+	t.Parallel()
+
+	m := scrollModel(30)
+	m.HandleKey(KeyHome)
+	frame := frameText(m)
+
+	assert.Contains(t, frame[len(frame)-1], "[1-8 of 31, End to follow]")
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		s     string
+		width int
+		want  string
+	}{
+		"fits":        {s: "plan", width: 10, want: "plan"},
+		"truncated":   {s: "implement", width: 5, want: "impl…"},
+		"multibyte":   {s: "✓✓✓✓", width: 3, want: "✓✓…"},
+		"width one":   {s: "plan", width: 1, want: "p"},
+		"zero width":  {s: "plan", width: 0, want: "plan"},
+		"exact width": {s: "plan", width: 4, want: "plan"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, truncate(tt.s, tt.width))
+		})
+	}
+}
+
+func TestModel_Summary(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel()
+	m.Write([]byte("banner\n"))
+	m.StartPhase("specify")
+	m.Write([]byte("spec written\n"))
+	m.EndPhase("specify", nil)
+	m.StartPhase("plan")
+	m.Write([]byte("step 1\nstep 2\nstep 3"))
+	m.EndPhase("plan", errors.New("agent exited"))
+
+	want := "✓ specify (1 lines)\n" +
+		"✗ plan (4 lines)\n" +
+		"    step 2\n" +
+		"    step 3\n" +
+		"    ✗ agent exited\n"
+	assert.Equal(t, want, m.Summary(3))
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 	"runtime"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -15,15 +17,52 @@ const (
 
 	// DefaultHTTPTimeout is the default timeout for HTTP requests.
 	DefaultHTTPTimeout = 5 * time.Second
+
+	// releaseListSize is how many recent releases are fetched for the
+	// prerelease channel and for release notes.
+	releaseListSize = 30
 )
 
+// Channel selects which releases an update check considers.
+type Channel string
+
+const (
+	// ChannelStable considers only full releases. This is the default.
+	ChannelStable Channel = "stable"
+	// ChannelPrerelease also considers prereleases such as v0.7.0-rc.1.
+	ChannelPrerelease Channel = "prerelease"
+)
+
+// ParseChannel validates a --channel value.
+func ParseChannel(s string) (Channel, error) {
+	switch Channel(s) {
+	case ChannelStable, ChannelPrerelease:
+		return Channel(s), nil
+	default:
+		return "", fmt.Errorf("invalid channel %q: expected %s or %s", s, ChannelStable, ChannelPrerelease)
+	}
+}
+
 // ReleaseInfo represents a GitHub release.
 type ReleaseInfo struct {
 	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"`
+	HTMLURL     string    `json:"html_url"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
 	PublishedAt time.Time `json:"published_at"`
 	Assets      []Asset   `json:"assets"`
 }
 
+// ReleaseNote is the changelog of one release newer than the running version.
+type ReleaseNote struct {
+	Version     string
+	PublishedAt time.Time
+	Body        string
+	URL         string
+}
+
 // Asset represents a single release asset.
 type Asset struct {
 	Name               string `json:"name"`
@@ -39,12 +78,17 @@ type UpdateCheck struct {
 	DownloadURL     string
 	ChecksumURL     string
 	AssetName       string
+	Channel         Channel
+	// Notes lists the releases between the current and latest version,
+	// newest first, when an update is available.
+	Notes []ReleaseNote
 }
 
 // Checker provides update checking functionality.
 type Checker struct {
 	httpClient *http.Client
 	apiURL     string
+	channel    Channel
 }
 
 // NewChecker creates a new update checker with the given timeout.
@@ -55,9 +99,15 @@ func NewChecker(timeout time.Duration) *Checker {
 	return &Checker{
 		httpClient: &http.Client{Timeout: timeout},
 		apiURL:     GitHubAPIURL,
+		channel:    ChannelStable,
 	}
 }
 
+// SetChannel selects the release channel to check. The default is stable.
+func (c *Checker) SetChannel(channel Channel) {
+	c.channel = channel
+}
+
 // SetAPIURL sets the API URL for the checker. This is intended for testing purposes.
 func (c *Checker) SetAPIURL(url string) {
 	c.apiURL = url
@@ -77,7 +127,7 @@ func (c *Checker) CheckForUpdate(ctx context.Context, currentVersion string) (*U
 		}, nil
 	}
 
-	release, err := c.fetchLatestRelease(ctx)
+	release, releases, err := c.fetchTargetRelease(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("fetching latest release: %w", err)
 	}
@@ -91,22 +141,130 @@ func (c *Checker) CheckForUpdate(ctx context.Context, currentVersion string) (*U
 		CurrentVersion:  currentVersion,
 		LatestVersion:   release.TagName,
 		UpdateAvailable: latest.IsNewerThan(current),
+		Channel:         c.channel,
 	}
 
 	if result.UpdateAvailable {
 		if err := c.populateDownloadURLs(result, release); err != nil {
 			return nil, fmt.Errorf("finding download URLs: %w", err)
 		}
+		result.Notes = c.releaseNotes(ctx, current, latest, release, releases)
 	}
 
 	return result, nil
 }
 
+// fetchTargetRelease returns the release to update to on the checker's
+// channel. The stable channel asks GitHub for the latest release; the
+// prerelease channel picks the newest of the recent releases, which it also
+// returns so release notes need no second request.
+func (c *Checker) fetchTargetRelease(ctx context.Context) (*ReleaseInfo, []ReleaseInfo, error) {
+	if c.channel != ChannelPrerelease {
+		release, err := c.fetchLatestRelease(ctx)
+		return release, nil, err
+	}
+
+	releases, err := c.fetchReleases(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	releases = filterReleases(releases, ChannelPrerelease)
+	if len(releases) == 0 {
+		return nil, nil, fmt.Errorf("no releases found")
+	}
+	return &releases[0], releases, nil
+}
+
+// releaseNotes returns the notes of the releases newer than current, up to
+// and including latest. When the release list can't be fetched it falls
+// back to the notes of the target release alone.
+func (c *Checker) releaseNotes(ctx context.Context, current, latest *Version, target *ReleaseInfo, releases []ReleaseInfo) []ReleaseNote {
+	if releases == nil {
+		fetched, err := c.fetchReleases(ctx)
+		if err != nil {
+			return []ReleaseNote{newReleaseNote(*target)}
+		}
+		releases = filterReleases(fetched, c.channel)
+	}
+
+	var notes []ReleaseNote
+	for _, release := range releases {
+		version, err := ParseVersion(release.TagName)
+		if err != nil || !version.IsNewerThan(current) || version.IsNewerThan(latest) {
+			continue
+		}
+		notes = append(notes, newReleaseNote(release))
+	}
+	if len(notes) == 0 {
+		notes = []ReleaseNote{newReleaseNote(*target)}
+	}
+	return notes
+}
+
+// newReleaseNote converts a release to its note.
+func newReleaseNote(release ReleaseInfo) ReleaseNote {
+	return ReleaseNote{
+		Version:     release.TagName,
+		PublishedAt: release.PublishedAt,
+		Body:        strings.TrimSpace(release.Body),
+		URL:         release.HTMLURL,
+	}
+}
+
+// filterReleases drops drafts, unparseable tags and, on the stable channel,
+// prereleases, and sorts the rest newest first.
+func filterReleases(releases []ReleaseInfo, channel Channel) []ReleaseInfo {
+	type parsed struct {
+		release ReleaseInfo
+		version *Version
+	}
+	var kept []parsed
+	for _, release := range releases {
+		version, err := ParseVersion(release.TagName)
+		if release.Draft || err != nil || version.IsDev() {
+			continue
+		}
+		if channel != ChannelPrerelease && (release.Prerelease || version.IsPrerelease()) {
+			continue
+		}
+		kept = append(kept, parsed{release: release, version: version})
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].version.IsNewerThan(kept[j].version)
+	})
+
+	filtered := make([]ReleaseInfo, len(kept))
+	for i, p := range kept {
+		filtered[i] = p.release
+	}
+	return filtered
+}
+
+// fetchReleases fetches the most recent releases, including drafts and
+// prereleases, from the GitHub API.
+func (c *Checker) fetchReleases(ctx context.Context) ([]ReleaseInfo, error) {
+	url := fmt.Sprintf("%s?per_page=%d", strings.TrimSuffix(c.apiURL, "/latest"), releaseListSize)
+	var releases []ReleaseInfo
+	if err := c.getJSON(ctx, url, &releases); err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
 // fetchLatestRelease fetches the latest release from GitHub API.
 func (c *Checker) fetchLatestRelease(ctx context.Context) (*ReleaseInfo, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.apiURL, nil)
+	var release ReleaseInfo
+	if err := c.getJSON(ctx, c.apiURL, &release); err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+// getJSON fetches a GitHub API URL and decodes its JSON response into v.
+func (c *Checker) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		return fmt.Errorf("creating request: %w", err)
 	}
 
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
@@ -114,26 +272,24 @@ func (c *Checker) fetchLatestRelease(ctx context.Context) (*ReleaseInfo, error)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("executing request: %w", err)
+		return fmt.Errorf("executing request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusForbidden {
-		return nil, fmt.Errorf("rate limit exceeded")
+		return fmt.Errorf("rate limit exceeded")
 	}
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("no releases found")
+		return fmt.Errorf("no releases found")
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	var release ReleaseInfo
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return nil, fmt.Errorf("decoding response: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
 	}
-
-	return &release, nil
+	return nil
 }
 
 // populateDownloadURLs finds and sets the appropriate download URLs for the current platform.
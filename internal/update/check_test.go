@@ -2,6 +2,7 @@ package update
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -113,6 +114,156 @@ func TestChecker_CheckForUpdate(t *testing.T) {
 	}
 }
 
+// releasesServer serves a release list at /releases and the newest stable
+// release at /releases/latest, like the GitHub API.
+func releasesServer(t *testing.T, releases []ReleaseInfo) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/releases/latest":
+			for _, release := range releases {
+				if !release.Draft && !release.Prerelease {
+					_ = json.NewEncoder(w).Encode(release)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+		case "/releases":
+			assert.Equal(t, "30", r.URL.Query().Get("per_page"))
+			_ = json.NewEncoder(w).Encode(releases)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// release builds a release with an asset for the current platform.
+func release(tag string, prerelease bool) ReleaseInfo {
+	return ReleaseInfo{
+		TagName:    tag,
+		Body:       "Notes for " + tag,
+		HTMLURL:    "https://example.com/" + tag,
+		Prerelease: prerelease,
+		Assets: []Asset{
+			{Name: buildAssetName(tag), BrowserDownloadURL: "https://example.com/" + tag + ".tar.gz"},
+			{Name: "checksums.txt", BrowserDownloadURL: "https://example.com/checksums.txt"},
+		},
+	}
+}
+
+func TestChecker_Channels(t *testing.T) {
+	t.Parallel()
+
+	draft := release("v0.9.0", false)
+	draft.Draft = true
+	// Newest first, as GitHub lists them
+	releases := []ReleaseInfo{draft, release("v0.8.0-rc.2", true), release("v0.7.1", false), release("v0.7.0", false), release("v0.6.0", false)}
+
+	tests := map[string]struct {
+		channel    Channel
+		current    string
+		wantLatest string
+		wantNotes  []string
+	}{
+		"stable skips drafts and prereleases": {
+			channel:    ChannelStable,
+			current:    "v0.6.0",
+			wantLatest: "v0.7.1",
+			wantNotes:  []string{"v0.7.1", "v0.7.0"},
+		},
+		"prerelease includes release candidates": {
+			channel:    ChannelPrerelease,
+			current:    "v0.7.0",
+			wantLatest: "v0.8.0-rc.2",
+			wantNotes:  []string{"v0.8.0-rc.2", "v0.7.1"},
+		},
+		"stable from a prerelease": {
+			channel:    ChannelStable,
+			current:    "v0.7.1-rc.1",
+			wantLatest: "v0.7.1",
+			wantNotes:  []string{"v0.7.1"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			server := releasesServer(t, releases)
+			checker := NewChecker(5 * time.Second)
+			checker.SetAPIURL(server.URL + "/releases/latest")
+			checker.SetChannel(tt.channel)
+
+			result, err := checker.CheckForUpdate(context.Background(), tt.current)
+
+			require.NoError(t, err)
+			assert.True(t, result.UpdateAvailable)
+			assert.Equal(t, tt.wantLatest, result.LatestVersion)
+			assert.Equal(t, tt.channel, result.Channel)
+			assert.Equal(t, "https://example.com/"+tt.wantLatest+".tar.gz", result.DownloadURL)
+			var notes []string
+			for _, note := range result.Notes {
+				notes = append(notes, note.Version)
+				assert.Equal(t, "Notes for "+note.Version, note.Body)
+			}
+			assert.Equal(t, tt.wantNotes, notes)
+		})
+	}
+}
+
+func TestChecker_NotesFallBackToLatestRelease(t *testing.T) {
+	t.Parallel()
+
+	// A server that only knows the latest release can't list releases
+	latest := release("v0.7.0", false)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/releases/latest" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(latest)
+	}))
+	defer server.Close()
+
+	checker := NewChecker(5 * time.Second)
+	checker.SetAPIURL(server.URL + "/releases/latest")
+
+	result, err := checker.CheckForUpdate(context.Background(), "v0.6.0")
+
+	require.NoError(t, err)
+	require.Len(t, result.Notes, 1)
+	assert.Equal(t, ReleaseNote{Version: "v0.7.0", Body: "Notes for v0.7.0", URL: "https://example.com/v0.7.0"}, result.Notes[0])
+}
+
+func TestParseChannel(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input   string
+		want    Channel
+		wantErr bool
+	}{
+		"stable":     {input: "stable", want: ChannelStable},
+		"prerelease": {input: "prerelease", want: ChannelPrerelease},
+		"unknown":    {input: "beta", wantErr: true},
+		"empty":      {input: "", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseChannel(tt.input)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "expected stable or prerelease")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestChecker_Timeout(t *testing.T) {
 	t.Parallel()
 
@@ -1,8 +1,9 @@
 // Package update provides version checking and self-update functionality for autospec.
 //
 // The package includes:
-//   - Semantic version parsing and comparison (version.go)
-//   - GitHub API client for fetching release info (check.go)
+//   - Semantic version parsing and comparison, including prereleases (version.go)
+//   - GitHub API client for fetching release info and release notes on the
+//     stable or prerelease channel (check.go)
 //   - Binary download with progress display (download.go)
 //   - Binary installation with backup and rollback (install.go)
 //
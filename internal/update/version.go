@@ -8,14 +8,16 @@ import (
 
 // Version represents a parsed semantic version.
 type Version struct {
-	Major int
-	Minor int
-	Patch int
-	Raw   string
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string // e.g. "rc.1" for v0.7.0-rc.1; empty for releases
+	Raw        string
 }
 
-// ParseVersion parses a version string in the format "v0.6.1" or "0.6.1".
-// Returns an error if the version string is invalid.
+// ParseVersion parses a version string in the format "v0.6.1" or "0.6.1",
+// optionally with a prerelease suffix ("v0.7.0-rc.1"). Build metadata
+// ("+build.5") is ignored. Returns an error if the version string is invalid.
 // The "dev" version is a special case that returns a zero version.
 func ParseVersion(v string) (*Version, error) {
 	raw := v
@@ -25,6 +27,12 @@ func ParseVersion(v string) (*Version, error) {
 		return &Version{Raw: raw}, nil
 	}
 
+	v, _, _ = strings.Cut(v, "+")
+	v, prerelease, hasPrerelease := strings.Cut(v, "-")
+	if hasPrerelease && prerelease == "" {
+		return nil, fmt.Errorf("parsing version %q: empty prerelease", raw)
+	}
+
 	parts := strings.Split(v, ".")
 	if len(parts) != 3 {
 		return nil, fmt.Errorf("parsing version %q: expected format MAJOR.MINOR.PATCH", raw)
@@ -45,7 +53,7 @@ func ParseVersion(v string) (*Version, error) {
 		return nil, fmt.Errorf("parsing patch version %q: %w", parts[2], err)
 	}
 
-	return &Version{Major: major, Minor: minor, Patch: patch, Raw: raw}, nil
+	return &Version{Major: major, Minor: minor, Patch: patch, Prerelease: prerelease, Raw: raw}, nil
 }
 
 // IsDev returns true if this is a development build (not a proper release version).
@@ -53,11 +61,19 @@ func (v *Version) IsDev() bool {
 	return v.Raw == "dev" || v.Raw == ""
 }
 
-// String returns the version string in "vMAJOR.MINOR.PATCH" format.
+// IsPrerelease returns true for versions with a prerelease suffix.
+func (v *Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// String returns the version string in "vMAJOR.MINOR.PATCH[-PRERELEASE]" format.
 func (v *Version) String() string {
 	if v.IsDev() {
 		return "dev"
 	}
+	if v.IsPrerelease() {
+		return fmt.Sprintf("v%d.%d.%d-%s", v.Major, v.Minor, v.Patch, v.Prerelease)
+	}
 	return fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
 }
 
@@ -66,7 +82,8 @@ func (v *Version) String() string {
 //   - 0 if v == other
 //   - 1 if v > other
 //
-// Dev versions are always considered less than any proper version.
+// Dev versions are always considered less than any proper version, and a
+// prerelease is less than the release it precedes (v0.7.0-rc.1 < v0.7.0).
 func (v *Version) Compare(other *Version) int {
 	if v.IsDev() && other.IsDev() {
 		return 0
@@ -84,7 +101,44 @@ func (v *Version) Compare(other *Version) int {
 	if v.Minor != other.Minor {
 		return compareInts(v.Minor, other.Minor)
 	}
-	return compareInts(v.Patch, other.Patch)
+	if v.Patch != other.Patch {
+		return compareInts(v.Patch, other.Patch)
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// comparePrerelease compares prerelease suffixes by semver precedence:
+// no suffix ranks highest, and dot-separated identifiers are compared in
+// order, numerically when both are numbers ("rc.2" < "rc.10").
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNum, aErr := strconv.Atoi(aParts[i])
+		bNum, bErr := strconv.Atoi(bParts[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNum != bNum {
+				return compareInts(aNum, bNum)
+			}
+		case aErr == nil:
+			return -1 // Numeric identifiers rank below alphanumeric ones
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(aParts[i], bParts[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareInts(len(aParts), len(bParts))
 }
 
 // IsNewerThan returns true if v is newer than other.
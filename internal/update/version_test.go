@@ -39,6 +39,18 @@ func TestParseVersion(t *testing.T) {
 			input: "",
 			want:  &Version{Raw: ""},
 		},
+		"prerelease": {
+			input: "v0.7.0-rc.1",
+			want:  &Version{Major: 0, Minor: 7, Patch: 0, Prerelease: "rc.1", Raw: "v0.7.0-rc.1"},
+		},
+		"build metadata ignored": {
+			input: "v0.7.0-beta+build.5",
+			want:  &Version{Major: 0, Minor: 7, Patch: 0, Prerelease: "beta", Raw: "v0.7.0-beta+build.5"},
+		},
+		"invalid - empty prerelease": {
+			input:   "v0.7.0-",
+			wantErr: true,
+		},
 		"invalid - too few parts": {
 			input:   "v1.2",
 			wantErr: true,
@@ -73,6 +85,7 @@ func TestParseVersion(t *testing.T) {
 			assert.Equal(t, tt.want.Major, got.Major)
 			assert.Equal(t, tt.want.Minor, got.Minor)
 			assert.Equal(t, tt.want.Patch, got.Patch)
+			assert.Equal(t, tt.want.Prerelease, got.Prerelease)
 			assert.Equal(t, tt.want.Raw, got.Raw)
 		})
 	}
@@ -118,6 +131,10 @@ func TestVersion_String(t *testing.T) {
 			version: &Version{Major: 0, Minor: 6, Patch: 1, Raw: "v0.6.1"},
 			want:    "v0.6.1",
 		},
+		"prerelease version": {
+			version: &Version{Major: 0, Minor: 7, Patch: 0, Prerelease: "rc.1", Raw: "v0.7.0-rc.1"},
+			want:    "v0.7.0-rc.1",
+		},
 		"dev build": {
 			version: &Version{Raw: "dev"},
 			want:    "dev",
@@ -179,6 +196,26 @@ func TestVersion_Compare(t *testing.T) {
 			v2:   &Version{Major: 0, Minor: 6, Patch: 1, Raw: "v0.6.1"},
 			want: -1,
 		},
+		"release greater than its prerelease": {
+			v1:   &Version{Major: 0, Minor: 7, Patch: 0, Raw: "v0.7.0"},
+			v2:   &Version{Major: 0, Minor: 7, Patch: 0, Prerelease: "rc.1", Raw: "v0.7.0-rc.1"},
+			want: 1,
+		},
+		"numeric prerelease identifiers": {
+			v1:   &Version{Major: 0, Minor: 7, Patch: 0, Prerelease: "rc.2", Raw: "v0.7.0-rc.2"},
+			v2:   &Version{Major: 0, Minor: 7, Patch: 0, Prerelease: "rc.10", Raw: "v0.7.0-rc.10"},
+			want: -1,
+		},
+		"alphanumeric prerelease identifiers": {
+			v1:   &Version{Major: 0, Minor: 7, Patch: 0, Prerelease: "beta", Raw: "v0.7.0-beta"},
+			v2:   &Version{Major: 0, Minor: 7, Patch: 0, Prerelease: "alpha.1", Raw: "v0.7.0-alpha.1"},
+			want: 1,
+		},
+		"longer prerelease ranks higher": {
+			v1:   &Version{Major: 0, Minor: 7, Patch: 0, Prerelease: "rc", Raw: "v0.7.0-rc"},
+			v2:   &Version{Major: 0, Minor: 7, Patch: 0, Prerelease: "rc.1", Raw: "v0.7.0-rc.1"},
+			want: -1,
+		},
 		"dev vs dev": {
 			v1:   &Version{Raw: "dev"},
 			v2:   &Version{Raw: "dev"},
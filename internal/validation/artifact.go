@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -11,13 +13,13 @@ import (
 
 // ValidationError represents a single validation error with location and context.
 type ValidationError struct {
-	Path     string // JSON-path style field location (e.g., "user_stories[0].id")
-	Line     int    // 1-based line number in source file
-	Column   int    // 1-based column number in source file
-	Message  string // Human-readable error description
-	Expected string // What was expected (type, value, format)
-	Actual   string // What was found
-	Hint     string // Suggestion for fixing the error
+	Path     string `json:"path,omitempty"`     // JSON-path style field location (e.g., "user_stories[0].id")
+	Line     int    `json:"line,omitempty"`     // 1-based line number in source file
+	Column   int    `json:"column,omitempty"`   // 1-based column number in source file
+	Message  string `json:"message"`            // Human-readable error description
+	Expected string `json:"expected,omitempty"` // What was expected (type, value, format)
+	Actual   string `json:"actual,omitempty"`   // What was found
+	Hint     string `json:"hint,omitempty"`     // Suggestion for fixing the error
 }
 
 // Error implements the error interface.
@@ -76,24 +78,24 @@ func (e *ValidationError) FormatFull() string {
 
 // ArtifactSummary contains summary statistics about a validated artifact.
 type ArtifactSummary struct {
-	Type   ArtifactType   // Type of artifact validated
-	Counts map[string]int // Key counts (stories, tasks, phases, etc.)
+	Type   ArtifactType   `json:"type"`   // Type of artifact validated
+	Counts map[string]int `json:"counts"` // Key counts (stories, tasks, phases, etc.)
 }
 
 // ValidationWarning represents a non-fatal validation warning.
 type ValidationWarning struct {
-	Path    string // JSON-path style field location
-	Line    int    // 1-based line number in source file
-	Message string // Human-readable warning description
-	Hint    string // Suggestion for addressing the warning
+	Path    string `json:"path,omitempty"` // JSON-path style field location
+	Line    int    `json:"line,omitempty"` // 1-based line number in source file
+	Message string `json:"message"`        // Human-readable warning description
+	Hint    string `json:"hint,omitempty"` // Suggestion for addressing the warning
 }
 
 // ValidationResult represents the complete validation outcome for an artifact.
 type ValidationResult struct {
-	Valid    bool                 // True if artifact passed all validation
-	Errors   []*ValidationError   // List of validation errors found
-	Warnings []*ValidationWarning // List of validation warnings (non-fatal)
-	Summary  *ArtifactSummary     // Summary statistics (populated on valid artifacts)
+	Valid    bool                 `json:"valid"`              // True if artifact passed all validation
+	Errors   []*ValidationError   `json:"errors"`             // List of validation errors found
+	Warnings []*ValidationWarning `json:"warnings,omitempty"` // List of validation warnings (non-fatal)
+	Summary  *ArtifactSummary     `json:"summary,omitempty"`  // Summary statistics (populated on valid artifacts)
 }
 
 // HasErrors returns true if there are any validation errors.
@@ -179,6 +181,27 @@ func parseYAMLReader(r io.Reader) (*yaml.Node, error) {
 	return &node, nil
 }
 
+// yamlErrorLine matches the location prefix yaml.v3 puts in syntax errors,
+// e.g. "yaml: line 12: did not find expected key".
+var yamlErrorLine = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// newParseError reports a YAML parse failure, moving the line number from the
+// parser message into the Line field so it is reported like schema errors.
+func newParseError(path string, err error) *ValidationError {
+	msg := err.Error()
+	line := 0
+	if m := yamlErrorLine.FindStringSubmatch(msg); m != nil {
+		line, _ = strconv.Atoi(m[1])
+		msg = strings.TrimPrefix(msg, m[0])
+	}
+	return &ValidationError{
+		Path:    path,
+		Line:    line,
+		Message: fmt.Sprintf("failed to parse YAML: %s", msg),
+		Hint:    "Check the YAML syntax for errors",
+	}
+}
+
 // findNode finds a node by key in a mapping node.
 // Handles YAML node hierarchy: DocumentNode → MappingNode → key/value pairs.
 // MappingNode.Content alternates key-value: [key0, val0, key1, val1, ...].
@@ -6,6 +6,10 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// ChecklistItemTypes are the allowed values of a checklist item's optional type,
+// the kind of requirement the item checks.
+var ChecklistItemTypes = []string{"functional", "security", "accessibility", "performance", "reliability", "usability"}
+
 // ChecklistValidator validates checklist.yaml artifacts.
 type ChecklistValidator struct {
 	baseValidator
@@ -23,11 +27,7 @@ func (v *ChecklistValidator) Validate(path string) *ValidationResult {
 	// Parse the YAML file
 	root, err := parseYAMLFile(path)
 	if err != nil {
-		result.AddError(&ValidationError{
-			Path:    path,
-			Message: fmt.Sprintf("failed to parse YAML: %v", err),
-			Hint:    "Check the YAML syntax for errors",
-		})
+		result.AddError(newParseError(path, err))
 		return result
 	}
 
@@ -175,6 +175,12 @@ func (v *ChecklistValidator) validateChecklistItem(node *yaml.Node, path string,
 			[]string{"pending", "pass", "fail"}, result)
 	}
 
+	// Validate type enum if present
+	typeNode := findNode(node, "type")
+	if typeNode != nil {
+		validateEnumValue(typeNode, path+".type", ChecklistItemTypes, result)
+	}
+
 	// Validate quality_dimension enum if present
 	qualityNode := findNode(node, "quality_dimension")
 	if qualityNode != nil {
@@ -165,6 +165,44 @@ categories:
         description: "Test item"
         status: "pass"
         quality_dimension: "invalid"
+`,
+			wantValid: false,
+			wantErrs:  1,
+		},
+		"valid item types": {
+			yaml: `checklist:
+  feature: "Test"
+  branch: "001-test"
+  domain: "security"
+
+categories:
+  - name: "Test Category"
+    items:
+      - id: "CHK001"
+        description: "Are authentication failure responses specified?"
+        type: "security"
+        status: "pending"
+      - id: "CHK002"
+        description: "Are keyboard navigation requirements defined?"
+        type: "accessibility"
+        status: "pass"
+`,
+			wantValid: true,
+			wantErrs:  0,
+		},
+		"invalid type value": {
+			yaml: `checklist:
+  feature: "Test"
+  branch: "001-test"
+  domain: "testing"
+
+categories:
+  - name: "Test Category"
+    items:
+      - id: "T-001"
+        description: "Test item"
+        status: "pass"
+        type: "a11y"
 `,
 			wantValid: false,
 			wantErrs:  1,
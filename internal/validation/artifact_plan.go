@@ -25,11 +25,7 @@ func (v *PlanValidator) Validate(path string) *ValidationResult {
 	// Parse the YAML file
 	root, err := parseYAMLFile(path)
 	if err != nil {
-		result.AddError(&ValidationError{
-			Path:    path,
-			Message: fmt.Sprintf("failed to parse YAML: %v", err),
-			Hint:    "Check the YAML syntax for errors",
-		})
+		result.AddError(newParseError(path, err))
 		return result
 	}
 
@@ -83,6 +79,9 @@ func (v *PlanValidator) Validate(path string) *ValidationResult {
 		v.validateRisks(risksNode, result)
 	}
 
+	// Check the remaining schema rules (nested fields, enums, patterns)
+	addSchemaErrors(root, &PlanSchema, result)
+
 	// Build summary if valid
 	if result.Valid {
 		result.Summary = v.buildSummary(rootMapping)
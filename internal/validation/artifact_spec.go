@@ -23,11 +23,7 @@ func (v *SpecValidator) Validate(path string) *ValidationResult {
 	// Parse the YAML file
 	root, err := parseYAMLFile(path)
 	if err != nil {
-		result.AddError(&ValidationError{
-			Path:    path,
-			Message: fmt.Sprintf("failed to parse YAML: %v", err),
-			Hint:    "Check the YAML syntax for errors",
-		})
+		result.AddError(newParseError(path, err))
 		return result
 	}
 
@@ -61,6 +57,9 @@ func (v *SpecValidator) Validate(path string) *ValidationResult {
 		v.validateRequirements(requirementsNode, result)
 	}
 
+	// Check the remaining schema rules (nested fields, enums, patterns)
+	addSchemaErrors(root, &SpecSchema, result)
+
 	// Build summary if valid
 	if result.Valid {
 		result.Summary = v.buildSummary(rootMapping)
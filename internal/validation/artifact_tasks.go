@@ -26,11 +26,7 @@ func (v *TasksValidator) Validate(path string) *ValidationResult {
 	// Parse the YAML file
 	root, err := parseYAMLFile(path)
 	if err != nil {
-		result.AddError(&ValidationError{
-			Path:    path,
-			Message: fmt.Sprintf("failed to parse YAML: %v", err),
-			Hint:    "Check the YAML syntax for errors",
-		})
+		result.AddError(newParseError(path, err))
 		return result
 	}
 
@@ -73,6 +69,9 @@ func (v *TasksValidator) Validate(path string) *ValidationResult {
 		v.validateAllDependencies(phasesNode, taskIDs, taskLines, result)
 	}
 
+	// Check the remaining schema rules (nested fields, enums, patterns)
+	addSchemaErrors(root, &TasksSchema, result)
+
 	// Build summary if valid
 	if result.Valid {
 		result.Summary = v.buildSummary(rootMapping, taskIDs)
@@ -197,10 +196,10 @@ func (v *TasksValidator) validateTask(node *yaml.Node, path string, result *Vali
 			Path:    path + ".status",
 			Line:    getNodeLine(node),
 			Message: "missing required field: status",
-			Hint:    "Add a 'status' field with one of: Pending, InProgress, Completed, Blocked",
+			Hint:    "Add a 'status' field with one of: Pending, InProgress, Completed, Blocked, Skipped",
 		})
 	} else {
-		validateEnumValue(statusNode, path+".status", []string{"Pending", "InProgress", "Completed", "Blocked"}, result)
+		validateEnumValue(statusNode, path+".status", []string{"Pending", "InProgress", "Completed", "Blocked", "Skipped"}, result)
 	}
 
 	// Validate type enum
@@ -231,6 +230,11 @@ func (v *TasksValidator) validateTask(node *yaml.Node, path string, result *Vali
 	// Validate blocked_reason for blocked tasks
 	v.validateBlockedReason(node, path, statusNode, result)
 
+	// verification is written by the verify stage
+	if verificationNode := findNode(node, "verification"); verificationNode != nil {
+		v.validateVerification(verificationNode, path+".verification", result)
+	}
+
 	// notes should be a string with max length if present
 	notesNode := findNode(node, "notes")
 	if notesNode != nil {
@@ -253,6 +257,30 @@ func (v *TasksValidator) validateTask(node *yaml.Node, path string, result *Vali
 	}
 }
 
+// validateVerification validates a task's verification record.
+func (v *TasksValidator) validateVerification(node *yaml.Node, path string, result *ValidationResult) {
+	if !validateFieldType(node, path, yaml.MappingNode, "object", result) {
+		return
+	}
+
+	statusNode := findNode(node, "status")
+	if statusNode == nil {
+		result.AddError(&ValidationError{
+			Path:    path + ".status",
+			Line:    getNodeLine(node),
+			Message: "missing required field: status",
+			Hint:    "Add a 'status' field with one of: Verified, Failed",
+		})
+	} else {
+		validateEnumValue(statusNode, path+".status", []string{VerificationVerified, VerificationFailed}, result)
+	}
+
+	// unmet should be an array if present
+	if unmetNode := findNode(node, "unmet"); unmetNode != nil {
+		validateFieldType(unmetNode, path+".unmet", yaml.SequenceNode, "array", result)
+	}
+}
+
 // validateBlockedReason checks that blocked tasks have a reason.
 func (v *TasksValidator) validateBlockedReason(node *yaml.Node, path string, statusNode *yaml.Node, result *ValidationResult) {
 	if statusNode == nil || statusNode.Value != "Blocked" {
@@ -441,6 +469,7 @@ func (v *TasksValidator) buildSummary(root *yaml.Node, taskIDs map[string]int) *
 	inProgress := 0
 	completed := 0
 	blocked := 0
+	skipped := 0
 
 	if phasesNode != nil && phasesNode.Kind == yaml.SequenceNode {
 		for _, phaseNode := range phasesNode.Content {
@@ -462,6 +491,8 @@ func (v *TasksValidator) buildSummary(root *yaml.Node, taskIDs map[string]int) *
 					completed++
 				case "Blocked":
 					blocked++
+				case "Skipped":
+					skipped++
 				}
 			}
 		}
@@ -471,6 +502,7 @@ func (v *TasksValidator) buildSummary(root *yaml.Node, taskIDs map[string]int) *
 	summary.Counts["in_progress"] = inProgress
 	summary.Counts["completed"] = completed
 	summary.Counts["blocked"] = blocked
+	summary.Counts["skipped"] = skipped
 	summary.Counts["blocked_without_reason"] = countBlockedWithoutReason(phasesNode)
 
 	return summary
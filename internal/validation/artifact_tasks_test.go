@@ -286,6 +286,29 @@ func TestTasksValidator_InvalidNotesTooLong(t *testing.T) {
 	}
 }
 
+func TestTasksValidator_InvalidVerificationStatus(t *testing.T) {
+	validator := &TasksValidator{}
+	result := validator.Validate(filepath.Join("testdata", "tasks", "invalid_verification_status.yaml"))
+
+	if result.Valid {
+		t.Error("expected validation to fail for invalid verification status")
+	}
+
+	found := false
+	for _, err := range result.Errors {
+		if strings.Contains(err.Path, "verification.status") {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("expected error about verification.status")
+		for _, err := range result.Errors {
+			t.Logf("  - %s", err.Error())
+		}
+	}
+}
+
 func TestTasksValidator_NonexistentFile(t *testing.T) {
 	validator := &TasksValidator{}
 	result := validator.Validate(filepath.Join("testdata", "tasks", "nonexistent.yaml"))
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
 	"gopkg.in/yaml.v3"
 )
 
@@ -87,7 +88,7 @@ func FixArtifact(path string, artifactType ArtifactType) (*AutoFixResult, error)
 			return nil, fmt.Errorf("failed to serialize YAML: %w", err)
 		}
 
-		if err := os.WriteFile(path, output, 0644); err != nil {
+		if err := atomicfile.WriteFile(path, output, 0644); err != nil {
 			return nil, fmt.Errorf("failed to write file: %w", err)
 		}
 
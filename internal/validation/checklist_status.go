@@ -0,0 +1,96 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ChecklistStatus summarizes the item statuses of one checklist file.
+type ChecklistStatus struct {
+	Path    string // Path to the checklist file
+	Domain  string // checklist.domain, e.g. "security"
+	Total   int
+	Passed  int
+	Failed  int
+	Pending int
+}
+
+// Percent returns the share of items that passed, from 0 to 100.
+// An empty checklist counts as complete.
+func (s ChecklistStatus) Percent() int {
+	if s.Total == 0 {
+		return 100
+	}
+	return s.Passed * 100 / s.Total
+}
+
+// ChecklistFiles returns the checklist files in specDir/checklists, sorted by name.
+// A missing checklists directory yields no files.
+func ChecklistFiles(specDir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(specDir, "checklists", "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("listing checklists: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// GetChecklistStatuses reads every checklist in specDir/checklists and counts
+// its items by status.
+func GetChecklistStatuses(specDir string) ([]ChecklistStatus, error) {
+	files, err := ChecklistFiles(specDir)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]ChecklistStatus, 0, len(files))
+	for _, path := range files {
+		status, err := readChecklistStatus(path)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// readChecklistStatus counts the items of a single checklist file.
+func readChecklistStatus(path string) (ChecklistStatus, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ChecklistStatus{}, fmt.Errorf("reading checklist: %w", err)
+	}
+	var checklist struct {
+		Checklist struct {
+			Domain string `yaml:"domain"`
+		} `yaml:"checklist"`
+		Categories []struct {
+			Items []struct {
+				Status string `yaml:"status"`
+			} `yaml:"items"`
+		} `yaml:"categories"`
+	}
+	if err := yaml.Unmarshal(data, &checklist); err != nil {
+		return ChecklistStatus{}, fmt.Errorf("parsing checklist %s: %w", path, err)
+	}
+
+	status := ChecklistStatus{Path: path, Domain: checklist.Checklist.Domain}
+	for _, category := range checklist.Categories {
+		for _, item := range category.Items {
+			status.Total++
+			switch item.Status {
+			case "pass":
+				status.Passed++
+			case "fail":
+				status.Failed++
+			default:
+				status.Pending++
+			}
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,107 @@
+// Package validation_test tests checklist completion counting.
+// Related: internal/validation/checklist_status.go
+// Tags: validation, checklist, status, completion
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChecklistStatuses(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		files   map[string]string // checklists/<name> -> content; nil means no checklists dir
+		want    []ChecklistStatus
+		wantErr bool
+	}{
+		"no checklists directory": {
+			want: []ChecklistStatus{},
+		},
+		"counts items by status across categories": {
+			files: map[string]string{
+				"security.yaml": `checklist:
+  domain: "security"
+categories:
+  - name: "A"
+    items:
+      - {id: "CHK001", status: "pass"}
+      - {id: "CHK002", status: "fail"}
+  - name: "B"
+    items:
+      - {id: "CHK003", status: "pending"}
+      - {id: "CHK004", status: "pass"}
+`,
+			},
+			want: []ChecklistStatus{
+				{Path: "security.yaml", Domain: "security", Total: 4, Passed: 2, Failed: 1, Pending: 1},
+			},
+		},
+		"sorted by file name": {
+			files: map[string]string{
+				"ux.yaml":  "checklist: {domain: ux}\ncategories: []\n",
+				"api.yaml": "checklist: {domain: api}\ncategories: []\n",
+			},
+			want: []ChecklistStatus{
+				{Path: "api.yaml", Domain: "api"},
+				{Path: "ux.yaml", Domain: "ux"},
+			},
+		},
+		"invalid yaml": {
+			files:   map[string]string{"bad.yaml": "categories: [unclosed\n"},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specDir := t.TempDir()
+			if tt.files != nil {
+				dir := filepath.Join(specDir, "checklists")
+				require.NoError(t, os.MkdirAll(dir, 0o755))
+				for file, content := range tt.files {
+					require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0o644))
+				}
+			}
+
+			got, err := GetChecklistStatuses(specDir)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for i := range got {
+				got[i].Path = filepath.Base(got[i].Path)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChecklistStatus_Percent(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status ChecklistStatus
+		want   int
+	}{
+		"empty checklist is complete": {status: ChecklistStatus{}, want: 100},
+		"none passed":                 {status: ChecklistStatus{Total: 4, Pending: 4}, want: 0},
+		"partially passed":            {status: ChecklistStatus{Total: 3, Passed: 2, Failed: 1}, want: 66},
+		"all passed":                  {status: ChecklistStatus{Total: 5, Passed: 5}, want: 100},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.status.Percent())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package validation
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 )
 
@@ -50,7 +51,7 @@ func GenerateContinuationPrompt(specDir string, phase string, phases []Phase) st
 	builder.WriteString(ListIncompletePhasesWithTasks(phases))
 
 	builder.WriteString(fmt.Sprintf("\nPlease continue working on the implementation for %s.\n", specDir))
-	builder.WriteString("Review the tasks.md file and complete the remaining tasks.\n")
+	builder.WriteString(fmt.Sprintf("Review %s and complete the remaining tasks.\n", filepath.Base(GetTasksFilePath(specDir))))
 
 	return builder.String()
 }
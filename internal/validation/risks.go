@@ -11,10 +11,10 @@ import (
 
 // RiskStats contains summary statistics about risks in a plan.
 type RiskStats struct {
-	Total  int // Total number of risks
-	High   int // Risks with impact "high"
-	Medium int // Risks with impact "medium"
-	Low    int // Risks with impact "low"
+	Total  int `json:"total"`  // Total number of risks
+	High   int `json:"high"`   // Risks with impact "high"
+	Medium int `json:"medium"` // Risks with impact "medium"
+	Low    int `json:"low"`    // Risks with impact "low"
 }
 
 // planRisksYAML represents the partial structure of a plan.yaml file for risk parsing.
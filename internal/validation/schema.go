@@ -102,7 +102,7 @@ var SpecSchema = Schema{
 		},
 		{
 			Name:        "success_criteria",
-			Type:        FieldTypeArray,
+			Type:        FieldTypeObject,
 			Required:    false,
 			Description: "Measurable success criteria",
 		},
@@ -130,6 +130,12 @@ var SpecSchema = Schema{
 			Required:    false,
 			Description: "Items explicitly excluded from scope",
 		},
+		{
+			Name:        "depends_on",
+			Type:        FieldTypeArray,
+			Required:    false,
+			Description: "Specs (e.g. 002-auth) whose tasks must be completed before this spec is implemented",
+		},
 		{
 			Name:        "_meta",
 			Type:        FieldTypeObject,
@@ -141,6 +147,8 @@ var SpecSchema = Schema{
 				{Name: "generator_version", Type: FieldTypeString, Required: false, Description: "Generator version"},
 				{Name: "created", Type: FieldTypeString, Required: false, Description: "Creation timestamp"},
 				{Name: "artifact_type", Type: FieldTypeString, Required: false, Enum: []string{"spec"}, Description: "Artifact type"},
+				{Name: "source_issue", Type: FieldTypeString, Required: false, Description: "URL of the GitHub issue the spec was created from (specify --from-issue)"},
+				{Name: "imported_from", Type: FieldTypeString, Required: false, Description: "Markdown document the spec was imported from (autospec import)"},
 			},
 		},
 	},
@@ -304,7 +312,7 @@ var TasksSchema = Schema{
 				{Name: "purpose", Type: FieldTypeString, Required: false, Description: "Phase purpose"},
 				{Name: "story_reference", Type: FieldTypeString, Required: false, Description: "Related user story ID"},
 				{Name: "independent_test", Type: FieldTypeString, Required: false, Description: "Independent test description"},
-				{Name: "tasks", Type: FieldTypeArray, Required: true, Description: "List of tasks in this phase"},
+				{Name: "tasks", Type: FieldTypeArray, Required: true, Description: "List of tasks in this phase", Children: TaskFieldSchema},
 			},
 		},
 		{
@@ -345,13 +353,24 @@ var TasksSchema = Schema{
 var TaskFieldSchema = []SchemaField{
 	{Name: "id", Type: FieldTypeString, Required: true, Pattern: `^T\d+$`, Description: "Task ID (TNNN format)"},
 	{Name: "title", Type: FieldTypeString, Required: true, Description: "Task title"},
-	{Name: "status", Type: FieldTypeString, Required: true, Enum: []string{"Pending", "InProgress", "Completed", "Blocked"}, Description: "Task status"},
+	{Name: "status", Type: FieldTypeString, Required: true, Enum: []string{"Pending", "InProgress", "Completed", "Blocked", "Skipped"}, Description: "Task status"},
 	{Name: "type", Type: FieldTypeString, Required: true, Enum: []string{"setup", "implementation", "test", "documentation", "refactor"}, Description: "Task type"},
 	{Name: "parallel", Type: FieldTypeBool, Required: false, Description: "Whether task can run in parallel"},
 	{Name: "story_id", Type: FieldTypeString, Required: false, Description: "Related user story ID"},
 	{Name: "file_path", Type: FieldTypeString, Required: false, Description: "Primary file path for this task"},
 	{Name: "dependencies", Type: FieldTypeArray, Required: false, Description: "List of task IDs this task depends on"},
 	{Name: "acceptance_criteria", Type: FieldTypeArray, Required: false, Description: "Acceptance criteria for the task"},
+	{
+		Name:        "verification",
+		Type:        FieldTypeObject,
+		Required:    false,
+		Description: "Result of checking the acceptance criteria against the code (written by autospec verify)",
+		Children: []SchemaField{
+			{Name: "status", Type: FieldTypeString, Required: true, Enum: []string{"Verified", "Failed"}, Description: "Verification result"},
+			{Name: "notes", Type: FieldTypeString, Required: false, Description: "How the criteria were checked"},
+			{Name: "unmet", Type: FieldTypeArray, Required: false, Description: "Acceptance criteria that don't hold"},
+		},
+	},
 }
 
 // AnalysisSchema defines the schema for analysis.yaml artifacts.
@@ -520,6 +539,7 @@ var ChecklistSchema = Schema{
 var ChecklistItemSchema = []SchemaField{
 	{Name: "id", Type: FieldTypeString, Required: true, Description: "Checklist item ID (CHKnnn format)"},
 	{Name: "description", Type: FieldTypeString, Required: true, Description: "Item description (question format)"},
+	{Name: "type", Type: FieldTypeString, Required: false, Enum: ChecklistItemTypes, Description: "Kind of requirement checked"},
 	{Name: "quality_dimension", Type: FieldTypeString, Required: false, Enum: []string{"completeness", "clarity", "consistency", "measurability", "coverage", "edge_cases"}, Description: "Quality dimension being checked"},
 	{Name: "spec_reference", Type: FieldTypeString, Required: false, Description: "Reference to spec requirement"},
 	{Name: "status", Type: FieldTypeString, Required: true, Enum: []string{"pending", "pass", "fail"}, Description: "Item status"},
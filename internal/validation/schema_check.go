@@ -0,0 +1,184 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CheckSchema validates a parsed artifact against a schema definition: required
+// fields, field types, enum values and patterns, recursing into object children
+// and into every item of arrays that declare children. Each error carries the
+// line and column of the offending node (or of the parent for missing fields).
+func CheckSchema(root *yaml.Node, schema *Schema) []*ValidationError {
+	result := &ValidationResult{Valid: true}
+	mapping := getRootMapping(root)
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		result.AddError(&ValidationError{
+			Line:    getNodeLine(root),
+			Message: "expected a YAML mapping at document root",
+		})
+		return result.Errors
+	}
+	checkFields(mapping, schema.Fields, "", result)
+	return result.Errors
+}
+
+// addSchemaErrors runs CheckSchema and adds the errors not already reported by
+// an artifact validator's own checks, so each problem is listed once.
+func addSchemaErrors(root *yaml.Node, schema *Schema, result *ValidationResult) {
+	for _, err := range CheckSchema(root, schema) {
+		if !alreadyReported(result.Errors, err) {
+			result.AddError(err)
+		}
+	}
+}
+
+// alreadyReported reports whether errs contains an error on the same line for
+// the same field. Artifact validators sometimes use the bare field name as the
+// path (e.g. "branch" for "feature.branch"), so suffix matches count.
+func alreadyReported(errs []*ValidationError, candidate *ValidationError) bool {
+	for _, existing := range errs {
+		if existing.Line != candidate.Line {
+			continue
+		}
+		if existing.Path == candidate.Path || strings.HasSuffix(candidate.Path, "."+existing.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkFields validates the fields of a mapping node.
+func checkFields(node *yaml.Node, fields []SchemaField, prefix string, result *ValidationResult) {
+	for _, field := range fields {
+		path := joinSchemaPath(prefix, field.Name)
+		value := findNode(node, field.Name)
+		if value == nil {
+			if field.Required {
+				result.AddError(&ValidationError{
+					Path:    path,
+					Line:    getNodeLine(node),
+					Column:  getNodeColumn(node),
+					Message: fmt.Sprintf("missing required field: %s", field.Name),
+					Hint:    fmt.Sprintf("Add the '%s' field (%s)", path, field.Description),
+				})
+			}
+			continue
+		}
+		checkField(value, field, path, result)
+	}
+}
+
+// checkField validates a single field value and recurses into its children.
+func checkField(node *yaml.Node, field SchemaField, path string, result *ValidationResult) {
+	if !checkFieldType(node, field.Type, path, result) {
+		return
+	}
+	switch field.Type {
+	case FieldTypeString:
+		if len(field.Enum) > 0 {
+			validateEnumValue(node, path, field.Enum, result)
+		} else if field.Pattern != "" {
+			checkPattern(node, field.Pattern, path, result)
+		}
+	case FieldTypeObject:
+		if len(field.Children) > 0 {
+			checkFields(node, field.Children, path, result)
+		}
+	case FieldTypeArray:
+		if len(field.Children) > 0 {
+			checkItems(node, field.Children, path, result)
+		}
+	}
+}
+
+// checkItems validates every item of an array whose items are objects.
+func checkItems(node *yaml.Node, children []SchemaField, path string, result *ValidationResult) {
+	for i, item := range node.Content {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		if item.Kind != yaml.MappingNode {
+			result.AddError(&ValidationError{
+				Path:     itemPath,
+				Line:     getNodeLine(item),
+				Column:   getNodeColumn(item),
+				Message:  fmt.Sprintf("wrong type for '%s'", itemPath),
+				Expected: "object",
+				Actual:   nodeKindToString(item.Kind),
+			})
+			continue
+		}
+		checkFields(item, children, itemPath, result)
+	}
+}
+
+// checkFieldType checks that a node matches the schema field type. Strings
+// accept any scalar, since YAML resolves unquoted dates and numbers to other tags.
+func checkFieldType(node *yaml.Node, fieldType FieldType, path string, result *ValidationResult) bool {
+	switch fieldType {
+	case FieldTypeObject:
+		return validateFieldType(node, path, yaml.MappingNode, "object", result)
+	case FieldTypeArray:
+		return validateFieldType(node, path, yaml.SequenceNode, "array", result)
+	case FieldTypeString:
+		return validateFieldType(node, path, yaml.ScalarNode, "string", result)
+	case FieldTypeInt:
+		return checkScalarTag(node, "!!int", "integer", path, result)
+	case FieldTypeBool:
+		return checkScalarTag(node, "!!bool", "boolean", path, result)
+	}
+	return true
+}
+
+// checkScalarTag checks that a node is a scalar resolved to the given YAML tag.
+func checkScalarTag(node *yaml.Node, tag, typeName, path string, result *ValidationResult) bool {
+	if !validateFieldType(node, path, yaml.ScalarNode, typeName, result) {
+		return false
+	}
+	if node.Tag == tag {
+		return true
+	}
+	result.AddError(&ValidationError{
+		Path:     path,
+		Line:     getNodeLine(node),
+		Column:   getNodeColumn(node),
+		Message:  fmt.Sprintf("wrong type for field '%s'", path),
+		Expected: typeName,
+		Actual:   fmt.Sprintf("'%s'", node.Value),
+		Hint:     fmt.Sprintf("Change '%s' to be a %s", path, typeName),
+	})
+	return false
+}
+
+// schemaPatterns caches compiled SchemaField patterns.
+var schemaPatterns sync.Map // pattern -> *regexp.Regexp
+
+// checkPattern checks that a scalar matches a SchemaField pattern.
+func checkPattern(node *yaml.Node, pattern, path string, result *ValidationResult) {
+	cached, ok := schemaPatterns.Load(pattern)
+	if !ok {
+		cached, _ = schemaPatterns.LoadOrStore(pattern, regexp.MustCompile(pattern))
+	}
+	if cached.(*regexp.Regexp).MatchString(node.Value) {
+		return
+	}
+	result.AddError(&ValidationError{
+		Path:     path,
+		Line:     getNodeLine(node),
+		Column:   getNodeColumn(node),
+		Message:  fmt.Sprintf("invalid format for field '%s'", path),
+		Expected: fmt.Sprintf("value matching %s", pattern),
+		Actual:   fmt.Sprintf("'%s'", node.Value),
+	})
+}
+
+// joinSchemaPath appends a field name to a JSON-path style prefix.
+func joinSchemaPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
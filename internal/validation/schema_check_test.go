@@ -0,0 +1,203 @@
+package validation
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeArtifact writes content to name in a temp directory and returns its path.
+func writeArtifact(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+// testSchema exercises every rule CheckSchema applies.
+var testSchema = Schema{
+	Fields: []SchemaField{
+		{Name: "meta", Type: FieldTypeObject, Required: true, Children: []SchemaField{
+			{Name: "name", Type: FieldTypeString, Required: true},
+			{Name: "state", Type: FieldTypeString, Enum: []string{"open", "closed"}},
+		}},
+		{Name: "items", Type: FieldTypeArray, Children: []SchemaField{
+			{Name: "id", Type: FieldTypeString, Required: true, Pattern: `^I-\d+$`},
+			{Name: "count", Type: FieldTypeInt},
+			{Name: "done", Type: FieldTypeBool},
+		}},
+	},
+}
+
+func TestCheckSchema(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		yaml     string
+		wantPath string
+		wantLine int
+		wantCol  int
+		wantMsg  string
+	}{
+		"valid document": {
+			yaml: "meta:\n  name: x\n  state: open\nitems:\n  - id: I-1\n    count: 3\n    done: true\n",
+		},
+		"missing top-level field reports document start": {
+			yaml:     "items: []\n",
+			wantPath: "meta", wantLine: 1, wantCol: 1,
+			wantMsg: "missing required field: meta",
+		},
+		"missing nested field reports parent mapping": {
+			yaml:     "meta:\n  state: open\n",
+			wantPath: "meta.name", wantLine: 2, wantCol: 3,
+			wantMsg: "missing required field: name",
+		},
+		"wrong object type": {
+			yaml:     "meta: [a]\n",
+			wantPath: "meta", wantLine: 1, wantCol: 7,
+			wantMsg: "wrong type for field 'meta'",
+		},
+		"invalid enum": {
+			yaml:     "meta:\n  name: x\n  state: pending\n",
+			wantPath: "meta.state", wantLine: 3, wantCol: 10,
+			wantMsg: "invalid value for field 'meta.state'",
+		},
+		"pattern mismatch in array item": {
+			yaml:     "meta:\n  name: x\nitems:\n  - id: I-1\n  - id: bad\n",
+			wantPath: "items[1].id", wantLine: 5, wantCol: 9,
+			wantMsg: "invalid format for field 'items[1].id'",
+		},
+		"non-integer value": {
+			yaml:     "meta:\n  name: x\nitems:\n  - id: I-1\n    count: many\n",
+			wantPath: "items[0].count", wantLine: 5, wantCol: 12,
+			wantMsg: "wrong type for field 'items[0].count'",
+		},
+		"quoted boolean is not a boolean": {
+			yaml:     "meta:\n  name: x\nitems:\n  - id: I-1\n    done: \"yes\"\n",
+			wantPath: "items[0].done", wantLine: 5, wantCol: 11,
+			wantMsg: "wrong type for field 'items[0].done'",
+		},
+		"scalar array item": {
+			yaml:     "meta:\n  name: x\nitems:\n  - I-1\n",
+			wantPath: "items[0]", wantLine: 4, wantCol: 5,
+			wantMsg: "wrong type for 'items[0]'",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			root, err := parseYAMLReader(strings.NewReader(tt.yaml))
+			require.NoError(t, err)
+
+			errs := CheckSchema(root, &testSchema)
+			if tt.wantMsg == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1, "errors: %v", errs)
+			assert.Equal(t, tt.wantPath, errs[0].Path)
+			assert.Equal(t, tt.wantLine, errs[0].Line)
+			assert.Equal(t, tt.wantCol, errs[0].Column)
+			assert.Equal(t, tt.wantMsg, errs[0].Message)
+		})
+	}
+}
+
+func TestCheckSchema_ArtifactTemplates(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		path   string
+		schema *Schema
+	}{
+		"spec":  {path: "testdata/spec/valid.yaml", schema: &SpecSchema},
+		"plan":  {path: "testdata/plan/valid.yaml", schema: &PlanSchema},
+		"tasks": {path: "testdata/tasks/valid.yaml", schema: &TasksSchema},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			root, err := parseYAMLFile(tt.path)
+			require.NoError(t, err)
+			assert.Empty(t, CheckSchema(root, tt.schema))
+		})
+	}
+}
+
+func TestTasksValidator_ReportsNestedSchemaErrors(t *testing.T) {
+	t.Parallel()
+
+	path := writeArtifact(t, "tasks.yaml", `tasks:
+  branch: "001-x"
+summary:
+  total_tasks: 1
+phases:
+  - number: 1
+    title: "Setup"
+    tasks:
+      - id: "task-1"
+        title: "Init"
+        status: "Pending"
+        type: "setup"
+        parallel: "maybe"
+`)
+	result := (&TasksValidator{}).Validate(path)
+
+	require.False(t, result.Valid)
+	var messages []string
+	for _, err := range result.Errors {
+		messages = append(messages, err.Error())
+	}
+	assert.Contains(t, messages, "line 9:13: phases[0].tasks[0].id: invalid format for field 'phases[0].tasks[0].id'")
+	assert.Contains(t, messages, "line 13:19: phases[0].tasks[0].parallel: wrong type for field 'phases[0].tasks[0].parallel'")
+}
+
+func TestAddSchemaErrors_SkipsDuplicates(t *testing.T) {
+	t.Parallel()
+
+	root, err := parseYAMLReader(strings.NewReader("meta:\n  state: open\n"))
+	require.NoError(t, err)
+	result := &ValidationResult{Valid: true}
+	// Artifact validators report nested missing fields with the bare name
+	validateRequiredField(findNode(root, "meta"), "name", result)
+
+	addSchemaErrors(root, &testSchema, result)
+
+	assert.Len(t, result.Errors, 1)
+}
+
+func TestNewParseError(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		err      error
+		wantLine int
+		wantMsg  string
+	}{
+		"syntax error with line": {
+			err:      errors.New("yaml: line 12: did not find expected key"),
+			wantLine: 12,
+			wantMsg:  "failed to parse YAML: did not find expected key",
+		},
+		"error without location": {
+			err:     errors.New("file is empty or contains only comments"),
+			wantMsg: "failed to parse YAML: file is empty or contains only comments",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got := newParseError("spec.yaml", tt.err)
+			assert.Equal(t, tt.wantLine, got.Line)
+			assert.Equal(t, tt.wantMsg, got.Message)
+		})
+	}
+}
@@ -210,7 +210,7 @@ func TestTasksSchema_StatusEnums(t *testing.T) {
 		t.Fatal("status field not found in task schema")
 	}
 
-	expectedEnums := []string{"Pending", "InProgress", "Completed", "Blocked"}
+	expectedEnums := []string{"Pending", "InProgress", "Completed", "Blocked", "Skipped"}
 	if len(statusField.Enum) != len(expectedEnums) {
 		t.Errorf("status enum has %d values, want %d", len(statusField.Enum), len(expectedEnums))
 	}
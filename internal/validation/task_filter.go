@@ -0,0 +1,149 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxTaskRange bounds how many IDs a range like T001-T999 expands to.
+const maxTaskRange = 1000
+
+// taskRangePattern matches a task ID range such as "T012-T015".
+var taskRangePattern = regexp.MustCompile(`^([A-Za-z]*)(\d+)-([A-Za-z]*)(\d+)$`)
+
+// taskFilterKeys are the task fields a filter term can select on.
+var taskFilterKeys = map[string]func(TaskItem) string{
+	"id":     func(t TaskItem) string { return t.ID },
+	"type":   func(t TaskItem) string { return t.Type },
+	"status": func(t TaskItem) string { return t.Status },
+	"story":  func(t TaskItem) string { return t.StoryID },
+}
+
+// TaskFilter selects a subset of tasks, as given to implement --only.
+// A filter is a space-separated list of key=value terms, e.g. "type=test
+// status=pending"; a task must match every term. A term's value may list
+// several comma-separated alternatives, and id values may be ranges
+// ("id=T010,T012-T015"). A term without a key is an id term.
+type TaskFilter struct {
+	expr  string
+	terms []taskFilterTerm
+}
+
+// taskFilterTerm matches tasks whose field, normalized, is one of values.
+type taskFilterTerm struct {
+	key    string
+	values map[string]bool
+}
+
+// ParseTaskFilter parses a filter expression.
+func ParseTaskFilter(expr string) (*TaskFilter, error) {
+	filter := &TaskFilter{expr: strings.TrimSpace(expr)}
+	for _, field := range strings.Fields(expr) {
+		key, value, found := strings.Cut(field, "=")
+		if !found {
+			key, value = "id", field
+		}
+		key = strings.ToLower(key)
+		if _, ok := taskFilterKeys[key]; !ok {
+			return nil, fmt.Errorf("unknown task filter key %q (valid keys: %s)", key, strings.Join(TaskFilterKeys(), ", "))
+		}
+
+		term := taskFilterTerm{key: key, values: make(map[string]bool)}
+		for _, v := range strings.Split(value, ",") {
+			if v == "" {
+				continue
+			}
+			if key != "id" {
+				term.values[normalizeFilterValue(v)] = true
+				continue
+			}
+			ids, err := expandTaskIDs(v)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range ids {
+				term.values[normalizeFilterValue(id)] = true
+			}
+		}
+		if len(term.values) == 0 {
+			return nil, fmt.Errorf("task filter %q has no value", field)
+		}
+		filter.terms = append(filter.terms, term)
+	}
+	if len(filter.terms) == 0 {
+		return nil, fmt.Errorf("empty task filter")
+	}
+	return filter, nil
+}
+
+// TaskFilterKeys returns the keys a filter term can use, sorted.
+func TaskFilterKeys() []string {
+	keys := make([]string, 0, len(taskFilterKeys))
+	for key := range taskFilterKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Match reports whether task matches every term of the filter.
+func (f *TaskFilter) Match(task TaskItem) bool {
+	for _, term := range f.terms {
+		if !term.values[normalizeFilterValue(taskFilterKeys[term.key](task))] {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the tasks that match, in their original order.
+func (f *TaskFilter) Filter(tasks []TaskItem) []TaskItem {
+	var matched []TaskItem
+	for _, task := range tasks {
+		if f.Match(task) {
+			matched = append(matched, task)
+		}
+	}
+	return matched
+}
+
+// String returns the filter expression as given.
+func (f *TaskFilter) String() string {
+	return f.expr
+}
+
+// expandTaskIDs expands a task ID or ID range ("T012-T015") to IDs. Range
+// bounds must share a prefix; IDs keep the zero padding of the lower bound.
+func expandTaskIDs(value string) ([]string, error) {
+	m := taskRangePattern.FindStringSubmatch(value)
+	if m == nil {
+		return []string{value}, nil
+	}
+	prefix, from, to := m[1], m[2], m[4]
+	if !strings.EqualFold(prefix, m[3]) {
+		return nil, fmt.Errorf("task range %q: bounds have different prefixes", value)
+	}
+	start, _ := strconv.Atoi(from)
+	end, _ := strconv.Atoi(to)
+	if start > end {
+		return nil, fmt.Errorf("task range %q: start is after end", value)
+	}
+	if end-start >= maxTaskRange {
+		return nil, fmt.Errorf("task range %q: more than %d tasks", value, maxTaskRange)
+	}
+
+	ids := make([]string, 0, end-start+1)
+	for n := start; n <= end; n++ {
+		ids = append(ids, fmt.Sprintf("%s%0*d", prefix, len(from), n))
+	}
+	return ids, nil
+}
+
+// normalizeFilterValue makes matching ignore case and the separators used
+// in statuses, so "in-progress" matches InProgress.
+func normalizeFilterValue(value string) string {
+	return strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(value))
+}
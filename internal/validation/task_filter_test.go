@@ -0,0 +1,108 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskFilter(t *testing.T) {
+	t.Parallel()
+
+	tasks := []TaskItem{
+		{ID: "T009", Type: "implementation", Status: "Completed", StoryID: "US1"},
+		{ID: "T010", Type: "test", Status: "Pending", StoryID: "US1"},
+		{ID: "T011", Type: "implementation", Status: "InProgress", StoryID: "US2"},
+		{ID: "T012", Type: "test", Status: "Pending", StoryID: "US2"},
+		{ID: "T013", Type: "implementation", Status: "Pending"},
+		{ID: "T015", Type: "test", Status: "Completed"},
+	}
+
+	tests := map[string]struct {
+		expr    string
+		wantIDs []string
+	}{
+		"bare id list and range": {
+			expr:    "T010,T012-T015",
+			wantIDs: []string{"T010", "T012", "T013", "T015"},
+		},
+		"id key": {
+			expr:    "id=t011",
+			wantIDs: []string{"T011"},
+		},
+		"type": {
+			expr:    "type=test",
+			wantIDs: []string{"T010", "T012", "T015"},
+		},
+		"status ignores case and separators": {
+			expr:    "status=in-progress",
+			wantIDs: []string{"T011"},
+		},
+		"alternatives": {
+			expr:    "story=US1,us2",
+			wantIDs: []string{"T009", "T010", "T011", "T012"},
+		},
+		"terms are combined": {
+			expr:    "type=test status=pending",
+			wantIDs: []string{"T010", "T012"},
+		},
+		"range combined with type": {
+			expr:    "T009-T012 type=implementation",
+			wantIDs: []string{"T009", "T011"},
+		},
+		"no match": {
+			expr: "type=docs",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			filter, err := ParseTaskFilter(tt.expr)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, task := range filter.Filter(tasks) {
+				ids = append(ids, task.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.expr, filter.String())
+		})
+	}
+}
+
+func TestParseTaskFilter_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		expr    string
+		wantErr string
+	}{
+		"empty":             {expr: "  ", wantErr: "empty task filter"},
+		"unknown key":       {expr: "owner=me", wantErr: `unknown task filter key "owner" (valid keys: id, status, story, type)`},
+		"no value":          {expr: "type=", wantErr: `task filter "type=" has no value`},
+		"reversed range":    {expr: "T015-T012", wantErr: "start is after end"},
+		"mixed prefixes":    {expr: "T001-X003", wantErr: "different prefixes"},
+		"oversized range":   {expr: "T1-T5000", wantErr: "more than 1000 tasks"},
+		"bad term in range": {expr: "id=T001-T003 bogus=1", wantErr: `unknown task filter key "bogus"`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseTaskFilter(tt.expr)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestExpandTaskIDs_KeepsPadding(t *testing.T) {
+	t.Parallel()
+
+	ids, err := expandTaskIDs("T008-T011")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"T008", "T009", "T010", "T011"}, ids)
+}
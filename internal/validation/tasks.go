@@ -27,6 +27,7 @@ type Task struct {
 
 // Phase represents a section in tasks.md (identified by ## heading)
 type Phase struct {
+	Number       int // Phase number from tasks.yaml, or 1-based position in tasks.md
 	Name         string
 	Tasks        []Task
 	LineNumber   int
@@ -0,0 +1,301 @@
+package validation
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/build"
+	"gopkg.in/yaml.v3"
+)
+
+// Task details that don't fit a checkbox line are written as indented
+// "Key: value" bullets below the task, so they survive a round trip:
+//
+//	tasks.md:
+//	- [ ] T003 [P] [US1] Add login handler
+//	  - Status: Blocked
+//	  - Blocked: waiting for API keys
+//	  - Depends on: T001, T002
+//	  - File: internal/auth/login.go
+//	  - Acceptance: Returns 401 for bad credentials
+var (
+	// mdPhasePattern matches "## Phase 2: Title"; the number is optional
+	mdPhasePattern = regexp.MustCompile(`^##\s+(?:Phase\s+(\d+)\s*[:.\-–—]?\s*)?(.*)$`)
+	// mdTaskPattern matches a top-level checkbox item
+	mdTaskPattern = regexp.MustCompile(`^[-*]\s+\[([ xX])\]\s+(.+)$`)
+	// mdDetailPattern matches an indented bullet below a task
+	mdDetailPattern = regexp.MustCompile(`^\s+[-*]\s+(?:\[[ xX]\]\s+)?(.+)$`)
+	// mdTaskIDPattern matches the task ID at the start of a checkbox item
+	mdTaskIDPattern = regexp.MustCompile(`^(T\d+)[:.]?\s+`)
+	// mdMarkerPattern matches a leading [P] or [US1] marker
+	mdMarkerPattern = regexp.MustCompile(`^\[([^\]]+)\]\s*`)
+)
+
+// TasksMarkdownToYAML converts a tasks.md checkbox list into tasks.yaml.
+// "## Phase N: Title" headings become phases and checkbox items become
+// tasks, keeping their IDs, [P] and [USn] markers and completion state.
+// Items without an ID get the next free one. branch is recorded in the
+// tasks header.
+func TasksMarkdownToYAML(md []byte, branch string) ([]byte, error) {
+	phases := parseTasksMarkdown(string(md))
+	if len(phases) == 0 {
+		return nil, fmt.Errorf("no tasks found: expected checkbox items like '- [ ] T001 Title'")
+	}
+	assignMissingTaskIDs(phases)
+
+	doc := TasksYAML{
+		Meta: TasksMeta{
+			Version:          "1.0.0",
+			Generator:        "autospec",
+			GeneratorVersion: build.Version,
+			Created:          time.Now().Format(time.RFC3339),
+			ArtifactType:     "tasks",
+		},
+		Tasks: TasksInfo{
+			Branch:   branch,
+			Created:  time.Now().Format("2006-01-02"),
+			SpecPath: "spec.yaml",
+			PlanPath: "plan.yaml",
+		},
+		Phases: phases,
+	}
+	doc.Summary.TotalPhases = len(phases)
+	for _, phase := range phases {
+		doc.Summary.TotalTasks += len(phase.Tasks)
+		for _, task := range phase.Tasks {
+			if task.Parallel {
+				doc.Summary.ParallelOpportunities++
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("serializing tasks.yaml: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("serializing tasks.yaml: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// parseTasksMarkdown collects phases and tasks from a tasks.md. Tasks before
+// the first phase heading go into a phase of their own; headings without
+// tasks are dropped.
+func parseTasksMarkdown(text string) []TaskPhase {
+	var phases []TaskPhase
+	var phase *TaskPhase
+	var task *TaskItem
+	inCode := false
+
+	flush := func() {
+		if phase != nil && len(phase.Tasks) > 0 {
+			phases = append(phases, *phase)
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+
+		if m := mdPhasePattern.FindStringSubmatch(line); m != nil {
+			flush()
+			number := len(phases) + 1
+			if n, err := strconv.Atoi(m[1]); err == nil {
+				number = n
+			}
+			phase = &TaskPhase{Number: number, Title: strings.TrimSpace(m[2])}
+			task = nil
+			continue
+		}
+		if m := mdTaskPattern.FindStringSubmatch(line); m != nil {
+			if phase == nil {
+				phase = &TaskPhase{Number: 1, Title: "Tasks"}
+			}
+			phase.Tasks = append(phase.Tasks, parseTaskLine(m[1], m[2]))
+			task = &phase.Tasks[len(phase.Tasks)-1]
+			continue
+		}
+		if m := mdDetailPattern.FindStringSubmatch(line); m != nil && task != nil {
+			applyTaskDetail(task, m[1])
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		// Text between a heading and its first task describes the phase
+		if phase != nil && len(phase.Tasks) == 0 && !strings.HasPrefix(line, "#") {
+			purpose := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "**Purpose**:"))
+			phase.Purpose = strings.TrimSpace(phase.Purpose + " " + purpose)
+		}
+		task = nil
+	}
+	flush()
+	return phases
+}
+
+// parseTaskLine builds a task from a checkbox item.
+func parseTaskLine(checkbox, text string) TaskItem {
+	task := TaskItem{Status: "Pending", Type: "implementation", Dependencies: []string{}, AcceptanceCriteria: []string{}}
+	if checkbox == "x" || checkbox == "X" {
+		task.Status = "Completed"
+	}
+	if m := mdTaskIDPattern.FindStringSubmatch(text); m != nil {
+		task.ID = m[1]
+		text = text[len(m[0]):]
+	}
+	for {
+		m := mdMarkerPattern.FindStringSubmatch(text)
+		if m == nil {
+			break
+		}
+		switch marker := m[1]; {
+		case marker == "P":
+			task.Parallel = true
+		case strings.HasPrefix(marker, "US"):
+			task.StoryID = marker
+		default:
+			text = strings.TrimSpace(text)
+			task.Title = text
+			return task
+		}
+		text = text[len(m[0]):]
+	}
+	task.Title = strings.TrimSpace(text)
+	return task
+}
+
+// applyTaskDetail applies an indented "Key: value" bullet to task. Bullets
+// without a known key are acceptance criteria.
+func applyTaskDetail(task *TaskItem, detail string) {
+	key, value, found := strings.Cut(detail, ":")
+	value = strings.TrimSpace(value)
+	if found {
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "status":
+			if value != "" {
+				task.Status = value
+			}
+			return
+		case "blocked", "blocked reason":
+			task.BlockedReason = value
+			return
+		case "depends on", "dependencies":
+			for _, dep := range strings.Split(value, ",") {
+				if dep = strings.TrimSpace(dep); dep != "" {
+					task.Dependencies = append(task.Dependencies, dep)
+				}
+			}
+			return
+		case "type":
+			task.Type = value
+			return
+		case "file":
+			task.FilePath = value
+			return
+		case "notes":
+			task.Notes = value
+			return
+		case "acceptance":
+			task.AcceptanceCriteria = append(task.AcceptanceCriteria, value)
+			return
+		}
+	}
+	task.AcceptanceCriteria = append(task.AcceptanceCriteria, strings.TrimSpace(detail))
+}
+
+// assignMissingTaskIDs numbers tasks without an ID after the highest one used.
+func assignMissingTaskIDs(phases []TaskPhase) {
+	next := 1
+	for _, phase := range phases {
+		for _, task := range phase.Tasks {
+			if n, err := strconv.Atoi(strings.TrimPrefix(task.ID, "T")); err == nil && n >= next {
+				next = n + 1
+			}
+		}
+	}
+	for i := range phases {
+		for j := range phases[i].Tasks {
+			if phases[i].Tasks[j].ID == "" {
+				phases[i].Tasks[j].ID = fmt.Sprintf("T%03d", next)
+				next++
+			}
+		}
+	}
+}
+
+// TasksYAMLToMarkdown renders tasks.yaml as a tasks.md checkbox list that
+// TasksMarkdownToYAML reads back: one "## Phase N: Title" section per phase
+// and one checkbox item per task, checked when the task is completed.
+func TasksYAMLToMarkdown(data []byte) ([]byte, error) {
+	var doc TasksYAML
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing tasks.yaml: %w", err)
+	}
+
+	var b strings.Builder
+	title := "Tasks"
+	if doc.Tasks.Branch != "" {
+		title += ": " + doc.Tasks.Branch
+	}
+	fmt.Fprintf(&b, "# %s\n", title)
+	for _, phase := range doc.Phases {
+		fmt.Fprintf(&b, "\n## Phase %d: %s\n\n", phase.Number, phase.Title)
+		if phase.Purpose != "" {
+			fmt.Fprintf(&b, "**Purpose**: %s\n\n", phase.Purpose)
+		}
+		for _, task := range phase.Tasks {
+			writeTaskMarkdown(&b, task)
+		}
+	}
+	return []byte(b.String()), nil
+}
+
+// writeTaskMarkdown writes a task's checkbox item and detail bullets.
+func writeTaskMarkdown(b *strings.Builder, task TaskItem) {
+	checkbox := " "
+	if task.IsCompleted() {
+		checkbox = "x"
+	}
+	line := fmt.Sprintf("- [%s] %s", checkbox, task.ID)
+	if task.Parallel {
+		line += " [P]"
+	}
+	if task.StoryID != "" {
+		line += " [" + task.StoryID + "]"
+	}
+	fmt.Fprintf(b, "%s %s\n", line, task.Title)
+
+	if task.Status != "Pending" && !task.IsCompleted() {
+		fmt.Fprintf(b, "  - Status: %s\n", task.Status)
+	}
+	if task.BlockedReason != "" {
+		fmt.Fprintf(b, "  - Blocked: %s\n", task.BlockedReason)
+	}
+	if task.Type != "" && task.Type != "implementation" {
+		fmt.Fprintf(b, "  - Type: %s\n", task.Type)
+	}
+	if len(task.Dependencies) > 0 {
+		fmt.Fprintf(b, "  - Depends on: %s\n", strings.Join(task.Dependencies, ", "))
+	}
+	if task.FilePath != "" {
+		fmt.Fprintf(b, "  - File: %s\n", task.FilePath)
+	}
+	for _, criterion := range task.AcceptanceCriteria {
+		fmt.Fprintf(b, "  - Acceptance: %s\n", criterion)
+	}
+	if task.Notes != "" {
+		fmt.Fprintf(b, "  - Notes: %s\n", strings.ReplaceAll(task.Notes, "\n", " "))
+	}
+}
@@ -0,0 +1,196 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const specKitTasksMD = `# Tasks: 003-auth
+
+**Input**: Design documents from /specs/003-auth/
+
+## Phase 1: Setup
+
+**Purpose**: Project initialization
+
+- [x] T001 Create project structure
+- [ ] T002 [P] Configure linting in .golangci.yml
+
+## Phase 2: User Story 1 - Login (Priority: P1)
+
+### Tests for User Story 1
+
+- [ ] T003 [P] [US1] Contract test for POST /login
+- [ ] T004 [US1] Implement login handler
+  - Status: Blocked
+  - Blocked: waiting for API keys
+  - Depends on: T001, T003
+  - Returns 401 for bad credentials
+
+## Dependencies & Execution Order
+
+Setup blocks everything.
+`
+
+func TestTasksMarkdownToYAML(t *testing.T) {
+	t.Parallel()
+
+	out, err := TasksMarkdownToYAML([]byte(specKitTasksMD), "003-auth")
+	require.NoError(t, err)
+
+	var doc TasksYAML
+	require.NoError(t, yaml.Unmarshal(out, &doc))
+	assert.Equal(t, "003-auth", doc.Tasks.Branch)
+	assert.Equal(t, 4, doc.Summary.TotalTasks)
+	assert.Equal(t, 2, doc.Summary.TotalPhases)
+	assert.Equal(t, 2, doc.Summary.ParallelOpportunities)
+	require.Len(t, doc.Phases, 2)
+
+	setup := doc.Phases[0]
+	assert.Equal(t, 1, setup.Number)
+	assert.Equal(t, "Setup", setup.Title)
+	assert.Equal(t, "Project initialization", setup.Purpose)
+	require.Len(t, setup.Tasks, 2)
+	assert.Equal(t, "T001", setup.Tasks[0].ID)
+	assert.Equal(t, "Completed", setup.Tasks[0].Status)
+	assert.Equal(t, "Configure linting in .golangci.yml", setup.Tasks[1].Title)
+	assert.True(t, setup.Tasks[1].Parallel)
+
+	login := doc.Phases[1]
+	assert.Equal(t, "User Story 1 - Login (Priority: P1)", login.Title)
+	require.Len(t, login.Tasks, 2)
+	assert.Equal(t, "US1", login.Tasks[0].StoryID)
+	blocked := login.Tasks[1]
+	assert.Equal(t, "Blocked", blocked.Status)
+	assert.Equal(t, "waiting for API keys", blocked.BlockedReason)
+	assert.Equal(t, []string{"T001", "T003"}, blocked.Dependencies)
+	assert.Equal(t, []string{"Returns 401 for bad credentials"}, blocked.AcceptanceCriteria)
+
+	// The result passes tasks.yaml validation
+	path := filepath.Join(t.TempDir(), "tasks.yaml")
+	require.NoError(t, os.WriteFile(path, out, 0o644))
+	result := (&TasksValidator{}).Validate(path)
+	assert.True(t, result.Valid, "validation errors: %v", result.Errors)
+}
+
+func TestTasksMarkdownToYAML_Edges(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		md      string
+		wantIDs []string
+		wantErr bool
+	}{
+		"missing IDs continue after highest": {
+			md:      "## Phase 1: Setup\n- [ ] T005 First\n- [ ] Second\n- [x] Third\n",
+			wantIDs: []string{"T005", "T006", "T007"},
+		},
+		"tasks without a phase heading": {
+			md:      "- [ ] T001 Only task\n",
+			wantIDs: []string{"T001"},
+		},
+		"checkboxes in code blocks are ignored": {
+			md:      "## Phase 1: Setup\n```\n- [ ] T009 Example\n```\n- [ ] T001 Real\n",
+			wantIDs: []string{"T001"},
+		},
+		"no tasks": {
+			md:      "# Tasks\n\nNothing yet.\n",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			out, err := TasksMarkdownToYAML([]byte(tt.md), "001-x")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var doc TasksYAML
+			require.NoError(t, yaml.Unmarshal(out, &doc))
+			var ids []string
+			for _, phase := range doc.Phases {
+				for _, task := range phase.Tasks {
+					ids = append(ids, task.ID)
+				}
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestTasksYAMLToMarkdown_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original, err := os.ReadFile(filepath.Join("testdata", "tasks", "blocked_with_reason.yaml"))
+	require.NoError(t, err)
+
+	md, err := TasksYAMLToMarkdown(original)
+	require.NoError(t, err)
+	converted, err := TasksMarkdownToYAML(md, "")
+	require.NoError(t, err)
+
+	var want, got TasksYAML
+	require.NoError(t, yaml.Unmarshal(original, &want))
+	require.NoError(t, yaml.Unmarshal(converted, &got))
+	require.Len(t, got.Phases, len(want.Phases))
+	for i, phase := range want.Phases {
+		assert.Equal(t, phase.Number, got.Phases[i].Number)
+		assert.Equal(t, phase.Title, got.Phases[i].Title)
+		require.Len(t, got.Phases[i].Tasks, len(phase.Tasks))
+		for j, task := range phase.Tasks {
+			gotTask := got.Phases[i].Tasks[j]
+			assert.Equal(t, task.ID, gotTask.ID)
+			assert.Equal(t, task.Title, gotTask.Title)
+			assert.Equal(t, task.Status, gotTask.Status)
+			assert.Equal(t, task.Type, gotTask.Type)
+			assert.Equal(t, task.Parallel, gotTask.Parallel)
+			assert.Equal(t, task.BlockedReason, gotTask.BlockedReason)
+			assert.ElementsMatch(t, task.Dependencies, gotTask.Dependencies)
+			assert.Equal(t, task.AcceptanceCriteria, gotTask.AcceptanceCriteria)
+		}
+	}
+}
+
+func TestTasksYAMLToMarkdown(t *testing.T) {
+	t.Parallel()
+
+	md, err := TasksYAMLToMarkdown([]byte(`tasks:
+  branch: 003-auth
+phases:
+  - number: 1
+    title: Setup
+    purpose: Scaffolding
+    tasks:
+      - id: T001
+        title: Create module
+        status: Completed
+        type: setup
+      - id: T002
+        title: Add parser
+        status: InProgress
+        type: implementation
+        parallel: true
+        story_id: US1
+        dependencies: [T001]
+`))
+	require.NoError(t, err)
+	assert.Equal(t, `# Tasks: 003-auth
+
+## Phase 1: Setup
+
+**Purpose**: Scaffolding
+
+- [x] T001 Create module
+  - Type: setup
+- [ ] T002 [P] [US1] Add parser
+  - Status: InProgress
+  - Depends on: T001
+`, string(md))
+}
@@ -0,0 +1,79 @@
+package validation
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+	"github.com/ariel-frischer/autospec/internal/filelock"
+	"gopkg.in/yaml.v3"
+)
+
+// SetTaskStatus sets the status of a task in tasks.yaml. The file is edited
+// as a node tree, so comments, key order and quoting are kept. The file is
+// locked against other autospec processes while it is updated.
+func SetTaskStatus(tasksPath, taskID, status string) error {
+	unlock, err := filelock.Lock(tasksPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	data, err := os.ReadFile(tasksPath)
+	if err != nil {
+		return fmt.Errorf("reading tasks.yaml: %w", err)
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("parsing tasks.yaml: %w", err)
+	}
+
+	statusNode := findTaskStatusNode(&root, taskID)
+	if statusNode == nil {
+		return fmt.Errorf("task %s not found in %s", taskID, tasksPath)
+	}
+	if statusNode.Value == status {
+		return nil
+	}
+	statusNode.Value = status
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&root); err != nil {
+		return fmt.Errorf("serializing tasks.yaml: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("serializing tasks.yaml: %w", err)
+	}
+	if err := atomicfile.WriteFile(tasksPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("writing tasks.yaml: %w", err)
+	}
+	return nil
+}
+
+// findTaskStatusNode returns the status value node of the task mapping with
+// the given ID, or nil if there is none.
+func findTaskStatusNode(node *yaml.Node, taskID string) *yaml.Node {
+	if node.Kind == yaml.MappingNode {
+		var id, status *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			switch node.Content[i].Value {
+			case "id":
+				id = node.Content[i+1]
+			case "status":
+				status = node.Content[i+1]
+			}
+		}
+		if id != nil && status != nil && id.Value == taskID {
+			return status
+		}
+	}
+	for _, child := range node.Content {
+		if found := findTaskStatusNode(child, taskID); found != nil {
+			return found
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetTaskStatus(t *testing.T) {
+	t.Parallel()
+
+	const content = `# Generated tasks
+phases:
+  - number: 1
+    title: "Setup"
+    tasks:
+      - id: "T001"
+        title: "Create module"
+        status: "Completed" # done by hand
+      - id: "T002"
+        title: "Add handler"
+        status: "Pending"
+`
+
+	tests := map[string]struct {
+		taskID  string
+		status  string
+		want    string
+		wantErr string
+	}{
+		"flips status and keeps comments": {
+			taskID: "T001",
+			status: "InProgress",
+			want:   `status: "InProgress" # done by hand`,
+		},
+		"unchanged status": {
+			taskID: "T002",
+			status: "Pending",
+			want:   `status: "Pending"`,
+		},
+		"unknown task": {
+			taskID:  "T009",
+			status:  "InProgress",
+			wantErr: "task T009 not found",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "tasks.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+			err := SetTaskStatus(path, tt.taskID, tt.status)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Contains(t, string(data), "# Generated tasks")
+			assert.Contains(t, string(data), tt.want)
+		})
+	}
+}
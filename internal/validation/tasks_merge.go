@@ -0,0 +1,120 @@
+package validation
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MergeTasksPhase replaces phase number of the original tasks.yaml with the
+// same phase from regenerated, keeping every other phase exactly as it was in
+// original, including task statuses. The regenerated phase must not reuse task
+// IDs of the other phases. summary.total_tasks is updated when present.
+func MergeTasksPhase(original, regenerated []byte, number int) ([]byte, error) {
+	var root, other yaml.Node
+	if err := yaml.Unmarshal(original, &root); err != nil {
+		return nil, fmt.Errorf("parsing original tasks.yaml: %w", err)
+	}
+	if err := yaml.Unmarshal(regenerated, &other); err != nil {
+		return nil, fmt.Errorf("parsing regenerated tasks.yaml: %w", err)
+	}
+
+	phases := findNode(&root, "phases")
+	index := phaseIndex(phases, number)
+	if index < 0 {
+		return nil, fmt.Errorf("phase %d not found in tasks.yaml", number)
+	}
+	newPhases := findNode(&other, "phases")
+	newIndex := phaseIndex(newPhases, number)
+	if newIndex < 0 {
+		return nil, fmt.Errorf("regenerated tasks.yaml has no phase %d", number)
+	}
+	phase := newPhases.Content[newIndex]
+
+	// Task IDs must stay unique across phases
+	otherIDs := make(map[string]bool)
+	for i, p := range phases.Content {
+		if i != index {
+			for _, id := range phaseTaskIDs(p) {
+				otherIDs[id] = true
+			}
+		}
+	}
+	var reused []string
+	for _, id := range phaseTaskIDs(phase) {
+		if otherIDs[id] && !slices.Contains(reused, id) {
+			reused = append(reused, id)
+		}
+	}
+	if len(reused) > 0 {
+		return nil, fmt.Errorf("regenerated phase %d reuses task IDs of other phases: %s", number, strings.Join(reused, ", "))
+	}
+
+	old := phases.Content[index]
+	phase.HeadComment, phase.LineComment, phase.FootComment = old.HeadComment, old.LineComment, old.FootComment
+	phases.Content[index] = phase
+
+	if summary := findNode(&root, "summary"); summary != nil {
+		if total := findNode(summary, "total_tasks"); total != nil {
+			count := 0
+			for _, p := range phases.Content {
+				count += len(phaseTaskIDs(p))
+			}
+			total.Value = strconv.Itoa(count)
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&root); err != nil {
+		return nil, fmt.Errorf("serializing tasks.yaml: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("serializing tasks.yaml: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// HasTasksPhase reports whether tasks.yaml content has a phase with the
+// given number.
+func HasTasksPhase(data []byte, number int) bool {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return false
+	}
+	return phaseIndex(findNode(&root, "phases"), number) >= 0
+}
+
+// phaseIndex returns the position of the phase with the given number in a
+// phases sequence, or -1.
+func phaseIndex(phases *yaml.Node, number int) int {
+	if phases == nil || phases.Kind != yaml.SequenceNode {
+		return -1
+	}
+	for i, p := range phases.Content {
+		if n := findNode(p, "number"); n != nil && n.Value == strconv.Itoa(number) {
+			return i
+		}
+	}
+	return -1
+}
+
+// phaseTaskIDs returns the IDs of a phase's tasks in order.
+func phaseTaskIDs(phase *yaml.Node) []string {
+	tasks := findNode(phase, "tasks")
+	if tasks == nil || tasks.Kind != yaml.SequenceNode {
+		return nil
+	}
+	ids := make([]string, 0, len(tasks.Content))
+	for _, task := range tasks.Content {
+		if id := findNode(task, "id"); id != nil {
+			ids = append(ids, id.Value)
+		}
+	}
+	return ids
+}
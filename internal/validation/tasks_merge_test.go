@@ -0,0 +1,125 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const mergeOriginalTasks = `summary:
+  total_tasks: 3
+phases:
+  # Setup comes first
+  - number: 1
+    title: Setup
+    tasks:
+      - id: T001
+        title: Init module
+        status: Completed
+  - number: 2
+    title: Core
+    tasks:
+      - id: T002
+        title: Add parser
+        status: Pending
+      - id: T003
+        title: Add printer
+        status: Pending
+`
+
+func TestMergeTasksPhase(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		regenerated string
+		phase       int
+		wantIDs     map[int][]string
+		wantTotal   int
+		wantErr     string
+	}{
+		"replaces only the phase": {
+			regenerated: `phases:
+  - number: 1
+    title: Setup
+    tasks:
+      - id: T001
+        title: Init module again
+        status: Pending
+  - number: 2
+    title: Core
+    tasks:
+      - id: T002
+        title: Add lexer
+        status: Pending
+      - id: T004
+        title: Add parser
+        status: Pending
+      - id: T005
+        title: Add printer
+        status: Pending
+`,
+			phase:     2,
+			wantIDs:   map[int][]string{1: {"T001"}, 2: {"T002", "T004", "T005"}},
+			wantTotal: 4,
+		},
+		"phase missing from original": {
+			regenerated: mergeOriginalTasks,
+			phase:       3,
+			wantErr:     "phase 3 not found",
+		},
+		"phase missing from regenerated": {
+			regenerated: "phases:\n  - number: 1\n    tasks: []\n",
+			phase:       2,
+			wantErr:     "regenerated tasks.yaml has no phase 2",
+		},
+		"reused ID of another phase": {
+			regenerated: `phases:
+  - number: 2
+    tasks:
+      - id: T001
+        title: Add parser
+        status: Pending
+`,
+			phase:   2,
+			wantErr: "reuses task IDs of other phases: T001",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			merged, err := MergeTasksPhase([]byte(mergeOriginalTasks), []byte(tt.regenerated), tt.phase)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var doc TasksYAML
+			require.NoError(t, yaml.Unmarshal(merged, &doc))
+			assert.Equal(t, tt.wantTotal, doc.Summary.TotalTasks)
+			for _, phase := range doc.Phases {
+				var ids []string
+				for _, task := range phase.Tasks {
+					ids = append(ids, task.ID)
+				}
+				assert.Equal(t, tt.wantIDs[phase.Number], ids, "phase %d", phase.Number)
+			}
+			// The kept phase is unchanged, including status and comments
+			assert.Equal(t, "Init module", doc.Phases[0].Tasks[0].Title)
+			assert.Equal(t, "Completed", doc.Phases[0].Tasks[0].Status)
+			assert.Contains(t, string(merged), "# Setup comes first")
+		})
+	}
+}
+
+func TestHasTasksPhase(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, HasTasksPhase([]byte(mergeOriginalTasks), 2))
+	assert.False(t, HasTasksPhase([]byte(mergeOriginalTasks), 3))
+	assert.False(t, HasTasksPhase([]byte("{not yaml"), 1))
+}
@@ -0,0 +1,80 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Verification statuses recorded in tasks.yaml by the verify stage.
+const (
+	VerificationVerified = "Verified"
+	VerificationFailed   = "Failed"
+)
+
+// VerificationSummary groups the completed tasks that have acceptance
+// criteria by the result of the verify stage. Task IDs are in file order.
+type VerificationSummary struct {
+	Verified   []string            // All criteria hold
+	Failed     []string            // At least one criterion doesn't hold
+	Unverified []string            // Not checked yet
+	Unmet      map[string][]string // Unmet criteria of failed tasks by task ID
+}
+
+// IsVerified returns true if every completed task with acceptance criteria
+// was verified.
+func (s *VerificationSummary) IsVerified() bool {
+	return len(s.Failed) == 0 && len(s.Unverified) == 0
+}
+
+// String describes the failed and unverified tasks, e.g.
+// "1 failed (T004), 2 unverified (T002, T005)".
+func (s *VerificationSummary) String() string {
+	var parts []string
+	if len(s.Failed) > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed (%s)", len(s.Failed), strings.Join(s.Failed, ", ")))
+	}
+	if len(s.Unverified) > 0 {
+		parts = append(parts, fmt.Sprintf("%d unverified (%s)", len(s.Unverified), strings.Join(s.Unverified, ", ")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// GetVerificationSummary reads tasks.yaml and summarizes the verification of
+// its completed tasks. Tasks that are not completed or have no acceptance
+// criteria are left out.
+func GetVerificationSummary(tasksPath string) (*VerificationSummary, error) {
+	if !strings.HasSuffix(tasksPath, ".yaml") && !strings.HasSuffix(tasksPath, ".yml") {
+		return nil, fmt.Errorf("verification requires tasks.yaml, got %s", tasksPath)
+	}
+	tasks, err := ParseTasksYAML(tasksPath)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &VerificationSummary{Unmet: make(map[string][]string)}
+	for _, phase := range tasks.Phases {
+		for _, task := range phase.Tasks {
+			if len(task.AcceptanceCriteria) == 0 {
+				continue
+			}
+			switch strings.ToLower(task.Status) {
+			case "completed", "done", "complete":
+			default:
+				continue
+			}
+
+			switch {
+			case task.Verification == nil:
+				summary.Unverified = append(summary.Unverified, task.ID)
+			case task.Verification.Status == VerificationVerified:
+				summary.Verified = append(summary.Verified, task.ID)
+			case task.Verification.Status == VerificationFailed:
+				summary.Failed = append(summary.Failed, task.ID)
+				summary.Unmet[task.ID] = task.Verification.Unmet
+			default:
+				summary.Unverified = append(summary.Unverified, task.ID)
+			}
+		}
+	}
+	return summary, nil
+}
@@ -0,0 +1,100 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const verifyTasks = `phases:
+  - number: 1
+    title: Core
+    tasks:
+      - id: T001
+        title: Add parser
+        status: Completed
+        acceptance_criteria: ["Parses YAML"]
+        verification:
+          status: Verified
+      - id: T002
+        title: Add printer
+        status: Completed
+        acceptance_criteria: ["Prints YAML", "Keeps comments"]
+        verification:
+          status: Failed
+          unmet: ["Keeps comments"]
+      - id: T003
+        title: Add linter
+        status: Completed
+        acceptance_criteria: ["Reports errors"]
+      - id: T004
+        title: Add formatter
+        status: Pending
+        acceptance_criteria: ["Formats YAML"]
+      - id: T005
+        title: Write README
+        status: Completed
+`
+
+func TestGetVerificationSummary(t *testing.T) {
+	t.Parallel()
+
+	tasksPath := filepath.Join(t.TempDir(), "tasks.yaml")
+	require.NoError(t, os.WriteFile(tasksPath, []byte(verifyTasks), 0644))
+
+	summary, err := GetVerificationSummary(tasksPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"T001"}, summary.Verified)
+	assert.Equal(t, []string{"T002"}, summary.Failed)
+	assert.Equal(t, []string{"T003"}, summary.Unverified)
+	assert.Equal(t, []string{"Keeps comments"}, summary.Unmet["T002"])
+	assert.False(t, summary.IsVerified())
+	assert.Equal(t, "1 failed (T002), 1 unverified (T003)", summary.String())
+}
+
+func TestGetVerificationSummary_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		fileName string
+		content  string
+		wantErr  string
+	}{
+		"markdown tasks": {
+			fileName: "tasks.md",
+			content:  "- [x] T001 Add parser\n",
+			wantErr:  "requires tasks.yaml",
+		},
+		"missing file": {
+			fileName: "tasks.yaml",
+			wantErr:  "failed to read tasks file",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tasksPath := filepath.Join(t.TempDir(), tt.fileName)
+			if tt.content != "" {
+				require.NoError(t, os.WriteFile(tasksPath, []byte(tt.content), 0644))
+			}
+
+			_, err := GetVerificationSummary(tasksPath)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestVerificationSummary_IsVerified(t *testing.T) {
+	t.Parallel()
+
+	summary := &VerificationSummary{Verified: []string{"T001", "T002"}}
+	assert.True(t, summary.IsVerified())
+	assert.Empty(t, summary.String())
+}
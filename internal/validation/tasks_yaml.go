@@ -62,28 +62,43 @@ type TaskItem struct {
 	Dependencies       []string `yaml:"dependencies"`
 	AcceptanceCriteria []string `yaml:"acceptance_criteria"`
 	BlockedReason      string   `yaml:"blocked_reason,omitempty"`
+	BlockedAt          string   `yaml:"blocked_at,omitempty"`   // RFC 3339 time the task was blocked
+	BlockedFrom        string   `yaml:"blocked_from,omitempty"` // Status before it was blocked
+	SkipReason         string   `yaml:"skip_reason,omitempty"`
 	Notes              string   `yaml:"notes,omitempty"`
+	// Verification is the result of the verify stage, if it checked this task
+	Verification *TaskVerification `yaml:"verification,omitempty"`
+}
+
+// TaskVerification records whether a task's acceptance criteria were found
+// to hold in the code
+type TaskVerification struct {
+	Status string   `yaml:"status"`          // Verified or Failed
+	Notes  string   `yaml:"notes,omitempty"` // How the criteria were checked
+	Unmet  []string `yaml:"unmet,omitempty"` // Criteria that don't hold (Failed only)
 }
 
 // TaskStats contains computed statistics about task completion
 type TaskStats struct {
-	TotalTasks      int
-	CompletedTasks  int
-	InProgressTasks int
-	PendingTasks    int
-	BlockedTasks    int
-	TotalPhases     int
-	CompletedPhases int
-	PhaseStats      []PhaseStats
+	TotalTasks      int          `json:"total_tasks"`
+	CompletedTasks  int          `json:"completed_tasks"`
+	InProgressTasks int          `json:"in_progress_tasks"`
+	PendingTasks    int          `json:"pending_tasks"`
+	BlockedTasks    int          `json:"blocked_tasks"`
+	SkippedTasks    int          `json:"skipped_tasks"`
+	TotalPhases     int          `json:"total_phases"`
+	CompletedPhases int          `json:"completed_phases"`
+	PhaseStats      []PhaseStats `json:"phases"`
 }
 
 // PhaseStats contains statistics for a single phase
 type PhaseStats struct {
-	Number         int
-	Title          string
-	TotalTasks     int
-	CompletedTasks int
-	IsComplete     bool
+	Number         int    `json:"number"`
+	Title          string `json:"title"`
+	TotalTasks     int    `json:"total_tasks"`
+	CompletedTasks int    `json:"completed_tasks"`
+	SkippedTasks   int    `json:"skipped_tasks"`
+	IsComplete     bool   `json:"is_complete"`
 }
 
 // CompletionPercentage returns the completion percentage
@@ -94,9 +109,9 @@ func (s *TaskStats) CompletionPercentage() float64 {
 	return float64(s.CompletedTasks) / float64(s.TotalTasks) * 100.0
 }
 
-// IsComplete returns true if all tasks are completed
+// IsComplete returns true if all tasks are completed or skipped
 func (s *TaskStats) IsComplete() bool {
-	return s.TotalTasks > 0 && s.CompletedTasks == s.TotalTasks
+	return s.TotalTasks > 0 && s.CompletedTasks+s.SkippedTasks == s.TotalTasks
 }
 
 // ParseTasksYAML parses a tasks.yaml file and returns the structure
@@ -155,13 +170,16 @@ func GetTaskStats(tasksPath string) (*TaskStats, error) {
 				stats.InProgressTasks++
 			case "blocked":
 				stats.BlockedTasks++
+			case "skipped":
+				stats.SkippedTasks++
+				phaseStat.SkippedTasks++
 			default:
 				// Pending or unknown status
 				stats.PendingTasks++
 			}
 		}
 
-		phaseStat.IsComplete = phaseStat.TotalTasks > 0 && phaseStat.CompletedTasks == phaseStat.TotalTasks
+		phaseStat.IsComplete = phaseStat.TotalTasks > 0 && phaseStat.CompletedTasks+phaseStat.SkippedTasks == phaseStat.TotalTasks
 		if phaseStat.IsComplete {
 			stats.CompletedPhases++
 		}
@@ -207,6 +225,62 @@ func getTaskStatsFromMarkdown(tasksPath string) (*TaskStats, error) {
 	return stats, nil
 }
 
+// LoadTaskPhases reads tasks.yaml or tasks.md into phases for continuation
+// prompts. YAML tasks count as checked when completed or skipped; blocked
+// tasks stay unchecked since they still prevent the stage from finishing.
+func LoadTaskPhases(tasksPath string) ([]Phase, error) {
+	if !strings.HasSuffix(tasksPath, ".yaml") && !strings.HasSuffix(tasksPath, ".yml") {
+		phases, err := ParseTasksByPhase(tasksPath)
+		for i := range phases {
+			phases[i].Number = i + 1
+		}
+		return phases, err
+	}
+
+	tasks, err := ParseTasksYAML(tasksPath)
+	if err != nil {
+		return nil, err
+	}
+	phases := make([]Phase, 0, len(tasks.Phases))
+	for _, tp := range tasks.Phases {
+		phase := Phase{Number: tp.Number, Name: fmt.Sprintf("Phase %d: %s", tp.Number, tp.Title)}
+		for _, item := range tp.Tasks {
+			checked := isCompletedStatus(item.Status) || item.IsSkipped()
+			phase.Tasks = append(phase.Tasks, Task{
+				Description: fmt.Sprintf("%s: %s", item.ID, item.Title),
+				Checked:     checked,
+				PhaseName:   phase.Name,
+			})
+			phase.TotalTasks++
+			if checked {
+				phase.CheckedTasks++
+			}
+		}
+		phases = append(phases, phase)
+	}
+	return phases, nil
+}
+
+// IsCompleted reports whether the task's status counts as completed.
+func (t TaskItem) IsCompleted() bool {
+	return isCompletedStatus(t.Status)
+}
+
+// isCompletedStatus reports whether a tasks.yaml status counts as completed.
+func isCompletedStatus(status string) bool {
+	switch strings.ToLower(status) {
+	case "completed", "done", "complete":
+		return true
+	}
+	return false
+}
+
+// IsSkipped reports whether the task was deliberately deferred (task skip).
+// Skipped tasks don't keep a spec from completing.
+func (t TaskItem) IsSkipped() bool {
+	return strings.EqualFold(t.Status, "Skipped")
+}
+
 // PhaseInfo contains detailed information about a phase's status for execution decisions
 type PhaseInfo struct {
 	Number          int    // Phase number (1-based)
@@ -214,10 +288,11 @@ type PhaseInfo struct {
 	TotalTasks      int    // Total tasks in this phase
 	CompletedTasks  int    // Tasks with Completed status
 	BlockedTasks    int    // Tasks with Blocked status
+	SkippedTasks    int    // Tasks with Skipped status
 	ActionableTasks int    // Tasks with Pending or InProgress status
 }
 
-// IsComplete returns true when all tasks are Completed or Blocked (no actionable tasks remain)
+// IsComplete returns true when all tasks are Completed, Blocked or Skipped (no actionable tasks remain)
 func (p *PhaseInfo) IsComplete() bool {
 	return p.ActionableTasks == 0
 }
@@ -245,6 +320,8 @@ func GetPhaseInfo(tasksPath string) ([]PhaseInfo, error) {
 				info.CompletedTasks++
 			case "blocked":
 				info.BlockedTasks++
+			case "skipped":
+				info.SkippedTasks++
 			default:
 				// Pending, InProgress, or unknown = actionable
 				info.ActionableTasks++
@@ -257,8 +334,8 @@ func GetPhaseInfo(tasksPath string) ([]PhaseInfo, error) {
 	return phases, nil
 }
 
-// IsPhaseComplete checks if a specific phase is complete (all tasks Completed or Blocked)
-// Returns true when all tasks are Completed or Blocked, false otherwise
+// IsPhaseComplete checks if a specific phase is complete (all tasks Completed, Blocked or Skipped)
+// Returns true when all tasks are Completed, Blocked or Skipped, false otherwise
 // Returns true for empty phases
 func IsPhaseComplete(tasksPath string, phaseNumber int) (bool, error) {
 	phases, err := GetPhaseInfo(tasksPath)
@@ -424,7 +501,7 @@ func GetTasksInDependencyOrder(tasks []TaskItem) ([]TaskItem, error) {
 }
 
 // ValidateTaskDependenciesMet checks if all dependencies of a task are completed
-// Returns true if all dependencies have Completed status, false otherwise
+// Returns true if all dependencies have Completed (or Skipped) status, false otherwise
 // Also returns a list of unmet dependency IDs for logging/error messages
 func ValidateTaskDependenciesMet(task TaskItem, tasks []TaskItem) (bool, []string) {
 	if len(task.Dependencies) == 0 {
@@ -446,9 +523,8 @@ func ValidateTaskDependenciesMet(task TaskItem, tasks []TaskItem) (bool, []strin
 			continue
 		}
 
-		// Check if dependency is completed (case-insensitive)
-		statusLower := strings.ToLower(status)
-		if statusLower != "completed" && statusLower != "done" && statusLower != "complete" {
+		// Check if dependency is completed or skipped (case-insensitive)
+		if !isCompletedStatus(status) && !strings.EqualFold(status, "Skipped") {
 			unmetDeps = append(unmetDeps, depID)
 		}
 	}
@@ -470,8 +546,8 @@ func FormatTaskSummary(stats *TaskStats) string {
 	// Phase completion line
 	sb.WriteString(fmt.Sprintf("  %d/%d task phases completed\n", stats.CompletedPhases, stats.TotalPhases))
 
-	// Show in-progress/blocked if any
-	if stats.InProgressTasks > 0 || stats.BlockedTasks > 0 {
+	// Show in-progress/blocked/skipped if any
+	if stats.InProgressTasks > 0 || stats.BlockedTasks > 0 || stats.SkippedTasks > 0 {
 		parts := []string{}
 		if stats.InProgressTasks > 0 {
 			parts = append(parts, fmt.Sprintf("%d in progress", stats.InProgressTasks))
@@ -479,6 +555,9 @@ func FormatTaskSummary(stats *TaskStats) string {
 		if stats.BlockedTasks > 0 {
 			parts = append(parts, fmt.Sprintf("%d blocked", stats.BlockedTasks))
 		}
+		if stats.SkippedTasks > 0 {
+			parts = append(parts, fmt.Sprintf("%d skipped", stats.SkippedTasks))
+		}
 		sb.WriteString(fmt.Sprintf("  (%s)\n", strings.Join(parts, ", ")))
 	}
 
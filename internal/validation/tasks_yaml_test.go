@@ -136,6 +136,7 @@ func TestGetTaskStats_YAML(t *testing.T) {
 		wantInProgress   int
 		wantPending      int
 		wantBlocked      int
+		wantSkipped      int
 		wantPhases       int
 		wantCompletedPhs int
 	}{
@@ -224,6 +225,29 @@ func TestGetTaskStats_YAML(t *testing.T) {
 			wantPhases:       1,
 			wantCompletedPhs: 0,
 		},
+		"skipped tasks complete a phase": {
+			content: `phases:
+  - number: 1
+    title: Phase 1
+    tasks:
+      - id: T001
+        status: Completed
+      - id: T002
+        status: Skipped
+        skip_reason: Deferred
+  - number: 2
+    title: Phase 2
+    tasks:
+      - id: T003
+        status: Pending
+`,
+			wantTotal:        3,
+			wantCompleted:    1,
+			wantPending:      1,
+			wantSkipped:      1,
+			wantPhases:       2,
+			wantCompletedPhs: 1,
+		},
 	}
 
 	for name, tc := range tests {
@@ -243,6 +267,7 @@ func TestGetTaskStats_YAML(t *testing.T) {
 			assert.Equal(t, tc.wantInProgress, stats.InProgressTasks)
 			assert.Equal(t, tc.wantPending, stats.PendingTasks)
 			assert.Equal(t, tc.wantBlocked, stats.BlockedTasks)
+			assert.Equal(t, tc.wantSkipped, stats.SkippedTasks)
 			assert.Equal(t, tc.wantPhases, stats.TotalPhases)
 			assert.Equal(t, tc.wantCompletedPhs, stats.CompletedPhases)
 		})
@@ -333,6 +358,10 @@ func TestTaskStats_IsComplete(t *testing.T) {
 			stats: &TaskStats{TotalTasks: 0, CompletedTasks: 0},
 			want:  false, // Empty is not considered complete
 		},
+		"rest skipped": {
+			stats: &TaskStats{TotalTasks: 5, CompletedTasks: 3, SkippedTasks: 2},
+			want:  true,
+		},
 	}
 
 	for name, tc := range tests {
@@ -436,6 +465,22 @@ func TestFormatTaskSummary(t *testing.T) {
 				"1 blocked",
 			},
 		},
+		"with skipped tasks": {
+			stats: &TaskStats{
+				TotalTasks:      10,
+				CompletedTasks:  9,
+				SkippedTasks:    1,
+				TotalPhases:     2,
+				CompletedPhases: 2,
+			},
+			wantContains: []string{
+				"9/10 tasks completed",
+				"1 skipped",
+			},
+			wantNotContains: []string{
+				"blocked",
+			},
+		},
 		"with both in-progress and blocked": {
 			stats: &TaskStats{
 				TotalTasks:      10,
@@ -584,6 +629,67 @@ func TestTaskItem_Fields(t *testing.T) {
 }
 
 // Tests for GetPhaseInfo function
+func TestLoadTaskPhases(t *testing.T) {
+	tests := map[string]struct {
+		fileName   string
+		content    string
+		wantPhases []Phase
+	}{
+		"yaml counts completed tasks as checked": {
+			fileName: "tasks.yaml",
+			content: `phases:
+  - number: 2
+    title: "Core"
+    tasks:
+      - id: "T003"
+        title: "Add parser"
+        status: "Completed"
+      - id: "T004"
+        title: "Add writer"
+        status: "Blocked"
+`,
+			wantPhases: []Phase{{
+				Number:       2,
+				Name:         "Phase 2: Core",
+				TotalTasks:   2,
+				CheckedTasks: 1,
+				Tasks: []Task{
+					{Description: "T003: Add parser", Checked: true, PhaseName: "Phase 2: Core"},
+					{Description: "T004: Add writer", Checked: false, PhaseName: "Phase 2: Core"},
+				},
+			}},
+		},
+		"markdown phases are numbered by position": {
+			fileName: "tasks.md",
+			content:  "## Setup\n- [x] Init\n## Build\n- [ ] Compile\n",
+			wantPhases: []Phase{
+				{Number: 1, Name: "Setup", TotalTasks: 1, CheckedTasks: 1},
+				{Number: 2, Name: "Build", TotalTasks: 1},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tasksPath := filepath.Join(t.TempDir(), tc.fileName)
+			require.NoError(t, os.WriteFile(tasksPath, []byte(tc.content), 0644))
+
+			phases, err := LoadTaskPhases(tasksPath)
+			require.NoError(t, err)
+			require.Len(t, phases, len(tc.wantPhases))
+			for i, want := range tc.wantPhases {
+				assert.Equal(t, want.Number, phases[i].Number)
+				assert.Equal(t, want.Name, phases[i].Name)
+				assert.Equal(t, want.TotalTasks, phases[i].TotalTasks)
+				assert.Equal(t, want.CheckedTasks, phases[i].CheckedTasks)
+				if want.Tasks != nil {
+					assert.Equal(t, want.Tasks, phases[i].Tasks)
+				}
+			}
+		})
+	}
+}
+
 func TestGetPhaseInfo(t *testing.T) {
 	tests := map[string]struct {
 		content    string
@@ -1601,6 +1707,7 @@ func TestValidateTaskDependenciesMet(t *testing.T) {
 		{ID: "T003", Status: "Pending"},
 		{ID: "T004", Status: "InProgress"},
 		{ID: "T005", Status: "Blocked"},
+		{ID: "T007", Status: "Skipped"},
 	}
 
 	tests := map[string]struct {
@@ -1643,6 +1750,11 @@ func TestValidateTaskDependenciesMet(t *testing.T) {
 			wantMet:   false,
 			wantUnmet: []string{"T005"},
 		},
+		"single skipped dependency - met": {
+			task:      TaskItem{ID: "T006", Dependencies: []string{"T007"}},
+			wantMet:   true,
+			wantUnmet: nil,
+		},
 		"multiple completed dependencies - all met": {
 			task:      TaskItem{ID: "T006", Dependencies: []string{"T001", "T002"}},
 			wantMet:   true,
@@ -5,6 +5,7 @@
 package validation
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,55 +14,62 @@ import (
 	"github.com/ariel-frischer/autospec/internal/yaml"
 )
 
-// ValidateSpecFile checks if spec.md or spec.yaml exists in the given spec directory
-// Performance contract: <10ms
+// ValidateSpecFile checks if spec.md or spec.yaml exists in the given spec directory.
+// spec.yaml is also validated against SpecSchema, reporting errors with line/column.
 func ValidateSpecFile(specDir string) error {
-	// Check for YAML first, then markdown
-	yamlPath := filepath.Join(specDir, "spec.yaml")
-	mdPath := filepath.Join(specDir, "spec.md")
-
-	if _, err := os.Stat(yamlPath); err == nil {
-		return nil // spec.yaml exists
-	}
-	if _, err := os.Stat(mdPath); err == nil {
-		return nil // spec.md exists
-	}
-
-	return fmt.Errorf("spec file not found in %s - run 'autospec specify <description>' to create it", specDir)
+	return validateArtifactFile(specDir, "spec", &SpecValidator{}, "run 'autospec specify <description>' to create it")
 }
 
-// ValidatePlanFile checks if plan.md or plan.yaml exists in the given spec directory
-// Performance contract: <10ms
+// ValidatePlanFile checks if plan.md or plan.yaml exists in the given spec directory.
+// plan.yaml is also validated against PlanSchema, reporting errors with line/column.
 func ValidatePlanFile(specDir string) error {
-	// Check for YAML first, then markdown
-	yamlPath := filepath.Join(specDir, "plan.yaml")
-	mdPath := filepath.Join(specDir, "plan.md")
+	return validateArtifactFile(specDir, "plan", &PlanValidator{}, "run 'autospec plan' to create it")
+}
+
+// ValidateTasksFile checks if tasks.md or tasks.yaml exists in the given spec directory.
+// tasks.yaml is also validated against TasksSchema, reporting errors with line/column.
+func ValidateTasksFile(specDir string) error {
+	return validateArtifactFile(specDir, "tasks", &TasksValidator{}, "run 'autospec tasks' to create it")
+}
 
+// validateArtifactFile validates <name>.yaml if present, falling back to an
+// existence check for legacy <name>.md files.
+func validateArtifactFile(specDir, name string, validator ArtifactValidator, createHint string) error {
+	yamlPath := filepath.Join(specDir, name+".yaml")
 	if _, err := os.Stat(yamlPath); err == nil {
-		return nil // plan.yaml exists
+		return SchemaError(name+".yaml", validator.Validate(yamlPath).Errors)
 	}
-	if _, err := os.Stat(mdPath); err == nil {
-		return nil // plan.md exists
+	if _, err := os.Stat(filepath.Join(specDir, name+".md")); err == nil {
+		return nil // legacy markdown artifact
 	}
-
-	return fmt.Errorf("plan file not found in %s - run 'autospec plan' to create it", specDir)
+	return fmt.Errorf("%s file not found in %s - %s", name, specDir, createHint)
 }
 
-// ValidateTasksFile checks if tasks.md or tasks.yaml exists in the given spec directory
-// Performance contract: <10ms
-func ValidateTasksFile(specDir string) error {
-	// Check for YAML first, then markdown
-	yamlPath := filepath.Join(specDir, "tasks.yaml")
-	mdPath := filepath.Join(specDir, "tasks.md")
-
-	if _, err := os.Stat(yamlPath); err == nil {
-		return nil // tasks.yaml exists
+// SchemaError combines validation errors for an artifact into a single error
+// listing each problem with its location, suitable for retry feedback.
+// Returns nil if there are no errors.
+func SchemaError(artifactName string, errs []*ValidationError) error {
+	if len(errs) == 0 {
+		return nil
 	}
-	if _, err := os.Stat(mdPath); err == nil {
-		return nil // tasks.md exists
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "schema validation failed for %s:\n", artifactName)
+	for _, err := range errs {
+		fmt.Fprintf(&sb, "- %s%s\n", err.Error(), schemaErrorDetail(err))
 	}
+	return errors.New(sb.String())
+}
 
-	return fmt.Errorf("tasks file not found in %s - run 'autospec tasks' to create it", specDir)
+// schemaErrorDetail returns the expected/actual values of an error as a
+// suffix for SchemaError's one-line-per-error format.
+func schemaErrorDetail(err *ValidationError) string {
+	switch {
+	case err.Expected != "" && err.Actual != "":
+		return fmt.Sprintf(" (expected %s, got %s)", err.Expected, err.Actual)
+	case err.Expected != "":
+		return fmt.Sprintf(" (expected %s)", err.Expected)
+	}
+	return ""
 }
 
 // ValidateYAMLFile validates a YAML file's syntax
@@ -6,6 +6,7 @@ package validation
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -135,6 +136,87 @@ func TestValidateTasksFile(t *testing.T) {
 	}
 }
 
+func TestValidateArtifactFiles_YAMLSchema(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		fixture  string
+		filename string
+		validate func(string) error
+		wantErr  string
+	}{
+		"valid spec.yaml": {
+			fixture: "testdata/spec/valid.yaml", filename: "spec.yaml", validate: ValidateSpecFile,
+		},
+		"spec.yaml with invalid priority": {
+			fixture: "testdata/spec/invalid_enum_priority.yaml", filename: "spec.yaml", validate: ValidateSpecFile,
+			wantErr: "invalid value for field 'user_stories[0].priority'",
+		},
+		"valid plan.yaml": {
+			fixture: "testdata/plan/valid.yaml", filename: "plan.yaml", validate: ValidatePlanFile,
+		},
+		"plan.yaml missing summary": {
+			fixture: "testdata/plan/missing_summary.yaml", filename: "plan.yaml", validate: ValidatePlanFile,
+			wantErr: "missing required field: summary",
+		},
+		"valid tasks.yaml": {
+			fixture: "testdata/tasks/valid.yaml", filename: "tasks.yaml", validate: ValidateTasksFile,
+		},
+		"tasks.yaml with invalid status": {
+			fixture: "testdata/tasks/invalid_enum_status.yaml", filename: "tasks.yaml", validate: ValidateTasksFile,
+			wantErr: "schema validation failed for tasks.yaml",
+		},
+		"malformed yaml reports parse line": {
+			fixture: "testdata/common/malformed_indent.yaml", filename: "spec.yaml", validate: ValidateSpecFile,
+			wantErr: "- line ",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			content, err := os.ReadFile(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, tt.filename), content, 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			err = tt.validate(dir)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchemaError(t *testing.T) {
+	t.Parallel()
+
+	if err := SchemaError("spec.yaml", nil); err != nil {
+		t.Errorf("SchemaError(nil) = %v, want nil", err)
+	}
+
+	err := SchemaError("spec.yaml", []*ValidationError{
+		{Path: "feature.status", Line: 4, Column: 11, Message: "invalid value for field 'feature.status'", Expected: "one of: Draft, Review", Actual: "'Done'"},
+		{Path: "user_stories", Line: 1, Message: "missing required field: user_stories"},
+	})
+	want := "schema validation failed for spec.yaml:\n" +
+		"- line 4:11: feature.status: invalid value for field 'feature.status' (expected one of: Draft, Review, got 'Done')\n" +
+		"- line 1: user_stories: missing required field: user_stories\n"
+	if err == nil || err.Error() != want {
+		t.Errorf("SchemaError() = %q, want %q", err, want)
+	}
+}
+
 func TestResult_ShouldRetry(t *testing.T) {
 	tests := map[string]struct {
 		result   *Result
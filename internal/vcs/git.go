@@ -0,0 +1,24 @@
+package vcs
+
+import "github.com/ariel-frischer/autospec/internal/git"
+
+// gitVCS is the git backend, a thin wrapper over package git.
+type gitVCS struct{}
+
+func (gitVCS) Name() string { return Git }
+
+func (gitVCS) InRepository() bool { return git.IsGitRepository() }
+
+func (gitVCS) Root() (string, error) { return git.GetRepositoryRoot() }
+
+func (gitVCS) CurrentBranch() (string, error) { return git.GetCurrentBranch() }
+
+func (gitVCS) BranchNames() ([]string, error) { return git.GetBranchNames() }
+
+func (gitVCS) CreateBranch(name string) error { return git.CreateBranch(name) }
+
+func (gitVCS) RenameBranch(oldName, newName string) error {
+	return git.RenameBranch(oldName, newName)
+}
+
+func (gitVCS) Fetch() (bool, error) { return git.FetchAllRemotes() }
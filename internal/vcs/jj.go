@@ -0,0 +1,125 @@
+package vcs
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// runJJ runs jj with args and returns its stdout. A variable so tests can
+// stand in for the jj binary.
+var runJJ = func(args ...string) ([]byte, error) {
+	cmd := exec.Command("jj", args...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return out, fmt.Errorf("jj %s: %s", args[0], msg)
+		}
+		return out, fmt.Errorf("jj %s: %w", args[0], err)
+	}
+	return out, nil
+}
+
+// currentBookmarkRevset selects the nearest bookmarked commit at or below
+// the working copy. New work usually sits in a change on top of the
+// bookmarked one, so the bookmark is an ancestor of @ rather than on it.
+const currentBookmarkRevset = "heads(::@ & bookmarks())"
+
+// jjVCS is the jujutsu backend. Branches are jj bookmarks.
+type jjVCS struct{}
+
+func (jjVCS) Name() string { return JJ }
+
+func (jjVCS) InRepository() bool {
+	_, err := runJJ("root")
+	return err == nil
+}
+
+func (jjVCS) Root() (string, error) {
+	out, err := runJJ("root")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func (jjVCS) CurrentBranch() (string, error) {
+	out, err := runJJ("log", "--no-graph", "--limit", "1", "-r", currentBookmarkRevset,
+		"-T", `local_bookmarks.map(|b| b.name()).join("\n") ++ "\n"`)
+	if err != nil {
+		return "", err
+	}
+	if names := lines(out); len(names) > 0 {
+		return names[0], nil
+	}
+	return "", fmt.Errorf("no bookmark on the working copy or its ancestors")
+}
+
+func (jjVCS) BranchNames() ([]string, error) {
+	out, err := runJJ("bookmark", "list", "--all-remotes", "-T", `name ++ "\n"`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+	names := lines(out)
+	slices.Sort(names)
+	return slices.Compact(names), nil
+}
+
+func (j jjVCS) CreateBranch(name string) error {
+	names, err := j.BranchNames()
+	if err != nil {
+		return fmt.Errorf("failed to check existing bookmarks: %w", err)
+	}
+	if slices.Contains(names, name) {
+		return fmt.Errorf("bookmark '%s' already exists", name)
+	}
+	if _, err := runJJ("bookmark", "create", name, "-r", "@"); err != nil {
+		return fmt.Errorf("failed to create bookmark '%s': %w", name, err)
+	}
+	return nil
+}
+
+func (j jjVCS) RenameBranch(oldName, newName string) error {
+	names, err := j.BranchNames()
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(names, oldName) {
+		return fmt.Errorf("bookmark '%s' does not exist", oldName)
+	}
+	if slices.Contains(names, newName) {
+		return fmt.Errorf("bookmark '%s' already exists", newName)
+	}
+	if _, err := runJJ("bookmark", "rename", oldName, newName); err != nil {
+		return fmt.Errorf("failed to rename bookmark '%s': %w", oldName, err)
+	}
+	return nil
+}
+
+func (jjVCS) Fetch() (bool, error) {
+	out, err := runJJ("git", "remote", "list")
+	if err != nil || len(lines(out)) == 0 {
+		// No git remotes (or a non-git jj backend) is not an error
+		return true, nil
+	}
+	if _, err := runJJ("git", "fetch", "--all-remotes"); err != nil {
+		fmt.Fprintf(os.Stderr, "[jj] Warning: %v\n", err)
+		return false, nil
+	}
+	return true, nil
+}
+
+// lines splits command output into its non-empty trimmed lines.
+func lines(out []byte) []string {
+	var result []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			result = append(result, line)
+		}
+	}
+	return result
+}
@@ -0,0 +1,114 @@
+package vcs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubJJ replaces runJJ with responses keyed by the joined arguments'
+// prefix, recording every call.
+func stubJJ(t *testing.T, responses map[string]string) *[]string {
+	t.Helper()
+	var calls []string
+	orig := runJJ
+	runJJ = func(args ...string) ([]byte, error) {
+		call := strings.Join(args, " ")
+		calls = append(calls, call)
+		for prefix, out := range responses {
+			if strings.HasPrefix(call, prefix) {
+				if strings.HasPrefix(out, "error: ") {
+					return nil, errors.New(strings.TrimPrefix(out, "error: "))
+				}
+				return []byte(out), nil
+			}
+		}
+		return nil, nil
+	}
+	t.Cleanup(func() { runJJ = orig })
+	return &calls
+}
+
+func TestJJ_CurrentBranch(t *testing.T) {
+	// Not parallel: replaces runJJ
+
+	tests := map[string]struct {
+		out     string
+		want    string
+		wantErr string
+	}{
+		"bookmark":           {out: "003-user-auth\n", want: "003-user-auth"},
+		"several bookmarks":  {out: "003-user-auth\nmain\n", want: "003-user-auth"},
+		"no bookmark":        {out: "", wantErr: "no bookmark"},
+		"jj fails":           {out: "error: There is no jj repo in \".\"", wantErr: "no jj repo"},
+		"templated bookmark": {out: "feat/004-search\n", want: "feat/004-search"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls := stubJJ(t, map[string]string{"log": tt.out})
+
+			branch, err := jjVCS{}.CurrentBranch()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, branch)
+			assert.Contains(t, (*calls)[0], currentBookmarkRevset)
+		})
+	}
+}
+
+func TestJJ_BranchNames(t *testing.T) {
+	stubJJ(t, map[string]string{"bookmark list": "main\n002-search\nmain\n001-login\n"})
+
+	names, err := jjVCS{}.BranchNames()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"001-login", "002-search", "main"}, names)
+}
+
+func TestJJ_CreateBranch(t *testing.T) {
+	calls := stubJJ(t, map[string]string{"bookmark list": "main\n001-login\n"})
+
+	require.NoError(t, jjVCS{}.CreateBranch("002-search"))
+	assert.Equal(t, "bookmark create 002-search -r @", (*calls)[len(*calls)-1])
+
+	assert.ErrorContains(t, jjVCS{}.CreateBranch("001-login"), "bookmark '001-login' already exists")
+}
+
+func TestJJ_RenameBranch(t *testing.T) {
+	calls := stubJJ(t, map[string]string{"bookmark list": "main\n001-login\n"})
+
+	require.NoError(t, jjVCS{}.RenameBranch("001-login", "001-sign-in"))
+	assert.Equal(t, "bookmark rename 001-login 001-sign-in", (*calls)[len(*calls)-1])
+
+	assert.ErrorContains(t, jjVCS{}.RenameBranch("009-missing", "009-x"), "does not exist")
+	assert.ErrorContains(t, jjVCS{}.RenameBranch("001-login", "main"), "already exists")
+}
+
+func TestJJ_Fetch(t *testing.T) {
+	tests := map[string]struct {
+		responses map[string]string
+		want      bool
+		wantFetch bool
+	}{
+		"no remotes":   {responses: map[string]string{"git remote list": ""}, want: true},
+		"fetches":      {responses: map[string]string{"git remote list": "origin https://example.com/repo.git\n"}, want: true, wantFetch: true},
+		"fetch failed": {responses: map[string]string{"git remote list": "origin x\n", "git fetch": "error: network down"}, want: false, wantFetch: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls := stubJJ(t, tt.responses)
+
+			ok, err := jjVCS{}.Fetch()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ok)
+			assert.Equal(t, tt.wantFetch, strings.Join(*calls, ";") != "git remote list")
+		})
+	}
+}
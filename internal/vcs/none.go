@@ -0,0 +1,27 @@
+package vcs
+
+import "errors"
+
+// errNoVCS is returned by the none backend's branch operations.
+var errNoVCS = errors.New("no version control (vcs: none)")
+
+// noneVCS is the backend for plain directories. It has no branches, so spec
+// numbering only sees spec directories and the current spec is detected from
+// SPECIFY_FEATURE or the most recently modified spec.
+type noneVCS struct{}
+
+func (noneVCS) Name() string { return None }
+
+func (noneVCS) InRepository() bool { return false }
+
+func (noneVCS) Root() (string, error) { return "", errNoVCS }
+
+func (noneVCS) CurrentBranch() (string, error) { return "", errNoVCS }
+
+func (noneVCS) BranchNames() ([]string, error) { return nil, nil }
+
+func (noneVCS) CreateBranch(string) error { return errNoVCS }
+
+func (noneVCS) RenameBranch(string, string) error { return errNoVCS }
+
+func (noneVCS) Fetch() (bool, error) { return true, nil }
@@ -0,0 +1,127 @@
+// Package vcs abstracts the version control system autospec uses for spec
+// branches: numbering new specs past existing branches, detecting the current
+// spec from the checked-out branch, and creating and renaming feature
+// branches. Git, jujutsu (jj) and plain directories ("none") are supported.
+package vcs
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/git"
+)
+
+// Backend names accepted by the vcs config key and AUTOSPEC_VCS.
+const (
+	Auto = "auto"
+	Git  = "git"
+	JJ   = "jj"
+	None = "none"
+)
+
+// Names lists the valid backend names, in the order shown to users.
+var Names = []string{Auto, Git, JJ, None}
+
+// VCS is a version control backend. Branch operations map to jj bookmarks
+// in the jj backend; the none backend has no branches.
+type VCS interface {
+	// Name returns the backend name, e.g. "git".
+	Name() string
+	// InRepository reports whether the current directory is under version control.
+	InRepository() bool
+	// Root returns the absolute path of the repository root.
+	Root() (string, error)
+	// CurrentBranch returns the branch (or bookmark) the working copy is on.
+	CurrentBranch() (string, error)
+	// BranchNames returns all local and remote branch names, deduplicated.
+	BranchNames() ([]string, error)
+	// CreateBranch creates a branch at the working copy and switches to it.
+	CreateBranch(name string) error
+	// RenameBranch renames a local branch.
+	RenameBranch(oldName, newName string) error
+	// Fetch updates branches from all remotes. It returns false if any
+	// remote could not be fetched.
+	Fetch() (bool, error)
+}
+
+// selected is the backend chosen with Use; empty defers to AUTOSPEC_VCS.
+var selected string
+
+// Use selects the backend returned by Current, overriding AUTOSPEC_VCS.
+// "" or "auto" clears the selection, so AUTOSPEC_VCS or detection applies.
+func Use(name string) error {
+	if err := Validate(name); err != nil {
+		return err
+	}
+	if name == Auto {
+		name = ""
+	}
+	selected = name
+	return nil
+}
+
+// Validate checks that name is a backend name or empty.
+func Validate(name string) error {
+	if name == "" {
+		return nil
+	}
+	for _, valid := range Names {
+		if name == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown vcs %q (valid: %s)", name, strings.Join(Names, ", "))
+}
+
+// Current returns the backend selected with Use, else with AUTOSPEC_VCS,
+// else detected from the current directory: jj if it is inside a jj repo
+// (colocated git repos included), git if inside a git repo, otherwise none.
+func Current() VCS {
+	name := selected
+	if name == "" {
+		name = os.Getenv("AUTOSPEC_VCS")
+	}
+	switch name {
+	case Git:
+		return gitVCS{}
+	case JJ:
+		return jjVCS{}
+	case None:
+		return noneVCS{}
+	}
+	return Detect()
+}
+
+// Detect returns the backend for the current directory, ignoring Use and
+// AUTOSPEC_VCS. A jj repo is only used if the jj binary is installed.
+func Detect() VCS {
+	if _, err := exec.LookPath("jj"); err == nil && inJJRepo() {
+		return jjVCS{}
+	}
+	if git.IsGitRepository() {
+		return gitVCS{}
+	}
+	return noneVCS{}
+}
+
+// inJJRepo reports whether the current directory or one of its parents has
+// a .jj directory.
+func inJJRepo() bool {
+	dir, err := os.Getwd()
+	if err != nil {
+		return false
+	}
+	for {
+		if info, err := os.Stat(filepath.Join(dir, ".jj")); err == nil && info.IsDir() {
+			return true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
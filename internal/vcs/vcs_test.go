@@ -0,0 +1,106 @@
+package vcs
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range append([]string{""}, Names...) {
+		assert.NoError(t, Validate(name), name)
+	}
+	assert.ErrorContains(t, Validate("hg"), `unknown vcs "hg" (valid: auto, git, jj, none)`)
+}
+
+func TestCurrent(t *testing.T) {
+	// Not parallel: changes directory, env and the selected backend
+
+	tests := map[string]struct {
+		use     string
+		env     string
+		gitRepo bool
+		jjRepo  bool
+		want    string
+	}{
+		"plain directory":          {want: None},
+		"git repository":           {gitRepo: true, want: Git},
+		"jj repository":            {jjRepo: true, want: JJ},
+		"colocated jj and git":     {gitRepo: true, jjRepo: true, want: JJ},
+		"env overrides detection":  {gitRepo: true, env: None, want: None},
+		"use overrides env":        {env: None, use: Git, want: Git},
+		"auto detects":             {gitRepo: true, use: Auto, want: Git},
+		"auto defers to env":       {gitRepo: true, use: Auto, env: None, want: None},
+		"env auto detects":         {env: Auto, want: None},
+		"jj selected without repo": {env: JJ, want: JJ},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.gitRepo {
+				require.NoError(t, exec.Command("git", "init", "-q", dir).Run())
+			}
+			if tt.jjRepo {
+				require.NoError(t, os.MkdirAll(filepath.Join(dir, ".jj"), 0o755))
+			}
+			sub := filepath.Join(dir, "sub")
+			require.NoError(t, os.MkdirAll(sub, 0o755))
+			t.Chdir(sub)
+			t.Setenv("AUTOSPEC_VCS", tt.env)
+			t.Setenv("PATH", fakeJJPath(t)+string(os.PathListSeparator)+os.Getenv("PATH"))
+			require.NoError(t, Use(tt.use))
+			t.Cleanup(func() { selected = "" })
+
+			assert.Equal(t, tt.want, Current().Name())
+		})
+	}
+}
+
+func TestDetect_JJNotInstalled(t *testing.T) {
+	// Not parallel: changes directory and env
+
+	dir := t.TempDir()
+	require.NoError(t, exec.Command("git", "init", "-q", dir).Run())
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".jj"), 0o755))
+	t.Chdir(dir)
+	gitPath, err := exec.LookPath("git")
+	require.NoError(t, err)
+	t.Setenv("PATH", filepath.Dir(gitPath))
+	if _, err := exec.LookPath("jj"); err == nil {
+		t.Skip("jj is installed next to git")
+	}
+
+	assert.Equal(t, Git, Detect().Name(), "a colocated repo falls back to git without the jj binary")
+}
+
+func TestNone(t *testing.T) {
+	t.Parallel()
+
+	var none noneVCS
+	assert.False(t, none.InRepository())
+	names, err := none.BranchNames()
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+	_, err = none.CurrentBranch()
+	assert.ErrorIs(t, err, errNoVCS)
+	assert.ErrorIs(t, none.CreateBranch("001-x"), errNoVCS)
+	ok, err := none.Fetch()
+	assert.True(t, ok)
+	assert.NoError(t, err)
+}
+
+// fakeJJPath returns a directory holding a jj executable that does nothing,
+// so detection sees jj as installed.
+func fakeJJPath(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "jj"), []byte("#!/bin/sh\nexit 0\n"), 0o755))
+	return dir
+}
@@ -0,0 +1,58 @@
+package watch
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// addTree watches dir and, up to the depth artifacts live at, the directories
+// below it, recording artifacts that already exist as known.
+func (w *Watcher) addTree(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			if _, _, ok := Classify(w.specsDir, path); ok {
+				w.known[path] = true
+			}
+			return nil
+		}
+		if w.depth(path) > 2 {
+			return filepath.SkipDir
+		}
+		if err := w.fs.Add(path); err != nil {
+			return fmt.Errorf("watching %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// queueExisting marks artifacts already present in a newly created directory
+// as pending, since their create events may have fired before it was watched.
+func (w *Watcher) queueExisting(dir string) bool {
+	queued := false
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if _, _, ok := Classify(w.specsDir, path); ok {
+			delete(w.known, path)
+			w.pending[path] = true
+			queued = true
+		}
+		return nil
+	})
+	return queued
+}
+
+// depth returns how many directories path is below the specs directory.
+func (w *Watcher) depth(path string) int {
+	rel, err := filepath.Rel(w.specsDir, path)
+	if err != nil || rel == "." {
+		return 0
+	}
+	return len(strings.Split(filepath.ToSlash(rel), "/"))
+}
@@ -0,0 +1,167 @@
+// Package watch monitors the specs directory for changes to spec artifacts.
+// Related: internal/cli/watch.go
+// Tags: watch, fsnotify, artifacts, validation
+//
+// File system events are debounced so that an editor save or an agent
+// rewriting a file produces a single Event per artifact.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDebounce is how long the watcher waits for writes to settle.
+const DefaultDebounce = 500 * time.Millisecond
+
+// checklistsDir is the per-spec directory holding checklist artifacts.
+const checklistsDir = "checklists"
+
+// artifactFiles maps artifact file names in a spec directory to their types.
+var artifactFiles = map[string]validation.ArtifactType{
+	"spec.yaml":     validation.ArtifactTypeSpec,
+	"plan.yaml":     validation.ArtifactTypePlan,
+	"tasks.yaml":    validation.ArtifactTypeTasks,
+	"analysis.yaml": validation.ArtifactTypeAnalysis,
+}
+
+// Event is a settled change to a spec artifact.
+type Event struct {
+	Path     string                  // Path to the artifact file
+	SpecName string                  // Spec directory name (e.g., "001-user-auth")
+	Type     validation.ArtifactType // Artifact type, used to pick a validator
+	Created  bool                    // True if the artifact did not exist before this change
+}
+
+// Watcher reports changes to artifacts under a specs directory.
+type Watcher struct {
+	specsDir string
+	debounce time.Duration
+	fs       *fsnotify.Watcher
+	known    map[string]bool // Artifacts that existed when last seen
+	pending  map[string]bool // Artifacts changed since the last flush
+}
+
+// New creates a watcher for specsDir and every spec directory inside it.
+// Artifacts that already exist are not reported until they change.
+func New(specsDir string, debounce time.Duration) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("creating file watcher: %w", err)
+	}
+	w := &Watcher{
+		specsDir: filepath.Clean(specsDir),
+		debounce: debounce,
+		fs:       fsw,
+		known:    make(map[string]bool),
+		pending:  make(map[string]bool),
+	}
+	if err := w.addTree(w.specsDir); err != nil {
+		_ = fsw.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// Close stops watching.
+func (w *Watcher) Close() error {
+	return w.fs.Close()
+}
+
+// Run delivers events to handle until ctx is cancelled. Events are delivered
+// in path order, one at a time; changes made while handle runs (e.g., by an
+// agent it started) are delivered after it returns.
+func (w *Watcher) Run(ctx context.Context, handle func(Event)) error {
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-w.fs.Events:
+			if !ok {
+				return nil
+			}
+			if w.observe(ev) {
+				timer.Reset(w.debounce)
+			}
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("watching %s: %w", w.specsDir, err)
+		case <-timer.C:
+			w.flush(handle)
+		}
+	}
+}
+
+// observe records a file system event. It returns true if an artifact changed.
+func (w *Watcher) observe(ev fsnotify.Event) bool {
+	if ev.Has(fsnotify.Create) {
+		if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+			// New spec or checklists directory: watch it and pick up files
+			// written before the watch was added
+			_ = w.addTree(ev.Name)
+			return w.queueExisting(ev.Name)
+		}
+	}
+	if _, _, ok := Classify(w.specsDir, ev.Name); !ok {
+		return false
+	}
+	w.pending[ev.Name] = true
+	return true
+}
+
+// flush delivers one event per pending artifact that still exists.
+func (w *Watcher) flush(handle func(Event)) {
+	paths := make([]string, 0, len(w.pending))
+	for path := range w.pending {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	w.pending = make(map[string]bool)
+
+	for _, path := range paths {
+		specName, artType, _ := Classify(w.specsDir, path)
+		if _, err := os.Stat(path); err != nil {
+			delete(w.known, path) // Removed, or renamed away
+			continue
+		}
+		created := !w.known[path]
+		w.known[path] = true
+		handle(Event{Path: path, SpecName: specName, Type: artType, Created: created})
+	}
+}
+
+// Classify maps a path under specsDir to its spec name and artifact type.
+// It returns false for files that are not spec artifacts, such as editor
+// swap files or files outside a spec directory.
+func Classify(specsDir, path string) (string, validation.ArtifactType, bool) {
+	rel, err := filepath.Rel(specsDir, path)
+	if err != nil {
+		return "", "", false
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	switch {
+	case parts[0] == "..":
+		return "", "", false
+	case len(parts) == 2:
+		if artType, ok := artifactFiles[parts[1]]; ok {
+			return parts[0], artType, true
+		}
+		return "", "", false
+	case len(parts) == 3 && parts[1] == checklistsDir && strings.HasSuffix(parts[2], ".yaml"):
+		return parts[0], validation.ArtifactTypeChecklist, true
+	default:
+		return "", "", false
+	}
+}
@@ -0,0 +1,139 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	t.Parallel()
+
+	specsDir := filepath.Join("project", "specs")
+	tests := map[string]struct {
+		path     string
+		wantSpec string
+		wantType validation.ArtifactType
+		wantOK   bool
+	}{
+		"spec":          {path: "001-auth/spec.yaml", wantSpec: "001-auth", wantType: validation.ArtifactTypeSpec, wantOK: true},
+		"plan":          {path: "001-auth/plan.yaml", wantSpec: "001-auth", wantType: validation.ArtifactTypePlan, wantOK: true},
+		"tasks":         {path: "001-auth/tasks.yaml", wantSpec: "001-auth", wantType: validation.ArtifactTypeTasks, wantOK: true},
+		"analysis":      {path: "001-auth/analysis.yaml", wantSpec: "001-auth", wantType: validation.ArtifactTypeAnalysis, wantOK: true},
+		"checklist":     {path: "001-auth/checklists/security.yaml", wantSpec: "001-auth", wantType: validation.ArtifactTypeChecklist, wantOK: true},
+		"editor swap":   {path: "001-auth/.tasks.yaml.swp"},
+		"other file":    {path: "001-auth/notes.md"},
+		"top level":     {path: "spec.yaml"},
+		"too deep":      {path: "001-auth/contracts/api/plan.yaml"},
+		"outside specs": {path: "../other/001-auth/spec.yaml"},
+		"parent dir":    {path: "../spec.yaml"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			specName, artType, ok := Classify(specsDir, filepath.Join(specsDir, tt.path))
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantSpec, specName)
+			assert.Equal(t, tt.wantType, artType)
+		})
+	}
+}
+
+// runWatcher starts a watcher on specsDir and returns a channel of its events.
+func runWatcher(t *testing.T, specsDir string) <-chan Event {
+	t.Helper()
+	w, err := New(specsDir, 50*time.Millisecond)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = w.Close() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	events := make(chan Event, 16)
+	go func() { _ = w.Run(ctx, func(ev Event) { events <- ev }) }()
+	return events
+}
+
+// nextEvent waits for the next event or fails the test.
+func nextEvent(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+	select {
+	case ev := <-events:
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+		return Event{}
+	}
+}
+
+func TestWatcher_ReportsCreatedAndModified(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	specDir := filepath.Join(specsDir, "001-auth")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	existing := filepath.Join(specDir, "spec.yaml")
+	require.NoError(t, os.WriteFile(existing, []byte("feature: {}\n"), 0o644))
+
+	events := runWatcher(t, specsDir)
+
+	plan := filepath.Join(specDir, "plan.yaml")
+	require.NoError(t, os.WriteFile(plan, []byte("plan: {}\n"), 0o644))
+	ev := nextEvent(t, events)
+	assert.Equal(t, Event{Path: plan, SpecName: "001-auth", Type: validation.ArtifactTypePlan, Created: true}, ev)
+
+	require.NoError(t, os.WriteFile(existing, []byte("feature: {branch: x}\n"), 0o644))
+	ev = nextEvent(t, events)
+	assert.Equal(t, existing, ev.Path)
+	assert.False(t, ev.Created, "spec.yaml existed when the watch started")
+}
+
+func TestWatcher_NewSpecDirectory(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	events := runWatcher(t, specsDir)
+
+	checklists := filepath.Join(specsDir, "002-api", "checklists")
+	require.NoError(t, os.MkdirAll(checklists, 0o755))
+	time.Sleep(100 * time.Millisecond) // Let the watcher pick up the new directories
+	checklist := filepath.Join(checklists, "api.yaml")
+	require.NoError(t, os.WriteFile(checklist, []byte("checklist: {}\n"), 0o644))
+
+	ev := nextEvent(t, events)
+	assert.Equal(t, Event{Path: checklist, SpecName: "002-api", Type: validation.ArtifactTypeChecklist, Created: true}, ev)
+}
+
+func TestWatcher_DebouncesWrites(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	specDir := filepath.Join(specsDir, "001-auth")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	events := runWatcher(t, specsDir)
+
+	tasks := filepath.Join(specDir, "tasks.yaml")
+	for range 5 {
+		require.NoError(t, os.WriteFile(tasks, []byte("tasks: {}\n"), 0o644))
+	}
+	assert.Equal(t, tasks, nextEvent(t, events).Path)
+
+	select {
+	case ev := <-events:
+		t.Fatalf("unexpected second event: %+v", ev)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestNew_MissingSpecsDir(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(filepath.Join(t.TempDir(), "missing"), DefaultDebounce)
+	assert.Error(t, err)
+}
@@ -0,0 +1,205 @@
+package web
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// runStages lists the stages the API can run for an existing spec.
+var runStages = []string{"plan", "tasks", "implement"}
+
+// runRequest is the optional JSON body of POST /api/specs/{name}/run/{stage}.
+type runRequest struct {
+	Phase  int  `json:"phase"`  // implement only: run just this phase
+	Resume bool `json:"resume"` // implement only: resume an interrupted run
+}
+
+// specifyRequest is the JSON body of POST /api/specify.
+type specifyRequest struct {
+	Description string `json:"description"`
+}
+
+// EnableAPI registers the orchestration endpoints, which start autospec
+// commands through runner. Every endpoint requires the header
+// "Authorization: Bearer <token>".
+func (s *Server) EnableAPI(runner *JobRunner, token string) {
+	auth := func(handler http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			given, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !found || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeError(w, http.StatusUnauthorized, errors.New("missing or invalid API token"))
+				return
+			}
+			handler(w, r)
+		}
+	}
+	api := &apiHandlers{server: s, runner: runner}
+	s.mux.HandleFunc("POST /api/specify", auth(api.handleSpecify))
+	s.mux.HandleFunc("POST /api/specs/{name}/run/{stage}", auth(api.handleRun))
+	s.mux.HandleFunc("GET /api/jobs", auth(api.handleJobs))
+	s.mux.HandleFunc("GET /api/jobs/{id}", auth(api.handleJob))
+	s.mux.HandleFunc("GET /api/jobs/{id}/logs", auth(api.handleLogs))
+	s.mux.HandleFunc("POST /api/jobs/{id}/cancel", auth(api.handleCancel))
+}
+
+// apiHandlers serves the orchestration endpoints.
+type apiHandlers struct {
+	server *Server
+	runner *JobRunner
+}
+
+func (a *apiHandlers) handleSpecify(w http.ResponseWriter, r *http.Request) {
+	var req specifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if strings.TrimSpace(req.Description) == "" {
+		writeError(w, http.StatusBadRequest, errors.New("description is required"))
+		return
+	}
+	a.start(w, "", "specify", []string{"specify", "--", req.Description})
+}
+
+func (a *apiHandlers) handleRun(w http.ResponseWriter, r *http.Request) {
+	name, stage := r.PathValue("name"), r.PathValue("stage")
+	if !containsString(runStages, stage) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown stage %q (expected one of: %s)", stage, strings.Join(runStages, ", ")))
+		return
+	}
+	var req runRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+			return
+		}
+	}
+	if _, err := a.server.source.Status(name); err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, ErrSpecNotFound) {
+			code = http.StatusNotFound
+		}
+		writeError(w, code, err)
+		return
+	}
+
+	var args []string
+	switch stage {
+	case "implement":
+		args = []string{"implement", name}
+		if req.Phase > 0 {
+			args = append(args, "--phase", strconv.Itoa(req.Phase))
+		}
+		if req.Resume {
+			args = append(args, "--resume")
+		}
+	default:
+		// plan and tasks act on the current branch's spec; run --spec targets this one
+		args = []string{"run", "--spec", name, "--" + stage}
+	}
+	a.start(w, name, stage, args)
+}
+
+// start launches a job and responds 202 with it.
+func (a *apiHandlers) start(w http.ResponseWriter, spec, stage string, args []string) {
+	job, err := a.runner.Start(spec, stage, args)
+	switch {
+	case errors.Is(err, ErrSpecBusy):
+		writeError(w, http.StatusConflict, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Location", "/api/jobs/"+job.ID)
+	w.WriteHeader(http.StatusAccepted)
+	writeJSON(w, job)
+}
+
+func (a *apiHandlers) handleJobs(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, a.runner.Jobs())
+}
+
+func (a *apiHandlers) handleJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := a.runner.Job(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.New("job not found"))
+		return
+	}
+	writeJSON(w, job)
+}
+
+func (a *apiHandlers) handleCancel(w http.ResponseWriter, r *http.Request) {
+	if !a.runner.Cancel(r.PathValue("id")) {
+		writeError(w, http.StatusNotFound, errors.New("job not found"))
+		return
+	}
+	job, _ := a.runner.Job(r.PathValue("id"))
+	writeJSON(w, job)
+}
+
+// handleLogs streams a job's output as server-sent events: a "log" event per
+// line (its id is the line number, so clients can reconnect with
+// Last-Event-ID), then a "done" event with the finished job.
+func (a *apiHandlers) handleLogs(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, ok := a.runner.Job(id); !ok {
+		writeError(w, http.StatusNotFound, errors.New("job not found"))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming not supported"))
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	offset := 0
+	if last, err := strconv.Atoi(r.Header.Get("Last-Event-ID")); err == nil {
+		offset = last + 1
+	}
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+	for {
+		lines, next, changed, done, _ := a.runner.Logs(id, offset)
+		for i, line := range lines {
+			data, _ := json.Marshal(line)
+			fmt.Fprintf(w, "id: %d\nevent: log\ndata: %s\n\n", next-len(lines)+i, data)
+		}
+		offset = next
+		if done {
+			job, _ := a.runner.Job(id)
+			data, _ := json.Marshal(job)
+			fmt.Fprintf(w, "event: done\ndata: %s\n\n", data)
+			flusher.Flush()
+			return
+		}
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-changed:
+		case <-ticker.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		}
+	}
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
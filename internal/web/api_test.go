@@ -0,0 +1,313 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "s3cret"
+
+// fakeAutospec writes a stand-in autospec binary that echoes its arguments.
+// Implement runs until interrupted, so tests can observe a running job.
+func fakeAutospec(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "autospec")
+	script := `#!/bin/sh
+echo "args: $*"
+echo "yes: $AUTOSPEC_YES"
+case "$*" in
+  *implement*) exec sleep 30 ;;
+  *fail*) exit 3 ;;
+esac
+`
+	require.NoError(t, os.WriteFile(path, []byte(script), 0755))
+	return path
+}
+
+// newAPIServer returns a server with the API enabled and its job runner.
+func newAPIServer(t *testing.T) (*Server, *JobRunner) {
+	t.Helper()
+	runner := &JobRunner{Executable: fakeAutospec(t), GlobalArgs: []string{"--config", "ci.yml"}}
+	t.Cleanup(runner.CancelAll)
+	server := NewServer(newFakeSource(), 0)
+	server.EnableAPI(runner, testToken)
+	return server, runner
+}
+
+// apiRequest sends an authenticated request to server.
+func apiRequest(server http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	return rec
+}
+
+// waitForJob polls until the job leaves the running state.
+func waitForJob(t *testing.T, runner *JobRunner, id string) Job {
+	t.Helper()
+	var job Job
+	require.Eventually(t, func() bool {
+		job, _ = runner.Job(id)
+		return job.Status != JobRunning
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestAPI_Auth(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		header string
+	}{
+		"no header":    {},
+		"wrong token":  {header: "Bearer nope"},
+		"wrong scheme": {header: "Basic " + testToken},
+	}
+
+	server, _ := newAPIServer(t)
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodGet, "/api/jobs", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
+func TestAPI_Requests(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		method   string
+		path     string
+		body     string
+		wantCode int
+		wantBody string
+	}{
+		"unknown spec": {
+			method:   http.MethodPost,
+			path:     "/api/specs/999-missing/run/plan",
+			wantCode: http.StatusNotFound,
+			wantBody: "spec not found",
+		},
+		"unknown stage": {
+			method:   http.MethodPost,
+			path:     "/api/specs/001-auth/run/deploy",
+			wantCode: http.StatusNotFound,
+			wantBody: `unknown stage \"deploy\"`,
+		},
+		"invalid body": {
+			method:   http.MethodPost,
+			path:     "/api/specs/001-auth/run/implement",
+			body:     "{",
+			wantCode: http.StatusBadRequest,
+			wantBody: "invalid request body",
+		},
+		"specify without description": {
+			method:   http.MethodPost,
+			path:     "/api/specify",
+			body:     `{"description": "  "}`,
+			wantCode: http.StatusBadRequest,
+			wantBody: "description is required",
+		},
+		"unknown job": {
+			method:   http.MethodGet,
+			path:     "/api/jobs/42",
+			wantCode: http.StatusNotFound,
+			wantBody: "job not found",
+		},
+		"cancel unknown job": {
+			method:   http.MethodPost,
+			path:     "/api/jobs/42/cancel",
+			wantCode: http.StatusNotFound,
+		},
+		"logs of unknown job": {
+			method:   http.MethodGet,
+			path:     "/api/jobs/42/logs",
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			server, _ := newAPIServer(t)
+			rec := apiRequest(server, tt.method, tt.path, tt.body)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestAPI_RunStage(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		path       string
+		body       string
+		wantStage  string
+		wantSpec   string
+		wantOutput string
+	}{
+		"plan": {
+			path:       "/api/specs/001-auth/run/plan",
+			wantStage:  "plan",
+			wantSpec:   "001-auth",
+			wantOutput: "args: --config ci.yml run --spec 001-auth --plan",
+		},
+		"tasks": {
+			path:       "/api/specs/001-auth/run/tasks",
+			wantStage:  "tasks",
+			wantSpec:   "001-auth",
+			wantOutput: "args: --config ci.yml run --spec 001-auth --tasks",
+		},
+		"specify": {
+			path:       "/api/specify",
+			body:       `{"description": "Add OAuth login"}`,
+			wantStage:  "specify",
+			wantOutput: "args: --config ci.yml specify -- Add OAuth login",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			server, runner := newAPIServer(t)
+			rec := apiRequest(server, http.MethodPost, tt.path, tt.body)
+			require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+
+			var job Job
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+			assert.Equal(t, "/api/jobs/"+job.ID, rec.Header().Get("Location"))
+			assert.Equal(t, tt.wantStage, job.Stage)
+			assert.Equal(t, tt.wantSpec, job.Spec)
+
+			job = waitForJob(t, runner, job.ID)
+			assert.Equal(t, JobCompleted, job.Status)
+			assert.Zero(t, job.ExitCode)
+			assert.NotNil(t, job.FinishedAt)
+			lines, _, _, done, ok := runner.Logs(job.ID, 0)
+			require.True(t, ok)
+			assert.True(t, done)
+			assert.Equal(t, []string{tt.wantOutput, "yes: 1"}, lines)
+		})
+	}
+}
+
+func TestAPI_ImplementBusyAndCancel(t *testing.T) {
+	t.Parallel()
+
+	server, runner := newAPIServer(t)
+	rec := apiRequest(server, http.MethodPost, "/api/specs/001-auth/run/implement", `{"phase": 2, "resume": true}`)
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	var job Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+	assert.Equal(t, []string{"implement", "001-auth", "--phase", "2", "--resume"}, job.Args)
+	assert.Equal(t, JobRunning, job.Status)
+
+	// A second run for the same spec is rejected while the first is active
+	rec = apiRequest(server, http.MethodPost, "/api/specs/001-auth/run/plan", "")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "already running")
+
+	rec = apiRequest(server, http.MethodGet, "/api/jobs", "")
+	var jobs []Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &jobs))
+	require.Len(t, jobs, 1)
+
+	rec = apiRequest(server, http.MethodPost, "/api/jobs/"+job.ID+"/cancel", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	job = waitForJob(t, runner, job.ID)
+	assert.Equal(t, JobFailed, job.Status)
+	assert.NotEmpty(t, job.Error)
+
+	// The spec is free again once the job ends
+	rec = apiRequest(server, http.MethodPost, "/api/specs/001-auth/run/plan", "")
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+}
+
+func TestAPI_FailedJob(t *testing.T) {
+	t.Parallel()
+
+	server, runner := newAPIServer(t)
+	rec := apiRequest(server, http.MethodPost, "/api/specify", `{"description": "fail fast"}`)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	var job Job
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &job))
+
+	job = waitForJob(t, runner, job.ID)
+	assert.Equal(t, JobFailed, job.Status)
+	assert.Equal(t, 3, job.ExitCode)
+}
+
+func TestAPI_Logs(t *testing.T) {
+	t.Parallel()
+
+	server, runner := newAPIServer(t)
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	job, err := runner.Start("001-auth", "plan", []string{"run", "--spec", "001-auth", "--plan"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/jobs/"+job.ID+"/logs", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// The stream ends on its own after the "done" event
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var logs []string
+	var done Job
+	var event string
+	scanner := bufio.NewScanner(strings.NewReader(string(body)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if name, ok := strings.CutPrefix(line, "event: "); ok {
+			event = name
+		}
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok {
+			continue
+		}
+		switch event {
+		case "log":
+			var text string
+			require.NoError(t, json.Unmarshal([]byte(data), &text))
+			logs = append(logs, text)
+		case "done":
+			require.NoError(t, json.Unmarshal([]byte(data), &done))
+		}
+	}
+	assert.Equal(t, []string{"args: --config ci.yml run --spec 001-auth --plan", "yes: 1"}, logs)
+	assert.Equal(t, JobCompleted, done.Status)
+	assert.Contains(t, string(body), "id: 1\nevent: log\n")
+}
@@ -0,0 +1,82 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+)
+
+// keepAlive is how often an idle event stream sends a comment line, so
+// proxies don't close the connection.
+const keepAlive = 30 * time.Second
+
+// snapshot is the payload of an "update" event.
+type snapshot struct {
+	Specs []Spec                 `json:"specs"`
+	Runs  []history.HistoryEntry `json:"runs"`
+}
+
+// handleEvents streams server-sent events. An "update" event carrying the
+// specs and active runs is sent on connect and whenever either changes; an
+// "error" event is sent when loading starts failing.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("streaming not supported"))
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	poll := time.NewTicker(s.interval)
+	defer poll.Stop()
+	var last string
+	lastWrite := time.Now()
+	for {
+		event, data := s.currentEvent()
+		if message := fmt.Sprintf("event: %s\ndata: %s\n\n", event, data); message != last {
+			fmt.Fprint(w, message)
+			last = message
+			flusher.Flush()
+			lastWrite = time.Now()
+		} else if time.Since(lastWrite) >= keepAlive {
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+			lastWrite = time.Now()
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-poll.C:
+		}
+	}
+}
+
+// currentEvent returns an "update" event with the current specs and active
+// runs, or an "error" event if they can't be loaded.
+func (s *Server) currentEvent() (string, []byte) {
+	specs, err := s.source.Specs()
+	if err != nil {
+		return "error", errorPayload(err)
+	}
+	runs, err := s.activeRuns()
+	if err != nil {
+		return "error", errorPayload(err)
+	}
+	data, err := json.Marshal(snapshot{Specs: specs, Runs: runs})
+	if err != nil {
+		return "error", errorPayload(err)
+	}
+	return "update", data
+}
+
+// errorPayload encodes err as an event payload.
+func errorPayload(err error) []byte {
+	data, _ := json.Marshal(map[string]string{"error": err.Error()})
+	return data
+}
@@ -0,0 +1,218 @@
+package web
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// maxJobLogLines is the log scrollback kept per job.
+const maxJobLogLines = 5000
+
+// Job states reported by the API.
+const (
+	JobRunning   = "running"
+	JobCompleted = "completed"
+	JobFailed    = "failed"
+)
+
+// ErrSpecBusy is returned when a job is already running for the spec.
+var ErrSpecBusy = errors.New("a job is already running for this spec")
+
+// Job is an autospec command started through the API.
+type Job struct {
+	ID         string     `json:"id"`
+	Spec       string     `json:"spec,omitempty"`
+	Stage      string     `json:"stage"`
+	Args       []string   `json:"args"`
+	Status     string     `json:"status"`
+	ExitCode   int        `json:"exit_code"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+
+	cmd     *exec.Cmd
+	lines   []string
+	dropped int           // Lines discarded after exceeding maxJobLogLines
+	changed chan struct{} // Closed and replaced whenever the job changes
+}
+
+// JobRunner starts autospec subprocesses and tracks them as jobs.
+type JobRunner struct {
+	// Executable is the path to the autospec binary.
+	Executable string
+	// GlobalArgs are prepended to every invocation (e.g., ["--config", "path"]).
+	GlobalArgs []string
+
+	mu     sync.Mutex
+	jobs   []*Job
+	nextID int
+}
+
+// Start runs autospec with args as a new job for spec (empty for specify).
+// Only one job runs per spec at a time.
+func (r *JobRunner) Start(spec, stage string, args []string) (Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, job := range r.jobs {
+		if spec != "" && job.Spec == spec && job.Status == JobRunning {
+			return Job{}, fmt.Errorf("%w: job %s", ErrSpecBusy, job.ID)
+		}
+	}
+
+	cmd := exec.Command(r.Executable, append(append([]string{}, r.GlobalArgs...), args...)...)
+	// No TTY is attached, so confirmation prompts must be skipped
+	cmd.Env = append(os.Environ(), "AUTOSPEC_YES=1", "NO_COLOR=1")
+	pipeR, pipeW := io.Pipe()
+	cmd.Stdout, cmd.Stderr = pipeW, pipeW
+	if err := cmd.Start(); err != nil {
+		return Job{}, fmt.Errorf("starting autospec %s: %w", stage, err)
+	}
+
+	r.nextID++
+	job := &Job{
+		ID:        strconv.Itoa(r.nextID),
+		Spec:      spec,
+		Stage:     stage,
+		Args:      args,
+		Status:    JobRunning,
+		StartedAt: time.Now(),
+		cmd:       cmd,
+		changed:   make(chan struct{}),
+	}
+	r.jobs = append(r.jobs, job)
+
+	go func() {
+		waitErr := make(chan error, 1)
+		go func() {
+			waitErr <- cmd.Wait()
+			_ = pipeW.Close()
+		}()
+		scanner := bufio.NewScanner(pipeR)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			r.appendLine(job, scanner.Text())
+		}
+		// Keep the process from blocking on a line too long to scan
+		_, _ = io.Copy(io.Discard, pipeR)
+		r.finish(job, <-waitErr)
+	}()
+	return *job, nil
+}
+
+// appendLine adds a log line to job, enforcing the scrollback limit.
+func (r *JobRunner) appendLine(job *Job, line string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job.lines = append(job.lines, line)
+	if excess := len(job.lines) - maxJobLogLines; excess > 0 {
+		job.lines = job.lines[excess:]
+		job.dropped += excess
+	}
+	r.notify(job)
+}
+
+// finish records the job's outcome.
+func (r *JobRunner) finish(job *Job, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	job.FinishedAt = &now
+	job.Status = JobCompleted
+	if err != nil {
+		job.Status = JobFailed
+		job.Error = err.Error()
+		job.ExitCode = -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			job.ExitCode = exitErr.ExitCode()
+		}
+	}
+	r.notify(job)
+}
+
+// notify wakes log followers. Callers must hold r.mu.
+func (r *JobRunner) notify(job *Job) {
+	close(job.changed)
+	job.changed = make(chan struct{})
+}
+
+// Jobs returns all jobs, newest first.
+func (r *JobRunner) Jobs() []Job {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	jobs := make([]Job, 0, len(r.jobs))
+	for i := len(r.jobs) - 1; i >= 0; i-- {
+		jobs = append(jobs, *r.jobs[i])
+	}
+	return jobs
+}
+
+// Job returns the job with the given ID.
+func (r *JobRunner) Job(id string) (Job, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.find(id)
+	if job == nil {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// find returns the job with the given ID, or nil. Callers must hold r.mu.
+func (r *JobRunner) find(id string) *Job {
+	for _, job := range r.jobs {
+		if job.ID == id {
+			return job
+		}
+	}
+	return nil
+}
+
+// Logs returns the job's log lines from line offset on, the offset to pass
+// next time, a channel closed when more output arrives, and whether the job
+// has finished. Offsets count from the job's first line, including lines
+// dropped from the scrollback.
+func (r *JobRunner) Logs(id string, offset int) (lines []string, next int, changed <-chan struct{}, done bool, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.find(id)
+	if job == nil {
+		return nil, 0, nil, false, false
+	}
+	start := min(max(offset-job.dropped, 0), len(job.lines))
+	lines = append([]string(nil), job.lines[start:]...)
+	return lines, job.dropped + len(job.lines), job.changed, job.Status != JobRunning, true
+}
+
+// Cancel interrupts a running job. It reports false for unknown jobs.
+func (r *JobRunner) Cancel(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.find(id)
+	if job == nil {
+		return false
+	}
+	if job.Status == JobRunning {
+		_ = job.cmd.Process.Signal(syscall.SIGINT)
+	}
+	return true
+}
+
+// CancelAll interrupts every running job, e.g. when the server stops.
+func (r *JobRunner) CancelAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, job := range r.jobs {
+		if job.Status == JobRunning {
+			_ = job.cmd.Process.Signal(syscall.SIGINT)
+		}
+	}
+}
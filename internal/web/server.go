@@ -0,0 +1,229 @@
+// Package web serves a read-only dashboard for autospec over HTTP: an
+// embedded single-page UI, a JSON API for specs, tasks, history and active
+// runs, and a server-sent events stream that pushes updates while runs
+// progress.
+//
+// The dashboard never modifies specs. All data comes from a Source, which the
+// CLI backs with the specs directory and the history file. EnableAPI adds
+// token-protected endpoints that run autospec commands as jobs, for CI
+// systems and bots driving the workflow remotely.
+package web
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+//go:embed static/index.html
+var static embed.FS
+
+// DefaultInterval is how often the event stream checks for changes.
+const DefaultInterval = 2 * time.Second
+
+// defaultHistoryLimit is the number of history entries /api/history returns
+// when no limit is given.
+const defaultHistoryLimit = 100
+
+// ErrSpecNotFound is returned by Source.Tasks and Source.Status for an
+// unknown spec.
+var ErrSpecNotFound = errors.New("spec not found")
+
+// Spec is a spec as listed by the API.
+type Spec struct {
+	Name         string                `json:"name"`
+	Status       string                `json:"status"`
+	Branch       string                `json:"branch,omitempty"`
+	Artifacts    []string              `json:"artifacts"`
+	Tasks        *validation.TaskStats `json:"tasks,omitempty"`
+	LastActivity time.Time             `json:"last_activity"`
+}
+
+// Task is a task of a spec as returned by the API.
+type Task struct {
+	ID            string   `json:"id"`
+	Title         string   `json:"title"`
+	Status        string   `json:"status"`
+	Phase         int      `json:"phase"`
+	PhaseTitle    string   `json:"phase_title"`
+	Dependencies  []string `json:"dependencies,omitempty"`
+	BlockedReason string   `json:"blocked_reason,omitempty"`
+}
+
+// SpecStatus is a spec's workflow progress as returned by the status endpoint.
+type SpecStatus struct {
+	Name        string                `json:"name"`
+	Artifacts   []string              `json:"artifacts"`
+	Tasks       *validation.TaskStats `json:"tasks,omitempty"`
+	Stages      []StageStatus         `json:"stages"`
+	CurrentTask string                `json:"current_task,omitempty"` // Where implement --tasks --resume picks up
+	Next        string                `json:"next,omitempty"`         // Recommended command ("" when done)
+}
+
+// StageStatus is the progress of one workflow stage.
+type StageStatus struct {
+	Stage           string `json:"stage"`
+	Done            bool   `json:"done"`
+	Retries         int    `json:"retries"`
+	MaxRetries      int    `json:"max_retries,omitempty"`
+	CompletedPhases int    `json:"completed_phases,omitempty"` // implement --phases progress
+	TotalPhases     int    `json:"total_phases,omitempty"`
+}
+
+// Source supplies the data the server exposes. Implementations must be safe
+// for concurrent use.
+type Source interface {
+	// Specs lists every spec, ordered by name.
+	Specs() ([]Spec, error)
+	// Tasks returns a spec's tasks, or ErrSpecNotFound.
+	Tasks(spec string) ([]Task, error)
+	// Status returns a spec's workflow progress, or ErrSpecNotFound.
+	Status(spec string) (*SpecStatus, error)
+	// History returns command history, oldest first.
+	History() ([]history.HistoryEntry, error)
+}
+
+// Server is the dashboard HTTP handler.
+type Server struct {
+	source   Source
+	interval time.Duration
+	mux      *http.ServeMux
+}
+
+// NewServer creates a server reading from source. The event stream polls
+// the source every interval (DefaultInterval if zero).
+func NewServer(source Source, interval time.Duration) *Server {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	s := &Server{source: source, interval: interval, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /{$}", s.handleIndex)
+	s.mux.HandleFunc("GET /api/specs", s.handleSpecs)
+	s.mux.HandleFunc("GET /api/specs/{name}/tasks", s.handleTasks)
+	s.mux.HandleFunc("GET /api/specs/{name}/status", s.handleStatus)
+	s.mux.HandleFunc("GET /api/history", s.handleHistory)
+	s.mux.HandleFunc("GET /api/runs", s.handleRuns)
+	s.mux.HandleFunc("GET /api/events", s.handleEvents)
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, _ *http.Request) {
+	page, err := static.ReadFile("static/index.html")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(page)
+}
+
+func (s *Server) handleSpecs(w http.ResponseWriter, _ *http.Request) {
+	specs, err := s.source.Specs()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, specs)
+}
+
+func (s *Server) handleTasks(w http.ResponseWriter, r *http.Request) {
+	tasks, err := s.source.Tasks(r.PathValue("name"))
+	switch {
+	case errors.Is(err, ErrSpecNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+	default:
+		writeJSON(w, tasks)
+	}
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := s.source.Status(r.PathValue("name"))
+	switch {
+	case errors.Is(err, ErrSpecNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+	default:
+		writeJSON(w, status)
+	}
+}
+
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	limit := defaultHistoryLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid limit %q: must be a positive integer", value))
+			return
+		}
+		limit = n
+	}
+	entries, err := s.source.History()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, newestFirst(entries, limit))
+}
+
+func (s *Server) handleRuns(w http.ResponseWriter, _ *http.Request) {
+	runs, err := s.activeRuns()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, runs)
+}
+
+// activeRuns returns history entries that are still running, newest first.
+func (s *Server) activeRuns() ([]history.HistoryEntry, error) {
+	entries, err := s.source.History()
+	if err != nil {
+		return nil, err
+	}
+	runs := []history.HistoryEntry{}
+	for _, entry := range newestFirst(entries, len(entries)) {
+		if entry.Status == history.StatusRunning {
+			runs = append(runs, entry)
+		}
+	}
+	return runs, nil
+}
+
+// newestFirst returns up to limit entries in reverse order.
+func newestFirst(entries []history.HistoryEntry, limit int) []history.HistoryEntry {
+	result := make([]history.HistoryEntry, 0, min(limit, len(entries)))
+	for i := len(entries) - 1; i >= 0 && len(result) < limit; i-- {
+		result = append(result, entries[i])
+	}
+	return result
+}
+
+// writeJSON writes v as an indented JSON response.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+// writeError writes {"error": "..."} with the given status code.
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,235 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource is an in-memory Source whose history can change during a test.
+type fakeSource struct {
+	mu      sync.Mutex
+	specs   []Spec
+	tasks   map[string][]Task
+	history []history.HistoryEntry
+	err     error
+}
+
+func (f *fakeSource) Specs() ([]Spec, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.specs, f.err
+}
+
+func (f *fakeSource) Tasks(spec string) ([]Task, error) {
+	tasks, ok := f.tasks[spec]
+	if !ok {
+		return nil, ErrSpecNotFound
+	}
+	return tasks, nil
+}
+
+func (f *fakeSource) Status(spec string) (*SpecStatus, error) {
+	if _, ok := f.tasks[spec]; !ok {
+		return nil, ErrSpecNotFound
+	}
+	return &SpecStatus{
+		Name:   spec,
+		Stages: []StageStatus{{Stage: "specify", Done: true}, {Stage: "plan", Retries: 1, MaxRetries: 3}},
+		Next:   "autospec run --spec " + spec + " --plan",
+	}, nil
+}
+
+func (f *fakeSource) History() ([]history.HistoryEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.history, f.err
+}
+
+func (f *fakeSource) setHistory(entries []history.HistoryEntry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.history = entries
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{
+		specs: []Spec{{Name: "001-auth", Status: "Draft", Artifacts: []string{"spec.yaml"}, Tasks: &validation.TaskStats{TotalTasks: 2}}},
+		tasks: map[string][]Task{"001-auth": {{ID: "T001", Title: "Add login", Status: "Pending", Phase: 1, PhaseTitle: "Setup"}}},
+		history: []history.HistoryEntry{
+			{Command: "specify", Spec: "001-auth", Status: history.StatusCompleted},
+			{Command: "plan", Spec: "001-auth", Status: history.StatusFailed},
+			{Command: "implement", Spec: "001-auth", Status: history.StatusRunning},
+		},
+	}
+}
+
+func TestServer_Endpoints(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		method   string
+		path     string
+		wantCode int
+		wantBody string
+	}{
+		"index page": {
+			path:     "/",
+			wantCode: http.StatusOK,
+			wantBody: "<title>autospec</title>",
+		},
+		"specs": {
+			path:     "/api/specs",
+			wantCode: http.StatusOK,
+			wantBody: `"name": "001-auth"`,
+		},
+		"tasks": {
+			path:     "/api/specs/001-auth/tasks",
+			wantCode: http.StatusOK,
+			wantBody: `"phase_title": "Setup"`,
+		},
+		"tasks of unknown spec": {
+			path:     "/api/specs/999-missing/tasks",
+			wantCode: http.StatusNotFound,
+			wantBody: `{"error":"spec not found"}`,
+		},
+		"status": {
+			path:     "/api/specs/001-auth/status",
+			wantCode: http.StatusOK,
+			wantBody: `"next": "autospec run --spec 001-auth --plan"`,
+		},
+		"status of unknown spec": {
+			path:     "/api/specs/999-missing/status",
+			wantCode: http.StatusNotFound,
+			wantBody: `{"error":"spec not found"}`,
+		},
+		"orchestration is off by default": {
+			method:   http.MethodPost,
+			path:     "/api/specs/001-auth/run/plan",
+			wantCode: http.StatusNotFound,
+		},
+		"history newest first": {
+			path:     "/api/history?limit=1",
+			wantCode: http.StatusOK,
+			wantBody: `"command": "implement"`,
+		},
+		"invalid history limit": {
+			path:     "/api/history?limit=0",
+			wantCode: http.StatusBadRequest,
+			wantBody: "invalid limit",
+		},
+		"active runs": {
+			path:     "/api/runs",
+			wantCode: http.StatusOK,
+			wantBody: `"status": "running"`,
+		},
+		"writes are rejected": {
+			method:   http.MethodPost,
+			path:     "/api/specs",
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		"unknown path": {
+			path:     "/nope",
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			rec := httptest.NewRecorder()
+			NewServer(newFakeSource(), 0).ServeHTTP(rec, httptest.NewRequest(method, tt.path, nil))
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestServer_HistoryOrder(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	NewServer(newFakeSource(), 0).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/history", nil))
+
+	var entries []history.HistoryEntry
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &entries))
+	require.Len(t, entries, 3)
+	assert.Equal(t, "implement", entries[0].Command)
+	assert.Equal(t, "specify", entries[2].Command)
+}
+
+func TestServer_SourceError(t *testing.T) {
+	t.Parallel()
+
+	source := newFakeSource()
+	source.err = errors.New("specs directory unreadable")
+	rec := httptest.NewRecorder()
+	NewServer(source, 0).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/specs", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "specs directory unreadable")
+}
+
+func TestServer_Events(t *testing.T) {
+	t.Parallel()
+
+	source := newFakeSource()
+	srv := httptest.NewServer(NewServer(source, 10*time.Millisecond))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/events", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := bufio.NewScanner(resp.Body)
+	nextEvent := func() (string, snapshot) {
+		var event string
+		for events.Scan() {
+			line := events.Text()
+			if name, ok := strings.CutPrefix(line, "event: "); ok {
+				event = name
+			}
+			if data, ok := strings.CutPrefix(line, "data: "); ok {
+				var snap snapshot
+				require.NoError(t, json.Unmarshal([]byte(data), &snap))
+				return event, snap
+			}
+		}
+		t.Fatalf("event stream ended: %v", events.Err())
+		return "", snapshot{}
+	}
+
+	event, snap := nextEvent()
+	assert.Equal(t, "update", event)
+	require.Len(t, snap.Runs, 1)
+	assert.Equal(t, "implement", snap.Runs[0].Command)
+
+	// Finishing the run pushes a new update with no active runs
+	source.setHistory([]history.HistoryEntry{{Command: "implement", Spec: "001-auth", Status: history.StatusCompleted}})
+	event, snap = nextEvent()
+	assert.Equal(t, "update", event)
+	assert.Empty(t, snap.Runs)
+	assert.Len(t, snap.Specs, 1)
+}
@@ -0,0 +1,35 @@
+package workflow
+
+import "fmt"
+
+// selectSession points the runner at the spec's previous agent session when
+// session_mode is continuous, or at a new session otherwise. Runners that
+// don't implement SessionResumer are left untouched.
+func (e *Executor) selectSession(specName string) {
+	resumer, ok := e.Claude.(SessionResumer)
+	if !ok {
+		return
+	}
+	id := e.Sessions.Resume(specName, e.agentName())
+	if id != "" {
+		e.debugLog("Resuming agent session %s for %s", id, specName)
+	}
+	resumer.SetResumeSession(id)
+}
+
+// recordSession stores the session the agent just ran so the spec's next
+// stage can continue it. Failures only warn: like usage tracking, session
+// continuity must never fail a stage.
+func (e *Executor) recordSession(specName string, stage Stage) {
+	if e.Sessions == nil {
+		return
+	}
+	resumer, ok := e.Claude.(SessionResumer)
+	if !ok || resumer.LastSessionID() == "" {
+		return
+	}
+	err := e.Sessions.Record(e.usageSpecName(specName), e.agentName(), string(stage), resumer.LastSessionID())
+	if err != nil {
+		fmt.Fprintf(e.output(), "Warning: failed to record agent session: %v\n", err)
+	}
+}
@@ -0,0 +1,203 @@
+// Package workflow escalates tasks that stay blocked, so a human is pulled in.
+// Related: internal/notify/handler.go, internal/issue/create.go, internal/cli/task_blocked.go
+// Tags: workflow, blocked, escalation, notifications, issues
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+	"github.com/ariel-frischer/autospec/internal/filelock"
+	"github.com/ariel-frischer/autospec/internal/issue"
+	"github.com/ariel-frischer/autospec/internal/notify"
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+// BlockedTasksFileName is the state file that tracks each spec's blocked
+// tasks, so a block is escalated only once.
+const BlockedTasksFileName = "blocked_tasks.json"
+
+// escalationIssueTimeout bounds opening an escalation issue, so an
+// unreachable GitHub cannot stall implement.
+const escalationIssueTimeout = 30 * time.Second
+
+// blockedRecord is the escalation state of one blocked task.
+type blockedRecord struct {
+	BlockedAt string    `json:"blocked_at,omitempty"` // The task's blocked_at when last seen
+	Since     time.Time `json:"since"`                // blocked_at, or when implement first saw the block
+	Escalated bool      `json:"escalated,omitempty"`
+}
+
+// escalateBlockedTasks escalates the spec's tasks that have been blocked for
+// EscalateAfter, each block once, as set by EscalateBlocked. Tasks
+// without blocked_at (e.g. blocked by editing tasks.yaml) count from the
+// first run that saw them blocked. Failures are reported and never fail
+// the run.
+func (e *Executor) escalateBlockedTasks(specName string, now time.Time) {
+	if e.EscalateAfter <= 0 {
+		return
+	}
+	tasks, err := validation.GetAllTasks(e.tasksPath(specName))
+	if err != nil {
+		return
+	}
+	due, err := e.claimBlockedEscalations(specName, tasks, now)
+	if err != nil {
+		fmt.Fprintf(e.output(), "Warning: tracking blocked tasks: %v\n", err)
+	}
+	for _, task := range due {
+		e.escalateBlockedTask(specName, task.TaskItem, now.Sub(task.since))
+	}
+}
+
+// dueTask is a blocked task whose escalation is due.
+type dueTask struct {
+	validation.TaskItem
+	since time.Time
+}
+
+// claimBlockedEscalations updates the spec's blocked-task records and returns
+// the tasks to escalate, marking them escalated. Records of tasks that are no
+// longer blocked are dropped, so a later block is escalated again. Without a
+// StateDir nothing is remembered between runs.
+func (e *Executor) claimBlockedEscalations(specName string, tasks []validation.TaskItem, now time.Time) ([]dueTask, error) {
+	var due []dueTask
+	update := func(records map[string]map[string]blockedRecord) {
+		previous := records[specName]
+		current := make(map[string]blockedRecord)
+		for _, task := range tasks {
+			if !strings.EqualFold(task.Status, "Blocked") {
+				continue
+			}
+			rec, ok := previous[task.ID]
+			if !ok || rec.BlockedAt != task.BlockedAt {
+				rec = blockedRecord{BlockedAt: task.BlockedAt, Since: now}
+				if at, err := time.Parse(time.RFC3339, task.BlockedAt); err == nil {
+					rec.Since = at
+				}
+			}
+			if !rec.Escalated && now.Sub(rec.Since) >= e.EscalateAfter {
+				rec.Escalated = true
+				due = append(due, dueTask{TaskItem: task, since: rec.Since})
+			}
+			current[task.ID] = rec
+		}
+		if len(current) == 0 {
+			delete(records, specName)
+		} else {
+			records[specName] = current
+		}
+	}
+
+	if e.StateDir == "" {
+		update(make(map[string]map[string]blockedRecord))
+		return due, nil
+	}
+	if err := os.MkdirAll(e.StateDir, 0755); err != nil {
+		return due, fmt.Errorf("creating state directory: %w", err)
+	}
+	path := filepath.Join(e.StateDir, BlockedTasksFileName)
+	err := filelock.With(path, func() error {
+		records := loadBlockedRecords(path)
+		update(records)
+		data, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling blocked tasks: %w", err)
+		}
+		return atomicfile.WriteFile(path, data, 0644)
+	})
+	return due, err
+}
+
+// loadBlockedRecords reads the blocked-task records, treating a missing or
+// corrupted file as empty.
+func loadBlockedRecords(path string) map[string]map[string]blockedRecord {
+	records := make(map[string]map[string]blockedRecord)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return records
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return make(map[string]map[string]blockedRecord)
+	}
+	return records
+}
+
+// escalateBlockedTask opens an issue for the task (issue, both), then
+// notifies (notify, both): a notification and the task.escalated webhook
+// event, which links the issue if one was opened.
+func (e *Executor) escalateBlockedTask(specName string, task validation.TaskItem, age time.Duration) {
+	reason := task.BlockedReason
+	if reason == "" {
+		reason = "no reason given"
+	}
+	message := fmt.Sprintf("blocked for %s: %s", formatBlockedAge(age), reason)
+	fmt.Fprintf(e.output(), "\n⚠ Task %s has been %s\n", task.ID, message)
+
+	if e.EscalateBlocked == "issue" || e.EscalateBlocked == "both" {
+		url, err := e.openBlockedTaskIssue(specName, task, message)
+		if err != nil {
+			fmt.Fprintf(e.output(), "Warning: opening an issue for blocked task %s: %v\n", task.ID, err)
+		} else {
+			fmt.Fprintf(e.output(), "  Opened %s\n", url)
+			message += " (" + url + ")"
+		}
+	}
+	if e.EscalateBlocked == "issue" {
+		return
+	}
+
+	if e.NotificationHandler != nil {
+		e.NotificationHandler.OnBlockedTaskEscalated(specName, task.ID, message)
+	}
+	e.emitEvent(notify.Event{
+		Event:     notify.EventTaskEscalated,
+		Spec:      specName,
+		Stage:     string(StageImplement),
+		TaskID:    task.ID,
+		TaskTitle: task.Title,
+		Message:   message,
+	})
+}
+
+// openBlockedTaskIssue opens a GitHub issue asking for help with the task.
+// message says how long and why it has been blocked.
+func (e *Executor) openBlockedTaskIssue(specName string, task validation.TaskItem, message string) (string, error) {
+	open := e.openIssue
+	if open == nil {
+		open = issue.NewFetcher(issue.DefaultHTTPTimeout).Create
+	}
+	body := fmt.Sprintf("Task **%s** (%s) of spec `%s` has been %s\n\n"+
+		"Once it is resolved, unblock the task on the spec's branch and run implement again:\n\n"+
+		"```bash\nautospec task unblock %s\nautospec implement\n```\n",
+		task.ID, task.Title, specName, message, task.ID)
+	parent := e.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, escalationIssueTimeout)
+	defer cancel()
+	return open(ctx, issue.NewIssue{
+		Title: fmt.Sprintf("Blocked task %s in %s: %s", task.ID, specName, task.Title),
+		Body:  body,
+	})
+}
+
+// formatBlockedAge formats how long a task has been blocked, e.g. "3d",
+// "5h" or "15m".
+func formatBlockedAge(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
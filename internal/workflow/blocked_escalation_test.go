@@ -0,0 +1,176 @@
+// Package workflow tests escalation of tasks that stay blocked.
+// Related: internal/workflow/blocked_escalation.go, internal/issue/create.go
+// Tags: workflow, blocked, escalation, notifications, issues
+
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/issue"
+	"github.com/ariel-frischer/autospec/internal/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscalateBlockedTasks(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		extra      string
+		escalate   string
+		issueErr   error
+		wantEvents []notify.EventType
+		wantIssue  bool
+		wantOutput []string
+	}{
+		"blocked past threshold notifies": {
+			extra:      `blocked_reason: "Waiting for API key"` + "\n        " + `blocked_at: "2026-03-08T12:00:00Z"`,
+			escalate:   "notify",
+			wantEvents: []notify.EventType{notify.EventTaskEscalated},
+			wantOutput: []string{"⚠ Task T002 has been blocked for 2d: Waiting for API key"},
+		},
+		"blocked below threshold": {
+			extra:    `blocked_at: "2026-03-10T06:00:00Z"`,
+			escalate: "notify",
+		},
+		"issue only": {
+			extra:      `blocked_at: "2026-03-01T12:00:00Z"`,
+			escalate:   "issue",
+			wantIssue:  true,
+			wantOutput: []string{"blocked for 9d: no reason given", "Opened https://github.com/acme/widgets/issues/9"},
+		},
+		"both links the issue": {
+			extra:      `blocked_at: "2026-03-01T12:00:00Z"`,
+			escalate:   "both",
+			wantEvents: []notify.EventType{notify.EventTaskEscalated},
+			wantIssue:  true,
+		},
+		"issue failure still notifies": {
+			extra:      `blocked_at: "2026-03-01T12:00:00Z"`,
+			escalate:   "both",
+			issueErr:   errors.New("not logged in"),
+			wantEvents: []notify.EventType{notify.EventTaskEscalated},
+			wantIssue:  true,
+			wantOutput: []string{"Warning: opening an issue for blocked task T002: not logged in"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specsDir := t.TempDir()
+			specDir := filepath.Join(specsDir, "003-auth")
+			require.NoError(t, os.MkdirAll(specDir, 0755))
+			writeWebhookTasks(t, specDir, "Completed", "Blocked", tt.extra)
+
+			rec, hooks := newWebhookRecorder(t)
+			var out bytes.Buffer
+			var issues []issue.NewIssue
+			e := &Executor{
+				SpecsDir:        specsDir,
+				StateDir:        t.TempDir(),
+				EscalateAfter:   24 * time.Hour,
+				EscalateBlocked: tt.escalate,
+				Webhooks:        hooks,
+				Output:          &out,
+				openIssue: func(_ context.Context, n issue.NewIssue) (string, error) {
+					issues = append(issues, n)
+					return "https://github.com/acme/widgets/issues/9", tt.issueErr
+				},
+			}
+
+			e.escalateBlockedTasks("003-auth", now)
+			assert.Equal(t, tt.wantEvents, rec.types())
+			for _, want := range tt.wantOutput {
+				assert.Contains(t, out.String(), want)
+			}
+			if !tt.wantIssue {
+				assert.Empty(t, issues)
+				return
+			}
+			require.Len(t, issues, 1)
+			assert.Equal(t, "Blocked task T002 in 003-auth: Wire API", issues[0].Title)
+			assert.Contains(t, issues[0].Body, "autospec task unblock T002")
+			if len(tt.wantEvents) > 0 && tt.issueErr == nil {
+				assert.Contains(t, rec.snapshot()[0].Message, "(https://github.com/acme/widgets/issues/9)")
+			}
+		})
+	}
+}
+
+func TestEscalateBlockedTasks_OncePerBlock(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	specDir := filepath.Join(specsDir, "003-auth")
+	require.NoError(t, os.MkdirAll(specDir, 0755))
+	rec, hooks := newWebhookRecorder(t)
+	e := &Executor{
+		SpecsDir:      specsDir,
+		StateDir:      t.TempDir(),
+		EscalateAfter: time.Hour,
+		Webhooks:      hooks,
+		Output:        &bytes.Buffer{},
+	}
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	// Without blocked_at the block counts from the first run that saw it
+	writeWebhookTasks(t, specDir, "Completed", "Blocked", "")
+	e.escalateBlockedTasks("003-auth", now)
+	assert.Empty(t, rec.types())
+	e.escalateBlockedTasks("003-auth", now.Add(2*time.Hour))
+	e.escalateBlockedTasks("003-auth", now.Add(3*time.Hour))
+	assert.Len(t, rec.types(), 1, "escalated once")
+
+	// Unblocking forgets the block; blocking again escalates again
+	writeWebhookTasks(t, specDir, "Completed", "Pending", "")
+	e.escalateBlockedTasks("003-auth", now.Add(4*time.Hour))
+	writeWebhookTasks(t, specDir, "Completed", "Blocked", `blocked_at: "2026-03-10T12:00:00Z"`)
+	e.escalateBlockedTasks("003-auth", now.Add(5*time.Hour))
+	assert.Len(t, rec.types(), 2, "escalated again after a new block")
+}
+
+func TestEscalateBlockedTasks_Disabled(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	specDir := filepath.Join(specsDir, "003-auth")
+	require.NoError(t, os.MkdirAll(specDir, 0755))
+	writeWebhookTasks(t, specDir, "Completed", "Blocked", `blocked_at: "2026-03-01T12:00:00Z"`)
+	rec, hooks := newWebhookRecorder(t)
+	stateDir := t.TempDir()
+	e := &Executor{SpecsDir: specsDir, StateDir: stateDir, Webhooks: hooks}
+
+	e.escalateBlockedTasks("003-auth", time.Now())
+	assert.Empty(t, rec.types())
+	assert.NoFileExists(t, filepath.Join(stateDir, BlockedTasksFileName))
+}
+
+func TestFormatBlockedAge(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		age  time.Duration
+		want string
+	}{
+		"minutes": {age: 15*time.Minute + 30*time.Second, want: "15m"},
+		"hours":   {age: 5*time.Hour + 59*time.Minute, want: "5h"},
+		"days":    {age: 50 * time.Hour, want: "2d"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, formatBlockedAge(tt.age))
+		})
+	}
+}
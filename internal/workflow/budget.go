@@ -0,0 +1,30 @@
+package workflow
+
+import "fmt"
+
+// checkBudget returns an error when the run or the spec has used up its
+// budget, before another agent session starts. Completed stages stay
+// checkpointed, so the run can be resumed once the limit is raised.
+func (e *Executor) checkBudget(ctx *stageExecutionContext) error {
+	if err := e.Budget.Check(ctx.specName); err != nil {
+		ctx.result.Error = err
+		fmt.Fprintf(e.output(), "⛔ Stopping before %s: %v\n", ctx.stage, err)
+		return err
+	}
+	return nil
+}
+
+// chargeBudget adds the usage of the last agent run to the budget. Failures
+// to persist the spec's spend only warn.
+func (e *Executor) chargeBudget(specName string) {
+	if e.Budget == nil {
+		return
+	}
+	reporter, ok := e.Claude.(UsageReporter)
+	if !ok || reporter.LastUsage() == nil {
+		return
+	}
+	if err := e.Budget.Charge(e.usageSpecName(specName), *reporter.LastUsage()); err != nil {
+		fmt.Fprintf(e.output(), "Warning: failed to record spec spend: %v\n", err)
+	}
+}
@@ -0,0 +1,72 @@
+// Package workflow tests budget enforcement between agent sessions.
+// Related: internal/workflow/budget.go, internal/budget/budget.go
+// Tags: workflow, budget, cost, tokens
+package workflow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/budget"
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteStage_StopsWhenBudgetExceeded(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config    budget.Config
+		wantScope string
+	}{
+		"run limit": {
+			config:    budget.Config{Run: budget.Limit{CostUSD: 0.5}},
+			wantScope: "run",
+		},
+		"spec limit": {
+			config:    budget.Config{Spec: budget.Limit{Tokens: 1000}},
+			wantScope: "spec",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := &usageMockRunner{usage: &cliagent.Usage{InputTokens: 1200, CostUSD: 0.6}}
+			stateDir := t.TempDir()
+			executor := &Executor{
+				Claude:   runner,
+				StateDir: stateDir,
+				SpecsDir: t.TempDir(),
+				Budget:   budget.NewTracker(tt.config, stateDir),
+			}
+			validate := func(string) error { return nil }
+
+			_, err := executor.ExecuteStage("001-test", StagePlan, "/autospec.plan", validate)
+			require.NoError(t, err, "the first session starts within budget")
+
+			result, err := executor.ExecuteStage("001-test", StageTasks, "/autospec.tasks", validate)
+			var exceeded *budget.ExceededError
+			require.True(t, errors.As(err, &exceeded))
+			assert.Equal(t, tt.wantScope, exceeded.Scope)
+			assert.False(t, result.Success)
+			assert.Len(t, runner.executeCalls, 1, "no session starts once the budget is used up")
+		})
+	}
+}
+
+func TestExecuteStage_NoBudget(t *testing.T) {
+	t.Parallel()
+
+	runner := &usageMockRunner{usage: &cliagent.Usage{CostUSD: 100}}
+	executor := &Executor{Claude: runner, StateDir: t.TempDir(), SpecsDir: t.TempDir()}
+	validate := func(string) error { return nil }
+
+	for range 2 {
+		_, err := executor.ExecuteStage("001-test", StagePlan, "/autospec.plan", validate)
+		require.NoError(t, err)
+	}
+	assert.Len(t, runner.executeCalls, 2)
+}
@@ -0,0 +1,79 @@
+// Package workflow provides workflow checkpointing for resuming full workflow runs.
+// Related: internal/retry/checkpoint.go, internal/workflow/orchestrator.go
+// Tags: workflow, checkpoint, resume, state
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/retry"
+)
+
+// checkpointArtifacts maps checkpointed stages to the artifact they produce.
+// A completed stage is only skipped on resume if its artifact still exists.
+var checkpointArtifacts = map[Stage]string{
+	StageSpecify: "spec.yaml",
+	StagePlan:    "plan.yaml",
+	StageTasks:   "tasks.yaml",
+}
+
+// loadResumeCheckpoint returns the checkpoint a full workflow run should start from.
+// Without resume, or when no usable checkpoint exists for the feature description,
+// it returns an empty checkpoint so the run starts fresh and records its progress.
+func (w *WorkflowOrchestrator) loadResumeCheckpoint(featureDescription string, resume bool) *retry.WorkflowCheckpoint {
+	fresh := &retry.WorkflowCheckpoint{FeatureDescription: featureDescription}
+	if !resume {
+		return fresh
+	}
+
+	cp, err := retry.FindCheckpoint(w.Executor.StateDir, featureDescription)
+	if err != nil {
+		fmt.Printf("Warning: failed to load workflow checkpoint: %v\n", err)
+		return fresh
+	}
+	if cp == nil || len(cp.CompletedStages) == 0 {
+		w.debugLog("No workflow checkpoint found for %q, starting fresh", featureDescription)
+		return fresh
+	}
+
+	fmt.Printf("Resuming %s from checkpoint (completed: %s)\n\n", cp.SpecName, strings.Join(cp.CompletedStages, ", "))
+	return cp
+}
+
+// canSkipStage reports whether a checkpointed stage completed and its artifact is still present.
+func (w *WorkflowOrchestrator) canSkipStage(cp *retry.WorkflowCheckpoint, stage Stage) bool {
+	if cp == nil || cp.SpecName == "" || !cp.IsStageCompleted(string(stage)) {
+		return false
+	}
+	artifact := filepath.Join(w.SpecsDir, cp.SpecName, checkpointArtifacts[stage])
+	if _, err := os.Stat(artifact); err != nil {
+		fmt.Printf("Warning: %s is missing, re-running %s stage\n", artifact, stage)
+		return false
+	}
+	return true
+}
+
+// recordCheckpoint marks a stage complete in the checkpoint and persists it.
+// Failures are reported but never fail the workflow.
+func (w *WorkflowOrchestrator) recordCheckpoint(cp *retry.WorkflowCheckpoint, specName string, stage Stage) {
+	if cp == nil {
+		return
+	}
+	cp.SpecName = specName
+	if !cp.IsStageCompleted(string(stage)) {
+		cp.CompletedStages = append(cp.CompletedStages, string(stage))
+	}
+	if err := retry.MarkCheckpointStage(w.Executor.StateDir, specName, cp.FeatureDescription, string(stage)); err != nil {
+		fmt.Printf("Warning: failed to save workflow checkpoint: %v\n", err)
+	}
+}
+
+// clearCheckpoint removes the checkpoint once the full workflow has completed.
+func (w *WorkflowOrchestrator) clearCheckpoint(specName string) {
+	if err := retry.ClearCheckpoint(w.Executor.StateDir, specName); err != nil {
+		fmt.Printf("Warning: failed to clear workflow checkpoint: %v\n", err)
+	}
+}
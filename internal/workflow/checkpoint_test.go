@@ -0,0 +1,165 @@
+// Package workflow tests full workflow checkpointing and resume.
+// Related: internal/workflow/checkpoint.go, internal/retry/checkpoint.go
+// Tags: workflow, checkpoint, resume, orchestrator
+package workflow
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const checkpointTestFeature = "Add user auth"
+
+// newCheckpointTestOrchestrator creates an orchestrator with mock stage and phase executors.
+func newCheckpointTestOrchestrator(t *testing.T) (*WorkflowOrchestrator, *MockStageExecutor, *MockPhaseExecutor) {
+	t.Helper()
+	cfg := &config.Configuration{
+		SpecsDir:   t.TempDir(),
+		StateDir:   filepath.Join(t.TempDir(), "state"),
+		MaxRetries: 1,
+	}
+	mockStage := NewMockStageExecutor()
+	mockPhase := NewMockPhaseExecutor()
+	orch := NewWorkflowOrchestratorWithExecutors(cfg, ExecutorOptions{
+		StageExecutor: mockStage,
+		PhaseExecutor: mockPhase,
+	})
+	orch.SkipPreflight = true
+	return orch, mockStage, mockPhase
+}
+
+// writeCheckpointArtifacts creates the named artifacts for the mock spec.
+func writeCheckpointArtifacts(t *testing.T, orch *WorkflowOrchestrator, specName string, files ...string) {
+	t.Helper()
+	specDir := filepath.Join(orch.SpecsDir, specName)
+	require.NoError(t, os.MkdirAll(specDir, 0755))
+	for _, f := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(specDir, f), []byte("{}"), 0644))
+	}
+}
+
+func TestRunFullWorkflow_Checkpoint(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		completed   []string
+		artifacts   []string
+		resume      bool
+		wantSpecify int
+		wantPlan    int
+		wantTasks   int
+		wantResume  bool
+	}{
+		"no checkpoint runs every stage": {
+			resume:      true,
+			wantSpecify: 1,
+			wantPlan:    1,
+			wantTasks:   1,
+			wantResume:  true,
+		},
+		"resume skips completed stages": {
+			completed:  []string{"specify", "plan"},
+			artifacts:  []string{"spec.yaml", "plan.yaml"},
+			resume:     true,
+			wantTasks:  1,
+			wantResume: true,
+		},
+		"resume with all planning stages complete goes straight to implement": {
+			completed:  []string{"specify", "plan", "tasks"},
+			artifacts:  []string{"spec.yaml", "plan.yaml", "tasks.yaml"},
+			resume:     true,
+			wantResume: true,
+		},
+		"missing artifact re-runs its stage": {
+			completed:  []string{"specify", "plan"},
+			artifacts:  []string{"spec.yaml"},
+			resume:     true,
+			wantPlan:   1,
+			wantTasks:  1,
+			wantResume: true,
+		},
+		"without resume checkpoint is ignored": {
+			completed:   []string{"specify", "plan"},
+			artifacts:   []string{"spec.yaml", "plan.yaml"},
+			resume:      false,
+			wantSpecify: 1,
+			wantPlan:    1,
+			wantTasks:   1,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			orch, mockStage, mockPhase := newCheckpointTestOrchestrator(t)
+			specName := mockStage.SpecifyResult
+			writeCheckpointArtifacts(t, orch, specName, tt.artifacts...)
+			for _, stage := range tt.completed {
+				require.NoError(t, retry.MarkCheckpointStage(orch.Executor.StateDir, specName, checkpointTestFeature, stage))
+			}
+
+			require.NoError(t, orch.RunFullWorkflow(checkpointTestFeature, tt.resume))
+
+			assert.Len(t, mockStage.SpecifyCalls, tt.wantSpecify)
+			assert.Len(t, mockStage.PlanCalls, tt.wantPlan)
+			assert.Len(t, mockStage.TasksCalls, tt.wantTasks)
+			require.Len(t, mockPhase.DefaultCalls, 1)
+			assert.Equal(t, specName, mockPhase.DefaultCalls[0].SpecName)
+			assert.Equal(t, tt.wantResume, mockPhase.DefaultCalls[0].Resume)
+
+			cp, err := retry.LoadCheckpoint(orch.Executor.StateDir, specName)
+			require.NoError(t, err)
+			assert.Nil(t, cp, "checkpoint should be cleared after a successful run")
+		})
+	}
+}
+
+func TestRunFullWorkflow_CheckpointSurvivesFailure(t *testing.T) {
+	t.Parallel()
+
+	orch, mockStage, mockPhase := newCheckpointTestOrchestrator(t)
+	mockPhase.DefaultError = errors.New("agent crashed")
+
+	err := orch.RunFullWorkflow(checkpointTestFeature, false)
+	require.Error(t, err)
+
+	cp, err := retry.FindCheckpoint(orch.Executor.StateDir, checkpointTestFeature)
+	require.NoError(t, err)
+	require.NotNil(t, cp)
+	assert.Equal(t, mockStage.SpecifyResult, cp.SpecName)
+	assert.Equal(t, []string{"specify", "plan", "tasks"}, cp.CompletedStages)
+}
+
+func TestRunFullWorkflow_CheckpointRecordsPartialProgress(t *testing.T) {
+	t.Parallel()
+
+	orch, mockStage, _ := newCheckpointTestOrchestrator(t)
+	mockStage.TasksError = errors.New("tasks validation failed")
+
+	require.Error(t, orch.RunFullWorkflow(checkpointTestFeature, false))
+
+	cp, err := retry.LoadCheckpoint(orch.Executor.StateDir, mockStage.SpecifyResult)
+	require.NoError(t, err)
+	require.NotNil(t, cp)
+	assert.Equal(t, []string{"specify", "plan"}, cp.CompletedStages)
+}
+
+func TestRunCompleteWorkflow_DoesNotCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	orch, mockStage, _ := newCheckpointTestOrchestrator(t)
+
+	require.NoError(t, orch.RunCompleteWorkflow(checkpointTestFeature))
+
+	cp, err := retry.LoadCheckpoint(orch.Executor.StateDir, mockStage.SpecifyResult)
+	require.NoError(t, err)
+	assert.Nil(t, cp)
+}
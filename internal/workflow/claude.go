@@ -10,6 +10,7 @@ import (
 
 	"github.com/ariel-frischer/autospec/internal/cliagent"
 	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/session"
 )
 
 // ClaudeExecutor handles CLI agent command execution.
@@ -19,6 +20,10 @@ type ClaudeExecutor struct {
 
 	Timeout int // Timeout in seconds (0 = no timeout)
 
+	// StageTimeout overrides Timeout for the current stage (0 = use Timeout).
+	// Set per stage by the Executor from the phase_timeouts config.
+	StageTimeout time.Duration
+
 	// OutputStyle controls how stream-json output is formatted for display.
 	// When set and stream-json mode is detected, output is formatted using cclean.
 	// Valid values: default, compact, minimal, plain, raw
@@ -32,13 +37,134 @@ type ClaudeExecutor struct {
 	// When true (default), uses syscall.Exec for full terminal control in interactive mode.
 	// Set to false for multi-stage runs where we need to continue after interactive stages.
 	ReplaceProcessForInteractive bool
+
+	// StageAgent replaces Agent for the current stage (nil = use Agent).
+	// Set per stage by the Executor from the stage_agents config.
+	StageAgent cliagent.Agent
+
+	// SubAgent names the agent-internal sub-agent to run (e.g., opencode --agent).
+	// Empty runs the agent's default. Set per stage by the Executor.
+	SubAgent string
+
+	// Model selects the model the agent runs (empty = agent default).
+	// Set per stage by the Executor from the model config.
+	Model string
+
+	// ResumeSession is the agent session to continue (empty = new session).
+	// Set per stage by the Executor when session_mode is continuous.
+	ResumeSession string
+
+	// Sandbox runs the agent inside a container (nil = run on the host).
+	// Set per stage by the Executor from the sandbox config.
+	Sandbox *cliagent.SandboxConfig
+
+	// ToolPolicy restricts the tools the agent may use (nil = agent defaults).
+	// Set per stage by the Executor from .autospec/policy.yaml.
+	ToolPolicy *cliagent.ToolPolicy
+
+	// WorkDir is the directory the agent runs in (empty = current directory).
+	// Set by WorkflowOrchestrator.SetWorkDir, e.g. for implement --worktree.
+	WorkDir string
+
+	// StallTimeout stops a headless agent that prints nothing for this long
+	// (0 = never); the run then fails with a *StallError.
+	StallTimeout time.Duration
+
+	// InputAlert is called when a headless agent seems to be waiting for
+	// input: its output ends in a prompt, or it printed nothing for
+	// InputIdleThreshold (0 = prompts only). nil turns watching off.
+	// Set per stage by the Executor from the notifications config.
+	InputAlert         func(reason string)
+	InputIdleThreshold time.Duration
+
+	// lastUsage holds token usage reported by the most recent execution.
+	lastUsage *cliagent.Usage
+
+	// lastSessionID holds the session ID reported by the most recent execution.
+	lastSessionID string
+
+	// transcript receives a copy of agent output while set. Set per stage by the Executor.
+	transcript *session.Recorder
+}
+
+// SetSubAgent implements SubAgentSelector.
+func (c *ClaudeExecutor) SetSubAgent(name string) {
+	c.SubAgent = name
+}
+
+// SetModel implements ModelSelector.
+func (c *ClaudeExecutor) SetModel(name string) {
+	c.Model = name
+}
+
+// SetResumeSession implements SessionResumer.
+func (c *ClaudeExecutor) SetResumeSession(id string) {
+	c.ResumeSession = id
+}
+
+// LastSessionID implements SessionResumer.
+func (c *ClaudeExecutor) LastSessionID() string {
+	return c.lastSessionID
+}
+
+// SetSandbox implements SandboxSelector.
+func (c *ClaudeExecutor) SetSandbox(sandbox *cliagent.SandboxConfig) {
+	c.Sandbox = sandbox
+}
+
+// SetToolPolicy implements ToolPolicySelector.
+func (c *ClaudeExecutor) SetToolPolicy(p *cliagent.ToolPolicy) {
+	c.ToolPolicy = p
+}
+
+// SetInputAlert implements InputAlerter.
+func (c *ClaudeExecutor) SetInputAlert(idleThreshold time.Duration, alert func(reason string)) {
+	c.InputIdleThreshold = idleThreshold
+	c.InputAlert = alert
+}
+
+// SetStageAgent implements StageAgentSelector.
+func (c *ClaudeExecutor) SetStageAgent(agent cliagent.Agent) {
+	c.StageAgent = agent
+}
+
+// agent returns the agent for the current execution.
+func (c *ClaudeExecutor) agent() cliagent.Agent {
+	if c.StageAgent != nil {
+		return c.StageAgent
+	}
+	return c.Agent
+}
+
+// SetStageTimeout implements StageTimeoutSetter.
+func (c *ClaudeExecutor) SetStageTimeout(d time.Duration) {
+	c.StageTimeout = d
+}
+
+// timeout returns the limit for the current execution (0 = no timeout).
+func (c *ClaudeExecutor) timeout() time.Duration {
+	if c.StageTimeout > 0 {
+		return c.StageTimeout
+	}
+	return time.Duration(c.Timeout) * time.Second
+}
+
+// CaptureTranscript implements TranscriptCapturer.
+func (c *ClaudeExecutor) CaptureTranscript(rec *session.Recorder) {
+	c.transcript = rec
+}
+
+// LastUsage returns token usage reported by the most recent Execute or
+// StreamCommand call, or nil if the agent reported none.
+func (c *ClaudeExecutor) LastUsage() *cliagent.Usage {
+	return c.lastUsage
 }
 
 // Execute runs an agent command with the given prompt.
 // Streams output to stdout in real-time.
-// If Timeout > 0, the command is terminated after the timeout duration.
+// If a timeout applies, the agent is asked to exit (then killed) once it passes.
 func (c *ClaudeExecutor) Execute(prompt string) error {
-	if c.Agent == nil {
+	if c.agent() == nil {
 		return fmt.Errorf("no agent configured")
 	}
 	return c.executeWithAgent(prompt, false)
@@ -48,7 +174,7 @@ func (c *ClaudeExecutor) Execute(prompt string) error {
 // Unlike Execute, this skips headless flags (-p, --output-format)
 // to allow multi-turn conversation with the user.
 func (c *ClaudeExecutor) ExecuteInteractive(prompt string) error {
-	if c.Agent == nil {
+	if c.agent() == nil {
 		return fmt.Errorf("no agent configured")
 	}
 	return c.executeWithAgent(prompt, true)
@@ -64,21 +190,51 @@ func (c *ClaudeExecutor) executeWithAgent(prompt string, interactive bool) error
 
 	// Determine stdout writer, potentially wrapping with formatter
 	// Skip formatter for interactive mode (no stream-json output)
-	var stdout io.Writer = os.Stdout
+	var stdout, stderr io.Writer = os.Stdout, os.Stderr
+	// Capture raw output before formatting so the transcript keeps every event
+	if c.transcript != nil && !interactive {
+		stdout = io.MultiWriter(stdout, c.transcript.Stdout())
+		stderr = io.MultiWriter(stderr, c.transcript.Stderr())
+		if c.detectStreamJsonMode() {
+			c.transcript.SetStreamJSON()
+		}
+	}
+	var stall *stallWatch
+	if c.StallTimeout > 0 && !interactive {
+		var stop context.CancelFunc
+		ctx, stop = context.WithCancel(ctx)
+		defer stop()
+		stall = newStallWatch(c.StallTimeout, stop)
+		defer stall.start()()
+		stdout = io.MultiWriter(stdout, stall)
+		stderr = io.MultiWriter(stderr, stall)
+	}
+	if c.InputAlert != nil && !interactive {
+		watch := newInputWatch(c.InputIdleThreshold, c.InputAlert)
+		defer watch.start()()
+		stdout = io.MultiWriter(stdout, watch)
+		stderr = io.MultiWriter(stderr, watch)
+	}
 	if !interactive {
-		stdout = c.getFormattedStdout(os.Stdout)
+		stdout = c.getFormattedStdout(stdout)
 	}
 
 	opts := cliagent.ExecOptions{
 		Stdout:          stdout,
-		Stderr:          os.Stderr,
-		Timeout:         time.Duration(c.Timeout) * time.Second,
+		Stderr:          stderr,
+		Timeout:         c.timeout(),
 		UseSubscription: c.UseSubscription,
 		Interactive:     interactive,
 		ReplaceProcess:  interactive && c.ReplaceProcessForInteractive,
+		SubAgent:        c.SubAgent,
+		Model:           c.Model,
+		ResumeSession:   c.ResumeSession,
+		Sandbox:         c.Sandbox,
+		ToolPolicy:      c.ToolPolicy,
+		WorkDir:         c.WorkDir,
 	}
 
-	result, err := c.Agent.Execute(ctx, prompt, opts)
+	result, err := c.runAgent(ctx, prompt, opts)
 
 	// Flush formatter if used (only applies to non-interactive mode)
 	if !interactive {
@@ -86,36 +242,58 @@ func (c *ClaudeExecutor) executeWithAgent(prompt string, interactive bool) error
 	}
 
 	if err != nil {
+		if stall != nil && stall.stalled() {
+			return &StallError{Agent: c.agent().Name(), Idle: c.StallTimeout}
+		}
 		// Check for timeout specifically
 		if ctx.Err() == context.DeadlineExceeded {
-			return NewTimeoutError(time.Duration(c.Timeout)*time.Second, c.FormatCommand(prompt))
+			return NewTimeoutError(c.timeout(), c.FormatCommand(prompt))
 		}
-		return fmt.Errorf("agent %s command failed: %w", c.Agent.Name(), err)
+		return fmt.Errorf("agent %s command failed: %w", c.agent().Name(), err)
 	}
 
-	// Check exit code
-	if result.ExitCode != 0 {
-		return fmt.Errorf("agent %s exited with code %d", c.Agent.Name(), result.ExitCode)
+	return c.exitError(result)
+}
+
+// runAgent executes the agent and remembers the token usage and session ID
+// it reported.
+func (c *ClaudeExecutor) runAgent(ctx context.Context, prompt string, opts cliagent.ExecOptions) (*cliagent.Result, error) {
+	c.lastUsage = nil
+	c.lastSessionID = ""
+	result, err := c.agent().Execute(ctx, prompt, opts)
+	if result != nil {
+		c.lastUsage = result.Usage
+		c.lastSessionID = result.SessionID
+		if c.transcript != nil {
+			c.transcript.SetExitCode(result.ExitCode)
+		}
 	}
-	return nil
+	return result, err
 }
 
 // createTimeoutContext creates a context with optional timeout
 func (c *ClaudeExecutor) createTimeoutContext() (context.Context, context.CancelFunc) {
-	if c.Timeout > 0 {
-		return context.WithTimeout(context.Background(), time.Duration(c.Timeout)*time.Second)
+	if timeout := c.timeout(); timeout > 0 {
+		return context.WithTimeout(context.Background(), timeout)
 	}
 	return context.Background(), nil
 }
 
 // FormatCommand returns a human-readable command string for display and error messages.
 func (c *ClaudeExecutor) FormatCommand(prompt string) string {
-	if c.Agent == nil {
+	if c.agent() == nil {
 		return "[no agent configured]"
 	}
-	cmd, err := c.Agent.BuildCommand(prompt, cliagent.ExecOptions{})
+	cmd, err := c.agent().BuildCommand(prompt, cliagent.ExecOptions{
+		SubAgent:      c.SubAgent,
+		Model:         c.Model,
+		ResumeSession: c.ResumeSession,
+		Sandbox:       c.Sandbox,
+		ToolPolicy:    c.ToolPolicy,
+		WorkDir:       c.WorkDir,
+	})
 	if err != nil {
-		return fmt.Sprintf("%s [error: %v]", c.Agent.Name(), err)
+		return fmt.Sprintf("%s [error: %v]", c.agent().Name(), err)
 	}
 	return strings.Join(cmd.Args, " ")
 }
@@ -128,9 +306,9 @@ func (c *ClaudeExecutor) ExecuteSpecKitCommand(command string) error {
 
 // StreamCommand executes a command and streams output to the provided writer.
 // This is useful for testing or capturing output.
-// If Timeout > 0, the command is terminated after the timeout duration.
+// If a timeout applies, the agent is asked to exit (then killed) once it passes.
 func (c *ClaudeExecutor) StreamCommand(prompt string, stdout, stderr io.Writer) error {
-	if c.Agent == nil {
+	if c.agent() == nil {
 		return fmt.Errorf("no agent configured")
 	}
 
@@ -145,26 +323,45 @@ func (c *ClaudeExecutor) StreamCommand(prompt string, stdout, stderr io.Writer)
 	opts := cliagent.ExecOptions{
 		Stdout:          formattedStdout,
 		Stderr:          stderr,
-		Timeout:         time.Duration(c.Timeout) * time.Second,
+		Timeout:         c.timeout(),
 		UseSubscription: c.UseSubscription,
+		SubAgent:        c.SubAgent,
+		Model:           c.Model,
+		ResumeSession:   c.ResumeSession,
+		Sandbox:         c.Sandbox,
+		ToolPolicy:      c.ToolPolicy,
+		WorkDir:         c.WorkDir,
 	}
 
-	result, err := c.Agent.Execute(ctx, prompt, opts)
+	result, err := c.runAgent(ctx, prompt, opts)
 
 	// Flush formatter if used
 	c.flushFormatter(formattedStdout)
 
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return NewTimeoutError(time.Duration(c.Timeout)*time.Second, c.FormatCommand(prompt))
+			return NewTimeoutError(c.timeout(), c.FormatCommand(prompt))
 		}
-		return fmt.Errorf("agent %s command failed: %w", c.Agent.Name(), err)
+		return fmt.Errorf("agent %s command failed: %w", c.agent().Name(), err)
 	}
 
-	if result.ExitCode != 0 {
-		return fmt.Errorf("agent %s exited with code %d", c.Agent.Name(), result.ExitCode)
+	return c.exitError(result)
+}
+
+// exitError returns nil for a zero exit code, a *RateLimitError if the agent
+// failed on a rate limit, an *AgentUnreachableError if it couldn't reach or
+// authenticate with its provider, or a generic exit error.
+func (c *ClaudeExecutor) exitError(result *cliagent.Result) error {
+	if result.ExitCode == 0 {
+		return nil
+	}
+	if limit := result.RateLimit; limit != nil {
+		return &RateLimitError{Agent: c.agent().Name(), RetryAfter: limit.RetryAfter, Message: limit.Message}
+	}
+	if unreachable := result.Unreachable; unreachable != nil {
+		return &AgentUnreachableError{Agent: c.agent().Name(), Reason: string(unreachable.Reason), Message: unreachable.Message}
 	}
-	return nil
+	return fmt.Errorf("agent %s exited with code %d", c.agent().Name(), result.ExitCode)
 }
 
 // getFormattedStdout returns either a FormatterWriter or the original writer.
@@ -207,10 +404,10 @@ func (c *ClaudeExecutor) detectStreamJsonMode() bool {
 
 // getCommandArgs returns the args that will be used for command execution.
 func (c *ClaudeExecutor) getCommandArgs() []string {
-	if c.Agent == nil {
+	if c.agent() == nil {
 		return nil
 	}
-	cmd, err := c.Agent.BuildCommand("", cliagent.ExecOptions{})
+	cmd, err := c.agent().BuildCommand("", cliagent.ExecOptions{})
 	if err != nil {
 		return nil
 	}
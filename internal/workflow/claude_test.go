@@ -123,6 +123,78 @@ func TestClaudeExecutor_Timeout(t *testing.T) {
 	assert.True(t, errors.As(err, &timeoutErr), "Error should be TimeoutError")
 }
 
+// TestClaudeExecutor_StageTimeout tests that a stage timeout overrides the global timeout
+func TestClaudeExecutor_StageTimeout(t *testing.T) {
+	t.Parallel()
+
+	customAgent, err := cliagent.NewCustomAgentFromConfig(cliagent.CustomAgentConfig{
+		Command: "sleep",
+		Args:    []string{"{{PROMPT}}"},
+	})
+	require.NoError(t, err)
+
+	executor := &ClaudeExecutor{
+		Agent:   customAgent,
+		Timeout: 60,
+	}
+	executor.SetStageTimeout(200 * time.Millisecond)
+
+	err = executor.Execute("10")
+	var timeoutErr *TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, 200*time.Millisecond, timeoutErr.Timeout)
+}
+
+// TestClaudeExecutor_StageAgent tests that a stage agent replaces the default agent until cleared
+func TestClaudeExecutor_StageAgent(t *testing.T) {
+	t.Parallel()
+
+	defaultAgent, err := cliagent.NewCustomAgentFromConfig(cliagent.CustomAgentConfig{
+		Command: "echo",
+		Args:    []string{"default", "{{PROMPT}}"},
+	})
+	require.NoError(t, err)
+	stageAgent, err := cliagent.NewCustomAgentFromConfig(cliagent.CustomAgentConfig{
+		Command: "echo",
+		Args:    []string{"stage", "{{PROMPT}}"},
+	})
+	require.NoError(t, err)
+
+	executor := &ClaudeExecutor{Agent: defaultAgent, Timeout: 60}
+
+	var stdout, stderr bytes.Buffer
+	executor.SetStageAgent(stageAgent)
+	require.NoError(t, executor.StreamCommand("plan", &stdout, &stderr))
+	assert.Equal(t, "stage plan\n", stdout.String())
+
+	stdout.Reset()
+	executor.SetStageAgent(nil)
+	require.NoError(t, executor.StreamCommand("implement", &stdout, &stderr))
+	assert.Equal(t, "default implement\n", stdout.String())
+}
+
+// TestClaudeExecutor_SessionID tests that the session reported in the agent's
+// result event is captured for the next stage to resume
+func TestClaudeExecutor_SessionID(t *testing.T) {
+	t.Parallel()
+
+	agent := &cliagent.BaseAgent{
+		AgentName: "echo",
+		Cmd:       "echo",
+		AgentCaps: cliagent.Caps{
+			PromptDelivery: cliagent.PromptDelivery{Method: cliagent.PromptMethodPositional},
+		},
+	}
+	executor := &ClaudeExecutor{Agent: agent, Timeout: 60}
+
+	var stdout, stderr bytes.Buffer
+	require.NoError(t, executor.StreamCommand(`{"type":"result","session_id":"abc-123"}`, &stdout, &stderr))
+	assert.Equal(t, "abc-123", executor.LastSessionID())
+
+	require.NoError(t, executor.StreamCommand("no session", &stdout, &stderr))
+	assert.Empty(t, executor.LastSessionID())
+}
+
 // TestClaudeExecutor_Timeout_CompletesBeforeTimeout tests command completing before timeout
 func TestClaudeExecutor_Timeout_CompletesBeforeTimeout(t *testing.T) {
 	t.Parallel()
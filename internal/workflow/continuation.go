@@ -0,0 +1,60 @@
+package workflow
+
+import (
+	"errors"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+// continuationError is a validation error for unfinished implement work. It
+// carries a continuation prompt listing the remaining tasks, which
+// handleStageRetry appends to the retry command.
+type continuationError struct {
+	err    error
+	prompt string
+}
+
+func (c *continuationError) Error() string { return c.err.Error() }
+
+func (c *continuationError) Unwrap() error { return c.err }
+
+// withContinuation attaches a continuation prompt for the spec's incomplete
+// tasks to err. phaseNumber limits the prompt to one phase; 0 lists all phases.
+// err is returned unchanged if it is nil or the tasks file cannot be read.
+func withContinuation(err error, specDir string, phaseNumber int) error {
+	if err == nil {
+		return nil
+	}
+	phases, loadErr := validation.LoadTaskPhases(validation.GetTasksFilePath(specDir))
+	if loadErr != nil {
+		return err
+	}
+	if phaseNumber > 0 {
+		phases = filterPhases(phases, phaseNumber)
+	}
+	if len(phases) == 0 {
+		return err
+	}
+	prompt := validation.GenerateContinuationPrompt(specDir, string(StageImplement), phases)
+	return &continuationError{err: err, prompt: prompt}
+}
+
+// filterPhases returns the phase with the given number, if present.
+func filterPhases(phases []validation.Phase, number int) []validation.Phase {
+	for _, phase := range phases {
+		if phase.Number == number {
+			return []validation.Phase{phase}
+		}
+	}
+	return nil
+}
+
+// continuationPrompt returns the continuation prompt attached to a validation
+// error, or "" if there is none.
+func continuationPrompt(err error) string {
+	var cont *continuationError
+	if errors.As(err, &cont) {
+		return cont.prompt
+	}
+	return ""
+}
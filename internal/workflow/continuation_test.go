@@ -0,0 +1,123 @@
+// Package workflow tests continuation prompts attached to implement retries.
+// Related: internal/workflow/continuation.go
+// Tags: workflow, retry, continuation, implement
+
+package workflow
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const continuationTasksYAML = `phases:
+  - number: 1
+    title: "Setup"
+    tasks:
+      - id: "T001"
+        title: "Create module"
+        status: "Completed"
+      - id: "T002"
+        title: "Add config loader"
+        status: "Pending"
+  - number: 2
+    title: "Core"
+    tasks:
+      - id: "T003"
+        title: "Add parser"
+        status: "InProgress"
+`
+
+func writeContinuationTasks(t *testing.T) string {
+	t.Helper()
+	specDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "tasks.yaml"), []byte(continuationTasksYAML), 0o644))
+	return specDir
+}
+
+func TestWithContinuation(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		phaseNumber  int
+		missingTasks bool
+		wantContains []string
+		wantMissing  []string
+	}{
+		"all phases": {
+			wantContains: []string{"implement phase is incomplete", "2 task(s) remain", "T002: Add config loader", "T003: Add parser", "tasks.yaml"},
+		},
+		"single phase": {
+			phaseNumber:  2,
+			wantContains: []string{"1 task(s) remain", "Phase 2: Core", "T003: Add parser"},
+			wantMissing:  []string{"T002"},
+		},
+		"unknown phase": {
+			phaseNumber: 9,
+		},
+		"missing tasks file": {
+			missingTasks: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			specDir := t.TempDir()
+			if !tt.missingTasks {
+				specDir = writeContinuationTasks(t)
+			}
+			base := errors.New("implementation incomplete: 2 tasks remain")
+
+			err := withContinuation(base, specDir, tt.phaseNumber)
+
+			assert.ErrorIs(t, err, base)
+			assert.Equal(t, base.Error(), err.Error())
+			prompt := continuationPrompt(err)
+			if tt.wantContains == nil {
+				assert.Empty(t, prompt)
+				return
+			}
+			for _, want := range tt.wantContains {
+				assert.Contains(t, prompt, want)
+			}
+			for _, unwanted := range tt.wantMissing {
+				assert.NotContains(t, prompt, unwanted)
+			}
+		})
+	}
+}
+
+func TestWithContinuation_NilError(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, withContinuation(nil, writeContinuationTasks(t), 0))
+}
+
+func TestExecuteStage_RetryIncludesContinuation(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	specDir := filepath.Join(specsDir, "001-auth")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "tasks.yaml"), []byte(continuationTasksYAML), 0o644))
+
+	claude := &mockClaudeExecutor{}
+	executor := &Executor{Claude: claude, StateDir: t.TempDir(), SpecsDir: specsDir, MaxRetries: 1}
+	validateFunc := func(sd string) error {
+		return withContinuation(errors.New("implementation incomplete: 2 tasks remain (1 pending, 1 in-progress)"), sd, 0)
+	}
+
+	_, err := executor.ExecuteStage("001-auth", StageImplement, "/autospec.implement", validateFunc)
+
+	require.Error(t, err)
+	require.Len(t, claude.executeCalls, 2)
+	retry := claude.executeCalls[1]
+	assert.Contains(t, retry, "RETRY 1/1")
+	assert.Contains(t, retry, "- implementation incomplete: 2 tasks remain")
+	assert.Contains(t, retry, "- [ ] T002: Add config loader")
+	assert.NotContains(t, claude.executeCalls[0], "T002")
+}
@@ -0,0 +1,54 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+)
+
+// customPhasePromptPath returns a custom phase's prompt template path, which
+// defaults to <PromptsDir>/<name>.tmpl.
+func (e *Executor) customPhasePromptPath(name string, phase config.CustomPhaseConfig) string {
+	if phase.Prompt != "" {
+		return phase.Prompt
+	}
+	dir := e.PromptsDir
+	if dir == "" {
+		dir = PromptsDir()
+	}
+	return filepath.Join(dir, name+".tmpl")
+}
+
+// ExecuteCustomPhase runs a custom_phases entry as an agent session. The
+// prompt template is rendered like stage templates, and the phase's validate
+// command decides whether the session succeeded; its output is fed into the
+// retry prompt. Retry state, progress and hooks are keyed by the phase name.
+func (e *Executor) ExecuteCustomPhase(specName, name string, phase config.CustomPhaseConfig) error {
+	path := e.customPhasePromptPath(name, phase)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading %s prompt template: %w", name, err)
+	}
+	prompt, err := e.renderPromptTemplate(path, string(content), Stage(name), PromptData{SpecName: specName})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Executing: %s phase (%s)\n", name, path)
+	result, err := e.ExecuteStage(specName, Stage(name), prompt, func(specDir string) error {
+		if strings.TrimSpace(phase.Validate) == "" {
+			return nil
+		}
+		return e.runHook(name+" validation", phase.Validate, Stage(name), specName, specDir)
+	})
+	if err != nil {
+		if result.Exhausted {
+			return fmt.Errorf("%s phase exhausted retries after %d total attempts: %w", name, result.RetryCount+1, err)
+		}
+		return fmt.Errorf("%s phase failed: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Package workflow tests custom agent phases.
+// Related: internal/workflow/custom_phase.go
+// Tags: workflow, pipeline, custom-phase, retry, prompt
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteCustomPhase(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		validate    func(dir string) string
+		noTemplate  bool
+		maxRetries  int
+		wantErr     string
+		wantCalls   int
+		wantInRetry string
+	}{
+		"renders template and passes without validation": {
+			validate:  func(string) string { return "" },
+			wantCalls: 1,
+		},
+		"failing validation is retried with its output": {
+			validate: func(dir string) string {
+				marker := filepath.Join(dir, "reviewed")
+				return "test -f " + marker + " || { touch " + marker + "; echo 'security.yaml: missing threat model' >&2; exit 1; }"
+			},
+			maxRetries:  1,
+			wantCalls:   2,
+			wantInRetry: "security.yaml: missing threat model",
+		},
+		"validation failure without retries fails the phase": {
+			validate:  func(string) string { return "exit 1" },
+			wantErr:   "security-review phase exhausted retries",
+			wantCalls: 1,
+		},
+		"missing template": {
+			validate:   func(string) string { return "" },
+			noTemplate: true,
+			wantErr:    "reading security-review prompt template",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			promptsDir := t.TempDir()
+			if !tt.noTemplate {
+				template := "Review {{.SpecName}} ({{.Stage}}) for security issues."
+				require.NoError(t, os.WriteFile(filepath.Join(promptsDir, "security-review.tmpl"), []byte(template), 0o644))
+			}
+			mock := NewMockClaudeExecutor()
+			e := &Executor{
+				Claude:     mock,
+				StateDir:   t.TempDir(),
+				SpecsDir:   t.TempDir(),
+				MaxRetries: tt.maxRetries,
+				PromptsDir: promptsDir,
+			}
+
+			err := e.ExecuteCustomPhase("001-auth", "security-review", config.CustomPhaseConfig{Validate: tt.validate(dir)})
+			assert.Len(t, mock.ExecuteCalls, tt.wantCalls)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, mock.ExecuteCalls[0], "Review 001-auth (security-review) for security issues.")
+			if tt.wantInRetry != "" {
+				assert.Contains(t, mock.ExecuteCalls[len(mock.ExecuteCalls)-1], tt.wantInRetry)
+			}
+		})
+	}
+}
+
+func TestExecuteCustomPhase_PromptOverride(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "review.md")
+	require.NoError(t, os.WriteFile(path, []byte("Custom review prompt"), 0o644))
+	mock := NewMockClaudeExecutor()
+	e := &Executor{Claude: mock, StateDir: t.TempDir(), SpecsDir: t.TempDir()}
+
+	require.NoError(t, e.ExecuteCustomPhase("001-auth", "review", config.CustomPhaseConfig{Prompt: path}))
+	require.Len(t, mock.ExecuteCalls, 1)
+	assert.Contains(t, mock.ExecuteCalls[0], "Custom review prompt")
+}
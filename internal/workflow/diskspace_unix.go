@@ -0,0 +1,15 @@
+//go:build !windows
+
+package workflow
+
+import "syscall"
+
+// freeDiskBytes returns the space available to unprivileged users on the
+// file system holding dir.
+func freeDiskBytes(dir string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return 0, err
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,10 @@
+//go:build windows
+
+package workflow
+
+import "errors"
+
+// freeDiskBytes is not implemented on Windows; the disk space check is skipped.
+func freeDiskBytes(string) (uint64, error) {
+	return 0, errors.ErrUnsupported
+}
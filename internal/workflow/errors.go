@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
 )
 
 // TimeoutError represents a command timeout failure
@@ -15,7 +17,7 @@ type TimeoutError struct {
 
 // Error returns a human-readable error message with timeout details
 func (e *TimeoutError) Error() string {
-	return fmt.Sprintf("command timed out after %v: %s (hint: increase timeout in config)", e.Timeout, e.Command)
+	return fmt.Sprintf("command timed out after %v: %s (hint: increase timeout or phase_timeouts in config)", e.Timeout, e.Command)
 }
 
 // Unwrap returns the underlying error for errors.Is/As compatibility
@@ -31,3 +33,54 @@ func NewTimeoutError(timeout time.Duration, command string) *TimeoutError {
 		Err:     context.DeadlineExceeded,
 	}
 }
+
+// StallError represents an agent stopped for printing nothing for too long
+type StallError struct {
+	Agent string        // The agent that stalled
+	Idle  time.Duration // How long it had been silent (the stall timeout)
+}
+
+// Error returns a human-readable error message with the idle time
+func (e *StallError) Error() string {
+	return fmt.Sprintf("agent %s stalled: no output for %v, stopped (hint: increase stall_timeout, or set it to 0 to disable)", e.Agent, e.Idle)
+}
+
+// RateLimitError represents an agent failing on a rate or usage limit
+type RateLimitError struct {
+	Agent      string        // The agent that was rate limited
+	RetryAfter time.Duration // How long the agent said to wait (0 = no hint)
+	Message    string        // The output line that reported the limit
+}
+
+// Error returns a human-readable error message with the retry hint, if any
+func (e *RateLimitError) Error() string {
+	msg := fmt.Sprintf("agent %s hit a rate limit", e.Agent)
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(" (retry after %v)", e.RetryAfter.Round(time.Second))
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// AgentUnreachableError represents an agent failing because it could not
+// reach its provider or its credentials were rejected
+type AgentUnreachableError struct {
+	Agent   string // The agent that failed
+	Reason  string // "network" or "auth"
+	Message string // The output line that reported the failure
+}
+
+// Error returns a human-readable error message with the reason
+func (e *AgentUnreachableError) Error() string {
+	what := "could not reach its provider"
+	if e.Reason == string(cliagent.UnreachableAuth) {
+		what = "was not authenticated"
+	}
+	msg := fmt.Sprintf("agent %s %s", e.Agent, what)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
@@ -20,17 +20,17 @@ func TestTimeoutError_Error(t *testing.T) {
 		"5 minute timeout": {
 			timeout:         5 * time.Minute,
 			command:         "claude /autospec.plan",
-			expectedMessage: "command timed out after 5m0s: claude /autospec.plan (hint: increase timeout in config)",
+			expectedMessage: "command timed out after 5m0s: claude /autospec.plan (hint: increase timeout or phase_timeouts in config)",
 		},
 		"30 second timeout": {
 			timeout:         30 * time.Second,
 			command:         "claude /autospec.implement",
-			expectedMessage: "command timed out after 30s: claude /autospec.implement (hint: increase timeout in config)",
+			expectedMessage: "command timed out after 30s: claude /autospec.implement (hint: increase timeout or phase_timeouts in config)",
 		},
 		"1 hour timeout": {
 			timeout:         1 * time.Hour,
 			command:         "claude /autospec.workflow",
-			expectedMessage: "command timed out after 1h0m0s: claude /autospec.workflow (hint: increase timeout in config)",
+			expectedMessage: "command timed out after 1h0m0s: claude /autospec.workflow (hint: increase timeout or phase_timeouts in config)",
 		},
 	}
 
@@ -87,7 +87,7 @@ func TestTimeoutError_ErrorMessageFormat(t *testing.T) {
 			timeout: 1 * time.Hour,
 			command: "test command",
 			shouldContain: []string{
-				"hint: increase timeout in config",
+				"hint: increase timeout or phase_timeouts in config",
 			},
 		},
 	}
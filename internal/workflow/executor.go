@@ -1,13 +1,23 @@
 package workflow
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
 	"strings"
+	"time"
 
+	"github.com/ariel-frischer/autospec/internal/budget"
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/issue"
 	"github.com/ariel-frischer/autospec/internal/lifecycle"
 	"github.com/ariel-frischer/autospec/internal/notify"
 	"github.com/ariel-frischer/autospec/internal/progress"
 	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/state"
 	"github.com/ariel-frischer/autospec/internal/validation"
 )
 
@@ -22,7 +32,9 @@ type Executor struct {
 	Claude              ClaudeRunner              // Interface for Claude command execution (allows mocking)
 	StateDir            string                    // Directory for retry state storage
 	SpecsDir            string                    // Directory for spec files
+	Constitution        string                    // Configured constitution file (empty = ConstitutionPaths)
 	MaxRetries          int                       // Maximum retry attempts (1-10 range)
+	RetryPolicy         retry.PolicyConfig        // Backoff between retry attempts (zero value = retry immediately)
 	TotalStages         int                       // Total stages in workflow
 	Debug               bool                      // Enable debug logging
 	AutoCommit          bool                      // Enable auto-commit instruction injection
@@ -30,8 +42,45 @@ type Executor struct {
 	Notify              *NotifyDispatcher         // Optional notification dispatcher
 	ProgressDisplay     *progress.ProgressDisplay // Deprecated: use Progress instead
 	NotificationHandler *notify.Handler           // Deprecated: use Notify instead
+	UsageRecorder       UsageRecorder             // Optional sink for per-phase token usage (e.g., history.Writer)
+	LiveOutput          LiveOutput                // Optional live view that groups streamed output per phase
+	Validations         ValidationRecorder        // Optional sink for validation results (e.g., a JUnit report)
+	Metrics             MetricsRecorder           // Optional sink for local usage metrics (e.g., metrics.Default)
+	PostValidate        map[string]string         // Per-stage shell commands run after built-in validation passes
+	Hooks               map[string]string         // pre_<stage>/post_<stage> shell commands run around each stage session
+	Tests               TestRunner                // Test suite run after each implement session (zero value = disabled)
+	SubAgents           cliagent.SubAgentConfig   // Sub-agent selected per stage (e.g., opencode --agent)
+	Models              cliagent.ModelConfig      // Model selected per stage (e.g., claude --model)
+	StageAgents         map[string]cliagent.Agent // Agent replacing the runner's default for a stage (stage_agents)
+	PhaseTimeouts       map[string]time.Duration  // Per-stage agent time limits overriding the global timeout
+	Sessions            *state.SessionManager     // Agent session continued across a spec's stages (nil = fresh sessions)
+	Sandbox             cliagent.SandboxConfig    // Container the agent runs in, per stage (zero value = host)
+	PolicyFile          string                    // Tool permission policy applied per stage (empty = no policy)
+	Budget              *budget.Tracker           // Token/cost ceilings checked before each agent session (nil = unlimited)
+	OnUnreachable       string                    // Agent offline or logged out: ask, queue or fail (empty = fail)
+	OnStall             string                    // Agent stopped for stalling: retry or fail (empty = retry)
+	EscalateAfter       time.Duration             // Tasks implement finds blocked this long are escalated once (0 = never)
+	EscalateBlocked     string                    // How blocked tasks are escalated: notify, issue or both (empty = notify)
+	Webhooks            *notify.WebhookNotifier   // Optional webhooks for phase, task and retry events
+	MaxSessions         int                       // Agent transcripts kept under StateDir/sessions (0 = capture disabled)
+	MaxSnapshots        int                       // Artifact snapshots kept per spec under StateDir/snapshots (0 = disabled)
+	WorkDir             string                    // Directory task commits run in (empty = current directory)
+	Context             context.Context           // Cancels retry cool-downs (nil = context.Background())
+	Output              io.Writer                 // Destination for retry status messages (nil = os.Stdout)
+	PromptHook          PromptHook                // Optional last look at each stage prompt (e.g., --edit-prompt)
+	PromptsDir          string                    // Directory of <stage>.tmpl prompt overrides (empty = built-in prompts only)
+	Estimates           map[string]time.Duration  // Usual duration per stage from history, shown while it runs (nil = none)
+
+	sleep        func(time.Duration)                                   // Replaced in tests to skip real backoff delays
+	confirm      func(string) bool                                     // Asks the user a yes/no question (nil = prompt on stdin if it is a terminal)
+	openIssue    func(context.Context, issue.NewIssue) (string, error) // Opens escalation issues (nil = gh or the GitHub API)
+	policyWarned bool                                                  // Set once the agent was reported unable to enforce the policy
 }
 
+// PromptHook receives the prompt built for a stage, including injected
+// instructions, and returns the prompt to send instead. An error aborts the stage.
+type PromptHook func(stage Stage, prompt string) (string, error)
+
 // Stage represents a workflow stage (specify, plan, tasks, implement)
 type Stage string
 
@@ -47,6 +96,7 @@ const (
 	StageClarify      Stage = "clarify"
 	StageChecklist    Stage = "checklist"
 	StageAnalyze      Stage = "analyze"
+	StageVerify       Stage = "verify"
 )
 
 // debugLog prints a debug message if debug mode is enabled
@@ -58,7 +108,7 @@ func (e *Executor) debugLog(format string, args ...interface{}) {
 
 // getStageNumber returns the sequential number for a stage (1-based)
 // For optional stages, this returns their position in the canonical order:
-// constitution(1) -> specify(2) -> clarify(3) -> plan(4) -> tasks(5) -> checklist(6) -> analyze(7) -> implement(8)
+// constitution(1) -> specify(2) -> clarify(3) -> plan(4) -> tasks(5) -> checklist(6) -> analyze(7) -> implement(8) -> verify(9)
 func (e *Executor) getStageNumber(stage Stage) int {
 	switch stage {
 	case StageConstitution:
@@ -77,6 +127,8 @@ func (e *Executor) getStageNumber(stage Stage) int {
 		return 7
 	case StageImplement:
 		return 8
+	case StageVerify:
+		return 9
 	default:
 		return 0
 	}
@@ -91,6 +143,7 @@ func (e *Executor) buildStageInfo(stage Stage, retryCount int) progress.StageInf
 		Status:      progress.StageInProgress,
 		RetryCount:  retryCount,
 		MaxRetries:  e.MaxRetries,
+		Estimate:    e.Estimates[string(stage)],
 	}
 }
 
@@ -101,6 +154,8 @@ type StageResult struct {
 	Error            error
 	RetryCount       int
 	Exhausted        bool
+	TimedOut         bool     // The agent was stopped for exceeding its time limit
+	Stalled          bool     // The agent was stopped for printing nothing for stall_timeout
 	ValidationErrors []string // Schema validation errors for retry context
 }
 
@@ -131,6 +186,17 @@ func (e *Executor) ExecuteStage(specName string, stage Stage, command string, va
 	commandWithInstructions := InjectAutoCommitInstructions(command, e.AutoCommit)
 	e.debugLog("AutoCommit enabled: %v", e.AutoCommit)
 
+	if commandWithInstructions, err = e.applyPromptHook(stage, commandWithInstructions); err != nil {
+		result.Error = err
+		return result, err
+	}
+
+	toolPolicy, err := e.loadToolPolicy(stage)
+	if err != nil {
+		result.Error = err
+		return result, err
+	}
+
 	ctx := &stageExecutionContext{
 		specName:       specName,
 		stage:          stage,
@@ -140,9 +206,40 @@ func (e *Executor) ExecuteStage(specName string, stage Stage, command string, va
 		result:         result,
 		retryState:     retryState,
 		interactive:    IsInteractive(stage),
+		toolPolicy:     toolPolicy,
+	}
+
+	e.snapshotArtifacts(specName, stage)
+	if stage == StageImplement {
+		defer e.emitTaskTransitions(specName, e.snapshotTaskStatuses(specName))
+	}
+
+	result, err = e.executeStageLoop(ctx)
+	if err != nil && result.Exhausted {
+		e.emitEvent(notify.Event{
+			Event:   notify.EventRetriesExhausted,
+			Spec:    specName,
+			Stage:   string(stage),
+			Retries: result.RetryCount,
+			Message: err.Error(),
+		})
 	}
+	return result, err
+}
 
-	return e.executeStageLoop(ctx)
+// applyPromptHook passes the prompt through PromptHook, if set. The live view
+// is suspended meanwhile, since the hook may open an editor.
+func (e *Executor) applyPromptHook(stage Stage, prompt string) (string, error) {
+	if e.PromptHook == nil {
+		return prompt, nil
+	}
+	e.suspendLiveOutput()
+	defer e.resumeLiveOutput()
+	edited, err := e.PromptHook(stage, prompt)
+	if err != nil {
+		return "", fmt.Errorf("preparing %s prompt: %w", stage, err)
+	}
+	return edited, nil
 }
 
 // stageExecutionContext holds state for stage execution loop
@@ -155,7 +252,10 @@ type stageExecutionContext struct {
 	result               *StageResult
 	retryState           *retry.RetryState
 	lastValidationErrors []string
-	interactive          bool // When true, skip retry loop and use interactive mode
+	interactive          bool                 // When true, skip retry loop and use interactive mode
+	toolPolicy           *cliagent.ToolPolicy // Tool rules for the stage from PolicyFile (nil = none)
+	rateLimit            *RateLimitError      // Rate limit to wait out before the next attempt
+	rateLimitWaits       int                  // Rate limits already waited out for the stage
 }
 
 // executeStageLoop runs the retry loop for stage execution.
@@ -166,12 +266,31 @@ func (e *Executor) executeStageLoop(ctx *stageExecutionContext) (*StageResult, e
 		return e.executeInteractiveStage(ctx)
 	}
 
+	if done, err := e.runPreHook(ctx); done {
+		return ctx.result, err
+	}
+
 	for {
+		if err := e.checkBudget(ctx); err != nil {
+			return ctx.result, err
+		}
+		if err := e.waitForRetryCooldown(ctx.stage, ctx.retryState); err != nil {
+			return ctx.result, err
+		}
+
 		stageInfo := e.buildStageInfo(ctx.stage, ctx.retryState.Count)
 		e.startProgressDisplay(stageInfo)
 
+		phase := e.startLivePhase(stageInfo)
 		stageErr, validationErr := e.executeStageAttempt(ctx, stageInfo)
+		e.endLivePhase(phase, errors.Join(stageErr, validationErr))
 
+		if ctx.rateLimit != nil {
+			if err := e.waitForRateLimit(ctx); err != nil {
+				return ctx.result, err
+			}
+			continue
+		}
 		if stageErr != nil {
 			return ctx.result, stageErr
 		}
@@ -185,17 +304,68 @@ func (e *Executor) executeStageLoop(ctx *stageExecutionContext) (*StageResult, e
 	}
 }
 
+// runPreHook runs the stage's pre_<stage> hook before the first attempt. A
+// failure is handled like a validation failure: it uses up a retry and the
+// hook's output is injected into the prompt, so the first agent session can
+// fix the problem. Returns done=true when no retries are left.
+func (e *Executor) runPreHook(ctx *stageExecutionContext) (bool, error) {
+	specDir := fmt.Sprintf("%s/%s", e.SpecsDir, ctx.specName)
+	err := e.runStageHook("pre_", ctx.stage, ctx.specName, specDir)
+	if err == nil {
+		return false, nil
+	}
+	ctx.result.ValidationErrors = ExtractValidationErrors(err)
+	ctx.lastValidationErrors = ctx.result.ValidationErrors
+	e.debugLog("pre_%s hook failed: %v", ctx.stage, err)
+	return e.handleStageRetry(ctx, e.buildStageInfo(ctx.stage, ctx.retryState.Count), err)
+}
+
 // executeInteractiveStage runs a stage in interactive mode without retry loop.
 // Interactive stages skip validation and rely on user conversation.
 func (e *Executor) executeInteractiveStage(ctx *stageExecutionContext) (*StageResult, error) {
 	e.debugLog("Executing interactive stage: %s", ctx.stage)
 
+	if err := e.checkBudget(ctx); err != nil {
+		return ctx.result, err
+	}
+
+	e.suspendLiveOutput()
+	defer e.resumeLiveOutput()
+
+	e.selectStageAgent(ctx.stage)
+	e.selectSubAgent(ctx.stage)
+	e.selectModel(ctx.stage)
+	e.selectTimeout(ctx.stage)
+	e.selectSandbox(ctx.stage)
+	e.selectToolPolicy(ctx.toolPolicy)
+	e.selectSession(ctx.specName)
+	specDir := fmt.Sprintf("%s/%s", e.SpecsDir, ctx.specName)
+	if err := e.runStageHook("pre_", ctx.stage, ctx.specName, specDir); err != nil {
+		ctx.result.Error = err
+		ctx.result.ValidationErrors = ExtractValidationErrors(err)
+		return ctx.result, err
+	}
+
 	e.displayInteractiveCommandExecution(ctx.currentCommand)
+	start := time.Now()
 	if err := e.Claude.ExecuteInteractive(ctx.currentCommand); err != nil {
 		ctx.result.Error = fmt.Errorf("interactive session failed: %w", err)
+		e.recordValidation(ctx, start, ctx.result.Error, true)
 		return ctx.result, ctx.result.Error
 	}
 
+	// No retry loop here: a validation or hook failure ends the stage for the user to resolve
+	err := ctx.validateFunc(specDir)
+	if err == nil {
+		err = e.runStageHook("post_", ctx.stage, ctx.specName, specDir)
+	}
+	e.recordValidation(ctx, start, err, false)
+	if err != nil {
+		ctx.result.Error = err
+		ctx.result.ValidationErrors = ExtractValidationErrors(err)
+		return ctx.result, err
+	}
+
 	ctx.result.Success = true
 	e.debugLog("Interactive stage %s completed", ctx.stage)
 	return ctx.result, nil
@@ -204,15 +374,46 @@ func (e *Executor) executeInteractiveStage(ctx *stageExecutionContext) (*StageRe
 // executeStageAttempt executes a single attempt of a stage
 func (e *Executor) executeStageAttempt(ctx *stageExecutionContext, stageInfo progress.StageInfo) (stageErr, validationErr error) {
 	_ = lifecycle.RunStage(e.NotificationHandler, string(ctx.stage), func() error {
+		e.selectStageAgent(ctx.stage)
+		e.selectSubAgent(ctx.stage)
+		e.selectModel(ctx.stage)
+		e.selectTimeout(ctx.stage)
+		e.selectSandbox(ctx.stage)
+		e.selectToolPolicy(ctx.toolPolicy)
+		e.selectSession(ctx.specName)
+		e.selectInputAlert(ctx.stage)
+		e.displayEstimate(stageInfo)
 		e.displayCommandExecution(ctx.currentCommand)
-		if err := e.Claude.Execute(ctx.currentCommand); err != nil {
+		transcript := e.startTranscript(ctx)
+		start := time.Now()
+		err := e.Claude.Execute(ctx.currentCommand)
+		e.saveTranscript(transcript, err)
+		e.recordUsage(ctx.specName, ctx.stage, sessionDuration(start, err))
+		e.chargeBudget(ctx.specName)
+		e.recordSession(ctx.specName, ctx.stage)
+		if err != nil {
+			e.recordValidation(ctx, start, err, true)
+			if e.retryStall(ctx, err) {
+				validationErr = err
+				return err
+			}
+			if e.deferRateLimit(ctx, err) {
+				stageErr = err
+				return err
+			}
+			if e.handleUnreachable(ctx, stageInfo, err) {
+				stageErr = ctx.result.Error
+				return stageErr
+			}
 			stageErr = e.handleExecutionFailure(ctx.result, ctx.retryState, stageInfo, err)
 			return stageErr
 		}
 		e.debugLog("Claude.Execute() completed successfully")
 
 		specDir := fmt.Sprintf("%s/%s", e.SpecsDir, ctx.specName)
-		if err := ctx.validateFunc(specDir); err != nil {
+		err = e.validateStage(ctx, specDir)
+		e.recordValidation(ctx, start, err, false)
+		if err != nil {
 			validationErr = err
 			ctx.result.ValidationErrors = ExtractValidationErrors(err)
 			ctx.lastValidationErrors = ctx.result.ValidationErrors
@@ -246,14 +447,134 @@ func (e *Executor) handleStageRetry(ctx *stageExecutionContext, stageInfo progre
 	}
 
 	retryContext := FormatRetryContext(ctx.retryState.Count, e.MaxRetries, ctx.lastValidationErrors)
+	if prompt := continuationPrompt(validationErr); prompt != "" {
+		retryContext += "\n\n" + prompt
+	}
 	ctx.currentCommand = BuildRetryCommand(ctx.command, retryContext, "")
 	ctx.result.RetryCount = ctx.retryState.Count
 
 	e.debugLog("Retrying (attempt %d/%d) with error context", ctx.retryState.Count, e.MaxRetries)
-	fmt.Printf("\n⟳ Retry %d/%d - injecting validation errors into command\n", ctx.retryState.Count, e.MaxRetries)
+	fmt.Printf("\n%s %d/%d - injecting validation errors into command\n", RetryMarker, ctx.retryState.Count, e.MaxRetries)
 	return false, nil
 }
 
+// waitForRetryCooldown blocks until the stage's retry policy allows the next attempt.
+// The wait is measured from the persisted LastAttempt, so a stage that just failed
+// in a previous run still cools down before being retried.
+func (e *Executor) waitForRetryCooldown(stage Stage, retryState *retry.RetryState) error {
+	policy := e.RetryPolicy.ForStage(string(stage))
+	wait := retryState.Cooldown(policy, time.Now())
+	if wait <= 0 {
+		return nil
+	}
+	fmt.Fprintf(e.output(), "⏳ Waiting %s before retry (%s backoff)\n", wait.Round(time.Second), policy.Type)
+	if err := e.sleepFor(wait); err != nil {
+		return fmt.Errorf("retry cool-down for %s interrupted: %w", stage, err)
+	}
+	return nil
+}
+
+// selectStageAgent points the runner at the agent configured for a stage in
+// stage_agents, or back at its default agent. Runners that don't implement
+// StageAgentSelector are left untouched.
+func (e *Executor) selectStageAgent(stage Stage) {
+	if selector, ok := e.Claude.(StageAgentSelector); ok {
+		selector.SetStageAgent(e.StageAgents[string(stage)])
+	}
+}
+
+// selectSubAgent points the runner at the sub-agent configured for a stage.
+// Runners that don't implement SubAgentSelector are left untouched.
+func (e *Executor) selectSubAgent(stage Stage) {
+	if selector, ok := e.Claude.(SubAgentSelector); ok {
+		selector.SetSubAgent(e.SubAgents.ForStage(string(stage)))
+	}
+}
+
+// selectModel points the runner at the model configured for a stage.
+// Runners that don't implement ModelSelector are left untouched.
+func (e *Executor) selectModel(stage Stage) {
+	if selector, ok := e.Claude.(ModelSelector); ok {
+		selector.SetModel(e.Models.ForStage(string(stage)))
+	}
+}
+
+// selectSandbox runs the stage's agent in the sandbox container if the
+// sandbox is enabled for it, or on the host otherwise. Runners that don't
+// implement SandboxSelector are left untouched.
+func (e *Executor) selectSandbox(stage Stage) {
+	if selector, ok := e.Claude.(SandboxSelector); ok {
+		selector.SetSandbox(e.Sandbox.ForStage(string(stage)))
+	}
+}
+
+// selectInputAlert has the runner watch the stage's agent for signs it is
+// waiting for input, printing a warning and alerting through the
+// notification handler (bell and notification, as configured). Without a
+// handler nothing is watched. Runners that don't implement InputAlerter are
+// left untouched.
+func (e *Executor) selectInputAlert(stage Stage) {
+	alerter, ok := e.Claude.(InputAlerter)
+	if !ok {
+		return
+	}
+	handler := e.NotificationHandler
+	if handler == nil {
+		alerter.SetInputAlert(0, nil)
+		return
+	}
+	alerter.SetInputAlert(handler.Config().InputIdleThreshold, func(reason string) {
+		fmt.Fprintf(e.output(), "\n⚠ %s: the agent may be waiting for input (%s)\n", stage, reason)
+		handler.OnInputRequired(string(stage), reason)
+	})
+}
+
+// selectTimeout applies the stage's phase_timeouts limit to the runner, or
+// clears it so the global timeout applies. Runners that don't implement
+// StageTimeoutSetter are left untouched.
+func (e *Executor) selectTimeout(stage Stage) {
+	if setter, ok := e.Claude.(StageTimeoutSetter); ok {
+		setter.SetStageTimeout(e.PhaseTimeouts[string(stage)])
+	}
+}
+
+// sleepFor pauses execution, using the test hook when set. The wait ends early
+// with the context's error when e.Context is cancelled or the user presses Ctrl-C.
+func (e *Executor) sleepFor(d time.Duration) error {
+	if e.sleep != nil {
+		e.sleep(d)
+		return nil
+	}
+	if d <= 0 {
+		return nil
+	}
+
+	parent := e.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt)
+	defer stop()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// output returns the writer for executor status messages.
+func (e *Executor) output() io.Writer {
+	if e.Output != nil {
+		return e.Output
+	}
+	return os.Stdout
+}
+
 // loadStageRetryState loads retry state for a stage
 func (e *Executor) loadStageRetryState(specName string, stage Stage) (*retry.RetryState, error) {
 	e.debugLog("Loading retry state from: %s", e.StateDir)
@@ -287,6 +608,24 @@ func (e *Executor) startProgressDisplay(stageInfo progress.StageInfo) {
 	}
 }
 
+// displayEstimate shows how long the stage usually takes. A progress display
+// already includes the estimate, so this only prints without one.
+func (e *Executor) displayEstimate(stageInfo progress.StageInfo) {
+	if stageInfo.Estimate <= 0 || (e.Progress != nil && e.Progress.HasDisplay()) || e.ProgressDisplay != nil {
+		return
+	}
+	fmt.Printf("\n⏱ %s usually takes %s on this repo\n", strings.ToUpper(stageInfo.Name[:1])+stageInfo.Name[1:], progress.FormatEstimate(stageInfo.Estimate))
+}
+
+// sessionDuration returns how long an agent session started at start ran,
+// or 0 if it failed.
+func sessionDuration(start time.Time, err error) time.Duration {
+	if err != nil {
+		return 0
+	}
+	return time.Since(start)
+}
+
 // displayCommandExecution shows the command being executed.
 // Compact tags [+Name] are shown for injected instructions.
 // In debug mode, shows [+Name: hint] if a DisplayHint is present.
@@ -315,10 +654,10 @@ func (e *Executor) formatInteractiveCommand(prompt string) string {
 	// For Claude, interactive mode is: claude <prompt> (positional, no -p flag)
 	// We get the agent name and append the prompt directly
 	ce, ok := e.Claude.(*ClaudeExecutor)
-	if !ok || ce.Agent == nil {
+	if !ok || ce.agent() == nil {
 		return fmt.Sprintf("claude %s", prompt)
 	}
-	return fmt.Sprintf("%s %s", ce.Agent.Name(), prompt)
+	return fmt.Sprintf("%s %s", ce.agent().Name(), prompt)
 }
 
 // failStageProgress marks a stage as failed in the progress display.
@@ -378,6 +717,10 @@ func (e *Executor) sendErrorNotification(stageName string, err error) {
 func (e *Executor) handleExecutionFailure(result *StageResult, retryState *retry.RetryState, stageInfo progress.StageInfo, err error) error {
 	e.debugLog("Claude.Execute() returned error: %v", err)
 	result.Error = fmt.Errorf("command execution failed: %w", err)
+	var timeoutErr *TimeoutError
+	result.TimedOut = errors.As(err, &timeoutErr)
+	var stallErr *StallError
+	result.Stalled = errors.As(err, &stallErr)
 
 	// Fail stage in progress display
 	e.failStageProgress(stageInfo, result.Error)
@@ -455,6 +798,7 @@ func (e *Executor) completeStageSuccessNoNotify(result *StageResult, stageInfo p
 // This is a simplified version that doesn't require stage tracking
 func (e *Executor) ExecuteWithRetry(command string, maxAttempts int) error {
 	var lastErr error
+	rateLimitWaits := 0
 
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
 		err := e.Claude.Execute(command)
@@ -463,8 +807,20 @@ func (e *Executor) ExecuteWithRetry(command string, maxAttempts int) error {
 		}
 
 		lastErr = err
+		var limitErr *RateLimitError
+		if errors.As(err, &limitErr) && rateLimitWaits < e.RetryPolicy.RateLimitWaits {
+			rateLimitWaits++
+			if err := e.sleepRateLimit(limitErr, e.RetryPolicy, rateLimitWaits); err != nil {
+				return fmt.Errorf("rate-limit wait interrupted: %w", lastErr)
+			}
+			attempt--
+			continue
+		}
 		if attempt < maxAttempts {
-			fmt.Printf("Attempt %d/%d failed: %v\nRetrying...\n", attempt, maxAttempts, err)
+			fmt.Fprintf(e.output(), "Attempt %d/%d failed: %v\nRetrying...\n", attempt, maxAttempts, err)
+			if err := e.sleepFor(e.RetryPolicy.Delay(attempt)); err != nil {
+				return fmt.Errorf("retry interrupted after attempt %d/%d: %w", attempt, maxAttempts, lastErr)
+			}
 		}
 	}
 
@@ -498,7 +854,9 @@ func (e *Executor) ValidateTasks(specDir string) error {
 
 // ValidateTasksComplete checks if all tasks are completed
 // Supports both YAML (status field) and Markdown (checkbox) formats
-func (e *Executor) ValidateTasksComplete(tasksPath string) error {
+// In strict mode every completed task with acceptance criteria must also have
+// been verified by the verify stage (tasks.yaml only)
+func (e *Executor) ValidateTasksComplete(tasksPath string, strict bool) error {
 	stats, err := validation.GetTaskStats(tasksPath)
 	if err != nil {
 		return fmt.Errorf("getting task stats: %w", err)
@@ -514,9 +872,23 @@ func (e *Executor) ValidateTasksComplete(tasksPath string) error {
 			remaining, stats.PendingTasks, stats.InProgressTasks)
 	}
 
+	if strict {
+		summary, err := validation.GetVerificationSummary(tasksPath)
+		if err != nil {
+			return fmt.Errorf("getting verification summary: %w", err)
+		}
+		if !summary.IsVerified() {
+			return fmt.Errorf("acceptance criteria not verified: %s\nRun 'autospec verify' to check them", summary)
+		}
+	}
+
 	return nil
 }
 
+// RetryMarker starts the line printed when a stage is retried after failed
+// validation. autospec batch counts these lines in child output.
+const RetryMarker = "⟳ Retry"
+
 // maxRetryErrors is the maximum number of validation errors to include in retry context
 const maxRetryErrors = 10
 
@@ -1,15 +1,20 @@
 package workflow
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ariel-frischer/autospec/internal/cliagent"
 	"github.com/ariel-frischer/autospec/internal/progress"
 	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/ariel-frischer/autospec/internal/state"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -453,7 +458,7 @@ func TestValidateTasksComplete(t *testing.T) {
 			require.NoError(t, os.WriteFile(tasksPath, []byte(tc.content), 0644))
 
 			executor := &Executor{}
-			err := executor.ValidateTasksComplete(tasksPath)
+			err := executor.ValidateTasksComplete(tasksPath, false)
 
 			if tc.wantErr {
 				assert.Error(t, err)
@@ -465,6 +470,83 @@ func TestValidateTasksComplete(t *testing.T) {
 	}
 }
 
+func TestValidateTasksComplete_Skipped(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status  string
+		wantErr string
+	}{
+		"skipped task does not block": {status: "Skipped"},
+		"blocked task does":           {status: "Blocked", wantErr: "1 blocked"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			content := "phases:\n  - number: 1\n    title: Core\n    tasks:\n" +
+				"      - id: T001\n        status: Completed\n" +
+				"      - id: T002\n        status: " + tt.status + "\n"
+			tasksPath := filepath.Join(t.TempDir(), "tasks.yaml")
+			require.NoError(t, os.WriteFile(tasksPath, []byte(content), 0644))
+
+			err := (&Executor{}).ValidateTasksComplete(tasksPath, false)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTasksComplete_Strict(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		verification string
+		wantErr      string
+	}{
+		"verified": {
+			verification: "\n        verification:\n          status: Verified",
+		},
+		"failed": {
+			verification: "\n        verification:\n          status: Failed\n          unmet: [\"Parses YAML\"]",
+			wantErr:      "1 failed (T001)",
+		},
+		"not verified": {
+			wantErr: "1 unverified (T001)",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			tasksPath := filepath.Join(t.TempDir(), "tasks.yaml")
+			content := `phases:
+  - number: 1
+    title: Core
+    tasks:
+      - id: T001
+        title: Add parser
+        status: Completed
+        acceptance_criteria: ["Parses YAML"]` + tc.verification + "\n"
+			require.NoError(t, os.WriteFile(tasksPath, []byte(content), 0644))
+
+			executor := &Executor{}
+			require.NoError(t, executor.ValidateTasksComplete(tasksPath, false))
+
+			err := executor.ValidateTasksComplete(tasksPath, true)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
 func TestDebugLog(t *testing.T) {
 	t.Run("debug disabled does not print", func(t *testing.T) {
 		t.Parallel()
@@ -1667,6 +1749,390 @@ func TestExecutor_ClaudeRunnerInterface(t *testing.T) {
 	}
 }
 
+// subAgentMockRunner records the sub-agent selected before each execution.
+type subAgentMockRunner struct {
+	mockClaudeExecutor
+	current  string
+	selected []string
+}
+
+func (m *subAgentMockRunner) SetSubAgent(name string) {
+	m.current = name
+}
+
+func (m *subAgentMockRunner) Execute(prompt string) error {
+	m.selected = append(m.selected, m.current)
+	return m.mockClaudeExecutor.Execute(prompt)
+}
+
+func TestExecuteStage_SelectsSubAgentPerStage(t *testing.T) {
+	t.Parallel()
+
+	runner := &subAgentMockRunner{}
+	executor := &Executor{
+		Claude:   runner,
+		StateDir: t.TempDir(),
+		SpecsDir: t.TempDir(),
+		SubAgents: cliagent.SubAgentConfig{
+			Name:   "build",
+			Stages: map[string]string{"plan": "planner"},
+		},
+	}
+
+	for _, stage := range []Stage{StagePlan, StageTasks} {
+		_, err := executor.ExecuteStage("001-test", stage, "/autospec."+string(stage), func(string) error { return nil })
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"planner", "build"}, runner.selected)
+}
+
+// modelMockRunner records the model selected before each execution.
+type modelMockRunner struct {
+	mockClaudeExecutor
+	current  string
+	selected []string
+}
+
+func (m *modelMockRunner) SetModel(name string) {
+	m.current = name
+}
+
+func (m *modelMockRunner) Execute(prompt string) error {
+	m.selected = append(m.selected, m.current)
+	return m.mockClaudeExecutor.Execute(prompt)
+}
+
+func TestExecuteStage_SelectsModelPerStage(t *testing.T) {
+	t.Parallel()
+
+	runner := &modelMockRunner{}
+	executor := &Executor{
+		Claude:   runner,
+		StateDir: t.TempDir(),
+		SpecsDir: t.TempDir(),
+		Models: cliagent.ModelConfig{
+			Name:   "sonnet",
+			Stages: map[string]string{"specify": "haiku", "implement": "opus"},
+		},
+	}
+
+	for _, stage := range []Stage{StageSpecify, StagePlan, StageImplement} {
+		_, err := executor.ExecuteStage("001-test", stage, "/autospec."+string(stage), func(string) error { return nil })
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"haiku", "sonnet", "opus"}, runner.selected)
+}
+
+// stageAgentMockRunner records the stage agent selected before each execution.
+type stageAgentMockRunner struct {
+	mockClaudeExecutor
+	current  cliagent.Agent
+	selected []string
+}
+
+func (m *stageAgentMockRunner) SetStageAgent(agent cliagent.Agent) {
+	m.current = agent
+}
+
+func (m *stageAgentMockRunner) Execute(prompt string) error {
+	name := "default"
+	if m.current != nil {
+		name = m.current.Name()
+	}
+	m.selected = append(m.selected, name)
+	return m.mockClaudeExecutor.Execute(prompt)
+}
+
+func TestExecuteStage_SelectsStageAgent(t *testing.T) {
+	t.Parallel()
+
+	runner := &stageAgentMockRunner{}
+	executor := &Executor{
+		Claude:      runner,
+		StateDir:    t.TempDir(),
+		SpecsDir:    t.TempDir(),
+		StageAgents: map[string]cliagent.Agent{"plan": cliagent.NewOllama()},
+	}
+
+	for _, stage := range []Stage{StagePlan, StageImplement} {
+		_, err := executor.ExecuteStage("001-test", stage, "/autospec."+string(stage), func(string) error { return nil })
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"ollama", "default"}, runner.selected)
+}
+
+// sessionMockRunner records the session each execution resumes and reports
+// a new session ID per execution.
+type sessionMockRunner struct {
+	mockClaudeExecutor
+	resume  string
+	resumed []string
+	last    string
+}
+
+func (m *sessionMockRunner) SetResumeSession(id string) {
+	m.resume = id
+}
+
+func (m *sessionMockRunner) LastSessionID() string {
+	return m.last
+}
+
+func (m *sessionMockRunner) Execute(prompt string) error {
+	m.resumed = append(m.resumed, m.resume)
+	m.last = "sess-" + strconv.Itoa(len(m.resumed))
+	return m.mockClaudeExecutor.Execute(prompt)
+}
+
+func TestExecuteStage_ContinuesAgentSession(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		mode        string
+		wantResumed []string
+	}{
+		"continuous resumes the previous stage's session": {
+			mode:        state.SessionModeContinuous,
+			wantResumed: []string{"", "sess-1", "sess-2"},
+		},
+		"fresh starts a new session every stage": {
+			mode:        state.SessionModeFresh,
+			wantResumed: []string{"", "", ""},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := &sessionMockRunner{}
+			stateDir := t.TempDir()
+			executor := &Executor{
+				Claude:   runner,
+				StateDir: stateDir,
+				SpecsDir: t.TempDir(),
+				Sessions: state.NewSessionManager(stateDir, tt.mode),
+			}
+
+			for _, stage := range []Stage{StagePlan, StageTasks, StageImplement} {
+				_, err := executor.ExecuteStage("001-test", stage, "/autospec."+string(stage), func(string) error { return nil })
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantResumed, runner.resumed)
+		})
+	}
+}
+
+// sandboxMockRunner records whether each execution ran sandboxed.
+type sandboxMockRunner struct {
+	mockClaudeExecutor
+	current   *cliagent.SandboxConfig
+	sandboxed []bool
+}
+
+func (m *sandboxMockRunner) SetSandbox(sandbox *cliagent.SandboxConfig) {
+	m.current = sandbox
+}
+
+func (m *sandboxMockRunner) Execute(prompt string) error {
+	m.sandboxed = append(m.sandboxed, m.current != nil)
+	return m.mockClaudeExecutor.Execute(prompt)
+}
+
+func TestExecuteStage_SelectsSandbox(t *testing.T) {
+	t.Parallel()
+
+	runner := &sandboxMockRunner{}
+	executor := &Executor{
+		Claude:   runner,
+		StateDir: t.TempDir(),
+		SpecsDir: t.TempDir(),
+		Sandbox:  cliagent.SandboxConfig{Enabled: true, Image: "agent:latest", Stages: []string{"implement"}},
+	}
+
+	for _, stage := range []Stage{StagePlan, StageImplement, StageTasks} {
+		_, err := executor.ExecuteStage("001-test", stage, "/autospec."+string(stage), func(string) error { return nil })
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []bool{false, true, false}, runner.sandboxed)
+}
+
+// policyMockRunner records the tool policy each execution ran with.
+type policyMockRunner struct {
+	mockClaudeExecutor
+	current  *cliagent.ToolPolicy
+	policies []*cliagent.ToolPolicy
+}
+
+func (m *policyMockRunner) SetToolPolicy(p *cliagent.ToolPolicy) {
+	m.current = p
+}
+
+func (m *policyMockRunner) Execute(prompt string) error {
+	m.policies = append(m.policies, m.current)
+	return m.mockClaudeExecutor.Execute(prompt)
+}
+
+func TestExecuteStage_AppliesToolPolicy(t *testing.T) {
+	t.Parallel()
+
+	policyFile := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(policyFile, []byte(`
+stages:
+  implement:
+    deny:
+      commands: ["git push"]
+`), 0644))
+
+	runner := &policyMockRunner{}
+	executor := &Executor{
+		Claude:     runner,
+		StateDir:   t.TempDir(),
+		SpecsDir:   t.TempDir(),
+		PolicyFile: policyFile,
+	}
+
+	for _, stage := range []Stage{StagePlan, StageImplement} {
+		_, err := executor.ExecuteStage("001-test", stage, "/autospec."+string(stage), func(string) error { return nil })
+		require.NoError(t, err)
+	}
+
+	require.Len(t, runner.policies, 2)
+	assert.Nil(t, runner.policies[0])
+	require.NotNil(t, runner.policies[1])
+	assert.Equal(t, []string{"git push"}, runner.policies[1].Deny.Commands)
+}
+
+func TestExecuteStage_InvalidToolPolicyFailsStage(t *testing.T) {
+	t.Parallel()
+
+	policyFile := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(policyFile, []byte("deny:\n  comands: [git push]\n"), 0644))
+
+	runner := &policyMockRunner{}
+	executor := &Executor{
+		Claude:     runner,
+		StateDir:   t.TempDir(),
+		SpecsDir:   t.TempDir(),
+		PolicyFile: policyFile,
+	}
+
+	result, err := executor.ExecuteStage("001-test", StageImplement, "/autospec.implement", func(string) error { return nil })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "loading tool policy")
+	assert.False(t, result.Success)
+	assert.Empty(t, runner.policies, "the agent must not run without its policy")
+}
+
+// timeoutMockRunner records the stage timeout set before each execution and
+// fails with a TimeoutError when told to.
+type timeoutMockRunner struct {
+	mockClaudeExecutor
+	current  time.Duration
+	selected []time.Duration
+	timeout  bool
+}
+
+func (m *timeoutMockRunner) SetStageTimeout(d time.Duration) {
+	m.current = d
+}
+
+func (m *timeoutMockRunner) Execute(prompt string) error {
+	m.selected = append(m.selected, m.current)
+	if m.timeout {
+		return NewTimeoutError(m.current, prompt)
+	}
+	return m.mockClaudeExecutor.Execute(prompt)
+}
+
+func TestExecuteStage_AppliesPhaseTimeouts(t *testing.T) {
+	t.Parallel()
+
+	runner := &timeoutMockRunner{}
+	executor := &Executor{
+		Claude:        runner,
+		StateDir:      t.TempDir(),
+		SpecsDir:      t.TempDir(),
+		PhaseTimeouts: map[string]time.Duration{"specify": 10 * time.Minute},
+	}
+
+	for _, stage := range []Stage{StageSpecify, StagePlan} {
+		_, err := executor.ExecuteStage("001-test", stage, "/autospec."+string(stage), func(string) error { return nil })
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []time.Duration{10 * time.Minute, 0}, runner.selected)
+}
+
+func TestExecuteStage_TimeoutSurfacedInResult(t *testing.T) {
+	t.Parallel()
+
+	runner := &timeoutMockRunner{timeout: true}
+	executor := &Executor{
+		Claude:        runner,
+		StateDir:      t.TempDir(),
+		SpecsDir:      t.TempDir(),
+		PhaseTimeouts: map[string]time.Duration{"implement": 2 * time.Hour},
+	}
+
+	result, err := executor.ExecuteStage("001-test", StageImplement, "/autospec.implement", func(string) error { return nil })
+	require.Error(t, err)
+	assert.True(t, result.TimedOut)
+
+	var timeoutErr *TimeoutError
+	require.ErrorAs(t, result.Error, &timeoutErr)
+	assert.Equal(t, 2*time.Hour, timeoutErr.Timeout)
+}
+
+func TestExecuteStage_PromptHook(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		hook      PromptHook
+		wantCalls []string
+		wantErr   string
+	}{
+		"prompt replaced": {
+			hook: func(stage Stage, prompt string) (string, error) {
+				return prompt + " (reviewed for " + string(stage) + ")", nil
+			},
+			wantCalls: []string{"/autospec.plan (reviewed for plan)"},
+		},
+		"error aborts before the agent runs": {
+			hook: func(Stage, string) (string, error) {
+				return "", errors.New("prompt is empty")
+			},
+			wantErr: "preparing plan prompt: prompt is empty",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			runner := &mockClaudeExecutor{}
+			executor := &Executor{
+				Claude:     runner,
+				StateDir:   t.TempDir(),
+				SpecsDir:   t.TempDir(),
+				PromptHook: tt.hook,
+			}
+
+			_, err := executor.ExecuteStage("001-test", StagePlan, "/autospec.plan", func(string) error { return nil })
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, runner.executeCalls)
+		})
+	}
+}
+
 // TestInjectAutoCommitInstructions tests the InjectAutoCommitInstructions function.
 // Verifies that auto-commit instructions are properly appended with markers when enabled.
 func TestInjectAutoCommitInstructions(t *testing.T) {
@@ -1960,3 +2426,140 @@ func TestCompactInstructionsForDisplayMalformedMarkers(t *testing.T) {
 		})
 	}
 }
+
+// TestExecuteStage_RetryPolicyCooldown verifies that retries wait for the
+// configured backoff and that a zero policy retries immediately.
+func TestExecuteStage_RetryPolicyCooldown(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		policy    retry.PolicyConfig
+		wantSleep int
+	}{
+		"zero policy does not sleep": {
+			policy:    retry.PolicyConfig{},
+			wantSleep: 0,
+		},
+		"fixed policy sleeps before each retry": {
+			policy:    retry.PolicyConfig{Type: retry.PolicyFixed, InitialDelay: time.Hour},
+			wantSleep: 2,
+		},
+		"stage override applies": {
+			policy: retry.PolicyConfig{
+				Type:   retry.PolicyNone,
+				Stages: map[string]retry.PolicyConfig{"specify": {Type: retry.PolicyFixed, InitialDelay: time.Hour}},
+			},
+			wantSleep: 2,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var sleeps []time.Duration
+			executor := &Executor{
+				Claude:      testClaudeExecutor(t, "success"),
+				StateDir:    t.TempDir(),
+				SpecsDir:    t.TempDir(),
+				MaxRetries:  2,
+				RetryPolicy: tt.policy,
+				sleep:       func(d time.Duration) { sleeps = append(sleeps, d) },
+			}
+			validateFunc := func(dir string) error { return errors.New("validation failed") }
+
+			_, err := executor.ExecuteStage("001-test", StageSpecify, "/test.command", validateFunc)
+
+			require.Error(t, err)
+			require.Len(t, sleeps, tt.wantSleep)
+			for _, d := range sleeps {
+				assert.Greater(t, d, 59*time.Minute)
+				assert.LessOrEqual(t, d, time.Hour)
+			}
+		})
+	}
+}
+
+func TestExecuteWithRetry_SleepsBetweenAttempts(t *testing.T) {
+	t.Parallel()
+
+	var sleeps []time.Duration
+	executor := &Executor{
+		Claude:      testClaudeExecutorWithCmd(t, "false"),
+		RetryPolicy: retry.PolicyConfig{Type: retry.PolicyLinear, InitialDelay: time.Second},
+		sleep:       func(d time.Duration) { sleeps = append(sleeps, d) },
+	}
+
+	err := executor.ExecuteWithRetry("/test.command", 3)
+
+	require.Error(t, err)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, sleeps)
+}
+
+func TestExecuteStage_CooldownStopsOnCancelledContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var out bytes.Buffer
+	executor := &Executor{
+		Claude:      testClaudeExecutor(t, "success"),
+		StateDir:    t.TempDir(),
+		SpecsDir:    t.TempDir(),
+		MaxRetries:  2,
+		RetryPolicy: retry.PolicyConfig{Type: retry.PolicyFixed, InitialDelay: time.Hour},
+		Context:     ctx,
+		Output:      &out,
+	}
+	validateFunc := func(dir string) error { return errors.New("validation failed") }
+
+	start := time.Now()
+	_, err := executor.ExecuteStage("001-test", StageSpecify, "/test.command", validateFunc)
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), time.Minute)
+	assert.Contains(t, out.String(), "Waiting 1h0m0s before retry (fixed backoff)")
+}
+
+func TestExecuteStage_InteractiveValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		validateErr error
+		wantErr     bool
+	}{
+		"validation passes": {},
+		"validation failure fails the stage": {
+			validateErr: errors.New("analysis.yaml reports 1 critical constitution violation(s)"),
+			wantErr:     true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specsDir := t.TempDir()
+			executor := &Executor{
+				Claude:   &mockClaudeExecutor{},
+				StateDir: t.TempDir(),
+				SpecsDir: specsDir,
+			}
+			var gotDir string
+			result, err := executor.ExecuteStage("001-test", StageAnalyze, "/autospec.analyze", func(specDir string) error {
+				gotDir = specDir
+				return tt.validateErr
+			})
+
+			assert.Equal(t, specsDir+"/001-test", gotDir)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.validateErr)
+				assert.False(t, result.Success)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, result.Success)
+		})
+	}
+}
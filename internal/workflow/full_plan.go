@@ -0,0 +1,126 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/retry"
+)
+
+// PlannedStage describes one step of a full workflow run.
+type PlannedStage struct {
+	Name             string // Stage or custom step name
+	Stage            Stage  // Built-in stage ("" for custom steps)
+	Command          string // Shell command for custom steps
+	Agent            string // Agent running the stage, with its sub-agent if configured
+	RetriesRemaining int
+	MaxRetries       int
+	Artifact         string // File the stage produces in the spec dir ("" if none)
+	ArtifactExists   bool
+	Skipped          bool // Completed in the checkpointed run; skipped on --resume
+}
+
+// FullWorkflowPlan previews what `autospec full` would execute, without
+// running any agent.
+type FullWorkflowPlan struct {
+	FeatureDescription string
+	SpecName           string // Spec being resumed ("" when specify creates a new one)
+	ImplementMethod    string // phases, tasks or single-session
+	ConstitutionPath   string // "" when no constitution exists
+	Stages             []PlannedStage
+	NotRun             []Stage // Stages the pipeline does not include
+}
+
+// PlanFullWorkflow builds the execution plan for a full workflow run from
+// the configured pipeline. With resume, the checkpoint for featureDescription
+// (if any) decides which steps are skipped and which spec's retry state and
+// artifacts are reported.
+func PlanFullWorkflow(cfg *config.Configuration, featureDescription string, resume bool) (*FullWorkflowPlan, error) {
+	agent, err := cfg.GetAgent()
+	if err != nil {
+		return nil, fmt.Errorf("resolving agent: %w", err)
+	}
+	stageAgents, err := cfg.GetStageAgents()
+	if err != nil {
+		return nil, fmt.Errorf("resolving stage agents: %w", err)
+	}
+	steps, err := BuildPipeline(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &FullWorkflowPlan{
+		FeatureDescription: featureDescription,
+		ImplementMethod:    cfg.ImplementMethod,
+	}
+	for _, stage := range canonicalStages {
+		if !slices.ContainsFunc(steps, func(s PipelineStep) bool { return s.Stage == stage }) {
+			plan.NotRun = append(plan.NotRun, stage)
+		}
+	}
+	if plan.ImplementMethod == "" {
+		plan.ImplementMethod = "phases"
+	}
+	if check := CheckConstitutionAt(cfg.Constitution); check.Exists {
+		plan.ConstitutionPath = check.Path
+	}
+
+	var cp *retry.WorkflowCheckpoint
+	if resume {
+		if cp, err = retry.FindCheckpoint(cfg.StateDir, featureDescription); err != nil {
+			return nil, fmt.Errorf("loading workflow checkpoint: %w", err)
+		}
+		if cp != nil && len(cp.CompletedStages) > 0 {
+			plan.SpecName = cp.SpecName
+		}
+	}
+
+	for _, step := range steps {
+		planned := PlannedStage{Name: step.Name, Stage: step.Stage, Command: step.Command}
+		if !step.IsCommand() {
+			stageAgent := agent
+			if a, ok := stageAgents[step.Name]; ok {
+				stageAgent = a
+			}
+			planned.Agent = stageAgent.Name()
+			if subAgent := cfg.SubAgent.ForStage(step.Name); subAgent != "" {
+				planned.Agent = fmt.Sprintf("%s (%s)", stageAgent.Name(), subAgent)
+			}
+			if model := cfg.Model.ForStage(step.Name); model != "" {
+				planned.Agent += " [" + model + "]"
+			}
+			planned.MaxRetries = cfg.MaxRetries
+			planned.RetriesRemaining = cfg.MaxRetries
+			if produced := GetProducedArtifacts(step.Stage); len(produced) > 0 {
+				planned.Artifact = produced[0]
+			}
+		}
+		if plan.SpecName != "" {
+			specDir := filepath.Join(cfg.SpecsDir, plan.SpecName)
+			if !step.IsCommand() {
+				planned.RetriesRemaining = remainingRetries(cfg, plan.SpecName, Stage(step.Name))
+			}
+			if planned.Artifact != "" {
+				_, statErr := os.Stat(filepath.Join(specDir, planned.Artifact))
+				planned.ArtifactExists = statErr == nil
+			}
+			// Mirrors canSkipStage: implement is never skipped, it resumes in place
+			_, statErr := os.Stat(filepath.Join(specDir, checkpointArtifacts[Stage(step.Name)]))
+			planned.Skipped = step.Stage != StageImplement && statErr == nil && cp.IsStageCompleted(step.Name)
+		}
+		plan.Stages = append(plan.Stages, planned)
+	}
+	return plan, nil
+}
+
+// remainingRetries returns the retries left for a stage of an existing spec.
+func remainingRetries(cfg *config.Configuration, specName string, stage Stage) int {
+	state, err := retry.LoadRetryState(cfg.StateDir, specName, string(stage), cfg.MaxRetries)
+	if err != nil {
+		return cfg.MaxRetries
+	}
+	return max(cfg.MaxRetries-state.Count, 0)
+}
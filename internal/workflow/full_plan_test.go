@@ -0,0 +1,109 @@
+// Package workflow tests the full workflow execution plan preview.
+// Related: internal/workflow/full_plan.go
+// Tags: workflow, plan, preview, checkpoint, retry
+
+package workflow
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanFullWorkflow(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		resume      bool
+		wantSpec    string
+		wantRetries []int
+		wantExists  []bool
+		wantSkipped []bool
+	}{
+		"new run plans every stage with full retries": {
+			wantRetries: []int{3, 3, 3, 3},
+			wantExists:  []bool{false, false, false, false},
+			wantSkipped: []bool{false, false, false, false},
+		},
+		"resume reports checkpoint, artifacts and used retries": {
+			resume:      true,
+			wantSpec:    "001-user-auth",
+			wantRetries: []int{3, 1, 3, 3},
+			wantExists:  []bool{true, true, false, false},
+			wantSkipped: []bool{true, false, false, false},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &config.Configuration{
+				AgentPreset: "claude",
+				SpecsDir:    t.TempDir(),
+				StateDir:    filepath.Join(t.TempDir(), "state"),
+				MaxRetries:  3,
+				SubAgent:    cliagent.SubAgentConfig{Stages: map[string]string{"implement": "builder"}},
+				StageAgents: map[string]string{"plan": "ollama"},
+				Model:       cliagent.ModelConfig{Stages: map[string]string{"tasks": "haiku"}},
+			}
+			specName := "001-user-auth"
+			orch := &WorkflowOrchestrator{SpecsDir: cfg.SpecsDir}
+			writeCheckpointArtifacts(t, orch, specName, "spec.yaml", "plan.yaml")
+			require.NoError(t, retry.MarkCheckpointStage(cfg.StateDir, specName, checkpointTestFeature, "specify"))
+			_, err := retry.IncrementRetryCount(cfg.StateDir, specName, "plan", cfg.MaxRetries)
+			require.NoError(t, err)
+			_, err = retry.IncrementRetryCount(cfg.StateDir, specName, "plan", cfg.MaxRetries)
+			require.NoError(t, err)
+
+			plan, err := PlanFullWorkflow(cfg, checkpointTestFeature, tt.resume)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantSpec, plan.SpecName)
+			assert.Equal(t, "phases", plan.ImplementMethod)
+			assert.Equal(t, []Stage{StageConstitution, StageClarify, StageChecklist, StageAnalyze, StageVerify}, plan.NotRun)
+			require.Len(t, plan.Stages, 4)
+			for i, stage := range plan.Stages {
+				assert.Equal(t, Stage(defaultPipeline[i]), stage.Stage)
+				assert.Equal(t, tt.wantRetries[i], stage.RetriesRemaining, "retries for %s", stage.Stage)
+				assert.Equal(t, tt.wantExists[i], stage.ArtifactExists, "artifact for %s", stage.Stage)
+				assert.Equal(t, tt.wantSkipped[i], stage.Skipped, "skip for %s", stage.Stage)
+			}
+			assert.Equal(t, "claude", plan.Stages[0].Agent)
+			assert.Equal(t, "ollama", plan.Stages[1].Agent)
+			assert.Equal(t, "claude [haiku]", plan.Stages[2].Agent)
+			assert.Equal(t, "claude (builder)", plan.Stages[3].Agent)
+			assert.Empty(t, plan.Stages[3].Artifact)
+		})
+	}
+}
+
+func TestPlanFullWorkflow_Pipeline(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Configuration{
+		AgentPreset:      "claude",
+		SpecsDir:         t.TempDir(),
+		StateDir:         filepath.Join(t.TempDir(), "state"),
+		MaxRetries:       2,
+		Pipeline:         []string{"specify", "clarify", "plan", "lint-plan", "tasks", "analyze", "implement"},
+		PipelineCommands: map[string]string{"lint-plan": "make lint-plan"},
+	}
+
+	plan, err := PlanFullWorkflow(cfg, checkpointTestFeature, false)
+	require.NoError(t, err)
+
+	require.Len(t, plan.Stages, 7)
+	lint := plan.Stages[3]
+	assert.Equal(t, "lint-plan", lint.Name)
+	assert.Equal(t, "make lint-plan", lint.Command)
+	assert.Empty(t, lint.Agent)
+	assert.Zero(t, lint.MaxRetries)
+	assert.Equal(t, "analysis.yaml", plan.Stages[5].Artifact)
+	assert.Equal(t, []Stage{StageConstitution, StageChecklist, StageVerify}, plan.NotRun)
+}
@@ -0,0 +1,95 @@
+package workflow
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+)
+
+const (
+	// promptGrace is how long output must stay on a prompt before alerting,
+	// so prompts the agent answers itself (or prints mid-stream) don't alert.
+	promptGrace = 3 * time.Second
+
+	// inputCheckInterval is how often a running agent's output is checked.
+	inputCheckInterval = time.Second
+
+	// inputTailSize bounds the output kept to look for a prompt.
+	inputTailSize = 4 * 1024
+)
+
+// inputWatch is an io.Writer placed alongside agent output that calls alert
+// when the agent seems to be waiting for input: the output ends in a prompt
+// for promptGrace, or nothing was written for idleThreshold. It alerts once
+// per pause; new output re-arms it.
+type inputWatch struct {
+	idleThreshold time.Duration // 0 = prompts only
+	alert         func(reason string)
+
+	mu      sync.Mutex
+	last    time.Time // When output was last written
+	tail    []byte
+	alerted bool
+}
+
+// newInputWatch creates a watch whose idle time starts now.
+func newInputWatch(idleThreshold time.Duration, alert func(reason string)) *inputWatch {
+	return &inputWatch{idleThreshold: idleThreshold, alert: alert, last: time.Now()}
+}
+
+// Write records output. It never fails, so it can't break the agent's output.
+func (w *inputWatch) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.last = time.Now()
+	w.alerted = false
+	w.tail = append(w.tail, p...)
+	if len(w.tail) > inputTailSize {
+		w.tail = w.tail[len(w.tail)-inputTailSize:]
+	}
+	return len(p), nil
+}
+
+// start checks the output every inputCheckInterval until the returned stop
+// function is called.
+func (w *inputWatch) start() (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(inputCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				w.check(now)
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// check alerts if, as of now, the agent has been sitting on a prompt or
+// silent for too long, and it hasn't alerted since the last output.
+func (w *inputWatch) check(now time.Time) {
+	w.mu.Lock()
+	if w.alerted {
+		w.mu.Unlock()
+		return
+	}
+	idle := now.Sub(w.last)
+	reason := ""
+	if prompt := cliagent.DetectInputPrompt(string(w.tail)); prompt != "" && idle >= promptGrace {
+		reason = fmt.Sprintf("%q", prompt)
+	} else if w.idleThreshold > 0 && idle >= w.idleThreshold {
+		reason = fmt.Sprintf("no output for %s", idle.Round(time.Second))
+	}
+	w.alerted = reason != ""
+	w.mu.Unlock()
+
+	if reason != "" {
+		w.alert(reason)
+	}
+}
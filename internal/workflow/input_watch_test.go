@@ -0,0 +1,113 @@
+// Package workflow tests alerts for agents waiting on user input.
+// Related: internal/workflow/input_watch.go, internal/workflow/executor.go
+// Tags: workflow, input, prompt, idle, bell, notifications
+package workflow
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInputWatch_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		threshold time.Duration
+		output    string
+		after     time.Duration
+		want      []string
+	}{
+		"prompt after grace period": {
+			output: "Apply these changes? (y/n) ",
+			after:  promptGrace,
+			want:   []string{`"Apply these changes? (y/n)"`},
+		},
+		"prompt within grace period": {
+			output: "Apply these changes? (y/n) ",
+			after:  time.Second,
+		},
+		"silent past threshold": {
+			threshold: 10 * time.Minute,
+			output:    "Running tests...\n",
+			after:     11 * time.Minute,
+			want:      []string{"no output for 11m0s"},
+		},
+		"silent below threshold": {
+			threshold: 10 * time.Minute,
+			output:    "Running tests...\n",
+			after:     9 * time.Minute,
+		},
+		"no threshold only detects prompts": {
+			output: "Running tests...\n",
+			after:  time.Hour,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var alerts []string
+			w := newInputWatch(tt.threshold, func(reason string) { alerts = append(alerts, reason) })
+			_, _ = w.Write([]byte(tt.output))
+
+			w.check(w.last.Add(tt.after))
+			w.check(w.last.Add(tt.after + time.Second))
+			assert.Equal(t, tt.want, alerts, "alerts once per pause")
+		})
+	}
+}
+
+func TestInputWatch_RearmsOnOutput(t *testing.T) {
+	t.Parallel()
+
+	var alerts []string
+	w := newInputWatch(time.Minute, func(reason string) { alerts = append(alerts, reason) })
+
+	w.check(w.last.Add(2 * time.Minute))
+	_, _ = w.Write([]byte("still working\n"))
+	w.check(w.last.Add(30 * time.Second))
+	w.check(w.last.Add(2 * time.Minute))
+
+	assert.Equal(t, []string{"no output for 2m0s", "no output for 2m0s"}, alerts)
+}
+
+func TestInputWatch_KeepsTail(t *testing.T) {
+	t.Parallel()
+
+	w := newInputWatch(0, func(string) {})
+	_, _ = w.Write(bytes.Repeat([]byte("x"), inputTailSize))
+	_, _ = w.Write([]byte("\nContinue? [y/N]"))
+
+	assert.Len(t, w.tail, inputTailSize)
+	assert.True(t, bytes.HasSuffix(w.tail, []byte("Continue? [y/N]")))
+}
+
+func TestExecutor_SelectInputAlert(t *testing.T) {
+	t.Parallel()
+
+	claude := &ClaudeExecutor{}
+	var out bytes.Buffer
+	e := &Executor{Claude: claude, Output: &out}
+
+	e.selectInputAlert(StagePlan)
+	assert.Nil(t, claude.InputAlert, "nothing is watched without a notification handler")
+
+	sender := &mockNotifySender{}
+	e.NotificationHandler = notify.NewHandlerWithSender(notify.NotificationConfig{
+		InputIdleThreshold: 5 * time.Minute,
+		OnInputRequired:    true,
+	}, sender)
+	e.selectInputAlert(StagePlan)
+
+	require.NotNil(t, claude.InputAlert)
+	assert.Equal(t, 5*time.Minute, claude.InputIdleThreshold)
+	claude.InputAlert(`"Continue? (y/n)"`)
+	assert.Contains(t, out.String(), `plan: the agent may be waiting for input ("Continue? (y/n)")`)
+	assert.Empty(t, sender.visualCalls, "notifications stay off unless enabled")
+}
@@ -3,7 +3,17 @@
 // Tags: workflow, interfaces, dependency-injection, executors
 package workflow
 
-import "github.com/ariel-frischer/autospec/internal/validation"
+import (
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/metrics"
+	"github.com/ariel-frischer/autospec/internal/report"
+	"github.com/ariel-frischer/autospec/internal/session"
+	"github.com/ariel-frischer/autospec/internal/tui"
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
 
 // ClaudeRunner abstracts Claude command execution for testability.
 // This interface enables mocking Claude commands in unit tests without
@@ -38,10 +48,147 @@ type ClaudeRunner interface {
 	FormatCommand(prompt string) string
 }
 
+// UsageReporter is optionally implemented by a ClaudeRunner that captures
+// token usage from the agent's JSON output. The executor checks for it after
+// each stage attempt; runners that don't implement it simply record no usage.
+//
+// Primary implementation: ClaudeExecutor in claude.go
+type UsageReporter interface {
+	// LastUsage returns usage reported by the most recent Execute call,
+	// or nil if the agent reported none.
+	LastUsage() *cliagent.Usage
+}
+
+// SubAgentSelector is optionally implemented by a ClaudeRunner whose agent can
+// run a named sub-agent (e.g., opencode --agent). The executor sets it before
+// each stage attempt; an empty name runs the agent's default.
+//
+// Primary implementation: ClaudeExecutor in claude.go
+type SubAgentSelector interface {
+	SetSubAgent(name string)
+}
+
+// ModelSelector is optionally implemented by a ClaudeRunner whose agent can
+// run a chosen model (e.g., claude --model). The executor sets it before each
+// stage attempt; an empty name runs the agent's default model.
+//
+// Primary implementation: ClaudeExecutor in claude.go
+type ModelSelector interface {
+	SetModel(name string)
+}
+
+// StageAgentSelector is optionally implemented by a ClaudeRunner that can run
+// a stage with a different agent (stage_agents config). The executor sets it
+// before each stage attempt; nil restores the runner's default agent.
+//
+// Primary implementation: ClaudeExecutor in claude.go
+type StageAgentSelector interface {
+	SetStageAgent(agent cliagent.Agent)
+}
+
+// SessionResumer is optionally implemented by a ClaudeRunner whose agent can
+// continue an earlier session (e.g., claude --resume). When session_mode is
+// continuous, the executor sets the spec's session before each stage attempt
+// and records the session the agent reports afterwards.
+//
+// Primary implementation: ClaudeExecutor in claude.go
+type SessionResumer interface {
+	// SetResumeSession sets the session to continue ("" = new session).
+	SetResumeSession(id string)
+	// LastSessionID returns the session ID reported by the most recent
+	// Execute call, or "" if the agent reported none.
+	LastSessionID() string
+}
+
+// SandboxSelector is optionally implemented by a ClaudeRunner that can run the
+// agent inside a container. The executor sets it before each stage attempt;
+// nil runs the agent on the host.
+//
+// Primary implementation: ClaudeExecutor in claude.go
+type SandboxSelector interface {
+	SetSandbox(sandbox *cliagent.SandboxConfig)
+}
+
+// ToolPolicySelector is optionally implemented by a ClaudeRunner that can
+// restrict the agent's tool use. The executor sets the stage's rules from
+// .autospec/policy.yaml before each stage attempt; nil applies no policy.
+//
+// Primary implementation: ClaudeExecutor in claude.go
+type ToolPolicySelector interface {
+	SetToolPolicy(p *cliagent.ToolPolicy)
+}
+
+// StageTimeoutSetter is optionally implemented by a ClaudeRunner that can
+// apply a per-stage time limit. The executor sets it before each stage
+// attempt; zero falls back to the runner's global timeout.
+//
+// Primary implementation: ClaudeExecutor in claude.go
+type StageTimeoutSetter interface {
+	SetStageTimeout(d time.Duration)
+}
+
+// TranscriptCapturer is optionally implemented by a ClaudeRunner that can copy
+// agent output into a session transcript. The executor sets a recorder before
+// each stage attempt and clears it afterwards; nil stops capturing.
+//
+// Primary implementation: ClaudeExecutor in claude.go
+type TranscriptCapturer interface {
+	CaptureTranscript(rec *session.Recorder)
+}
+
+// InputAlerter is optionally implemented by a ClaudeRunner that can watch
+// agent output for signs the agent is waiting for user input. The executor
+// sets it before each stage attempt; a nil alert turns watching off.
+//
+// Primary implementation: ClaudeExecutor in claude.go
+type InputAlerter interface {
+	SetInputAlert(idleThreshold time.Duration, alert func(reason string))
+}
+
+// UsageRecorder persists per-phase token usage and cost estimates.
+//
+// Primary implementation: history.Writer, which attaches usage to the
+// history entry of the running command.
+type UsageRecorder interface {
+	RecordUsage(usage history.PhaseUsage) error
+}
+
+// ValidationRecorder receives the outcome of every validated stage attempt.
+//
+// Primary implementations: report.JUnit and report.SARIF, enabled with
+// --report junit=<path> and --report sarif=<path>.
+type ValidationRecorder interface {
+	RecordValidation(result report.ValidationResult)
+}
+
+// MetricsRecorder counts the agent sessions of each stage for the local
+// usage metrics. attempt is 1 for the first session of a stage.
+//
+// Primary implementation: metrics.Recorder, enabled by metrics.enabled.
+type MetricsRecorder interface {
+	RecordStage(stage, agent string, attempt int, passed bool, duration time.Duration)
+}
+
+// LiveOutput receives phase boundaries so streamed agent output can be
+// grouped per phase. Interactive stages need the raw terminal, so the
+// executor suspends the live view around them.
+//
+// Primary implementation: tui.Program, enabled with the --tui flag.
+type LiveOutput interface {
+	// StartPhase begins a new transcript section; EndPhase closes it.
+	// Both receive the same name for a given attempt.
+	StartPhase(name string)
+	EndPhase(name string, err error)
+
+	// Suspend hands the terminal back; Resume takes it over again.
+	Suspend()
+	Resume() error
+}
+
 // StageExecutorInterface defines the contract for stage execution (specify, plan, tasks).
 // Implementations handle the core workflow stages that transform feature descriptions into
 // specifications, plans, and task breakdowns. Also handles auxiliary stages like constitution,
-// clarify, checklist, and analyze.
+// clarify, checklist, analyze, and verify.
 //
 // Design rationale: Narrow interface following Go idiom "accept interfaces, return concrete types"
 // to enable focused mocking in unit tests without coupling to implementation details.
@@ -76,6 +223,10 @@ type StageExecutorInterface interface {
 	// ExecuteAnalyze runs the analyze stage with optional prompt.
 	// Analyze performs cross-artifact consistency and quality analysis.
 	ExecuteAnalyze(specName string, prompt string) error
+
+	// ExecuteVerify runs the verify stage with optional prompt.
+	// Verify checks completed tasks' acceptance criteria against the code.
+	ExecuteVerify(specName string, prompt string) error
 }
 
 // PhaseExecutorInterface defines the contract for phase-based implementation execution.
@@ -153,6 +304,45 @@ var (
 	// Verify ClaudeExecutor satisfies ClaudeRunner
 	_ ClaudeRunner = (*ClaudeExecutor)(nil)
 
+	// Verify ClaudeExecutor reports token usage
+	_ UsageReporter = (*ClaudeExecutor)(nil)
+
+	// Verify ClaudeExecutor can switch sub-agents per stage
+	_ SubAgentSelector = (*ClaudeExecutor)(nil)
+
+	// Verify ClaudeExecutor can continue agent sessions
+	_ SessionResumer = (*ClaudeExecutor)(nil)
+
+	// Verify ClaudeExecutor can run agents in a sandbox
+	_ SandboxSelector = (*ClaudeExecutor)(nil)
+
+	// Verify ClaudeExecutor can restrict agent tool use
+	_ ToolPolicySelector = (*ClaudeExecutor)(nil)
+
+	// Verify ClaudeExecutor can apply per-stage timeouts
+	_ StageTimeoutSetter = (*ClaudeExecutor)(nil)
+
+	// Verify ClaudeExecutor can capture session transcripts
+	_ TranscriptCapturer = (*ClaudeExecutor)(nil)
+
+	// Verify ClaudeExecutor can alert when the agent waits for input
+	_ InputAlerter = (*ClaudeExecutor)(nil)
+
+	// Verify history.Writer can persist token usage
+	_ UsageRecorder = (*history.Writer)(nil)
+
+	// Verify the JUnit report can collect validation results
+	_ ValidationRecorder = (*report.JUnit)(nil)
+
+	// Verify the SARIF report can collect analyze results
+	_ ValidationRecorder = (*report.SARIF)(nil)
+
+	// Verify the metrics recorder can count stage sessions
+	_ MetricsRecorder = (*metrics.Recorder)(nil)
+
+	// Verify the TUI program can stream stage output
+	_ LiveOutput = (*tui.Program)(nil)
+
 	// Verify StageExecutor satisfies StageExecutorInterface
 	_ StageExecutorInterface = (*StageExecutor)(nil)
 
@@ -0,0 +1,46 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/ariel-frischer/autospec/internal/progress"
+)
+
+// startLivePhase opens a live transcript section for a stage attempt and
+// returns its name. Retries get their own section so each attempt's output
+// can be collapsed independently.
+func (e *Executor) startLivePhase(stageInfo progress.StageInfo) string {
+	name := stageInfo.Name
+	if stageInfo.RetryCount > 0 {
+		name = fmt.Sprintf("%s (retry %d)", name, stageInfo.RetryCount)
+	}
+	if e.LiveOutput != nil {
+		e.LiveOutput.StartPhase(name)
+	}
+	return name
+}
+
+// endLivePhase closes the live transcript section opened by startLivePhase.
+func (e *Executor) endLivePhase(name string, err error) {
+	if e.LiveOutput != nil {
+		e.LiveOutput.EndPhase(name, err)
+	}
+}
+
+// suspendLiveOutput releases the terminal for an interactive agent session.
+func (e *Executor) suspendLiveOutput() {
+	if e.LiveOutput != nil {
+		e.LiveOutput.Suspend()
+	}
+}
+
+// resumeLiveOutput takes the terminal back after an interactive session.
+// Failure only warns; output then goes straight to the terminal.
+func (e *Executor) resumeLiveOutput() {
+	if e.LiveOutput == nil {
+		return
+	}
+	if err := e.LiveOutput.Resume(); err != nil {
+		fmt.Printf("Warning: failed to resume live output: %v\n", err)
+	}
+}
@@ -0,0 +1,116 @@
+// Package workflow tests live output phase events.
+// Related: internal/workflow/live_output.go, internal/tui/program.go
+// Tags: workflow, tui, live-output, streaming
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockLiveOutput records phase events in order.
+type mockLiveOutput struct {
+	events    []string
+	resumeErr error
+}
+
+func (m *mockLiveOutput) StartPhase(name string) {
+	m.events = append(m.events, "start "+name)
+}
+
+func (m *mockLiveOutput) EndPhase(name string, err error) {
+	status := "ok"
+	if err != nil {
+		status = "failed"
+	}
+	m.events = append(m.events, fmt.Sprintf("end %s %s", name, status))
+}
+
+func (m *mockLiveOutput) Suspend() {
+	m.events = append(m.events, "suspend")
+}
+
+func (m *mockLiveOutput) Resume() error {
+	m.events = append(m.events, "resume")
+	return m.resumeErr
+}
+
+func TestExecuteStage_LiveOutputPhases(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		stage       Stage
+		executeErr  error
+		validations []error
+		wantEvents  []string
+		wantErr     bool
+	}{
+		"successful stage": {
+			stage:      StagePlan,
+			wantEvents: []string{"start plan", "end plan ok"},
+		},
+		"agent failure": {
+			stage:      StagePlan,
+			executeErr: errors.New("agent crashed"),
+			wantEvents: []string{"start plan", "end plan failed"},
+			wantErr:    true,
+		},
+		"retry gets its own phase": {
+			stage:       StageTasks,
+			validations: []error{errors.New("missing tasks"), nil},
+			wantEvents:  []string{"start tasks", "end tasks failed", "start tasks (retry 1)", "end tasks (retry 1) ok"},
+		},
+		"interactive stage suspends the view": {
+			stage:      StageClarify,
+			wantEvents: []string{"suspend", "resume"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			live := &mockLiveOutput{}
+			executor := &Executor{
+				Claude:     &mockClaudeExecutor{executeErr: tt.executeErr},
+				StateDir:   t.TempDir(),
+				SpecsDir:   t.TempDir(),
+				MaxRetries: 1,
+				LiveOutput: live,
+			}
+			validations := tt.validations
+			validate := func(string) error {
+				if len(validations) == 0 {
+					return nil
+				}
+				err := validations[0]
+				validations = validations[1:]
+				return err
+			}
+
+			_, err := executor.ExecuteStage("001-test", tt.stage, "/autospec."+string(tt.stage), validate)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantEvents, live.events)
+		})
+	}
+}
+
+func TestExecuteStage_NoLiveOutput(t *testing.T) {
+	t.Parallel()
+
+	executor := &Executor{
+		Claude:   &mockClaudeExecutor{},
+		StateDir: t.TempDir(),
+		SpecsDir: t.TempDir(),
+	}
+	_, err := executor.ExecuteStage("001-test", StageClarify, "/autospec.clarify", func(string) error { return nil })
+	assert.NoError(t, err)
+}
@@ -270,6 +270,7 @@ type MockStageExecutor struct {
 	ClarifyError      error
 	ChecklistError    error
 	AnalyzeError      error
+	VerifyError       error
 
 	// Call tracking
 	SpecifyCalls      []string // Feature descriptions
@@ -279,6 +280,7 @@ type MockStageExecutor struct {
 	ClarifyCalls      []ClarifyCall
 	ChecklistCalls    []ChecklistCall
 	AnalyzeCalls      []AnalyzeCall
+	VerifyCalls       []VerifyCall
 }
 
 // PlanCall records a call to ExecutePlan.
@@ -311,6 +313,12 @@ type AnalyzeCall struct {
 	Prompt   string
 }
 
+// VerifyCall records a call to ExecuteVerify.
+type VerifyCall struct {
+	SpecName string
+	Prompt   string
+}
+
 // NewMockStageExecutor creates a new MockStageExecutor with default success behavior.
 func NewMockStageExecutor() *MockStageExecutor {
 	return &MockStageExecutor{
@@ -322,6 +330,7 @@ func NewMockStageExecutor() *MockStageExecutor {
 		ClarifyCalls:      make([]ClarifyCall, 0),
 		ChecklistCalls:    make([]ChecklistCall, 0),
 		AnalyzeCalls:      make([]AnalyzeCall, 0),
+		VerifyCalls:       make([]VerifyCall, 0),
 	}
 }
 
@@ -367,6 +376,12 @@ func (m *MockStageExecutor) ExecuteAnalyze(specName string, prompt string) error
 	return m.AnalyzeError
 }
 
+// ExecuteVerify implements StageExecutorInterface.
+func (m *MockStageExecutor) ExecuteVerify(specName string, prompt string) error {
+	m.VerifyCalls = append(m.VerifyCalls, VerifyCall{SpecName: specName, Prompt: prompt})
+	return m.VerifyError
+}
+
 // Compile-time interface compliance check.
 var _ StageExecutorInterface = (*MockStageExecutor)(nil)
 
@@ -13,10 +13,20 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/ariel-frischer/autospec/internal/budget"
 	"github.com/ariel-frischer/autospec/internal/config"
 	"github.com/ariel-frischer/autospec/internal/dag"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/metrics"
+	"github.com/ariel-frischer/autospec/internal/notify"
+	"github.com/ariel-frischer/autospec/internal/policy"
+	"github.com/ariel-frischer/autospec/internal/retry"
 	"github.com/ariel-frischer/autospec/internal/spec"
+	"github.com/ariel-frischer/autospec/internal/state"
 	"github.com/ariel-frischer/autospec/internal/validation"
 )
 
@@ -71,12 +81,15 @@ func (w *WorkflowOrchestrator) debugLog(format string, args ...interface{}) {
 // Agent resolution priority:
 // 1. cfg.GetAgent() - uses agent abstraction (agent_preset or custom_agent)
 // 2. Falls back to default "claude" agent from registry
+// cfg.GetStageAgents() replaces the agent for stages listed in stage_agents.
 //
 // Note: CLI commands typically set Executor.NotificationHandler after construction.
 // The Executor methods support both new controllers and deprecated fields via fallback.
 func NewWorkflowOrchestrator(cfg *config.Configuration) *WorkflowOrchestrator {
 	// Create ClaudeExecutor with agent from config
 	claude := newClaudeExecutorFromConfig(cfg)
+	// Unknown agent names are reported by config validation when loading
+	stageAgents, _ := cfg.GetStageAgents()
 
 	// Create ProgressController with nil display (no-op, CLI commands don't use progress display)
 	progressCtrl := NewProgressController(nil)
@@ -85,21 +98,49 @@ func NewWorkflowOrchestrator(cfg *config.Configuration) *WorkflowOrchestrator {
 	notifyDispatch := NewNotifyDispatcher(nil)
 
 	executor := &Executor{
-		Claude:      claude,
-		StateDir:    cfg.StateDir,
-		SpecsDir:    cfg.SpecsDir,
-		MaxRetries:  cfg.MaxRetries,
-		TotalStages: 3,     // Default to 3 stages (specify, plan, tasks)
-		Debug:       false, // Will be set by CLI command
-		AutoCommit:  cfg.AutoCommit,
-		Progress:    progressCtrl,
-		Notify:      notifyDispatch,
+		Claude:          claude,
+		StateDir:        cfg.StateDir,
+		SpecsDir:        cfg.SpecsDir,
+		Constitution:    cfg.Constitution,
+		MaxRetries:      cfg.MaxRetries,
+		RetryPolicy:     cfg.RetryPolicy,
+		TotalStages:     3,     // Default to 3 stages (specify, plan, tasks)
+		Debug:           false, // Will be set by CLI command
+		AutoCommit:      cfg.AutoCommit,
+		Progress:        progressCtrl,
+		Notify:          notifyDispatch,
+		PostValidate:    cfg.PostValidate,
+		Hooks:           cfg.Hooks,
+		Tests:           TestRunner(cfg.Validation.Tests),
+		SubAgents:       cfg.SubAgent,
+		Models:          cfg.Model,
+		StageAgents:     stageAgents,
+		PhaseTimeouts:   cfg.PhaseTimeouts,
+		Sessions:        state.NewSessionManager(cfg.StateDir, cfg.SessionMode),
+		Sandbox:         cfg.Sandbox,
+		PolicyFile:      policy.Path(),
+		Budget:          budget.NewTracker(cfg.Budget, cfg.StateDir),
+		OnUnreachable:   cfg.OnUnreachable,
+		OnStall:         cfg.OnStall,
+		EscalateAfter:   cfg.EscalateBlockedAfter,
+		EscalateBlocked: cfg.EscalateBlocked,
+		PromptsDir:      PromptsDir(),
+		Estimates:       history.LoadPhaseEstimates(cfg.StateDir, cfg.SpecsDir),
+		Webhooks:        notify.NewWebhookNotifier(cfg.Notifications.AllWebhooks()),
+		MaxSessions:     cfg.MaxSessions,
+		MaxSnapshots:    cfg.MaxSnapshots,
+	}
+
+	if cfg.Metrics.Enabled {
+		executor.Metrics = metrics.Default
 	}
 
 	// Create default executor implementations
 	stageExec := NewStageExecutor(executor, cfg.SpecsDir, false)
 	phaseExec := NewPhaseExecutor(executor, cfg.SpecsDir, false)
 	taskExec := NewTaskExecutor(executor, cfg.SpecsDir, false)
+	taskExec.commitTasks = cfg.TaskCommits
+	taskExec.taskGate = cfg.Validation.TaskGate
 
 	return &WorkflowOrchestrator{
 		Executor:      executor,
@@ -145,47 +186,77 @@ func NewWorkflowOrchestratorWithExecutors(cfg *config.Configuration, opts Execut
 	return orch
 }
 
+// SetWorkDir runs the agent and per-task commits in dir instead of the current
+// directory, e.g. an isolated worktree for implement --worktree.
+func (w *WorkflowOrchestrator) SetWorkDir(dir string) {
+	w.Executor.WorkDir = dir
+	if claude, ok := w.Executor.Claude.(*ClaudeExecutor); ok {
+		claude.WorkDir = dir
+	}
+}
+
 // RunCompleteWorkflow executes the full specify → plan → tasks workflow
 func (w *WorkflowOrchestrator) RunCompleteWorkflow(featureDescription string) error {
+	specName, err := w.runCompleteWorkflow(featureDescription)
+	w.Executor.emitWorkflowResult(specName, err)
+	return err
+}
+
+// runCompleteWorkflow runs specify → plan → tasks and returns the spec name.
+func (w *WorkflowOrchestrator) runCompleteWorkflow(featureDescription string) (string, error) {
 	if err := w.runPreflightIfNeeded(); err != nil {
-		return fmt.Errorf("preflight checks failed: %w", err)
+		return "", fmt.Errorf("preflight checks failed: %w", err)
 	}
 
 	specName, err := w.executeSpecifyPlanTasks(featureDescription, 3)
 	if err != nil {
-		return fmt.Errorf("executing specify-plan-tasks workflow: %w", err)
+		return specName, fmt.Errorf("executing specify-plan-tasks workflow: %w", err)
 	}
 
 	fmt.Println("Workflow completed successfully!")
 	fmt.Printf("Spec: specs/%s/\n", specName)
 	fmt.Println("Next: autospec implement")
 
-	return nil
+	return specName, nil
 }
 
-// RunFullWorkflow executes the complete specify → plan → tasks → implement workflow
+// RunFullWorkflow executes the complete specify → plan → tasks → implement
+// workflow, or the steps set by the pipeline config. Progress is checkpointed
+// after each step; with resume, steps recorded in the checkpoint for this
+// feature description are skipped and implement resumes in place.
 func (w *WorkflowOrchestrator) RunFullWorkflow(featureDescription string, resume bool) error {
-	// Set total stages for full workflow
-	w.Executor.TotalStages = 4
+	specName, err := w.runFullWorkflow(featureDescription, resume)
+	w.Executor.emitWorkflowResult(specName, err)
+	return err
+}
 
-	if err := w.runPreflightIfNeeded(); err != nil {
-		return fmt.Errorf("preflight checks failed: %w", err)
+// runFullWorkflow runs the full workflow pipeline and returns the spec name.
+func (w *WorkflowOrchestrator) runFullWorkflow(featureDescription string, resume bool) (string, error) {
+	steps, err := BuildPipeline(w.Config)
+	if err != nil {
+		return "", err
 	}
+	w.Executor.TotalStages = len(steps)
 
-	// Execute specify → plan → tasks stages
-	specName, err := w.executeSpecifyPlanTasks(featureDescription, 4)
-	if err != nil {
-		return fmt.Errorf("executing specify-plan-tasks workflow: %w", err)
+	if err := w.runPreflightIfNeeded(); err != nil {
+		return "", fmt.Errorf("preflight checks failed: %w", err)
 	}
 
-	// Execute implement stage
-	if err := w.executeImplementStage(specName, featureDescription, resume); err != nil {
-		return fmt.Errorf("executing implement stage: %w", err)
+	checkpoint := w.loadResumeCheckpoint(featureDescription, resume)
+
+	var specName string
+	for i, step := range steps {
+		fmt.Printf("[Stage %d/%d] %s...\n", i+1, len(steps), stageTitle(step.Name))
+		if specName, err = w.runPipelineStep(step, featureDescription, specName, resume, checkpoint); err != nil {
+			return specName, err
+		}
 	}
 
+	w.clearCheckpoint(specName)
+
 	// Print success summary
-	w.printFullWorkflowSummary(specName)
-	return nil
+	w.printFullWorkflowSummary(specName, steps)
+	return specName, nil
 }
 
 // runPreflightIfNeeded runs preflight checks if enabled
@@ -199,63 +270,78 @@ func (w *WorkflowOrchestrator) runPreflightIfNeeded() error {
 // executeSpecifyPlanTasks runs specify, plan, and tasks stages sequentially.
 // Delegates to StageExecutor for all stage execution.
 func (w *WorkflowOrchestrator) executeSpecifyPlanTasks(featureDescription string, totalStages int) (string, error) {
-	// Stage 1: Specify
-	fmt.Printf("[Stage 1/%d] Specify...\n", totalStages)
-	fmt.Printf("Executing: /autospec.specify \"%s\"\n", featureDescription)
-
-	specName, err := w.stageExecutor.ExecuteSpecify(featureDescription)
-	if err != nil {
-		return "", fmt.Errorf("specify stage failed: %w", err)
+	var specName string
+	for i, stage := range []Stage{StageSpecify, StagePlan, StageTasks} {
+		fmt.Printf("[Stage %d/%d] %s...\n", i+1, totalStages, stageTitle(string(stage)))
+		var err error
+		if specName, err = w.runCheckpointedStage(stage, featureDescription, specName, nil); err != nil {
+			return "", err
+		}
 	}
-	fmt.Printf("✓ Created specs/%s/spec.yaml (schema valid)\n\n", specName)
-
-	// Stage 2: Plan
-	fmt.Printf("[Stage 2/%d] Plan...\n", totalStages)
-	fmt.Println("Executing: /autospec.plan")
+	return specName, nil
+}
 
-	if err := w.stageExecutor.ExecutePlan(specName, ""); err != nil {
-		return "", fmt.Errorf("plan stage failed: %w", err)
+// runCheckpointedStage runs the specify, plan or tasks stage, or skips it when
+// the checkpoint records it as complete. A nil checkpoint runs the stage
+// without recording progress. Returns the spec name, which specify sets.
+func (w *WorkflowOrchestrator) runCheckpointedStage(stage Stage, featureDescription, specName string, cp *retry.WorkflowCheckpoint) (string, error) {
+	if w.canSkipStage(cp, stage) {
+		fmt.Printf("✓ Skipped (specs/%s/%s completed in previous run)\n\n", cp.SpecName, checkpointArtifacts[stage])
+		return cp.SpecName, nil
 	}
-	fmt.Printf("✓ Created specs/%s/plan.yaml (schema valid)\n\n", specName)
-
-	// Stage 3: Tasks
-	fmt.Printf("[Stage 3/%d] Tasks...\n", totalStages)
-	fmt.Println("Executing: /autospec.tasks")
 
-	if err := w.stageExecutor.ExecuteTasks(specName, ""); err != nil {
-		return "", fmt.Errorf("tasks stage failed: %w", err)
+	var err error
+	switch stage {
+	case StageSpecify:
+		fmt.Printf("Executing: /autospec.specify \"%s\"\n", featureDescription)
+		specName, err = w.stageExecutor.ExecuteSpecify(featureDescription)
+	case StagePlan:
+		fmt.Println("Executing: /autospec.plan")
+		err = w.stageExecutor.ExecutePlan(specName, "")
+	case StageTasks:
+		fmt.Println("Executing: /autospec.tasks")
+		err = w.stageExecutor.ExecuteTasks(specName, "")
+	default:
+		return specName, fmt.Errorf("stage %s is not checkpointed", stage)
 	}
-	fmt.Printf("✓ Created specs/%s/tasks.yaml (schema valid)\n\n", specName)
-
+	if err != nil {
+		return "", fmt.Errorf("%s stage failed: %w", stage, err)
+	}
+	w.recordCheckpoint(cp, specName, stage)
+	fmt.Printf("✓ Created specs/%s/%s (schema valid)\n\n", specName, checkpointArtifacts[stage])
 	return specName, nil
 }
 
-// executeImplementStage runs the implement stage with resume support.
-// Delegates to PhaseExecutor.ExecuteDefault for execution.
-func (w *WorkflowOrchestrator) executeImplementStage(specName, featureDescription string, resume bool) error {
-	fmt.Println("[Stage 4/4] Implement...")
-	specDir := filepath.Join(w.SpecsDir, specName)
-	return w.phaseExecutor.ExecuteDefault(specName, specDir, "", resume)
+// stageTitle capitalizes a stage or step name for progress headers.
+func stageTitle(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
 }
 
 // printFullWorkflowSummary prints the completion summary for full workflow
-func (w *WorkflowOrchestrator) printFullWorkflowSummary(specName string) {
-	fmt.Println("\n✓ All tasks completed!")
-	fmt.Println()
-
+func (w *WorkflowOrchestrator) printFullWorkflowSummary(specName string, steps []PipelineStep) {
 	specDir := filepath.Join(w.SpecsDir, specName)
-	tasksPath := validation.GetTasksFilePath(specDir)
-	stats, statsErr := validation.GetTaskStats(tasksPath)
-	if statsErr == nil && stats.TotalTasks > 0 {
-		fmt.Println("Task Summary:")
-		fmt.Print(validation.FormatTaskSummary(stats))
+	if slices.ContainsFunc(steps, func(s PipelineStep) bool { return s.Stage == StageImplement }) {
+		fmt.Println("\n✓ All tasks completed!")
 		fmt.Println()
-	}
 
-	// Mark spec as completed
-	markSpecCompletedAndPrint(specDir)
+		tasksPath := validation.GetTasksFilePath(specDir)
+		stats, statsErr := validation.GetTaskStats(tasksPath)
+		if statsErr == nil && stats.TotalTasks > 0 {
+			fmt.Println("Task Summary:")
+			fmt.Print(validation.FormatTaskSummary(stats))
+			fmt.Println()
+		}
+
+		// Mark spec as completed
+		markSpecCompletedAndPrint(specDir)
+	} else {
+		fmt.Println()
+	}
 
-	fmt.Println("Completed 4 workflow stage(s): specify → plan → tasks → implement")
+	fmt.Printf("Completed %d workflow stage(s): %s\n", len(steps), pipelineStepNames(steps))
 	fmt.Printf("Spec: specs/%s/\n", specName)
 	w.debugLog("RunFullWorkflow exiting normally")
 }
@@ -376,6 +462,10 @@ func (w *WorkflowOrchestrator) ExecuteTasks(specNameArg string, prompt string) e
 		return fmt.Errorf("resolving spec name: %w", err)
 	}
 
+	if err := w.handleSpecDrift(specName, StagePlan); err != nil {
+		return err
+	}
+
 	if prompt != "" {
 		fmt.Printf("Executing: /autospec.tasks \"%s\"\n", prompt)
 	} else {
@@ -415,12 +505,65 @@ func (w *WorkflowOrchestrator) ExecuteImplement(specNameArg string, prompt strin
 		specName = fmt.Sprintf("%s-%s", metadata.Number, metadata.Name)
 	}
 
-	// Dispatch to appropriate execution mode based on phase options
+	if err := w.checkSpecDependencies(metadata.Directory); err != nil {
+		return err
+	}
+	if !phaseOpts.DryRun {
+		if err := w.handleSpecDrift(specName, StagePlan, StageTasks); err != nil {
+			return err
+		}
+	}
+
+	// Escalate tasks left blocked by earlier runs, and start tracking the
+	// ones this run blocks
+	if !phaseOpts.DryRun {
+		w.Executor.escalateBlockedTasks(specName, time.Now())
+	}
+	err = w.executeImplementMode(specName, metadata, prompt, resume, phaseOpts)
+	if !phaseOpts.DryRun {
+		w.Executor.escalateBlockedTasks(specName, time.Now())
+		w.Executor.emitWorkflowResult(specName, err)
+	}
+	return err
+}
+
+// checkSpecDependencies refuses to implement a spec while specs listed in its
+// depends_on have incomplete tasks. With SkipPreflight it only warns.
+func (w *WorkflowOrchestrator) checkSpecDependencies(specDir string) error {
+	statuses, err := spec.CheckDependencies(w.SpecsDir, specDir)
+	if err != nil {
+		return fmt.Errorf("checking spec dependencies: %w", err)
+	}
+	incomplete := spec.IncompleteDependencies(statuses)
+	if len(incomplete) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	for _, dep := range incomplete {
+		fmt.Fprintf(&b, "\n  - %s", dep)
+	}
+	if w.SkipPreflight {
+		fmt.Printf("Warning: spec dependencies are not complete:%s\n\n", b.String())
+		return nil
+	}
+	return fmt.Errorf("spec dependencies are not complete:%s\ncomplete them first or use --skip-preflight to implement anyway", b.String())
+}
+
+// executeImplementMode dispatches to the execution mode selected by the phase options.
+func (w *WorkflowOrchestrator) executeImplementMode(specName string, metadata *spec.Metadata, prompt string, resume bool, phaseOpts PhaseExecutionOptions) error {
 	switch phaseOpts.Mode() {
 	case ModeParallel:
 		return w.ExecuteImplementParallel(specName, metadata, prompt, phaseOpts)
 	case ModeAllTasks:
-		return w.ExecuteImplementWithTasks(specName, metadata, prompt, phaseOpts.FromTask)
+		fromTask := phaseOpts.FromTask
+		if resume && fromTask == "" {
+			fromTask = w.checkpointedTask(specName)
+		}
+		if phaseOpts.TaskFilter != nil {
+			return w.ExecuteImplementSelectedTasks(specName, prompt, fromTask, phaseOpts.TaskFilter)
+		}
+		return w.ExecuteImplementWithTasks(specName, metadata, prompt, fromTask)
 	case ModeAllPhases:
 		return w.ExecuteImplementWithPhases(specName, metadata, prompt, resume)
 	case ModeSinglePhase:
@@ -513,6 +656,52 @@ func (w *WorkflowOrchestrator) ExecuteImplementWithTasks(specName string, metada
 	return w.taskExecutor.ExecuteTaskLoop(specName, tasksPath, orderedTasks, startIdx, totalTasks, prompt)
 }
 
+// ExecuteImplementSelectedTasks runs the tasks matching filter (--only), each
+// in a separate Claude session, in dependency order. Each session is validated
+// on its own task only, so the rest of tasks.yaml may stay pending.
+func (w *WorkflowOrchestrator) ExecuteImplementSelectedTasks(specName, prompt, fromTask string, filter *validation.TaskFilter) error {
+	tasksPath := validation.GetTasksFilePath(filepath.Join(w.SpecsDir, specName))
+
+	orderedTasks, _, totalTasks, err := w.taskExecutor.PrepareTaskExecution(tasksPath, "")
+	if err != nil {
+		return fmt.Errorf("preparing task execution: %w", err)
+	}
+	selected := filter.Filter(orderedTasks)
+	if len(selected) == 0 {
+		return fmt.Errorf("no tasks match --only %q", filter)
+	}
+
+	startIdx := 0
+	if fromTask != "" {
+		startIdx = slices.IndexFunc(selected, func(t validation.TaskItem) bool { return t.ID == fromTask })
+		if startIdx == -1 {
+			return fmt.Errorf("task %s is not among the tasks selected by --only %q", fromTask, filter)
+		}
+	}
+
+	fmt.Printf("Running %d of %d tasks (--only %s)\n\n", len(selected), totalTasks, filter)
+	return w.taskExecutor.ExecuteTaskLoop(specName, tasksPath, selected, startIdx, len(selected), prompt)
+}
+
+// checkpointedTask returns the task an interrupted --tasks run was working on,
+// or "" when there is no task checkpoint or the task no longer exists.
+func (w *WorkflowOrchestrator) checkpointedTask(specName string) string {
+	state, err := retry.LoadTaskState(w.Executor.StateDir, specName)
+	if err != nil || state == nil || state.CurrentTaskID == "" {
+		return ""
+	}
+	tasks, err := validation.GetAllTasks(validation.GetTasksFilePath(filepath.Join(w.SpecsDir, specName)))
+	if err != nil {
+		return ""
+	}
+	if _, err := validation.GetTaskByID(tasks, state.CurrentTaskID); err != nil {
+		fmt.Printf("Warning: checkpointed task %s is no longer in tasks.yaml, starting from the first pending task\n", state.CurrentTaskID)
+		return ""
+	}
+	fmt.Printf("Resuming from checkpointed task %s\n", state.CurrentTaskID)
+	return state.CurrentTaskID
+}
+
 // ExecuteImplementParallel runs tasks concurrently using DAG-based wave scheduling.
 // Independent tasks within each wave run in parallel, respecting the max-parallel limit.
 func (w *WorkflowOrchestrator) ExecuteImplementParallel(specName string, metadata *spec.Metadata, prompt string, phaseOpts PhaseExecutionOptions) error {
@@ -738,6 +927,7 @@ func newClaudeExecutorFromConfig(cfg *config.Configuration) *ClaudeExecutor {
 		OutputStyle:                  outputStyle,
 		UseSubscription:              cfg.UseSubscription,
 		ReplaceProcessForInteractive: true, // Default: replace process for full terminal control
+		StallTimeout:                 cfg.StallTimeout,
 	}
 }
 
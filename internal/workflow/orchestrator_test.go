@@ -2666,6 +2666,46 @@ _meta:
 	}
 }
 
+// writeTestChecklist writes a valid checklists/<domain>.yaml to the given spec directory.
+func writeTestChecklist(t *testing.T, specDir, domain string) {
+	t.Helper()
+	content := `checklist:
+  feature: "Test"
+  branch: "001-test-feature"
+  domain: "` + domain + `"
+categories:
+  - name: "Completeness"
+    items:
+      - id: "CHK001"
+        description: "Are all functional requirements specified?"
+        type: "functional"
+        status: "pending"
+`
+	dir := filepath.Join(specDir, "checklists")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create checklists dir: %v", err)
+	}
+	path := filepath.Join(dir, domain+".yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write checklist: %v", err)
+	}
+}
+
+// writeTestAnalysis writes a valid analysis.yaml with the given findings to the given spec directory.
+func writeTestAnalysis(t *testing.T, specDir, findings string) {
+	t.Helper()
+	content := `analysis:
+  branch: "001-test-feature"
+  timestamp: "2025-01-01T00:00:00Z"
+summary:
+  overall_status: "PASS"
+findings: ` + findings + "\n"
+	path := filepath.Join(specDir, "analysis.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write analysis.yaml: %v", err)
+	}
+}
+
 // writeTestPlan writes a valid plan.yaml to the given spec directory.
 func writeTestPlan(t *testing.T, specDir string) {
 	t.Helper()
@@ -3326,6 +3366,7 @@ func TestAuxiliaryExecuteMethods(t *testing.T) {
 			methodName: "ExecuteChecklist",
 			setup: func(t *testing.T, specDir string) {
 				writeTestSpec(t, specDir)
+				writeTestChecklist(t, specDir, "ux")
 			},
 			execute: func(o *WorkflowOrchestrator, specName string) error {
 				return o.ExecuteChecklist(specName, "")
@@ -3336,6 +3377,7 @@ func TestAuxiliaryExecuteMethods(t *testing.T) {
 			methodName: "ExecuteChecklist",
 			setup: func(t *testing.T, specDir string) {
 				writeTestSpec(t, specDir)
+				writeTestChecklist(t, specDir, "ux")
 			},
 			execute: func(o *WorkflowOrchestrator, specName string) error {
 				return o.ExecuteChecklist(specName, "Include accessibility checks")
@@ -3346,6 +3388,7 @@ func TestAuxiliaryExecuteMethods(t *testing.T) {
 			methodName: "ExecuteAnalyze",
 			setup: func(t *testing.T, specDir string) {
 				writeTestSpec(t, specDir)
+				writeTestAnalysis(t, specDir, "[]")
 			},
 			execute: func(o *WorkflowOrchestrator, specName string) error {
 				return o.ExecuteAnalyze(specName, "")
@@ -3356,12 +3399,29 @@ func TestAuxiliaryExecuteMethods(t *testing.T) {
 			methodName: "ExecuteAnalyze",
 			setup: func(t *testing.T, specDir string) {
 				writeTestSpec(t, specDir)
+				writeTestAnalysis(t, specDir, "[]")
 			},
 			execute: func(o *WorkflowOrchestrator, specName string) error {
 				return o.ExecuteAnalyze(specName, "Focus on API consistency")
 			},
 			wantErr: false,
 		},
+		"ExecuteAnalyze with critical constitution violation": {
+			methodName: "ExecuteAnalyze",
+			setup: func(t *testing.T, specDir string) {
+				writeTestSpec(t, specDir)
+				writeTestAnalysis(t, specDir, `
+  - id: "K1"
+    category: "constitution"
+    severity: "CRITICAL"
+    location: "tasks.yaml:T001"
+    summary: "Tests are written after implementation"`)
+			},
+			execute: func(o *WorkflowOrchestrator, specName string) error {
+				return o.ExecuteAnalyze(specName, "")
+			},
+			wantErr: true,
+		},
 	}
 
 	for name, tt := range tests {
@@ -5019,6 +5079,87 @@ func TestOrchestratorDelegation_TaskExecutor(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		"ExecuteImplementSelectedTasks runs only matching tasks": {
+			setupSpec: func(specDir string) {
+				writeTestTasksForDelegation(t, specDir)
+			},
+			setup: func(m *MockTaskExecutor) {
+				m.PrepareResult = []validation.TaskItem{
+					{ID: "T001", Type: "test", Status: "Pending"},
+					{ID: "T002", Type: "implementation", Status: "Pending"},
+					{ID: "T003", Type: "test", Status: "Pending"},
+				}
+				m.PrepareTotalTasks = 3
+			},
+			action: func(orch *WorkflowOrchestrator, specName string) error {
+				filter, err := validation.ParseTaskFilter("type=test")
+				if err != nil {
+					return err
+				}
+				return orch.ExecuteImplementSelectedTasks(specName, "", "T003", filter)
+			},
+			verify: func(t *testing.T, m *MockTaskExecutor) {
+				if len(m.PrepareCalls) != 1 || m.PrepareCalls[0].FromTask != "" {
+					t.Errorf("PrepareCalls = %+v, want one call without fromTask", m.PrepareCalls)
+				}
+				if len(m.TaskLoopCalls) != 1 {
+					t.Fatalf("TaskLoopCalls = %d, want 1", len(m.TaskLoopCalls))
+				}
+				call := m.TaskLoopCalls[0]
+				var ids []string
+				for _, task := range call.OrderedTasks {
+					ids = append(ids, task.ID)
+				}
+				if strings.Join(ids, ",") != "T001,T003" || call.StartIdx != 1 || call.TotalTasks != 2 {
+					t.Errorf("TaskLoop tasks = %v, startIdx = %d, total = %d; want [T001 T003], 1, 2", ids, call.StartIdx, call.TotalTasks)
+				}
+			},
+			wantErr: false,
+		},
+		"ExecuteImplementSelectedTasks fails when nothing matches": {
+			setupSpec: func(specDir string) {
+				writeTestTasksForDelegation(t, specDir)
+			},
+			setup: func(m *MockTaskExecutor) {
+				m.PrepareResult = []validation.TaskItem{{ID: "T001", Type: "implementation"}}
+				m.PrepareTotalTasks = 1
+			},
+			action: func(orch *WorkflowOrchestrator, specName string) error {
+				filter, err := validation.ParseTaskFilter("T005-T007")
+				if err != nil {
+					return err
+				}
+				return orch.ExecuteImplementSelectedTasks(specName, "", "", filter)
+			},
+			verify: func(t *testing.T, m *MockTaskExecutor) {
+				if len(m.TaskLoopCalls) != 0 {
+					t.Errorf("TaskLoopCalls = %d, want 0", len(m.TaskLoopCalls))
+				}
+			},
+			wantErr: true,
+		},
+		"ExecuteImplementSelectedTasks rejects fromTask outside the selection": {
+			setupSpec: func(specDir string) {
+				writeTestTasksForDelegation(t, specDir)
+			},
+			setup: func(m *MockTaskExecutor) {
+				m.PrepareResult = []validation.TaskItem{{ID: "T001"}, {ID: "T002"}}
+				m.PrepareTotalTasks = 2
+			},
+			action: func(orch *WorkflowOrchestrator, specName string) error {
+				filter, err := validation.ParseTaskFilter("T002")
+				if err != nil {
+					return err
+				}
+				return orch.ExecuteImplementSelectedTasks(specName, "", "T001", filter)
+			},
+			verify: func(t *testing.T, m *MockTaskExecutor) {
+				if len(m.TaskLoopCalls) != 0 {
+					t.Errorf("TaskLoopCalls = %d, want 0", len(m.TaskLoopCalls))
+				}
+			},
+			wantErr: true,
+		},
 		"ExecuteImplementWithTasks propagates PrepareTaskExecution error": {
 			setupSpec: func(specDir string) {
 				writeTestTasksForDelegation(t, specDir)
@@ -5113,3 +5254,64 @@ _meta:
 		t.Fatalf("Failed to write tasks.yaml: %v", err)
 	}
 }
+
+// TestCheckSpecDependencies tests that implement is refused while depends_on
+// specs have incomplete tasks, and only warned about with SkipPreflight.
+func TestCheckSpecDependencies(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		dependsOn     string
+		skipPreflight bool
+		wantErr       string
+	}{
+		"no dependencies": {},
+		"completed dependency": {
+			dependsOn: "depends_on: [001-test-feature]\n",
+		},
+		"incomplete dependency refused": {
+			dependsOn: "depends_on: [002-pending]\n",
+			wantErr:   "002-pending (0/1 tasks)",
+		},
+		"missing dependency refused": {
+			dependsOn: "depends_on: [009-missing]\n",
+			wantErr:   "009-missing (not found)",
+		},
+		"incomplete dependency with skip preflight": {
+			dependsOn:     "depends_on: [002-pending]\n",
+			skipPreflight: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specsDir := t.TempDir()
+			writeTestTasksCompleted(t, setupSpecDirectory(t, specsDir, "001-test-feature"))
+			pending := setupSpecDirectory(t, specsDir, "002-pending")
+			pendingTasks := "tasks:\n  branch: \"002-pending\"\nphases:\n  - number: 1\n    title: \"Setup\"\n    tasks:\n      - id: \"T001\"\n        title: \"Task\"\n        status: \"Pending\"\n"
+			if err := os.WriteFile(filepath.Join(pending, "tasks.yaml"), []byte(pendingTasks), 0644); err != nil {
+				t.Fatalf("failed to write tasks.yaml: %v", err)
+			}
+
+			specDir := setupSpecDirectory(t, specsDir, "003-dependent")
+			specYAML := "feature:\n  branch: \"003-dependent\"\n" + tt.dependsOn
+			if err := os.WriteFile(filepath.Join(specDir, "spec.yaml"), []byte(specYAML), 0644); err != nil {
+				t.Fatalf("failed to write spec.yaml: %v", err)
+			}
+
+			w := &WorkflowOrchestrator{SpecsDir: specsDir, SkipPreflight: tt.skipPreflight}
+			err := w.checkSpecDependencies(specDir)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkSpecDependencies() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkSpecDependencies() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package workflow
 
+import "github.com/ariel-frischer/autospec/internal/validation"
+
 // PhaseExecutionMode represents the type of phase execution
 type PhaseExecutionMode int
 
@@ -30,6 +32,8 @@ type PhaseExecutionOptions struct {
 	TaskMode bool
 	// FromTask is the task ID to start from (--from-task TXXX, empty = not set)
 	FromTask string
+	// TaskFilter selects the tasks to run (--only, nil = all tasks); implies task mode
+	TaskFilter *validation.TaskFilter
 	// ParallelMode indicates --parallel flag was set (DAG-based concurrent execution)
 	ParallelMode bool
 	// MaxParallel is the maximum number of concurrent Claude sessions (default 4)
@@ -47,7 +51,7 @@ func (o *PhaseExecutionOptions) Mode() PhaseExecutionMode {
 	if o.ParallelMode {
 		return ModeParallel
 	}
-	if o.TaskMode {
+	if o.TaskMode || o.TaskFilter != nil {
 		return ModeAllTasks
 	}
 	if o.RunAllPhases {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/ariel-frischer/autospec/internal/notify"
 	"github.com/ariel-frischer/autospec/internal/validation"
 )
 
@@ -131,7 +132,15 @@ func (p *PhaseExecutor) executeSinglePhaseSession(specName string, phaseNumber i
 	EnsureContextDirGitignored()
 
 	// Build and execute command
-	command := p.buildPhaseCommand(phaseNumber, contextFilePath, prompt)
+	command, err := p.executor.renderStagePrompt(StageImplement, PromptData{
+		SpecName: specName,
+		Guidance: prompt,
+		Command:  p.buildPhaseCommand(phaseNumber, contextFilePath, prompt),
+		Phase:    phaseNumber,
+	})
+	if err != nil {
+		return err
+	}
 	fmt.Printf("Executing: %s\n", command)
 
 	return p.executePhaseWithValidation(specName, phaseNumber, command)
@@ -159,15 +168,15 @@ func (p *PhaseExecutor) checkPhaseSkipConditions(tasksPath string, phaseNumber i
 	return false, nil
 }
 
-// allTasksCompletedOrBlocked checks if all tasks in a phase are completed or blocked.
+// allTasksCompletedOrBlocked checks if all tasks in a phase are completed, blocked or skipped.
 func (p *PhaseExecutor) allTasksCompletedOrBlocked(phaseTasks []validation.TaskItem, phaseNumber int) bool {
 	completedCount := 0
 	for _, task := range phaseTasks {
 		status := task.Status
 		if status == "Completed" || status == "completed" || status == "Done" || status == "done" {
 			completedCount++
-		} else if status != "Blocked" && status != "blocked" {
-			return false // Found a task that's neither completed nor blocked
+		} else if status != "Blocked" && status != "blocked" && !task.IsSkipped() {
+			return false // Found a task that's neither completed, blocked nor skipped
 		}
 	}
 
@@ -204,6 +213,9 @@ func (p *PhaseExecutor) buildPhaseCommand(phaseNumber int, contextFilePath, prom
 
 // executePhaseWithValidation executes the phase command with validation.
 func (p *PhaseExecutor) executePhaseWithValidation(specName string, phaseNumber int, command string) error {
+	phaseEvent := notify.Event{Event: notify.EventPhaseStarted, Spec: specName, Stage: string(StageImplement), Phase: phaseNumber}
+	p.executor.emitEvent(phaseEvent)
+
 	result, err := p.executor.ExecuteStage(
 		specName,
 		StageImplement,
@@ -215,13 +227,16 @@ func (p *PhaseExecutor) executePhaseWithValidation(specName string, phaseNumber
 				return fmt.Errorf("checking phase %d completion: %w", phaseNumber, err)
 			}
 			if !complete {
-				return fmt.Errorf("phase %d has incomplete tasks", phaseNumber)
+				return withContinuation(fmt.Errorf("phase %d has incomplete tasks", phaseNumber), specDir, phaseNumber)
 			}
 			return nil
 		},
 	)
 
 	if err != nil {
+		phaseEvent.Event = notify.EventPhaseFailed
+		phaseEvent.Message = err.Error()
+		p.executor.emitEvent(phaseEvent)
 		if result.Exhausted {
 			fmt.Printf("\nPhase %d paused.\n", phaseNumber)
 			fmt.Printf("To resume: autospec implement --phase %d\n", phaseNumber)
@@ -230,6 +245,8 @@ func (p *PhaseExecutor) executePhaseWithValidation(specName string, phaseNumber
 		return fmt.Errorf("executing phase %d session: %w", phaseNumber, err)
 	}
 
+	phaseEvent.Event = notify.EventPhaseCompleted
+	p.executor.emitEvent(phaseEvent)
 	return nil
 }
 
@@ -290,7 +307,14 @@ func (p *PhaseExecutor) ExecuteDefault(specName, specDir, prompt string, resume
 	fmt.Printf("Progress: checking tasks...\n\n")
 
 	// Build command with optional prompt and resume flag
-	command := p.buildDefaultCommand(prompt, resume)
+	command, err := p.executor.renderStagePrompt(StageImplement, PromptData{
+		SpecName: specName,
+		Guidance: prompt,
+		Command:  p.buildDefaultCommand(prompt, resume),
+	})
+	if err != nil {
+		return err
+	}
 	p.printExecuting("/autospec.implement", prompt)
 
 	result, err := p.executor.ExecuteStage(
@@ -299,7 +323,7 @@ func (p *PhaseExecutor) ExecuteDefault(specName, specDir, prompt string, resume
 		command,
 		func(sd string) error {
 			tasksPath := validation.GetTasksFilePath(sd)
-			return p.executor.ValidateTasksComplete(tasksPath)
+			return withContinuation(p.executor.ValidateTasksComplete(tasksPath, false), sd, 0)
 		},
 	)
 
@@ -0,0 +1,142 @@
+package workflow
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/retry"
+)
+
+// defaultPipeline is the step order `autospec full` runs when no pipeline is
+// configured.
+var defaultPipeline = []string{"specify", "plan", "tasks", "implement"}
+
+// canonicalStages lists every stage in canonical execution order.
+var canonicalStages = []Stage{
+	StageConstitution, StageSpecify, StageClarify, StagePlan,
+	StageTasks, StageChecklist, StageAnalyze, StageImplement, StageVerify,
+}
+
+// PipelineStep is one step of the full workflow: a built-in stage, a
+// custom command from pipeline_commands or an agent phase from custom_phases.
+type PipelineStep struct {
+	Name    string                    // Step name, e.g. "plan", "lint-plan" or "security-review"
+	Stage   Stage                     // Built-in stage ("" otherwise)
+	Command string                    // Shell command for pipeline_commands steps
+	Phase   *config.CustomPhaseConfig // Agent phase for custom_phases steps
+}
+
+// IsCommand reports whether the step runs a pipeline_commands command
+// instead of an agent session.
+func (s PipelineStep) IsCommand() bool {
+	return s.Stage == "" && s.Phase == nil
+}
+
+// BuildPipeline resolves the configured pipeline into steps. An empty (or
+// nil) config yields the default specify → plan → tasks → implement. Every
+// stage's input artifacts must be produced by an earlier step, e.g. tasks
+// requires plan.
+func BuildPipeline(cfg *config.Configuration) ([]PipelineStep, error) {
+	names := defaultPipeline
+	var commands map[string]string
+	var phases map[string]config.CustomPhaseConfig
+	if cfg != nil {
+		if len(cfg.Pipeline) > 0 {
+			names = cfg.Pipeline
+		}
+		commands, phases = cfg.PipelineCommands, cfg.CustomPhases
+	}
+
+	produced := make(map[string]bool)
+	steps := make([]PipelineStep, 0, len(names))
+	for _, name := range names {
+		if command, ok := commands[name]; ok {
+			steps = append(steps, PipelineStep{Name: name, Command: command})
+			continue
+		}
+		if phase, ok := phases[name]; ok {
+			steps = append(steps, PipelineStep{Name: name, Phase: &phase})
+			continue
+		}
+		stage := Stage(name)
+		if !slices.Contains(canonicalStages, stage) {
+			return nil, fmt.Errorf("pipeline step %q is not a stage, pipeline_commands entry or custom phase", name)
+		}
+		for _, artifact := range GetRequiredArtifacts(stage) {
+			if !produced[artifact] {
+				return nil, fmt.Errorf("pipeline stage %s requires %s, which no earlier stage produces", name, artifact)
+			}
+		}
+		for _, artifact := range GetProducedArtifacts(stage) {
+			produced[artifact] = true
+		}
+		steps = append(steps, PipelineStep{Name: name, Stage: stage})
+	}
+	if !produced["spec.yaml"] {
+		return nil, fmt.Errorf("pipeline must include specify")
+	}
+	return steps, nil
+}
+
+// pipelineStepNames returns the step names joined for display.
+func pipelineStepNames(steps []PipelineStep) string {
+	names := make([]string, len(steps))
+	for i, step := range steps {
+		names[i] = step.Name
+	}
+	return strings.Join(names, " → ")
+}
+
+// runPipelineStep runs one step of the full workflow and returns the spec
+// name, which is set by the specify stage. Completed steps are recorded in the
+// checkpoint and skipped on resume; implement instead resumes in place.
+func (w *WorkflowOrchestrator) runPipelineStep(step PipelineStep, featureDescription, specName string, resume bool, cp *retry.WorkflowCheckpoint) (string, error) {
+	if step.Stage == StageImplement {
+		specDir := filepath.Join(w.SpecsDir, specName)
+		if err := w.phaseExecutor.ExecuteDefault(specName, specDir, "", resume); err != nil {
+			return specName, fmt.Errorf("executing implement stage: %w", err)
+		}
+		return specName, nil
+	}
+	if slices.Contains([]Stage{StageSpecify, StagePlan, StageTasks}, step.Stage) {
+		return w.runCheckpointedStage(step.Stage, featureDescription, specName, cp)
+	}
+
+	if specName != "" && w.canSkipStage(cp, Stage(step.Name)) {
+		fmt.Printf("✓ Skipped (%s completed in previous run)\n\n", step.Name)
+		return specName, nil
+	}
+	var err error
+	switch {
+	case step.Phase != nil:
+		err = w.Executor.ExecuteCustomPhase(specName, step.Name, *step.Phase)
+	case step.Stage == StageConstitution:
+		err = w.stageExecutor.ExecuteConstitution("")
+	case step.Stage == StageClarify:
+		err = w.stageExecutor.ExecuteClarify(specName, "")
+	case step.Stage == StageChecklist:
+		err = w.stageExecutor.ExecuteChecklist(specName, "")
+	case step.Stage == StageAnalyze:
+		err = w.stageExecutor.ExecuteAnalyze(specName, "")
+	case step.Stage == StageVerify:
+		err = w.ExecuteVerify(specName, "", false)
+	default:
+		specDir := ""
+		if specName != "" {
+			specDir = filepath.Join(w.SpecsDir, specName)
+		}
+		fmt.Printf("Executing: %s\n", step.Command)
+		err = w.Executor.runHook(step.Name+" step", step.Command, Stage(step.Name), specName, specDir)
+	}
+	if err != nil {
+		return specName, fmt.Errorf("%s step failed: %w", step.Name, err)
+	}
+	if specName != "" {
+		w.recordCheckpoint(cp, specName, Stage(step.Name))
+	}
+	fmt.Printf("✓ %s completed\n\n", step.Name)
+	return specName, nil
+}
@@ -0,0 +1,136 @@
+// Package workflow tests configurable full workflow pipelines.
+// Related: internal/workflow/pipeline.go, internal/workflow/orchestrator.go
+// Tags: workflow, pipeline, orchestrator, checkpoint
+
+package workflow
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildPipeline(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		names     []string
+		commands  map[string]string
+		phases    map[string]config.CustomPhaseConfig
+		wantNames []string
+		wantErr   string
+	}{
+		"empty uses default": {
+			wantNames: []string{"specify", "plan", "tasks", "implement"},
+		},
+		"optional stages and custom step": {
+			names:     []string{"specify", "clarify", "plan", "lint", "tasks", "analyze", "implement"},
+			commands:  map[string]string{"lint": "make lint"},
+			wantNames: []string{"specify", "clarify", "plan", "lint", "tasks", "analyze", "implement"},
+		},
+		"custom phase": {
+			names:     []string{"specify", "plan", "tasks", "implement", "security-review"},
+			phases:    map[string]config.CustomPhaseConfig{"security-review": {Validate: "./check.sh"}},
+			wantNames: []string{"specify", "plan", "tasks", "implement", "security-review"},
+		},
+		"planning only": {
+			names:     []string{"specify", "plan"},
+			wantNames: []string{"specify", "plan"},
+		},
+		"missing input artifact": {
+			names:   []string{"specify", "tasks", "implement"},
+			wantErr: "tasks requires plan.yaml",
+		},
+		"unknown step": {
+			names:   []string{"specify", "review"},
+			wantErr: `pipeline step "review"`,
+		},
+		"no specify": {
+			names:   []string{"constitution"},
+			wantErr: "must include specify",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			steps, err := BuildPipeline(&config.Configuration{
+				Pipeline:         tt.names,
+				PipelineCommands: tt.commands,
+				CustomPhases:     tt.phases,
+			})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var names []string
+			for _, step := range steps {
+				names = append(names, step.Name)
+				assert.Equal(t, step.Command != "", step.IsCommand(), "step %s", step.Name)
+				assert.Equal(t, tt.phases[step.Name].Validate != "", step.Phase != nil, "step %s", step.Name)
+			}
+			assert.Equal(t, tt.wantNames, names)
+		})
+	}
+}
+
+func TestRunFullWorkflow_Pipeline(t *testing.T) {
+	t.Parallel()
+
+	orch, mockStage, mockPhase := newCheckpointTestOrchestrator(t)
+	specName := mockStage.SpecifyResult
+	marker := filepath.Join(t.TempDir(), "lint-ran")
+	orch.Config.Pipeline = []string{"specify", "clarify", "plan", "lint", "tasks"}
+	orch.Config.PipelineCommands = map[string]string{
+		"lint": `test "$AUTOSPEC_SPEC_NAME" = ` + specName + ` && touch ` + marker,
+	}
+
+	require.NoError(t, orch.RunFullWorkflow(checkpointTestFeature, false))
+
+	assert.Len(t, mockStage.SpecifyCalls, 1)
+	require.Len(t, mockStage.ClarifyCalls, 1)
+	assert.Equal(t, specName, mockStage.ClarifyCalls[0].SpecName)
+	assert.Len(t, mockStage.PlanCalls, 1)
+	assert.Len(t, mockStage.TasksCalls, 1)
+	assert.Empty(t, mockPhase.DefaultCalls, "implement is not in the pipeline")
+	assert.FileExists(t, marker)
+}
+
+func TestRunFullWorkflow_PipelineStepFailure(t *testing.T) {
+	t.Parallel()
+
+	orch, mockStage, mockPhase := newCheckpointTestOrchestrator(t)
+	specName := mockStage.SpecifyResult
+	writeCheckpointArtifacts(t, orch, specName, "spec.yaml", "plan.yaml")
+	orch.Config.Pipeline = []string{"specify", "clarify", "plan", "lint", "tasks", "implement"}
+	orch.Config.PipelineCommands = map[string]string{"lint": "echo 'plan.yaml: missing risks' >&2; exit 1"}
+
+	err := orch.RunFullWorkflow(checkpointTestFeature, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "lint step failed")
+	assert.Contains(t, err.Error(), "plan.yaml: missing risks")
+	assert.Empty(t, mockStage.TasksCalls)
+	assert.Empty(t, mockPhase.DefaultCalls)
+
+	cp, err := retry.LoadCheckpoint(orch.Executor.StateDir, specName)
+	require.NoError(t, err)
+	require.NotNil(t, cp)
+	assert.Equal(t, []string{"specify", "clarify", "plan"}, cp.CompletedStages)
+
+	// On resume, completed steps are skipped and the fixed step runs
+	orch.Config.PipelineCommands["lint"] = "true"
+	require.NoError(t, orch.RunFullWorkflow(checkpointTestFeature, true))
+	assert.Len(t, mockStage.SpecifyCalls, 1)
+	assert.Len(t, mockStage.ClarifyCalls, 1)
+	assert.Len(t, mockStage.PlanCalls, 1)
+	assert.Len(t, mockStage.TasksCalls, 1)
+	assert.Len(t, mockPhase.DefaultCalls, 1)
+}
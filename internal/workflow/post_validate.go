@@ -0,0 +1,137 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/spec"
+)
+
+// hookTimeout bounds how long a post_validate or pre/post stage hook may run.
+const hookTimeout = 10 * time.Minute
+
+// maxHookErrorLines caps how many lines of hook output are fed into a retry.
+const maxHookErrorLines = 50
+
+// validateStage runs a stage's built-in validation followed by its
+// post_validate hook, the test suite (implement only) and its post_<stage>
+// hook, so any failure triggers a retry.
+func (e *Executor) validateStage(ctx *stageExecutionContext, specDir string) error {
+	if err := ctx.validateFunc(specDir); err != nil {
+		return err
+	}
+	if err := e.runPostValidate(ctx.stage, ctx.specName, specDir); err != nil {
+		return err
+	}
+	if ctx.stage == StageImplement {
+		if err := e.runTests(ctx.specName, specDir); err != nil {
+			return err
+		}
+	}
+	return e.runStageHook("post_", ctx.stage, ctx.specName, specDir)
+}
+
+// runPostValidate runs the user's post_validate hook for a stage, if one is
+// configured. A non-zero exit returns an error whose output lines are "- "
+// bullets, so ExtractValidationErrors feeds them into the retry prompt like
+// built-in validation errors.
+func (e *Executor) runPostValidate(stage Stage, specName, specDir string) error {
+	command := strings.TrimSpace(e.PostValidate[string(stage)])
+	if command == "" {
+		return nil
+	}
+	return e.runHook(fmt.Sprintf("post_validate hook for %s", stage), command, stage, specName, specDir)
+}
+
+// runStageHook runs the hooks entry named prefix+stage (pre_implement,
+// post_plan, ...), if one is configured. Failures are reported like
+// runPostValidate failures.
+func (e *Executor) runStageHook(prefix string, stage Stage, specName, specDir string) error {
+	name := prefix + string(stage)
+	command := strings.TrimSpace(e.Hooks[name])
+	if command == "" {
+		return nil
+	}
+	return e.runHook(name+" hook", command, stage, specName, specDir)
+}
+
+// runHook runs a hook command through `sh -c` with AUTOSPEC_STAGE,
+// AUTOSPEC_SPEC_NAME and AUTOSPEC_SPEC_DIR set. hook names the hook in
+// debug output and failure messages.
+func (e *Executor) runHook(hook, command string, stage Stage, specName, specDir string) error {
+	specName, specDir = e.resolveHookSpec(specName, specDir)
+	e.debugLog("Running %s: %s", hook, command)
+
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = append(os.Environ(),
+		"AUTOSPEC_STAGE="+string(stage),
+		"AUTOSPEC_SPEC_NAME="+specName,
+		"AUTOSPEC_SPEC_DIR="+specDir,
+	)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err == nil {
+		return nil
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", hookTimeout)
+	}
+	return formatHookFailure(hook, command, err, hookOutput(stderr.String(), stdout.String()))
+}
+
+// resolveHookSpec fills in the spec for the specify stage, which runs before
+// the spec directory is known.
+func (e *Executor) resolveHookSpec(specName, specDir string) (string, string) {
+	if specName != "" {
+		return specName, specDir
+	}
+	metadata, err := spec.DetectCurrentSpec(e.SpecsDir)
+	if err != nil {
+		return specName, specDir
+	}
+	return fmt.Sprintf("%s-%s", metadata.Number, metadata.Name), metadata.Directory
+}
+
+// hookOutput returns the hook's stderr, or its stdout when stderr is empty.
+func hookOutput(stderr, stdout string) string {
+	if strings.TrimSpace(stderr) != "" {
+		return stderr
+	}
+	return stdout
+}
+
+// formatHookFailure builds the validation error for a failed hook, with one
+// bullet per non-empty output line.
+func formatHookFailure(hook, command string, runErr error, output string) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s failed (%s): %v:\n", hook, command, runErr)
+
+	lines := 0
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if lines == maxHookErrorLines {
+			sb.WriteString("- (further output truncated)\n")
+			break
+		}
+		fmt.Fprintf(&sb, "- %s\n", strings.TrimPrefix(line, "- "))
+		lines++
+	}
+	if lines == 0 {
+		fmt.Fprintf(&sb, "- %s `%s` failed: %v\n", hook, command, runErr)
+	}
+	return errors.New(sb.String())
+}
@@ -0,0 +1,246 @@
+// Package workflow tests post_validate hooks run after built-in validation and
+// pre/post stage hooks run around each stage session.
+// Related: internal/workflow/post_validate.go
+// Tags: workflow, validation, hooks, retry
+
+package workflow
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunPostValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		hooks      map[string]string
+		wantErr    bool
+		wantErrors []string
+	}{
+		"no hooks configured": {},
+		"hook for another stage is ignored": {
+			hooks: map[string]string{"tasks": "exit 1"},
+		},
+		"passing hook": {
+			hooks: map[string]string{"plan": "exit 0"},
+		},
+		"hook sees stage and spec environment": {
+			hooks: map[string]string{"plan": `test "$AUTOSPEC_STAGE" = plan && test "$AUTOSPEC_SPEC_NAME" = 001-auth && test "$AUTOSPEC_SPEC_DIR" = specs/001-auth`},
+		},
+		"failing hook reports stderr lines": {
+			hooks:      map[string]string{"plan": "echo 'plan.yaml: missing rollback section' >&2; echo '- risks: need at least 2' >&2; exit 3"},
+			wantErr:    true,
+			wantErrors: []string{"plan.yaml: missing rollback section", "risks: need at least 2"},
+		},
+		"stdout is used when stderr is empty": {
+			hooks:      map[string]string{"plan": "echo 'summary too short'; exit 1"},
+			wantErr:    true,
+			wantErrors: []string{"summary too short"},
+		},
+		"silent failure names the hook": {
+			hooks:      map[string]string{"plan": "exit 2"},
+			wantErr:    true,
+			wantErrors: []string{"post_validate hook for plan `exit 2` failed: exit status 2"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			e := &Executor{PostValidate: tt.hooks}
+
+			err := e.runPostValidate(StagePlan, "001-auth", "specs/001-auth")
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "post_validate hook for plan failed")
+			assert.Equal(t, tt.wantErrors, ExtractValidationErrors(err))
+		})
+	}
+}
+
+func TestFormatHookFailure_TruncatesOutput(t *testing.T) {
+	t.Parallel()
+
+	var output strings.Builder
+	for i := 0; i < maxHookErrorLines+10; i++ {
+		fmt.Fprintf(&output, "problem %d\n", i)
+	}
+
+	errs := ExtractValidationErrors(formatHookFailure("post_validate hook for plan", "check", fmt.Errorf("exit status 1"), output.String()))
+
+	require.Len(t, errs, maxHookErrorLines+1)
+	assert.Equal(t, "(further output truncated)", errs[maxHookErrorLines])
+}
+
+func TestExecuteStage_PostValidateFeedsRetry(t *testing.T) {
+	t.Parallel()
+
+	claude := &mockClaudeExecutor{}
+	executor := &Executor{
+		Claude:       claude,
+		StateDir:     t.TempDir(),
+		SpecsDir:     t.TempDir(),
+		MaxRetries:   1,
+		PostValidate: map[string]string{"plan": "echo 'constitution gate missing' >&2; exit 1"},
+	}
+	builtinCalls := 0
+	validateFunc := func(string) error {
+		builtinCalls++
+		return nil
+	}
+
+	result, err := executor.ExecuteStage("001-auth", StagePlan, "/autospec.plan", validateFunc)
+
+	require.Error(t, err)
+	assert.True(t, result.Exhausted)
+	assert.Equal(t, 2, builtinCalls, "built-in validation runs before the hook on every attempt")
+	require.Len(t, claude.executeCalls, 2)
+	assert.Contains(t, claude.executeCalls[1], "constitution gate missing", "retry prompt includes hook output")
+}
+
+func TestExecuteStage_PostValidateSkippedWhenBuiltinFails(t *testing.T) {
+	t.Parallel()
+
+	executor := &Executor{
+		Claude:       &mockClaudeExecutor{},
+		StateDir:     t.TempDir(),
+		SpecsDir:     t.TempDir(),
+		PostValidate: map[string]string{"plan": "echo hook-ran >&2; exit 1"},
+	}
+	validateFunc := func(string) error {
+		return fmt.Errorf("schema validation failed for plan.yaml:\n- missing required field: summary")
+	}
+
+	_, err := executor.ExecuteStage("001-auth", StagePlan, "/autospec.plan", validateFunc)
+
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "hook-ran")
+}
+
+func TestRunStageHook(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		hooks   map[string]string
+		prefix  string
+		wantErr string
+	}{
+		"no hooks configured": {prefix: "pre_"},
+		"hook for the other side is ignored": {
+			hooks:  map[string]string{"post_implement": "exit 1"},
+			prefix: "pre_",
+		},
+		"hook for another stage is ignored": {
+			hooks:  map[string]string{"pre_plan": "exit 1"},
+			prefix: "pre_",
+		},
+		"hook sees stage and spec environment": {
+			hooks:  map[string]string{"post_implement": `test "$AUTOSPEC_STAGE" = implement && test "$AUTOSPEC_SPEC_DIR" = specs/001-auth`},
+			prefix: "post_",
+		},
+		"failing hook is named in the error": {
+			hooks:   map[string]string{"pre_implement": "echo 'lint: unused variable x' >&2; exit 1"},
+			prefix:  "pre_",
+			wantErr: "pre_implement hook failed (echo 'lint: unused variable x' >&2; exit 1): exit status 1",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			e := &Executor{Hooks: tt.hooks}
+
+			err := e.runStageHook(tt.prefix, StageImplement, "001-auth", "specs/001-auth")
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestExecuteStage_Hooks(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		hooks       func(dir string) map[string]string
+		maxRetries  int
+		wantErr     string
+		wantCalls   int
+		wantInRetry string // Expected in the prompt of the last agent session
+	}{
+		"passing hooks run around the session": {
+			hooks: func(dir string) map[string]string {
+				return map[string]string{
+					"pre_plan":  "touch " + filepath.Join(dir, "pre"),
+					"post_plan": "test -f " + filepath.Join(dir, "pre"),
+				}
+			},
+			wantCalls: 1,
+		},
+		"failing post hook is retried with its output": {
+			hooks: func(dir string) map[string]string {
+				marker := filepath.Join(dir, "tested")
+				return map[string]string{"post_plan": "test -f " + marker + " || { touch " + marker + "; echo 'TestAuth failed' >&2; exit 1; }"}
+			},
+			maxRetries:  1,
+			wantCalls:   2,
+			wantInRetry: "TestAuth failed",
+		},
+		"failing pre hook feeds its output into the first session": {
+			hooks: func(string) map[string]string {
+				return map[string]string{"pre_plan": "echo 'lint: unused import' >&2; exit 1"}
+			},
+			maxRetries:  1,
+			wantCalls:   1,
+			wantInRetry: "lint: unused import",
+		},
+		"failing pre hook without retries fails the stage": {
+			hooks: func(string) map[string]string {
+				return map[string]string{"pre_plan": "exit 1"}
+			},
+			wantErr:   "pre_plan hook failed",
+			wantCalls: 0,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			mock := NewMockClaudeExecutor()
+			e := &Executor{
+				Claude:     mock,
+				StateDir:   t.TempDir(),
+				SpecsDir:   t.TempDir(),
+				MaxRetries: tt.maxRetries,
+				Hooks:      tt.hooks(dir),
+			}
+
+			result, err := e.ExecuteStage("001-auth", StagePlan, "/autospec.plan", func(string) error { return nil })
+			assert.Len(t, mock.ExecuteCalls, tt.wantCalls)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, result.Success)
+			if tt.wantInRetry != "" {
+				assert.Contains(t, mock.ExecuteCalls[len(mock.ExecuteCalls)-1], tt.wantInRetry)
+			}
+		})
+	}
+}
@@ -462,7 +462,24 @@ type ConstitutionCheckResult struct {
 // running any workflow stages (specify, plan, tasks, implement).
 // Checks paths in ConstitutionPaths order (.yaml and .yml extensions supported)
 func CheckConstitutionExists() *ConstitutionCheckResult {
+	return CheckConstitutionAt("")
+}
+
+// CheckConstitutionAt checks for the configured constitution file (the
+// constitution config key, set per package in a monorepo), falling back to
+// ConstitutionPaths when path is empty.
+func CheckConstitutionAt(path string) *ConstitutionCheckResult {
 	result := &ConstitutionCheckResult{}
+	if path != "" {
+		if _, err := os.Stat(path); err == nil {
+			result.Exists = true
+			result.Path = path
+			return result
+		}
+		result.ErrorMessage = generateConstitutionMissingError() +
+			fmt.Sprintf("\nThe configured constitution is %s (constitution in config).\n", path)
+		return result
+	}
 
 	// Check all valid constitution paths in priority order
 	for _, path := range ConstitutionPaths {
@@ -0,0 +1,197 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+)
+
+// DefaultMinFreeDiskMB is the free space 'autospec preflight' requires by default.
+const DefaultMinFreeDiskMB = 500
+
+// PreflightStatus is the outcome of one check in a PreflightReport.
+type PreflightStatus string
+
+const (
+	PreflightPass PreflightStatus = "pass"
+	PreflightWarn PreflightStatus = "warn"
+	PreflightFail PreflightStatus = "fail"
+	PreflightSkip PreflightStatus = "skip"
+)
+
+// PreflightOptions selects what RunPreflight checks.
+type PreflightOptions struct {
+	// SpecsDir must be writable (created on first specify if missing).
+	SpecsDir string
+	// Agent is checked for installation and credentials (nil skips the check).
+	Agent cliagent.Agent
+	// RequireClean fails, rather than warns about, uncommitted changes.
+	RequireClean bool
+	// MinFreeDiskMB is the free space SpecsDir's file system needs (0 skips the check).
+	MinFreeDiskMB uint64
+}
+
+// PreflightCheckResult is the result of one granular preflight check.
+type PreflightCheckResult struct {
+	Name    string          `json:"name"`
+	Status  PreflightStatus `json:"status"`
+	Message string          `json:"message"`
+}
+
+// PreflightReport is the result of 'autospec preflight'. Passed is false
+// only when a check failed; warnings and skipped checks don't fail it.
+type PreflightReport struct {
+	Passed bool                   `json:"passed"`
+	Checks []PreflightCheckResult `json:"checks"`
+}
+
+// RunPreflight runs the granular preflight checks: git repository, clean
+// working tree, project initialized, agent authentication, writable specs
+// directory and free disk space.
+func RunPreflight(opts PreflightOptions) *PreflightReport {
+	report := &PreflightReport{Passed: true}
+	add := func(name string, status PreflightStatus, format string, args ...any) {
+		report.Checks = append(report.Checks, PreflightCheckResult{Name: name, Status: status, Message: fmt.Sprintf(format, args...)})
+		if status == PreflightFail {
+			report.Passed = false
+		}
+	}
+
+	gitRoot, err := getGitRoot()
+	if err != nil {
+		add("git_repo", PreflightFail, "not inside a git repository")
+		add("clean_tree", PreflightSkip, "not inside a git repository")
+	} else {
+		add("git_repo", PreflightPass, "%s", gitRoot)
+		status, message := checkCleanTree(opts.RequireClean)
+		add("clean_tree", status, "%s", message)
+	}
+
+	if err := CheckProjectStructure(); err != nil {
+		add("project_initialized", PreflightFail, "%v; run 'autospec init'", err)
+	} else {
+		add("project_initialized", PreflightPass, ".autospec and .claude/commands present")
+	}
+
+	status, message := checkAgentAuth(opts.Agent)
+	add("agent_auth", status, "%s", message)
+
+	dir, err := existingAncestor(opts.SpecsDir)
+	if err != nil {
+		add("specs_dir_writable", PreflightFail, "%v", err)
+	} else if err := checkWritable(dir); err != nil {
+		add("specs_dir_writable", PreflightFail, "%s is not writable: %v", dir, err)
+	} else {
+		add("specs_dir_writable", PreflightPass, "%s", opts.SpecsDir)
+	}
+
+	status, message = checkDiskSpace(dir, opts.MinFreeDiskMB)
+	add("disk_space", status, "%s", message)
+
+	return report
+}
+
+// checkCleanTree reports uncommitted changes, which only fail the check when
+// a clean tree is required.
+func checkCleanTree(required bool) (PreflightStatus, string) {
+	output, err := exec.Command("git", "status", "--porcelain").Output()
+	if err != nil {
+		return PreflightFail, fmt.Sprintf("git status failed: %v", err)
+	}
+	status := strings.TrimSpace(string(output))
+	if status == "" {
+		return PreflightPass, "no uncommitted changes"
+	}
+	changes := len(strings.Split(status, "\n"))
+	switch {
+	case required:
+		return PreflightFail, fmt.Sprintf("%d uncommitted change(s)", changes)
+	default:
+		return PreflightWarn, fmt.Sprintf("%d uncommitted change(s) (use --require-clean to fail)", changes)
+	}
+}
+
+// checkAgentAuth checks that the agent is installed and has credentials. For
+// Claude, an OAuth login without an API key must also be present.
+func checkAgentAuth(agent cliagent.Agent) (PreflightStatus, string) {
+	if agent == nil {
+		return PreflightSkip, "no agent configured"
+	}
+	if err := agent.Validate(); err != nil {
+		return PreflightFail, err.Error()
+	}
+	if agent.Name() != "claude" {
+		return PreflightPass, fmt.Sprintf("%s is available", agent.Name())
+	}
+	auth := cliagent.DetectClaudeAuth()
+	switch {
+	case !auth.IsAuthenticated():
+		return PreflightFail, "claude is not authenticated; run 'claude' to log in or set ANTHROPIC_API_KEY"
+	case auth.AuthType == cliagent.AuthTypeOAuth && auth.OAuthExpired && !auth.APIKeySet:
+		return PreflightWarn, "claude OAuth token has expired; run 'autospec doctor --fix' if the next run fails to log in"
+	default:
+		return PreflightPass, fmt.Sprintf("claude authenticated (%s)", auth.AuthType)
+	}
+}
+
+// existingAncestor returns dir, or its nearest existing parent when dir
+// doesn't exist yet.
+func existingAncestor(dir string) (string, error) {
+	if dir == "" {
+		dir = "."
+	}
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return "", fmt.Errorf("%s is not a directory", dir)
+			}
+			return dir, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", err
+		}
+		dir = parent
+	}
+}
+
+// checkWritable creates and removes a temporary file in dir.
+func checkWritable(dir string) error {
+	f, err := os.CreateTemp(dir, ".autospec-preflight-*")
+	if err != nil {
+		return err
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
+
+// checkDiskSpace checks that dir's file system has at least minMB free.
+func checkDiskSpace(dir string, minMB uint64) (PreflightStatus, string) {
+	if minMB == 0 {
+		return PreflightSkip, "disabled"
+	}
+	if dir == "" {
+		return PreflightSkip, "specs directory unavailable"
+	}
+	free, err := freeDiskBytes(dir)
+	if errors.Is(err, errors.ErrUnsupported) {
+		return PreflightSkip, "not supported on this platform"
+	}
+	if err != nil {
+		return PreflightFail, fmt.Sprintf("checking free space: %v", err)
+	}
+	freeMB := free / (1024 * 1024)
+	if freeMB < minMB {
+		return PreflightFail, fmt.Sprintf("%d MB free, need %d MB", freeMB, minMB)
+	}
+	return PreflightPass, fmt.Sprintf("%d MB free", freeMB)
+}
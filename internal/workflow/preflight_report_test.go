@@ -0,0 +1,116 @@
+// Package workflow tests the granular checks behind 'autospec preflight'.
+// Related: internal/workflow/preflight_report.go, internal/cli/preflight.go
+// Tags: workflow, preflight, checks, json
+
+package workflow
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupPreflightRepo creates an initialized autospec project with an
+// uncommitted config in a new git repository and changes into it.
+func setupPreflightRepo(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(origDir) })
+
+	require.NoError(t, exec.Command("git", "init", "-q").Run())
+	require.NoError(t, os.MkdirAll(".autospec", 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(".claude", "commands"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(".autospec", "config.yml"), nil, 0644))
+}
+
+func preflightStatuses(report *PreflightReport) map[string]PreflightStatus {
+	statuses := make(map[string]PreflightStatus)
+	for _, check := range report.Checks {
+		statuses[check.Name] = check.Status
+	}
+	return statuses
+}
+
+func TestRunPreflight(t *testing.T) {
+	tests := map[string]struct {
+		opts       PreflightOptions
+		wantPassed bool
+		want       map[string]PreflightStatus
+	}{
+		"dirty tree only warns": {
+			opts:       PreflightOptions{SpecsDir: "specs/nested", MinFreeDiskMB: 1},
+			wantPassed: true,
+			want: map[string]PreflightStatus{
+				"git_repo":            PreflightPass,
+				"clean_tree":          PreflightWarn,
+				"project_initialized": PreflightPass,
+				"agent_auth":          PreflightSkip,
+				"specs_dir_writable":  PreflightPass,
+				"disk_space":          PreflightPass,
+			},
+		},
+		"require clean fails on a dirty tree": {
+			opts:       PreflightOptions{SpecsDir: "specs", RequireClean: true},
+			wantPassed: false,
+			want: map[string]PreflightStatus{
+				"clean_tree": PreflightFail,
+				"disk_space": PreflightSkip,
+			},
+		},
+		"not enough disk space": {
+			opts:       PreflightOptions{SpecsDir: "specs", MinFreeDiskMB: 1 << 40},
+			wantPassed: false,
+			want:       map[string]PreflightStatus{"disk_space": PreflightFail},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			setupPreflightRepo(t)
+
+			report := RunPreflight(tt.opts)
+			assert.Equal(t, tt.wantPassed, report.Passed)
+			statuses := preflightStatuses(report)
+			for check, want := range tt.want {
+				assert.Equal(t, want, statuses[check], check)
+			}
+		})
+	}
+}
+
+func TestRunPreflight_NotInitialized(t *testing.T) {
+	dir := t.TempDir()
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	report := RunPreflight(PreflightOptions{SpecsDir: "specs"})
+	assert.False(t, report.Passed)
+	statuses := preflightStatuses(report)
+	assert.Equal(t, PreflightFail, statuses["git_repo"])
+	assert.Equal(t, PreflightSkip, statuses["clean_tree"])
+	assert.Equal(t, PreflightFail, statuses["project_initialized"])
+}
+
+func TestExistingAncestor(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0644))
+
+	got, err := existingAncestor(filepath.Join(dir, "a", "b"))
+	require.NoError(t, err)
+	assert.Equal(t, dir, got)
+
+	_, err = existingAncestor(file)
+	assert.ErrorContains(t, err, "is not a directory")
+}
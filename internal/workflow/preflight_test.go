@@ -368,6 +368,46 @@ func TestCheckConstitutionExists(t *testing.T) {
 	}
 }
 
+func TestCheckConstitutionAt(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	require.NoError(t, os.MkdirAll(filepath.Join(".autospec", "memory"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(".autospec", "memory", "constitution.yaml"), []byte("project_name: Root"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join("services", "api"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join("services", "api", "constitution.yaml"), []byte("project_name: API"), 0644))
+
+	tests := map[string]struct {
+		path       string
+		wantExists bool
+		wantPath   string
+	}{
+		"empty path uses default locations": {
+			wantExists: true,
+			wantPath:   ".autospec/memory/constitution.yaml",
+		},
+		"configured path": {
+			path:       "services/api/constitution.yaml",
+			wantExists: true,
+			wantPath:   "services/api/constitution.yaml",
+		},
+		"missing configured path does not fall back": {
+			path: "services/web/constitution.yaml",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := CheckConstitutionAt(tc.path)
+
+			assert.Equal(t, tc.wantExists, result.Exists)
+			assert.Equal(t, tc.wantPath, result.Path)
+			if !tc.wantExists {
+				assert.Contains(t, result.ErrorMessage, tc.path)
+			}
+		})
+	}
+}
+
 // TestGenerateConstitutionMissingError tests the error message generation
 func TestGenerateConstitutionMissingError(t *testing.T) {
 	errMsg := generateConstitutionMissingError()
@@ -0,0 +1,110 @@
+package workflow
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PromptsDir returns the directory of project prompt templates, one
+// <stage>.tmpl file per stage whose built-in prompt is overridden.
+func PromptsDir() string {
+	return filepath.Join(".autospec", "prompts")
+}
+
+// PromptData is the data available to a prompt template.
+type PromptData struct {
+	Stage              string // specify, plan, tasks, implement or a custom phase name
+	SpecName           string // e.g. "003-command-timeout" (empty for specify)
+	SpecDir            string // e.g. "specs/003-command-timeout" (empty for specify)
+	FeatureDescription string // The specify description, or feature.input from spec.yaml
+	Guidance           string // Optional prompt passed on the command line
+	Command            string // The built-in prompt, e.g. `/autospec.plan "..."`
+	Phase              int    // Implement phase number in --phases mode (0 otherwise)
+	TaskID             string // Implement task ID in --tasks mode (empty otherwise)
+	Constitution       string // Project constitution content
+	Spec               string // spec.yaml content, if it exists
+	Plan               string // plan.yaml content, if it exists
+	Tasks              string // tasks.yaml content, if it exists
+}
+
+// renderStagePrompt returns data.Command, or the project template
+// <PromptsDir>/<stage>.tmpl rendered with data when one exists. Missing
+// artifacts render as empty strings; template errors fail the stage.
+func (e *Executor) renderStagePrompt(stage Stage, data PromptData) (string, error) {
+	if e.PromptsDir == "" {
+		return data.Command, nil
+	}
+	path := filepath.Join(e.PromptsDir, string(stage)+".tmpl")
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return data.Command, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading prompt template: %w", err)
+	}
+	return e.renderPromptTemplate(path, string(content), stage, data)
+}
+
+// renderPromptTemplate renders the template content read from path with data
+// and the spec's artifacts.
+func (e *Executor) renderPromptTemplate(path, content string, stage Stage, data PromptData) (string, error) {
+	tmpl, err := template.New(filepath.Base(path)).Option("missingkey=error").Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("parsing prompt template %s: %w", path, err)
+	}
+
+	data.Stage = string(stage)
+	if data.SpecName != "" {
+		data.SpecDir = filepath.Join(e.SpecsDir, data.SpecName)
+	}
+	e.loadPromptArtifacts(&data)
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("rendering prompt template %s: %w", path, err)
+	}
+	e.debugLog("Rendered %s prompt from %s", stage, path)
+	return out.String(), nil
+}
+
+// loadPromptArtifacts fills in the constitution and the spec's artifacts.
+func (e *Executor) loadPromptArtifacts(data *PromptData) {
+	if check := CheckConstitutionAt(e.Constitution); check.Exists {
+		if content, err := os.ReadFile(check.Path); err == nil {
+			data.Constitution = string(content)
+		}
+	}
+	if data.SpecDir == "" {
+		return
+	}
+	data.Spec = readArtifact(data.SpecDir, "spec.yaml")
+	data.Plan = readArtifact(data.SpecDir, "plan.yaml")
+	data.Tasks = readArtifact(data.SpecDir, "tasks.yaml")
+	if data.FeatureDescription == "" {
+		var spec struct {
+			Feature struct {
+				Input string `yaml:"input"`
+			} `yaml:"feature"`
+		}
+		if yaml.Unmarshal([]byte(data.Spec), &spec) == nil {
+			data.FeatureDescription = strings.TrimSpace(spec.Feature.Input)
+		}
+	}
+}
+
+// readArtifact returns the content of a spec artifact, or "" if it is missing.
+func readArtifact(specDir, name string) string {
+	content, err := os.ReadFile(filepath.Join(specDir, name))
+	if err != nil {
+		return ""
+	}
+	return string(content)
+}
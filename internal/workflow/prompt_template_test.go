@@ -0,0 +1,73 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderStagePrompt(t *testing.T) {
+	t.Parallel()
+
+	specYAML := "feature:\n  input: \"Add dark mode\"\n"
+
+	tests := map[string]struct {
+		template string // Content of plan.tmpl ("" = no template)
+		data     PromptData
+		want     string
+		wantErr  string
+	}{
+		"no template uses built-in prompt": {
+			data: PromptData{SpecName: "001-dark-mode", Command: "/autospec.plan"},
+			want: "/autospec.plan",
+		},
+		"template variables": {
+			template: "Plan {{.SpecName}} ({{.Stage}}) in {{.SpecDir}}: {{.FeatureDescription}}. {{.Guidance}}\n{{.Spec}}",
+			data:     PromptData{SpecName: "001-dark-mode", Guidance: "Keep it small.", Command: "/autospec.plan"},
+			want:     "Plan 001-dark-mode (plan) in SPECS/001-dark-mode: Add dark mode. Keep it small.\n" + specYAML,
+		},
+		"built-in prompt embedded": {
+			template: "{{.Command}}\n{{if not .Tasks}}No tasks yet.{{end}}",
+			data:     PromptData{SpecName: "001-dark-mode", Command: `/autospec.plan "focus"`},
+			want:     "/autospec.plan \"focus\"\nNo tasks yet.",
+		},
+		"parse error": {
+			template: "{{.SpecName",
+			data:     PromptData{SpecName: "001-dark-mode"},
+			wantErr:  "parsing prompt template",
+		},
+		"unknown field": {
+			template: "{{.Description}}",
+			data:     PromptData{SpecName: "001-dark-mode"},
+			wantErr:  "rendering prompt template",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			specsDir := t.TempDir()
+			specDir := filepath.Join(specsDir, "001-dark-mode")
+			require.NoError(t, os.MkdirAll(specDir, 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(specDir, "spec.yaml"), []byte(specYAML), 0o644))
+
+			promptsDir := t.TempDir()
+			if tt.template != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(promptsDir, "plan.tmpl"), []byte(tt.template), 0o644))
+			}
+			e := &Executor{SpecsDir: specsDir, PromptsDir: promptsDir}
+
+			got, err := e.renderStagePrompt(StagePlan, tt.data)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, strings.ReplaceAll(got, specsDir, "SPECS"))
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"golang.org/x/term"
+)
+
+// deferRateLimit reports whether err is a rate limit the stage should wait
+// out instead of using up a retry, and if so remembers it for
+// waitForRateLimit. Once retry_policy.rate_limit_waits is used up, rate
+// limits fail the attempt like any other agent error.
+func (e *Executor) deferRateLimit(ctx *stageExecutionContext, err error) bool {
+	var limitErr *RateLimitError
+	if !errors.As(err, &limitErr) {
+		return false
+	}
+	if ctx.rateLimitWaits >= e.RetryPolicy.ForStage(string(ctx.stage)).RateLimitWaits {
+		return false
+	}
+	ctx.rateLimit = limitErr
+	return true
+}
+
+// waitForRateLimit waits out the rate limit remembered by deferRateLimit
+// before the stage's attempt is run again.
+func (e *Executor) waitForRateLimit(ctx *stageExecutionContext) error {
+	limitErr := ctx.rateLimit
+	ctx.rateLimit = nil
+	ctx.rateLimitWaits++
+
+	policy := e.RetryPolicy.ForStage(string(ctx.stage))
+	if err := e.sleepRateLimit(limitErr, policy, ctx.rateLimitWaits); err != nil {
+		ctx.result.Error = fmt.Errorf("rate-limit wait for %s interrupted: %w", ctx.stage, err)
+		return ctx.result.Error
+	}
+	return nil
+}
+
+// sleepRateLimit announces and waits out a rate limit, counting down on a
+// terminal. wait is the 1-based number of this wait.
+func (e *Executor) sleepRateLimit(limitErr *RateLimitError, policy retry.PolicyConfig, wait int) error {
+	d := policy.RateLimitWait(limitErr.RetryAfter)
+	source := "retry_policy.rate_limit_delay"
+	if limitErr.RetryAfter > 0 {
+		source = "agent's retry hint"
+	}
+	fmt.Fprintf(e.output(), "⏳ Agent %s hit a rate limit; waiting %s (%s) without using a retry (%d/%d)\n",
+		limitErr.Agent, d.Round(time.Second), source, wait, policy.RateLimitWaits)
+	if e.sleep != nil || e.Output != nil || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return e.sleepFor(d)
+	}
+	return e.countdown(d)
+}
+
+// countdown sleeps for d, rewriting a "resuming in" line every second.
+func (e *Executor) countdown(d time.Duration) error {
+	defer fmt.Print("\r\033[K")
+	for remaining := d; remaining > 0; remaining -= time.Second {
+		fmt.Printf("\r\033[K   resuming in %s", remaining.Round(time.Second))
+		if err := e.sleepFor(min(remaining, time.Second)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
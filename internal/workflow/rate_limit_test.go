@@ -0,0 +1,138 @@
+// Package workflow tests waiting out agent rate limits.
+// Related: internal/workflow/rate_limit.go, internal/cliagent/ratelimit.go
+// Tags: workflow, retry, rate-limit
+package workflow
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sequenceRunner is a ClaudeRunner whose Execute calls return errs in order,
+// then succeed.
+type sequenceRunner struct {
+	mockClaudeExecutor
+	errs []error
+}
+
+func (m *sequenceRunner) Execute(prompt string) error {
+	m.executeCalls = append(m.executeCalls, prompt)
+	if len(m.executeCalls) > len(m.errs) {
+		return nil
+	}
+	return m.errs[len(m.executeCalls)-1]
+}
+
+func TestExecuteStage_WaitsOutRateLimits(t *testing.T) {
+	t.Parallel()
+
+	hinted := &RateLimitError{Agent: "claude", RetryAfter: 5 * time.Minute}
+	unhinted := &RateLimitError{Agent: "claude"}
+
+	tests := map[string]struct {
+		errs           []error
+		waits          int
+		wantSleeps     []time.Duration
+		wantErr        bool
+		wantRetryCount int
+	}{
+		"waits for the hint, then the delay, without using retries": {
+			errs:       []error{hinted, unhinted},
+			waits:      2,
+			wantSleeps: []time.Duration{5 * time.Minute, 30 * time.Second},
+		},
+		"rate limit uses a retry once waits are used up": {
+			errs:           []error{hinted, hinted},
+			waits:          1,
+			wantSleeps:     []time.Duration{5 * time.Minute},
+			wantErr:        true,
+			wantRetryCount: 1,
+		},
+		"disabled waits count rate limits as failures": {
+			errs:           []error{hinted},
+			wantErr:        true,
+			wantRetryCount: 1,
+		},
+		"other errors are not waited out": {
+			errs:           []error{errors.New("agent crashed")},
+			waits:          2,
+			wantErr:        true,
+			wantRetryCount: 1,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var sleeps []time.Duration
+			var out bytes.Buffer
+			runner := &sequenceRunner{errs: tt.errs}
+			executor := &Executor{
+				Claude:      runner,
+				StateDir:    t.TempDir(),
+				SpecsDir:    t.TempDir(),
+				MaxRetries:  3,
+				RetryPolicy: retry.PolicyConfig{RateLimitWaits: tt.waits, RateLimitDelay: 30 * time.Second},
+				Output:      &out,
+				sleep:       func(d time.Duration) { sleeps = append(sleeps, d) },
+			}
+
+			result, err := executor.ExecuteStage("001-test", StagePlan, "/autospec.plan", func(string) error { return nil })
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, result.Success)
+				assert.Len(t, runner.executeCalls, len(tt.errs)+1)
+			}
+			assert.Equal(t, tt.wantSleeps, sleeps)
+			assert.Equal(t, tt.wantRetryCount, result.RetryCount)
+			if len(tt.wantSleeps) > 0 {
+				assert.Contains(t, out.String(), "hit a rate limit; waiting 5m0s (agent's retry hint) without using a retry")
+			}
+		})
+	}
+}
+
+func TestExecuteWithRetry_WaitsOutRateLimits(t *testing.T) {
+	t.Parallel()
+
+	var sleeps []time.Duration
+	runner := &sequenceRunner{errs: []error{
+		&RateLimitError{Agent: "claude", RetryAfter: time.Minute},
+		errors.New("agent crashed"),
+	}}
+	executor := &Executor{
+		Claude:      runner,
+		RetryPolicy: retry.PolicyConfig{RateLimitWaits: 1},
+		Output:      &bytes.Buffer{},
+		sleep:       func(d time.Duration) { sleeps = append(sleeps, d) },
+	}
+
+	require.NoError(t, executor.ExecuteWithRetry("/test.command", 2))
+	assert.Len(t, runner.executeCalls, 3, "the rate-limited call does not count as an attempt")
+	assert.Equal(t, []time.Duration{time.Minute, 0}, sleeps)
+}
+
+func TestClaudeExecutor_RateLimitError(t *testing.T) {
+	t.Parallel()
+
+	claude := shellClaude(t, `echo "Rate limit reached. Please try again in 2 minutes." >&2; exit 1`)
+	err := claude.Execute("/autospec.plan")
+
+	var limitErr *RateLimitError
+	require.True(t, errors.As(err, &limitErr), "got %v", err)
+	assert.Equal(t, 2*time.Minute, limitErr.RetryAfter)
+	assert.Contains(t, err.Error(), "hit a rate limit (retry after 2m0s)")
+
+	claude = shellClaude(t, `echo "boom" >&2; exit 1`)
+	assert.False(t, errors.As(claude.Execute("/autospec.plan"), &limitErr))
+}
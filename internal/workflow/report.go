@@ -0,0 +1,38 @@
+package workflow
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/report"
+)
+
+// recordValidation reports the outcome of a stage attempt to the
+// MetricsRecorder and ValidationRecorder, if configured. execution marks
+// failures of the agent run itself, before anything could be validated.
+func (e *Executor) recordValidation(ctx *stageExecutionContext, start time.Time, err error, execution bool) {
+	if e.Metrics != nil {
+		e.Metrics.RecordStage(string(ctx.stage), e.agentName(), ctx.retryState.Count+1, err == nil, time.Since(start))
+	}
+	if e.Validations == nil {
+		return
+	}
+	result := report.ValidationResult{
+		Spec:      e.usageSpecName(ctx.specName),
+		Stage:     string(ctx.stage),
+		Attempt:   ctx.retryState.Count + 1,
+		Passed:    err == nil,
+		Execution: execution,
+		Duration:  time.Since(start),
+	}
+	if m := phaseArgPattern.FindStringSubmatch(ctx.command); m != nil {
+		result.Phase, _ = strconv.Atoi(m[1])
+	}
+	if m := taskArgPattern.FindStringSubmatch(ctx.command); m != nil {
+		result.TaskID = m[1]
+	}
+	if err != nil {
+		result.Message = err.Error()
+	}
+	e.Validations.RecordValidation(result)
+}
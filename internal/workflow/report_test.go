@@ -0,0 +1,91 @@
+// Package workflow tests validation result recording for --report.
+// Related: internal/workflow/report.go, internal/report/junit.go
+// Tags: workflow, report, junit, validation
+package workflow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockValidationRecorder collects recorded validation results.
+type mockValidationRecorder struct {
+	results []report.ValidationResult
+}
+
+func (m *mockValidationRecorder) RecordValidation(result report.ValidationResult) {
+	m.results = append(m.results, result)
+}
+
+func TestExecuteStage_RecordsValidations(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		command    string
+		executeErr error
+		validate   func(calls *int) error
+		wantErr    bool
+		want       []report.ValidationResult
+	}{
+		"passing attempt": {
+			command: "/autospec.plan",
+			want:    []report.ValidationResult{{Spec: "001-test", Stage: "plan", Attempt: 1, Passed: true}},
+		},
+		"retried validation failure": {
+			command: "/autospec.implement --phase 2 --context-file ctx.yaml",
+			validate: func(calls *int) error {
+				if *calls == 1 {
+					return errors.New("phase 2 has incomplete tasks")
+				}
+				return nil
+			},
+			want: []report.ValidationResult{
+				{Spec: "001-test", Stage: "plan", Phase: 2, Attempt: 1, Message: "phase 2 has incomplete tasks"},
+				{Spec: "001-test", Stage: "plan", Phase: 2, Attempt: 2, Passed: true},
+			},
+		},
+		"agent failure": {
+			command:    "/autospec.implement --task T003",
+			executeErr: errors.New("agent crashed"),
+			wantErr:    true,
+			want:       []report.ValidationResult{{Spec: "001-test", Stage: "plan", TaskID: "T003", Attempt: 1, Execution: true, Message: "agent crashed"}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := &mockValidationRecorder{}
+			executor := &Executor{
+				Claude:      &mockClaudeExecutor{executeErr: tt.executeErr},
+				StateDir:    t.TempDir(),
+				SpecsDir:    t.TempDir(),
+				MaxRetries:  3,
+				Validations: recorder,
+			}
+			calls := 0
+			_, err := executor.ExecuteStage("001-test", StagePlan, tt.command, func(string) error {
+				calls++
+				if tt.validate == nil {
+					return nil
+				}
+				return tt.validate(&calls)
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			for i := range recorder.results {
+				recorder.results[i].Duration = 0 // Timing varies
+			}
+			assert.Equal(t, tt.want, recorder.results)
+		})
+	}
+}
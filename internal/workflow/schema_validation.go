@@ -6,11 +6,13 @@ package workflow
 import (
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/ariel-frischer/autospec/internal/spec"
 	"github.com/ariel-frischer/autospec/internal/validation"
+	"gopkg.in/yaml.v3"
 )
 
 // ValidateSpecSchema validates a spec.yaml file against its full schema.
@@ -64,6 +66,92 @@ func ValidateTasksSchema(specDir string) error {
 	return formatValidationErrors("tasks.yaml", result.Errors)
 }
 
+// ValidateChecklists validates every checklist in specDir/checklists against
+// the checklist schema. It fails if the checklist stage wrote no checklist.
+func ValidateChecklists(specDir string) error {
+	files, err := validation.ChecklistFiles(specDir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no checklist found in %s", filepath.Join(specDir, "checklists"))
+	}
+
+	var failures []string
+	validator := &validation.ChecklistValidator{}
+	for _, path := range files {
+		result := validator.Validate(path)
+		if !result.Valid {
+			name := filepath.Join("checklists", filepath.Base(path))
+			failures = append(failures, formatValidationErrors(name, result.Errors).Error())
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, ""))
+	}
+	return nil
+}
+
+// ValidateAnalysis validates the analysis.yaml written by the analyze stage
+// against its schema, then fails if the report contains CRITICAL
+// constitution findings, so a plan or task list that violates a project
+// principle stops the workflow before implementation.
+func ValidateAnalysis(specDir string) error {
+	analysisPath := filepath.Join(specDir, "analysis.yaml")
+	validator := &validation.AnalysisValidator{}
+	result := validator.Validate(analysisPath)
+	if !result.Valid {
+		return formatValidationErrors("analysis.yaml", result.Errors)
+	}
+
+	data, err := os.ReadFile(analysisPath)
+	if err != nil {
+		return fmt.Errorf("reading analysis.yaml: %w", err)
+	}
+	var report struct {
+		Findings []struct {
+			ID       string `yaml:"id"`
+			Category string `yaml:"category"`
+			Severity string `yaml:"severity"`
+			Location string `yaml:"location"`
+			Summary  string `yaml:"summary"`
+		} `yaml:"findings"`
+	}
+	if err := yaml.Unmarshal(data, &report); err != nil {
+		return fmt.Errorf("parsing analysis.yaml: %w", err)
+	}
+
+	var violations []string
+	for _, f := range report.Findings {
+		if f.Category == "constitution" && f.Severity == "CRITICAL" {
+			violations = append(violations, fmt.Sprintf("- %s (%s): %s", f.ID, f.Location, f.Summary))
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("analysis.yaml reports %d critical constitution violation(s); update the spec, plan or tasks and re-run analyze:\n%s",
+		len(violations), strings.Join(violations, "\n"))
+}
+
+// ValidateVerification validates tasks.yaml after the verify stage: the file
+// must match its schema and every completed task with acceptance criteria
+// must have a verification record. Failed verifications don't fail the stage.
+func ValidateVerification(specDir string) error {
+	if err := ValidateTasksSchema(specDir); err != nil {
+		return err
+	}
+	summary, err := validation.GetVerificationSummary(filepath.Join(specDir, "tasks.yaml"))
+	if err != nil {
+		return err
+	}
+	if len(summary.Unverified) > 0 {
+		return fmt.Errorf("tasks.yaml has no verification record for %d completed task(s): %s; add a verification block (status: Verified or Failed) to each",
+			len(summary.Unverified), strings.Join(summary.Unverified, ", "))
+	}
+	return nil
+}
+
 // MakeSpecSchemaValidatorWithDetection creates a validation function that first
 // detects the current spec directory, then validates spec.yaml against its schema.
 // This is necessary for the specify stage where the spec name is not known until
@@ -87,16 +175,5 @@ func MakeSpecSchemaValidatorWithDetection(specsDir string) func(string) error {
 // formatValidationErrors formats a list of validation errors into a single error.
 // The error message is formatted for inclusion in retry context.
 func formatValidationErrors(artifactName string, validationErrs []*validation.ValidationError) error {
-	if len(validationErrs) == 0 {
-		return nil
-	}
-
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("schema validation failed for %s:\n", artifactName))
-
-	for _, err := range validationErrs {
-		sb.WriteString(fmt.Sprintf("- %s\n", err.Error()))
-	}
-
-	return errors.New(sb.String())
+	return validation.SchemaError(artifactName, validationErrs)
 }
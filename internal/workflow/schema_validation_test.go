@@ -424,3 +424,165 @@ requirements:
 		}
 	}
 }
+
+func TestValidateAnalysis(t *testing.T) {
+	t.Parallel()
+
+	const header = `analysis:
+  branch: "001-test"
+  timestamp: "2025-01-01T00:00:00Z"
+summary:
+  overall_status: "FAIL"
+`
+	tests := map[string]struct {
+		content     string // analysis.yaml content; empty means the file is missing
+		wantErr     bool
+		errContains []string
+	}{
+		"no findings": {
+			content: header + "findings: []\n",
+		},
+		"critical finding outside constitution passes": {
+			content: header + `findings:
+  - id: "C1"
+    category: "coverage"
+    severity: "CRITICAL"
+    location: "spec.yaml:FR-001"
+    summary: "Requirement has no tasks"
+`,
+		},
+		"high constitution finding passes": {
+			content: header + `findings:
+  - id: "K1"
+    category: "constitution"
+    severity: "HIGH"
+    location: "plan.yaml:summary"
+    summary: "SHOULD principle not followed"
+`,
+		},
+		"critical constitution finding fails": {
+			content: header + `findings:
+  - id: "K1"
+    category: "constitution"
+    severity: "CRITICAL"
+    location: "tasks.yaml:T003"
+    summary: "Tests are written after implementation"
+`,
+			wantErr:     true,
+			errContains: []string{"1 critical constitution violation", "K1 (tasks.yaml:T003): Tests are written after implementation"},
+		},
+		"schema errors fail": {
+			content:     "findings: []\n",
+			wantErr:     true,
+			errContains: []string{"missing required field: analysis"},
+		},
+		"missing report fails": {
+			wantErr:     true,
+			errContains: []string{"failed to parse YAML"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specDir := t.TempDir()
+			if tc.content != "" {
+				if err := os.WriteFile(filepath.Join(specDir, "analysis.yaml"), []byte(tc.content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := ValidateAnalysis(specDir)
+
+			if !tc.wantErr {
+				if err != nil {
+					t.Errorf("ValidateAnalysis() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("ValidateAnalysis() expected error, got nil")
+			}
+			for _, want := range tc.errContains {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("ValidateAnalysis() error = %q, want error containing %q", err.Error(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateChecklists(t *testing.T) {
+	t.Parallel()
+
+	const valid = `checklist:
+  feature: "Test"
+  branch: "001-test"
+  domain: "security"
+categories:
+  - name: "Security"
+    items:
+      - id: "CHK001"
+        description: "Are authentication requirements specified?"
+        type: "security"
+        status: "pending"
+`
+	tests := map[string]struct {
+		files       map[string]string // checklists/<name> -> content
+		wantErr     bool
+		errContains []string
+	}{
+		"valid checklists": {
+			files: map[string]string{"security.yaml": valid, "ux.yaml": valid},
+		},
+		"no checklists": {
+			wantErr:     true,
+			errContains: []string{"no checklist found"},
+		},
+		"invalid checklist is named": {
+			files: map[string]string{
+				"security.yaml": valid,
+				"ux.yaml":       "checklist:\n  feature: \"Test\"\ncategories: []\n",
+			},
+			wantErr:     true,
+			errContains: []string{"checklists/ux.yaml", "missing required field: branch"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specDir := t.TempDir()
+			if len(tc.files) > 0 {
+				dir := filepath.Join(specDir, "checklists")
+				if err := os.MkdirAll(dir, 0o755); err != nil {
+					t.Fatal(err)
+				}
+				for file, content := range tc.files {
+					if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0o644); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			err := ValidateChecklists(specDir)
+
+			if !tc.wantErr {
+				if err != nil {
+					t.Errorf("ValidateChecklists() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("ValidateChecklists() expected error, got nil")
+			}
+			for _, want := range tc.errContains {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("ValidateChecklists() error = %q, want error containing %q", err.Error(), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Package workflow snapshots spec artifacts before stages that change them.
+// Related: internal/snapshot/snapshot.go, internal/cli/util/snapshots.go
+// Tags: workflow, snapshot, artifacts, rollback
+package workflow
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/snapshot"
+)
+
+// readOnlyStages don't change spec.yaml, plan.yaml or tasks.yaml, so no
+// snapshot is taken before them. specify has no spec directory yet.
+var readOnlyStages = map[Stage]bool{
+	StageSpecify:      true,
+	StageConstitution: true,
+	StageChecklist:    true,
+	StageAnalyze:      true,
+}
+
+// snapshotArtifacts saves the spec's artifacts before stage may change them,
+// so 'autospec snapshots restore' can roll back a bad regeneration. Failures
+// are reported as warnings and never fail the stage.
+func (e *Executor) snapshotArtifacts(specName string, stage Stage) {
+	if e.MaxSnapshots <= 0 || e.StateDir == "" || specName == "" || readOnlyStages[stage] {
+		return
+	}
+	specDir := filepath.Join(e.SpecsDir, specName)
+	s, err := snapshot.Take(e.StateDir, specDir, string(stage), e.MaxSnapshots)
+	if err != nil {
+		fmt.Fprintf(e.output(), "Warning: failed to snapshot artifacts: %v\n", err)
+		return
+	}
+	if s != nil {
+		e.debugLog("Saved artifact snapshot %s before %s", s.ID, stage)
+	}
+}
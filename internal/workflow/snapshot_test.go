@@ -0,0 +1,72 @@
+// Package workflow tests artifact snapshots taken before stages.
+// Related: internal/workflow/snapshot.go, internal/snapshot/snapshot.go
+// Tags: workflow, snapshot, artifacts, rollback
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteStage_SnapshotsArtifacts(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		stage         Stage
+		maxSnapshots  int
+		wantSnapshots int
+	}{
+		"plan is snapshotted": {
+			stage:         StagePlan,
+			maxSnapshots:  5,
+			wantSnapshots: 1,
+		},
+		"implement is snapshotted": {
+			stage:         StageImplement,
+			maxSnapshots:  5,
+			wantSnapshots: 1,
+		},
+		"read-only stage": {
+			stage:        StageAnalyze,
+			maxSnapshots: 5,
+		},
+		"snapshots disabled": {
+			stage: StagePlan,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			stateDir := t.TempDir()
+			specsDir := t.TempDir()
+			specDir := filepath.Join(specsDir, "003-x")
+			require.NoError(t, os.MkdirAll(specDir, 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(specDir, "spec.yaml"), []byte("feature: {}\n"), 0o644))
+
+			executor := &Executor{
+				Claude:       &mockClaudeExecutor{},
+				StateDir:     stateDir,
+				SpecsDir:     specsDir,
+				MaxSnapshots: tt.maxSnapshots,
+			}
+			_, err := executor.ExecuteStage("003-x", tt.stage, "/autospec."+string(tt.stage), func(string) error { return nil })
+			require.NoError(t, err)
+
+			snapshots, err := snapshot.List(stateDir, "003-x")
+			require.NoError(t, err)
+			require.Len(t, snapshots, tt.wantSnapshots)
+			for _, s := range snapshots {
+				assert.Equal(t, string(tt.stage), s.Stage)
+				assert.Equal(t, []string{"spec.yaml"}, s.Files)
+			}
+		})
+	}
+}
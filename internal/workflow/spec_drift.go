@@ -0,0 +1,93 @@
+// Package workflow detects plan.yaml and tasks.yaml that were generated from
+// an older spec.yaml.
+// Related: internal/state/spec_hash.go, internal/cli/stages/plan.go
+// Tags: workflow, spec, drift, refresh
+package workflow
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/ariel-frischer/autospec/internal/state"
+)
+
+// Values of the on_spec_change setting.
+const (
+	OnSpecChangeWarn   = "warn"
+	OnSpecChangeReplan = "replan"
+	OnSpecChangeIgnore = "ignore"
+)
+
+// refreshGuidance tells the agent to update an existing artifact for a
+// changed spec instead of starting over.
+var refreshGuidance = map[Stage]string{
+	StagePlan: "spec.yaml changed since plan.yaml was generated. Update the existing plan.yaml to match " +
+		"the current spec.yaml: keep decisions that still apply, revise what the changed requirements " +
+		"affect, and remove what no longer applies.",
+	StageTasks: "spec.yaml or plan.yaml changed since tasks.yaml was generated. Update the existing " +
+		"tasks.yaml to match them: keep completed tasks and their status, revise or add tasks for " +
+		"changed requirements, and remove pending tasks that no longer apply.",
+}
+
+// RefreshGuidance returns the prompt for regenerating stage's artifact after
+// the spec changed, followed by the user's own prompt if any.
+func RefreshGuidance(stage Stage, prompt string) string {
+	guidance := refreshGuidance[stage]
+	if prompt == "" {
+		return guidance
+	}
+	return guidance + " " + prompt
+}
+
+// recordSpecHash remembers which spec.yaml stage generated its artifact from.
+// Failures are reported as warnings and never fail the stage.
+func (e *Executor) recordSpecHash(specName string, stage Stage) {
+	if e.StateDir == "" {
+		return
+	}
+	specDir := filepath.Join(e.SpecsDir, specName)
+	if err := state.RecordSpecHash(e.StateDir, specDir, string(stage)); err != nil {
+		fmt.Fprintf(e.output(), "Warning: failed to record spec hash: %v\n", err)
+	}
+}
+
+// handleSpecDrift checks whether the artifacts of the given stages were
+// generated from an older spec.yaml and, depending on on_spec_change, warns
+// or regenerates them before the next stage runs.
+func (w *WorkflowOrchestrator) handleSpecDrift(specName string, stages ...Stage) error {
+	mode := OnSpecChangeWarn
+	if w.Config != nil && w.Config.OnSpecChange != "" {
+		mode = w.Config.OnSpecChange
+	}
+	if mode == OnSpecChangeIgnore || w.Executor == nil || w.Executor.StateDir == "" {
+		return nil
+	}
+	specDir := filepath.Join(w.SpecsDir, specName)
+
+	for _, stage := range stages {
+		// Recheck each time: refreshing the plan leaves tasks.yaml stale
+		if !slices.Contains(state.StaleStages(w.Executor.StateDir, specDir), string(stage)) {
+			continue
+		}
+		artifact := string(stage) + ".yaml"
+		if mode != OnSpecChangeReplan {
+			fmt.Printf("⚠ spec.yaml changed since %s was generated; run 'autospec %s --refresh' "+
+				"to update it (or set on_spec_change: replan)\n", artifact, stage)
+			continue
+		}
+
+		fmt.Printf("spec.yaml changed since %s was generated; refreshing it\n", artifact)
+		var err error
+		switch stage {
+		case StagePlan:
+			err = w.stageExecutor.ExecutePlan(specName, RefreshGuidance(StagePlan, ""))
+		case StageTasks:
+			err = w.stageExecutor.ExecuteTasks(specName, RefreshGuidance(StageTasks, ""))
+		}
+		if err != nil {
+			return fmt.Errorf("refreshing %s after spec change: %w", artifact, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Package workflow tests detection of artifacts generated from an older spec.
+// Related: internal/workflow/spec_drift.go, internal/state/spec_hash.go
+// Tags: workflow, spec, drift, refresh
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleSpecDrift(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		mode          string
+		changeSpec    bool
+		planErr       error
+		wantPlanCalls int
+		wantTaskCalls int
+		wantErr       string
+	}{
+		"unchanged spec": {
+			mode: OnSpecChangeReplan,
+		},
+		"warn only": {
+			changeSpec: true,
+		},
+		"ignore": {
+			mode:       OnSpecChangeIgnore,
+			changeSpec: true,
+		},
+		"replan refreshes plan then tasks": {
+			mode:          OnSpecChangeReplan,
+			changeSpec:    true,
+			wantPlanCalls: 1,
+			wantTaskCalls: 1,
+		},
+		"failed refresh stops": {
+			mode:          OnSpecChangeReplan,
+			changeSpec:    true,
+			planErr:       assert.AnError,
+			wantPlanCalls: 1,
+			wantErr:       "refreshing plan.yaml after spec change",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specsDir := t.TempDir()
+			stateDir := t.TempDir()
+			specDir := filepath.Join(specsDir, "003-x")
+			require.NoError(t, os.MkdirAll(specDir, 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(specDir, "spec.yaml"), []byte("feature: v1\n"), 0o644))
+			require.NoError(t, state.RecordSpecHash(stateDir, specDir, "plan"))
+			require.NoError(t, state.RecordSpecHash(stateDir, specDir, "tasks"))
+			if tt.changeSpec {
+				require.NoError(t, os.WriteFile(filepath.Join(specDir, "spec.yaml"), []byte("feature: v2\n"), 0o644))
+			}
+
+			mockStage := NewMockStageExecutor()
+			mockStage.PlanError = tt.planErr
+			orch := NewWorkflowOrchestratorWithExecutors(&config.Configuration{
+				SpecsDir:     specsDir,
+				StateDir:     stateDir,
+				OnSpecChange: tt.mode,
+			}, ExecutorOptions{StageExecutor: mockStage})
+
+			err := orch.handleSpecDrift("003-x", StagePlan, StageTasks)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, mockStage.PlanCalls, tt.wantPlanCalls)
+			require.Len(t, mockStage.TasksCalls, tt.wantTaskCalls)
+			if tt.wantPlanCalls > 0 {
+				assert.Contains(t, mockStage.PlanCalls[0].Prompt, "Update the existing plan.yaml")
+			}
+		})
+	}
+}
+
+func TestExecutor_RecordSpecHash(t *testing.T) {
+	t.Parallel()
+
+	specsDir := t.TempDir()
+	stateDir := t.TempDir()
+	specDir := filepath.Join(specsDir, "003-x")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "spec.yaml"), []byte("feature: v1\n"), 0o644))
+
+	executor := &Executor{Claude: &mockClaudeExecutor{}, StateDir: stateDir, SpecsDir: specsDir}
+	executor.recordSpecHash("003-x", StagePlan)
+
+	assert.Contains(t, state.LoadSpecHashes(stateDir, "003-x"), "plan")
+	assert.Empty(t, state.StaleStages(stateDir, specDir))
+}
+
+func TestRefreshGuidance(t *testing.T) {
+	t.Parallel()
+
+	assert.Contains(t, RefreshGuidance(StagePlan, ""), "plan.yaml")
+	got := RefreshGuidance(StageTasks, "Keep phase 1 as is")
+	assert.Contains(t, got, "keep completed tasks")
+	assert.Contains(t, got, "Keep phase 1 as is")
+}
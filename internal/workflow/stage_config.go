@@ -2,7 +2,7 @@ package workflow
 
 // StageConfig represents the user's selected stages for execution.
 // It determines which workflow stages (specify, plan, tasks, implement)
-// and optional stages (constitution, clarify, checklist, analyze)
+// and optional stages (constitution, clarify, checklist, analyze, verify)
 // will be executed during a run.
 type StageConfig struct {
 	// Core workflow stages
@@ -16,6 +16,7 @@ type StageConfig struct {
 	Clarify      bool
 	Checklist    bool
 	Analyze      bool
+	Verify       bool
 }
 
 // NewStageConfig creates a new StageConfig with all stages disabled.
@@ -36,13 +37,13 @@ func NewStageConfigAll() *StageConfig {
 // HasAnyStage returns true if any stage (core or optional) is selected.
 func (sc *StageConfig) HasAnyStage() bool {
 	return sc.Specify || sc.Plan || sc.Tasks || sc.Implement ||
-		sc.Constitution || sc.Clarify || sc.Checklist || sc.Analyze
+		sc.Constitution || sc.Clarify || sc.Checklist || sc.Analyze || sc.Verify
 }
 
 // GetSelectedStages returns a slice of selected stages in canonical order.
-// The canonical order is always: constitution -> specify -> clarify -> plan -> tasks -> checklist -> analyze -> implement.
+// The canonical order is always: constitution -> specify -> clarify -> plan -> tasks -> checklist -> analyze -> implement -> verify.
 func (sc *StageConfig) GetSelectedStages() []Stage {
-	stages := make([]Stage, 0, 8)
+	stages := make([]Stage, 0, 9)
 	if sc.Constitution {
 		stages = append(stages, StageConstitution)
 	}
@@ -67,12 +68,15 @@ func (sc *StageConfig) GetSelectedStages() []Stage {
 	if sc.Implement {
 		stages = append(stages, StageImplement)
 	}
+	if sc.Verify {
+		stages = append(stages, StageVerify)
+	}
 	return stages
 }
 
 // GetCanonicalOrder is an alias for GetSelectedStages that returns stages
 // in the canonical execution order:
-// constitution -> specify -> clarify -> plan -> tasks -> checklist -> analyze -> implement
+// constitution -> specify -> clarify -> plan -> tasks -> checklist -> analyze -> implement -> verify
 // This ensures stages always execute in the correct order regardless of
 // the order in which flags were specified.
 func (sc *StageConfig) GetCanonicalOrder() []Stage {
@@ -116,6 +120,9 @@ func (sc *StageConfig) Count() int {
 	if sc.Analyze {
 		count++
 	}
+	if sc.Verify {
+		count++
+	}
 	return count
 }
 
@@ -171,7 +178,12 @@ var artifactDependencies = map[Stage]ArtifactDependency{
 	StageAnalyze: {
 		Stage:    StageAnalyze,
 		Requires: []string{"spec.yaml", "plan.yaml", "tasks.yaml"}, // Analyze validates all artifacts
-		Produces: []string{},                                       // Analyze outputs analysis report
+		Produces: []string{"analysis.yaml"},                        // Analyze writes the analysis report
+	},
+	StageVerify: {
+		Stage:    StageVerify,
+		Requires: []string{"tasks.yaml"}, // Verify checks completed tasks
+		Produces: []string{},             // Verify records results in tasks.yaml
 	},
 }
 
@@ -323,9 +323,9 @@ func TestCount(t *testing.T) {
 func TestGetArtifactDependencies(t *testing.T) {
 	deps := GetArtifactDependencies()
 
-	// 4 core stages + 4 optional stages = 8 total
-	if len(deps) != 8 {
-		t.Errorf("GetArtifactDependencies() returned %d entries, want 8", len(deps))
+	// 4 core stages + 5 optional stages = 9 total
+	if len(deps) != 9 {
+		t.Errorf("GetArtifactDependencies() returned %d entries, want 9", len(deps))
 	}
 
 	// Verify each stage has a dependency entry
@@ -333,7 +333,7 @@ func TestGetArtifactDependencies(t *testing.T) {
 		// Core stages
 		StageSpecify, StagePlan, StageTasks, StageImplement,
 		// Optional stages
-		StageConstitution, StageClarify, StageChecklist, StageAnalyze,
+		StageConstitution, StageClarify, StageChecklist, StageAnalyze, StageVerify,
 	}
 	for _, stage := range stages {
 		if _, ok := deps[stage]; !ok {
@@ -388,7 +388,7 @@ func TestGetArtifactDependency(t *testing.T) {
 		{
 			stage:            StageAnalyze,
 			expectedRequires: []string{"spec.yaml", "plan.yaml", "tasks.yaml"},
-			expectedProduces: []string{},
+			expectedProduces: []string{"analysis.yaml"},
 		},
 	}
 
@@ -445,11 +445,11 @@ func TestGetProducedArtifacts(t *testing.T) {
 		{StagePlan, []string{"plan.yaml"}},
 		{StageTasks, []string{"tasks.yaml"}},
 		{StageImplement, []string{}},
-		// Optional stages (none produce tracked artifacts)
+		// Optional stages (only analyze produces a tracked artifact)
 		{StageConstitution, []string{}},
 		{StageClarify, []string{}},
 		{StageChecklist, []string{}},
-		{StageAnalyze, []string{}},
+		{StageAnalyze, []string{"analysis.yaml"}},
 	}
 
 	for _, tt := range tests {
@@ -65,7 +65,13 @@ func (s *StageExecutor) resetSpecifyRetryState() {
 
 // runSpecifyStage executes the specify stage command
 func (s *StageExecutor) runSpecifyStage(featureDescription string) (*StageResult, error) {
-	command := fmt.Sprintf("/autospec.specify \"%s\"", featureDescription)
+	command, err := s.executor.renderStagePrompt(StageSpecify, PromptData{
+		FeatureDescription: featureDescription,
+		Command:            fmt.Sprintf("/autospec.specify \"%s\"", featureDescription),
+	})
+	if err != nil {
+		return &StageResult{Stage: StageSpecify}, err
+	}
 	validateFunc := MakeSpecSchemaValidatorWithDetection(s.specsDir)
 	return s.executor.ExecuteStage("", StageSpecify, command, validateFunc)
 }
@@ -102,7 +108,14 @@ func (s *StageExecutor) ExecutePlan(specNameArg string, prompt string) error {
 
 	s.debugLog("ExecutePlan called for spec: %s, prompt: %s", specName, prompt)
 
-	command := s.buildPlanCommand(prompt)
+	command, err := s.executor.renderStagePrompt(StagePlan, PromptData{
+		SpecName: specName,
+		Guidance: prompt,
+		Command:  s.buildPlanCommand(prompt),
+	})
+	if err != nil {
+		return err
+	}
 	specDir := filepath.Join(s.specsDir, specName)
 
 	result, err := s.executor.ExecuteStage(
@@ -128,6 +141,7 @@ func (s *StageExecutor) ExecutePlan(specNameArg string, prompt string) error {
 		s.debugLog("Research file exists at: %s", researchPath)
 	}
 
+	s.executor.recordSpecHash(specName, StagePlan)
 	s.debugLog("ExecutePlan completed successfully")
 	return nil
 }
@@ -143,7 +157,14 @@ func (s *StageExecutor) ExecuteTasks(specNameArg string, prompt string) error {
 
 	s.debugLog("ExecuteTasks called for spec: %s, prompt: %s", specName, prompt)
 
-	command := s.buildTasksCommand(prompt)
+	command, err := s.executor.renderStagePrompt(StageTasks, PromptData{
+		SpecName: specName,
+		Guidance: prompt,
+		Command:  s.buildTasksCommand(prompt),
+	})
+	if err != nil {
+		return err
+	}
 
 	result, err := s.executor.ExecuteStage(
 		specName,
@@ -162,6 +183,7 @@ func (s *StageExecutor) ExecuteTasks(specNameArg string, prompt string) error {
 			totalAttempts, result.RetryCount, err)
 	}
 
+	s.executor.recordSpecHash(specName, StageTasks)
 	s.debugLog("ExecuteTasks completed successfully")
 	return nil
 }
@@ -246,15 +268,15 @@ func (s *StageExecutor) ExecuteClarify(specName string, prompt string) error {
 }
 
 // ExecuteChecklist runs the checklist stage with optional prompt.
-// Checklist generates a custom checklist for the current feature.
+// Checklist generates a custom checklist for the current feature; each
+// checklists/*.yaml it writes is schema-validated, with retries on failure.
 func (s *StageExecutor) ExecuteChecklist(specName string, prompt string) error {
 	s.debugLog("ExecuteChecklist called for spec: %s, prompt: %s", specName, prompt)
 
 	command := s.buildCommand("/autospec.checklist", prompt)
 	s.printExecuting("/autospec.checklist", prompt)
 
-	result, err := s.executor.ExecuteStage(specName, StageChecklist, command,
-		func(specDir string) error { return nil })
+	result, err := s.executor.ExecuteStage(specName, StageChecklist, command, ValidateChecklists)
 
 	if err != nil {
 		if result.Exhausted {
@@ -270,6 +292,7 @@ func (s *StageExecutor) ExecuteChecklist(specName string, prompt string) error {
 // ExecuteAnalyze runs the analyze stage with optional prompt.
 // Analyze performs cross-artifact consistency and quality analysis.
 // This stage runs in interactive mode (no retry loop, multi-turn conversation).
+// The session must write analysis.yaml; CRITICAL constitution findings fail the stage.
 func (s *StageExecutor) ExecuteAnalyze(specName string, prompt string) error {
 	s.debugLog("ExecuteAnalyze called for spec: %s, prompt: %s", specName, prompt)
 
@@ -278,8 +301,7 @@ func (s *StageExecutor) ExecuteAnalyze(specName string, prompt string) error {
 
 	// ExecuteStage automatically detects interactive mode via IsInteractive(StageAnalyze)
 	// Interactive stages skip retry loop and run without -p flag
-	_, err := s.executor.ExecuteStage(specName, StageAnalyze, command,
-		func(specDir string) error { return nil })
+	_, err := s.executor.ExecuteStage(specName, StageAnalyze, command, ValidateAnalysis)
 
 	if err != nil {
 		return fmt.Errorf("analyze session failed: %w", err)
@@ -289,6 +311,26 @@ func (s *StageExecutor) ExecuteAnalyze(specName string, prompt string) error {
 	return nil
 }
 
+// ExecuteVerify runs the verify stage with optional prompt.
+// Verify checks the acceptance criteria of every completed task against the
+// code and records the result in tasks.yaml. The stage is retried until each
+// of those tasks has a verification record; unmet criteria don't fail it.
+func (s *StageExecutor) ExecuteVerify(specName string, prompt string) error {
+	s.debugLog("ExecuteVerify called for spec: %s, prompt: %s", specName, prompt)
+
+	command := s.buildCommand("/autospec.verify", prompt)
+	s.printExecuting("/autospec.verify", prompt)
+
+	result, err := s.executor.ExecuteStage(specName, StageVerify, command, ValidateVerification)
+	if err != nil {
+		if result.Exhausted {
+			return fmt.Errorf("verify stage exhausted retries: %w", err)
+		}
+		return fmt.Errorf("verify failed: %w", err)
+	}
+	return nil
+}
+
 // buildCommand constructs a command with optional prompt.
 func (s *StageExecutor) buildCommand(baseCmd, prompt string) string {
 	if prompt != "" {
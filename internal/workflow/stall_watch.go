@@ -0,0 +1,92 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// stallWatch is an io.Writer placed alongside agent output that calls stop
+// once nothing has been written for timeout, e.g. to cancel the agent's
+// context. Checked every inputCheckInterval.
+type stallWatch struct {
+	timeout time.Duration
+	stop    func()
+
+	mu      sync.Mutex
+	last    time.Time // When output was last written
+	tripped bool
+}
+
+// newStallWatch creates a watch whose idle time starts now.
+func newStallWatch(timeout time.Duration, stop func()) *stallWatch {
+	return &stallWatch{timeout: timeout, stop: stop, last: time.Now()}
+}
+
+// Write records output. It never fails, so it can't break the agent's output.
+func (w *stallWatch) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.last = time.Now()
+	return len(p), nil
+}
+
+// start checks for a stall every inputCheckInterval until the returned
+// function is called.
+func (w *stallWatch) start() (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(inputCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				if w.check(now) {
+					return
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// check calls stop if, as of now, nothing was written for timeout.
+// Reports whether the watch has tripped.
+func (w *stallWatch) check(now time.Time) bool {
+	w.mu.Lock()
+	trip := !w.tripped && now.Sub(w.last) >= w.timeout
+	if trip {
+		w.tripped = true
+	}
+	tripped := w.tripped
+	w.mu.Unlock()
+
+	if trip {
+		w.stop()
+	}
+	return tripped
+}
+
+// stalled reports whether the watch stopped the agent.
+func (w *stallWatch) stalled() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.tripped
+}
+
+// retryStall reports whether a failed attempt stalled and should be retried:
+// on_stall is not "fail" and a retry is left. The stall is then handled like
+// a validation failure, so the retried session is told the last one stalled.
+func (e *Executor) retryStall(ctx *stageExecutionContext, err error) bool {
+	var stallErr *StallError
+	if !errors.As(err, &stallErr) || e.OnStall == "fail" || !ctx.retryState.CanRetry() {
+		return false
+	}
+	ctx.result.ValidationErrors = []string{err.Error()}
+	ctx.lastValidationErrors = ctx.result.ValidationErrors
+	fmt.Fprintf(e.output(), "\n⚠ %s: %v\n", ctx.stage, err)
+	return true
+}
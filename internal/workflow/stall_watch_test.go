@@ -0,0 +1,115 @@
+// Package workflow tests stopping agents that stall.
+// Related: internal/workflow/stall_watch.go, internal/workflow/claude.go, internal/workflow/executor.go
+// Tags: workflow, stall, watchdog, timeout, retry
+package workflow
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStallWatch_Check(t *testing.T) {
+	t.Parallel()
+
+	stops := 0
+	w := newStallWatch(time.Minute, func() { stops++ })
+
+	assert.False(t, w.check(w.last.Add(30*time.Second)))
+	_, _ = w.Write([]byte("working\n"))
+	assert.False(t, w.check(w.last.Add(59*time.Second)), "output resets the idle time")
+	assert.True(t, w.check(w.last.Add(time.Minute)))
+	assert.True(t, w.check(w.last.Add(2*time.Minute)))
+
+	assert.Equal(t, 1, stops, "stops once")
+	assert.True(t, w.stalled())
+}
+
+func TestClaudeExecutor_StallTimeout(t *testing.T) {
+	t.Parallel()
+
+	claude := shellClaude(t, `echo started; exec sleep 30`)
+	claude.StallTimeout = time.Second
+
+	start := time.Now()
+	err := claude.Execute("/autospec.plan")
+
+	var stallErr *StallError
+	require.ErrorAs(t, err, &stallErr)
+	assert.Equal(t, time.Second, stallErr.Idle)
+	assert.Contains(t, err.Error(), "stalled: no output for 1s")
+	assert.Less(t, time.Since(start), 10*time.Second, "agent is stopped, not waited for")
+}
+
+// stallMockRunner stalls on its first stallCalls executions.
+type stallMockRunner struct {
+	mockClaudeExecutor
+	stallCalls int
+}
+
+func (m *stallMockRunner) Execute(prompt string) error {
+	m.executeCalls = append(m.executeCalls, prompt)
+	if len(m.executeCalls) <= m.stallCalls {
+		return &StallError{Agent: "claude", Idle: 15 * time.Minute}
+	}
+	return nil
+}
+
+func TestExecuteStage_Stall(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		onStall     string
+		maxRetries  int
+		stallCalls  int
+		wantCalls   int
+		wantErr     bool
+		wantStalled bool
+	}{
+		"retried when a retry is left": {
+			maxRetries: 1, stallCalls: 1, wantCalls: 2,
+		},
+		"failed when retries run out": {
+			maxRetries: 1, stallCalls: 2, wantCalls: 2, wantErr: true, wantStalled: true,
+		},
+		"failed without retries": {
+			stallCalls: 1, wantCalls: 1, wantErr: true, wantStalled: true,
+		},
+		"failed when on_stall is fail": {
+			onStall: "fail", maxRetries: 1, stallCalls: 1, wantCalls: 1, wantErr: true, wantStalled: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := &stallMockRunner{stallCalls: tt.stallCalls}
+			var out bytes.Buffer
+			executor := &Executor{
+				Claude:     runner,
+				StateDir:   t.TempDir(),
+				SpecsDir:   t.TempDir(),
+				MaxRetries: tt.maxRetries,
+				OnStall:    tt.onStall,
+				Output:     &out,
+			}
+
+			result, err := executor.ExecuteStage("001-test", StagePlan, "/autospec.plan", func(string) error { return nil })
+			assert.Len(t, runner.executeCalls, tt.wantCalls)
+			assert.Equal(t, tt.wantStalled, result.Stalled)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				assert.Contains(t, runner.executeCalls[1], "stalled", "retry is told the last session stalled")
+				return
+			}
+			var stallErr *StallError
+			require.Error(t, err)
+			assert.True(t, errors.As(result.Error, &stallErr))
+		})
+	}
+}
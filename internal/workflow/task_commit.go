@@ -0,0 +1,90 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ariel-frischer/autospec/internal/git"
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+// taskCommitTypes maps tasks.yaml task types to conventional-commit types.
+// Unknown types (and "implementation") produce "feat".
+var taskCommitTypes = map[string]string{
+	"setup":         "chore",
+	"test":          "test",
+	"documentation": "docs",
+	"refactor":      "refactor",
+}
+
+// TaskCommitMessage builds a conventional-commit subject for a completed task,
+// e.g. "feat(T014): add retry policy".
+func TaskCommitMessage(task validation.TaskItem) string {
+	commitType, ok := taskCommitTypes[task.Type]
+	if !ok {
+		commitType = "feat"
+	}
+	return fmt.Sprintf("%s(%s): %s", commitType, task.ID, lowerFirstWord(strings.TrimSpace(task.Title)))
+}
+
+// lowerFirstWord lowercases the first letter of a title unless its first word
+// looks like an acronym or identifier (e.g. "API", "OAuth").
+func lowerFirstWord(title string) string {
+	first, size := utf8.DecodeRuneInString(title)
+	if size == 0 || !unicode.IsUpper(first) {
+		return title
+	}
+	word, _, _ := strings.Cut(title, " ")
+	for _, r := range word[size:] {
+		if unicode.IsUpper(r) {
+			return title
+		}
+	}
+	return string(unicode.ToLower(first)) + title[size:]
+}
+
+// taskSnapshot records the working tree before a task runs and commits what
+// the task changed. Implemented by *git.Snapshot.
+type taskSnapshot interface {
+	CommitChanges(message string) (bool, error)
+}
+
+// takeGitSnapshot adapts git.TakeSnapshot to the taskSnapshot interface.
+func takeGitSnapshot(dir string) (taskSnapshot, error) {
+	return git.TakeSnapshot(dir)
+}
+
+// snapshotTree records the working tree before a task runs when per-task
+// commits are enabled. Returns nil (no commit) if disabled or git fails.
+func (te *TaskExecutor) snapshotTree(task validation.TaskItem) taskSnapshot {
+	if !te.commitTasks {
+		return nil
+	}
+	snap, err := te.snapshot(te.executor.WorkDir)
+	if err != nil {
+		fmt.Printf("⚠ Task %s will not be committed: %v\n", task.ID, err)
+		return nil
+	}
+	return snap
+}
+
+// commitTask commits the paths a task changed after it completes. Changes
+// that were already in the working tree before the task started are left
+// uncommitted. A failed commit is reported but does not fail the task.
+func (te *TaskExecutor) commitTask(task validation.TaskItem, snap taskSnapshot) {
+	if snap == nil {
+		return
+	}
+	message := TaskCommitMessage(task)
+	committed, err := snap.CommitChanges(message)
+	switch {
+	case err != nil:
+		fmt.Printf("⚠ Could not commit task %s: %v\n", task.ID, err)
+	case committed:
+		fmt.Printf("✓ Committed: %s\n", message)
+	default:
+		te.debugLog("no changes to commit for task %s", task.ID)
+	}
+}
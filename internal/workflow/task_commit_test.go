@@ -0,0 +1,129 @@
+// Package workflow tests per-task commit messages and commit behavior.
+// Related: internal/workflow/task_commit.go
+// Tags: workflow, task-executor, git, commit
+
+package workflow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskCommitMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		task validation.TaskItem
+		want string
+	}{
+		"implementation task is a feature": {
+			task: validation.TaskItem{ID: "T014", Title: "Add retry policy", Type: "implementation"},
+			want: "feat(T014): add retry policy",
+		},
+		"setup task is a chore": {
+			task: validation.TaskItem{ID: "T001", Title: "Initialize module", Type: "setup"},
+			want: "chore(T001): initialize module",
+		},
+		"test task": {
+			task: validation.TaskItem{ID: "T002", Title: "Cover parser edge cases", Type: "test"},
+			want: "test(T002): cover parser edge cases",
+		},
+		"documentation task": {
+			task: validation.TaskItem{ID: "T003", Title: "Document flags", Type: "documentation"},
+			want: "docs(T003): document flags",
+		},
+		"refactor task": {
+			task: validation.TaskItem{ID: "T004", Title: "Split executor", Type: "refactor"},
+			want: "refactor(T004): split executor",
+		},
+		"unknown type defaults to feat": {
+			task: validation.TaskItem{ID: "T005", Title: "do thing", Type: "other"},
+			want: "feat(T005): do thing",
+		},
+		"acronym keeps its case": {
+			task: validation.TaskItem{ID: "T006", Title: "API client for issues", Type: "implementation"},
+			want: "feat(T006): API client for issues",
+		},
+		"mixed-case identifier keeps its case": {
+			task: validation.TaskItem{ID: "T007", Title: "OAuth login", Type: "implementation"},
+			want: "feat(T007): OAuth login",
+		},
+		"surrounding whitespace is trimmed": {
+			task: validation.TaskItem{ID: "T008", Title: "  Add cache  ", Type: "implementation"},
+			want: "feat(T008): add cache",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, TaskCommitMessage(tt.task))
+		})
+	}
+}
+
+// fakeTaskSnapshot records the commit requested for a task.
+type fakeTaskSnapshot struct {
+	message string
+	err     error
+}
+
+func (f *fakeTaskSnapshot) CommitChanges(message string) (bool, error) {
+	f.message = message
+	return f.err == nil, f.err
+}
+
+func TestTaskExecutor_CommitTask(t *testing.T) {
+	t.Parallel()
+
+	task := validation.TaskItem{ID: "T014", Title: "Add retry policy", Type: "implementation"}
+
+	tests := map[string]struct {
+		enabled     bool
+		snapshotErr error
+		commitErr   error
+		wantMessage string
+	}{
+		"disabled does not commit": {},
+		"enabled commits with task message": {
+			enabled:     true,
+			wantMessage: "feat(T014): add retry policy",
+		},
+		"commit error is not fatal": {
+			enabled:     true,
+			commitErr:   errors.New("hook rejected commit"),
+			wantMessage: "feat(T014): add retry policy",
+		},
+		"snapshot error skips the commit": {
+			enabled:     true,
+			snapshotErr: errors.New("not a git repository"),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var gotDir string
+			fake := &fakeTaskSnapshot{err: tt.commitErr}
+			te := NewTaskExecutor(&Executor{WorkDir: "/wt"}, "specs", false)
+			te.commitTasks = tt.enabled
+			te.snapshot = func(dir string) (taskSnapshot, error) {
+				gotDir = dir
+				if tt.snapshotErr != nil {
+					return nil, tt.snapshotErr
+				}
+				return fake, nil
+			}
+
+			te.commitTask(task, te.snapshotTree(task))
+
+			assert.Equal(t, tt.wantMessage, fake.message)
+			if tt.enabled {
+				assert.Equal(t, "/wt", gotDir, "snapshot is taken in the executor's work dir")
+			}
+		})
+	}
+}
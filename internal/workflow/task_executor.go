@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/ariel-frischer/autospec/internal/retry"
 	"github.com/ariel-frischer/autospec/internal/validation"
 )
 
@@ -19,6 +20,10 @@ type TaskExecutor struct {
 	executor *Executor // Underlying executor for Claude command execution
 	specsDir string    // Base directory for spec storage (e.g., "specs/")
 	debug    bool      // Enable debug logging
+
+	commitTasks bool                                   // Commit each completed task's changes
+	taskGate    []string                               // Build/lint commands run after each completed task
+	snapshot    func(dir string) (taskSnapshot, error) // Replaced in tests; defaults to git.TakeSnapshot
 }
 
 // NewTaskExecutor creates a new TaskExecutor with the given dependencies.
@@ -30,6 +35,7 @@ func NewTaskExecutor(executor *Executor, specsDir string, debug bool) *TaskExecu
 		executor: executor,
 		specsDir: specsDir,
 		debug:    debug,
+		snapshot: takeGitSnapshot,
 	}
 }
 
@@ -61,19 +67,53 @@ func (te *TaskExecutor) ExecuteTaskLoop(specName, tasksPath string, orderedTasks
 		}
 
 		fmt.Printf("[Task %d/%d] %s - %s\n", i+1, totalTasks, task.ID, task.Title)
+		te.checkpointTask(specName, task.ID, totalTasks)
 
 		// Execute and verify task
 		if err := te.executeAndVerifyTask(specName, tasksPath, task, prompt); err != nil {
 			return fmt.Errorf("executing task %s: %w", task.ID, err)
 		}
+		te.checkpointTaskComplete(specName, task.ID)
 
 		fmt.Printf("✓ Task %s complete\n\n", task.ID)
 	}
 
-	te.printTasksSummary(tasksPath, specDir)
+	te.clearTaskCheckpoint(specName)
+	te.printTasksSummary(tasksPath, specDir, len(orderedTasks))
 	return nil
 }
 
+// checkpointTask records the task the agent is working on, so implement
+// --resume restarts from it. Checkpoint failures never fail the run.
+func (te *TaskExecutor) checkpointTask(specName, taskID string, totalTasks int) {
+	if te.executor.StateDir == "" {
+		return
+	}
+	if err := retry.SetCurrentTask(te.executor.StateDir, specName, taskID, totalTasks); err != nil {
+		fmt.Printf("Warning: failed to save task checkpoint: %v\n", err)
+	}
+}
+
+// checkpointTaskComplete records a verified task in the task checkpoint.
+func (te *TaskExecutor) checkpointTaskComplete(specName, taskID string) {
+	if te.executor.StateDir == "" {
+		return
+	}
+	if err := retry.MarkTaskComplete(te.executor.StateDir, specName, taskID); err != nil {
+		fmt.Printf("Warning: failed to save task checkpoint: %v\n", err)
+	}
+}
+
+// clearTaskCheckpoint removes the task checkpoint once every task was processed.
+func (te *TaskExecutor) clearTaskCheckpoint(specName string) {
+	if te.executor.StateDir == "" {
+		return
+	}
+	if err := retry.ResetTaskState(te.executor.StateDir, specName); err != nil {
+		fmt.Printf("Warning: failed to clear task checkpoint: %v\n", err)
+	}
+}
+
 // ExecuteSingleTask runs a specific task by ID.
 // specName: the spec directory name
 // taskID: task identifier (e.g., "T001")
@@ -98,20 +138,35 @@ func (te *TaskExecutor) executeAndVerifyTask(specName, tasksPath string, task va
 		return nil
 	}
 
+	// Record pre-existing changes so only this task's changes are committed
+	snap := te.snapshotTree(task)
+
 	// Execute this task in a fresh Claude session
 	if err := te.executeSingleTaskSession(specName, task.ID, task.Title, prompt); err != nil {
 		return fmt.Errorf("task %s failed: %w", task.ID, err)
 	}
 
 	// Verify task completion
-	return te.verifyTaskCompletion(tasksPath, task.ID)
+	if err := te.verifyTaskCompletion(tasksPath, task.ID); err != nil {
+		return err
+	}
+	te.commitTask(task, snap)
+	return nil
 }
 
 // executeSingleTaskSession executes a single task in a fresh Claude session.
 func (te *TaskExecutor) executeSingleTaskSession(specName, taskID, taskTitle, prompt string) error {
 	te.debugLog("executeSingleTaskSession: taskID=%s, taskTitle=%s", taskID, taskTitle)
 
-	command := te.buildTaskCommand(taskID, prompt)
+	command, err := te.executor.renderStagePrompt(StageImplement, PromptData{
+		SpecName: specName,
+		Guidance: prompt,
+		Command:  te.buildTaskCommand(taskID, prompt),
+		TaskID:   taskID,
+	})
+	if err != nil {
+		return err
+	}
 	fmt.Printf("Executing: %s\n", command)
 
 	return te.executeTaskWithValidation(specName, taskID, command)
@@ -133,14 +188,17 @@ func (te *TaskExecutor) executeTaskWithValidation(specName, taskID, command stri
 		command,
 		func(specDir string) error {
 			// For task execution, we validate the specific task is completed
-			return te.validateTaskCompleted(specDir, taskID)
+			if err := te.validateTaskCompleted(specDir, taskID); err != nil {
+				return err
+			}
+			return te.runTaskGate(specName, specDir, taskID)
 		},
 	)
 
 	if err != nil {
 		if result.Exhausted {
 			fmt.Printf("\nTask %s paused.\n", taskID)
-			fmt.Printf("To resume: autospec implement --tasks --from-task %s\n", taskID)
+			fmt.Println("To resume: autospec implement --tasks --resume")
 			return fmt.Errorf("task %s exhausted retries: %w", taskID, err)
 		}
 		return fmt.Errorf("executing task %s session: %w", taskID, err)
@@ -241,15 +299,25 @@ func (te *TaskExecutor) findTaskStartIndex(orderedTasks, allTasks []validation.T
 	return startIdx, nil
 }
 
-// printTasksSummary prints the final task execution summary and marks spec as completed.
-func (te *TaskExecutor) printTasksSummary(tasksPath, specDir string) {
-	fmt.Println("✓ All tasks processed!")
-	fmt.Println()
+// printTasksSummary prints the final task execution summary and marks spec as
+// completed. When only some of the spec's tasks were run (implement --only),
+// the spec is left as is.
+func (te *TaskExecutor) printTasksSummary(tasksPath, specDir string, ranTasks int) {
 	stats, statsErr := validation.GetTaskStats(tasksPath)
+	selected := statsErr == nil && ranTasks < stats.TotalTasks
+	if selected {
+		fmt.Println("✓ Selected tasks processed!")
+	} else {
+		fmt.Println("✓ All tasks processed!")
+	}
+	fmt.Println()
 	if statsErr == nil && stats.TotalTasks > 0 {
 		fmt.Println("Task Summary:")
 		fmt.Print(validation.FormatTaskSummary(stats))
 	}
+	if selected {
+		return
+	}
 
 	// Mark spec as completed
 	markSpecCompletedAndPrint(specDir)
@@ -266,6 +334,10 @@ func shouldSkipTask(task validation.TaskItem, idx, totalTasks int) bool {
 		fmt.Printf("⚠ Task %d/%d: %s - %s (blocked)\n", idx+1, totalTasks, task.ID, task.Title)
 		return true
 	}
+	if task.IsSkipped() {
+		fmt.Printf("⊘ Task %d/%d: %s - %s (skipped)\n", idx+1, totalTasks, task.ID, task.Title)
+		return true
+	}
 	return false
 }
 
@@ -6,6 +6,7 @@ package workflow
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ariel-frischer/autospec/internal/validation"
@@ -348,6 +349,73 @@ func TestTaskExecutor_FindTaskStartIndex(t *testing.T) {
 	}
 }
 
+// TestTaskExecutor_TaskCheckpoint tests that the current task is checkpointed
+// and picked up by implement --resume.
+func TestTaskExecutor_TaskCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		checkpoint string
+		wantResume string
+	}{
+		"resumes from checkpointed task": {
+			checkpoint: "T002",
+			wantResume: "T002",
+		},
+		"ignores task missing from tasks.yaml": {
+			checkpoint: "T999",
+			wantResume: "",
+		},
+		"no checkpoint": {
+			wantResume: "",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specsDir := t.TempDir()
+			stateDir := t.TempDir()
+			specDir := filepath.Join(specsDir, "001-demo")
+			if err := os.MkdirAll(specDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			tasksContent := `phases:
+  - number: 1
+    title: "Setup"
+    tasks:
+      - id: "T001"
+        title: "First"
+        status: "Completed"
+      - id: "T002"
+        title: "Second"
+        status: "InProgress"
+`
+			if err := os.WriteFile(filepath.Join(specDir, "tasks.yaml"), []byte(tasksContent), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			te := NewTaskExecutor(&Executor{StateDir: stateDir}, specsDir, false)
+			if tt.checkpoint != "" {
+				te.checkpointTask("001-demo", "T001", 2)
+				te.checkpointTaskComplete("001-demo", "T001")
+				te.checkpointTask("001-demo", tt.checkpoint, 2)
+			}
+
+			w := &WorkflowOrchestrator{Executor: &Executor{StateDir: stateDir}, SpecsDir: specsDir}
+			if got := w.checkpointedTask("001-demo"); got != tt.wantResume {
+				t.Errorf("checkpointedTask() = %q, want %q", got, tt.wantResume)
+			}
+
+			te.clearTaskCheckpoint("001-demo")
+			if got := w.checkpointedTask("001-demo"); got != "" {
+				t.Errorf("checkpointedTask() after clear = %q, want empty", got)
+			}
+		})
+	}
+}
+
 // TestTaskExecutor_MethodSignatures verifies method signatures match interface.
 func TestTaskExecutor_MethodSignatures(t *testing.T) {
 	t.Parallel()
@@ -565,3 +633,61 @@ phases:
 		})
 	}
 }
+
+// TestTaskExecutor_PrintTasksSummary tests that the spec is only marked
+// completed when every task was run, not a selection (implement --only).
+func TestTaskExecutor_PrintTasksSummary(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		ranTasks   int
+		wantStatus string
+	}{
+		"all tasks ran":      {ranTasks: 2, wantStatus: "Completed"},
+		"selection of tasks": {ranTasks: 1, wantStatus: "Draft"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specDir := t.TempDir()
+			tasksContent := `_meta:
+  artifact_type: tasks
+  version: "1.0.0"
+phases:
+  - number: 1
+    title: "Phase 1"
+    tasks:
+      - id: "T001"
+        title: "Task 1"
+        status: "Completed"
+      - id: "T002"
+        title: "Task 2"
+        status: "Pending"
+`
+			specContent := `feature:
+  branch: "001-test"
+  status: "Draft"
+`
+			tasksPath := filepath.Join(specDir, "tasks.yaml")
+			if err := os.WriteFile(tasksPath, []byte(tasksContent), 0644); err != nil {
+				t.Fatalf("failed to write tasks.yaml: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(specDir, "spec.yaml"), []byte(specContent), 0644); err != nil {
+				t.Fatalf("failed to write spec.yaml: %v", err)
+			}
+
+			te := NewTaskExecutor(&Executor{}, filepath.Dir(specDir), false)
+			te.printTasksSummary(tasksPath, specDir, tt.ranTasks)
+
+			data, err := os.ReadFile(filepath.Join(specDir, "spec.yaml"))
+			if err != nil {
+				t.Fatalf("failed to read spec.yaml: %v", err)
+			}
+			if !strings.Contains(string(data), tt.wantStatus) {
+				t.Errorf("spec.yaml = %s, want status %s", data, tt.wantStatus)
+			}
+		})
+	}
+}
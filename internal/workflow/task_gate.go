@@ -0,0 +1,27 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+// runTaskGate runs the validation.task_gate commands once a task has been
+// marked Completed. On the first failing command the task is reset to
+// InProgress, so the retried session picks it up again, and the command's
+// output is returned as the validation error fed into the retry prompt.
+func (te *TaskExecutor) runTaskGate(specName, specDir, taskID string) error {
+	for _, command := range te.taskGate {
+		hook := fmt.Sprintf("task gate for %s", taskID)
+		err := te.executor.runHook(hook, command, StageImplement, specName, specDir)
+		if err == nil {
+			continue
+		}
+		if resetErr := validation.SetTaskStatus(validation.GetTasksFilePath(specDir), taskID, "InProgress"); resetErr != nil {
+			return fmt.Errorf("%w\nresetting task %s status: %v", err, taskID, resetErr)
+		}
+		fmt.Fprintf(te.executor.output(), "⚠ Task %s gate failed (%s); status reset to InProgress\n", taskID, command)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Package workflow tests the per-task build/lint gate.
+// Related: internal/workflow/task_gate.go
+// Tags: workflow, task-executor, validation, gate
+
+package workflow
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskExecutor_RunTaskGate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		gate       []string
+		wantErr    string
+		wantStatus string
+	}{
+		"no gate configured": {
+			wantStatus: "Completed",
+		},
+		"passing commands": {
+			gate:       []string{"true", `test "$AUTOSPEC_STAGE" = implement`},
+			wantStatus: "Completed",
+		},
+		"failing command resets task with its output": {
+			gate:       []string{"true", "echo 'main.go:3: undefined: foo' >&2; exit 1", "echo should-not-run >&2; exit 1"},
+			wantErr:    "- main.go:3: undefined: foo",
+			wantStatus: "InProgress",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specDir := t.TempDir()
+			tasksPath := filepath.Join(specDir, "tasks.yaml")
+			content := "phases:\n  - number: 1\n    title: Setup\n    tasks:\n      - id: T001\n        title: Task\n        status: Completed\n"
+			require.NoError(t, os.WriteFile(tasksPath, []byte(content), 0o644))
+
+			var out bytes.Buffer
+			te := NewTaskExecutor(&Executor{Output: &out}, filepath.Dir(specDir), false)
+			te.taskGate = tt.gate
+
+			err := te.runTaskGate(filepath.Base(specDir), specDir, "T001")
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "task gate for T001 failed")
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.NotContains(t, err.Error(), "should-not-run")
+				assert.Contains(t, out.String(), "status reset to InProgress")
+			}
+
+			tasks, err := validation.GetAllTasks(tasksPath)
+			require.NoError(t, err)
+			require.Len(t, tasks, 1)
+			assert.Equal(t, tt.wantStatus, tasks[0].Status)
+		})
+	}
+}
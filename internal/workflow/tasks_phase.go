@@ -0,0 +1,96 @@
+// Package workflow regenerates the tasks of a single phase of tasks.yaml.
+// Related: internal/validation/tasks_merge.go, internal/cli/stages/tasks.go
+// Tags: workflow, tasks, phases, regenerate
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/atomicfile"
+	"github.com/ariel-frischer/autospec/internal/filelock"
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+// PhaseRegenerateGuidance returns the prompt for re-breaking down one phase of
+// the plan into tasks, followed by the user's own prompt if any.
+func PhaseRegenerateGuidance(phase int, prompt string) string {
+	guidance := fmt.Sprintf("Regenerate only phase %d of the existing tasks.yaml from plan.yaml: rewrite "+
+		"the tasks of that phase and leave every other phase unchanged. Keep task IDs unique across "+
+		"the file by reusing this phase's IDs or continuing after the highest existing ID, and keep "+
+		"completed tasks of this phase that still apply.", phase)
+	if prompt == "" {
+		return guidance
+	}
+	return guidance + " " + prompt
+}
+
+// ExecuteTasksPhase asks the agent to regenerate the tasks of one phase and
+// merges that phase into the existing tasks.yaml. Every other phase is kept as
+// it was before the run, so completed tasks elsewhere are never lost. If the
+// merged file can't be built or fails validation, the original is restored.
+func (w *WorkflowOrchestrator) ExecuteTasksPhase(specNameArg string, prompt string, phase int) error {
+	specName, err := w.resolveSpecName(specNameArg)
+	if err != nil {
+		return fmt.Errorf("resolving spec name: %w", err)
+	}
+	specDir := filepath.Join(w.SpecsDir, specName)
+	tasksPath := filepath.Join(specDir, "tasks.yaml")
+
+	original, err := os.ReadFile(tasksPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("tasks.yaml not found: %s\nRun 'autospec tasks' first to generate tasks", tasksPath)
+		}
+		return fmt.Errorf("reading tasks.yaml: %w", err)
+	}
+	if !validation.HasTasksPhase(original, phase) {
+		return fmt.Errorf("phase %d not found in %s", phase, tasksPath)
+	}
+
+	if err := w.handleSpecDrift(specName, StagePlan); err != nil {
+		return err
+	}
+
+	fmt.Printf("Executing: /autospec.tasks (regenerating phase %d)\n", phase)
+	if err := w.stageExecutor.ExecuteTasks(specName, PhaseRegenerateGuidance(phase, prompt)); err != nil {
+		return fmt.Errorf("executing tasks stage: %w", err)
+	}
+
+	if err := mergeTasksPhase(tasksPath, original, phase); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Regenerated phase %d of specs/%s/tasks.yaml (schema valid)\n\n", phase, specName)
+	fmt.Println("Next: autospec implement")
+	return nil
+}
+
+// mergeTasksPhase combines the agent's version of phase with the other
+// phases of original and writes the result to tasksPath, restoring original
+// if that fails.
+func mergeTasksPhase(tasksPath string, original []byte, phase int) error {
+	return filelock.With(tasksPath, func() error {
+		regenerated, err := os.ReadFile(tasksPath)
+		if err != nil {
+			return fmt.Errorf("reading regenerated tasks.yaml: %w", err)
+		}
+
+		merged, err := validation.MergeTasksPhase(original, regenerated, phase)
+		if err == nil {
+			err = atomicfile.WriteFile(tasksPath, merged, 0644)
+		}
+		if err == nil {
+			err = ValidateTasksSchema(filepath.Dir(tasksPath))
+		}
+		if err == nil {
+			return nil
+		}
+
+		if restoreErr := atomicfile.WriteFile(tasksPath, original, 0644); restoreErr != nil {
+			return fmt.Errorf("merging regenerated phase %d: %w (restoring tasks.yaml also failed: %v)", phase, err, restoreErr)
+		}
+		return fmt.Errorf("merging regenerated phase %d: %w (tasks.yaml was restored)", phase, err)
+	})
+}
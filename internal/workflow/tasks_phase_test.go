@@ -0,0 +1,130 @@
+// Package workflow tests regenerating the tasks of a single phase.
+// Related: internal/workflow/tasks_phase.go, internal/validation/tasks_merge.go
+// Tags: workflow, tasks, phases, regenerate
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/ariel-frischer/autospec/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rewritingStageExecutor writes a fixed tasks.yaml when the tasks stage runs,
+// like an agent regenerating the file.
+type rewritingStageExecutor struct {
+	*MockStageExecutor
+	tasksPath string
+	content   string
+}
+
+func (r *rewritingStageExecutor) ExecuteTasks(specNameArg string, prompt string) error {
+	if err := r.MockStageExecutor.ExecuteTasks(specNameArg, prompt); err != nil {
+		return err
+	}
+	return os.WriteFile(r.tasksPath, []byte(r.content), 0o644)
+}
+
+func TestExecuteTasksPhase(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := os.ReadFile(filepath.Join("testdata", "tasks", "valid", "tasks.yaml"))
+	require.NoError(t, err)
+	// T001 was completed before the run
+	original := strings.Replace(string(fixture), `status: "Pending"`, `status: "Completed"`, 1)
+	// The agent resets T001 and adds T007 to phase 3
+	newTask := `          - "PUT /api/v1/auth/reset-password validates token"
+      - id: "T007"
+        title: "Rate limit reset requests"
+        status: "Pending"
+        type: "implementation"
+        parallel: false
+        story_id: "US-002"
+        file_path: "internal/auth/limit.go"
+        dependencies: ["T006"]
+        acceptance_criteria:
+          - "At most 3 reset emails per hour"
+`
+	regenerated := strings.Replace(string(fixture), `          - "PUT /api/v1/auth/reset-password validates token"
+`, newTask, 1)
+
+	tests := map[string]struct {
+		phase        int
+		regenerated  string
+		wantErr      string
+		wantCalls    int
+		wantPhase3   []string
+		wantT001     string
+		wantOriginal bool
+	}{
+		"merges the phase and keeps the others": {
+			phase:       3,
+			regenerated: regenerated,
+			wantCalls:   1,
+			wantPhase3:  []string{"T005", "T006", "T007"},
+			wantT001:    "Completed",
+		},
+		"unknown phase": {
+			phase:        9,
+			regenerated:  regenerated,
+			wantErr:      "phase 9 not found",
+			wantOriginal: true,
+		},
+		"reused task ID restores tasks.yaml": {
+			phase:        3,
+			regenerated:  strings.Replace(regenerated, `id: "T007"`, `id: "T001"`, 1),
+			wantErr:      "reuses task IDs of other phases: T001",
+			wantCalls:    1,
+			wantOriginal: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specsDir := t.TempDir()
+			specDir := filepath.Join(specsDir, "001-example-feature")
+			require.NoError(t, os.MkdirAll(specDir, 0o755))
+			tasksPath := filepath.Join(specDir, "tasks.yaml")
+			require.NoError(t, os.WriteFile(tasksPath, []byte(original), 0o644))
+
+			stage := &rewritingStageExecutor{MockStageExecutor: NewMockStageExecutor(), tasksPath: tasksPath, content: tt.regenerated}
+			orch := NewWorkflowOrchestratorWithExecutors(&config.Configuration{
+				SpecsDir: specsDir,
+				StateDir: t.TempDir(),
+			}, ExecutorOptions{StageExecutor: stage})
+
+			err := orch.ExecuteTasksPhase("001-example-feature", "keep it small", tt.phase)
+			require.Len(t, stage.TasksCalls, tt.wantCalls)
+			if tt.wantCalls > 0 {
+				assert.Contains(t, stage.TasksCalls[0].Prompt, "Regenerate only phase 3")
+				assert.Contains(t, stage.TasksCalls[0].Prompt, "keep it small")
+			}
+			if tt.wantOriginal {
+				assert.ErrorContains(t, err, tt.wantErr)
+				content, readErr := os.ReadFile(tasksPath)
+				require.NoError(t, readErr)
+				assert.Equal(t, original, string(content))
+				return
+			}
+			require.NoError(t, err)
+
+			doc, err := validation.ParseTasksYAML(tasksPath)
+			require.NoError(t, err)
+			var phase3 []string
+			for _, task := range doc.Phases[2].Tasks {
+				phase3 = append(phase3, task.ID)
+			}
+			assert.Equal(t, tt.wantPhase3, phase3)
+			assert.Equal(t, tt.wantT001, doc.Phases[0].Tasks[0].Status)
+			assert.Equal(t, 7, doc.Summary.TotalTasks)
+		})
+	}
+}
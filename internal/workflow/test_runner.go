@@ -0,0 +1,172 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultTestTimeout bounds a validation.tests run when no timeout is set.
+const defaultTestTimeout = 10 * time.Minute
+
+// TestRunner runs the project's test suite after implement sessions
+// (validation.tests in config). The zero value is disabled.
+type TestRunner struct {
+	Command       string        // Shell command, e.g. "go test ./..." (empty = disabled)
+	Timeout       time.Duration // Upper bound on a run (0 = 10m)
+	PassThreshold int           // Percent of tests that must pass (0 = 100)
+}
+
+// Enabled reports whether a test command is configured.
+func (r TestRunner) Enabled() bool {
+	return strings.TrimSpace(r.Command) != ""
+}
+
+// TestCounts holds pass/fail counts parsed from test runner output.
+type TestCounts struct {
+	Passed int
+	Failed int
+}
+
+// Total returns the number of tests that ran.
+func (c TestCounts) Total() int {
+	return c.Passed + c.Failed
+}
+
+// PassRate returns the percentage of tests that passed.
+func (c TestCounts) PassRate() int {
+	if c.Total() == 0 {
+		return 0
+	}
+	return c.Passed * 100 / c.Total()
+}
+
+var (
+	// summaryCountPattern matches summary counts like "3 failed, 10 passed"
+	// (pytest, jest) or "10 passed; 3 failed" (cargo).
+	summaryCountPattern = regexp.MustCompile(`(\d+) (passed|failed)`)
+	// goTestResultPattern matches go test -v result lines.
+	goTestResultPattern = regexp.MustCompile(`^\s*--- (PASS|FAIL): `)
+)
+
+// ParseTestCounts extracts pass/fail counts from test output. Summary lines
+// from pytest, jest and cargo are preferred; otherwise go test -v result
+// lines are counted. ok is false when no counts were found.
+func ParseTestCounts(output string) (counts TestCounts, ok bool) {
+	var goCounts TestCounts
+	for _, line := range strings.Split(output, "\n") {
+		if m := goTestResultPattern.FindStringSubmatch(line); m != nil {
+			if m[1] == "PASS" {
+				goCounts.Passed++
+			} else {
+				goCounts.Failed++
+			}
+			continue
+		}
+		// jest also prints per-suite counts, which would count tests twice
+		if strings.HasPrefix(strings.TrimSpace(line), "Test Suites:") {
+			continue
+		}
+		for _, m := range summaryCountPattern.FindAllStringSubmatch(line, -1) {
+			n, _ := strconv.Atoi(m[1])
+			if m[2] == "passed" {
+				counts.Passed += n
+			} else {
+				counts.Failed += n
+			}
+			ok = true
+		}
+	}
+	if ok {
+		return counts, true
+	}
+	return goCounts, goCounts.Total() > 0
+}
+
+// runTests runs the configured test suite for an implement session. The run
+// passes when the command exits 0, or when PassThreshold is below 100 and the
+// pass rate parsed from the output meets it. A failure returns an error whose
+// last output lines are "- " bullets, so they are fed into the retry prompt.
+func (e *Executor) runTests(specName, specDir string) error {
+	if !e.Tests.Enabled() {
+		return nil
+	}
+	command := strings.TrimSpace(e.Tests.Command)
+	timeout := e.Tests.Timeout
+	if timeout <= 0 {
+		timeout = defaultTestTimeout
+	}
+	threshold := e.Tests.PassThreshold
+	if threshold <= 0 {
+		threshold = 100
+	}
+	specName, specDir = e.resolveHookSpec(specName, specDir)
+	fmt.Fprintf(e.output(), "Running tests: %s\n", command)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = append(os.Environ(),
+		"AUTOSPEC_STAGE="+string(StageImplement),
+		"AUTOSPEC_SPEC_NAME="+specName,
+		"AUTOSPEC_SPEC_DIR="+specDir,
+	)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return formatTestFailure(command, fmt.Errorf("timed out after %s", timeout), output.String())
+	}
+	if err == nil {
+		return nil
+	}
+
+	counts, ok := ParseTestCounts(output.String())
+	if !ok {
+		return formatTestFailure(command, err, output.String())
+	}
+	if threshold < 100 && counts.PassRate() >= threshold {
+		fmt.Fprintf(e.output(), "⚠ %d/%d tests passed (%d%%), meeting pass_threshold %d%%\n",
+			counts.Passed, counts.Total(), counts.PassRate(), threshold)
+		return nil
+	}
+	return formatTestFailure(command,
+		fmt.Errorf("%w; %d/%d tests passed (%d%%, pass_threshold %d%%)",
+			err, counts.Passed, counts.Total(), counts.PassRate(), threshold),
+		output.String())
+}
+
+// formatTestFailure builds the validation error for a failed test run, with
+// one bullet per non-empty line from the end of the output, where runners
+// print failures and summaries.
+func formatTestFailure(command string, runErr error, output string) error {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "tests failed (%s): %v:\n", command, runErr)
+	if len(lines) > maxHookErrorLines {
+		sb.WriteString("- (earlier output truncated)\n")
+		lines = lines[len(lines)-maxHookErrorLines:]
+	}
+	for _, line := range lines {
+		fmt.Fprintf(&sb, "- %s\n", strings.TrimPrefix(line, "- "))
+	}
+	if len(lines) == 0 {
+		fmt.Fprintf(&sb, "- test command `%s` failed: %v\n", command, runErr)
+	}
+	return errors.New(sb.String())
+}
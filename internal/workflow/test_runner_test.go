@@ -0,0 +1,172 @@
+// Package workflow tests the validation.tests run after implement sessions.
+// Related: internal/workflow/test_runner.go
+// Tags: workflow, validation, tests, retry
+
+package workflow
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTestCounts(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		output string
+		want   TestCounts
+		wantOK bool
+	}{
+		"no counts": {
+			output: "ok  \tgithub.com/x/y\t0.1s\n",
+		},
+		"go test -v": {
+			output: "=== RUN   TestA\n--- PASS: TestA (0.00s)\n=== RUN   TestB\n--- FAIL: TestB (0.00s)\n    --- PASS: TestB/sub (0.00s)\nFAIL\n",
+			want:   TestCounts{Passed: 2, Failed: 1},
+			wantOK: true,
+		},
+		"pytest": {
+			output: "FAILED tests/test_auth.py::test_login\n===== 1 failed, 9 passed in 0.52s =====\n",
+			want:   TestCounts{Passed: 9, Failed: 1},
+			wantOK: true,
+		},
+		"jest ignores suite counts": {
+			output: "Test Suites: 1 failed, 3 passed, 4 total\nTests:       2 failed, 18 passed, 20 total\n",
+			want:   TestCounts{Passed: 18, Failed: 2},
+			wantOK: true,
+		},
+		"cargo sums crates": {
+			output: "test result: ok. 4 passed; 0 failed; 0 ignored\ntest result: FAILED. 5 passed; 1 failed; 0 ignored\n",
+			want:   TestCounts{Passed: 9, Failed: 1},
+			wantOK: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := ParseTestCounts(tt.output)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunTests(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		runner     TestRunner
+		wantErr    string
+		wantErrors []string
+	}{
+		"disabled": {},
+		"passing suite": {
+			runner: TestRunner{Command: "echo ok"},
+		},
+		"suite sees spec environment": {
+			runner: TestRunner{Command: `test "$AUTOSPEC_STAGE" = implement && test "$AUTOSPEC_SPEC_NAME" = 001-auth`},
+		},
+		"failing suite feeds output tail": {
+			runner:     TestRunner{Command: "echo '--- FAIL: TestLogin (0.00s)'; echo '    auth_test.go:12: want 200, got 500'; exit 1"},
+			wantErr:    "tests failed (",
+			wantErrors: []string{"--- FAIL: TestLogin (0.00s)", "auth_test.go:12: want 200, got 500"},
+		},
+		"failing suite below threshold": {
+			runner:  TestRunner{Command: "echo '3 failed, 7 passed'; exit 1", PassThreshold: 80},
+			wantErr: "7/10 tests passed (70%, pass_threshold 80%)",
+		},
+		"failing suite meeting threshold": {
+			runner: TestRunner{Command: "echo '1 failed, 9 passed'; exit 1", PassThreshold: 90},
+		},
+		"threshold needs parsable counts": {
+			runner:     TestRunner{Command: "exit 2", PassThreshold: 50},
+			wantErr:    "exit status 2",
+			wantErrors: []string{"test command `exit 2` failed: exit status 2"},
+		},
+		"timeout": {
+			runner:  TestRunner{Command: "sleep 5", Timeout: 50 * time.Millisecond},
+			wantErr: "timed out after 50ms",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			e := &Executor{Tests: tt.runner, Output: io.Discard}
+
+			err := e.runTests("001-auth", "specs/001-auth")
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			if tt.wantErrors != nil {
+				assert.Equal(t, tt.wantErrors, ExtractValidationErrors(err))
+			}
+		})
+	}
+}
+
+func TestFormatTestFailure_KeepsOutputTail(t *testing.T) {
+	t.Parallel()
+
+	var output strings.Builder
+	for i := 0; i < maxHookErrorLines+10; i++ {
+		output.WriteString("line\n")
+	}
+	output.WriteString("FAIL summary\n")
+
+	errs := ExtractValidationErrors(formatTestFailure("make test", io.EOF, output.String()))
+
+	require.Len(t, errs, maxHookErrorLines+1)
+	assert.Equal(t, "(earlier output truncated)", errs[0])
+	assert.Equal(t, "FAIL summary", errs[len(errs)-1])
+}
+
+func TestExecuteStage_TestsGateImplement(t *testing.T) {
+	t.Parallel()
+
+	marker := filepath.Join(t.TempDir(), "fixed")
+	mock := NewMockClaudeExecutor()
+	e := &Executor{
+		Claude:     mock,
+		StateDir:   t.TempDir(),
+		SpecsDir:   t.TempDir(),
+		MaxRetries: 1,
+		Output:     io.Discard,
+		// Fails until the first session has run, like a bug the agent then fixes
+		Tests: TestRunner{Command: "test -f " + marker + " || { touch " + marker + "; echo 'TestCheckout: got nil'; exit 1; }"},
+	}
+
+	result, err := e.ExecuteStage("001-auth", StageImplement, "/autospec.implement", func(string) error { return nil })
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	require.Len(t, mock.ExecuteCalls, 2)
+	assert.Contains(t, mock.ExecuteCalls[1], "TestCheckout: got nil")
+}
+
+func TestExecuteStage_TestsOnlyRunForImplement(t *testing.T) {
+	t.Parallel()
+
+	e := &Executor{
+		Claude:   NewMockClaudeExecutor(),
+		StateDir: t.TempDir(),
+		SpecsDir: t.TempDir(),
+		Output:   io.Discard,
+		Tests:    TestRunner{Command: "exit 1"},
+	}
+
+	result, err := e.ExecuteStage("001-auth", StagePlan, "/autospec.plan", func(string) error { return nil })
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+}
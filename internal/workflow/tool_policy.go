@@ -0,0 +1,43 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/policy"
+)
+
+// loadToolPolicy returns the rules PolicyFile sets for stage, or nil when
+// there is no policy. An unreadable or invalid policy fails the stage rather
+// than running the agent unrestricted.
+func (e *Executor) loadToolPolicy(stage Stage) (*cliagent.ToolPolicy, error) {
+	if e.PolicyFile == "" {
+		return nil, nil
+	}
+	p, err := policy.Load(e.PolicyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading tool policy: %w", err)
+	}
+	return p.ForStage(string(stage)), nil
+}
+
+// selectToolPolicy passes the stage's tool rules to the runner. Runners that
+// don't implement ToolPolicySelector are left untouched. A policy the agent
+// has no permission flags for is reported once, since it isn't enforced.
+func (e *Executor) selectToolPolicy(rules *cliagent.ToolPolicy) {
+	selector, ok := e.Claude.(ToolPolicySelector)
+	if !ok {
+		return
+	}
+	selector.SetToolPolicy(rules)
+
+	if rules == nil || e.policyWarned {
+		return
+	}
+	if c, ok := e.Claude.(*ClaudeExecutor); ok && c.agent() != nil {
+		if caps := c.agent().Capabilities(); caps.AllowToolsFlag == "" && caps.DenyToolsFlag == "" {
+			fmt.Fprintf(e.output(), "Warning: agent %s does not support permission flags; %s is not enforced\n", c.agent().Name(), e.PolicyFile)
+			e.policyWarned = true
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Package workflow records agent transcripts for later inspection.
+// Related: internal/session/session.go, internal/cli/util/sessions.go
+// Tags: workflow, sessions, transcripts, debugging
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/ariel-frischer/autospec/internal/session"
+)
+
+var (
+	phaseArgPattern = regexp.MustCompile(`--phase (\d+)`)
+	taskArgPattern  = regexp.MustCompile(`--task (\S+)`)
+)
+
+// startTranscript begins capturing the agent output of a stage attempt.
+// Returns nil when capture is disabled or the runner cannot capture output.
+func (e *Executor) startTranscript(ctx *stageExecutionContext) *session.Recorder {
+	if e.MaxSessions <= 0 || e.StateDir == "" {
+		return nil
+	}
+	capturer, ok := e.Claude.(TranscriptCapturer)
+	if !ok {
+		return nil
+	}
+
+	s := &session.Session{
+		Spec:    ctx.specName,
+		Stage:   string(ctx.stage),
+		Attempt: ctx.retryState.Count + 1,
+		Command: e.Claude.FormatCommand(ctx.currentCommand),
+		Prompt:  ctx.currentCommand,
+	}
+	if m := phaseArgPattern.FindStringSubmatch(ctx.command); m != nil {
+		s.Phase, _ = strconv.Atoi(m[1])
+	}
+	if m := taskArgPattern.FindStringSubmatch(ctx.command); m != nil {
+		s.TaskID = m[1]
+	}
+
+	rec := session.NewRecorder(s)
+	if err := rec.StartLive(e.StateDir); err != nil {
+		e.debugLog("Live log unavailable: %v", err)
+	}
+	capturer.CaptureTranscript(rec)
+	return rec
+}
+
+// saveTranscript stops capturing and persists the transcript under
+// StateDir/sessions. Failures are reported as warnings and never fail the stage.
+func (e *Executor) saveTranscript(rec *session.Recorder, execErr error) {
+	if rec == nil {
+		return
+	}
+	if capturer, ok := e.Claude.(TranscriptCapturer); ok {
+		capturer.CaptureTranscript(nil)
+	}
+
+	s := rec.Finish(execErr)
+	if err := session.Save(e.StateDir, s, e.MaxSessions); err != nil {
+		fmt.Printf("Warning: failed to save session transcript: %v\n", err)
+		return
+	}
+	e.debugLog("Saved session transcript %s", s.ID)
+	if execErr != nil {
+		fmt.Printf("Session transcript: autospec sessions show %s\n", s.ID)
+	}
+}
@@ -0,0 +1,121 @@
+// Package workflow tests agent transcript capture for stage attempts.
+// Related: internal/workflow/transcript.go, internal/session/session.go
+// Tags: workflow, sessions, transcripts, debugging
+
+package workflow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteStage_CapturesTranscripts(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		script       string
+		command      string
+		maxSessions  int
+		validateErr  error
+		wantSessions int
+		wantExitCode int
+		wantStdout   string
+		wantStderr   string
+		wantPhase    int
+		wantTaskID   string
+	}{
+		"successful run": {
+			script:       `echo planning; echo careful >&2`,
+			command:      "/autospec.plan",
+			maxSessions:  10,
+			wantSessions: 1,
+			wantStdout:   "planning\n",
+			wantStderr:   "careful\n",
+		},
+		"failing agent": {
+			script:       `echo partial; echo boom >&2; exit 3`,
+			command:      "/autospec.implement --phase 2 --context-file ctx.yaml",
+			maxSessions:  10,
+			wantSessions: 1,
+			wantExitCode: 3,
+			wantStdout:   "partial\n",
+			wantStderr:   "boom\n",
+			wantPhase:    2,
+		},
+		"every retry attempt is kept": {
+			script:       `echo try`,
+			command:      "/autospec.implement --task T003",
+			maxSessions:  10,
+			validateErr:  errors.New("tasks incomplete"),
+			wantSessions: 2,
+			wantStdout:   "try\n",
+			wantTaskID:   "T003",
+		},
+		"capture disabled": {
+			script: `echo quiet`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			stateDir := t.TempDir()
+			executor := &Executor{
+				Claude:      shellClaude(t, tt.script),
+				StateDir:    stateDir,
+				SpecsDir:    t.TempDir(),
+				MaxRetries:  1,
+				MaxSessions: tt.maxSessions,
+			}
+
+			_, _ = executor.ExecuteStage("001-test", StageImplement, tt.command, func(string) error {
+				return tt.validateErr
+			})
+
+			sessions, err := session.List(stateDir)
+			require.NoError(t, err)
+			require.Len(t, sessions, tt.wantSessions)
+			for i, s := range sessions {
+				assert.Equal(t, "001-test", s.Spec)
+				assert.Equal(t, "implement", s.Stage)
+				assert.Equal(t, i+1, s.Attempt)
+				assert.Equal(t, tt.wantExitCode, s.ExitCode)
+				assert.Equal(t, tt.wantExitCode != 0, s.Error != "")
+				assert.Equal(t, tt.wantStdout, s.Text(session.StreamStdout))
+				assert.Equal(t, tt.wantStderr, s.Text(session.StreamStderr))
+				assert.Equal(t, tt.wantPhase, s.Phase)
+				assert.Equal(t, tt.wantTaskID, s.TaskID)
+				assert.Contains(t, s.Prompt, tt.command)
+				assert.Contains(t, s.Command, "sh -c")
+				assert.NotEmpty(t, s.Duration)
+			}
+			if len(sessions) == 2 {
+				assert.Contains(t, sessions[1].Prompt, "tasks incomplete", "retry prompt includes validation errors")
+			}
+		})
+	}
+}
+
+func TestExecuteStage_TranscriptNeedsCapturer(t *testing.T) {
+	t.Parallel()
+
+	stateDir := t.TempDir()
+	executor := &Executor{
+		Claude:      &mockClaudeExecutor{},
+		StateDir:    stateDir,
+		SpecsDir:    t.TempDir(),
+		MaxSessions: 10,
+	}
+
+	_, err := executor.ExecuteStage("001-test", StagePlan, "/autospec.plan", func(string) error { return nil })
+	require.NoError(t, err)
+
+	sessions, err := session.List(stateDir)
+	require.NoError(t, err)
+	assert.Empty(t, sessions, "runners without TranscriptCapturer record nothing")
+}
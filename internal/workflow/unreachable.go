@@ -0,0 +1,78 @@
+package workflow
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/progress"
+	"github.com/ariel-frischer/autospec/internal/queue"
+	"golang.org/x/term"
+)
+
+// handleUnreachable fails the stage without using up a retry when err is an
+// *AgentUnreachableError, since retrying can't help until the agent is back.
+// Depending on OnUnreachable, the stage is first deferred to the queue for
+// 'autospec queue retry-pending'. Reports whether err was handled.
+func (e *Executor) handleUnreachable(ctx *stageExecutionContext, stageInfo progress.StageInfo, err error) bool {
+	var unreachable *AgentUnreachableError
+	if !errors.As(err, &unreachable) {
+		return false
+	}
+
+	ctx.result.Error = fmt.Errorf("command execution failed: %w", err)
+	if e.shouldQueueUnreachable(ctx, unreachable) {
+		item, qErr := queue.Defer(e.StateDir, ctx.specName, []string{string(ctx.stage)}, unreachable.Error(), time.Now())
+		if qErr != nil {
+			fmt.Fprintf(e.output(), "Warning: failed to queue %s: %v\n", ctx.stage, qErr)
+		} else {
+			ctx.result.Error = fmt.Errorf("%w; queued as #%d, run 'autospec queue retry-pending' once the agent is back", err, item.ID)
+		}
+	}
+
+	e.failStageProgress(stageInfo, ctx.result.Error)
+	e.sendErrorNotification(stageInfo.Name, ctx.result.Error)
+	return true
+}
+
+// shouldQueueUnreachable decides whether to defer the stage: always with
+// on_unreachable "queue", after asking with "ask". Stages that don't operate
+// on an existing spec can't be queued.
+func (e *Executor) shouldQueueUnreachable(ctx *stageExecutionContext, unreachable *AgentUnreachableError) bool {
+	if !slices.Contains(queue.QueueableStages, string(ctx.stage)) {
+		return false
+	}
+	switch e.OnUnreachable {
+	case "queue":
+		return true
+	case "ask":
+		question := fmt.Sprintf("⚠️  %v\n   Queue %s for %s to run later with 'autospec queue retry-pending'? [y/N] ",
+			unreachable, ctx.stage, ctx.specName)
+		return e.ask(question)
+	default:
+		return false
+	}
+}
+
+// ask asks a yes/no question, defaulting to no when stdin is not a terminal.
+func (e *Executor) ask(question string) bool {
+	if e.confirm != nil {
+		return e.confirm(question)
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false
+	}
+	e.suspendLiveOutput()
+	defer e.resumeLiveOutput()
+	fmt.Fprint(os.Stderr, question)
+	response, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	response = strings.ToLower(strings.TrimSpace(response))
+	return response == "y" || response == "yes"
+}
@@ -0,0 +1,109 @@
+// Package workflow tests deferring stages whose agent is unreachable.
+// Related: internal/workflow/unreachable.go, internal/queue/queue.go
+// Tags: workflow, queue, offline, retry
+package workflow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/queue"
+	"github.com/ariel-frischer/autospec/internal/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteStage_AgentUnreachable(t *testing.T) {
+	t.Parallel()
+
+	unreachable := &AgentUnreachableError{Agent: "claude", Reason: "network", Message: "getaddrinfo ENOTFOUND api.anthropic.com"}
+
+	tests := map[string]struct {
+		onUnreachable string
+		answer        bool
+		stage         Stage
+		wantQueued    bool
+		wantAsked     bool
+	}{
+		"queue defers the stage": {
+			onUnreachable: "queue",
+			stage:         StagePlan,
+			wantQueued:    true,
+		},
+		"ask queues when confirmed": {
+			onUnreachable: "ask",
+			answer:        true,
+			stage:         StagePlan,
+			wantQueued:    true,
+			wantAsked:     true,
+		},
+		"ask fails when declined": {
+			onUnreachable: "ask",
+			stage:         StagePlan,
+			wantAsked:     true,
+		},
+		"fail does not queue": {
+			onUnreachable: "fail",
+			stage:         StagePlan,
+		},
+		"specify cannot be queued": {
+			onUnreachable: "queue",
+			stage:         StageSpecify,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			asked := false
+			executor := &Executor{
+				Claude:        &mockClaudeExecutor{executeErr: unreachable},
+				StateDir:      t.TempDir(),
+				SpecsDir:      t.TempDir(),
+				MaxRetries:    3,
+				OnUnreachable: tt.onUnreachable,
+				confirm: func(string) bool {
+					asked = true
+					return tt.answer
+				},
+			}
+
+			result, err := executor.ExecuteStage("001-test", tt.stage, "/autospec."+string(tt.stage), func(string) error { return nil })
+
+			require.Error(t, err)
+			var unreachableErr *AgentUnreachableError
+			assert.True(t, errors.As(err, &unreachableErr))
+			assert.Equal(t, tt.wantAsked, asked)
+
+			state, loadErr := retry.LoadRetryState(executor.StateDir, "001-test", string(tt.stage), 3)
+			require.NoError(t, loadErr)
+			assert.Zero(t, state.Count, "an unreachable agent does not use up a retry")
+			assert.Zero(t, result.RetryCount)
+
+			items, listErr := queue.List(executor.StateDir)
+			require.NoError(t, listErr)
+			if !tt.wantQueued {
+				assert.Empty(t, items)
+				return
+			}
+			require.Len(t, items, 1)
+			assert.Equal(t, queue.StatusDeferred, items[0].Status)
+			assert.Equal(t, []string{string(tt.stage)}, items[0].Stages)
+			assert.Contains(t, items[0].Error, "could not reach its provider")
+			assert.Contains(t, err.Error(), "queued as #1, run 'autospec queue retry-pending'")
+		})
+	}
+}
+
+func TestClaudeExecutor_AgentUnreachableError(t *testing.T) {
+	t.Parallel()
+
+	claude := shellClaude(t, `echo "Invalid API key · Please run /login" >&2; exit 1`)
+	err := claude.Execute("/autospec.plan")
+
+	var unreachable *AgentUnreachableError
+	require.True(t, errors.As(err, &unreachable), "got %v", err)
+	assert.Equal(t, "auth", unreachable.Reason)
+	assert.Contains(t, err.Error(), "agent custom was not authenticated: Invalid API key")
+}
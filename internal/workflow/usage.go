@@ -0,0 +1,68 @@
+package workflow
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/ariel-frischer/autospec/internal/spec"
+)
+
+// recordUsage persists the token usage of the last agent run for a stage,
+// along with how long the session ran (0 for sessions that failed, which
+// would skew duration estimates).
+// It is a no-op when no recorder is configured or the agent reported no usage.
+// Failures only warn: usage tracking must never fail a stage.
+func (e *Executor) recordUsage(specName string, stage Stage, elapsed time.Duration) {
+	if e.UsageRecorder == nil {
+		return
+	}
+	reporter, ok := e.Claude.(UsageReporter)
+	if !ok {
+		return
+	}
+	usage := reporter.LastUsage()
+	if usage == nil {
+		return
+	}
+
+	record := history.PhaseUsage{
+		Spec:                e.usageSpecName(specName),
+		Phase:               string(stage),
+		Agent:               e.agentName(),
+		InputTokens:         usage.InputTokens,
+		OutputTokens:        usage.OutputTokens,
+		CacheCreationTokens: usage.CacheCreationTokens,
+		CacheReadTokens:     usage.CacheReadTokens,
+		CostUSD:             usage.CostUSD,
+	}
+	if elapsed > 0 {
+		record.Duration = elapsed.Round(time.Millisecond).String()
+	}
+	if err := e.UsageRecorder.RecordUsage(record); err != nil {
+		fmt.Printf("Warning: failed to record token usage: %v\n", err)
+	}
+	e.debugLog("Recorded usage for %s: %d tokens, $%.4f", stage, usage.TotalTokens(), usage.CostUSD)
+}
+
+// agentName returns the name of the agent the runner executes, or empty for
+// runners that are not backed by a cliagent.Agent (e.g., test doubles).
+func (e *Executor) agentName() string {
+	if c, ok := e.Claude.(*ClaudeExecutor); ok && c.agent() != nil {
+		return c.agent().Name()
+	}
+	return ""
+}
+
+// usageSpecName returns specName, or for specify (which runs before the spec
+// name is known) the spec the agent just created, detected best-effort.
+func (e *Executor) usageSpecName(specName string) string {
+	if specName != "" {
+		return specName
+	}
+	metadata, err := spec.DetectCurrentSpec(e.SpecsDir)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s-%s", metadata.Number, metadata.Name)
+}
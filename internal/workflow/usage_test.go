@@ -0,0 +1,119 @@
+// Package workflow tests per-phase token usage recording.
+// Related: internal/workflow/usage.go, internal/history/writer.go
+// Tags: workflow, usage, tokens, cost, history
+package workflow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/history"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// usageMockRunner is a ClaudeRunner that also reports token usage.
+type usageMockRunner struct {
+	mockClaudeExecutor
+	usage *cliagent.Usage
+}
+
+func (m *usageMockRunner) LastUsage() *cliagent.Usage {
+	return m.usage
+}
+
+// mockUsageRecorder collects recorded usage.
+type mockUsageRecorder struct {
+	records []history.PhaseUsage
+	err     error
+}
+
+func (m *mockUsageRecorder) RecordUsage(usage history.PhaseUsage) error {
+	m.records = append(m.records, usage)
+	return m.err
+}
+
+func TestExecuteStage_RecordsUsage(t *testing.T) {
+	t.Parallel()
+
+	usage := &cliagent.Usage{InputTokens: 1200, OutputTokens: 300, CacheReadTokens: 50, CostUSD: 0.42}
+
+	tests := map[string]struct {
+		runner      ClaudeRunner
+		executeErr  error
+		recorderErr error
+		wantRecords []history.PhaseUsage
+		wantTimed   bool // Successful sessions record their duration
+		wantErr     bool
+	}{
+		"records usage after a successful run": {
+			runner: &usageMockRunner{usage: usage},
+			wantRecords: []history.PhaseUsage{{
+				Spec: "001-test", Phase: "plan",
+				InputTokens: 1200, OutputTokens: 300, CacheReadTokens: 50, CostUSD: 0.42,
+			}},
+			wantTimed: true,
+		},
+		"records usage even when the agent fails": {
+			runner: &usageMockRunner{
+				mockClaudeExecutor: mockClaudeExecutor{executeErr: errors.New("agent crashed")},
+				usage:              usage,
+			},
+			wantRecords: []history.PhaseUsage{{
+				Spec: "001-test", Phase: "plan",
+				InputTokens: 1200, OutputTokens: 300, CacheReadTokens: 50, CostUSD: 0.42,
+			}},
+			wantErr: true,
+		},
+		"records the agent name for real agents": {
+			runner: shellClaude(t, `echo '{"type":"result","total_cost_usd":0.1,"usage":{"input_tokens":10,"output_tokens":5}}'`),
+			wantRecords: []history.PhaseUsage{{
+				Spec: "001-test", Phase: "plan", Agent: "custom",
+				InputTokens: 10, OutputTokens: 5, CostUSD: 0.1,
+			}},
+			wantTimed: true,
+		},
+		"skips agents that report no usage": {
+			runner: &usageMockRunner{},
+		},
+		"skips runners without usage reporting": {
+			runner: &mockClaudeExecutor{},
+		},
+		"recorder failure does not fail the stage": {
+			runner:      &usageMockRunner{usage: usage},
+			recorderErr: errors.New("disk full"),
+			wantRecords: []history.PhaseUsage{{
+				Spec: "001-test", Phase: "plan",
+				InputTokens: 1200, OutputTokens: 300, CacheReadTokens: 50, CostUSD: 0.42,
+			}},
+			wantTimed: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := &mockUsageRecorder{err: tt.recorderErr}
+			executor := &Executor{
+				Claude:        tt.runner,
+				StateDir:      t.TempDir(),
+				SpecsDir:      t.TempDir(),
+				UsageRecorder: recorder,
+			}
+
+			_, err := executor.ExecuteStage("001-test", StagePlan, "/autospec.plan", func(string) error { return nil })
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			for i := range recorder.records {
+				assert.Equal(t, tt.wantTimed, recorder.records[i].Duration != "", "duration recorded")
+				recorder.records[i].Duration = ""
+			}
+			assert.Equal(t, tt.wantRecords, recorder.records)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Package workflow checks completed tasks' acceptance criteria against the code.
+// Related: internal/validation/tasks_verify.go, internal/cli/verify.go
+// Tags: workflow, verify, acceptance-criteria, tasks
+package workflow
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+// ExecuteVerify runs the verify stage and prints which completed tasks meet
+// their acceptance criteria. It fails if any criterion is unmet. In strict
+// mode every task must also be completed and verified, as checked by
+// ValidateTasksComplete.
+func (w *WorkflowOrchestrator) ExecuteVerify(specNameArg string, prompt string, strict bool) error {
+	specName, err := w.resolveSpecName(specNameArg)
+	if err != nil {
+		return fmt.Errorf("resolving spec name: %w", err)
+	}
+	if err := w.stageExecutor.ExecuteVerify(specName, prompt); err != nil {
+		return err
+	}
+
+	tasksPath := filepath.Join(w.SpecsDir, specName, "tasks.yaml")
+	summary, err := validation.GetVerificationSummary(tasksPath)
+	if err != nil {
+		return fmt.Errorf("reading verification results: %w", err)
+	}
+	printVerificationSummary(os.Stdout, specName, summary)
+
+	if strict {
+		return w.Executor.ValidateTasksComplete(tasksPath, true)
+	}
+	if len(summary.Failed) > 0 {
+		return fmt.Errorf("acceptance criteria not met for %d task(s): %s",
+			len(summary.Failed), strings.Join(summary.Failed, ", "))
+	}
+	return nil
+}
+
+// printVerificationSummary prints the verified task count and the unmet
+// criteria of each failed task.
+func printVerificationSummary(out io.Writer, specName string, summary *validation.VerificationSummary) {
+	fmt.Fprintf(out, "\n✓ Verified %d task(s) in specs/%s/tasks.yaml\n", len(summary.Verified), specName)
+	for _, id := range summary.Failed {
+		fmt.Fprintf(out, "✗ %s: acceptance criteria not met\n", id)
+		for _, criterion := range summary.Unmet[id] {
+			fmt.Fprintf(out, "    - %s\n", criterion)
+		}
+	}
+	if len(summary.Unverified) > 0 {
+		fmt.Fprintf(out, "⚠ Not verified: %s\n", strings.Join(summary.Unverified, ", "))
+	}
+}
@@ -0,0 +1,177 @@
+// Package workflow tests the verify stage and its acceptance criteria checks.
+// Related: internal/workflow/verify.go, internal/validation/tasks_verify.go
+// Tags: workflow, verify, acceptance-criteria, tasks
+
+package workflow
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// verifyTasksYAML returns a schema-valid tasks.yaml with a completed T001 and
+// a T002 with the given status, followed by each task's extra fields.
+func verifyTasksYAML(t002Status, t001Extra, t002Extra string) string {
+	return `tasks:
+  branch: "001-example-feature"
+  created: "2025-01-15"
+  spec_path: "specs/001-example-feature/spec.yaml"
+  plan_path: "specs/001-example-feature/plan.yaml"
+
+summary:
+  total_tasks: 2
+  total_phases: 1
+  parallel_opportunities: 0
+  estimated_complexity: "low"
+
+phases:
+  - number: 1
+    title: "Core"
+    purpose: "Add the parser"
+    tasks:
+      - id: "T001"
+        title: "Add parser"
+        status: "Completed"
+        type: "implementation"
+        parallel: false
+        dependencies: []
+        acceptance_criteria:
+          - "Parses YAML"` + t001Extra + `
+      - id: "T002"
+        title: "Add printer"
+        status: "` + t002Status + `"
+        type: "implementation"
+        parallel: false
+        dependencies: ["T001"]
+        acceptance_criteria:
+          - "Prints YAML"` + t002Extra + `
+
+_meta:
+  version: "1.0.0"
+  generator: "autospec"
+  generator_version: "1.0.0"
+  created: "2025-01-15T12:00:00Z"
+  artifact_type: "tasks"
+`
+}
+
+const (
+	verifiedBlock = `
+        verification:
+          status: "Verified"
+          notes: "Covered by parser_test.go"`
+	failedBlock = `
+        verification:
+          status: "Failed"
+          unmet:
+            - "Prints YAML"`
+)
+
+func TestValidateVerification(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		content string
+		wantErr string
+	}{
+		"every completed task recorded": {
+			content: verifyTasksYAML("Completed", verifiedBlock, failedBlock),
+		},
+		"pending tasks need no record": {
+			content: verifyTasksYAML("Pending", verifiedBlock, ""),
+		},
+		"completed task without record": {
+			content: verifyTasksYAML("Completed", verifiedBlock, ""),
+			wantErr: "no verification record for 1 completed task(s): T002",
+		},
+		"invalid verification status": {
+			content: verifyTasksYAML("Completed", verifiedBlock, "\n        verification:\n          status: \"Passed\""),
+			wantErr: "verification.status",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specDir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(specDir, "tasks.yaml"), []byte(tt.content), 0o644))
+
+			err := ValidateVerification(specDir)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestExecuteVerify(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		content    string
+		strict     bool
+		stageError error
+		wantErr    string
+	}{
+		"all criteria met": {
+			content: verifyTasksYAML("Completed", verifiedBlock, verifiedBlock),
+		},
+		"unmet criteria fail": {
+			content: verifyTasksYAML("Completed", verifiedBlock, failedBlock),
+			wantErr: "acceptance criteria not met for 1 task(s): T002",
+		},
+		"pending task passes without strict": {
+			content: verifyTasksYAML("Pending", verifiedBlock, ""),
+		},
+		"pending task fails with strict": {
+			content: verifyTasksYAML("Pending", verifiedBlock, ""),
+			strict:  true,
+			wantErr: "implementation incomplete",
+		},
+		"strict with all criteria met": {
+			content: verifyTasksYAML("Completed", verifiedBlock, verifiedBlock),
+			strict:  true,
+		},
+		"stage failure": {
+			content:    verifyTasksYAML("Completed", verifiedBlock, verifiedBlock),
+			stageError: errors.New("verify stage exhausted retries"),
+			wantErr:    "exhausted retries",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specsDir := t.TempDir()
+			specDir := filepath.Join(specsDir, "001-example-feature")
+			require.NoError(t, os.MkdirAll(specDir, 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(specDir, "tasks.yaml"), []byte(tt.content), 0o644))
+
+			stage := NewMockStageExecutor()
+			stage.VerifyError = tt.stageError
+			orch := NewWorkflowOrchestratorWithExecutors(&config.Configuration{
+				SpecsDir: specsDir,
+				StateDir: t.TempDir(),
+			}, ExecutorOptions{StageExecutor: stage})
+
+			err := orch.ExecuteVerify("001-example-feature", "check errors", tt.strict)
+			require.Len(t, stage.VerifyCalls, 1)
+			assert.Equal(t, VerifyCall{SpecName: "001-example-feature", Prompt: "check errors"}, stage.VerifyCalls[0])
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
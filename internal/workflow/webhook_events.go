@@ -0,0 +1,78 @@
+// Package workflow emits webhook events for phase, task and retry updates.
+// Related: internal/notify/webhook.go, internal/workflow/executor.go, internal/workflow/phase_executor.go
+// Tags: workflow, notifications, webhooks, events
+package workflow
+
+import (
+	"path/filepath"
+
+	"github.com/ariel-frischer/autospec/internal/notify"
+	"github.com/ariel-frischer/autospec/internal/validation"
+)
+
+// emitEvent sends a workflow event to the configured webhooks.
+// No-op when no webhooks are configured.
+func (e *Executor) emitEvent(event notify.Event) {
+	e.Webhooks.Send(event)
+}
+
+// snapshotTaskStatuses returns the current status of every task in the spec,
+// keyed by task ID. Returns nil when webhooks are off or tasks.yaml is unreadable.
+func (e *Executor) snapshotTaskStatuses(specName string) map[string]string {
+	if e.Webhooks == nil {
+		return nil
+	}
+	tasks, err := validation.GetAllTasks(e.tasksPath(specName))
+	if err != nil {
+		return nil
+	}
+	statuses := make(map[string]string, len(tasks))
+	for _, task := range tasks {
+		statuses[task.ID] = task.Status
+	}
+	return statuses
+}
+
+// emitTaskTransitions compares tasks.yaml against an earlier snapshot and emits
+// task.completed and task.blocked for every task that moved into those states.
+// The agent updates tasks.yaml itself, so diffing is the only reliable signal.
+func (e *Executor) emitTaskTransitions(specName string, before map[string]string) {
+	if before == nil {
+		return
+	}
+	tasks, err := validation.GetAllTasks(e.tasksPath(specName))
+	if err != nil {
+		return
+	}
+	for _, task := range tasks {
+		if task.Status == before[task.ID] {
+			continue
+		}
+		event := notify.Event{Spec: specName, Stage: string(StageImplement), TaskID: task.ID, TaskTitle: task.Title}
+		switch task.Status {
+		case "Completed", "completed":
+			event.Event = notify.EventTaskCompleted
+		case "Blocked", "blocked":
+			event.Event = notify.EventTaskBlocked
+			event.Message = task.BlockedReason
+		default:
+			continue
+		}
+		e.emitEvent(event)
+	}
+}
+
+// tasksPath returns the tasks file path for a spec.
+func (e *Executor) tasksPath(specName string) string {
+	return validation.GetTasksFilePath(filepath.Join(e.SpecsDir, specName))
+}
+
+// emitWorkflowResult sends workflow.completed or workflow.failed for a finished run.
+func (e *Executor) emitWorkflowResult(specName string, err error) {
+	event := notify.Event{Event: notify.EventWorkflowCompleted, Spec: specName}
+	if err != nil {
+		event.Event = notify.EventWorkflowFailed
+		event.Message = err.Error()
+	}
+	e.emitEvent(event)
+}
@@ -0,0 +1,260 @@
+// Package workflow tests webhook events emitted for phases, tasks and retries.
+// Related: internal/workflow/webhook_events.go, internal/notify/webhook.go
+// Tags: workflow, notifications, webhooks, events
+
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ariel-frischer/autospec/internal/cliagent"
+	"github.com/ariel-frischer/autospec/internal/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const webhookTasksTemplate = `phases:
+  - number: 1
+    title: "Core"
+    tasks:
+      - id: "T001"
+        title: "Build core"
+        status: "%s"
+        type: "implementation"
+        parallel: false
+        dependencies: []
+        acceptance_criteria: []
+      - id: "T002"
+        title: "Wire API"
+        status: "%s"
+        %s
+        type: "implementation"
+        parallel: false
+        dependencies: []
+        acceptance_criteria: []
+`
+
+// webhookRecorder collects events POSTed to an httptest server.
+type webhookRecorder struct {
+	mu     sync.Mutex
+	events []notify.Event
+}
+
+func (r *webhookRecorder) snapshot() []notify.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]notify.Event(nil), r.events...)
+}
+
+func (r *webhookRecorder) types() []notify.EventType {
+	var types []notify.EventType
+	for _, e := range r.snapshot() {
+		types = append(types, e.Event)
+	}
+	return types
+}
+
+func newWebhookRecorder(t *testing.T) (*webhookRecorder, *notify.WebhookNotifier) {
+	t.Helper()
+	rec := &webhookRecorder{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var event notify.Event
+		if err := json.NewDecoder(req.Body).Decode(&event); err == nil {
+			rec.mu.Lock()
+			rec.events = append(rec.events, event)
+			rec.mu.Unlock()
+		}
+	}))
+	t.Cleanup(server.Close)
+	return rec, notify.NewWebhookNotifier([]notify.WebhookConfig{{URL: server.URL}})
+}
+
+func writeWebhookTasks(t *testing.T, specDir, first, second, extra string) {
+	t.Helper()
+	content := fmt.Sprintf(webhookTasksTemplate, first, second, extra)
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "tasks.yaml"), []byte(content), 0644))
+}
+
+// shellClaude returns a runner that executes script with sh, standing in for
+// an agent that edits tasks.yaml.
+func shellClaude(t *testing.T, script string) *ClaudeExecutor {
+	t.Helper()
+	agent, err := cliagent.NewCustomAgentFromConfig(cliagent.CustomAgentConfig{
+		Command: "sh",
+		Args:    []string{"-c", script, "{{PROMPT}}"},
+	})
+	require.NoError(t, err)
+	return &ClaudeExecutor{Agent: agent}
+}
+
+func TestExecuteStage_WebhookEvents(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		stage    Stage
+		validate func(t *testing.T, specDir string) error
+		want     []notify.EventType
+	}{
+		"implement emits task transitions": {
+			stage: StageImplement,
+			validate: func(t *testing.T, specDir string) error {
+				writeWebhookTasks(t, specDir, "Completed", "Blocked", `blocked_reason: "Waiting for API key"`)
+				return nil
+			},
+			want: []notify.EventType{notify.EventTaskCompleted, notify.EventTaskBlocked},
+		},
+		"unchanged tasks emit nothing": {
+			stage:    StageImplement,
+			validate: func(t *testing.T, specDir string) error { return nil },
+		},
+		"other stages skip task diffing": {
+			stage: StagePlan,
+			validate: func(t *testing.T, specDir string) error {
+				writeWebhookTasks(t, specDir, "Completed", "Pending", "")
+				return nil
+			},
+		},
+		"exhausted retries": {
+			stage:    StagePlan,
+			validate: func(t *testing.T, specDir string) error { return errors.New("plan.yaml missing") },
+			want:     []notify.EventType{notify.EventRetriesExhausted},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specsDir := t.TempDir()
+			specDir := filepath.Join(specsDir, "001-test")
+			require.NoError(t, os.MkdirAll(specDir, 0755))
+			writeWebhookTasks(t, specDir, "Pending", "Pending", "")
+
+			rec, webhooks := newWebhookRecorder(t)
+			executor := &Executor{
+				Claude:   testClaudeExecutor(t),
+				StateDir: t.TempDir(),
+				SpecsDir: specsDir,
+				Webhooks: webhooks,
+			}
+
+			_, _ = executor.ExecuteStage("001-test", tt.stage, "/test.command", func(string) error {
+				return tt.validate(t, specDir)
+			})
+
+			assert.Equal(t, tt.want, rec.types())
+			for _, event := range rec.snapshot() {
+				assert.Equal(t, "001-test", event.Spec)
+				if event.Event == notify.EventTaskBlocked {
+					assert.Equal(t, "T002", event.TaskID)
+					assert.Equal(t, "Waiting for API key", event.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestExecutePhaseWithValidation_WebhookEvents(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		complete bool
+		want     []notify.EventType
+	}{
+		"completed phase": {
+			complete: true,
+			want:     []notify.EventType{notify.EventPhaseStarted, notify.EventTaskCompleted, notify.EventPhaseCompleted},
+		},
+		"failed phase": {
+			want: []notify.EventType{notify.EventPhaseStarted, notify.EventRetriesExhausted, notify.EventPhaseFailed},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			specsDir := t.TempDir()
+			specDir := filepath.Join(specsDir, "001-test")
+			require.NoError(t, os.MkdirAll(specDir, 0755))
+			writeWebhookTasks(t, specDir, "Completed", "Pending", "")
+
+			claude := testClaudeExecutor(t)
+			if tt.complete {
+				doneDir := t.TempDir()
+				writeWebhookTasks(t, doneDir, "Completed", "Completed", "")
+				claude = shellClaude(t, fmt.Sprintf("cp %q %q", filepath.Join(doneDir, "tasks.yaml"), filepath.Join(specDir, "tasks.yaml")))
+			}
+
+			rec, webhooks := newWebhookRecorder(t)
+			executor := &Executor{
+				Claude:   claude,
+				StateDir: t.TempDir(),
+				SpecsDir: specsDir,
+				Webhooks: webhooks,
+			}
+			phaseExec := NewPhaseExecutor(executor, specsDir, false)
+
+			err := phaseExec.executePhaseWithValidation("001-test", 1, "/autospec.implement --phase 1")
+			assert.Equal(t, !tt.complete, err != nil)
+			assert.Equal(t, tt.want, rec.types())
+			for _, event := range rec.snapshot() {
+				if event.Event != notify.EventRetriesExhausted && event.Event != notify.EventTaskCompleted {
+					assert.Equal(t, 1, event.Phase)
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteImplement_WorkflowWebhookEvents(t *testing.T) {
+	tests := map[string]struct {
+		opts    PhaseExecutionOptions
+		want    []notify.EventType
+		wantMsg string
+	}{
+		"completed run": {
+			opts: PhaseExecutionOptions{RunAllPhases: true},
+			want: []notify.EventType{notify.EventWorkflowCompleted},
+		},
+		"failed run": {
+			opts:    PhaseExecutionOptions{SinglePhase: 9},
+			want:    []notify.EventType{notify.EventWorkflowFailed},
+			wantMsg: "phase 9 is out of range",
+		},
+		"dry run sends nothing": {
+			opts: PhaseExecutionOptions{ParallelMode: true, DryRun: true},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			specName := "001-test-feature"
+			orchestrator := newTestOrchestratorWithSpecName(t, tmpDir, specName)
+			specDir := setupSpecDirectory(t, tmpDir, specName)
+			writeTestSpec(t, specDir)
+			writeTestPlan(t, specDir)
+			writeTestTasksCompleted(t, specDir)
+
+			rec, webhooks := newWebhookRecorder(t)
+			orchestrator.Executor.Webhooks = webhooks
+
+			_ = orchestrator.ExecuteImplement(specName, "", false, tt.opts)
+
+			assert.Equal(t, tt.want, rec.types())
+			for _, event := range rec.snapshot() {
+				assert.Equal(t, specName, event.Spec)
+				assert.Contains(t, event.Message, tt.wantMsg)
+			}
+		})
+	}
+}
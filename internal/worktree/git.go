@@ -121,8 +121,16 @@ func parseWorktreeList(output []byte) ([]GitWorktreeEntry, error) {
 }
 
 // HasUncommittedChanges checks if the worktree has uncommitted changes.
-func HasUncommittedChanges(worktreePath string) (bool, error) {
-	cmd := exec.Command("git", "status", "--porcelain")
+// Changes under the exclude paths (relative to the worktree root) are ignored.
+func HasUncommittedChanges(worktreePath string, exclude ...string) (bool, error) {
+	args := []string{"status", "--porcelain"}
+	if len(exclude) > 0 {
+		args = append(args, "--", ".")
+		for _, path := range exclude {
+			args = append(args, ":(exclude)"+filepath.ToSlash(path))
+		}
+	}
+	cmd := exec.Command("git", args...)
 	cmd.Dir = worktreePath
 
 	output, err := cmd.Output()
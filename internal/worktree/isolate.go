@@ -0,0 +1,96 @@
+package worktree
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// isolatedSuffix distinguishes implement worktrees (and their branches) from
+// the spec branch, which is usually checked out in the main worktree.
+const isolatedSuffix = "-implement"
+
+// IsolatedName returns the worktree name and branch used by
+// `implement --worktree` for a spec.
+func IsolatedName(specName string) string {
+	return specName + isolatedSuffix
+}
+
+// Isolate returns a worktree dedicated to implementing a spec, creating it on
+// first use. A new worktree branches from HEAD and receives a copy of the spec
+// directory so uncommitted spec artifacts are available. An existing one is
+// reused as-is so an interrupted run can resume with its task progress.
+// Returns the worktree and whether it was reused.
+func (m *DefaultManager) Isolate(specName, specDir string) (*Worktree, bool, error) {
+	name := IsolatedName(specName)
+	path := m.resolveWorktreePath(name, "")
+	if _, err := os.Stat(path); err == nil {
+		return &Worktree{Name: name, Path: path, Branch: name, Status: StatusActive}, true, nil
+	}
+
+	wt, err := m.Create(name, name, "")
+	if err != nil {
+		return nil, false, err
+	}
+	rel, err := m.specRelPath(specDir)
+	if err != nil {
+		return wt, false, err
+	}
+	if err := CopyDir(filepath.Join(m.repoRoot, rel), filepath.Join(wt.Path, rel)); err != nil {
+		return wt, false, fmt.Errorf("copying spec directory into worktree: %w", err)
+	}
+	return wt, false, nil
+}
+
+// specRelPath returns specDir relative to the repository root.
+func (m *DefaultManager) specRelPath(specDir string) (string, error) {
+	absSpecDir, err := filepath.Abs(specDir)
+	if err != nil {
+		return "", fmt.Errorf("resolving spec directory: %w", err)
+	}
+	rel, err := filepath.Rel(m.repoRoot, absSpecDir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("spec directory %s is outside the repository", specDir)
+	}
+	return rel, nil
+}
+
+// Release copies the worktree's spec directory back over specDir, so task
+// progress made in the worktree shows up in the main checkout, then removes
+// the worktree if it has no other uncommitted changes. The spec directory is
+// left out of that check because Isolate's copy of it is always untracked or
+// modified; once copied back it is safe to remove with --force.
+// Its branch is kept so the implementation can be reviewed and merged.
+// Returns true if the worktree was removed.
+func (m *DefaultManager) Release(wt *Worktree, specDir string) (bool, error) {
+	rel, err := m.specRelPath(specDir)
+	if err != nil {
+		return false, err
+	}
+	if err := CopyDir(filepath.Join(wt.Path, rel), filepath.Join(m.repoRoot, rel)); err != nil {
+		return false, fmt.Errorf("copying spec directory back from worktree: %w", err)
+	}
+
+	dirty, err := m.gitOps.HasUncommittedChanges(wt.Path, rel)
+	if err != nil {
+		return false, fmt.Errorf("checking uncommitted changes: %w", err)
+	}
+	if dirty {
+		return false, nil
+	}
+	if err := m.gitOps.Remove(m.repoRoot, wt.Path, true); err != nil {
+		return false, fmt.Errorf("removing git worktree: %w", err)
+	}
+
+	state, err := LoadState(m.stateDir)
+	if err != nil {
+		return true, fmt.Errorf("loading state: %w", err)
+	}
+	if state.RemoveWorktree(wt.Name) {
+		if err := SaveState(m.stateDir, state); err != nil {
+			return true, fmt.Errorf("saving state: %w", err)
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,134 @@
+package worktree
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newIsolateManager returns a manager whose mocked git add creates the worktree directory.
+func newIsolateManager(t *testing.T, ops *mockGitOps) (*DefaultManager, string, string) {
+	t.Helper()
+	repoRoot := t.TempDir()
+	baseDir := t.TempDir()
+	cfg := &WorktreeConfig{BaseDir: baseDir, TrackStatus: true}
+	manager := NewManager(cfg, t.TempDir(), repoRoot,
+		WithStdout(&bytes.Buffer{}),
+		WithGitOps(ops),
+		WithCopyFunc(func(string, string, []string) ([]string, error) { return nil, nil }),
+	)
+	return manager, repoRoot, baseDir
+}
+
+func TestIsolatedName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "003-auth-implement", IsolatedName("003-auth"))
+}
+
+func TestManager_Isolate_CreatesWorktreeWithSpec(t *testing.T) {
+	t.Parallel()
+
+	ops := &mockGitOps{}
+	manager, repoRoot, baseDir := newIsolateManager(t, ops)
+	specDir := filepath.Join(repoRoot, "specs", "003-auth")
+	require.NoError(t, os.MkdirAll(specDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "tasks.yaml"), []byte("tasks: {}\n"), 0o644))
+
+	wt, reused, err := manager.Isolate("003-auth", specDir)
+	require.NoError(t, err)
+
+	assert.False(t, reused)
+	assert.True(t, ops.addCalled)
+	assert.Equal(t, "003-auth-implement", wt.Branch)
+	assert.Equal(t, filepath.Join(baseDir, "003-auth-implement"), wt.Path)
+	assert.FileExists(t, filepath.Join(wt.Path, "specs", "003-auth", "tasks.yaml"))
+}
+
+func TestManager_Isolate_ReusesExistingWorktree(t *testing.T) {
+	t.Parallel()
+
+	ops := &mockGitOps{}
+	manager, repoRoot, baseDir := newIsolateManager(t, ops)
+	existing := filepath.Join(baseDir, "003-auth-implement")
+	require.NoError(t, os.MkdirAll(existing, 0o755))
+
+	wt, reused, err := manager.Isolate("003-auth", filepath.Join(repoRoot, "specs", "003-auth"))
+	require.NoError(t, err)
+
+	assert.True(t, reused)
+	assert.False(t, ops.addCalled, "existing worktree must not be recreated")
+	assert.Equal(t, existing, wt.Path)
+	assert.NoDirExists(t, filepath.Join(existing, "specs"), "progress in a reused worktree is not overwritten")
+}
+
+func TestManager_Isolate_SpecOutsideRepo(t *testing.T) {
+	t.Parallel()
+
+	manager, _, _ := newIsolateManager(t, &mockGitOps{})
+
+	_, _, err := manager.Isolate("003-auth", t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "outside the repository")
+}
+
+func TestManager_Release(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		ops         *mockGitOps
+		wantRemoved bool
+		wantErr     bool
+	}{
+		"clean worktree is removed": {
+			ops:         &mockGitOps{},
+			wantRemoved: true,
+		},
+		"uncommitted changes keep the worktree": {
+			ops: &mockGitOps{uncommitted: true},
+		},
+		"status check failure": {
+			ops:     &mockGitOps{uncommittedErr: errors.New("git status failed")},
+			wantErr: true,
+		},
+		"remove failure": {
+			ops:     &mockGitOps{removeErr: errors.New("locked")},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			manager, repoRoot, _ := newIsolateManager(t, tt.ops)
+			wt, err := manager.Create("003-auth-implement", "003-auth-implement", "")
+			require.NoError(t, err)
+			specDir := filepath.Join(repoRoot, "specs", "003-auth")
+			wtSpecDir := filepath.Join(wt.Path, "specs", "003-auth")
+			require.NoError(t, os.MkdirAll(wtSpecDir, 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(wtSpecDir, "tasks.yaml"), []byte("status: Completed\n"), 0o644))
+
+			removed, err := manager.Release(wt, specDir)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemoved, removed)
+			assert.Equal(t, []string{filepath.Join("specs", "003-auth")}, tt.ops.excluded, "copied spec dir is ignored by the dirty check")
+			assert.Equal(t, tt.wantRemoved, tt.ops.removeForce)
+
+			synced, readErr := os.ReadFile(filepath.Join(specDir, "tasks.yaml"))
+			require.NoError(t, readErr)
+			assert.Equal(t, "status: Completed\n", string(synced), "task progress is copied back")
+
+			_, getErr := manager.Get("003-auth-implement")
+			assert.Equal(t, tt.wantRemoved, getErr != nil, "state entry removed only with the worktree")
+		})
+	}
+}
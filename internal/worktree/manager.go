@@ -43,7 +43,7 @@ type GitOperations interface {
 	Add(repoPath, worktreePath, branch string) error
 	Remove(repoPath, worktreePath string, force bool) error
 	List(repoPath string) ([]GitWorktreeEntry, error)
-	HasUncommittedChanges(path string) (bool, error)
+	HasUncommittedChanges(path string, exclude ...string) (bool, error)
 	HasUnpushedCommits(path string) (bool, error)
 }
 
@@ -68,8 +68,8 @@ func (g *defaultGitOps) List(repoPath string) ([]GitWorktreeEntry, error) {
 	return GitWorktreeList(repoPath)
 }
 
-func (g *defaultGitOps) HasUncommittedChanges(path string) (bool, error) {
-	return HasUncommittedChanges(path)
+func (g *defaultGitOps) HasUncommittedChanges(path string, exclude ...string) (bool, error) {
+	return HasUncommittedChanges(path, exclude...)
 }
 
 func (g *defaultGitOps) HasUnpushedCommits(path string) (bool, error) {
@@ -15,11 +15,13 @@ type mockGitOps struct {
 	addCalled      bool
 	addErr         error
 	removeCalled   bool
+	removeForce    bool
 	removeErr      error
 	listResult     []GitWorktreeEntry
 	listErr        error
 	uncommitted    bool
 	uncommittedErr error
+	excluded       []string
 	unpushed       bool
 	unpushedErr    error
 }
@@ -31,6 +33,7 @@ func (m *mockGitOps) Add(repoPath, worktreePath, branch string) error {
 
 func (m *mockGitOps) Remove(repoPath, worktreePath string, force bool) error {
 	m.removeCalled = true
+	m.removeForce = force
 	return m.removeErr
 }
 
@@ -38,7 +41,8 @@ func (m *mockGitOps) List(repoPath string) ([]GitWorktreeEntry, error) {
 	return m.listResult, m.listErr
 }
 
-func (m *mockGitOps) HasUncommittedChanges(path string) (bool, error) {
+func (m *mockGitOps) HasUncommittedChanges(path string, exclude ...string) (bool, error) {
+	m.excluded = exclude
 	return m.uncommitted, m.uncommittedErr
 }
 
@@ -0,0 +1,243 @@
+package yaml
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ChangeKind describes how a field differs between two artifact versions.
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// Change is one semantic difference between two versions of an artifact.
+// Path addresses the field by YAML names, with list items identified by
+// their id (or number, phase or name) where they have one:
+// "user_stories[US-001].priority", "phases[2].tasks[T004].status".
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	Path string     `json:"path"`
+	Old  any        `json:"old,omitempty"`
+	New  any        `json:"new,omitempty"`
+}
+
+// itemKeys are the fields that identify list items, in order of preference.
+var itemKeys = []string{"id", "number", "phase", "name"}
+
+// Diff compares two versions of a YAML artifact field by field. List items
+// with an identifying field are matched by it rather than by position, so
+// reordering or inserting tasks doesn't report every later task as changed.
+// The _meta section is ignored. Empty data is treated as an empty document.
+func Diff(oldData, newData []byte) ([]Change, error) {
+	var oldDoc, newDoc any
+	if err := yaml.Unmarshal(oldData, &oldDoc); err != nil {
+		return nil, fmt.Errorf("parsing old version: %w", err)
+	}
+	if err := yaml.Unmarshal(newData, &newDoc); err != nil {
+		return nil, fmt.Errorf("parsing new version: %w", err)
+	}
+	if m, ok := oldDoc.(map[string]any); ok {
+		delete(m, "_meta")
+	}
+	if m, ok := newDoc.(map[string]any); ok {
+		delete(m, "_meta")
+	}
+
+	var changes []Change
+	diffValue("", oldDoc, newDoc, &changes)
+	return changes, nil
+}
+
+// diffValue appends the changes from before to after at path.
+func diffValue(path string, before, after any, changes *[]Change) {
+	switch {
+	case before == nil && after == nil:
+		return
+	case before == nil:
+		*changes = append(*changes, Change{Kind: ChangeAdded, Path: path, New: after})
+		return
+	case after == nil:
+		*changes = append(*changes, Change{Kind: ChangeRemoved, Path: path, Old: before})
+		return
+	}
+
+	beforeMap, beforeIsMap := before.(map[string]any)
+	afterMap, afterIsMap := after.(map[string]any)
+	if beforeIsMap && afterIsMap {
+		diffMap(path, beforeMap, afterMap, changes)
+		return
+	}
+	beforeList, beforeIsList := before.([]any)
+	afterList, afterIsList := after.([]any)
+	if beforeIsList && afterIsList {
+		diffList(path, beforeList, afterList, changes)
+		return
+	}
+	if !reflect.DeepEqual(before, after) {
+		*changes = append(*changes, Change{Kind: ChangeChanged, Path: path, Old: before, New: after})
+	}
+}
+
+// diffMap compares two mappings key by key, in sorted key order.
+func diffMap(path string, before, after map[string]any, changes *[]Change) {
+	keys := make([]string, 0, len(before)+len(after))
+	for k := range before {
+		keys = append(keys, k)
+	}
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		diffValue(joinPath(path, k), before[k], after[k], changes)
+	}
+}
+
+// diffList compares two lists. Lists of scalars are compared as sets, lists
+// of items sharing an identifying field are matched by it, and anything else
+// is compared by position.
+func diffList(path string, before, after []any, changes *[]Change) {
+	if isScalarList(before) && isScalarList(after) {
+		diffScalarSet(path, before, after, changes)
+		return
+	}
+	key := identityKey(before, after)
+	if key == "" {
+		for i := 0; i < max(len(before), len(after)); i++ {
+			var o, n any
+			if i < len(before) {
+				o = before[i]
+			}
+			if i < len(after) {
+				n = after[i]
+			}
+			diffValue(fmt.Sprintf("%s[%d]", path, i), o, n, changes)
+		}
+		return
+	}
+
+	beforeByID := make(map[string]any, len(before))
+	for _, item := range before {
+		beforeByID[itemID(item, key)] = item
+	}
+	seen := make(map[string]bool, len(after))
+	for _, item := range after {
+		id := itemID(item, key)
+		seen[id] = true
+		diffValue(fmt.Sprintf("%s[%s]", path, id), beforeByID[id], item, changes)
+	}
+	for _, item := range before {
+		if id := itemID(item, key); !seen[id] {
+			*changes = append(*changes, Change{Kind: ChangeRemoved, Path: fmt.Sprintf("%s[%s]", path, id), Old: item})
+		}
+	}
+}
+
+// diffScalarSet reports the values added to and removed from a scalar list.
+func diffScalarSet(path string, before, after []any, changes *[]Change) {
+	inBefore := make(map[string]bool, len(before))
+	for _, v := range before {
+		inBefore[fmt.Sprint(v)] = true
+	}
+	inAfter := make(map[string]bool, len(after))
+	for _, v := range after {
+		inAfter[fmt.Sprint(v)] = true
+		if !inBefore[fmt.Sprint(v)] {
+			*changes = append(*changes, Change{Kind: ChangeAdded, Path: path, New: v})
+		}
+	}
+	for _, v := range before {
+		if !inAfter[fmt.Sprint(v)] {
+			*changes = append(*changes, Change{Kind: ChangeRemoved, Path: path, Old: v})
+		}
+	}
+}
+
+// identityKey returns the first of itemKeys that every item of both lists
+// has, with no duplicate values, or "" if there is none.
+func identityKey(lists ...[]any) string {
+	for _, key := range itemKeys {
+		if hasUniqueKey(key, lists...) {
+			return key
+		}
+	}
+	return ""
+}
+
+func hasUniqueKey(key string, lists ...[]any) bool {
+	for _, list := range lists {
+		seen := make(map[string]bool, len(list))
+		for _, item := range list {
+			m, ok := item.(map[string]any)
+			if !ok || m[key] == nil {
+				return false
+			}
+			id := fmt.Sprint(m[key])
+			if seen[id] {
+				return false
+			}
+			seen[id] = true
+		}
+	}
+	return true
+}
+
+func itemID(item any, key string) string {
+	return fmt.Sprint(item.(map[string]any)[key])
+}
+
+func isScalarList(list []any) bool {
+	for _, item := range list {
+		switch item.(type) {
+		case map[string]any, []any:
+			return false
+		}
+	}
+	return true
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// Summarize returns a one-line description of a changed value: scalars as
+// they are, list items by their title, description or name, and other
+// mappings and lists by size.
+func Summarize(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case map[string]any:
+		for _, key := range []string{"title", "description", "name", "question", "risk", "scenario"} {
+			if s, ok := val[key].(string); ok && s != "" {
+				return truncate(s)
+			}
+		}
+		return fmt.Sprintf("{%d fields}", len(val))
+	case []any:
+		return fmt.Sprintf("[%d items]", len(val))
+	default:
+		return truncate(fmt.Sprint(val))
+	}
+}
+
+func truncate(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > 80 {
+		return string(r[:77]) + "..."
+	}
+	return s
+}
@@ -0,0 +1,114 @@
+// Package yaml_test tests semantic diffs between artifact versions.
+// Related: internal/yaml/diff.go
+// Tags: yaml, diff, artifacts, tasks, spec
+package yaml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		old  string
+		new  string
+		want []Change
+	}{
+		"identical": {
+			old: "feature:\n  status: Draft\n",
+			new: "feature:\n  status: Draft\n",
+		},
+		"meta is ignored": {
+			old: "_meta:\n  created: \"2025-01-01\"\nfeature:\n  status: Draft\n",
+			new: "_meta:\n  created: \"2025-02-01\"\nfeature:\n  status: Draft\n",
+		},
+		"scalar field changed": {
+			old:  "feature:\n  status: Draft\n",
+			new:  "feature:\n  status: Approved\n",
+			want: []Change{{Kind: ChangeChanged, Path: "feature.status", Old: "Draft", New: "Approved"}},
+		},
+		"field added and removed": {
+			old: "summary: old\nnotes: x\n",
+			new: "summary: old\nrisks: []\n",
+			want: []Change{
+				{Kind: ChangeRemoved, Path: "notes", Old: "x"},
+				{Kind: ChangeAdded, Path: "risks", New: []any{}},
+			},
+		},
+		"items matched by id": {
+			old: "user_stories:\n  - id: US-001\n    priority: P1\n  - id: US-002\n    priority: P2\n",
+			new: "user_stories:\n  - id: US-003\n    priority: P3\n  - id: US-001\n    priority: P2\n",
+			want: []Change{
+				{Kind: ChangeAdded, Path: "user_stories[US-003]", New: map[string]any{"id": "US-003", "priority": "P3"}},
+				{Kind: ChangeChanged, Path: "user_stories[US-001].priority", Old: "P1", New: "P2"},
+				{Kind: ChangeRemoved, Path: "user_stories[US-002]", Old: map[string]any{"id": "US-002", "priority": "P2"}},
+			},
+		},
+		"tasks matched within phases": {
+			old: "phases:\n  - number: 1\n    tasks:\n      - id: T001\n        status: Pending\n        dependencies: [T000]\n",
+			new: "phases:\n  - number: 1\n    tasks:\n      - id: T001\n        status: Completed\n        dependencies: [T002]\n",
+			want: []Change{
+				{Kind: ChangeAdded, Path: "phases[1].tasks[T001].dependencies", New: "T002"},
+				{Kind: ChangeRemoved, Path: "phases[1].tasks[T001].dependencies", Old: "T000"},
+				{Kind: ChangeChanged, Path: "phases[1].tasks[T001].status", Old: "Pending", New: "Completed"},
+			},
+		},
+		"items without identity compared by position": {
+			old: "edge_cases:\n  - scenario: a\n",
+			new: "edge_cases:\n  - scenario: b\n  - scenario: c\n",
+			want: []Change{
+				{Kind: ChangeChanged, Path: "edge_cases[0].scenario", Old: "a", New: "b"},
+				{Kind: ChangeAdded, Path: "edge_cases[1]", New: map[string]any{"scenario": "c"}},
+			},
+		},
+		"artifact created": {
+			old:  "",
+			new:  "summary: new\n",
+			want: []Change{{Kind: ChangeAdded, Path: "", New: map[string]any{"summary": "new"}}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			changes, err := Diff([]byte(tt.old), []byte(tt.new))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, changes)
+		})
+	}
+}
+
+func TestDiff_InvalidYAML(t *testing.T) {
+	t.Parallel()
+	_, err := Diff([]byte("a: ["), []byte("a: 1"))
+	assert.ErrorContains(t, err, "parsing old version")
+}
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value any
+		want  string
+	}{
+		"nil":               {value: nil, want: ""},
+		"scalar":            {value: 3, want: "3"},
+		"multi-line text":   {value: "line one\n  line two", want: "line one line two"},
+		"item with title":   {value: map[string]any{"id": "T001", "title": "Add parser"}, want: "Add parser"},
+		"item without text": {value: map[string]any{"id": "T001"}, want: "{1 fields}"},
+		"list":              {value: []any{1, 2}, want: "[2 items]"},
+		"long text": {
+			value: "0123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890",
+			want:  "01234567890123456789012345678901234567890123456789012345678901234567890123456...",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, Summarize(tt.value))
+		})
+	}
+}